@@ -4,7 +4,10 @@
 package countconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector"
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
@@ -30,6 +33,22 @@ const (
 	defaultMetricDescLogs = "The number of log records observed."
 )
 
+const (
+	// TemporalityDelta emits the counts observed since the previous emission.
+	TemporalityDelta = "delta"
+	// TemporalityCumulative emits the counts observed since the first observation.
+	TemporalityCumulative = "cumulative"
+
+	// ConditionsMatchAny counts data that matches any of the conditions.
+	ConditionsMatchAny = "any"
+	// ConditionsMatchAll counts data that matches all of the conditions.
+	ConditionsMatchAll = "all"
+
+	defaultOverflowValue = "overflow"
+
+	defaultExpiration = 5 * time.Minute
+)
+
 // Config for the connector
 type Config struct {
 	Spans      map[string]MetricInfo `mapstructure:"spans"`
@@ -37,26 +56,57 @@ type Config struct {
 	Metrics    map[string]MetricInfo `mapstructure:"metrics"`
 	DataPoints map[string]MetricInfo `mapstructure:"datapoints"`
 	Logs       map[string]MetricInfo `mapstructure:"logs"`
+
+	// Interval at which the accumulated counts are emitted.
+	// When zero, the counts are emitted for each consumed batch of data.
+	Interval time.Duration `mapstructure:"interval"`
+	// Temporality of the emitted counts, either "delta" (default) or "cumulative".
+	Temporality string `mapstructure:"temporality"`
+	// Expiration is the time after which the cumulative counts of a resource whose data was not counted
+	// are forgotten, so that they restart from zero if its data is counted again. Zero never forgets them.
+	Expiration time.Duration `mapstructure:"expiration"`
 }
 
 // MetricInfo for a data type
 type MetricInfo struct {
-	Description string            `mapstructure:"description"`
-	Conditions  []string          `mapstructure:"conditions"`
-	Attributes  []AttributeConfig `mapstructure:"attributes"`
+	Description string   `mapstructure:"description"`
+	Conditions  []string `mapstructure:"conditions"`
+	// ConditionsMatch is either "any" (default) to count data matching any of the conditions,
+	// or "all" to count data matching all of them.
+	ConditionsMatch string            `mapstructure:"conditions_match"`
+	Attributes      []AttributeConfig `mapstructure:"attributes"`
 }
 
 type AttributeConfig struct {
 	Key          string `mapstructure:"key"`
 	DefaultValue string `mapstructure:"default_value"`
+	// MaxValues limits the number of distinct values counted for the attribute.
+	// Further values are counted as OverflowValue. Zero means no limit.
+	MaxValues int `mapstructure:"max_values"`
+	// OverflowValue replaces the values over the limit, "overflow" by default.
+	OverflowValue string `mapstructure:"overflow_value"`
 }
 
 func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	switch c.Temporality {
+	case "", TemporalityDelta, TemporalityCumulative:
+	default:
+		return fmt.Errorf("temporality must be %q or %q: %q", TemporalityDelta, TemporalityCumulative, c.Temporality)
+	}
+	if c.Expiration < 0 {
+		return errors.New("expiration must not be negative")
+	}
 	for name, info := range c.Spans {
 		if name == "" {
 			return fmt.Errorf("spans: metric name missing")
 		}
-		if _, err := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		if err := info.validateConditionsMatch(); err != nil {
+			return fmt.Errorf("spans condition: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForSpan(info.conditions(), filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("spans condition: metric %q: %w", name, err)
 		}
 		if err := info.validateAttributes(); err != nil {
@@ -67,7 +117,10 @@ func (c *Config) Validate() error {
 		if name == "" {
 			return fmt.Errorf("spanevents: metric name missing")
 		}
-		if _, err := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		if err := info.validateConditionsMatch(); err != nil {
+			return fmt.Errorf("spanevents condition: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForSpanEvent(info.conditions(), filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("spanevents condition: metric %q: %w", name, err)
 		}
 		if err := info.validateAttributes(); err != nil {
@@ -78,7 +131,10 @@ func (c *Config) Validate() error {
 		if name == "" {
 			return fmt.Errorf("metrics: metric name missing")
 		}
-		if _, err := filterottl.NewBoolExprForMetric(info.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		if err := info.validateConditionsMatch(); err != nil {
+			return fmt.Errorf("metrics condition: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForMetric(info.conditions(), filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("metrics condition: metric %q: %w", name, err)
 		}
 		if len(info.Attributes) > 0 {
@@ -90,7 +146,10 @@ func (c *Config) Validate() error {
 		if name == "" {
 			return fmt.Errorf("datapoints: metric name missing")
 		}
-		if _, err := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		if err := info.validateConditionsMatch(); err != nil {
+			return fmt.Errorf("datapoints condition: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForDataPoint(info.conditions(), filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("datapoints condition: metric %q: %w", name, err)
 		}
 		if err := info.validateAttributes(); err != nil {
@@ -101,7 +160,10 @@ func (c *Config) Validate() error {
 		if name == "" {
 			return fmt.Errorf("logs: metric name missing")
 		}
-		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		if err := info.validateConditionsMatch(); err != nil {
+			return fmt.Errorf("logs condition: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForLog(info.conditions(), filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("logs condition: metric %q: %w", name, err)
 		}
 		if err := info.validateAttributes(); err != nil {
@@ -116,10 +178,31 @@ func (i *MetricInfo) validateAttributes() error {
 		if attr.Key == "" {
 			return fmt.Errorf("attribute key missing")
 		}
+		if attr.MaxValues < 0 {
+			return fmt.Errorf("attribute %q: max_values must not be negative", attr.Key)
+		}
 	}
 	return nil
 }
 
+func (i *MetricInfo) validateConditionsMatch() error {
+	switch i.ConditionsMatch {
+	case "", ConditionsMatchAny, ConditionsMatchAll:
+		return nil
+	default:
+		return fmt.Errorf("conditions_match must be %q or %q: %q", ConditionsMatchAny, ConditionsMatchAll, i.ConditionsMatch)
+	}
+}
+
+// conditions returns the conditions to evaluate, which are ORed together.
+// Conditions that must all match are combined into a single condition.
+func (i *MetricInfo) conditions() []string {
+	if i.ConditionsMatch != ConditionsMatchAll || len(i.Conditions) < 2 {
+		return i.Conditions
+	}
+	return []string{"(" + strings.Join(i.Conditions, ") and (") + ")"}
+}
+
 var _ confmap.Unmarshaler = (*Config)(nil)
 
 // Unmarshal with custom logic to set default values.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,7 @@ func TestLoadConfig(t *testing.T) {
 						Description: defaultMetricDescLogs,
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -79,6 +81,7 @@ func TestLoadConfig(t *testing.T) {
 						Description: "My description for default log count metric.",
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -109,6 +112,7 @@ func TestLoadConfig(t *testing.T) {
 						Description: "My log record count.",
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -144,6 +148,7 @@ func TestLoadConfig(t *testing.T) {
 						Conditions:  []string{`IsMatch(resource.attributes["host.name"], "pod-l")`},
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -194,6 +199,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -236,6 +242,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				Expiration: defaultExpiration,
 			},
 		},
 		{
@@ -321,6 +328,36 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				Expiration: defaultExpiration,
+			},
+		},
+		{
+			name: "interval",
+			expect: &Config{
+				Spans:      defaultSpansConfig(),
+				SpanEvents: defaultSpanEventsConfig(),
+				Metrics:    defaultMetricsConfig(),
+				DataPoints: defaultDataPointsConfig(),
+				Logs: map[string]MetricInfo{
+					"error.logrecord.count": {
+						Description:     "Error log record count.",
+						ConditionsMatch: ConditionsMatchAll,
+						Conditions: []string{
+							"severity_number >= SEVERITY_NUMBER_ERROR",
+							`IsMatch(resource.attributes["host.name"], "pod-l")`,
+						},
+						Attributes: []AttributeConfig{
+							{
+								Key:           "env",
+								MaxValues:     10,
+								OverflowValue: "other",
+							},
+						},
+					},
+				},
+				Interval:    30 * time.Second,
+				Temporality: TemporalityCumulative,
+				Expiration:  10 * time.Minute,
 			},
 		},
 	}
@@ -463,6 +500,50 @@ func TestConfigErrors(t *testing.T) {
 			},
 			expect: fmt.Sprintf("logs condition: metric %q: unable to parse OTTL statement", defaultMetricNameLogs),
 		},
+		{
+			name: "negative_interval",
+			input: &Config{
+				Interval: -time.Second,
+			},
+			expect: "interval must not be negative",
+		},
+		{
+			name: "negative_expiration",
+			input: &Config{
+				Expiration: -time.Second,
+			},
+			expect: "expiration must not be negative",
+		},
+		{
+			name: "invalid_temporality",
+			input: &Config{
+				Temporality: "gauge",
+			},
+			expect: `temporality must be "delta" or "cumulative": "gauge"`,
+		},
+		{
+			name: "invalid_conditions_match",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						ConditionsMatch: "none",
+						Conditions:      []string{`attributes["env"] == "prod"`},
+					},
+				},
+			},
+			expect: fmt.Sprintf(`logs condition: metric %q: conditions_match must be "any" or "all": "none"`, defaultMetricNameLogs),
+		},
+		{
+			name: "negative_attribute_max_values",
+			input: &Config{
+				Spans: map[string]MetricInfo{
+					defaultMetricNameSpans: {
+						Attributes: []AttributeConfig{{Key: "env", MaxValues: -1}},
+					},
+				},
+			},
+			expect: fmt.Sprintf(`spans attributes: metric %q: attribute "env": max_values must not be negative`, defaultMetricNameSpans),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -14,12 +16,14 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
 const scopeName = "otelcol/countconnector"
@@ -28,28 +32,208 @@ const scopeName = "otelcol/countconnector"
 // and emit the counts onto a metrics pipeline.
 type count struct {
 	metricsConsumer consumer.Metrics
-	component.StartFunc
-	component.ShutdownFunc
+	logger          *zap.Logger
+
+	// interval at which the counts are emitted, zero to emit them for each batch
+	interval    time.Duration
+	temporality pmetric.AggregationTemporality
+	// expiration after which the cumulative counts of a resource which is not updated are forgotten
+	expiration time.Duration
 
 	spansMetricDefs      map[string]metricDef[ottlspan.TransformContext]
 	spanEventsMetricDefs map[string]metricDef[ottlspanevent.TransformContext]
 	metricsMetricDefs    map[string]metricDef[ottlmetric.TransformContext]
 	dataPointsMetricDefs map[string]metricDef[ottldatapoint.TransformContext]
 	logsMetricDefs       map[string]metricDef[ottllog.TransformContext]
+
+	// mu guards the accumulated counts and the attribute values tracked by the metric definitions
+	mu sync.Mutex
+	// resources holds the counts by resource when they are accumulated across batches
+	resources map[[16]byte]*resourceCounts
+	lastFlush time.Time
+	// lastExpiry is when the expired resources were last forgotten, when the counts are emitted for each batch
+	lastExpiry time.Time
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newCount(cfg *Config, logger *zap.Logger, nextConsumer consumer.Metrics) *count {
+	temporality := pmetric.AggregationTemporalityDelta
+	if cfg.Temporality == TemporalityCumulative {
+		temporality = pmetric.AggregationTemporalityCumulative
+	}
+	return &count{
+		metricsConsumer: nextConsumer,
+		logger:          logger,
+		interval:        cfg.Interval,
+		temporality:     temporality,
+		expiration:      cfg.Expiration,
+		resources:       make(map[[16]byte]*resourceCounts),
+		lastFlush:       time.Now(),
+		lastExpiry:      time.Now(),
+	}
 }
 
 func (c *count) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+func (c *count) Start(context.Context, component.Host) error {
+	if c.interval > 0 {
+		c.shutdownCh = make(chan struct{})
+		c.wg.Add(1)
+		go c.flushLoop()
+	}
+	return nil
+}
+
+// Shutdown stops emitting counts on an interval, after emitting the remaining counts.
+func (c *count) Shutdown(ctx context.Context) error {
+	if c.shutdownCh == nil {
+		return nil
+	}
+	close(c.shutdownCh)
+	c.wg.Wait()
+	return c.flush(ctx)
+}
+
+func (c *count) flushLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.flush(context.Background()); err != nil {
+				c.logger.Error("failed to emit counts", zap.Error(err))
+			}
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// flush emits the counts accumulated for all resources. Delta counts restart afterwards,
+// and cumulative counts are forgotten once they expired.
+func (c *count) flush(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	countMetrics := pmetric.NewMetrics()
+	for key, rc := range c.resources {
+		rc.appendTo(countMetrics, c.temporality, now)
+		if c.temporality == pmetric.AggregationTemporalityDelta || c.expired(rc, now) {
+			delete(c.resources, key)
+		}
+	}
+	if c.temporality == pmetric.AggregationTemporalityDelta {
+		c.resetAttributeValues()
+	}
+	c.lastFlush = now
+	c.mu.Unlock()
+
+	if countMetrics.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return c.metricsConsumer.ConsumeMetrics(ctx, countMetrics)
+}
+
+// accumulates reports whether counts are kept across batches, which is the case
+// when they are emitted on an interval or as cumulative values.
+func (c *count) accumulates() bool {
+	return c.interval > 0 || c.temporality == pmetric.AggregationTemporalityCumulative
+}
+
+// expired reports whether the cumulative counts of a resource were not updated for the expiration.
+func (c *count) expired(rc *resourceCounts, now time.Time) bool {
+	return c.expiration > 0 && now.Sub(rc.lastUpdated) >= c.expiration
+}
+
+// removeExpired forgets the expired cumulative counts, at most once per expiration.
+func (c *count) removeExpired(now time.Time) {
+	if c.expiration <= 0 || now.Sub(c.lastExpiry) < c.expiration {
+		return
+	}
+	for key, rc := range c.resources {
+		if c.expired(rc, now) {
+			delete(c.resources, key)
+		}
+	}
+	c.lastExpiry = now
+}
+
+// resetAttributeValues forgets the attribute values counted once the delta counts were emitted, so that the
+// limit of distinct values applies to each interval, or batch, rather than to the lifetime of the connector.
+func (c *count) resetAttributeValues() {
+	resetAttributeValues(c.spansMetricDefs)
+	resetAttributeValues(c.spanEventsMetricDefs)
+	resetAttributeValues(c.metricsMetricDefs)
+	resetAttributeValues(c.dataPointsMetricDefs)
+	resetAttributeValues(c.logsMetricDefs)
+}
+
+// resourceCounts returns the counters to update for a resource of a batch.
+func (c *count) resourceCounts(resource pcommon.Resource) *resourceCounts {
+	if !c.accumulates() {
+		return c.newResourceCounts(resource, time.Time{})
+	}
+	now := time.Now()
+	key := pdatautil.MapHash(resource.Attributes())
+	rc, ok := c.resources[key]
+	if !ok {
+		startTime := now
+		if c.temporality == pmetric.AggregationTemporalityDelta {
+			startTime = c.lastFlush
+		}
+		rc = c.newResourceCounts(resource, startTime)
+		c.resources[key] = rc
+	}
+	rc.lastUpdated = now
+	return rc
+}
+
+// batchMetrics returns the counts of the resources updated by a batch,
+// or no counts when they are emitted on an interval.
+func (c *count) batchMetrics(updated []*resourceCounts) pmetric.Metrics {
+	countMetrics := pmetric.NewMetrics()
+	if c.interval > 0 {
+		return countMetrics
+	}
+	now := time.Now()
+	countMetrics.ResourceMetrics().EnsureCapacity(len(updated))
+	appended := make(map[*resourceCounts]bool, len(updated))
+	for _, rc := range updated {
+		// Accumulated counts of a resource seen multiple times in the batch are emitted once
+		if !appended[rc] {
+			appended[rc] = true
+			rc.appendTo(countMetrics, c.temporality, now)
+		}
+	}
+	if !c.accumulates() {
+		c.resetAttributeValues()
+	}
+	// The counts emitted for each batch are not flushed, expired counts are forgotten here instead
+	c.removeExpired(now)
+	return countMetrics
+}
+
+// consumeCounts emits the counts of a batch, unless they are emitted on an interval.
+func (c *count) consumeCounts(ctx context.Context, countMetrics pmetric.Metrics) error {
+	if c.interval > 0 {
+		return nil
+	}
+	return c.metricsConsumer.ConsumeMetrics(ctx, countMetrics)
+}
+
 func (c *count) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var multiError error
-	countMetrics := pmetric.NewMetrics()
-	countMetrics.ResourceMetrics().EnsureCapacity(td.ResourceSpans().Len())
+	c.mu.Lock()
+	updated := make([]*resourceCounts, 0, td.ResourceSpans().Len())
 	for i := 0; i < td.ResourceSpans().Len(); i++ {
 		resourceSpan := td.ResourceSpans().At(i)
-		spansCounter := newCounter[ottlspan.TransformContext](c.spansMetricDefs)
-		spanEventsCounter := newCounter[ottlspanevent.TransformContext](c.spanEventsMetricDefs)
+		counts := c.resourceCounts(resourceSpan.Resource())
+		spansCounter := counts.spans
+		spanEventsCounter := counts.spanEvents
 
 		for j := 0; j < resourceSpan.ScopeSpans().Len(); j++ {
 			scopeSpan := resourceSpan.ScopeSpans().At(j)
@@ -67,34 +251,25 @@ func (c *count) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 			}
 		}
 
-		if len(spansCounter.counts)+len(spanEventsCounter.counts) == 0 {
-			continue // don't add an empty resource
-		}
-
-		countResource := countMetrics.ResourceMetrics().AppendEmpty()
-		resourceSpan.Resource().Attributes().CopyTo(countResource.Resource().Attributes())
-
-		countResource.ScopeMetrics().EnsureCapacity(resourceSpan.ScopeSpans().Len())
-		countScope := countResource.ScopeMetrics().AppendEmpty()
-		countScope.Scope().SetName(scopeName)
-
-		spansCounter.appendMetricsTo(countScope.Metrics())
-		spanEventsCounter.appendMetricsTo(countScope.Metrics())
+		updated = append(updated, counts)
 	}
+	countMetrics := c.batchMetrics(updated)
+	c.mu.Unlock()
 	if multiError != nil {
 		return multiError
 	}
-	return c.metricsConsumer.ConsumeMetrics(ctx, countMetrics)
+	return c.consumeCounts(ctx, countMetrics)
 }
 
 func (c *count) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var multiError error
-	countMetrics := pmetric.NewMetrics()
-	countMetrics.ResourceMetrics().EnsureCapacity(md.ResourceMetrics().Len())
+	c.mu.Lock()
+	updated := make([]*resourceCounts, 0, md.ResourceMetrics().Len())
 	for i := 0; i < md.ResourceMetrics().Len(); i++ {
 		resourceMetric := md.ResourceMetrics().At(i)
-		metricsCounter := newCounter[ottlmetric.TransformContext](c.metricsMetricDefs)
-		dataPointsCounter := newCounter[ottldatapoint.TransformContext](c.dataPointsMetricDefs)
+		counts := c.resourceCounts(resourceMetric.Resource())
+		metricsCounter := counts.metrics
+		dataPointsCounter := counts.dataPoints
 
 		for j := 0; j < resourceMetric.ScopeMetrics().Len(); j++ {
 			scopeMetrics := resourceMetric.ScopeMetrics().At(j)
@@ -142,33 +317,24 @@ func (c *count) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 			}
 		}
 
-		if len(metricsCounter.counts)+len(dataPointsCounter.counts) == 0 {
-			continue // don't add an empty resource
-		}
-
-		countResource := countMetrics.ResourceMetrics().AppendEmpty()
-		resourceMetric.Resource().Attributes().CopyTo(countResource.Resource().Attributes())
-
-		countResource.ScopeMetrics().EnsureCapacity(resourceMetric.ScopeMetrics().Len())
-		countScope := countResource.ScopeMetrics().AppendEmpty()
-		countScope.Scope().SetName(scopeName)
-
-		metricsCounter.appendMetricsTo(countScope.Metrics())
-		dataPointsCounter.appendMetricsTo(countScope.Metrics())
+		updated = append(updated, counts)
 	}
+	countMetrics := c.batchMetrics(updated)
+	c.mu.Unlock()
 	if multiError != nil {
 		return multiError
 	}
-	return c.metricsConsumer.ConsumeMetrics(ctx, countMetrics)
+	return c.consumeCounts(ctx, countMetrics)
 }
 
 func (c *count) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var multiError error
-	countMetrics := pmetric.NewMetrics()
-	countMetrics.ResourceMetrics().EnsureCapacity(ld.ResourceLogs().Len())
+	c.mu.Lock()
+	updated := make([]*resourceCounts, 0, ld.ResourceLogs().Len())
 	for i := 0; i < ld.ResourceLogs().Len(); i++ {
 		resourceLog := ld.ResourceLogs().At(i)
-		counter := newCounter[ottllog.TransformContext](c.logsMetricDefs)
+		counts := c.resourceCounts(resourceLog.Resource())
+		counter := counts.logs
 
 		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
 			scopeLogs := resourceLog.ScopeLogs().At(j)
@@ -181,21 +347,12 @@ func (c *count) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 			}
 		}
 
-		if len(counter.counts) == 0 {
-			continue // don't add an empty resource
-		}
-
-		countResource := countMetrics.ResourceMetrics().AppendEmpty()
-		resourceLog.Resource().Attributes().CopyTo(countResource.Resource().Attributes())
-
-		countResource.ScopeMetrics().EnsureCapacity(resourceLog.ScopeLogs().Len())
-		countScope := countResource.ScopeMetrics().AppendEmpty()
-		countScope.Scope().SetName(scopeName)
-
-		counter.appendMetricsTo(countScope.Metrics())
+		updated = append(updated, counts)
 	}
+	countMetrics := c.batchMetrics(updated)
+	c.mu.Unlock()
 	if multiError != nil {
 		return multiError
 	}
-	return c.metricsConsumer.ConsumeMetrics(ctx, countMetrics)
+	return c.consumeCounts(ctx, countMetrics)
 }
@@ -7,12 +7,15 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
@@ -604,6 +607,43 @@ func TestLogsToMetrics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "all_conditions",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"count.if": {
+						Description:     "Count if all ...",
+						ConditionsMatch: ConditionsMatchAll,
+						Conditions: []string{
+							`resource.attributes["resource.optional"] != nil`,
+							`attributes["log.optional"] != nil`,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "attribute_value_limit",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"log.count.by_attr": {
+						Description: "Log count by attributes with limited values",
+						Attributes: []AttributeConfig{
+							{
+								Key:       "log.required",
+								MaxValues: 1,
+							},
+							{
+								Key:           "log.optional",
+								DefaultValue:  "other",
+								MaxValues:     1,
+								OverflowValue: "more",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "condition_and_attribute",
 			cfg: &Config{
@@ -658,3 +698,168 @@ func TestLogsToMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsToMetricsCumulative(t *testing.T) {
+	cfg := &Config{
+		Logs:        defaultLogsConfig(),
+		Temporality: TemporalityCumulative,
+	}
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	testLogs, err := golden.ReadLogs(filepath.Join("testdata", "logs", "input.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	allMetrics := sink.AllMetrics()
+	require.Len(t, allMetrics, 2)
+	for i, md := range allMetrics {
+		require.Equal(t, 4, md.ResourceMetrics().Len())
+		for j := 0; j < md.ResourceMetrics().Len(); j++ {
+			sum := md.ResourceMetrics().At(j).ScopeMetrics().At(0).Metrics().At(0).Sum()
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+			dp := sum.DataPoints().At(0)
+			assert.Equal(t, int64(4*(i+1)), dp.IntValue())
+			assert.NotZero(t, dp.StartTimestamp())
+			assert.GreaterOrEqual(t, dp.Timestamp(), dp.StartTimestamp())
+		}
+	}
+	// The start of cumulative counts doesn't change
+	assert.Equal(t,
+		allMetrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp(),
+		allMetrics[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp())
+}
+
+func TestLogsToMetricsCumulativeExpiration(t *testing.T) {
+	cfg := &Config{
+		Logs:        defaultLogsConfig(),
+		Temporality: TemporalityCumulative,
+		Expiration:  time.Minute,
+	}
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	testLogs, err := golden.ReadLogs(filepath.Join("testdata", "logs", "input.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	c := conn.(*count)
+	require.Len(t, c.resources, 4)
+
+	// The counts of the resources which were not updated for the expiration are forgotten.
+	for _, rc := range c.resources {
+		rc.lastUpdated = rc.lastUpdated.Add(-time.Hour)
+	}
+	c.lastExpiry = c.lastExpiry.Add(-time.Hour)
+	require.NoError(t, conn.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Empty(t, c.resources)
+
+	// The counts restart from zero.
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	allMetrics := sink.AllMetrics()
+	require.Len(t, allMetrics, 3)
+	for _, md := range []pmetric.Metrics{allMetrics[0], allMetrics[2]} {
+		require.Equal(t, 4, md.ResourceMetrics().Len())
+		for j := 0; j < md.ResourceMetrics().Len(); j++ {
+			assert.Equal(t, int64(4), md.ResourceMetrics().At(j).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+		}
+	}
+}
+
+func TestLogsToMetricsInterval(t *testing.T) {
+	cfg := &Config{
+		Logs:     defaultLogsConfig(),
+		Interval: time.Hour,
+	}
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	testLogs, err := golden.ReadLogs(filepath.Join("testdata", "logs", "input.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	assert.Empty(t, sink.AllMetrics())
+
+	// Delta counts restart after being emitted
+	require.NoError(t, conn.(*count).flush(context.Background()))
+	require.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+	// Remaining counts are emitted on shutdown
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	allMetrics := sink.AllMetrics()
+	require.Len(t, allMetrics, 2)
+	for i, want := range []int64{8, 4} {
+		md := allMetrics[i]
+		require.Equal(t, 4, md.ResourceMetrics().Len())
+		for j := 0; j < md.ResourceMetrics().Len(); j++ {
+			sum := md.ResourceMetrics().At(j).ScopeMetrics().At(0).Metrics().At(0).Sum()
+			assert.Equal(t, pmetric.AggregationTemporalityDelta, sum.AggregationTemporality())
+			assert.Equal(t, want, sum.DataPoints().At(0).IntValue())
+		}
+	}
+	// The second interval starts when the first one ends
+	assert.Equal(t,
+		allMetrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Timestamp(),
+		allMetrics[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp())
+}
+
+func TestLogsToMetricsAttributeValueLimitReset(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"log.count.by_attr": {
+				Description: "Log count by attribute with limited values",
+				Attributes:  []AttributeConfig{{Key: "user", MaxValues: 1}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(context.Background()))
+	}()
+
+	newLogs := func(users ...string) plog.Logs {
+		ld := plog.NewLogs()
+		records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for _, user := range users {
+			records.AppendEmpty().Attributes().PutStr("user", user)
+		}
+		return ld
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), newLogs("alice", "bob")))
+	// The values counted by a batch don't count towards the limit of the next one.
+	require.NoError(t, conn.ConsumeLogs(context.Background(), newLogs("carol")))
+
+	allMetrics := sink.AllMetrics()
+	require.Len(t, allMetrics, 2)
+	users := func(md pmetric.Metrics) []string {
+		var values []string
+		dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			value, _ := dps.At(i).Attributes().Get("user")
+			values = append(values, value.Str())
+		}
+		return values
+	}
+	assert.ElementsMatch(t, []string{"alice", defaultOverflowValue}, users(allMetrics[0]))
+	assert.Equal(t, []string{"carol"}, users(allMetrics[1]))
+}
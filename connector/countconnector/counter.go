@@ -11,23 +11,29 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
 var noAttributes = [16]byte{}
 
-func newCounter[K any](metricDefs map[string]metricDef[K]) *counter[K] {
+func newCounter[K any](metricDefs map[string]metricDef[K], startTime time.Time) *counter[K] {
 	return &counter[K]{
 		metricDefs: metricDefs,
 		counts:     make(map[string]map[[16]byte]*attrCounter, len(metricDefs)),
-		timestamp:  time.Now(),
+		startTime:  startTime,
 	}
 }
 
 type counter[K any] struct {
 	metricDefs map[string]metricDef[K]
 	counts     map[string]map[[16]byte]*attrCounter
-	timestamp  time.Time
+	// startTime of the counts, unset when the counts are emitted for each batch
+	startTime time.Time
 }
 
 type attrCounter struct {
@@ -38,10 +44,23 @@ type attrCounter struct {
 func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) error {
 	var multiError error
 	for name, md := range c.metricDefs {
+		// No conditions, so match all.
+		// Otherwise conditions are evaluated first, so that only matching data counts towards attribute value limits.
+		if md.condition != nil {
+			match, err := md.condition.Eval(ctx, tCtx)
+			if err != nil {
+				multiError = errors.Join(multiError, err)
+				continue
+			}
+			if !match {
+				continue
+			}
+		}
+
 		countAttrs := pcommon.NewMap()
-		for _, attr := range md.attrs {
+		for i, attr := range md.attrs {
 			if attrVal, ok := attrs.Get(attr.Key); ok {
-				countAttrs.PutStr(attr.Key, attrVal.Str())
+				countAttrs.PutStr(attr.Key, md.attributeValue(i, attrVal.Str()))
 			} else if attr.DefaultValue != "" {
 				countAttrs.PutStr(attr.Key, attr.DefaultValue)
 			}
@@ -52,17 +71,7 @@ func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) erro
 			continue
 		}
 
-		// No conditions, so match all.
-		if md.condition == nil {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
-			continue
-		}
-
-		if match, err := md.condition.Eval(ctx, tCtx); err != nil {
-			multiError = errors.Join(multiError, err)
-		} else if match {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
-		}
+		multiError = errors.Join(multiError, c.increment(name, countAttrs))
 	}
 	return multiError
 }
@@ -85,7 +94,7 @@ func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
 	return nil
 }
 
-func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
+func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice, temporality pmetric.AggregationTemporality, timestamp time.Time) {
 	for name, md := range c.metricDefs {
 		if len(c.counts[name]) == 0 {
 			continue
@@ -96,13 +105,59 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		sum := countMetric.SetEmptySum()
 		// The delta value is always positive, so a value accumulated downstream is monotonic
 		sum.SetIsMonotonic(true)
-		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		sum.SetAggregationTemporality(temporality)
 		for _, dpCount := range c.counts[name] {
 			dp := sum.DataPoints().AppendEmpty()
 			dpCount.attrs.CopyTo(dp.Attributes())
 			dp.SetIntValue(int64(dpCount.count))
-			// TODO determine appropriate start time
-			dp.SetTimestamp(pcommon.NewTimestampFromTime(c.timestamp))
+			if !c.startTime.IsZero() {
+				dp.SetStartTimestamp(pcommon.NewTimestampFromTime(c.startTime))
+			}
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 		}
 	}
 }
+
+// resourceCounts holds the counters of a resource.
+type resourceCounts struct {
+	attrs      pcommon.Map
+	spans      *counter[ottlspan.TransformContext]
+	spanEvents *counter[ottlspanevent.TransformContext]
+	metrics    *counter[ottlmetric.TransformContext]
+	dataPoints *counter[ottldatapoint.TransformContext]
+	logs       *counter[ottllog.TransformContext]
+
+	// lastUpdated is when the data of the resource was last counted, to expire cumulative counts.
+	lastUpdated time.Time
+}
+
+func (c *count) newResourceCounts(resource pcommon.Resource, startTime time.Time) *resourceCounts {
+	rc := &resourceCounts{
+		attrs:      pcommon.NewMap(),
+		spans:      newCounter[ottlspan.TransformContext](c.spansMetricDefs, startTime),
+		spanEvents: newCounter[ottlspanevent.TransformContext](c.spanEventsMetricDefs, startTime),
+		metrics:    newCounter[ottlmetric.TransformContext](c.metricsMetricDefs, startTime),
+		dataPoints: newCounter[ottldatapoint.TransformContext](c.dataPointsMetricDefs, startTime),
+		logs:       newCounter[ottllog.TransformContext](c.logsMetricDefs, startTime),
+	}
+	resource.Attributes().CopyTo(rc.attrs)
+	return rc
+}
+
+func (rc *resourceCounts) appendTo(md pmetric.Metrics, temporality pmetric.AggregationTemporality, timestamp time.Time) {
+	if len(rc.spans.counts)+len(rc.spanEvents.counts)+len(rc.metrics.counts)+len(rc.dataPoints.counts)+len(rc.logs.counts) == 0 {
+		return // don't add an empty resource
+	}
+
+	countResource := md.ResourceMetrics().AppendEmpty()
+	rc.attrs.CopyTo(countResource.Resource().Attributes())
+
+	countScope := countResource.ScopeMetrics().AppendEmpty()
+	countScope.Scope().SetName(scopeName)
+
+	rc.spans.appendMetricsTo(countScope.Metrics(), temporality, timestamp)
+	rc.spanEvents.appendMetricsTo(countScope.Metrics(), temporality, timestamp)
+	rc.metrics.appendMetricsTo(countScope.Metrics(), temporality, timestamp)
+	rc.dataPoints.appendMetricsTo(countScope.Metrics(), temporality, timestamp)
+	rc.logs.appendMetricsTo(countScope.Metrics(), temporality, timestamp)
+}
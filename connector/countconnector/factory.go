@@ -36,7 +36,9 @@ func NewFactory() connector.Factory {
 
 // createDefaultConfig creates the default configuration.
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Expiration: defaultExpiration,
+	}
 }
 
 // createTracesToMetrics creates a traces to metrics connector based on provided config.
@@ -51,12 +53,13 @@ func createTracesToMetrics(
 	spanMetricDefs := make(map[string]metricDef[ottlspan.TransformContext], len(c.Spans))
 	for name, info := range c.Spans {
 		md := metricDef[ottlspan.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			attrValues: newAttributeValues(info.Attributes),
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			condition, _ := filterottl.NewBoolExprForSpan(info.conditions(), filterottl.StandardSpanFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
 		spanMetricDefs[name] = md
@@ -65,22 +68,22 @@ func createTracesToMetrics(
 	spanEventMetricDefs := make(map[string]metricDef[ottlspanevent.TransformContext], len(c.SpanEvents))
 	for name, info := range c.SpanEvents {
 		md := metricDef[ottlspanevent.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			attrValues: newAttributeValues(info.Attributes),
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			condition, _ := filterottl.NewBoolExprForSpanEvent(info.conditions(), filterottl.StandardSpanEventFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
 		spanEventMetricDefs[name] = md
 	}
 
-	return &count{
-		metricsConsumer:      nextConsumer,
-		spansMetricDefs:      spanMetricDefs,
-		spanEventsMetricDefs: spanEventMetricDefs,
-	}, nil
+	conn := newCount(c, set.Logger, nextConsumer)
+	conn.spansMetricDefs = spanMetricDefs
+	conn.spanEventsMetricDefs = spanEventMetricDefs
+	return conn, nil
 }
 
 // createMetricsToMetrics creates a metricds to metrics connector based on provided config.
@@ -99,7 +102,7 @@ func createMetricsToMetrics(
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForMetric(info.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			condition, _ := filterottl.NewBoolExprForMetric(info.conditions(), filterottl.StandardMetricFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
 		metricMetricDefs[name] = md
@@ -108,22 +111,22 @@ func createMetricsToMetrics(
 	dataPointMetricDefs := make(map[string]metricDef[ottldatapoint.TransformContext], len(c.DataPoints))
 	for name, info := range c.DataPoints {
 		md := metricDef[ottldatapoint.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			attrValues: newAttributeValues(info.Attributes),
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			condition, _ := filterottl.NewBoolExprForDataPoint(info.conditions(), filterottl.StandardDataPointFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
 		dataPointMetricDefs[name] = md
 	}
 
-	return &count{
-		metricsConsumer:      nextConsumer,
-		metricsMetricDefs:    metricMetricDefs,
-		dataPointsMetricDefs: dataPointMetricDefs,
-	}, nil
+	conn := newCount(c, set.Logger, nextConsumer)
+	conn.metricsMetricDefs = metricMetricDefs
+	conn.dataPointsMetricDefs = dataPointMetricDefs
+	return conn, nil
 }
 
 // createLogsToMetrics creates a logs to metrics connector based on provided config.
@@ -138,25 +141,65 @@ func createLogsToMetrics(
 	metricDefs := make(map[string]metricDef[ottllog.TransformContext], len(c.Logs))
 	for name, info := range c.Logs {
 		md := metricDef[ottllog.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			attrValues: newAttributeValues(info.Attributes),
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			condition, _ := filterottl.NewBoolExprForLog(info.conditions(), filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
 		metricDefs[name] = md
 	}
 
-	return &count{
-		metricsConsumer: nextConsumer,
-		logsMetricDefs:  metricDefs,
-	}, nil
+	conn := newCount(c, set.Logger, nextConsumer)
+	conn.logsMetricDefs = metricDefs
+	return conn, nil
 }
 
 type metricDef[K any] struct {
 	condition expr.BoolExpr[K]
 	desc      string
 	attrs     []AttributeConfig
+	// attrValues holds the distinct values counted for each attribute with a limit of values
+	attrValues []map[string]struct{}
+}
+
+func newAttributeValues(attrs []AttributeConfig) []map[string]struct{} {
+	attrValues := make([]map[string]struct{}, len(attrs))
+	for i, attr := range attrs {
+		if attr.MaxValues > 0 {
+			attrValues[i] = make(map[string]struct{}, attr.MaxValues)
+		}
+	}
+	return attrValues
+}
+
+// resetAttributeValues forgets the distinct values counted for the attributes.
+func resetAttributeValues[K any](metricDefs map[string]metricDef[K]) {
+	for _, md := range metricDefs {
+		for _, values := range md.attrValues {
+			for value := range values {
+				delete(values, value)
+			}
+		}
+	}
+}
+
+// attributeValue returns the value to count for an attribute. Once the limit of distinct values
+// of the attribute is reached, values which were not counted yet are replaced by the overflow value.
+func (md metricDef[K]) attributeValue(i int, value string) string {
+	values := md.attrValues[i]
+	if values == nil {
+		return value
+	}
+	if _, ok := values[value]; ok || len(values) < md.attrs[i].MaxValues {
+		values[value] = struct{}{}
+		return value
+	}
+	if md.attrs[i].OverflowValue != "" {
+		return md.attrs[i].OverflowValue
+	}
+	return defaultOverflowValue
 }
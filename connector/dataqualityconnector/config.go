@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataqualityconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// RuleConfig defines the configuration of a quality rule.
+type RuleConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// RulesConfig defines the quality rules evaluated against the telemetry.
+type RulesConfig struct {
+	// MissingServiceName flags the records of resources without service.name.
+	MissingServiceName RuleConfig `mapstructure:"missing_service_name"`
+	// ZeroTimestamp flags the spans, data points and log records without timestamp.
+	ZeroTimestamp RuleConfig `mapstructure:"zero_timestamp"`
+	// MissingTraceContext flags the log records without trace ID. It does not apply
+	// to spans and data points.
+	MissingTraceContext RuleConfig `mapstructure:"missing_trace_context"`
+}
+
+// Config defines the configuration options for dataqualityconnector
+type Config struct {
+	Rules RulesConfig `mapstructure:"rules"`
+
+	// Interval is how often the scores are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MetricsExpiration is how long the metrics of a service without records
+	// are still emitted.
+	MetricsExpiration time.Duration `mapstructure:"metrics_expiration"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (c Config) Validate() error {
+	if c.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if c.MetricsExpiration <= 0 {
+		return errors.New("metrics_expiration must be positive")
+	}
+	if !c.Rules.MissingServiceName.Enabled && !c.Rules.ZeroTimestamp.Enabled && !c.Rules.MissingTraceContext.Enabled {
+		return errors.New("at least one rule must be enabled")
+	}
+	return nil
+}
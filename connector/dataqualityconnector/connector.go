@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataqualityconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+
+	ruleMissingServiceName  = "missing_service_name"
+	ruleZeroTimestamp       = "zero_timestamp"
+	ruleMissingTraceContext = "missing_trace_context"
+
+	// unknownService is the service of the resources without service.name, as
+	// defined by the resource semantic conventions.
+	unknownService = "unknown_service"
+)
+
+type connectorImp struct {
+	logger          *zap.Logger
+	config          *Config
+	metricsConsumer consumer.Metrics
+	scorecard       *scorecard
+
+	ticker       *time.Ticker
+	done         chan struct{}
+	wg           sync.WaitGroup
+	shutdownOnce sync.Once
+}
+
+func newConnector(logger *zap.Logger, config *Config, signal string, metricsConsumer consumer.Metrics) *connectorImp {
+	return &connectorImp{
+		logger:          logger,
+		config:          config,
+		metricsConsumer: metricsConsumer,
+		scorecard:       newScorecard(signal, config.MetricsExpiration),
+		done:            make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (c *connectorImp) Start(ctx context.Context, _ component.Host) error {
+	c.ticker = time.NewTicker(c.config.Interval)
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-c.ticker.C:
+				c.exportMetrics(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (c *connectorImp) Shutdown(context.Context) error {
+	c.shutdownOnce.Do(func() {
+		if c.ticker != nil {
+			c.ticker.Stop()
+			close(c.done)
+			c.wg.Wait()
+		}
+	})
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (c *connectorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *connectorImp) exportMetrics(ctx context.Context) {
+	md, ok := c.scorecard.metrics(time.Now())
+	if !ok {
+		return
+	}
+	if err := c.metricsConsumer.ConsumeMetrics(ctx, md); err != nil {
+		c.logger.Error("Failed to export data quality metrics", zap.Error(err))
+	}
+}
+
+// ConsumeTraces implements the consumer.Traces interface.
+func (c *connectorImp) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	now := time.Now()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service, hasService := serviceName(rs.Resource())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				violated := c.resourceViolations(hasService)
+				if c.config.Rules.ZeroTimestamp.Enabled && (span.StartTimestamp() == 0 || span.EndTimestamp() == 0) {
+					violated = append(violated, ruleZeroTimestamp)
+				}
+				c.scorecard.record(service, violated, now)
+			}
+		}
+	}
+	return nil
+}
+
+// ConsumeMetrics implements the consumer.Metrics interface.
+func (c *connectorImp) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	now := time.Now()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		service, hasService := serviceName(rm.Resource())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			metrics := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				forEachDataPointTimestamp(metrics.At(k), func(ts pcommon.Timestamp) {
+					violated := c.resourceViolations(hasService)
+					if c.config.Rules.ZeroTimestamp.Enabled && ts == 0 {
+						violated = append(violated, ruleZeroTimestamp)
+					}
+					c.scorecard.record(service, violated, now)
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// ConsumeLogs implements the consumer.Logs interface.
+func (c *connectorImp) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	now := time.Now()
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		service, hasService := serviceName(rl.Resource())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			records := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				record := records.At(k)
+				violated := c.resourceViolations(hasService)
+				// The timestamp of a log record is optional when it was observed
+				if c.config.Rules.ZeroTimestamp.Enabled && record.Timestamp() == 0 && record.ObservedTimestamp() == 0 {
+					violated = append(violated, ruleZeroTimestamp)
+				}
+				if c.config.Rules.MissingTraceContext.Enabled && record.TraceID().IsEmpty() {
+					violated = append(violated, ruleMissingTraceContext)
+				}
+				c.scorecard.record(service, violated, now)
+			}
+		}
+	}
+	return nil
+}
+
+// resourceViolations returns the rules violated by the resource of a record.
+func (c *connectorImp) resourceViolations(hasService bool) []string {
+	if c.config.Rules.MissingServiceName.Enabled && !hasService {
+		return []string{ruleMissingServiceName}
+	}
+	return nil
+}
+
+// serviceName returns the service.name of resource, or unknownService and false
+// when it is missing or empty.
+func serviceName(resource pcommon.Resource) (string, bool) {
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok && v.AsString() != "" {
+		return v.AsString(), true
+	}
+	return unknownService, false
+}
+
+// forEachDataPointTimestamp calls fn with the timestamp of each data point of m.
+func forEachDataPointTimestamp(m pmetric.Metric, fn func(pcommon.Timestamp)) {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			fn(m.Gauge().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			fn(m.Sum().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			fn(m.Histogram().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(m.ExponentialHistogram().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			fn(m.Summary().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
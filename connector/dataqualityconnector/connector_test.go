@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataqualityconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// dataPoints returns the data points of the metric named name, keyed by their
+// attributes.
+func dataPoints(t *testing.T, md pmetric.Metrics, name string) map[string]pmetric.NumberDataPoint {
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		if m.Type() == pmetric.MetricTypeGauge {
+			dps = m.Gauge().DataPoints()
+		} else {
+			dps = m.Sum().DataPoints()
+		}
+		points := make(map[string]pmetric.NumberDataPoint, dps.Len())
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			service, _ := dp.Attributes().Get("service.name")
+			key := service.Str()
+			if rule, ok := dp.Attributes().Get("rule"); ok {
+				key += "/" + rule.Str()
+			}
+			points[key] = dp
+		}
+		return points
+	}
+	require.Failf(t, "metric not found", "metric %q", name)
+	return nil
+}
+
+func TestTracesToMetrics(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateTracesToMetrics(context.Background(), connectortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	span := spans.AppendEmpty()
+	span.SetStartTimestamp(1)
+	span.SetEndTimestamp(2)
+	spans.AppendEmpty().SetStartTimestamp(1)
+	// Spans do not require a trace context to be attached
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetStartTimestamp(1)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+
+	conn.(*connectorImp).exportMetrics(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+
+	records := dataPoints(t, md, "dataquality.records")
+	assert.Equal(t, int64(2), records["checkout"].IntValue())
+	assert.Equal(t, int64(1), records["unknown_service"].IntValue())
+	signal, _ := records["checkout"].Attributes().Get("signal")
+	assert.Equal(t, "traces", signal.Str())
+
+	violations := dataPoints(t, md, "dataquality.violations")
+	assert.Len(t, violations, 3)
+	assert.Equal(t, int64(1), violations["checkout/zero_timestamp"].IntValue())
+	assert.Equal(t, int64(1), violations["unknown_service/missing_service_name"].IntValue())
+	assert.Equal(t, int64(1), violations["unknown_service/zero_timestamp"].IntValue())
+
+	scores := dataPoints(t, md, "dataquality.score")
+	assert.Equal(t, 0.5, scores["checkout"].DoubleValue())
+	assert.Equal(t, 0.0, scores["unknown_service"].DoubleValue())
+}
+
+func TestMetricsToMetrics(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "cart")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty().SetEmptyGauge()
+	gauge.DataPoints().AppendEmpty().SetTimestamp(1)
+	gauge.DataPoints().AppendEmpty()
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().SetTimestamp(1)
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+
+	conn.(*connectorImp).exportMetrics(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	out := sink.AllMetrics()[0]
+
+	assert.Equal(t, int64(3), dataPoints(t, out, "dataquality.records")["cart"].IntValue())
+	violations := dataPoints(t, out, "dataquality.violations")
+	assert.Len(t, violations, 1)
+	assert.Equal(t, int64(1), violations["cart/zero_timestamp"].IntValue())
+	assert.InDelta(t, 2.0/3, dataPoints(t, out, "dataquality.score")["cart"].DoubleValue(), 1e-9)
+}
+
+func TestLogsToMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rules.MissingServiceName.Enabled = false
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	traced := records.AppendEmpty()
+	traced.SetObservedTimestamp(1)
+	traced.SetTraceID(pcommon.TraceID{1})
+	records.AppendEmpty().SetTimestamp(1)
+	records.AppendEmpty()
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	conn.(*connectorImp).exportMetrics(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	out := sink.AllMetrics()[0]
+
+	assert.Equal(t, int64(3), dataPoints(t, out, "dataquality.records")["unknown_service"].IntValue())
+	violations := dataPoints(t, out, "dataquality.violations")
+	assert.Len(t, violations, 2)
+	assert.Equal(t, int64(2), violations["unknown_service/missing_trace_context"].IntValue())
+	assert.Equal(t, int64(1), violations["unknown_service/zero_timestamp"].IntValue())
+	assert.InDelta(t, 1.0/3, dataPoints(t, out, "dataquality.score")["unknown_service"].DoubleValue(), 1e-9)
+}
+
+func TestExportOnInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Nothing is exported before the first record
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, sink.AllMetrics())
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, conn.Shutdown(context.Background()))
+	require.NoError(t, conn.Shutdown(context.Background()))
+}
+
+func TestScorecardWindowAndExpiration(t *testing.T) {
+	s := newScorecard(signalLogs, time.Minute)
+	now := time.Now()
+	s.record("checkout", []string{ruleZeroTimestamp}, now)
+	s.record("cart", nil, now)
+
+	md, ok := s.metrics(now)
+	require.True(t, ok)
+	scores := dataPoints(t, md, "dataquality.score")
+	assert.Equal(t, 0.0, scores["checkout"].DoubleValue())
+	assert.Equal(t, 1.0, scores["cart"].DoubleValue())
+
+	// The score only covers the records since the last emission
+	now = now.Add(40 * time.Second)
+	s.record("checkout", nil, now)
+	md, ok = s.metrics(now)
+	require.True(t, ok)
+	scores = dataPoints(t, md, "dataquality.score")
+	assert.Len(t, scores, 1)
+	assert.Equal(t, 1.0, scores["checkout"].DoubleValue())
+	assert.Equal(t, int64(2), dataPoints(t, md, "dataquality.records")["checkout"].IntValue())
+	assert.Equal(t, int64(1), dataPoints(t, md, "dataquality.records")["cart"].IntValue())
+
+	// cart expires, checkout does not
+	now = now.Add(40 * time.Second)
+	md, ok = s.metrics(now)
+	require.True(t, ok)
+	records := dataPoints(t, md, "dataquality.records")
+	assert.Len(t, records, 1)
+	assert.Contains(t, records, "checkout")
+
+	now = now.Add(time.Minute)
+	_, ok = s.metrics(now)
+	assert.False(t, ok)
+	assert.Empty(t, s.services)
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dataqualityconnector evaluates telemetry against quality rules and
+// emits per-service quality score metrics.
+package dataqualityconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector"
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package dataqualityconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector/internal/metadata"
+)
+
+// NewFactory creates a factory for the data quality connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithTracesToMetrics(createTracesToMetricsConnector, metadata.TracesToMetricsStability),
+		connector.WithMetricsToMetrics(createMetricsToMetricsConnector, metadata.MetricsToMetricsStability),
+		connector.WithLogsToMetrics(createLogsToMetricsConnector, metadata.LogsToMetricsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Rules: RulesConfig{
+			MissingServiceName:  RuleConfig{Enabled: true},
+			ZeroTimestamp:       RuleConfig{Enabled: true},
+			MissingTraceContext: RuleConfig{Enabled: true},
+		},
+		Interval:          time.Minute,
+		MetricsExpiration: time.Hour,
+	}
+}
+
+func createTracesToMetricsConnector(_ context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
+	return newConnector(params.Logger, cfg.(*Config), signalTraces, nextConsumer), nil
+}
+
+func createMetricsToMetricsConnector(_ context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Metrics, error) {
+	return newConnector(params.Logger, cfg.(*Config), signalMetrics, nextConsumer), nil
+}
+
+func createLogsToMetricsConnector(_ context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Logs, error) {
+	return newConnector(params.Logger, cfg.(*Config), signalLogs, nextConsumer), nil
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataqualityconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dataqualityconnector"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	metricRecords    = "dataquality.records"
+	metricViolations = "dataquality.violations"
+	metricScore      = "dataquality.score"
+
+	signalKey = "signal"
+	ruleKey   = "rule"
+
+	scopeName = "otelcol/dataqualityconnector"
+)
+
+// serviceScore holds the counts of a service.
+type serviceScore struct {
+	// start is when the service was first seen, the start of its cumulative counts
+	start    pcommon.Timestamp
+	lastSeen time.Time
+
+	records    int64
+	violations map[string]int64
+
+	// intervalRecords and intervalFailed are the number of records, and of
+	// records violating at least one rule, since the scores were last emitted.
+	intervalRecords int64
+	intervalFailed  int64
+}
+
+// scorecard accumulates the quality counts of the services of a signal.
+type scorecard struct {
+	signal string
+	// expiration is how long the counts of a service without records are kept.
+	expiration time.Duration
+
+	mu       sync.Mutex
+	services map[string]*serviceScore
+}
+
+func newScorecard(signal string, expiration time.Duration) *scorecard {
+	return &scorecard{
+		signal:     signal,
+		expiration: expiration,
+		services:   make(map[string]*serviceScore),
+	}
+}
+
+// record counts a record of service received at now, violating the given rules.
+func (s *scorecard) record(service string, violated []string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	score, ok := s.services[service]
+	if !ok {
+		score = &serviceScore{
+			start:      pcommon.NewTimestampFromTime(now),
+			violations: make(map[string]int64),
+		}
+		s.services[service] = score
+	}
+	score.lastSeen = now
+	score.records++
+	score.intervalRecords++
+	if len(violated) > 0 {
+		score.intervalFailed++
+	}
+	for _, rule := range violated {
+		score.violations[rule]++
+	}
+}
+
+// removeExpired removes the services without records for longer than the
+// expiration, so that their cumulative counts restart if they come back.
+func (s *scorecard) removeExpired(now time.Time) {
+	for service, score := range s.services {
+		if now.Sub(score.lastSeen) > s.expiration {
+			delete(s.services, service)
+		}
+	}
+}
+
+// metrics returns the cumulative counts of the services as of now, and the
+// scores of the services with records since the scores were last emitted, or
+// false when there is no service.
+func (s *scorecard) metrics(now time.Time) (pmetric.Metrics, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired(now)
+	if len(s.services) == 0 {
+		return pmetric.Metrics{}, false
+	}
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	records := sm.Metrics().AppendEmpty()
+	records.SetName(metricRecords)
+	records.SetDescription("Number of records evaluated against the quality rules.")
+	records.SetUnit("{records}")
+	recordsSum := records.SetEmptySum()
+	recordsSum.SetIsMonotonic(true)
+	recordsSum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	violations := sm.Metrics().AppendEmpty()
+	violations.SetName(metricViolations)
+	violations.SetDescription("Number of records violating a quality rule.")
+	violations.SetUnit("{records}")
+	violationsSum := violations.SetEmptySum()
+	violationsSum.SetIsMonotonic(true)
+	violationsSum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	score := sm.Metrics().AppendEmpty()
+	score.SetName(metricScore)
+	score.SetDescription("Ratio of the records received since the last emission violating none of the quality rules, between 0 and 1.")
+	score.SetUnit("1")
+	scoreGauge := score.SetEmptyGauge()
+
+	services := make([]string, 0, len(s.services))
+	for service := range s.services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	ts := pcommon.NewTimestampFromTime(now)
+	for _, service := range services {
+		counts := s.services[service]
+
+		dp := recordsSum.DataPoints().AppendEmpty()
+		s.setDataPoint(dp, counts.start, ts, service)
+		dp.SetIntValue(counts.records)
+
+		rules := make([]string, 0, len(counts.violations))
+		for rule := range counts.violations {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+		for _, rule := range rules {
+			dp = violationsSum.DataPoints().AppendEmpty()
+			s.setDataPoint(dp, counts.start, ts, service)
+			dp.Attributes().PutStr(ruleKey, rule)
+			dp.SetIntValue(counts.violations[rule])
+		}
+
+		if counts.intervalRecords == 0 {
+			continue
+		}
+		dp = scoreGauge.DataPoints().AppendEmpty()
+		s.setDataPoint(dp, counts.start, ts, service)
+		dp.SetDoubleValue(float64(counts.intervalRecords-counts.intervalFailed) / float64(counts.intervalRecords))
+		counts.intervalRecords = 0
+		counts.intervalFailed = 0
+	}
+	return md, true
+}
+
+func (s *scorecard) setDataPoint(dp pmetric.NumberDataPoint, start, ts pcommon.Timestamp, service string) {
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.Attributes().PutStr(conventions.AttributeServiceName, service)
+	dp.Attributes().PutStr(signalKey, s.signal)
+}
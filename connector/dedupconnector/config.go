@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines configuration for the Dedup connector.
+type Config struct {
+	// Window is how long the duplicates of a record are dropped for, from the first record.
+	// The first record is emitted at the end of the window.
+	Window time.Duration `mapstructure:"window"`
+
+	// IncludeAttributes are the attributes of the records compared to find the duplicates.
+	// All the attributes are compared when empty. It can't be used together with ExcludeAttributes.
+	IncludeAttributes []string `mapstructure:"include_attributes"`
+
+	// ExcludeAttributes are the attributes of the records which are not compared to find the duplicates,
+	// such as the attributes holding request IDs, so that near duplicates are dropped too.
+	ExcludeAttributes []string `mapstructure:"exclude_attributes"`
+
+	// MaxEntries is the maximum number of distinct records kept, 0 meaning no limit. When it is reached,
+	// all the records kept are emitted without waiting for the end of their window.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if len(c.IncludeAttributes) > 0 && len(c.ExcludeAttributes) > 0 {
+		return errors.New("include_attributes and exclude_attributes can't be used together")
+	}
+	if c.MaxEntries < 0 {
+		return errors.New("max_entries can't be negative")
+	}
+	return nil
+}
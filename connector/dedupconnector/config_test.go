@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: &Config{Window: defaultWindow, MaxEntries: defaultMaxEntries},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Window:            time.Minute,
+				ExcludeAttributes: []string{"request.id", "trace.id"},
+				MaxEntries:        500,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "include"),
+			expected: &Config{
+				Window:            defaultWindow,
+				IncludeAttributes: []string{"error.type"},
+				MaxEntries:        defaultMaxEntries,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_window"),
+			expectedErr: "window must be positive",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "include_and_exclude"),
+			expectedErr: "include_attributes and exclude_attributes can't be used together",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_max_entries"),
+			expectedErr: "max_entries can't be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.NoError(t, cfg.(*Config).Validate())
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// visitFunc is called with each record of the data, along with its resource and its scope, the fields of
+// the record compared to find the duplicates and its attributes.
+type visitFunc[R any] func(resourceKey [16]byte, resource pcommon.Resource, scope pcommon.InstrumentationScope, record R, fields pcommon.Map, attrs pcommon.Map)
+
+// signal holds the functions handling the data of a signal. The type parameters D and R are expected
+// to be plog.Logs and plog.LogRecord, or ptrace.Traces and ptrace.Span.
+type signal[D any, R any] struct {
+	// name is the name of the records, used in the logs.
+	name string
+	// records calls the visit function with each record of the data.
+	records func(data D, visit visitFunc[R])
+	// newRecord returns an empty record, the records kept being copied to it.
+	newRecord func() R
+	// copyRecord copies a record to another.
+	copyRecord func(src R, dest R)
+	// build returns the data holding copies of the records of the entries, with their count.
+	build func(resources []*resourceGroup[R]) D
+	// consume emits the data.
+	consume func(ctx context.Context, data D) error
+}
+
+// resourceGroup are the entries of a resource, grouped by scope.
+type resourceGroup[R any] struct {
+	resource pcommon.Resource
+	scopes   []*scopeGroup[R]
+}
+
+// scopeGroup are the entries of a scope.
+type scopeGroup[R any] struct {
+	scope   pcommon.InstrumentationScope
+	entries []*entry[R]
+}
+
+// dedupConnector drops the duplicates of the records of a signal, the first record of each key being
+// emitted at the end of its window.
+type dedupConnector[D any, R any] struct {
+	logger *zap.Logger
+	signal signal[D, R]
+	keys   keyBuilder
+	dedup  *deduplicator[R]
+	loop   *flushLoop
+	// now is replaced by tests.
+	now func() time.Time
+}
+
+func newDedupConnector[D any, R any](logger *zap.Logger, cfg *Config, s signal[D, R]) *dedupConnector[D, R] {
+	return &dedupConnector[D, R]{
+		logger: logger,
+		signal: s,
+		keys:   newKeyBuilder(cfg),
+		dedup:  newDeduplicator[R](cfg.Window, cfg.MaxEntries),
+		loop:   newFlushLoop(cfg.Window),
+		now:    time.Now,
+	}
+}
+
+// Start starts emitting the records whose window ended.
+func (c *dedupConnector[D, R]) Start(context.Context, component.Host) error {
+	c.loop.start(func() {
+		// Errors are logged by flush, and the records are emitted again by the next flush
+		_ = c.flush(context.Background(), false)
+	})
+	return nil
+}
+
+// Shutdown emits all the records kept, without waiting for the end of their window.
+func (c *dedupConnector[D, R]) Shutdown(ctx context.Context) error {
+	c.loop.stop()
+	return c.flush(ctx, true)
+}
+
+func (c *dedupConnector[D, R]) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// consume keeps the first record of each key until the end of its window, and drops the others.
+// All the records kept are emitted first when their maximum number is reached, the data being
+// rejected if they can't be. The records of the data kept before are then counted again when the
+// data is sent again.
+func (c *dedupConnector[D, R]) consume(ctx context.Context, data D) error {
+	now := c.now()
+	var err error
+	c.signal.records(data, func(rk [16]byte, resource pcommon.Resource, scope pcommon.InstrumentationScope, record R, fields pcommon.Map, attrs pcommon.Map) {
+		if err != nil {
+			return
+		}
+		key := c.keys.key(rk, scope, fields, attrs)
+		copyFn := func() *entry[R] {
+			e := &entry[R]{
+				resourceKey: rk,
+				resource:    pcommon.NewResource(),
+				scope:       pcommon.NewInstrumentationScope(),
+				record:      c.signal.newRecord(),
+			}
+			resource.CopyTo(e.resource)
+			scope.CopyTo(e.scope)
+			c.signal.copyRecord(record, e.record)
+			return e
+		}
+		for !c.dedup.observe(key, now, copyFn) {
+			if err = c.flush(ctx, true); err != nil {
+				return
+			}
+		}
+	})
+	return err
+}
+
+// flush emits the records whose window ended, or all of them if force is true, with the number
+// of records they stand for. The records are kept to be emitted by the next flush if they can't be.
+func (c *dedupConnector[D, R]) flush(ctx context.Context, force bool) error {
+	entries := c.dedup.expire(c.now(), force)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var resources []*resourceGroup[R]
+	resourceGroups := make(map[[16]byte]*resourceGroup[R])
+	scopeGroups := make(map[scopeKey]*scopeGroup[R])
+	for _, e := range entries {
+		rg, ok := resourceGroups[e.resourceKey]
+		if !ok {
+			rg = &resourceGroup[R]{resource: e.resource}
+			resourceGroups[e.resourceKey] = rg
+			resources = append(resources, rg)
+		}
+		sk := newScopeKey(e.resourceKey, e.scope)
+		sg, ok := scopeGroups[sk]
+		if !ok {
+			sg = &scopeGroup[R]{scope: e.scope}
+			scopeGroups[sk] = sg
+			rg.scopes = append(rg.scopes, sg)
+		}
+		sg.entries = append(sg.entries, e)
+	}
+
+	if err := c.signal.consume(ctx, c.signal.build(resources)); err != nil {
+		c.logger.Error("Failed to emit the deduplicated "+c.signal.name, zap.Error(err))
+		c.dedup.restore(entries)
+		return err
+	}
+	return nil
+}
+
+type logsDedup struct {
+	*dedupConnector[plog.Logs, plog.LogRecord]
+}
+
+func newLogsConnector(logger *zap.Logger, cfg *Config, logs consumer.Logs) *logsDedup {
+	return &logsDedup{newDedupConnector(logger, cfg, signal[plog.Logs, plog.LogRecord]{
+		name:       "logs",
+		records:    logRecords,
+		newRecord:  plog.NewLogRecord,
+		copyRecord: plog.LogRecord.CopyTo,
+		build:      buildLogs,
+		consume:    logs.ConsumeLogs,
+	})}
+}
+
+// ConsumeLogs keeps the first log record of each key until the end of its window, and drops the others.
+func (c *logsDedup) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.consume(ctx, ld)
+}
+
+func logRecords(ld plog.Logs, visit visitFunc[plog.LogRecord]) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		rk := resourceKey(rl.Resource())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				fields := pcommon.NewMap()
+				fields.PutInt("severity_number", int64(lr.SeverityNumber()))
+				lr.Body().CopyTo(fields.PutEmpty("body"))
+				visit(rk, rl.Resource(), sl.Scope(), lr, fields, lr.Attributes())
+			}
+		}
+	}
+}
+
+func buildLogs(resources []*resourceGroup[plog.LogRecord]) plog.Logs {
+	ld := plog.NewLogs()
+	for _, rg := range resources {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rg.resource.CopyTo(rl.Resource())
+		for _, sg := range rg.scopes {
+			sl := rl.ScopeLogs().AppendEmpty()
+			sg.scope.CopyTo(sl.Scope())
+			for _, e := range sg.entries {
+				lr := sl.LogRecords().AppendEmpty()
+				e.record.CopyTo(lr)
+				lr.Attributes().PutInt(dedupCountKey, e.count)
+			}
+		}
+	}
+	return ld
+}
+
+type tracesDedup struct {
+	*dedupConnector[ptrace.Traces, ptrace.Span]
+}
+
+func newTracesConnector(logger *zap.Logger, cfg *Config, traces consumer.Traces) *tracesDedup {
+	return &tracesDedup{newDedupConnector(logger, cfg, signal[ptrace.Traces, ptrace.Span]{
+		name:       "spans",
+		records:    spans,
+		newRecord:  ptrace.NewSpan,
+		copyRecord: ptrace.Span.CopyTo,
+		build:      buildTraces,
+		consume:    traces.ConsumeTraces,
+	})}
+}
+
+// ConsumeTraces keeps the first span of each key until the end of its window, and drops the others.
+func (c *tracesDedup) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.consume(ctx, td)
+}
+
+func spans(td ptrace.Traces, visit visitFunc[ptrace.Span]) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		rk := resourceKey(rs.Resource())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				fields := pcommon.NewMap()
+				fields.PutStr("name", span.Name())
+				fields.PutInt("kind", int64(span.Kind()))
+				fields.PutInt("status.code", int64(span.Status().Code()))
+				fields.PutStr("status.message", span.Status().Message())
+				visit(rk, rs.Resource(), ss.Scope(), span, fields, span.Attributes())
+			}
+		}
+	}
+}
+
+func buildTraces(resources []*resourceGroup[ptrace.Span]) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, rg := range resources {
+		rs := td.ResourceSpans().AppendEmpty()
+		rg.resource.CopyTo(rs.Resource())
+		for _, sg := range rg.scopes {
+			ss := rs.ScopeSpans().AppendEmpty()
+			sg.scope.CopyTo(ss.Scope())
+			for _, e := range sg.entries {
+				span := ss.Spans().AppendEmpty()
+				e.record.CopyTo(span)
+				span.Attributes().PutInt(dedupCountKey, e.count)
+			}
+		}
+	}
+	return td
+}
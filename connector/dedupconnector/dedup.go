@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// dedupCountKey is the attribute holding the number of records a kept record stands for.
+const dedupCountKey = "dedup.count"
+
+// entry is the first record of a key, with its resource and scope, and the number of records of the key
+// seen in its window. The type parameter R is expected to be one of plog.LogRecord or ptrace.Span.
+type entry[R any] struct {
+	key         [16]byte
+	resourceKey [16]byte
+	resource    pcommon.Resource
+	scope       pcommon.InstrumentationScope
+	record      R
+	count       int64
+	firstSeen   time.Time
+	// seq orders the entries by arrival.
+	seq uint64
+}
+
+// deduplicator keeps the first record of each key until the end of its window, counting the duplicates.
+type deduplicator[R any] struct {
+	window     time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[[16]byte]*entry[R]
+	seq     uint64
+}
+
+func newDeduplicator[R any](window time.Duration, maxEntries int) *deduplicator[R] {
+	return &deduplicator[R]{
+		window:     window,
+		maxEntries: maxEntries,
+		entries:    make(map[[16]byte]*entry[R]),
+	}
+}
+
+// observe records a record of a key. The record, its resource and its scope are copied by the copyFn
+// function if it is the first record of the key in its window, otherwise the record is counted. It returns
+// false, without recording the record, when it is the first record of its key and the deduplicator is full.
+func (d *deduplicator[R]) observe(key [16]byte, now time.Time, copyFn func() *entry[R]) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if e, ok := d.entries[key]; ok {
+		e.count++
+		return true
+	}
+	if d.maxEntries > 0 && len(d.entries) >= d.maxEntries {
+		return false
+	}
+	e := copyFn()
+	e.key = key
+	e.count = 1
+	e.firstSeen = now
+	e.seq = d.seq
+	d.seq++
+	d.entries[key] = e
+	return true
+}
+
+// expire removes and returns the entries whose window ended, or all of them if force is true,
+// in the order they were first seen.
+func (d *deduplicator[R]) expire(now time.Time, force bool) []*entry[R] {
+	d.mu.Lock()
+	var expired []*entry[R]
+	for key, e := range d.entries {
+		if force || now.Sub(e.firstSeen) >= d.window {
+			expired = append(expired, e)
+			delete(d.entries, key)
+		}
+	}
+	d.mu.Unlock()
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].seq < expired[j].seq
+	})
+	return expired
+}
+
+// restore puts back expired entries which could not be emitted, so that they are emitted by the next flush.
+// The records of their keys seen since they expired are counted in the restored entries.
+func (d *deduplicator[R]) restore(entries []*entry[R]) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range entries {
+		if newer, ok := d.entries[e.key]; ok {
+			e.count += newer.count
+		}
+		d.entries[e.key] = e
+	}
+}
+
+// keyBuilder computes the keys of the records, from their resource, their scope, the fields of the
+// records and their attributes.
+type keyBuilder struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+func newKeyBuilder(cfg *Config) keyBuilder {
+	return keyBuilder{include: toSet(cfg.IncludeAttributes), exclude: toSet(cfg.ExcludeAttributes)}
+}
+
+func toSet(keys []string) map[string]bool {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// key returns the hash of the record, whose fields are set in the given map.
+func (b keyBuilder) key(resourceKey [16]byte, scope pcommon.InstrumentationScope, fields pcommon.Map, attrs pcommon.Map) [16]byte {
+	fields.PutStr("resource", string(resourceKey[:]))
+	fields.PutStr("scope.name", scope.Name())
+	fields.PutStr("scope.version", scope.Version())
+	keyAttrs := fields.PutEmptyMap("attributes")
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if (b.include == nil || b.include[k]) && !b.exclude[k] {
+			v.CopyTo(keyAttrs.PutEmpty(k))
+		}
+		return true
+	})
+	return pdatautil.MapHash(fields)
+}
+
+// resourceKey returns the hash of a resource, identifying the resource of the records.
+func resourceKey(resource pcommon.Resource) [16]byte {
+	return pdatautil.MapHash(resource.Attributes())
+}
+
+// scopeKey identifies the scope of the records of a resource.
+type scopeKey struct {
+	resourceKey [16]byte
+	name        string
+	version     string
+}
+
+func newScopeKey(resourceKey [16]byte, scope pcommon.InstrumentationScope) scopeKey {
+	return scopeKey{resourceKey: resourceKey, name: scope.Name(), version: scope.Version()}
+}
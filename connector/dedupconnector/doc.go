@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dedupconnector drops the duplicates of log records and spans within
+// a window, counting them on the record which is kept.
+package dedupconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector"
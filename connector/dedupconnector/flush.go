@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/dedupconnector"
+
+import (
+	"sync"
+	"time"
+)
+
+// flushChecksPerWindow is how many times per window the connector checks for the records whose window ended,
+// so that the records are emitted at most a tenth of the window late.
+const flushChecksPerWindow = 10
+
+// flushLoop runs a flush function on an interval until stopped.
+type flushLoop struct {
+	interval   time.Duration
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newFlushLoop(window time.Duration) *flushLoop {
+	interval := window / flushChecksPerWindow
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	return &flushLoop{interval: interval}
+}
+
+func (l *flushLoop) start(flush func()) {
+	l.shutdownCh = make(chan struct{})
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				flush()
+			case <-l.shutdownCh:
+				return
+			}
+		}
+	}()
+}
+
+// stop stops the loop, and waits for the running flush to complete.
+func (l *flushLoop) stop() {
+	if l.shutdownCh == nil {
+		return
+	}
+	close(l.shutdownCh)
+	l.wg.Wait()
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLogs(service string, records ...map[string]string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+	for _, record := range records {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+		for k, v := range record {
+			if k == "body" {
+				lr.Body().SetStr(v)
+				continue
+			}
+			lr.Attributes().PutStr(k, v)
+		}
+	}
+	return ld
+}
+
+func TestLogsDedup(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	cfg := &Config{Window: 10 * time.Second, ExcludeAttributes: []string{"request.id"}}
+	c := newLogsConnector(zap.NewNop(), cfg, sink)
+	clock := &testClock{now: time.Unix(1000, 0)}
+	c.now = clock.Now
+
+	ctx := context.Background()
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "connection refused", "request.id": "1"},
+		map[string]string{"body": "connection refused", "request.id": "2"},
+		map[string]string{"body": "timeout", "request.id": "3"},
+	)))
+	clock.now = clock.now.Add(5 * time.Second)
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "connection refused", "request.id": "4"},
+	)))
+	// Records of other resources are not duplicates
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("b",
+		map[string]string{"body": "connection refused", "request.id": "5"},
+	)))
+
+	// Nothing is emitted before the end of the window
+	require.NoError(t, c.flush(ctx, false))
+	assert.Empty(t, sink.AllLogs())
+
+	clock.now = clock.now.Add(5 * time.Second)
+	require.NoError(t, c.flush(ctx, false))
+	require.Len(t, sink.AllLogs(), 1)
+	ld := sink.AllLogs()[0]
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	service, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "a", service.Str())
+	sl := ld.ResourceLogs().At(0).ScopeLogs()
+	require.Equal(t, 1, sl.Len())
+	assert.Equal(t, "scope", sl.At(0).Scope().Name())
+	records := sl.At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assertRecord(t, records.At(0), "connection refused", "1", 3)
+	assertRecord(t, records.At(1), "timeout", "3", 1)
+
+	// A new window starts for the records of a key emitted
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "connection refused", "request.id": "6"},
+	)))
+	require.NoError(t, c.Shutdown(ctx))
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 2, sink.AllLogs()[1].LogRecordCount())
+}
+
+func assertRecord(t *testing.T, lr plog.LogRecord, body string, requestID string, count int64) {
+	assert.Equal(t, body, lr.Body().Str())
+	id, _ := lr.Attributes().Get("request.id")
+	assert.Equal(t, requestID, id.Str())
+	dedupCount, _ := lr.Attributes().Get(dedupCountKey)
+	assert.Equal(t, count, dedupCount.Int())
+}
+
+func TestLogsDedupIncludeAttributes(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	c := newLogsConnector(zap.NewNop(), &Config{Window: time.Minute, IncludeAttributes: []string{"error.type"}}, sink)
+
+	ctx := context.Background()
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "failed", "error.type": "io", "user": "alice"},
+		map[string]string{"body": "failed", "error.type": "io", "user": "bob"},
+		map[string]string{"body": "failed", "error.type": "timeout", "user": "alice"},
+	)))
+	require.NoError(t, c.flush(ctx, true))
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestLogsConnectorLifecycle(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(),
+		&Config{Window: 10 * time.Millisecond}, sink)
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), newTestLogs("a",
+		map[string]string{"body": "failed"},
+		map[string]string{"body": "failed"},
+	)))
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
+
+func TestLogsDedupMaxEntries(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	c := newLogsConnector(zap.NewNop(), &Config{Window: time.Minute, MaxEntries: 2}, sink)
+
+	ctx := context.Background()
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "failed"},
+		map[string]string{"body": "timeout"},
+		map[string]string{"body": "failed"},
+	)))
+	assert.Empty(t, sink.AllLogs(), "Must count the duplicates of the records kept")
+
+	// The records kept are emitted before the window ends once the maximum is reached, even within a batch
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a",
+		map[string]string{"body": "refused"},
+		map[string]string{"body": "reset"},
+		map[string]string{"body": "closed"},
+	)))
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 2, sink.AllLogs()[0].LogRecordCount())
+	assert.Equal(t, 2, sink.AllLogs()[1].LogRecordCount())
+
+	require.NoError(t, c.Shutdown(ctx))
+	assert.Equal(t, 5, sink.LogRecordCount())
+}
+
+func TestLogsDedupEmitError(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	failing := consumertest.NewErr(errors.New("backend unavailable"))
+	var next consumer.Logs = failing
+	logs, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return next.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	c := newLogsConnector(zap.NewNop(), &Config{Window: time.Minute, MaxEntries: 1}, logs)
+
+	ctx := context.Background()
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a", map[string]string{"body": "failed"})))
+	require.Error(t, c.flush(ctx, true))
+	// The data is rejected while the records kept can't be emitted
+	require.Error(t, c.ConsumeLogs(ctx, newTestLogs("a", map[string]string{"body": "timeout"})))
+
+	// The records kept are emitted again by the next flush
+	next = sink
+	require.NoError(t, c.ConsumeLogs(ctx, newTestLogs("a", map[string]string{"body": "timeout"})))
+	require.Len(t, sink.AllLogs(), 1)
+	assertRecord(t, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0), "failed", "", 1)
+	require.NoError(t, c.Shutdown(ctx))
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
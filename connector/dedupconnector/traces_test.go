@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestTracesDedup(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	c := newTracesConnector(zap.NewNop(), &Config{Window: time.Minute}, sink)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 3; i++ {
+		span := spans.AppendEmpty()
+		span.SetName("GET /health")
+		span.SetTraceID([16]byte{byte(i)})
+		span.Status().SetCode(ptrace.StatusCodeOk)
+	}
+	span := spans.AppendEmpty()
+	span.SetName("GET /health")
+	span.Status().SetCode(ptrace.StatusCodeError)
+
+	ctx := context.Background()
+	require.NoError(t, c.ConsumeTraces(ctx, td))
+	require.NoError(t, c.flush(ctx, true))
+
+	require.Equal(t, 2, sink.SpanCount())
+	emitted := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, [16]byte{0}, [16]byte(emitted.At(0).TraceID()))
+	count, _ := emitted.At(0).Attributes().Get(dedupCountKey)
+	assert.Equal(t, int64(3), count.Int())
+	count, _ = emitted.At(1).Attributes().Get(dedupCountKey)
+	assert.Equal(t, int64(1), count.Int())
+}
@@ -4,7 +4,9 @@
 package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -24,6 +26,34 @@ type Config struct {
 	// The dimensions will be fetched from the span's attributes. Examples of some conventionally used attributes:
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// Fingerprint configures the fingerprinting of the stack traces of exceptions.
+	Fingerprint FingerprintConfig `mapstructure:"fingerprint"`
+
+	// Deduplication configures the deduplication of the generated logs by fingerprint.
+	Deduplication DeduplicationConfig `mapstructure:"deduplication"`
+}
+
+// FingerprintConfig defines the fingerprinting of exceptions, identifying the exceptions of a type
+// thrown from the same code path.
+type FingerprintConfig struct {
+	// Enabled adds the exception.fingerprint dimension to the metrics and attribute to the logs.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxFrames limits the number of frames of the stack trace, from its top, that are fingerprinted.
+	// Zero fingerprints all the frames.
+	MaxFrames int `mapstructure:"max_frames"`
+}
+
+// DeduplicationConfig defines the deduplication of the logs of exceptions having the same fingerprint.
+type DeduplicationConfig struct {
+	// Enabled emits a log for the first exception of a fingerprint, then at most one log per interval
+	// for the following exceptions of the fingerprint, counting them.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval at which the logs of the deduplicated exceptions are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+	// Expiration is the time after which a fingerprint which was not seen again is forgotten,
+	// and its next exception is considered as first seen.
+	Expiration time.Duration `mapstructure:"expiration"`
 }
 
 var _ component.ConfigValidator = (*Config)(nil)
@@ -34,6 +64,27 @@ func (c Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	if c.Fingerprint.Enabled {
+		for _, d := range c.Dimensions {
+			if d.Name == exceptionFingerprintKey {
+				return fmt.Errorf("duplicate dimension name %q", d.Name)
+			}
+		}
+	}
+	if c.Fingerprint.MaxFrames < 0 {
+		return errors.New("fingerprint max_frames must not be negative")
+	}
+	if c.Deduplication.Enabled {
+		if !c.Fingerprint.Enabled {
+			return errors.New("deduplication requires fingerprint to be enabled")
+		}
+		if c.Deduplication.Interval <= 0 {
+			return errors.New("deduplication interval must be positive")
+		}
+		if c.Deduplication.Expiration < c.Deduplication.Interval {
+			return errors.New("deduplication expiration must not be shorter than the interval")
+		}
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ package exceptionsconnector
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +37,15 @@ func TestLoadConfig(t *testing.T) {
 					{Name: exceptionTypeKey},
 					{Name: exceptionMessageKey},
 				},
+				Fingerprint: FingerprintConfig{
+					Enabled:   true,
+					MaxFrames: 20,
+				},
+				Deduplication: DeduplicationConfig{
+					Enabled:    true,
+					Interval:   5 * time.Minute,
+					Expiration: 24 * time.Hour,
+				},
 			},
 		},
 	}
@@ -98,3 +108,58 @@ func TestValidateDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFingerprintAndDeduplication(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name: "fingerprint dimension",
+			modify: func(cfg *Config) {
+				cfg.Fingerprint.Enabled = true
+				cfg.Dimensions = append(cfg.Dimensions, Dimension{Name: exceptionFingerprintKey})
+			},
+			expectedErr: `duplicate dimension name "exception.fingerprint"`,
+		},
+		{
+			name: "negative max frames",
+			modify: func(cfg *Config) {
+				cfg.Fingerprint.MaxFrames = -1
+			},
+			expectedErr: "fingerprint max_frames must not be negative",
+		},
+		{
+			name: "deduplication without fingerprint",
+			modify: func(cfg *Config) {
+				cfg.Deduplication.Enabled = true
+			},
+			expectedErr: "deduplication requires fingerprint to be enabled",
+		},
+		{
+			name: "zero deduplication interval",
+			modify: func(cfg *Config) {
+				cfg.Fingerprint.Enabled = true
+				cfg.Deduplication.Enabled = true
+				cfg.Deduplication.Interval = 0
+			},
+			expectedErr: "deduplication interval must be positive",
+		},
+		{
+			name: "expiration shorter than interval",
+			modify: func(cfg *Config) {
+				cfg.Fingerprint.Enabled = true
+				cfg.Deduplication.Enabled = true
+				cfg.Deduplication.Expiration = time.Second
+			},
+			expectedErr: "deduplication expiration must not be shorter than the interval",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tc.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tc.expectedErr)
+		})
+	}
+}
@@ -13,6 +13,11 @@ const (
 	exceptionTypeKey       = conventions.AttributeExceptionType
 	exceptionMessageKey    = conventions.AttributeExceptionMessage
 	exceptionStacktraceKey = conventions.AttributeExceptionStacktrace
+	// exceptionFingerprintKey identifies the exceptions of a type thrown from the same code path.
+	exceptionFingerprintKey = "exception.fingerprint"
+	// exceptionFirstSeenKey and exceptionCountKey are the attributes of the deduplicated logs.
+	exceptionFirstSeenKey = "exception.first_seen"
+	exceptionCountKey     = "exception.count"
 	// TODO(marctc): formalize these constants in the OpenTelemetry specification.
 	spanKindKey   = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey = "status.code" // OpenTelemetry non-standard constant.
@@ -6,6 +6,8 @@ package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-c
 import (
 	"context"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -25,8 +27,11 @@ type logsConnector struct {
 	dimensions []dimension
 
 	logsConsumer consumer.Logs
-	component.StartFunc
-	component.ShutdownFunc
+
+	// dedup deduplicates the logs by fingerprint, when enabled.
+	dedup      *deduplicator
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
 
 	logger *zap.Logger
 }
@@ -34,11 +39,59 @@ type logsConnector struct {
 func newLogsConnector(logger *zap.Logger, config component.Config) *logsConnector {
 	cfg := config.(*Config)
 
-	return &logsConnector{
+	c := &logsConnector{
 		logger:     logger,
 		config:     *cfg,
 		dimensions: newDimensions(cfg.Dimensions),
 	}
+	if cfg.Deduplication.Enabled {
+		c.dedup = newDeduplicator(cfg.Deduplication.Expiration)
+	}
+	return c
+}
+
+// Start starts emitting the deduplicated logs on an interval, when deduplication is enabled.
+func (c *logsConnector) Start(context.Context, component.Host) error {
+	if c.dedup != nil {
+		c.shutdownCh = make(chan struct{})
+		c.wg.Add(1)
+		go c.flushLoop()
+	}
+	return nil
+}
+
+// Shutdown stops emitting the deduplicated logs, after emitting the pending ones.
+func (c *logsConnector) Shutdown(ctx context.Context) error {
+	if c.shutdownCh == nil {
+		return nil
+	}
+	close(c.shutdownCh)
+	c.wg.Wait()
+	return c.flush(ctx)
+}
+
+func (c *logsConnector) flushLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.config.Deduplication.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are logged by exportLogs
+			_ = c.flush(context.Background())
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// flush emits the logs of the exceptions deduplicated since the previous flush.
+func (c *logsConnector) flush(ctx context.Context) error {
+	ld := c.dedup.flush(time.Now())
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+	return c.exportLogs(ctx, ld)
 }
 
 // Capabilities implements the consumer interface.
@@ -75,9 +128,30 @@ func (c *logsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Traces)
 			}
 		}
 	}
+	if c.dedup != nil {
+		if ld = c.deduplicate(ld); ld.LogRecordCount() == 0 {
+			return nil
+		}
+	}
 	return c.exportLogs(ctx, ld)
 }
 
+// deduplicate removes the logs of the exceptions whose fingerprint was already seen,
+// which are emitted on the deduplication interval instead.
+func (c *logsConnector) deduplicate(ld plog.Logs) plog.Logs {
+	now := time.Now()
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		sls := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			sl.LogRecords().RemoveIf(func(logRecord plog.LogRecord) bool {
+				return !c.dedup.observe(logRecord, sl.Scope(), now)
+			})
+		}
+	}
+	return ld
+}
+
 func (c *logsConnector) exportLogs(ctx context.Context, ld plog.Logs) error {
 	if err := c.logsConsumer.ConsumeLogs(ctx, ld); err != nil {
 		c.logger.Error("failed to convert exceptions to logs", zap.Error(err))
@@ -116,6 +190,10 @@ func (c *logsConnector) attrToLogRecord(sl plog.ScopeLogs, serviceName string, s
 	// Add stacktrace to the log record.
 	logRecord.Attributes().PutStr(exceptionStacktraceKey, getValue(eventAttrs, exceptionStacktraceKey))
 
+	if c.config.Fingerprint.Enabled {
+		logRecord.Attributes().PutStr(exceptionFingerprintKey, fingerprint(eventAttrs, c.config.Fingerprint.MaxFrames))
+	}
+
 	// Add HTTP context to the log record.
 	for k, v := range extractHTTP(spanAttrs) {
 		logRecord.Attributes().PutStr(k, v)
@@ -6,12 +6,15 @@ package exceptionsconnector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -58,3 +61,106 @@ func newTestLogsConnector(lcon consumer.Logs, logger *zap.Logger) *logsConnector
 	c.logsConsumer = lcon
 	return c
 }
+
+func TestConnectorLogDeduplication(t *testing.T) {
+	lsink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	cfg.Deduplication.Enabled = true
+	cfg.Deduplication.Interval = time.Hour
+	require.NoError(t, cfg.Validate())
+	c := newLogsConnector(zaptest.NewLogger(t), cfg)
+	c.logsConsumer = lsink
+	ctx := context.Background()
+	require.NoError(t, c.Start(ctx, componenttest.NewNopHost()))
+
+	// The sample trace has 3 exceptions with the same fingerprint, 2 of service-a and 1 of service-b,
+	// only the first one of each service is emitted.
+	require.NoError(t, c.ConsumeTraces(ctx, buildSampleTrace()))
+	require.Len(t, lsink.AllLogs(), 1)
+	require.Equal(t, 2, lsink.AllLogs()[0].LogRecordCount())
+	first := lsink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	fp, ok := first.Attributes().Get(exceptionFingerprintKey)
+	require.True(t, ok)
+	assert.Len(t, fp.Str(), 16)
+	count, _ := first.Attributes().Get(exceptionCountKey)
+	assert.Equal(t, int64(1), count.Int())
+	firstSeen, ok := first.Attributes().Get(exceptionFirstSeenKey)
+	require.True(t, ok)
+
+	// Further exceptions are not emitted until the interval elapses.
+	require.NoError(t, c.ConsumeTraces(ctx, buildSampleTrace()))
+	require.Len(t, lsink.AllLogs(), 1)
+
+	// The pending exceptions are emitted on shutdown, counting the exceptions of each service since
+	// its first log.
+	require.NoError(t, c.Shutdown(ctx))
+	require.Len(t, lsink.AllLogs(), 2)
+	latest := lsink.AllLogs()[1]
+	require.Equal(t, 2, latest.LogRecordCount())
+	counts := map[string]int64{}
+	for i := 0; i < latest.ResourceLogs().Len(); i++ {
+		logRecord := latest.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0)
+		service, _ := logRecord.Attributes().Get(serviceNameKey)
+		count, _ = logRecord.Attributes().Get(exceptionCountKey)
+		counts[service.Str()] = count.Int()
+		latestFp, _ := logRecord.Attributes().Get(exceptionFingerprintKey)
+		assert.Equal(t, fp.Str(), latestFp.Str())
+		if service.Str() == "service-a" {
+			latestFirstSeen, _ := logRecord.Attributes().Get(exceptionFirstSeenKey)
+			assert.Equal(t, firstSeen.Str(), latestFirstSeen.Str())
+		}
+	}
+	assert.Equal(t, map[string]int64{"service-a": 3, "service-b": 1}, counts)
+}
+
+func TestDeduplicatorExpiration(t *testing.T) {
+	d := newDeduplicator(time.Hour)
+	now := time.Now()
+	logRecord := plog.NewLogRecord()
+	logRecord.Attributes().PutStr(exceptionFingerprintKey, "fp")
+	scope := pcommon.NewInstrumentationScope()
+
+	assert.True(t, d.observe(logRecord, scope, now))
+	assert.False(t, d.observe(logRecord, scope, now))
+	assert.Equal(t, 1, d.flush(now).LogRecordCount())
+
+	// Nothing pending, the fingerprint is kept until it expires.
+	assert.Equal(t, 0, d.flush(now.Add(time.Minute)).LogRecordCount())
+	assert.Len(t, d.fingerprints, 1)
+	assert.Equal(t, 0, d.flush(now.Add(time.Hour)).LogRecordCount())
+	assert.Len(t, d.fingerprints, 0)
+
+	// The fingerprint is seen for the first time again.
+	assert.True(t, d.observe(logRecord, scope, now.Add(time.Hour)))
+}
+
+func TestDeduplicatorServices(t *testing.T) {
+	d := newDeduplicator(time.Hour)
+	now := time.Now()
+	scope := pcommon.NewInstrumentationScope()
+	newLogRecord := func(service string) plog.LogRecord {
+		logRecord := plog.NewLogRecord()
+		logRecord.Attributes().PutStr(serviceNameKey, service)
+		logRecord.Attributes().PutStr(exceptionFingerprintKey, "fp")
+		return logRecord
+	}
+
+	// The same exception is deduplicated for each service.
+	assert.True(t, d.observe(newLogRecord("checkout"), scope, now))
+	assert.True(t, d.observe(newLogRecord("cart"), scope, now))
+	assert.False(t, d.observe(newLogRecord("checkout"), scope, now))
+	assert.False(t, d.observe(newLogRecord("cart"), scope, now))
+	assert.False(t, d.observe(newLogRecord("cart"), scope, now))
+
+	ld := d.flush(now)
+	require.Equal(t, 2, ld.LogRecordCount())
+	counts := map[string]int64{}
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		logRecord := ld.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0)
+		service, _ := logRecord.Attributes().Get(serviceNameKey)
+		count, _ := logRecord.Attributes().Get(exceptionCountKey)
+		counts[service.Str()] = count.Int()
+	}
+	assert.Equal(t, map[string]int64{"checkout": 1, "cart": 2}, counts)
+}
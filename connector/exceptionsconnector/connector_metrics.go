@@ -92,9 +92,17 @@ func (c *metricsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Trac
 
 						c.keyBuf.Reset()
 						buildKey(c.keyBuf, serviceName, span, c.dimensions, eventAttrs)
+						var fp string
+						if c.config.Fingerprint.Enabled {
+							fp = fingerprint(eventAttrs, c.config.Fingerprint.MaxFrames)
+							concatDimensionValue(c.keyBuf, fp, true)
+						}
 						key := c.keyBuf.String()
 
 						attrs := buildDimensionKVs(c.dimensions, serviceName, span, eventAttrs)
+						if c.config.Fingerprint.Enabled {
+							attrs.PutStr(exceptionFingerprintKey, fp)
+						}
 						c.addException(key, attrs)
 					}
 				}
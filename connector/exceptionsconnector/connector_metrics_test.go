@@ -89,6 +89,32 @@ func TestConnectorConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestConnectorConsumeTracesWithFingerprint(t *testing.T) {
+	msink := &consumertest.MetricsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	p := newMetricsConnector(zaptest.NewLogger(t), cfg)
+	p.metricsConsumer = msink
+
+	traces := buildSampleTrace()
+	// Another code path of the exception of the service-b span
+	event := traces.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).Events().At(0)
+	event.Attributes().PutStr(exceptionStacktraceKey, "Exception message\n\tat com.example.B.b(B.java:2)")
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	require.Len(t, msink.AllMetrics(), 1)
+	dps := msink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	fingerprints := map[string]string{}
+	for i := 0; i < dps.Len(); i++ {
+		service, _ := dps.At(i).Attributes().Get(serviceNameKey)
+		fp, ok := dps.At(i).Attributes().Get(exceptionFingerprintKey)
+		require.True(t, ok)
+		fingerprints[service.Str()] = fp.Str()
+	}
+	assert.NotEqual(t, fingerprints["service-a"], fingerprints["service-b"])
+}
+
 func BenchmarkConnectorConsumeTraces(b *testing.B) {
 	msink := &consumertest.MetricsSink{}
 
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// dedupKey identifies the exceptions of a fingerprint thrown by a service, so that the same
+// exception thrown by several services is deduplicated for each of them.
+type dedupKey struct {
+	service     string
+	fingerprint string
+}
+
+// fingerprintState tracks the exceptions of a fingerprint.
+type fingerprintState struct {
+	firstSeen pcommon.Timestamp
+	lastSeen  time.Time
+
+	// pending is the number of exceptions since the last emitted log, and latest the
+	// log of the latest of these exceptions, with its scope.
+	pending     int
+	latest      plog.LogRecord
+	latestScope pcommon.InstrumentationScope
+}
+
+// deduplicator emits a log for the first exception of a fingerprint of a service, and then the
+// latest log of the following exceptions once per interval, with the number of exceptions since the previous log.
+type deduplicator struct {
+	expiration time.Duration
+
+	mu           sync.Mutex
+	fingerprints map[dedupKey]*fingerprintState
+}
+
+func newDeduplicator(expiration time.Duration) *deduplicator {
+	return &deduplicator{
+		expiration:   expiration,
+		fingerprints: make(map[dedupKey]*fingerprintState),
+	}
+}
+
+// observe records the log of an exception, and returns whether the log is emitted now.
+// Emitted logs are annotated with the deduplication attributes.
+func (d *deduplicator) observe(logRecord plog.LogRecord, scope pcommon.InstrumentationScope, now time.Time) bool {
+	fingerprintAttr, ok := logRecord.Attributes().Get(exceptionFingerprintKey)
+	if !ok {
+		return true
+	}
+	key := dedupKey{fingerprint: fingerprintAttr.Str()}
+	if serviceAttr, ok := logRecord.Attributes().Get(serviceNameKey); ok {
+		key.service = serviceAttr.Str()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	state, ok := d.fingerprints[key]
+	if !ok {
+		d.fingerprints[key] = &fingerprintState{firstSeen: logRecord.Timestamp(), lastSeen: now}
+		annotate(logRecord, logRecord.Timestamp(), 1)
+		return true
+	}
+
+	state.lastSeen = now
+	state.pending++
+	state.latest = plog.NewLogRecord()
+	logRecord.CopyTo(state.latest)
+	state.latestScope = pcommon.NewInstrumentationScope()
+	scope.CopyTo(state.latestScope)
+	return false
+}
+
+// flush returns the logs of the exceptions deduplicated since the previous flush, and forgets the
+// fingerprints which expired.
+func (d *deduplicator) flush(now time.Time) plog.Logs {
+	ld := plog.NewLogs()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, state := range d.fingerprints {
+		if state.pending > 0 {
+			sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+			state.latestScope.CopyTo(sl.Scope())
+			logRecord := sl.LogRecords().AppendEmpty()
+			state.latest.CopyTo(logRecord)
+			annotate(logRecord, state.firstSeen, state.pending)
+			state.pending = 0
+			continue
+		}
+		if now.Sub(state.lastSeen) >= d.expiration {
+			delete(d.fingerprints, key)
+		}
+	}
+	return ld
+}
+
+func annotate(logRecord plog.LogRecord, firstSeen pcommon.Timestamp, count int) {
+	logRecord.Attributes().PutStr(exceptionFirstSeenKey, firstSeen.AsTime().Format(time.RFC3339Nano))
+	logRecord.Attributes().PutInt(exceptionCountKey, int64(count))
+}
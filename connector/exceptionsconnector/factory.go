@@ -7,6 +7,7 @@ package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
@@ -31,6 +32,10 @@ func createDefaultConfig() component.Config {
 			{Name: exceptionTypeKey},
 			{Name: exceptionMessageKey},
 		},
+		Deduplication: DeduplicationConfig{
+			Interval:   time.Minute,
+			Expiration: time.Hour,
+		},
 	}
 }
 
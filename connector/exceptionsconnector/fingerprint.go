@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"encoding/hex"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const causedByPrefix = "Caused by: "
+
+var (
+	// omittedFramesRegexp matches the summary of the frames omitted from Java stack traces.
+	omittedFramesRegexp = regexp.MustCompile(`^\.\.\. \d+ more$`)
+	// variableRegexp matches the parts of the frames which vary between builds and runs of the same code:
+	// memory addresses and offsets, and line numbers.
+	variableRegexp = regexp.MustCompile(`\+?0x[0-9a-fA-F]+|:\d+|line \d+`)
+)
+
+// fingerprint returns a hash identifying the exception of a span event, which is the same for the exceptions
+// of a type thrown from the same code path. The exception type and the frames of the stack trace are hashed,
+// ignoring the messages of the exceptions, line numbers and memory addresses. maxFrames limits the number of
+// frames hashed, from the top of the stack trace, zero hashing all of them.
+func fingerprint(eventAttrs pcommon.Map, maxFrames int) string {
+	excType := getValue(eventAttrs, exceptionTypeKey)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(excType))
+
+	lines := strings.Split(getValue(eventAttrs, exceptionStacktraceKey), "\n")
+	frames := 0
+	// The first line holds the type and the message of the exception, or a header.
+	for _, line := range lines[1:] {
+		if maxFrames > 0 && frames == maxFrames {
+			break
+		}
+		frame := normalizeFrame(line, excType)
+		if frame == "" {
+			continue
+		}
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(frame))
+		frames++
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalizeFrame returns the frame of a line of a stack trace without the parts varying for a code path,
+// or an empty string if the line is not a frame.
+func normalizeFrame(line string, excType string) string {
+	frame := strings.TrimSpace(line)
+	switch {
+	case frame == "" || omittedFramesRegexp.MatchString(frame):
+		return ""
+	case excType != "" && isExceptionLine(frame, excType):
+		// The type and message of the exception, e.g. the last line of Python tracebacks
+		return ""
+	case strings.HasPrefix(frame, causedByPrefix):
+		// Keep the type of the chained exception, without its message
+		causeType, _, _ := strings.Cut(strings.TrimPrefix(frame, causedByPrefix), ":")
+		return causedByPrefix + causeType
+	}
+	return strings.TrimSpace(variableRegexp.ReplaceAllString(frame, ""))
+}
+
+// isExceptionLine reports whether the line starts with the type of the exception, qualified or not.
+func isExceptionLine(line string, excType string) bool {
+	if strings.HasPrefix(line, excType) {
+		return true
+	}
+	if i := strings.LastIndexAny(excType, ".:"); i >= 0 && i < len(excType)-1 {
+		return strings.HasPrefix(line, excType[i+1:]+":")
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func exceptionAttrs(excType, stacktrace string) pcommon.Map {
+	attrs := pcommon.NewMap()
+	attrs.PutStr(exceptionTypeKey, excType)
+	attrs.PutStr(exceptionStacktraceKey, stacktrace)
+	return attrs
+}
+
+func TestFingerprint(t *testing.T) {
+	javaTrace := `java.lang.IllegalStateException: order 42 not found
+	at com.example.OrderService.get(OrderService.java:%s)
+	at com.example.OrderController.handle(OrderController.java:17)
+Caused by: java.sql.SQLException: connection %s refused
+	at com.example.Db.query(Db.java:99)
+	... 12 more`
+	pythonTrace := `Traceback (most recent call last):
+  File "/app/main.py", line %s, in handle
+    order = get_order(order_id)
+ValueError: order %s not found`
+	goTrace := `goroutine 1 [running]:
+main.getOrder(0xc0000%s, 0x%s)
+	/app/main.go:12 +0x1d
+main.main()
+	/app/main.go:20 +0x25`
+
+	for _, tc := range []struct {
+		name       string
+		excType    string
+		stacktrace string
+	}{
+		{name: "java", excType: "java.lang.IllegalStateException", stacktrace: javaTrace},
+		{name: "python", excType: "ValueError", stacktrace: pythonTrace},
+		{name: "go", excType: "*errors.errorString", stacktrace: goTrace},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := fingerprint(exceptionAttrs(tc.excType, fmt.Sprintf(tc.stacktrace, "10", "1")), 0)
+			assert.Len(t, fp, 16)
+
+			// Line numbers, addresses and messages don't change the fingerprint
+			assert.Equal(t, fp, fingerprint(exceptionAttrs(tc.excType, fmt.Sprintf(tc.stacktrace, "11", "2")), 0))
+
+			// Exceptions of another type have another fingerprint
+			assert.NotEqual(t, fp, fingerprint(exceptionAttrs("OtherException", fmt.Sprintf(tc.stacktrace, "10", "1")), 0))
+		})
+	}
+
+	// Another code path has another fingerprint
+	assert.NotEqual(t,
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", fmt.Sprintf(javaTrace, "10", "1")), 0),
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", "java.lang.IllegalStateException: order 42 not found\n\tat com.example.OrderService.list(OrderService.java:10)"), 0))
+}
+
+func TestFingerprintMaxFrames(t *testing.T) {
+	trace := "java.lang.IllegalStateException: failure\n\tat com.example.A.a(A.java:1)\n\tat com.example.B.b(B.java:2)"
+	otherCaller := "java.lang.IllegalStateException: failure\n\tat com.example.A.a(A.java:1)\n\tat com.example.C.c(C.java:3)"
+
+	assert.NotEqual(t,
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", trace), 0),
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", otherCaller), 0))
+	assert.Equal(t,
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", trace), 1),
+		fingerprint(exceptionAttrs("java.lang.IllegalStateException", otherCaller), 1))
+}
+
+func TestNormalizeFrame(t *testing.T) {
+	for _, tc := range []struct {
+		line     string
+		excType  string
+		expected string
+	}{
+		{line: "\tat com.example.A.a(A.java:12)", expected: "at com.example.A.a(A.java)"},
+		{line: `  File "/app/main.py", line 10, in handle`, expected: `File "/app/main.py", , in handle`},
+		{line: "\t/app/main.go:12 +0x1d", expected: "/app/main.go"},
+		{line: "Caused by: java.sql.SQLException: refused", expected: "Caused by: java.sql.SQLException"},
+		{line: "\t... 12 more", expected: ""},
+		{line: "   ", expected: ""},
+		{line: "ValueError: order 1 not found", excType: "ValueError", expected: ""},
+		{line: "ValueError: order 1 not found", excType: "builtins.ValueError", expected: ""},
+	} {
+		t.Run(tc.line, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeFrame(tc.line, tc.excType))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoPriorityLevels   = errors.New("at least one priority level must be configured")
+	errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
+)
+
+// Config defines configuration for the Failover connector.
+type Config struct {
+	// PriorityLevels is the list of levels of pipelines, from the highest to the lowest priority.
+	// The data is sent to all the pipelines of the highest priority level which is healthy.
+	// Required.
+	PriorityLevels [][]component.ID `mapstructure:"priority_levels"`
+
+	// ProbeInterval is how often the data is sent to an unhealthy level, to check whether it recovered.
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+
+	// StabilizationWindow is how long an unhealthy level must keep succeeding the probes before the
+	// connector returns to it. Zero returns to a level after its first successful probe.
+	StabilizationWindow time.Duration `mapstructure:"stabilization_window"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	if len(c.PriorityLevels) == 0 {
+		return errNoPriorityLevels
+	}
+	seen := make(map[component.ID]int)
+	for i, level := range c.PriorityLevels {
+		if len(level) == 0 {
+			return fmt.Errorf("priority level %d has no pipelines", i)
+		}
+		for _, id := range level {
+			if j, ok := seen[id]; ok {
+				return fmt.Errorf("pipeline %q is in priority levels %d and %d", id, j, i)
+			}
+			seen[id] = i
+		}
+	}
+	if c.ProbeInterval <= 0 {
+		return errors.New("probe_interval must be positive")
+	}
+	if c.StabilizationWindow < 0 {
+		return errors.New("stabilization_window must not be negative")
+	}
+	return nil
+}
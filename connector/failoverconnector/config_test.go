@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	primary := component.NewIDWithName(component.DataTypeTraces, "primary")
+	secondary := component.NewIDWithName(component.DataTypeTraces, "secondary")
+	tertiary := component.NewIDWithName(component.DataTypeTraces, "tertiary")
+	backup := component.NewIDWithName(component.DataTypeTraces, "backup")
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				PriorityLevels:      [][]component.ID{{primary}},
+				ProbeInterval:       defaultProbeInterval,
+				StabilizationWindow: defaultStabilizationWindow,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				PriorityLevels:      [][]component.ID{{primary}, {secondary, tertiary}, {backup}},
+				ProbeInterval:       time.Minute,
+				StabilizationWindow: 5 * time.Minute,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_levels"),
+			expectedErr: "at least one priority level must be configured",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "empty_level"),
+			expectedErr: "priority level 1 has no pipelines",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicate_pipeline"),
+			expectedErr: `pipeline "traces/primary" is in priority levels 0 and 1`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_probe_interval"),
+			expectedErr: "probe_interval must be positive",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "negative_stabilization_window"),
+			expectedErr: "stabilization_window must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTracesFailover(t *testing.T) {
+	primary := component.NewIDWithName(component.DataTypeTraces, "primary")
+	backup := component.NewIDWithName(component.DataTypeTraces, "backup")
+	cfg := createDefaultConfig().(*Config)
+	cfg.PriorityLevels = [][]component.ID{{primary}, {backup}}
+
+	router := testRouter{primary: &toggleConsumer{}, backup: &toggleConsumer{}}
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, 1, router[primary].consumed)
+
+	router[primary].err = errors.New("primary down")
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, 1, router[primary].consumed)
+	assert.Equal(t, 2, router[backup].consumed)
+}
+
+func TestMetricsFailover(t *testing.T) {
+	primary := component.NewIDWithName(component.DataTypeMetrics, "primary")
+	backup := component.NewIDWithName(component.DataTypeMetrics, "backup")
+	cfg := createDefaultConfig().(*Config)
+	cfg.PriorityLevels = [][]component.ID{{primary}, {backup}}
+
+	var primarySink, backupSink consumertest.MetricsSink
+	router := connectortest.NewMetricsRouter(
+		connectortest.WithMetricsSink(primary, &primarySink),
+		connectortest.WithMetricsSink(backup, &backupSink),
+	)
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Metrics))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, primarySink.AllMetrics(), 1)
+	assert.Empty(t, backupSink.AllMetrics())
+}
+
+func TestLogsFailover(t *testing.T) {
+	primary := component.NewIDWithName(component.DataTypeLogs, "primary")
+	backup := component.NewIDWithName(component.DataTypeLogs, "backup")
+	cfg := createDefaultConfig().(*Config)
+	cfg.PriorityLevels = [][]component.ID{{primary}, {backup}}
+
+	var primarySink, backupSink consumertest.LogsSink
+	router := connectortest.NewLogsRouter(
+		connectortest.WithLogsSink(primary, &primarySink),
+		connectortest.WithLogsSink(backup, &backupSink),
+	)
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Logs))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, primarySink.AllLogs(), 1)
+	assert.Empty(t, backupSink.AllLogs())
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.EqualError(t, cfg.(*Config).Validate(), "at least one priority level must be configured")
+}
+
+func TestCreateConnectors(t *testing.T) {
+	primary := component.NewIDWithName(component.DataTypeTraces, "primary")
+	backup := component.NewIDWithName(component.DataTypeTraces, "backup")
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.PriorityLevels = [][]component.ID{{primary}, {backup}}
+
+	router := connectortest.NewTracesRouter(
+		connectortest.WithNopTraces(primary),
+		connectortest.WithNopTraces(backup),
+	)
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+
+	_, err = NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, errUnexpectedConsumer)
+
+	cfg.PriorityLevels = [][]component.ID{{primary}, {component.NewIDWithName(component.DataTypeTraces, "unknown")}}
+	_, err = NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Traces))
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// levelState is the health of a priority level.
+type levelState struct {
+	healthy bool
+	// nextProbe is when the data is next sent to the level, while it is unhealthy.
+	nextProbe time.Time
+	// recoveringSince is when the level started succeeding the probes, zero if the latest probe failed.
+	recoveringSince time.Time
+}
+
+// failoverRouter sends the data to the consumer of the highest priority level which is healthy. A level
+// becomes unhealthy when it fails to consume the data, which is then sent to the next level. Unhealthy
+// levels are probed with the data on an interval, and become healthy again after succeeding the probes
+// for the stabilization window.
+type failoverRouter[C any] struct {
+	id                  component.ID
+	signal              component.DataType
+	logger              *zap.Logger
+	probeInterval       time.Duration
+	stabilizationWindow time.Duration
+	// now is replaced by tests.
+	now func() time.Time
+
+	levels []C
+
+	mu          sync.Mutex
+	states      []levelState
+	activeLevel int
+}
+
+func newFailoverRouter[C any](
+	id component.ID,
+	signal component.DataType,
+	cfg *Config,
+	consumerFn func(...component.ID) (C, error),
+	logger *zap.Logger,
+) (*failoverRouter[C], error) {
+	f := &failoverRouter[C]{
+		id:                  id,
+		signal:              signal,
+		logger:              logger,
+		probeInterval:       cfg.ProbeInterval,
+		stabilizationWindow: cfg.StabilizationWindow,
+		now:                 time.Now,
+		states:              make([]levelState, len(cfg.PriorityLevels)),
+	}
+	for _, pipelineIDs := range cfg.PriorityLevels {
+		c, err := consumerFn(pipelineIDs...)
+		if err != nil {
+			return nil, err
+		}
+		f.levels = append(f.levels, c)
+	}
+	for i := range f.states {
+		f.states[i].healthy = true
+	}
+	f.recordActiveLevel()
+	return f, nil
+}
+
+// consume sends the data to the levels with the consume function, in the order of priority, until one
+// of them succeeds. It returns the errors of the levels which failed if none of them succeeded.
+func (f *failoverRouter[C]) consume(consume func(C) error) error {
+	var errs error
+	for i, level := range f.levels {
+		if !f.shouldTry(i) {
+			continue
+		}
+		err := consume(level)
+		f.report(i, err)
+		if err == nil {
+			return nil
+		}
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+// shouldTry returns whether the data is sent to a level: healthy levels always are, unhealthy levels only
+// when they are due for a probe. The lowest priority level is always tried when reached, so that
+// the data is not dropped without an attempt when all levels are unhealthy.
+func (f *failoverRouter[C]) shouldTry(level int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := &f.states[level]
+	if state.healthy || level == len(f.states)-1 {
+		return true
+	}
+	now := f.now()
+	if now.Before(state.nextProbe) {
+		return false
+	}
+	// Concurrent requests are not sent to the level until the probe completes.
+	state.nextProbe = now.Add(f.probeInterval)
+	return true
+}
+
+// report updates the health of a level with the result of sending data to it.
+func (f *failoverRouter[C]) report(level int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := &f.states[level]
+	now := f.now()
+	switch {
+	case err != nil:
+		if state.healthy {
+			f.logger.Warn("Priority level failed, falling back to the next level", zap.Int("level", level), zap.Error(err))
+		}
+		state.healthy = false
+		state.recoveringSince = time.Time{}
+		state.nextProbe = now.Add(f.probeInterval)
+	case !state.healthy:
+		if state.recoveringSince.IsZero() {
+			state.recoveringSince = now
+		}
+		if now.Sub(state.recoveringSince) >= f.stabilizationWindow {
+			f.logger.Info("Priority level recovered", zap.Int("level", level))
+			state.healthy = true
+			state.recoveringSince = time.Time{}
+		}
+	}
+	f.updateActiveLevel()
+}
+
+// updateActiveLevel sets the active level to the highest priority level which is healthy, or to the lowest
+// priority level when none is. The caller must hold the lock.
+func (f *failoverRouter[C]) updateActiveLevel() {
+	active := len(f.states) - 1
+	for i, state := range f.states {
+		if state.healthy {
+			active = i
+			break
+		}
+	}
+	if active == f.activeLevel {
+		return
+	}
+	f.activeLevel = active
+	f.recordActiveLevel(mLevelChanges.M(1))
+}
+
+// recordActiveLevel records the active level, along with the given measurements.
+func (f *failoverRouter[C]) recordActiveLevel(ms ...stats.Measurement) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{
+			tag.Upsert(connectorTagKey, f.id.String()),
+			tag.Upsert(signalTagKey, string(f.signal)),
+		},
+		append(ms, mActiveLevel.M(int64(f.activeLevel)))...,
+	)
+}
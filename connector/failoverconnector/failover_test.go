@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// toggleConsumer counts the traces it consumes, and fails while err is set.
+type toggleConsumer struct {
+	err      error
+	consumed int
+}
+
+func (c *toggleConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (c *toggleConsumer) ConsumeTraces(context.Context, ptrace.Traces) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.consumed++
+	return nil
+}
+
+// testRouter implements connector.TracesRouter with the given consumers.
+type testRouter map[component.ID]*toggleConsumer
+
+func (r testRouter) Consumer(ids ...component.ID) (consumer.Traces, error) {
+	if len(ids) != 1 {
+		return nil, errors.New("expected a single pipeline per level")
+	}
+	c, ok := r[ids[0]]
+	if !ok {
+		return nil, errors.New("unknown pipeline")
+	}
+	return c, nil
+}
+
+func (r testRouter) PipelineIDs() []component.ID {
+	ids := make([]component.ID, 0, len(r))
+	for id := range r {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r testRouter) ConsumeTraces(context.Context, ptrace.Traces) error {
+	return nil
+}
+
+func (r testRouter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestFailover(t *testing.T, levels int, stabilizationWindow time.Duration) (*failoverRouter[consumer.Traces], []*toggleConsumer, *testClock) {
+	router := testRouter{}
+	cfg := &Config{ProbeInterval: time.Minute, StabilizationWindow: stabilizationWindow}
+	consumers := make([]*toggleConsumer, levels)
+	for i := range consumers {
+		id := component.NewIDWithName(component.DataTypeTraces, string(rune('a'+i)))
+		consumers[i] = &toggleConsumer{}
+		router[id] = consumers[i]
+		cfg.PriorityLevels = append(cfg.PriorityLevels, []component.ID{id})
+	}
+	require.NoError(t, cfg.Validate())
+
+	f, err := newFailoverRouter(component.NewID("failover"), component.DataTypeTraces, cfg, router.Consumer, zap.NewNop())
+	require.NoError(t, err)
+	clock := &testClock{now: time.Unix(1000, 0)}
+	f.now = clock.Now
+	return f, consumers, clock
+}
+
+func consumeTraces(f *failoverRouter[consumer.Traces]) error {
+	return f.consume(func(c consumer.Traces) error {
+		return c.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	})
+}
+
+func TestFailoverFallsBackThroughLevels(t *testing.T) {
+	f, consumers, clock := newTestFailover(t, 3, 0)
+
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, consumers[0].consumed)
+	assert.Equal(t, 0, f.activeLevel)
+
+	consumers[0].err = errors.New("level 0 down")
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, consumers[1].consumed)
+	assert.Equal(t, 1, f.activeLevel)
+
+	consumers[1].err = errors.New("level 1 down")
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, consumers[2].consumed)
+	assert.Equal(t, 2, f.activeLevel)
+
+	// The unhealthy levels are not tried before their next probe
+	clock.now = clock.now.Add(30 * time.Second)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 2, consumers[2].consumed)
+
+	// The lowest priority level is always tried
+	consumers[2].err = errors.New("level 2 down")
+	err := consumeTraces(f)
+	assert.EqualError(t, err, "level 2 down")
+	assert.Equal(t, 2, f.activeLevel)
+
+	// The probes of all the levels fail
+	clock.now = clock.now.Add(time.Minute)
+	err = consumeTraces(f)
+	assert.EqualError(t, err, "level 0 down\nlevel 1 down\nlevel 2 down")
+}
+
+func TestFailoverProbesAndReturns(t *testing.T) {
+	f, consumers, clock := newTestFailover(t, 3, 0)
+
+	consumers[0].err = errors.New("level 0 down")
+	consumers[1].err = errors.New("level 1 down")
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 2, f.activeLevel)
+
+	// Level 1 recovers first, and the connector returns to it on its next probe
+	consumers[1].err = nil
+	clock.now = clock.now.Add(time.Minute)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, consumers[1].consumed)
+	assert.Equal(t, 1, f.activeLevel)
+
+	// Level 0 is still probed on its own interval
+	clock.now = clock.now.Add(30 * time.Second)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 2, consumers[1].consumed)
+
+	consumers[0].err = nil
+	clock.now = clock.now.Add(30 * time.Second)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, consumers[0].consumed)
+	assert.Equal(t, 0, f.activeLevel)
+}
+
+func TestFailoverStabilizationWindow(t *testing.T) {
+	f, consumers, clock := newTestFailover(t, 2, 3*time.Minute)
+
+	consumers[0].err = errors.New("level 0 down")
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, f.activeLevel)
+
+	// The level succeeds its probes, but stays unhealthy for the stabilization window
+	consumers[0].err = nil
+	for i := 0; i < 3; i++ {
+		clock.now = clock.now.Add(time.Minute)
+		require.NoError(t, consumeTraces(f))
+		assert.Equal(t, i+1, consumers[0].consumed)
+		assert.Equal(t, 1, f.activeLevel)
+
+		// Data between the probes is sent to the active level
+		require.NoError(t, consumeTraces(f))
+		assert.Equal(t, i+2, consumers[1].consumed)
+	}
+
+	// A failed probe restarts the stabilization window
+	consumers[0].err = errors.New("level 0 down")
+	clock.now = clock.now.Add(time.Minute)
+	require.NoError(t, consumeTraces(f))
+	consumers[0].err = nil
+	clock.now = clock.now.Add(time.Minute)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 1, f.activeLevel)
+
+	clock.now = clock.now.Add(3 * time.Minute)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 0, f.activeLevel)
+	require.NoError(t, consumeTraces(f))
+	assert.Equal(t, 6, consumers[0].consumed)
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logsFailover struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	failover *failoverRouter[consumer.Logs]
+}
+
+func newLogsConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	logs consumer.Logs,
+) (*logsFailover, error) {
+	cfg := config.(*Config)
+
+	r, ok := logs.(connector.LogsRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	f, err := newFailoverRouter(set.ID, component.DataTypeLogs, cfg, r.Consumer, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &logsFailover{failover: f}, nil
+}
+
+func (*logsFailover) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return f.failover.consume(func(c consumer.Logs) error {
+		return c.ConsumeLogs(ctx, ld)
+	})
+}
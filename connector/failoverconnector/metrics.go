@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type metricsFailover struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	failover *failoverRouter[consumer.Metrics]
+}
+
+func newMetricsConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	metrics consumer.Metrics,
+) (*metricsFailover, error) {
+	cfg := config.(*Config)
+
+	r, ok := metrics.(connector.MetricsRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	f, err := newFailoverRouter(set.ID, component.DataTypeMetrics, cfg, r.Consumer, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsFailover{failover: f}, nil
+}
+
+func (*metricsFailover) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return f.failover.consume(func(c consumer.Metrics) error {
+		return c.ConsumeMetrics(ctx, md)
+	})
+}
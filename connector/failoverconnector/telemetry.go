@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mActiveLevel  = stats.Int64("failover_active_level", "Priority level the data is currently sent to, 0 being the highest priority", stats.UnitDimensionless)
+	mLevelChanges = stats.Int64("failover_level_changes", "Number of times the active priority level changed", stats.UnitDimensionless)
+
+	connectorTagKey = tag.MustNewKey("connector")
+	signalTagKey    = tag.MustNewKey("signal")
+)
+
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mActiveLevel.Name(),
+			Measure:     mActiveLevel,
+			Description: mActiveLevel.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{connectorTagKey, signalTagKey},
+		},
+		{
+			Name:        mLevelChanges.Name(),
+			Measure:     mLevelChanges,
+			Description: mLevelChanges.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{connectorTagKey, signalTagKey},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type tracesFailover struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	failover *failoverRouter[consumer.Traces]
+}
+
+func newTracesConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	traces consumer.Traces,
+) (*tracesFailover, error) {
+	cfg := config.(*Config)
+
+	r, ok := traces.(connector.TracesRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	f, err := newFailoverRouter(set.ID, component.DataTypeTraces, cfg, r.Consumer, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesFailover{failover: f}, nil
+}
+
+func (*tracesFailover) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (f *tracesFailover) ConsumeTraces(ctx context.Context, t ptrace.Traces) error {
+	return f.failover.consume(func(c consumer.Traces) error {
+		return c.ConsumeTraces(ctx, t)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var noAttributes = [16]byte{}
+
+// series holds the values extracted for a set of attributes of a metric.
+type series struct {
+	attrs pcommon.Map
+
+	count        uint64
+	sum          float64
+	min, max     float64
+	bucketCounts []uint64
+
+	// last value of a gauge, with the timestamp of its log record
+	last          float64
+	lastTimestamp pcommon.Timestamp
+}
+
+// aggregator aggregates the values extracted from the log records of a resource.
+type aggregator struct {
+	metricDefs map[string]metricDef
+	series     map[string]map[[16]byte]*series
+}
+
+func newAggregator(metricDefs map[string]metricDef) *aggregator {
+	return &aggregator{
+		metricDefs: metricDefs,
+		series:     make(map[string]map[[16]byte]*series, len(metricDefs)),
+	}
+}
+
+func (a *aggregator) record(metricName string, attrs pcommon.Map, value float64, timestamp pcommon.Timestamp) {
+	md := a.metricDefs[metricName]
+	if _, ok := a.series[metricName]; !ok {
+		a.series[metricName] = make(map[[16]byte]*series)
+	}
+
+	key := noAttributes
+	if attrs.Len() > 0 {
+		key = pdatautil.MapHash(attrs)
+	}
+
+	s, ok := a.series[metricName][key]
+	if !ok {
+		s = &series{attrs: attrs, min: value, max: value}
+		if md.typ == MetricTypeHistogram {
+			s.bucketCounts = make([]uint64, len(md.bounds)+1)
+		}
+		a.series[metricName][key] = s
+	}
+
+	s.count++
+	s.sum += value
+	if value < s.min {
+		s.min = value
+	}
+	if value > s.max {
+		s.max = value
+	}
+	if s.bucketCounts != nil {
+		// Buckets include their upper bound
+		s.bucketCounts[sort.SearchFloat64s(md.bounds, value)]++
+	}
+	if timestamp >= s.lastTimestamp {
+		s.last = value
+		s.lastTimestamp = timestamp
+	}
+}
+
+func (a *aggregator) appendMetricsTo(metricSlice pmetric.MetricSlice, timestamp pcommon.Timestamp) {
+	for name, md := range a.metricDefs {
+		if len(a.series[name]) == 0 {
+			continue
+		}
+		metric := metricSlice.AppendEmpty()
+		metric.SetName(name)
+		metric.SetDescription(md.desc)
+		metric.SetUnit(md.unit)
+
+		switch md.typ {
+		case MetricTypeGauge:
+			gauge := metric.SetEmptyGauge()
+			for _, s := range a.series[name] {
+				dp := gauge.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetDoubleValue(s.last)
+				dp.SetTimestamp(s.lastTimestamp)
+			}
+		case MetricTypeCounter:
+			sum := metric.SetEmptySum()
+			// Negative values are not recorded, so a value accumulated downstream is monotonic
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, s := range a.series[name] {
+				dp := sum.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetDoubleValue(s.sum)
+				dp.SetTimestamp(timestamp)
+			}
+		case MetricTypeHistogram:
+			histogram := metric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, s := range a.series[name] {
+				dp := histogram.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetCount(s.count)
+				dp.SetSum(s.sum)
+				dp.SetMin(s.min)
+				dp.SetMax(s.max)
+				dp.ExplicitBounds().FromRaw(md.bounds)
+				dp.BucketCounts().FromRaw(s.bucketCounts)
+				dp.SetTimestamp(timestamp)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	// MetricTypeGauge emits the last value extracted in a batch.
+	MetricTypeGauge = "gauge"
+	// MetricTypeCounter emits the sum of the values extracted in a batch.
+	MetricTypeCounter = "counter"
+	// MetricTypeHistogram emits the distribution of the values extracted in a batch.
+	MetricTypeHistogram = "histogram"
+)
+
+// defaultHistogramBuckets are the default explicit bucket boundaries of the OpenTelemetry SDKs.
+var defaultHistogramBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Config for the connector
+type Config struct {
+	// Logs defines the metrics extracted from log records, by metric name.
+	Logs map[string]MetricInfo `mapstructure:"logs"`
+}
+
+// MetricInfo defines a metric extracted from log records.
+type MetricInfo struct {
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Type of the metric, one of "gauge", "counter" or "histogram".
+	Type string `mapstructure:"type"`
+	// Value is the OTTL value expression evaluated against each log record, e.g. `attributes["duration"]`.
+	// Strings and booleans are converted to numbers. Log records without value are not recorded.
+	Value string `mapstructure:"value"`
+	// Conditions select the log records the value is extracted from. Conditions are ORed together.
+	Conditions []string          `mapstructure:"conditions"`
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+	// Buckets are the explicit bucket boundaries of a histogram.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+type AttributeConfig struct {
+	Key          string `mapstructure:"key"`
+	DefaultValue string `mapstructure:"default_value"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if len(c.Logs) == 0 {
+		return errors.New("no metrics configured")
+	}
+	settings := component.TelemetrySettings{Logger: zap.NewNop()}
+	for name, info := range c.Logs {
+		if name == "" {
+			return errors.New("logs: metric name missing")
+		}
+		switch info.Type {
+		case MetricTypeGauge, MetricTypeCounter:
+			if len(info.Buckets) > 0 {
+				return fmt.Errorf("logs: metric %q: buckets are only supported by histograms", name)
+			}
+		case MetricTypeHistogram:
+			for i := 1; i < len(info.Buckets); i++ {
+				if info.Buckets[i] <= info.Buckets[i-1] {
+					return fmt.Errorf("logs: metric %q: buckets must be strictly increasing", name)
+				}
+			}
+		default:
+			return fmt.Errorf("logs: metric %q: type must be %q, %q or %q: %q", name, MetricTypeGauge, MetricTypeCounter, MetricTypeHistogram, info.Type)
+		}
+		if info.Value == "" {
+			return fmt.Errorf("logs: metric %q: value missing", name)
+		}
+		if _, err := newValueExpr(info.Value, settings); err != nil {
+			return fmt.Errorf("logs value: metric %q: %w", name, err)
+		}
+		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, settings); err != nil {
+			return fmt.Errorf("logs condition: metric %q: %w", name, err)
+		}
+		for _, attr := range info.Attributes {
+			if attr.Key == "" {
+				return fmt.Errorf("logs attributes: metric %q: attribute key missing", name)
+			}
+		}
+	}
+	return nil
+}
+
+// buckets returns the bucket boundaries of a histogram.
+func (i *MetricInfo) buckets() []float64 {
+	if len(i.Buckets) == 0 {
+		return defaultHistogramBuckets
+	}
+	return i.Buckets
+}
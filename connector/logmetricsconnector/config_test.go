@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Logs: map[string]MetricInfo{
+					"http.server.request.duration": {
+						Description: "The duration of the requests served.",
+						Unit:        "ms",
+						Type:        MetricTypeHistogram,
+						Value:       `attributes["duration_ms"]`,
+						Conditions:  []string{`attributes["event.name"] == "access"`},
+						Attributes:  []AttributeConfig{{Key: "http.route", DefaultValue: "unknown"}},
+						Buckets:     []float64{10, 100, 1000},
+					},
+					"http.server.response.size": {
+						Type:  MetricTypeCounter,
+						Value: `attributes["bytes"]`,
+					},
+					"queue.depth": {
+						Type:  MetricTypeGauge,
+						Value: `Double(body)`,
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_metrics"),
+			expectedErr: "no metrics configured",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_type"),
+			expectedErr: `logs: metric "my.metric": type must be "gauge", "counter" or "histogram": "summary"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_value"),
+			expectedErr: `logs: metric "my.metric": value missing`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_value"),
+			expectedErr: `logs value: metric "my.metric": invalid value`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_condition"),
+			expectedErr: `logs condition: metric "my.metric"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "unsorted_buckets"),
+			expectedErr: `logs: metric "my.metric": buckets must be strictly increasing`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "gauge_buckets"),
+			expectedErr: `logs: metric "my.metric": buckets are only supported by histograms`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_attribute_key"),
+			expectedErr: `logs attributes: metric "my.metric": attribute key missing`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const scopeName = "otelcol/logmetricsconnector"
+
+type metricDef struct {
+	desc      string
+	unit      string
+	typ       string
+	condition expr.BoolExpr[ottllog.TransformContext]
+	value     *valueExpr
+	attrs     []AttributeConfig
+	bounds    []float64
+}
+
+// logMetrics extracts values from log records and emits them as metrics onto a metrics pipeline.
+type logMetrics struct {
+	metricsConsumer consumer.Metrics
+	logger          *zap.Logger
+	metricDefs      map[string]metricDef
+
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (c *logMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logMetrics) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var multiError error
+	md := pmetric.NewMetrics()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLog := ld.ResourceLogs().At(i)
+		agg := newAggregator(c.metricDefs)
+
+		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLog.ScopeLogs().At(j)
+
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+
+				lCtx := ottllog.NewTransformContext(logRecord, scopeLogs.Scope(), resourceLog.Resource())
+				multiError = errors.Join(multiError, c.update(ctx, agg, logRecord, lCtx))
+			}
+		}
+
+		if len(agg.series) == 0 {
+			continue // don't add an empty resource
+		}
+		resourceMetrics := md.ResourceMetrics().AppendEmpty()
+		resourceLog.Resource().Attributes().CopyTo(resourceMetrics.Resource().Attributes())
+		scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+		scopeMetrics.Scope().SetName(scopeName)
+		agg.appendMetricsTo(scopeMetrics.Metrics(), now)
+	}
+	if multiError != nil {
+		return multiError
+	}
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return c.metricsConsumer.ConsumeMetrics(ctx, md)
+}
+
+// update records the values extracted from a log record by the matching metrics.
+func (c *logMetrics) update(ctx context.Context, agg *aggregator, logRecord plog.LogRecord, lCtx ottllog.TransformContext) error {
+	var multiError error
+	for name, md := range c.metricDefs {
+		// No conditions, so match all.
+		if md.condition != nil {
+			match, err := md.condition.Eval(ctx, lCtx)
+			if err != nil {
+				multiError = errors.Join(multiError, err)
+				continue
+			}
+			if !match {
+				continue
+			}
+		}
+
+		value, ok, err := md.value.extract(ctx, lCtx)
+		if err != nil {
+			multiError = errors.Join(multiError, fmt.Errorf("metric %q: %w", name, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.IsNaN(value) || (md.typ == MetricTypeCounter && value < 0) {
+			c.logger.Debug("Ignoring invalid value", zap.String("metric", name), zap.Float64("value", value))
+			continue
+		}
+
+		attrs := pcommon.NewMap()
+		for _, attr := range md.attrs {
+			if attrVal, ok := logRecord.Attributes().Get(attr.Key); ok {
+				attrs.PutStr(attr.Key, attrVal.AsString())
+			} else if attr.DefaultValue != "" {
+				attrs.PutStr(attr.Key, attr.DefaultValue)
+			}
+		}
+
+		// Missing necessary attributes to be recorded
+		if attrs.Len() != len(md.attrs) {
+			continue
+		}
+
+		agg.record(name, attrs, value, recordTimestamp(logRecord))
+	}
+	return multiError
+}
+
+// recordTimestamp returns the timestamp of a log record, or its observed timestamp if it is unset.
+func recordTimestamp(logRecord plog.LogRecord) pcommon.Timestamp {
+	if logRecord.Timestamp() != 0 {
+		return logRecord.Timestamp()
+	}
+	if logRecord.ObservedTimestamp() != 0 {
+		return logRecord.ObservedTimestamp()
+	}
+	return pcommon.NewTimestampFromTime(time.Now())
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestConnector(t *testing.T, cfg *Config) (*logMetrics, *consumertest.MetricsSink) {
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	return conn.(*logMetrics), sink
+}
+
+func newTestLogs() (plog.Logs, plog.LogRecordSlice) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "frontend")
+	return ld, rl.ScopeLogs().AppendEmpty().LogRecords()
+}
+
+func findMetric(t *testing.T, md pmetric.Metrics, name string) pmetric.Metric {
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "metric %q", name)
+	return pmetric.Metric{}
+}
+
+func TestLogsToHistogram(t *testing.T) {
+	conn, sink := newTestConnector(t, &Config{
+		Logs: map[string]MetricInfo{
+			"http.server.request.duration": {
+				Description: "The duration of the requests served.",
+				Unit:        "ms",
+				Type:        MetricTypeHistogram,
+				Value:       `attributes["duration_ms"]`,
+				Conditions:  []string{`attributes["event.name"] == "access"`},
+				Attributes:  []AttributeConfig{{Key: "http.route", DefaultValue: "unknown"}},
+				Buckets:     []float64{10, 100, 1000},
+			},
+		},
+	})
+
+	ld, logRecords := newTestLogs()
+	for _, record := range []struct {
+		event    string
+		route    string
+		duration float64
+	}{
+		{"access", "/users", 5},
+		{"access", "/users", 50},
+		{"access", "", 500},
+		{"startup", "/users", 7},
+	} {
+		lr := logRecords.AppendEmpty()
+		lr.Attributes().PutStr("event.name", record.event)
+		if record.route != "" {
+			lr.Attributes().PutStr("http.route", record.route)
+		}
+		lr.Attributes().PutDouble("duration_ms", record.duration)
+	}
+	// No value to extract
+	logRecords.AppendEmpty().Attributes().PutStr("event.name", "access")
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"service.name": "frontend"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rm.ScopeMetrics().At(0).Scope().Name())
+
+	m := findMetric(t, md, "http.server.request.duration")
+	assert.Equal(t, "The duration of the requests served.", m.Description())
+	assert.Equal(t, "ms", m.Unit())
+	require.Equal(t, pmetric.MetricTypeHistogram, m.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+
+	dps := m.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	byRoute := map[string]pmetric.HistogramDataPoint{}
+	for i := 0; i < dps.Len(); i++ {
+		route, _ := dps.At(i).Attributes().Get("http.route")
+		byRoute[route.Str()] = dps.At(i)
+		assert.Equal(t, []float64{10, 100, 1000}, dps.At(i).ExplicitBounds().AsRaw())
+	}
+
+	users := byRoute["/users"]
+	assert.Equal(t, uint64(2), users.Count())
+	assert.Equal(t, 55.0, users.Sum())
+	assert.Equal(t, 5.0, users.Min())
+	assert.Equal(t, 50.0, users.Max())
+	assert.Equal(t, []uint64{1, 1, 0, 0}, users.BucketCounts().AsRaw())
+
+	unknown := byRoute["unknown"]
+	assert.Equal(t, uint64(1), unknown.Count())
+	assert.Equal(t, []uint64{0, 0, 1, 0}, unknown.BucketCounts().AsRaw())
+}
+
+func TestLogsToCounterAndGauge(t *testing.T) {
+	conn, sink := newTestConnector(t, &Config{
+		Logs: map[string]MetricInfo{
+			"http.server.response.size": {
+				Type:  MetricTypeCounter,
+				Value: `attributes["bytes"]`,
+			},
+			"queue.depth": {
+				Type:  MetricTypeGauge,
+				Value: `body`,
+			},
+		},
+	})
+
+	ld, logRecords := newTestLogs()
+	// String values are converted, negative values are not counted
+	logRecords.AppendEmpty().Attributes().PutStr("bytes", "100")
+	logRecords.AppendEmpty().Attributes().PutInt("bytes", 200)
+	logRecords.AppendEmpty().Attributes().PutInt("bytes", -5)
+	// The gauge has the value of the latest log record
+	latest := logRecords.AppendEmpty()
+	latest.Body().SetStr("42")
+	latest.SetTimestamp(2)
+	earlier := logRecords.AppendEmpty()
+	earlier.Body().SetStr("40")
+	earlier.SetTimestamp(1)
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+
+	counter := findMetric(t, md, "http.server.response.size")
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, counter.Sum().AggregationTemporality())
+	require.Equal(t, 1, counter.Sum().DataPoints().Len())
+	assert.Equal(t, 300.0, counter.Sum().DataPoints().At(0).DoubleValue())
+
+	gauge := findMetric(t, md, "queue.depth")
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	require.Equal(t, 1, gauge.Gauge().DataPoints().Len())
+	assert.Equal(t, 42.0, gauge.Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(2), gauge.Gauge().DataPoints().At(0).Timestamp())
+}
+
+func TestLogsWithoutValues(t *testing.T) {
+	conn, sink := newTestConnector(t, &Config{
+		Logs: map[string]MetricInfo{
+			"http.server.response.size": {
+				Type:  MetricTypeCounter,
+				Value: `attributes["bytes"]`,
+			},
+		},
+	})
+
+	ld, logRecords := newTestLogs()
+	logRecords.AppendEmpty().Body().SetStr("no bytes")
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestLogsInvalidValue(t *testing.T) {
+	conn, sink := newTestConnector(t, &Config{
+		Logs: map[string]MetricInfo{
+			"http.server.response.size": {
+				Type:  MetricTypeCounter,
+				Value: `attributes["bytes"]`,
+			},
+		},
+	})
+
+	ld, logRecords := newTestLogs()
+	logRecords.AppendEmpty().Attributes().PutStr("bytes", "many")
+
+	assert.ErrorContains(t, conn.ConsumeLogs(context.Background(), ld), `metric "http.server.response.size"`)
+	assert.Len(t, sink.AllMetrics(), 0)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// valueExpr extracts a numeric value from a log record.
+type valueExpr struct {
+	expression *ottl.ValueExpression[ottllog.TransformContext]
+}
+
+func newValueExpr(value string, settings component.TelemetrySettings) (*valueExpr, error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), settings)
+	if err != nil {
+		return nil, err
+	}
+	expression, err := parser.ParseValueExpression(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	return &valueExpr{expression: expression}, nil
+}
+
+// extract returns the value of the expression for the log record, and false if it has no value.
+func (v *valueExpr) extract(ctx context.Context, tCtx ottllog.TransformContext) (float64, bool, error) {
+	value, err := v.expression.EvalFloat(ctx, tCtx)
+	if err != nil || value == nil {
+		return 0, false, err
+	}
+	return *value, true, nil
+}
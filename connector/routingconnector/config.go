@@ -5,10 +5,13 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
 var (
@@ -16,6 +19,17 @@ var (
 	errNoPipelines        = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
 	errNoTableItems       = errors.New("invalid routing table: the routing table is empty")
+	errDropWithPipelines  = errors.New("invalid route: pipelines defined for a drop route")
+)
+
+// Action is the action taken on the data matched by a route.
+type Action string
+
+const (
+	// ActionRoute sends the data to the pipelines of the route.
+	ActionRoute Action = "route"
+	// ActionDrop discards the data, which isn't sent to any pipeline.
+	ActionDrop Action = "drop"
 )
 
 // Config defines configuration for the Routing processor.
@@ -36,9 +50,22 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// MatchOnce determines whether the data is only sent to the first route it matches, in the order
+	// of the routing table, instead of all of them. It applies to all the routes.
+	// The default value is `false`.
+	MatchOnce bool `mapstructure:"match_once"`
+
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+
+	// Macros are named conditions that can be referenced from the statements of the routes.
+	// Optional.
+	Macros ottl.Macros `mapstructure:"macros"`
+
+	// MacroFiles are YAML files defining macros, shared with other components.
+	// Optional.
+	MacroFiles []string `mapstructure:"macro_files"`
 }
 
 // Validate checks if the processor configuration is valid.
@@ -49,18 +76,37 @@ func (c *Config) Validate() error {
 	}
 
 	// validate that every route has a value for the routing attribute and has
-	// at least one pipeline
+	// at least one pipeline, unless it drops the data
 	for _, item := range c.Table {
 		if len(item.Statement) == 0 {
 			return errEmptyRoute
 		}
 
-		if len(item.Pipelines) == 0 {
-			return errNoPipelines
+		switch item.Action {
+		case "", ActionRoute:
+			if len(item.Pipelines) == 0 {
+				return errNoPipelines
+			}
+		case ActionDrop:
+			if len(item.Pipelines) != 0 {
+				return errDropWithPipelines
+			}
+		default:
+			return fmt.Errorf("invalid route: unknown action %q", item.Action)
 		}
 	}
 
-	return nil
+	_, err := c.loadMacros()
+	return err
+}
+
+// loadMacros returns the macros of the configuration and of the macro files.
+func (c *Config) loadMacros() (ottl.Macros, error) {
+	macros, err := ottl.LoadMacros(c.Macros, c.MacroFiles)
+	if err != nil {
+		return nil, err
+	}
+	return macros, macros.ValidateNames(ottl.FunctionNames(common.Functions[ottlresource.TransformContext]()))
 }
 
 // RoutingTableItem specifies how data should be routed to the different pipelines
@@ -75,4 +121,16 @@ type RoutingTableItem struct {
 	// The routing processor will fail upon the first failure from these pipelines.
 	// Optional.
 	Pipelines []component.ID `mapstructure:"pipelines"`
+
+	// Action is the action taken on the data matching the statement: `route` sends it to the pipelines,
+	// and `drop` discards it, without sending it to the pipelines of any other route, or to the
+	// default pipelines.
+	// The default value is `route`.
+	Action Action `mapstructure:"action"`
+
+	// MatchOnce determines whether the routes following this one in the routing table are evaluated
+	// when the data matches this route. It can be used to route the data matching this route to
+	// its pipelines only, while the other routes fan out.
+	// The default value is `false`.
+	MatchOnce bool `mapstructure:"match_once"`
 }
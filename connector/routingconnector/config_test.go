@@ -38,11 +38,16 @@ func TestLoadConfig(t *testing.T) {
 							component.NewIDWithName(component.DataTypeTraces, "otlp-acme"),
 						},
 					},
+					{
+						Statement: `route() where attributes["X-Tenant"] == "spam"`,
+						Action:    ActionDrop,
+					},
 					{
 						Statement: `route() where attributes["X-Tenant"] == "globex"`,
 						Pipelines: []component.ID{
 							component.NewIDWithName(component.DataTypeTraces, "otlp-globex"),
 						},
+						MatchOnce: true,
 					},
 				},
 			},
@@ -147,6 +152,33 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "invalid route: no pipelines defined",
 		},
+		{
+			name: "drop route with pipelines",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Statement: `route() where attributes["attr"] == "acme"`,
+						Action:    ActionDrop,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid route: pipelines defined for a drop route",
+		},
+		{
+			name: "unknown action",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Statement: `route() where attributes["attr"] == "acme"`,
+						Action:    "forward",
+					},
+				},
+			},
+			error: `invalid route: unknown action "forward"`,
+		},
 		{
 			name: "no routes provided",
 			config: &Config{
@@ -156,6 +188,40 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "invalid routing table: the routing table is empty",
 		},
+		{
+			name: "invalid macro",
+			config: &Config{
+				Macros: ottl.Macros{
+					"is_acme": {Condition: `attributes["attr"] == $name`},
+				},
+				Table: []RoutingTableItem{
+					{
+						Statement: `route() where is_acme()`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `macro "is_acme": unknown parameter "$name"`,
+		},
+		{
+			name: "macro named after a function",
+			config: &Config{
+				Macros: ottl.Macros{
+					"route": {Condition: `attributes["attr"] == "acme"`},
+				},
+				Table: []RoutingTableItem{
+					{
+						Statement: `route() where attributes["attr"] == "acme"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `macro "route": name is already used by a function`,
+		},
 		{
 			name:   "empty config",
 			config: &Config{},
@@ -8,6 +8,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
@@ -18,6 +19,8 @@ import (
 
 // NewFactory returns a ConnectorFactory.
 func NewFactory() connector.Factory {
+	_ = view.Register(metricViews()...)
+
 	return connector.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
@@ -13,7 +13,6 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
@@ -39,10 +38,10 @@ func newLogsConnector(
 	}
 
 	r, err := newRouter(
-		cfg.Table,
-		cfg.DefaultPipelines,
+		cfg,
+		component.DataTypeLogs,
 		lr.Consumer,
-		set.TelemetrySettings)
+		set)
 
 	if err != nil {
 		return nil, err
@@ -71,26 +70,13 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		rlogs := ld.ResourceLogs().At(i)
 		rtx := ottlresource.NewTransformContext(rlogs.Resource())
 
-		noRoutesMatch := true
-		for _, route := range c.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
-			if err != nil {
-				if c.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				c.group(groups, c.router.defaultConsumer, rlogs)
-				continue
-			}
-			if isMatch {
-				noRoutesMatch = false
-				c.group(groups, route.consumer, rlogs)
-			}
-
+		routes, err := c.router.match(ctx, rtx)
+		if err != nil {
+			return err
 		}
-
-		if noRoutesMatch {
-			// no route conditions are matched, add resource logs to default exporters group
-			c.group(groups, c.router.defaultConsumer, rlogs)
+		for _, route := range routes {
+			c.router.recordRouted(ctx, route, logRecordCount(rlogs))
+			c.group(groups, route.consumer, rlogs)
 		}
 	}
 	for consumer, group := range groups {
@@ -114,3 +100,11 @@ func (c *logsConnector) group(
 	logs.CopyTo(group.ResourceLogs().AppendEmpty())
 	groups[consumer] = group
 }
+
+func logRecordCount(rlogs plog.ResourceLogs) int {
+	count := 0
+	for i := 0; i < rlogs.ScopeLogs().Len(); i++ {
+		count += rlogs.ScopeLogs().At(i).LogRecords().Len()
+	}
+	return count
+}
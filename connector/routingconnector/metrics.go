@@ -13,7 +13,6 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
@@ -39,10 +38,10 @@ func newMetricsConnector(
 	}
 
 	r, err := newRouter(
-		cfg.Table,
-		cfg.DefaultPipelines,
+		cfg,
+		component.DataTypeMetrics,
 		mr.Consumer,
-		set.TelemetrySettings)
+		set)
 
 	if err != nil {
 		return nil, err
@@ -71,26 +70,13 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 		rmetrics := md.ResourceMetrics().At(i)
 		rtx := ottlresource.NewTransformContext(rmetrics.Resource())
 
-		noRoutesMatch := true
-		for _, route := range c.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
-			if err != nil {
-				if c.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				c.group(groups, c.router.defaultConsumer, rmetrics)
-				continue
-			}
-			if isMatch {
-				noRoutesMatch = false
-				c.group(groups, route.consumer, rmetrics)
-			}
-
+		routes, err := c.router.match(ctx, rtx)
+		if err != nil {
+			return err
 		}
-
-		if noRoutesMatch {
-			// no route conditions are matched, add resource metrics to default exporters group
-			c.group(groups, c.router.defaultConsumer, rmetrics)
+		for _, route := range routes {
+			c.router.recordRouted(ctx, route, dataPointCount(rmetrics))
+			c.group(groups, route.consumer, rmetrics)
 		}
 	}
 
@@ -115,3 +101,26 @@ func (c *metricsConnector) group(
 	metrics.CopyTo(group.ResourceMetrics().AppendEmpty())
 	groups[consumer] = group
 }
+
+func dataPointCount(rmetrics pmetric.ResourceMetrics) int {
+	count := 0
+	for i := 0; i < rmetrics.ScopeMetrics().Len(); i++ {
+		metrics := rmetrics.ScopeMetrics().At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			metric := metrics.At(j)
+			switch metric.Type() {
+			case pmetric.MetricTypeGauge:
+				count += metric.Gauge().DataPoints().Len()
+			case pmetric.MetricTypeSum:
+				count += metric.Sum().DataPoints().Len()
+			case pmetric.MetricTypeHistogram:
+				count += metric.Histogram().DataPoints().Len()
+			case pmetric.MetricTypeExponentialHistogram:
+				count += metric.ExponentialHistogram().DataPoints().Len()
+			case pmetric.MetricTypeSummary:
+				count += metric.Summary().DataPoints().Len()
+			}
+		}
+	}
+	return count
+}
@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 )
 
 func TestMetricsRegisterConsumersForValidRoute(t *testing.T) {
@@ -244,6 +246,46 @@ func TestMetricsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestMetricsRoutedDataPoints(t *testing.T) {
+	metricsDefault := component.NewIDWithName(component.DataTypeMetrics, "default")
+	cfg := &Config{
+		DefaultPipelines: []component.ID{metricsDefault},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where attributes["X-Tenant"] == "spam"`,
+				Action:    ActionDrop,
+			},
+		},
+	}
+
+	set := connectortest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(metadata.Type, "datapoints")
+	var defaultSink consumertest.MetricsSink
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), set, cfg,
+		connectortest.NewMetricsRouter(
+			connectortest.WithMetricsSink(metricsDefault, &defaultSink),
+		).(consumer.Metrics))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"acme", "spam"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("X-Tenant", tenant)
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+		hist := metrics.AppendEmpty().SetEmptyHistogram()
+		hist.DataPoints().AppendEmpty()
+		hist.DataPoints().AppendEmpty()
+	}
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+
+	assert.Equal(t, 3, defaultSink.DataPointCount())
+	assert.Equal(t, map[string]float64{
+		cfg.Table[0].Statement: 3,
+		defaultRouteName:       3,
+	}, routedItems(t, set.ID, component.DataTypeMetrics))
+}
+
 func TestMetricsResourceAttributeDroppedByOTTL(t *testing.T) {
 	metricsDefault := component.NewIDWithName(component.DataTypeMetrics, "default")
 	metricsOther := component.NewIDWithName(component.DataTypeMetrics, "other")
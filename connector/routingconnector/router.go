@@ -4,10 +4,14 @@
 package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
@@ -17,6 +21,9 @@ import (
 
 var errPipelineNotFound = errors.New("pipeline not found")
 
+// defaultRouteName identifies the default pipelines in the telemetry of the routes.
+const defaultRouteName = "default"
+
 // consumerProvider is a function with a type parameter C (expected to be one
 // of consumer.Traces, consumer.Metrics, or Consumer.Logs). returns a
 // consumer for the given component ID(s).
@@ -29,8 +36,16 @@ type router[C any] struct {
 	logger *zap.Logger
 	parser ottl.Parser[ottlresource.TransformContext]
 
+	errorMode ottl.ErrorMode
+	matchOnce bool
+	macros    ottl.Macros
+	// telemetryTags identify the connector in the telemetry of the routes.
+	telemetryTags []tag.Mutator
+
 	table  []RoutingTableItem
 	routes map[string]routingItem[C]
+	// order holds the keys of the routes, in the order of the routing table.
+	order []string
 
 	defaultConsumer  C
 	consumerProvider consumerProvider[C]
@@ -39,29 +54,41 @@ type router[C any] struct {
 // newRouter creates a new router instance with based on type parameters C and K.
 // see router struct definition for the allowed types.
 func newRouter[C any](
-	table []RoutingTableItem,
-	defaultPipelineIDs []component.ID,
+	cfg *Config,
+	dataType component.DataType,
 	provider consumerProvider[C],
-	settings component.TelemetrySettings,
+	set connector.CreateSettings,
 ) (*router[C], error) {
 	parser, err := ottlresource.NewParser(
 		common.Functions[ottlresource.TransformContext](),
-		settings,
+		set.TelemetrySettings,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	macros, err := cfg.loadMacros()
+	if err != nil {
+		return nil, err
+	}
+
 	r := &router[C]{
-		logger:           settings.Logger,
-		parser:           parser,
-		table:            table,
+		logger:    set.TelemetrySettings.Logger,
+		parser:    parser,
+		errorMode: cfg.ErrorMode,
+		macros:    macros,
+		matchOnce: cfg.MatchOnce,
+		telemetryTags: []tag.Mutator{
+			tag.Upsert(connectorTagKey, set.ID.String()),
+			tag.Upsert(signalTagKey, string(dataType)),
+		},
+		table:            cfg.Table,
 		routes:           make(map[string]routingItem[C]),
 		consumerProvider: provider,
 	}
 
-	if err := r.registerConsumers(defaultPipelineIDs); err != nil {
+	if err := r.registerConsumers(cfg.DefaultPipelines); err != nil {
 		return nil, err
 	}
 
@@ -71,6 +98,10 @@ func newRouter[C any](
 type routingItem[C any] struct {
 	consumer  C
 	statement *ottl.Statement[ottlresource.TransformContext]
+	// name identifies the route in its telemetry.
+	name      string
+	drop      bool
+	matchOnce bool
 }
 
 func (r *router[C]) registerConsumers(defaultPipelineIDs []component.ID) error {
@@ -118,13 +149,19 @@ func (r *router[C]) registerRouteConsumers() error {
 		route, ok := r.routes[key(item)]
 		if !ok {
 			route.statement = statement
+			route.name = key(item)
+			r.order = append(r.order, key(item))
 		}
-
-		consumer, err := r.consumerProvider(item.Pipelines...)
-		if err != nil {
-			return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
+		route.drop = item.Action == ActionDrop
+		route.matchOnce = route.matchOnce || item.MatchOnce
+
+		if !route.drop {
+			consumer, err := r.consumerProvider(item.Pipelines...)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
+			}
+			route.consumer = consumer
 		}
-		route.consumer = consumer
 
 		r.routes[key(item)] = route
 	}
@@ -137,8 +174,11 @@ func (r *router[C]) registerRouteConsumers() error {
 func (r *router[C]) getStatementFrom(item RoutingTableItem) (*ottl.Statement[ottlresource.TransformContext], error) {
 	var statement *ottl.Statement[ottlresource.TransformContext]
 	if item.Statement != "" {
-		var err error
-		statement, err = r.parser.ParseStatement(item.Statement)
+		expanded, err := r.macros.ExpandConditions([]string{item.Statement})
+		if err != nil {
+			return nil, err
+		}
+		statement, err = r.parser.ParseStatement(expanded[0])
 		if err != nil {
 			return statement, err
 		}
@@ -146,6 +186,48 @@ func (r *router[C]) getStatementFrom(item RoutingTableItem) (*ottl.Statement[ott
 	return statement, nil
 }
 
+// match returns the routes matching a resource, in the order of the routing table. The default route,
+// sending to the default pipelines, is returned when no route matches, or when the statement of a route
+// fails and errors are ignored. A matching drop route takes precedence over all the other routes.
+func (r *router[C]) match(ctx context.Context, rtx ottlresource.TransformContext) ([]routingItem[C], error) {
+	var matched []routingItem[C]
+	useDefault := false
+	for _, k := range r.order {
+		route := r.routes[k]
+		_, isMatch, err := route.statement.Execute(ctx, rtx)
+		if err != nil {
+			if r.errorMode == ottl.PropagateError {
+				return nil, err
+			}
+			useDefault = true
+			continue
+		}
+		if !isMatch {
+			continue
+		}
+		if route.drop {
+			return []routingItem[C]{route}, nil
+		}
+		matched = append(matched, route)
+		if r.matchOnce || route.matchOnce {
+			break
+		}
+	}
+	if useDefault || len(matched) == 0 {
+		matched = append(matched, routingItem[C]{consumer: r.defaultConsumer, name: defaultRouteName})
+	}
+	return matched, nil
+}
+
+// recordRouted records the number of items, spans, data points or log records, matched by a route.
+func (r *router[C]) recordRouted(ctx context.Context, route routingItem[C], items int) {
+	_ = stats.RecordWithTags(
+		ctx,
+		append([]tag.Mutator{tag.Upsert(routeTagKey, route.name)}, r.telemetryTags...),
+		mRoutedItems.M(int64(items)),
+	)
+}
+
 func key(entry RoutingTableItem) string {
 	return entry.Statement
 }
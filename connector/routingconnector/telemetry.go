@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mRoutedItems = stats.Int64("routing_routed_items", "Number of spans, data points or log records matched by each route", stats.UnitDimensionless)
+
+	connectorTagKey = tag.MustNewKey("connector")
+	signalTagKey    = tag.MustNewKey("signal")
+	routeTagKey     = tag.MustNewKey("route")
+)
+
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mRoutedItems.Name(),
+			Measure:     mRoutedItems,
+			Description: mRoutedItems.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{connectorTagKey, signalTagKey, routeTagKey},
+		},
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
@@ -39,10 +38,10 @@ func newTracesConnector(
 	}
 
 	r, err := newRouter(
-		cfg.Table,
-		cfg.DefaultPipelines,
+		cfg,
+		component.DataTypeTraces,
 		tr.Consumer,
-		set.TelemetrySettings)
+		set)
 
 	if err != nil {
 		return nil, err
@@ -70,26 +69,13 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, t ptrace.Traces) er
 		rspans := t.ResourceSpans().At(i)
 		rtx := ottlresource.NewTransformContext(rspans.Resource())
 
-		noRoutesMatch := true
-		for _, route := range c.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
-			if err != nil {
-				if c.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				c.group(groups, c.router.defaultConsumer, rspans)
-				continue
-			}
-			if isMatch {
-				noRoutesMatch = false
-				c.group(groups, route.consumer, rspans)
-			}
-
+		routes, err := c.router.match(ctx, rtx)
+		if err != nil {
+			return err
 		}
-
-		if noRoutesMatch {
-			// no route conditions are matched, add resource spans to default pipelines group
-			c.group(groups, c.router.defaultConsumer, rspans)
+		for _, route := range routes {
+			c.router.recordRouted(ctx, route, spanCount(rspans))
+			c.group(groups, route.consumer, rspans)
 		}
 	}
 
@@ -114,3 +100,11 @@ func (c *tracesConnector) group(
 	spans.CopyTo(group.ResourceSpans().AppendEmpty())
 	groups[consumer] = group
 }
+
+func spanCount(rspans ptrace.ResourceSpans) int {
+	count := 0
+	for i := 0; i < rspans.ScopeSpans().Len(); i++ {
+		count += rspans.ScopeSpans().At(i).Spans().Len()
+	}
+	return count
+}
@@ -9,12 +9,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestTracesRegisterConsumersForValidRoute(t *testing.T) {
@@ -204,6 +209,224 @@ func TestTracesCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestTracesMatchOnceAndDrop(t *testing.T) {
+	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+	traces1 := component.NewIDWithName(component.DataTypeTraces, "1")
+
+	newTraces := func(values ...int64) ptrace.Traces {
+		tr := ptrace.NewTraces()
+		for _, value := range values {
+			rl := tr.ResourceSpans().AppendEmpty()
+			rl.Resource().Attributes().PutInt("value", value)
+			spans := rl.ScopeSpans().AppendEmpty().Spans()
+			spans.AppendEmpty().SetName("span")
+			spans.AppendEmpty().SetName("span1")
+		}
+		return tr
+	}
+
+	table := []RoutingTableItem{
+		{
+			Statement: `route() where attributes["value"] > 0`,
+			Pipelines: []component.ID{traces0},
+		},
+		{
+			Statement: `route() where attributes["value"] > 1`,
+			Pipelines: []component.ID{traces1},
+		},
+		{
+			Statement: `route() where attributes["value"] == 3`,
+			Action:    ActionDrop,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		matchOnce     bool
+		routeOnce     bool
+		values        []int64
+		expectDefault int
+		expect0       int
+		expect1       int
+	}{
+		{
+			name:    "fanout to all matching routes",
+			values:  []int64{2},
+			expect0: 2,
+			expect1: 2,
+		},
+		{
+			name:      "match once",
+			matchOnce: true,
+			values:    []int64{2},
+			expect0:   2,
+		},
+		{
+			name:      "route matching once",
+			routeOnce: true,
+			values:    []int64{1, 2},
+			expect0:   4,
+		},
+		{
+			name:    "drop takes precedence",
+			values:  []int64{2, 3},
+			expect0: 2,
+			expect1: 2,
+		},
+		{
+			name:      "match once before drop",
+			matchOnce: true,
+			values:    []int64{3},
+			expect0:   2,
+		},
+		{
+			name:          "default pipelines",
+			matchOnce:     true,
+			values:        []int64{0},
+			expectDefault: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{
+				DefaultPipelines: []component.ID{tracesDefault},
+				MatchOnce:        tc.matchOnce,
+				Table:            append([]RoutingTableItem{}, table...),
+			}
+			cfg.Table[0].MatchOnce = tc.routeOnce
+			require.NoError(t, cfg.Validate())
+
+			var defaultSink, sink0, sink1 consumertest.TracesSink
+			router := connectortest.NewTracesRouter(
+				connectortest.WithTracesSink(tracesDefault, &defaultSink),
+				connectortest.WithTracesSink(traces0, &sink0),
+				connectortest.WithTracesSink(traces1, &sink1),
+			)
+			conn, err := NewFactory().CreateTracesToTraces(
+				context.Background(),
+				connectortest.NewNopCreateSettings(),
+				cfg,
+				router.(consumer.Traces),
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(tc.values...)))
+			assert.Equal(t, tc.expectDefault, defaultSink.SpanCount())
+			assert.Equal(t, tc.expect0, sink0.SpanCount())
+			assert.Equal(t, tc.expect1, sink1.SpanCount())
+		})
+	}
+}
+
+func TestTracesRouteWithMacros(t *testing.T) {
+	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
+	tracesAcme := component.NewIDWithName(component.DataTypeTraces, "acme")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{tracesDefault},
+		Macros: ottl.Macros{
+			"is_tenant": {
+				Params:    []string{"tenant"},
+				Condition: `attributes["X-Tenant"] == $tenant`,
+			},
+		},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where is_tenant("acme")`,
+				Pipelines: []component.ID{tracesAcme},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, acmeSink consumertest.TracesSink
+	router := connectortest.NewTracesRouter(
+		connectortest.WithTracesSink(tracesDefault, &defaultSink),
+		connectortest.WithTracesSink(tracesAcme, &acmeSink),
+	)
+	conn, err := NewFactory().CreateTracesToTraces(
+		context.Background(),
+		connectortest.NewNopCreateSettings(),
+		cfg,
+		router.(consumer.Traces),
+	)
+	require.NoError(t, err)
+
+	tr := ptrace.NewTraces()
+	for _, tenant := range []string{"acme", "globex"} {
+		rs := tr.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("X-Tenant", tenant)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	assert.Equal(t, 1, acmeSink.SpanCount())
+	assert.Equal(t, 1, defaultSink.SpanCount())
+}
+
+func TestTracesRoutedItemsTelemetry(t *testing.T) {
+	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+	cfg := &Config{
+		DefaultPipelines: []component.ID{tracesDefault},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where attributes["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{traces0},
+			},
+			{
+				Statement: `route() where attributes["X-Tenant"] == "spam"`,
+				Action:    ActionDrop,
+			},
+		},
+	}
+
+	set := connectortest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(metadata.Type, "telemetry")
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), set, cfg,
+		connectortest.NewTracesRouter(
+			connectortest.WithNopTraces(tracesDefault),
+			connectortest.WithNopTraces(traces0),
+		).(consumer.Traces))
+	require.NoError(t, err)
+
+	tr := ptrace.NewTraces()
+	for tenant, spans := range map[string]int{"acme": 3, "spam": 2, "globex": 1} {
+		rl := tr.ResourceSpans().AppendEmpty()
+		rl.Resource().Attributes().PutStr("X-Tenant", tenant)
+		for i := 0; i < spans; i++ {
+			rl.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		}
+	}
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+
+	assert.Equal(t, map[string]float64{
+		cfg.Table[0].Statement: 3,
+		cfg.Table[1].Statement: 2,
+		defaultRouteName:       1,
+	}, routedItems(t, set.ID, component.DataTypeTraces))
+}
+
+// routedItems returns the number of items recorded for each route of a connector.
+func routedItems(t *testing.T, id component.ID, dataType component.DataType) map[string]float64 {
+	rows, err := view.RetrieveData(mRoutedItems.Name())
+	require.NoError(t, err)
+
+	items := make(map[string]float64)
+	for _, row := range rows {
+		tags := make(map[tag.Key]string)
+		for _, tg := range row.Tags {
+			tags[tg.Key] = tg.Value
+		}
+		if tags[connectorTagKey] != id.String() || tags[signalTagKey] != string(dataType) {
+			continue
+		}
+		items[tags[routeTagKey]] = row.Data.(*view.SumData).Value
+	}
+	return items
+}
+
 func TestTracesResourceAttributeDroppedByOTTL(t *testing.T) {
 	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
 	tracesOther := component.NewIDWithName(component.DataTypeTraces, "other")
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Mode is how the data is distributed across the pipelines.
+type Mode string
+
+const (
+	// ModeRoundRobin sends each batch of data to the next pipeline.
+	ModeRoundRobin Mode = "round_robin"
+	// ModeResource sends the data of a resource to the pipeline selected by a hash of its attributes.
+	ModeResource Mode = "resource"
+	// ModeTraceID sends the spans of a trace to the pipeline selected by a hash of the trace ID.
+	// It is only supported for traces.
+	ModeTraceID Mode = "trace_id"
+)
+
+var (
+	errNoPipelines        = errors.New("at least one pipeline must be configured")
+	errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
+)
+
+// Config defines configuration for the Sharding connector.
+type Config struct {
+	// Pipelines is the list of pipelines the data is distributed across.
+	// Required.
+	Pipelines []component.ID `mapstructure:"pipelines"`
+
+	// Mode is how the data is distributed across the pipelines: `round_robin`, `resource` or `trace_id`.
+	// The default value is `round_robin`.
+	Mode Mode `mapstructure:"mode"`
+
+	// ResourceKeys are the resource attributes hashed with the `resource` mode. All the resource
+	// attributes are hashed when empty.
+	// Optional.
+	ResourceKeys []string `mapstructure:"resource_keys"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	if len(c.Pipelines) == 0 {
+		return errNoPipelines
+	}
+	seen := make(map[component.ID]bool, len(c.Pipelines))
+	for _, id := range c.Pipelines {
+		if seen[id] {
+			return fmt.Errorf("duplicate pipeline %q", id)
+		}
+		seen[id] = true
+	}
+	switch c.Mode {
+	case ModeRoundRobin, ModeTraceID:
+		if len(c.ResourceKeys) != 0 {
+			return fmt.Errorf("resource_keys are only supported by the %q mode", ModeResource)
+		}
+	case ModeResource:
+	default:
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+	return nil
+}
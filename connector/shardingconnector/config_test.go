@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+	traces1 := component.NewIDWithName(component.DataTypeTraces, "1")
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Pipelines: []component.ID{traces0, traces1},
+				Mode:      ModeRoundRobin,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "resource"),
+			expected: &Config{
+				Pipelines: []component.ID{
+					component.NewIDWithName(component.DataTypeMetrics, "0"),
+					component.NewIDWithName(component.DataTypeMetrics, "1"),
+					component.NewIDWithName(component.DataTypeMetrics, "2"),
+				},
+				Mode:         ModeResource,
+				ResourceKeys: []string{"service.name", "service.instance.id"},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "trace_id"),
+			expected: &Config{
+				Pipelines: []component.ID{traces0, traces1},
+				Mode:      ModeTraceID,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_pipelines"),
+			expectedErr: "at least one pipeline must be configured",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicate_pipeline"),
+			expectedErr: `duplicate pipeline "traces/0"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "unknown_mode"),
+			expectedErr: `unknown mode "random"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "resource_keys_round_robin"),
+			expectedErr: `resource_keys are only supported by the "resource" mode`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
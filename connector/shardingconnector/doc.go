@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package shardingconnector distributes the telemetry of a pipeline across
+// several pipelines, in a round-robin fashion or by hash.
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
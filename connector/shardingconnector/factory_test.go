@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.EqualError(t, cfg.(*Config).Validate(), "at least one pipeline must be configured")
+}
+
+func TestCreateConnectors(t *testing.T) {
+	metrics0 := component.NewIDWithName(component.DataTypeMetrics, "0")
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Pipelines = []component.ID{metrics0}
+	router := connectortest.NewMetricsRouter(connectortest.WithNopMetrics(metrics0))
+
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Metrics))
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+
+	_, err = NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, errUnexpectedConsumer)
+
+	cfg.Mode = ModeTraceID
+	_, err = NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, router.(consumer.Metrics))
+	assert.EqualError(t, err, `the "trace_id" mode is only supported for traces`)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logsSharding struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mode    Mode
+	sharder *sharder[consumer.Logs]
+}
+
+func newLogsConnector(
+	config component.Config,
+	logs consumer.Logs,
+) (*logsSharding, error) {
+	cfg := config.(*Config)
+	if cfg.Mode == ModeTraceID {
+		return nil, fmt.Errorf("the %q mode is only supported for traces", ModeTraceID)
+	}
+
+	r, ok := logs.(connector.LogsRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	s, err := newSharder(cfg, r.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &logsSharding{mode: cfg.Mode, sharder: s}, nil
+}
+
+func (*logsSharding) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logsSharding) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if c.mode != ModeResource {
+		return c.sharder.shards[c.sharder.nextShard()].ConsumeLogs(ctx, ld)
+	}
+
+	groups := make(map[int]plog.Logs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rlogs := ld.ResourceLogs().At(i)
+		shard := c.sharder.resourceShard(rlogs.Resource())
+		group, ok := groups[shard]
+		if !ok {
+			group = plog.NewLogs()
+			groups[shard] = group
+		}
+		rlogs.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+
+	var errs error
+	for shard, group := range groups {
+		errs = errors.Join(errs, c.sharder.shards[shard].ConsumeLogs(ctx, group))
+	}
+	return errs
+}
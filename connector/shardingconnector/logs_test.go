@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogsByResourceKeys(t *testing.T) {
+	logs0 := component.NewIDWithName(component.DataTypeLogs, "0")
+	logs1 := component.NewIDWithName(component.DataTypeLogs, "1")
+	cfg := &Config{
+		Pipelines:    []component.ID{logs0, logs1},
+		Mode:         ModeResource,
+		ResourceKeys: []string{"service.name"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var sink0, sink1 consumertest.LogsSink
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(), cfg,
+		connectortest.NewLogsRouter(
+			connectortest.WithLogsSink(logs0, &sink0),
+			connectortest.WithLogsSink(logs1, &sink1),
+		).(consumer.Logs))
+	require.NoError(t, err)
+
+	// The resources only differ by attributes which are not hashed.
+	ld := plog.NewLogs()
+	for _, host := range []string{"a", "b", "c", "d"} {
+		rlogs := ld.ResourceLogs().AppendEmpty()
+		rlogs.Resource().Attributes().PutStr("service.name", "checkout")
+		rlogs.Resource().Attributes().PutStr("host.name", host)
+		rlogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	assert.Equal(t, 4, sink0.LogRecordCount()+sink1.LogRecordCount())
+	assert.True(t, sink0.LogRecordCount() == 0 || sink1.LogRecordCount() == 0)
+	assert.Equal(t, 1, len(sink0.AllLogs())+len(sink1.AllLogs()))
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type metricsSharding struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mode    Mode
+	sharder *sharder[consumer.Metrics]
+}
+
+func newMetricsConnector(
+	config component.Config,
+	metrics consumer.Metrics,
+) (*metricsSharding, error) {
+	cfg := config.(*Config)
+	if cfg.Mode == ModeTraceID {
+		return nil, fmt.Errorf("the %q mode is only supported for traces", ModeTraceID)
+	}
+
+	r, ok := metrics.(connector.MetricsRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	s, err := newSharder(cfg, r.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsSharding{mode: cfg.Mode, sharder: s}, nil
+}
+
+func (*metricsSharding) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *metricsSharding) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if c.mode != ModeResource {
+		return c.sharder.shards[c.sharder.nextShard()].ConsumeMetrics(ctx, md)
+	}
+
+	groups := make(map[int]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rmetrics := md.ResourceMetrics().At(i)
+		shard := c.sharder.resourceShard(rmetrics.Resource())
+		group, ok := groups[shard]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[shard] = group
+		}
+		rmetrics.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+
+	var errs error
+	for shard, group := range groups {
+		errs = errors.Join(errs, c.sharder.shards[shard].ConsumeMetrics(ctx, group))
+	}
+	return errs
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// sharder selects the shards, one per pipeline, the data is sent to. The type parameter C is
+// expected to be one of: consumer.Traces, consumer.Metrics, or consumer.Logs.
+type sharder[C any] struct {
+	shards       []C
+	resourceKeys []string
+	next         atomic.Uint64
+}
+
+func newSharder[C any](cfg *Config, consumerFn func(...component.ID) (C, error)) (*sharder[C], error) {
+	s := &sharder[C]{resourceKeys: cfg.ResourceKeys}
+	for _, id := range cfg.Pipelines {
+		c, err := consumerFn(id)
+		if err != nil {
+			return nil, err
+		}
+		s.shards = append(s.shards, c)
+	}
+	return s, nil
+}
+
+// nextShard returns the shards in turn.
+func (s *sharder[C]) nextShard() int {
+	return int((s.next.Add(1) - 1) % uint64(len(s.shards)))
+}
+
+// resourceShard returns the shard of a resource, from a hash of its attributes.
+func (s *sharder[C]) resourceShard(resource pcommon.Resource) int {
+	attrs := resource.Attributes()
+	if len(s.resourceKeys) > 0 {
+		attrs = pcommon.NewMap()
+		for _, k := range s.resourceKeys {
+			if v, ok := resource.Attributes().Get(k); ok {
+				v.CopyTo(attrs.PutEmpty(k))
+			}
+		}
+	}
+	h := pdatautil.MapHash(attrs)
+	return int(binary.BigEndian.Uint64(h[:8]) % uint64(len(s.shards)))
+}
+
+// traceShard returns the shard of a trace, from a hash of its ID.
+func (s *sharder[C]) traceShard(traceID pcommon.TraceID) int {
+	h := fnv.New64a()
+	_, _ = h.Write(traceID[:])
+	return int(h.Sum64() % uint64(len(s.shards)))
+}
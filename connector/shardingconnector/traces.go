@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/shardingconnector"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type tracesSharding struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mode    Mode
+	sharder *sharder[consumer.Traces]
+}
+
+func newTracesConnector(
+	config component.Config,
+	traces consumer.Traces,
+) (*tracesSharding, error) {
+	cfg := config.(*Config)
+
+	tr, ok := traces.(connector.TracesRouter)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	s, err := newSharder(cfg, tr.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesSharding{mode: cfg.Mode, sharder: s}, nil
+}
+
+func (*tracesSharding) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *tracesSharding) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	switch c.mode {
+	case ModeResource:
+		return c.consumeByResource(ctx, td)
+	case ModeTraceID:
+		return c.consumeByTraceID(ctx, td)
+	default:
+		return c.sharder.shards[c.sharder.nextShard()].ConsumeTraces(ctx, td)
+	}
+}
+
+func (c *tracesSharding) consumeByResource(ctx context.Context, td ptrace.Traces) error {
+	groups := make(map[int]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rspans := td.ResourceSpans().At(i)
+		rspans.CopyTo(tracesGroup(groups, c.sharder.resourceShard(rspans.Resource())).ResourceSpans().AppendEmpty())
+	}
+	return c.consumeGroups(ctx, groups)
+}
+
+// consumeByTraceID splits the spans by trace, keeping the resource and the scope of the spans.
+func (c *tracesSharding) consumeByTraceID(ctx context.Context, td ptrace.Traces) error {
+	groups := make(map[int]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rspans := td.ResourceSpans().At(i)
+		// The resource spans and scope spans of each shard are created for its first span.
+		rspansByShard := make(map[int]ptrace.ResourceSpans)
+		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+			sspans := rspans.ScopeSpans().At(j)
+			sspansByShard := make(map[int]ptrace.ScopeSpans)
+			for k := 0; k < sspans.Spans().Len(); k++ {
+				span := sspans.Spans().At(k)
+				shard := c.sharder.traceShard(span.TraceID())
+				dest, ok := sspansByShard[shard]
+				if !ok {
+					destResource, ok := rspansByShard[shard]
+					if !ok {
+						destResource = tracesGroup(groups, shard).ResourceSpans().AppendEmpty()
+						rspans.Resource().CopyTo(destResource.Resource())
+						destResource.SetSchemaUrl(rspans.SchemaUrl())
+						rspansByShard[shard] = destResource
+					}
+					dest = destResource.ScopeSpans().AppendEmpty()
+					sspans.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(sspans.SchemaUrl())
+					sspansByShard[shard] = dest
+				}
+				span.CopyTo(dest.Spans().AppendEmpty())
+			}
+		}
+	}
+	return c.consumeGroups(ctx, groups)
+}
+
+func (c *tracesSharding) consumeGroups(ctx context.Context, groups map[int]ptrace.Traces) error {
+	var errs error
+	for shard, group := range groups {
+		errs = errors.Join(errs, c.sharder.shards[shard].ConsumeTraces(ctx, group))
+	}
+	return errs
+}
+
+func tracesGroup(groups map[int]ptrace.Traces, shard int) ptrace.Traces {
+	group, ok := groups[shard]
+	if !ok {
+		group = ptrace.NewTraces()
+		groups[shard] = group
+	}
+	return group
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shardingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTracesConnector(t *testing.T, mode Mode, shards int) (connector.Traces, []*consumertest.TracesSink) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = mode
+	sinks := make([]*consumertest.TracesSink, shards)
+	var opts []connectortest.TracesRouterOption
+	for i := range sinks {
+		id := component.NewIDWithName(component.DataTypeTraces, string(rune('a'+i)))
+		sinks[i] = &consumertest.TracesSink{}
+		opts = append(opts, connectortest.WithTracesSink(id, sinks[i]))
+		cfg.Pipelines = append(cfg.Pipelines, id)
+	}
+	require.NoError(t, cfg.Validate())
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, connectortest.NewTracesRouter(opts...).(consumer.Traces))
+	require.NoError(t, err)
+	return conn, sinks
+}
+
+// newTraces returns traces with a resource per service, each with a span of each of the traces.
+func newTraces(services []string, traceIDs []pcommon.TraceID) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range services {
+		rspans := td.ResourceSpans().AppendEmpty()
+		rspans.Resource().Attributes().PutStr("service.name", service)
+		sspans := rspans.ScopeSpans().AppendEmpty()
+		sspans.Scope().SetName("scope")
+		for _, traceID := range traceIDs {
+			span := sspans.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetName(service)
+		}
+	}
+	return td
+}
+
+func TestTracesRoundRobin(t *testing.T) {
+	conn, sinks := newTestTracesConnector(t, ModeRoundRobin, 3)
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces([]string{"a"}, []pcommon.TraceID{{1}})))
+	}
+	assert.Len(t, sinks[0].AllTraces(), 3)
+	assert.Len(t, sinks[1].AllTraces(), 2)
+	assert.Len(t, sinks[2].AllTraces(), 2)
+}
+
+func TestTracesByResource(t *testing.T) {
+	conn, sinks := newTestTracesConnector(t, ModeResource, 4)
+
+	services := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	traceIDs := []pcommon.TraceID{{1}, {2}}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces(services, traceIDs)))
+	}
+
+	// The spans of a service are always sent to the same shard.
+	shardOf := make(map[string]int)
+	total := 0
+	for shard, sink := range sinks {
+		total += sink.SpanCount()
+		for _, td := range sink.AllTraces() {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				service, _ := td.ResourceSpans().At(i).Resource().Attributes().Get("service.name")
+				if previous, ok := shardOf[service.Str()]; ok {
+					assert.Equal(t, previous, shard, service.Str())
+				}
+				shardOf[service.Str()] = shard
+			}
+		}
+	}
+	assert.Equal(t, 3*len(services)*len(traceIDs), total)
+	assert.Len(t, shardOf, len(services))
+}
+
+func TestTracesByTraceID(t *testing.T) {
+	conn, sinks := newTestTracesConnector(t, ModeTraceID, 3)
+
+	var traceIDs []pcommon.TraceID
+	for i := 0; i < 16; i++ {
+		traceIDs = append(traceIDs, pcommon.TraceID{byte(i), 0xff})
+	}
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces([]string{"a", "b"}, traceIDs[:8])))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTraces([]string{"b", "c"}, traceIDs)))
+
+	// The spans of a trace are always sent to the same shard, with their resource and scope.
+	shardOf := make(map[pcommon.TraceID]int)
+	total := 0
+	for shard, sink := range sinks {
+		total += sink.SpanCount()
+		for _, td := range sink.AllTraces() {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				rspans := td.ResourceSpans().At(i)
+				service, _ := rspans.Resource().Attributes().Get("service.name")
+				require.Equal(t, 1, rspans.ScopeSpans().Len())
+				sspans := rspans.ScopeSpans().At(0)
+				assert.Equal(t, "scope", sspans.Scope().Name())
+				for j := 0; j < sspans.Spans().Len(); j++ {
+					span := sspans.Spans().At(j)
+					assert.Equal(t, service.Str(), span.Name())
+					if previous, ok := shardOf[span.TraceID()]; ok {
+						assert.Equal(t, previous, shard)
+					}
+					shardOf[span.TraceID()] = shard
+				}
+			}
+		}
+	}
+	assert.Equal(t, 2*8+2*16, total)
+	assert.Len(t, shardOf, 16)
+	for _, sink := range sinks {
+		assert.NotZero(t, sink.SpanCount())
+	}
+}
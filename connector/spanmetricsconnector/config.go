@@ -58,6 +58,19 @@ type Config struct {
 
 	// Exemplars defines the configuration for exemplars.
 	Exemplars ExemplarsConfig `mapstructure:"exemplars"`
+
+	// Events defines the configuration for the metric counting span events.
+	Events EventsConfig `mapstructure:"events"`
+
+	// ResourceMetricsKeyAttributes filters the resource attributes which identify, and are emitted with,
+	// the resource metrics. The service.name attribute is always included.
+	// Optional. All the resource attributes are used by default.
+	ResourceMetricsKeyAttributes []string `mapstructure:"resource_metrics_key_attributes"`
+
+	// MetricsExpiration is the time after which series which have not been updated are no longer emitted,
+	// releasing their memory. It only applies to cumulative metrics, as delta metrics are reset on each flush.
+	// Optional. Series never expire by default.
+	MetricsExpiration time.Duration `mapstructure:"metrics_expiration"`
 }
 
 type HistogramConfig struct {
@@ -69,6 +82,18 @@ type HistogramConfig struct {
 
 type ExemplarsConfig struct {
 	Enabled bool `mapstructure:"enabled"`
+	// MaxPerDataPoint limits the number of exemplars kept for each histogram data point between flushes.
+	// Optional. Exemplars are not limited by default.
+	MaxPerDataPoint int `mapstructure:"max_per_data_point"`
+}
+
+// EventsConfig defines the configuration of the metric counting span events.
+type EventsConfig struct {
+	// Enabled enables the events metric, counting the events of spans.
+	Enabled bool `mapstructure:"enabled"`
+	// Dimensions defines the dimensions fetched from the event attributes, on top of the dimensions of the span.
+	// At least one dimension is required when the events metric is enabled.
+	Dimensions []Dimension `mapstructure:"dimensions"`
 }
 
 type ExponentialHistogramConfig struct {
@@ -99,6 +124,23 @@ func (c Config) Validate() error {
 	if c.Histogram.Explicit != nil && c.Histogram.Exponential != nil {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
+
+	if c.Exemplars.MaxPerDataPoint < 0 {
+		return fmt.Errorf("invalid exemplars max_per_data_point: %v, it must not be negative", c.Exemplars.MaxPerDataPoint)
+	}
+
+	if c.Events.Enabled {
+		if len(c.Events.Dimensions) == 0 {
+			return errors.New("no dimensions configured for events")
+		}
+		if err := validateDimensions(append(append([]Dimension{}, c.Dimensions...), c.Events.Dimensions...)); err != nil {
+			return fmt.Errorf("invalid events dimensions: %w", err)
+		}
+	}
+
+	if c.MetricsExpiration < 0 {
+		return fmt.Errorf("invalid metrics_expiration: %v, it must not be negative", c.MetricsExpiration)
+	}
 	return nil
 }
 
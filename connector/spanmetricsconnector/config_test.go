@@ -95,6 +95,30 @@ func TestLoadConfig(t *testing.T) {
 				Exemplars:              ExemplarsConfig{Enabled: true},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "events_and_expiration"),
+			expected: &Config{
+				AggregationTemporality: "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				DimensionsCacheSize:    defaultDimensionsCacheSize,
+				MetricsFlushInterval:   15 * time.Second,
+				Histogram:              HistogramConfig{Disable: false, Unit: defaultUnit},
+				Exemplars:              ExemplarsConfig{Enabled: true, MaxPerDataPoint: 5},
+				Events: EventsConfig{
+					Enabled:    true,
+					Dimensions: []Dimension{{Name: "exception.type"}},
+				},
+				ResourceMetricsKeyAttributes: []string{"k8s.namespace.name"},
+				MetricsExpiration:            5 * time.Minute,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "events_without_dimensions"),
+			errorMessage: "no dimensions configured for events",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_per_data_point"),
+			errorMessage: "invalid exemplars max_per_data_point: -1, it must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -36,6 +36,7 @@ const (
 
 	metricNameDuration = "duration"
 	metricNameCalls    = "calls"
+	metricNameEvents   = "events"
 
 	defaultUnit = metrics.Milliseconds
 )
@@ -49,6 +50,8 @@ type connectorImp struct {
 
 	// Additional dimensions to add to metrics.
 	dimensions []dimension
+	// Dimensions of span events, added to the dimensions of their span.
+	eventsDimensions []dimension
 
 	// The starting time of the data points.
 	startTimestamp pcommon.Timestamp
@@ -71,7 +74,10 @@ type connectorImp struct {
 type resourceMetrics struct {
 	histograms metrics.HistogramMetrics
 	sums       metrics.SumMetrics
+	events     metrics.SumMetrics
 	attributes pcommon.Map
+	// lastSeen records when each series was last updated, when series expire.
+	lastSeen map[metrics.Key]time.Time
 }
 
 type dimension struct {
@@ -109,6 +115,7 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		resourceMetrics:       make(map[resourceKey]*resourceMetrics),
 		dimensions:            newDimensions(cfg.Dimensions),
+		eventsDimensions:      newDimensions(cfg.Events.Dimensions),
 		keyBuf:                bytes.NewBuffer(make([]byte, 0, 1024)),
 		metricKeyToDimensions: metricKeyToDimensionsCache,
 		ticker:                ticker,
@@ -125,7 +132,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		if cfg.Histogram.Exponential.MaxSize != 0 {
 			maxSize = cfg.Histogram.Exponential.MaxSize
 		}
-		return metrics.NewExponentialHistogramMetrics(maxSize)
+		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint)
 	}
 
 	var bounds []float64
@@ -143,7 +150,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		}
 	}
 
-	return metrics.NewExplicitHistogramMetrics(bounds)
+	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint)
 }
 
 // unitDivider returns a unit divider to convert nanoseconds to milliseconds or seconds.
@@ -245,6 +252,11 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 			metric.SetUnit(p.config.Histogram.Unit.String())
 			histograms.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
 		}
+		if p.config.Events.Enabled {
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(buildMetricName(p.config.Namespace, metricNameEvents))
+			rawMetrics.events.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
+		}
 	}
 
 	return m
@@ -258,6 +270,7 @@ func (p *connectorImp) resetState() {
 		p.startTimestamp = pcommon.NewTimestampFromTime(time.Now())
 	} else {
 		p.metricKeyToDimensions.RemoveEvictedItems()
+		p.removeExpiredSeries()
 
 		// Exemplars are only relevant to this batch of traces, so must be cleared within the lock
 		if p.config.Histogram.Disable {
@@ -321,7 +334,77 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				// aggregate sums metrics
 				s := sums.GetOrCreate(key, attributes)
 				s.Add(1)
+				rm.markSeen(key)
+
+				if p.config.Events.Enabled {
+					p.aggregateEvents(rm, key, attributes, span)
+				}
+			}
+		}
+	}
+}
+
+// aggregateEvents counts the events of a span, by the dimensions of the span and of the events.
+func (p *connectorImp) aggregateEvents(rm *resourceMetrics, spanKey metrics.Key, spanAttributes pcommon.Map, span ptrace.Span) {
+	// Event dimensions are only looked up in the event attributes
+	noResourceAttrs := pcommon.NewMap()
+	for l := 0; l < span.Events().Len(); l++ {
+		event := span.Events().At(l)
+		p.keyBuf.Reset()
+		p.keyBuf.WriteString(string(spanKey))
+		// Distinguishes event keys from span keys with the same values
+		concatDimensionValue(p.keyBuf, metricNameEvents, true)
+		for _, d := range p.eventsDimensions {
+			if v, ok := getDimensionValue(d, event.Attributes(), noResourceAttrs); ok {
+				concatDimensionValue(p.keyBuf, v.AsString(), true)
+			}
+		}
+		key := metrics.Key(p.keyBuf.String())
+
+		attributes, ok := p.metricKeyToDimensions.Get(key)
+		if !ok {
+			attributes = pcommon.NewMap()
+			spanAttributes.CopyTo(attributes)
+			for _, d := range p.eventsDimensions {
+				if v, ok := getDimensionValue(d, event.Attributes(), noResourceAttrs); ok {
+					v.CopyTo(attributes.PutEmpty(d.name))
+				}
+			}
+			p.metricKeyToDimensions.Add(key, attributes)
+		}
+		rm.events.GetOrCreate(key, attributes).Add(1)
+		rm.markSeen(key)
+	}
+}
+
+// markSeen records the time a series was updated, to expire it once it's no longer updated.
+func (rm *resourceMetrics) markSeen(key metrics.Key) {
+	if rm.lastSeen != nil {
+		rm.lastSeen[key] = time.Now()
+	}
+}
+
+// removeExpiredSeries removes the series which were not updated within the metrics expiration,
+// and the resources without any remaining series.
+func (p *connectorImp) removeExpiredSeries() {
+	if p.config.MetricsExpiration <= 0 {
+		return
+	}
+	expiredBefore := time.Now().Add(-p.config.MetricsExpiration)
+	for resourceKey, rm := range p.resourceMetrics {
+		for key, lastSeen := range rm.lastSeen {
+			if !lastSeen.Before(expiredBefore) {
+				continue
 			}
+			rm.sums.Remove(key)
+			rm.events.Remove(key)
+			if rm.histograms != nil {
+				rm.histograms.Remove(key)
+			}
+			delete(rm.lastSeen, key)
+		}
+		if len(rm.lastSeen) == 0 {
+			delete(p.resourceMetrics, resourceKey)
 		}
 	}
 }
@@ -340,19 +423,39 @@ func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics
 type resourceKey [16]byte
 
 func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMetrics {
+	if len(p.config.ResourceMetricsKeyAttributes) > 0 {
+		attr = p.filterResourceAttributes(attr)
+	}
 	key := resourceKey(pdatautil.MapHash(attr))
 	v, ok := p.resourceMetrics[key]
 	if !ok {
 		v = &resourceMetrics{
 			histograms: initHistogramMetrics(p.config),
 			sums:       metrics.NewSumMetrics(),
+			events:     metrics.NewSumMetrics(),
 			attributes: attr,
 		}
+		if p.config.MetricsExpiration > 0 {
+			v.lastSeen = make(map[metrics.Key]time.Time)
+		}
 		p.resourceMetrics[key] = v
 	}
 	return v
 }
 
+// filterResourceAttributes returns the service name and the configured resource metrics key attributes.
+func (p *connectorImp) filterResourceAttributes(attr pcommon.Map) pcommon.Map {
+	filtered := pcommon.NewMap()
+	filtered.EnsureCapacity(len(p.config.ResourceMetricsKeyAttributes) + 1)
+	attr.Range(func(k string, v pcommon.Value) bool {
+		if k == serviceNameKey || contains(p.config.ResourceMetricsKeyAttributes, k) {
+			v.CopyTo(filtered.PutEmpty(k))
+		}
+		return true
+	})
+	return filtered
+}
+
 // contains checks if string slice contains a string value
 func contains(elements []string, value string) bool {
 	for _, element := range elements {
@@ -1170,7 +1170,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		{
 			name:   "initialize histogram with no config provided",
 			config: Config{},
-			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs),
+			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0),
 		},
 		{
 			name: "Disable histogram",
@@ -1188,7 +1188,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Milliseconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0),
 		},
 		{
 			name: "initialize explicit histogram with default bounds (seconds)",
@@ -1197,7 +1197,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Seconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (seconds)",
@@ -1212,7 +1212,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}),
+			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (ms)",
@@ -1227,7 +1227,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}),
+			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, 0),
 		},
 		{
 			name: "initialize exponential histogram",
@@ -1239,7 +1239,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(10),
+			want: metrics.NewExponentialHistogramMetrics(10, 0),
 		},
 		{
 			name: "initialize exponential histogram with default max buckets count",
@@ -1249,7 +1249,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Exponential: &ExponentialHistogramConfig{},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize),
+			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, 0),
 		},
 	}
 	for _, tt := range tests {
@@ -1259,3 +1259,121 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsMetric(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Dimensions: []Dimension{{Name: "exception.type"}},
+	}
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	traces := buildSampleTrace()
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	for _, exceptionType := range []string{"Timeout", "Timeout", "NullPointer", ""} {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		if exceptionType != "" {
+			event.Attributes().PutStr("exception.type", exceptionType)
+		}
+	}
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	md := p.buildMetrics()
+	counts := make(map[string]int64)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		ms := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 3, ms.Len())
+		events := ms.At(2)
+		assert.Equal(t, metricNameEvents, events.Name())
+		for j := 0; j < events.Sum().DataPoints().Len(); j++ {
+			dp := events.Sum().DataPoints().At(j)
+			serviceName, _ := dp.Attributes().Get(serviceNameKey)
+			spanKind, _ := dp.Attributes().Get(spanKindKey)
+			key := serviceName.Str() + "/" + spanKind.Str() + "/"
+			if exceptionType, ok := dp.Attributes().Get("exception.type"); ok {
+				key += exceptionType.Str()
+			}
+			counts[key] = dp.IntValue()
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"service-a/SPAN_KIND_SERVER/Timeout":     2,
+		"service-a/SPAN_KIND_SERVER/NullPointer": 1,
+		"service-a/SPAN_KIND_SERVER/":            1,
+	}, counts)
+}
+
+func TestExemplarsMaxPerDataPoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Exemplars = ExemplarsConfig{Enabled: true, MaxPerDataPoint: 1}
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+
+	md := p.buildMetrics()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		duration := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(1)
+		require.Equal(t, metricNameDuration, duration.Name())
+		for j := 0; j < duration.Histogram().DataPoints().Len(); j++ {
+			dp := duration.Histogram().DataPoints().At(j)
+			assert.Equal(t, uint64(2), dp.Count())
+			assert.Equal(t, 1, dp.Exemplars().Len())
+		}
+	}
+}
+
+func TestResourceMetricsKeyAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceMetricsKeyAttributes = []string{regionResourceAttrName}
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	// The process ID changes between the resources but doesn't identify the resource metrics
+	for pid := 0; pid < 2; pid++ {
+		traces := buildSampleTrace()
+		for i := 0; i < traces.ResourceSpans().Len(); i++ {
+			traces.ResourceSpans().At(i).Resource().Attributes().PutInt("process.pid", int64(pid))
+		}
+		require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	}
+
+	md := p.buildMetrics()
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		attrs := md.ResourceMetrics().At(i).Resource().Attributes()
+		assert.Equal(t, 2, attrs.Len())
+		_, ok := attrs.Get(serviceNameKey)
+		assert.True(t, ok)
+		region, _ := attrs.Get(regionResourceAttrName)
+		assert.Equal(t, sampleRegion, region.Str())
+	}
+}
+
+func TestMetricsExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExpiration = time.Minute
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+	require.Len(t, p.resourceMetrics, 2)
+
+	// The series of service-a were not updated since longer than the expiration
+	for _, rm := range p.resourceMetrics {
+		if serviceName, _ := rm.attributes.Get(serviceNameKey); serviceName.Str() == "service-a" {
+			for key := range rm.lastSeen {
+				rm.lastSeen[key] = time.Now().Add(-2 * time.Minute)
+			}
+		}
+	}
+	p.resetState()
+
+	md := p.buildMetrics()
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	serviceName, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get(serviceNameKey)
+	assert.Equal(t, "service-b", serviceName.Str())
+}
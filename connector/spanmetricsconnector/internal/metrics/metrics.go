@@ -18,6 +18,7 @@ type HistogramMetrics interface {
 	GetOrCreate(key Key, attributes pcommon.Map) Histogram
 	BuildMetrics(pmetric.Metric, pcommon.Timestamp, pmetric.AggregationTemporality)
 	Reset(onlyExemplars bool)
+	Remove(key Key)
 }
 
 type Histogram interface {
@@ -26,18 +27,22 @@ type Histogram interface {
 }
 
 type explicitHistogramMetrics struct {
-	metrics map[Key]*explicitHistogram
-	bounds  []float64
+	metrics          map[Key]*explicitHistogram
+	bounds           []float64
+	maxExemplarCount int
 }
 
 type exponentialHistogramMetrics struct {
-	metrics map[Key]*exponentialHistogram
-	maxSize int32
+	metrics          map[Key]*exponentialHistogram
+	maxSize          int32
+	maxExemplarCount int
 }
 
 type explicitHistogram struct {
 	attributes pcommon.Map
 	exemplars  pmetric.ExemplarSlice
+	// maxExemplarCount limits the exemplars kept between flushes, zero means no limit
+	maxExemplarCount int
 
 	bucketCounts []uint64
 	count        uint64
@@ -49,21 +54,25 @@ type explicitHistogram struct {
 type exponentialHistogram struct {
 	attributes pcommon.Map
 	exemplars  pmetric.ExemplarSlice
+	// maxExemplarCount limits the exemplars kept between flushes, zero means no limit
+	maxExemplarCount int
 
 	histogram *structure.Histogram[float64]
 }
 
-func NewExponentialHistogramMetrics(maxSize int32) HistogramMetrics {
+func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount int) HistogramMetrics {
 	return &exponentialHistogramMetrics{
-		metrics: make(map[Key]*exponentialHistogram),
-		maxSize: maxSize,
+		metrics:          make(map[Key]*exponentialHistogram),
+		maxSize:          maxSize,
+		maxExemplarCount: maxExemplarCount,
 	}
 }
 
-func NewExplicitHistogramMetrics(bounds []float64) HistogramMetrics {
+func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount int) HistogramMetrics {
 	return &explicitHistogramMetrics{
-		metrics: make(map[Key]*explicitHistogram),
-		bounds:  bounds,
+		metrics:          make(map[Key]*explicitHistogram),
+		bounds:           bounds,
+		maxExemplarCount: maxExemplarCount,
 	}
 }
 
@@ -71,10 +80,11 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Map)
 	h, ok := m.metrics[key]
 	if !ok {
 		h = &explicitHistogram{
-			attributes:   attributes,
-			exemplars:    pmetric.NewExemplarSlice(),
-			bounds:       m.bounds,
-			bucketCounts: make([]uint64, len(m.bounds)+1),
+			attributes:       attributes,
+			exemplars:        pmetric.NewExemplarSlice(),
+			maxExemplarCount: m.maxExemplarCount,
+			bounds:           m.bounds,
+			bucketCounts:     make([]uint64, len(m.bounds)+1),
 		}
 		m.metrics[key] = h
 	}
@@ -118,6 +128,10 @@ func (m *explicitHistogramMetrics) Reset(onlyExemplars bool) {
 	m.metrics = make(map[Key]*explicitHistogram)
 }
 
+func (m *explicitHistogramMetrics) Remove(key Key) {
+	delete(m.metrics, key)
+}
+
 func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Map) Histogram {
 	h, ok := m.metrics[key]
 	if !ok {
@@ -128,9 +142,10 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Ma
 		histogram.Init(cfg)
 
 		h = &exponentialHistogram{
-			histogram:  histogram,
-			attributes: attributes,
-			exemplars:  pmetric.NewExemplarSlice(),
+			histogram:        histogram,
+			attributes:       attributes,
+			exemplars:        pmetric.NewExemplarSlice(),
+			maxExemplarCount: m.maxExemplarCount,
 		}
 		m.metrics[key] = h
 	}
@@ -202,6 +217,10 @@ func (m *exponentialHistogramMetrics) Reset(onlyExemplars bool) {
 	m.metrics = make(map[Key]*exponentialHistogram)
 }
 
+func (m *exponentialHistogramMetrics) Remove(key Key) {
+	delete(m.metrics, key)
+}
+
 func (h *explicitHistogram) Observe(value float64) {
 	h.sum += value
 	h.count++
@@ -212,6 +231,9 @@ func (h *explicitHistogram) Observe(value float64) {
 }
 
 func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
+	if h.maxExemplarCount > 0 && h.exemplars.Len() >= h.maxExemplarCount {
+		return
+	}
 	e := h.exemplars.AppendEmpty()
 	e.SetTraceID(traceID)
 	e.SetSpanID(spanID)
@@ -223,6 +245,9 @@ func (h *exponentialHistogram) Observe(value float64) {
 }
 
 func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
+	if h.maxExemplarCount > 0 && h.exemplars.Len() >= h.maxExemplarCount {
+		return
+	}
 	e := h.exemplars.AppendEmpty()
 	e.SetTraceID(traceID)
 	e.SetSpanID(spanID)
@@ -280,3 +305,7 @@ func (m *SumMetrics) BuildMetrics(
 func (m *SumMetrics) Reset() {
 	m.metrics = make(map[Key]*Sum)
 }
+
+func (m *SumMetrics) Remove(key Key) {
+	delete(m.metrics, key)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesummaryconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines configuration for the Trace Summary connector.
+type Config struct {
+	// WaitDuration is how long the spans of a trace are collected for, from its first span,
+	// before the trace is summarized.
+	WaitDuration time.Duration `mapstructure:"wait_duration"`
+
+	// MaxTraces is the maximum number of traces collected at once. The oldest trace is summarized
+	// before the end of its wait duration when a new trace exceeds the maximum.
+	MaxTraces int `mapstructure:"max_traces"`
+
+	// Attributes are the span attributes copied to the summaries, from the root span of the traces
+	// or, if the root span doesn't have them, from the first span having them.
+	Attributes []string `mapstructure:"attributes"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	if c.WaitDuration <= 0 {
+		return errors.New("wait_duration must be positive")
+	}
+	if c.MaxTraces <= 0 {
+		return errors.New("max_traces must be positive")
+	}
+	return nil
+}
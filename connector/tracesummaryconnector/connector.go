@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesummaryconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const scopeName = "otelcol/tracesummaryconnector"
+
+// checksPerWaitDuration is how many times per wait duration the connector checks for the traces to summarize,
+// so that the traces are summarized at most a tenth of the wait duration late.
+const checksPerWaitDuration = 10
+
+// traceSummaries collects the spans of the traces, and emits a log record summarizing each trace once its
+// wait duration ended.
+type traceSummaries struct {
+	logger       *zap.Logger
+	logsConsumer consumer.Logs
+	config       Config
+	// now is replaced by tests.
+	now func() time.Time
+
+	mu     sync.Mutex
+	traces map[pcommon.TraceID]*traceSummary
+	// queue holds the traces in the order of their first span, which is also the order their wait
+	// duration ends.
+	queue []*traceSummary
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newTraceSummaries(logger *zap.Logger, cfg *Config, logs consumer.Logs) *traceSummaries {
+	return &traceSummaries{
+		logger:       logger,
+		logsConsumer: logs,
+		config:       *cfg,
+		now:          time.Now,
+		traces:       make(map[pcommon.TraceID]*traceSummary),
+	}
+}
+
+// Start starts summarizing the traces whose wait duration ended.
+func (c *traceSummaries) Start(context.Context, component.Host) error {
+	interval := c.config.WaitDuration / checksPerWaitDuration
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	c.shutdownCh = make(chan struct{})
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// Errors are logged by emit
+				_ = c.emit(context.Background(), c.expired(false))
+			case <-c.shutdownCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown summarizes all the traces collected, without waiting for the end of their wait duration.
+func (c *traceSummaries) Shutdown(ctx context.Context) error {
+	if c.shutdownCh != nil {
+		close(c.shutdownCh)
+		c.wg.Wait()
+	}
+	return c.emit(ctx, c.expired(true))
+}
+
+func (c *traceSummaries) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces adds the spans to the summaries of their traces. The oldest traces are summarized
+// right away when the maximum number of traces is exceeded.
+func (c *traceSummaries) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	now := c.now()
+	var evicted []*traceSummary
+
+	c.mu.Lock()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				summary, ok := c.traces[span.TraceID()]
+				if !ok {
+					if len(c.queue) >= c.config.MaxTraces {
+						evicted = append(evicted, c.pop())
+					}
+					summary = newTraceSummary(span.TraceID(), now)
+					c.traces[span.TraceID()] = summary
+					c.queue = append(c.queue, summary)
+				}
+				summary.add(rs.Resource(), span, c.config.Attributes)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	return c.emit(ctx, evicted)
+}
+
+// expired removes and returns the traces whose wait duration ended, or all of them if force is true.
+func (c *traceSummaries) expired(force bool) []*traceSummary {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expired []*traceSummary
+	for len(c.queue) > 0 && (force || now.Sub(c.queue[0].firstSeen) >= c.config.WaitDuration) {
+		expired = append(expired, c.pop())
+	}
+	return expired
+}
+
+// pop removes and returns the oldest trace. The caller must hold the lock.
+func (c *traceSummaries) pop() *traceSummary {
+	summary := c.queue[0]
+	c.queue[0] = nil
+	c.queue = c.queue[1:]
+	delete(c.traces, summary.traceID)
+	return summary
+}
+
+// emit emits the log records summarizing the traces.
+func (c *traceSummaries) emit(ctx context.Context, summaries []*traceSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	ld := plog.NewLogs()
+	now := pcommon.NewTimestampFromTime(c.now())
+	for _, summary := range summaries {
+		summary.appendTo(ld, c.config.Attributes, now)
+	}
+	if err := c.logsConsumer.ConsumeLogs(ctx, ld); err != nil {
+		c.logger.Error("Failed to emit the trace summaries", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesummaryconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var (
+	traceID1 = pcommon.TraceID{1}
+	traceID2 = pcommon.TraceID{2}
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+type testSpan struct {
+	traceID pcommon.TraceID
+	spanID  byte
+	parent  byte
+	service string
+	name    string
+	start   int64
+	end     int64
+	status  ptrace.StatusCode
+	attrs   map[string]any
+}
+
+func newTestTraces(spans ...testSpan) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, s := range spans {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(s.traceID)
+		span.SetSpanID(pcommon.SpanID{s.spanID})
+		if s.parent != 0 {
+			span.SetParentSpanID(pcommon.SpanID{s.parent})
+		}
+		span.SetName(s.name)
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.Timestamp(s.start * int64(time.Millisecond)))
+		span.SetEndTimestamp(pcommon.Timestamp(s.end * int64(time.Millisecond)))
+		span.Status().SetCode(s.status)
+		_ = span.Attributes().FromRaw(s.attrs)
+	}
+	return td
+}
+
+func newTestConnector(cfg *Config) (*traceSummaries, *consumertest.LogsSink, *testClock) {
+	sink := &consumertest.LogsSink{}
+	c := newTraceSummaries(zap.NewNop(), cfg, sink)
+	clock := &testClock{now: time.Unix(1000, 0)}
+	c.now = clock.Now
+	return c, sink, clock
+}
+
+func TestTraceSummaries(t *testing.T) {
+	c, sink, clock := newTestConnector(&Config{
+		WaitDuration: 10 * time.Second,
+		MaxTraces:    10,
+		Attributes:   []string{"http.route", "db.system"},
+	})
+	ctx := context.Background()
+
+	// The spans of the trace arrive out of order, across batches.
+	require.NoError(t, c.ConsumeTraces(ctx, newTestTraces(
+		testSpan{traceID: traceID1, spanID: 2, parent: 1, service: "db", name: "SELECT", start: 110, end: 150,
+			status: ptrace.StatusCodeError, attrs: map[string]any{"db.system": "postgresql", "http.route": "ignored"}},
+	)))
+	clock.now = clock.now.Add(5 * time.Second)
+	require.NoError(t, c.ConsumeTraces(ctx, newTestTraces(
+		testSpan{traceID: traceID1, spanID: 1, service: "frontend", name: "GET /users", start: 100, end: 300,
+			status: ptrace.StatusCodeOk, attrs: map[string]any{"http.route": "/users"}},
+		testSpan{traceID: traceID2, spanID: 1, service: "frontend", name: "GET /health", start: 100, end: 101},
+	)))
+
+	require.NoError(t, c.emit(ctx, c.expired(false)))
+	assert.Empty(t, sink.AllLogs())
+
+	clock.now = clock.now.Add(5 * time.Second)
+	require.NoError(t, c.emit(ctx, c.expired(false)))
+	require.Equal(t, 1, sink.LogRecordCount())
+
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	service, _ := rl.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "frontend", service.Str())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, traceID1, lr.TraceID())
+	assert.Equal(t, pcommon.SpanID{1}, lr.SpanID())
+	assert.Equal(t, "GET /users", lr.Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, lr.SeverityNumber())
+	assert.Equal(t, pcommon.Timestamp(100*time.Millisecond), lr.Timestamp())
+	assert.Equal(t, map[string]any{
+		traceDurationKey:   200.0,
+		traceSpanCountKey:  int64(2),
+		traceErrorCountKey: int64(1),
+		traceStatusKey:     "STATUS_CODE_ERROR",
+		rootSpanNameKey:    "GET /users",
+		rootSpanKindKey:    "SPAN_KIND_SERVER",
+		"http.route":       "/users",
+		"db.system":        "postgresql",
+	}, lr.Attributes().AsRaw())
+
+	require.NoError(t, c.Shutdown(ctx))
+	require.Equal(t, 2, sink.LogRecordCount())
+	lr = sink.AllLogs()[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, traceID2, lr.TraceID())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	status, _ := lr.Attributes().Get(traceStatusKey)
+	assert.Equal(t, "STATUS_CODE_UNSET", status.Str())
+}
+
+func TestTraceSummariesWithoutRootSpan(t *testing.T) {
+	c, sink, _ := newTestConnector(&Config{WaitDuration: time.Second, MaxTraces: 10})
+	ctx := context.Background()
+
+	require.NoError(t, c.ConsumeTraces(ctx, newTestTraces(
+		testSpan{traceID: traceID1, spanID: 2, parent: 1, service: "backend", name: "query", start: 120, end: 130},
+		testSpan{traceID: traceID1, spanID: 3, parent: 1, service: "backend", name: "render", start: 130, end: 170},
+	)))
+	require.NoError(t, c.emit(ctx, c.expired(true)))
+
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.True(t, lr.SpanID().IsEmpty())
+	assert.Empty(t, lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		traceDurationKey:   50.0,
+		traceSpanCountKey:  int64(2),
+		traceErrorCountKey: int64(0),
+		traceStatusKey:     "STATUS_CODE_UNSET",
+	}, lr.Attributes().AsRaw())
+}
+
+func TestTraceSummariesMaxTraces(t *testing.T) {
+	c, sink, _ := newTestConnector(&Config{WaitDuration: time.Minute, MaxTraces: 1})
+	ctx := context.Background()
+
+	require.NoError(t, c.ConsumeTraces(ctx, newTestTraces(
+		testSpan{traceID: traceID1, spanID: 1, name: "first"},
+	)))
+	assert.Empty(t, sink.AllLogs())
+	require.NoError(t, c.ConsumeTraces(ctx, newTestTraces(
+		testSpan{traceID: traceID2, spanID: 1, name: "second"},
+	)))
+	require.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, traceID1, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).TraceID())
+}
+
+func TestConnectorLifecycle(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	conn, err := NewFactory().CreateTracesToLogs(context.Background(), connectortest.NewNopCreateSettings(),
+		&Config{WaitDuration: 10 * time.Millisecond, MaxTraces: 10}, sink)
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), newTestTraces(
+		testSpan{traceID: traceID1, spanID: 1, name: "root"},
+	)))
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracesummaryconnector emits a log record summarizing each trace,
+// correlated with the trace by its trace ID.
+package tracesummaryconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector"
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package tracesummaryconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector/internal/metadata"
+)
+
+const (
+	defaultWaitDuration = 10 * time.Second
+	defaultMaxTraces    = 10000
+)
+
+// NewFactory returns a ConnectorFactory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithTracesToLogs(createTracesToLogs, metadata.TracesToLogsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		WaitDuration: defaultWaitDuration,
+		MaxTraces:    defaultMaxTraces,
+	}
+}
+
+// createTracesToLogs creates a traces to logs connector based on provided config.
+func createTracesToLogs(
+	_ context.Context,
+	set connector.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Traces, error) {
+	return newTraceSummaries(set.Logger, cfg.(*Config), nextConsumer), nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesummaryconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/tracesummaryconnector"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+const (
+	traceDurationKey   = "trace.duration_ms"
+	traceSpanCountKey  = "trace.span_count"
+	traceErrorCountKey = "trace.error_count"
+	traceStatusKey     = "trace.status"
+	rootSpanNameKey    = "trace.root_span.name"
+	rootSpanKindKey    = "trace.root_span.kind"
+)
+
+// traceSummary accumulates the spans of a trace.
+type traceSummary struct {
+	traceID   pcommon.TraceID
+	firstSeen time.Time
+
+	start      pcommon.Timestamp
+	end        pcommon.Timestamp
+	spanCount  int64
+	errorCount int64
+
+	// resource is the resource of the root span, or of the first span while the root span wasn't seen.
+	resource pcommon.Resource
+	// root is the root span, if seen.
+	root    ptrace.Span
+	hasRoot bool
+	// attributes are the configured attributes found in the spans which are not the root span.
+	attributes pcommon.Map
+}
+
+func newTraceSummary(traceID pcommon.TraceID, now time.Time) *traceSummary {
+	return &traceSummary{
+		traceID:    traceID,
+		firstSeen:  now,
+		resource:   pcommon.NewResource(),
+		root:       ptrace.NewSpan(),
+		attributes: pcommon.NewMap(),
+	}
+}
+
+// add adds a span of the trace to the summary.
+func (s *traceSummary) add(resource pcommon.Resource, span ptrace.Span, attributes []string) {
+	if s.spanCount == 0 || span.StartTimestamp() < s.start {
+		s.start = span.StartTimestamp()
+	}
+	if span.EndTimestamp() > s.end {
+		s.end = span.EndTimestamp()
+	}
+	if span.Status().Code() == ptrace.StatusCodeError {
+		s.errorCount++
+	}
+	if s.spanCount == 0 {
+		resource.CopyTo(s.resource)
+	}
+	s.spanCount++
+
+	if span.ParentSpanID().IsEmpty() && !s.hasRoot {
+		s.hasRoot = true
+		span.CopyTo(s.root)
+		resource.CopyTo(s.resource)
+		return
+	}
+	for _, k := range attributes {
+		if _, ok := s.attributes.Get(k); ok {
+			continue
+		}
+		if v, ok := span.Attributes().Get(k); ok {
+			v.CopyTo(s.attributes.PutEmpty(k))
+		}
+	}
+}
+
+// appendTo appends the log record summarizing the trace, with its resource, to the logs.
+func (s *traceSummary) appendTo(ld plog.Logs, attributes []string, now pcommon.Timestamp) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	s.resource.MoveTo(rl.Resource())
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	lr := sl.LogRecords().AppendEmpty()
+
+	lr.SetTimestamp(s.start)
+	lr.SetObservedTimestamp(now)
+	lr.SetTraceID(s.traceID)
+	if s.errorCount > 0 {
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+		lr.SetSeverityText("ERROR")
+	} else {
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText("INFO")
+	}
+
+	attrs := lr.Attributes()
+	duration := 0.0
+	if s.end > s.start {
+		duration = float64(s.end-s.start) / float64(time.Millisecond)
+	}
+	attrs.PutDouble(traceDurationKey, duration)
+	attrs.PutInt(traceSpanCountKey, s.spanCount)
+	attrs.PutInt(traceErrorCountKey, s.errorCount)
+	attrs.PutStr(traceStatusKey, s.status())
+	if s.hasRoot {
+		lr.SetSpanID(s.root.SpanID())
+		lr.Body().SetStr(s.root.Name())
+		attrs.PutStr(rootSpanNameKey, s.root.Name())
+		attrs.PutStr(rootSpanKindKey, traceutil.SpanKindStr(s.root.Kind()))
+		for _, k := range attributes {
+			if v, ok := s.root.Attributes().Get(k); ok {
+				v.CopyTo(s.attributes.PutEmpty(k))
+			}
+		}
+	}
+	s.attributes.Range(func(k string, v pcommon.Value) bool {
+		v.CopyTo(attrs.PutEmpty(k))
+		return true
+	})
+}
+
+// status returns the status of the trace: the status of its root span, or Error if any of its spans failed.
+func (s *traceSummary) status() string {
+	if s.errorCount > 0 {
+		return traceutil.StatusCodeStr(ptrace.StatusCodeError)
+	}
+	if s.hasRoot {
+		return traceutil.StatusCodeStr(s.root.Status().Code())
+	}
+	return traceutil.StatusCodeStr(ptrace.StatusCodeUnset)
+}
@@ -5,10 +5,22 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"fmt"
 
 	"go.uber.org/multierr"
 )
 
+const (
+	// the server side encryptions of the objects
+	sseS3  = "AES256"
+	sseKMS = "aws:kms"
+
+	// minPartSizeMB is the minimum size of the parts of S3 multipart uploads
+	minPartSizeMB = 5
+	// maxObjectTags is the maximum number of tags of an S3 object
+	maxObjectTags = 10
+)
+
 // S3UploaderConfig contains aws s3 uploader related config to controls things
 // like bucket, prefix, batching, connections, retries, etc.
 type S3UploaderConfig struct {
@@ -18,6 +30,32 @@ type S3UploaderConfig struct {
 	S3Partition string `mapstructure:"s3_partition"`
 	FilePrefix  string `mapstructure:"file_prefix"`
 	Endpoint    string `mapstructure:"endpoint"`
+
+	// S3KeyTemplate overrides the keys of the objects, built from s3_prefix,
+	// s3_partition and file_prefix by default. See the README for its
+	// placeholders.
+	S3KeyTemplate string `mapstructure:"s3_key_template"`
+
+	// PartSizeMB is the size in megabytes of the parts of the uploads, the
+	// batches larger than it being uploaded in several parts.
+	PartSizeMB int64 `mapstructure:"part_size_mb"`
+	// UploadConcurrency is the number of parts of an upload sent in parallel.
+	UploadConcurrency int `mapstructure:"upload_concurrency"`
+
+	// ServerSideEncryption is the encryption of the objects at rest: AES256
+	// (SSE-S3) or aws:kms (SSE-KMS). The bucket default applies if it is empty.
+	ServerSideEncryption string `mapstructure:"server_side_encryption"`
+	// SSEKMSKeyID is the ID or ARN of the KMS key of the aws:kms encryption,
+	// the AWS managed key being used if it is empty.
+	SSEKMSKeyID string `mapstructure:"sse_kms_key_id"`
+	// BucketKeyEnabled enables the S3 Bucket Key of the aws:kms encryption.
+	BucketKeyEnabled bool `mapstructure:"bucket_key_enabled"`
+
+	// Tags are static tags added to the objects.
+	Tags map[string]string `mapstructure:"tags"`
+	// TagResourceAttributes are resource attributes added as tags to the
+	// objects, the batches being split by their values.
+	TagResourceAttributes []string `mapstructure:"tag_resource_attributes"`
 }
 
 type MarshalerType string
@@ -43,5 +81,52 @@ func (c *Config) Validate() error {
 	if c.S3Uploader.S3Bucket == "" {
 		errs = multierr.Append(errs, errors.New("bucket is required"))
 	}
+	if c.S3Uploader.S3KeyTemplate != "" {
+		if _, err := parseKeyTemplate(c.S3Uploader.S3KeyTemplate); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if c.S3Uploader.PartSizeMB < minPartSizeMB {
+		errs = multierr.Append(errs, fmt.Errorf("part_size_mb must be at least %d", minPartSizeMB))
+	}
+	if c.S3Uploader.UploadConcurrency <= 0 {
+		errs = multierr.Append(errs, errors.New("upload_concurrency must be positive"))
+	}
+	switch c.S3Uploader.ServerSideEncryption {
+	case "", sseS3:
+		if c.S3Uploader.SSEKMSKeyID != "" || c.S3Uploader.BucketKeyEnabled {
+			errs = multierr.Append(errs, errors.New("sse_kms_key_id and bucket_key_enabled require the aws:kms server_side_encryption"))
+		}
+	case sseKMS:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("unsupported server_side_encryption %q", c.S3Uploader.ServerSideEncryption))
+	}
+	if len(c.S3Uploader.Tags)+len(c.S3Uploader.TagResourceAttributes) > maxObjectTags {
+		errs = multierr.Append(errs, fmt.Errorf("objects can't have more than %d tags", maxObjectTags))
+	}
 	return errs
 }
+
+// groupResourceAttributes returns the resource attributes the batches are
+// split by, so that each object has a single value of the attributes of its
+// tags and of its key template, if any.
+func (c *Config) groupResourceAttributes(tmpl *keyTemplate) []string {
+	attributes := append([]string{}, c.S3Uploader.TagResourceAttributes...)
+	if tmpl != nil {
+		for _, attribute := range tmpl.resourceAttributes {
+			if !contains(attributes, attribute) {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+	return attributes
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -30,9 +30,11 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, e,
 		&Config{
 			S3Uploader: S3UploaderConfig{
-				Region:      "us-east-1",
-				S3Bucket:    "foo",
-				S3Partition: "minute",
+				Region:            "us-east-1",
+				S3Bucket:          "foo",
+				S3Partition:       "minute",
+				PartSizeMB:        5,
+				UploadConcurrency: 5,
 			},
 			MarshalerName: "otlp_json",
 		},
@@ -56,17 +58,58 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, e,
 		&Config{
 			S3Uploader: S3UploaderConfig{
-				Region:      "us-east-1",
-				S3Bucket:    "foo",
-				S3Prefix:    "bar",
-				S3Partition: "minute",
-				Endpoint:    "http://endpoint.com",
+				Region:            "us-east-1",
+				S3Bucket:          "foo",
+				S3Prefix:          "bar",
+				S3Partition:       "minute",
+				Endpoint:          "http://endpoint.com",
+				PartSizeMB:        5,
+				UploadConcurrency: 5,
 			},
 			MarshalerName: "otlp_json",
 		},
 	)
 }
 
+func TestConfigUpload(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[factory.Type()] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(
+		filepath.Join("testdata", "upload.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e := cfg.Exporters[component.NewID("awss3")].(*Config)
+
+	assert.Equal(t, e,
+		&Config{
+			S3Uploader: S3UploaderConfig{
+				Region:                "eu-central-1",
+				S3Bucket:              "foo",
+				S3Prefix:              "telemetry",
+				S3Partition:           "minute",
+				S3KeyTemplate:         "{prefix}/{resource.deployment.environment}/{signal}/{year}/{month}/{day}/{hour}/{random}.{format}",
+				PartSizeMB:            16,
+				UploadConcurrency:     3,
+				ServerSideEncryption:  "aws:kms",
+				SSEKMSKeyID:           "arn:aws:kms:eu-central-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+				BucketKeyEnabled:      true,
+				Tags:                  map[string]string{"team": "observability"},
+				TagResourceAttributes: []string{"service.name"},
+			},
+			MarshalerName: "otlp_json",
+		},
+	)
+	tmpl, err := parseKeyTemplate(e.S3Uploader.S3KeyTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service.name", "deployment.environment"}, e.groupResourceAttributes(tmpl))
+	assert.Equal(t, []string{"service.name"}, e.groupResourceAttributes(nil))
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -115,6 +158,60 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			errExpected: errors.New("region is required"),
 		},
+		{
+			name: "part size too small",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.PartSizeMB = 1
+				c.S3Uploader.UploadConcurrency = 0
+				return c
+			}(),
+			errExpected: multierr.Append(errors.New("part_size_mb must be at least 5"),
+				errors.New("upload_concurrency must be positive")),
+		},
+		{
+			name: "unsupported server side encryption",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.ServerSideEncryption = "aws:kms:dsse"
+				return c
+			}(),
+			errExpected: errors.New(`unsupported server_side_encryption "aws:kms:dsse"`),
+		},
+		{
+			name: "kms key without kms encryption",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.ServerSideEncryption = "AES256"
+				c.S3Uploader.SSEKMSKeyID = "key"
+				return c
+			}(),
+			errExpected: errors.New("sse_kms_key_id and bucket_key_enabled require the aws:kms server_side_encryption"),
+		},
+		{
+			name: "too many tags",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.Tags = map[string]string{"a": "1", "b": "2", "c": "3"}
+				c.S3Uploader.TagResourceAttributes = []string{"d", "e", "f", "g", "h", "i", "j", "k"}
+				return c
+			}(),
+			errExpected: errors.New("objects can't have more than 10 tags"),
+		},
+		{
+			name: "unknown key template placeholder",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.S3KeyTemplate = "{prefix}/{second}/{random}"
+				return c
+			}(),
+			errExpected: errors.New(`unknown placeholder "{second}" in s3_key_template`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,9 +239,11 @@ func TestMarshallerName(t *testing.T) {
 	assert.Equal(t, e,
 		&Config{
 			S3Uploader: S3UploaderConfig{
-				Region:      "us-east-1",
-				S3Bucket:    "foo",
-				S3Partition: "minute",
+				Region:            "us-east-1",
+				S3Bucket:          "foo",
+				S3Partition:       "minute",
+				PartSizeMB:        5,
+				UploadConcurrency: 5,
 			},
 			MarshalerName: "sumo_ic",
 		},
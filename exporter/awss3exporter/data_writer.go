@@ -6,5 +6,7 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 import "context"
 
 type dataWriter interface {
-	writeBuffer(ctx context.Context, buf []byte, config *Config, metadata string, format string) error
+	// writeBuffer writes an object of the resources with the given values of
+	// the attributes the batches are grouped by.
+	writeBuffer(ctx context.Context, buf []byte, config *Config, metadata string, format string, attributes map[string]string) error
 }
@@ -8,11 +8,16 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
 )
 
 type s3Exporter struct {
@@ -20,6 +25,8 @@ type s3Exporter struct {
 	dataWriter dataWriter
 	logger     *zap.Logger
 	marshaler  marshaler
+	// groupAttributes are the resource attributes the batches are split by.
+	groupAttributes []string
 }
 
 func newS3Exporter(config *Config,
@@ -36,11 +43,20 @@ func newS3Exporter(config *Config,
 		return nil, errors.New("unknown marshaler")
 	}
 
+	var tmpl *keyTemplate
+	if config.S3Uploader.S3KeyTemplate != "" {
+		if tmpl, err = parseKeyTemplate(config.S3Uploader.S3KeyTemplate); err != nil {
+			return nil, err
+		}
+	}
+
 	s3Exporter := &s3Exporter{
 		config:     config,
-		dataWriter: &s3Writer{},
+		dataWriter: &s3Writer{keyTemplate: tmpl},
 		logger:     logger,
 		marshaler:  m,
+
+		groupAttributes: config.groupResourceAttributes(tmpl),
 	}
 	return s3Exporter, nil
 }
@@ -50,30 +66,73 @@ func (e *s3Exporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *s3Exporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	buf, err := e.marshaler.MarshalMetrics(md)
-
+	failed, err := exportGroups(ctx, e, "metrics", resourcegroup.Metrics(md, e.groupKey), e.marshaler.MarshalMetrics,
+		func(md pmetric.Metrics) pcommon.Resource { return md.ResourceMetrics().At(0).Resource() },
+		pmetric.NewMetrics, func(src, dest pmetric.Metrics) { src.ResourceMetrics().MoveAndAppendTo(dest.ResourceMetrics()) })
 	if err != nil {
-		return err
+		return consumererror.NewMetrics(err, failed)
 	}
-
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "metrics", e.marshaler.format())
+	return nil
 }
 
 func (e *s3Exporter) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
-	buf, err := e.marshaler.MarshalLogs(logs)
-
+	failed, err := exportGroups(ctx, e, "logs", resourcegroup.Logs(logs, e.groupKey), e.marshaler.MarshalLogs,
+		func(ld plog.Logs) pcommon.Resource { return ld.ResourceLogs().At(0).Resource() },
+		plog.NewLogs, func(src, dest plog.Logs) { src.ResourceLogs().MoveAndAppendTo(dest.ResourceLogs()) })
 	if err != nil {
-		return err
+		return consumererror.NewLogs(err, failed)
 	}
-
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "logs", e.marshaler.format())
+	return nil
 }
 
 func (e *s3Exporter) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
-	buf, err := e.marshaler.MarshalTraces(traces)
+	failed, err := exportGroups(ctx, e, "traces", resourcegroup.Traces(traces, e.groupKey), e.marshaler.MarshalTraces,
+		func(td ptrace.Traces) pcommon.Resource { return td.ResourceSpans().At(0).Resource() },
+		ptrace.NewTraces, func(src, dest ptrace.Traces) { src.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans()) })
 	if err != nil {
-		return err
+		return consumererror.NewTraces(err, failed)
 	}
+	return nil
+}
+
+// groupKey returns the key of the group of the resources sharing the values of
+// the attributes the batches are split by.
+func (e *s3Exporter) groupKey(resource pcommon.Resource) string {
+	return groupKey(resource, e.groupAttributes)
+}
 
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "traces", e.marshaler.format())
+// exportGroups writes an object per group, with the values of the attributes of
+// the first resource of the group, and returns the groups which failed to be
+// written, moved together by moveTo, so that only they are retried.
+func exportGroups[T any](
+	ctx context.Context,
+	e *s3Exporter,
+	metadata string,
+	groups []resourcegroup.Group[T],
+	marshal func(T) ([]byte, error),
+	firstResource func(T) pcommon.Resource,
+	newData func() T,
+	moveTo func(src, dest T),
+) (T, error) {
+	var errs error
+	var failed []T
+	for _, group := range groups {
+		buf, err := marshal(group.Data)
+		if err == nil {
+			attributes := groupValues(firstResource(group.Data), e.groupAttributes)
+			err = e.dataWriter.writeBuffer(ctx, buf, e.config, metadata, e.marshaler.format(), attributes)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			failed = append(failed, group.Data)
+		}
+	}
+	if len(failed) == 1 {
+		return failed[0], errs
+	}
+	merged := newData()
+	for _, data := range failed {
+		moveTo(data, merged)
+	}
+	return merged, errs
 }
@@ -5,9 +5,12 @@ package awss3exporter
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 )
@@ -18,7 +21,7 @@ type TestWriter struct {
 	t *testing.T
 }
 
-func (testWriter *TestWriter) writeBuffer(_ context.Context, buf []byte, _ *Config, _ string, _ string) error {
+func (testWriter *TestWriter) writeBuffer(_ context.Context, buf []byte, _ *Config, _ string, _ string, _ map[string]string) error {
 	assert.Equal(testWriter.t, testLogs, buf)
 	return nil
 }
@@ -46,3 +49,57 @@ func TestLog(t *testing.T) {
 	exporter := getLogExporter(t)
 	assert.NoError(t, exporter.ConsumeLogs(context.Background(), logs))
 }
+
+type recordingWriter struct {
+	attributes []map[string]string
+	// failedService is the service.name of the objects failing to be written.
+	failedService string
+}
+
+func (w *recordingWriter) writeBuffer(_ context.Context, _ []byte, _ *Config, _ string, _ string, attributes map[string]string) error {
+	w.attributes = append(w.attributes, attributes)
+	if w.failedService != "" && attributes["service.name"] == w.failedService {
+		return errors.New("upload failed")
+	}
+	return nil
+}
+
+func TestLogGroupedByResourceAttributes(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.S3Uploader.TagResourceAttributes = []string{"service.name"}
+	writer := &recordingWriter{}
+	marshaler, _ := newMarshaler("otlp_json", zap.NewNop())
+	exporter := &s3Exporter{
+		config:          config,
+		dataWriter:      writer,
+		logger:          zap.NewNop(),
+		marshaler:       marshaler,
+		groupAttributes: config.groupResourceAttributes(nil),
+	}
+
+	assert.NoError(t, exporter.ConsumeLogs(context.Background(), newServiceLogs("checkout", "cart", "checkout")))
+	assert.Equal(t, []map[string]string{
+		{"service.name": "checkout"},
+		{"service.name": "cart"},
+	}, writer.attributes)
+}
+
+func TestLogGroupFailed(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.S3Uploader.TagResourceAttributes = []string{"service.name"}
+	marshaler, _ := newMarshaler("otlp_json", zap.NewNop())
+	exporter := &s3Exporter{
+		config:          config,
+		dataWriter:      &recordingWriter{failedService: "cart"},
+		logger:          zap.NewNop(),
+		marshaler:       marshaler,
+		groupAttributes: config.groupResourceAttributes(nil),
+	}
+
+	// Only the logs of the group which failed to be written are retried.
+	err := exporter.ConsumeLogs(context.Background(), newServiceLogs("checkout", "cart", "checkout", "cart"))
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	assert.EqualError(t, err, "upload failed")
+	assert.Equal(t, newServiceLogs("cart", "cart"), logsErr.Data())
+}
@@ -14,6 +14,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
 )
 
+const (
+	defaultPartSizeMB        = 5
+	defaultUploadConcurrency = 5
+)
+
 // NewFactory creates a factory for S3 exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
@@ -28,8 +33,10 @@ func NewFactory() exporter.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		S3Uploader: S3UploaderConfig{
-			Region:      "us-east-1",
-			S3Partition: "minute",
+			Region:            "us-east-1",
+			S3Partition:       "minute",
+			PartSizeMB:        defaultPartSizeMB,
+			UploadConcurrency: defaultUploadConcurrency,
 		},
 		MarshalerName: "otlp_json",
 	}
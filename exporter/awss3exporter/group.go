@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// groupKey returns a key identifying the values of the attributes of a
+// resource.
+func groupKey(resource pcommon.Resource, attributes []string) string {
+	var key strings.Builder
+	for _, attribute := range attributes {
+		if value, ok := resource.Attributes().Get(attribute); ok {
+			key.WriteString("=")
+			key.WriteString(value.AsString())
+		}
+		// The separator distinguishes the missing attributes from the empty
+		// ones.
+		key.WriteString("\x00")
+	}
+	return key.String()
+}
+
+// groupValues returns the values of the attributes of a resource.
+func groupValues(resource pcommon.Resource, attributes []string) map[string]string {
+	values := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		if value, ok := resource.Attributes().Get(attribute); ok {
+			values[attribute] = value.AsString()
+		}
+	}
+	return values
+}
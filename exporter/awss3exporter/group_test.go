@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newServiceLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if service != "" {
+			rl.Resource().Attributes().PutStr("service.name", service)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message from " + service)
+	}
+	return ld
+}
+
+func TestGroupKey(t *testing.T) {
+	ld := newServiceLogs("checkout", "", "cart")
+	rls := ld.ResourceLogs()
+	rls.At(2).Resource().Attributes().PutStr("deployment.environment", "")
+	attributes := []string{"service.name", "deployment.environment"}
+
+	assert.NotEqual(t, groupKey(rls.At(0).Resource(), attributes), groupKey(rls.At(1).Resource(), attributes))
+	// The resources without the attribute are grouped apart from the ones with an empty value.
+	assert.NotEqual(t, groupKey(rls.At(2).Resource(), attributes), groupKey(newServiceLogs("cart").ResourceLogs().At(0).Resource(), attributes))
+	assert.Equal(t, groupKey(rls.At(0).Resource(), attributes), groupKey(newServiceLogs("checkout").ResourceLogs().At(0).Resource(), attributes))
+	assert.Equal(t, "", groupKey(rls.At(0).Resource(), nil))
+
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, groupValues(rls.At(0).Resource(), attributes))
+	assert.Equal(t, map[string]string{}, groupValues(rls.At(1).Resource(), attributes))
+	assert.Equal(t, map[string]string{"service.name": "cart", "deployment.environment": ""}, groupValues(rls.At(2).Resource(), attributes))
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	resourcePlaceholderPrefix = "resource."
+	// unknownAttributeValue replaces the resource attributes missing from the
+	// resources of an object in its key.
+	unknownAttributeValue = "unknown"
+)
+
+var keyPlaceholder = regexp.MustCompile(`{([^{}]*)}`)
+
+// keyPlaceholders are the placeholders of the key templates, besides the
+// resource attributes.
+var keyPlaceholders = map[string]bool{
+	"prefix":      true,
+	"file_prefix": true,
+	"signal":      true,
+	"format":      true,
+	"year":        true,
+	"month":       true,
+	"day":         true,
+	"hour":        true,
+	"minute":      true,
+	"random":      true,
+}
+
+// keyTemplate builds the keys of the objects from a template such as
+// {prefix}/{resource.service.name}/{year}/{month}/{day}/{signal}_{random}.{format}.
+type keyTemplate struct {
+	template           string
+	resourceAttributes []string
+}
+
+func parseKeyTemplate(template string) (*keyTemplate, error) {
+	kt := &keyTemplate{template: template}
+	for _, match := range keyPlaceholder.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		switch {
+		case keyPlaceholders[name]:
+		case strings.HasPrefix(name, resourcePlaceholderPrefix) && len(name) > len(resourcePlaceholderPrefix):
+			attribute := strings.TrimPrefix(name, resourcePlaceholderPrefix)
+			if !contains(kt.resourceAttributes, attribute) {
+				kt.resourceAttributes = append(kt.resourceAttributes, attribute)
+			}
+		default:
+			return nil, fmt.Errorf("unknown placeholder %q in s3_key_template", match[0])
+		}
+	}
+	if !strings.Contains(template, "{random}") {
+		// Objects would overwrite each other.
+		return nil, fmt.Errorf("s3_key_template must contain the {random} placeholder")
+	}
+	return kt, nil
+}
+
+// render returns the key of an object with the given resource attributes.
+func (kt *keyTemplate) render(now time.Time, config *S3UploaderConfig, signal string, format string, attributes map[string]string) string {
+	year, month, day := now.Date()
+	return keyPlaceholder.ReplaceAllStringFunc(kt.template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		switch name {
+		case "prefix":
+			return config.S3Prefix
+		case "file_prefix":
+			return config.FilePrefix
+		case "signal":
+			return signal
+		case "format":
+			return format
+		case "year":
+			return strconv.Itoa(year)
+		case "month":
+			return fmt.Sprintf("%02d", month)
+		case "day":
+			return fmt.Sprintf("%02d", day)
+		case "hour":
+			return fmt.Sprintf("%02d", now.Hour())
+		case "minute":
+			return fmt.Sprintf("%02d", now.Minute())
+		case "random":
+			return strconv.Itoa(randomInRange(100000000, 999999999))
+		}
+		if value, ok := attributes[strings.TrimPrefix(name, resourcePlaceholderPrefix)]; ok && value != "" {
+			return value
+		}
+		return unknownAttributeValue
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyTemplate(t *testing.T) {
+	kt, err := parseKeyTemplate("{prefix}/{resource.service.name}/{resource.k8s.namespace.name}/{resource.service.name}-{random}.{format}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service.name", "k8s.namespace.name"}, kt.resourceAttributes)
+
+	_, err = parseKeyTemplate("{prefix}/{resource.}/{random}")
+	assert.EqualError(t, err, `unknown placeholder "{resource.}" in s3_key_template`)
+
+	_, err = parseKeyTemplate("{prefix}/{signal}.{format}")
+	assert.EqualError(t, err, "s3_key_template must contain the {random} placeholder")
+}
+
+func TestRenderKeyTemplate(t *testing.T) {
+	kt, err := parseKeyTemplate("{prefix}/{resource.deployment.environment}/{resource.service.name}/{year}/{month}/{day}/{hour}/{minute}/{file_prefix}{signal}_{random}.{format}")
+	require.NoError(t, err)
+
+	now := time.Date(2023, 6, 5, 7, 8, 0, 0, time.UTC)
+	config := &S3UploaderConfig{S3Prefix: "telemetry", FilePrefix: "collector-"}
+	key := kt.render(now, config, "logs", "json", map[string]string{"service.name": "checkout"})
+
+	// The missing attributes are replaced with unknown.
+	re := regexp.MustCompile(`^telemetry/unknown/checkout/2023/06/05/07/08/collector-logs_[0-9]+\.json$`)
+	assert.Regexp(t, re, key)
+}
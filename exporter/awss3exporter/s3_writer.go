@@ -7,7 +7,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
+	"net/url"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -17,6 +20,8 @@ import (
 )
 
 type s3Writer struct {
+	// keyTemplate builds the keys of the objects, if configured.
+	keyTemplate *keyTemplate
 }
 
 // generate the s3 time key based on partition configuration
@@ -59,11 +64,70 @@ func getSessionConfig(config *Config) *aws.Config {
 	return sessionConfig
 }
 
-func (s3writer *s3Writer) writeBuffer(_ context.Context, buf []byte, config *Config, metadata string, format string) error {
+// getObjectKey returns the key of an object, built from tmpl if not nil.
+func getObjectKey(now time.Time, config *S3UploaderConfig, tmpl *keyTemplate, metadata string, format string, attributes map[string]string) string {
+	if tmpl == nil {
+		return getS3Key(now, config.S3Prefix, config.S3Partition, config.FilePrefix, metadata, format)
+	}
+	return tmpl.render(now, config, metadata, format, attributes)
+}
+
+// invalidTagCharacter matches the characters which are not allowed in the
+// tags of S3 objects.
+var invalidTagCharacter = regexp.MustCompile(`[^\p{L}\p{Z}\p{N}_.:/=+\-@]`)
+
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+func sanitizeTag(s string, maxLength int) string {
+	s = invalidTagCharacter.ReplaceAllString(s, "_")
+	if runes := []rune(s); len(runes) > maxLength {
+		s = string(runes[:maxLength])
+	}
+	return s
+}
+
+// getObjectTagging returns the URL encoded tags of an object, from the static
+// tags and the resource attributes the object has.
+func getObjectTagging(config *S3UploaderConfig, attributes map[string]string) string {
+	tags := url.Values{}
+	for key, value := range config.Tags {
+		tags.Set(sanitizeTag(key, maxTagKeyLength), sanitizeTag(value, maxTagValueLength))
+	}
+	for _, attribute := range config.TagResourceAttributes {
+		if value, ok := attributes[attribute]; ok {
+			tags.Set(sanitizeTag(attribute, maxTagKeyLength), sanitizeTag(value, maxTagValueLength))
+		}
+	}
+	return tags.Encode()
+}
+
+func getUploadInput(config *S3UploaderConfig, key string, body io.Reader, attributes map[string]string) *s3manager.UploadInput {
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(config.S3Bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}
+	if config.ServerSideEncryption != "" {
+		input.ServerSideEncryption = aws.String(config.ServerSideEncryption)
+	}
+	if config.SSEKMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(config.SSEKMSKeyID)
+	}
+	if config.BucketKeyEnabled {
+		input.BucketKeyEnabled = aws.Bool(true)
+	}
+	if tagging := getObjectTagging(config, attributes); tagging != "" {
+		input.Tagging = aws.String(tagging)
+	}
+	return input
+}
+
+func (s3writer *s3Writer) writeBuffer(ctx context.Context, buf []byte, config *Config, metadata string, format string, attributes map[string]string) error {
 	now := time.Now()
-	key := getS3Key(now,
-		config.S3Uploader.S3Prefix, config.S3Uploader.S3Partition,
-		config.S3Uploader.FilePrefix, metadata, format)
+	key := getObjectKey(now, &config.S3Uploader, s3writer.keyTemplate, metadata, format, attributes)
 
 	// create a reader from data data in memory
 	reader := bytes.NewReader(buf)
@@ -75,13 +139,13 @@ func (s3writer *s3Writer) writeBuffer(_ context.Context, buf []byte, config *Con
 		return err
 	}
 
-	uploader := s3manager.NewUploader(sess)
-
-	_, err = uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(config.S3Uploader.S3Bucket),
-		Key:    aws.String(key),
-		Body:   reader,
+	// the batches larger than a part are uploaded in several parts
+	uploader := s3manager.NewUploader(sess, func(u *s3manager.Uploader) {
+		u.PartSize = config.S3Uploader.PartSizeMB * 1024 * 1024
+		u.Concurrency = config.S3Uploader.UploadConcurrency
 	})
+
+	_, err = uploader.UploadWithContext(ctx, getUploadInput(&config.S3Uploader, key, reader, attributes))
 	if err != nil {
 		return err
 	}
@@ -66,3 +66,40 @@ func TestGetSessionConfigNoEndpoint(t *testing.T) {
 	assert.Empty(t, sessionConfig.Endpoint)
 	assert.Equal(t, sessionConfig.Region, aws.String(region))
 }
+
+func TestGetObjectKeyWithTemplate(t *testing.T) {
+	config := &S3UploaderConfig{
+		S3Prefix:      "telemetry",
+		S3KeyTemplate: "{prefix}/{resource.service.name}/{signal}_{random}.{format}",
+	}
+	tmpl, err := parseKeyTemplate(config.S3KeyTemplate)
+	require.NoError(t, err)
+	key := getObjectKey(time.Now(), config, tmpl, "traces", "json", map[string]string{"service.name": "checkout"})
+	assert.Regexp(t, `^telemetry/checkout/traces_[0-9]+\.json$`, key)
+}
+
+func TestGetUploadInput(t *testing.T) {
+	config := &S3UploaderConfig{
+		S3Bucket:              "bucket",
+		ServerSideEncryption:  sseKMS,
+		SSEKMSKeyID:           "key",
+		BucketKeyEnabled:      true,
+		Tags:                  map[string]string{"team": "observability", "cost center": "a&b"},
+		TagResourceAttributes: []string{"service.name", "deployment.environment"},
+	}
+	input := getUploadInput(config, "key", nil, map[string]string{"service.name": "checkout"})
+	assert.Equal(t, aws.String("bucket"), input.Bucket)
+	assert.Equal(t, aws.String("key"), input.Key)
+	assert.Equal(t, aws.String("aws:kms"), input.ServerSideEncryption)
+	assert.Equal(t, aws.String("key"), input.SSEKMSKeyId)
+	assert.Equal(t, aws.Bool(true), input.BucketKeyEnabled)
+	// The characters S3 doesn't allow in tags are replaced, and the missing
+	// attributes are not tagged.
+	assert.Equal(t, aws.String("cost+center=a_b&service.name=checkout&team=observability"), input.Tagging)
+
+	input = getUploadInput(&S3UploaderConfig{S3Bucket: "bucket"}, "key", nil, nil)
+	assert.Nil(t, input.ServerSideEncryption)
+	assert.Nil(t, input.SSEKMSKeyId)
+	assert.Nil(t, input.BucketKeyEnabled)
+	assert.Nil(t, input.Tagging)
+}
@@ -12,8 +12,31 @@ import (
 
 // Defaults for not specified configuration settings.
 const (
-	DefaultEndpoint    = "localhost:2003"
-	DefaultSendTimeout = 5 * time.Second
+	DefaultEndpoint                 = "localhost:2003"
+	DefaultSendTimeout              = 5 * time.Second
+	DefaultTagMode                  = TagModeTags
+	DefaultProtocol                 = ProtocolPlaintext
+	DefaultMaxBatchSize             = 1000
+	DefaultMaxConnections           = 4
+	DefaultReconnectInitialInterval = 100 * time.Millisecond
+	DefaultReconnectMaxInterval     = 30 * time.Second
+)
+
+// Tag modes, defining how the attributes of the data points are added to the Carbon metric paths.
+const (
+	// TagModeTags adds the attributes as Graphite tags, ie.: "<metric_name>;key0=val0;key1=val1".
+	TagModeTags = "tags"
+	// TagModePath adds the attributes as nodes of the metric path, for Graphite versions without tags support,
+	// ie.: "<metric_name>.key0.val0.key1.val1".
+	TagModePath = "path"
+)
+
+// Protocols supported to send the metrics to Carbon.
+const (
+	// ProtocolPlaintext sends the metrics as lines of text.
+	ProtocolPlaintext = "plaintext"
+	// ProtocolPickle sends the metrics as pickled batches, which Carbon accepts on a dedicated port (2004 by default).
+	ProtocolPickle = "pickle"
 )
 
 // Config defines configuration for Carbon exporter.
@@ -27,6 +50,38 @@ type Config struct {
 	// data to the Carbon/Graphite backend.
 	// The default value is defined by the DefaultSendTimeout constant.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TagMode defines how the attributes of the data points are added to the
+	// metric paths, either "tags" or "path".
+	// The default value is defined by the DefaultTagMode constant.
+	TagMode string `mapstructure:"tag_mode"`
+
+	// Protocol used to send the metrics, either "plaintext" or "pickle".
+	// The default value is defined by the DefaultProtocol constant.
+	Protocol string `mapstructure:"protocol"`
+
+	// MaxBatchSize is the maximum number of Carbon metrics sent in a single
+	// write, the batches of an export being sent concurrently.
+	// The default value is defined by the DefaultMaxBatchSize constant.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// MaxConnections is the maximum number of connections to the Carbon
+	// backend open at the same time.
+	// The default value is defined by the DefaultMaxConnections constant.
+	MaxConnections int `mapstructure:"max_connections"`
+
+	// ReconnectBackoff defines how long the exporter waits before connecting
+	// again to the Carbon backend after a failed connection.
+	ReconnectBackoff ReconnectBackoffSettings `mapstructure:"reconnect_backoff"`
+}
+
+// ReconnectBackoffSettings defines the exponential backoff between the attempts to connect to the Carbon backend.
+type ReconnectBackoffSettings struct {
+	// InitialInterval is the time to wait after the first failed connection.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+
+	// MaxInterval is the upper bound of the time to wait between connections.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
 func (cfg *Config) Validate() error {
@@ -41,5 +96,29 @@ func (cfg *Config) Validate() error {
 		return errors.New("exporter requires a positive timeout")
 	}
 
+	switch cfg.TagMode {
+	case TagModeTags, TagModePath:
+	default:
+		return fmt.Errorf("exporter has an unsupported tag_mode %q, must be %q or %q", cfg.TagMode, TagModeTags, TagModePath)
+	}
+
+	switch cfg.Protocol {
+	case ProtocolPlaintext, ProtocolPickle:
+	default:
+		return fmt.Errorf("exporter has an unsupported protocol %q, must be %q or %q", cfg.Protocol, ProtocolPlaintext, ProtocolPickle)
+	}
+
+	if cfg.MaxBatchSize <= 0 {
+		return errors.New("exporter requires a positive max_batch_size")
+	}
+
+	if cfg.MaxConnections <= 0 {
+		return errors.New("exporter requires a positive max_connections")
+	}
+
+	if cfg.ReconnectBackoff.InitialInterval < 0 || cfg.ReconnectBackoff.MaxInterval < cfg.ReconnectBackoff.InitialInterval {
+		return errors.New("exporter requires a reconnect_backoff max_interval greater than or equal to its positive initial_interval")
+	}
+
 	return nil
 }
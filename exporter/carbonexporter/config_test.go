@@ -35,8 +35,16 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "allsettings"),
 			expected: &Config{
-				Endpoint: "localhost:8080",
-				Timeout:  10 * time.Second,
+				Endpoint:       "localhost:8080",
+				Timeout:        10 * time.Second,
+				TagMode:        TagModePath,
+				Protocol:       ProtocolPickle,
+				MaxBatchSize:   500,
+				MaxConnections: 8,
+				ReconnectBackoff: ReconnectBackoffSettings{
+					InitialInterval: time.Second,
+					MaxInterval:     time.Minute,
+				},
 			},
 		},
 	}
@@ -80,6 +88,51 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid_tag_mode",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.TagMode = "labels"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid_protocol",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Protocol = "udp"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid_max_batch_size",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.MaxBatchSize = 0
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid_max_connections",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.MaxConnections = -1
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid_reconnect_backoff",
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.ReconnectBackoff.MaxInterval = time.Millisecond
+				return cfg
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
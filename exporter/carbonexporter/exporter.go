@@ -5,20 +5,21 @@ package carbonexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 )
 
 // newCarbonExporter returns a new Carbon exporter.
 func newCarbonExporter(cfg *Config, set exporter.CreateSettings) (exporter.Metrics, error) {
-	sender := carbonSender{
-		connPool: newTCPConnPool(cfg.Endpoint, cfg.Timeout),
-	}
+	sender := newCarbonSender(cfg)
 
 	return exporterhelper.NewMetricsExporter(
 		context.TODO(),
@@ -32,18 +33,62 @@ func newCarbonExporter(cfg *Config, set exporter.CreateSettings) (exporter.Metri
 // connections into an implementations of exporterhelper.PushMetricsData so
 // the exporter can leverage the helper and get consistent observability.
 type carbonSender struct {
-	connPool *connPool
+	connPool     *connPool
+	paths        pathBuilder
+	encode       func([]carbonMetric) ([]byte, error)
+	maxBatchSize int
+}
+
+func newCarbonSender(cfg *Config) *carbonSender {
+	cs := &carbonSender{
+		connPool:     newTCPConnPool(cfg.Endpoint, cfg.Timeout, cfg.MaxConnections, cfg.ReconnectBackoff),
+		paths:        newPathBuilder(cfg.TagMode),
+		encode:       encodePickle,
+		maxBatchSize: cfg.MaxBatchSize,
+	}
+	if cfg.Protocol != ProtocolPickle {
+		cs.encode = func(metrics []carbonMetric) ([]byte, error) {
+			return encodePlaintext(metrics), nil
+		}
+	}
+	return cs
 }
 
+// pushMetricsData splits the metrics in batches of at most maxBatchSize Carbon
+// metrics, which are written concurrently to the connections of the pool.
 func (cs *carbonSender) pushMetricsData(_ context.Context, md pmetric.Metrics) error {
-	lines := metricDataToPlaintext(md)
+	metrics := metricDataToCarbon(md, cs.paths)
+
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		errs error
+	)
+	for start := 0; start < len(metrics); start += cs.maxBatchSize {
+		end := start + cs.maxBatchSize
+		if end > len(metrics) {
+			end = len(metrics)
+		}
 
-	if _, err := cs.connPool.Write([]byte(lines)); err != nil {
-		// Use the sum of converted and dropped since the write failed for all.
-		return err
+		payload, err := cs.encode(metrics[start:end])
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cs.connPool.Write(payload); err != nil {
+				mtx.Lock()
+				errs = multierr.Append(errs, err)
+				mtx.Unlock()
+			}
+		}()
 	}
+	wg.Wait()
 
-	return nil
+	return errs
 }
 
 func (cs *carbonSender) Shutdown(context.Context) error {
@@ -57,23 +102,41 @@ func (cs *carbonSender) Shutdown(context.Context) error {
 // https://github.com/signalfx/gateway/blob/master/protocol/carbon/conn_pool.go
 // but not its implementation).
 //
-// It keeps a unbounded "stack" of TCPConn instances always "popping" the most
-// recently returned to the pool. There is no accounting to terminating old
-// unused connections as that was the case on the prior art mentioned above.
+// It keeps a "stack" of TCPConn instances always "popping" the most
+// recently returned to the pool, at most maxConns of them being in use at the
+// same time. There is no accounting to terminating old unused connections as
+// that was the case on the prior art mentioned above.
+//
+// After a failed connection, no new connection is attempted until the
+// reconnect backoff elapsed, the writes failing right away in the meantime.
 type connPool struct {
 	mtx      sync.Mutex
 	conns    []*net.TCPConn
+	closed   bool
 	endpoint string
 	timeout  time.Duration
+	slots    chan struct{}
+	backoff  *backoff.ExponentialBackOff
+	nextDial time.Time
 }
 
 func newTCPConnPool(
 	endpoint string,
 	timeout time.Duration,
+	maxConns int,
+	reconnect ReconnectBackoffSettings,
 ) *connPool {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = reconnect.InitialInterval
+	b.MaxInterval = reconnect.MaxInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+
 	return &connPool{
 		endpoint: endpoint,
 		timeout:  timeout,
+		slots:    make(chan struct{}, maxConns),
+		backoff:  b,
 	}
 }
 
@@ -81,11 +144,18 @@ func (cp *connPool) Write(bytes []byte) (int, error) {
 	var conn *net.TCPConn
 	var err error
 
+	cp.slots <- struct{}{}
+	defer func() { <-cp.slots }()
+
 	// The deferred function below is what puts back connections on the pool.
 	defer func() {
 		if err == nil {
 			cp.mtx.Lock()
-			cp.conns = append(cp.conns, conn)
+			if cp.closed {
+				conn.Close()
+			} else {
+				cp.conns = append(cp.conns, conn)
+			}
 			cp.mtx.Unlock()
 		} else if conn != nil {
 			conn.Close()
@@ -142,12 +212,26 @@ func (cp *connPool) Close() {
 		conn.Close()
 	}
 	cp.conns = nil
+	cp.closed = true
 }
 
 func (cp *connPool) createTCPConn() (*net.TCPConn, error) {
+	cp.mtx.Lock()
+	wait := time.Until(cp.nextDial)
+	cp.mtx.Unlock()
+	if wait > 0 {
+		return nil, fmt.Errorf("waiting %s before reconnecting to %s", wait.Round(time.Millisecond), cp.endpoint)
+	}
+
 	c, err := net.DialTimeout("tcp", cp.endpoint, cp.timeout)
+
+	cp.mtx.Lock()
+	defer cp.mtx.Unlock()
 	if err != nil {
+		cp.nextDial = time.Now().Add(cp.backoff.NextBackOff())
 		return nil, err
 	}
-	return c.(*net.TCPConn), err
+	cp.backoff.Reset()
+	cp.nextDial = time.Time{}
+	return c.(*net.TCPConn), nil
 }
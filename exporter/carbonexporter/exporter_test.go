@@ -5,7 +5,9 @@ package carbonexporter
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -169,8 +171,10 @@ func Test_connPool_Concurrency(t *testing.T) {
 
 	startCh := make(chan struct{})
 
-	cp := newTCPConnPool(addr, 500*time.Millisecond)
-	sender := carbonSender{connPool: cp}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.Timeout = 500 * time.Millisecond
+	sender := newCarbonSender(cfg)
 	ctx := context.Background()
 	md := generateLargeBatch()
 	concurrentWriters := 3
@@ -231,6 +235,89 @@ func Test_connPool_Concurrency(t *testing.T) {
 	recvWG.Wait()
 }
 
+func TestPushMetricsDataPickleBatches(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.Protocol = ProtocolPickle
+	cfg.MaxBatchSize = 2
+	cfg.MaxConnections = 1
+	sender := newCarbonSender(cfg)
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for i := 0; i < 5; i++ {
+		m := ms.AppendEmpty()
+		m.SetName("gauge_" + strconv.Itoa(i))
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(int64(i))
+	}
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, acceptErr := ln.Accept()
+		if acceptErr != nil {
+			return
+		}
+		defer conn.Close()
+		var payloads []byte
+		header := make([]byte, 4)
+		for i := 0; i < 3; i++ {
+			_, readErr := io.ReadFull(conn, header)
+			require.NoError(t, readErr)
+			payload := make([]byte, binary.BigEndian.Uint32(header))
+			_, readErr = io.ReadFull(conn, payload)
+			require.NoError(t, readErr)
+			payloads = append(payloads, header...)
+			payloads = append(payloads, payload...)
+		}
+		received <- payloads
+	}()
+
+	require.NoError(t, sender.pushMetricsData(context.Background(), md))
+
+	// The batches are written concurrently, in any order, on the only connection.
+	payloads := <-received
+	metrics := metricDataToCarbon(md, taggedPathBuilder{})
+	for _, batch := range [][]carbonMetric{metrics[0:2], metrics[2:4], metrics[4:5]} {
+		want, err := encodePickle(batch)
+		require.NoError(t, err)
+		assert.True(t, bytes.Contains(payloads, want), "missing batch %v", batch)
+	}
+	assert.NoError(t, sender.Shutdown(context.Background()))
+}
+
+func TestConnPoolReconnectBackoff(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cp := newTCPConnPool(addr, time.Second, 1, ReconnectBackoffSettings{
+		InitialInterval: time.Hour,
+		MaxInterval:     time.Hour,
+	})
+	defer cp.Close()
+
+	// Nothing listens on the address, the connection fails.
+	_, err := cp.Write([]byte("a 1 1574092046\n"))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "before reconnecting")
+
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// The backoff didn't elapse, no connection is attempted.
+	_, err = cp.Write([]byte("a 1 1574092046\n"))
+	assert.ErrorContains(t, err, "before reconnecting to "+addr)
+
+	cp.mtx.Lock()
+	cp.nextDial = time.Now()
+	cp.mtx.Unlock()
+	_, err = cp.Write([]byte("a 1 1574092046\n"))
+	assert.NoError(t, err)
+}
+
 func generateLargeBatch() pmetric.Metrics {
 	ts := time.Now()
 	metrics := pmetric.NewMetrics()
@@ -22,8 +22,16 @@ func NewFactory() exporter.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Endpoint: DefaultEndpoint,
-		Timeout:  DefaultSendTimeout,
+		Endpoint:       DefaultEndpoint,
+		Timeout:        DefaultSendTimeout,
+		TagMode:        DefaultTagMode,
+		Protocol:       DefaultProtocol,
+		MaxBatchSize:   DefaultMaxBatchSize,
+		MaxConnections: DefaultMaxConnections,
+		ReconnectBackoff: ReconnectBackoffSettings{
+			InitialInterval: DefaultReconnectInitialInterval,
+			MaxInterval:     DefaultReconnectMaxInterval,
+		},
 	}
 }
 
@@ -22,14 +22,12 @@ const (
 	tagValueNotSetPlaceholder = "<null>"
 
 	// Constants used when converting from distribution metrics to Carbon format.
-	distributionBucketSuffix             = ".bucket"
-	distributionUpperBoundTagKey         = "upper_bound"
-	distributionUpperBoundTagBeforeValue = tagPrefix + distributionUpperBoundTagKey + tagKeyValueSeparator
+	distributionBucketSuffix     = ".bucket"
+	distributionUpperBoundTagKey = "upper_bound"
 
 	// Constants used when converting from summary metrics to Carbon format.
-	summaryQuantileSuffix         = ".quantile"
-	summaryQuantileTagKey         = "quantile"
-	summaryQuantileTagBeforeValue = tagPrefix + summaryQuantileTagKey + tagKeyValueSeparator
+	summaryQuantileSuffix = ".quantile"
+	summaryQuantileTagKey = "quantile"
 
 	// Suffix to be added to original metric name for a Carbon metric representing
 	// a count metric for either distribution or summary metrics.
@@ -38,8 +36,71 @@ const (
 	// Textual representation for positive infinity valid in Carbon, ie.:
 	// positive infinity as represented in Python.
 	infinityCarbonValue = "inf"
+
+	// Constants used to build the metric paths with TagModePath.
+	pathNodeSeparator        = "."
+	pathNodeEmptyPlaceholder = "_"
 )
 
+// carbonMetric is a single Carbon metric, with its value and timestamp
+// already formatted.
+type carbonMetric struct {
+	path      string
+	value     string
+	timestamp string
+}
+
+// pathBuilder builds the <path> of the Carbon metrics from the name and the
+// attributes of the data points, as per a tag mode.
+type pathBuilder interface {
+	// build returns the path of the metric with the given name and attributes.
+	build(name string, attributes pcommon.Map) string
+	// withTag adds the given tag to a path returned by build.
+	withTag(path string, key string, value string) string
+}
+
+func newPathBuilder(tagMode string) pathBuilder {
+	if tagMode == TagModePath {
+		return hierarchicalPathBuilder{}
+	}
+	return taggedPathBuilder{}
+}
+
+// taggedPathBuilder adds the attributes as Graphite tags to the paths.
+type taggedPathBuilder struct{}
+
+func (taggedPathBuilder) build(name string, attributes pcommon.Map) string {
+	return buildPath(name, attributes)
+}
+
+func (taggedPathBuilder) withTag(path string, key string, value string) string {
+	return path + tagPrefix + key + tagKeyValueSeparator + value
+}
+
+// hierarchicalPathBuilder adds the attributes as key and value nodes to the
+// paths, for the Graphite backends without tags support.
+type hierarchicalPathBuilder struct{}
+
+func (hierarchicalPathBuilder) build(name string, attributes pcommon.Map) string {
+	if attributes.Len() == 0 {
+		return name
+	}
+
+	var sb strings.Builder
+	sb.WriteString(name)
+
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		sb.WriteString(pathNodeSeparator + sanitizePathNode(k) + pathNodeSeparator + sanitizePathNode(v.AsString()))
+		return true
+	})
+
+	return sb.String()
+}
+
+func (hierarchicalPathBuilder) withTag(path string, key string, value string) string {
+	return path + pathNodeSeparator + sanitizePathNode(key) + pathNodeSeparator + sanitizePathNode(value)
+}
+
 // metricDataToPlaintext converts internal metrics data to the Carbon plaintext
 // format as defined in https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol)
 // and https://graphite.readthedocs.io/en/latest/tags.html#carbon. See details
@@ -70,11 +131,18 @@ const (
 //   - number of time series successfully converted to carbon.
 //   - number of time series that could not be converted to Carbon.
 func metricDataToPlaintext(md pmetric.Metrics) string {
+	return string(encodePlaintext(metricDataToCarbon(md, taggedPathBuilder{})))
+}
+
+// metricDataToCarbon converts internal metrics data to Carbon metrics, whose
+// paths are built by the given path builder. See metricDataToPlaintext for
+// the details of the conversion.
+func metricDataToCarbon(md pmetric.Metrics, paths pathBuilder) []carbonMetric {
 	if md.DataPointCount() == 0 {
-		return ""
+		return nil
 	}
 
-	var sb strings.Builder
+	metrics := make([]carbonMetric, 0, md.DataPointCount())
 
 	for i := 0; i < md.ResourceMetrics().Len(); i++ {
 		rm := md.ResourceMetrics().At(i)
@@ -88,22 +156,32 @@ func metricDataToPlaintext(md pmetric.Metrics) string {
 				}
 				switch metric.Type() {
 				case pmetric.MetricTypeGauge:
-					formatNumberDataPoints(&sb, metric.Name(), metric.Gauge().DataPoints())
+					metrics = formatNumberDataPoints(metrics, paths, metric.Name(), metric.Gauge().DataPoints())
 				case pmetric.MetricTypeSum:
-					formatNumberDataPoints(&sb, metric.Name(), metric.Sum().DataPoints())
+					metrics = formatNumberDataPoints(metrics, paths, metric.Name(), metric.Sum().DataPoints())
 				case pmetric.MetricTypeHistogram:
-					formatHistogramDataPoints(&sb, metric.Name(), metric.Histogram().DataPoints())
+					metrics = formatHistogramDataPoints(metrics, paths, metric.Name(), metric.Histogram().DataPoints())
 				case pmetric.MetricTypeSummary:
-					formatSummaryDataPoints(&sb, metric.Name(), metric.Summary().DataPoints())
+					metrics = formatSummaryDataPoints(metrics, paths, metric.Name(), metric.Summary().DataPoints())
 				}
 			}
 		}
 	}
 
-	return sb.String()
+	return metrics
+}
+
+// encodePlaintext encodes the metrics as per the Carbon plaintext protocol,
+// ie.: one line per metric.
+func encodePlaintext(metrics []carbonMetric) []byte {
+	var sb strings.Builder
+	for _, m := range metrics {
+		sb.WriteString(buildLine(m.path, m.value, m.timestamp))
+	}
+	return []byte(sb.String())
 }
 
-func formatNumberDataPoints(sb *strings.Builder, metricName string, dps pmetric.NumberDataPointSlice) {
+func formatNumberDataPoints(metrics []carbonMetric, paths pathBuilder, metricName string, dps pmetric.NumberDataPointSlice) []carbonMetric {
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
 		var valueStr string
@@ -113,12 +191,13 @@ func formatNumberDataPoints(sb *strings.Builder, metricName string, dps pmetric.
 		case pmetric.NumberDataPointValueTypeDouble:
 			valueStr = formatFloatForValue(dp.DoubleValue())
 		}
-		sb.WriteString(buildLine(buildPath(metricName, dp.Attributes()), valueStr, formatTimestamp(dp.Timestamp())))
+		metrics = append(metrics, carbonMetric{paths.build(metricName, dp.Attributes()), valueStr, formatTimestamp(dp.Timestamp())})
 	}
+	return metrics
 }
 
 // formatHistogramDataPoints transforms a slice of histogram data points into a series
-// of Carbon metrics and injects them into the string builder.
+// of Carbon metrics and appends them to the given metrics.
 //
 // Carbon doesn't have direct support to distribution metrics they will be
 // translated into a series of Carbon metrics:
@@ -132,15 +211,16 @@ func formatNumberDataPoints(sb *strings.Builder, metricName string, dps pmetric.
 // that bucket. This metric specifies the number of events with a value that is
 // less than or equal to the upper bound.
 func formatHistogramDataPoints(
-	sb *strings.Builder,
+	metrics []carbonMetric,
+	paths pathBuilder,
 	metricName string,
 	dps pmetric.HistogramDataPointSlice,
-) {
+) []carbonMetric {
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
 
 		timestampStr := formatTimestamp(dp.Timestamp())
-		formatCountAndSum(sb, metricName, dp.Attributes(), dp.Count(), dp.Sum(), timestampStr)
+		metrics = formatCountAndSum(metrics, paths, metricName, dp.Attributes(), dp.Count(), dp.Sum(), timestampStr)
 		if dp.ExplicitBounds().Len() == 0 {
 			continue
 		}
@@ -152,15 +232,19 @@ func formatHistogramDataPoints(
 		}
 		carbonBounds[len(carbonBounds)-1] = infinityCarbonValue
 
-		bucketPath := buildPath(metricName+distributionBucketSuffix, dp.Attributes())
+		bucketPath := paths.build(metricName+distributionBucketSuffix, dp.Attributes())
 		for j := 0; j < dp.BucketCounts().Len(); j++ {
-			sb.WriteString(buildLine(bucketPath+distributionUpperBoundTagBeforeValue+carbonBounds[j], formatUint64(dp.BucketCounts().At(j)), timestampStr))
+			metrics = append(metrics, carbonMetric{
+				paths.withTag(bucketPath, distributionUpperBoundTagKey, carbonBounds[j]),
+				formatUint64(dp.BucketCounts().At(j)),
+				timestampStr})
 		}
 	}
+	return metrics
 }
 
 // formatSummaryDataPoints transforms a slice of summary data points into a series
-// of Carbon metrics and injects them into the string builder.
+// of Carbon metrics and appends them to the given metrics.
 //
 // Carbon doesn't have direct support to summary metrics they will be
 // translated into a series of Carbon metrics:
@@ -172,28 +256,30 @@ func formatHistogramDataPoints(
 // 3. Each quantile is represented by a metric named "<metricName>.quantile"
 // and will include a tag key "quantile" that specifies the quantile value.
 func formatSummaryDataPoints(
-	sb *strings.Builder,
+	metrics []carbonMetric,
+	paths pathBuilder,
 	metricName string,
 	dps pmetric.SummaryDataPointSlice,
-) {
+) []carbonMetric {
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
 
 		timestampStr := formatTimestamp(dp.Timestamp())
-		formatCountAndSum(sb, metricName, dp.Attributes(), dp.Count(), dp.Sum(), timestampStr)
+		metrics = formatCountAndSum(metrics, paths, metricName, dp.Attributes(), dp.Count(), dp.Sum(), timestampStr)
 
 		if dp.QuantileValues().Len() == 0 {
 			continue
 		}
 
-		quantilePath := buildPath(metricName+summaryQuantileSuffix, dp.Attributes())
+		quantilePath := paths.build(metricName+summaryQuantileSuffix, dp.Attributes())
 		for j := 0; j < dp.QuantileValues().Len(); j++ {
-			sb.WriteString(buildLine(
-				quantilePath+summaryQuantileTagBeforeValue+formatFloatForLabel(dp.QuantileValues().At(j).Quantile()*100),
+			metrics = append(metrics, carbonMetric{
+				paths.withTag(quantilePath, summaryQuantileTagKey, formatFloatForLabel(dp.QuantileValues().At(j).Quantile()*100)),
 				formatFloatForValue(dp.QuantileValues().At(j).Value()),
-				timestampStr))
+				timestampStr})
 		}
 	}
+	return metrics
 }
 
 // Carbon doesn't have direct support to distribution or summary metrics in both
@@ -204,21 +290,22 @@ func formatSummaryDataPoints(
 //
 // 2. The total sum will be represented by a metruc with the original "<metricName>".
 func formatCountAndSum(
-	sb *strings.Builder,
+	metrics []carbonMetric,
+	paths pathBuilder,
 	metricName string,
 	attributes pcommon.Map,
 	count uint64,
 	sum float64,
 	timestampStr string,
-) {
+) []carbonMetric {
 	// Build count and sum metrics.
-	countPath := buildPath(metricName+countSuffix, attributes)
+	countPath := paths.build(metricName+countSuffix, attributes)
 	valueStr := formatUint64(count)
-	sb.WriteString(buildLine(countPath, valueStr, timestampStr))
+	metrics = append(metrics, carbonMetric{countPath, valueStr, timestampStr})
 
-	sumPath := buildPath(metricName, attributes)
+	sumPath := paths.build(metricName, attributes)
 	valueStr = formatFloatForValue(sum)
-	sb.WriteString(buildLine(sumPath, valueStr, timestampStr))
+	return append(metrics, carbonMetric{sumPath, valueStr, timestampStr})
 }
 
 // buildPath is used to build the <metric_path> per description above.
@@ -231,7 +318,7 @@ func buildPath(name string, attributes pcommon.Map) string {
 	sb.WriteString(name)
 
 	attributes.Range(func(k string, v pcommon.Value) bool {
-		value := v.AsString()
+		value := sanitizeTagValue(v.AsString())
 		if value == "" {
 			value = tagValueEmptyPlaceholder
 		}
@@ -278,6 +365,25 @@ func sanitizeTagValue(value string) string {
 	return strings.Map(mapRune, value)
 }
 
+// sanitizePathNode removes any character splitting or terminating a node of
+// the path, the invalid characters are ". ;", and replaces empty nodes.
+func sanitizePathNode(node string) string {
+	if node == "" {
+		return pathNodeEmptyPlaceholder
+	}
+
+	mapRune := func(r rune) rune {
+		switch r {
+		case '.', ' ', ';', '\t', '\n':
+			return sanitizedRune
+		default:
+			return r
+		}
+	}
+
+	return strings.Map(mapRune, node)
+}
+
 // Formats a float64 per Prometheus label value. This is an attempt to keep other
 // the label values with different formats of metrics.
 func formatFloatForLabel(f float64) string {
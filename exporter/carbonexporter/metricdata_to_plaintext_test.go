@@ -112,6 +112,46 @@ func TestBuildPath(t *testing.T) {
 	}
 }
 
+func TestHierarchicalPathBuilder(t *testing.T) {
+	attributes := pcommon.NewMap()
+	attributes.PutStr("host.name", "web 1")
+	attributes.PutStr("empty", "")
+	attributes.PutInt("port", 8080)
+
+	paths := newPathBuilder(TagModePath)
+	path := paths.build("http.requests", attributes)
+	assert.Equal(t, "http.requests.host_name.web_1.empty._.port.8080", path)
+	assert.Equal(t, "http.requests.host_name.web_1.empty._.port.8080.upper_bound.2_5", paths.withTag(path, "upper_bound", "2.5"))
+	assert.Equal(t, "http.requests", paths.build("http.requests", pcommon.NewMap()))
+}
+
+func TestMetricDataToCarbonTagModes(t *testing.T) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	dp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("route", "/a;b")
+	dp.SetTimestamp(pcommon.Timestamp(1574092046 * time.Second))
+	dp.SetCount(3)
+	dp.SetSum(4.5)
+	dp.ExplicitBounds().FromRaw([]float64{1.5})
+	dp.BucketCounts().FromRaw([]uint64{1, 2})
+
+	assert.Equal(t, []carbonMetric{
+		{"latency.count;route=/a_b", "3", "1574092046"},
+		{"latency;route=/a_b", "4.5", "1574092046"},
+		{"latency.bucket;route=/a_b;upper_bound=1.5", "1", "1574092046"},
+		{"latency.bucket;route=/a_b;upper_bound=inf", "2", "1574092046"},
+	}, metricDataToCarbon(md, newPathBuilder(TagModeTags)))
+
+	assert.Equal(t, []carbonMetric{
+		{"latency.count.route./a_b", "3", "1574092046"},
+		{"latency.route./a_b", "4.5", "1574092046"},
+		{"latency.bucket.route./a_b.upper_bound.1_5", "1", "1574092046"},
+		{"latency.bucket.route./a_b.upper_bound.inf", "2", "1574092046"},
+	}, metricDataToCarbon(md, newPathBuilder(TagModePath)))
+}
+
 func TestToPlaintext(t *testing.T) {
 	expectedTagsCombinations := []string{";k0=v0;k1=v1", ";k1=v1;k0=v0"}
 
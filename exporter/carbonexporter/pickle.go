@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package carbonexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Opcodes of the pickle protocol 2 used to encode the metrics, see
+// https://github.com/python/cpython/blob/main/Lib/pickletools.py.
+const (
+	pickleProto      = 0x80
+	pickleEmptyList  = ']'
+	pickleMark       = '('
+	pickleAppends    = 'e'
+	pickleBinUnicode = 'X'
+	pickleBinInt     = 'J'
+	pickleLong1      = 0x8a
+	pickleBinFloat   = 'G'
+	pickleTuple2     = 0x86
+	pickleStop       = '.'
+)
+
+// encodePickle encodes the metrics as per the Carbon pickle protocol, see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol,
+// ie.: a list of (path, (timestamp, value)) tuples pickled with the protocol 2,
+// prefixed by its length as a 4 bytes big-endian unsigned integer.
+func encodePickle(metrics []carbonMetric) ([]byte, error) {
+	var buf bytes.Buffer
+	// Reserve the header, written once the length of the payload is known.
+	buf.Write([]byte{0, 0, 0, 0})
+	buf.Write([]byte{pickleProto, 2, pickleEmptyList, pickleMark})
+
+	for _, m := range metrics {
+		timestamp, err := strconv.ParseInt(m.timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp of the metric %q: %w", m.path, err)
+		}
+		value, err := strconv.ParseFloat(m.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of the metric %q: %w", m.path, err)
+		}
+
+		pickleString(&buf, m.path)
+		pickleInt(&buf, timestamp)
+		pickleFloat(&buf, value)
+		buf.WriteByte(pickleTuple2)
+		buf.WriteByte(pickleTuple2)
+	}
+	buf.Write([]byte{pickleAppends, pickleStop})
+
+	payload := buf.Bytes()
+	binary.BigEndian.PutUint32(payload, uint32(len(payload)-4))
+	return payload, nil
+}
+
+func pickleString(buf *bytes.Buffer, s string) {
+	buf.WriteByte(pickleBinUnicode)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+	buf.WriteString(s)
+}
+
+func pickleInt(buf *bytes.Buffer, i int64) {
+	if i >= math.MinInt32 && i <= math.MaxInt32 {
+		buf.WriteByte(pickleBinInt)
+		_ = binary.Write(buf, binary.LittleEndian, int32(i))
+		return
+	}
+	// LONG1 encodes the integer as its little-endian two's complement bytes.
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(i))
+	buf.Write([]byte{pickleLong1, 8})
+	buf.Write(b[:])
+}
+
+func pickleFloat(buf *bytes.Buffer, f float64) {
+	buf.WriteByte(pickleBinFloat)
+	_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package carbonexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodePickle(t *testing.T) {
+	got, err := encodePickle([]carbonMetric{
+		{path: "a;k=v", value: "1.5", timestamp: "1574092046"},
+		{path: "b", value: "-3", timestamp: "4294967296"},
+	})
+	require.NoError(t, err)
+
+	want := []byte{
+		0, 0, 0, 59, // length of the payload
+		0x80, 2, ']', '(', // protocol 2, empty list and mark
+		'X', 5, 0, 0, 0, 'a', ';', 'k', '=', 'v', // path
+		'J', 0x0e, 0xbd, 0xd2, 0x5d, // timestamp
+		'G', 0x3f, 0xf8, 0, 0, 0, 0, 0, 0, // value
+		0x86, 0x86, // (path, (timestamp, value))
+		'X', 1, 0, 0, 0, 'b',
+		0x8a, 8, 0, 0, 0, 0, 1, 0, 0, 0, // timestamp over 32 bits
+		'G', 0xc0, 0x08, 0, 0, 0, 0, 0, 0,
+		0x86, 0x86,
+		'e', '.', // appends and stop
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestEncodePickleInvalidValue(t *testing.T) {
+	_, err := encodePickle([]carbonMetric{{path: "a", value: "a", timestamp: "1574092046"}})
+	assert.ErrorContains(t, err, `invalid value of the metric "a"`)
+}
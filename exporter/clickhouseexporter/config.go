@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -37,7 +39,31 @@ type Config struct {
 	// MetricsTableName is the table name for metrics. default is `otel_metrics`.
 	MetricsTableName string `mapstructure:"metrics_table_name"`
 	// TTLDays is The data time-to-live in days, 0 means no ttl.
+	// Deprecated: Use 'ttl' instead.
 	TTLDays uint `mapstructure:"ttl_days"`
+	// TTL is The data time-to-live, 0 means no ttl.
+	TTL time.Duration `mapstructure:"ttl"`
+	// AsyncInsert is the asynchronous inserts settings.
+	AsyncInsert AsyncInsertConfig `mapstructure:"async_insert"`
+	// LogsSchema customizes the schema of the logs table.
+	LogsSchema SchemaConfig `mapstructure:"logs_schema"`
+	// TracesSchema customizes the schema of the traces table.
+	TracesSchema SchemaConfig `mapstructure:"traces_schema"`
+	// MetricsSchema customizes the schemas of the metrics tables.
+	MetricsSchema MetricsSchemaConfig `mapstructure:"metrics_schema"`
+}
+
+// AsyncInsertConfig defines the asynchronous inserts settings, which let the server
+// batch the inserted rows. See https://clickhouse.com/docs/en/optimize/asynchronous-inserts.
+type AsyncInsertConfig struct {
+	// Enabled enables the asynchronous inserts. default is false.
+	Enabled bool `mapstructure:"enabled"`
+	// Wait waits for the rows to be flushed to the table before acknowledging the insert,
+	// so that the errors are returned to the exporter. default is true.
+	Wait bool `mapstructure:"wait"`
+	// BusyTimeout is the maximum time the server waits before flushing the rows, 0 means
+	// the server default.
+	BusyTimeout time.Duration `mapstructure:"busy_timeout"`
 }
 
 const defaultDatabase = "default"
@@ -45,6 +71,7 @@ const defaultDatabase = "default"
 var (
 	errConfigNoEndpoint      = errors.New("endpoint must be specified")
 	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
+	errConfigTTL             = errors.New("both 'ttl_days' and 'ttl' can not be provided. 'ttl_days' is deprecated, use 'ttl' instead")
 )
 
 // Validate the clickhouse server configuration.
@@ -52,6 +79,21 @@ func (cfg *Config) Validate() (err error) {
 	if cfg.Endpoint == "" {
 		err = errors.Join(err, errConfigNoEndpoint)
 	}
+	if cfg.TTLDays > 0 && cfg.TTL > 0 {
+		err = errors.Join(err, errConfigTTL)
+	}
+	if cfg.TTL < 0 || cfg.AsyncInsert.BusyTimeout < 0 {
+		err = errors.Join(err, errors.New("'ttl' and 'async_insert.busy_timeout' must not be negative"))
+	}
+	if e := cfg.LogsSchema.validate(logsColumns); e != nil {
+		err = errors.Join(err, fmt.Errorf("logs_schema: %w", e))
+	}
+	if e := cfg.TracesSchema.validate(tracesColumns); e != nil {
+		err = errors.Join(err, fmt.Errorf("traces_schema: %w", e))
+	}
+	if e := cfg.MetricsSchema.validate(); e != nil {
+		err = errors.Join(err, fmt.Errorf("metrics_schema: %w", e))
+	}
 	dsn, e := cfg.buildDSN(cfg.Database)
 	if e != nil {
 		err = errors.Join(err, e)
@@ -74,6 +116,19 @@ func (cfg *Config) buildDSN(database string) (string, error) {
 
 	queryParams := dsnURL.Query()
 
+	// Enable async inserts, unless overridden by connection params.
+	if cfg.AsyncInsert.Enabled {
+		queryParams.Set("async_insert", "1")
+		if cfg.AsyncInsert.Wait {
+			queryParams.Set("wait_for_async_insert", "1")
+		} else {
+			queryParams.Set("wait_for_async_insert", "0")
+		}
+		if cfg.AsyncInsert.BusyTimeout > 0 {
+			queryParams.Set("async_insert_busy_timeout_ms", strconv.FormatInt(cfg.AsyncInsert.BusyTimeout.Milliseconds(), 10))
+		}
+	}
+
 	// Add connection params to query params.
 	for k, v := range cfg.ConnectionParams {
 		queryParams.Set(k, v)
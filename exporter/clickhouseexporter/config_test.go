@@ -71,8 +71,34 @@ func TestLoadConfig(t *testing.T) {
 					QueueSize:    100,
 					StorageID:    &storageID,
 				},
+				AsyncInsert: AsyncInsertConfig{
+					Wait: true,
+				},
+				LogsSchema: SchemaConfig{
+					AttributesLayout: attributesLayoutMap,
+				},
+				TracesSchema: SchemaConfig{
+					AttributesLayout: attributesLayoutMap,
+				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "async_insert"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.TTL = 72 * time.Hour
+				cfg.AsyncInsert = AsyncInsertConfig{
+					Enabled:     true,
+					Wait:        false,
+					BusyTimeout: 500 * time.Millisecond,
+				}
+				cfg.LogsSchema.AttributesLayout = attributesLayoutNested
+				cfg.TracesSchema.CreateTableSQL = "CREATE TABLE IF NOT EXISTS {{.Table}} (Timestamp DateTime64(9), {{attributesColumn \"SpanAttributes\"}}) ENGINE MergeTree() {{.TTL}} ORDER BY Timestamp"
+				cfg.TracesSchema.Columns = map[string]string{"SpanAttributes": "Attributes"}
+				cfg.MetricsSchema.Gauge.CreateTableSQL = "CREATE TABLE IF NOT EXISTS {{.Table}} (TimeUnix DateTime64(9), {{attributesColumn \"Attributes\"}}) ENGINE MergeTree() {{.TTL}} ORDER BY TimeUnix"
+				cfg.MetricsSchema.Gauge.Columns = map[string]string{"TimeUnix": "Time"}
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,6 +116,77 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "both ttl and ttl_days",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.TTLDays = 3
+				cfg.TTL = time.Hour
+			}),
+			err: errConfigTTL.Error(),
+		},
+		{
+			name: "negative busy timeout",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.AsyncInsert.BusyTimeout = -time.Second
+			}),
+			err: "'ttl' and 'async_insert.busy_timeout' must not be negative",
+		},
+		{
+			name: "unsupported attributes layout",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.LogsSchema.AttributesLayout = "json"
+			}),
+			err: `logs_schema: unsupported attributes_layout "json"`,
+		},
+		{
+			name: "invalid create table template",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.TracesSchema.CreateTableSQL = "CREATE TABLE {{.Table"
+			}),
+			err: "traces_schema: invalid create_table_sql: template: create_table_sql:1: unclosed action",
+		},
+		{
+			name: "invalid metrics create table template",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.MetricsSchema.ExponentialHistogram.CreateTableSQL = "CREATE TABLE {{.Table"
+			}),
+			err: "metrics_schema: exponential_histogram: invalid create_table_sql: template: create_table_sql:1: unclosed action",
+		},
+		{
+			name: "unknown column",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.LogsSchema.Columns = map[string]string{"Severity": "Level"}
+			}),
+			err: `logs_schema: columns: unknown column "Severity"`,
+		},
+		{
+			name: "invalid metrics column name",
+			cfg: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.MetricsSchema.Sum.Columns = map[string]string{"Value": "Value, Other"}
+			}),
+			err: `metrics_schema: sum: columns: invalid name "Value, Other" of column "Value"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, component.ValidateConfig(tt.cfg), tt.err)
+		})
+	}
+}
+
 func withDefaultConfig(fns ...func(*Config)) *Config {
 	cfg := createDefaultConfig().(*Config)
 	for _, fn := range fns {
@@ -105,6 +202,7 @@ func TestConfig_buildDSN(t *testing.T) {
 		Password         string
 		Database         string
 		ConnectionParams map[string]string
+		AsyncInsert      AsyncInsertConfig
 	}
 	type args struct {
 		database string
@@ -243,6 +341,25 @@ func TestConfig_buildDSN(t *testing.T) {
 			},
 			want: "tcp://127.0.0.1:9000/default",
 		},
+		{
+			name: "enable async inserts",
+			fields: fields{
+				Endpoint:    defaultEndpoint,
+				AsyncInsert: AsyncInsertConfig{Enabled: true, Wait: true, BusyTimeout: time.Second},
+			},
+			args: args{},
+			want: "clickhouse://127.0.0.1:9000/default?async_insert=1&async_insert_busy_timeout_ms=1000&wait_for_async_insert=1",
+		},
+		{
+			name: "connection parameters override async inserts",
+			fields: fields{
+				Endpoint:         defaultEndpoint,
+				AsyncInsert:      AsyncInsertConfig{Enabled: true},
+				ConnectionParams: map[string]string{"wait_for_async_insert": "1"},
+			},
+			args: args{},
+			want: "clickhouse://127.0.0.1:9000/default?async_insert=1&wait_for_async_insert=1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -252,6 +369,7 @@ func TestConfig_buildDSN(t *testing.T) {
 				Password:         configopaque.String(tt.fields.Password),
 				Database:         tt.fields.Database,
 				ConnectionParams: tt.fields.ConnectionParams,
+				AsyncInsert:      tt.fields.AsyncInsert,
 			}
 			got, err := cfg.buildDSN(tt.args.database)
 
@@ -72,7 +72,7 @@ func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 			logs := ld.ResourceLogs().At(i)
 			res := logs.Resource()
 			resURL := logs.SchemaUrl()
-			resAttr := attributesToMap(res.Attributes())
+			resAttr := e.cfg.LogsSchema.attributesValues(res.Attributes())
 			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
 				serviceName = v.Str()
 			}
@@ -81,11 +81,10 @@ func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 				scopeURL := logs.ScopeLogs().At(j).SchemaUrl()
 				scopeName := logs.ScopeLogs().At(j).Scope().Name()
 				scopeVersion := logs.ScopeLogs().At(j).Scope().Version()
-				scopeAttr := attributesToMap(logs.ScopeLogs().At(j).Scope().Attributes())
+				scopeAttr := e.cfg.LogsSchema.attributesValues(logs.ScopeLogs().At(j).Scope().Attributes())
 				for k := 0; k < rs.Len(); k++ {
 					r := rs.At(k)
-					logAttr := attributesToMap(r.Attributes())
-					_, err = statement.ExecContext(ctx,
+					args := []any{
 						r.Timestamp().AsTime(),
 						traceutil.TraceIDToHexOrEmptyString(r.TraceID()),
 						traceutil.SpanIDToHexOrEmptyString(r.SpanID()),
@@ -95,13 +94,12 @@ func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 						serviceName,
 						r.Body().AsString(),
 						resURL,
-						resAttr,
-						scopeURL,
-						scopeName,
-						scopeVersion,
-						scopeAttr,
-						logAttr,
-					)
+					}
+					args = append(args, resAttr...)
+					args = append(args, scopeURL, scopeName, scopeVersion)
+					args = append(args, scopeAttr...)
+					args = append(args, e.cfg.LogsSchema.attributesValues(r.Attributes())...)
+					_, err = statement.ExecContext(ctx, args...)
 					if err != nil {
 						return fmt.Errorf("ExecContext:%w", err)
 					}
@@ -128,7 +126,7 @@ func attributesToMap(attributes pcommon.Map) map[string]string {
 const (
 	// language=ClickHouse SQL
 	createLogsTableSQL = `
-CREATE TABLE IF NOT EXISTS %s (
+CREATE TABLE IF NOT EXISTS {{.Table}} (
      Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
      TraceId String CODEC(ZSTD(1)),
      SpanId String CODEC(ZSTD(1)),
@@ -138,62 +136,49 @@ CREATE TABLE IF NOT EXISTS %s (
      ServiceName LowCardinality(String) CODEC(ZSTD(1)),
      Body String CODEC(ZSTD(1)),
      ResourceSchemaUrl String CODEC(ZSTD(1)),
-     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     {{attributesColumn "ResourceAttributes"}},
      ScopeSchemaUrl String CODEC(ZSTD(1)),
      ScopeName String CODEC(ZSTD(1)),
      ScopeVersion String CODEC(ZSTD(1)),
-     ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
-     LogAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     {{attributesColumn "ScopeAttributes"}},
+     {{attributesColumn "LogAttributes"}},
      INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
-     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_scope_attr_key mapKeys(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_scope_attr_value mapValues(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_log_attr_key mapKeys(LogAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_log_attr_value mapValues(LogAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_key {{attributesKeys "ResourceAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value {{attributesValues "ResourceAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_key {{attributesKeys "ScopeAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_value {{attributesValues "ScopeAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_log_attr_key {{attributesKeys "LogAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_log_attr_value {{attributesValues "LogAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_body Body TYPE tokenbf_v1(32768, 3, 0) GRANULARITY 1
 ) ENGINE MergeTree()
-%s
+{{.TTL}}
 PARTITION BY toDate(Timestamp)
 ORDER BY (ServiceName, SeverityText, toUnixTimestamp(Timestamp), TraceId)
 SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
 `
-	// language=ClickHouse SQL
-	insertLogsSQLTemplate = `INSERT INTO %s (
-                        Timestamp,
-                        TraceId,
-                        SpanId,
-                        TraceFlags,
-                        SeverityText,
-                        SeverityNumber,
-                        ServiceName,
-                        Body,
-                        ResourceSchemaUrl,
-                        ResourceAttributes,
-                        ScopeSchemaUrl,
-                        ScopeName,
-                        ScopeVersion,
-                        ScopeAttributes,
-                        LogAttributes
-                        ) VALUES (
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?
-                                  )`
 )
 
+// logsColumns are the columns the logs are inserted into.
+var logsColumns = []string{
+	"Timestamp",
+	"TraceId",
+	"SpanId",
+	"TraceFlags",
+	"SeverityText",
+	"SeverityNumber",
+	"ServiceName",
+	"Body",
+	"ResourceSchemaUrl",
+	"ResourceAttributes",
+	"ScopeSchemaUrl",
+	"ScopeName",
+	"ScopeVersion",
+	"ScopeAttributes",
+	"LogAttributes",
+}
+
+var logsAttributesColumns = map[string]bool{"ResourceAttributes": true, "ScopeAttributes": true, "LogAttributes": true}
+
 var driverName = "clickhouse" // for testing
 
 // newClickhouseClient create a clickhouse client.
@@ -227,22 +212,23 @@ func createDatabase(ctx context.Context, cfg *Config) error {
 }
 
 func createLogsTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateLogsTableSQL(cfg)); err != nil {
+	query, err := renderCreateLogsTableSQL(cfg)
+	if err != nil {
+		return fmt.Errorf("render create logs table sql: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, query); err != nil {
 		return fmt.Errorf("exec create logs table sql: %w", err)
 	}
 	return nil
 }
 
-func renderCreateLogsTableSQL(cfg *Config) string {
-	var ttlExpr string
-	if cfg.TTLDays > 0 {
-		ttlExpr = fmt.Sprintf(`TTL toDateTime(Timestamp) + toIntervalDay(%d)`, cfg.TTLDays)
-	}
-	return fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, ttlExpr)
+func renderCreateLogsTableSQL(cfg *Config) (string, error) {
+	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL, "Timestamp")
+	return cfg.LogsSchema.renderCreateTableSQL(createLogsTableSQL, cfg.Database, cfg.LogsTableName, ttlExpr)
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
-	return fmt.Sprintf(insertLogsSQLTemplate, cfg.LogsTableName)
+	return renderInsertSQL(cfg.LogsTableName, cfg.LogsSchema.insertColumns(logsColumns, logsAttributesColumns))
 }
 
 func doWithTx(_ context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
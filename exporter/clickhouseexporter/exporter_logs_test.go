@@ -119,6 +119,39 @@ func TestExporter_pushLogsData(t *testing.T) {
 		exporter := newTestLogsExporter(t, defaultEndpoint)
 		mustPushLogsData(t, exporter, simpleLogs(1))
 	})
+	t.Run("test nested attributes layout", func(t *testing.T) {
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "CREATE TABLE") {
+				require.Contains(t, query, "ResourceAttributes Nested(Key LowCardinality(String), Value String)")
+				require.Contains(t, query, "INDEX idx_log_attr_key LogAttributes.Key TYPE bloom_filter(0.01)")
+			}
+			if strings.HasPrefix(query, "INSERT") {
+				require.Contains(t, query, "ResourceAttributes.Key, ResourceAttributes.Value")
+				require.Equal(t, []string{"service.name"}, values[9])
+				require.Equal(t, []string{"test-service"}, values[10])
+				require.Equal(t, []string{"lib"}, values[14])
+				require.Equal(t, []string{"clickhouse"}, values[15])
+			}
+			return nil
+		})
+		exporter := newTestLogsExporter(t, defaultEndpoint, func(cfg *Config) {
+			cfg.LogsSchema.AttributesLayout = attributesLayoutNested
+		})
+		mustPushLogsData(t, exporter, simpleLogs(1))
+	})
+}
+
+func TestExporter_createLogsTable(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+	newTestLogsExporter(t, defaultEndpoint, func(cfg *Config) {
+		cfg.TTL = 12 * time.Hour
+		cfg.LogsSchema.CreateTableSQL = "CREATE TABLE IF NOT EXISTS {{.Database}}.{{.Table}} ({{attributesColumn \"LogAttributes\"}}) {{.TTL}}"
+	})
+	require.Contains(t, queries, "CREATE TABLE IF NOT EXISTS default.otel_logs (LogAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1))) TTL toDateTime(Timestamp) + toIntervalHour(12)")
 }
 
 func newTestLogsExporter(t *testing.T, dsn string, fns ...func(*Config)) *logsExporter {
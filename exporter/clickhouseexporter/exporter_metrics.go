@@ -42,7 +42,12 @@ func (e *metricsExporter) start(ctx context.Context, _ component.Host) error {
 	}
 
 	internal.SetLogger(e.logger)
-	return internal.NewMetricsTable(ctx, e.cfg.MetricsTableName, e.cfg.TTLDays, e.client)
+	ttlExpr := generateTTLExpr(e.cfg.TTLDays, e.cfg.TTL, "TimeUnix")
+	createTableSQL, err := e.cfg.MetricsSchema.renderCreateTableSQL(e.cfg.Database, e.cfg.MetricsTableName, ttlExpr)
+	if err != nil {
+		return fmt.Errorf("render create metrics table sql: %w", err)
+	}
+	return internal.NewMetricsTable(ctx, e.cfg.MetricsTableName, ttlExpr, createTableSQL, e.client)
 }
 
 // shutdown will shut down the exporter.
@@ -54,7 +59,7 @@ func (e *metricsExporter) shutdown(_ context.Context) error {
 }
 
 func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
-	metricsMap := internal.NewMetricsModel(e.cfg.MetricsTableName)
+	metricsMap := internal.NewMetricsModel(e.cfg.MetricsTableName, e.cfg.MetricsSchema.columns())
 	for i := 0; i < md.ResourceMetrics().Len(); i++ {
 		metrics := md.ResourceMetrics().At(i)
 		resAttr := attributesToMap(metrics.Resource().Attributes())
@@ -451,8 +451,20 @@ func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Met
 	require.NoError(t, err)
 }
 
-func newTestMetricsExporter(t *testing.T) *metricsExporter {
-	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig()(defaultEndpoint))
+func TestExporter_createMetricsTable(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+	newTestMetricsExporter(t, func(cfg *Config) {
+		cfg.MetricsSchema.Gauge.CreateTableSQL = "CREATE TABLE IF NOT EXISTS {{.Database}}.{{.Table}} (Value Float64) {{.TTL}}"
+	})
+	require.Contains(t, queries, "CREATE TABLE IF NOT EXISTS default.otel_metrics_gauge (Value Float64) ")
+}
+
+func newTestMetricsExporter(t *testing.T, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(defaultEndpoint))
 	require.NoError(t, err)
 	require.NoError(t, exporter.start(context.TODO(), nil))
 
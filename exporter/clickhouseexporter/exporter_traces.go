@@ -7,7 +7,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
 	"time"
 
 	_ "github.com/ClickHouse/clickhouse-go/v2" // For register database driver.
@@ -70,7 +69,7 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 		for i := 0; i < td.ResourceSpans().Len(); i++ {
 			spans := td.ResourceSpans().At(i)
 			res := spans.Resource()
-			resAttr := attributesToMap(res.Attributes())
+			resAttr := e.cfg.TracesSchema.attributesValues(res.Attributes())
 			var serviceName string
 			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
 				serviceName = v.Str()
@@ -81,11 +80,10 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 				scopeVersion := spans.ScopeSpans().At(j).Scope().Version()
 				for k := 0; k < rs.Len(); k++ {
 					r := rs.At(k)
-					spanAttr := attributesToMap(r.Attributes())
 					status := r.Status()
 					eventTimes, eventNames, eventAttrs := convertEvents(r.Events())
 					linksTraceIDs, linksSpanIDs, linksTraceStates, linksAttrs := convertLinks(r.Links())
-					_, err = statement.ExecContext(ctx,
+					args := []any{
 						r.StartTimestamp().AsTime(),
 						traceutil.TraceIDToHexOrEmptyString(r.TraceID()),
 						traceutil.SpanIDToHexOrEmptyString(r.SpanID()),
@@ -94,10 +92,11 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 						r.Name(),
 						traceutil.SpanKindStr(r.Kind()),
 						serviceName,
-						resAttr,
-						scopeName,
-						scopeVersion,
-						spanAttr,
+					}
+					args = append(args, resAttr...)
+					args = append(args, scopeName, scopeVersion)
+					args = append(args, e.cfg.TracesSchema.attributesValues(r.Attributes())...)
+					args = append(args,
 						r.EndTimestamp().AsTime().Sub(r.StartTimestamp().AsTime()).Nanoseconds(),
 						traceutil.StatusCodeStr(status.Code()),
 						status.Message(),
@@ -109,6 +108,7 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 						linksTraceStates,
 						linksAttrs,
 					)
+					_, err = statement.ExecContext(ctx, args...)
 					if err != nil {
 						return fmt.Errorf("ExecContext:%w", err)
 					}
@@ -158,7 +158,7 @@ func convertLinks(links ptrace.SpanLinkSlice) ([]string, []string, []string, []m
 const (
 	// language=ClickHouse SQL
 	createTracesTableSQL = `
-CREATE TABLE IF NOT EXISTS %s (
+CREATE TABLE IF NOT EXISTS {{.Table}} (
      Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
      TraceId String CODEC(ZSTD(1)),
      SpanId String CODEC(ZSTD(1)),
@@ -167,10 +167,10 @@ CREATE TABLE IF NOT EXISTS %s (
      SpanName LowCardinality(String) CODEC(ZSTD(1)),
      SpanKind LowCardinality(String) CODEC(ZSTD(1)),
      ServiceName LowCardinality(String) CODEC(ZSTD(1)),
-     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     {{attributesColumn "ResourceAttributes"}},
      ScopeName String CODEC(ZSTD(1)),
      ScopeVersion String CODEC(ZSTD(1)),
-     SpanAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     {{attributesColumn "SpanAttributes"}},
      Duration Int64 CODEC(ZSTD(1)),
      StatusCode LowCardinality(String) CODEC(ZSTD(1)),
      StatusMessage String CODEC(ZSTD(1)),
@@ -186,67 +186,47 @@ CREATE TABLE IF NOT EXISTS %s (
          Attributes Map(LowCardinality(String), String)
      ) CODEC(ZSTD(1)),
      INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
-     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_span_attr_key mapKeys(SpanAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
-     INDEX idx_span_attr_value mapValues(SpanAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_key {{attributesKeys "ResourceAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value {{attributesValues "ResourceAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_span_attr_key {{attributesKeys "SpanAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_span_attr_value {{attributesValues "SpanAttributes"}} TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_duration Duration TYPE minmax GRANULARITY 1
 ) ENGINE MergeTree()
-%s
+{{.TTL}}
 PARTITION BY toDate(Timestamp)
 ORDER BY (ServiceName, SpanName, toUnixTimestamp(Timestamp), TraceId)
 SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
 `
-	// language=ClickHouse SQL
-	insertTracesSQLTemplate = `INSERT INTO %s (
-                        Timestamp,
-                        TraceId,
-                        SpanId,
-                        ParentSpanId,
-                        TraceState,
-                        SpanName,
-                        SpanKind,
-                        ServiceName,
-					    ResourceAttributes,
-						ScopeName,
-						ScopeVersion,
-                        SpanAttributes,
-                        Duration,
-                        StatusCode,
-                        StatusMessage,
-                        Events.Timestamp,
-                        Events.Name,
-                        Events.Attributes,
-                        Links.TraceId,
-                        Links.SpanId,
-                        Links.TraceState,
-                        Links.Attributes
-                        ) VALUES (
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?,
-                                  ?
-                                  )`
 )
 
+// tracesColumns are the columns the spans are inserted into.
+var tracesColumns = []string{
+	"Timestamp",
+	"TraceId",
+	"SpanId",
+	"ParentSpanId",
+	"TraceState",
+	"SpanName",
+	"SpanKind",
+	"ServiceName",
+	"ResourceAttributes",
+	"ScopeName",
+	"ScopeVersion",
+	"SpanAttributes",
+	"Duration",
+	"StatusCode",
+	"StatusMessage",
+	"Events.Timestamp",
+	"Events.Name",
+	"Events.Attributes",
+	"Links.TraceId",
+	"Links.SpanId",
+	"Links.TraceState",
+	"Links.Attributes",
+}
+
+var tracesAttributesColumns = map[string]bool{"ResourceAttributes": true, "SpanAttributes": true}
+
 const (
 	createTraceIDTsTableSQL = `
 create table IF NOT EXISTS %s_trace_id_ts (
@@ -274,7 +254,11 @@ GROUP BY TraceId;
 )
 
 func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateTracesTableSQL(cfg)); err != nil {
+	query, err := renderCreateTracesTableSQL(cfg)
+	if err != nil {
+		return fmt.Errorf("render create traces table sql: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, query); err != nil {
 		return fmt.Errorf("exec create traces table sql: %w", err)
 	}
 	if _, err := db.ExecContext(ctx, renderCreateTraceIDTsTableSQL(cfg)); err != nil {
@@ -287,22 +271,16 @@ func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
 }
 
 func renderInsertTracesSQL(cfg *Config) string {
-	return fmt.Sprintf(strings.ReplaceAll(insertTracesSQLTemplate, "'", "`"), cfg.TracesTableName)
+	return renderInsertSQL(cfg.TracesTableName, cfg.TracesSchema.insertColumns(tracesColumns, tracesAttributesColumns))
 }
 
-func renderCreateTracesTableSQL(cfg *Config) string {
-	var ttlExpr string
-	if cfg.TTLDays > 0 {
-		ttlExpr = fmt.Sprintf(`TTL toDateTime(Timestamp) + toIntervalDay(%d)`, cfg.TTLDays)
-	}
-	return fmt.Sprintf(createTracesTableSQL, cfg.TracesTableName, ttlExpr)
+func renderCreateTracesTableSQL(cfg *Config) (string, error) {
+	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL, "Timestamp")
+	return cfg.TracesSchema.renderCreateTableSQL(createTracesTableSQL, cfg.Database, cfg.TracesTableName, ttlExpr)
 }
 
 func renderCreateTraceIDTsTableSQL(cfg *Config) string {
-	var ttlExpr string
-	if cfg.TTLDays > 0 {
-		ttlExpr = fmt.Sprintf(`TTL toDateTime(Start) + toIntervalDay(%d)`, cfg.TTLDays)
-	}
+	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL, "Start")
 	return fmt.Sprintf(createTraceIDTsTableSQL, cfg.TracesTableName, ttlExpr)
 }
 
@@ -41,6 +41,16 @@ func createDefaultConfig() component.Config {
 		TracesTableName:  "otel_traces",
 		MetricsTableName: "otel_metrics",
 		TTLDays:          0,
+		TTL:              0,
+		AsyncInsert: AsyncInsertConfig{
+			Wait: true,
+		},
+		LogsSchema: SchemaConfig{
+			AttributesLayout: attributesLayoutMap,
+		},
+		TracesSchema: SchemaConfig{
+			AttributesLayout: attributesLayoutMap,
+		},
 	}
 }
 
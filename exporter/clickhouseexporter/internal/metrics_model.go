@@ -18,12 +18,31 @@ import (
 	"go.uber.org/zap"
 )
 
-var supportedMetricTypes = map[string]struct{}{
-	createGaugeTableSQL:        {},
-	createSumTableSQL:          {},
-	createHistogramTableSQL:    {},
-	createExpHistogramTableSQL: {},
-	createSummaryTableSQL:      {},
+// supportedMetricTypes are the statements creating the table of each metric type.
+var supportedMetricTypes = map[pmetric.MetricType]string{
+	pmetric.MetricTypeGauge:                createGaugeTableSQL,
+	pmetric.MetricTypeSum:                  createSumTableSQL,
+	pmetric.MetricTypeHistogram:            createHistogramTableSQL,
+	pmetric.MetricTypeExponentialHistogram: createExpHistogramTableSQL,
+	pmetric.MetricTypeSummary:              createSummaryTableSQL,
+}
+
+// insertSQLs are the statements inserting the data points of each metric type.
+var insertSQLs = map[pmetric.MetricType]string{
+	pmetric.MetricTypeGauge:                insertGaugeTableSQL,
+	pmetric.MetricTypeSum:                  insertSumTableSQL,
+	pmetric.MetricTypeHistogram:            insertHistogramTableSQL,
+	pmetric.MetricTypeExponentialHistogram: insertExpHistogramTableSQL,
+	pmetric.MetricTypeSummary:              insertSummaryTableSQL,
+}
+
+// TableSuffixes are the suffixes of the names of the tables of each metric type.
+var TableSuffixes = map[pmetric.MetricType]string{
+	pmetric.MetricTypeGauge:                "_gauge",
+	pmetric.MetricTypeSum:                  "_sum",
+	pmetric.MetricTypeHistogram:            "_histogram",
+	pmetric.MetricTypeExponentialHistogram: "_exponential_histogram",
+	pmetric.MetricTypeSummary:              "_summary",
 }
 
 var logger *zap.Logger
@@ -50,14 +69,15 @@ func SetLogger(l *zap.Logger) {
 	logger = l
 }
 
-// NewMetricsTable create metric tables with an expiry time to storage metric telemetry data
-func NewMetricsTable(ctx context.Context, tableName string, ttlDays uint, db *sql.DB) error {
-	var ttlExpr string
-	if ttlDays > 0 {
-		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, ttlDays)
-	}
-	for table := range supportedMetricTypes {
+// NewMetricsTable create metric tables with the ttlExpr TTL clause to storage metric telemetry data.
+// The tables of the metric types of createTableSQL are created by its statements instead of the
+// built-in ones.
+func NewMetricsTable(ctx context.Context, tableName string, ttlExpr string, createTableSQL map[pmetric.MetricType]string, db *sql.DB) error {
+	for metricType, table := range supportedMetricTypes {
 		query := fmt.Sprintf(table, tableName, ttlExpr)
+		if custom, ok := createTableSQL[metricType]; ok {
+			query = custom
+		}
 		if _, err := db.ExecContext(ctx, query); err != nil {
 			return fmt.Errorf("exec create metrics table sql: %w", err)
 		}
@@ -65,27 +85,65 @@ func NewMetricsTable(ctx context.Context, tableName string, ttlDays uint, db *sq
 	return nil
 }
 
-// NewMetricsModel create a model for contain different metric data
-func NewMetricsModel(tableName string) map[pmetric.MetricType]MetricsModel {
+// NewMetricsModel create a model for contain different metric data. The columns
+// of the metric types of columns are renamed from their built-in name to the
+// name they map to.
+func NewMetricsModel(tableName string, columns map[pmetric.MetricType]map[string]string) map[pmetric.MetricType]MetricsModel {
+	insertSQL := func(metricType pmetric.MetricType) string {
+		return renameInsertColumns(fmt.Sprintf(insertSQLs[metricType], tableName), columns[metricType])
+	}
 	return map[pmetric.MetricType]MetricsModel{
 		pmetric.MetricTypeGauge: &gaugeMetrics{
-			insertSQL: fmt.Sprintf(insertGaugeTableSQL, tableName),
+			insertSQL: insertSQL(pmetric.MetricTypeGauge),
 		},
 		pmetric.MetricTypeSum: &sumMetrics{
-			insertSQL: fmt.Sprintf(insertSumTableSQL, tableName),
+			insertSQL: insertSQL(pmetric.MetricTypeSum),
 		},
 		pmetric.MetricTypeHistogram: &histogramMetrics{
-			insertSQL: fmt.Sprintf(insertHistogramTableSQL, tableName),
+			insertSQL: insertSQL(pmetric.MetricTypeHistogram),
 		},
 		pmetric.MetricTypeExponentialHistogram: &expHistogramMetrics{
-			insertSQL: fmt.Sprintf(insertExpHistogramTableSQL, tableName),
+			insertSQL: insertSQL(pmetric.MetricTypeExponentialHistogram),
 		},
 		pmetric.MetricTypeSummary: &summaryMetrics{
-			insertSQL: fmt.Sprintf(insertSummaryTableSQL, tableName),
+			insertSQL: insertSQL(pmetric.MetricTypeSummary),
 		},
 	}
 }
 
+// InsertColumns returns the built-in columns the data points of metricType are
+// inserted into.
+func InsertColumns(metricType pmetric.MetricType) []string {
+	columns, _, _ := splitInsertColumns(insertSQLs[metricType])
+	return columns
+}
+
+// splitInsertColumns returns the columns of an INSERT statement, along with the
+// statement before and after them.
+func splitInsertColumns(insertSQL string) (columns []string, before, after string) {
+	start := strings.IndexByte(insertSQL, '(')
+	end := strings.Index(insertSQL, ") VALUES")
+	for _, column := range strings.Split(insertSQL[start+1:end], ",") {
+		columns = append(columns, strings.TrimSpace(column))
+	}
+	return columns, insertSQL[:start+1], insertSQL[end:]
+}
+
+// renameInsertColumns renames the columns of an INSERT statement from their name
+// to the name they map to in columns.
+func renameInsertColumns(insertSQL string, columns map[string]string) string {
+	if len(columns) == 0 {
+		return insertSQL
+	}
+	names, before, after := splitInsertColumns(insertSQL)
+	for i, name := range names {
+		if renamed, ok := columns[name]; ok {
+			names[i] = renamed
+		}
+	}
+	return before + strings.Join(names, ", ") + after
+}
+
 // InsertMetrics insert metric data into clickhouse concurrently
 func InsertMetrics(ctx context.Context, db *sql.DB, metricsMap map[pmetric.MetricType]MetricsModel) error {
 	errsChan := make(chan error, len(supportedMetricTypes))
@@ -226,3 +226,11 @@ func Test_newPlaceholder(t *testing.T) {
 	expectStr := "(?,?,?,?,?),"
 	require.Equal(t, newPlaceholder(5), &expectStr)
 }
+
+func Test_renameInsertColumns(t *testing.T) {
+	insertSQL := "INSERT INTO otel_metrics_sum (\n    MetricName,\n    Value,\n\tExemplars.Value) VALUES (?,?,?)"
+	require.Equal(t, insertSQL, renameInsertColumns(insertSQL, nil))
+	require.Equal(t, "INSERT INTO otel_metrics_sum (MetricName, Total, Exemplars.Value) VALUES (?,?,?)",
+		renameInsertColumns(insertSQL, map[string]string{"Value": "Total"}))
+	require.Contains(t, InsertColumns(pmetric.MetricTypeGauge), "Exemplars.TimeUnix")
+}
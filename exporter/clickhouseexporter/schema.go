@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+)
+
+const (
+	// attributesLayoutMap stores the attributes in Map(LowCardinality(String), String) columns.
+	attributesLayoutMap = "map"
+	// attributesLayoutNested stores the attributes in Nested(Key LowCardinality(String), Value String)
+	// columns, that is in the Key and Value arrays of the column.
+	attributesLayoutNested = "nested"
+)
+
+// SchemaConfig customizes the schema of the table of a signal.
+type SchemaConfig struct {
+	// CreateTableSQL is a Go template of the statement creating the table, replacing
+	// the built-in one. It is rendered with the {{.Database}}, {{.Table}} and {{.TTL}}
+	// fields, and the attributesColumn, attributesKeys and attributesValues functions
+	// returning the definition, the keys and the values of an attributes column.
+	CreateTableSQL string `mapstructure:"create_table_sql"`
+	// AttributesLayout is the layout of the attributes columns, map or nested
+	// (default map).
+	AttributesLayout string `mapstructure:"attributes_layout"`
+	// Columns renames the columns the data is inserted into, from the name of the
+	// built-in column to the name of the column of the table.
+	Columns map[string]string `mapstructure:"columns"`
+}
+
+func (cfg *SchemaConfig) validate(builtinColumns []string) error {
+	switch cfg.AttributesLayout {
+	case attributesLayoutMap, attributesLayoutNested:
+	default:
+		return fmt.Errorf("unsupported attributes_layout %q", cfg.AttributesLayout)
+	}
+	if err := validateColumns(cfg.Columns, builtinColumns); err != nil {
+		return err
+	}
+	if cfg.CreateTableSQL != "" {
+		if _, err := cfg.parseCreateTableSQL(""); err != nil {
+			return fmt.Errorf("invalid create_table_sql: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseCreateTableSQL parses the custom template creating the table, or the
+// built-in one when not set.
+func (cfg *SchemaConfig) parseCreateTableSQL(builtin string) (*template.Template, error) {
+	text := builtin
+	if cfg.CreateTableSQL != "" {
+		text = cfg.CreateTableSQL
+	}
+	nested := cfg.AttributesLayout == attributesLayoutNested
+	return template.New("create_table_sql").Funcs(template.FuncMap{
+		"attributesColumn": func(name string) string {
+			if nested {
+				return name + " Nested(Key LowCardinality(String), Value String)"
+			}
+			return name + " Map(LowCardinality(String), String) CODEC(ZSTD(1))"
+		},
+		"attributesKeys": func(name string) string {
+			if nested {
+				return name + ".Key"
+			}
+			return "mapKeys(" + name + ")"
+		},
+		"attributesValues": func(name string) string {
+			if nested {
+				return name + ".Value"
+			}
+			return "mapValues(" + name + ")"
+		},
+	}).Parse(text)
+}
+
+// renderCreateTableSQL renders the statement creating table.
+func (cfg *SchemaConfig) renderCreateTableSQL(builtin, database, table, ttlExpr string) (string, error) {
+	tmpl, err := cfg.parseCreateTableSQL(builtin)
+	if err != nil {
+		return "", err
+	}
+	var sql strings.Builder
+	err = tmpl.Execute(&sql, struct {
+		Database string
+		Table    string
+		TTL      string
+	}{Database: database, Table: table, TTL: ttlExpr})
+	return sql.String(), err
+}
+
+// validateColumns checks columns only renames builtin columns, to valid names.
+func validateColumns(columns map[string]string, builtin []string) error {
+	known := make(map[string]bool, len(builtin))
+	for _, column := range builtin {
+		known[column] = true
+	}
+	for column, name := range columns {
+		if !known[column] {
+			return fmt.Errorf("columns: unknown column %q", column)
+		}
+		if name == "" || strings.ContainsAny(name, " \t\r\n,()`") {
+			return fmt.Errorf("columns: invalid name %q of column %q", name, column)
+		}
+	}
+	return nil
+}
+
+// insertColumns renames columns according to Columns, and expands the attributes
+// columns according to the layout.
+func (cfg *SchemaConfig) insertColumns(columns []string, attributesColumns map[string]bool) []string {
+	expanded := make([]string, 0, len(columns)+len(attributesColumns))
+	for _, column := range columns {
+		name := column
+		if renamed, ok := cfg.Columns[column]; ok {
+			name = renamed
+		}
+		if cfg.AttributesLayout == attributesLayoutNested && attributesColumns[column] {
+			expanded = append(expanded, name+".Key", name+".Value")
+			continue
+		}
+		expanded = append(expanded, name)
+	}
+	return expanded
+}
+
+// attributesValues returns the values inserted into an attributes column, that is
+// a map with the map layout, and the key and value arrays with the nested layout.
+func (cfg *SchemaConfig) attributesValues(attributes pcommon.Map) []any {
+	if cfg.AttributesLayout != attributesLayoutNested {
+		return []any{attributesToMap(attributes)}
+	}
+	keys := make([]string, 0, attributes.Len())
+	values := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		keys = append(keys, k)
+		values = append(values, v.AsString())
+		return true
+	})
+	return []any{keys, values}
+}
+
+// MetricsSchemaConfig customizes the schemas of the tables of the metric types.
+// The attributes of the metrics are always stored in map columns.
+type MetricsSchemaConfig struct {
+	Gauge                MetricTableConfig `mapstructure:"gauge"`
+	Sum                  MetricTableConfig `mapstructure:"sum"`
+	Histogram            MetricTableConfig `mapstructure:"histogram"`
+	ExponentialHistogram MetricTableConfig `mapstructure:"exponential_histogram"`
+	Summary              MetricTableConfig `mapstructure:"summary"`
+}
+
+// MetricTableConfig customizes the schema of the table of a metric type.
+type MetricTableConfig struct {
+	// CreateTableSQL is a Go template of the statement creating the table, replacing
+	// the built-in one, rendered like SchemaConfig.CreateTableSQL with the map layout.
+	CreateTableSQL string `mapstructure:"create_table_sql"`
+	// Columns renames the columns the data points are inserted into, like
+	// SchemaConfig.Columns.
+	Columns map[string]string `mapstructure:"columns"`
+}
+
+func (cfg *MetricsSchemaConfig) tables() map[pmetric.MetricType]SchemaConfig {
+	tables := map[pmetric.MetricType]MetricTableConfig{
+		pmetric.MetricTypeGauge:                cfg.Gauge,
+		pmetric.MetricTypeSum:                  cfg.Sum,
+		pmetric.MetricTypeHistogram:            cfg.Histogram,
+		pmetric.MetricTypeExponentialHistogram: cfg.ExponentialHistogram,
+		pmetric.MetricTypeSummary:              cfg.Summary,
+	}
+	schemas := make(map[pmetric.MetricType]SchemaConfig, len(tables))
+	for metricType, table := range tables {
+		if table.CreateTableSQL != "" || len(table.Columns) > 0 {
+			schemas[metricType] = SchemaConfig{
+				CreateTableSQL:   table.CreateTableSQL,
+				AttributesLayout: attributesLayoutMap,
+				Columns:          table.Columns,
+			}
+		}
+	}
+	return schemas
+}
+
+// columns returns the renamed columns of the customized metric types.
+func (cfg *MetricsSchemaConfig) columns() map[pmetric.MetricType]map[string]string {
+	columns := make(map[pmetric.MetricType]map[string]string)
+	for metricType, schema := range cfg.tables() {
+		if len(schema.Columns) > 0 {
+			columns[metricType] = schema.Columns
+		}
+	}
+	return columns
+}
+
+func (cfg *MetricsSchemaConfig) validate() error {
+	var errs error
+	tables := cfg.tables()
+	for _, metricType := range []pmetric.MetricType{
+		pmetric.MetricTypeGauge,
+		pmetric.MetricTypeSum,
+		pmetric.MetricTypeHistogram,
+		pmetric.MetricTypeExponentialHistogram,
+		pmetric.MetricTypeSummary,
+	} {
+		schema, ok := tables[metricType]
+		if !ok {
+			continue
+		}
+		if err := schema.validate(internal.InsertColumns(metricType)); err != nil {
+			// The settings of a metric type are named after the suffix of its table
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", strings.TrimPrefix(internal.TableSuffixes[metricType], "_"), err))
+		}
+	}
+	return errs
+}
+
+// renderCreateTableSQL renders the statements creating the tables of the
+// customized metric types, named after tableName.
+func (cfg *MetricsSchemaConfig) renderCreateTableSQL(database, tableName, ttlExpr string) (map[pmetric.MetricType]string, error) {
+	statements := make(map[pmetric.MetricType]string)
+	for metricType, schema := range cfg.tables() {
+		if schema.CreateTableSQL == "" {
+			continue
+		}
+		sql, err := schema.renderCreateTableSQL("", database, tableName+internal.TableSuffixes[metricType], ttlExpr)
+		if err != nil {
+			return nil, err
+		}
+		statements[metricType] = sql
+	}
+	return statements, nil
+}
+
+func renderInsertSQL(table string, columns []string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+}
+
+// generateTTLExpr returns the TTL clause of a table whose rows are timed by
+// timeField, in the largest unit dividing the TTL.
+func generateTTLExpr(ttlDays uint, ttl time.Duration, timeField string) string {
+	if ttlDays > 0 {
+		return fmt.Sprintf(`TTL toDateTime(%s) + toIntervalDay(%d)`, timeField, ttlDays)
+	}
+	if ttl <= 0 {
+		return ""
+	}
+	switch {
+	case ttl%(24*time.Hour) == 0:
+		return fmt.Sprintf(`TTL toDateTime(%s) + toIntervalDay(%d)`, timeField, ttl/(24*time.Hour))
+	case ttl%time.Hour == 0:
+		return fmt.Sprintf(`TTL toDateTime(%s) + toIntervalHour(%d)`, timeField, ttl/time.Hour)
+	case ttl%time.Minute == 0:
+		return fmt.Sprintf(`TTL toDateTime(%s) + toIntervalMinute(%d)`, timeField, ttl/time.Minute)
+	default:
+		return fmt.Sprintf(`TTL toDateTime(%s) + toIntervalSecond(%d)`, timeField, ttl/time.Second)
+	}
+}
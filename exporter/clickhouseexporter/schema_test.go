@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+)
+
+func TestGenerateTTLExpr(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttlDays  uint
+		ttl      time.Duration
+		expected string
+	}{
+		{name: "no ttl"},
+		{name: "ttl days", ttlDays: 3, expected: "TTL toDateTime(Timestamp) + toIntervalDay(3)"},
+		{name: "days", ttl: 48 * time.Hour, expected: "TTL toDateTime(Timestamp) + toIntervalDay(2)"},
+		{name: "hours", ttl: 36 * time.Hour, expected: "TTL toDateTime(Timestamp) + toIntervalHour(36)"},
+		{name: "minutes", ttl: 90 * time.Minute, expected: "TTL toDateTime(Timestamp) + toIntervalMinute(90)"},
+		{name: "seconds", ttl: 90 * time.Second, expected: "TTL toDateTime(Timestamp) + toIntervalSecond(90)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, generateTTLExpr(tt.ttlDays, tt.ttl, "Timestamp"))
+		})
+	}
+}
+
+func TestSchemaConfig_insertColumns(t *testing.T) {
+	columns := []string{"Timestamp", "LogAttributes"}
+	attributesColumns := map[string]bool{"LogAttributes": true}
+
+	cfg := SchemaConfig{AttributesLayout: attributesLayoutMap}
+	assert.Equal(t, columns, cfg.insertColumns(columns, attributesColumns))
+	assert.Equal(t, []any{map[string]string{"key": "1"}}, cfg.attributesValues(testAttributes()))
+
+	cfg = SchemaConfig{AttributesLayout: attributesLayoutNested}
+	assert.Equal(t, []string{"Timestamp", "LogAttributes.Key", "LogAttributes.Value"}, cfg.insertColumns(columns, attributesColumns))
+	assert.Equal(t, []any{[]string{"key"}, []string{"1"}}, cfg.attributesValues(testAttributes()))
+
+	cfg.Columns = map[string]string{"Timestamp": "Time", "LogAttributes": "Attributes"}
+	assert.Equal(t, []string{"Time", "Attributes.Key", "Attributes.Value"}, cfg.insertColumns(columns, attributesColumns))
+}
+
+func TestMetricsSchemaConfig_columns(t *testing.T) {
+	cfg := MetricsSchemaConfig{
+		Sum: MetricTableConfig{Columns: map[string]string{"Value": "Total"}},
+	}
+	columns := cfg.columns()
+	assert.Equal(t, map[pmetric.MetricType]map[string]string{pmetric.MetricTypeSum: {"Value": "Total"}}, columns)
+
+	statements, err := cfg.renderCreateTableSQL("otel", "otel_metrics", "")
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+
+	models := internal.NewMetricsModel("otel_metrics", columns)
+	assert.NotNil(t, models[pmetric.MetricTypeSum])
+}
+
+func TestMetricsSchemaConfig_renderCreateTableSQL(t *testing.T) {
+	cfg := MetricsSchemaConfig{
+		Sum: MetricTableConfig{CreateTableSQL: "CREATE TABLE {{.Database}}.{{.Table}} ({{attributesColumn \"Attributes\"}}) {{.TTL}}"},
+	}
+	statements, err := cfg.renderCreateTableSQL("otel", "otel_metrics", "TTL toDateTime(TimeUnix) + toIntervalDay(3)")
+	require.NoError(t, err)
+	assert.Equal(t, map[pmetric.MetricType]string{
+		pmetric.MetricTypeSum: "CREATE TABLE otel.otel_metrics_sum (Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1))) TTL toDateTime(TimeUnix) + toIntervalDay(3)",
+	}, statements)
+}
+
+func TestRenderInsertSQL(t *testing.T) {
+	assert.Equal(t, "INSERT INTO otel_logs (Timestamp, Body) VALUES (?, ?)", renderInsertSQL("otel_logs", []string{"Timestamp", "Body"}))
+}
+
+func testAttributes() pcommon.Map {
+	attributes := pcommon.NewMap()
+	attributes.PutInt("key", 1)
+	return attributes
+}
@@ -45,6 +45,9 @@ type Config struct {
 	LogsIndex string `mapstructure:"logs_index"`
 	// fall back to pure LogsIndex, if 'elasticsearch.index.prefix' or 'elasticsearch.index.suffix' are not found in resource or attribute (prio: resource > attribute)
 	LogsDynamicIndex DynamicIndexSetting `mapstructure:"logs_dynamic_index"`
+	// LogsDataStream routes the log records to the data streams named after their
+	// attributes. It takes precedence over LogsIndex and LogsDynamicIndex.
+	LogsDataStream DataStreamSettings `mapstructure:"logs_data_stream"`
 	// This setting is required when traces pipelines used.
 	TracesIndex string `mapstructure:"traces_index"`
 	// fall back to pure TracesIndex, if 'elasticsearch.index.prefix' or 'elasticsearch.index.suffix' are not found in resource or attribute (prio: resource > attribute)
@@ -55,6 +58,16 @@ type Config struct {
 	//
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html
 	Pipeline string `mapstructure:"pipeline"`
+	// LogsPipeline configures the ingest node pipeline for logs. It takes precedence over Pipeline.
+	LogsPipeline string `mapstructure:"logs_pipeline"`
+	// TracesPipeline configures the ingest node pipeline for traces. It takes precedence over Pipeline.
+	TracesPipeline string `mapstructure:"traces_pipeline"`
+
+	// DocumentID configures how the _id of the indexed documents is generated.
+	DocumentID DocumentIDSettings `mapstructure:"document_id"`
+
+	// Routing configures the routing key of the indexed documents.
+	Routing RoutingSettings `mapstructure:"routing"`
 
 	HTTPClientSettings `mapstructure:",squash"`
 	Discovery          DiscoverySettings `mapstructure:"discover"`
@@ -146,6 +159,51 @@ type RetrySettings struct {
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
+// DataStreamSettings defines the data streams the documents are routed to, so
+// that they land in the right data stream without an ingest pipeline.
+//
+// https://www.elastic.co/guide/en/fleet/current/data-streams.html#data-streams-naming-scheme
+type DataStreamSettings struct {
+	// Index is the name of the data stream, whose {attribute} placeholders are
+	// replaced with the values of the attributes, looked up in the resource
+	// attributes first and in the record attributes otherwise. For example
+	// logs-{service.name}-{deployment.environment}. The values are lowercased,
+	// and their dashes and the characters not allowed in names replaced with
+	// underscores. Routing to data streams is disabled when empty.
+	Index string `mapstructure:"index"`
+
+	// Defaults are the values of the placeholders whose attribute is missing.
+	// The placeholders of missing attributes without default are replaced with
+	// "default".
+	Defaults map[string]string `mapstructure:"defaults"`
+}
+
+// RoutingSettings defines the routing key of the documents, which routes the
+// documents with the same key to the same shard.
+type RoutingSettings struct {
+	// Attribute holds the name of the attribute holding the routing key, looked up
+	// in the resource attributes first and in the record attributes otherwise.
+	// Documents without the attribute are routed by their _id.
+	Attribute string `mapstructure:"attribute"`
+}
+
+// DocumentIDSettings defines how the exporter generates the document _id.
+// Documents with a deterministic _id are not duplicated when a bulk request
+// is retried.
+type DocumentIDSettings struct {
+	// Strategy configures the _id generation. Supported strategies are:
+	//
+	//   none: Elasticsearch generates the _id. This is the default.
+	//   hash: the _id is the SHA-256 hash of the encoded document.
+	//   attribute: the _id is the value of Attribute, looked up in the
+	//   resource attributes first and in the record attributes otherwise.
+	//   Documents without the attribute get an _id generated by Elasticsearch.
+	Strategy string `mapstructure:"strategy"`
+
+	// Attribute holds the name of the attribute used by the attribute strategy.
+	Attribute string `mapstructure:"attribute"`
+}
+
 type MappingsSettings struct {
 	// Mode configures the field mappings.
 	Mode string `mapstructure:"mode"`
@@ -173,6 +231,7 @@ const (
 var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
+	errConfigNoAttribute   = errors.New("document_id.attribute must be specified with the attribute strategy")
 )
 
 func (m MappingMode) String() string {
@@ -222,5 +281,21 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
 
+	switch cfg.DocumentID.Strategy {
+	case "", documentIDNone, documentIDHash:
+	case documentIDAttribute:
+		if cfg.DocumentID.Attribute == "" {
+			return errConfigNoAttribute
+		}
+	default:
+		return fmt.Errorf("unknown document_id strategy %v", cfg.DocumentID.Strategy)
+	}
+
+	if cfg.LogsDataStream.Index != "" {
+		if _, err := newIndexTemplate(cfg.LogsDataStream); err != nil {
+			return fmt.Errorf("invalid logs_data_stream.index %q: %w", cfg.LogsDataStream.Index, err)
+		}
+	}
+
 	return nil
 }
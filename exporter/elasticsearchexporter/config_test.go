@@ -140,12 +140,17 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: exporterhelper.NewDefaultQueueSettings().NumConsumers,
 					QueueSize:    exporterhelper.NewDefaultQueueSettings().QueueSize,
 				},
-				Endpoints:   []string{"http://localhost:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "my_log_index",
+				Endpoints: []string{"http://localhost:9200"},
+				CloudID:   "TRNMxjXlNJEt",
+				Index:     "",
+				LogsIndex: "my_log_index",
+				LogsDataStream: DataStreamSettings{
+					Index:    "logs-{service.name}-{deployment.environment}",
+					Defaults: map[string]string{"deployment.environment": "production"},
+				},
 				TracesIndex: "traces-generic-default",
 				Pipeline:    "mypipeline",
+				Routing:     RoutingSettings{Attribute: "tenant.id"},
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
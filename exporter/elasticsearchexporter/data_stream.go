@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultDataStreamValue replaces the placeholders of the missing attributes
+// without default, as the default namespace of the data stream naming scheme.
+const defaultDataStreamValue = "default"
+
+// maxIndexNameBytes is the maximum length of an index or data stream name.
+const maxIndexNameBytes = 255
+
+// invalidIndexNameChars are the characters not allowed in index and data stream
+// names, see https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html#indices-create-api-path-params
+const invalidIndexNameChars = `\/*?"<>|,# :`
+
+// indexTemplate is the name of a data stream whose {attribute} placeholders
+// are replaced with the values of the attributes of the records.
+type indexTemplate struct {
+	// parts are the literal parts of the name, and the placeholders at the odd positions.
+	parts    []string
+	defaults map[string]string
+}
+
+func newIndexTemplate(settings DataStreamSettings) (*indexTemplate, error) {
+	t := &indexTemplate{defaults: settings.Defaults}
+	rest := settings.Index
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			return nil, errors.New("unclosed placeholder")
+		}
+		name := rest[start+1 : start+end]
+		if name == "" || strings.ContainsRune(name, '{') {
+			return nil, fmt.Errorf("invalid placeholder %q", rest[start:start+end+1])
+		}
+		t.parts = append(t.parts, rest[:start], name)
+		rest = rest[start+end+1:]
+	}
+	t.parts = append(t.parts, rest)
+
+	for i := 0; i < len(t.parts); i += 2 {
+		literal := t.parts[i]
+		if strings.ContainsRune(literal, '}') {
+			return nil, errors.New("unopened placeholder")
+		}
+		if literal != strings.ToLower(literal) || strings.ContainsAny(literal, invalidIndexNameChars) {
+			return nil, fmt.Errorf("%q must be lowercase and not contain any of %s", literal, invalidIndexNameChars)
+		}
+	}
+	if t.parts[0] != "" && strings.ContainsRune("-_+.", rune(t.parts[0][0])) {
+		return nil, errors.New("the name must not start with '-', '_', '+' or '.'")
+	}
+	return t, nil
+}
+
+// render returns the name of the data stream of record, looking up the attributes
+// in the resource attributes first and in the record attributes otherwise.
+func (t *indexTemplate) render(resource attrGetter, record attrGetter) string {
+	var name strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			name.WriteString(part)
+			continue
+		}
+		value := getFromBothResourceAndAttribute(part, resource, record)
+		if value == "" {
+			value = t.defaults[part]
+		}
+		if value == "" {
+			value = defaultDataStreamValue
+		}
+		name.WriteString(sanitizeDataStreamValue(value))
+	}
+	index := name.String()
+	if len(index) > maxIndexNameBytes {
+		index = strings.ToValidUTF8(index[:maxIndexNameBytes], "")
+	}
+	return index
+}
+
+// sanitizeDataStreamValue makes the value of an attribute valid in a data stream
+// name. The dashes are replaced too, since they separate the type, the dataset
+// and the namespace of the data streams.
+func sanitizeDataStreamValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || strings.ContainsRune(invalidIndexNameChars, r) {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestIndexTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   DataStreamSettings
+		resource   map[string]any
+		record     map[string]any
+		expected   string
+		errMessage string
+	}{
+		{
+			name:     "resource attributes first",
+			settings: DataStreamSettings{Index: "logs-{service.name}-{deployment.environment}"},
+			resource: map[string]any{"service.name": "cart", "deployment.environment": "prod"},
+			record:   map[string]any{"service.name": "other"},
+			expected: "logs-cart-prod",
+		},
+		{
+			name:     "record attributes and defaults",
+			settings: DataStreamSettings{Index: "logs-{service.name}-{deployment.environment}", Defaults: map[string]string{"deployment.environment": "dev"}},
+			record:   map[string]any{"service.name": "cart"},
+			expected: "logs-cart-dev",
+		},
+		{
+			name:     "missing attributes",
+			settings: DataStreamSettings{Index: "logs-{service.name}-{deployment.environment}"},
+			expected: "logs-default-default",
+		},
+		{
+			name:     "sanitized values",
+			settings: DataStreamSettings{Index: "logs-{service.name}.{k8s.pod.uid}-default"},
+			resource: map[string]any{"service.name": "My-Service/v2", "k8s.pod.uid": 42},
+			expected: "logs-my_service_v2.42-default",
+		},
+		{
+			name:     "no placeholder",
+			settings: DataStreamSettings{Index: "logs-generic-default"},
+			expected: "logs-generic-default",
+		},
+		{
+			name:       "unclosed placeholder",
+			settings:   DataStreamSettings{Index: "logs-{service.name"},
+			errMessage: "unclosed placeholder",
+		},
+		{
+			name:       "unopened placeholder",
+			settings:   DataStreamSettings{Index: "logs-service.name}"},
+			errMessage: "unopened placeholder",
+		},
+		{
+			name:       "empty placeholder",
+			settings:   DataStreamSettings{Index: "logs-{}"},
+			errMessage: `invalid placeholder "{}"`,
+		},
+		{
+			name:       "uppercase",
+			settings:   DataStreamSettings{Index: "Logs-{service.name}"},
+			errMessage: `"Logs-" must be lowercase and not contain any of \/*?"<>|,# :`,
+		},
+		{
+			name:       "invalid start",
+			settings:   DataStreamSettings{Index: "_logs-{service.name}"},
+			errMessage: "the name must not start with '-', '_', '+' or '.'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := newIndexTemplate(tt.settings)
+			if tt.errMessage != "" {
+				assert.EqualError(t, err, tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			resource := pcommon.NewResource()
+			require.NoError(t, resource.Attributes().FromRaw(tt.resource))
+			record := pcommon.NewResource()
+			require.NoError(t, record.Attributes().FromRaw(tt.record))
+			assert.Equal(t, tt.expected, tmpl.render(resource, record))
+		})
+	}
+}
+
+func TestIndexTemplateMaxLength(t *testing.T) {
+	tmpl, err := newIndexTemplate(DataStreamSettings{Index: "logs-{service.name}-default"})
+	require.NoError(t, err)
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", strings.Repeat("é", 200))
+	index := tmpl.render(resource, pcommon.NewResource())
+	assert.LessOrEqual(t, len(index), maxIndexNameBytes)
+	assert.True(t, strings.HasPrefix(index, "logs-éé"))
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// document _id generation strategies
+const (
+	documentIDNone      = "none"
+	documentIDHash      = "hash"
+	documentIDAttribute = "attribute"
+)
+
+// documentID returns the _id of the encoded document of a record. An empty _id
+// lets Elasticsearch generate it.
+func documentID(settings DocumentIDSettings, resource attrGetter, record attrGetter, document []byte) string {
+	switch settings.Strategy {
+	case documentIDHash:
+		sum := sha256.Sum256(document)
+		return hex.EncodeToString(sum[:])
+	case documentIDAttribute:
+		return getFromBothResourceAndAttribute(settings.Attribute, resource, record)
+	default:
+		return ""
+	}
+}
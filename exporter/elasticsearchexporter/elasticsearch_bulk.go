@@ -136,14 +136,14 @@ func newTransport(config *Config, tlsCfg *tls.Config) *http.Transport {
 	return transport
 }
 
-func newBulkIndexer(logger *zap.Logger, client *elasticsearch7.Client, config *Config) (esBulkIndexerCurrent, error) {
+func newBulkIndexer(logger *zap.Logger, client *elasticsearch7.Client, config *Config, pipeline string) (esBulkIndexerCurrent, error) {
 	// TODO: add debug logger
 	return esutil7.NewBulkIndexer(esutil7.BulkIndexerConfig{
 		NumWorkers:    config.NumWorkers,
 		FlushBytes:    config.Flush.Bytes,
 		FlushInterval: config.Flush.Interval,
 		Client:        client,
-		Pipeline:      config.Pipeline,
+		Pipeline:      pipeline,
 		Timeout:       config.Timeout,
 
 		OnError: func(_ context.Context, err error) {
@@ -184,10 +184,10 @@ func shouldRetryEvent(status int) bool {
 	return false
 }
 
-func pushDocuments(ctx context.Context, logger *zap.Logger, index string, document []byte, bulkIndexer esBulkIndexerCurrent, maxAttempts int) error {
+func pushDocuments(ctx context.Context, logger *zap.Logger, index string, docID string, routing string, document []byte, bulkIndexer esBulkIndexerCurrent, maxAttempts int) error {
 	attempts := 1
 	body := bytes.NewReader(document)
-	item := esBulkIndexerItem{Action: createAction, Index: index, Body: body}
+	item := esBulkIndexerItem{Action: createAction, Index: index, DocumentID: docID, Routing: routing, Body: body}
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
 	item.OnFailure = func(ctx context.Context, item esBulkIndexerItem, resp esBulkIndexerResponseItem, err error) {
@@ -203,6 +203,13 @@ func pushDocuments(ctx context.Context, logger *zap.Logger, index string, docume
 			_, _ = body.Seek(0, io.SeekStart)
 			_ = bulkIndexer.Add(ctx, item)
 
+		case resp.Status == http.StatusConflict && item.DocumentID != "":
+			// The document has already been indexed, e.g. by a previous attempt
+			// of a retried request
+			logger.Debug("Skip docs: already indexed",
+				zap.String("name", index),
+				zap.String("id", item.DocumentID))
+
 		case resp.Status == 0 && err != nil:
 			// Encoding error. We didn't even attempt to send the event
 			logger.Error("Drop docs: failed to add docs to the bulk request buffer.",
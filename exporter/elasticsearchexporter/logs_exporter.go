@@ -20,6 +20,9 @@ type elasticsearchLogsExporter struct {
 
 	index        string
 	dynamicIndex bool
+	dataStream   *indexTemplate
+	documentID   DocumentIDSettings
+	routing      RoutingSettings
 	maxAttempts  int
 
 	client      *esClientCurrent
@@ -41,7 +44,11 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		return nil, err
 	}
 
-	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	pipeline := cfg.Pipeline
+	if cfg.LogsPipeline != "" {
+		pipeline = cfg.LogsPipeline
+	}
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +64,13 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 	if cfg.Index != "" {
 		indexStr = cfg.Index
 	}
+
+	var dataStream *indexTemplate
+	if cfg.LogsDataStream.Index != "" {
+		if dataStream, err = newIndexTemplate(cfg.LogsDataStream); err != nil {
+			return nil, err
+		}
+	}
 	esLogsExp := &elasticsearchLogsExporter{
 		logger:      logger,
 		client:      client,
@@ -64,6 +78,9 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 
 		index:        indexStr,
 		dynamicIndex: cfg.LogsDynamicIndex.Enabled,
+		dataStream:   dataStream,
+		documentID:   cfg.DocumentID,
+		routing:      cfg.Routing,
 		maxAttempts:  maxAttempts,
 		model:        model,
 	}
@@ -102,7 +119,9 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 
 func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord, scope pcommon.InstrumentationScope) error {
 	fIndex := e.index
-	if e.dynamicIndex {
+	if e.dataStream != nil {
+		fIndex = e.dataStream.render(resource, record)
+	} else if e.dynamicIndex {
 		prefix := getFromBothResourceAndAttribute(indexPrefix, resource, record)
 		suffix := getFromBothResourceAndAttribute(indexSuffix, resource, record)
 
@@ -113,5 +132,10 @@ func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, fIndex, document, e.bulkIndexer, e.maxAttempts)
+	docID := documentID(e.documentID, resource, record, document)
+	var routing string
+	if e.routing.Attribute != "" {
+		routing = getFromBothResourceAndAttribute(e.routing.Attribute, resource, record)
+	}
+	return pushDocuments(ctx, e.logger, fIndex, docID, routing, document, e.bulkIndexer, e.maxAttempts)
 }
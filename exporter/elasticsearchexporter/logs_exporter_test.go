@@ -123,6 +123,27 @@ func TestExporter_New(t *testing.T) {
 			}),
 			want: successWithInternalModel(&encodeModel{dedot: false, dedup: true}),
 		},
+		"fail with attribute document_id strategy without attribute": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DocumentID.Strategy = "attribute"
+			}),
+			want: failWith(errConfigNoAttribute),
+		},
+		"fail with unknown document_id strategy": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DocumentID.Strategy = "uuid"
+			}),
+			want: failWithMessage("unknown document_id strategy uuid"),
+		},
+		"fail with invalid logs_data_stream index": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.LogsDataStream.Index = "logs-{service.name"
+			}),
+			want: failWithMessage(`invalid logs_data_stream.index "logs-{service.name": unclosed placeholder`),
+		},
 	}
 
 	for name, test := range tests {
@@ -210,6 +231,81 @@ func TestExporter_PushEvent(t *testing.T) {
 		rec.WaitItems(1)
 	})
 
+	t.Run("publish with document id from attribute", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+			cfg.DocumentID.Strategy = "attribute"
+			cfg.DocumentID.Attribute = "log.id"
+		})
+
+		mustSendLogsWithAttributes(t, exporter, map[string]string{"log.id": "attr-id"}, map[string]string{})
+		mustSendLogsWithAttributes(t, exporter, map[string]string{}, map[string]string{})
+
+		rec.WaitItems(2)
+		items := rec.Items()
+		assert.Equal(t, "attr-id", actionDocumentID(t, items[0]))
+		assert.Equal(t, "", actionDocumentID(t, items[1]))
+	})
+
+	t.Run("publish with data stream and routing from attributes", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+			cfg.LogsIndex = "someindex"
+			cfg.LogsDynamicIndex.Enabled = true
+			cfg.LogsDataStream = DataStreamSettings{
+				Index:    "logs-{service.name}-{deployment.environment}",
+				Defaults: map[string]string{"service.name": "generic"},
+			}
+			cfg.Routing.Attribute = "tenant.id"
+		})
+
+		mustSendLogsWithAttributes(t, exporter,
+			map[string]string{"deployment.environment": "staging", "tenant.id": "tenant-1"},
+			map[string]string{"service.name": "Checkout-API", "deployment.environment": "prod"},
+		)
+		mustSendLogsWithAttributes(t, exporter, map[string]string{}, map[string]string{})
+
+		rec.WaitItems(2)
+		items := rec.Items()
+		assert.Equal(t, "logs-checkout_api-prod", actionField(t, items[0], "_index"))
+		assert.Equal(t, "tenant-1", actionField(t, items[0], "routing"))
+		assert.Equal(t, "logs-generic-default", actionField(t, items[1], "_index"))
+		assert.Equal(t, "", actionField(t, items[1], "routing"))
+	})
+
+	t.Run("publish with document id from hash", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+			cfg.DocumentID.Strategy = "hash"
+		})
+
+		mustSendLogsWithAttributes(t, exporter, map[string]string{"key": "value"}, map[string]string{})
+		mustSendLogsWithAttributes(t, exporter, map[string]string{"key": "value"}, map[string]string{})
+		mustSendLogsWithAttributes(t, exporter, map[string]string{"key": "other"}, map[string]string{})
+
+		rec.WaitItems(3)
+		items := rec.Items()
+		id := actionDocumentID(t, items[0])
+		assert.Len(t, id, 64)
+		assert.Equal(t, id, actionDocumentID(t, items[1]))
+		assert.NotEqual(t, id, actionDocumentID(t, items[2]))
+	})
+
 	t.Run("retry http request", func(t *testing.T) {
 		failures := 0
 		rec := newBulkRecorder()
@@ -396,7 +492,7 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 }
 
 func mustSend(t *testing.T, exporter *elasticsearchLogsExporter, contents string) {
-	err := pushDocuments(context.TODO(), zap.L(), exporter.index, []byte(contents), exporter.bulkIndexer, exporter.maxAttempts)
+	err := pushDocuments(context.TODO(), zap.L(), exporter.index, "", "", []byte(contents), exporter.bulkIndexer, exporter.maxAttempts)
 	require.NoError(t, err)
 }
 
@@ -20,6 +20,8 @@ type elasticsearchTracesExporter struct {
 
 	index        string
 	dynamicIndex bool
+	documentID   DocumentIDSettings
+	routing      RoutingSettings
 	maxAttempts  int
 
 	client      *esClientCurrent
@@ -37,7 +39,11 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		return nil, err
 	}
 
-	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	pipeline := cfg.Pipeline
+	if cfg.TracesPipeline != "" {
+		pipeline = cfg.TracesPipeline
+	}
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +62,8 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 
 		index:        cfg.TracesIndex,
 		dynamicIndex: cfg.TracesDynamicIndex.Enabled,
+		documentID:   cfg.DocumentID,
+		routing:      cfg.Routing,
 		maxAttempts:  maxAttempts,
 		model:        model,
 	}, nil
@@ -105,5 +113,10 @@ func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resou
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, fIndex, document, e.bulkIndexer, e.maxAttempts)
+	docID := documentID(e.documentID, resource, span, document)
+	var routing string
+	if e.routing.Attribute != "" {
+		routing = getFromBothResourceAndAttribute(e.routing.Attribute, resource, span)
+	}
+	return pushDocuments(ctx, e.logger, fIndex, docID, routing, document, e.bulkIndexer, e.maxAttempts)
 }
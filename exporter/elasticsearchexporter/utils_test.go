@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -196,6 +197,25 @@ func (item *itemResponse) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// actionDocumentID returns the _id of the create action of item.
+func actionDocumentID(t *testing.T, item itemRequest) string {
+	var action struct {
+		Create struct {
+			ID string `json:"_id"`
+		} `json:"create"`
+	}
+	require.NoError(t, json.Unmarshal(item.Action, &action))
+	return action.Create.ID
+}
+
+func actionField(t *testing.T, item itemRequest, field string) string {
+	var action struct {
+		Create map[string]string `json:"create"`
+	}
+	require.NoError(t, json.Unmarshal(item.Action, &action))
+	return action.Create[field]
+}
+
 func itemsAllOK(docs []itemRequest) ([]itemResponse, error) {
 	return itemsReportStatus(docs, http.StatusOK)
 }
@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	rotationFieldName = "rotation"
-	backupsFieldName  = "max_backups"
+	rotationFieldName  = "rotation"
+	backupsFieldName   = "max_backups"
+	partitionFieldName = "partition"
 )
 
 // Config defines configuration for file exporter.
@@ -25,6 +26,10 @@ type Config struct {
 	// Rotation defines an option about rotation of telemetry files
 	Rotation *Rotation `mapstructure:"rotation"`
 
+	// Partition defines an option to write the telemetry to files partitioned
+	// by resource attribute and period of time, in the directory at Path.
+	Partition *Partition `mapstructure:"partition"`
+
 	// FormatType define the data format of encoded telemetry data
 	// Options:
 	// - json[default]:  OTLP json bytes.
@@ -63,6 +68,34 @@ type Rotation struct {
 	LocalTime bool `mapstructure:"localtime"`
 }
 
+// Partition an option to write telemetry files by resource attribute and period
+type Partition struct {
+	// Attribute is the resource attribute whose values name the directories
+	// of the files. It defaults to service.name.
+	Attribute string `mapstructure:"attribute"`
+
+	// TimeFormat is the Go time layout naming the files after the period of
+	// time they were written in. It defaults to an hourly 2006-01-02-15.
+	TimeFormat string `mapstructure:"time_format"`
+
+	// LocalTime determines if the computer's local time is used to name the
+	// files. The default is to use UTC time.
+	LocalTime bool `mapstructure:"localtime"`
+
+	// MaxMegabytes is the maximum size in megabytes of a file before the next
+	// file of the period is written. It defaults to 100 megabytes, 0 meaning
+	// no limit.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxAge is how long the files are retained after their last
+	// modification. The default is not to remove the files.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// Compression is the compression of the files as a whole. Supported
+	// compression algorithms: `gzip`[default]. Set it to `none` to disable it.
+	Compression string `mapstructure:"compression"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -79,6 +112,20 @@ func (cfg *Config) Validate() error {
 	if cfg.FlushInterval < 0 {
 		return errors.New("flush_interval must be larger than zero")
 	}
+	if cfg.Partition != nil {
+		if cfg.Rotation != nil {
+			return errors.New("rotation and partition can't be used together")
+		}
+		if cfg.Partition.Attribute == "" || cfg.Partition.TimeFormat == "" {
+			return errors.New("partition attribute and time_format must be non-empty")
+		}
+		if cfg.Partition.MaxMegabytes < 0 || cfg.Partition.MaxAge < 0 {
+			return errors.New("partition max_megabytes and max_age must not be negative")
+		}
+		if cfg.Partition.Compression != compressionGZIP && cfg.Partition.Compression != compressionNone {
+			return errors.New("partition compression is not supported")
+		}
+	}
 	return nil
 }
 
@@ -87,6 +134,16 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
 		return errors.New("empty config for file exporter")
 	}
+	// the defaults of the partition only apply if it is present.
+	if componentParser.IsSet(partitionFieldName) && cfg.Partition == nil {
+		cfg.Partition = &Partition{
+			Attribute:    defaultPartitionAttribute,
+			TimeFormat:   defaultPartitionTimeFormat,
+			MaxMegabytes: defaultPartitionMaxMegabytes,
+			Compression:  compressionGZIP,
+		}
+	}
+
 	// first load the config normally
 	err := componentParser.Unmarshal(cfg, confmap.WithErrorUnused())
 	if err != nil {
@@ -119,6 +119,39 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "path must be non-empty",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_with_default_settings"),
+			expected: &Config{
+				Path:       "./archive",
+				FormatType: formatTypeJSON,
+				Partition: &Partition{
+					Attribute:    defaultPartitionAttribute,
+					TimeFormat:   defaultPartitionTimeFormat,
+					MaxMegabytes: defaultPartitionMaxMegabytes,
+					Compression:  compressionGZIP,
+				},
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_with_custom_settings"),
+			expected: &Config{
+				Path:       "./archive",
+				FormatType: formatTypeJSON,
+				Partition: &Partition{
+					Attribute:    "k8s.namespace.name",
+					TimeFormat:   "2006-01-02",
+					MaxMegabytes: 50,
+					MaxAge:       720 * time.Hour,
+					Compression:  compressionNone,
+				},
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_with_rotation"),
+			errorMessage: "rotation and partition can't be used together",
+		},
 	}
 
 	for _, tt := range tests {
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
@@ -28,6 +29,15 @@ const (
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
+
+	// the compression of the partitioned files
+	compressionGZIP = "gzip"
+	compressionNone = "none"
+
+	// the defaults of the partitioned files
+	defaultPartitionAttribute    = "service.name"
+	defaultPartitionTimeFormat   = "2006-01-02-15"
+	defaultPartitionMaxMegabytes = 100
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -52,14 +62,10 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set.Logger)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -76,14 +82,10 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set.Logger)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -100,14 +102,10 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set.Logger)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -119,6 +117,31 @@ func createLogsExporter(
 	)
 }
 
+// getOrCreateFileExporter returns the exporter shared by the signals of a
+// configuration.
+func getOrCreateFileExporter(conf *Config, logger *zap.Logger) (*sharedcomponent.SharedComponent, error) {
+	if conf.Partition != nil {
+		return exporters.GetOrAdd(conf, func() component.Component {
+			return newPartitionedFileExporter(conf, logger)
+		}), nil
+	}
+	writer, err := buildFileWriter(conf)
+	if err != nil {
+		return nil, err
+	}
+	return exporters.GetOrAdd(conf, func() component.Component {
+		return newFileExporter(conf, writer)
+	}), nil
+}
+
+func newPartitionedFileExporter(conf *Config, logger *zap.Logger) *fileExporter {
+	fe := newFileExporter(conf, nil)
+	fe.partitions = newPartitionedWriter(conf, logger)
+	fe.partitionAttribute = conf.Partition.Attribute
+	fe.writeMessage = buildWriteFunc(conf)
+	return fe
+}
+
 func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
 	return &fileExporter{
 		path:             conf.Path,
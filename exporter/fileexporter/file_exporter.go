@@ -11,9 +11,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
 )
 
 // Marshaler configuration used for marhsaling Protobuf
@@ -52,9 +55,18 @@ type fileExporter struct {
 	flushInterval time.Duration
 	flushTicker   *time.Ticker
 	stopTicker    chan struct{}
+
+	// partitions writes the telemetry to partitioned files instead of file.
+	partitions         *partitionedWriter
+	partitionAttribute string
+	writeMessage       writeFunc
 }
 
 func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if e.partitions != nil {
+		return exportPartitions(e, resourcegroup.Traces(td, e.partitionName), e.tracesMarshaler.MarshalTraces)
+	}
+
 	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
@@ -64,6 +76,10 @@ func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if e.partitions != nil {
+		return exportPartitions(e, resourcegroup.Metrics(md, e.partitionName), e.metricsMarshaler.MarshalMetrics)
+	}
+
 	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
@@ -73,6 +89,10 @@ func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if e.partitions != nil {
+		return exportPartitions(e, resourcegroup.Logs(ld, e.partitionName), e.logsMarshaler.MarshalLogs)
+	}
+
 	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
@@ -85,10 +105,14 @@ func exportMessageAsLine(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	return writeMessageAsLine(e.file, buf)
+}
+
+func writeMessageAsLine(w io.Writer, buf []byte) error {
+	if _, err := w.Write(buf); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
+	if _, err := io.WriteString(w, "\n"); err != nil {
 		return err
 	}
 	return nil
@@ -98,12 +122,43 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
+	return writeMessageAsBuffer(e.file, buf)
+}
+
+func writeMessageAsBuffer(w io.Writer, buf []byte) error {
 	// write the size of each message before writing the message itself.  https://developers.google.com/protocol-buffers/docs/techniques
 	// each encoded object is preceded by 4 bytes (an unsigned 32 bit integer)
 	data := make([]byte, 4, 4+len(buf))
 	binary.BigEndian.PutUint32(data, uint32(len(buf)))
 
-	return binary.Write(e.file, binary.BigEndian, append(data, buf...))
+	return binary.Write(w, binary.BigEndian, append(data, buf...))
+}
+
+// exportPartitions writes the telemetry of each partition to its file.
+func exportPartitions[T any](e *fileExporter, partitions []resourcegroup.Group[T], marshal func(T) ([]byte, error)) error {
+	for _, partition := range partitions {
+		buf, err := marshal(partition.Data)
+		if err != nil {
+			return err
+		}
+		if err = e.exportPartition(partition.Key, e.compressor(buf)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitionName returns the partition of the telemetry of a resource.
+func (e *fileExporter) partitionName(resource pcommon.Resource) string {
+	return partitionName(resource, e.partitionAttribute)
+}
+
+// exportPartition writes a message to the file of its partition.
+func (e *fileExporter) exportPartition(partition string, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.partitions.write(partition, buf, e.writeMessage)
 }
 
 // startFlusher starts the flusher.
@@ -111,8 +166,12 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 func (e *fileExporter) startFlusher() {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	ff, ok := e.file.(interface{ flush() error })
-	if !ok {
+	var ff interface{ flush() error }
+	if e.partitions != nil {
+		ff = e.partitions
+	} else if f, ok := e.file.(interface{ flush() error }); ok {
+		ff = f
+	} else {
 		// Just in case.
 		return
 	}
@@ -154,9 +213,19 @@ func (e *fileExporter) Shutdown(context.Context) error {
 		// Stop the go routine.
 		close(e.stopTicker)
 	}
+	if e.partitions != nil {
+		return e.partitions.Close()
+	}
 	return e.file.Close()
 }
 
+func buildWriteFunc(cfg *Config) writeFunc {
+	if cfg.FormatType == formatTypeProto || cfg.Compression != "" {
+		return writeMessageAsBuffer
+	}
+	return writeMessageAsLine
+}
+
 func buildExportFunc(cfg *Config) func(e *fileExporter, buf []byte) error {
 	if cfg.FormatType == formatTypeProto {
 		return exportMessageAsBuffer
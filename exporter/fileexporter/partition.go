@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// unknownPartition names the partition of the resources without the attribute.
+const unknownPartition = "unknown"
+
+// writeFunc defines how to write an encoded message to a file.
+type writeFunc func(w io.Writer, buf []byte) error
+
+// partitionedWriter writes the messages to a file per partition and period of
+// time, rolling the files over once they reach their maximum size, and
+// removing the files older than the retention.
+type partitionedWriter struct {
+	dir        string
+	timeFormat string
+	localTime  bool
+	maxBytes   int64
+	maxAge     time.Duration
+	gzip       bool
+	extension  string
+	logger     *zap.Logger
+	now        func() time.Time
+
+	// period is the formatted period of the open files.
+	period string
+	files  map[string]*partitionFile
+}
+
+func newPartitionedWriter(cfg *Config, logger *zap.Logger) *partitionedWriter {
+	extension := "." + cfg.FormatType
+	if cfg.Partition.Compression == compressionGZIP {
+		extension += ".gz"
+	}
+	return &partitionedWriter{
+		dir:        cfg.Path,
+		timeFormat: cfg.Partition.TimeFormat,
+		localTime:  cfg.Partition.LocalTime,
+		maxBytes:   int64(cfg.Partition.MaxMegabytes) * 1024 * 1024,
+		maxAge:     cfg.Partition.MaxAge,
+		gzip:       cfg.Partition.Compression == compressionGZIP,
+		extension:  extension,
+		logger:     logger,
+		now:        time.Now,
+		files:      map[string]*partitionFile{},
+	}
+}
+
+// partitionName returns the partition of the telemetry of a resource, named
+// after the value of its attribute.
+func partitionName(resource pcommon.Resource, attribute string) string {
+	value, ok := resource.Attributes().Get(attribute)
+	if !ok || value.AsString() == "" {
+		return unknownPartition
+	}
+	// The name must not escape the directory of the files.
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == 0 {
+			return '_'
+		}
+		return r
+	}, value.AsString())
+	if name == "." || name == ".." {
+		return strings.Repeat("_", len(name))
+	}
+	return name
+}
+
+// write writes a message to the current file of a partition.
+func (w *partitionedWriter) write(partition string, buf []byte, writeMessage writeFunc) error {
+	now := w.now()
+	if !w.localTime {
+		now = now.UTC()
+	}
+	if period := now.Format(w.timeFormat); period != w.period {
+		// The files of the previous period are complete.
+		err := w.closeFiles()
+		w.period = period
+		w.removeExpiredFiles(now)
+		if err != nil {
+			return err
+		}
+	}
+
+	file, ok := w.files[partition]
+	if !ok {
+		var err error
+		if file, err = w.open(partition); err != nil {
+			return err
+		}
+		w.files[partition] = file
+	}
+	if err := writeMessage(file, buf); err != nil {
+		return err
+	}
+	if w.maxBytes > 0 && file.size() >= w.maxBytes {
+		// The next message is written to the next file of the partition.
+		delete(w.files, partition)
+		return file.close()
+	}
+	return nil
+}
+
+// open opens the first file of the partition for the current period which has
+// not reached its maximum size yet, appending to it if it exists.
+func (w *partitionedWriter) open(partition string) (*partitionFile, error) {
+	for index := 0; ; index++ {
+		name := w.period
+		if index > 0 {
+			name += "." + strconv.Itoa(index)
+		}
+		path := filepath.Join(w.dir, partition, name+w.extension)
+
+		var size int64
+		info, err := os.Stat(path)
+		switch {
+		case err == nil:
+			size = info.Size()
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+		if w.maxBytes > 0 && size >= w.maxBytes {
+			continue
+		}
+
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return newPartitionFile(f, size, w.gzip), nil
+	}
+}
+
+func (w *partitionedWriter) flush() error {
+	var errs error
+	for _, file := range w.files {
+		errs = errors.Join(errs, file.flush())
+	}
+	return errs
+}
+
+func (w *partitionedWriter) closeFiles() error {
+	var errs error
+	for partition, file := range w.files {
+		errs = errors.Join(errs, file.close())
+		delete(w.files, partition)
+	}
+	return errs
+}
+
+// Close closes the open files.
+func (w *partitionedWriter) Close() error {
+	return w.closeFiles()
+}
+
+// removeExpiredFiles removes the files written by the exporter which were
+// last modified before the retention, and the directories left empty.
+func (w *partitionedWriter) removeExpiredFiles(now time.Time) {
+	if w.maxAge <= 0 {
+		return
+	}
+	expiry := now.Add(-w.maxAge)
+	var dirs []string
+	err := filepath.WalkDir(w.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != w.dir {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !strings.HasSuffix(path, w.extension) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(expiry) {
+			if err = os.Remove(path); err != nil {
+				return err
+			}
+			w.logger.Debug("Removed expired file", zap.String("path", path))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		w.logger.Warn("Failed to remove the expired files", zap.String("path", w.dir), zap.Error(err))
+	}
+
+	// Remove the deepest directories first, the ones which are not empty
+	// failing to be removed.
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, dir := range dirs {
+		_ = os.Remove(dir)
+	}
+}
+
+// partitionFile is a file of a partition, compressed as a whole if needed.
+type partitionFile struct {
+	file     *os.File
+	counter  *countingWriter
+	gzip     *gzip.Writer
+	buffered *bufio.Writer
+}
+
+func newPartitionFile(f *os.File, size int64, compress bool) *partitionFile {
+	pf := &partitionFile{
+		file:    f,
+		counter: &countingWriter{w: f, n: size},
+	}
+	var w io.Writer = pf.counter
+	if compress {
+		// Appending to an existing file adds a gzip member to it, which gzip
+		// readers concatenate.
+		pf.gzip = gzip.NewWriter(w)
+		w = pf.gzip
+	}
+	pf.buffered = bufio.NewWriter(w)
+	return pf
+}
+
+func (pf *partitionFile) Write(p []byte) (int, error) {
+	return pf.buffered.Write(p)
+}
+
+// size returns the size of the file, including the data still buffered unless
+// it is compressed, in which case the size is only known once it is flushed.
+func (pf *partitionFile) size() int64 {
+	if pf.gzip != nil {
+		return pf.counter.n
+	}
+	return pf.counter.n + int64(pf.buffered.Buffered())
+}
+
+func (pf *partitionFile) flush() error {
+	if err := pf.buffered.Flush(); err != nil {
+		return err
+	}
+	if pf.gzip != nil {
+		return pf.gzip.Flush()
+	}
+	return nil
+}
+
+func (pf *partitionFile) close() error {
+	err := pf.buffered.Flush()
+	if pf.gzip != nil {
+		err = errors.Join(err, pf.gzip.Close())
+	}
+	return errors.Join(err, pf.file.Close())
+}
+
+// countingWriter counts the bytes written to a writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+)
+
+func TestPartitionName(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		expected   string
+	}{
+		{
+			name:       "attribute",
+			attributes: map[string]any{"service.name": "checkout"},
+			expected:   "checkout",
+		},
+		{
+			name:     "no attribute",
+			expected: unknownPartition,
+		},
+		{
+			name:       "empty attribute",
+			attributes: map[string]any{"service.name": ""},
+			expected:   unknownPartition,
+		},
+		{
+			name:       "path separators",
+			attributes: map[string]any{"service.name": "../etc/passwd"},
+			expected:   ".._etc_passwd",
+		},
+		{
+			name:       "parent directory",
+			attributes: map[string]any{"service.name": ".."},
+			expected:   "__",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := pcommon.NewResource()
+			require.NoError(t, resource.Attributes().FromRaw(tt.attributes))
+			assert.Equal(t, tt.expected, partitionName(resource, "service.name"))
+		})
+	}
+}
+
+func newServiceLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message from " + service)
+	}
+	return ld
+}
+
+func TestPartitionLogs(t *testing.T) {
+	ld := newServiceLogs("a", "b", "a")
+	e := &fileExporter{partitionAttribute: "service.name"}
+	partitions := resourcegroup.Logs(ld, e.partitionName)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, "a", partitions[0].Key)
+	assert.Equal(t, 2, partitions[0].Data.ResourceLogs().Len())
+	assert.Equal(t, "b", partitions[1].Key)
+	assert.Equal(t, 1, partitions[1].Data.ResourceLogs().Len())
+
+	// The resources without the attribute are in the unknown partition.
+	ld = newServiceLogs("")
+	partitions = resourcegroup.Logs(ld, e.partitionName)
+	require.Len(t, partitions, 1)
+	assert.Equal(t, unknownPartition, partitions[0].Key)
+}
+
+func newTestPartitionedWriter(t *testing.T, partition *Partition) (*partitionedWriter, *time.Time) {
+	cfg := &Config{Path: t.TempDir(), FormatType: formatTypeJSON, Partition: partition}
+	w := newPartitionedWriter(cfg, zap.NewNop())
+	now := time.Date(2023, 10, 30, 12, 30, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	t.Cleanup(func() { assert.NoError(t, w.Close()) })
+	return w, &now
+}
+
+func readGzipLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	var lines []string
+	scanner := bufio.NewScanner(gr)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestPartitionedWriter(t *testing.T) {
+	w, now := newTestPartitionedWriter(t, &Partition{
+		TimeFormat:  defaultPartitionTimeFormat,
+		Compression: compressionGZIP,
+	})
+
+	require.NoError(t, w.write("a", []byte("1"), writeMessageAsLine))
+	require.NoError(t, w.write("b", []byte("2"), writeMessageAsLine))
+	require.NoError(t, w.write("a", []byte("3"), writeMessageAsLine))
+	// The files of the previous hour are closed.
+	*now = now.Add(time.Hour)
+	require.NoError(t, w.write("a", []byte("4"), writeMessageAsLine))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"1", "3"}, readGzipLines(t, filepath.Join(w.dir, "a", "2023-10-30-12.json.gz")))
+	assert.Equal(t, []string{"2"}, readGzipLines(t, filepath.Join(w.dir, "b", "2023-10-30-12.json.gz")))
+	assert.Equal(t, []string{"4"}, readGzipLines(t, filepath.Join(w.dir, "a", "2023-10-30-13.json.gz")))
+
+	// After a restart, the files are appended to.
+	w.period = ""
+	require.NoError(t, w.write("a", []byte("5"), writeMessageAsLine))
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"4", "5"}, readGzipLines(t, filepath.Join(w.dir, "a", "2023-10-30-13.json.gz")))
+}
+
+func TestPartitionedWriterRollover(t *testing.T) {
+	w, _ := newTestPartitionedWriter(t, &Partition{
+		TimeFormat:  defaultPartitionTimeFormat,
+		Compression: compressionNone,
+	})
+	w.maxBytes = 3
+
+	for _, message := range []string{"12", "34", "56"} {
+		require.NoError(t, w.write("a", []byte(message), writeMessageAsLine))
+	}
+	require.NoError(t, w.flush())
+
+	// The first file is closed once it reaches its maximum size.
+	data, err := os.ReadFile(filepath.Join(w.dir, "a", "2023-10-30-12.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "12\n", string(data))
+	data, err = os.ReadFile(filepath.Join(w.dir, "a", "2023-10-30-12.1.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "34\n", string(data))
+	data, err = os.ReadFile(filepath.Join(w.dir, "a", "2023-10-30-12.2.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "56\n", string(data))
+}
+
+func TestPartitionedWriterRetention(t *testing.T) {
+	w, now := newTestPartitionedWriter(t, &Partition{
+		TimeFormat:  defaultPartitionTimeFormat,
+		MaxAge:      24 * time.Hour,
+		Compression: compressionNone,
+	})
+
+	expired := filepath.Join(w.dir, "old", "2023-10-28-12.json")
+	recent := filepath.Join(w.dir, "a", "2023-10-30-11.json")
+	other := filepath.Join(w.dir, "old", "notes.txt")
+	for _, path := range []string{expired, recent, other} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0600))
+	}
+	require.NoError(t, os.Chtimes(expired, now.Add(-48*time.Hour), now.Add(-48*time.Hour)))
+	require.NoError(t, os.Chtimes(other, now.Add(-48*time.Hour), now.Add(-48*time.Hour)))
+	require.NoError(t, os.Chtimes(recent, now.Add(-time.Hour), now.Add(-time.Hour)))
+
+	// The expired files are removed when the files of a period are opened.
+	require.NoError(t, w.write("a", []byte("1"), writeMessageAsLine))
+	assert.NoFileExists(t, expired)
+	assert.FileExists(t, recent)
+	// The files which were not written by the exporter are kept.
+	assert.FileExists(t, other)
+}
+
+func TestPartitionedExporter(t *testing.T) {
+	cfg := &Config{
+		Path:       t.TempDir(),
+		FormatType: formatTypeJSON,
+		Partition: &Partition{
+			Attribute:   "service.name",
+			TimeFormat:  "2006",
+			Compression: compressionGZIP,
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	le, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.ConsumeLogs(context.Background(), newServiceLogs("checkout", "cart")))
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	year := time.Now().UTC().Format("2006")
+	for _, service := range []string{"checkout", "cart"} {
+		lines := readGzipLines(t, filepath.Join(cfg.Path, service, year+".json.gz"))
+		require.Len(t, lines, 1)
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(lines[0]))
+		require.NoError(t, err)
+		assert.Equal(t, newServiceLogs(service), ld)
+	}
+}
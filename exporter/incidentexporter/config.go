@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	backendPagerDuty  = "pagerduty"
+	backendServiceNow = "servicenow"
+
+	severityCritical = "critical"
+	severityError    = "error"
+	severityWarning  = "warning"
+	severityInfo     = "info"
+)
+
+// Config defines configuration for the incident exporter.
+type Config struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Backend is the incident management system events are sent to, either `pagerduty` or `servicenow`.
+	Backend string `mapstructure:"backend"`
+
+	// PagerDuty holds the settings used when Backend is `pagerduty`.
+	PagerDuty PagerDutyConfig `mapstructure:"pagerduty"`
+
+	// ServiceNow holds the settings used when Backend is `servicenow`.
+	ServiceNow ServiceNowConfig `mapstructure:"servicenow"`
+
+	// Conditions is the list of ottllog conditions that determine which log records become incidents.
+	// A log record matches if any of the conditions is true. All log records match when empty.
+	Conditions []string `mapstructure:"conditions"`
+
+	// DedupKeyAttributes is the list of attributes whose values make up the deduplication key of an incident.
+	// Log record attributes take precedence over resource attributes with the same name.
+	DedupKeyAttributes []string `mapstructure:"dedup_key_attributes"`
+
+	// SeverityMapping maps log severities (trace, debug, info, warn, error and fatal)
+	// to incident severities (critical, error, warning and info).
+	SeverityMapping map[string]string `mapstructure:"severity_mapping"`
+}
+
+// PagerDutyConfig defines configuration for the PagerDuty Events API v2.
+type PagerDutyConfig struct {
+	// RoutingKey is the integration key of the PagerDuty service.
+	RoutingKey configopaque.String `mapstructure:"routing_key"`
+}
+
+// ServiceNowConfig defines configuration for the ServiceNow Table API.
+type ServiceNowConfig struct {
+	// Username is the user incidents are created as.
+	Username string `mapstructure:"username"`
+
+	// Password is the password of Username.
+	Password configopaque.String `mapstructure:"password"`
+
+	// AssignmentGroup is the optional group new incidents are assigned to.
+	AssignmentGroup string `mapstructure:"assignment_group"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if err := cfg.QueueSettings.Validate(); err != nil {
+		return fmt.Errorf("queue settings has invalid configuration: %w", err)
+	}
+
+	switch cfg.Backend {
+	case backendPagerDuty:
+		if cfg.PagerDuty.RoutingKey == "" {
+			return errors.New("\"pagerduty.routing_key\" must be specified")
+		}
+	case backendServiceNow:
+		if cfg.Endpoint == "" {
+			return errors.New("\"endpoint\" must be specified for the servicenow backend")
+		}
+		if cfg.ServiceNow.Username == "" || cfg.ServiceNow.Password == "" {
+			return errors.New("\"servicenow.username\" and \"servicenow.password\" must be specified")
+		}
+	default:
+		return fmt.Errorf("\"backend\" must be one of %q or %q, got %q", backendPagerDuty, backendServiceNow, cfg.Backend)
+	}
+
+	if cfg.Endpoint != "" {
+		if _, err := url.ParseRequestURI(cfg.Endpoint); err != nil {
+			return fmt.Errorf("\"endpoint\" must be a valid URL: %w", err)
+		}
+	}
+
+	for logSeverity, severity := range cfg.SeverityMapping {
+		if _, ok := defaultSeverityMapping[logSeverity]; !ok {
+			return fmt.Errorf("invalid log severity %q in \"severity_mapping\"", logSeverity)
+		}
+		switch severity {
+		case severityCritical, severityError, severityWarning, severityInfo:
+		default:
+			return fmt.Errorf("invalid incident severity %q for log severity %q in \"severity_mapping\"", severity, logSeverity)
+		}
+	}
+
+	if len(cfg.Conditions) > 0 {
+		if _, err := filterottl.NewBoolExprForLog(cfg.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
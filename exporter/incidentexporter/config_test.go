@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "\"pagerduty.routing_key\" must be specified",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "pagerduty"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.PagerDuty.RoutingKey = "my-routing-key"
+				cfg.Conditions = []string{"severity_number >= SEVERITY_NUMBER_ERROR"}
+				cfg.DedupKeyAttributes = []string{"service.name", "error.type"}
+				cfg.SeverityMapping = map[string]string{"error": "critical"}
+			}),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "servicenow"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Backend = backendServiceNow
+				cfg.Endpoint = "https://example.service-now.com"
+				cfg.ServiceNow = ServiceNowConfig{
+					Username:        "otel",
+					Password:        "secret",
+					AssignmentGroup: "sre",
+				}
+				cfg.Conditions = []string{`attributes["incident"] == true`}
+			}),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_backend"),
+			errorMessage: "\"backend\" must be one of \"pagerduty\" or \"servicenow\", got \"opsgenie\"",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_severity"),
+			errorMessage: "invalid incident severity \"urgent\" for log severity \"error\" in \"severity_mapping\"",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_condition"),
+			errorMessage: "unable to parse OTTL statement",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "servicenow_missing_endpoint"),
+			errorMessage: "\"endpoint\" must be specified for the servicenow backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func withDefaultConfig(fns ...func(*Config)) *Config {
+	cfg := createDefaultConfig().(*Config)
+	for _, fn := range fns {
+		fn(cfg)
+	}
+	return cfg
+}
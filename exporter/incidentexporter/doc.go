@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package incidentexporter turns matching log records into PagerDuty or ServiceNow incidents.
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const maxErrMsgLen = 1024
+
+type requestFunc func(ctx context.Context, cfg *Config, inc incident) (*http.Request, error)
+
+type logsExporter struct {
+	config   *Config
+	settings component.TelemetrySettings
+	client   *http.Client
+
+	// condition selects the log records to report, all of them when nil.
+	condition  expr.BoolExpr[ottllog.TransformContext]
+	builder    *incidentBuilder
+	newRequest requestFunc
+}
+
+func newLogsExporter(cfg *Config, settings component.TelemetrySettings) (*logsExporter, error) {
+	e := &logsExporter{
+		config:   cfg,
+		settings: settings,
+		builder:  newIncidentBuilder(cfg),
+	}
+
+	switch cfg.Backend {
+	case backendPagerDuty:
+		e.newRequest = newPagerDutyRequest
+	case backendServiceNow:
+		e.newRequest = newServiceNowRequest
+	default:
+		return nil, fmt.Errorf("unsupported backend %q", cfg.Backend)
+	}
+
+	if len(cfg.Conditions) > 0 {
+		condition, err := filterottl.NewBoolExprForLog(cfg.Conditions, filterottl.StandardLogFuncs(), ottl.IgnoreError, settings)
+		if err != nil {
+			return nil, err
+		}
+		e.condition = condition
+	}
+	return e, nil
+}
+
+func (e *logsExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.config.HTTPClientSettings.ToClient(host, e.settings)
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+// exportLogs creates the incidents of the batch. When some of them fail to be
+// created, only their log records are retried, so that the incidents already
+// created are not created again.
+func (e *logsExporter) exportLogs(ctx context.Context, ld plog.Logs) error {
+	incidents, owners, err := e.incidents(ctx, ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	var retryable, permanent error
+	failed := make([]bool, len(incidents))
+	for i, inc := range incidents {
+		err := e.send(ctx, inc)
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanent = multierr.Append(permanent, err)
+		default:
+			failed[i] = true
+			retryable = multierr.Append(retryable, err)
+		}
+	}
+	if retryable == nil {
+		return permanent
+	}
+	if permanent != nil {
+		e.settings.Logger.Error("Dropping incidents rejected by the backend", zap.Error(permanent))
+	}
+	return consumererror.NewLogs(retryable, failedLogs(ld, owners, failed))
+}
+
+// incidents returns an incident for every matching log record, and for every
+// log record the index of the incident reporting it, -1 when not reported.
+// Log records sharing a deduplication key within the batch are reported once.
+func (e *logsExporter) incidents(ctx context.Context, ld plog.Logs) ([]incident, []int, error) {
+	var incidents []incident
+	var owners []int
+	seen := map[string]int{}
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				if e.condition != nil {
+					matched, err := e.condition.Eval(ctx, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource()))
+					if err != nil {
+						return nil, nil, err
+					}
+					if !matched {
+						owners = append(owners, -1)
+						continue
+					}
+				}
+				inc := e.builder.build(rl.Resource(), lr)
+				if inc.dedupKey != "" {
+					if owner, ok := seen[inc.dedupKey]; ok {
+						owners = append(owners, owner)
+						continue
+					}
+					seen[inc.dedupKey] = len(incidents)
+				}
+				owners = append(owners, len(incidents))
+				incidents = append(incidents, inc)
+			}
+		}
+	}
+	return incidents, owners, nil
+}
+
+// failedLogs returns a copy of the batch holding only the log records of the
+// incidents that failed.
+func failedLogs(ld plog.Logs, owners []int, failed []bool) plog.Logs {
+	out := plog.NewLogs()
+	ld.CopyTo(out)
+	n := 0
+	out.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				owner := owners[n]
+				n++
+				return owner < 0 || !failed[owner]
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return out
+}
+
+func (e *logsExporter) send(ctx context.Context, inc incident) error {
+	req, err := e.newRequest(ctx, e.config, inc)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, string(v))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxErrMsgLen))
+		line := ""
+		if scanner.Scan() {
+			line = scanner.Text()
+		}
+		err = fmt.Errorf("HTTP %d %q: %s", resp.StatusCode, http.StatusText(resp.StatusCode), line)
+
+		// Errors with 4xx status code (excluding 429) should not be retried
+		if resp.StatusCode >= http.StatusBadRequest &&
+			resp.StatusCode < http.StatusInternalServerError &&
+			resp.StatusCode != http.StatusTooManyRequests {
+			return consumererror.NewPermanent(err)
+		}
+		return err
+	}
+	return nil
+}
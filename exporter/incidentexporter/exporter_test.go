@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type recordingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []map[string]any
+}
+
+func newRecordingServer(t *testing.T, status int) *recordingServer {
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		s.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *logsExporter {
+	exp, err := newLogsExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("host.name", "edge-1")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := lrs.AppendEmpty()
+	lr.Body().SetStr("disk full")
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2023, 10, 26, 12, 0, 0, 0, time.UTC)))
+	lr.Attributes().PutStr("error.type", "ENOSPC")
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetStr("disk still full")
+	lr.SetSeverityNumber(plog.SeverityNumberFatal)
+	lr.Attributes().PutStr("error.type", "ENOSPC")
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetStr("connection refused")
+	lr.SetSeverityText("WARN")
+	lr.Attributes().PutStr("error.type", "ECONNREFUSED")
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetStr("request served")
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	return ld
+}
+
+func TestExportPagerDuty(t *testing.T) {
+	server := newRecordingServer(t, http.StatusAccepted)
+	exp := newTestExporter(t, withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoint = server.URL
+		cfg.PagerDuty.RoutingKey = "my-routing-key"
+		cfg.Conditions = []string{`severity_number >= SEVERITY_NUMBER_WARN`, `severity_text == "WARN"`}
+		cfg.DedupKeyAttributes = []string{"service.name", "error.type"}
+	}))
+
+	require.NoError(t, exp.exportLogs(context.Background(), testLogs()))
+	require.Len(t, server.bodies, 2)
+
+	assert.Equal(t, map[string]any{
+		"routing_key":  "my-routing-key",
+		"event_action": "trigger",
+		"dedup_key":    "checkout/ENOSPC",
+		"payload": map[string]any{
+			"summary":   "disk full",
+			"source":    "edge-1",
+			"severity":  "error",
+			"timestamp": "2023-10-26T12:00:00Z",
+			"custom_details": map[string]any{
+				"attributes": map[string]any{"error.type": "ENOSPC"},
+				"resource":   map[string]any{"service.name": "checkout", "host.name": "edge-1"},
+			},
+		},
+	}, server.bodies[0])
+
+	assert.Equal(t, "checkout/ECONNREFUSED", server.bodies[1]["dedup_key"])
+	payload := server.bodies[1]["payload"].(map[string]any)
+	assert.Equal(t, "warning", payload["severity"])
+	assert.NotContains(t, payload, "timestamp")
+}
+
+func TestExportServiceNow(t *testing.T) {
+	server := newRecordingServer(t, http.StatusCreated)
+	exp := newTestExporter(t, withDefaultConfig(func(cfg *Config) {
+		cfg.Backend = backendServiceNow
+		cfg.Endpoint = server.URL + "/"
+		cfg.ServiceNow = ServiceNowConfig{Username: "otel", Password: "secret", AssignmentGroup: "sre"}
+		cfg.Conditions = []string{`severity_number >= SEVERITY_NUMBER_ERROR`}
+		cfg.SeverityMapping = map[string]string{"error": severityCritical}
+	}))
+
+	require.NoError(t, exp.exportLogs(context.Background(), testLogs()))
+	require.Len(t, server.bodies, 2)
+
+	req := server.requests[0]
+	assert.Equal(t, serviceNowIncidentPath, req.URL.Path)
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "otel", username)
+	assert.Equal(t, "secret", password)
+
+	body := server.bodies[0]
+	assert.Equal(t, "disk full", body["short_description"])
+	assert.Equal(t, "1", body["urgency"])
+	assert.Equal(t, "1", body["impact"])
+	assert.Equal(t, "sre", body["assignment_group"])
+	assert.NotContains(t, body, "correlation_id")
+	assert.True(t, strings.HasPrefix(body["description"].(string), "disk full\n\nSource: edge-1\n\n"))
+}
+
+func TestExportErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, permanent: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, permanent: false},
+		{name: "server error", status: http.StatusInternalServerError, permanent: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRecordingServer(t, tt.status)
+			exp := newTestExporter(t, withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = server.URL
+				cfg.PagerDuty.RoutingKey = "my-routing-key"
+				cfg.Conditions = []string{`severity_number >= SEVERITY_NUMBER_FATAL`}
+			}))
+
+			err := exp.exportLogs(context.Background(), testLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestExportRetriesFailedIncidents(t *testing.T) {
+	var mu sync.Mutex
+	var summaries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		summary := body["payload"].(map[string]any)["summary"].(string)
+		mu.Lock()
+		summaries = append(summaries, summary)
+		attempts := len(summaries)
+		mu.Unlock()
+		switch {
+		case summary == "disk full" && attempts == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case summary == "connection refused":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+	exp := newTestExporter(t, withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoint = server.URL
+		cfg.PagerDuty.RoutingKey = "my-routing-key"
+		cfg.Conditions = []string{`severity_number >= SEVERITY_NUMBER_WARN`, `severity_text == "WARN"`}
+	}))
+
+	err := exp.exportLogs(context.Background(), testLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err), "Must retry the incidents failing with a retryable error")
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	retried := logsErr.Data()
+	require.Equal(t, 1, retried.LogRecordCount(), "Must only retry the failed incidents")
+	assert.Equal(t, "disk full", retried.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	require.NoError(t, exp.exportLogs(context.Background(), retried))
+	assert.Equal(t, []string{"disk full", "disk still full", "connection refused", "disk full"}, summaries)
+}
+
+func TestDedupKey(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	lr := plog.NewLogRecord()
+	lr.Attributes().PutStr("service.name", "cart")
+	lr.Attributes().PutStr("long", strings.Repeat("x", maxDedupKeyLen))
+
+	builder := newIncidentBuilder(withDefaultConfig(func(cfg *Config) {
+		cfg.DedupKeyAttributes = []string{"service.name", "missing"}
+	}))
+	assert.Equal(t, "cart", builder.dedupKey(resource, lr))
+
+	builder = newIncidentBuilder(withDefaultConfig(func(cfg *Config) {
+		cfg.DedupKeyAttributes = []string{"missing"}
+	}))
+	assert.Equal(t, "", builder.dedupKey(resource, lr))
+
+	builder = newIncidentBuilder(withDefaultConfig(func(cfg *Config) {
+		cfg.DedupKeyAttributes = []string{"service.name", "long"}
+	}))
+	assert.Len(t, builder.dedupKey(resource, lr), 64)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	assert.Equal(t, "a", truncate("aé", 2))
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter/internal/metadata"
+)
+
+// NewFactory creates a factory for the incident exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Timeout: 30 * time.Second,
+			Headers: map[string]configopaque.String{},
+		},
+		RetrySettings:      exporterhelper.NewDefaultRetrySettings(),
+		QueueSettings:      exporterhelper.NewDefaultQueueSettings(),
+		Backend:            backendPagerDuty,
+		Conditions:         []string{},
+		DedupKeyAttributes: []string{},
+		SeverityMapping:    map[string]string{},
+	}
+}
+
+func createLogsExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Logs, error) {
+	exporterConfig := cfg.(*Config)
+	exp, err := newLogsExporter(exporterConfig, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		set,
+		cfg,
+		exp.exportLogs,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(exporterConfig.RetrySettings),
+		exporterhelper.WithQueue(exporterConfig.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+	)
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestFactory_CreateLogsExporter(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.PagerDuty.RoutingKey = "my-routing-key"
+	})
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestFactory_CreateLogsExporterInvalidBackend(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Backend = "opsgenie"
+	})
+	_, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	defaultSource = "opentelemetry-collector"
+	// maxDedupKeyLen is the longest deduplication key accepted by PagerDuty.
+	maxDedupKeyLen = 255
+)
+
+var defaultSeverityMapping = map[string]string{
+	"trace": severityInfo,
+	"debug": severityInfo,
+	"info":  severityInfo,
+	"warn":  severityWarning,
+	"error": severityError,
+	"fatal": severityCritical,
+}
+
+// incident is the backend agnostic representation of a log record to be reported.
+type incident struct {
+	summary   string
+	source    string
+	severity  string
+	timestamp time.Time
+	dedupKey  string
+	details   map[string]any
+}
+
+type incidentBuilder struct {
+	dedupKeyAttributes []string
+	severityMapping    map[string]string
+}
+
+func newIncidentBuilder(cfg *Config) *incidentBuilder {
+	severityMapping := make(map[string]string, len(defaultSeverityMapping))
+	for logSeverity, severity := range defaultSeverityMapping {
+		severityMapping[logSeverity] = severity
+	}
+	for logSeverity, severity := range cfg.SeverityMapping {
+		severityMapping[logSeverity] = severity
+	}
+	return &incidentBuilder{
+		dedupKeyAttributes: cfg.DedupKeyAttributes,
+		severityMapping:    severityMapping,
+	}
+}
+
+func (b *incidentBuilder) build(resource pcommon.Resource, lr plog.LogRecord) incident {
+	timestamp := lr.Timestamp()
+	if timestamp == 0 {
+		timestamp = lr.ObservedTimestamp()
+	}
+	return incident{
+		summary:   lr.Body().AsString(),
+		source:    source(resource),
+		severity:  b.severity(lr),
+		timestamp: timestamp.AsTime(),
+		dedupKey:  b.dedupKey(resource, lr),
+		details: map[string]any{
+			"attributes": lr.Attributes().AsRaw(),
+			"resource":   resource.Attributes().AsRaw(),
+		},
+	}
+}
+
+// severity maps the severity of the log record to an incident severity.
+// The severity text is only used when the severity number is not set.
+func (b *incidentBuilder) severity(lr plog.LogRecord) string {
+	logSeverity := strings.ToLower(lr.SeverityText())
+	switch sn := lr.SeverityNumber(); {
+	case sn >= plog.SeverityNumberFatal:
+		logSeverity = "fatal"
+	case sn >= plog.SeverityNumberError:
+		logSeverity = "error"
+	case sn >= plog.SeverityNumberWarn:
+		logSeverity = "warn"
+	case sn >= plog.SeverityNumberInfo:
+		logSeverity = "info"
+	case sn >= plog.SeverityNumberDebug:
+		logSeverity = "debug"
+	case sn >= plog.SeverityNumberTrace:
+		logSeverity = "trace"
+	}
+	if severity, ok := b.severityMapping[logSeverity]; ok {
+		return severity
+	}
+	return severityInfo
+}
+
+// dedupKey joins the values of the configured attributes found on the log record or its resource.
+// Keys too long for the backends are replaced by their SHA-256 hash.
+func (b *incidentBuilder) dedupKey(resource pcommon.Resource, lr plog.LogRecord) string {
+	var parts []string
+	for _, name := range b.dedupKeyAttributes {
+		if v, ok := lr.Attributes().Get(name); ok {
+			parts = append(parts, v.AsString())
+		} else if v, ok := resource.Attributes().Get(name); ok {
+			parts = append(parts, v.AsString())
+		}
+	}
+	key := strings.Join(parts, "/")
+	if len(key) > maxDedupKeyLen {
+		sum := sha256.Sum256([]byte(key))
+		key = hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+func source(resource pcommon.Resource) string {
+	for _, name := range []string{conventions.AttributeHostName, conventions.AttributeServiceName} {
+		if v, ok := resource.Attributes().Get(name); ok && v.AsString() != "" {
+			return v.AsString()
+		}
+	}
+	return defaultSource
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	// drop any rune cut in half
+	return strings.ToValidUTF8(s[:maxLen], "")
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultPagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
+	// maxPagerDutySummaryLen is the longest summary accepted by the Events API v2.
+	maxPagerDutySummaryLen = 1024
+)
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key,omitempty"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     string         `json:"timestamp,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// newPagerDutyRequest builds a request triggering an alert through the PagerDuty Events API v2.
+func newPagerDutyRequest(ctx context.Context, cfg *Config, inc incident) (*http.Request, error) {
+	event := pagerDutyEvent{
+		RoutingKey:  string(cfg.PagerDuty.RoutingKey),
+		EventAction: "trigger",
+		DedupKey:    inc.dedupKey,
+		Payload: pagerDutyPayload{
+			Summary:       truncate(inc.summary, maxPagerDutySummaryLen),
+			Source:        inc.source,
+			Severity:      inc.severity,
+			CustomDetails: inc.details,
+		},
+	}
+	if !inc.timestamp.IsZero() && inc.timestamp.Unix() != 0 {
+		event.Payload.Timestamp = inc.timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultPagerDutyEndpoint
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package incidentexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/incidentexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	serviceNowIncidentPath = "/api/now/table/incident"
+	// maxServiceNowShortDescriptionLen is the size of the short_description column of the incident table.
+	maxServiceNowShortDescriptionLen = 160
+)
+
+// serviceNowPriorities maps incident severities to the urgency and impact of a ServiceNow incident,
+// 1 being the highest and 3 the lowest.
+var serviceNowPriorities = map[string]string{
+	severityCritical: "1",
+	severityError:    "2",
+	severityWarning:  "3",
+	severityInfo:     "3",
+}
+
+type serviceNowIncident struct {
+	ShortDescription string `json:"short_description"`
+	Description      string `json:"description"`
+	Urgency          string `json:"urgency"`
+	Impact           string `json:"impact"`
+	CorrelationID    string `json:"correlation_id,omitempty"`
+	AssignmentGroup  string `json:"assignment_group,omitempty"`
+}
+
+// newServiceNowRequest builds a request creating a record in the incident table through the ServiceNow Table API.
+func newServiceNowRequest(ctx context.Context, cfg *Config, inc incident) (*http.Request, error) {
+	description, err := json.MarshalIndent(inc.details, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	record := serviceNowIncident{
+		ShortDescription: truncate(inc.summary, maxServiceNowShortDescriptionLen),
+		Description:      inc.summary + "\n\nSource: " + inc.source + "\n\n" + string(description),
+		Urgency:          serviceNowPriorities[inc.severity],
+		Impact:           serviceNowPriorities[inc.severity],
+		CorrelationID:    inc.dedupKey,
+		AssignmentGroup:  cfg.ServiceNow.AssignmentGroup,
+	}
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/") + serviceNowIncidentPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(cfg.ServiceNow.Username, string(cfg.ServiceNow.Password))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
@@ -27,6 +27,20 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// TopicFromAttribute is the name of a resource attribute. When set, data is
+	// exported to the topic named by the value of the attribute, or to the topic
+	// of the signal if the resource does not have the attribute.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
+	// Traces defines the settings specific to traces.
+	Traces TracesConfig `mapstructure:"traces"`
+
+	// Metrics defines the settings specific to metrics.
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// Logs defines the settings specific to logs.
+	Logs SignalConfig `mapstructure:"logs"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
@@ -39,6 +53,41 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication kafka.Authentication `mapstructure:"auth"`
+
+	// LazyStart makes the exporter start even if the brokers are unreachable.
+	// The connection is retried in the background, while the data is kept in
+	// the sending queue.
+	LazyStart bool `mapstructure:"lazy_start"`
+}
+
+// TracesConfig defines the configuration specific to traces.
+type TracesConfig struct {
+	// The name of the kafka topic to export traces to. Takes precedence over Topic.
+	Topic string `mapstructure:"topic"`
+
+	// PartitionByTraceID splits traces so that each message holds the spans of a
+	// single trace, and sets the trace ID as message key.
+	PartitionByTraceID bool `mapstructure:"partition_by_trace_id"`
+}
+
+// MetricsConfig defines the configuration specific to metrics.
+type MetricsConfig struct {
+	SignalConfig `mapstructure:",squash"`
+
+	// PartitionByResourceAttributes splits metrics by resource, and sets a hash of
+	// the resource attributes as message key, so that all the series of a
+	// resource are sent to the same partition.
+	PartitionByResourceAttributes bool `mapstructure:"partition_by_resource_attributes"`
+}
+
+// SignalConfig defines the configuration specific to metrics or logs.
+type SignalConfig struct {
+	// The name of the kafka topic to export the signal to. Takes precedence over Topic.
+	Topic string `mapstructure:"topic"`
+
+	// KeyAttribute is the name of a resource attribute. When set, data is split by
+	// the value of the attribute, which is set as message key.
+	KeyAttribute string `mapstructure:"key_attribute"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -77,6 +126,16 @@ type Producer struct {
 	// broker request. Defaults to 0 for unlimited. Similar to
 	// `queue.buffering.max.messages` in the JVM producer.
 	FlushMaxMessages int `mapstructure:"flush_max_messages"`
+
+	// IdempotentWrites makes the brokers discard the messages the producer sends
+	// again when retrying, e.g. after a timeout. Requires required_acks to be -1.
+	IdempotentWrites bool `mapstructure:"idempotent_writes"`
+
+	// TransactionalID enables transactions, the messages of each export being
+	// committed at once or not at all. It must be unique to each collector
+	// instance, and is suffixed with the signal of the exporter. Requires
+	// idempotent_writes.
+	TransactionalID string `mapstructure:"transactional_id"`
 }
 
 // MetadataRetry defines retry configuration for Metadata.
@@ -97,6 +156,14 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("producer.required_acks has to be between -1 and 1. configured value %v", cfg.Producer.RequiredAcks)
 	}
 
+	if cfg.Metrics.KeyAttribute != "" && cfg.Metrics.PartitionByResourceAttributes {
+		return fmt.Errorf("metrics.key_attribute and metrics.partition_by_resource_attributes cannot be both set")
+	}
+
+	if err := validateProducerDelivery(cfg); err != nil {
+		return err
+	}
+
 	_, err := saramaProducerCompressionCodec(cfg.Producer.Compression)
 	if err != nil {
 		return err
@@ -105,24 +172,46 @@ func (cfg *Config) Validate() error {
 	return validateSASLConfig(cfg.Authentication.SASL)
 }
 
-func validateSASLConfig(c *kafka.SASLConfig) error {
-	if c == nil {
+func validateProducerDelivery(cfg *Config) error {
+	if cfg.Producer.TransactionalID != "" && !cfg.Producer.IdempotentWrites {
+		return fmt.Errorf("producer.transactional_id requires producer.idempotent_writes to be enabled")
+	}
+	if !cfg.Producer.IdempotentWrites {
 		return nil
 	}
-
-	if c.Username == "" {
-		return fmt.Errorf("auth.sasl.username is required")
+	if cfg.Producer.RequiredAcks != sarama.WaitForAll {
+		return fmt.Errorf("producer.idempotent_writes requires producer.required_acks to be -1. configured value %v", cfg.Producer.RequiredAcks)
+	}
+	if cfg.ProtocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.ProtocolVersion)
+		if err == nil && !version.IsAtLeast(sarama.V0_11_0_0) {
+			return fmt.Errorf("producer.idempotent_writes requires protocol_version to be at least 0.11.0. configured value %v", cfg.ProtocolVersion)
+		}
 	}
+	return nil
+}
 
-	if c.Password == "" {
-		return fmt.Errorf("auth.sasl.password is required")
+func validateSASLConfig(c *kafka.SASLConfig) error {
+	if c == nil {
+		return nil
 	}
 
 	switch c.Mechanism {
+	case "AWS_MSK_IAM_OAUTHBEARER":
+		// The tokens are signed with the AWS credentials, no username nor password are used
+		if c.AWSMSK.Region == "" {
+			return fmt.Errorf("auth.sasl.aws_msk.region is required")
+		}
 	case "PLAIN", "AWS_MSK_IAM", "SCRAM-SHA-256", "SCRAM-SHA-512":
-		// Do nothing, valid mechanism
+		if c.Username == "" {
+			return fmt.Errorf("auth.sasl.username is required")
+		}
+
+		if c.Password == "" {
+			return fmt.Errorf("auth.sasl.password is required")
+		}
 	default:
-		return fmt.Errorf("auth.sasl.mechanism should be one of 'PLAIN', 'AWS_MSK_IAM', 'SCRAM-SHA-256' or 'SCRAM-SHA-512'. configured value %v", c.Mechanism)
+		return fmt.Errorf("auth.sasl.mechanism should be one of 'PLAIN', 'AWS_MSK_IAM', 'AWS_MSK_IAM_OAUTHBEARER', 'SCRAM-SHA-256' or 'SCRAM-SHA-512'. configured value %v", c.Mechanism)
 	}
 
 	if c.Version < 0 || c.Version > 1 {
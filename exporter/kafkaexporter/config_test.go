@@ -54,7 +54,20 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
+				Topic:              "spans",
+				TopicFromAttribute: "tenant",
+				Traces: TracesConfig{
+					PartitionByTraceID: true,
+				},
+				Metrics: MetricsConfig{
+					SignalConfig: SignalConfig{
+						Topic: "metrics",
+					},
+				},
+				Logs: SignalConfig{
+					Topic:        "logs",
+					KeyAttribute: "service.name",
+				},
 				Encoding: "otlp_proto",
 				Brokers:  []string{"foo:123", "bar:456"},
 				Authentication: kafka.Authentication{
@@ -106,7 +119,20 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
+				Topic:              "spans",
+				TopicFromAttribute: "tenant",
+				Traces: TracesConfig{
+					PartitionByTraceID: true,
+				},
+				Metrics: MetricsConfig{
+					SignalConfig: SignalConfig{
+						Topic: "metrics",
+					},
+				},
+				Logs: SignalConfig{
+					Topic:        "logs",
+					KeyAttribute: "service.name",
+				},
 				Encoding: "otlp_proto",
 				Brokers:  []string{"foo:123", "bar:456"},
 				Authentication: kafka.Authentication{
@@ -162,6 +188,65 @@ func TestValidate_err_compression(t *testing.T) {
 	assert.EqualError(t, err, "producer.compression should be one of 'none', 'gzip', 'snappy', 'lz4', or 'zstd'. configured value idk")
 }
 
+func TestValidate_metrics_partitioning(t *testing.T) {
+	config := &Config{
+		Producer: Producer{
+			Compression: "none",
+		},
+		Metrics: MetricsConfig{
+			SignalConfig: SignalConfig{
+				KeyAttribute: "service.name",
+			},
+			PartitionByResourceAttributes: true,
+		},
+	}
+
+	err := config.Validate()
+	assert.EqualError(t, err, "metrics.key_attribute and metrics.partition_by_resource_attributes cannot be both set")
+}
+
+func TestValidate_producer_delivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		producer Producer
+		version  string
+		err      string
+	}{
+		{
+			name:     "idempotent writes",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, IdempotentWrites: true, TransactionalID: "otelcol"},
+			version:  "2.0.0",
+		},
+		{
+			name:     "idempotent writes without all acks",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForLocal, IdempotentWrites: true},
+			err:      "producer.idempotent_writes requires producer.required_acks to be -1. configured value 1",
+		},
+		{
+			name:     "idempotent writes with old protocol",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, IdempotentWrites: true},
+			version:  "0.10.2.0",
+			err:      "producer.idempotent_writes requires protocol_version to be at least 0.11.0. configured value 0.10.2.0",
+		},
+		{
+			name:     "transactions without idempotent writes",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, TransactionalID: "otelcol"},
+			err:      "producer.transactional_id requires producer.idempotent_writes to be enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Producer: tt.producer, ProtocolVersion: tt.version}
+			err := config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
 func TestValidate_sasl_username(t *testing.T) {
 	config := &Config{
 		Producer: Producer{
@@ -213,7 +298,26 @@ func TestValidate_sasl_mechanism(t *testing.T) {
 	}
 
 	err := config.Validate()
-	assert.EqualError(t, err, "auth.sasl.mechanism should be one of 'PLAIN', 'AWS_MSK_IAM', 'SCRAM-SHA-256' or 'SCRAM-SHA-512'. configured value FAKE")
+	assert.EqualError(t, err, "auth.sasl.mechanism should be one of 'PLAIN', 'AWS_MSK_IAM', 'AWS_MSK_IAM_OAUTHBEARER', 'SCRAM-SHA-256' or 'SCRAM-SHA-512'. configured value FAKE")
+}
+
+func TestValidate_sasl_aws_msk_iam_oauthbearer(t *testing.T) {
+	config := &Config{
+		Producer: Producer{
+			Compression: "none",
+		},
+		Authentication: kafka.Authentication{
+			SASL: &kafka.SASLConfig{
+				Mechanism: "AWS_MSK_IAM_OAUTHBEARER",
+			},
+		},
+	}
+
+	err := config.Validate()
+	assert.EqualError(t, err, "auth.sasl.aws_msk.region is required")
+
+	config.Authentication.SASL.AWSMSK.Region = "us-east-1"
+	assert.NoError(t, config.Validate())
 }
 
 func TestValidate_sasl_version(t *testing.T) {
@@ -60,6 +60,12 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, "", cfg.Topic)
 }
 
+func TestSignalTopic(t *testing.T) {
+	assert.Equal(t, "otlp_spans", signalTopic("", "", defaultTracesTopic))
+	assert.Equal(t, "shared", signalTopic("", "shared", defaultTracesTopic))
+	assert.Equal(t, "spans", signalTopic("spans", "shared", defaultTracesTopic))
+}
+
 func TestCreateMetricExporter(t *testing.T) {
 	t.Parallel()
 
@@ -88,6 +94,15 @@ func TestCreateMetricExporter(t *testing.T) {
 			}),
 			err: &net.DNSError{},
 		},
+		{
+			name: "lazy start (validating broker)",
+			conf: applyConfigOption(func(conf *Config) {
+				conf.Brokers = []string{"invalid:9092"}
+				conf.ProtocolVersion = "2.0.0"
+				conf.LazyStart = true
+			}),
+			err: nil,
+		},
 		{
 			name: "default_encoding",
 			conf: applyConfigOption(func(conf *Config) {
@@ -162,6 +177,15 @@ func TestCreateLogExporter(t *testing.T) {
 			}),
 			err: &net.DNSError{},
 		},
+		{
+			name: "lazy start (validating broker)",
+			conf: applyConfigOption(func(conf *Config) {
+				conf.Brokers = []string{"invalid:9092"}
+				conf.ProtocolVersion = "2.0.0"
+				conf.LazyStart = true
+			}),
+			err: nil,
+		},
 		{
 			name: "default_encoding",
 			conf: applyConfigOption(func(conf *Config) {
@@ -236,6 +260,16 @@ func TestCreateTraceExporter(t *testing.T) {
 			marshalers: nil,
 			err:        &net.DNSError{},
 		},
+		{
+			name: "lazy start (validating brokers)",
+			conf: applyConfigOption(func(conf *Config) {
+				conf.Brokers = []string{"invalid:9092"}
+				conf.ProtocolVersion = "2.0.0"
+				conf.LazyStart = true
+			}),
+			marshalers: nil,
+			err:        nil,
+		},
 		{
 			name: "default_encoding",
 			conf: applyConfigOption(func(conf *Config) {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -21,9 +22,15 @@ import (
 
 var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
+// syncProducer is the subset of sarama.SyncProducer used by the exporters.
+type syncProducer interface {
+	SendMessages(msgs []*sarama.ProducerMessage) error
+	Close() error
+}
+
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
+	producer  syncProducer
 	topic     string
 	marshaler TracesMarshaler
 	logger    *zap.Logger
@@ -56,13 +63,17 @@ func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces)
 	return nil
 }
 
+func (e *kafkaTracesProducer) start(ctx context.Context, host component.Host) error {
+	return startProducer(ctx, host, e.producer)
+}
+
 func (e *kafkaTracesProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
+	producer  syncProducer
 	topic     string
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
@@ -86,13 +97,17 @@ func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.M
 	return nil
 }
 
+func (e *kafkaMetricsProducer) start(ctx context.Context, host component.Host) error {
+	return startProducer(ctx, host, e.producer)
+}
+
 func (e *kafkaMetricsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
+	producer  syncProducer
 	topic     string
 	marshaler LogsMarshaler
 	logger    *zap.Logger
@@ -116,11 +131,46 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) erro
 	return nil
 }
 
+func (e *kafkaLogsProducer) start(ctx context.Context, host component.Host) error {
+	return startProducer(ctx, host, e.producer)
+}
+
 func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
-func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
+// newProducer creates the producer of the exporter of signal. With lazy start,
+// the connection to the brokers is deferred until the exporter starts.
+func newProducer(config Config, set exporter.CreateSettings, signal string) (syncProducer, error) {
+	c, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Producer.TransactionalID != "" {
+		// The producers of the signals must not fence each other
+		c.Producer.Transaction.ID = config.Producer.TransactionalID + "-" + signal
+	}
+
+	if config.LazyStart {
+		return newLazyProducer(config, c, set.TelemetrySettings), nil
+	}
+	return newSyncProducer(config.Brokers, c)
+}
+
+// newSyncProducer connects a producer to the brokers, sending the messages of
+// each export in a transaction if the config is transactional.
+func newSyncProducer(brokers []string, c *sarama.Config) (syncProducer, error) {
+	producer, err := sarama.NewSyncProducer(brokers, c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Producer.Transaction.ID != "" {
+		return &transactionalProducer{SyncProducer: producer}, nil
+	}
+	return producer, nil
+}
+
+func newSaramaConfig(config Config) (*sarama.Config, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
@@ -133,6 +183,11 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
 	c.Producer.MaxMessageBytes = config.Producer.MaxMessageBytes
 	c.Producer.Flush.MaxMessages = config.Producer.FlushMaxMessages
+	if config.Producer.IdempotentWrites {
+		c.Producer.Idempotent = true
+		// Ordering, and therefore deduplication, requires a single in-flight request
+		c.Net.MaxOpenRequests = 1
+	}
 
 	if config.ProtocolVersion != "" {
 		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
@@ -152,11 +207,7 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	}
 	c.Producer.Compression = compression
 
-	producer, err := sarama.NewSyncProducer(config.Brokers, c)
-	if err != nil {
-		return nil, err
-	}
-	return producer, nil
+	return c, nil
 }
 
 func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers map[string]MetricsMarshaler) (*kafkaMetricsProducer, error) {
@@ -164,7 +215,16 @@ func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers m
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	switch {
+	case config.Metrics.KeyAttribute != "":
+		marshaler = attributeKeyedMetricsMarshaler{MetricsMarshaler: marshaler, attribute: config.Metrics.KeyAttribute}
+	case config.Metrics.PartitionByResourceAttributes:
+		marshaler = resourceKeyedMetricsMarshaler{MetricsMarshaler: marshaler}
+	}
+	if config.TopicFromAttribute != "" {
+		marshaler = topicRoutedMetricsMarshaler{MetricsMarshaler: marshaler, attribute: config.TopicFromAttribute}
+	}
+	producer, err := newProducer(config, set, "metrics")
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +244,14 @@ func newTracesExporter(config Config, set exporter.CreateSettings, marshalers ma
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	// Jaeger messages hold a single span, and are always keyed by trace ID
+	if _, ok := marshaler.(jaegerMarshaler); config.Traces.PartitionByTraceID && !ok {
+		marshaler = traceIDKeyedTracesMarshaler{TracesMarshaler: marshaler}
+	}
+	if config.TopicFromAttribute != "" {
+		marshaler = topicRoutedTracesMarshaler{TracesMarshaler: marshaler, attribute: config.TopicFromAttribute}
+	}
+	producer, err := newProducer(config, set, "traces")
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +268,13 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	if config.Logs.KeyAttribute != "" {
+		marshaler = attributeKeyedLogsMarshaler{LogsMarshaler: marshaler, attribute: config.Logs.KeyAttribute}
+	}
+	if config.TopicFromAttribute != "" {
+		marshaler = topicRoutedLogsMarshaler{LogsMarshaler: marshaler, attribute: config.TopicFromAttribute}
+	}
+	producer, err := newProducer(config, set, "logs")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"encoding/hex"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// traceIDKeyedTracesMarshaler splits traces by trace ID, and keys the messages of
+// each trace by its trace ID, so that the spans of a trace end up in the same
+// partition.
+type traceIDKeyedTracesMarshaler struct {
+	TracesMarshaler
+}
+
+func (m traceIDKeyedTracesMarshaler) Marshal(td ptrace.Traces, topic string) ([]*sarama.ProducerMessage, error) {
+	var messages []*sarama.ProducerMessage
+	for _, trace := range batchpersignal.SplitTraces(td) {
+		traceMessages, err := m.TracesMarshaler.Marshal(trace, topic)
+		if err != nil {
+			return nil, err
+		}
+		// Split traces hold at least one span
+		traceID := trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+		setKey(traceMessages, traceutil.TraceIDToHexOrEmptyString(traceID))
+		messages = append(messages, traceMessages...)
+	}
+	return messages, nil
+}
+
+// attributeKeyedMetricsMarshaler splits metrics by the value of a resource
+// attribute, and keys the messages by this value.
+type attributeKeyedMetricsMarshaler struct {
+	MetricsMarshaler
+	attribute string
+}
+
+func (m attributeKeyedMetricsMarshaler) Marshal(md pmetric.Metrics, topic string) ([]*sarama.ProducerMessage, error) {
+	return marshalKeyedMetrics(m.MetricsMarshaler, md, topic, func(resource pcommon.Resource) string {
+		return resourceKey(resource.Attributes().Get(m.attribute))
+	})
+}
+
+// resourceKeyedMetricsMarshaler splits metrics by resource, and keys the
+// messages by a hash of the resource attributes.
+type resourceKeyedMetricsMarshaler struct {
+	MetricsMarshaler
+}
+
+func (m resourceKeyedMetricsMarshaler) Marshal(md pmetric.Metrics, topic string) ([]*sarama.ProducerMessage, error) {
+	return marshalKeyedMetrics(m.MetricsMarshaler, md, topic, func(resource pcommon.Resource) string {
+		hash := pdatautil.MapHash(resource.Attributes())
+		return hex.EncodeToString(hash[:])
+	})
+}
+
+// attributeKeyedLogsMarshaler splits logs by the value of a resource attribute,
+// and keys the messages by this value.
+type attributeKeyedLogsMarshaler struct {
+	LogsMarshaler
+	attribute string
+}
+
+func (m attributeKeyedLogsMarshaler) Marshal(ld plog.Logs, topic string) ([]*sarama.ProducerMessage, error) {
+	groups := resourcegroup.Logs(ld, func(resource pcommon.Resource) string {
+		return resourceKey(resource.Attributes().Get(m.attribute))
+	})
+	return marshalGroups(groups, keyedMarshal(m.LogsMarshaler.Marshal, topic))
+}
+
+// marshalKeyedMetrics groups the resources of md by the key returned by keyFunc,
+// and marshals each group in messages keyed by the key of the group.
+func marshalKeyedMetrics(marshaler MetricsMarshaler, md pmetric.Metrics, topic string, keyFunc func(pcommon.Resource) string) ([]*sarama.ProducerMessage, error) {
+	return marshalGroups(resourcegroup.Metrics(md, keyFunc), keyedMarshal(marshaler.Marshal, topic))
+}
+
+// keyedMarshal returns a function marshaling data to topic with marshal, in
+// messages keyed by the given key.
+func keyedMarshal[T any](marshal func(T, string) ([]*sarama.ProducerMessage, error), topic string) func(T, string) ([]*sarama.ProducerMessage, error) {
+	return func(data T, key string) ([]*sarama.ProducerMessage, error) {
+		messages, err := marshal(data, topic)
+		if err != nil {
+			return nil, err
+		}
+		setKey(messages, key)
+		return messages, nil
+	}
+}
+
+// resourceKey returns the message key of a resource. Resources without the key
+// attribute get an empty key.
+func resourceKey(value pcommon.Value, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return value.AsString()
+}
+
+// setKey sets key as the key of messages. Messages are not keyed by an empty key,
+// so that they are distributed over partitions.
+func setKey(messages []*sarama.ProducerMessage, key string) {
+	if key == "" {
+		return
+	}
+	for _, message := range messages {
+		message.Key = sarama.StringEncoder(key)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func messageKeys(t *testing.T, messages []*sarama.ProducerMessage) []string {
+	var keys []string
+	for _, message := range messages {
+		if message.Key == nil {
+			keys = append(keys, "")
+			continue
+		}
+		key, err := message.Key.Encode()
+		require.NoError(t, err)
+		keys = append(keys, string(key))
+	}
+	return keys
+}
+
+func TestTraceIDKeyedTracesMarshaler(t *testing.T) {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetTraceID(pcommon.TraceID{1, 2, 3})
+	spans.AppendEmpty().SetTraceID(pcommon.TraceID{4, 5, 6})
+	spans.AppendEmpty().SetTraceID(pcommon.TraceID{1, 2, 3})
+
+	marshaler := traceIDKeyedTracesMarshaler{TracesMarshaler: newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding)}
+	messages, err := marshaler.Marshal(td, "spans")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.ElementsMatch(t, []string{
+		"01020300000000000000000000000000",
+		"04050600000000000000000000000000",
+	}, messageKeys(t, messages))
+
+	for _, message := range messages {
+		assert.Equal(t, "spans", message.Topic)
+		value, err := message.Value.Encode()
+		require.NoError(t, err)
+		trace, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(value)
+		require.NoError(t, err)
+
+		key, err := message.Key.Encode()
+		require.NoError(t, err)
+		if string(key) == "01020300000000000000000000000000" {
+			assert.Equal(t, 2, trace.SpanCount())
+		} else {
+			assert.Equal(t, 1, trace.SpanCount())
+		}
+	}
+}
+
+func TestAttributeKeyedMetricsMarshaler(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"a", "b", "a", ""} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if service != "" {
+			rm.Resource().Attributes().PutStr("service.name", service)
+		}
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+
+	marshaler := attributeKeyedMetricsMarshaler{
+		MetricsMarshaler: newPdataMetricsMarshaler(&pmetric.ProtoMarshaler{}, defaultEncoding),
+		attribute:        "service.name",
+	}
+	messages, err := marshaler.Marshal(md, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", ""}, messageKeys(t, messages))
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	metrics, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.ResourceMetrics().Len())
+}
+
+func TestResourceKeyedMetricsMarshaler(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, resource := range []map[string]any{
+		{"service.name": "a", "host.name": "h1"},
+		{"service.name": "a", "host.name": "h2"},
+		// Same resource attributes in a different order
+		{"host.name": "h1", "service.name": "a"},
+	} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		require.NoError(t, rm.Resource().Attributes().FromRaw(resource))
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+
+	marshaler := resourceKeyedMetricsMarshaler{
+		MetricsMarshaler: newPdataMetricsMarshaler(&pmetric.ProtoMarshaler{}, defaultEncoding),
+	}
+	messages, err := marshaler.Marshal(md, "metrics")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	keys := messageKeys(t, messages)
+	assert.NotEqual(t, keys[0], keys[1])
+	assert.Len(t, keys[0], 32)
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	metrics, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	// The key of a resource is stable across batches
+	again, err := marshaler.Marshal(md, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, keys, messageKeys(t, again))
+}
+
+func TestAttributeKeyedLogsMarshaler(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, host := range []int64{1, 2, 1} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutInt("host.id", host)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	}
+
+	marshaler := attributeKeyedLogsMarshaler{
+		LogsMarshaler: newPdataLogsMarshaler(&plog.ProtoMarshaler{}, defaultEncoding),
+		attribute:     "host.id",
+	}
+	messages, err := marshaler.Marshal(ld, "logs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, messageKeys(t, messages))
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	logs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, logs.LogRecordCount())
+}
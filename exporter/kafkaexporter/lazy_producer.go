@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lazyconnect"
+)
+
+// lazyProducer connects to the brokers in the background once started, so
+// that unreachable brokers do not prevent the collector from starting.
+type lazyProducer struct {
+	conn *lazyconnect.Connection[syncProducer]
+}
+
+func newLazyProducer(config Config, c *sarama.Config, set component.TelemetrySettings) *lazyProducer {
+	connect := func() (syncProducer, error) {
+		return newSyncProducer(config.Brokers, c)
+	}
+	return &lazyProducer{
+		conn: lazyconnect.New(connect, config.RetrySettings.InitialInterval, set),
+	}
+}
+
+// startProducer starts connecting producer to the brokers if it is a lazy producer.
+func startProducer(_ context.Context, _ component.Host, producer syncProducer) error {
+	if p, ok := producer.(*lazyProducer); ok {
+		p.conn.Start()
+	}
+	return nil
+}
+
+func (p *lazyProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	producer, err := p.conn.Get()
+	if err != nil {
+		return err
+	}
+	return producer.SendMessages(msgs)
+}
+
+func (p *lazyProducer) Close() error {
+	return p.conn.Shutdown(func(producer syncProducer) error {
+		return producer.Close()
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lazyconnect"
+)
+
+func TestLazyProducer(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	mockProducer.ExpectSendMessageAndSucceed()
+
+	connect := make(chan struct{})
+	p := &lazyProducer{
+		conn: lazyconnect.New(func() (syncProducer, error) {
+			<-connect
+			return mockProducer, nil
+		}, time.Millisecond, componenttest.NewNopTelemetrySettings()),
+	}
+	require.NoError(t, startProducer(context.Background(), componenttest.NewNopHost(), p))
+
+	msgs := []*sarama.ProducerMessage{{Topic: "topic", Value: sarama.StringEncoder("value")}}
+	assert.ErrorIs(t, p.SendMessages(msgs), lazyconnect.ErrNotConnected)
+
+	close(connect)
+	assert.Eventually(t, func() bool {
+		return !errors.Is(p.SendMessages(msgs), lazyconnect.ErrNotConnected)
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Close())
+}
+
+func TestLazyProducerCloseNotStarted(t *testing.T) {
+	p := newLazyProducer(Config{}, sarama.NewConfig(), componenttest.NewNopTelemetrySettings())
+	assert.ErrorIs(t, p.SendMessages(nil), lazyconnect.ErrNotConnected)
+	require.NoError(t, p.Close())
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/IBM/sarama"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+)
+
+// marshalGroups marshals each group with its key by marshal, and returns the
+// messages of all groups.
+func marshalGroups[T any](groups []resourcegroup.Group[T], marshal func(data T, key string) ([]*sarama.ProducerMessage, error)) ([]*sarama.ProducerMessage, error) {
+	var messages []*sarama.ProducerMessage
+	for _, group := range groups {
+		groupMessages, err := marshal(group.Data, group.Key)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, groupMessages...)
+	}
+	return messages, nil
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+)
+
+// topicRoutedTracesMarshaler splits traces by the value of a resource attribute,
+// and exports them to the topic named by this value. Traces of resources without
+// the attribute are exported to the configured topic.
+type topicRoutedTracesMarshaler struct {
+	TracesMarshaler
+	attribute string
+}
+
+func (m topicRoutedTracesMarshaler) Marshal(td ptrace.Traces, topic string) ([]*sarama.ProducerMessage, error) {
+	groups := resourcegroup.Traces(td, func(resource pcommon.Resource) string {
+		return routeTopic(resource.Attributes(), m.attribute, topic)
+	})
+	return marshalGroups(groups, m.TracesMarshaler.Marshal)
+}
+
+// topicRoutedMetricsMarshaler splits metrics by the value of a resource
+// attribute, and exports them to the topic named by this value. Metrics of
+// resources without the attribute are exported to the configured topic.
+type topicRoutedMetricsMarshaler struct {
+	MetricsMarshaler
+	attribute string
+}
+
+func (m topicRoutedMetricsMarshaler) Marshal(md pmetric.Metrics, topic string) ([]*sarama.ProducerMessage, error) {
+	groups := resourcegroup.Metrics(md, func(resource pcommon.Resource) string {
+		return routeTopic(resource.Attributes(), m.attribute, topic)
+	})
+	return marshalGroups(groups, m.MetricsMarshaler.Marshal)
+}
+
+// topicRoutedLogsMarshaler splits logs by the value of a resource attribute, and
+// exports them to the topic named by this value. Logs of resources without the
+// attribute are exported to the configured topic.
+type topicRoutedLogsMarshaler struct {
+	LogsMarshaler
+	attribute string
+}
+
+func (m topicRoutedLogsMarshaler) Marshal(ld plog.Logs, topic string) ([]*sarama.ProducerMessage, error) {
+	groups := resourcegroup.Logs(ld, func(resource pcommon.Resource) string {
+		return routeTopic(resource.Attributes(), m.attribute, topic)
+	})
+	return marshalGroups(groups, m.LogsMarshaler.Marshal)
+}
+
+// routeTopic returns the topic named by the value of the attribute in attrs, or
+// fallback if the attribute is missing or empty.
+func routeTopic(attrs pcommon.Map, attribute, fallback string) string {
+	if topic := resourceKey(attrs.Get(attribute)); topic != "" {
+		return topic
+	}
+	return fallback
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func messageTopics(messages []*sarama.ProducerMessage) []string {
+	var topics []string
+	for _, message := range messages {
+		topics = append(topics, message.Topic)
+	}
+	return topics
+}
+
+func TestTopicRoutedTracesMarshaler(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, tenant := range []string{"a", "", "b", "a"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		if tenant != "" {
+			rs.Resource().Attributes().PutStr("tenant", "spans_"+tenant)
+		}
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(pcommon.TraceID{1, 2, 3})
+	}
+
+	marshaler := topicRoutedTracesMarshaler{
+		TracesMarshaler: newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+		attribute:       "tenant",
+	}
+	messages, err := marshaler.Marshal(td, "spans")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spans_a", "spans", "spans_b"}, messageTopics(messages))
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	traces, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, traces.SpanCount())
+}
+
+func TestTopicRoutedTracesMarshalerWithKeys(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, tenant := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID{1, 2, 3})
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID{4, 5, 6})
+	}
+
+	marshaler := topicRoutedTracesMarshaler{
+		TracesMarshaler: traceIDKeyedTracesMarshaler{
+			TracesMarshaler: newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+		},
+		attribute: "tenant",
+	}
+	messages, err := marshaler.Marshal(td, "spans")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "a", "b", "b"}, messageTopics(messages))
+	assert.ElementsMatch(t, []string{
+		"01020300000000000000000000000000",
+		"04050600000000000000000000000000",
+		"01020300000000000000000000000000",
+		"04050600000000000000000000000000",
+	}, messageKeys(t, messages))
+}
+
+func TestTopicRoutedMetricsMarshaler(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"a", "b", "a"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("tenant", tenant)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+
+	marshaler := topicRoutedMetricsMarshaler{
+		MetricsMarshaler: newPdataMetricsMarshaler(&pmetric.ProtoMarshaler{}, defaultEncoding),
+		attribute:        "tenant",
+	}
+	messages, err := marshaler.Marshal(md, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, messageTopics(messages))
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	metrics, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.ResourceMetrics().Len())
+}
+
+func TestTopicRoutedLogsMarshaler(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, tenant := range []string{"", "b"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant", tenant)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	}
+
+	marshaler := topicRoutedLogsMarshaler{
+		LogsMarshaler: newPdataLogsMarshaler(&plog.ProtoMarshaler{}, defaultEncoding),
+		attribute:     "tenant",
+	}
+	messages, err := marshaler.Marshal(ld, "logs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs", "b"}, messageTopics(messages))
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// transactionalProducer sends the messages of each export in a transaction, so
+// that consumers reading committed messages only never see the messages of an
+// export which failed and is retried.
+type transactionalProducer struct {
+	sarama.SyncProducer
+
+	// A producer runs a single transaction at a time
+	mu sync.Mutex
+}
+
+func (p *transactionalProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.BeginTxn(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := p.SyncProducer.SendMessages(msgs); err != nil {
+		if abortErr := p.AbortTxn(); abortErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to abort transaction: %w", abortErr))
+		}
+		return err
+	}
+	if err := p.CommitTxn(); err != nil {
+		if abortErr := p.AbortTxn(); abortErr != nil {
+			return errors.Join(fmt.Errorf("failed to commit transaction: %w", err), fmt.Errorf("failed to abort transaction: %w", abortErr))
+		}
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransactionalMock(t *testing.T) *mocks.SyncProducer {
+	c := sarama.NewConfig()
+	c.Producer.Return.Successes = true
+	c.Producer.RequiredAcks = sarama.WaitForAll
+	c.Producer.Idempotent = true
+	c.Net.MaxOpenRequests = 1
+	c.Producer.Transaction.ID = "otelcol-traces"
+	return mocks.NewSyncProducer(t, c)
+}
+
+func TestTransactionalProducer_commit(t *testing.T) {
+	mock := newTransactionalMock(t)
+	mock.ExpectSendMessageAndSucceed()
+	mock.ExpectSendMessageAndSucceed()
+	p := &transactionalProducer{SyncProducer: mock}
+
+	// The mock fails the test when sending outside a transaction
+	require.NoError(t, p.SendMessages([]*sarama.ProducerMessage{
+		{Topic: "otlp_spans", Value: sarama.ByteEncoder("a")},
+		{Topic: "otlp_spans", Value: sarama.ByteEncoder("b")},
+	}))
+	assert.Equal(t, sarama.ProducerTxnFlagReady, p.TxnStatus())
+	require.NoError(t, p.Close())
+}
+
+func TestTransactionalProducer_abort(t *testing.T) {
+	mock := newTransactionalMock(t)
+	mock.ExpectSendMessageAndFail(fmt.Errorf("failed to send"))
+	p := &transactionalProducer{SyncProducer: mock}
+
+	err := p.SendMessages([]*sarama.ProducerMessage{{Topic: "otlp_spans", Value: sarama.ByteEncoder("a")}})
+	assert.ErrorContains(t, err, "failed to send")
+	assert.Equal(t, sarama.ProducerTxnFlagReady, p.TxnStatus())
+	require.NoError(t, p.Close())
+}
+
+func TestNewSaramaConfig_idempotentWrites(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.IdempotentWrites = true
+	c, err := newSaramaConfig(*cfg)
+	require.NoError(t, err)
+	assert.True(t, c.Producer.Idempotent)
+	assert.Equal(t, 1, c.Net.MaxOpenRequests)
+	assert.NoError(t, c.Validate())
+}
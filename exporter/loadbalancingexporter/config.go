@@ -4,6 +4,8 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
@@ -18,11 +20,47 @@ const (
 	resourceRouting
 )
 
+// maxWeight is the highest weight of a backend, ten times the default one.
+const maxWeight = 1000
+
 // Config defines configuration for the exporter.
 type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RoutingExpression is an OTTL expression evaluated to the key the telemetry is routed by, in place of the
+	// routing_key. It is evaluated against each span and each log record, the batches being split by backend, and
+	// against the first metric of a batch of metrics.
+	RoutingExpression string `mapstructure:"routing_expression"`
+
+	// Weights are the weights of the backends in the ring, by endpoint, so that a backend such as a canary
+	// receives a fraction of the traffic. The backends without weight have the default weight of 100.
+	Weights map[string]int `mapstructure:"weights"`
+
+	// HealthCheck enables the active health checking of the backends, evicting the unhealthy ones from the ring.
+	HealthCheck *HealthCheckSettings `mapstructure:"health_check"`
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RoutingKey != "" && cfg.RoutingExpression != "" {
+		return errors.New("routing_key and routing_expression can't be both set")
+	}
+	for endpoint, weight := range cfg.Weights {
+		if weight < 0 || weight > maxWeight {
+			return fmt.Errorf("the weight of the endpoint %q must be between 0 and %d", endpoint, maxWeight)
+		}
+	}
+	if hc := cfg.HealthCheck; hc != nil {
+		if hc.Interval < 0 || hc.Timeout < 0 || hc.Hysteresis < 0 {
+			return errors.New("the health_check durations can't be negative")
+		}
+		if hc.UnhealthyThreshold < 0 || hc.HealthyThreshold < 0 {
+			return errors.New("the health_check thresholds can't be negative")
+		}
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -30,11 +68,27 @@ type Protocol struct {
 	OTLP otlpexporter.Config `mapstructure:"otlp"`
 }
 
+// HealthCheckSettings defines the configuration of the active health checking of the backends
+type HealthCheckSettings struct {
+	// Interval is the time between the checks of a backend.
+	Interval time.Duration `mapstructure:"interval"`
+	// Timeout is the time a backend has to accept the connection of a check.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// UnhealthyThreshold is the number of consecutive failed checks after which a backend is evicted.
+	UnhealthyThreshold int `mapstructure:"unhealthy_threshold"`
+	// HealthyThreshold is the number of consecutive successful checks after which an evicted backend is readmitted.
+	HealthyThreshold int `mapstructure:"healthy_threshold"`
+	// Hysteresis is the minimum time between two changes of the ring caused by the health of the backends.
+	Hysteresis time.Duration `mapstructure:"hysteresis"`
+}
+
 // ResolverSettings defines the configurations for the backend resolver
 type ResolverSettings struct {
-	Static *StaticResolver `mapstructure:"static"`
-	DNS    *DNSResolver    `mapstructure:"dns"`
-	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
+	Static           *StaticResolver           `mapstructure:"static"`
+	DNS              *DNSResolver              `mapstructure:"dns"`
+	K8sSvc           *K8sSvcResolver           `mapstructure:"k8s"`
+	K8sEndpointSlice *K8sEndpointSliceResolver `mapstructure:"k8s_endpointslice"`
+	AWSCloudMap      *AWSCloudMapResolver      `mapstructure:"aws_cloud_map"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -55,3 +109,21 @@ type K8sSvcResolver struct {
 	Service string  `mapstructure:"service"`
 	Ports   []int32 `mapstructure:"ports"`
 }
+
+// K8sEndpointSliceResolver defines the configuration for the resolver of the endpoints of the EndpointSlices of a service
+type K8sEndpointSliceResolver struct {
+	Service         string  `mapstructure:"service"`
+	Ports           []int32 `mapstructure:"ports"`
+	IncludeNotReady bool    `mapstructure:"include_not_ready"`
+}
+
+// AWSCloudMapResolver defines the configuration for the resolver of the instances registered in AWS Cloud Map
+type AWSCloudMapResolver struct {
+	NamespaceName string        `mapstructure:"namespace"`
+	ServiceName   string        `mapstructure:"service_name"`
+	HealthStatus  string        `mapstructure:"health_status"`
+	Region        string        `mapstructure:"region"`
+	Port          *uint16       `mapstructure:"port"`
+	Interval      time.Duration `mapstructure:"interval"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+}
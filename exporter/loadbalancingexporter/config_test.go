@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -25,3 +27,100 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadServiceDiscoveryResolvers(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "4").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, &K8sEndpointSliceResolver{
+		Service: "lb-svc.lb-ns",
+		Ports:   []int32{4317},
+	}, cfg.Resolver.K8sEndpointSlice)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "5").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	port := uint16(4317)
+	assert.Equal(t, &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		HealthStatus:  "HEALTHY_OR_ELSE_ALL",
+		Port:          &port,
+		Interval:      30 * time.Second,
+		Timeout:       5 * time.Second,
+	}, cfg.Resolver.AWSCloudMap)
+}
+
+func TestLoadRoutingExpressionWeightsAndHealthCheck(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "6").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, `resource.attributes["tenant"]`, cfg.RoutingExpression)
+	assert.Equal(t, map[string]int{"canary:4317": 10}, cfg.Weights)
+	assert.Equal(t, &HealthCheckSettings{
+		Interval:           10 * time.Second,
+		Timeout:            2 * time.Second,
+		UnhealthyThreshold: 3,
+		HealthyThreshold:   2,
+		Hysteresis:         time.Minute,
+	}, cfg.HealthCheck)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    string
+	}{
+		{
+			desc:   "routing key and expression",
+			config: &Config{RoutingKey: "service", RoutingExpression: `attributes["tenant"]`},
+			err:    "routing_key and routing_expression can't be both set",
+		},
+		{
+			desc:   "negative weight",
+			config: &Config{Weights: map[string]int{"canary": -1}},
+			err:    `the weight of the endpoint "canary" must be between 0 and 1000`,
+		},
+		{
+			desc:   "weight too high",
+			config: &Config{Weights: map[string]int{"canary": 1001}},
+			err:    `the weight of the endpoint "canary" must be between 0 and 1000`,
+		},
+		{
+			desc:   "negative health check interval",
+			config: &Config{HealthCheck: &HealthCheckSettings{Interval: -time.Second}},
+			err:    "the health_check durations can't be negative",
+		},
+		{
+			desc:   "negative health check threshold",
+			config: &Config{HealthCheck: &HealthCheckSettings{HealthyThreshold: -1}},
+			err:    "the health_check thresholds can't be negative",
+		},
+		{
+			desc:   "valid",
+			config: &Config{RoutingExpression: `attributes["tenant"]`, Weights: map[string]int{"canary": 0}},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"hash/crc32"
+	"math"
 	"sort"
 )
 
@@ -27,9 +28,10 @@ type hashRing struct {
 	items []ringItem
 }
 
-// newHashRing builds a new immutable consistent hash ring based on the given endpoints.
-func newHashRing(endpoints []string) *hashRing {
-	items := positionsForEndpoints(endpoints, defaultWeight)
+// newHashRing builds a new immutable consistent hash ring based on the given endpoints. The weights, by endpoint
+// with its port, replace the default weight of the endpoints.
+func newHashRing(endpoints []string, weights map[string]int) *hashRing {
+	items := positionsForEndpoints(endpoints, defaultWeight, weights)
 	return &hashRing{
 		items: items,
 	}
@@ -55,46 +57,11 @@ func (h *hashRing) findEndpoint(pos position) string {
 	if ringSize == 0 {
 		return ""
 	}
-	left, right := h.items[:ringSize/2], h.items[ringSize/2:]
-	found := bsearch(pos, left, right)
-	return found.endpoint
-}
-
-// bsearch is a binary search-like algorithm, returning the closest "next" item instead of an exact match
-func bsearch(pos position, left []ringItem, right []ringItem) ringItem {
-	// if it's the last item of the left side, return it
-	if left[len(left)-1].pos == pos {
-		return left[len(left)-1]
-	}
-
-	// if it's the first item of the right side, return it
-	if right[0].pos == pos {
-		return right[0]
-	}
-
-	// if we want a higher angle than the highest from the ring, the first angle is the right one
-	if pos > right[len(right)-1].pos {
-		return left[0]
-	}
-
-	// if the requested position is higher than the highest in the left, the item is in the right side
-	if pos > left[len(left)-1].pos {
-		size := len(right)
-		if size == 1 {
-			return right[0]
-		}
-
-		l, r := right[:size/2], right[size/2:]
-		return bsearch(pos, l, r)
-	}
-
-	// not on the right side, has to be on the left side
-	size := len(left)
-	if size == 1 {
-		return left[0]
-	}
-	l, r := left[:size/2], left[size/2:]
-	return bsearch(pos, l, r)
+	// the first item at or after the position, wrapping around to the first item of the ring
+	i := sort.Search(ringSize, func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+	return h.items[i%ringSize].endpoint
 }
 
 // positionFor calculates all the positions in the ring based. The numPoints indicates how many positions to calculate.
@@ -105,6 +72,10 @@ func positionsFor(endpoint string, numPoints int) []position {
 		h := crc32.NewIEEE()
 		h.Write([]byte(endpoint))
 		h.Write([]byte{byte(i)})
+		if i > math.MaxUint8 {
+			// the first points keep the positions they had before the weights could exceed 256
+			h.Write([]byte{byte(i >> 8)})
+		}
 		hash := h.Sum32()
 		pos := hash % maxPositions
 		res = append(res, position(pos))
@@ -113,13 +84,17 @@ func positionsFor(endpoint string, numPoints int) []position {
 	return res
 }
 
-// positionsForEndpoints calculates all the positions for all the given endpoints
-func positionsForEndpoints(endpoints []string, weight int) []ringItem {
+// positionsForEndpoints calculates all the positions for all the given endpoints, the endpoints having the given
+// weight unless the weights, by endpoint with its port, have another one
+func positionsForEndpoints(endpoints []string, weight int, weights map[string]int) []ringItem {
 	var items []ringItem
 	positions := map[position]bool{} // tracking the used positions
 	for _, endpoint := range endpoints {
-		// for this initial implementation, we don't allow endpoints to have custom weights
-		for _, pos := range positionsFor(endpoint, weight) {
+		numPoints := weight
+		if w, ok := weights[endpointWithPort(endpoint)]; ok {
+			numPoints = w
+		}
+		for _, pos := range positionsFor(endpoint, numPoints) {
 			// if this position is occupied already, skip this item
 			if _, found := positions[pos]; found {
 				continue
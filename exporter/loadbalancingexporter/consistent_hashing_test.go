@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHashRing(t *testing.T) {
@@ -15,16 +16,49 @@ func TestNewHashRing(t *testing.T) {
 	endpoints := []string{"endpoint-1", "endpoint-2"}
 
 	// test
-	ring := newHashRing(endpoints)
+	ring := newHashRing(endpoints, nil)
 
 	// verify
 	assert.Len(t, ring.items, 2*defaultWeight)
 }
 
+func TestNewHashRingWithWeights(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2:4317", "endpoint-3"}
+	weights := map[string]int{"endpoint-1:4317": 10, "endpoint-3:4317": 0}
+
+	// test
+	ring := newHashRing(endpoints, weights)
+
+	// verify
+	counts := map[string]int{}
+	for _, item := range ring.items {
+		counts[item.endpoint]++
+	}
+	assert.Equal(t, map[string]int{"endpoint-1": 10, "endpoint-2:4317": defaultWeight}, counts)
+}
+
+func TestWeightedEndpointFor(t *testing.T) {
+	// prepare
+	endpoints := []string{"stable", "canary"}
+	ring := newHashRing(endpoints, map[string]int{"canary:4317": 10})
+
+	// test
+	canary := 0
+	for i := 0; i < 10000; i++ {
+		if ring.endpointFor([]byte(fmt.Sprintf("trace-%d", i))) == "canary" {
+			canary++
+		}
+	}
+
+	// verify: the canary gets about a tenth of the traffic
+	assert.InDelta(t, 10000/11, canary, 500)
+}
+
 func TestEndpointFor(t *testing.T) {
 	// prepare
 	endpoints := []string{"endpoint-1", "endpoint-2"}
-	ring := newHashRing(endpoints)
+	ring := newHashRing(endpoints, nil)
 
 	for _, tt := range []struct {
 		id       []byte
@@ -58,44 +92,54 @@ func TestPositionsFor(t *testing.T) {
 	assert.Len(t, positions, 10)
 }
 
-func TestBinarySearch(t *testing.T) {
+func TestPositionsForMoreThan256Points(t *testing.T) {
+	// test
+	positions := positionsFor("host1", 1000)
+
+	// verify: the points beyond 256 don't repeat the first ones
+	assert.Equal(t, positionsFor("host1", 256), positions[:256])
+	assert.NotEqual(t, positions[:256], positions[256:512])
+}
+
+func TestFindEndpoint(t *testing.T) {
 	// prepare
-	items := []ringItem{
-		{pos: 14},
-		{pos: 25},
-		{pos: 33},
-		{pos: 47},
-		{pos: 56},
-		{pos: 121},
-		{pos: 134},
-		{pos: 158},
-		{pos: 240},
-		{pos: 270},
-		{pos: 350},
+	ring := &hashRing{}
+	for _, pos := range []position{14, 25, 33, 47, 56, 121, 134, 158, 240, 270, 350} {
+		ring.items = append(ring.items, ringItem{pos: pos, endpoint: fmt.Sprintf("endpoint-%d", pos)})
 	}
-	ringSize := len(items)
-	left, right := items[:ringSize/2], items[ringSize/2:]
 
 	for _, tt := range []struct {
 		requested position
-		expected  position
+		expected  string
 	}{
-		{position(85), position(121)},
-		{position(14), position(14)},
-		{position(351), position(14)},
-		{position(270), position(270)},
-		{position(271), position(350)},
+		{position(85), "endpoint-121"},
+		{position(14), "endpoint-14"},
+		{position(351), "endpoint-14"},
+		{position(270), "endpoint-270"},
+		{position(271), "endpoint-350"},
 	} {
 		t.Run(fmt.Sprintf("Angle %d Requested", uint32(tt.requested)), func(t *testing.T) {
 			// test
-			found := bsearch(tt.requested, left, right)
+			found := ring.findEndpoint(tt.requested)
 
 			// verify
-			assert.Equal(t, tt.expected, found.pos)
+			assert.Equal(t, tt.expected, found)
 		})
 	}
 }
 
+func TestFindEndpointSingleItem(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1"}, map[string]int{"endpoint-1:4317": 1})
+	require.Len(t, ring.items, 1)
+
+	// test and verify
+	for _, pos := range []position{0, ring.items[0].pos, ring.items[0].pos + 1, position(maxPositions - 1)} {
+		assert.Equal(t, "endpoint-1", ring.findEndpoint(pos))
+	}
+	assert.Equal(t, "endpoint-1", ring.endpointFor([]byte{1, 2, 3, 4}))
+}
+
 func TestPositionsForEndpoints(t *testing.T) {
 	for _, tt := range []struct {
 		name      string
@@ -146,7 +190,7 @@ func TestPositionsForEndpoints(t *testing.T) {
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			// test
-			items := positionsForEndpoints(tt.endpoints, 5)
+			items := positionsForEndpoints(tt.endpoints, 5, nil)
 
 			// verify
 			assert.Equal(t, tt.expected, items)
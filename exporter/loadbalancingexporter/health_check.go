@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckInterval   = 5 * time.Second
+	defaultHealthCheckTimeout    = time.Second
+	defaultUnhealthyThreshold    = 3
+	defaultHealthyThreshold      = 2
+	defaultHealthCheckHysteresis = 30 * time.Second
+)
+
+// backendHealth holds the outcomes of the latest checks of a backend.
+type backendHealth struct {
+	unhealthy bool
+	failures  int
+	successes int
+}
+
+// healthChecker actively checks the health of the resolved backends, so that the unhealthy ones are evicted from
+// the ring until they recover, rather than until the resolver stops returning them.
+type healthChecker struct {
+	logger *zap.Logger
+
+	interval           time.Duration
+	timeout            time.Duration
+	unhealthyThreshold int
+	healthyThreshold   int
+	hysteresis         time.Duration
+
+	check    func(ctx context.Context, endpoint string) error
+	now      func() time.Time
+	onChange func()
+
+	lock      sync.Mutex
+	backends  map[string]*backendHealth
+	evicted   map[string]bool // the unhealthy backends, as seen by the ring
+	lastApply time.Time
+
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	shutdownWg sync.WaitGroup
+}
+
+func newHealthChecker(logger *zap.Logger, cfg *HealthCheckSettings, onChange func()) *healthChecker {
+	hc := &healthChecker{
+		logger:             logger,
+		interval:           cfg.Interval,
+		timeout:            cfg.Timeout,
+		unhealthyThreshold: cfg.UnhealthyThreshold,
+		healthyThreshold:   cfg.HealthyThreshold,
+		hysteresis:         cfg.Hysteresis,
+		check:              dialBackend,
+		now:                time.Now,
+		onChange:           onChange,
+		backends:           map[string]*backendHealth{},
+		evicted:            map[string]bool{},
+		stopCh:             make(chan struct{}),
+	}
+	if hc.interval == 0 {
+		hc.interval = defaultHealthCheckInterval
+	}
+	if hc.timeout == 0 {
+		hc.timeout = defaultHealthCheckTimeout
+	}
+	if hc.unhealthyThreshold == 0 {
+		hc.unhealthyThreshold = defaultUnhealthyThreshold
+	}
+	if hc.healthyThreshold == 0 {
+		hc.healthyThreshold = defaultHealthyThreshold
+	}
+	if hc.hysteresis == 0 {
+		hc.hysteresis = defaultHealthCheckHysteresis
+	}
+	return hc
+}
+
+// dialBackend checks that the backend accepts TCP connections.
+func dialBackend(ctx context.Context, endpoint string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpointWithPort(endpoint))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (hc *healthChecker) start() {
+	hc.shutdownWg.Add(1)
+	go hc.periodicallyCheck()
+}
+
+func (hc *healthChecker) shutdown() {
+	hc.stopOnce.Do(func() {
+		close(hc.stopCh)
+	})
+	hc.shutdownWg.Wait()
+}
+
+func (hc *healthChecker) periodicallyCheck() {
+	defer hc.shutdownWg.Done()
+
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			hc.checkBackends()
+		case <-hc.stopCh:
+			return
+		}
+	}
+}
+
+// setEndpoints replaces the backends to check with the resolved ones. The new backends are deemed healthy until
+// their checks fail.
+func (hc *healthChecker) setEndpoints(endpoints []string) {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	backends := make(map[string]*backendHealth, len(endpoints))
+	for _, endpoint := range endpoints {
+		if health, ok := hc.backends[endpoint]; ok {
+			backends[endpoint] = health
+		} else {
+			backends[endpoint] = &backendHealth{}
+		}
+	}
+	hc.backends = backends
+
+	for endpoint := range hc.evicted {
+		if _, ok := backends[endpoint]; !ok {
+			delete(hc.evicted, endpoint)
+		}
+	}
+}
+
+// healthy returns the endpoints which aren't evicted, or all of them when all of them are, as there would be
+// nowhere to send the telemetry to otherwise.
+func (hc *healthChecker) healthy(endpoints []string) []string {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	var healthy []string
+	for _, endpoint := range endpoints {
+		if !hc.evicted[endpoint] {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) == 0 {
+		return endpoints
+	}
+	return healthy
+}
+
+// checkBackends checks all the backends, and evicts or readmits the ones whose health changed, unless the ring
+// changed less than the hysteresis ago. The pending changes are then applied after a later check.
+func (hc *healthChecker) checkBackends() {
+	hc.lock.Lock()
+	endpoints := make([]string, 0, len(hc.backends))
+	for endpoint := range hc.backends {
+		endpoints = append(endpoints, endpoint)
+	}
+	hc.lock.Unlock()
+
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), hc.timeout)
+			defer cancel()
+			errs[i] = hc.check(ctx, endpoint)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	if hc.update(endpoints, errs) {
+		hc.onChange()
+	}
+}
+
+// update records the outcomes of the checks, and returns whether the evicted backends changed.
+func (hc *healthChecker) update(endpoints []string, errs []error) bool {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	for i, endpoint := range endpoints {
+		health, ok := hc.backends[endpoint]
+		if !ok {
+			// removed by the resolver in the meantime
+			continue
+		}
+		if errs[i] != nil {
+			health.failures++
+			health.successes = 0
+			if !health.unhealthy && health.failures >= hc.unhealthyThreshold {
+				health.unhealthy = true
+				hc.logger.Warn("backend failed its health checks", zap.String("endpoint", endpoint), zap.Error(errs[i]))
+			}
+		} else {
+			health.successes++
+			health.failures = 0
+			if health.unhealthy && health.successes >= hc.healthyThreshold {
+				health.unhealthy = false
+				hc.logger.Info("backend passed its health checks", zap.String("endpoint", endpoint))
+			}
+		}
+	}
+
+	changed := false
+	for endpoint, health := range hc.backends {
+		if health.unhealthy != hc.evicted[endpoint] {
+			changed = true
+			break
+		}
+	}
+	now := hc.now()
+	if !changed || now.Sub(hc.lastApply) < hc.hysteresis {
+		return false
+	}
+
+	evicted := map[string]bool{}
+	for endpoint, health := range hc.backends {
+		if health.unhealthy {
+			evicted[endpoint] = true
+		}
+	}
+	hc.evicted = evicted
+	hc.lastApply = now
+	_ = stats.RecordWithTags(context.Background(), nil, mNumUnhealthyBackends.M(int64(len(evicted))))
+	return true
+}
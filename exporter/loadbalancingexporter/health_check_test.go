@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+// fakeBackends fails the health checks of the endpoints marked as down.
+type fakeBackends struct {
+	lock sync.Mutex
+	down map[string]bool
+}
+
+func (f *fakeBackends) setDown(endpoint string, down bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.down[endpoint] = down
+}
+
+func (f *fakeBackends) check(_ context.Context, endpoint string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.down[endpoint] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestHealthCheckerEvictsAndReadmits(t *testing.T) {
+	// prepare
+	backends := &fakeBackends{down: map[string]bool{}}
+	changes := 0
+	hc := newHealthChecker(zap.NewNop(), &HealthCheckSettings{}, func() { changes++ })
+	hc.check = backends.check
+	now := time.Now()
+	hc.now = func() time.Time { return now }
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+	hc.setEndpoints(endpoints)
+
+	// test: the backend is evicted after the unhealthy threshold
+	backends.setDown("endpoint-2", true)
+	for i := 0; i < defaultUnhealthyThreshold-1; i++ {
+		hc.checkBackends()
+	}
+	assert.Equal(t, endpoints, hc.healthy(endpoints))
+	hc.checkBackends()
+	assert.Equal(t, []string{"endpoint-1"}, hc.healthy(endpoints))
+	assert.Equal(t, 1, changes)
+
+	// test: the recovered backend is readmitted after the healthy threshold, once the hysteresis elapsed
+	backends.setDown("endpoint-2", false)
+	for i := 0; i < defaultHealthyThreshold; i++ {
+		hc.checkBackends()
+	}
+	assert.Equal(t, []string{"endpoint-1"}, hc.healthy(endpoints))
+	now = now.Add(defaultHealthCheckHysteresis)
+	hc.checkBackends()
+	assert.Equal(t, endpoints, hc.healthy(endpoints))
+	assert.Equal(t, 2, changes)
+}
+
+func TestHealthCheckerAllUnhealthy(t *testing.T) {
+	// prepare
+	backends := &fakeBackends{down: map[string]bool{"endpoint-1": true, "endpoint-2": true}}
+	hc := newHealthChecker(zap.NewNop(), &HealthCheckSettings{UnhealthyThreshold: 1}, func() {})
+	hc.check = backends.check
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+	hc.setEndpoints(endpoints)
+
+	// test
+	hc.checkBackends()
+
+	// verify: there's nowhere else to send the telemetry to
+	assert.Equal(t, endpoints, hc.healthy(endpoints))
+}
+
+func TestHealthCheckerForgetsRemovedBackends(t *testing.T) {
+	// prepare
+	backends := &fakeBackends{down: map[string]bool{"endpoint-2": true}}
+	hc := newHealthChecker(zap.NewNop(), &HealthCheckSettings{UnhealthyThreshold: 1}, func() {})
+	hc.check = backends.check
+	hc.setEndpoints([]string{"endpoint-1", "endpoint-2"})
+	hc.checkBackends()
+
+	// test
+	hc.setEndpoints([]string{"endpoint-1"})
+	hc.setEndpoints([]string{"endpoint-1", "endpoint-2"})
+
+	// verify: the backend is deemed healthy again when it's resolved again
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2"}, hc.healthy([]string{"endpoint-1", "endpoint-2"}))
+}
+
+func TestDialBackend(t *testing.T) {
+	// prepare
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+
+	// test
+	assert.NoError(t, dialBackend(context.Background(), endpoint))
+	require.NoError(t, ln.Close())
+	assert.Error(t, dialBackend(context.Background(), endpoint))
+}
+
+func TestLoadBalancerEvictsUnhealthyBackends(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.HealthCheck = &HealthCheckSettings{UnhealthyThreshold: 1, Interval: time.Hour}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+	backends := &fakeBackends{down: map[string]bool{"endpoint-2": true}}
+	p.healthChecker.check = backends.check
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	require.Len(t, p.ring.items, 2*defaultWeight)
+
+	// test
+	p.healthChecker.checkBackends()
+
+	// verify: the ring leaves the backend out, whose exporter is kept for when it recovers
+	assert.Len(t, p.ring.items, defaultWeight)
+	assert.Equal(t, "endpoint-1", p.Endpoint([]byte{128, 128, 0, 0}))
+	assert.Len(t, p.exporters, 2)
+
+	// the resolver removing the backend doesn't depend on its health
+	p.onBackendChanges([]string{"endpoint-1"})
+	assert.Len(t, p.ring.items, defaultWeight)
+	assert.Len(t, p.exporters, 1)
+}
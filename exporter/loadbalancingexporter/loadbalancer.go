@@ -38,8 +38,11 @@ type loadBalancerImp struct {
 	logger *zap.Logger
 	host   component.Host
 
-	res  resolver
-	ring *hashRing
+	res           resolver
+	ring          *hashRing
+	weights       map[string]int
+	healthChecker *healthChecker
+	resolved      []string
 
 	componentFactory componentFactory
 	exporters        map[string]component.Component
@@ -55,6 +58,10 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
 		return nil, errMultipleResolversProvided
 	}
+	// the service discovery resolvers can't be combined with any other resolver
+	if (oCfg.Resolver.K8sEndpointSlice != nil || oCfg.Resolver.AWSCloudMap != nil) && countResolvers(oCfg.Resolver) > 1 {
+		return nil, errMultipleResolversProvided
+	}
 
 	var res resolver
 	if oCfg.Resolver.Static != nil {
@@ -86,32 +93,97 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 		}
 	}
 
+	if oCfg.Resolver.K8sEndpointSlice != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s endpointslice"))
+
+		clt, err := newInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sEndpointSliceResolver(clt, k8sLogger, oCfg.Resolver.K8sEndpointSlice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if oCfg.Resolver.AWSCloudMap != nil {
+		awsLogger := params.Logger.With(zap.String("resolver", "aws_cloud_map"))
+
+		var err error
+		res, err = newCloudMapResolver(awsLogger, oCfg.Resolver.AWSCloudMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
 
-	return &loadBalancerImp{
+	// the endpoints are compared with their ports
+	weights := make(map[string]int, len(oCfg.Weights))
+	for endpoint, weight := range oCfg.Weights {
+		weights[endpointWithPort(endpoint)] = weight
+	}
+
+	lb := &loadBalancerImp{
 		logger:           params.Logger,
 		res:              res,
+		weights:          weights,
 		componentFactory: factory,
 		exporters:        map[string]component.Component{},
-	}, nil
+	}
+	if oCfg.HealthCheck != nil {
+		lb.healthChecker = newHealthChecker(params.Logger.With(zap.String("component", "health_check")), oCfg.HealthCheck, lb.onHealthChanges)
+	}
+	return lb, nil
+}
+
+func countResolvers(cfg ResolverSettings) int {
+	count := 0
+	if cfg.Static != nil {
+		count++
+	}
+	if cfg.DNS != nil {
+		count++
+	}
+	if cfg.K8sSvc != nil {
+		count++
+	}
+	if cfg.K8sEndpointSlice != nil {
+		count++
+	}
+	if cfg.AWSCloudMap != nil {
+		count++
+	}
+	return count
 }
 
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
-	return lb.res.start(ctx)
+	if err := lb.res.start(ctx); err != nil {
+		return err
+	}
+	if lb.healthChecker != nil {
+		lb.healthChecker.start()
+	}
+	return nil
 }
 
 func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	if lb.healthChecker != nil {
+		lb.healthChecker.setEndpoints(resolved)
+	}
 
-	if !newRing.equal(lb.ring) {
-		lb.updateLock.Lock()
-		defer lb.updateLock.Unlock()
+	// The lock is taken before reading the ring and the resolved endpoints,
+	// which the health checks update concurrently.
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
 
+	newRing := newHashRing(lb.ringEndpoints(resolved), lb.weights)
+	if !newRing.equal(lb.ring) || !equalStringSlice(resolved, lb.resolved) {
 		lb.ring = newRing
+		lb.resolved = resolved
 
 		// TODO: set a timeout?
 		ctx := context.Background()
@@ -122,6 +194,24 @@ func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
 	}
 }
 
+// onHealthChanges rebuilds the ring once the health checks evicted or readmitted backends. The exporters of the
+// evicted backends are kept, for when they recover.
+func (lb *loadBalancerImp) onHealthChanges() {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	lb.ring = newHashRing(lb.ringEndpoints(lb.resolved), lb.weights)
+}
+
+// ringEndpoints returns the resolved endpoints which are part of the ring, leaving out the ones evicted by the
+// health checks.
+func (lb *loadBalancerImp) ringEndpoints(resolved []string) []string {
+	if lb.healthChecker == nil {
+		return resolved
+	}
+	return lb.healthChecker.healthy(resolved)
+}
+
 func (lb *loadBalancerImp) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
 		endpoint = endpointWithPort(endpoint)
@@ -174,6 +264,9 @@ func endpointFound(endpoint string, endpoints []string) bool {
 
 func (lb *loadBalancerImp) Shutdown(context.Context) error {
 	lb.stopped = true
+	if lb.healthChecker != nil {
+		lb.healthChecker.shutdown()
+	}
 	return nil
 }
 
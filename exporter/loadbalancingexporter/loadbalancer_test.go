@@ -82,6 +82,42 @@ func TestNewLoadBalancerInvalidK8sResolver(t *testing.T) {
 	assert.True(t, clientcmd.IsConfigurationInvalid(err) || errors.Is(err, errNoSvc))
 }
 
+func TestNewLoadBalancerInvalidK8sEndpointSliceResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sEndpointSlice: &K8sEndpointSliceResolver{
+				Service: "",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.True(t, clientcmd.IsConfigurationInvalid(err) || errors.Is(err, errNoSvc))
+}
+
+func TestNewLoadBalancerInvalidAWSCloudMapResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			AWSCloudMap: &AWSCloudMapResolver{
+				NamespaceName: "cloudmap",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.Equal(t, errNoService, err)
+}
+
 func TestLoadBalancerStart(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -162,6 +198,27 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleResolversWithServiceDiscovery(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sEndpointSlice: &K8sEndpointSliceResolver{
+				Service: "lb-svc.lb-ns",
+			},
+			AWSCloudMap: &AWSCloudMapResolver{
+				NamespaceName: "cloudmap",
+				ServiceName:   "otelcol",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
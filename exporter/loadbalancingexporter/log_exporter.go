@@ -21,12 +21,14 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
 var _ exporter.Logs = (*logExporterImp)(nil)
 
 type logExporterImp struct {
-	loadBalancer loadBalancer
+	loadBalancer      loadBalancer
+	routingExpression *routingExpression[ottllog.TransformContext]
 
 	started    bool
 	shutdownWg sync.WaitGroup
@@ -44,9 +46,15 @@ func newLogsExporter(params exporter.CreateSettings, cfg component.Config) (*log
 		return nil, err
 	}
 
-	return &logExporterImp{
-		loadBalancer: lb,
-	}, nil
+	logExporter := logExporterImp{loadBalancer: lb}
+
+	if expression := cfg.(*Config).RoutingExpression; expression != "" {
+		logExporter.routingExpression, err = newRoutingExpression(expression, ottllog.NewParser, params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &logExporter, nil
 }
 
 func (e *logExporterImp) Capabilities() consumer.Capabilities {
@@ -58,16 +66,20 @@ func (e *logExporterImp) Start(ctx context.Context, host component.Host) error {
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *logExporterImp) Shutdown(context.Context) error {
+func (e *logExporterImp) Shutdown(ctx context.Context) error {
 	if !e.started {
 		return nil
 	}
 	e.started = false
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if e.routingExpression != nil {
+		return e.consumeRoutedLogs(ctx, ld)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
@@ -79,15 +91,98 @@ func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	traceID := traceIDFromLogs(ld)
-	balancingKey := traceID
 	if traceID == pcommon.NewTraceIDEmpty() {
 		// every log may not contain a traceID
 		// generate a random traceID as balancingKey
 		// so the log can be routed to a random backend
-		balancingKey = random()
+		traceID = random()
 	}
 
-	endpoint := e.loadBalancer.Endpoint(balancingKey[:])
+	endpoint := e.loadBalancer.Endpoint(traceID[:])
+	return e.exportLogs(ctx, endpoint, ld)
+}
+
+// consumeRoutedLogs evaluates the routing expression against each log record, and sends the log records to the
+// endpoints their keys are assigned to, a batch per endpoint.
+func (e *logExporterImp) consumeRoutedLogs(ctx context.Context, ld plog.Logs) error {
+	batches, err := e.splitByEndpoint(ctx, ld)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.exportLogs(ctx, endpoint, batch))
+	}
+	return errs
+}
+
+// splitByEndpoint groups the log records by the endpoints their balancing keys are assigned to, keeping their
+// resources and scopes.
+func (e *logExporterImp) splitByEndpoint(ctx context.Context, ld plog.Logs) (map[string]plog.Logs, error) {
+	// the log records without a key nor a traceID are routed to the same random backend
+	fallback := random()
+	batches := map[string]plog.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resources := map[string]plog.ResourceLogs{}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			scopes := map[string]plog.LogRecordSlice{}
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				record := sl.LogRecords().At(k)
+				key, err := e.balancingKey(ctx, record, sl.Scope(), rl.Resource(), fallback)
+				if err != nil {
+					return nil, err
+				}
+				endpoint := e.loadBalancer.Endpoint(key)
+				records, ok := scopes[endpoint]
+				if !ok {
+					resource, ok := resources[endpoint]
+					if !ok {
+						batch, ok := batches[endpoint]
+						if !ok {
+							batch = plog.NewLogs()
+							batches[endpoint] = batch
+						}
+						resource = batch.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(resource.Resource())
+						resource.SetSchemaUrl(rl.SchemaUrl())
+						resources[endpoint] = resource
+					}
+					scope := resource.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(sl.SchemaUrl())
+					records = scope.LogRecords()
+					scopes[endpoint] = records
+				}
+				record.CopyTo(records.AppendEmpty())
+			}
+		}
+	}
+	return batches, nil
+}
+
+// balancingKey returns the value of the routing expression for the log record, falling back to its traceID, or to
+// the fallback key when it has no traceID, when the value is nil.
+func (e *logExporterImp) balancingKey(ctx context.Context, record plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource, fallback pcommon.TraceID) ([]byte, error) {
+	key, ok, err := e.routingExpression.routingKey(ctx, ottllog.NewTransformContext(record, scope, resource))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return []byte(key), nil
+	}
+
+	traceID := record.TraceID()
+	if traceID.IsEmpty() {
+		traceID = fallback
+	}
+	return traceID[:], nil
+}
+
+// exportLogs sends the logs to the exporter of the endpoint, recording the latency of the backend.
+func (e *logExporterImp) exportLogs(ctx context.Context, endpoint string, ld plog.Logs) error {
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
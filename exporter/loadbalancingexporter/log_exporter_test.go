@@ -491,3 +491,56 @@ func newNopMockLogsExporter() exporter.Logs {
 		},
 	}
 }
+
+func TestLogsRoutingExpression(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	cfg.RoutingExpression = `attributes["tenant"]`
+	p, err := newLogsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockLogsExporter(func(ctx context.Context, ld plog.Logs) error {
+			mu.Lock()
+			defer mu.Unlock()
+			records := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i := 0; i < records.Len(); i++ {
+				received[endpoint] = append(received[endpoint], records.At(i).Body().Str())
+			}
+			return nil
+		}), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// every log record of the batch is routed by its own key
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	expected := map[string][]string{}
+	for i := 0; i < 10; i++ {
+		record := records.AppendEmpty()
+		record.Body().SetStr(fmt.Sprintf("log-%d", i))
+		record.SetTraceID([16]byte{1, 2, 3, 4})
+		key := []byte{1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+		if i > 0 {
+			// falls back to the trace ID for the first log record
+			record.Attributes().PutStr("tenant", fmt.Sprintf("tenant-%d", i))
+			key = []byte(fmt.Sprintf("tenant-%d", i))
+		}
+		endpoint := endpointWithPort(lb.Endpoint(key))
+		expected[endpoint] = append(expected[endpoint], record.Body().Str())
+	}
+	require.Len(t, expected, 2)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, expected, received)
+}
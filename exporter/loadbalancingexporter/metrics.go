@@ -14,6 +14,8 @@ var (
 	mNumBackends    = stats.Int64("loadbalancer_num_backends", "Current number of backends in use", stats.UnitDimensionless)
 	mBackendLatency = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
 
+	mNumUnhealthyBackends = stats.Int64("loadbalancer_num_unhealthy_backends", "Current number of backends evicted from the ring by the health checks", stats.UnitDimensionless)
+
 	endpointTagKey      = tag.MustNewKey("endpoint")
 	successTrueMutator  = tag.Upsert(tag.MustNewKey("success"), "true")
 	successFalseMutator = tag.Upsert(tag.MustNewKey("success"), "false")
@@ -69,5 +71,11 @@ func metricViews() []*view.View {
 			},
 			Aggregation: view.Count(),
 		},
+		{
+			Name:        mNumUnhealthyBackends.Name(),
+			Measure:     mNumUnhealthyBackends,
+			Description: mNumUnhealthyBackends.Description(),
+			Aggregation: view.LastValue(),
+		},
 	}
 }
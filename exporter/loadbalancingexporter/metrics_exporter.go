@@ -24,13 +24,15 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 )
 
 var _ exporter.Metrics = (*metricExporterImp)(nil)
 
 type metricExporterImp struct {
-	loadBalancer loadBalancer
-	routingKey   routingKey
+	loadBalancer      loadBalancer
+	routingKey        routingKey
+	routingExpression *routingExpression[ottlmetric.TransformContext]
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -60,6 +62,13 @@ func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %q", cfg.(*Config).RoutingKey)
 	}
+
+	if expression := cfg.(*Config).RoutingExpression; expression != "" {
+		metricExporter.routingExpression, err = newRoutingExpression(expression, ottlmetric.NewParser, params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &metricExporter, nil
 
 }
@@ -72,10 +81,10 @@ func (e *metricExporterImp) Start(ctx context.Context, host component.Host) erro
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *metricExporterImp) Shutdown(context.Context) error {
+func (e *metricExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -90,7 +99,7 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 
 func (e *metricExporterImp) consumeMetric(ctx context.Context, md pmetric.Metrics) error {
 	var exp component.Component
-	routingIds, err := routingIdentifiersFromMetrics(md, e.routingKey)
+	routingIds, err := e.routingIdentifiers(ctx, md)
 	if err != nil {
 		return err
 	}
@@ -126,6 +135,24 @@ func (e *metricExporterImp) consumeMetric(ctx context.Context, md pmetric.Metric
 	return err
 }
 
+// routingIdentifiers returns the value of the routing expression for the first metric, falling back to the routing
+// key when there's no expression or when its value is nil.
+func (e *metricExporterImp) routingIdentifiers(ctx context.Context, md pmetric.Metrics) (map[string]bool, error) {
+	if e.routingExpression != nil && md.MetricCount() > 0 {
+		rm := md.ResourceMetrics().At(0)
+		sm := rm.ScopeMetrics().At(0)
+		tCtx := ottlmetric.NewTransformContext(sm.Metrics().At(0), sm.Metrics(), sm.Scope(), rm.Resource())
+		key, ok, err := e.routingExpression.routingKey(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return map[string]bool{key: true}, nil
+		}
+	}
+	return routingIdentifiersFromMetrics(md, e.routingKey)
+}
+
 func routingIdentifiersFromMetrics(mds pmetric.Metrics, key routingKey) (map[string]bool, error) {
 	ids := make(map[string]bool)
 
@@ -786,3 +786,14 @@ func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Met
 	}
 	return e.ConsumeMetricsFn(ctx, md)
 }
+
+func TestMetricsRoutingExpression(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.RoutingExpression = `Concat([resource.attributes["service.name"], name], "/")`
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	res, err := p.routingIdentifiers(context.Background(), simpleMetricsWithServiceName())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{serviceName1 + "/" + signal1Name: true}, res)
+}
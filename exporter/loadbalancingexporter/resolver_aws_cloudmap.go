@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/servicediscovery"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+var _ resolver = (*cloudMapResolver)(nil)
+
+const (
+	// maxCloudMapInstances is the maximum number of instances returned by DiscoverInstances.
+	maxCloudMapInstances = 1000
+
+	cloudMapAttributeIPv4 = "AWS_INSTANCE_IPV4"
+	cloudMapAttributeIPv6 = "AWS_INSTANCE_IPV6"
+	cloudMapAttributePort = "AWS_INSTANCE_PORT"
+)
+
+var (
+	errNoNamespace = errors.New("no Cloud Map namespace specified to resolve the backends")
+	errNoService   = errors.New("no Cloud Map service specified to resolve the backends")
+
+	awsResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "aws")
+
+	awsResolverSuccessTrueMutators  = []tag.Mutator{awsResolverMutator, successTrueMutator}
+	awsResolverSuccessFalseMutators = []tag.Mutator{awsResolverMutator, successFalseMutator}
+)
+
+type cloudMapResolver struct {
+	logger *zap.Logger
+
+	namespaceName string
+	serviceName   string
+	healthStatus  string
+	port          *uint16
+	discoverer    cloudMapDiscoverer
+	resInterval   time.Duration
+	resTimeout    time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan (struct{})
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+type cloudMapDiscoverer interface {
+	DiscoverInstancesWithContext(ctx aws.Context, input *servicediscovery.DiscoverInstancesInput, opts ...request.Option) (*servicediscovery.DiscoverInstancesOutput, error)
+}
+
+func newCloudMapResolver(logger *zap.Logger, cfg *AWSCloudMapResolver) (*cloudMapResolver, error) {
+	if len(cfg.NamespaceName) == 0 {
+		return nil, errNoNamespace
+	}
+	if len(cfg.ServiceName) == 0 {
+		return nil, errNoService
+	}
+
+	healthStatus := cfg.HealthStatus
+	if healthStatus == "" {
+		healthStatus = servicediscovery.HealthStatusFilterHealthy
+	}
+	if !isValidHealthStatus(healthStatus) {
+		return nil, fmt.Errorf("unsupported health status %q, expected one of %v", healthStatus, servicediscovery.HealthStatusFilter_Values())
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultResInterval
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultResTimeout
+	}
+
+	awsCfg := aws.Config{}
+	if cfg.Region != "" {
+		awsCfg.Region = aws.String(cfg.Region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsCfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the AWS session: %w", err)
+	}
+
+	return &cloudMapResolver{
+		logger:        logger,
+		namespaceName: cfg.NamespaceName,
+		serviceName:   cfg.ServiceName,
+		healthStatus:  healthStatus,
+		port:          cfg.Port,
+		discoverer:    servicediscovery.New(sess),
+		resInterval:   interval,
+		resTimeout:    timeout,
+		stopCh:        make(chan struct{}),
+	}, nil
+}
+
+func isValidHealthStatus(status string) bool {
+	for _, valid := range servicediscovery.HealthStatusFilter_Values() {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *cloudMapResolver) start(ctx context.Context) error {
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	go r.periodicallyResolve()
+
+	r.logger.Debug("AWS Cloud Map resolver started",
+		zap.String("namespace", r.namespaceName), zap.String("service_name", r.serviceName),
+		zap.String("health_status", r.healthStatus),
+		zap.Duration("interval", r.resInterval), zap.Duration("timeout", r.resTimeout))
+	return nil
+}
+
+func (r *cloudMapResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *cloudMapResolver) periodicallyResolve() {
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
+			if _, err := r.resolve(ctx); err != nil {
+				r.logger.Warn("failed to resolve", zap.Error(err))
+			} else {
+				r.logger.Debug("resolved successfully")
+			}
+			cancel()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *cloudMapResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	out, err := r.discoverer.DiscoverInstancesWithContext(ctx, &servicediscovery.DiscoverInstancesInput{
+		NamespaceName: aws.String(r.namespaceName),
+		ServiceName:   aws.String(r.serviceName),
+		HealthStatus:  aws.String(r.healthStatus),
+		MaxResults:    aws.Int64(maxCloudMapInstances),
+	})
+	if err != nil {
+		_ = stats.RecordWithTags(ctx, awsResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	_ = stats.RecordWithTags(ctx, awsResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	backends := make([]string, 0, len(out.Instances))
+	for _, instance := range out.Instances {
+		backend, ok := r.instanceToBackend(instance)
+		if !ok {
+			r.logger.Debug("ignoring the instance without IP address", zap.String("instance_id", aws.StringValue(instance.InstanceId)))
+			continue
+		}
+		backends = append(backends, backend)
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.updateLock.Lock()
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, awsResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+// instanceToBackend returns the endpoint of an instance: its IPv4 address, or
+// else its IPv6 address, with the configured port, or else the port of the
+// instance, or else the default port.
+func (r *cloudMapResolver) instanceToBackend(instance *servicediscovery.HttpInstanceSummary) (string, bool) {
+	ip := aws.StringValue(instance.Attributes[cloudMapAttributeIPv4])
+	if ip == "" {
+		ip = aws.StringValue(instance.Attributes[cloudMapAttributeIPv6])
+	}
+	if ip == "" {
+		return "", false
+	}
+
+	port := aws.StringValue(instance.Attributes[cloudMapAttributePort])
+	if r.port != nil {
+		port = strconv.FormatUint(uint64(*r.port), 10)
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return net.JoinHostPort(ip, port), true
+}
+
+func (r *cloudMapResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/servicediscovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInitialCloudMapResolution(t *testing.T) {
+	// prepare
+	res, err := newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		Region:        "us-east-1",
+	})
+	require.NoError(t, err)
+
+	var input *servicediscovery.DiscoverInstancesInput
+	res.discoverer = &mockCloudMapDiscoverer{
+		onDiscoverInstances: func(in *servicediscovery.DiscoverInstancesInput) (*servicediscovery.DiscoverInstancesOutput, error) {
+			input = in
+			return &servicediscovery.DiscoverInstancesOutput{
+				Instances: []*servicediscovery.HttpInstanceSummary{
+					cloudMapInstance("i-2", map[string]string{cloudMapAttributeIPv4: "10.0.0.2", cloudMapAttributePort: "4317"}),
+					cloudMapInstance("i-1", map[string]string{cloudMapAttributeIPv4: "10.0.0.1"}),
+					cloudMapInstance("i-3", map[string]string{cloudMapAttributeIPv6: "2001:db8::1"}),
+					cloudMapInstance("i-4", map[string]string{"AWS_INSTANCE_CNAME": "backend.example.com"}),
+				},
+			}, nil
+		},
+	}
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317", "[2001:db8::1]:4317"}, resolved)
+	assert.Equal(t, "cloudmap", aws.StringValue(input.NamespaceName))
+	assert.Equal(t, "otelcol", aws.StringValue(input.ServiceName))
+	assert.Equal(t, servicediscovery.HealthStatusFilterHealthy, aws.StringValue(input.HealthStatus))
+}
+
+func TestCloudMapResolutionWithPort(t *testing.T) {
+	// prepare
+	port := uint16(55690)
+	res, err := newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		Region:        "us-east-1",
+		HealthStatus:  servicediscovery.HealthStatusFilterAll,
+		Port:          &port,
+	})
+	require.NoError(t, err)
+
+	res.discoverer = &mockCloudMapDiscoverer{
+		onDiscoverInstances: func(*servicediscovery.DiscoverInstancesInput) (*servicediscovery.DiscoverInstancesOutput, error) {
+			return &servicediscovery.DiscoverInstancesOutput{
+				Instances: []*servicediscovery.HttpInstanceSummary{
+					cloudMapInstance("i-1", map[string]string{cloudMapAttributeIPv4: "10.0.0.1", cloudMapAttributePort: "4317"}),
+					cloudMapInstance("i-2", map[string]string{cloudMapAttributeIPv6: "2001:db8::1"}),
+				},
+			}, nil
+		},
+	}
+
+	// test
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:55690", "[2001:db8::1]:55690"}, resolved)
+}
+
+func TestCloudMapResolutionFailure(t *testing.T) {
+	// prepare
+	res, err := newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		Region:        "us-east-1",
+	})
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	res.discoverer = &mockCloudMapDiscoverer{
+		onDiscoverInstances: func(*servicediscovery.DiscoverInstancesInput) (*servicediscovery.DiscoverInstancesOutput, error) {
+			return nil, expectedErr
+		},
+	}
+
+	// test
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	assert.Nil(t, resolved)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestCloudMapResolverPeriodicallyResolves(t *testing.T) {
+	// prepare
+	res, err := newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		Region:        "us-east-1",
+		Interval:      10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	instances := make(chan []*servicediscovery.HttpInstanceSummary, 2)
+	instances <- []*servicediscovery.HttpInstanceSummary{
+		cloudMapInstance("i-1", map[string]string{cloudMapAttributeIPv4: "10.0.0.1"}),
+	}
+	instances <- []*servicediscovery.HttpInstanceSummary{
+		cloudMapInstance("i-1", map[string]string{cloudMapAttributeIPv4: "10.0.0.1"}),
+		cloudMapInstance("i-2", map[string]string{cloudMapAttributeIPv4: "10.0.0.2"}),
+	}
+	var last []*servicediscovery.HttpInstanceSummary
+	res.discoverer = &mockCloudMapDiscoverer{
+		onDiscoverInstances: func(*servicediscovery.DiscoverInstancesInput) (*servicediscovery.DiscoverInstancesOutput, error) {
+			select {
+			case last = <-instances:
+			default:
+			}
+			return &servicediscovery.DiscoverInstancesOutput{Instances: last}, nil
+		},
+	}
+
+	changes := make(chan []string, 10)
+	res.onChange(func(endpoints []string) {
+		changes <- endpoints
+	})
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317"}, <-changes)
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317"}, <-changes)
+}
+
+func TestNewCloudMapResolverInvalidConfig(t *testing.T) {
+	_, err := newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{ServiceName: "otelcol"})
+	assert.Equal(t, errNoNamespace, err)
+
+	_, err = newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{NamespaceName: "cloudmap"})
+	assert.Equal(t, errNoService, err)
+
+	_, err = newCloudMapResolver(zap.NewNop(), &AWSCloudMapResolver{
+		NamespaceName: "cloudmap",
+		ServiceName:   "otelcol",
+		HealthStatus:  "READY",
+	})
+	assert.EqualError(t, err, `unsupported health status "READY", expected one of [HEALTHY UNHEALTHY ALL HEALTHY_OR_ELSE_ALL]`)
+}
+
+func cloudMapInstance(id string, attributes map[string]string) *servicediscovery.HttpInstanceSummary {
+	return &servicediscovery.HttpInstanceSummary{
+		InstanceId: aws.String(id),
+		Attributes: aws.StringMap(attributes),
+	}
+}
+
+type mockCloudMapDiscoverer struct {
+	onDiscoverInstances func(*servicediscovery.DiscoverInstancesInput) (*servicediscovery.DiscoverInstancesOutput, error)
+}
+
+func (m *mockCloudMapDiscoverer) DiscoverInstancesWithContext(_ aws.Context, input *servicediscovery.DiscoverInstancesInput, _ ...request.Option) (*servicediscovery.DiscoverInstancesOutput, error) {
+	return m.onDiscoverInstances(input)
+}
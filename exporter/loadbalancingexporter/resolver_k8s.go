@@ -66,19 +66,7 @@ func newK8sResolver(clt kubernetes.Interface,
 		return nil, errNoSvc
 	}
 
-	nAddr := strings.SplitN(service, ".", 2)
-	name, namespace := nAddr[0], "default"
-	if len(nAddr) > 1 {
-		namespace = nAddr[1]
-	} else {
-		logger.Info("the namespace for the Kubernetes service wasn't provided, trying to determine the current namespace", zap.String("name", name))
-		if ns, err := getInClusterNamespace(); err == nil {
-			namespace = ns
-			logger.Info("namespace for the Collector determined", zap.String("namespace", namespace))
-		} else {
-			logger.Warn(`could not determine the namespace for this collector, will use "default" as the namespace`, zap.Error(err))
-		}
-	}
+	name, namespace := splitK8sService(logger, service)
 
 	epsSelector := fmt.Sprintf("metadata.name=%s", name)
 	epsListWatcher := &cache.ListWatch{
@@ -206,6 +194,25 @@ func (r *k8sResolver) Endpoints() []string {
 	return r.endpoints
 }
 
+// splitK8sService returns the name and namespace of a service given as name.namespace,
+// or as name when the service is in the namespace of the collector.
+func splitK8sService(logger *zap.Logger, service string) (string, string) {
+	nAddr := strings.SplitN(service, ".", 2)
+	name, namespace := nAddr[0], "default"
+	if len(nAddr) > 1 {
+		namespace = nAddr[1]
+	} else {
+		logger.Info("the namespace for the Kubernetes service wasn't provided, trying to determine the current namespace", zap.String("name", name))
+		if ns, err := getInClusterNamespace(); err == nil {
+			namespace = ns
+			logger.Info("namespace for the Collector determined", zap.String("namespace", namespace))
+		} else {
+			logger.Warn(`could not determine the namespace for this collector, will use "default" as the namespace`, zap.Error(err))
+		}
+	}
+	return name, namespace
+}
+
 const inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 
 func getInClusterNamespace() (string, error) {
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+	"k8s.io/utils/strings/slices"
+)
+
+var _ resolver = (*k8sEndpointSliceResolver)(nil)
+
+var (
+	k8sEndpointSliceResolverMutator              = tag.Upsert(tag.MustNewKey("resolver"), "k8s_endpointslice")
+	k8sEndpointSliceResolverSuccessTrueMutators  = []tag.Mutator{k8sEndpointSliceResolverMutator, successTrueMutator}
+	k8sEndpointSliceResolverSuccessFalseMutators = []tag.Mutator{k8sEndpointSliceResolverMutator, successFalseMutator}
+)
+
+// k8sEndpointSliceResolver resolves the backends from the EndpointSlices of a
+// service, which are updated as soon as the readiness of the pods changes.
+type k8sEndpointSliceResolver struct {
+	logger          *zap.Logger
+	svcName         string
+	svcNs           string
+	port            []int32
+	includeNotReady bool
+
+	once           *sync.Once
+	slicesWatcher  cache.ListerWatcher
+	slicesLock     sync.Mutex
+	sliceAddresses map[string][]string
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.RWMutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sEndpointSliceResolver(clt kubernetes.Interface, logger *zap.Logger, cfg *K8sEndpointSliceResolver) (*k8sEndpointSliceResolver, error) {
+	if len(cfg.Service) == 0 {
+		return nil, errNoSvc
+	}
+
+	name, namespace := splitK8sService(logger, cfg.Service)
+
+	selector := fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)
+	slicesWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			options.TimeoutSeconds = pointer.Int64(1)
+			return clt.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			options.TimeoutSeconds = pointer.Int64(1)
+			return clt.DiscoveryV1().EndpointSlices(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	return &k8sEndpointSliceResolver{
+		logger:          logger,
+		svcName:         name,
+		svcNs:           namespace,
+		port:            cfg.Ports,
+		includeNotReady: cfg.IncludeNotReady,
+		once:            &sync.Once{},
+		slicesWatcher:   slicesWatcher,
+		sliceAddresses:  map[string][]string{},
+		stopCh:          make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sEndpointSliceResolver) start(_ context.Context) error {
+	var initErr error
+	r.once.Do(func() {
+		r.logger.Debug("creating and starting endpoint slices informer")
+		informer := cache.NewSharedInformer(r.slicesWatcher, &discoveryv1.EndpointSlice{}, 0)
+		registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    r.onSliceUpdate,
+			UpdateFunc: func(_, newObj any) { r.onSliceUpdate(newObj) },
+			DeleteFunc: r.onSliceDelete,
+		})
+		if err != nil {
+			initErr = fmt.Errorf("unable to start watching for changes to the specified service names: %w", err)
+			return
+		}
+		go informer.Run(r.stopCh)
+		// wait for the initial slices to be handled, not only listed
+		if !cache.WaitForCacheSync(r.stopCh, registration.HasSynced) {
+			initErr = errors.New("endpoint slices informer not sync")
+		}
+	})
+	if initErr != nil {
+		return initErr
+	}
+
+	r.logger.Debug("K8s endpoint slices resolver started",
+		zap.String("service", r.svcName),
+		zap.String("namespace", r.svcNs),
+		zap.Int32s("ports", r.port),
+		zap.Bool("include_not_ready", r.includeNotReady))
+	return nil
+}
+
+func (r *k8sEndpointSliceResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sEndpointSliceResolver) onSliceUpdate(obj any) {
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		r.logger.Warn("Got an unexpected Kubernetes data type during the update of the endpoint slices of a service", zap.Any("obj", obj))
+		_ = stats.RecordWithTags(context.Background(), k8sEndpointSliceResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return
+	}
+
+	r.slicesLock.Lock()
+	r.sliceAddresses[slice.Name] = r.sliceToAddresses(slice)
+	r.slicesLock.Unlock()
+	_, _ = r.resolve(context.Background())
+}
+
+func (r *k8sEndpointSliceResolver) onSliceDelete(obj any) {
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		r.logger.Warn("Got an unexpected Kubernetes data type during the removal of the endpoint slices of a service", zap.Any("obj", obj))
+		_ = stats.RecordWithTags(context.Background(), k8sEndpointSliceResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return
+	}
+
+	r.slicesLock.Lock()
+	delete(r.sliceAddresses, slice.Name)
+	r.slicesLock.Unlock()
+	_, _ = r.resolve(context.Background())
+}
+
+// sliceToAddresses returns the addresses of the ready endpoints of a slice, or
+// of all its endpoints when the endpoints not ready are included. An endpoint
+// whose readiness is unknown is considered ready.
+func (r *k8sEndpointSliceResolver) sliceToAddresses(slice *discoveryv1.EndpointSlice) []string {
+	var addresses []string
+	for _, endpoint := range slice.Endpoints {
+		if !r.includeNotReady && endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+			continue
+		}
+		addresses = append(addresses, endpoint.Addresses...)
+	}
+	return addresses
+}
+
+func (r *k8sEndpointSliceResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	// the same address may be in several slices while the endpoints are moved
+	// between them
+	addresses := map[string]struct{}{}
+	r.slicesLock.Lock()
+	for _, sliceAddresses := range r.sliceAddresses {
+		for _, addr := range sliceAddresses {
+			addresses[addr] = struct{}{}
+		}
+	}
+	r.slicesLock.Unlock()
+
+	var backends []string
+	for addr := range addresses {
+		if len(r.port) == 0 {
+			backends = append(backends, net.JoinHostPort(addr, defaultPort))
+		} else {
+			for _, port := range r.port {
+				backends = append(backends, net.JoinHostPort(addr, strconv.FormatInt(int64(port), 10)))
+			}
+		}
+	}
+	_ = stats.RecordWithTags(ctx, k8sEndpointSliceResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if slices.Equal(r.Endpoints(), backends) {
+		return r.Endpoints(), nil
+	}
+
+	// the list has changed!
+	r.updateLock.Lock()
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sEndpointSliceResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.Endpoints())
+	}
+	r.changeCallbackLock.RUnlock()
+	return r.Endpoints(), nil
+}
+
+func (r *k8sEndpointSliceResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+func (r *k8sEndpointSliceResolver) Endpoints() []string {
+	r.updateLock.RLock()
+	defer r.updateLock.RUnlock()
+	return r.endpoints
+}
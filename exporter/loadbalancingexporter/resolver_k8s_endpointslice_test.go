@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newEndpointSlice(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "lb-ns",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "lb-svc"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
+
+func newSliceEndpoint(ip string, ready *bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{ip},
+		Conditions: discoveryv1.EndpointConditions{Ready: ready},
+	}
+}
+
+func TestK8sEndpointSliceResolve(t *testing.T) {
+	slice := newEndpointSlice("lb-svc-abcde",
+		newSliceEndpoint("192.168.10.100", pointer.Bool(true)),
+		newSliceEndpoint("192.168.10.101", pointer.Bool(false)),
+		newSliceEndpoint("192.168.10.102", nil),
+	)
+	otherService := newEndpointSlice("other-svc-abcde", newSliceEndpoint("192.168.20.100", nil))
+	otherService.Labels[discoveryv1.LabelServiceName] = "other-svc"
+
+	cl := fake.NewSimpleClientset(slice, otherService)
+	res, err := newK8sEndpointSliceResolver(cl, zap.NewNop(), &K8sEndpointSliceResolver{
+		Service: "lb-svc.lb-ns",
+		Ports:   []int32{4317},
+	})
+	require.NoError(t, err)
+
+	changes := make(chan []string, 10)
+	res.onChange(func(endpoints []string) {
+		changes <- endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// the endpoints not ready are excluded
+	assert.Equal(t, []string{"192.168.10.100:4317", "192.168.10.102:4317"}, res.Endpoints())
+
+	// a pod becomes ready, and another one is added in a new slice
+	updated := slice.DeepCopy()
+	updated.Endpoints[1].Conditions.Ready = pointer.Bool(true)
+	_, err = cl.DiscoveryV1().EndpointSlices("lb-ns").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = cl.DiscoveryV1().EndpointSlices("lb-ns").Create(context.Background(),
+		newEndpointSlice("lb-svc-fghij", newSliceEndpoint("192.168.10.103", nil)), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"192.168.10.100:4317", "192.168.10.101:4317", "192.168.10.102:4317", "192.168.10.103:4317",
+		}, res.Endpoints())
+	}, time.Second, 10*time.Millisecond)
+
+	// the first slice is deleted
+	require.NoError(t, cl.DiscoveryV1().EndpointSlices("lb-ns").Delete(context.Background(), slice.Name, metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"192.168.10.103:4317"}, res.Endpoints())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sEndpointSliceResolveIncludeNotReady(t *testing.T) {
+	cl := fake.NewSimpleClientset(
+		newEndpointSlice("lb-svc-abcde",
+			newSliceEndpoint("192.168.10.100", pointer.Bool(true)),
+			newSliceEndpoint("192.168.10.101", pointer.Bool(false)),
+		),
+		// the same endpoint may be in two slices while it is moved
+		newEndpointSlice("lb-svc-fghij", newSliceEndpoint("192.168.10.100", pointer.Bool(true))),
+	)
+	res, err := newK8sEndpointSliceResolver(cl, zap.NewNop(), &K8sEndpointSliceResolver{
+		Service:         "lb-svc.lb-ns",
+		IncludeNotReady: true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	assert.Equal(t, []string{"192.168.10.100:4317", "192.168.10.101:4317"}, res.Endpoints())
+}
+
+func TestNewK8sEndpointSliceResolverNoService(t *testing.T) {
+	_, err := newK8sEndpointSliceResolver(fake.NewSimpleClientset(), zap.NewNop(), &K8sEndpointSliceResolver{})
+	assert.Equal(t, errNoSvc, err)
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// routingExpression evaluates the OTTL expression configured as routing_expression to the routing key of the telemetry.
+type routingExpression[K any] struct {
+	expression *ottl.ValueExpression[K]
+}
+
+// newRoutingExpression parses the expression with the NewParser function of an OTTL context, such as ottlspan.NewParser.
+func newRoutingExpression[K any, O any](
+	expression string,
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...O) (ottl.Parser[K], error),
+	settings component.TelemetrySettings,
+) (*routingExpression[K], error) {
+	parser, err := newParser(ottlfuncs.StandardConverters[K](), settings)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := parser.ParseValueExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid routing_expression %q: %w", expression, err)
+	}
+	return &routingExpression[K]{expression: expr}, nil
+}
+
+// routingKey returns the value of the expression for the given context, or false when it evaluates to nil, such as
+// when the attribute it reads is missing.
+func (r *routingExpression[K]) routingKey(ctx context.Context, tCtx K) (string, bool, error) {
+	key, err := r.expression.EvalString(ctx, tCtx)
+	if err != nil || key == nil {
+		return "", false, err
+	}
+	return *key, true, nil
+}
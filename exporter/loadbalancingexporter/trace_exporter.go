@@ -16,17 +16,20 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 var _ exporter.Traces = (*traceExporterImp)(nil)
 
 type traceExporterImp struct {
-	loadBalancer loadBalancer
-	routingKey   routingKey
+	loadBalancer      loadBalancer
+	routingKey        routingKey
+	routingExpression *routingExpression[ottlspan.TransformContext]
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -53,6 +56,13 @@ func newTracesExporter(params exporter.CreateSettings, cfg component.Config) (*t
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
 	}
+
+	if expression := cfg.(*Config).RoutingExpression; expression != "" {
+		traceExporter.routingExpression, err = newRoutingExpression(expression, ottlspan.NewParser, params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &traceExporter, nil
 }
 
@@ -70,13 +80,17 @@ func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *traceExporterImp) Shutdown(context.Context) error {
+func (e *traceExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if e.routingExpression != nil {
+		return e.consumeRoutedTraces(ctx, td)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitTraces(td)
 	for _, batch := range batches {
@@ -87,39 +101,118 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 }
 
 func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) error {
-	var exp component.Component
 	routingIds, err := routingIdentifiersFromTraces(td, e.routingKey)
 	if err != nil {
 		return err
 	}
+	var errs error
 	for rid := range routingIds {
 		endpoint := e.loadBalancer.Endpoint([]byte(rid))
-		exp, err = e.loadBalancer.Exporter(endpoint)
-		if err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, e.exportTraces(ctx, endpoint, td))
+	}
+	return errs
+}
 
-		te, ok := exp.(exporter.Traces)
-		if !ok {
-			return fmt.Errorf("unable to export traces, unexpected exporter type: expected exporter.Traces but got %T", exp)
-		}
+// consumeRoutedTraces evaluates the routing expression against each span, and sends the spans to the endpoints their
+// keys are assigned to, a batch per endpoint.
+func (e *traceExporterImp) consumeRoutedTraces(ctx context.Context, td ptrace.Traces) error {
+	batches, err := e.splitByEndpoint(ctx, td)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.exportTraces(ctx, endpoint, batch))
+	}
+	return errs
+}
 
-		start := time.Now()
-		err = te.ConsumeTraces(ctx, td)
-		duration := time.Since(start)
-
-		if err == nil {
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
-				mBackendLatency.M(duration.Milliseconds()))
-		} else {
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
-				mBackendLatency.M(duration.Milliseconds()))
+// splitByEndpoint groups the spans by the endpoints their routing keys are assigned to, keeping their resources
+// and scopes.
+func (e *traceExporterImp) splitByEndpoint(ctx context.Context, td ptrace.Traces) (map[string]ptrace.Traces, error) {
+	batches := map[string]ptrace.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resources := map[string]ptrace.ResourceSpans{}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scopes := map[string]ptrace.SpanSlice{}
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				key, err := e.spanRoutingKey(ctx, span, ss.Scope(), rs.Resource())
+				if err != nil {
+					return nil, err
+				}
+				endpoint := e.loadBalancer.Endpoint(key)
+				spans, ok := scopes[endpoint]
+				if !ok {
+					resource, ok := resources[endpoint]
+					if !ok {
+						batch, ok := batches[endpoint]
+						if !ok {
+							batch = ptrace.NewTraces()
+							batches[endpoint] = batch
+						}
+						resource = batch.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(resource.Resource())
+						resource.SetSchemaUrl(rs.SchemaUrl())
+						resources[endpoint] = resource
+					}
+					scope := resource.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(ss.SchemaUrl())
+					spans = scope.Spans()
+					scopes[endpoint] = spans
+				}
+				span.CopyTo(spans.AppendEmpty())
+			}
 		}
 	}
+	return batches, nil
+}
+
+// spanRoutingKey returns the value of the routing expression for the span, falling back to its trace ID when the
+// value is nil.
+func (e *traceExporterImp) spanRoutingKey(ctx context.Context, span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) ([]byte, error) {
+	key, ok, err := e.routingExpression.routingKey(ctx, ottlspan.NewTransformContext(span, scope, resource))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return []byte(key), nil
+	}
+	tid := span.TraceID()
+	return tid[:], nil
+}
+
+// exportTraces sends the traces to the exporter of the endpoint, recording the latency of the backend.
+func (e *traceExporterImp) exportTraces(ctx context.Context, endpoint string, td ptrace.Traces) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	te, ok := exp.(exporter.Traces)
+	if !ok {
+		return fmt.Errorf("unable to export traces, unexpected exporter type: expected exporter.Traces but got %T", exp)
+	}
+
+	start := time.Now()
+	err = te.ConsumeTraces(ctx, td)
+	duration := time.Since(start)
+
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
 	return err
 }
 
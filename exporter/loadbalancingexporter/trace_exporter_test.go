@@ -621,3 +621,61 @@ func (e *mockTracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces
 	}
 	return e.ConsumeTracesFn(ctx, td)
 }
+
+func TestTracesRoutingExpression(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	cfg.RoutingExpression = `attributes["tenant"]`
+	p, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockTracesExporter(func(ctx context.Context, td ptrace.Traces) error {
+			mu.Lock()
+			defer mu.Unlock()
+			spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+			for i := 0; i < spans.Len(); i++ {
+				received[endpoint] = append(received[endpoint], spans.At(i).Name())
+			}
+			return nil
+		}), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// every span of the batch is routed by its own key
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	expected := map[string][]string{}
+	for i := 0; i < 10; i++ {
+		span := spans.AppendEmpty()
+		span.SetName(fmt.Sprintf("span-%d", i))
+		span.SetTraceID([16]byte{1, 2, 3, 4})
+		key := []byte{1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+		if i > 0 {
+			// falls back to the trace ID for the first span
+			span.Attributes().PutStr("tenant", fmt.Sprintf("tenant-%d", i))
+			key = []byte(fmt.Sprintf("tenant-%d", i))
+		}
+		endpoint := endpointWithPort(lb.Endpoint(key))
+		expected[endpoint] = append(expected[endpoint], span.Name())
+	}
+	require.Len(t, expected, 2)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, expected, received)
+}
+
+func TestNewTracesExporterInvalidRoutingExpression(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.RoutingExpression = `attributes["tenant"`
+	_, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "invalid routing_expression")
+}
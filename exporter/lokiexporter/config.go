@@ -18,6 +18,33 @@ type Config struct {
 	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
 
 	DefaultLabelsEnabled map[string]bool `mapstructure:"default_labels_enabled"`
+
+	// StructuredMetadata configures the structured metadata of the entries.
+	StructuredMetadata StructuredMetadataConfig `mapstructure:"structured_metadata"`
+
+	// Labels restricts the attributes promoted to labels by the hints.
+	Labels LabelsConfig `mapstructure:"labels"`
+}
+
+// StructuredMetadataConfig configures the Loki structured metadata of the entries.
+type StructuredMetadataConfig struct {
+	// Enabled sends the resource and log attributes not promoted to labels as
+	// structured metadata, instead of writing them in the log lines. It requires
+	// Loki 3.0 or later, with structured metadata allowed.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// LabelsConfig restricts the attributes promoted to labels, to limit the number
+// of streams.
+type LabelsConfig struct {
+	// Allowed lists the attributes which may be promoted to labels. All the hinted
+	// attributes may be promoted when empty.
+	Allowed []string `mapstructure:"allowed"`
+
+	// MaxValues is the number of distinct values each label promoted from an
+	// attribute may have over an hour. The attributes with new values beyond it
+	// are not promoted. 0, the default, disables the limit.
+	MaxValues int `mapstructure:"max_values"`
 }
 
 func (c *Config) Validate() error {
@@ -28,5 +55,9 @@ func (c *Config) Validate() error {
 	if _, err := url.Parse(c.Endpoint); c.Endpoint == "" || err != nil {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
+
+	if c.Labels.MaxValues < 0 {
+		return fmt.Errorf("\"labels.max_values\" must not be negative")
+	}
 	return nil
 }
@@ -71,6 +71,13 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					"instance": true,
 					"level":    false,
 				},
+				StructuredMetadata: StructuredMetadataConfig{
+					Enabled: true,
+				},
+				Labels: LabelsConfig{
+					Allowed:   []string{"service.name", "http.route"},
+					MaxValues: 500,
+				},
 			},
 		},
 	}
@@ -106,6 +113,16 @@ func TestConfigValidate(t *testing.T) {
 			cfg:  &Config{},
 			err:  fmt.Errorf("\"endpoint\" must be a valid URL"),
 		},
+		{
+			desc: "Labels max values is negative",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				Labels: LabelsConfig{MaxValues: -1},
+			},
+			err: fmt.Errorf("\"labels.max_values\" must not be negative"),
+		},
 		{
 			desc: "Config is valid",
 			cfg: &Config{
@@ -35,6 +35,7 @@ type lokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+	labels   *labelLimiter
 }
 
 func newExporter(config *Config, settings component.TelemetrySettings) *lokiExporter {
@@ -43,11 +44,19 @@ func newExporter(config *Config, settings component.TelemetrySettings) *lokiExpo
 	return &lokiExporter{
 		config:   config,
 		settings: settings,
+		labels:   newLabelLimiter(config.Labels, settings.Logger),
 	}
 }
 
 func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld, l.config.DefaultLabelsEnabled)
+	opts := loki.Options{
+		DefaultLabelsEnabled: l.config.DefaultLabelsEnabled,
+		StructuredMetadata:   l.config.StructuredMetadata.Enabled,
+	}
+	if l.labels.enabled() {
+		opts.LabelFilter = l.labels.allow
+	}
+	requests := loki.LogsToLokiRequestsWithOptions(ld, opts)
 
 	var errs error
 	for tenant, request := range requests {
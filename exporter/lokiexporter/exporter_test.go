@@ -30,8 +30,11 @@ func TestPushLogData(t *testing.T) {
 		hints         map[string]interface{}
 		attrs         map[string]interface{}
 		res           map[string]interface{}
+		structured    bool
+		labels        LabelsConfig
 		expectedLabel string
 		expectedLine  string
+		expectedMeta  push.LabelsAdapter
 	}{
 		{
 			desc: "with attribute to label and regular attribute",
@@ -57,6 +60,39 @@ func TestPushLogData(t *testing.T) {
 			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
 			expectedLine:  `{"traceid":"01020304000000000000000000000000","resources":{"region.az":"eu-west-1a"}}`,
 		},
+		{
+			desc: "with structured metadata",
+			attrs: map[string]interface{}{
+				"host.name":   "guarana",
+				"http.status": 200,
+			},
+			res: map[string]interface{}{
+				"region.az": "eu-west-1a",
+			},
+			hints: map[string]interface{}{
+				"loki.attribute.labels": "host.name",
+			},
+			structured:    true,
+			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
+			expectedLine:  `{"traceid":"01020304000000000000000000000000"}`,
+			expectedMeta: push.LabelsAdapter{
+				{Name: "http_status", Value: "200"},
+				{Name: "region_az", Value: "eu-west-1a"},
+			},
+		},
+		{
+			desc: "with attribute not allowed as label",
+			attrs: map[string]interface{}{
+				"host.name":   "guarana",
+				"http.status": 200,
+			},
+			hints: map[string]interface{}{
+				"loki.attribute.labels": "host.name, http.status",
+			},
+			labels:        LabelsConfig{Allowed: []string{"http.status"}},
+			expectedLabel: `{exporter="OTLP", http_status="200"}`,
+			expectedLine:  `{"traceid":"01020304000000000000000000000000","attributes":{"host.name":"guarana"}}`,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -79,6 +115,8 @@ func TestPushLogData(t *testing.T) {
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: ts.URL,
 				},
+				StructuredMetadata: StructuredMetadataConfig{Enabled: tC.structured},
+				Labels:             tC.labels,
 			}
 
 			f := NewFactory()
@@ -118,6 +156,7 @@ func TestPushLogData(t *testing.T) {
 
 			assert.Len(t, actualPushRequest.Streams[0].Entries, 1)
 			assert.Equal(t, tC.expectedLine, actualPushRequest.Streams[0].Entries[0].Line)
+			assert.Equal(t, tC.expectedMeta, actualPushRequest.Streams[0].Entries[0].StructuredMetadata)
 
 			// cleanup
 			err = exp.Shutdown(context.Background())
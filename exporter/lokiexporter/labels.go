@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// labelValuesInterval is the interval after which the values of the labels are
+// counted again, so that the labels whose values change over time, such as pod
+// names, are not demoted forever.
+const labelValuesInterval = time.Hour
+
+// labelLimiter decides which hinted attributes are promoted to labels: only
+// the allowed ones, as long as they do not have too many distinct values.
+type labelLimiter struct {
+	allowed   map[string]struct{}
+	maxValues int
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu      sync.Mutex
+	values  map[string]map[string]struct{}
+	resetAt time.Time
+}
+
+func newLabelLimiter(cfg LabelsConfig, logger *zap.Logger) *labelLimiter {
+	l := &labelLimiter{
+		maxValues: cfg.MaxValues,
+		logger:    logger,
+		now:       time.Now,
+		values:    map[string]map[string]struct{}{},
+	}
+	if len(cfg.Allowed) > 0 {
+		l.allowed = make(map[string]struct{}, len(cfg.Allowed))
+		for _, name := range cfg.Allowed {
+			l.allowed[name] = struct{}{}
+		}
+	}
+	return l
+}
+
+// enabled returns whether the limiter rejects any label.
+func (l *labelLimiter) enabled() bool {
+	return l.allowed != nil || l.maxValues > 0
+}
+
+// allow returns whether the attribute may be promoted to a label.
+func (l *labelLimiter) allow(name, value string) bool {
+	if l.allowed != nil {
+		if _, ok := l.allowed[name]; !ok {
+			return false
+		}
+	}
+	if l.maxValues <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now := l.now(); now.After(l.resetAt) {
+		l.values = map[string]map[string]struct{}{}
+		l.resetAt = now.Add(labelValuesInterval)
+	}
+	values, ok := l.values[name]
+	if !ok {
+		values = map[string]struct{}{}
+		l.values[name] = values
+	}
+	if _, ok = values[value]; ok {
+		return true
+	}
+	if len(values) >= l.maxValues {
+		return false
+	}
+	values[value] = struct{}{}
+	if len(values) == l.maxValues {
+		l.logger.Warn(
+			"label reached the maximum number of values, its new values are kept as attributes",
+			zap.String("label", name),
+			zap.Int("max_values", l.maxValues),
+		)
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newLabelLimiter(LabelsConfig{
+		Allowed:   []string{"http.route", "pod.name"},
+		MaxValues: 2,
+	}, zap.NewNop())
+	l.now = func() time.Time { return now }
+	assert.True(t, l.enabled())
+
+	assert.False(t, l.allow("user.id", "42"), "not allowed")
+	assert.True(t, l.allow("http.route", "/cart"))
+	assert.True(t, l.allow("http.route", "/checkout"))
+	assert.False(t, l.allow("http.route", "/product/1"), "over the limit")
+	assert.True(t, l.allow("http.route", "/cart"), "known value")
+	assert.True(t, l.allow("pod.name", "checkout-1"), "limit per label")
+
+	now = now.Add(labelValuesInterval + time.Second)
+	assert.True(t, l.allow("http.route", "/product/1"), "counted again")
+}
+
+func TestLabelLimiterDisabled(t *testing.T) {
+	// The limiter is disabled by default.
+	l := newLabelLimiter(createDefaultConfig().(*Config).Labels, zap.NewNop())
+	assert.False(t, l.enabled())
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.allow("user.id", string(rune('a'+i))))
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+)
+
+// Config defines the configuration of the NATS exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// URL of the NATS server, or comma separated URLs of the servers of a cluster
+	// (default nats://localhost:4222).
+	URL string `mapstructure:"url"`
+	// TLSSetting configures the TLS connection to the servers.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls"`
+	// Auth defines the credentials of the connection to the servers.
+	Auth natsconn.AuthConfig `mapstructure:"auth"`
+
+	// Subject the messages are published to (default otlp.traces for traces,
+	// otlp.metrics for metrics and otlp.logs for logs). The {key} placeholders are
+	// replaced by the value of the resource attribute named key.
+	Subject string `mapstructure:"subject"`
+	// Encoding of the messages, otlp_proto or otlp_json (default otlp_proto).
+	Encoding string `mapstructure:"encoding"`
+	// JetStream publishes the messages to a JetStream stream.
+	JetStream JetStreamConfig `mapstructure:"jetstream"`
+}
+
+// JetStreamConfig defines the publication of the messages to JetStream.
+type JetStreamConfig struct {
+	// Enabled publishes the messages through JetStream, waiting for their
+	// acknowledgement by the stream (default false).
+	Enabled bool `mapstructure:"enabled"`
+	// Stream is the name of the stream expected to store the messages. The
+	// publication fails when another stream acknowledges them.
+	Stream string `mapstructure:"stream"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.URL == "" {
+		return errors.New("url must be specified")
+	}
+	if cfg.Subject != "" {
+		if _, err := parseSubjectTemplate(cfg.Subject); err != nil {
+			return err
+		}
+	}
+	switch cfg.Encoding {
+	case encodingOTLPProto, encodingOTLPJSON:
+	default:
+		return fmt.Errorf("unsupported encoding %q", cfg.Encoding)
+	}
+	if cfg.JetStream.Stream != "" && !cfg.JetStream.Enabled {
+		return errors.New("jetstream.stream requires jetstream to be enabled")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:             true,
+					InitialInterval:     10 * time.Second,
+					RandomizationFactor: 0.7,
+					Multiplier:          1.3,
+					MaxInterval:         60 * time.Second,
+					MaxElapsedTime:      10 * time.Minute,
+				},
+				URL: "nats://nats-1:4222,nats://nats-2:4222",
+				TLSSetting: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "ca.pem",
+					},
+				},
+				Auth: natsconn.AuthConfig{
+					Username: "otelcol",
+					Password: "secret",
+				},
+				Subject:  "otlp.{service.namespace}.{service.name}",
+				Encoding: encodingOTLPJSON,
+				JetStream: JetStreamConfig{
+					Enabled: true,
+					Stream:  "OTLP",
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_encoding"),
+			errorMessage: `unsupported encoding "jaeger_proto"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_subject"),
+			errorMessage: `subject "otlp.{service.name" has an unclosed placeholder`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "wildcard_subject"),
+			errorMessage: `subject "otlp.>" must not contain wildcards or whitespaces`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "several_auth"),
+			errorMessage: "only one of auth token, username, credentials_file and nkey_seed_file may be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "stream_without_jetstream"),
+			errorMessage: "jetstream.stream requires jetstream to be enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Auth.Password = "secret"
+	assert.EqualError(t, component.ValidateConfig(cfg), "auth password requires a username")
+}
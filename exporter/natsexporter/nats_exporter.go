@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+)
+
+// natsExporter exports one signal to the subjects of its configuration.
+type natsExporter struct {
+	config  *Config
+	name    string
+	subject *subjectTemplate
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+
+	newPublisher func(cfg *Config, name string) (publisher, error)
+	publisher    publisher
+}
+
+func newNATSExporter(cfg *Config, set exporter.CreateSettings, defaultSubject string) (*natsExporter, error) {
+	subject := cfg.Subject
+	if subject == "" {
+		subject = defaultSubject
+	}
+	template, err := parseSubjectTemplate(subject)
+	if err != nil {
+		return nil, err
+	}
+	e := &natsExporter{
+		config:       cfg,
+		name:         "otelcol/" + set.ID.String(),
+		subject:      template,
+		newPublisher: newNATSPublisher,
+	}
+	switch cfg.Encoding {
+	case encodingOTLPProto:
+		e.tracesMarshaler = &ptrace.ProtoMarshaler{}
+		e.metricsMarshaler = &pmetric.ProtoMarshaler{}
+		e.logsMarshaler = &plog.ProtoMarshaler{}
+	case encodingOTLPJSON:
+		e.tracesMarshaler = &ptrace.JSONMarshaler{}
+		e.metricsMarshaler = &pmetric.JSONMarshaler{}
+		e.logsMarshaler = &plog.JSONMarshaler{}
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", cfg.Encoding)
+	}
+	return e, nil
+}
+
+func (e *natsExporter) start(_ context.Context, _ component.Host) error {
+	p, err := e.newPublisher(e.config, e.name)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", e.config.URL, err)
+	}
+	e.publisher = p
+	return nil
+}
+
+func (e *natsExporter) shutdown(context.Context) error {
+	if e.publisher != nil {
+		e.publisher.Close()
+	}
+	return nil
+}
+
+func (e *natsExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	groups := []resourcegroup.Group[ptrace.Traces]{{Key: e.subject.render(pcommon.NewMap()), Data: td}}
+	if !e.subject.isStatic() {
+		groups = resourcegroup.Traces(td, e.resourceSubject)
+	}
+	retry, err := publishGroups(ctx, e.publisher, groups, e.tracesMarshaler.MarshalTraces)
+	if len(retry) == 0 {
+		return err
+	}
+	if len(retry) < len(groups) {
+		// The groups of a dynamic subject are copies of td
+		td = ptrace.NewTraces()
+		for _, group := range retry {
+			group.Data.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+		}
+	}
+	return consumererror.NewTraces(err, td)
+}
+
+func (e *natsExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	groups := []resourcegroup.Group[pmetric.Metrics]{{Key: e.subject.render(pcommon.NewMap()), Data: md}}
+	if !e.subject.isStatic() {
+		groups = resourcegroup.Metrics(md, e.resourceSubject)
+	}
+	retry, err := publishGroups(ctx, e.publisher, groups, e.metricsMarshaler.MarshalMetrics)
+	if len(retry) == 0 {
+		return err
+	}
+	if len(retry) < len(groups) {
+		// The groups of a dynamic subject are copies of md
+		md = pmetric.NewMetrics()
+		for _, group := range retry {
+			group.Data.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		}
+	}
+	return consumererror.NewMetrics(err, md)
+}
+
+func (e *natsExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	groups := []resourcegroup.Group[plog.Logs]{{Key: e.subject.render(pcommon.NewMap()), Data: ld}}
+	if !e.subject.isStatic() {
+		groups = resourcegroup.Logs(ld, e.resourceSubject)
+	}
+	retry, err := publishGroups(ctx, e.publisher, groups, e.logsMarshaler.MarshalLogs)
+	if len(retry) == 0 {
+		return err
+	}
+	if len(retry) < len(groups) {
+		// The groups of a dynamic subject are copies of ld
+		ld = plog.NewLogs()
+		for _, group := range retry {
+			group.Data.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+		}
+	}
+	return consumererror.NewLogs(err, ld)
+}
+
+// resourceSubject returns the subject the telemetry of a resource is published to.
+func (e *natsExporter) resourceSubject(resource pcommon.Resource) string {
+	return e.subject.render(resource.Attributes())
+}
+
+// publishGroups publishes the groups of resources to their subject, waits for
+// their delivery, and returns the groups to retry. Groups failing permanently
+// are not retried, and their error is only reported as permanent when no group
+// is retried.
+func publishGroups[T any](ctx context.Context, p publisher, groups []resourcegroup.Group[T], marshal func(T) ([]byte, error)) ([]resourcegroup.Group[T], error) {
+	var retry, published []resourcegroup.Group[T]
+	var errs, permanentErrs error
+	for _, group := range groups {
+		data, err := marshal(group.Data)
+		if err != nil {
+			permanentErrs = multierr.Append(permanentErrs, fmt.Errorf("failed to marshal the data of subject %q: %w", group.Key, err))
+			continue
+		}
+		if err = p.Publish(ctx, group.Key, data); err != nil {
+			err = fmt.Errorf("failed to publish to subject %q: %w", group.Key, err)
+			if errors.Is(err, nats.ErrMaxPayload) {
+				// The message will never fit in the payload allowed by the server
+				permanentErrs = multierr.Append(permanentErrs, err)
+			} else {
+				errs = multierr.Append(errs, err)
+				retry = append(retry, group)
+			}
+			continue
+		}
+		published = append(published, group)
+	}
+	if len(published) > 0 {
+		if err := p.Flush(ctx); err != nil {
+			// The published messages may not have reached the server
+			errs = multierr.Append(errs, fmt.Errorf("failed to flush: %w", err))
+			retry = append(retry, published...)
+		}
+	}
+
+	switch {
+	case errs == nil && permanentErrs == nil:
+		return nil, nil
+	case errs == nil:
+		return nil, consumererror.NewPermanent(permanentErrs)
+	case permanentErrs != nil:
+		// Not wrapped, so that the groups to retry are not dropped as permanent failures
+		errs = multierr.Append(errs, errors.New(permanentErrs.Error()))
+	}
+	return retry, errs
+}
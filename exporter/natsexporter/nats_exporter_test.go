@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type fakePublisher struct {
+	messages   map[string][][]byte
+	publishErr error
+	// subjectErrs are the errors of publishing to the given subjects.
+	subjectErrs map[string]error
+	flushErr    error
+	flushed     int
+	closed      bool
+}
+
+func (p *fakePublisher) Publish(_ context.Context, subject string, data []byte) error {
+	if p.publishErr != nil {
+		return p.publishErr
+	}
+	if err := p.subjectErrs[subject]; err != nil {
+		return err
+	}
+	if p.messages == nil {
+		p.messages = map[string][][]byte{}
+	}
+	p.messages[subject] = append(p.messages[subject], data)
+	return nil
+}
+
+func (p *fakePublisher) Flush(context.Context) error {
+	p.flushed++
+	return p.flushErr
+}
+
+func (p *fakePublisher) Close() {
+	p.closed = true
+}
+
+func newTestExporter(t *testing.T, cfg *Config, defaultSubject string) (*natsExporter, *fakePublisher) {
+	exp, err := newNATSExporter(cfg, exportertest.NewNopCreateSettings(), defaultSubject)
+	require.NoError(t, err)
+	pub := &fakePublisher{}
+	exp.newPublisher = func(*Config, string) (publisher, error) {
+		return pub, nil
+	}
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.shutdown(context.Background()))
+		assert.True(t, pub.closed)
+	})
+	return exp, pub
+}
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"checkout", "cart", "checkout"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(service)
+	}
+	return td
+}
+
+func TestPushTracesStaticSubject(t *testing.T) {
+	exp, pub := newTestExporter(t, createDefaultConfig().(*Config), defaultTracesSubject)
+
+	td := testTraces()
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	require.Len(t, pub.messages, 1)
+	require.Len(t, pub.messages[defaultTracesSubject], 1)
+	assert.Equal(t, 1, pub.flushed)
+
+	got, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(pub.messages[defaultTracesSubject][0])
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
+func TestPushTracesSubjectTemplate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subject = "otlp.traces.{service.name}"
+	cfg.Encoding = encodingOTLPJSON
+	exp, pub := newTestExporter(t, cfg, defaultTracesSubject)
+
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces()))
+	require.Len(t, pub.messages, 2)
+
+	checkout, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(pub.messages["otlp.traces.checkout"][0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, checkout.ResourceSpans().Len())
+	cart, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(pub.messages["otlp.traces.cart"][0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, cart.ResourceSpans().Len())
+}
+
+func TestPushMetricsSubjectTemplate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subject = "otlp.metrics.{host.name}"
+	exp, pub := newTestExporter(t, cfg, defaultMetricsSubject)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("host.name", "node-1")
+	md.ResourceMetrics().AppendEmpty()
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	assert.Len(t, pub.messages["otlp.metrics.node-1"], 1)
+	assert.Len(t, pub.messages["otlp.metrics.undefined"], 1)
+}
+
+func TestPushLogsDefaultSubject(t *testing.T) {
+	exp, pub := newTestExporter(t, createDefaultConfig().(*Config), defaultLogsSubject)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+
+	got, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(pub.messages[defaultLogsSubject][0])
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestPublishErrors(t *testing.T) {
+	exp, pub := newTestExporter(t, createDefaultConfig().(*Config), defaultLogsSubject)
+
+	pub.publishErr = nats.ErrConnectionClosed
+	err := exp.pushLogs(context.Background(), plog.NewLogs())
+	require.ErrorIs(t, err, nats.ErrConnectionClosed)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Zero(t, pub.flushed)
+
+	pub.publishErr = nats.ErrMaxPayload
+	err = exp.pushLogs(context.Background(), plog.NewLogs())
+	assert.True(t, consumererror.IsPermanent(err))
+
+	pub.publishErr = nil
+	pub.flushErr = nats.ErrTimeout
+	err = exp.pushLogs(context.Background(), plog.NewLogs())
+	assert.ErrorIs(t, err, nats.ErrTimeout)
+}
+
+func TestPublishPartialFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subject = "otlp.traces.{service.name}"
+	exp, pub := newTestExporter(t, cfg, defaultTracesSubject)
+
+	pub.subjectErrs = map[string]error{"otlp.traces.cart": nats.ErrConnectionClosed}
+	err := exp.pushTraces(context.Background(), testTraces())
+	require.ErrorIs(t, err, nats.ErrConnectionClosed)
+	assert.False(t, consumererror.IsPermanent(err))
+	var tracesErr consumererror.Traces
+	require.ErrorAs(t, err, &tracesErr)
+	retry := tracesErr.Data()
+	require.Equal(t, 1, retry.ResourceSpans().Len())
+	assert.Equal(t, "cart", retry.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Len(t, pub.messages["otlp.traces.checkout"], 1)
+
+	// Only the batches failing temporarily are retried
+	pub.subjectErrs["otlp.traces.checkout"] = nats.ErrMaxPayload
+	err = exp.pushTraces(context.Background(), testTraces())
+	assert.False(t, consumererror.IsPermanent(err))
+	require.ErrorAs(t, err, &tracesErr)
+	assert.Equal(t, 1, tracesErr.Data().ResourceSpans().Len())
+
+	delete(pub.subjectErrs, "otlp.traces.cart")
+	err = exp.pushTraces(context.Background(), testTraces())
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// Published messages may not have reached the server when flushing fails
+	pub.subjectErrs = nil
+	pub.flushErr = nats.ErrTimeout
+	err = exp.pushTraces(context.Background(), testTraces())
+	require.ErrorAs(t, err, &tracesErr)
+	assert.Equal(t, 3, tracesErr.Data().ResourceSpans().Len())
+}
+
+func TestStartFailure(t *testing.T) {
+	exp, err := newNATSExporter(createDefaultConfig().(*Config), exportertest.NewNopCreateSettings(), defaultTracesSubject)
+	require.NoError(t, err)
+	exp.newPublisher = func(*Config, string) (publisher, error) {
+		return nil, errors.New("no servers available for connection")
+	}
+	assert.EqualError(t, exp.start(context.Background(), componenttest.NewNopHost()),
+		"failed to connect to nats://localhost:4222: no servers available for connection")
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+)
+
+// publisher publishes the messages of the exporter.
+type publisher interface {
+	// Publish publishes data to subject. Without JetStream, the message may not
+	// have reached the server until Flush returns.
+	Publish(ctx context.Context, subject string, data []byte) error
+	Flush(ctx context.Context) error
+	Close()
+}
+
+type natsPublisher struct {
+	conn *nats.Conn
+	// js is nil without JetStream.
+	js     nats.JetStreamContext
+	stream string
+}
+
+func newNATSPublisher(cfg *Config, name string) (publisher, error) {
+	opts, err := natsconn.ConnectOptions(cfg.TLSSetting, cfg.Auth, name)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p := &natsPublisher{conn: conn, stream: cfg.JetStream.Stream}
+	if cfg.JetStream.Enabled {
+		if p.js, err = conn.JetStream(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+	if p.js == nil {
+		return p.conn.Publish(subject, data)
+	}
+	opts := []nats.PubOpt{nats.Context(ctx)}
+	if p.stream != "" {
+		opts = append(opts, nats.ExpectStream(p.stream))
+	}
+	_, err := p.js.Publish(subject, data, opts...)
+	return err
+}
+
+func (p *natsPublisher) Flush(ctx context.Context) error {
+	if p.js != nil {
+		// The messages were acknowledged by the stream
+		return nil
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return p.conn.Flush()
+	}
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p *natsPublisher) Close() {
+	p.conn.Close()
+}
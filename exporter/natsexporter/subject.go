@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// undefinedToken replaces the placeholders of the attributes a resource does not have.
+const undefinedToken = "undefined"
+
+// tokenReplacer replaces the characters of attribute values that would change
+// the tokens of a subject, so that subscribers may rely on wildcards.
+var tokenReplacer = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_", "\r", "_", "\n", "_")
+
+// subjectTemplate is a subject in which the {key} placeholders are replaced by
+// the values of the resource attributes.
+type subjectTemplate struct {
+	// literals surround the placeholders, and hold one element more than keys.
+	literals []string
+	keys     []string
+}
+
+func parseSubjectTemplate(subject string) (*subjectTemplate, error) {
+	t := &subjectTemplate{}
+	rest := subject
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("subject %q has an unclosed placeholder", subject)
+		}
+		key := rest[start+1 : start+end]
+		if key == "" {
+			return nil, fmt.Errorf("subject %q has an empty placeholder", subject)
+		}
+		t.literals = append(t.literals, rest[:start])
+		t.keys = append(t.keys, key)
+		rest = rest[start+end+1:]
+	}
+	t.literals = append(t.literals, rest)
+
+	for _, literal := range t.literals {
+		if strings.ContainsRune(literal, '}') {
+			return nil, fmt.Errorf("subject %q has an unopened placeholder", subject)
+		}
+		if strings.ContainsAny(literal, "*> \t\r\n") {
+			return nil, fmt.Errorf("subject %q must not contain wildcards or whitespaces", subject)
+		}
+	}
+	return t, nil
+}
+
+// isStatic returns whether the subject does not depend on the resource.
+func (t *subjectTemplate) isStatic() bool {
+	return len(t.keys) == 0
+}
+
+func (t *subjectTemplate) render(attrs pcommon.Map) string {
+	var sb strings.Builder
+	for i, key := range t.keys {
+		sb.WriteString(t.literals[i])
+		token := undefinedToken
+		if value, ok := attrs.Get(key); ok && value.AsString() != "" {
+			token = tokenReplacer.Replace(value.AsString())
+		}
+		sb.WriteString(token)
+	}
+	sb.WriteString(t.literals[len(t.keys)])
+	return sb.String()
+}
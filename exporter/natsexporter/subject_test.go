@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseSubjectTemplate(t *testing.T) {
+	tests := []struct {
+		subject      string
+		static       bool
+		errorMessage string
+	}{
+		{subject: "otlp.traces", static: true},
+		{subject: "otlp.{service.name}"},
+		{subject: "{cloud.region}.otlp.{service.name}.traces"},
+		{subject: "otlp.{service.name", errorMessage: `subject "otlp.{service.name" has an unclosed placeholder`},
+		{subject: "otlp.service.name}", errorMessage: `subject "otlp.service.name}" has an unopened placeholder`},
+		{subject: "otlp.{}", errorMessage: `subject "otlp.{}" has an empty placeholder`},
+		{subject: "otlp.*", errorMessage: `subject "otlp.*" must not contain wildcards or whitespaces`},
+		{subject: "otlp traces", errorMessage: `subject "otlp traces" must not contain wildcards or whitespaces`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			template, err := parseSubjectTemplate(tt.subject)
+			if tt.errorMessage != "" {
+				assert.EqualError(t, err, tt.errorMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.static, template.isStatic())
+		})
+	}
+}
+
+func TestRenderSubjectTemplate(t *testing.T) {
+	template, err := parseSubjectTemplate("{cloud.region}.otlp.{service.name}.{service.instance.id}")
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("cloud.region", "eu-west-1")
+	attrs.PutStr("service.name", "checkout api.v2>*")
+	attrs.PutStr("service.instance.id", "")
+	assert.Equal(t, "eu-west-1.otlp.checkout_api_v2__.undefined", template.render(attrs))
+
+	attrs = pcommon.NewMap()
+	attrs.PutInt("cloud.region", 1)
+	assert.Equal(t, "1.otlp.undefined.undefined", template.render(attrs))
+}
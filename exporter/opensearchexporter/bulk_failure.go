@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opensearchexporter"
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchutil"
+)
+
+// itemOutcome is what becomes of a document OpenSearch failed to index.
+type itemOutcome int
+
+const (
+	// itemIndexed means that the document was indexed by a previous attempt.
+	itemIndexed itemOutcome = iota
+	// itemRetry means that the document is retried, alone with the other retryable documents of the bulk.
+	itemRetry
+	// itemDeadLetter means that the document is written to the dead letter queue, if any, and dropped otherwise.
+	itemDeadLetter
+	// itemDrop means that the document is dropped.
+	itemDrop
+)
+
+// mappingConflictTypes are the types of the errors of the documents which don't match the mapping of the index,
+// and which would fail again if retried.
+var mappingConflictTypes = map[string]bool{
+	"mapper_parsing_exception":         true,
+	"strict_dynamic_mapping_exception": true,
+	"illegal_argument_exception":       true,
+	"document_parsing_exception":       true,
+}
+
+func classifyItemFailure(item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error) itemOutcome {
+	switch {
+	case isAlreadyIndexed(item, resp):
+		return itemIndexed
+	case shouldRetryEvent(resp.Status):
+		return itemRetry
+	case itemErr == nil && resp.Status == http.StatusBadRequest && mappingConflictTypes[resp.Error.Type]:
+		return itemDeadLetter
+	default:
+		return itemDrop
+	}
+}
+
+// deadLetter is a line of the dead letter queue.
+type deadLetter struct {
+	Timestamp   time.Time       `json:"timestamp"`
+	Index       string          `json:"index"`
+	Status      int             `json:"status"`
+	ErrorType   string          `json:"error_type"`
+	ErrorReason string          `json:"error_reason"`
+	Document    json.RawMessage `json:"document"`
+}
+
+// deadLetterQueue appends the documents rejected because of mapping conflicts to a file, one JSON object per line.
+type deadLetterQueue struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newDeadLetterQueue(path string) (*deadLetterQueue, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterQueue{file: file}, nil
+}
+
+func (q *deadLetterQueue) write(item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, document []byte) error {
+	line, err := json.Marshal(deadLetter{
+		Timestamp:   time.Now().UTC(),
+		Index:       item.Index,
+		Status:      resp.Status,
+		ErrorType:   resp.Error.Type,
+		ErrorReason: resp.Error.Reason,
+		Document:    document,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, err = q.file.Write(line)
+	return err
+}
+
+func (q *deadLetterQueue) close() error {
+	if q == nil {
+		return nil
+	}
+	return q.file.Close()
+}
+
+// writeDeadLetter writes the document to the dead letter queue, returning the error of the document when there's
+// no dead letter queue to write it to.
+func writeDeadLetter(q *deadLetterQueue, item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, document []byte) error {
+	if q == nil {
+		return responseAsError(resp)
+	}
+	return q.write(item, resp, document)
+}
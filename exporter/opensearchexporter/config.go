@@ -46,6 +46,72 @@ type Config struct {
 	// BulkAction configures the action for ingesting data. Only `create` and `index` are allowed here.
 	// If not specified, the default value `create` will be used.
 	BulkAction string `mapstructure:"bulk_action"`
+
+	// LogsPipeline configures the ingest pipeline logs are processed with.
+	// https://opensearch.org/docs/latest/ingest-pipelines/index/
+	LogsPipeline string `mapstructure:"logs_pipeline"`
+
+	// TracesPipeline configures the ingest pipeline traces are processed with.
+	// https://opensearch.org/docs/latest/ingest-pipelines/index/
+	TracesPipeline string `mapstructure:"traces_pipeline"`
+
+	// DocumentID configures how the _id of the indexed documents is generated.
+	DocumentID DocumentIDSettings `mapstructure:"document_id"`
+
+	// DeadLetterQueue configures the file the documents rejected because of mapping conflicts are written to.
+	DeadLetterQueue DeadLetterQueueSettings `mapstructure:"dead_letter_queue"`
+
+	// IndexTemplate configures the index template put when the exporter starts.
+	// https://opensearch.org/docs/latest/im-plugin/index-templates/
+	IndexTemplate IndexTemplateSettings `mapstructure:"index_template"`
+
+	// ISMPolicy configures the Index State Management policy put when the exporter starts, and attached to
+	// the index the exporter writes to.
+	// https://opensearch.org/docs/latest/im-plugin/ism/index/
+	ISMPolicy ISMPolicySettings `mapstructure:"ism_policy"`
+}
+
+// DeadLetterQueueSettings defines where the documents OpenSearch can't index
+// because of mapping conflicts are kept. Without a dead letter queue, these
+// documents are dropped.
+type DeadLetterQueueSettings struct {
+	// Path is the file the documents are appended to, one JSON object per line.
+	Path string `mapstructure:"path"`
+}
+
+// IndexTemplateSettings defines the index template put by the exporter.
+type IndexTemplateSettings struct {
+	// Name is the name of the index template.
+	Name string `mapstructure:"name"`
+
+	// File is the JSON file holding the body of the index template.
+	File string `mapstructure:"file"`
+}
+
+// ISMPolicySettings defines the ISM policy put by the exporter.
+type ISMPolicySettings struct {
+	// Name is the ID of the policy. An existing policy with this ID is kept.
+	Name string `mapstructure:"name"`
+
+	// File is the JSON file holding the body of the policy.
+	File string `mapstructure:"file"`
+}
+
+// DocumentIDSettings defines how the exporter generates the document _id.
+// Documents with a deterministic _id are not duplicated when a bulk request
+// is retried.
+type DocumentIDSettings struct {
+	// Strategy configures the _id generation. Supported strategies are:
+	//
+	//   none: OpenSearch generates the _id. This is the default.
+	//   hash: the _id is the SHA-256 hash of the record with its resource and scope.
+	//   attribute: the _id is the value of Attribute, looked up in the
+	//   resource attributes first and in the record attributes otherwise.
+	//   Documents without the attribute get an _id generated by OpenSearch.
+	Strategy string `mapstructure:"strategy"`
+
+	// Attribute holds the name of the attribute used by the attribute strategy.
+	Attribute string `mapstructure:"attribute"`
 }
 
 var (
@@ -54,6 +120,10 @@ var (
 	errNamespaceNoValue   = errors.New("namespace must be specified")
 	errBulkActionInvalid  = errors.New("bulk_action can either be `create` or `index`")
 	errMappingModeInvalid = errors.New("mapping.mode is invalid")
+	errDocumentIDInvalid  = errors.New("document_id.strategy can either be `none`, `hash` or `attribute`")
+	errDocumentIDNoAttr   = errors.New("document_id.attribute must be specified with the `attribute` strategy")
+	errIndexTemplateSpec  = errors.New("index_template.name and index_template.file must be specified together")
+	errISMPolicySpec      = errors.New("ism_policy.name and ism_policy.file must be specified together")
 )
 
 type MappingsSettings struct {
@@ -147,5 +217,22 @@ func (cfg *Config) Validate() error {
 		multiErr = append(multiErr, errMappingModeInvalid)
 	}
 
+	switch cfg.DocumentID.Strategy {
+	case "", documentIDNone, documentIDHash:
+	case documentIDAttribute:
+		if len(cfg.DocumentID.Attribute) == 0 {
+			multiErr = append(multiErr, errDocumentIDNoAttr)
+		}
+	default:
+		multiErr = append(multiErr, errDocumentIDInvalid)
+	}
+
+	if (len(cfg.IndexTemplate.Name) == 0) != (len(cfg.IndexTemplate.File) == 0) {
+		multiErr = append(multiErr, errIndexTemplateSpec)
+	}
+	if (len(cfg.ISMPolicy.Name) == 0) != (len(cfg.ISMPolicy.File) == 0) {
+		multiErr = append(multiErr, errISMPolicySpec)
+	}
+
 	return errors.Join(multiErr...)
 }
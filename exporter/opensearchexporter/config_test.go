@@ -106,6 +106,46 @@ func TestLoadConfig(t *testing.T) {
 				return assert.ErrorContains(t, err, errBulkActionInvalid.Error())
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_document_id"),
+			expected: withDefaultConfig(func(config *Config) {
+				config.Endpoint = sampleEndpoint
+				config.DocumentID.Strategy = "uuid"
+			}),
+			configValidateAssert: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, errDocumentIDInvalid.Error())
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "document_id_without_attribute"),
+			expected: withDefaultConfig(func(config *Config) {
+				config.Endpoint = sampleEndpoint
+				config.DocumentID.Strategy = "attribute"
+			}),
+			configValidateAssert: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, errDocumentIDNoAttr.Error())
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "index_management"),
+			expected: withDefaultConfig(func(config *Config) {
+				config.Endpoint = sampleEndpoint
+				config.DeadLetterQueue.Path = "/var/lib/otelcol/opensearch-dlq.jsonl"
+				config.IndexTemplate = IndexTemplateSettings{Name: "otel", File: "/etc/otelcol/index-template.json"}
+				config.ISMPolicy = ISMPolicySettings{Name: "otel-retention", File: "/etc/otelcol/ism-policy.json"}
+			}),
+			configValidateAssert: assert.NoError,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ism_policy_without_file"),
+			expected: withDefaultConfig(func(config *Config) {
+				config.Endpoint = sampleEndpoint
+				config.ISMPolicy.Name = "otel-retention"
+			}),
+			configValidateAssert: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, errISMPolicySpec.Error())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opensearchexporter"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// document _id generation strategies
+const (
+	documentIDNone      = "none"
+	documentIDHash      = "hash"
+	documentIDAttribute = "attribute"
+)
+
+// documentID returns the _id of the document of a record with the given
+// attributes. An empty _id lets OpenSearch generate it.
+//
+// The hash strategy hashes the record with its resource and scope, as returned
+// by marshalRecord, rather than the encoded document: the document holds the
+// time the record was observed by the exporter, which differs on every retry.
+func documentID(settings DocumentIDSettings, resourceAttrs pcommon.Map, recordAttrs pcommon.Map, marshalRecord func() ([]byte, error)) (string, error) {
+	switch settings.Strategy {
+	case documentIDHash:
+		record, err := marshalRecord()
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(record)
+		return hex.EncodeToString(sum[:]), nil
+	case documentIDAttribute:
+		if v, ok := resourceAttrs.Get(settings.Attribute); ok {
+			return v.AsString(), nil
+		}
+		if v, ok := recordAttrs.Get(settings.Attribute); ok {
+			return v.AsString(), nil
+		}
+		return "", nil
+	default:
+		return "", nil
+	}
+}
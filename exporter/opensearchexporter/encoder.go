@@ -6,13 +6,13 @@ package opensearchexporter // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"bytes"
 	"encoding/json"
-	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opensearchexporter/internal/objmodel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/opensearch"
 )
 
 type mappingModel interface {
@@ -58,43 +58,7 @@ func (m *encodeModel) encodeLogSSO(
 	schemaURL string,
 	record plog.LogRecord,
 ) ([]byte, error) {
-	sso := ssoRecord{}
-	sso.Attributes = record.Attributes().AsRaw()
-	sso.Body = record.Body().AsString()
-
-	now := time.Now()
-	ts := record.Timestamp().AsTime()
-	sso.ObservedTimestamp = &now
-	sso.Timestamp = &ts
-
-	sso.Resource = attributesToMapString(resource.Attributes())
-	sso.SchemaURL = schemaURL
-	sso.SpanID = record.SpanID().String()
-	sso.TraceID = record.TraceID().String()
-
-	ds := dataStream{}
-	if m.dataset != "" {
-		ds.Dataset = m.dataset
-	}
-
-	if m.namespace != "" {
-		ds.Namespace = m.namespace
-	}
-
-	if ds != (dataStream{}) {
-		ds.Type = "record"
-		sso.Attributes["data_stream"] = ds
-	}
-
-	sso.InstrumentationScope.Name = scope.Name()
-	sso.InstrumentationScope.Version = scope.Version()
-	sso.InstrumentationScope.SchemaURL = schemaURL
-	sso.InstrumentationScope.Attributes = scope.Attributes().AsRaw()
-
-	sso.Severity.Text = record.SeverityText()
-	sso.Severity.Number = int64(record.SeverityNumber())
-
-	return json.Marshal(sso)
+	return json.Marshal(opensearch.LogRecordToSS4O(resource, scope, schemaURL, record, m.dataStream()))
 }
 
 // encodeLogDataModel encodes a plog.LogRecord following the Log Data Model.
@@ -148,74 +112,12 @@ func (m *encodeModel) encodeTrace(
 	schemaURL string,
 	span ptrace.Span,
 ) ([]byte, error) {
-	sso := ssoSpan{}
-	sso.Attributes = span.Attributes().AsRaw()
-	sso.DroppedAttributesCount = span.DroppedAttributesCount()
-	sso.DroppedEventsCount = span.DroppedEventsCount()
-	sso.DroppedLinksCount = span.DroppedLinksCount()
-	sso.EndTime = span.EndTimestamp().AsTime()
-	sso.Kind = span.Kind().String()
-	sso.Name = span.Name()
-	sso.ParentSpanID = span.ParentSpanID().String()
-	sso.Resource = attributesToMapString(resource.Attributes())
-	sso.SpanID = span.SpanID().String()
-	sso.StartTime = span.StartTimestamp().AsTime()
-	sso.Status.Code = span.Status().Code().String()
-	sso.Status.Message = span.Status().Message()
-	sso.TraceID = span.TraceID().String()
-	sso.TraceState = span.TraceState().AsRaw()
-
-	if span.Events().Len() > 0 {
-		sso.Events = make([]ssoSpanEvent, span.Events().Len())
-		for i := 0; i < span.Events().Len(); i++ {
-			e := span.Events().At(i)
-			ssoEvent := &sso.Events[i]
-			ssoEvent.Attributes = e.Attributes().AsRaw()
-			ssoEvent.DroppedAttributesCount = e.DroppedAttributesCount()
-			ssoEvent.Name = e.Name()
-			ts := e.Timestamp().AsTime()
-			if ts.Unix() != 0 {
-				ssoEvent.Timestamp = &ts
-			} else {
-				now := time.Now()
-				ssoEvent.ObservedTimestamp = &now
-			}
-		}
-	}
-
-	ds := dataStream{}
-	if m.dataset != "" {
-		ds.Dataset = m.dataset
-	}
-
-	if m.namespace != "" {
-		ds.Namespace = m.namespace
-	}
-
-	if ds != (dataStream{}) {
-		ds.Type = "span"
-		sso.Attributes["data_stream"] = ds
-	}
+	return json.Marshal(opensearch.SpanToSS4O(resource, scope, schemaURL, span, m.dataStream()))
+}
 
-	sso.InstrumentationScope.Name = scope.Name()
-	sso.InstrumentationScope.DroppedAttributesCount = scope.DroppedAttributesCount()
-	sso.InstrumentationScope.Version = scope.Version()
-	sso.InstrumentationScope.SchemaURL = schemaURL
-	sso.InstrumentationScope.Attributes = scope.Attributes().AsRaw()
-
-	if span.Links().Len() > 0 {
-		sso.Links = make([]ssoSpanLinks, span.Links().Len())
-		for i := 0; i < span.Links().Len(); i++ {
-			link := span.Links().At(i)
-			ssoLink := &sso.Links[i]
-			ssoLink.Attributes = link.Attributes().AsRaw()
-			ssoLink.DroppedAttributesCount = link.DroppedAttributesCount()
-			ssoLink.TraceID = link.TraceID().String()
-			ssoLink.TraceState = link.TraceState().AsRaw()
-			ssoLink.SpanID = link.SpanID().String()
-		}
-	}
-	return json.Marshal(sso)
+// dataStream returns the data stream added to the SS4O documents.
+func (m *encodeModel) dataStream() opensearch.DataStream {
+	return opensearch.DataStream{Dataset: m.dataset, Namespace: m.namespace}
 }
 
 func epochMilliTimestamp(record plog.LogRecord) int64 {
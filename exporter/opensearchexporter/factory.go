@@ -50,6 +50,7 @@ func createTracesExporter(ctx context.Context,
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		te.pushTraceData,
 		exporterhelper.WithStart(te.Start),
+		exporterhelper.WithShutdown(te.Shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithRetry(c.RetrySettings),
 		exporterhelper.WithTimeout(c.TimeoutSettings))
@@ -67,6 +68,7 @@ func createLogsExporter(ctx context.Context,
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		le.pushLogData,
 		exporterhelper.WithStart(le.Start),
+		exporterhelper.WithShutdown(le.Shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
 		exporterhelper.WithRetry(c.RetrySettings),
 		exporterhelper.WithTimeout(c.TimeoutSettings))
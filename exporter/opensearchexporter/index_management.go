@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opensearchexporter"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"go.uber.org/zap"
+)
+
+// ismAddResponse is the response of the ISM API attaching a policy to indices.
+type ismAddResponse struct {
+	Failures      bool `json:"failures"`
+	FailedIndices []struct {
+		IndexName string `json:"index_name"`
+		Reason    string `json:"reason"`
+	} `json:"failed_indices"`
+}
+
+// setupIndexManagement puts the index template and the ISM policy of the configuration, attaching the policy to
+// the index. Since OpenSearch may not be reachable yet, the failures of the requests are logged rather than
+// failing the start of the exporter.
+func setupIndexManagement(client *opensearch.Client, template IndexTemplateSettings, policy ISMPolicySettings, index string, logger *zap.Logger) error {
+	if template.Name != "" {
+		body, err := os.ReadFile(template.File)
+		if err != nil {
+			return fmt.Errorf("failed to read the index template: %w", err)
+		}
+		if err = putIndexTemplate(client, template.Name, body); err != nil {
+			logger.Error("Failed to put the index template", zap.String("name", template.Name), zap.Error(err))
+		}
+	}
+
+	if policy.Name != "" {
+		body, err := os.ReadFile(policy.File)
+		if err != nil {
+			return fmt.Errorf("failed to read the ISM policy: %w", err)
+		}
+		if err = putISMPolicy(client, policy.Name, body); err != nil {
+			logger.Error("Failed to put the ISM policy", zap.String("name", policy.Name), zap.Error(err))
+		} else if err = attachISMPolicy(client, policy.Name, index); err != nil {
+			logger.Warn("Failed to attach the ISM policy", zap.String("name", policy.Name), zap.String("index", index), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func putIndexTemplate(client *opensearch.Client, name string, body []byte) error {
+	res, err := client.Indices.PutIndexTemplate(name, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("unexpected response: %s", res.String())
+	}
+	return nil
+}
+
+// putISMPolicy creates the ISM policy, keeping the existing policy with the same ID, which may have been edited
+// since.
+func putISMPolicy(client *opensearch.Client, name string, body []byte) error {
+	res, err := performRequest(client, http.MethodPut, "/_plugins/_ism/policies/"+url.PathEscape(name), body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusConflict {
+		return nil
+	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return unexpectedResponse(res)
+	}
+	return nil
+}
+
+// attachISMPolicy attaches the ISM policy to the existing indices of the index, the new indices being covered by
+// the ism_template of the policy. The indices which have a policy already keep it.
+func attachISMPolicy(client *opensearch.Client, name string, index string) error {
+	body, err := json.Marshal(map[string]string{"policy_id": name})
+	if err != nil {
+		return err
+	}
+	res, err := performRequest(client, http.MethodPost, "/_plugins/_ism/add/"+url.PathEscape(index), body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return unexpectedResponse(res)
+	}
+
+	var added ismAddResponse
+	if err = json.NewDecoder(res.Body).Decode(&added); err != nil {
+		return err
+	}
+	if added.Failures && len(added.FailedIndices) > 0 {
+		failed := added.FailedIndices[0]
+		return fmt.Errorf("index %s: %s", failed.IndexName, failed.Reason)
+	}
+	return nil
+}
+
+func performRequest(client *opensearch.Client, method string, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return client.Perform(req)
+}
+
+func unexpectedResponse(res *http.Response) error {
+	body, _ := io.ReadAll(res.Body)
+	return fmt.Errorf("unexpected response: %s %s", res.Status, body)
+}
@@ -6,11 +6,15 @@ package opensearchexporter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -280,6 +284,238 @@ func TestOpenSearchLogExporter(t *testing.T) {
 	}
 }
 
+func TestOpenSearchLogExporterDocumentID(t *testing.T) {
+	var pipeline string
+	var ids []any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pipeline = r.URL.Query().Get("pipeline")
+
+		// Report the documents with an _id as already indexed, as when a request
+		// is retried
+		var items []map[string]any
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var jsonData map[string]any
+			require.NoError(t, decoder.Decode(&jsonData))
+			if actionData, isBulkAction := jsonData["create"]; isBulkAction {
+				id, hasID := actionData.(map[string]any)["_id"]
+				status := http.StatusCreated
+				if hasID {
+					ids = append(ids, id)
+					status = http.StatusConflict
+				}
+				items = append(items, map[string]any{"create": map[string]any{"status": status}})
+			}
+		}
+
+		w.WriteHeader(200)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"errors": true, "items": items}))
+	}))
+	defer ts.Close()
+
+	cfg := withDefaultConfig(func(config *Config) {
+		config.Endpoint = ts.URL
+		config.TimeoutSettings.Timeout = 0
+		config.LogsPipeline = "logs-pipeline"
+		config.DocumentID = DocumentIDSettings{Strategy: "attribute", Attribute: "log.optional"}
+	})
+
+	f := NewFactory()
+	exporter, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+
+	logs, err := golden.ReadLogs("testdata/logs-sample-a.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, exporter.ConsumeLogs(context.Background(), logs))
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	require.Equal(t, "logs-pipeline", pipeline)
+	require.Equal(t, []any{"bar", "notbar", "bar", "notbar", "bar", "notbar", "bar", "notbar"}, ids)
+}
+
+func TestOpenSearchLogExporterDocumentIDHash(t *testing.T) {
+	var ids []any
+	var observed []any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []map[string]any
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var jsonData map[string]any
+			require.NoError(t, decoder.Decode(&jsonData))
+			if actionData, isBulkAction := jsonData["create"]; isBulkAction {
+				ids = append(ids, actionData.(map[string]any)["_id"])
+				items = append(items, map[string]any{"create": map[string]any{"status": http.StatusCreated}})
+			} else {
+				observed = append(observed, jsonData["observedTimestamp"])
+			}
+		}
+
+		w.WriteHeader(200)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"errors": false, "items": items}))
+	}))
+	defer ts.Close()
+
+	cfg := withDefaultConfig(func(config *Config) {
+		config.Endpoint = ts.URL
+		config.TimeoutSettings.Timeout = 0
+		config.DocumentID = DocumentIDSettings{Strategy: "hash"}
+	})
+
+	// Export the same logs twice, as when they are retried: the documents are
+	// observed at different times but keep their _id
+	for i := 0; i < 2; i++ {
+		f := NewFactory()
+		exporter, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+		require.NoError(t, err)
+		require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+
+		logs, err := golden.ReadLogs("testdata/logs-sample-a.yaml")
+		require.NoError(t, err)
+
+		require.NoError(t, exporter.ConsumeLogs(context.Background(), logs))
+		require.NoError(t, exporter.Shutdown(context.Background()))
+		time.Sleep(time.Millisecond)
+	}
+
+	require.Len(t, ids, 32)
+	require.Equal(t, ids[:16], ids[16:])
+	require.NotEqual(t, observed[:16], observed[16:])
+	for _, id := range ids {
+		require.Len(t, id, 64)
+	}
+}
+
+func TestOpenSearchTraceExporterItemFailures(t *testing.T) {
+	// The first span is throttled and the second one conflicts with the mapping
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []map[string]any
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var jsonData map[string]any
+			require.NoError(t, decoder.Decode(&jsonData))
+			if _, isBulkAction := jsonData["create"]; !isBulkAction {
+				continue
+			}
+			item := map[string]any{"status": http.StatusCreated}
+			switch len(items) {
+			case 0:
+				item = map[string]any{"status": http.StatusTooManyRequests, "error": map[string]any{"type": "es_rejected_execution_exception"}}
+			case 1:
+				item = map[string]any{"status": http.StatusBadRequest, "error": map[string]any{"type": "mapper_parsing_exception", "reason": "failed to parse field [kind]"}}
+			}
+			items = append(items, map[string]any{"create": item})
+		}
+		w.WriteHeader(200)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"errors": true, "items": items}))
+	}))
+	defer ts.Close()
+
+	dlqPath := filepath.Join(t.TempDir(), "dlq.jsonl")
+	cfg := withDefaultConfig(func(config *Config) {
+		config.Endpoint = ts.URL
+		config.TimeoutSettings.Timeout = 0
+		config.RetrySettings.Enabled = false
+		config.DeadLetterQueue.Path = dlqPath
+	})
+
+	f := NewFactory()
+	exporter, err := f.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+
+	traces, err := golden.ReadTraces("testdata/traces-sample-a.yaml")
+	require.NoError(t, err)
+
+	// Only the throttled span is retried
+	err = exporter.ConsumeTraces(context.Background(), traces)
+	require.Error(t, err)
+	require.False(t, consumererror.IsPermanent(err))
+	var tracesErr consumererror.Traces
+	require.True(t, errors.As(err, &tracesErr))
+	require.Equal(t, 1, tracesErr.Data().SpanCount())
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	// The conflicting span is in the dead letter queue
+	content, err := os.ReadFile(dlqPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+	var letter map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &letter))
+	require.Equal(t, "ss4o_traces-default-namespace", letter["index"])
+	require.Equal(t, "mapper_parsing_exception", letter["error_type"])
+	require.NotEmpty(t, letter["document"])
+}
+
+func TestOpenSearchLogExporterBulkFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	cfg := withDefaultConfig(func(config *Config) {
+		config.Endpoint = ts.URL
+		config.TimeoutSettings.Timeout = 0
+		config.RetrySettings.Enabled = false
+	})
+
+	f := NewFactory()
+	exporter, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+
+	logs, err := golden.ReadLogs("testdata/logs-sample-a.yaml")
+	require.NoError(t, err)
+
+	// All the log records of the failed request are retried
+	err = exporter.ConsumeLogs(context.Background(), logs)
+	require.Error(t, err)
+	require.False(t, consumererror.IsPermanent(err))
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	require.Equal(t, logs.LogRecordCount(), logsErr.Data().LogRecordCount())
+	require.NoError(t, exporter.Shutdown(context.Background()))
+}
+
+func TestOpenSearchIndexManagement(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"updated_indices":1,"failures":false,"failed_indices":[]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	templateFile := filepath.Join(dir, "template.json")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{"index_patterns":["ss4o_logs-*"]}`), 0600))
+	policyFile := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"policy":{"states":[]}}`), 0600))
+
+	cfg := withDefaultConfig(func(config *Config) {
+		config.Endpoint = ts.URL
+		config.IndexTemplate = IndexTemplateSettings{Name: "otel", File: templateFile}
+		config.ISMPolicy = ISMPolicySettings{Name: "otel-retention", File: policyFile}
+	})
+
+	f := NewFactory()
+	exporter, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	require.Equal(t, []string{
+		`PUT /_index_template/otel {"index_patterns":["ss4o_logs-*"]}`,
+		`PUT /_plugins/_ism/policies/otel-retention {"policy":{"states":[]}}`,
+		`POST /_plugins/_ism/add/ss4o_logs-default-namespace {"policy_id":"otel-retention"}`,
+	}, requests)
+}
+
 // validateBulkAction ensures the JSON object is to the correct index.
 func validateBulkAction(t *testing.T, expectedIndex string, strMap map[string]any) {
 	val, exists := strMap["_index"]
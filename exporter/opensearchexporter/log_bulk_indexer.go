@@ -7,73 +7,154 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchutil"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 )
 
 type logBulkIndexer struct {
 	index       string
 	bulkAction  string
+	pipeline    string
+	documentID  DocumentIDSettings
 	model       mappingModel
-	errs        []error
+	dlq         *deadLetterQueue
+	logger      *zap.Logger
 	bulkIndexer opensearchutil.BulkIndexer
+
+	mu        sync.Mutex
+	errs      []error
+	retryErrs []error
+	retryLogs plog.Logs
+	// pending holds the log records not acknowledged by OpenSearch yet, by bulk item.
+	pending    []func() plog.Logs
+	indexerErr error
 }
 
-func newLogBulkIndexer(index, bulkAction string, model mappingModel) *logBulkIndexer {
-	return &logBulkIndexer{index, bulkAction, model, nil, nil}
+func newLogBulkIndexer(index, bulkAction, pipeline string, documentID DocumentIDSettings, model mappingModel, dlq *deadLetterQueue, logger *zap.Logger) *logBulkIndexer {
+	return &logBulkIndexer{
+		index:      index,
+		bulkAction: bulkAction,
+		pipeline:   pipeline,
+		documentID: documentID,
+		model:      model,
+		dlq:        dlq,
+		logger:     logger,
+		retryLogs:  plog.NewLogs(),
+	}
 }
 
 func (lbi *logBulkIndexer) start(client *opensearch.Client) error {
 	var startErr error
-	lbi.bulkIndexer, startErr = newLogOpenSearchBulkIndexer(client, lbi.onIndexerError)
+	lbi.bulkIndexer, startErr = newLogOpenSearchBulkIndexer(client, lbi.pipeline, lbi.onIndexerError)
 	return startErr
 }
 
+// joinedError returns the error of the bulk request. The log records which can be retried are returned in a
+// consumererror.Logs, so that they are retried alone, while the log records which can't are dropped: a failed
+// log record neither fails nor duplicates the rest of the bulk.
 func (lbi *logBulkIndexer) joinedError() error {
-	return errors.Join(lbi.errs...)
+	lbi.mu.Lock()
+	defer lbi.mu.Unlock()
+
+	if lbi.indexerErr != nil {
+		// The documents of the failed bulk requests weren't acknowledged.
+		for _, logs := range lbi.pending {
+			if logs != nil {
+				logs().ResourceLogs().MoveAndAppendTo(lbi.retryLogs.ResourceLogs())
+			}
+		}
+		lbi.retryErrs = append(lbi.retryErrs, lbi.indexerErr)
+	}
+
+	if len(lbi.retryErrs) == 0 {
+		return errors.Join(lbi.errs...)
+	}
+	if len(lbi.errs) > 0 {
+		lbi.logger.Warn("Dropping the log records which can't be indexed, retrying the others",
+			zap.Int("dropped", len(lbi.errs)), zap.Error(errors.Join(lbi.errs...)))
+	}
+	return consumererror.NewLogs(errors.Join(lbi.retryErrs...), lbi.retryLogs)
 }
 
 func (lbi *logBulkIndexer) close(ctx context.Context) {
 	closeErr := lbi.bulkIndexer.Close(ctx)
 	if closeErr != nil {
-		lbi.errs = append(lbi.errs, closeErr)
+		lbi.appendPermanentError(closeErr)
 	}
 }
 
+// onIndexerError records the failure of a bulk request as a whole, whose log records are retried as they aren't
+// acknowledged.
 func (lbi *logBulkIndexer) onIndexerError(_ context.Context, indexerErr error) {
 	if indexerErr != nil {
-		lbi.appendPermanentError(consumererror.NewPermanent(indexerErr))
+		lbi.mu.Lock()
+		lbi.indexerErr = indexerErr
+		lbi.mu.Unlock()
 	}
 }
 
 func (lbi *logBulkIndexer) appendPermanentError(e error) {
+	lbi.mu.Lock()
+	defer lbi.mu.Unlock()
 	lbi.errs = append(lbi.errs, consumererror.NewPermanent(e))
 }
 
 func (lbi *logBulkIndexer) appendRetryLogError(err error, log plog.Logs) {
-	lbi.errs = append(lbi.errs, consumererror.NewLogs(err, log))
+	lbi.mu.Lock()
+	defer lbi.mu.Unlock()
+	lbi.retryErrs = append(lbi.retryErrs, err)
+	log.ResourceLogs().MoveAndAppendTo(lbi.retryLogs.ResourceLogs())
+}
+
+// addPending records a log record submitted to the bulk indexer, returning the function acknowledging it.
+func (lbi *logBulkIndexer) addPending(logs func() plog.Logs) func() {
+	lbi.mu.Lock()
+	defer lbi.mu.Unlock()
+	id := len(lbi.pending)
+	lbi.pending = append(lbi.pending, logs)
+	return func() {
+		lbi.mu.Lock()
+		defer lbi.mu.Unlock()
+		lbi.pending[id] = nil
+	}
 }
 
 func (lbi *logBulkIndexer) submit(ctx context.Context, ld plog.Logs) {
 	forEachLog(ld, func(resource pcommon.Resource, resourceSchemaURL string, scope pcommon.InstrumentationScope, scopeSchemaURL string, log plog.LogRecord) {
+		logs := func() plog.Logs {
+			return makeLog(resource, resourceSchemaURL, scope, scopeSchemaURL, log)
+		}
 		payload, err := lbi.model.encodeLog(resource, scope, scopeSchemaURL, log)
+		var id string
+		if err == nil {
+			id, err = documentID(lbi.documentID, resource.Attributes(), log.Attributes(), func() ([]byte, error) {
+				return (&plog.ProtoMarshaler{}).MarshalLogs(logs())
+			})
+		}
 		if err != nil {
 			lbi.appendPermanentError(err)
 		} else {
-			ItemFailureHandler := func(ctx context.Context, item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error) {
+			acknowledge := lbi.addPending(logs)
+			bi := lbi.newBulkIndexerItem(payload, id)
+			bi.OnSuccess = func(context.Context, opensearchutil.BulkIndexerItem, opensearchutil.BulkIndexerResponseItem) {
+				acknowledge()
+			}
+			bi.OnFailure = func(ctx context.Context, item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error) {
 				// Setup error handler. The handler handles the per item response status based on the
 				// selective ACKing in the bulk response.
-				lbi.processItemFailure(resp, itemErr, makeLog(resource, resourceSchemaURL, scope, scopeSchemaURL, log))
+				acknowledge()
+				lbi.processItemFailure(item, resp, itemErr, payload, logs)
 			}
-			bi := lbi.newBulkIndexerItem(payload)
-			bi.OnFailure = ItemFailureHandler
 			err = lbi.bulkIndexer.Add(ctx, bi)
 			if err != nil {
-				lbi.appendRetryLogError(err, makeLog(resource, resourceSchemaURL, scope, scopeSchemaURL, log))
+				acknowledge()
+				lbi.appendRetryLogError(err, logs())
 			}
 		}
 	})
@@ -95,30 +176,39 @@ func makeLog(resource pcommon.Resource, resourceSchemaURL string, scope pcommon.
 	return logs
 }
 
-func (lbi *logBulkIndexer) processItemFailure(resp opensearchutil.BulkIndexerResponseItem, itemErr error, logs plog.Logs) {
-	switch {
-	case shouldRetryEvent(resp.Status):
+func (lbi *logBulkIndexer) processItemFailure(item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error, document []byte, logs func() plog.Logs) {
+	switch classifyItemFailure(item, resp, itemErr) {
+	case itemIndexed:
+		// The document has been indexed by a previous attempt
+	case itemRetry:
 		// Recoverable OpenSearch error
-		lbi.appendRetryLogError(responseAsError(resp), logs)
-	case resp.Status != 0 && itemErr == nil:
-		// Non-recoverable OpenSearch error while indexing document
-		lbi.appendPermanentError(responseAsError(resp))
+		lbi.appendRetryLogError(responseAsError(resp), logs())
+	case itemDeadLetter:
+		// Mapping conflict, which would fail again if retried
+		if err := writeDeadLetter(lbi.dlq, item, resp, document); err != nil {
+			lbi.appendPermanentError(err)
+		}
 	default:
-		// Encoding error. We didn't even attempt to send the event
+		// Non-recoverable OpenSearch error while indexing document, or encoding error if we didn't even
+		// attempt to send the event
+		if itemErr == nil {
+			itemErr = responseAsError(resp)
+		}
 		lbi.appendPermanentError(itemErr)
 	}
 }
 
-func (lbi *logBulkIndexer) newBulkIndexerItem(document []byte) opensearchutil.BulkIndexerItem {
+func (lbi *logBulkIndexer) newBulkIndexerItem(document []byte, docID string) opensearchutil.BulkIndexerItem {
 	body := bytes.NewReader(document)
-	item := opensearchutil.BulkIndexerItem{Action: lbi.bulkAction, Index: lbi.index, Body: body}
+	item := opensearchutil.BulkIndexerItem{Action: lbi.bulkAction, Index: lbi.index, DocumentID: docID, Body: body}
 	return item
 }
 
-func newLogOpenSearchBulkIndexer(client *opensearch.Client, onIndexerError func(context.Context, error)) (opensearchutil.BulkIndexer, error) {
+func newLogOpenSearchBulkIndexer(client *opensearch.Client, pipeline string, onIndexerError func(context.Context, error)) (opensearchutil.BulkIndexer, error) {
 	return opensearchutil.NewBulkIndexer(opensearchutil.BulkIndexerConfig{
 		NumWorkers: 1,
 		Client:     client,
+		Pipeline:   pipeline,
 		OnError:    onIndexerError,
 	})
 }
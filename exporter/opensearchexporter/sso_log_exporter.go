@@ -15,12 +15,18 @@ import (
 )
 
 type logExporter struct {
-	client       *opensearch.Client
-	Index        string
-	bulkAction   string
-	model        mappingModel
-	httpSettings confighttp.HTTPClientSettings
-	telemetry    component.TelemetrySettings
+	client        *opensearch.Client
+	Index         string
+	bulkAction    string
+	pipeline      string
+	documentID    DocumentIDSettings
+	dlqPath       string
+	dlq           *deadLetterQueue
+	indexTemplate IndexTemplateSettings
+	ismPolicy     ISMPolicySettings
+	model         mappingModel
+	httpSettings  confighttp.HTTPClientSettings
+	telemetry     component.TelemetrySettings
 }
 
 func newLogExporter(cfg *Config, set exporter.CreateSettings) (*logExporter, error) {
@@ -40,11 +46,16 @@ func newLogExporter(cfg *Config, set exporter.CreateSettings) (*logExporter, err
 	}
 
 	return &logExporter{
-		telemetry:    set.TelemetrySettings,
-		Index:        getIndexName(cfg.Dataset, cfg.Namespace, cfg.LogsIndex),
-		bulkAction:   cfg.BulkAction,
-		httpSettings: cfg.HTTPClientSettings,
-		model:        model,
+		telemetry:     set.TelemetrySettings,
+		Index:         getIndexName(cfg.Dataset, cfg.Namespace, cfg.LogsIndex),
+		bulkAction:    cfg.BulkAction,
+		pipeline:      cfg.LogsPipeline,
+		documentID:    cfg.DocumentID,
+		dlqPath:       cfg.DeadLetterQueue.Path,
+		indexTemplate: cfg.IndexTemplate,
+		ismPolicy:     cfg.ISMPolicy,
+		httpSettings:  cfg.HTTPClientSettings,
+		model:         model,
 	}, nil
 }
 
@@ -60,11 +71,21 @@ func (l *logExporter) Start(_ context.Context, host component.Host) error {
 	}
 
 	l.client = client
-	return nil
+
+	if l.dlqPath != "" {
+		if l.dlq, err = newDeadLetterQueue(l.dlqPath); err != nil {
+			return err
+		}
+	}
+	return setupIndexManagement(client, l.indexTemplate, l.ismPolicy, l.Index, l.telemetry.Logger)
+}
+
+func (l *logExporter) Shutdown(context.Context) error {
+	return l.dlq.close()
 }
 
 func (l *logExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	indexer := newLogBulkIndexer(l.Index, l.bulkAction, l.model)
+	indexer := newLogBulkIndexer(l.Index, l.bulkAction, l.pipeline, l.documentID, l.model, l.dlq, l.telemetry.Logger)
 	startErr := indexer.start(l.client)
 	if startErr != nil {
 		return startErr
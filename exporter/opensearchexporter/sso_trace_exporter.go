@@ -16,13 +16,19 @@ import (
 )
 
 type ssoTracesExporter struct {
-	client       *opensearch.Client
-	Namespace    string
-	Dataset      string
-	bulkAction   string
-	model        mappingModel
-	httpSettings confighttp.HTTPClientSettings
-	telemetry    component.TelemetrySettings
+	client        *opensearch.Client
+	Namespace     string
+	Dataset       string
+	bulkAction    string
+	pipeline      string
+	documentID    DocumentIDSettings
+	dlqPath       string
+	dlq           *deadLetterQueue
+	indexTemplate IndexTemplateSettings
+	ismPolicy     ISMPolicySettings
+	model         mappingModel
+	httpSettings  confighttp.HTTPClientSettings
+	telemetry     component.TelemetrySettings
 }
 
 func newSSOTracesExporter(cfg *Config, set exporter.CreateSettings) (*ssoTracesExporter, error) {
@@ -36,12 +42,17 @@ func newSSOTracesExporter(cfg *Config, set exporter.CreateSettings) (*ssoTracesE
 	}
 
 	return &ssoTracesExporter{
-		telemetry:    set.TelemetrySettings,
-		Namespace:    cfg.Namespace,
-		Dataset:      cfg.Dataset,
-		bulkAction:   cfg.BulkAction,
-		model:        model,
-		httpSettings: cfg.HTTPClientSettings,
+		telemetry:     set.TelemetrySettings,
+		Namespace:     cfg.Namespace,
+		Dataset:       cfg.Dataset,
+		bulkAction:    cfg.BulkAction,
+		pipeline:      cfg.TracesPipeline,
+		documentID:    cfg.DocumentID,
+		dlqPath:       cfg.DeadLetterQueue.Path,
+		indexTemplate: cfg.IndexTemplate,
+		ismPolicy:     cfg.ISMPolicy,
+		model:         model,
+		httpSettings:  cfg.HTTPClientSettings,
 	}, nil
 }
 
@@ -57,11 +68,21 @@ func (s *ssoTracesExporter) Start(_ context.Context, host component.Host) error
 	}
 
 	s.client = client
-	return nil
+
+	if s.dlqPath != "" {
+		if s.dlq, err = newDeadLetterQueue(s.dlqPath); err != nil {
+			return err
+		}
+	}
+	return setupIndexManagement(client, s.indexTemplate, s.ismPolicy, getTracesIndexName(s.Dataset, s.Namespace), s.telemetry.Logger)
+}
+
+func (s *ssoTracesExporter) Shutdown(context.Context) error {
+	return s.dlq.close()
 }
 
 func (s *ssoTracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
-	indexer := newTraceBulkIndexer(s.Dataset, s.Namespace, s.bulkAction, s.model)
+	indexer := newTraceBulkIndexer(s.Dataset, s.Namespace, s.bulkAction, s.pipeline, s.documentID, s.model, s.dlq, s.telemetry.Logger)
 	startErr := indexer.start(s.client)
 	if startErr != nil {
 		return startErr
@@ -8,75 +8,158 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchutil"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
 type traceBulkIndexer struct {
 	dataset     string
 	namespace   string
 	bulkAction  string
+	pipeline    string
+	documentID  DocumentIDSettings
 	model       mappingModel
-	errs        []error
+	dlq         *deadLetterQueue
+	logger      *zap.Logger
 	bulkIndexer opensearchutil.BulkIndexer
+
+	mu          sync.Mutex
+	errs        []error
+	retryErrs   []error
+	retryTraces ptrace.Traces
+	// pending holds the spans not acknowledged by OpenSearch yet, by bulk item.
+	pending    []func() ptrace.Traces
+	indexerErr error
 }
 
-func newTraceBulkIndexer(dataset string, namespace string, bulkAction string, model mappingModel) *traceBulkIndexer {
-	return &traceBulkIndexer{dataset, namespace, bulkAction, model, nil, nil}
+func newTraceBulkIndexer(dataset string, namespace string, bulkAction string, pipeline string, documentID DocumentIDSettings, model mappingModel, dlq *deadLetterQueue, logger *zap.Logger) *traceBulkIndexer {
+	return &traceBulkIndexer{
+		dataset:     dataset,
+		namespace:   namespace,
+		bulkAction:  bulkAction,
+		pipeline:    pipeline,
+		documentID:  documentID,
+		model:       model,
+		dlq:         dlq,
+		logger:      logger,
+		retryTraces: ptrace.NewTraces(),
+	}
 }
 
+// joinedError returns the error of the bulk request. The spans which can be retried are returned in a
+// consumererror.Traces, so that they are retried alone, while the spans which can't are dropped: a failed span
+// neither fails nor duplicates the rest of the bulk.
 func (tbi *traceBulkIndexer) joinedError() error {
-	return errors.Join(tbi.errs...)
+	tbi.mu.Lock()
+	defer tbi.mu.Unlock()
+
+	if tbi.indexerErr != nil {
+		// The documents of the failed bulk requests weren't acknowledged.
+		for _, traces := range tbi.pending {
+			if traces != nil {
+				traces().ResourceSpans().MoveAndAppendTo(tbi.retryTraces.ResourceSpans())
+			}
+		}
+		tbi.retryErrs = append(tbi.retryErrs, tbi.indexerErr)
+	}
+
+	if len(tbi.retryErrs) == 0 {
+		return errors.Join(tbi.errs...)
+	}
+	if len(tbi.errs) > 0 {
+		tbi.logger.Warn("Dropping the spans which can't be indexed, retrying the others",
+			zap.Int("dropped", len(tbi.errs)), zap.Error(errors.Join(tbi.errs...)))
+	}
+	return consumererror.NewTraces(errors.Join(tbi.retryErrs...), tbi.retryTraces)
 }
 
 func (tbi *traceBulkIndexer) start(client *opensearch.Client) error {
 	var startErr error
-	tbi.bulkIndexer, startErr = newOpenSearchBulkIndexer(client, tbi.onIndexerError)
+	tbi.bulkIndexer, startErr = newOpenSearchBulkIndexer(client, tbi.pipeline, tbi.onIndexerError)
 	return startErr
 }
 
 func (tbi *traceBulkIndexer) close(ctx context.Context) {
 	closeErr := tbi.bulkIndexer.Close(ctx)
 	if closeErr != nil {
-		tbi.errs = append(tbi.errs, closeErr)
+		tbi.appendPermanentError(closeErr)
 	}
 }
 
+// onIndexerError records the failure of a bulk request as a whole, whose spans are retried as they aren't
+// acknowledged.
 func (tbi *traceBulkIndexer) onIndexerError(_ context.Context, indexerErr error) {
 	if indexerErr != nil {
-		tbi.appendPermanentError(consumererror.NewPermanent(indexerErr))
+		tbi.mu.Lock()
+		tbi.indexerErr = indexerErr
+		tbi.mu.Unlock()
 	}
 }
 
 func (tbi *traceBulkIndexer) appendPermanentError(e error) {
+	tbi.mu.Lock()
+	defer tbi.mu.Unlock()
 	tbi.errs = append(tbi.errs, consumererror.NewPermanent(e))
 }
 
 func (tbi *traceBulkIndexer) appendRetryTraceError(err error, trace ptrace.Traces) {
-	tbi.errs = append(tbi.errs, consumererror.NewTraces(err, trace))
+	tbi.mu.Lock()
+	defer tbi.mu.Unlock()
+	tbi.retryErrs = append(tbi.retryErrs, err)
+	trace.ResourceSpans().MoveAndAppendTo(tbi.retryTraces.ResourceSpans())
+}
+
+// addPending records a span submitted to the bulk indexer, returning the function acknowledging it.
+func (tbi *traceBulkIndexer) addPending(traces func() ptrace.Traces) func() {
+	tbi.mu.Lock()
+	defer tbi.mu.Unlock()
+	id := len(tbi.pending)
+	tbi.pending = append(tbi.pending, traces)
+	return func() {
+		tbi.mu.Lock()
+		defer tbi.mu.Unlock()
+		tbi.pending[id] = nil
+	}
 }
 
 func (tbi *traceBulkIndexer) submit(ctx context.Context, td ptrace.Traces) {
 	forEachSpan(td, func(resource pcommon.Resource, resourceSchemaURL string, scope pcommon.InstrumentationScope, scopeSchemaURL string, span ptrace.Span) {
+		traces := func() ptrace.Traces {
+			return makeTrace(resource, resourceSchemaURL, scope, scopeSchemaURL, span)
+		}
 		payload, err := tbi.model.encodeTrace(resource, scope, scopeSchemaURL, span)
+		var id string
+		if err == nil {
+			id, err = documentID(tbi.documentID, resource.Attributes(), span.Attributes(), func() ([]byte, error) {
+				return (&ptrace.ProtoMarshaler{}).MarshalTraces(traces())
+			})
+		}
 		if err != nil {
 			tbi.appendPermanentError(err)
 		} else {
-			ItemFailureHandler := func(ctx context.Context, item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error) {
+			acknowledge := tbi.addPending(traces)
+			bi := tbi.newBulkIndexerItem(payload, id)
+			bi.OnSuccess = func(context.Context, opensearchutil.BulkIndexerItem, opensearchutil.BulkIndexerResponseItem) {
+				acknowledge()
+			}
+			bi.OnFailure = func(ctx context.Context, item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error) {
 				// Setup error handler. The handler handles the per item response status based on the
 				// selective ACKing in the bulk response.
-				tbi.processItemFailure(resp, itemErr, makeTrace(resource, resourceSchemaURL, scope, scopeSchemaURL, span))
+				acknowledge()
+				tbi.processItemFailure(item, resp, itemErr, payload, traces)
 			}
-			bi := tbi.newBulkIndexerItem(payload)
-			bi.OnFailure = ItemFailureHandler
 			err = tbi.bulkIndexer.Add(ctx, bi)
 			if err != nil {
-				tbi.appendRetryTraceError(err, makeTrace(resource, resourceSchemaURL, scope, scopeSchemaURL, span))
+				acknowledge()
+				tbi.appendRetryTraceError(err, traces())
 			}
 		}
 	})
@@ -98,16 +181,24 @@ func makeTrace(resource pcommon.Resource, resourceSchemaURL string, scope pcommo
 	return traces
 }
 
-func (tbi *traceBulkIndexer) processItemFailure(resp opensearchutil.BulkIndexerResponseItem, itemErr error, traces ptrace.Traces) {
-	switch {
-	case shouldRetryEvent(resp.Status):
+func (tbi *traceBulkIndexer) processItemFailure(item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem, itemErr error, document []byte, traces func() ptrace.Traces) {
+	switch classifyItemFailure(item, resp, itemErr) {
+	case itemIndexed:
+		// The document has been indexed by a previous attempt
+	case itemRetry:
 		// Recoverable OpenSearch error
-		tbi.appendRetryTraceError(responseAsError(resp), traces)
-	case resp.Status != 0 && itemErr == nil:
-		// Non-recoverable OpenSearch error while indexing document
-		tbi.appendPermanentError(responseAsError(resp))
+		tbi.appendRetryTraceError(responseAsError(resp), traces())
+	case itemDeadLetter:
+		// Mapping conflict, which would fail again if retried
+		if err := writeDeadLetter(tbi.dlq, item, resp, document); err != nil {
+			tbi.appendPermanentError(err)
+		}
 	default:
-		// Encoding error. We didn't even attempt to send the event
+		// Non-recoverable OpenSearch error while indexing document, or encoding error if we didn't even
+		// attempt to send the event
+		if itemErr == nil {
+			itemErr = responseAsError(resp)
+		}
 		tbi.appendPermanentError(itemErr)
 	}
 }
@@ -118,13 +209,10 @@ func responseAsError(item opensearchutil.BulkIndexerResponseItem) error {
 	return errors.New(string(errorJSON))
 }
 
-func attributesToMapString(attributes pcommon.Map) map[string]string {
-	m := make(map[string]string, attributes.Len())
-	attributes.Range(func(k string, v pcommon.Value) bool {
-		m[k] = v.AsString()
-		return true
-	})
-	return m
+// isAlreadyIndexed returns whether the item failed because a document with its
+// _id exists already, e.g. because a retried request indexed it.
+func isAlreadyIndexed(item opensearchutil.BulkIndexerItem, resp opensearchutil.BulkIndexerResponseItem) bool {
+	return item.DocumentID != "" && resp.Status == http.StatusConflict
 }
 
 func shouldRetryEvent(status int) bool {
@@ -137,20 +225,25 @@ func shouldRetryEvent(status int) bool {
 	return false
 }
 
-func (tbi *traceBulkIndexer) newBulkIndexerItem(document []byte) opensearchutil.BulkIndexerItem {
+func (tbi *traceBulkIndexer) newBulkIndexerItem(document []byte, docID string) opensearchutil.BulkIndexerItem {
 	body := bytes.NewReader(document)
-	item := opensearchutil.BulkIndexerItem{Action: tbi.bulkAction, Index: tbi.getIndexName(), Body: body}
+	item := opensearchutil.BulkIndexerItem{Action: tbi.bulkAction, Index: tbi.getIndexName(), DocumentID: docID, Body: body}
 	return item
 }
 
 func (tbi *traceBulkIndexer) getIndexName() string {
-	return strings.Join([]string{"ss4o_traces", tbi.dataset, tbi.namespace}, "-")
+	return getTracesIndexName(tbi.dataset, tbi.namespace)
+}
+
+func getTracesIndexName(dataset, namespace string) string {
+	return strings.Join([]string{"ss4o_traces", dataset, namespace}, "-")
 }
 
-func newOpenSearchBulkIndexer(client *opensearch.Client, onIndexerError func(context.Context, error)) (opensearchutil.BulkIndexer, error) {
+func newOpenSearchBulkIndexer(client *opensearch.Client, pipeline string, onIndexerError func(context.Context, error)) (opensearchutil.BulkIndexer, error) {
 	return opensearchutil.NewBulkIndexer(opensearchutil.BulkIndexerConfig{
 		NumWorkers: 1,
 		Client:     client,
+		Pipeline:   pipeline,
 		OnError:    onIndexerError,
 	})
 }
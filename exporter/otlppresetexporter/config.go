@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	otlphttp "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+// Config defines configuration for the OTLP preset exporter.
+type Config struct {
+	// Config represents the OTLP HTTP Exporter configuration. The endpoint and the
+	// headers not set explicitly are filled in from the preset.
+	otlphttp.Config `mapstructure:",squash"`
+
+	// Preset is the name of the vendor preset to load defaults from.
+	Preset string `mapstructure:"preset"`
+
+	// PresetParams are the values of the parameters referenced by the preset,
+	// e.g. the tenant of the endpoint or the API token sent in headers.
+	PresetParams map[string]configopaque.String `mapstructure:"preset_params"`
+
+	// AttributeValueLengthLimit truncates string attribute values to the given
+	// number of characters. If 0, the limit of the preset is used, if negative
+	// attribute values are not truncated.
+	AttributeValueLengthLimit int `mapstructure:"attribute_value_length_limit"`
+}
+
+var _ component.Config = (*Config)(nil)
+var _ confmap.Unmarshaler = (*Config)(nil)
+
+// Unmarshal applies the preset once the configuration is read, so that the
+// endpoint validation of the OTLP HTTP Exporter sees the endpoint of the preset.
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		// Nothing to do if there is no config given.
+		return nil
+	}
+
+	if err := componentParser.Unmarshal(cfg); err != nil {
+		return err
+	}
+
+	return cfg.applyPreset()
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Preset == "" {
+		return errors.New(`requires a non-empty "preset"`)
+	}
+	if _, ok := presets[cfg.Preset]; !ok {
+		return fmt.Errorf("unknown preset %q, must be one of: %s", cfg.Preset, strings.Join(presetNames(), ", "))
+	}
+	return nil
+}
+
+// applyPreset fills in the settings which are not set explicitly from the preset.
+// Unknown presets are left to Validate.
+func (cfg *Config) applyPreset() error {
+	p, ok := presets[cfg.Preset]
+	if !ok {
+		return nil
+	}
+
+	params := make(map[string]string, len(cfg.PresetParams))
+	for name, value := range cfg.PresetParams {
+		if _, ok := p.params[name]; !ok {
+			return fmt.Errorf("preset %q: unknown parameter %q", cfg.Preset, name)
+		}
+		params[name] = string(value)
+	}
+
+	if cfg.Endpoint == "" {
+		endpoint, err := p.expand(p.endpoint, params)
+		if err != nil {
+			return fmt.Errorf("preset %q: endpoint: %w", cfg.Preset, err)
+		}
+		cfg.Endpoint = endpoint
+	}
+
+	if cfg.Headers == nil {
+		cfg.Headers = make(map[string]configopaque.String, len(p.headers))
+	}
+	for name, pattern := range p.headers {
+		if _, ok := cfg.Headers[name]; ok {
+			continue
+		}
+		value, err := p.expand(pattern, params)
+		if err != nil {
+			return fmt.Errorf("preset %q: header %q: %w", cfg.Preset, name, err)
+		}
+		cfg.Headers[name] = configopaque.String(value)
+	}
+
+	if cfg.AttributeValueLengthLimit == 0 {
+		cfg.AttributeValueLengthLimit = p.attributeValueLengthLimit
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected func(cfg *Config)
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func(cfg *Config) {
+				cfg.Preset = "newrelic"
+				cfg.PresetParams = map[string]configopaque.String{"license_key": "my-license-key"}
+				cfg.Endpoint = "https://otlp.nr-data.net"
+				cfg.Headers = map[string]configopaque.String{"api-key": "my-license-key"}
+				cfg.AttributeValueLengthLimit = 4095
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "dynatrace"),
+			expected: func(cfg *Config) {
+				cfg.Preset = "dynatrace"
+				cfg.PresetParams = map[string]configopaque.String{"environment_id": "abc12345", "api_token": "my-api-token"}
+				cfg.Endpoint = "https://abc12345.live.dynatrace.com/api/v2/otlp"
+				cfg.Headers = map[string]configopaque.String{"Authorization": "Api-Token my-api-token"}
+				cfg.Timeout = 10 * time.Second
+				cfg.QueueSettings = exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				}
+			},
+		},
+		{
+			// Explicit settings take precedence, and the license key is not required
+			// since the header is set explicitly
+			id: component.NewIDWithName(metadata.Type, "override"),
+			expected: func(cfg *Config) {
+				cfg.Preset = "newrelic"
+				cfg.Endpoint = "https://otlp.eu01.nr-data.net"
+				cfg.Headers = map[string]configopaque.String{"api-key": "other-license-key"}
+				cfg.AttributeValueLengthLimit = -1
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			require.NoError(t, component.ValidateConfig(cfg))
+
+			expected := factory.CreateDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id                 component.ID
+		expectedUnmarshal  string
+		expectedValidation string
+	}{
+		{
+			id:                component.NewIDWithName(metadata.Type, "missing_param"),
+			expectedUnmarshal: `preset "dynatrace": header "Authorization": missing required parameter "api_token"`,
+		},
+		{
+			id:                component.NewIDWithName(metadata.Type, "unknown_param"),
+			expectedUnmarshal: `preset "newrelic": unknown parameter "region"`,
+		},
+		{
+			id:                 component.NewIDWithName(metadata.Type, "unknown_preset"),
+			expectedValidation: `unknown preset "unknown", must be one of: dynatrace, newrelic`,
+		},
+		{
+			id:                 component.NewIDWithName(metadata.Type, "no_preset"),
+			expectedValidation: `requires a non-empty "preset"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			err = component.UnmarshalConfig(sub, cfg)
+			if tt.expectedUnmarshal != "" {
+				assert.ErrorContains(t, err, tt.expectedUnmarshal)
+				return
+			}
+			require.NoError(t, err)
+			assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedValidation)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package otlppresetexporter implements an OTLP/HTTP exporter which loads vendor-specific defaults from a named preset.
+package otlppresetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter"
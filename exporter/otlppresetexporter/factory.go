@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	otlphttp "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter/internal/metadata"
+)
+
+type presetFactory struct {
+	exporter.Factory
+}
+
+// NewFactory returns a factory of the OTLP preset exporter that can be registered to the Collector.
+func NewFactory() exporter.Factory {
+	return &presetFactory{Factory: otlphttp.NewFactory()}
+}
+
+func (f *presetFactory) Type() component.Type {
+	return metadata.Type
+}
+
+func (f *presetFactory) TracesExporterStability() component.StabilityLevel {
+	return metadata.TracesStability
+}
+
+func (f *presetFactory) MetricsExporterStability() component.StabilityLevel {
+	return metadata.MetricsStability
+}
+
+func (f *presetFactory) LogsExporterStability() component.StabilityLevel {
+	return metadata.LogsStability
+}
+
+func (f *presetFactory) CreateDefaultConfig() component.Config {
+	return &Config{
+		Config: *f.Factory.CreateDefaultConfig().(*otlphttp.Config),
+	}
+}
+
+func (f *presetFactory) CreateTracesExporter(
+	ctx context.Context,
+	params exporter.CreateSettings,
+	config component.Config) (exporter.Traces, error) {
+
+	cfg := config.(*Config)
+	exp, err := f.Factory.CreateTracesExporter(ctx, params, &cfg.Config)
+	if err != nil || cfg.AttributeValueLengthLimit <= 0 {
+		return exp, err
+	}
+	return &truncatingTracesExporter{Traces: exp, limit: cfg.AttributeValueLengthLimit}, nil
+}
+
+func (f *presetFactory) CreateMetricsExporter(
+	ctx context.Context,
+	params exporter.CreateSettings,
+	config component.Config) (exporter.Metrics, error) {
+
+	cfg := config.(*Config)
+	exp, err := f.Factory.CreateMetricsExporter(ctx, params, &cfg.Config)
+	if err != nil || cfg.AttributeValueLengthLimit <= 0 {
+		return exp, err
+	}
+	return &truncatingMetricsExporter{Metrics: exp, limit: cfg.AttributeValueLengthLimit}, nil
+}
+
+func (f *presetFactory) CreateLogsExporter(
+	ctx context.Context,
+	params exporter.CreateSettings,
+	config component.Config) (exporter.Logs, error) {
+
+	cfg := config.(*Config)
+	exp, err := f.Factory.CreateLogsExporter(ctx, params, &cfg.Config)
+	if err != nil || cfg.AttributeValueLengthLimit <= 0 {
+		return exp, err
+	}
+	return &truncatingLogsExporter{Logs: exp, limit: cfg.AttributeValueLengthLimit}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter/internal/metadata"
+)
+
+func TestFactory_TestType(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, f.Type(), component.Type(metadata.Type))
+	assert.Equal(t, metadata.TracesStability, f.TracesExporterStability())
+	assert.Equal(t, metadata.MetricsStability, f.MetricsExporterStability())
+	assert.Equal(t, metadata.LogsStability, f.LogsExporterStability())
+}
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestFactory_CreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Preset = "newrelic"
+	cfg.PresetParams = map[string]configopaque.String{"license_key": "key"}
+	require.NoError(t, cfg.applyPreset())
+
+	params := exportertest.NewNopCreateSettings()
+	traces, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &truncatingTracesExporter{}, traces)
+	metrics, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &truncatingMetricsExporter{}, metrics)
+	logs, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &truncatingLogsExporter{}, logs)
+
+	// Without limit, the OTLP HTTP Exporter is used as is
+	cfg.AttributeValueLengthLimit = -1
+	logs, err = factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	_, ok := logs.(*truncatingLogsExporter)
+	assert.False(t, ok)
+	assert.False(t, logs.Capabilities().MutatesData)
+}
+
+func TestExportWithPreset(t *testing.T) {
+	var received plogotlp.ExportRequest
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received = plogotlp.NewExportRequest()
+		assert.NoError(t, received.UnmarshalProto(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Preset = "newrelic"
+	cfg.PresetParams = map[string]configopaque.String{"license_key": "key"}
+	cfg.Endpoint = srv.URL
+	cfg.Compression = ""
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	cfg.AttributeValueLengthLimit = 3
+	require.NoError(t, cfg.applyPreset())
+
+	exp, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("key", "abcdef")
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	assert.Equal(t, "key", header.Get("api-key"))
+	attrs := received.Logs().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	v, ok := attrs.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "abc", v.Str())
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter"
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// preset holds the defaults of a vendor accepting OTLP/HTTP. The endpoint and
+// header values may reference parameters as {name}, which are replaced by the
+// preset_params of the configuration, or by the default value of the parameter.
+type preset struct {
+	// endpoint is the pattern of the base URL data is sent to.
+	endpoint string
+	// headers are the headers the vendor requires.
+	headers map[string]string
+	// params lists the parameters of the preset along with their default value.
+	// Parameters without default value must be configured when they are used.
+	params map[string]string
+	// attributeValueLengthLimit is the maximum length of string attribute values
+	// accepted by the vendor, 0 means no limit.
+	attributeValueLengthLimit int
+}
+
+var presets = map[string]preset{
+	"dynatrace": {
+		endpoint: "https://{environment_id}.live.dynatrace.com/api/v2/otlp",
+		headers: map[string]string{
+			"Authorization": "Api-Token {api_token}",
+		},
+		params: map[string]string{
+			"environment_id": "",
+			"api_token":      "",
+		},
+	},
+	"newrelic": {
+		endpoint: "https://{host}",
+		headers: map[string]string{
+			"api-key": "{license_key}",
+		},
+		params: map[string]string{
+			"host":        "otlp.nr-data.net",
+			"license_key": "",
+		},
+		attributeValueLengthLimit: 4095,
+	},
+}
+
+var paramPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// presetNames returns the sorted names of the available presets.
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expand replaces the parameters referenced by pattern by their value.
+func (p preset) expand(pattern string, params map[string]string) (string, error) {
+	var err error
+	expanded := paramPattern.ReplaceAllStringFunc(pattern, func(ref string) string {
+		name := ref[1 : len(ref)-1]
+		if v, ok := params[name]; ok && v != "" {
+			return v
+		}
+		if v := p.params[name]; v != "" {
+			return v
+		}
+		if err == nil {
+			err = fmt.Errorf("missing required parameter %q", name)
+		}
+		return ref
+	})
+	return expanded, err
+}
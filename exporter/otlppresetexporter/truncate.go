@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlppresetexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// truncatingTracesExporter truncates the string attribute values of the spans
+// before exporting them.
+type truncatingTracesExporter struct {
+	exporter.Traces
+	limit int
+}
+
+func (e *truncatingTracesExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (e *truncatingTracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		truncateAttributes(rs.Resource().Attributes(), e.limit)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			truncateAttributes(ss.Scope().Attributes(), e.limit)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				truncateAttributes(span.Attributes(), e.limit)
+				for l := 0; l < span.Events().Len(); l++ {
+					truncateAttributes(span.Events().At(l).Attributes(), e.limit)
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					truncateAttributes(span.Links().At(l).Attributes(), e.limit)
+				}
+			}
+		}
+	}
+	return e.Traces.ConsumeTraces(ctx, td)
+}
+
+// truncatingMetricsExporter truncates the string attribute values of the data
+// points before exporting them.
+type truncatingMetricsExporter struct {
+	exporter.Metrics
+	limit int
+}
+
+func (e *truncatingMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (e *truncatingMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		truncateAttributes(rm.Resource().Attributes(), e.limit)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			truncateAttributes(sm.Scope().Attributes(), e.limit)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				truncateDataPoints(sm.Metrics().At(k), e.limit)
+			}
+		}
+	}
+	return e.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func truncateDataPoints(m pmetric.Metric, limit int) {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			truncateAttributes(m.Gauge().DataPoints().At(i).Attributes(), limit)
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			truncateAttributes(m.Sum().DataPoints().At(i).Attributes(), limit)
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			truncateAttributes(m.Histogram().DataPoints().At(i).Attributes(), limit)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			truncateAttributes(m.ExponentialHistogram().DataPoints().At(i).Attributes(), limit)
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			truncateAttributes(m.Summary().DataPoints().At(i).Attributes(), limit)
+		}
+	}
+}
+
+// truncatingLogsExporter truncates the string attribute values of the log
+// records before exporting them.
+type truncatingLogsExporter struct {
+	exporter.Logs
+	limit int
+}
+
+func (e *truncatingLogsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (e *truncatingLogsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		truncateAttributes(rl.Resource().Attributes(), e.limit)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			truncateAttributes(sl.Scope().Attributes(), e.limit)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				truncateAttributes(sl.LogRecords().At(k).Attributes(), e.limit)
+			}
+		}
+	}
+	return e.Logs.ConsumeLogs(ctx, ld)
+}
+
+// truncateAttributes truncates the string values of attrs, including the ones
+// nested in maps and slices, to limit characters.
+func truncateAttributes(attrs pcommon.Map, limit int) {
+	attrs.Range(func(_ string, v pcommon.Value) bool {
+		truncateValue(v, limit)
+		return true
+	})
+}
+
+func truncateValue(v pcommon.Value, limit int) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		if s := v.Str(); len(s) > limit {
+			v.SetStr(truncateString(s, limit))
+		}
+	case pcommon.ValueTypeMap:
+		truncateAttributes(v.Map(), limit)
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < v.Slice().Len(); i++ {
+			truncateValue(v.Slice().At(i), limit)
+		}
+	}
+}
+
+// truncateString returns the first limit characters of s.
+func truncateString(s string, limit int) string {
+	n := 0
+	for i := range s {
+		if n == limit {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppresetexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTruncateAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("short", "abc")
+	attrs.PutStr("long", "abcdef")
+	attrs.PutStr("multibyte", "héllo")
+	attrs.PutInt("int", 123456)
+	nested := attrs.PutEmptyMap("map")
+	nested.PutStr("long", "abcdef")
+	slice := attrs.PutEmptySlice("slice")
+	slice.AppendEmpty().SetStr("abcdef")
+
+	truncateAttributes(attrs, 4)
+
+	assert.Equal(t, map[string]any{
+		"short":     "abc",
+		"long":      "abcd",
+		"multibyte": "héll",
+		"int":       int64(123456),
+		"map":       map[string]any{"long": "abcd"},
+		"slice":     []any{"abcd"},
+	}, attrs.AsRaw())
+}
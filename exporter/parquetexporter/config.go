@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	compressionSnappy = "snappy"
+	compressionZstd   = "zstd"
+	compressionGzip   = "gzip"
+	compressionNone   = "none"
+)
+
+// Config defines the configuration of the Parquet exporter.
+type Config struct {
+	// Path is the directory the files are written to, in a subdirectory per
+	// signal: traces, metrics and logs.
+	Path string `mapstructure:"path"`
+
+	// MaxRows is the number of rows after which a file is completed and the
+	// next one is started (default 100000).
+	MaxRows int `mapstructure:"max_rows"`
+
+	// RotationInterval is how long a file is written to before it is completed,
+	// whatever its number of rows (default 1m).
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+
+	// Compression is the compression of the columns: snappy, zstd, gzip or
+	// none (default zstd).
+	Compression string `mapstructure:"compression"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.MaxRows <= 0 {
+		return errors.New("max_rows must be positive")
+	}
+	if cfg.RotationInterval <= 0 {
+		return errors.New("rotation_interval must be positive")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	return nil
+}
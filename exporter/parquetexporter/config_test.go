@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Path:             "./archive",
+				MaxRows:          defaultMaxRows,
+				RotationInterval: defaultRotationInterval,
+				Compression:      compressionZstd,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				Path:             "/var/lib/otelcol/parquet",
+				MaxRows:          50000,
+				RotationInterval: 5 * time.Minute,
+				Compression:      compressionSnappy,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_path"),
+			errorMessage: "path must be non-empty",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_max_rows"),
+			errorMessage: "max_rows must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_rotation_interval"),
+			errorMessage: "rotation_interval must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_compression"),
+			errorMessage: `compression "lz4" is not supported`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
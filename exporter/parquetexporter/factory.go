@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter/internal/metadata"
+)
+
+const (
+	defaultMaxRows          = 100000
+	defaultRotationInterval = time.Minute
+)
+
+// NewFactory creates a factory for the Parquet exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxRows:          defaultMaxRows,
+		RotationInterval: defaultRotationInterval,
+		Compression:      compressionZstd,
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	pe := newParquetExporter(cfg.(*Config), signalTraces, tracesSchema, set.Logger)
+	return exporterhelper.NewTracesExporter(
+		ctx,
+		set,
+		cfg,
+		pe.consumeTraces,
+		exporterhelper.WithStart(pe.start),
+		exporterhelper.WithShutdown(pe.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	pe := newParquetExporter(cfg.(*Config), signalMetrics, metricsSchema, set.Logger)
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		pe.consumeMetrics,
+		exporterhelper.WithStart(pe.start),
+		exporterhelper.WithShutdown(pe.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	pe := newParquetExporter(cfg.(*Config), signalLogs, logsSchema, set.Logger)
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		set,
+		cfg,
+		pe.consumeLogs,
+		exporterhelper.WithStart(pe.start),
+		exporterhelper.WithShutdown(pe.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
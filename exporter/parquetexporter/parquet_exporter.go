@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// The signals, naming the directories of their files.
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// parquetExporter writes the telemetry of a signal to Parquet files.
+type parquetExporter struct {
+	writer *fileWriter
+	mem    memory.Allocator
+}
+
+func newParquetExporter(cfg *Config, signal string, schema *arrow.Schema, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{
+		writer: newFileWriter(cfg, filepath.Join(cfg.Path, signal), schema, logger),
+		mem:    memory.DefaultAllocator,
+	}
+}
+
+func (e *parquetExporter) start(context.Context, component.Host) error {
+	return e.writer.start()
+}
+
+func (e *parquetExporter) shutdown(context.Context) error {
+	return e.writer.close()
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if td.SpanCount() == 0 {
+		return nil
+	}
+	rec := tracesRecord(e.mem, td)
+	defer rec.Release()
+	return e.writer.write(rec)
+}
+
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	rec := metricsRecord(e.mem, md)
+	defer rec.Release()
+	return e.writer.write(rec)
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+	rec := logsRecord(e.mem, ld)
+	defer rec.Release()
+	return e.writer.write(rec)
+}
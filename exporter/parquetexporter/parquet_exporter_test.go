@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var testTime = time.Date(2023, 10, 30, 12, 30, 0, 0, time.UTC)
+
+// readRows reads the rows of the Parquet files of a directory, as decoded from
+// their JSON encoding.
+func readRows(t *testing.T, dir string) []map[string]any {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+
+	var rows []map[string]any
+	for _, path := range paths {
+		rdr, err := file.OpenParquetFile(path, false)
+		require.NoError(t, err)
+		fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+		require.NoError(t, err)
+		table, err := fr.ReadTable(context.Background())
+		require.NoError(t, err)
+
+		tr := array.NewTableReader(table, table.NumRows())
+		for tr.Next() {
+			var buf bytes.Buffer
+			require.NoError(t, array.RecordToJSON(tr.Record(), &buf))
+			decoder := json.NewDecoder(&buf)
+			for decoder.More() {
+				var row map[string]any
+				require.NoError(t, decoder.Decode(&row))
+				rows = append(rows, row)
+			}
+		}
+		tr.Release()
+		table.Release()
+		require.NoError(t, rdr.Close())
+	}
+	return rows
+}
+
+func newTestExporter(t *testing.T, signal string) (*parquetExporter, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	schemas := map[string]*arrow.Schema{
+		signalTraces:  tracesSchema,
+		signalMetrics: metricsSchema,
+		signalLogs:    logsSchema,
+	}
+	e := newParquetExporter(cfg, signal, schemas[signal], zap.NewNop())
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e, filepath.Join(cfg.Path, signal)
+}
+
+func TestConsumeTraces(t *testing.T) {
+	e, dir := newTestExporter(t, signalTraces)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Attributes().PutInt("http.status_code", 500)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.Links().AppendEmpty().SetSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+	// A span without events nor links.
+	ss.Spans().AppendEmpty().SetName("child")
+
+	require.NoError(t, e.consumeTraces(context.Background(), td))
+	// The file is not visible until it is completed.
+	assert.Empty(t, readRows(t, dir))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, dir)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "checkout", rows[0]["service_name"])
+	assert.Equal(t, "tracer", rows[0]["scope_name"])
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", rows[0]["trace_id"])
+	assert.Equal(t, "0102030405060708", rows[0]["span_id"])
+	assert.Nil(t, rows[0]["parent_span_id"])
+	assert.Equal(t, "GET /cart", rows[0]["name"])
+	assert.Equal(t, "Server", rows[0]["kind"])
+	assert.Equal(t, "2023-10-30 12:30:00", rows[0]["start_time"])
+	assert.EqualValues(t, time.Second, rows[0]["duration_ns"])
+	assert.Equal(t, "Error", rows[0]["status_code"])
+	assert.Equal(t, []any{map[string]any{"key": "http.status_code", "value": "500"}}, rows[0]["attributes"])
+	assert.Equal(t, []any{map[string]any{"time": "2023-10-30 12:30:00", "name": "exception", "attributes": []any{}}}, rows[0]["events"])
+	assert.Len(t, rows[0]["links"], 1)
+	assert.Equal(t, "child", rows[1]["name"])
+	assert.Nil(t, rows[1]["start_time"])
+	assert.Empty(t, rows[1]["events"])
+}
+
+func TestConsumeLogs(t *testing.T) {
+	e, dir := newTestExporter(t, signalLogs)
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetEmptyMap().PutStr("message", "disk almost full")
+	lr.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+
+	require.NoError(t, e.consumeLogs(context.Background(), ld))
+	// Empty batches don't start a file.
+	require.NoError(t, e.consumeLogs(context.Background(), plog.NewLogs()))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, dir)
+	require.Len(t, rows, 1)
+	assert.Nil(t, rows[0]["service_name"])
+	assert.Nil(t, rows[0]["time"])
+	assert.Equal(t, "2023-10-30 12:30:00", rows[0]["observed_time"])
+	assert.EqualValues(t, plog.SeverityNumberWarn, rows[0]["severity_number"])
+	assert.Equal(t, "WARN", rows[0]["severity_text"])
+	assert.Equal(t, `{"message":"disk almost full"}`, rows[0]["body"])
+	assert.Nil(t, rows[0]["trace_id"])
+	assert.Equal(t, "0102030405060708", rows[0]["span_id"])
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	e, dir := newTestExporter(t, signalMetrics)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(4.5)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{1.5})
+	exponential := metrics.AppendEmpty()
+	exponential.SetName("size")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetScale(2)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{4})
+	summary := metrics.AppendEmpty()
+	summary.SetName("duration")
+	quantile := summary.SetEmptySummary().DataPoints().AppendEmpty().QuantileValues().AppendEmpty()
+	quantile.SetQuantile(0.99)
+	quantile.SetValue(1.2)
+
+	require.NoError(t, e.consumeMetrics(context.Background(), md))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, dir)
+	require.Len(t, rows, 4)
+	assert.Equal(t, "requests", rows[0]["metric_name"])
+	assert.Equal(t, "Sum", rows[0]["metric_type"])
+	assert.Equal(t, "Cumulative", rows[0]["aggregation_temporality"])
+	assert.Equal(t, true, rows[0]["is_monotonic"])
+	assert.EqualValues(t, 42, rows[0]["value_int"])
+	assert.Nil(t, rows[0]["value_double"])
+	assert.Nil(t, rows[0]["count"])
+
+	assert.Equal(t, "Histogram", rows[1]["metric_type"])
+	assert.Nil(t, rows[1]["is_monotonic"])
+	assert.EqualValues(t, 3, rows[1]["count"])
+	assert.EqualValues(t, 4.5, rows[1]["sum"])
+	assert.Nil(t, rows[1]["min"])
+	assert.Equal(t, []any{1.0, 2.0}, rows[1]["bucket_counts"])
+	assert.Equal(t, []any{1.5}, rows[1]["explicit_bounds"])
+
+	assert.Equal(t, "ExponentialHistogram", rows[2]["metric_type"])
+	assert.EqualValues(t, 2, rows[2]["scale"])
+	assert.EqualValues(t, -1, rows[2]["positive_offset"])
+	assert.Equal(t, []any{4.0}, rows[2]["positive_bucket_counts"])
+	assert.Empty(t, rows[2]["negative_bucket_counts"])
+
+	assert.Equal(t, "Summary", rows[3]["metric_type"])
+	assert.Nil(t, rows[3]["aggregation_temporality"])
+	assert.Equal(t, []any{map[string]any{"quantile": 0.99, "value": 1.2}}, rows[3]["quantiles"])
+}
+
+func TestShutdownWithoutFile(t *testing.T) {
+	e, dir := newTestExporter(t, signalLogs)
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Empty(t, readRows(t, dir))
+}
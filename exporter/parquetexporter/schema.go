@@ -0,0 +1,589 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+var (
+	// timestampType is the type of the timestamps, unset timestamps being null.
+	timestampType = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+	// attributesType is the type of the attributes, whose values are converted
+	// to strings, the maps and slices being JSON encoded.
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+)
+
+// The columns shared by all the signals, describing where the telemetry comes
+// from.
+const (
+	colResourceAttributes = iota
+	colServiceName
+	colScopeName
+	colScopeVersion
+	numCommonColumns
+)
+
+var commonFields = []arrow.Field{
+	{Name: "resource_attributes", Type: attributesType},
+	{Name: "service_name", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_name", Type: arrow.BinaryTypes.String},
+	{Name: "scope_version", Type: arrow.BinaryTypes.String},
+}
+
+func newSchema(fields ...arrow.Field) *arrow.Schema {
+	return arrow.NewSchema(append(append([]arrow.Field{}, commonFields...), fields...), nil)
+}
+
+// The columns of the traces, one row per span.
+const (
+	colSpanTraceID = iota + numCommonColumns
+	colSpanID
+	colSpanParentSpanID
+	colSpanTraceState
+	colSpanName
+	colSpanKind
+	colSpanStartTime
+	colSpanEndTime
+	colSpanDuration
+	colSpanStatusCode
+	colSpanStatusMessage
+	colSpanAttributes
+	colSpanEvents
+	colSpanLinks
+)
+
+var tracesSchema = newSchema(
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "parent_span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "kind", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "end_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "duration_ns", Type: arrow.PrimitiveTypes.Int64},
+	arrow.Field{Name: "status_code", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "events", Type: arrow.ListOf(arrow.StructOf(
+		arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	))},
+	arrow.Field{Name: "links", Type: arrow.ListOf(arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	))},
+)
+
+// The columns of the logs, one row per log record.
+const (
+	colLogTime = iota + numCommonColumns
+	colLogObservedTime
+	colLogSeverityNumber
+	colLogSeverityText
+	colLogBody
+	colLogAttributes
+	colLogTraceID
+	colLogSpanID
+	colLogFlags
+)
+
+var logsSchema = newSchema(
+	arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "observed_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "body", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+)
+
+// The columns of the metrics, one row per data point, the columns which don't
+// apply to the type of the metric being null.
+const (
+	colMetricName = iota + numCommonColumns
+	colMetricDescription
+	colMetricUnit
+	colMetricType
+	colMetricTemporality
+	colMetricMonotonic
+	colMetricStartTime
+	colMetricTime
+	colMetricAttributes
+	colMetricFlags
+	colMetricValueDouble
+	colMetricValueInt
+	colMetricCount
+	colMetricSum
+	colMetricMin
+	colMetricMax
+	colMetricBucketCounts
+	colMetricExplicitBounds
+	colMetricScale
+	colMetricZeroCount
+	colMetricPositiveOffset
+	colMetricPositiveBucketCounts
+	colMetricNegativeOffset
+	colMetricNegativeBucketCounts
+	colMetricQuantiles
+)
+
+var metricsSchema = newSchema(
+	arrow.Field{Name: "metric_name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_description", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_unit", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_type", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "aggregation_temporality", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "value_double", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "value_int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+	arrow.Field{Name: "scale", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "positive_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "positive_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "negative_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "negative_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "quantiles", Type: arrow.ListOf(arrow.StructOf(
+		arrow.Field{Name: "quantile", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+	)), Nullable: true},
+)
+
+// tracesRecord converts the traces to a record of the traces schema.
+func tracesRecord(mem memory.Allocator, td ptrace.Traces) arrow.Record {
+	b := newRecordBuilder(mem, tracesSchema)
+	defer b.Release()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				appendCommon(b, rs.Resource(), ss.Scope())
+				appendSpan(b, ss.Spans().At(k))
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+func appendSpan(b *array.RecordBuilder, span ptrace.Span) {
+	b.Field(colSpanTraceID).(*array.StringBuilder).Append(span.TraceID().String())
+	b.Field(colSpanID).(*array.StringBuilder).Append(span.SpanID().String())
+	appendSpanID(b.Field(colSpanParentSpanID).(*array.StringBuilder), span.ParentSpanID())
+	b.Field(colSpanTraceState).(*array.StringBuilder).Append(span.TraceState().AsRaw())
+	b.Field(colSpanName).(*array.StringBuilder).Append(span.Name())
+	b.Field(colSpanKind).(*array.StringBuilder).Append(span.Kind().String())
+	appendTimestamp(b.Field(colSpanStartTime).(*array.TimestampBuilder), span.StartTimestamp())
+	appendTimestamp(b.Field(colSpanEndTime).(*array.TimestampBuilder), span.EndTimestamp())
+	var duration int64
+	if span.StartTimestamp() != 0 && span.EndTimestamp() >= span.StartTimestamp() {
+		duration = int64(span.EndTimestamp() - span.StartTimestamp())
+	}
+	b.Field(colSpanDuration).(*array.Int64Builder).Append(duration)
+	b.Field(colSpanStatusCode).(*array.StringBuilder).Append(span.Status().Code().String())
+	b.Field(colSpanStatusMessage).(*array.StringBuilder).Append(span.Status().Message())
+	appendAttributes(b.Field(colSpanAttributes).(*array.MapBuilder), span.Attributes())
+
+	events := b.Field(colSpanEvents).(*array.ListBuilder)
+	events.Append(true)
+	eb := events.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		eb.Append(true)
+		appendTimestamp(eb.FieldBuilder(0).(*array.TimestampBuilder), event.Timestamp())
+		eb.FieldBuilder(1).(*array.StringBuilder).Append(event.Name())
+		appendAttributes(eb.FieldBuilder(2).(*array.MapBuilder), event.Attributes())
+	}
+
+	links := b.Field(colSpanLinks).(*array.ListBuilder)
+	links.Append(true)
+	lb := links.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		lb.Append(true)
+		lb.FieldBuilder(0).(*array.StringBuilder).Append(link.TraceID().String())
+		lb.FieldBuilder(1).(*array.StringBuilder).Append(link.SpanID().String())
+		lb.FieldBuilder(2).(*array.StringBuilder).Append(link.TraceState().AsRaw())
+		appendAttributes(lb.FieldBuilder(3).(*array.MapBuilder), link.Attributes())
+	}
+}
+
+// logsRecord converts the logs to a record of the logs schema.
+func logsRecord(mem memory.Allocator, ld plog.Logs) arrow.Record {
+	b := newRecordBuilder(mem, logsSchema)
+	defer b.Release()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				appendCommon(b, rl.Resource(), sl.Scope())
+				appendLogRecord(b, sl.LogRecords().At(k))
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+func appendLogRecord(b *array.RecordBuilder, lr plog.LogRecord) {
+	appendTimestamp(b.Field(colLogTime).(*array.TimestampBuilder), lr.Timestamp())
+	appendTimestamp(b.Field(colLogObservedTime).(*array.TimestampBuilder), lr.ObservedTimestamp())
+	b.Field(colLogSeverityNumber).(*array.Int32Builder).Append(int32(lr.SeverityNumber()))
+	b.Field(colLogSeverityText).(*array.StringBuilder).Append(lr.SeverityText())
+	b.Field(colLogBody).(*array.StringBuilder).Append(lr.Body().AsString())
+	appendAttributes(b.Field(colLogAttributes).(*array.MapBuilder), lr.Attributes())
+	if traceID := lr.TraceID(); traceID.IsEmpty() {
+		b.Field(colLogTraceID).AppendNull()
+	} else {
+		b.Field(colLogTraceID).(*array.StringBuilder).Append(traceID.String())
+	}
+	appendSpanID(b.Field(colLogSpanID).(*array.StringBuilder), lr.SpanID())
+	b.Field(colLogFlags).(*array.Uint32Builder).Append(uint32(lr.Flags()))
+}
+
+// metricsRecord converts the metrics to a record of the metrics schema.
+func metricsRecord(mem memory.Allocator, md pmetric.Metrics) arrow.Record {
+	b := newRecordBuilder(mem, metricsSchema)
+	defer b.Release()
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				appendMetric(b, rm.Resource(), sm.Scope(), sm.Metrics().At(k))
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+// optional is a value which may be unset, appended as null.
+type optional[T any] struct {
+	value T
+	valid bool
+}
+
+func some[T any](value T) optional[T] {
+	return optional[T]{value: value, valid: true}
+}
+
+// metricRow holds the columns of a data point which depend on the type of the
+// metric.
+type metricRow struct {
+	startTime      pcommon.Timestamp
+	time           pcommon.Timestamp
+	attributes     pcommon.Map
+	flags          pmetric.DataPointFlags
+	valueDouble    optional[float64]
+	valueInt       optional[int64]
+	count          optional[uint64]
+	sum            optional[float64]
+	min            optional[float64]
+	max            optional[float64]
+	bucketCounts   optional[pcommon.UInt64Slice]
+	explicitBounds optional[pcommon.Float64Slice]
+	scale          optional[int32]
+	zeroCount      optional[uint64]
+	positive       optional[pmetric.ExponentialHistogramDataPointBuckets]
+	negative       optional[pmetric.ExponentialHistogramDataPointBuckets]
+	quantiles      optional[pmetric.SummaryDataPointValueAtQuantileSlice]
+}
+
+func numberRow(dp pmetric.NumberDataPoint) metricRow {
+	row := metricRow{
+		startTime:  dp.StartTimestamp(),
+		time:       dp.Timestamp(),
+		attributes: dp.Attributes(),
+		flags:      dp.Flags(),
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		row.valueDouble = some(dp.DoubleValue())
+	case pmetric.NumberDataPointValueTypeInt:
+		row.valueInt = some(dp.IntValue())
+	}
+	return row
+}
+
+func histogramRow(dp pmetric.HistogramDataPoint) metricRow {
+	row := metricRow{
+		startTime:      dp.StartTimestamp(),
+		time:           dp.Timestamp(),
+		attributes:     dp.Attributes(),
+		flags:          dp.Flags(),
+		count:          some(dp.Count()),
+		bucketCounts:   some(dp.BucketCounts()),
+		explicitBounds: some(dp.ExplicitBounds()),
+	}
+	if dp.HasSum() {
+		row.sum = some(dp.Sum())
+	}
+	if dp.HasMin() {
+		row.min = some(dp.Min())
+	}
+	if dp.HasMax() {
+		row.max = some(dp.Max())
+	}
+	return row
+}
+
+func exponentialHistogramRow(dp pmetric.ExponentialHistogramDataPoint) metricRow {
+	row := metricRow{
+		startTime:  dp.StartTimestamp(),
+		time:       dp.Timestamp(),
+		attributes: dp.Attributes(),
+		flags:      dp.Flags(),
+		count:      some(dp.Count()),
+		scale:      some(dp.Scale()),
+		zeroCount:  some(dp.ZeroCount()),
+		positive:   some(dp.Positive()),
+		negative:   some(dp.Negative()),
+	}
+	if dp.HasSum() {
+		row.sum = some(dp.Sum())
+	}
+	if dp.HasMin() {
+		row.min = some(dp.Min())
+	}
+	if dp.HasMax() {
+		row.max = some(dp.Max())
+	}
+	return row
+}
+
+func summaryRow(dp pmetric.SummaryDataPoint) metricRow {
+	return metricRow{
+		startTime:  dp.StartTimestamp(),
+		time:       dp.Timestamp(),
+		attributes: dp.Attributes(),
+		flags:      dp.Flags(),
+		count:      some(dp.Count()),
+		sum:        some(dp.Sum()),
+		quantiles:  some(dp.QuantileValues()),
+	}
+}
+
+func appendMetric(b *array.RecordBuilder, resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) {
+	var temporality optional[pmetric.AggregationTemporality]
+	var monotonic optional[bool]
+	var rows []metricRow
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, numberRow(dps.At(i)))
+		}
+	case pmetric.MetricTypeSum:
+		temporality = some(m.Sum().AggregationTemporality())
+		monotonic = some(m.Sum().IsMonotonic())
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, numberRow(dps.At(i)))
+		}
+	case pmetric.MetricTypeHistogram:
+		temporality = some(m.Histogram().AggregationTemporality())
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, histogramRow(dps.At(i)))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		temporality = some(m.ExponentialHistogram().AggregationTemporality())
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, exponentialHistogramRow(dps.At(i)))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, summaryRow(dps.At(i)))
+		}
+	}
+
+	for _, row := range rows {
+		appendCommon(b, resource, scope)
+		b.Field(colMetricName).(*array.StringBuilder).Append(m.Name())
+		b.Field(colMetricDescription).(*array.StringBuilder).Append(m.Description())
+		b.Field(colMetricUnit).(*array.StringBuilder).Append(m.Unit())
+		b.Field(colMetricType).(*array.StringBuilder).Append(m.Type().String())
+		if temporality.valid {
+			b.Field(colMetricTemporality).(*array.StringBuilder).Append(temporality.value.String())
+		} else {
+			b.Field(colMetricTemporality).AppendNull()
+		}
+		if monotonic.valid {
+			b.Field(colMetricMonotonic).(*array.BooleanBuilder).Append(monotonic.value)
+		} else {
+			b.Field(colMetricMonotonic).AppendNull()
+		}
+		appendMetricRow(b, row)
+	}
+}
+
+func appendMetricRow(b *array.RecordBuilder, row metricRow) {
+	appendTimestamp(b.Field(colMetricStartTime).(*array.TimestampBuilder), row.startTime)
+	appendTimestamp(b.Field(colMetricTime).(*array.TimestampBuilder), row.time)
+	appendAttributes(b.Field(colMetricAttributes).(*array.MapBuilder), row.attributes)
+	b.Field(colMetricFlags).(*array.Uint32Builder).Append(uint32(row.flags))
+	row.valueDouble.appendTo(b.Field(colMetricValueDouble).(*array.Float64Builder))
+	row.valueInt.appendTo(b.Field(colMetricValueInt).(*array.Int64Builder))
+	row.count.appendTo(b.Field(colMetricCount).(*array.Uint64Builder))
+	row.sum.appendTo(b.Field(colMetricSum).(*array.Float64Builder))
+	row.min.appendTo(b.Field(colMetricMin).(*array.Float64Builder))
+	row.max.appendTo(b.Field(colMetricMax).(*array.Float64Builder))
+	appendUInt64s(b.Field(colMetricBucketCounts).(*array.ListBuilder), row.bucketCounts)
+
+	bounds := b.Field(colMetricExplicitBounds).(*array.ListBuilder)
+	if row.explicitBounds.valid {
+		bounds.Append(true)
+		bounds.ValueBuilder().(*array.Float64Builder).AppendValues(row.explicitBounds.value.AsRaw(), nil)
+	} else {
+		bounds.AppendNull()
+	}
+
+	row.scale.appendTo(b.Field(colMetricScale).(*array.Int32Builder))
+	row.zeroCount.appendTo(b.Field(colMetricZeroCount).(*array.Uint64Builder))
+	appendBuckets(b.Field(colMetricPositiveOffset).(*array.Int32Builder), b.Field(colMetricPositiveBucketCounts).(*array.ListBuilder), row.positive)
+	appendBuckets(b.Field(colMetricNegativeOffset).(*array.Int32Builder), b.Field(colMetricNegativeBucketCounts).(*array.ListBuilder), row.negative)
+
+	quantiles := b.Field(colMetricQuantiles).(*array.ListBuilder)
+	if !row.quantiles.valid {
+		quantiles.AppendNull()
+		return
+	}
+	quantiles.Append(true)
+	qb := quantiles.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < row.quantiles.value.Len(); i++ {
+		q := row.quantiles.value.At(i)
+		qb.Append(true)
+		qb.FieldBuilder(0).(*array.Float64Builder).Append(q.Quantile())
+		qb.FieldBuilder(1).(*array.Float64Builder).Append(q.Value())
+	}
+}
+
+// newRecordBuilder returns a builder of records of the schema. Its builders
+// are reserved ahead, the Parquet writer failing on the columns whose data
+// buffers were never allocated, such as the values of lists which are always
+// empty.
+func newRecordBuilder(mem memory.Allocator, schema *arrow.Schema) *array.RecordBuilder {
+	b := array.NewRecordBuilder(mem, schema)
+	for _, field := range b.Fields() {
+		reserve(field)
+	}
+	return b
+}
+
+func reserve(b array.Builder) {
+	b.Reserve(1)
+	switch b := b.(type) {
+	case *array.MapBuilder:
+		reserve(b.KeyBuilder())
+		reserve(b.ItemBuilder())
+	case *array.ListBuilder:
+		reserve(b.ValueBuilder())
+	case *array.StructBuilder:
+		for i := 0; i < b.NumField(); i++ {
+			reserve(b.FieldBuilder(i))
+		}
+	}
+}
+
+// appendCommon appends the columns shared by all the signals.
+func appendCommon(b *array.RecordBuilder, resource pcommon.Resource, scope pcommon.InstrumentationScope) {
+	appendAttributes(b.Field(colResourceAttributes).(*array.MapBuilder), resource.Attributes())
+	if serviceName, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		b.Field(colServiceName).(*array.StringBuilder).Append(serviceName.AsString())
+	} else {
+		b.Field(colServiceName).AppendNull()
+	}
+	b.Field(colScopeName).(*array.StringBuilder).Append(scope.Name())
+	b.Field(colScopeVersion).(*array.StringBuilder).Append(scope.Version())
+}
+
+func appendAttributes(b *array.MapBuilder, attributes pcommon.Map) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	items := b.ItemBuilder().(*array.StringBuilder)
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		keys.Append(k)
+		items.Append(v.AsString())
+		return true
+	})
+}
+
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(ts))
+}
+
+// appendSpanID appends the span ID, or null if it is empty.
+func appendSpanID(b *array.StringBuilder, spanID pcommon.SpanID) {
+	if spanID.IsEmpty() {
+		b.AppendNull()
+		return
+	}
+	b.Append(spanID.String())
+}
+
+// appendTo appends the value to the builder of its column.
+func (o optional[T]) appendTo(b interface {
+	Append(T)
+	AppendNull()
+}) {
+	if o.valid {
+		b.Append(o.value)
+	} else {
+		b.AppendNull()
+	}
+}
+
+func appendUInt64s(b *array.ListBuilder, values optional[pcommon.UInt64Slice]) {
+	if !values.valid {
+		b.AppendNull()
+		return
+	}
+	b.Append(true)
+	b.ValueBuilder().(*array.Uint64Builder).AppendValues(values.value.AsRaw(), nil)
+}
+
+func appendBuckets(offset *array.Int32Builder, counts *array.ListBuilder, buckets optional[pmetric.ExponentialHistogramDataPointBuckets]) {
+	if !buckets.valid {
+		offset.AppendNull()
+		counts.AppendNull()
+		return
+	}
+	offset.Append(buckets.value.Offset())
+	appendUInt64s(counts, some(buckets.value.BucketCounts()))
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"go.uber.org/zap"
+)
+
+const (
+	// fileTimeFormat names the files after the time they were started at.
+	fileTimeFormat = "2006-01-02T15-04-05.000000000Z"
+	fileExtension  = ".parquet"
+	// pendingSuffix is appended to the name of the files being written, which
+	// are not valid Parquet files until they are completed.
+	pendingSuffix = ".tmp"
+)
+
+var compressionCodecs = map[string]compress.Compression{
+	compressionSnappy: compress.Codecs.Snappy,
+	compressionZstd:   compress.Codecs.Zstd,
+	compressionGzip:   compress.Codecs.Gzip,
+	compressionNone:   compress.Codecs.Uncompressed,
+}
+
+// fileWriter writes records to a Parquet file, completing it once it reaches
+// its maximum number of rows or age, and starting the next one on the next
+// write.
+type fileWriter struct {
+	dir              string
+	schema           *arrow.Schema
+	props            *parquet.WriterProperties
+	arrowProps       pqarrow.ArrowWriterProperties
+	maxRows          int64
+	rotationInterval time.Duration
+	logger           *zap.Logger
+	now              func() time.Time
+
+	mu   sync.Mutex
+	file *pendingFile
+}
+
+// pendingFile is a file being written.
+type pendingFile struct {
+	// path is the path of the file once completed.
+	path   string
+	writer *pqarrow.FileWriter
+	rows   int64
+	timer  *time.Timer
+}
+
+func newFileWriter(cfg *Config, dir string, schema *arrow.Schema, logger *zap.Logger) *fileWriter {
+	return &fileWriter{
+		dir:              dir,
+		schema:           schema,
+		props:            parquet.NewWriterProperties(parquet.WithCompression(compressionCodecs[cfg.Compression])),
+		arrowProps:       pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()),
+		maxRows:          int64(cfg.MaxRows),
+		rotationInterval: cfg.RotationInterval,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+func (w *fileWriter) start() error {
+	return os.MkdirAll(w.dir, 0700)
+}
+
+// write writes the record to the current file, starting one if needed.
+func (w *fileWriter) write(rec arrow.Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		file, err := w.open()
+		if err != nil {
+			return err
+		}
+		w.file = file
+	}
+	if err := w.file.writer.WriteBuffered(rec); err != nil {
+		// The rows already written are kept, the next write starting a new
+		// file.
+		return errors.Join(err, w.complete())
+	}
+	w.file.rows += rec.NumRows()
+	if w.file.rows >= w.maxRows {
+		return w.complete()
+	}
+	return nil
+}
+
+func (w *fileWriter) open() (*pendingFile, error) {
+	path := filepath.Join(w.dir, w.now().UTC().Format(fileTimeFormat)+fileExtension)
+	f, err := os.OpenFile(path+pendingSuffix, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := pqarrow.NewFileWriter(w.schema, f, w.props, w.arrowProps)
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.Join(err, os.Remove(path+pendingSuffix))
+	}
+	file := &pendingFile{path: path, writer: writer}
+	file.timer = time.AfterFunc(w.rotationInterval, func() { w.rotate(file) })
+	return file, nil
+}
+
+// rotate completes the file once it reached its maximum age, unless it was
+// completed already.
+func (w *fileWriter) rotate(file *pendingFile) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != file {
+		return
+	}
+	if err := w.complete(); err != nil {
+		w.logger.Error("Failed to complete the file", zap.String("path", file.path), zap.Error(err))
+	}
+}
+
+// complete writes the footer of the current file, and gives it its final name
+// so that it is picked by the readers.
+func (w *fileWriter) complete() error {
+	file := w.file
+	w.file = nil
+	file.timer.Stop()
+	if err := file.writer.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.path+pendingSuffix, file.path)
+}
+
+// close completes the current file.
+func (w *fileWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.complete()
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestWriter(t *testing.T, cfg *Config) *fileWriter {
+	w := newFileWriter(cfg, t.TempDir(), logsSchema, zap.NewNop())
+	now := testTime
+	w.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	require.NoError(t, w.start())
+	t.Cleanup(func() { assert.NoError(t, w.close()) })
+	return w
+}
+
+func writeLogs(t *testing.T, w *fileWriter, count int) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < count; i++ {
+		records.AppendEmpty()
+	}
+	rec := logsRecord(memory.DefaultAllocator, ld)
+	defer rec.Release()
+	require.NoError(t, w.write(rec))
+}
+
+func completedFiles(t *testing.T, w *fileWriter) []string {
+	paths, err := filepath.Glob(filepath.Join(w.dir, "*"+fileExtension))
+	require.NoError(t, err)
+	for i, path := range paths {
+		paths[i] = filepath.Base(path)
+	}
+	return paths
+}
+
+func TestFileWriterMaxRows(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRows = 3
+	w := newTestWriter(t, cfg)
+
+	writeLogs(t, w, 2)
+	assert.Empty(t, completedFiles(t, w))
+	// The file is completed once it reaches its maximum number of rows.
+	writeLogs(t, w, 2)
+	assert.Equal(t, []string{"2023-10-30T12-30-01.000000000Z.parquet"}, completedFiles(t, w))
+
+	writeLogs(t, w, 1)
+	require.NoError(t, w.close())
+	assert.Equal(t, []string{
+		"2023-10-30T12-30-01.000000000Z.parquet",
+		"2023-10-30T12-30-02.000000000Z.parquet",
+	}, completedFiles(t, w))
+	assert.Len(t, readRows(t, w.dir), 5)
+}
+
+func TestFileWriterRotationInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RotationInterval = 10 * time.Millisecond
+	w := newTestWriter(t, cfg)
+
+	writeLogs(t, w, 1)
+	// The file is completed once it reaches its maximum age, without waiting
+	// for the next write.
+	assert.Eventually(t, func() bool {
+		return len(completedFiles(t, w)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	writeLogs(t, w, 1)
+	require.NoError(t, w.close())
+	assert.Len(t, completedFiles(t, w), 2)
+	assert.Len(t, readRows(t, w.dir), 2)
+}
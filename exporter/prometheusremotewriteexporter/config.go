@@ -48,6 +48,10 @@ type Config struct {
 
 	// AddMetricSuffixes controls whether unit and type suffixes are added to metrics on export
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
+
+	// ProtocolVersion is the version of the remote write protocol, either "1.0" or "2.0".
+	// Remote Write 2.0 interns strings, and sends metadata and created timestamps with the series.
+	ProtocolVersion string `mapstructure:"protocol_version"`
 }
 
 type CreatedMetric struct {
@@ -93,6 +97,25 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	switch cfg.ProtocolVersion {
+	case "":
+		cfg.ProtocolVersion = remoteWriteVersion1
+	case remoteWriteVersion1:
+	case remoteWriteVersion2:
+		if cfg.WAL != nil {
+			return fmt.Errorf("wal is not supported with protocol version %s", remoteWriteVersion2)
+		}
+	default:
+		return fmt.Errorf("unsupported protocol version %q, must be %q or %q", cfg.ProtocolVersion, remoteWriteVersion1, remoteWriteVersion2)
+	}
+
+	if cfg.WAL != nil && cfg.WAL.MaxEntries < 0 {
+		return fmt.Errorf("wal max_entries can't be negative")
+	}
+	if cfg.WAL != nil && cfg.WAL.MaxRetryElapsedTime < 0 {
+		return fmt.Errorf("wal max_retry_elapsed_time can't be negative")
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
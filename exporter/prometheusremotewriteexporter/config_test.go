@@ -56,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 				},
 				AddMetricSuffixes: false,
+				ProtocolVersion:   "2.0",
 				Namespace:         "test-space",
 				ExternalLabels:    map[string]string{"key1": "value1", "key2": "value2"},
 				HTTPClientSettings: confighttp.HTTPClientSettings{
@@ -88,6 +89,22 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unsupported_protocol_version"),
+			errorMessage: `unsupported protocol version "1.1", must be "1.0" or "2.0"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "protocol_version_2_wal"),
+			errorMessage: "wal is not supported with protocol version 2.0",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_wal_max_entries"),
+			errorMessage: "wal max_entries can't be negative",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_wal_max_retry_elapsed_time"),
+			errorMessage: "wal max_retry_elapsed_time can't be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -16,7 +16,6 @@ import (
 	"sync"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
 	"go.opentelemetry.io/collector/component"
@@ -45,6 +44,12 @@ type prwExporter struct {
 	retrySettings     exporterhelper.RetrySettings
 	wal               *prweWAL
 	exporterSettings  prometheusremotewrite.Settings
+	remoteWriteV2     bool
+}
+
+// writeRequest is a remote write request, of either protocol version.
+type writeRequest interface {
+	Marshal() ([]byte, error)
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -60,6 +65,7 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 	}
 
 	userAgentHeader := fmt.Sprintf("%s/%s", strings.ReplaceAll(strings.ToLower(set.BuildInfo.Description), " ", "-"), set.BuildInfo.Version)
+	remoteWriteV2 := cfg.ProtocolVersion == remoteWriteVersion2
 
 	prwe := &prwExporter{
 		endpointURL:       endpointURL,
@@ -72,12 +78,15 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 		settings:          set.TelemetrySettings,
 		retrySettings:     cfg.RetrySettings,
 		exporterSettings: prometheusremotewrite.Settings{
-			Namespace:           cfg.Namespace,
-			ExternalLabels:      sanitizedLabels,
-			DisableTargetInfo:   !cfg.TargetInfo.Enabled,
-			ExportCreatedMetric: cfg.CreatedMetric.Enabled,
+			Namespace:         cfg.Namespace,
+			ExternalLabels:    sanitizedLabels,
+			DisableTargetInfo: !cfg.TargetInfo.Enabled,
+			// Remote Write 2.0 sends the _created series as the created timestamp of
+			// the other series of their family
+			ExportCreatedMetric: cfg.CreatedMetric.Enabled || remoteWriteV2,
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 		},
+		remoteWriteV2: remoteWriteV2,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -135,6 +144,10 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 			err = consumererror.NewPermanent(err)
 		}
 		// Call export even if a conversion error, since there may be points that were successfully converted.
+		if prwe.remoteWriteV2 {
+			metadata := prometheusremotewrite.OtelMetricsToMetadata(md, prwe.exporterSettings)
+			return multierr.Combine(err, prwe.handleExportV2(ctx, tsMap, metadata))
+		}
 		return multierr.Combine(err, prwe.handleExport(ctx, tsMap))
 	}
 }
@@ -169,15 +182,42 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 	// Otherwise the WAL is enabled, and just persist the requests to the WAL
 	// and they'll be exported in another goroutine to the RemoteWrite endpoint.
+	if err = prwe.wal.waitForSpace(ctx); err != nil {
+		return err
+	}
 	if err = prwe.wal.persistToWAL(requests); err != nil {
 		return consumererror.NewPermanent(err)
 	}
 	return nil
 }
 
+// handleExportV2 sends the time series using the Remote Write 2.0 protocol. The WAL
+// is not supported with this protocol.
+func (prwe *prwExporter) handleExportV2(ctx context.Context, tsMap map[string]*prompb.TimeSeries, metadata []*prompb.MetricMetadata) error {
+	// There are no metrics to export, so return.
+	if len(tsMap) == 0 {
+		return nil
+	}
+
+	requests := batchSeriesV2(prepareSeriesV2(tsMap, metadata), prwe.maxBatchSizeBytes)
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	return prwe.exportRequests(ctx, writeRequests)
+}
+
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	return prwe.exportRequests(ctx, writeRequests)
+}
+
+func (prwe *prwExporter) exportRequests(ctx context.Context, requests []writeRequest) error {
+	input := make(chan writeRequest, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -206,7 +246,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 					}
 					if errExecute := prwe.execute(ctx, request); errExecute != nil {
 						mu.Lock()
-						errs = multierr.Append(errs, consumererror.NewPermanent(errExecute))
+						errs = multierr.Append(errs, errExecute)
 						mu.Unlock()
 					}
 				}
@@ -218,11 +258,16 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 	return errs
 }
 
-func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+func (prwe *prwExporter) execute(ctx context.Context, writeReq writeRequest) error {
+	contentType, version := "application/x-protobuf", "0.1.0"
+	if _, ok := writeReq.(*writeV2Request); ok {
+		contentType, version = remoteWriteV2ContentType, "2.0.0"
+	}
+
 	// executeFunc can be used for backoff and non backoff scenarios.
 	executeFunc := func() error {
-		// Uses proto.Marshal to convert the WriteRequest into bytes array
-		data, err := proto.Marshal(writeReq)
+		// Converts the WriteRequest into bytes array
+		data, err := writeReq.Marshal()
 		if err != nil {
 			return backoff.Permanent(consumererror.NewPermanent(err))
 		}
@@ -238,8 +283,8 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", version)
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -280,10 +325,8 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		err = executeFunc()
 	}
 
-	if err != nil {
-		return consumererror.NewPermanent(err)
-	}
-
+	// The 4xx responses are permanent errors, while the other errors are kept
+	// retryable, so that the WAL retries them.
 	return err
 }
 
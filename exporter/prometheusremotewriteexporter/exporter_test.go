@@ -1063,3 +1063,24 @@ func TestNoRetryOn4xx(t *testing.T) {
 	assert.True(t, consumererror.IsPermanent(err))
 	assert.Equal(t, 1, attempts)
 }
+
+func TestRetryableErrorOn5xx(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+
+	endpointURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	exporter := &prwExporter{
+		endpointURL: endpointURL,
+		client:      http.DefaultClient,
+		concurrency: 1,
+	}
+
+	// The 5xx responses are not permanent, so that the WAL retries the requests.
+	err = exporter.export(context.Background(), []*prompb.WriteRequest{{}})
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
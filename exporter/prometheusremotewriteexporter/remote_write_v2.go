@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	remoteWriteVersion1 = "1.0"
+	remoteWriteVersion2 = "2.0"
+
+	remoteWriteV2ContentType = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+	createdSuffix            = "_created"
+)
+
+// familySuffixes are the suffixes of the series of histogram and summary families,
+// in addition to createdSuffix.
+var familySuffixes = []string{"_bucket", "_sum", "_count"}
+
+// writeV2Request is an io.prometheus.write.v2.Request. Label names and values, help
+// and units are interned in Symbols, and referenced by their index in the time series.
+//
+// The protobuf definition is not part of the vendored Prometheus version, the
+// request is marshaled by hand. Samples and histograms have the same wire format as
+// in Remote Write 1.0, and are marshaled with prompb.
+type writeV2Request struct {
+	Symbols    []string
+	Timeseries []writeV2TimeSeries
+
+	symbolRefs map[string]uint32
+}
+
+type writeV2TimeSeries struct {
+	LabelsRefs       []uint32
+	Samples          []prompb.Sample
+	Histograms       []prompb.Histogram
+	Exemplars        []writeV2Exemplar
+	Metadata         writeV2Metadata
+	CreatedTimestamp int64
+}
+
+type writeV2Exemplar struct {
+	LabelsRefs []uint32
+	Value      float64
+	Timestamp  int64
+}
+
+type writeV2Metadata struct {
+	Type    prompb.MetricMetadata_MetricType
+	HelpRef uint32
+	UnitRef uint32
+}
+
+func newWriteV2Request() *writeV2Request {
+	// The first symbol must be the empty string
+	return &writeV2Request{
+		Symbols:    []string{""},
+		symbolRefs: map[string]uint32{"": 0},
+	}
+}
+
+// symbolRef returns the reference of s, and interns it if needed.
+func (r *writeV2Request) symbolRef(s string) uint32 {
+	if ref, ok := r.symbolRefs[s]; ok {
+		return ref
+	}
+	ref := uint32(len(r.Symbols))
+	r.Symbols = append(r.Symbols, s)
+	r.symbolRefs[s] = ref
+	return ref
+}
+
+func (r *writeV2Request) labelsRefs(labels []prompb.Label) []uint32 {
+	refs := make([]uint32, 0, 2*len(labels))
+	for _, l := range labels {
+		refs = append(refs, r.symbolRef(l.Name), r.symbolRef(l.Value))
+	}
+	return refs
+}
+
+// addSeries converts s and adds it to the request.
+func (r *writeV2Request) addSeries(s seriesV2) {
+	ts := writeV2TimeSeries{
+		LabelsRefs:       r.labelsRefs(s.Labels),
+		Samples:          s.Samples,
+		Histograms:       s.Histograms,
+		CreatedTimestamp: s.createdTimestamp,
+	}
+	for _, e := range s.Exemplars {
+		ts.Exemplars = append(ts.Exemplars, writeV2Exemplar{
+			LabelsRefs: r.labelsRefs(e.Labels),
+			Value:      e.Value,
+			Timestamp:  e.Timestamp,
+		})
+	}
+	if s.metadata != nil {
+		ts.Metadata = writeV2Metadata{
+			Type:    s.metadata.Type,
+			HelpRef: r.symbolRef(s.metadata.Help),
+			UnitRef: r.symbolRef(s.metadata.Unit),
+		}
+	}
+	r.Timeseries = append(r.Timeseries, ts)
+}
+
+// Marshal encodes the request in the protobuf wire format.
+func (r *writeV2Request) Marshal() ([]byte, error) {
+	var b []byte
+	for _, s := range r.Symbols {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, s)
+	}
+	for i := range r.Timeseries {
+		ts, err := r.Timeseries[i].marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, ts)
+	}
+	return b, nil
+}
+
+func (ts *writeV2TimeSeries) marshal() ([]byte, error) {
+	b := appendPackedRefs(nil, 1, ts.LabelsRefs)
+	for i := range ts.Samples {
+		sample, err := ts.Samples[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, sample)
+	}
+	for i := range ts.Histograms {
+		histogram, err := ts.Histograms[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, histogram)
+	}
+	for _, e := range ts.Exemplars {
+		exemplar := appendPackedRefs(nil, 1, e.LabelsRefs)
+		if e.Value != 0 {
+			exemplar = protowire.AppendTag(exemplar, 2, protowire.Fixed64Type)
+			exemplar = protowire.AppendFixed64(exemplar, math.Float64bits(e.Value))
+		}
+		exemplar = appendVarintField(exemplar, 3, uint64(e.Timestamp))
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, exemplar)
+	}
+	metadata := appendVarintField(nil, 1, uint64(ts.Metadata.Type))
+	metadata = appendVarintField(metadata, 3, uint64(ts.Metadata.HelpRef))
+	metadata = appendVarintField(metadata, 4, uint64(ts.Metadata.UnitRef))
+	b = protowire.AppendTag(b, 5, protowire.BytesType)
+	b = protowire.AppendBytes(b, metadata)
+	b = appendVarintField(b, 6, uint64(ts.CreatedTimestamp))
+	return b, nil
+}
+
+// appendVarintField appends a varint field, unless v is the default value.
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendPackedRefs(b []byte, num protowire.Number, refs []uint32) []byte {
+	if len(refs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, ref := range refs {
+		packed = protowire.AppendVarint(packed, uint64(ref))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+// seriesV2 is a time series along with the fields Remote Write 2.0 sends with it.
+type seriesV2 struct {
+	*prompb.TimeSeries
+	metadata         *prompb.MetricMetadata
+	createdTimestamp int64
+}
+
+// prepareSeriesV2 attaches the metadata of their family to the series of tsMap. The
+// _created series of counters, histograms and summaries are removed, and their value
+// is set as the created timestamp of the other series of their family.
+func prepareSeriesV2(tsMap map[string]*prompb.TimeSeries, metadata []*prompb.MetricMetadata) []seriesV2 {
+	families := make(map[string]*prompb.MetricMetadata, len(metadata))
+	for _, m := range metadata {
+		families[m.MetricFamilyName] = m
+	}
+
+	// Series are sorted so that requests are reproducible
+	sigs := make([]string, 0, len(tsMap))
+	for sig := range tsMap {
+		sigs = append(sigs, sig)
+	}
+	sort.Strings(sigs)
+
+	createdTimestamps := make(map[string]int64)
+	series := make([]seriesV2, 0, len(tsMap))
+	for _, sig := range sigs {
+		ts := tsMap[sig]
+		name := seriesName(ts.Labels)
+		if _, ok := families[name]; !ok && strings.HasSuffix(name, createdSuffix) && len(ts.Samples) > 0 {
+			family := strings.TrimSuffix(name, createdSuffix)
+			if m, ok := families[family]; ok && m.Type != prompb.MetricMetadata_GAUGE {
+				createdTimestamps[familySignature(family, ts.Labels)] = int64(ts.Samples[0].Value)
+				continue
+			}
+		}
+		series = append(series, seriesV2{TimeSeries: ts})
+	}
+
+	for i := range series {
+		family, m := lookupFamily(families, seriesName(series[i].Labels))
+		if m == nil {
+			continue
+		}
+		series[i].metadata = m
+		series[i].createdTimestamp = createdTimestamps[familySignature(family, series[i].Labels)]
+	}
+	return series
+}
+
+// lookupFamily returns the family of the series called name.
+func lookupFamily(families map[string]*prompb.MetricMetadata, name string) (string, *prompb.MetricMetadata) {
+	if m, ok := families[name]; ok {
+		return name, m
+	}
+	for _, suffix := range familySuffixes {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		family := strings.TrimSuffix(name, suffix)
+		if m, ok := families[family]; ok && (m.Type == prompb.MetricMetadata_HISTOGRAM || m.Type == prompb.MetricMetadata_SUMMARY) {
+			return family, m
+		}
+	}
+	return "", nil
+}
+
+// familySignature identifies the series of a family sharing the same attributes.
+// The labels are sorted by name.
+func familySignature(family string, labels []prompb.Label) string {
+	b := strings.Builder{}
+	b.WriteString(family)
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.BucketLabel, model.QuantileLabel:
+			continue
+		}
+		b.WriteString("-")
+		b.WriteString(l.Name)
+		b.WriteString("-")
+		b.WriteString(l.Value)
+	}
+	return b.String()
+}
+
+func seriesName(labels []prompb.Label) string {
+	for _, l := range labels {
+		if l.Name == model.MetricNameLabel {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// batchSeriesV2 splits series into multiple Remote Write 2.0 requests. The size of
+// the series in Remote Write 1.0 is used as an upper bound of their interned size.
+func batchSeriesV2(series []seriesV2, maxBatchByteSize int) []*writeV2Request {
+	var requests []*writeV2Request
+	request := newWriteV2Request()
+	sizeOfCurrentBatch := 0
+
+	for _, s := range series {
+		sizeOfSeries := s.Size()
+		if sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize && len(request.Timeseries) > 0 {
+			requests = append(requests, request)
+			request = newWriteV2Request()
+			sizeOfCurrentBatch = 0
+		}
+
+		// Prometheus requires time series to be sorted by Timestamp to avoid out of order problems.
+		sort.Slice(s.Samples, func(i, j int) bool {
+			return s.Samples[i].Timestamp < s.Samples[j].Timestamp
+		})
+		request.addSeries(s)
+		sizeOfCurrentBatch += sizeOfSeries
+	}
+
+	if len(request.Timeseries) > 0 {
+		requests = append(requests, request)
+	}
+	return requests
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteV2Request decodes a request marshaled by writeV2Request.Marshal.
+func decodeWriteV2Request(t *testing.T, b []byte) *writeV2Request {
+	r := &writeV2Request{}
+	forEachField(t, b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch num {
+		case 4:
+			r.Symbols = append(r.Symbols, string(v))
+		case 5:
+			r.Timeseries = append(r.Timeseries, decodeWriteV2TimeSeries(t, v))
+		default:
+			t.Fatalf("unexpected request field %d", num)
+		}
+	})
+	return r
+}
+
+func decodeWriteV2TimeSeries(t *testing.T, b []byte) writeV2TimeSeries {
+	var ts writeV2TimeSeries
+	forEachField(t, b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch num {
+		case 1:
+			ts.LabelsRefs = decodePackedRefs(t, v)
+		case 2:
+			var s prompb.Sample
+			require.NoError(t, s.Unmarshal(v))
+			ts.Samples = append(ts.Samples, s)
+		case 3:
+			var h prompb.Histogram
+			require.NoError(t, h.Unmarshal(v))
+			ts.Histograms = append(ts.Histograms, h)
+		case 4:
+			var e writeV2Exemplar
+			forEachField(t, v, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+				switch num {
+				case 1:
+					e.LabelsRefs = decodePackedRefs(t, v)
+				case 2:
+					e.Value = math.Float64frombits(x)
+				case 3:
+					e.Timestamp = int64(x)
+				}
+			})
+			ts.Exemplars = append(ts.Exemplars, e)
+		case 5:
+			forEachField(t, v, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+				switch num {
+				case 1:
+					ts.Metadata.Type = prompb.MetricMetadata_MetricType(x)
+				case 3:
+					ts.Metadata.HelpRef = uint32(x)
+				case 4:
+					ts.Metadata.UnitRef = uint32(x)
+				}
+			})
+		case 6:
+			ts.CreatedTimestamp = int64(x)
+		default:
+			t.Fatalf("unexpected time series field %d", num)
+		}
+	})
+	return ts
+}
+
+func decodePackedRefs(t *testing.T, b []byte) []uint32 {
+	var refs []uint32
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		require.GreaterOrEqual(t, n, 0)
+		refs = append(refs, uint32(v))
+		b = b[n:]
+	}
+	return refs
+}
+
+func forEachField(t *testing.T, b []byte, f func(num protowire.Number, typ protowire.Type, v []byte, x uint64)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, n, 0)
+			f(num, typ, v, 0)
+			b = b[n:]
+		case protowire.VarintType:
+			x, n := protowire.ConsumeVarint(b)
+			require.GreaterOrEqual(t, n, 0)
+			f(num, typ, nil, x)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			x, n := protowire.ConsumeFixed64(b)
+			require.GreaterOrEqual(t, n, 0)
+			f(num, typ, nil, x)
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+}
+
+// labelsOf resolves the label references of a time series.
+func labelsOf(r *writeV2Request, refs []uint32) map[string]string {
+	labels := make(map[string]string, len(refs)/2)
+	for i := 0; i+1 < len(refs); i += 2 {
+		labels[r.Symbols[refs[i]]] = r.Symbols[refs[i+1]]
+	}
+	return labels
+}
+
+func TestWriteV2RequestMarshal(t *testing.T) {
+	request := newWriteV2Request()
+	request.addSeries(seriesV2{
+		TimeSeries: &prompb.TimeSeries{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total"}, {Name: "job", Value: "api"}},
+			Samples: []prompb.Sample{{Value: 3, Timestamp: 2000}, {Value: 5, Timestamp: 3000}},
+			Exemplars: []prompb.Exemplar{{
+				Labels:    []prompb.Label{{Name: "trace_id", Value: "0102"}},
+				Value:     1,
+				Timestamp: 2500,
+			}},
+		},
+		metadata:         &prompb.MetricMetadata{Type: prompb.MetricMetadata_COUNTER, Help: "Requests", Unit: "{requests}"},
+		createdTimestamp: 1000,
+	})
+	request.addSeries(seriesV2{
+		TimeSeries: &prompb.TimeSeries{
+			Labels: []prompb.Label{{Name: "__name__", Value: "latency"}, {Name: "job", Value: "api"}},
+			Histograms: []prompb.Histogram{{
+				Count:          &prompb.Histogram_CountInt{CountInt: 3},
+				Sum:            4.5,
+				Schema:         2,
+				ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: -1, Length: 2}},
+				PositiveDeltas: []int64{1, 0},
+				Timestamp:      3000,
+			}},
+		},
+	})
+
+	// Label names and values are only interned once
+	assert.Equal(t, []string{"", "__name__", "requests_total", "job", "api", "trace_id", "0102", "Requests", "{requests}", "latency"}, request.Symbols)
+
+	data, err := request.Marshal()
+	require.NoError(t, err)
+	decoded := decodeWriteV2Request(t, data)
+	decoded.symbolRefs = request.symbolRefs
+	assert.Equal(t, request, decoded)
+}
+
+func TestPrepareSeriesV2(t *testing.T) {
+	series := func(name string, value float64, extra ...string) *prompb.TimeSeries {
+		labels := []prompb.Label{{Name: "__name__", Value: name}, {Name: "job", Value: "api"}}
+		for i := 0; i+1 < len(extra); i += 2 {
+			labels = append(labels, prompb.Label{Name: extra[i], Value: extra[i+1]})
+		}
+		return &prompb.TimeSeries{Labels: labels, Samples: []prompb.Sample{{Value: value, Timestamp: 2000}}}
+	}
+	tsMap := map[string]*prompb.TimeSeries{
+		"a": series("requests_total", 3),
+		"b": series("requests_total_created", 1000),
+		"c": series("latency_bucket", 1, "le", "+Inf"),
+		"d": series("latency_count", 1),
+		"e": series("latency_created", 1500),
+		"f": series("temperature", 20),
+		"g": series("unknown", 1),
+		// Gauges have no created timestamp, this one is a family on its own
+		"h": series("items_created", 4),
+	}
+	counter := &prompb.MetricMetadata{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total"}
+	histogram := &prompb.MetricMetadata{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency"}
+	gauge := &prompb.MetricMetadata{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "temperature"}
+	itemsCreated := &prompb.MetricMetadata{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "items_created"}
+
+	got := prepareSeriesV2(tsMap, []*prompb.MetricMetadata{counter, histogram, gauge, itemsCreated})
+
+	assert.Equal(t, []seriesV2{
+		{TimeSeries: tsMap["a"], metadata: counter, createdTimestamp: 1000},
+		{TimeSeries: tsMap["c"], metadata: histogram, createdTimestamp: 1500},
+		{TimeSeries: tsMap["d"], metadata: histogram, createdTimestamp: 1500},
+		{TimeSeries: tsMap["f"], metadata: gauge},
+		{TimeSeries: tsMap["g"]},
+		{TimeSeries: tsMap["h"], metadata: itemsCreated},
+	}, got)
+}
+
+func TestBatchSeriesV2(t *testing.T) {
+	var series []seriesV2
+	for i := 0; i < 10; i++ {
+		series = append(series, seriesV2{TimeSeries: &prompb.TimeSeries{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "metric"}, {Name: "index", Value: string(rune('0' + i))}},
+			Samples: []prompb.Sample{{Value: 2, Timestamp: 2}, {Value: 1, Timestamp: 1}},
+		}})
+	}
+
+	// Batches are closed before reaching the maximum size
+	requests := batchSeriesV2(series, 4*series[0].Size())
+	require.Len(t, requests, 4)
+	for _, r := range requests {
+		// Each request has its own symbols
+		assert.Equal(t, "", r.Symbols[0])
+		assert.Equal(t, "metric", r.Symbols[2])
+		for _, ts := range r.Timeseries {
+			assert.Equal(t, []prompb.Sample{{Value: 1, Timestamp: 1}, {Value: 2, Timestamp: 2}}, ts.Samples)
+		}
+	}
+	assert.Len(t, requests[0].Timeseries, 3)
+	assert.Len(t, requests[3].Timeseries, 1)
+	assert.Equal(t, map[string]string{"__name__": "metric", "index": "9"}, labelsOf(requests[3], requests[3].Timeseries[0].LabelsRefs))
+
+	assert.Len(t, batchSeriesV2(series, math.MaxInt), 1)
+}
+
+func TestPushMetricsV2(t *testing.T) {
+	var received []*writeV2Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2.0.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		assert.Equal(t, remoteWriteV2ContentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		data, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		received = append(received, decodeWriteV2Request(t, data))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.RemoteWriteQueue.NumConsumers = 1
+	cfg.ProtocolVersion = remoteWriteVersion2
+	cfg.AddMetricSuffixes = false
+	require.NoError(t, cfg.Validate())
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, prwe.Shutdown(context.Background())) }()
+
+	start := pcommon.NewTimestampFromTime(time.UnixMilli(1000))
+	now := pcommon.NewTimestampFromTime(time.UnixMilli(2000))
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	m := metrics.AppendEmpty()
+	m.SetName("requests")
+	m.SetDescription("Number of requests")
+	m.SetUnit("1")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(now)
+	dp.SetIntValue(42)
+
+	m = metrics.AppendEmpty()
+	m.SetName("size")
+	eh := m.SetEmptyExponentialHistogram()
+	eh.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	ehdp := eh.DataPoints().AppendEmpty()
+	ehdp.SetTimestamp(now)
+	ehdp.SetScale(1)
+	ehdp.SetCount(2)
+	ehdp.SetSum(3)
+	ehdp.Positive().SetOffset(1)
+	ehdp.Positive().BucketCounts().FromRaw([]uint64{2})
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	require.Len(t, received, 1)
+	request := received[0]
+	require.Len(t, request.Timeseries, 2)
+
+	byName := make(map[string]writeV2TimeSeries)
+	for _, ts := range request.Timeseries {
+		byName[labelsOf(request, ts.LabelsRefs)["__name__"]] = ts
+	}
+
+	counter := byName["requests"]
+	assert.Equal(t, []prompb.Sample{{Value: 42, Timestamp: 2000}}, counter.Samples)
+	assert.Equal(t, int64(1000), counter.CreatedTimestamp)
+	assert.Equal(t, prompb.MetricMetadata_COUNTER, counter.Metadata.Type)
+	assert.Equal(t, "Number of requests", request.Symbols[counter.Metadata.HelpRef])
+	assert.Equal(t, "1", request.Symbols[counter.Metadata.UnitRef])
+
+	histogram := byName["size"]
+	assert.Equal(t, prompb.MetricMetadata_HISTOGRAM, histogram.Metadata.Type)
+	require.Len(t, histogram.Histograms, 1)
+	assert.Equal(t, int32(1), histogram.Histograms[0].Schema)
+	assert.Equal(t, 3.0, histogram.Histograms[0].Sum)
+}
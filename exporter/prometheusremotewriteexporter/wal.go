@@ -7,15 +7,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/fsnotify/fsnotify"
 	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/tidwall/wal"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
@@ -32,17 +37,32 @@ type prweWAL struct {
 	stopChan  chan struct{}
 	rWALIndex *atomic.Uint64
 	wWALIndex *atomic.Uint64
+
+	// exportedIndex is the index of the last request exported, or dropped. It
+	// is protected by mu.
+	exportedIndex uint64
+	// exportedChan is closed, and replaced, when requests are removed from the
+	// WAL, to wake up the exports waiting for room. It is protected by mu.
+	exportedChan chan struct{}
 }
 
 const (
-	defaultWALBufferSize        = 300
-	defaultWALTruncateFrequency = 1 * time.Minute
+	defaultWALBufferSize          = 300
+	defaultWALTruncateFrequency   = 1 * time.Minute
+	defaultWALMaxRetryElapsedTime = 1 * time.Hour
 )
 
 type WALConfig struct {
 	Directory         string        `mapstructure:"directory"`
 	BufferSize        int           `mapstructure:"buffer_size"`
 	TruncateFrequency time.Duration `mapstructure:"truncate_frequency"`
+	// MaxEntries is the maximum number of requests in the WAL waiting to be
+	// exported. When it is reached, the new data waits for requests to be
+	// exported, so that the disk does not fill up. 0 means no limit.
+	MaxEntries int `mapstructure:"max_entries"`
+	// MaxRetryElapsedTime is the maximum time spent retrying to export the
+	// requests read from the WAL, after which they are dropped.
+	MaxRetryElapsedTime time.Duration `mapstructure:"max_retry_elapsed_time"`
 }
 
 func (wc *WALConfig) bufferSize() int {
@@ -59,6 +79,13 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
+func (wc *WALConfig) maxRetryElapsedTime() time.Duration {
+	if wc.MaxRetryElapsedTime > 0 {
+		return wc.MaxRetryElapsedTime
+	}
+	return defaultWALMaxRetryElapsedTime
+}
+
 func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
@@ -67,11 +94,12 @@ func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.Wri
 	}
 
 	return &prweWAL{
-		exportSink: exportSink,
-		walConfig:  walConfig,
-		stopChan:   make(chan struct{}),
-		rWALIndex:  &atomic.Uint64{},
-		wWALIndex:  &atomic.Uint64{},
+		exportSink:   exportSink,
+		walConfig:    walConfig,
+		stopChan:     make(chan struct{}),
+		exportedChan: make(chan struct{}),
+		rWALIndex:    &atomic.Uint64{},
+		wWALIndex:    &atomic.Uint64{},
 	}, nil
 }
 
@@ -87,13 +115,42 @@ func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
 	return log, walPath, nil
 }
 
+// checkpointPath returns the path of the file storing the index of the last
+// request exported, which is not removed from the WAL when it is the last one.
+func (wc *WALConfig) checkpointPath() string {
+	return filepath.Join(wc.Directory, "prom_remotewrite.checkpoint")
+}
+
+// readCheckpoint returns the index of the last request exported, or 0 if none
+// was exported yet.
+func readCheckpoint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func writeCheckpoint(path string, index uint64) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strconv.FormatUint(index, 10)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 var (
 	errAlreadyClosed = errors.New("already closed")
+	errWALFull       = errors.New("prometheusremotewriteexporter: WAL is full")
 	errNilWAL        = errors.New("wal is nil")
 	errNilConfig     = errors.New("expecting a non-nil configuration")
 )
 
-// retrieveWALIndices queries the WriteAheadLog for its current first and last indices.
+// retrieveWALIndices queries the WriteAheadLog for its current first and last indices,
+// and moves the read index to the first request not exported yet.
 func (prwe *prweWAL) retrieveWALIndices() (err error) {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
@@ -111,17 +168,31 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 	prwe.wal = log
 	prwe.walPath = walPath
 
-	rIndex, err := prwe.wal.FirstIndex()
+	firstIndex, err := prwe.wal.FirstIndex()
 	if err != nil {
 		return fmt.Errorf("prometheusremotewriteexporter: failed to retrieve the first WAL index: %w", err)
 	}
-	prwe.rWALIndex.Store(rIndex)
 
 	wIndex, err := prwe.wal.LastIndex()
 	if err != nil {
 		return fmt.Errorf("prometheusremotewriteexporter: failed to retrieve the last WAL index: %w", err)
 	}
 	prwe.wWALIndex.Store(wIndex)
+
+	exportedIndex, err := readCheckpoint(prwe.walConfig.checkpointPath())
+	if err != nil {
+		return fmt.Errorf("prometheusremotewriteexporter: failed to read the WAL checkpoint: %w", err)
+	}
+	// The requests before the first one of the WAL were exported, and the
+	// checkpoint is obsolete if the WAL was removed.
+	if firstIndex > 0 && exportedIndex < firstIndex-1 {
+		exportedIndex = firstIndex - 1
+	}
+	if exportedIndex > wIndex {
+		exportedIndex = wIndex
+	}
+	prwe.exportedIndex = exportedIndex
+	prwe.rWALIndex.Store(exportedIndex + 1)
 	return nil
 }
 
@@ -152,6 +223,10 @@ func (prwe *prweWAL) run(ctx context.Context) (err error) {
 
 	runCtx, cancel := context.WithCancel(ctx)
 
+	retryBackoff := backoff.NewExponentialBackOff()
+	retryBackoff.MaxElapsedTime = prwe.walConfig.maxRetryElapsedTime()
+	lastExportedIndex := prwe.exportedIndex
+
 	// Start the process of exporting but wait until the exporting has started.
 	waitUntilStartedCh := make(chan bool)
 	go func() {
@@ -167,8 +242,32 @@ func (prwe *prweWAL) run(ctx context.Context) (err error) {
 				err := prwe.continuallyPopWALThenExport(runCtx, signalStart)
 				signalStart = func() {}
 				if err != nil {
-					// log err
-					logger.Error("error processing WAL entries", zap.Error(err))
+					switch {
+					case errors.Is(err, wal.ErrNotFound):
+						logger.Error("error processing WAL entries", zap.Error(err))
+					case isPermanentExportError(err):
+						// The requests would fail again, so they are dropped
+						// instead of blocking the ones written after them.
+						logger.Error("dropping WAL entries which can't be exported", zap.Error(err), zap.Uint64("dropped_requests", prwe.pendingReads()))
+						if errS := prwe.syncAndTruncateFront(); errS != nil {
+							logger.Error("unable to drop WAL entries", zap.Error(errS))
+						}
+					default:
+						logger.Error("error processing WAL entries", zap.Error(err))
+						// Wait before reading again the requests not exported, so
+						// that an outage of the endpoint is not hammered.
+						retry, ok := prwe.waitBeforeRetry(runCtx, retryBackoff, &lastExportedIndex)
+						if !ok {
+							return
+						}
+						if !retry {
+							logger.Error("dropping WAL entries which failed to be exported for too long", zap.Duration("max_retry_elapsed_time", retryBackoff.MaxElapsedTime), zap.Uint64("dropped_requests", prwe.pendingReads()))
+							if errS := prwe.syncAndTruncateFront(); errS != nil {
+								logger.Error("unable to drop WAL entries", zap.Error(errS))
+							}
+							retryBackoff.Reset()
+						}
+					}
 					// Restart WAL
 					if errS := prwe.retrieveWALIndices(); errS != nil {
 						logger.Error("unable to re-start write-ahead log after error", zap.Error(errS))
@@ -182,20 +281,62 @@ func (prwe *prweWAL) run(ctx context.Context) (err error) {
 	return nil
 }
 
+// isPermanentExportError returns whether all the errors of an export are
+// permanent, such as the 4xx responses of the endpoint.
+func isPermanentExportError(err error) bool {
+	for _, e := range multierr.Errors(err) {
+		if !consumererror.IsPermanent(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// pendingReads returns the number of requests read from the WAL since the last
+// request exported.
+func (prwe *prweWAL) pendingReads() uint64 {
+	prwe.mu.Lock()
+	defer prwe.mu.Unlock()
+	return prwe.rWALIndex.Load() - 1 - prwe.exportedIndex
+}
+
+// waitBeforeRetry waits for the next backoff interval, which is reset if
+// requests were exported since the previous failure. It returns false as
+// retry if the requests failed for longer than the maximum elapsed time of the
+// backoff, and false as ok if the WAL was stopped in the meantime.
+func (prwe *prweWAL) waitBeforeRetry(ctx context.Context, retryBackoff backoff.BackOff, lastExportedIndex *uint64) (retry bool, ok bool) {
+	prwe.mu.Lock()
+	exportedIndex := prwe.exportedIndex
+	prwe.mu.Unlock()
+	if exportedIndex != *lastExportedIndex {
+		retryBackoff.Reset()
+		*lastExportedIndex = exportedIndex
+	}
+
+	interval := retryBackoff.NextBackOff()
+	if interval == backoff.Stop {
+		return false, true
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, true
+	case <-ctx.Done():
+		return false, false
+	case <-prwe.stopChan:
+		return false, false
+	}
+}
+
 // continuallyPopWALThenExport reads a prompb.WriteRequest proto encoded blob from the WAL, and moves
 // the WAL's front index forward until either the read buffer period expires or the maximum
 // buffer size is exceeded. When either of the two conditions are matched, it then exports
 // the requests to the Remote-Write endpoint, and then truncates the head of the WAL to where
-// it last read from.
+// it last read from. The requests read but not exported yet when it returns are
+// read again from the WAL by the next run, or after a restart.
 func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
 	var reqL []*prompb.WriteRequest
-	defer func() {
-		// Keeping it within a closure to ensure that the later
-		// updated value of reqL is always flushed to disk.
-		if errL := prwe.exportSink(ctx, reqL); errL != nil {
-			err = multierr.Append(err, errL)
-		}
-	}()
 
 	freshTimer := func() *time.Timer {
 		return time.NewTimer(prwe.walConfig.truncateFrequency())
@@ -222,17 +363,24 @@ func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStar
 
 		var req *prompb.WriteRequest
 		req, err = prwe.readPrompbFromWAL(ctx, prwe.rWALIndex.Load())
-		if err != nil {
+		idle := errors.Is(err, wal.ErrNotFound)
+		switch {
+		case idle:
+			// Nothing was written for a while, so the requests already read
+			// are exported without waiting for more.
+			err = nil
+		case err != nil:
 			return err
+		default:
+			reqL = append(reqL, req)
 		}
-		reqL = append(reqL, req)
 
 		var shouldExport bool
 		select {
 		case <-timer.C:
 			shouldExport = true
 		default:
-			shouldExport = len(reqL) >= maxCountPerUpload
+			shouldExport = len(reqL) >= maxCountPerUpload || (idle && len(reqL) > 0)
 		}
 
 		if !shouldExport {
@@ -273,10 +421,30 @@ func (prwe *prweWAL) syncAndTruncateFront() error {
 		return err
 	}
 	// Truncate the WAL from the front for the entries that we already
-	// read from the WAL and had already exported.
-	if err := prwe.wal.TruncateFront(prwe.rWALIndex.Load()); err != nil && !errors.Is(err, wal.ErrOutOfRange) {
+	// read from the WAL and had already exported. The WAL can't be emptied,
+	// so the last entry is kept when all of them were exported, and the
+	// checkpoint tells that it was.
+	exportedIndex := prwe.rWALIndex.Load() - 1
+	lastIndex, err := prwe.wal.LastIndex()
+	if err != nil {
 		return err
 	}
+	truncateIndex := exportedIndex + 1
+	if truncateIndex > lastIndex {
+		truncateIndex = lastIndex
+	}
+	if truncateIndex > 0 {
+		if err = prwe.wal.TruncateFront(truncateIndex); err != nil && !errors.Is(err, wal.ErrOutOfRange) {
+			return err
+		}
+	}
+	if err = writeCheckpoint(prwe.walConfig.checkpointPath(), exportedIndex); err != nil {
+		return fmt.Errorf("prometheusremotewriteexporter: failed to write the WAL checkpoint: %w", err)
+	}
+
+	prwe.exportedIndex = exportedIndex
+	close(prwe.exportedChan)
+	prwe.exportedChan = make(chan struct{})
 	return nil
 }
 
@@ -291,11 +459,34 @@ func (prwe *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*pro
 	if errL := prwe.exportSink(ctx, reqL); errL != nil {
 		return errL
 	}
-	if err := prwe.syncAndTruncateFront(); err != nil {
-		return err
+	return prwe.syncAndTruncateFront()
+}
+
+// waitForSpace waits until the WAL holds less than the maximum number of
+// requests not exported yet. It returns errWALFull if ctx is done, which the
+// timeout of the exporter bounds, or the WAL is stopped before.
+func (prwe *prweWAL) waitForSpace(ctx context.Context) error {
+	maxEntries := prwe.walConfig.MaxEntries
+	if maxEntries <= 0 {
+		return nil
+	}
+	for {
+		prwe.mu.Lock()
+		full := prwe.wWALIndex.Load()-prwe.exportedIndex >= uint64(maxEntries)
+		exportedChan := prwe.exportedChan
+		prwe.mu.Unlock()
+		if !full {
+			return nil
+		}
+
+		select {
+		case <-exportedChan:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", errWALFull, ctx.Err())
+		case <-prwe.stopChan:
+			return errWALFull
+		}
 	}
-	// Reset by retrieving the respective read and write WAL indices.
-	return prwe.retrieveWALIndices()
 }
 
 // persistToWAL is the routine that'll be hooked into the exporter's receiving side and it'll
@@ -319,11 +510,10 @@ func (prwe *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
 	return prwe.wal.WriteBatch(batch)
 }
 
+// readPrompbFromWAL reads the request at index, waiting for it to be written for
+// at most the truncate frequency, after which wal.ErrNotFound is returned.
 func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq *prompb.WriteRequest, err error) {
-	prwe.mu.Lock()
-	defer prwe.mu.Unlock()
-
-	var protoBlob []byte
+	var walPath string
 	for i := 0; i < 12; i++ {
 		// Firstly check if we've been terminated, then exit if so.
 		select {
@@ -338,21 +528,14 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 			index = 1
 		}
 
-		if prwe.wal == nil {
-			return nil, fmt.Errorf("attempt to read from closed WAL")
-		}
-
-		protoBlob, err = prwe.wal.Read(index)
+		// The lock is only held while reading, so that the requests can be
+		// persisted while waiting for them.
+		wreq, walPath, err = prwe.readPrompbAt(index)
 		if err == nil { // The read succeeded.
-			req := new(prompb.WriteRequest)
-			if err = proto.Unmarshal(protoBlob, req); err != nil {
-				return nil, err
-			}
-
 			// Now increment the WAL's read index.
 			prwe.rWALIndex.Add(1)
 
-			return req, nil
+			return wreq, nil
 		}
 
 		if !errors.Is(err, wal.ErrNotFound) {
@@ -371,19 +554,22 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 		if werr != nil {
 			return nil, werr
 		}
-		if werr = walWatcher.Add(prwe.walPath); werr != nil {
+		if werr = walWatcher.Add(walPath); werr != nil {
+			walWatcher.Close()
 			return nil, werr
 		}
 
 		// Watch until perhaps there is a write to the WAL file.
 		watchCh := make(chan error)
 		wErr := err
+		watchTimer := time.NewTimer(prwe.walConfig.truncateFrequency())
 		go func() {
 			defer func() {
 				watchCh <- wErr
 				close(watchCh)
 				// Close the file watcher.
 				walWatcher.Close()
+				watchTimer.Stop()
 			}()
 
 			select {
@@ -391,6 +577,14 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 				wErr = ctx.Err()
 				return
 
+			case <-prwe.stopChan:
+				wErr = fmt.Errorf("attempt to read from WAL after stopped")
+				return
+
+			case <-watchTimer.C:
+				// Nothing was written, wErr is still wal.ErrNotFound.
+				return
+
 			case event, ok := <-walWatcher.Events:
 				if !ok {
 					return
@@ -423,3 +617,21 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 	}
 	return nil, err
 }
+
+func (prwe *prweWAL) readPrompbAt(index uint64) (*prompb.WriteRequest, string, error) {
+	prwe.mu.Lock()
+	defer prwe.mu.Unlock()
+
+	if prwe.wal == nil {
+		return nil, "", fmt.Errorf("attempt to read from closed WAL")
+	}
+	protoBlob, err := prwe.wal.Read(index)
+	if err != nil {
+		return nil, prwe.walPath, err
+	}
+	req := new(prompb.WriteRequest)
+	if err = proto.Unmarshal(protoBlob, req); err != nil {
+		return nil, "", err
+	}
+	return req, prwe.walPath, nil
+}
@@ -5,13 +5,17 @@ package prometheusremotewriteexporter
 
 import (
 	"context"
+	"errors"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
 )
 
 func doNothingExportSink(_ context.Context, reqL []*prompb.WriteRequest) error {
@@ -156,3 +160,132 @@ func TestWAL_persist(t *testing.T) {
 	require.Equal(t, reqLFromWAL[0], reqL[0])
 	require.Equal(t, reqLFromWAL[1], reqL[1])
 }
+
+type recordingExportSink struct {
+	mu       sync.Mutex
+	failures int
+	// failedSample fails the exports of the requests holding a sample with its
+	// value with failedErr.
+	failedSample float64
+	failedErr    error
+	reqs         []*prompb.WriteRequest
+}
+
+func (s *recordingExportSink) export(_ context.Context, reqL []*prompb.WriteRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("endpoint unavailable")
+	}
+	for _, req := range reqL {
+		if s.failedErr != nil && req.Timeseries[0].Samples[0].Value == s.failedSample {
+			return s.failedErr
+		}
+	}
+	s.reqs = append(s.reqs, reqL...)
+	return nil
+}
+
+func (s *recordingExportSink) exported() []*prompb.WriteRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*prompb.WriteRequest(nil), s.reqs...)
+}
+
+func writeRequestWithSample(value float64) *prompb.WriteRequest {
+	return &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "test"}},
+				Samples: []prompb.Sample{{Value: value, Timestamp: 100}},
+			},
+		},
+	}
+}
+
+func runWAL(t *testing.T, config *WALConfig, sink *recordingExportSink, reqL []*prompb.WriteRequest) *prweWAL {
+	pwal, err := newWAL(config, sink.export)
+	require.NoError(t, err)
+	require.NoError(t, pwal.retrieveWALIndices())
+	require.NoError(t, pwal.persistToWAL(reqL))
+
+	ctx, cancel := context.WithCancel(contextWithLogger(context.Background(), zap.NewNop()))
+	t.Cleanup(cancel)
+	require.NoError(t, pwal.run(ctx))
+	return pwal
+}
+
+func TestWAL_replaysOnlyRequestsNotExported(t *testing.T) {
+	config := &WALConfig{Directory: t.TempDir(), BufferSize: 1}
+
+	sink := &recordingExportSink{}
+	pwal := runWAL(t, config, sink, []*prompb.WriteRequest{writeRequestWithSample(1), writeRequestWithSample(2)})
+	require.Eventually(t, func() bool { return len(sink.exported()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, pwal.stop())
+
+	// After a restart, the requests exported before are not exported again.
+	sink = &recordingExportSink{}
+	pwal = runWAL(t, config, sink, []*prompb.WriteRequest{writeRequestWithSample(3)})
+	t.Cleanup(func() { assert.NoError(t, pwal.stop()) })
+	require.Eventually(t, func() bool { return len(sink.exported()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, writeRequestWithSample(3), sink.exported()[0])
+}
+
+func TestWAL_retriesFailedExports(t *testing.T) {
+	config := &WALConfig{Directory: t.TempDir(), BufferSize: 1}
+
+	sink := &recordingExportSink{failures: 2}
+	pwal := runWAL(t, config, sink, []*prompb.WriteRequest{writeRequestWithSample(1)})
+	t.Cleanup(func() { assert.NoError(t, pwal.stop()) })
+	require.Eventually(t, func() bool { return len(sink.exported()) == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, writeRequestWithSample(1), sink.exported()[0])
+}
+
+func TestWAL_dropsPermanentFailures(t *testing.T) {
+	config := &WALConfig{Directory: t.TempDir(), BufferSize: 1}
+
+	sink := &recordingExportSink{failedSample: 1, failedErr: consumererror.NewPermanent(errors.New("bad request"))}
+	pwal := runWAL(t, config, sink, []*prompb.WriteRequest{writeRequestWithSample(1), writeRequestWithSample(2)})
+	t.Cleanup(func() { assert.NoError(t, pwal.stop()) })
+	require.Eventually(t, func() bool { return len(sink.exported()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, writeRequestWithSample(2), sink.exported()[0])
+}
+
+func TestWAL_dropsAfterMaxRetryElapsedTime(t *testing.T) {
+	config := &WALConfig{Directory: t.TempDir(), BufferSize: 1, MaxRetryElapsedTime: time.Millisecond}
+
+	sink := &recordingExportSink{failedSample: 1, failedErr: errors.New("endpoint unavailable")}
+	pwal := runWAL(t, config, sink, []*prompb.WriteRequest{writeRequestWithSample(1), writeRequestWithSample(2)})
+	t.Cleanup(func() { assert.NoError(t, pwal.stop()) })
+	require.Eventually(t, func() bool { return len(sink.exported()) == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, writeRequestWithSample(2), sink.exported()[0])
+}
+
+func TestWAL_waitForSpace(t *testing.T) {
+	config := &WALConfig{Directory: t.TempDir(), MaxEntries: 2}
+	pwal, err := newWAL(config, doNothingExportSink)
+	require.NoError(t, err)
+	require.NoError(t, pwal.retrieveWALIndices())
+	t.Cleanup(func() { assert.NoError(t, pwal.stop()) })
+
+	require.NoError(t, pwal.waitForSpace(context.Background()))
+	require.NoError(t, pwal.persistToWAL([]*prompb.WriteRequest{writeRequestWithSample(1), writeRequestWithSample(2)}))
+
+	// The new data waits for the requests to be exported, for as long as ctx allows.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = pwal.waitForSpace(ctx)
+	assert.ErrorIs(t, err, errWALFull)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- pwal.waitForSpace(context.Background())
+	}()
+	_, err = pwal.readPrompbFromWAL(context.Background(), pwal.rWALIndex.Load())
+	require.NoError(t, err)
+	require.NoError(t, pwal.syncAndTruncateFront())
+	assert.NoError(t, <-waitErr)
+}
@@ -35,6 +35,13 @@ type Config struct {
 	OperationTimeout           time.Duration  `mapstructure:"operation_timeout"`
 	ConnectionTimeout          time.Duration  `mapstructure:"connection_timeout"`
 	MaxConnectionsPerBroker    int            `mapstructure:"map_connections_per_broker"`
+	// ListenerName is the name of the advertised listener the client connects and
+	// reconnects to, when the brokers advertise several.
+	ListenerName string `mapstructure:"listener_name"`
+	// LazyStart makes the exporter start even if the brokers are unreachable.
+	// The connection is retried in the background, while the data is kept in
+	// the sending queue.
+	LazyStart bool `mapstructure:"lazy_start"`
 }
 
 type Authentication struct {
@@ -83,13 +90,35 @@ type Producer struct {
 	BatchingMaxSize                 uint             `mapstructure:"batching_max_size"`
 	DisableBlockIfQueueFull         bool             `mapstructure:"disable_block_if_queue_full"`
 	DisableBatching                 bool             `mapstructure:"disable_batching"`
+	// ReconnectBackoff configures the delay between the attempts of a partition
+	// producer to reconnect to the broker.
+	ReconnectBackoff ReconnectBackoff `mapstructure:"reconnect_backoff"`
+	// FailFast makes each export wait for the acknowledgement of its messages and
+	// return the send errors, so that broker outages are retried and counted by
+	// the exporter instead of the client.
+	FailFast bool `mapstructure:"fail_fast"`
+}
+
+// ReconnectBackoff defines the exponential backoff between reconnection attempts.
+// The client defaults are used when unset.
+type ReconnectBackoff struct {
+	// MinInterval is the delay before the first attempt (default 100ms).
+	MinInterval time.Duration `mapstructure:"min_interval"`
+	// MaxInterval is the maximum delay between two attempts (default 60s).
+	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-
+	backoff := cfg.Producer.ReconnectBackoff
+	if backoff.MinInterval < 0 || backoff.MaxInterval < 0 {
+		return fmt.Errorf("producer.reconnect_backoff intervals must not be negative")
+	}
+	if backoff.MinInterval > 0 && backoff.MaxInterval > 0 && backoff.MinInterval > backoff.MaxInterval {
+		return fmt.Errorf("producer.reconnect_backoff.min_interval %v must not be greater than max_interval %v", backoff.MinInterval, backoff.MaxInterval)
+	}
 	return nil
 }
 
@@ -129,6 +158,7 @@ func (cfg *Config) clientOptions() pulsar.ClientOptions {
 		ConnectionTimeout:       cfg.ConnectionTimeout,
 		OperationTimeout:        cfg.OperationTimeout,
 		MaxConnectionsPerBroker: cfg.MaxConnectionsPerBroker,
+		ListenerName:            cfg.ListenerName,
 	}
 
 	options.TLSAllowInsecureConnection = cfg.TLSAllowInsecureConnection
@@ -158,6 +188,9 @@ func (cfg *Config) getProducerOptions() pulsar.ProducerOptions {
 		MaxReconnectToBroker:            cfg.Producer.MaxReconnectToBroker,
 		PartitionsAutoDiscoveryInterval: cfg.Producer.PartitionsAutoDiscoveryInterval,
 	}
+	if backoff := cfg.Producer.ReconnectBackoff; backoff.MinInterval > 0 || backoff.MaxInterval > 0 {
+		producerOptions.BackoffPolicy = newReconnectBackoff(backoff, cfg.ConnectionTimeout+cfg.OperationTimeout)
+	}
 	return producerOptions
 }
 
@@ -56,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 				MaxConnectionsPerBroker: 1,
 				ConnectionTimeout:       5 * time.Second,
 				OperationTimeout:        30 * time.Second,
+				ListenerName:            "internal",
 				Producer: Producer{
 					MaxReconnectToBroker:            nil,
 					HashingScheme:                   "java_string_hash",
@@ -69,6 +70,11 @@ func TestLoadConfig(t *testing.T) {
 					BatchingMaxSize:                 128000,
 					DisableBlockIfQueueFull:         false,
 					DisableBatching:                 false,
+					ReconnectBackoff: ReconnectBackoff{
+						MinInterval: time.Second,
+						MaxInterval: 10 * time.Second,
+					},
+					FailFast: true,
 				},
 			},
 		},
@@ -108,6 +114,57 @@ func TestClientOptions(t *testing.T) {
 		ConnectionTimeout:       5 * time.Second,
 		OperationTimeout:        30 * time.Second,
 		MaxConnectionsPerBroker: 1,
+		ListenerName:            "internal",
 	}, &options)
 
 }
+
+func TestProducerOptionsBackoffPolicy(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Nil(t, cfg.getProducerOptions().BackoffPolicy)
+
+	cfg.Producer.ReconnectBackoff.MaxInterval = 5 * time.Second
+	policy, ok := cfg.getProducerOptions().BackoffPolicy.(*reconnectBackoff)
+	require.True(t, ok)
+	assert.Equal(t, defaultReconnectMinInterval, policy.min)
+	assert.Equal(t, 5*time.Second, policy.max)
+}
+
+func TestValidateReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff ReconnectBackoff
+		err     string
+	}{
+		{
+			name:    "unset",
+			backoff: ReconnectBackoff{},
+		},
+		{
+			name:    "min only",
+			backoff: ReconnectBackoff{MinInterval: time.Minute},
+		},
+		{
+			name:    "negative",
+			backoff: ReconnectBackoff{MinInterval: -time.Second},
+			err:     "producer.reconnect_backoff intervals must not be negative",
+		},
+		{
+			name:    "min greater than max",
+			backoff: ReconnectBackoff{MinInterval: 10 * time.Second, MaxInterval: time.Second},
+			err:     "producer.reconnect_backoff.min_interval 10s must not be greater than max_interval 1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Producer.ReconnectBackoff = tt.backoff
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
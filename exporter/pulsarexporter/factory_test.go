@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 )
@@ -73,3 +74,16 @@ func TestCreateLogsExporter_err(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, mr)
 }
+
+func TestCreateExporterLazyStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "pulsar://invalid:6650"
+	cfg.LazyStart = true
+
+	f := pulsarExporterFactory{tracesMarshalers: tracesMarshalers()}
+	r, err := f.createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+}
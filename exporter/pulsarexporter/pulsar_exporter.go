@@ -6,8 +6,10 @@ package pulsarexporter // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -15,16 +17,18 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lazyconnect"
 )
 
 var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 type PulsarTracesProducer struct {
-	client    pulsar.Client
-	producer  pulsar.Producer
+	conn      *lazyconnect.Connection[*pulsarConnection]
 	topic     string
 	marshaler TracesMarshaler
 	logger    *zap.Logger
+	failFast  bool
 }
 
 func (e *PulsarTracesProducer) tracesPusher(ctx context.Context, td ptrace.Traces) error {
@@ -33,32 +37,24 @@ func (e *PulsarTracesProducer) tracesPusher(ctx context.Context, td ptrace.Trace
 		return consumererror.NewPermanent(err)
 	}
 
-	var errs error
-	for _, message := range messages {
-
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
-			if err != nil {
-				errs = multierr.Append(errs, err)
-			}
-		})
-
-	}
+	return send(ctx, e.conn, messages, e.failFast)
+}
 
-	return errs
+func (e *PulsarTracesProducer) start(context.Context, component.Host) error {
+	e.conn.Start()
+	return nil
 }
 
 func (e *PulsarTracesProducer) Close(context.Context) error {
-	e.producer.Close()
-	e.client.Close()
-	return nil
+	return e.conn.Shutdown((*pulsarConnection).close)
 }
 
 type PulsarMetricsProducer struct {
-	client    pulsar.Client
-	producer  pulsar.Producer
+	conn      *lazyconnect.Connection[*pulsarConnection]
 	topic     string
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
+	failFast  bool
 }
 
 func (e *PulsarMetricsProducer) metricsDataPusher(ctx context.Context, md pmetric.Metrics) error {
@@ -67,32 +63,24 @@ func (e *PulsarMetricsProducer) metricsDataPusher(ctx context.Context, md pmetri
 		return consumererror.NewPermanent(err)
 	}
 
-	var errs error
-	for _, message := range messages {
-
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
-			if err != nil {
-				errs = multierr.Append(errs, err)
-			}
-		})
-
-	}
+	return send(ctx, e.conn, messages, e.failFast)
+}
 
-	return errs
+func (e *PulsarMetricsProducer) start(context.Context, component.Host) error {
+	e.conn.Start()
+	return nil
 }
 
 func (e *PulsarMetricsProducer) Close(context.Context) error {
-	e.producer.Close()
-	e.client.Close()
-	return nil
+	return e.conn.Shutdown((*pulsarConnection).close)
 }
 
 type PulsarLogsProducer struct {
-	client    pulsar.Client
-	producer  pulsar.Producer
+	conn      *lazyconnect.Connection[*pulsarConnection]
 	topic     string
 	marshaler LogsMarshaler
 	logger    *zap.Logger
+	failFast  bool
 }
 
 func (e *PulsarLogsProducer) logsDataPusher(ctx context.Context, ld plog.Logs) error {
@@ -101,33 +89,75 @@ func (e *PulsarLogsProducer) logsDataPusher(ctx context.Context, ld plog.Logs) e
 		return consumererror.NewPermanent(err)
 	}
 
-	var errs error
-	for _, message := range messages {
+	return send(ctx, e.conn, messages, e.failFast)
+}
+
+func (e *PulsarLogsProducer) start(context.Context, component.Host) error {
+	e.conn.Start()
+	return nil
+}
+
+func (e *PulsarLogsProducer) Close(context.Context) error {
+	return e.conn.Shutdown((*pulsarConnection).close)
+}
 
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+// pulsarConnection is the client of an exporter and its producer.
+type pulsarConnection struct {
+	client   pulsar.Client
+	producer pulsar.Producer
+}
+
+func (c *pulsarConnection) close() error {
+	c.producer.Close()
+	c.client.Close()
+	return nil
+}
+
+// send sends messages with the producer of conn, once connected.
+func send(ctx context.Context, conn *lazyconnect.Connection[*pulsarConnection], messages []*pulsar.ProducerMessage, failFast bool) error {
+	c, err := conn.Get()
+	if err != nil {
+		return err
+	}
+	return sendMessages(ctx, c.producer, messages, failFast)
+}
+
+// sendMessages sends messages asynchronously. With failFast, it waits for their
+// acknowledgement and returns the send errors, e.g. while the producer fails to
+// reconnect to the broker.
+func sendMessages(ctx context.Context, producer pulsar.Producer, messages []*pulsar.ProducerMessage, failFast bool) error {
+	var (
+		mu   sync.Mutex
+		errs error
+		wg   sync.WaitGroup
+	)
+	for _, message := range messages {
+		wg.Add(1)
+		producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+			defer wg.Done()
 			if err != nil {
+				mu.Lock()
 				errs = multierr.Append(errs, err)
+				mu.Unlock()
 			}
 		})
-
 	}
 
+	if failFast {
+		wg.Wait()
+	}
+	mu.Lock()
+	defer mu.Unlock()
 	return errs
 }
 
-func (e *PulsarLogsProducer) Close(context.Context) error {
-	e.producer.Close()
-	e.client.Close()
-	return nil
-}
-
-func newPulsarProducer(config Config) (pulsar.Client, pulsar.Producer, error) {
+func newPulsarConnection(config Config) (*pulsarConnection, error) {
 	options := config.clientOptions()
 
 	client, err := pulsar.NewClient(options)
 
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	producerOptions := config.getProducerOptions()
@@ -135,10 +165,27 @@ func newPulsarProducer(config Config) (pulsar.Client, pulsar.Producer, error) {
 	producer, err := client.CreateProducer(producerOptions)
 
 	if err != nil {
-		return nil, nil, err
+		client.Close()
+		return nil, err
 	}
 
-	return client, producer, nil
+	return &pulsarConnection{client: client, producer: producer}, nil
+}
+
+// newConnection creates the connection of an exporter. With lazy start, the
+// connection to the brokers is deferred until the exporter starts.
+func newConnection(config Config, set exporter.CreateSettings) (*lazyconnect.Connection[*pulsarConnection], error) {
+	connect := func() (*pulsarConnection, error) {
+		return newPulsarConnection(config)
+	}
+	if config.LazyStart {
+		return lazyconnect.New(connect, config.RetrySettings.InitialInterval, set.TelemetrySettings), nil
+	}
+	conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	return lazyconnect.Connected(conn), nil
 }
 
 func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers map[string]MetricsMarshaler) (*PulsarMetricsProducer, error) {
@@ -146,17 +193,17 @@ func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers m
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	client, producer, err := newPulsarProducer(config)
+	conn, err := newConnection(config, set)
 	if err != nil {
 		return nil, err
 	}
 
 	return &PulsarMetricsProducer{
-		client:    client,
-		producer:  producer,
+		conn:      conn,
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		failFast:  config.Producer.FailFast,
 	}, nil
 
 }
@@ -166,16 +213,16 @@ func newTracesExporter(config Config, set exporter.CreateSettings, marshalers ma
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	client, producer, err := newPulsarProducer(config)
+	conn, err := newConnection(config, set)
 	if err != nil {
 		return nil, err
 	}
 	return &PulsarTracesProducer{
-		client:    client,
-		producer:  producer,
+		conn:      conn,
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		failFast:  config.Producer.FailFast,
 	}, nil
 }
 
@@ -184,17 +231,17 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	client, producer, err := newPulsarProducer(config)
+	conn, err := newConnection(config, set)
 	if err != nil {
 		return nil, err
 	}
 
 	return &PulsarLogsProducer{
-		client:    client,
-		producer:  producer,
+		conn:      conn,
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		failFast:  config.Producer.FailFast,
 	}, nil
 
 }
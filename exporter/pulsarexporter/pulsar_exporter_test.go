@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lazyconnect"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
@@ -48,7 +49,7 @@ func TestNewLogsExporter_err_traces_encoding(t *testing.T) {
 
 func Test_tracerPublisher(t *testing.T) {
 	mProducer := &mockProducer{name: "producer1", topic: "default"}
-	producer := PulsarTracesProducer{client: nil, producer: mProducer, marshaler: tracesMarshalers()["jaeger_proto"]}
+	producer := PulsarTracesProducer{conn: lazyconnect.Connected[*pulsarConnection](&pulsarConnection{producer: mProducer}), marshaler: tracesMarshalers()["jaeger_proto"]}
 	err := producer.tracesPusher(context.Background(), testdata.GenerateTracesManySpansSameResource(10))
 
 	assert.NoError(t, err)
@@ -56,13 +57,22 @@ func Test_tracerPublisher(t *testing.T) {
 
 func Test_tracerPublisher_marshaler_err(t *testing.T) {
 	mProducer := &mockProducer{name: "producer1", topic: "default"}
-	producer := PulsarTracesProducer{client: nil, producer: mProducer, marshaler: &customTraceMarshaler{encoding: "unknown"}}
+	producer := PulsarTracesProducer{conn: lazyconnect.Connected[*pulsarConnection](&pulsarConnection{producer: mProducer}), marshaler: &customTraceMarshaler{encoding: "unknown"}}
 	err := producer.tracesPusher(context.Background(), testdata.GenerateTracesManySpansSameResource(10))
 
 	assert.NotNil(t, err)
 	assert.True(t, consumererror.IsPermanent(err))
 }
 
+func Test_tracerPublisher_fail_fast(t *testing.T) {
+	mProducer := &mockProducer{name: "producer1", topic: "default", sendErr: errors.New("producer is reconnecting")}
+	producer := PulsarTracesProducer{conn: lazyconnect.Connected[*pulsarConnection](&pulsarConnection{producer: mProducer}), marshaler: tracesMarshalers()["jaeger_proto"], failFast: true}
+	err := producer.tracesPusher(context.Background(), testdata.GenerateTracesManySpansSameResource(10))
+
+	assert.ErrorContains(t, err, "producer is reconnecting")
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
 type customTraceMarshaler struct {
 	encoding string
 }
@@ -78,6 +88,8 @@ func (c *customTraceMarshaler) Encoding() string {
 type mockProducer struct {
 	topic string
 	name  string
+	// sendErr is the error of the asynchronous sends
+	sendErr error
 }
 
 func (c *mockProducer) Topic() string {
@@ -92,8 +104,8 @@ func (c *mockProducer) Send(context.Context, *pulsar.ProducerMessage) (pulsar.Me
 	return nil, nil
 }
 
-func (c *mockProducer) SendAsync(context.Context, *pulsar.ProducerMessage, func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
-
+func (c *mockProducer) SendAsync(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+	go callback(nil, msg, c.sendErr)
 }
 
 func (c *mockProducer) LastSequenceID() int64 {
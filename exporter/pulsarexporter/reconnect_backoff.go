@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pulsarexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/pulsarexporter"
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReconnectMinInterval = 100 * time.Millisecond
+	defaultReconnectMaxInterval = 60 * time.Second
+	reconnectJitterPercentage   = 0.2
+)
+
+// reconnectBackoff is the reconnection policy of a producer. Like the default
+// policy of the client, it doubles the delay on each attempt, adding up to 20
+// percents of jitter. The client shares it between the partitions of the
+// producer and never resets it, so an attempt made long after the previous one
+// starts over from the minimum delay.
+type reconnectBackoff struct {
+	min time.Duration
+	max time.Duration
+	// resetAfter is the time without attempt after which an outage is over
+	resetAfter time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	backoff time.Duration
+	last    time.Time
+}
+
+// newReconnectBackoff creates the policy of cfg. attemptTimeout is the longest
+// duration of a reconnection attempt.
+func newReconnectBackoff(cfg ReconnectBackoff, attemptTimeout time.Duration) *reconnectBackoff {
+	b := &reconnectBackoff{
+		min: cfg.MinInterval,
+		max: cfg.MaxInterval,
+		now: time.Now,
+	}
+	if b.min <= 0 {
+		b.min = defaultReconnectMinInterval
+	}
+	if b.max <= 0 {
+		b.max = defaultReconnectMaxInterval
+	}
+	if b.min > b.max {
+		b.max = b.min
+	}
+	b.resetAfter = 2*b.max + attemptTimeout
+	return b
+}
+
+// Next returns the delay to wait before the next attempt.
+func (b *reconnectBackoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if now.Sub(b.last) > b.resetAfter {
+		b.backoff = 0
+	}
+	b.last = now
+
+	b.backoff *= 2
+	if b.backoff < b.min {
+		b.backoff = b.min
+	} else if b.backoff > b.max {
+		b.backoff = b.max
+	}
+	jitter := rand.Float64() * float64(b.backoff) * reconnectJitterPercentage // #nosec G404 -- the jitter does not need a secure source
+	return b.backoff + time.Duration(jitter)
+}
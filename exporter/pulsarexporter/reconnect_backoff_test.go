@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pulsarexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	b := newReconnectBackoff(ReconnectBackoff{MinInterval: time.Second, MaxInterval: 5 * time.Second}, 10*time.Second)
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+
+	// The delays double up to the maximum, with up to 20% of jitter
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		d := b.Next()
+		assert.GreaterOrEqual(t, d, expected)
+		assert.LessOrEqual(t, d, expected+expected/5)
+		now = now.Add(d + 10*time.Second)
+	}
+
+	// A new outage starts over from the minimum
+	now = now.Add(time.Minute)
+	d := b.Next()
+	assert.GreaterOrEqual(t, d, time.Second)
+	assert.LessOrEqual(t, d, time.Second+time.Second/5)
+}
+
+func TestReconnectBackoffDefaults(t *testing.T) {
+	b := newReconnectBackoff(ReconnectBackoff{MaxInterval: 50 * time.Millisecond}, 0)
+	assert.Equal(t, defaultReconnectMinInterval, b.min)
+	assert.Equal(t, defaultReconnectMinInterval, b.max)
+
+	b = newReconnectBackoff(ReconnectBackoff{MinInterval: time.Second}, 0)
+	assert.Equal(t, defaultReconnectMaxInterval, b.max)
+}
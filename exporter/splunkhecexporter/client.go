@@ -23,6 +23,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 // allow monkey patching for injecting pushLogData function in test
@@ -53,6 +56,9 @@ type client struct {
 	heartbeater       *heartbeater
 	bufferPool        bufferPool
 	exporterName      string
+	logsRouting       *eventRouting[ottllog.TransformContext]
+	tracesRouting     *eventRouting[ottlspan.TransformContext]
+	metricsRouting    *eventRouting[ottldatapoint.TransformContext]
 }
 
 var jsonStreamPool = sync.Pool{
@@ -175,7 +181,7 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld plog.Logs, headers
 
 	for !is.done {
 		buf.Reset()
-		latestIterState, batchPermanentErrors := c.fillLogsBuffer(ld, buf, is)
+		latestIterState, batchPermanentErrors := c.fillLogsBuffer(ctx, ld, buf, is)
 		permanentErrors = append(permanentErrors, batchPermanentErrors...)
 		if !buf.Empty() {
 			if err := c.postEvents(ctx, buf, headers); err != nil {
@@ -189,7 +195,7 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld plog.Logs, headers
 }
 
 // fillLogsBuffer fills the buffer with Splunk events until the buffer is full or all logs are processed.
-func (c *client) fillLogsBuffer(logs plog.Logs, buf buffer, is iterState) (iterState, []error) {
+func (c *client) fillLogsBuffer(ctx context.Context, logs plog.Logs, buf buffer, is iterState) (iterState, []error) {
 	var b []byte
 	var permanentErrors []error
 	jsonStream := jsonStreamPool.Get().(*jsoniter.Stream)
@@ -209,6 +215,14 @@ func (c *client) fillLogsBuffer(logs plog.Logs, buf buffer, is iterState) (iterS
 				} else {
 					// Parsing log record to Splunk event.
 					event := mapLogRecordToSplunkEvent(rl.Resource(), logRecord, c.config)
+					if c.logsRouting != nil {
+						tCtx := ottllog.NewTransformContext(logRecord, sl.Scope(), rl.Resource())
+						if err := c.logsRouting.route(ctx, tCtx, event); err != nil {
+							permanentErrors = append(permanentErrors, consumererror.NewPermanent(fmt.Errorf(
+								"dropped log event: %v, error: %w", event, err)))
+							continue
+						}
+					}
 
 					// JSON encoding event and writing to buffer.
 					var err error
@@ -243,7 +257,7 @@ func (c *client) fillLogsBuffer(logs plog.Logs, buf buffer, is iterState) (iterS
 	return iterState{done: true}, permanentErrors
 }
 
-func (c *client) fillMetricsBuffer(metrics pmetric.Metrics, buf buffer, is iterState) (iterState, []error) {
+func (c *client) fillMetricsBuffer(ctx context.Context, metrics pmetric.Metrics, buf buffer, is iterState) (iterState, []error) {
 	var permanentErrors []error
 	jsonStream := jsonStreamPool.Get().(*jsoniter.Stream)
 	defer jsonStreamPool.Put(jsonStream)
@@ -259,7 +273,7 @@ func (c *client) fillMetricsBuffer(metrics pmetric.Metrics, buf buffer, is iterS
 				metric := sm.Metrics().At(k)
 
 				// Parsing metric record to Splunk event.
-				events := mapMetricToSplunkEvent(rm.Resource(), metric, c.config, c.logger)
+				events := mapMetricToSplunkEvent(rm.Resource(), metric, c.config, c.logger, c.metricRouter(ctx, rm, sm, metric, &permanentErrors))
 				tempBuf.Reset()
 				for _, event := range events {
 					// JSON encoding event and writing to buffer.
@@ -295,6 +309,27 @@ func (c *client) fillMetricsBuffer(metrics pmetric.Metrics, buf buffer, is iterS
 	return iterState{done: true}, permanentErrors
 }
 
+// metricRouter returns the router of the events of the data points of metric, or nil when the metrics are not
+// routed. The events of the data points whose expressions fail to evaluate are dropped, with a permanent error.
+func (c *client) metricRouter(ctx context.Context, rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, metric pmetric.Metric, permanentErrors *[]error) dataPointRouter {
+	if c.metricsRouting == nil {
+		return nil
+	}
+	return func(dataPoint any, events []*splunk.Event) []*splunk.Event {
+		tCtx := ottldatapoint.NewTransformContext(dataPoint, metric, sm.Metrics(), sm.Scope(), rm.Resource())
+		metadata, err := c.metricsRouting.evaluate(ctx, tCtx)
+		if err != nil {
+			*permanentErrors = append(*permanentErrors, consumererror.NewPermanent(fmt.Errorf(
+				"dropped metric events: %v, error: %w", events, err)))
+			return nil
+		}
+		for _, event := range events {
+			c.metricsRouting.apply(metadata, event)
+		}
+		return events
+	}
+}
+
 func (c *client) fillMetricsBufferMultiMetrics(events []*splunk.Event, buf buffer, is iterState) (iterState, []error) {
 	var permanentErrors []error
 	jsonStream := jsonStreamPool.Get().(*jsoniter.Stream)
@@ -332,7 +367,7 @@ func (c *client) fillMetricsBufferMultiMetrics(events []*splunk.Event, buf buffe
 	return iterState{done: true}, permanentErrors
 }
 
-func (c *client) fillTracesBuffer(traces ptrace.Traces, buf buffer, is iterState) (iterState, []error) {
+func (c *client) fillTracesBuffer(ctx context.Context, traces ptrace.Traces, buf buffer, is iterState) (iterState, []error) {
 	var permanentErrors []error
 	jsonStream := jsonStreamPool.Get().(*jsoniter.Stream)
 	defer jsonStreamPool.Put(jsonStream)
@@ -348,6 +383,13 @@ func (c *client) fillTracesBuffer(traces ptrace.Traces, buf buffer, is iterState
 
 				// Parsing span record to Splunk event.
 				event := mapSpanToSplunkEvent(rs.Resource(), span, c.config)
+				if c.tracesRouting != nil {
+					tCtx := ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource())
+					if err := c.tracesRouting.route(ctx, tCtx, event); err != nil {
+						permanentErrors = append(permanentErrors, consumererror.NewPermanent(fmt.Errorf("dropped span events: %v, error: %w", event, err)))
+						continue
+					}
+				}
 
 				// JSON encoding event and writing to buffer.
 				b, err := marshalEvent(event, c.config.MaxEventSize, jsonStream)
@@ -397,7 +439,7 @@ func (c *client) pushMultiMetricsDataInBatches(ctx context.Context, md pmetric.M
 				metric := sm.Metrics().At(k)
 
 				// Parsing metric record to Splunk event.
-				events = append(events, mapMetricToSplunkEvent(rm.Resource(), metric, c.config, c.logger)...)
+				events = append(events, mapMetricToSplunkEvent(rm.Resource(), metric, c.config, c.logger, c.metricRouter(ctx, rm, sm, metric, &permanentErrors))...)
 			}
 		}
 	}
@@ -435,7 +477,7 @@ func (c *client) pushMetricsDataInBatches(ctx context.Context, md pmetric.Metric
 
 	for !is.done {
 		buf.Reset()
-		latestIterState, batchPermanentErrors := c.fillMetricsBuffer(md, buf, is)
+		latestIterState, batchPermanentErrors := c.fillMetricsBuffer(ctx, md, buf, is)
 		permanentErrors = append(permanentErrors, batchPermanentErrors...)
 		if !buf.Empty() {
 			if err := c.postEvents(ctx, buf, headers); err != nil {
@@ -460,7 +502,7 @@ func (c *client) pushTracesDataInBatches(ctx context.Context, td ptrace.Traces,
 
 	for !is.done {
 		buf.Reset()
-		latestIterState, batchPermanentErrors := c.fillTracesBuffer(td, buf, is)
+		latestIterState, batchPermanentErrors := c.fillTracesBuffer(ctx, td, buf, is)
 		permanentErrors = append(permanentErrors, batchPermanentErrors...)
 		if !buf.Empty() {
 			if err := c.postEvents(ctx, buf, headers); err != nil {
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -269,6 +270,7 @@ func runTraceExport(testConfig *Config, traces ptrace.Traces, expectedBatchesNum
 	cfg.HTTPClientSettings.Endpoint = "http://" + listener.Addr().String() + "/services/collector"
 	cfg.DisableCompression = testConfig.DisableCompression
 	cfg.MaxContentLengthTraces = testConfig.MaxContentLengthTraces
+	cfg.Routing = testConfig.Routing
 	cfg.Token = "1234-1234"
 
 	rr := make(chan receivedRequest)
@@ -975,6 +977,55 @@ func TestReceiveSpanEvent(t *testing.T) {
 	compareWithTestData(t, actual[0].body, "testdata/hec_span_event.json")
 }
 
+func TestReceiveRoutedEvents(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DisableCompression = true
+	cfg.Routing = RoutingSettings{
+		Index:          `attributes["team"]`,
+		SourceType:     `resource.attributes["sourcetype"]`,
+		AllowedIndexes: []string{"payments"},
+	}
+
+	logs := createLogData(1, 1, 3)
+	logs.ResourceLogs().At(0).Resource().Attributes().PutStr("sourcetype", "cart:log")
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	records.At(0).Attributes().PutStr("team", "payments")
+	records.At(1).Attributes().PutStr("team", "marketing")
+	actual, err := runLogExport(cfg, logs, 1, t)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assertRoutedEvents(t, actual[0].body, []string{"payments", "myindex", "myindex"}, "cart:log")
+
+	traces := createTraceData(1, 2)
+	traces.ResourceSpans().At(0).Resource().Attributes().PutStr("sourcetype", "cart:span")
+	traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutStr("team", "payments")
+	actual, err = runTraceExport(cfg, traces, 1, t)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assertRoutedEvents(t, actual[0].body, []string{"", "payments"}, "cart:span")
+
+	metrics := createMetricsData(1, 2)
+	metrics.ResourceMetrics().At(0).Resource().Attributes().PutStr("sourcetype", "cart:metric")
+	metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().PutStr("team", "payments")
+	actual, err = runMetricsExport(cfg, metrics, 1, false, t)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assertRoutedEvents(t, actual[0].body, []string{"payments", ""}, "cart:metric")
+}
+
+// assertRoutedEvents checks the index and the sourcetype of the events of the request body, an empty index meaning
+// that the event has no index.
+func assertRoutedEvents(t *testing.T, body []byte, indexes []string, sourceType string) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for _, index := range indexes {
+		var event splunk.Event
+		require.NoError(t, decoder.Decode(&event))
+		assert.Equal(t, index, event.Index)
+		assert.Equal(t, sourceType, event.SourceType)
+	}
+	assert.False(t, decoder.More())
+}
+
 // compareWithTestData compares hec output with a json file using maps instead of strings to avoid key ordering
 // issues (jsoniter doesn't sort the keys).
 func compareWithTestData(t *testing.T, actual []byte, file string) {
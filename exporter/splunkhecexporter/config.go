@@ -61,6 +61,22 @@ type HecTelemetry struct {
 	ExtraAttributes map[string]string `mapstructure:"extra_attributes"`
 }
 
+// RoutingSettings defines the OTTL expressions computing the HEC metadata of each event
+type RoutingSettings struct {
+	// Index is the expression of the Splunk index of the event, e.g. `attributes["team"]`.
+	Index string `mapstructure:"index"`
+
+	// Source is the expression of the Splunk source of the event.
+	Source string `mapstructure:"source"`
+
+	// SourceType is the expression of the Splunk source type of the event.
+	SourceType string `mapstructure:"sourcetype"`
+
+	// AllowedIndexes restricts the indexes the events can be routed to. The events whose index expression
+	// evaluates to another index keep the index of the configuration or of the attributes.
+	AllowedIndexes []string `mapstructure:"allowed_indexes"`
+}
+
 // Config defines configuration for Splunk exporter.
 type Config struct {
 	confighttp.HTTPClientSettings `mapstructure:",squash"`
@@ -114,6 +130,9 @@ type Config struct {
 	HecToOtelAttrs splunk.HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
 	// HecFields creates a mapping from attributes to HEC fields.
 	HecFields OtelToHecFields `mapstructure:"otel_to_hec_fields"`
+	// Routing computes the index, source and sourcetype of each event from OTTL expressions,
+	// overriding the values of the configuration and of the attributes.
+	Routing RoutingSettings `mapstructure:"routing"`
 
 	// HealthPath for health API, default is '/services/collector/health'
 	HealthPath string `mapstructure:"health_path"`
@@ -179,6 +198,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf(`requires "max_event_size" <= %d`, maxMaxEventSize)
 	}
 
+	if len(cfg.Routing.AllowedIndexes) > 0 && cfg.Routing.Index == "" {
+		return errors.New(`requires a non-empty "routing.index" when "routing.allowed_indexes" is set`)
+	}
+
 	if err := cfg.QueueSettings.Validate(); err != nil {
 		return fmt.Errorf("sending_queue settings has invalid configuration: %w", err)
 	}
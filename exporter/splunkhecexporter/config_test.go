@@ -97,6 +97,11 @@ func TestLoadConfig(t *testing.T) {
 					SeverityText:   "myseverityfield",
 					SeverityNumber: "myseveritynumfield",
 				},
+				Routing: RoutingSettings{
+					Index:          `attributes["team"]`,
+					SourceType:     `resource.attributes["service.name"]`,
+					AllowedIndexes: []string{"payments", "checkout"},
+				},
 				HealthPath:            "/services/collector/health",
 				HecHealthCheckEnabled: false,
 				Heartbeat: HecHeartbeat{
@@ -205,6 +210,17 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			wantErr: "requires \"max_event_size\" <= 838860800",
 		},
+		{
+			name: "allowed indexes without index routing",
+			cfg: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.HTTPClientSettings.Endpoint = "http://foo_bar.com"
+				cfg.Routing.AllowedIndexes = []string{"main"}
+				cfg.Token = "foo"
+				return cfg
+			}(),
+			wantErr: "requires a non-empty \"routing.index\" when \"routing.allowed_indexes\" is set",
+		},
 	}
 
 	for _, tt := range tests {
@@ -17,6 +17,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 const (
@@ -97,6 +100,10 @@ func createTracesExporter(
 	cfg := config.(*Config)
 
 	c := newTracesClient(set, cfg)
+	var err error
+	if c.tracesRouting, err = newEventRouting(cfg.Routing, ottlspan.NewParser, set.TelemetrySettings); err != nil {
+		return nil, err
+	}
 
 	return exporterhelper.NewTracesExporter(
 		ctx,
@@ -119,6 +126,10 @@ func createMetricsExporter(
 	cfg := config.(*Config)
 
 	c := newMetricsClient(set, cfg)
+	var err error
+	if c.metricsRouting, err = newEventRouting(cfg.Routing, ottldatapoint.NewParser, set.TelemetrySettings); err != nil {
+		return nil, err
+	}
 
 	exporter, err := exporterhelper.NewMetricsExporter(
 		ctx,
@@ -151,6 +162,9 @@ func createLogsExporter(
 	cfg := config.(*Config)
 
 	c := newLogsClient(set, cfg)
+	if c.logsRouting, err = newEventRouting(cfg.Routing, ottllog.NewParser, set.TelemetrySettings); err != nil {
+		return nil, err
+	}
 
 	logsExporter, err := exporterhelper.NewLogsExporter(
 		ctx,
@@ -51,7 +51,18 @@ func sanitizeFloat(value float64) interface{} {
 	return value
 }
 
-func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Config, logger *zap.Logger) []*splunk.Event {
+// dataPointRouter routes the events of a data point, returning the events to keep.
+type dataPointRouter func(dataPoint any, events []*splunk.Event) []*splunk.Event
+
+// routeDataPoint routes the events of dataPoint, which are the events from index first on, with route if not nil.
+func routeDataPoint(route dataPointRouter, dataPoint any, events []*splunk.Event, first int) []*splunk.Event {
+	if route == nil {
+		return events
+	}
+	return append(events[:first], route(dataPoint, events[first:])...)
+}
+
+func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Config, logger *zap.Logger, route dataPointRouter) []*splunk.Event {
 	sourceKey := config.HecToOtelAttrs.Source
 	sourceTypeKey := config.HecToOtelAttrs.SourceType
 	indexKey := config.HecToOtelAttrs.Index
@@ -84,10 +95,11 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 	switch m.Type() {
 	case pmetric.MetricTypeGauge:
 		pts := m.Gauge().DataPoints()
-		splunkMetrics := make([]*splunk.Event, pts.Len())
+		splunkMetrics := make([]*splunk.Event, 0, pts.Len())
 
 		for gi := 0; gi < pts.Len(); gi++ {
 			dataPt := pts.At(gi)
+			first := len(splunkMetrics)
 			fields := cloneMap(commonFields)
 			populateAttributes(fields, dataPt.Attributes())
 			switch dataPt.ValueType() {
@@ -97,7 +109,8 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 				fields[metricFieldName] = sanitizeFloat(dataPt.DoubleValue())
 			}
 			fields[splunkMetricTypeKey] = pmetric.MetricTypeGauge.String()
-			splunkMetrics[gi] = createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+			splunkMetrics = append(splunkMetrics, createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields))
+			splunkMetrics = routeDataPoint(route, dataPt, splunkMetrics, first)
 		}
 		return splunkMetrics
 	case pmetric.MetricTypeHistogram:
@@ -105,6 +118,7 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 		var splunkMetrics []*splunk.Event
 		for gi := 0; gi < pts.Len(); gi++ {
 			dataPt := pts.At(gi)
+			first := len(splunkMetrics)
 			bounds := dataPt.ExplicitBounds()
 			counts := dataPt.BucketCounts()
 			// first, add one event for sum, and one for count
@@ -124,38 +138,39 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 			}
 			// Spec says counts is optional but if present it must have one more
 			// element than the bounds array.
-			if counts.Len() == 0 || counts.Len() != bounds.Len()+1 {
-				continue
-			}
-			value := uint64(0)
-			// now create buckets for each bound.
-			for bi := 0; bi < bounds.Len(); bi++ {
-				fields := cloneMap(commonFields)
-				populateAttributes(fields, dataPt.Attributes())
-				fields["le"] = float64ToDimValue(bounds.At(bi))
-				value += counts.At(bi)
-				fields[metricFieldName+bucketSuffix] = value
-				fields[splunkMetricTypeKey] = pmetric.MetricTypeHistogram.String()
-				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-				splunkMetrics = append(splunkMetrics, sm)
-			}
-			// add an upper bound for +Inf
-			{
-				fields := cloneMap(commonFields)
-				populateAttributes(fields, dataPt.Attributes())
-				fields["le"] = float64ToDimValue(math.Inf(1))
-				fields[metricFieldName+bucketSuffix] = value + counts.At(counts.Len()-1)
-				fields[splunkMetricTypeKey] = pmetric.MetricTypeHistogram.String()
-				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-				splunkMetrics = append(splunkMetrics, sm)
+			if counts.Len() != 0 && counts.Len() == bounds.Len()+1 {
+				value := uint64(0)
+				// now create buckets for each bound.
+				for bi := 0; bi < bounds.Len(); bi++ {
+					fields := cloneMap(commonFields)
+					populateAttributes(fields, dataPt.Attributes())
+					fields["le"] = float64ToDimValue(bounds.At(bi))
+					value += counts.At(bi)
+					fields[metricFieldName+bucketSuffix] = value
+					fields[splunkMetricTypeKey] = pmetric.MetricTypeHistogram.String()
+					sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+					splunkMetrics = append(splunkMetrics, sm)
+				}
+				// add an upper bound for +Inf
+				{
+					fields := cloneMap(commonFields)
+					populateAttributes(fields, dataPt.Attributes())
+					fields["le"] = float64ToDimValue(math.Inf(1))
+					fields[metricFieldName+bucketSuffix] = value + counts.At(counts.Len()-1)
+					fields[splunkMetricTypeKey] = pmetric.MetricTypeHistogram.String()
+					sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
+					splunkMetrics = append(splunkMetrics, sm)
+				}
 			}
+			splunkMetrics = routeDataPoint(route, dataPt, splunkMetrics, first)
 		}
 		return splunkMetrics
 	case pmetric.MetricTypeSum:
 		pts := m.Sum().DataPoints()
-		splunkMetrics := make([]*splunk.Event, pts.Len())
+		splunkMetrics := make([]*splunk.Event, 0, pts.Len())
 		for gi := 0; gi < pts.Len(); gi++ {
 			dataPt := pts.At(gi)
+			first := len(splunkMetrics)
 			fields := cloneMap(commonFields)
 			populateAttributes(fields, dataPt.Attributes())
 			switch dataPt.ValueType() {
@@ -166,7 +181,8 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 			}
 			fields[splunkMetricTypeKey] = pmetric.MetricTypeSum.String()
 			sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
-			splunkMetrics[gi] = sm
+			splunkMetrics = append(splunkMetrics, sm)
+			splunkMetrics = routeDataPoint(route, dataPt, splunkMetrics, first)
 		}
 		return splunkMetrics
 	case pmetric.MetricTypeSummary:
@@ -174,6 +190,7 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 		var splunkMetrics []*splunk.Event
 		for gi := 0; gi < pts.Len(); gi++ {
 			dataPt := pts.At(gi)
+			first := len(splunkMetrics)
 			// first, add one event for sum, and one for count
 			if !math.IsNaN(dataPt.Sum()) {
 				fields := cloneMap(commonFields)
@@ -203,6 +220,7 @@ func mapMetricToSplunkEvent(res pcommon.Resource, m pmetric.Metric, config *Conf
 				sm := createEvent(dataPt.Timestamp(), host, source, sourceType, index, fields)
 				splunkMetrics = append(splunkMetrics, sm)
 			}
+			splunkMetrics = routeDataPoint(route, dataPt, splunkMetrics, first)
 		}
 		return splunkMetrics
 	case pmetric.MetricTypeExponentialHistogram:
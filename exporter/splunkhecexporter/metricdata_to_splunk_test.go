@@ -546,7 +546,7 @@ func Test_metricDataToSplunk(t *testing.T) {
 			res := tt.resourceFn()
 			md := tt.metricsDataFn()
 			cfg := tt.configFn()
-			gotMetrics := mapMetricToSplunkEvent(res, md, cfg, zap.NewNop())
+			gotMetrics := mapMetricToSplunkEvent(res, md, cfg, zap.NewNop(), nil)
 			encoder := json.NewEncoder(io.Discard)
 			for i, want := range tt.wantSplunkMetrics {
 				assert.Equal(t, want, gotMetrics[i])
@@ -557,6 +557,41 @@ func Test_metricDataToSplunk(t *testing.T) {
 	}
 }
 
+func Test_metricDataToSplunkRouted(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("latency")
+	pts := metric.SetEmptyHistogram().DataPoints()
+	for _, team := range []string{"payments", "checkout"} {
+		pt := pts.AppendEmpty()
+		pt.Attributes().PutStr("team", team)
+		pt.SetCount(3)
+		pt.SetSum(6)
+		pt.ExplicitBounds().FromRaw([]float64{1, 2})
+		pt.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	}
+
+	// The events of the first data point are dropped and the ones of the second data point are routed.
+	var routed []int
+	route := func(dataPoint any, events []*splunk.Event) []*splunk.Event {
+		team, _ := dataPoint.(pmetric.HistogramDataPoint).Attributes().Get("team")
+		if team.Str() == "payments" {
+			return nil
+		}
+		routed = append(routed, len(events))
+		for _, event := range events {
+			event.Index = team.Str()
+		}
+		return events
+	}
+	events := mapMetricToSplunkEvent(pcommon.NewResource(), metric, &Config{}, zap.NewNop(), route)
+	assert.Equal(t, []int{5}, routed)
+	require.Len(t, events, 5)
+	for _, event := range events {
+		assert.Equal(t, "checkout", event.Index)
+		assert.Equal(t, "checkout", event.Fields["team"])
+	}
+}
+
 func Test_mergeEventsToMultiMetricFormat(t *testing.T) {
 	unixSecs := int64(1574092046)
 	unixNSecs := int64(11 * time.Millisecond)
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package splunkhecexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// eventRouting sets the index, source and sourcetype of the Splunk events to the values of the OTTL expressions
+// of the routing settings.
+type eventRouting[K any] struct {
+	logger         *zap.Logger
+	index          *ottl.ValueExpression[K]
+	source         *ottl.ValueExpression[K]
+	sourceType     *ottl.ValueExpression[K]
+	allowedIndexes map[string]bool
+}
+
+// newEventRouting parses the routing expressions with the NewParser function of an OTTL context, such as
+// ottllog.NewParser. It returns nil when no expression is configured.
+func newEventRouting[K any, O any](
+	cfg RoutingSettings,
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...O) (ottl.Parser[K], error),
+	settings component.TelemetrySettings,
+) (*eventRouting[K], error) {
+	if cfg.Index == "" && cfg.Source == "" && cfg.SourceType == "" {
+		return nil, nil
+	}
+
+	parser, err := newParser(ottlfuncs.StandardConverters[K](), settings)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &eventRouting[K]{logger: settings.Logger, allowedIndexes: map[string]bool{}}
+	for _, expression := range []struct {
+		name       string
+		value      string
+		expression **ottl.ValueExpression[K]
+	}{
+		{"index", cfg.Index, &r.index},
+		{"source", cfg.Source, &r.source},
+		{"sourcetype", cfg.SourceType, &r.sourceType},
+	} {
+		if expression.value == "" {
+			continue
+		}
+		*expression.expression, err = parser.ParseValueExpression(expression.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid routing %s %q: %w", expression.name, expression.value, err)
+		}
+	}
+	for _, index := range cfg.AllowedIndexes {
+		r.allowedIndexes[index] = true
+	}
+	return r, nil
+}
+
+// routedMetadata holds the values of the routing expressions, empty for the expressions evaluating to nil.
+type routedMetadata struct {
+	index      string
+	source     string
+	sourceType string
+}
+
+// route overrides the HEC metadata of the event with the values of the expressions.
+func (r *eventRouting[K]) route(ctx context.Context, tCtx K, event *splunk.Event) error {
+	metadata, err := r.evaluate(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	r.apply(metadata, event)
+	return nil
+}
+
+func (r *eventRouting[K]) evaluate(ctx context.Context, tCtx K) (routedMetadata, error) {
+	var metadata routedMetadata
+	var err error
+	if metadata.index, err = evaluate(ctx, r.index, tCtx); err != nil {
+		return metadata, fmt.Errorf("failed to evaluate the routing index: %w", err)
+	}
+	if metadata.source, err = evaluate(ctx, r.source, tCtx); err != nil {
+		return metadata, fmt.Errorf("failed to evaluate the routing source: %w", err)
+	}
+	if metadata.sourceType, err = evaluate(ctx, r.sourceType, tCtx); err != nil {
+		return metadata, fmt.Errorf("failed to evaluate the routing sourcetype: %w", err)
+	}
+	return metadata, nil
+}
+
+// apply sets the routed metadata of the event. The empty values, and the indexes missing from the allowed
+// indexes, keep the metadata of the event.
+func (r *eventRouting[K]) apply(metadata routedMetadata, event *splunk.Event) {
+	if metadata.index != "" {
+		if len(r.allowedIndexes) == 0 || r.allowedIndexes[metadata.index] {
+			event.Index = metadata.index
+		} else {
+			r.logger.Debug("Index not allowed, keeping the index of the event",
+				zap.String("index", metadata.index), zap.String("event_index", event.Index))
+		}
+	}
+	if metadata.source != "" {
+		event.Source = metadata.source
+	}
+	if metadata.sourceType != "" {
+		event.SourceType = metadata.sourceType
+	}
+}
+
+func evaluate[K any](ctx context.Context, expression *ottl.ValueExpression[K], tCtx K) (string, error) {
+	if expression == nil {
+		return "", nil
+	}
+	value, err := expression.EvalString(ctx, tCtx)
+	if err != nil || value == nil {
+		return "", err
+	}
+	return *value, nil
+}
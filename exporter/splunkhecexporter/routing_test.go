@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package splunkhecexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+func TestNewEventRouting(t *testing.T) {
+	settings := componenttest.NewNopTelemetrySettings()
+
+	r, err := newEventRouting(RoutingSettings{}, ottllog.NewParser, settings)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+
+	_, err = newEventRouting(RoutingSettings{SourceType: `attributes["sourcetype"`}, ottllog.NewParser, settings)
+	assert.ErrorContains(t, err, `invalid routing sourcetype "attributes[\"sourcetype\""`)
+
+	_, err = newEventRouting(RoutingSettings{Index: `unknown_path`}, ottllog.NewParser, settings)
+	assert.ErrorContains(t, err, `invalid routing index "unknown_path"`)
+}
+
+func TestEventRoutingRoute(t *testing.T) {
+	r, err := newEventRouting(RoutingSettings{
+		Index:          `attributes["team"]`,
+		Source:         `resource.attributes["service.name"]`,
+		SourceType:     `attributes["format"]`,
+		AllowedIndexes: []string{"payments", "checkout"},
+	}, ottllog.NewParser, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		expected   splunk.Event
+	}{
+		{
+			name:       "routed",
+			attributes: map[string]any{"team": "payments", "component": "api", "format": "json"},
+			expected:   splunk.Event{Index: "payments", Source: "cart", SourceType: "json"},
+		},
+		{
+			name:       "index not allowed",
+			attributes: map[string]any{"team": "marketing", "component": "api"},
+			expected:   splunk.Event{Index: "main", Source: "cart", SourceType: "otel"},
+		},
+		{
+			name:       "missing attributes",
+			attributes: map[string]any{},
+			expected:   splunk.Event{Index: "main", Source: "cart", SourceType: "otel"},
+		},
+		{
+			name:       "empty attribute",
+			attributes: map[string]any{"team": "", "format": ""},
+			expected:   splunk.Event{Index: "main", Source: "cart", SourceType: "otel"},
+		},
+		{
+			name:       "non string attribute",
+			attributes: map[string]any{"team": "checkout", "format": 3},
+			expected:   splunk.Event{Index: "checkout", Source: "cart", SourceType: "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := pcommon.NewResource()
+			resource.Attributes().PutStr("service.name", "cart")
+			lr := plog.NewLogRecord()
+			require.NoError(t, lr.Attributes().FromRaw(tt.attributes))
+
+			event := splunk.Event{Index: "main", Source: "otel", SourceType: "otel"}
+			tCtx := ottllog.NewTransformContext(lr, pcommon.NewInstrumentationScope(), resource)
+			require.NoError(t, r.route(context.Background(), tCtx, &event))
+			assert.Equal(t, tt.expected, event)
+		})
+	}
+}
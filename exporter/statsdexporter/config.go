@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	transportUDP      = "udp"
+	transportUnixgram = "unixgram"
+
+	protocolStatsD    = "statsd"
+	protocolDogStatsD = "dogstatsd"
+)
+
+// Config defines the configuration of the StatsD exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host:port of the StatsD server with the udp transport, or
+	// the path of its socket with the unixgram transport (default localhost:8125).
+	Endpoint string `mapstructure:"endpoint"`
+	// Transport is udp or unixgram (default udp).
+	Transport string `mapstructure:"transport"`
+	// Protocol is statsd or dogstatsd (default dogstatsd). The statsd protocol
+	// has no tags, so that the attributes are dropped.
+	Protocol string `mapstructure:"protocol"`
+	// Prefix is prepended to the metric names, followed by a dot.
+	Prefix string `mapstructure:"prefix"`
+	// MaxPacketSize is the maximum size of the datagrams, holding as many lines
+	// as fit (default 1432, which fits in the usual Ethernet MTU).
+	MaxPacketSize int `mapstructure:"max_packet_size"`
+	// Tags configures the DogStatsD tags of the lines.
+	Tags TagsConfig `mapstructure:"tags"`
+}
+
+// TagsConfig defines how the attributes are converted to DogStatsD tags.
+type TagsConfig struct {
+	// Static are tags added to every line, as key:value or value.
+	Static []string `mapstructure:"static"`
+	// Mapping renames the tags of the attributes, keyed by attribute. The attributes
+	// mapped to an empty name are dropped.
+	Mapping map[string]string `mapstructure:"mapping"`
+	// ResourceAttributes adds the resource attributes to the tags of the data
+	// points (default false).
+	ResourceAttributes bool `mapstructure:"resource_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch cfg.Transport {
+	case transportUDP, transportUnixgram:
+	default:
+		return fmt.Errorf("unsupported transport %q", cfg.Transport)
+	}
+	switch cfg.Protocol {
+	case protocolStatsD, protocolDogStatsD:
+	default:
+		return fmt.Errorf("unsupported protocol %q", cfg.Protocol)
+	}
+	if cfg.MaxPacketSize <= 0 {
+		return errors.New("max_packet_size must be positive")
+	}
+	for _, tag := range cfg.Tags.Static {
+		if tag == "" || strings.ContainsAny(tag, ",|#\n") {
+			return fmt.Errorf("invalid static tag %q", tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:             true,
+					InitialInterval:     10 * time.Second,
+					RandomizationFactor: 0.7,
+					Multiplier:          1.3,
+					MaxInterval:         60 * time.Second,
+					MaxElapsedTime:      10 * time.Minute,
+				},
+				Endpoint:      "/var/run/datadog/dsd.socket",
+				Transport:     transportUnixgram,
+				Protocol:      protocolDogStatsD,
+				Prefix:        "otel",
+				MaxPacketSize: 8192,
+				Tags: TagsConfig{
+					Static: []string{"env:production", "canary"},
+					Mapping: map[string]string{
+						"service.name": "service",
+						"http.url":     "",
+					},
+					ResourceAttributes: true,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_transport"),
+			errorMessage: `unsupported transport "tcp"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_protocol"),
+			errorMessage: `unsupported protocol "graphite"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_max_packet_size"),
+			errorMessage: "max_packet_size must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_static_tag"),
+			errorMessage: `invalid static tag "env:prod,team:a"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_endpoint"),
+			errorMessage: "endpoint must be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// seriesKey identifies a cumulative series.
+type seriesKey struct {
+	name       string
+	resource   [16]byte
+	attributes [16]byte
+}
+
+// seriesState is the last cumulative value of a series.
+type seriesState struct {
+	start    pcommon.Timestamp
+	value    float64
+	lastSeen time.Time
+}
+
+// seriesUpdate is a new cumulative value of a series.
+type seriesUpdate struct {
+	key   seriesKey
+	start pcommon.Timestamp
+	value float64
+}
+
+// deltaTracker converts cumulative values to the increase since the previous
+// value of their series, as StatsD counters expect.
+type deltaTracker struct {
+	maxStaleness time.Duration
+
+	// mu is held by the batch being sent, so that the deltas of concurrent
+	// batches are computed from the values committed by each other.
+	mu     sync.Mutex
+	series map[seriesKey]seriesState
+}
+
+func newDeltaTracker(maxStaleness time.Duration) *deltaTracker {
+	return &deltaTracker{maxStaleness: maxStaleness, series: map[seriesKey]seriesState{}}
+}
+
+// deltaBatch computes the deltas of the data points of a batch, which are only
+// recorded by the tracker for the data points sent once the batch is committed,
+// so that the deltas of the data points failing to be sent are computed again
+// when retried.
+type deltaBatch struct {
+	tracker *deltaTracker
+	updates map[seriesKey]seriesState
+}
+
+// newBatch starts a batch, waiting for the previous one to be committed. The
+// batch must be committed.
+func (t *deltaTracker) newBatch() *deltaBatch {
+	t.mu.Lock()
+	return &deltaBatch{tracker: t, updates: map[seriesKey]seriesState{}}
+}
+
+// delta returns the increase of the cumulative value of a series since its
+// previous value, and false for the first value of the series. A series whose
+// start time changed, or whose value decreased, was reset, and its delta is the
+// value itself.
+func (b *deltaBatch) delta(update seriesUpdate) (float64, bool) {
+	prev, ok := b.updates[update.key]
+	if !ok {
+		prev, ok = b.tracker.series[update.key]
+	}
+	b.updates[update.key] = seriesState{start: update.start, value: update.value}
+	if !ok {
+		return 0, false
+	}
+	if (update.start != 0 && update.start != prev.start) || update.value < prev.value {
+		return update.value, true
+	}
+	return update.value - prev.value, true
+}
+
+// commit records the values of the data points sent, forgets the series not
+// seen during maxStaleness and ends the batch.
+func (b *deltaBatch) commit(now time.Time, sent []seriesUpdate) {
+	defer b.tracker.mu.Unlock()
+	for _, update := range sent {
+		b.tracker.series[update.key] = seriesState{start: update.start, value: update.value, lastSeen: now}
+	}
+	for key, state := range b.tracker.series {
+		if now.Sub(state.lastSeen) > b.tracker.maxStaleness {
+			delete(b.tracker.series, key)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaTracker(t *testing.T) {
+	tracker := newDeltaTracker(time.Minute)
+	key := seriesKey{name: "requests"}
+	now := time.Unix(1000, 0)
+
+	batch := tracker.newBatch()
+	_, ok := batch.delta(seriesUpdate{key: key, start: 1, value: 10})
+	assert.False(t, ok, "first value has no delta")
+	delta, ok := batch.delta(seriesUpdate{key: key, start: 1, value: 15})
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, delta)
+	batch.commit(now, []seriesUpdate{{key: key, start: 1, value: 15}})
+
+	// The values which were not sent are not recorded.
+	batch = tracker.newBatch()
+	delta, ok = batch.delta(seriesUpdate{key: key, start: 1, value: 20})
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, delta)
+	batch.commit(now, nil)
+
+	batch = tracker.newBatch()
+	delta, ok = batch.delta(seriesUpdate{key: key, start: 1, value: 22})
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, delta)
+
+	// Resets
+	delta, ok = batch.delta(seriesUpdate{key: key, start: 1, value: 3})
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, delta)
+	delta, ok = batch.delta(seriesUpdate{key: key, start: 2, value: 4})
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, delta)
+	batch.commit(now, []seriesUpdate{{key: key, start: 2, value: 4}})
+
+	// Stale series are forgotten.
+	other := seriesKey{name: "errors"}
+	batch = tracker.newBatch()
+	batch.delta(seriesUpdate{key: other, start: 1, value: 1})
+	batch.commit(now.Add(2*time.Minute), []seriesUpdate{{key: other, start: 1, value: 1}})
+	assert.Len(t, tracker.series, 1)
+	assert.Contains(t, tracker.series, other)
+}
+
+func TestDeltaTrackerConcurrentBatches(t *testing.T) {
+	tracker := newDeltaTracker(time.Minute)
+	key := seriesKey{name: "requests"}
+	batch := tracker.newBatch()
+	batch.delta(seriesUpdate{key: key, start: 1, value: 0})
+	batch.commit(time.Now(), []seriesUpdate{{key: key, start: 1, value: 0}})
+
+	// Each batch computes its delta from the value committed by the previous one.
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		total float64
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			batch := tracker.newBatch()
+			prev := tracker.series[key].value
+			update := seriesUpdate{key: key, start: 1, value: prev + 1}
+			delta, _ := batch.delta(update)
+			batch.commit(time.Now(), []seriesUpdate{update})
+			mu.Lock()
+			total += delta
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10.0, total)
+	assert.Equal(t, 10.0, tracker.series[key].value)
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package statsdexporter exports metrics as StatsD or DogStatsD lines over UDP
+// or Unix domain sockets.
+package statsdexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter"
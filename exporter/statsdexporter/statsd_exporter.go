@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// maxSeriesStaleness is the time after which the cumulative series not seen
+// anymore are forgotten.
+const maxSeriesStaleness = time.Hour
+
+type statsDExporter struct {
+	config     *Config
+	settings   exporter.CreateSettings
+	translator *translator
+	dial       func(network, address string) (net.Conn, error)
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newStatsDExporter(config *Config, set exporter.CreateSettings) *statsDExporter {
+	return &statsDExporter{
+		config:     config,
+		settings:   set,
+		translator: newTranslator(config, newDeltaTracker(maxSeriesStaleness)),
+		dial:       net.Dial,
+	}
+}
+
+func (e *statsDExporter) start(context.Context, component.Host) error {
+	// Connecting to a socket which does not exist yet is retried by pushMetrics.
+	if _, err := e.connection(); err != nil {
+		e.settings.Logger.Warn("StatsD server not reachable yet", zap.Error(err))
+	}
+	return nil
+}
+
+func (e *statsDExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+func (e *statsDExporter) connection() (net.Conn, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		conn, err := e.dial(e.config.Transport, e.config.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", e.config.Endpoint, err)
+		}
+		e.conn = conn
+	}
+	return e.conn, nil
+}
+
+func (e *statsDExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	points, batch := e.translator.translate(md)
+	sent, err := e.send(points)
+	var updates []seriesUpdate
+	for _, point := range points[:sent] {
+		updates = append(updates, point.series...)
+	}
+	batch.commit(time.Now(), updates)
+	if err != nil && sent > 0 {
+		// Only the data points not sent are retried.
+		return consumererror.NewMetrics(err, unsentMetrics(md, points[sent:]))
+	}
+	return err
+}
+
+// send sends the lines of the data points, and returns the number of data
+// points whose lines were sent.
+func (e *statsDExporter) send(points []dataPointLines) (int, error) {
+	var (
+		sent int
+		conn net.Conn
+		err  error
+	)
+	for _, p := range packDataPoints(points, e.config.MaxPacketSize) {
+		if len(p.data) > 0 {
+			if conn == nil {
+				if conn, err = e.connection(); err != nil {
+					return sent, err
+				}
+			}
+			if _, err = conn.Write(p.data); err != nil {
+				// The connection is opened again by the next push.
+				e.mu.Lock()
+				if e.conn == conn {
+					_ = conn.Close()
+					e.conn = nil
+				}
+				e.mu.Unlock()
+				return sent, fmt.Errorf("failed to send metrics to %s: %w", e.config.Endpoint, err)
+			}
+		}
+		sent += p.dataPoints
+	}
+	return sent, nil
+}
+
+// packet holds lines separated with newlines, and the number of data points
+// whose last lines it holds.
+type packet struct {
+	data       []byte
+	dataPoints int
+}
+
+// packDataPoints packs the lines of the data points in packets of at most
+// maxSize bytes. The lines of a data point are kept in the same packet, unless
+// they are longer than maxSize. The lines longer than maxSize are sent alone.
+func packDataPoints(points []dataPointLines, maxSize int) []packet {
+	var (
+		packets    []packet
+		data       strings.Builder
+		dataPoints int
+	)
+	flush := func() {
+		packets = append(packets, packet{data: []byte(data.String()), dataPoints: dataPoints})
+		data.Reset()
+		dataPoints = 0
+	}
+	for _, point := range points {
+		size := len(point.lines) - 1
+		for _, line := range point.lines {
+			size += len(line)
+		}
+		if data.Len() > 0 && data.Len()+1+size > maxSize {
+			flush()
+		}
+		for _, line := range point.lines {
+			if data.Len() > 0 && data.Len()+1+len(line) > maxSize {
+				flush()
+			}
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(line)
+		}
+		dataPoints++
+	}
+	if dataPoints > 0 || data.Len() > 0 {
+		flush()
+	}
+	return packets
+}
+
+// unsentMetrics returns a copy of md holding only the data points not sent.
+func unsentMetrics(md pmetric.Metrics, unsent []dataPointLines) pmetric.Metrics {
+	keep := make(map[dataPointLocation]bool, len(unsent))
+	for _, point := range unsent {
+		keep[point.location] = true
+	}
+	remaining := pmetric.NewMetrics()
+	md.CopyTo(remaining)
+	var location dataPointLocation
+	remaining.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		location.scope = 0
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			location.metric = 0
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				location.index = 0
+				removed := removeDataPoints(m, func() bool {
+					sent := !keep[location]
+					location.index++
+					return sent
+				})
+				location.metric++
+				return removed
+			})
+			location.scope++
+			return sm.Metrics().Len() == 0
+		})
+		location.resource++
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return remaining
+}
+
+// removeDataPoints removes the data points of m, in order, for which remove
+// returns true, and returns whether m has no data points left.
+func removeDataPoints(m pmetric.Metric, remove func() bool) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+		return m.Gauge().DataPoints().Len() == 0
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return remove() })
+		return m.Sum().DataPoints().Len() == 0
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return remove() })
+		return m.Histogram().DataPoints().Len() == 0
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return remove() })
+		return m.ExponentialHistogram().DataPoints().Len() == 0
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return remove() })
+		return m.Summary().DataPoints().Len() == 0
+	}
+	return true
+}
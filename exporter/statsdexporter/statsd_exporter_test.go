@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestPushMetrics(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = listener.LocalAddr().String()
+	cfg.MaxPacketSize = 100
+	exp := newStatsDExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.shutdown(context.Background())) }()
+
+	lines := translateLines(newTranslator(cfg, newDeltaTracker(time.Hour)), testMetrics())
+	require.NoError(t, exp.pushMetrics(context.Background(), testMetrics()))
+
+	var received []string
+	buf := make([]byte, 65536)
+	for len(received) < len(lines) {
+		require.NoError(t, listener.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := listener.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, n, cfg.MaxPacketSize)
+		received = append(received, strings.Split(string(buf[:n]), "\n")...)
+	}
+	assert.Equal(t, lines, received)
+}
+
+func TestPushMetricsDialError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	exp := newStatsDExporter(cfg, exportertest.NewNopCreateSettings())
+	exp.dial = func(string, string) (net.Conn, error) {
+		return nil, errors.New("no such file or directory")
+	}
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	assert.EqualError(t, exp.pushMetrics(context.Background(), testMetrics()),
+		"failed to connect to localhost:8125: no such file or directory")
+}
+
+// failingConn is a connection failing to write after a number of packets.
+type failingConn struct {
+	net.Conn
+	packets []string
+	failAt  int
+}
+
+func (c *failingConn) Write(b []byte) (int, error) {
+	if len(c.packets) == c.failAt {
+		return 0, errors.New("connection refused")
+	}
+	c.packets = append(c.packets, string(b))
+	return len(b), nil
+}
+
+func (c *failingConn) Close() error {
+	return nil
+}
+
+func TestPushMetricsPartialFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Protocol = protocolStatsD
+	cfg.MaxPacketSize = 30
+	exp := newStatsDExporter(cfg, exportertest.NewNopCreateSettings())
+	conn := &failingConn{failAt: 1}
+	exp.dial = func(string, string) (net.Conn, error) {
+		return conn, nil
+	}
+
+	md := pmetric.NewMetrics()
+	sum := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, name := range []string{"a", "b", "c"} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetIntValue(1)
+		dp.Attributes().PutStr("name", name)
+	}
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("requests")
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	for i := 0; i < sum.DataPoints().Len(); i++ {
+		sum.DataPoints().At(i).SetIntValue(int64(i + 2))
+	}
+
+	// The first packet holds the lines of the first 2 data points.
+	err := exp.pushMetrics(context.Background(), md)
+	require.Error(t, err)
+	var failed consumererror.Metrics
+	require.True(t, errors.As(err, &failed))
+	assert.Equal(t, []string{"requests:1|c\nrequests:2|c"}, conn.packets)
+	retried := failed.Data()
+	require.Equal(t, 1, retried.DataPointCount())
+	dp := retried.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"name": "c"}, dp.Attributes().AsRaw())
+
+	// The data points sent are not sent again, and the delta of the others is
+	// computed from the values sent.
+	conn.failAt = -1
+	require.NoError(t, exp.pushMetrics(context.Background(), retried))
+	assert.Equal(t, []string{"requests:1|c\nrequests:2|c", "requests:3|c"}, conn.packets)
+}
+
+func TestPackDataPoints(t *testing.T) {
+	packets := packDataPoints([]dataPointLines{
+		{lines: []string{"a:1|c", "b:2|c"}},
+		{},
+		{lines: []string{"c:3|c"}},
+		{lines: []string{"d:4|c", "e:5|c"}},
+		{lines: []string{"a_very_long_name:6|c"}},
+	}, 11)
+	var got []string
+	var dataPoints []int
+	for _, p := range packets {
+		got = append(got, string(p.data))
+		dataPoints = append(dataPoints, p.dataPoints)
+	}
+	assert.Equal(t, []string{"a:1|c\nb:2|c", "c:3|c", "d:4|c\ne:5|c", "a_very_long_name:6|c"}, got)
+	assert.Equal(t, []int{2, 1, 1, 1}, dataPoints)
+}
@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/statsdexporter"
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const (
+	typeCounter = "c"
+	typeGauge   = "g"
+)
+
+// translator converts metrics to StatsD lines:
+//   - gauges and non-monotonic cumulative sums are sent as gauges,
+//   - monotonic sums are sent as counters, the cumulative ones being converted
+//     to the increase since their previous value,
+//   - non-monotonic delta sums are sent as relative gauges with StatsD, and as
+//     counters with DogStatsD, which does not support relative gauges,
+//   - histograms and summaries are sent as the .count and .sum counters, the
+//     .min and .max gauges of the histograms, and the .p<quantile> gauges of the
+//     summaries.
+type translator struct {
+	prefix    string
+	dogStatsD bool
+	tags      TagsConfig
+	deltas    *deltaTracker
+}
+
+func newTranslator(cfg *Config, deltas *deltaTracker) *translator {
+	t := &translator{
+		dogStatsD: cfg.Protocol == protocolDogStatsD,
+		tags:      cfg.Tags,
+		deltas:    deltas,
+	}
+	if cfg.Prefix != "" {
+		t.prefix = sanitizeName(cfg.Prefix) + "."
+	}
+	return t
+}
+
+// dataPointLocation is the location of a data point in a batch of metrics.
+type dataPointLocation struct {
+	resource, scope, metric, index int
+}
+
+// dataPointLines are the lines of a data point, and the cumulative series they
+// update. They are sent in the same packet, so that the data points failing to
+// be sent can be retried without sending the lines of the others again.
+type dataPointLines struct {
+	location dataPointLocation
+	lines    []string
+	series   []seriesUpdate
+}
+
+// lineWriter accumulates the lines of a batch of metrics.
+type lineWriter struct {
+	*translator
+	batch    *deltaBatch
+	points   []dataPointLines
+	location dataPointLocation
+
+	resourceHash [16]byte
+	resourceTags []string
+}
+
+// translate returns the lines of the data points of md, and the batch of the
+// deltas of the cumulative series, to commit once the lines were sent.
+func (t *translator) translate(md pmetric.Metrics) ([]dataPointLines, *deltaBatch) {
+	w := &lineWriter{translator: t, batch: t.deltas.newBatch()}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource().Attributes()
+		w.resourceHash = pdatautil.MapHash(resource)
+		w.resourceTags = nil
+		if t.dogStatsD && t.tags.ResourceAttributes {
+			w.resourceTags = t.appendTags(nil, resource)
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				w.location = dataPointLocation{resource: i, scope: j, metric: k}
+				w.writeMetric(ms.At(k))
+			}
+		}
+	}
+	return w.points, w.batch
+}
+
+// startDataPoint starts the lines of the data point at index of the current metric.
+func (w *lineWriter) startDataPoint(index int) {
+	w.location.index = index
+	w.points = append(w.points, dataPointLines{location: w.location})
+}
+
+func (w *lineWriter) writeMetric(m pmetric.Metric) {
+	name := w.prefix + sanitizeName(m.Name())
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			w.startDataPoint(i)
+			w.writeGauge(name, numberValue(dp), dp.Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			w.startDataPoint(i)
+			value := numberValue(dp)
+			cumulative := sum.AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+			switch {
+			case sum.IsMonotonic():
+				w.writeCounter(name, cumulative, dp.StartTimestamp(), value, dp.Attributes())
+			case cumulative:
+				w.writeGauge(name, value, dp.Attributes())
+			case w.dogStatsD:
+				w.writeLine(name, formatFloat(value), typeCounter, dp.Attributes())
+			default:
+				relative := formatFloat(value)
+				if value >= 0 {
+					relative = "+" + relative
+				}
+				w.writeLine(name, relative, typeGauge, dp.Attributes())
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		hist := m.Histogram()
+		cumulative := hist.AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+		dps := hist.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			w.startDataPoint(i)
+			w.writeCounter(name+".count", cumulative, dp.StartTimestamp(), float64(dp.Count()), dp.Attributes())
+			if dp.HasSum() {
+				w.writeCounter(name+".sum", cumulative, dp.StartTimestamp(), dp.Sum(), dp.Attributes())
+			}
+			if dp.HasMin() {
+				w.writeGauge(name+".min", dp.Min(), dp.Attributes())
+			}
+			if dp.HasMax() {
+				w.writeGauge(name+".max", dp.Max(), dp.Attributes())
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		hist := m.ExponentialHistogram()
+		cumulative := hist.AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+		dps := hist.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			w.startDataPoint(i)
+			w.writeCounter(name+".count", cumulative, dp.StartTimestamp(), float64(dp.Count()), dp.Attributes())
+			if dp.HasSum() {
+				w.writeCounter(name+".sum", cumulative, dp.StartTimestamp(), dp.Sum(), dp.Attributes())
+			}
+			if dp.HasMin() {
+				w.writeGauge(name+".min", dp.Min(), dp.Attributes())
+			}
+			if dp.HasMax() {
+				w.writeGauge(name+".max", dp.Max(), dp.Attributes())
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			w.startDataPoint(i)
+			w.writeCounter(name+".count", true, dp.StartTimestamp(), float64(dp.Count()), dp.Attributes())
+			w.writeCounter(name+".sum", true, dp.StartTimestamp(), dp.Sum(), dp.Attributes())
+			qs := dp.QuantileValues()
+			for j := 0; j < qs.Len(); j++ {
+				q := qs.At(j)
+				// Rounded to not name the quantiles after the floating point errors
+				quantile := strings.ReplaceAll(formatFloat(math.Round(q.Quantile()*1e6)/1e4), ".", "_")
+				w.writeGauge(name+".p"+quantile, q.Value(), dp.Attributes())
+			}
+		}
+	}
+}
+
+// writeCounter writes the counter line of a delta value, or of the increase of
+// a cumulative value since the previous value of its series.
+func (w *lineWriter) writeCounter(name string, cumulative bool, start pcommon.Timestamp, value float64, attributes pcommon.Map) {
+	if cumulative {
+		update := seriesUpdate{
+			key:   seriesKey{name: name, resource: w.resourceHash, attributes: pdatautil.MapHash(attributes)},
+			start: start,
+			value: value,
+		}
+		point := &w.points[len(w.points)-1]
+		point.series = append(point.series, update)
+		var ok bool
+		if value, ok = w.batch.delta(update); !ok {
+			return
+		}
+	}
+	w.writeLine(name, formatFloat(value), typeCounter, attributes)
+}
+
+func (w *lineWriter) writeGauge(name string, value float64, attributes pcommon.Map) {
+	// A negative value would decrement the StatsD gauge, which is reset first.
+	if value < 0 && !w.dogStatsD {
+		w.writeLine(name, "0", typeGauge, attributes)
+	}
+	w.writeLine(name, formatFloat(value), typeGauge, attributes)
+}
+
+func (w *lineWriter) writeLine(name string, value string, metricType string, attributes pcommon.Map) {
+	var line strings.Builder
+	line.WriteString(name)
+	line.WriteByte(':')
+	line.WriteString(value)
+	line.WriteByte('|')
+	line.WriteString(metricType)
+	if w.dogStatsD {
+		tags := make([]string, 0, len(w.tags.Static)+len(w.resourceTags)+attributes.Len())
+		tags = append(tags, w.tags.Static...)
+		tags = append(tags, w.resourceTags...)
+		tags = w.appendTags(tags, attributes)
+		if len(tags) > 0 {
+			line.WriteString("|#")
+			line.WriteString(strings.Join(tags, ","))
+		}
+	}
+	point := &w.points[len(w.points)-1]
+	point.lines = append(point.lines, line.String())
+}
+
+// appendTags appends the DogStatsD tags of attributes to tags.
+func (t *translator) appendTags(tags []string, attributes pcommon.Map) []string {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		if mapped, ok := t.tags.Mapping[k]; ok {
+			if mapped == "" {
+				return true
+			}
+			k = mapped
+		}
+		tags = append(tags, sanitizeTag(strings.ReplaceAll(k, ":", "_"))+":"+sanitizeTag(v.AsString()))
+		return true
+	})
+	return tags
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// sanitizeName replaces the characters separating the fields of the lines, and
+// the whitespaces, in metric names.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', '#', ',', ' ', '\t', '\n', '\r':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// sanitizeTag replaces the characters separating the tags in tag keys and values.
+func sanitizeTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ',', '|', '#', '\n', '\r':
+			return '_'
+		}
+		return r
+	}, tag)
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsdexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("queue size")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(-3)
+	dp.Attributes().PutStr("queue", "orders")
+
+	counter := ms.AppendEmpty()
+	counter.SetName("requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetDoubleValue(2.5)
+	dp.Attributes().PutStr("http.url", "/cart")
+	dp.Attributes().PutStr("code", "200")
+
+	updown := ms.AppendEmpty()
+	updown.SetName("connections")
+	sum = updown.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.DataPoints().AppendEmpty().SetIntValue(-2)
+
+	hist := ms.AppendEmpty()
+	hist.SetName("latency")
+	h := hist.SetEmptyHistogram()
+	h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := h.DataPoints().AppendEmpty()
+	hdp.SetCount(4)
+	hdp.SetSum(10)
+	hdp.SetMin(1)
+	hdp.SetMax(4)
+
+	summary := ms.AppendEmpty()
+	summary.SetName("duration")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetStartTimestamp(1)
+	sdp.SetCount(10)
+	sdp.SetSum(20)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.999)
+	q.SetValue(7)
+	return md
+}
+
+// translateLines returns the lines of md, committing the deltas of all its data
+// points.
+func translateLines(tr *translator, md pmetric.Metrics) []string {
+	points, batch := tr.translate(md)
+	var (
+		lines   []string
+		updates []seriesUpdate
+	)
+	for _, point := range points {
+		lines = append(lines, point.lines...)
+		updates = append(updates, point.series...)
+	}
+	batch.commit(time.Now(), updates)
+	return lines
+}
+
+func TestTranslateDogStatsD(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Prefix = "otel"
+	cfg.Tags = TagsConfig{
+		Static:             []string{"env:test"},
+		Mapping:            map[string]string{"service.name": "service", "http.url": ""},
+		ResourceAttributes: true,
+	}
+	tr := newTranslator(cfg, newDeltaTracker(time.Hour))
+
+	lines := translateLines(tr, testMetrics())
+	assert.Equal(t, []string{
+		"otel.queue_size:-3|g|#env:test,service:checkout,queue:orders",
+		"otel.requests:2.5|c|#env:test,service:checkout,code:200",
+		"otel.connections:-2|c|#env:test,service:checkout",
+		"otel.latency.count:4|c|#env:test,service:checkout",
+		"otel.latency.sum:10|c|#env:test,service:checkout",
+		"otel.latency.min:1|g|#env:test,service:checkout",
+		"otel.latency.max:4|g|#env:test,service:checkout",
+		"otel.duration.p99_9:7|g|#env:test,service:checkout",
+	}, lines)
+}
+
+func TestTranslateStatsD(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Protocol = protocolStatsD
+	cfg.Tags.Static = []string{"env:test"}
+	tr := newTranslator(cfg, newDeltaTracker(time.Hour))
+
+	lines := translateLines(tr, testMetrics())
+	assert.Equal(t, []string{
+		"queue_size:0|g",
+		"queue_size:-3|g",
+		"requests:2.5|c",
+		"connections:-2|g",
+		"latency.count:4|c",
+		"latency.sum:10|c",
+		"latency.min:1|g",
+		"latency.max:4|g",
+		"duration.p99_9:7|g",
+	}, lines)
+}
+
+func TestTranslateCumulative(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Protocol = protocolStatsD
+	tr := newTranslator(cfg, newDeltaTracker(time.Hour))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	counter := ms.AppendEmpty()
+	counter.SetName("requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(1)
+	dp.SetIntValue(10)
+	gauge := ms.AppendEmpty()
+	gauge.SetName("connections")
+	sum = gauge.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.DataPoints().AppendEmpty().SetIntValue(5)
+
+	assert.Equal(t, []string{"connections:5|g"}, translateLines(tr, md))
+
+	dp.SetIntValue(25)
+	assert.Equal(t, []string{"requests:15|c", "connections:5|g"}, translateLines(tr, md))
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "a_b_c_d_e", sanitizeName("a:b|c@d e"))
+	assert.Equal(t, "a:b_c_d", sanitizeTag("a:b,c|d"))
+}
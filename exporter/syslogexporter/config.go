@@ -5,6 +5,7 @@ package syslogexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"go.opentelemetry.io/collector/config/configtls"
@@ -17,6 +18,9 @@ var (
 	errInvalidEndpoint     = errors.New("invalid endpoint: endpoint is required but it is not configured")
 	errUnsupportedNetwork  = errors.New("unsupported network: network is required, only tcp/udp supported")
 	errUnsupportedProtocol = errors.New("unsupported protocol: Only rfc5424 and rfc3164 supported")
+	errUnsupportedFraming  = errors.New("unsupported framing: only non_transparent and octet_counting supported")
+	errFramingOverUDP      = errors.New("unsupported framing: octet_counting is only supported over tcp")
+	errStructuredData      = errors.New("unsupported structured_data: structured data is only supported by rfc5424")
 )
 
 // Config defines configuration for Syslog exporter.
@@ -31,6 +35,11 @@ type Config struct {
 	// Protocol of syslog messages
 	// options: rfc5424, rfc3164
 	Protocol string `mapstructure:"protocol"`
+	// Framing of syslog messages over TCP (RFC6587)
+	// options: non_transparent, octet_counting
+	Framing string `mapstructure:"framing"`
+	// StructuredData maps attributes of the log records to RFC5424 structured data elements
+	StructuredData []StructuredDataElement `mapstructure:"structured_data"`
 
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
@@ -62,6 +71,25 @@ func (cfg *Config) Validate() error {
 		invalidFields = append(invalidFields, errUnsupportedProtocol)
 	}
 
+	switch cfg.Framing {
+	case "", framingNonTransparent:
+	case framingOctetCounting:
+		if strings.ToLower(cfg.Network) == "udp" {
+			invalidFields = append(invalidFields, errFramingOverUDP)
+		}
+	default:
+		invalidFields = append(invalidFields, errUnsupportedFraming)
+	}
+
+	if len(cfg.StructuredData) > 0 && cfg.Protocol == protocolRFC3164Str {
+		invalidFields = append(invalidFields, errStructuredData)
+	}
+	for _, element := range cfg.StructuredData {
+		if err := element.Validate(); err != nil {
+			invalidFields = append(invalidFields, err)
+		}
+	}
+
 	if len(invalidFields) > 0 {
 		return multierr.Combine(invalidFields...)
 	}
@@ -69,6 +97,48 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// StructuredDataElement defines a structured data element of the syslog messages, whose params are taken from
+// the attributes of the log records.
+type StructuredDataElement struct {
+	// ID of the element, e.g. "origin" or "exampleSDID@32473"
+	ID string `mapstructure:"id"`
+	// Params maps the names of the params of the element to the names of the attributes holding their values
+	Params map[string]string `mapstructure:"params"`
+}
+
+// Validate checks that the ID and the param names are valid RFC5424 SD-NAMEs.
+func (e StructuredDataElement) Validate() error {
+	if !isValidSDName(e.ID) {
+		return fmt.Errorf("invalid structured data ID %q", e.ID)
+	}
+	if len(e.Params) == 0 {
+		return fmt.Errorf("structured data element %q has no params", e.ID)
+	}
+	for name, attribute := range e.Params {
+		if !isValidSDName(name) {
+			return fmt.Errorf("invalid param name %q of structured data element %q", name, e.ID)
+		}
+		if attribute == "" {
+			return fmt.Errorf("param %q of structured data element %q has no attribute", name, e.ID)
+		}
+	}
+	return nil
+}
+
+// isValidSDName reports whether name is 1 to 32 printable US-ASCII characters, except '=', ' ', ']' and '"'.
+func isValidSDName(name string) bool {
+	if len(name) == 0 || len(name) > 32 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			return false
+		}
+	}
+	return true
+}
+
 const (
 	// Syslog Network
 	DefaultNetwork = "tcp"
@@ -76,4 +146,6 @@ const (
 	DefaultPort = 514
 	// Syslog Protocol
 	DefaultProtocol = "rfc5424"
+	// Syslog Framing
+	DefaultFraming = "non_transparent"
 )
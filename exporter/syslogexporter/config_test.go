@@ -57,6 +57,73 @@ func TestValidate(t *testing.T) {
 			},
 			err: "unsupported protocol: Only rfc5424 and rfc3164 supported",
 		},
+		{
+			name: "Unsupported Framing",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				Framing:  "octet_stuffing",
+			},
+			err: "unsupported framing: only non_transparent and octet_counting supported",
+		},
+		{
+			name: "Octet Counting over UDP",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "udp",
+				Protocol: "rfc5424",
+				Framing:  "octet_counting",
+			},
+			err: "unsupported framing: octet_counting is only supported over tcp",
+		},
+		{
+			name: "Structured Data with RFC3164",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc3164",
+				StructuredData: []StructuredDataElement{
+					{ID: "origin", Params: map[string]string{"ip": "host.ip"}},
+				},
+			},
+			err: "unsupported structured_data: structured data is only supported by rfc5424",
+		},
+		{
+			name: "Invalid Structured Data",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				StructuredData: []StructuredDataElement{
+					{ID: "my origin", Params: map[string]string{"ip": "host.ip"}},
+					{ID: "origin"},
+					{ID: "meta", Params: map[string]string{"user=id": "user.id"}},
+					{ID: "exampleSDID@32473", Params: map[string]string{"user": ""}},
+				},
+			},
+			err: "invalid structured data ID \"my origin\"; " +
+				"structured data element \"origin\" has no params; " +
+				"invalid param name \"user=id\" of structured data element \"meta\"; " +
+				"param \"user\" of structured data element \"exampleSDID@32473\" has no attribute",
+		},
+		{
+			name: "Valid Structured Data and Framing",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				Framing:  "octet_counting",
+				StructuredData: []StructuredDataElement{
+					{ID: "exampleSDID@32473", Params: map[string]string{"eventSource": "event.source", "user": "user.id"}},
+				},
+			},
+		},
 	}
 	for _, testInstance := range tests {
 		t.Run(testInstance.name, func(t *testing.T) {
@@ -36,7 +36,7 @@ func initExporter(cfg *Config, createSettings exporter.CreateSettings) (*sysloge
 		config:    cfg,
 		logger:    createSettings.Logger,
 		tlsConfig: tlsConfig,
-		formatter: createFormatter(cfg.Protocol),
+		formatter: createFormatter(cfg.Protocol, cfg.StructuredData),
 	}
 
 	s.logger.Info("Syslog Exporter configured",
@@ -89,7 +89,7 @@ func (se *syslogexporter) exportBatch(logs plog.Logs) error {
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
 				formatted := se.formatter.format(logRecord)
-				payload.WriteString(formatted)
+				payload.WriteString(frame(formatted, se.config.Framing))
 			}
 		}
 	}
@@ -126,7 +126,7 @@ func (se *syslogexporter) exportNonBatch(logs plog.Logs) error {
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
 				formatted := se.formatter.format(logRecord)
-				err = sender.Write(formatted)
+				err = sender.Write(frame(formatted, se.config.Framing))
 				if err != nil {
 					errs = append(errs, err)
 					droppedLogRecord := droppedScopeLogs.LogRecords().AppendEmpty()
@@ -5,16 +5,19 @@ package syslogexporter
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -183,3 +186,79 @@ func TestSyslogExportFail(t *testing.T) {
 	assert.ErrorContains(t, consumerErr, "dial tcp 127.0.0.1:112: connect")
 	assert.Equal(t, droppedLog, originalForm)
 }
+
+func TestSyslogExportOctetCounting(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Framing = "octet_counting"
+	test := prepareExporterTest(t, cfg, false)
+	defer test.srv.Close()
+	go func() {
+		logs := logRecordsToLogs(exampleLog(t))
+		exampleLog(t).CopyTo(logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+		err := test.exp.pushLogsData(context.Background(), logs)
+		require.NoError(t, err, "could not send message")
+	}()
+	err := test.srv.SetDeadline(time.Now().Add(time.Second * 1))
+	require.NoError(t, err, "cannot set deadline")
+	conn, err := test.srv.AcceptTCP()
+	require.NoError(t, err, "could not accept connection")
+	defer conn.Close()
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err, "could not read all")
+	msg := strings.TrimSuffix(expectedForm, "\n")
+	framed := strconv.Itoa(len(msg)) + " " + msg
+	assert.Equal(t, framed+framed, string(b))
+}
+
+func TestSyslogExportTLSClientAuth(t *testing.T) {
+	serverTLS := configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: "testdata/server.crt",
+			KeyFile:  "testdata/server.key",
+		},
+		ClientCAFile: "testdata/ca.crt",
+	}
+	serverTLSConfig, err := serverTLS.LoadTLSConfig()
+	require.NoError(t, err)
+	srv, err := tls.Listen("tcp", "127.0.0.1:0", serverTLSConfig)
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile:   "testdata/ca.crt",
+			CertFile: "testdata/client.crt",
+			KeyFile:  "testdata/client.key",
+		},
+		ServerName: "localhost",
+	}
+	var port string
+	cfg.Endpoint, port, err = net.SplitHostPort(srv.Addr().String())
+	require.NoError(t, err)
+	cfg.Port, err = strconv.Atoi(port)
+	require.NoError(t, err)
+	exp, err := initExporter(cfg, createExporterCreateSettings())
+	require.NoError(t, err)
+
+	received := make(chan string, 1)
+	go func() {
+		conn, acceptErr := srv.Accept()
+		if acceptErr != nil {
+			received <- acceptErr.Error()
+			return
+		}
+		defer conn.Close()
+		b, _ := io.ReadAll(conn)
+		received <- string(b)
+	}()
+
+	err = exp.pushLogsData(context.Background(), logRecordsToLogs(exampleLog(t)))
+	require.NoError(t, err)
+	select {
+	case msg := <-received:
+		assert.Equal(t, expectedForm, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the syslog message wasn't received")
+	}
+}
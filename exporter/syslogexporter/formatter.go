@@ -7,9 +7,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
-func createFormatter(protocol string) formatter {
+func createFormatter(protocol string, structuredData []StructuredDataElement) formatter {
 	if protocol == protocolRFC5424Str {
-		return newRFC5424Formatter()
+		return newRFC5424Formatter(structuredData)
 	}
 	return newRFC3164Formatter()
 }
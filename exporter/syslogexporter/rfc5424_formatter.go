@@ -5,7 +5,9 @@ package syslogexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -13,10 +15,11 @@ import (
 )
 
 type rfc5424Formatter struct {
+	structuredData []StructuredDataElement
 }
 
-func newRFC5424Formatter() *rfc5424Formatter {
-	return &rfc5424Formatter{}
+func newRFC5424Formatter(structuredData []StructuredDataElement) *rfc5424Formatter {
+	return &rfc5424Formatter{structuredData: structuredData}
 }
 
 func (f *rfc5424Formatter) format(logRecord plog.LogRecord) string {
@@ -61,32 +64,62 @@ func (f *rfc5424Formatter) formatMessageID(logRecord plog.LogRecord) string {
 	return getAttributeValueOrDefault(logRecord, msgID, emptyValue)
 }
 
+// formatStructuredData formats the elements of the structured_data attribute, followed by the configured
+// elements whose params are found in the attributes. The configured elements with the same ID as an element
+// of the structured_data attribute are skipped, as an ID must not appear twice.
 func (f *rfc5424Formatter) formatStructuredData(logRecord plog.LogRecord) string {
-	structuredDataAttributeValue, found := logRecord.Attributes().Get(structuredData)
-	if !found {
-		return emptyValue
-	}
-	if structuredDataAttributeValue.Type() != pcommon.ValueTypeMap {
-		return emptyValue
+	var sb strings.Builder
+	ids := map[string]bool{}
+
+	if structuredDataAttributeValue, found := logRecord.Attributes().Get(structuredData); found && structuredDataAttributeValue.Type() == pcommon.ValueTypeMap {
+		structuredDataAttributeValue.Map().Range(func(id string, val pcommon.Value) bool {
+			ids[id] = true
+			sb.WriteString("[" + id)
+			if val.Type() == pcommon.ValueTypeMap {
+				val.Map().Range(func(k string, v pcommon.Value) bool {
+					if v.Type() == pcommon.ValueTypeStr {
+						writeSDParam(&sb, k, v.Str())
+					}
+					return true
+				})
+			}
+			sb.WriteString("]")
+			return true
+		})
 	}
 
-	sdElements := []string{}
-	for key, val := range structuredDataAttributeValue.Map().AsRaw() {
-		sdElements = append(sdElements, key)
-		vval, ok := val.(map[string]interface{})
-		if !ok {
+	for _, element := range f.structuredData {
+		if ids[element.ID] {
 			continue
 		}
-		for k, v := range vval {
-			vv, ok := v.(string)
-			if !ok {
-				continue
+		names := make([]string, 0, len(element.Params))
+		for name := range element.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var params strings.Builder
+		for _, name := range names {
+			if v, found := logRecord.Attributes().Get(element.Params[name]); found {
+				writeSDParam(&params, name, v.AsString())
 			}
-			sdElements = append(sdElements, fmt.Sprintf("%s=\"%s\"", k, vv))
 		}
+		if params.Len() > 0 {
+			sb.WriteString("[" + element.ID + params.String() + "]")
+		}
+	}
+
+	if sb.Len() == 0 {
+		return emptyValue
 	}
-	return fmt.Sprint(sdElements)
+	return sb.String()
+}
+
+// sdParamValueEscaper escapes the characters which must be escaped in the values of structured data params.
+var sdParamValueEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
 
+func writeSDParam(sb *strings.Builder, name string, value string) {
+	sb.WriteString(" " + name + `="` + sdParamValueEscaper.Replace(value) + `"`)
 }
 
 func (f *rfc5424Formatter) formatMessage(logRecord plog.LogRecord) string {
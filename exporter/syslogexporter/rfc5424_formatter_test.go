@@ -29,7 +29,7 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual := newRFC5424Formatter().format(logRecord)
+	actual := newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
 
 	expected = "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 111 ID47 - BOMAn application event log entry...\n"
@@ -45,7 +45,7 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter().format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
 
 	// Test structured data
@@ -72,7 +72,7 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter().format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.NoError(t, err)
 	matched, err := regexp.MatchString(expectedRegex, actual)
 	assert.NoError(t, err)
@@ -89,6 +89,39 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter().format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
 }
+
+func TestRFC5424FormatterStructuredDataMapping(t *testing.T) {
+	structuredData := []StructuredDataElement{
+		{ID: "exampleSDID@32473", Params: map[string]string{"iut": "iut", "eventSource": "event.source"}},
+		{ID: "origin", Params: map[string]string{"ip": "host.ip"}},
+		{ID: "meta", Params: map[string]string{"sequenceId": "sequence.id"}},
+	}
+
+	logRecord := plog.NewLogRecord()
+	logRecord.Attributes().PutStr("appname", "evntslog")
+	logRecord.Attributes().PutStr("hostname", "mymachine.example.com")
+	logRecord.Attributes().PutStr("message", "An application event log entry...")
+	logRecord.Attributes().PutStr("msg_id", "ID47")
+	logRecord.Attributes().PutInt("iut", 3)
+	logRecord.Attributes().PutStr("event.source", `App "lication" [1]\`)
+	logRecord.Attributes().PutStr("host.ip", "192.0.2.1")
+	timestamp, err := time.Parse(time.RFC3339Nano, "2003-10-11T22:14:15.003Z")
+	require.NoError(t, err)
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+
+	expected := "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 " +
+		`[exampleSDID@32473 eventSource="App \"lication\" [1\]\\" iut="3"][origin ip="192.0.2.1"] ` +
+		"An application event log entry...\n"
+	assert.Equal(t, expected, newRFC5424Formatter(structuredData).format(logRecord))
+
+	// The elements of the structured_data attribute take precedence over the configured ones with the same ID
+	origin := logRecord.Attributes().PutEmptyMap("structured_data").PutEmptyMap("origin")
+	origin.PutStr("ip", "192.0.2.2")
+	expected = "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 " +
+		`[origin ip="192.0.2.2"][exampleSDID@32473 eventSource="App \"lication\" [1\]\\" iut="3"] ` +
+		"An application event log entry...\n"
+	assert.Equal(t, expected, newRFC5424Formatter(structuredData).format(logRecord))
+}
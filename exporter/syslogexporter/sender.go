@@ -19,6 +19,9 @@ const versionRFC5424 = 1
 const protocolRFC5424Str = "rfc5424"
 const protocolRFC3164Str = "rfc3164"
 
+const framingNonTransparent = "non_transparent"
+const framingOctetCounting = "octet_counting"
+
 const priority = "priority"
 const version = "version"
 const hostname = "hostname"
@@ -101,11 +104,22 @@ func (s *sender) Write(msgStr string) error {
 
 	return s.write(msgStr)
 }
+
 func (s *sender) write(msg string) error {
+	_, err := fmt.Fprint(s.conn, msg)
+	return err
+}
+
+// frame frames the message as per RFC6587: prefixed with its length in octets for the octet counting framing,
+// and terminated by a new line character otherwise.
+func frame(msg string, framing string) string {
+	if framing == framingOctetCounting {
+		msg = strings.TrimSuffix(msg, "\n")
+		return fmt.Sprintf("%d %s", len(msg), msg)
+	}
 	// check if logs contains new line character at the end, if not add it
 	if !strings.HasSuffix(msg, "\n") {
 		msg = fmt.Sprintf("%s%s", msg, "\n")
 	}
-	_, err := fmt.Fprint(s.conn, msg)
-	return err
+	return msg
 }
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the admin UI extension.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// MetricsEndpoint is the URL of the Prometheus endpoint exposing the
+	// collector's own metrics, which are used to show the throughput of the
+	// receivers and exporters. The throughput is not shown if empty.
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+
+	// MaxRecentErrors is the number of most recent component errors shown.
+	MaxRecentErrors int `mapstructure:"max_recent_errors"`
+
+	// AllowedKeys is a list of configuration keys whose values are shown, in
+	// addition to the keys shown by default. All the other values are masked.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+var (
+	errNoEndpoint             = errors.New("endpoint must be specified")
+	errInvalidMaxRecentErrors = errors.New("max_recent_errors must be positive")
+)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errNoEndpoint
+	}
+	if cfg.MaxRecentErrors <= 0 {
+		return errInvalidMaxRecentErrors
+	}
+	if cfg.MetricsEndpoint != "" {
+		u, err := url.ParseRequestURI(cfg.MetricsEndpoint)
+		if err != nil {
+			return fmt.Errorf("invalid metrics_endpoint: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid metrics_endpoint: unsupported scheme %q", u.Scheme)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package adminuiextension implements an extension that serves a read-only web
+// page showing the pipelines, the configuration, the throughput and the recent
+// errors of the collector.
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// pipeline is a pipeline of the service, with the IDs of its components.
+type pipeline struct {
+	Name       string   `json:"name"`
+	Receivers  []string `json:"receivers" mapstructure:"receivers"`
+	Processors []string `json:"processors" mapstructure:"processors"`
+	Exporters  []string `json:"exporters" mapstructure:"exporters"`
+}
+
+// componentStatus is a status reported by a component.
+type componentStatus struct {
+	Kind      string    `json:"kind"`
+	Component string    `json:"component"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+// status is the state of the collector shown by the admin UI.
+type status struct {
+	Pipelines       []pipeline        `json:"pipelines"`
+	Components      []componentStatus `json:"components"`
+	RecentErrors    []componentStatus `json:"recent_errors"`
+	Throughput      []*throughput     `json:"throughput,omitempty"`
+	ThroughputError string            `json:"throughput_error,omitempty"`
+	ConfigAvailable bool              `json:"config_available"`
+}
+
+type adminUI struct {
+	config   *Config
+	logger   *zap.Logger
+	settings component.TelemetrySettings
+	redactor redactor
+	scraper  *throughputScraper
+	server   *http.Server
+	stopCh   chan struct{}
+
+	mu           sync.Mutex
+	renderedConf []byte
+	pipelines    []pipeline
+	statuses     map[componentKey]componentStatus
+	recentErrors []componentStatus
+}
+
+var _ extension.ConfigWatcher = (*adminUI)(nil)
+var _ extension.StatusWatcher = (*adminUI)(nil)
+
+func newAdminUI(config *Config, settings component.TelemetrySettings) *adminUI {
+	a := &adminUI{
+		config:   config,
+		logger:   settings.Logger,
+		settings: settings,
+		redactor: newRedactor(config.AllowedKeys),
+		statuses: make(map[componentKey]componentStatus),
+	}
+	if config.MetricsEndpoint != "" {
+		a.scraper = newThroughputScraper(config.MetricsEndpoint)
+	}
+	return a
+}
+
+func (a *adminUI) Start(_ context.Context, host component.Host) error {
+	a.logger.Info("Starting admin UI extension", zap.String("endpoint", a.config.Endpoint))
+	ln, err := a.config.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", a.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", readOnly(a.handleIndex))
+	mux.HandleFunc("/config", readOnly(a.handleConfig))
+	mux.HandleFunc("/api/status", readOnly(a.handleStatus))
+	a.server, err = a.config.ToServer(host, a.settings, mux)
+	if err != nil {
+		return err
+	}
+
+	a.stopCh = make(chan struct{})
+	go func() {
+		defer close(a.stopCh)
+
+		// The listener ownership goes to the server.
+		if errHTTP := a.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (a *adminUI) Shutdown(context.Context) error {
+	if a.server == nil {
+		return nil
+	}
+	err := a.server.Close()
+	if a.stopCh != nil {
+		<-a.stopCh
+	}
+	return err
+}
+
+// NotifyConfig keeps the effective configuration of the collector, with the
+// values of the keys that are not allowed masked, and the pipelines it defines.
+func (a *adminUI) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	rendered, err := yaml.Marshal(a.redactor.redactMap(conf.ToStringMap()))
+	if err != nil {
+		return fmt.Errorf("failed to render the configuration: %w", err)
+	}
+
+	var service struct {
+		Pipelines map[string]pipeline `mapstructure:"pipelines"`
+	}
+	serviceConf, err := conf.Sub("service")
+	if err != nil {
+		return err
+	}
+	if err = serviceConf.Unmarshal(&service); err != nil {
+		return fmt.Errorf("failed to read the pipelines: %w", err)
+	}
+	pipelines := make([]pipeline, 0, len(service.Pipelines))
+	for name, p := range service.Pipelines {
+		p.Name = name
+		pipelines = append(pipelines, p)
+	}
+	sort.Slice(pipelines, func(i, j int) bool { return pipelines[i].Name < pipelines[j].Name })
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.renderedConf = rendered
+	a.pipelines = pipelines
+	return nil
+}
+
+// ComponentStatusChanged keeps the latest status of each component, and the
+// most recent errors.
+func (a *adminUI) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	s := componentStatus{
+		Kind:      kindName(source.Kind),
+		Component: source.ID.String(),
+		Status:    strings.TrimPrefix(event.Status().String(), "Status"),
+		Timestamp: event.Timestamp(),
+	}
+	if event.Err() != nil {
+		s.Error = event.Err().Error()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.statuses[componentKey{kind: source.Kind, id: source.ID}] = s
+	if s.Error != "" {
+		a.recentErrors = append(a.recentErrors, s)
+		if len(a.recentErrors) > a.config.MaxRecentErrors {
+			a.recentErrors = a.recentErrors[len(a.recentErrors)-a.config.MaxRecentErrors:]
+		}
+	}
+}
+
+// status returns the current state of the collector. The throughput is scraped
+// on each call, so that it is live.
+func (a *adminUI) status(ctx context.Context) status {
+	var s status
+	if a.scraper != nil {
+		throughput, err := a.scraper.scrape(ctx)
+		if err != nil {
+			s.ThroughputError = err.Error()
+		}
+		s.Throughput = throughput
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s.ConfigAvailable = a.renderedConf != nil
+	s.Pipelines = a.pipelines
+	s.Components = make([]componentStatus, 0, len(a.statuses))
+	for _, cs := range a.statuses {
+		s.Components = append(s.Components, cs)
+	}
+	sort.Slice(s.Components, func(i, j int) bool {
+		if s.Components[i].Kind != s.Components[j].Kind {
+			return s.Components[i].Kind < s.Components[j].Kind
+		}
+		return s.Components[i].Component < s.Components[j].Component
+	})
+	// Most recent first
+	s.RecentErrors = make([]componentStatus, len(a.recentErrors))
+	for i, e := range a.recentErrors {
+		s.RecentErrors[len(a.recentErrors)-1-i] = e
+	}
+	return s
+}
+
+func (a *adminUI) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, a.status(r.Context())); err != nil {
+		a.logger.Warn("Failed to render the admin UI page", zap.Error(err))
+	}
+}
+
+func (a *adminUI) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	a.mu.Lock()
+	rendered := a.renderedConf
+	a.mu.Unlock()
+	if rendered == nil {
+		http.Error(w, "the configuration has not been notified yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(rendered)
+}
+
+func (a *adminUI) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.status(r.Context())); err != nil {
+		a.logger.Warn("Failed to encode the status", zap.Error(err))
+	}
+}
+
+// readOnly rejects the requests of handler that are not GET or HEAD.
+func readOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func kindName(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return "unknown"
+	}
+}
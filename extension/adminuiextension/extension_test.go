@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url) // nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestAdminUI(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MetricsEndpoint = ""
+	cfg.MaxRecentErrors = 2
+
+	ext := newAdminUI(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	url := "http://" + cfg.Endpoint
+
+	code, _ := get(t, url+"/config")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	require.NoError(t, ext.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"exporters": map[string]any{
+			"otlphttp": map[string]any{
+				"endpoint": "https://example.com",
+				"headers":  map[string]any{"Authorization": "Bearer abc"},
+			},
+		},
+		"receivers": map[string]any{
+			"otlp": map[string]any{"protocols": map[string]any{"grpc": nil}},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers": []any{"otlp"},
+					"exporters": []any{"otlphttp"},
+				},
+				"logs/2": map[string]any{
+					"receivers":  []any{"otlp"},
+					"processors": []any{"batch"},
+					"exporters":  []any{"otlphttp"},
+				},
+			},
+		},
+	})))
+
+	exporterID := &component.InstanceID{ID: component.NewID("otlphttp"), Kind: component.KindExporter}
+	ext.ComponentStatusChanged(exporterID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	ext.ComponentStatusChanged(exporterID, component.NewRecoverableErrorEvent(errors.New("timeout")))
+	ext.ComponentStatusChanged(exporterID, component.NewPermanentErrorEvent(errors.New("unauthorized")))
+	ext.ComponentStatusChanged(&component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}, component.NewStatusEvent(component.StatusOK))
+
+	code, body := get(t, url+"/config")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "endpoint: https://example.com")
+	assert.Contains(t, body, "Authorization: '[REDACTED]'")
+	assert.NotContains(t, body, "Bearer abc")
+
+	code, body = get(t, url+"/api/status")
+	assert.Equal(t, http.StatusOK, code)
+	var s status
+	require.NoError(t, json.Unmarshal([]byte(body), &s))
+	assert.True(t, s.ConfigAvailable)
+	assert.Equal(t, []pipeline{
+		{Name: "logs/2", Receivers: []string{"otlp"}, Processors: []string{"batch"}, Exporters: []string{"otlphttp"}},
+		{Name: "traces", Receivers: []string{"otlp"}, Exporters: []string{"otlphttp"}},
+	}, s.Pipelines)
+	require.Len(t, s.Components, 2)
+	assert.Equal(t, "exporter", s.Components[0].Kind)
+	assert.Equal(t, "PermanentError", s.Components[0].Status)
+	assert.Equal(t, "receiver", s.Components[1].Kind)
+	assert.Equal(t, "OK", s.Components[1].Status)
+	// Only the most recent errors are kept, most recent first
+	require.Len(t, s.RecentErrors, 2)
+	assert.Equal(t, "unauthorized", s.RecentErrors[0].Error)
+	assert.Equal(t, "timeout", s.RecentErrors[1].Error)
+
+	code, body = get(t, url+"/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "<td>logs/2</td><td>otlp</td><td>batch</td><td>otlphttp</td>")
+	assert.Contains(t, body, "unauthorized")
+
+	code, _ = get(t, url+"/unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	resp, err := http.Post(url+"/config", "text/plain", strings.NewReader("")) // nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestAdminUIPortAlreadyInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	ext := newAdminUI(cfg, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+}
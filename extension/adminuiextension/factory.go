@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint        = "localhost:13180"
+	defaultMetricsEndpoint = "http://localhost:8888/metrics"
+	defaultMaxRecentErrors = 20
+)
+
+// NewFactory creates a factory for the admin UI extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		MetricsEndpoint: defaultMetricsEndpoint,
+		MaxRecentErrors: defaultMaxRecentErrors,
+	}
+}
+
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newAdminUI(cfg.(*Config), set.TelemetrySettings), nil
+}
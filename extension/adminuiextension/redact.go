@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// defaultAllowedKeys are the configuration keys whose values are shown. The
+// values of all the other keys are masked, so that a secret stored under a key
+// that is not known to be sensitive is never shown.
+var defaultAllowedKeys = []string{
+	// service
+	"receivers",
+	"processors",
+	"exporters",
+	"extensions",
+	"connectors",
+	"level",
+	"verbosity",
+	// common settings of the components
+	"endpoint",
+	"endpoints",
+	"enabled",
+	"timeout",
+	"interval",
+	"collection_interval",
+	"initial_interval",
+	"max_interval",
+	"max_elapsed_time",
+	"num_consumers",
+	"queue_size",
+	"send_batch_size",
+	"send_batch_max_size",
+	"encoding",
+	"compression",
+	"protocol_version",
+	"brokers",
+	"topic",
+	"include",
+	"exclude",
+	"start_at",
+	"insecure",
+	"insecure_skip_verify",
+	"check_interval",
+	"limit_mib",
+	"spike_limit_mib",
+}
+
+// redactor masks the values of the keys of a configuration that are not
+// allowed.
+type redactor struct {
+	allowed map[string]bool
+}
+
+func newRedactor(allowedKeys []string) redactor {
+	allowed := make(map[string]bool, len(defaultAllowedKeys)+len(allowedKeys))
+	for _, key := range defaultAllowedKeys {
+		allowed[key] = true
+	}
+	for _, key := range allowedKeys {
+		allowed[strings.ToLower(key)] = true
+	}
+	return redactor{allowed: allowed}
+}
+
+// isAllowed returns whether the value of key can be shown, case insensitively.
+func (r redactor) isAllowed(key string) bool {
+	return r.allowed[strings.ToLower(key)]
+}
+
+// redactMap returns a copy of m where the values of the keys that are not
+// allowed are masked. Maps are never masked as a whole, so that the structure
+// of the configuration is still shown.
+func (r redactor) redactMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for key, value := range m {
+		out[key] = r.redactValue(value, r.isAllowed(key))
+	}
+	return out
+}
+
+func (r redactor) redactValue(value any, allowed bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return r.redactMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = r.redactValue(item, allowed)
+		}
+		return out
+	case nil:
+		return nil
+	case string:
+		if !allowed {
+			return redacted
+		}
+		return redactURLPassword(v)
+	default:
+		if !allowed {
+			return redacted
+		}
+		return v
+	}
+}
+
+// redactURLPassword masks the password of value if it is a URL with one,
+// e.g. an endpoint.
+func redactURLPassword(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	return u.Redacted()
+}
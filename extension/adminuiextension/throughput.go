@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// throughputMetricRegexp matches the names of the collector's own metrics that
+// count the items received by receivers and sent by exporters.
+var throughputMetricRegexp = regexp.MustCompile(`^otelcol_(receiver|exporter)_(accepted|refused|sent|send_failed)_(spans|metric_points|log_records)(_total)?$`)
+
+var signals = map[string]string{
+	"spans":         "traces",
+	"metric_points": "metrics",
+	"log_records":   "logs",
+}
+
+// throughput is the number of items a receiver received, or an exporter sent,
+// of a signal.
+type throughput struct {
+	Kind      string  `json:"kind"`
+	Component string  `json:"component"`
+	Signal    string  `json:"signal"`
+	Succeeded float64 `json:"succeeded"`
+	Failed    float64 `json:"failed"`
+	// Rate is the number of items per second succeeding since the previous scrape.
+	Rate float64 `json:"rate"`
+}
+
+type throughputKey struct {
+	kind      string
+	component string
+	signal    string
+}
+
+// throughputScraper scrapes the collector's own metrics to compute the
+// throughput of the receivers and exporters.
+type throughputScraper struct {
+	endpoint string
+	client   *http.Client
+
+	mu           sync.Mutex
+	previous     map[throughputKey]float64
+	previousTime time.Time
+}
+
+func newThroughputScraper(endpoint string) *throughputScraper {
+	return &throughputScraper{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *throughputScraper) scrape(ctx context.Context) ([]*throughput, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q from %s", resp.Status, s.endpoint)
+	}
+
+	values, err := parseThroughput(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var elapsed float64
+	if s.previous != nil {
+		elapsed = now.Sub(s.previousTime).Seconds()
+	}
+	succeeded := make(map[throughputKey]float64, len(values))
+	for _, t := range values {
+		key := throughputKey{kind: t.Kind, component: t.Component, signal: t.Signal}
+		succeeded[key] = t.Succeeded
+		if previous, ok := s.previous[key]; ok && elapsed > 0 && t.Succeeded >= previous {
+			t.Rate = (t.Succeeded - previous) / elapsed
+		}
+	}
+	s.previous = succeeded
+	s.previousTime = now
+	return values, nil
+}
+
+// parseThroughput parses the Prometheus text format in r, and returns the
+// throughput of each receiver and exporter, sorted by kind, component and signal.
+func parseThroughput(r io.Reader) ([]*throughput, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[throughputKey]*throughput)
+	for name, family := range families {
+		match := throughputMetricRegexp.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		kind, outcome, signal := match[1], match[2], signals[match[3]]
+		for _, metric := range family.GetMetric() {
+			component := labelValue(metric, kind)
+			key := throughputKey{kind: kind, component: component, signal: signal}
+			t, ok := values[key]
+			if !ok {
+				t = &throughput{Kind: kind, Component: component, Signal: signal}
+				values[key] = t
+			}
+			if outcome == "accepted" || outcome == "sent" {
+				t.Succeeded += metricValue(metric)
+			} else {
+				t.Failed += metricValue(metric)
+			}
+		}
+	}
+
+	result := make([]*throughput, 0, len(values))
+	for _, t := range values {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Kind != b.Kind {
+			// Receivers first
+			return a.Kind > b.Kind
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Signal < b.Signal
+	})
+	return result, nil
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue()
+	case metric.GetUntyped() != nil:
+		return metric.GetUntyped().GetValue()
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const selfMetrics = `# HELP otelcol_exporter_send_failed_spans Number of spans in failed attempts to send to destination.
+# TYPE otelcol_exporter_send_failed_spans counter
+otelcol_exporter_send_failed_spans{exporter="otlp",service_instance_id="abc"} 5
+# HELP otelcol_exporter_sent_spans Number of spans successfully sent to destination.
+# TYPE otelcol_exporter_sent_spans counter
+otelcol_exporter_sent_spans{exporter="otlp",service_instance_id="abc"} 95
+# HELP otelcol_receiver_accepted_spans Number of spans successfully pushed into the pipeline.
+# TYPE otelcol_receiver_accepted_spans counter
+otelcol_receiver_accepted_spans{receiver="otlp",service_instance_id="abc",transport="grpc"} 60
+otelcol_receiver_accepted_spans{receiver="otlp",service_instance_id="abc",transport="http"} 40
+# HELP otelcol_receiver_accepted_log_records_total Number of log records successfully pushed into the pipeline.
+# TYPE otelcol_receiver_accepted_log_records_total counter
+otelcol_receiver_accepted_log_records_total{receiver="filelog",service_instance_id="abc",transport=""} 7
+# HELP otelcol_process_uptime Uptime of the process
+# TYPE otelcol_process_uptime counter
+otelcol_process_uptime{service_instance_id="abc"} 10
+`
+
+func TestParseThroughput(t *testing.T) {
+	values, err := parseThroughput(strings.NewReader(selfMetrics))
+	require.NoError(t, err)
+	assert.Equal(t, []*throughput{
+		{Kind: "receiver", Component: "filelog", Signal: "logs", Succeeded: 7},
+		{Kind: "receiver", Component: "otlp", Signal: "traces", Succeeded: 100},
+		{Kind: "exporter", Component: "otlp", Signal: "traces", Succeeded: 95, Failed: 5},
+	}, values)
+}
+
+func TestThroughputScraper(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		sent += 100
+		fmt.Fprintf(w, "otelcol_exporter_sent_spans{exporter=\"otlp\"} %d\n", sent)
+	}))
+	defer srv.Close()
+
+	scraper := newThroughputScraper(srv.URL)
+	values, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, float64(100), values[0].Succeeded)
+	// No rate without a previous scrape
+	assert.Zero(t, values[0].Rate)
+
+	values, err = scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, float64(200), values[0].Succeeded)
+	assert.Positive(t, values[0].Rate)
+}
+
+func TestThroughputScraperError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := newThroughputScraper(srv.URL).scrape(context.Background())
+	assert.ErrorContains(t, err, "unexpected status")
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminuiextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/adminuiextension"
+
+import (
+	"html/template"
+	"strings"
+)
+
+var indexTemplate = template.Must(template.New("index").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>OpenTelemetry Collector</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+th { background: #eee; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>OpenTelemetry Collector</h1>
+<p>{{if .ConfigAvailable}}<a href="config">Effective configuration</a> (values not known to be safe are masked){{else}}The configuration is not available yet.{{end}}
+&middot; <a href="api/status">Status as JSON</a></p>
+
+<h2>Pipelines</h2>
+<table>
+<tr><th>Pipeline</th><th>Receivers</th><th>Processors</th><th>Exporters</th></tr>
+{{range .Pipelines}}<tr><td>{{.Name}}</td><td>{{join .Receivers ", "}}</td><td>{{join .Processors ", "}}</td><td>{{join .Exporters ", "}}</td></tr>
+{{end}}</table>
+
+<h2>Throughput</h2>
+{{if .ThroughputError}}<p class="error">Failed to scrape the collector metrics: {{.ThroughputError}}</p>{{end}}
+<table>
+<tr><th>Kind</th><th>Component</th><th>Signal</th><th>Succeeded</th><th>Failed</th><th>Items/s</th></tr>
+{{range .Throughput}}<tr><td>{{.Kind}}</td><td>{{.Component}}</td><td>{{.Signal}}</td><td>{{printf "%.0f" .Succeeded}}</td><td>{{printf "%.0f" .Failed}}</td><td>{{printf "%.1f" .Rate}}</td></tr>
+{{end}}</table>
+
+<h2>Components</h2>
+<table>
+<tr><th>Kind</th><th>Component</th><th>Status</th><th>Since</th><th>Error</th></tr>
+{{range .Components}}<tr><td>{{.Kind}}</td><td>{{.Component}}</td><td>{{.Status}}</td><td>{{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}}</td><td class="error">{{.Error}}</td></tr>
+{{end}}</table>
+
+<h2>Recent errors</h2>
+<table>
+<tr><th>Time</th><th>Kind</th><th>Component</th><th>Status</th><th>Error</th></tr>
+{{range .RecentErrors}}<tr><td>{{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}}</td><td>{{.Kind}}</td><td>{{.Component}}</td><td>{{.Status}}</td><td class="error">{{.Error}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -6,6 +6,7 @@ package awsproxy
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,9 +42,10 @@ func TestLoadConfig(t *testing.T) {
 						Insecure:   true,
 						ServerName: "something",
 					},
-					Region:      "us-west-1",
-					RoleARN:     "arn:aws:iam::123456789012:role/awesome_role",
-					AWSEndpoint: "https://another.aws.endpoint.com",
+					Region:                "us-west-1",
+					RoleARN:               "arn:aws:iam::123456789012:role/awesome_role",
+					AWSEndpoint:           "https://another.aws.endpoint.com",
+					SamplingRulesCacheTTL: 30 * time.Second,
 				},
 			},
 		},
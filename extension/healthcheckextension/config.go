@@ -37,6 +37,9 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// CheckComponentStatus contains the settings of the component status health check
+	CheckComponentStatus checkComponentStatusSettings `mapstructure:"check_component_status"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -72,3 +75,9 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type checkComponentStatusSettings struct {
+	// Enabled indicates whether to report the collector as unhealthy while a
+	// component reports an error status.
+	Enabled bool `mapstructure:"enabled"`
+}
@@ -47,6 +47,17 @@ func TestLoadConfig(t *testing.T) {
 				ResponseBody:           nil,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componentstatus"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: defaultEndpoint,
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				CheckComponentStatus:   checkComponentStatusSettings{Enabled: true},
+				Path:                   "/",
+			},
+		},
 		{
 			id:          component.NewIDWithName(metadata.Type, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
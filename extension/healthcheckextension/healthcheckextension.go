@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -26,9 +27,19 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	statusMu sync.Mutex
+	statuses map[componentKey]*component.StatusEvent
+}
+
+// componentKey identifies a component instance reporting its status.
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -103,7 +114,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 func (hc *healthCheckExtension) baseHandler() http.Handler {
 	if hc.config.ResponseBody != nil {
 		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			if hc.state.Get() == healthcheck.Ready {
+			if hc.state.Get() == healthcheck.Ready && hc.componentsHealthy() {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write([]byte(hc.config.ResponseBody.Healthy))
 			} else {
@@ -112,13 +123,16 @@ func (hc *healthCheckExtension) baseHandler() http.Handler {
 			}
 		})
 	}
+	if hc.config.CheckComponentStatus.Enabled {
+		return hc.state.CheckedHandler(hc.componentsHealthy)
+	}
 	return hc.state.Handler()
 }
 
 // new handler function used for check collector pipeline
 func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		if hc.check() && hc.state.Get() == healthcheck.Ready {
+		if hc.check() && hc.state.Get() == healthcheck.Ready && hc.componentsHealthy() {
 			w.WriteHeader(http.StatusOK)
 			if hc.config.ResponseBody != nil {
 				_, _ = w.Write([]byte(hc.config.ResponseBody.Healthy))
@@ -136,6 +150,36 @@ func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
 
+// ComponentStatusChanged records the latest status reported by a component.
+func (hc *healthCheckExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	if !hc.config.CheckComponentStatus.Enabled {
+		return
+	}
+	if event.Status() == component.StatusRecoverableError || event.Status() == component.StatusPermanentError {
+		hc.logger.Warn("Component reported an error status",
+			zap.Stringer("component", source.ID), zap.Error(event.Err()))
+	}
+
+	hc.statusMu.Lock()
+	defer hc.statusMu.Unlock()
+	hc.statuses[componentKey{kind: source.Kind, id: source.ID}] = event
+}
+
+// componentsHealthy returns false while a component reports an error status,
+// e.g. an exporter that cannot connect to its endpoint yet. It always returns
+// true when check_component_status is disabled.
+func (hc *healthCheckExtension) componentsHealthy() bool {
+	hc.statusMu.Lock()
+	defer hc.statusMu.Unlock()
+	for _, event := range hc.statuses {
+		switch event.Status() {
+		case component.StatusRecoverableError, component.StatusPermanentError, component.StatusFatalError:
+			return false
+		}
+	}
+	return true
+}
+
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.server == nil {
 		return nil
@@ -163,6 +207,7 @@ func newServer(config Config, settings component.TelemetrySettings) *healthCheck
 		logger:   settings.Logger,
 		state:    healthcheck.New(),
 		settings: settings,
+		statuses: make(map[componentKey]*component.StatusEvent),
 	}
 
 	hc.state.SetLogger(settings.Logger)
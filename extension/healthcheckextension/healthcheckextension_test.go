@@ -5,6 +5,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"net/http"
@@ -27,6 +28,11 @@ const (
 	expectedBodyReady    = "{\"status\":\"Server available\",\"upSince\":"
 )
 
+var exporterInstanceID = &component.InstanceID{
+	ID:   component.NewID("kafka"),
+	Kind: component.KindExporter,
+}
+
 func ensureServerRunning(url string) func() bool {
 	return func() bool {
 		_, err := net.DialTimeout("tcp", url, 30*time.Second)
@@ -305,6 +311,82 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithCheckComponentStatus",
+			config: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				CheckComponentStatus:   checkComponentStatusSettings{Enabled: true},
+				Path:                   "/",
+			},
+			teststeps: []teststep{
+				{
+					step:               func(hcExt *healthCheckExtension) error { return hcExt.Ready() },
+					expectedStatusCode: http.StatusOK,
+					expectedBody:       expectedBodyReady,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterInstanceID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+						return nil
+					},
+					expectedStatusCode: http.StatusServiceUnavailable,
+					expectedBody:       expectedBodyNotReady,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterInstanceID, component.NewStatusEvent(component.StatusOK))
+						return nil
+					},
+					expectedStatusCode: http.StatusOK,
+					expectedBody:       expectedBodyReady,
+				},
+			},
+		},
+		{
+			name: "WithCheckComponentStatusAndCustomResponseBody",
+			config: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				CheckComponentStatus:   checkComponentStatusSettings{Enabled: true},
+				Path:                   "/",
+				ResponseBody:           &ResponseBodySettings{Healthy: "ALL OK", Unhealthy: "NOT OK"},
+			},
+			teststeps: []teststep{
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterInstanceID, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+						return hcExt.Ready()
+					},
+					expectedStatusCode: http.StatusServiceUnavailable,
+					expectedBody:       "NOT OK",
+				},
+			},
+		},
+		{
+			name: "WithoutCheckComponentStatus",
+			config: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				Path:                   "/",
+			},
+			teststeps: []teststep{
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterInstanceID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+						return hcExt.Ready()
+					},
+					expectedStatusCode: http.StatusOK,
+					expectedBody:       expectedBodyReady,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
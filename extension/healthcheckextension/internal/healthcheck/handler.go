@@ -98,8 +98,17 @@ func (hc *HealthCheck) SetLogger(logger *zap.Logger) {
 
 // Handler creates a new HTTP handler.
 func (hc *HealthCheck) Handler() http.Handler {
+	return hc.CheckedHandler(nil)
+}
+
+// CheckedHandler creates a new HTTP handler that reports the service as
+// unavailable while healthy returns false, even if it is ready.
+func (hc *HealthCheck) CheckedHandler(healthy func() bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		hcState := hc.getState()
+		if hcState.status == Ready && healthy != nil && !healthy() {
+			hcState = state{status: Unavailable}
+		}
 		template := hc.responses[hcState.status]
 
 		w.Header().Set("Content-Type", "application/json")
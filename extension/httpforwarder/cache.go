@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// credentialHeaders are the request headers always identifying the cached
+// responses, so that a response is never served to a client with other
+// credentials.
+var credentialHeaders = []string{"Authorization", "Cookie"}
+
+// cachedResponse is a response of the forwarded GET requests.
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expiry     time.Time
+	// vary are the values of the request headers listed in the Vary header of
+	// the response, which must match for the response to be served.
+	vary map[string][]string
+}
+
+// responseCache caches the successful responses of the forwarded GET requests.
+type responseCache struct {
+	entries    *lru.Cache
+	ttl        time.Duration
+	keyHeaders []string
+	now        func() time.Time
+}
+
+func newResponseCache(cfg CacheConfig) (*responseCache, error) {
+	entries, err := lru.New(cfg.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	return &responseCache{
+		entries:    entries,
+		ttl:        cfg.TTL,
+		keyHeaders: cfg.KeyHeaders,
+		now:        time.Now,
+	}, nil
+}
+
+// key identifies the response of request by its URL, credentials and key
+// headers. The key is hashed so that the credentials are not kept in memory.
+func (c *responseCache) key(request *http.Request) string {
+	hash := sha256.New()
+	writeField := func(value string) {
+		_ = binary.Write(hash, binary.BigEndian, uint64(len(value)))
+		hash.Write([]byte(value))
+	}
+	writeField(request.URL.String())
+	for _, name := range append(credentialHeaders, c.keyHeaders...) {
+		values := request.Header.Values(name)
+		writeField(strconv.Itoa(len(values)))
+		for _, value := range values {
+			writeField(value)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns the response cached for key, if not expired and its Vary headers
+// match request.
+func (c *responseCache) get(key string, request *http.Request) (*cachedResponse, bool) {
+	value, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	response := value.(*cachedResponse)
+	if !c.now().Before(response.expiry) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	for name, values := range response.vary {
+		if !equalValues(values, request.Header.Values(name)) {
+			return nil, false
+		}
+	}
+	return response, true
+}
+
+func (c *responseCache) add(key string, request *http.Request, response *http.Response, body []byte) {
+	names := varyHeaders(response)
+	vary := make(map[string][]string, len(names))
+	for _, name := range names {
+		vary[name] = request.Header.Values(name)
+	}
+	c.entries.Add(key, &cachedResponse{
+		statusCode: response.StatusCode,
+		header:     response.Header.Clone(),
+		body:       body,
+		expiry:     c.now().Add(c.ttl),
+		vary:       vary,
+	})
+}
+
+// varyHeaders returns the request headers listed in the Vary header of response.
+func varyHeaders(response *http.Response) []string {
+	var names []string
+	for _, value := range response.Header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// isCacheable returns whether the response of a GET request may be cached, that
+// is whether it succeeded, the upstream does not forbid caching it and it does
+// not vary with anything else than request headers.
+func isCacheable(response *http.Response) bool {
+	if response.StatusCode != http.StatusOK {
+		return false
+	}
+	for _, name := range varyHeaders(response) {
+		if name == "*" {
+			return false
+		}
+	}
+	for _, directive := range strings.Split(response.Header.Get("Cache-Control"), ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "no-store", "no-cache", "private":
+			return false
+		}
+	}
+	return true
+}
+
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
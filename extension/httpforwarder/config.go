@@ -4,7 +4,14 @@
 package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
 
 import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // Config defines configuration for http forwarder extension.
@@ -15,4 +22,55 @@ type Config struct {
 
 	// Egress holds config settings to use for forwarded requests.
 	Egress confighttp.HTTPClientSettings `mapstructure:"egress"`
+
+	// Rewrite holds the OTTL statements rewriting the forwarded requests.
+	Rewrite RewriteConfig `mapstructure:"rewrite"`
+
+	// Cache holds config settings for caching the responses of GET requests.
+	Cache CacheConfig `mapstructure:"cache"`
+}
+
+// RewriteConfig defines the OTTL statements rewriting the forwarded requests.
+type RewriteConfig struct {
+	// ErrorMode determines how the errors of the statements are handled. With
+	// `propagate`, the requests are rejected. With `ignore`, the following
+	// statements are executed. The default value is `propagate`.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// Statements are executed in order against the method, path, headers, query
+	// and body paths of the requests.
+	Statements []string `mapstructure:"statements"`
+}
+
+// CacheConfig defines how the responses of GET requests are cached.
+type CacheConfig struct {
+	// TTL is how long the successful responses are cached. Caching is disabled
+	// when zero, which is the default.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// MaxEntries is the maximum number of cached responses, the least recently
+	// used ones being evicted. The default value is 1000.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// KeyHeaders are the request headers identifying the cached responses along
+	// with the URL and the credentials, e.g. X-Scope-OrgID.
+	KeyHeaders []string `mapstructure:"key_headers"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Rewrite.Statements) > 0 {
+		if _, err := newRewriteStatements(cfg.Rewrite, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return err
+		}
+	}
+	if cfg.Cache.TTL < 0 {
+		return errors.New("'cache.ttl' must not be negative")
+	}
+	if cfg.Cache.TTL > 0 && cfg.Cache.MaxEntries <= 0 {
+		return errors.New("'cache.max_entries' must be positive")
+	}
+	return nil
 }
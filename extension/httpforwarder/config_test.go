@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -42,6 +43,37 @@ func TestLoadConfig(t *testing.T) {
 					},
 					Timeout: 5 * time.Second,
 				},
+				Rewrite: RewriteConfig{
+					ErrorMode: ottl.PropagateError,
+				},
+				Cache: CacheConfig{
+					MaxEntries: defaultCacheMaxEntries,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				Ingress: confighttp.HTTPServerSettings{
+					Endpoint: defaultEndpoint,
+				},
+				Egress: confighttp.HTTPClientSettings{
+					Endpoint: "http://target/",
+					Timeout:  10 * time.Second,
+				},
+				Rewrite: RewriteConfig{
+					ErrorMode: ottl.IgnoreError,
+					Statements: []string{
+						`set(headers["X-Api-Key"], "secret") where path == "/api/sampling"`,
+						`delete_key(headers, "Cookie")`,
+						`replace_pattern(body, "\"debug\":true", "\"debug\":false")`,
+					},
+				},
+				Cache: CacheConfig{
+					TTL:        30 * time.Second,
+					MaxEntries: 100,
+					KeyHeaders: []string{"X-Scope-OrgID"},
+				},
 			},
 		},
 	}
@@ -59,3 +91,32 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		errorMessage string
+	}{
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_statement"),
+			errorMessage: `unable to parse OTTL statement "set(attributes[\"key\"], \"value\")": error while parsing arguments for call to "set": invalid argument at position 0: invalid path "attributes"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_cache"),
+			errorMessage: "'cache.max_entries' must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -14,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 type httpForwarder struct {
@@ -22,6 +25,8 @@ type httpForwarder struct {
 	server     *http.Server
 	settings   component.TelemetrySettings
 	config     *Config
+	rewrite    *ottl.Statements[*requestContext]
+	cache      *responseCache
 }
 
 var _ extension.Extension = (*httpForwarder)(nil)
@@ -75,10 +80,33 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 		forwarderRequest.Header.Add(k, string(v))
 	}
 
+	if h.rewrite != nil {
+		if err := rewriteRequest(request.Context(), h.rewrite, forwarderRequest); err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Add "Via" header for tracking purposes on both the outgoing requests and responses.
 	// See https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Via.
 	addViaHeader(forwarderRequest.Header, request.Proto, request.Host)
 
+	var cacheKey string
+	if h.cache != nil && forwarderRequest.Method == http.MethodGet {
+		cacheKey = h.cache.key(forwarderRequest)
+		if cached, ok := h.cache.get(cacheKey, forwarderRequest); ok {
+			for k := range cached.header {
+				writer.Header().Set(k, cached.header.Get(k))
+			}
+			addViaHeader(writer.Header(), request.Proto, request.Host)
+			writer.WriteHeader(cached.statusCode)
+			if _, err := writer.Write(cached.body); err != nil {
+				h.settings.Logger.Warn("Error writing HTTP response message", zap.Error(err))
+			}
+			return
+		}
+	}
+
 	response, err := h.httpClient.Do(forwarderRequest)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusBadGateway)
@@ -89,6 +117,17 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 	}
 	defer response.Body.Close()
 
+	body := io.Reader(response.Body)
+	if cacheKey != "" && isCacheable(response) {
+		content, err := io.ReadAll(response.Body)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadGateway)
+			return
+		}
+		h.cache.add(cacheKey, forwarderRequest, response, content)
+		body = bytes.NewReader(content)
+	}
+
 	// Copy over response from the final destination.
 	for k := range response.Header {
 		writer.Header().Set(k, response.Header.Get(k))
@@ -96,7 +135,7 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 	addViaHeader(writer.Header(), response.Proto, request.Host)
 
 	writer.WriteHeader(response.StatusCode)
-	written, err := io.Copy(writer, response.Body)
+	written, err := io.Copy(writer, body)
 	if err != nil {
 		h.settings.Logger.Warn("Error writing HTTP response message", zap.Error(err))
 	}
@@ -126,5 +165,16 @@ func newHTTPForwarder(config *Config, settings component.TelemetrySettings) (ext
 		settings:  settings,
 	}
 
+	if len(config.Rewrite.Statements) > 0 {
+		if h.rewrite, err = newRewriteStatements(config.Rewrite, settings); err != nil {
+			return nil, fmt.Errorf("invalid 'rewrite.statements': %w", err)
+		}
+	}
+	if config.Cache.TTL > 0 {
+		if h.cache, err = newResponseCache(config.Cache); err != nil {
+			return nil, err
+		}
+	}
+
 	return h, nil
 }
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -270,3 +271,128 @@ func getParsedURL(t *testing.T, rawURL string) *url.URL {
 	require.NoError(t, err)
 	return url
 }
+
+func TestRewrite(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/sampling", r.URL.Path)
+		assert.Equal(t, "service=checkout", r.URL.RawQuery)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		assert.Empty(t, r.Header.Get("Cookie"))
+		assert.Equal(t, `{"debug":false}`, string(readBody(r.Body)))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	listenAt := testutil.GetAvailableLocalAddress(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Ingress.Endpoint = listenAt
+	cfg.Egress.Endpoint = backend.URL
+	cfg.Rewrite.Statements = []string{
+		`set(path, "/api/v2/sampling") where path == "/api/sampling"`,
+		`delete_key(query, "debug")`,
+		`set(headers["X-Api-Key"], "secret")`,
+		`delete_key(headers, "Cookie")`,
+		`replace_pattern(body, "\"debug\":true", "\"debug\":false")`,
+	}
+	hf, err := newHTTPForwarder(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, hf.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, hf.Shutdown(context.Background())) }()
+
+	response, err := http.DefaultClient.Do(httpRequest(t, clientRequestArgs{
+		method:  "POST",
+		url:     fmt.Sprintf("http://%s/api/sampling?service=checkout&debug=1", listenAt),
+		headers: map[string]string{"Cookie": "session=1"},
+		body:    `{"debug":true}`,
+	}))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestRewriteError(t *testing.T) {
+	listenAt := testutil.GetAvailableLocalAddress(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Ingress.Endpoint = listenAt
+	cfg.Egress.Endpoint = "http://" + testutil.GetAvailableLocalAddress(t)
+	cfg.Rewrite.Statements = []string{`set(method, 1)`}
+	hf, err := newHTTPForwarder(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, hf.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, hf.Shutdown(context.Background())) }()
+
+	response, err := http.Get(fmt.Sprintf("http://%s/api", listenAt))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+}
+
+func TestCache(t *testing.T) {
+	requests := 0
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/private":
+			w.Header().Set("Cache-Control", "private")
+		case "/language":
+			w.Header().Set("Vary", "Accept-Language")
+		case "/any":
+			w.Header().Set("Vary", "*")
+		}
+		language := r.Header.Get("Accept-Language")
+		if language != "" {
+			language = " " + language
+		}
+		_, err := fmt.Fprintf(w, "%s %s%s %d", r.URL.Path, r.Header.Get("Authorization"), language, requests)
+		assert.NoError(t, err)
+	}))
+	defer backend.Close()
+
+	listenAt := testutil.GetAvailableLocalAddress(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Ingress.Endpoint = listenAt
+	cfg.Egress.Endpoint = backend.URL
+	cfg.Cache.TTL = time.Minute
+	ext, err := newHTTPForwarder(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	hf := ext.(*httpForwarder)
+	now := time.Now()
+	hf.cache.now = func() time.Time { return now }
+	require.NoError(t, hf.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, hf.Shutdown(context.Background())) }()
+
+	get := func(method, path, authorization string, headers ...string) string {
+		requestHeaders := map[string]string{"Authorization": authorization}
+		for i := 0; i+1 < len(headers); i += 2 {
+			requestHeaders[headers[i]] = headers[i+1]
+		}
+		response, err := http.DefaultClient.Do(httpRequest(t, clientRequestArgs{
+			method:  method,
+			url:     fmt.Sprintf("http://%s%s", listenAt, path),
+			headers: requestHeaders,
+		}))
+		require.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, fmt.Sprintf("%s %s", response.Proto, listenAt), response.Header.Get("Via"))
+		return string(readBody(response.Body))
+	}
+
+	assert.Equal(t, "/rules token-a 1", get("GET", "/rules", "token-a"))
+	assert.Equal(t, "/rules token-a 1", get("GET", "/rules", "token-a"))
+	assert.Equal(t, "/rules token-b 2", get("GET", "/rules", "token-b"))
+	assert.Equal(t, "/rules token-a 3", get("POST", "/rules", "token-a"))
+	assert.Equal(t, "/private token-a 4", get("GET", "/private", "token-a"))
+	assert.Equal(t, "/private token-a 5", get("GET", "/private", "token-a"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "/rules token-a 6", get("GET", "/rules", "token-a"))
+	assert.Equal(t, "/rules token-a 6", get("GET", "/rules", "token-a"))
+
+	// The request headers of the Vary header of the response must match
+	assert.Equal(t, "/language token-a en 7", get("GET", "/language", "token-a", "Accept-Language", "en"))
+	assert.Equal(t, "/language token-a en 7", get("GET", "/language", "token-a", "Accept-Language", "en"))
+	assert.Equal(t, "/language token-a fr 8", get("GET", "/language", "token-a", "Accept-Language", "fr"))
+	assert.Equal(t, "/any token-a 9", get("GET", "/any", "token-a"))
+	assert.Equal(t, "/any token-a 10", get("GET", "/any", "token-a"))
+}
@@ -12,11 +12,14 @@ import (
 	"go.opentelemetry.io/collector/extension"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 const (
 	// Default endpoints to bind to.
 	defaultEndpoint = ":6060"
+
+	defaultCacheMaxEntries = 1000
 )
 
 // NewFactory creates a factory for HostObserver extension.
@@ -36,6 +39,12 @@ func createDefaultConfig() component.Config {
 		Egress: confighttp.HTTPClientSettings{
 			Timeout: 10 * time.Second,
 		},
+		Rewrite: RewriteConfig{
+			ErrorMode: ottl.PropagateError,
+		},
+		Cache: CacheConfig{
+			MaxEntries: defaultCacheMaxEntries,
+		},
 	}
 }
 
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// requestContext is the OTTL transform context of the forwarded requests. The
+// headers and the query parameters are held in maps, which are applied to the
+// request once the statements executed.
+type requestContext struct {
+	request *http.Request
+	headers pcommon.Map
+	query   pcommon.Map
+	// body holds the body of the request once read.
+	body []byte
+	read bool
+}
+
+func newRequestContext(request *http.Request) *requestContext {
+	r := &requestContext{
+		request: request,
+		headers: pcommon.NewMap(),
+		query:   pcommon.NewMap(),
+	}
+	putValues(r.headers, request.Header)
+	putValues(r.query, request.URL.Query())
+	return r
+}
+
+// putValues puts the values of each key into m, as a string when single.
+func putValues(m pcommon.Map, values map[string][]string) {
+	for key, vs := range values {
+		if len(vs) == 1 {
+			m.PutStr(key, vs[0])
+			continue
+		}
+		slice := m.PutEmptySlice(key)
+		for _, v := range vs {
+			slice.AppendEmpty().SetStr(v)
+		}
+	}
+}
+
+// getValues returns the values of m, converting them to strings.
+func getValues(m pcommon.Map) map[string][]string {
+	values := make(map[string][]string, m.Len())
+	m.Range(func(key string, v pcommon.Value) bool {
+		if v.Type() != pcommon.ValueTypeSlice {
+			values[key] = []string{v.AsString()}
+			return true
+		}
+		for i := 0; i < v.Slice().Len(); i++ {
+			values[key] = append(values[key], v.Slice().At(i).AsString())
+		}
+		return true
+	})
+	return values
+}
+
+// apply applies the headers and the query parameters to the request. The query
+// is encoded again only when changed, so that the order of its parameters is kept
+// otherwise.
+func (r *requestContext) apply() {
+	header := http.Header{}
+	for key, vs := range getValues(r.headers) {
+		for _, v := range vs {
+			header.Add(key, v)
+		}
+	}
+	r.request.Header = header
+
+	query := url.Values(getValues(r.query))
+	if !reflect.DeepEqual(query, r.request.URL.Query()) {
+		r.request.URL.RawQuery = query.Encode()
+	}
+}
+
+func (r *requestContext) getBody() ([]byte, error) {
+	if r.read || r.request.Body == nil {
+		return r.body, nil
+	}
+	body, err := io.ReadAll(r.request.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = r.request.Body.Close()
+	r.setBody(body)
+	return body, nil
+}
+
+func (r *requestContext) setBody(body []byte) {
+	r.body, r.read = body, true
+	r.request.Body = io.NopCloser(bytes.NewReader(body))
+	r.request.ContentLength = int64(len(body))
+}
+
+// rewriteRequest executes statements against request.
+func rewriteRequest(ctx context.Context, statements *ottl.Statements[*requestContext], request *http.Request) error {
+	r := newRequestContext(request)
+	if err := statements.Execute(ctx, r); err != nil {
+		return err
+	}
+	r.apply()
+	return nil
+}
+
+// newRewriteStatements parses the statements rewriting the forwarded requests.
+func newRewriteStatements(cfg RewriteConfig, settings component.TelemetrySettings) (*ottl.Statements[*requestContext], error) {
+	parser, err := ottl.NewParser[*requestContext](ottlfuncs.StandardFuncs[*requestContext](), parseRequestPath, settings)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := parser.ParseStatements(cfg.Statements)
+	if err != nil {
+		return nil, err
+	}
+	s := ottl.NewStatements(statements, settings, ottl.WithErrorMode[*requestContext](cfg.ErrorMode))
+	return &s, nil
+}
+
+// parseRequestPath returns the accessors of the method, path, headers, query and
+// body paths of the requests.
+func parseRequestPath(path *ottl.Path) (ottl.GetSetter[*requestContext], error) {
+	if path == nil || len(path.Fields) != 1 {
+		return nil, fmt.Errorf("invalid path %+v", path)
+	}
+	field := path.Fields[0]
+	switch field.Name {
+	case "method":
+		return stringGetSetter(
+			func(r *requestContext) string { return r.request.Method },
+			func(r *requestContext, value string) { r.request.Method = value },
+		), nil
+	case "path":
+		return stringGetSetter(
+			func(r *requestContext) string { return r.request.URL.Path },
+			func(r *requestContext, value string) { r.request.URL.Path, r.request.URL.RawPath = value, "" },
+		), nil
+	case "headers":
+		// The header names are canonicalized, so that they may be indexed in any case
+		return mapGetSetter(field.Keys, http.CanonicalHeaderKey, func(r *requestContext) pcommon.Map { return r.headers })
+	case "query":
+		return mapGetSetter(field.Keys, func(key string) string { return key }, func(r *requestContext) pcommon.Map { return r.query })
+	case "body":
+		return ottl.StandardGetSetter[*requestContext]{
+			Getter: func(_ context.Context, r *requestContext) (any, error) {
+				body, err := r.getBody()
+				return string(body), err
+			},
+			Setter: func(_ context.Context, r *requestContext, val any) error {
+				if _, err := r.getBody(); err != nil {
+					return err
+				}
+				switch v := val.(type) {
+				case string:
+					r.setBody([]byte(v))
+				case []byte:
+					r.setBody(v)
+				default:
+					return fmt.Errorf("body must be set to a string, got %T", val)
+				}
+				return nil
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid path %q", field.Name)
+}
+
+func stringGetSetter(get func(r *requestContext) string, set func(r *requestContext, value string)) ottl.GetSetter[*requestContext] {
+	return ottl.StandardGetSetter[*requestContext]{
+		Getter: func(_ context.Context, r *requestContext) (any, error) {
+			return get(r), nil
+		},
+		Setter: func(_ context.Context, r *requestContext, val any) error {
+			value, ok := val.(string)
+			if !ok {
+				return fmt.Errorf("expected a string, got %T", val)
+			}
+			set(r, value)
+			return nil
+		},
+	}
+}
+
+// mapGetSetter accesses the map returned by get, or one of its values when
+// indexed by a key.
+func mapGetSetter(keys []ottl.Key, canonicalize func(string) string, get func(r *requestContext) pcommon.Map) (ottl.GetSetter[*requestContext], error) {
+	if len(keys) == 0 {
+		return ottl.StandardGetSetter[*requestContext]{
+			Getter: func(_ context.Context, r *requestContext) (any, error) {
+				return get(r), nil
+			},
+			Setter: func(_ context.Context, r *requestContext, val any) error {
+				m, ok := val.(pcommon.Map)
+				if !ok {
+					return fmt.Errorf("expected a map, got %T", val)
+				}
+				m.CopyTo(get(r))
+				return nil
+			},
+		}, nil
+	}
+	if len(keys) != 1 || keys[0].String == nil {
+		return nil, errors.New("headers and query must be indexed by a name")
+	}
+	key := canonicalize(*keys[0].String)
+	return ottl.StandardGetSetter[*requestContext]{
+		Getter: func(_ context.Context, r *requestContext) (any, error) {
+			if v, ok := get(r).Get(key); ok {
+				return v.AsRaw(), nil
+			}
+			return nil, nil
+		},
+		Setter: func(_ context.Context, r *requestContext, val any) error {
+			return get(r).PutEmpty(key).FromRaw(val)
+		},
+	}, nil
+}
@@ -5,6 +5,7 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"os"
@@ -35,6 +36,8 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// aead encrypts stored values when encryption is configured
+	aead cipher.AEAD
 }
 
 func bboltOptions(timeout time.Duration, fSync bool) *bbolt.Options {
@@ -46,7 +49,7 @@ func bboltOptions(timeout time.Duration, fSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, fSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, fSync bool, aead cipher.AEAD) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, fSync)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -62,7 +65,7 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, aead: aead}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,16 +107,26 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.aead != nil:
+					// decryption writes to a new buffer, so the value is valid outside the transaction
+					op.Value, err = decryptValue(c.aead, op.Key, value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.aead != nil {
+					if value, err = encryptValue(c.aead, op.Key, value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -20,6 +20,9 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// Encryption enables at-rest encryption of the stored values
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -47,6 +50,15 @@ type CompactionConfig struct {
 	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
 }
 
+// EncryptionConfig defines configuration for optional AES-GCM encryption of stored values.
+// The key is a base64 encoded AES-128, AES-192 or AES-256 key read from exactly one of the sources.
+type EncryptionConfig struct {
+	// KeyFile specifies the path of a file containing the key
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// KeyEnv specifies the name of an environment variable containing the key
+	KeyEnv string `mapstructure:"key_env,omitempty"`
+}
+
 func (cfg *Config) Validate() error {
 	var dirs []string
 	if cfg.Compaction.OnStart {
@@ -79,5 +91,9 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil && (cfg.Encryption.KeyFile == "") == (cfg.Encryption.KeyEnv == "") {
+		return errors.New("exactly one of encryption key_file and key_env must be set")
+	}
+
 	return nil
 }
@@ -48,6 +48,9 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Timeout: 2 * time.Second,
 				FSync:   true,
+				Encryption: &EncryptionConfig{
+					KeyEnv: "FILE_STORAGE_KEY",
+				},
 			},
 		},
 	}
@@ -94,3 +97,18 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestEncryptionRequiresSingleKeySource(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.Encryption = &EncryptionConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), "exactly one of encryption key_file and key_env must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyFile: "key", KeyEnv: "FILE_STORAGE_KEY"}
+	require.EqualError(t, component.ValidateConfig(cfg), "exactly one of encryption key_file and key_env must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyFile: "key"}
+	require.NoError(t, component.ValidateConfig(cfg))
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// newAEAD creates the AES-GCM cipher used to encrypt stored values, with the key loaded
+// from the file or environment variable set in the encryption configuration.
+func newAEAD(cfg *EncryptionConfig) (cipher.AEAD, error) {
+	var encoded string
+	switch {
+	case cfg.KeyFile != "":
+		content, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		encoded = string(content)
+	case cfg.KeyEnv != "":
+		var ok bool
+		if encoded, ok = os.LookupEnv(cfg.KeyEnv); !ok {
+			return nil, fmt.Errorf("encryption key environment variable %q is not set", cfg.KeyEnv)
+		}
+	default:
+		return nil, errors.New("no encryption key source configured")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptValue seals the value with a random nonce which is prepended to the result.
+// The storage key is used as additional data so that values cannot be swapped between keys.
+func encryptValue(aead cipher.AEAD, key string, value []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, value, []byte(key)), nil
+}
+
+// decryptValue opens a value sealed by encryptValue.
+func decryptValue(aead cipher.AEAD, key string, value []byte) ([]byte, error) {
+	if len(value) < aead.NonceSize() {
+		return nil, fmt.Errorf("failed to decrypt value of %q: value too short", key)
+	}
+	nonce, sealed := value[:aead.NonceSize()], value[aead.NonceSize():]
+	// an empty but non-nil destination keeps empty values distinguishable from missing ones
+	plain, err := aead.Open(make([]byte, 0, len(sealed)), nonce, sealed, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value of %q: %w", key, err)
+	}
+	return plain, nil
+}
@@ -5,6 +5,7 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"crypto/cipher"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -27,16 +28,25 @@ var replaceUnsafeCharactersFeatureGate = featuregate.GlobalRegistry().MustRegist
 type localFileStorage struct {
 	cfg    *Config
 	logger *zap.Logger
+	aead   cipher.AEAD
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
 func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
-	return &localFileStorage{
+	lfs := &localFileStorage{
 		cfg:    config,
 		logger: logger,
-	}, nil
+	}
+	if config.Encryption != nil {
+		aead, err := newAEAD(config.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		lfs.aead = aead
+	}
+	return lfs, nil
 }
 
 // Start does nothing
@@ -64,7 +74,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 		rawName = sanitize(rawName)
 	}
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cfg.FSync)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cfg.FSync, lfs.aead)
 
 	if err != nil {
 		return nil, err
@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.uber.org/zap"
 )
 
 func TestExtensionIntegrity(t *testing.T) {
@@ -281,6 +282,72 @@ func TestGetClientErrorsOnDeletedDirectory(t *testing.T) {
 	require.Nil(t, client)
 }
 
+func TestEncryptionKeyFromEnv(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("FILE_STORAGE_KEY", "MDEyMzQ1Njc4OWFiY2RlZg==")
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{KeyEnv: "FILE_STORAGE_KEY"}
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("queue"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+
+	files, err := os.ReadDir(cfg.Directory)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(filepath.Join(cfg.Directory, files[0].Name()))
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "value")
+}
+
+func TestEncryptionKeyErrors(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("dG9vIHNob3J0"), 0600))
+
+	tests := []struct {
+		name       string
+		encryption *EncryptionConfig
+		wantErr    string
+	}{
+		{
+			name:       "missing file",
+			encryption: &EncryptionConfig{KeyFile: filepath.Join(t.TempDir(), "missing")},
+			wantErr:    "failed to read encryption key file",
+		},
+		{
+			name:       "missing env",
+			encryption: &EncryptionConfig{KeyEnv: "FILE_STORAGE_MISSING_KEY"},
+			wantErr:    `encryption key environment variable "FILE_STORAGE_MISSING_KEY" is not set`,
+		},
+		{
+			name:       "invalid key size",
+			encryption: &EncryptionConfig{KeyFile: keyFile},
+			wantErr:    "invalid encryption key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = tt.encryption
+			_, err := newLocalFileStorage(zap.NewNop(), cfg)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
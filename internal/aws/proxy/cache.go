@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy"
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// samplingRulesPath is the path of the GetSamplingRules API, which is idempotent
+// although called with POST requests.
+const samplingRulesPath = "/GetSamplingRules"
+
+type cachedResponse struct {
+	header http.Header
+	body   []byte
+	expiry time.Time
+}
+
+// samplingRulesCache caches the successful responses of the GetSamplingRules
+// API, identified by the body of the requests holding the pagination token.
+type samplingRulesCache struct {
+	next   http.Handler
+	ttl    time.Duration
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+func newSamplingRulesCache(next http.Handler, ttl time.Duration, logger *zap.Logger) *samplingRulesCache {
+	return &samplingRulesCache{
+		next:    next,
+		ttl:     ttl,
+		logger:  logger,
+		now:     time.Now,
+		entries: map[string]*cachedResponse{},
+	}
+}
+
+func (c *samplingRulesCache) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || req.URL.Path != samplingRulesPath || req.Body == nil {
+		c.next.ServeHTTP(w, req)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	key := string(body)
+	if cached, ok := c.get(key); ok {
+		for k, v := range cached.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(cached.body); err != nil {
+			c.logger.Debug("Unable to write cached sampling rules", zap.Error(err))
+		}
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	c.next.ServeHTTP(recorder, req)
+	if recorder.statusCode == http.StatusOK {
+		c.add(key, recorder.Header().Clone(), recorder.body.Bytes())
+	}
+}
+
+func (c *samplingRulesCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.entries[key]
+	if !ok || !c.now().Before(cached.expiry) {
+		return nil, false
+	}
+	return cached, true
+}
+
+func (c *samplingRulesCache) add(key string, header http.Header, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	// Remove the expired responses, whose pagination tokens may not be used again
+	for k, cached := range c.entries {
+		if !now.Before(cached.expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = &cachedResponse{header: header, body: body, expiry: now.Add(c.ttl)}
+}
+
+// responseRecorder records the status code and the body of a response while
+// writing it.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSamplingRulesCache(t *testing.T) {
+	requests := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("X-Fail") != "" {
+			http.Error(w, "throttled", http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := fmt.Fprintf(w, `{"path":%q,"request":%d}`, req.URL.Path, requests)
+		assert.NoError(t, err)
+	})
+	cache := newSamplingRulesCache(next, time.Minute, zap.NewNop())
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	call := func(path, body string, fail bool) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if fail {
+			req.Header.Set("X-Fail", "true")
+		}
+		rec := httptest.NewRecorder()
+		cache.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+
+	code, body := call("/GetSamplingRules", "{}", true)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, "throttled\n", body)
+
+	_, body = call("/GetSamplingRules", "{}", false)
+	assert.Equal(t, `{"path":"/GetSamplingRules","request":2}`, body)
+	code, body = call("/GetSamplingRules", "{}", false)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"path":"/GetSamplingRules","request":2}`, body)
+
+	_, body = call("/GetSamplingRules", `{"NextToken":"abc"}`, false)
+	assert.Equal(t, `{"path":"/GetSamplingRules","request":3}`, body)
+	_, body = call("/SamplingTargets", "{}", false)
+	assert.Equal(t, `{"path":"/SamplingTargets","request":4}`, body)
+	_, body = call("/SamplingTargets", "{}", false)
+	assert.Equal(t, `{"path":"/SamplingTargets","request":5}`, body)
+
+	now = now.Add(time.Minute)
+	_, body = call("/GetSamplingRules", "{}", false)
+	assert.Equal(t, `{"path":"/GetSamplingRules","request":6}`, body)
+	assert.Len(t, cache.entries, 1)
+}
@@ -4,6 +4,8 @@
 package proxy // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 )
@@ -38,6 +40,11 @@ type Config struct {
 	// will be called or not. Set to `true` to skip EC2 instance
 	// metadata check.
 	LocalMode bool `mapstructure:"local_mode"`
+
+	// SamplingRulesCacheTTL is how long the local TCP server caches the
+	// sampling rules returned by the AWS X-Ray service, so that the SDKs
+	// polling them don't overload the service. Caching is disabled when zero.
+	SamplingRulesCacheTTL time.Duration `mapstructure:"sampling_rules_cache_ttl"`
 }
 
 func DefaultConfig() *Config {
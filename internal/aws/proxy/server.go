@@ -108,9 +108,14 @@ func NewServer(cfg *Config, logger *zap.Logger) (Server, error) {
 		},
 	}
 
+	var serverHandler http.Handler = handler
+	if cfg.SamplingRulesCacheTTL > 0 {
+		serverHandler = newSamplingRulesCache(handler, cfg.SamplingRulesCacheTTL, logger)
+	}
+
 	return &http.Server{
 		Addr:              cfg.Endpoint,
-		Handler:           handler,
+		Handler:           serverHandler,
 		ReadHeaderTimeout: 20 * time.Second,
 	}, nil
 }
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lazyconnect connects components to their backend in the background,
+// so that an unreachable backend does not prevent the collector from starting.
+package lazyconnect // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lazyconnect"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// ErrNotConnected is returned while the connection is not established. It is
+// not permanent, so that exporters retry or queue the data meanwhile.
+var ErrNotConnected = errors.New("not connected to the backend")
+
+// DefaultRetryInterval is the interval between connection attempts used when
+// the configured one is not positive.
+const DefaultRetryInterval = 5 * time.Second
+
+// Connection is a connection to a backend, established in the background once
+// started. The status of the component is reported as a recoverable error
+// until the connection succeeds.
+type Connection[T any] struct {
+	connect       func() (T, error)
+	retryInterval time.Duration
+	logger        *zap.Logger
+	reportStatus  component.StatusFunc
+
+	mu        sync.Mutex
+	conn      T
+	connected bool
+	started   bool
+	closeFunc func(T) error
+	stopCh    chan struct{}
+}
+
+// New returns a Connection established with connect, retried every
+// retryInterval once started.
+func New[T any](connect func() (T, error), retryInterval time.Duration, set component.TelemetrySettings) *Connection[T] {
+	if retryInterval <= 0 {
+		retryInterval = DefaultRetryInterval
+	}
+	return &Connection[T]{
+		connect:       connect,
+		retryInterval: retryInterval,
+		logger:        set.Logger,
+		reportStatus:  set.ReportComponentStatus,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Connected returns a Connection already established with conn.
+func Connected[T any](conn T) *Connection[T] {
+	return &Connection[T]{conn: conn, connected: true, stopCh: make(chan struct{})}
+}
+
+// Start starts connecting in the background, unless already connected.
+func (c *Connection[T]) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected || c.started || c.closeFunc != nil {
+		return
+	}
+	c.started = true
+	go c.run()
+}
+
+func (c *Connection[T]) run() {
+	for {
+		conn, err := c.connect()
+		if err == nil {
+			c.mu.Lock()
+			if closeFunc := c.closeFunc; closeFunc != nil {
+				// Shut down while connecting
+				c.mu.Unlock()
+				if err = closeFunc(conn); err != nil {
+					c.logger.Warn("Failed to close the connection to the backend", zap.Error(err))
+				}
+				return
+			}
+			c.conn = conn
+			c.connected = true
+			c.mu.Unlock()
+			c.logger.Info("Connected to the backend")
+			_ = c.reportStatus(component.NewStatusEvent(component.StatusOK))
+			return
+		}
+
+		select {
+		case <-c.stopCh:
+			return
+		default:
+		}
+		c.logger.Warn("Failed to connect to the backend, retrying", zap.Duration("interval", c.retryInterval), zap.Error(err))
+		_ = c.reportStatus(component.NewRecoverableErrorEvent(err))
+		select {
+		case <-c.stopCh:
+			return
+		case <-time.After(c.retryInterval):
+		}
+	}
+}
+
+// Get returns the connection, or ErrNotConnected while it is not established.
+func (c *Connection[T]) Get() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.connected {
+		var zero T
+		return zero, ErrNotConnected
+	}
+	return c.conn, nil
+}
+
+// Shutdown stops connecting, and closes the connection with closeFunc if it
+// is established. A connection attempt in progress is not waited for, its
+// connection is closed with closeFunc once established.
+func (c *Connection[T]) Shutdown(closeFunc func(T) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closeFunc != nil {
+		return nil
+	}
+	c.closeFunc = closeFunc
+	close(c.stopCh)
+	if !c.connected {
+		return nil
+	}
+	c.connected = false
+	return closeFunc(c.conn)
+}
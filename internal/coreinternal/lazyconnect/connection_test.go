@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lazyconnect
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses []component.Status
+}
+
+func (r *statusRecorder) report(ev *component.StatusEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses = append(r.statuses, ev.Status())
+	return nil
+}
+
+func (r *statusRecorder) get() []component.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]component.Status(nil), r.statuses...)
+}
+
+func newTestConnection(connect func() (string, error), retryInterval time.Duration) (*Connection[string], *statusRecorder) {
+	recorder := &statusRecorder{}
+	set := componenttest.NewNopTelemetrySettings()
+	set.ReportComponentStatus = recorder.report
+	return New(connect, retryInterval, set), recorder
+}
+
+func TestConnection(t *testing.T) {
+	attempts := 0
+	connect := make(chan struct{})
+	c, recorder := newTestConnection(func() (string, error) {
+		attempts++
+		if attempts == 1 {
+			return "", errors.New("backend unreachable")
+		}
+		<-connect
+		return "conn", nil
+	}, time.Millisecond)
+	c.Start()
+
+	_, err := c.Get()
+	assert.ErrorIs(t, err, ErrNotConnected)
+
+	close(connect)
+	assert.Eventually(t, func() bool {
+		return len(recorder.get()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, recorder.get())
+
+	conn, err := c.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "conn", conn)
+
+	var closed []string
+	require.NoError(t, c.Shutdown(func(conn string) error {
+		closed = append(closed, conn)
+		return nil
+	}))
+	assert.Equal(t, []string{"conn"}, closed)
+}
+
+func TestConnectionShutdownWhileConnecting(t *testing.T) {
+	c, recorder := newTestConnection(func() (string, error) {
+		return "", errors.New("backend unreachable")
+	}, time.Hour)
+	c.Start()
+	assert.Eventually(t, func() bool {
+		return len(recorder.get()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.Shutdown(func(string) error {
+		return errors.New("must not be closed")
+	}))
+	assert.Equal(t, []component.Status{component.StatusRecoverableError}, recorder.get())
+}
+
+func TestConnectionShutdownDuringAttempt(t *testing.T) {
+	attempt := make(chan struct{})
+	connect := make(chan struct{})
+	c, recorder := newTestConnection(func() (string, error) {
+		close(attempt)
+		<-connect
+		return "conn", nil
+	}, time.Hour)
+	c.Start()
+	<-attempt
+
+	closed := make(chan string)
+	require.NoError(t, c.Shutdown(func(conn string) error {
+		closed <- conn
+		return nil
+	}))
+
+	// The connection established after the shutdown is closed
+	close(connect)
+	assert.Equal(t, "conn", <-closed)
+	_, err := c.Get()
+	assert.ErrorIs(t, err, ErrNotConnected)
+	assert.Empty(t, recorder.get())
+}
+
+func TestConnectionShutdownNotStarted(t *testing.T) {
+	c, _ := newTestConnection(func() (string, error) {
+		return "conn", nil
+	}, time.Second)
+	_, err := c.Get()
+	assert.ErrorIs(t, err, ErrNotConnected)
+	require.NoError(t, c.Shutdown(func(string) error {
+		return errors.New("must not be closed")
+	}))
+}
+
+func TestConnectionDefaultRetryInterval(t *testing.T) {
+	c, _ := newTestConnection(nil, 0)
+	assert.Equal(t, DefaultRetryInterval, c.retryInterval)
+}
+
+func TestConnected(t *testing.T) {
+	c := Connected("conn")
+	c.Start()
+	conn, err := c.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "conn", conn)
+	require.NoError(t, c.Shutdown(func(string) error { return nil }))
+}
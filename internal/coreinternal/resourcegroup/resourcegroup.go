@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resourcegroup splits the resources of telemetry data by a key, for
+// the exporters sending the resources to different destinations.
+package resourcegroup // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/resourcegroup"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Group holds the resources of telemetry data which share a key.
+type Group[T any] struct {
+	Key  string
+	Data T
+}
+
+// Traces splits the resources of td by the key returned by key. The groups
+// are in the order their key is first seen, and hold td itself rather than a
+// copy when all the resources share a key.
+func Traces(td ptrace.Traces, key func(pcommon.Resource) string) []Group[ptrace.Traces] {
+	rss := td.ResourceSpans()
+	return group(td, rss.Len(),
+		func(i int) pcommon.Resource { return rss.At(i).Resource() },
+		ptrace.NewTraces,
+		func(i int, dest ptrace.Traces) { rss.At(i).CopyTo(dest.ResourceSpans().AppendEmpty()) },
+		key)
+}
+
+// Metrics splits the resources of md by the key returned by key, as Traces does.
+func Metrics(md pmetric.Metrics, key func(pcommon.Resource) string) []Group[pmetric.Metrics] {
+	rms := md.ResourceMetrics()
+	return group(md, rms.Len(),
+		func(i int) pcommon.Resource { return rms.At(i).Resource() },
+		pmetric.NewMetrics,
+		func(i int, dest pmetric.Metrics) { rms.At(i).CopyTo(dest.ResourceMetrics().AppendEmpty()) },
+		key)
+}
+
+// Logs splits the resources of ld by the key returned by key, as Traces does.
+func Logs(ld plog.Logs, key func(pcommon.Resource) string) []Group[plog.Logs] {
+	rls := ld.ResourceLogs()
+	return group(ld, rls.Len(),
+		func(i int) pcommon.Resource { return rls.At(i).Resource() },
+		plog.NewLogs,
+		func(i int, dest plog.Logs) { rls.At(i).CopyTo(dest.ResourceLogs().AppendEmpty()) },
+		key)
+}
+
+func group[T any](
+	data T,
+	count int,
+	resource func(i int) pcommon.Resource,
+	newData func() T,
+	copyTo func(i int, dest T),
+	key func(pcommon.Resource) string,
+) []Group[T] {
+	var groups []Group[T]
+	index := map[string]int{}
+	keys := make([]string, count)
+	for i := range keys {
+		keys[i] = key(resource(i))
+		if _, ok := index[keys[i]]; !ok {
+			index[keys[i]] = len(groups)
+			groups = append(groups, Group[T]{Key: keys[i]})
+		}
+	}
+	if len(groups) == 1 {
+		groups[0].Data = data
+		return groups
+	}
+	for i := range groups {
+		groups[i].Data = newData()
+	}
+	for i, k := range keys {
+		copyTo(i, groups[index[k]].Data)
+	}
+	return groups
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcegroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func serviceName(resource pcommon.Resource) string {
+	name, _ := resource.Attributes().Get("service.name")
+	return name.AsString()
+}
+
+func TestTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, name := range []string{"cart", "checkout", "cart"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", name)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	}
+
+	groups := Traces(td, serviceName)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "cart", groups[0].Key)
+	assert.Equal(t, 2, groups[0].Data.SpanCount())
+	assert.Equal(t, "checkout", groups[1].Key)
+	assert.Equal(t, 1, groups[1].Data.SpanCount())
+	assert.Equal(t, 3, td.SpanCount())
+}
+
+func TestMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, name := range []string{"cart", "checkout"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", name)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(name)
+	}
+
+	groups := Metrics(md, serviceName)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "cart", groups[0].Key)
+	assert.Equal(t, "cart", groups[0].Data.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, "checkout", groups[1].Key)
+	assert.Equal(t, 1, groups[1].Data.MetricCount())
+}
+
+func TestLogsSingleKey(t *testing.T) {
+	ld := plog.NewLogs()
+	for i := 0; i < 2; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", "cart")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+
+	// The resources sharing a key are not copied.
+	groups := Logs(ld, serviceName)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "cart", groups[0].Key)
+	groups[0].Data.ResourceLogs().AppendEmpty()
+	assert.Equal(t, 3, ld.ResourceLogs().Len())
+
+	assert.Empty(t, Logs(plog.NewLogs(), serviceName))
+}
@@ -34,7 +34,7 @@ type SASLConfig struct {
 	Username string `mapstructure:"username"`
 	// Password to be used on authentication
 	Password string `mapstructure:"password"`
-	// SASL Mechanism to be used, possible values are: (PLAIN, AWS_MSK_IAM, SCRAM-SHA-256 or SCRAM-SHA-512).
+	// SASL Mechanism to be used, possible values are: (PLAIN, AWS_MSK_IAM, AWS_MSK_IAM_OAUTHBEARER, SCRAM-SHA-256 or SCRAM-SHA-512).
 	Mechanism string `mapstructure:"mechanism"`
 	// SASL Protocol Version to be used, possible values are: (0, 1). Defaults to 0.
 	Version int `mapstructure:"version"`
@@ -43,7 +43,7 @@ type SASLConfig struct {
 }
 
 // AWSMSKConfig defines the additional SASL authentication
-// measures needed to use AWS_MSK_IAM mechanism
+// measures needed to use AWS_MSK_IAM and AWS_MSK_IAM_OAUTHBEARER mechanisms
 type AWSMSKConfig struct {
 	// Region is the AWS region the MSK cluster is based in
 	Region string `mapstructure:"region"`
@@ -91,6 +91,19 @@ func configurePlaintext(config PlainTextConfig, saramaConfig *sarama.Config) {
 }
 
 func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
+	saramaConfig.Net.SASL.Enable = true
+
+	// The OAUTHBEARER tokens are signed with the AWS credentials of the collector,
+	// so no username and password are needed.
+	if config.Mechanism == awsmsk.OAuthBearerMechanism {
+		tokenProvider, err := awsmsk.NewTokenProvider(config.AWSMSK.Region)
+		if err != nil {
+			return err
+		}
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		saramaConfig.Net.SASL.TokenProvider = tokenProvider
+		return configureSASLVersion(config, saramaConfig)
+	}
 
 	if config.Username == "" {
 		return fmt.Errorf("username have to be provided")
@@ -100,7 +113,6 @@ func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
 		return fmt.Errorf("password have to be provided")
 	}
 
-	saramaConfig.Net.SASL.Enable = true
 	saramaConfig.Net.SASL.User = config.Username
 	saramaConfig.Net.SASL.Password = config.Password
 
@@ -119,9 +131,13 @@ func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
 		}
 		saramaConfig.Net.SASL.Mechanism = awsmsk.Mechanism
 	default:
-		return fmt.Errorf(`invalid SASL Mechanism %q: can be either "PLAIN", "AWS_MSK_IAM", "SCRAM-SHA-256" or "SCRAM-SHA-512"`, config.Mechanism)
+		return fmt.Errorf(`invalid SASL Mechanism %q: can be either "PLAIN", "AWS_MSK_IAM", "AWS_MSK_IAM_OAUTHBEARER", "SCRAM-SHA-256" or "SCRAM-SHA-512"`, config.Mechanism)
 	}
 
+	return configureSASLVersion(config, saramaConfig)
+}
+
+func configureSASLVersion(config SASLConfig, saramaConfig *sarama.Config) error {
 	switch config.Version {
 	case 0:
 		saramaConfig.Net.SASL.Version = sarama.SASLHandshakeV0
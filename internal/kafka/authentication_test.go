@@ -44,6 +44,10 @@ func TestAuthentication(t *testing.T) {
 
 	saramaSASLPLAINConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
 
+	saramaSASLAWSIAMOAUTHConfig := &sarama.Config{}
+	saramaSASLAWSIAMOAUTHConfig.Net.SASL.Enable = true
+	saramaSASLAWSIAMOAUTHConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+
 	saramaTLSCfg := &sarama.Config{}
 	saramaTLSCfg.Net.TLS.Enable = true
 	tlsClient := configtls.TLSClientSetting{}
@@ -107,6 +111,15 @@ func TestAuthentication(t *testing.T) {
 			auth:         Authentication{SASL: &SASLConfig{Username: "jdoe", Password: "pass", Mechanism: "PLAIN"}},
 			saramaConfig: saramaSASLPLAINConfig,
 		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "AWS_MSK_IAM_OAUTHBEARER", AWSMSK: AWSMSKConfig{Region: "us-east-1"}}},
+			saramaConfig: saramaSASLAWSIAMOAUTHConfig,
+		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "AWS_MSK_IAM_OAUTHBEARER"}},
+			saramaConfig: saramaSASLAWSIAMOAUTHConfig,
+			err:          "missing MSK cluster region",
+		},
 		{
 			auth:         Authentication{SASL: &SASLConfig{Username: "jdoe", Password: "pass", Mechanism: "SCRAM-SHA-222"}},
 			saramaConfig: saramaSASLSCRAM512Config,
@@ -138,6 +151,11 @@ func TestAuthentication(t *testing.T) {
 			} else {
 				// equalizes SCRAMClientGeneratorFunc to do assertion with the same reference.
 				config.Net.SASL.SCRAMClientGeneratorFunc = test.saramaConfig.Net.SASL.SCRAMClientGeneratorFunc
+				// The token provider is tested by the awsmsk package.
+				if test.auth.SASL != nil && test.auth.SASL.Mechanism == "AWS_MSK_IAM_OAUTHBEARER" {
+					assert.NotNil(t, config.Net.SASL.TokenProvider)
+					config.Net.SASL.TokenProvider = nil
+				}
 				assert.Equal(t, test.saramaConfig, config)
 			}
 		})
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awsmsk // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/awsmsk"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	sign "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	// OAuthBearerMechanism is the SASL OAUTHBEARER mechanism, using tokens
+	// signed with the IAM credentials of the collector.
+	OAuthBearerMechanism = "AWS_MSK_IAM_OAUTHBEARER"
+
+	tokenUserAgent = "opentelemetry-collector-contrib"
+	// tokenLifetime is the lifetime of the signed tokens, which is the maximum
+	// accepted by MSK.
+	tokenLifetime = 15 * time.Minute
+	// tokenRefreshWindow is how long before its expiry a token is replaced.
+	tokenRefreshWindow = 3 * time.Minute
+)
+
+var _ sarama.AccessTokenProvider = (*TokenProvider)(nil)
+
+// TokenProvider provides the SASL OAUTHBEARER tokens of the MSK IAM access
+// control. The tokens are signed with the credentials of the default AWS
+// credentials chain, e.g. the web identity of IAM roles for service accounts
+// on EKS, and are reused until they are about to expire.
+type TokenProvider struct {
+	region      string
+	credentials *credentials.Credentials
+	now         func() time.Time
+
+	mu     sync.Mutex
+	token  *sarama.AccessToken
+	expiry time.Time
+}
+
+// NewTokenProvider creates a TokenProvider for the MSK clusters of region.
+func NewTokenProvider(region string) (*TokenProvider, error) {
+	if region == "" {
+		return nil, errors.New("missing MSK cluster region")
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	return newTokenProvider(region, sess.Config.Credentials), nil
+}
+
+func newTokenProvider(region string, creds *credentials.Credentials) *TokenProvider {
+	return &TokenProvider{
+		region:      region,
+		credentials: creds,
+		now:         time.Now,
+	}
+}
+
+// Token returns the current token, or signs a new one if the current token
+// expires within the refresh window.
+func (p *TokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.token != nil && now.Before(p.expiry.Add(-tokenRefreshWindow)) {
+		return p.token, nil
+	}
+
+	token, err := p.signToken(now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign MSK IAM token: %w", err)
+	}
+	p.token = &sarama.AccessToken{Token: token}
+	p.expiry = now.Add(tokenLifetime)
+	return p.token, nil
+}
+
+// signToken returns a token signed at signTime. The token is the base64url
+// encoding of a presigned kafka-cluster:Connect request.
+func (p *TokenProvider) signToken(signTime time.Time) (string, error) {
+	endpoint := fmt.Sprintf("https://kafka.%s.amazonaws.com/?Action=%s", p.region, "kafka-cluster%3AConnect")
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	signer := sign.NewSigner(p.credentials)
+	if _, err = signer.Presign(req, nil, service, p.region, tokenLifetime, signTime); err != nil {
+		return "", err
+	}
+
+	query := req.URL.Query()
+	query.Set("User-Agent", tokenUserAgent)
+	req.URL.RawQuery = query.Encode()
+	return base64.RawURLEncoding.EncodeToString([]byte(req.URL.String())), nil
+}
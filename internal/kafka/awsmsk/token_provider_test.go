@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awsmsk
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenProvider(t *testing.T) {
+	now := time.Date(2023, 10, 30, 12, 0, 0, 0, time.UTC)
+	provider := newTokenProvider("us-east-1", credentials.NewStaticCredentials("AKID", "SECRET", "SESSION"))
+	provider.now = func() time.Time { return now }
+
+	token, err := provider.Token()
+	require.NoError(t, err)
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token.Token)
+	require.NoError(t, err)
+	u, err := url.Parse(string(decoded))
+	require.NoError(t, err)
+	assert.Equal(t, "kafka.us-east-1.amazonaws.com", u.Host)
+
+	query := u.Query()
+	assert.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	assert.Equal(t, "AWS4-HMAC-SHA256", query.Get("X-Amz-Algorithm"))
+	assert.Equal(t, "AKID/20231030/us-east-1/kafka-cluster/aws4_request", query.Get("X-Amz-Credential"))
+	assert.Equal(t, "20231030T120000Z", query.Get("X-Amz-Date"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "SESSION", query.Get("X-Amz-Security-Token"))
+	assert.Equal(t, tokenUserAgent, query.Get("User-Agent"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+
+	// The token is reused until it is about to expire
+	now = now.Add(tokenLifetime - tokenRefreshWindow - time.Second)
+	cached, err := provider.Token()
+	require.NoError(t, err)
+	assert.Same(t, token, cached)
+
+	now = now.Add(time.Second)
+	refreshed, err := provider.Token()
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Token, refreshed.Token)
+}
+
+func TestTokenProviderCredentialsError(t *testing.T) {
+	provider := newTokenProvider("us-east-1", credentials.NewStaticCredentials("", "", ""))
+	_, err := provider.Token()
+	assert.ErrorContains(t, err, "failed to sign MSK IAM token")
+}
+
+func TestNewTokenProviderNoRegion(t *testing.T) {
+	_, err := NewTokenProvider("")
+	assert.EqualError(t, err, "missing MSK cluster region")
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package natsconn holds the connection settings shared by the NATS components.
+package natsconn // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+
+import (
+	"errors"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// AuthConfig defines the credentials of the connection to the servers. At most
+// one of the token, the username, the credentials file and the NKey seed file
+// may be set.
+type AuthConfig struct {
+	Token    configopaque.String `mapstructure:"token"`
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	// CredentialsFile is the path to a file holding the user JWT and NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+	// NKeySeedFile is the path to a file holding the NKey seed of the user.
+	NKeySeedFile string `mapstructure:"nkey_seed_file"`
+}
+
+// Validate checks that at most one authentication method is set.
+func (cfg *AuthConfig) Validate() error {
+	methods := 0
+	for _, set := range []bool{cfg.Token != "", cfg.Username != "", cfg.CredentialsFile != "", cfg.NKeySeedFile != ""} {
+		if set {
+			methods++
+		}
+	}
+	if methods > 1 {
+		return errors.New("only one of auth token, username, credentials_file and nkey_seed_file may be set")
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.New("auth password requires a username")
+	}
+	return nil
+}
+
+// ConnectOptions returns the options of a connection named name, secured by tls
+// when not nil and authenticated by auth.
+func ConnectOptions(tls *configtls.TLSClientSetting, auth AuthConfig, name string) ([]nats.Option, error) {
+	opts := []nats.Option{
+		nats.Name(name),
+		// The connection is reestablished for the lifetime of the component
+		nats.MaxReconnects(-1),
+	}
+	if tls != nil {
+		tlsConfig, err := tls.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			opts = append(opts, nats.Secure(tlsConfig))
+		}
+	}
+	switch {
+	case auth.Token != "":
+		opts = append(opts, nats.Token(string(auth.Token)))
+	case auth.Username != "":
+		opts = append(opts, nats.UserInfo(auth.Username, string(auth.Password)))
+	case auth.CredentialsFile != "":
+		opts = append(opts, nats.UserCredentials(auth.CredentialsFile))
+	case auth.NKeySeedFile != "":
+		opt, err := nats.NkeyOptionFromSeed(auth.NKeySeedFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
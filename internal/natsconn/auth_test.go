@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsconn
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func applyOptions(t *testing.T, tls *configtls.TLSClientSetting, auth AuthConfig) nats.Options {
+	opts, err := ConnectOptions(tls, auth, "otelcol/nats")
+	require.NoError(t, err)
+	options := nats.GetDefaultOptions()
+	for _, opt := range opts {
+		require.NoError(t, opt(&options))
+	}
+	return options
+}
+
+func TestConnectOptions(t *testing.T) {
+	options := applyOptions(t, nil, AuthConfig{})
+	assert.Equal(t, "otelcol/nats", options.Name)
+	assert.Equal(t, -1, options.MaxReconnect)
+	assert.False(t, options.Secure)
+
+	options = applyOptions(t, &configtls.TLSClientSetting{}, AuthConfig{Token: "secret"})
+	assert.Equal(t, "secret", options.Token)
+	assert.True(t, options.Secure)
+
+	options = applyOptions(t, nil, AuthConfig{Username: "otelcol", Password: "secret"})
+	assert.Equal(t, "otelcol", options.User)
+	assert.Equal(t, "secret", options.Password)
+}
+
+func TestConnectOptionsErrors(t *testing.T) {
+	_, err := ConnectOptions(nil, AuthConfig{NKeySeedFile: "missing.nk"}, "otelcol/nats")
+	assert.Error(t, err)
+
+	_, err = ConnectOptions(&configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{CAFile: "missing.pem"}}, AuthConfig{}, "otelcol/nats")
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&AuthConfig{Username: "otelcol", Password: "secret"}).Validate())
+	assert.EqualError(t, (&AuthConfig{Token: "secret", NKeySeedFile: "user.nk"}).Validate(),
+		"only one of auth token, username, credentials_file and nkey_seed_file may be set")
+	assert.EqualError(t, (&AuthConfig{Password: "secret"}).Validate(), "auth password requires a username")
+}
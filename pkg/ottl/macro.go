@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// maxMacroDepth bounds the expansion of macros referencing other macros.
+const maxMacroDepth = 10
+
+var macroNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Macro is a named and parameterized group of statements, or a condition, that can be
+// referenced from statements and conditions instead of being copied around.
+// Parameters are referenced as `$name` in the body of the macro.
+type Macro struct {
+	// Params are the names of the macro parameters.
+	Params []string `mapstructure:"params" yaml:"params"`
+	// Statements are the statements a statement macro expands to.
+	Statements []string `mapstructure:"statements" yaml:"statements"`
+	// Condition is the condition a condition macro expands to.
+	Condition string `mapstructure:"condition" yaml:"condition"`
+}
+
+// Macros holds macros by name.
+//
+// A statement macro is referenced by a statement consisting of a call to the macro,
+// optionally followed by a where clause that is added to every expanded statement:
+//
+//	redact(attributes["query"]) where attributes["db.system"] == "mysql"
+//
+// A condition macro can be referenced anywhere a boolean expression is allowed:
+//
+//	set(attributes["health"], true) where is_health_check(attributes["http.target"])
+type Macros map[string]Macro
+
+// Validate checks that the macros are well-formed.
+func (m Macros) Validate() error {
+	var errs error
+	for name, macro := range m {
+		if err := macro.validate(name); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("macro %q: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// ValidateNames checks that none of the macros is named after one of the functions, as the
+// calls to the function would be expanded as references to the macro.
+func (m Macros) ValidateNames(functions []string) error {
+	used := make(map[string]bool, len(functions))
+	for _, function := range functions {
+		used[function] = true
+	}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs error
+	for _, name := range names {
+		if used[name] {
+			errs = multierr.Append(errs, fmt.Errorf("macro %q: name is already used by a function", name))
+		}
+	}
+	return errs
+}
+
+// FunctionNames returns the sorted names of the functions.
+func FunctionNames[K any](functions ...map[string]Factory[K]) []string {
+	unique := make(map[string]bool)
+	for _, fs := range functions {
+		for name := range fs {
+			unique[name] = true
+		}
+	}
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadMacros returns the macros along with the macros defined in the given YAML files, so that
+// macros can be shared between components. The files map the names of the macros to their definitions,
+// in the same format as the macros of the components. A macro can't be defined more than once.
+func LoadMacros(macros Macros, files []string) (Macros, error) {
+	if len(files) == 0 {
+		return macros, nil
+	}
+	loaded := make(Macros, len(macros))
+	for name, macro := range macros {
+		loaded[name] = macro
+	}
+	for _, file := range files {
+		fileMacros, err := readMacros(file)
+		if err != nil {
+			return nil, err
+		}
+		for name, macro := range fileMacros {
+			if _, ok := loaded[name]; ok {
+				return nil, fmt.Errorf("macro %q of %s is already defined", name, file)
+			}
+			loaded[name] = macro
+		}
+	}
+	if err := loaded.Validate(); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
+func readMacros(file string) (Macros, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the macros: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	var macros Macros
+	if err = decoder.Decode(&macros); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse the macros of %s: %w", file, err)
+	}
+	return macros, nil
+}
+
+func (m Macro) validate(name string) error {
+	if !macroNameRegexp.MatchString(name) {
+		return errors.New("name must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if (len(m.Statements) == 0) == (m.Condition == "") {
+		return errors.New("exactly one of statements or condition must be set")
+	}
+
+	params := make(map[string]bool, len(m.Params))
+	for _, p := range m.Params {
+		if !macroNameRegexp.MatchString(p) {
+			return fmt.Errorf("invalid parameter name %q", p)
+		}
+		if params[p] {
+			return fmt.Errorf("duplicate parameter %q", p)
+		}
+		params[p] = true
+	}
+
+	bodies := m.Statements
+	if m.Condition != "" {
+		bodies = []string{m.Condition}
+	}
+	for _, body := range bodies {
+		if _, err := substituteParams(body, params, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExpandStatements replaces the references to macros in the statements with their bodies.
+func (m Macros) ExpandStatements(statements []string) ([]string, error) {
+	if len(m) == 0 {
+		return statements, nil
+	}
+	return m.expandStatements(statements, 0)
+}
+
+func (m Macros) expandStatements(statements []string, depth int) ([]string, error) {
+	if depth > maxMacroDepth {
+		return nil, fmt.Errorf("macros nested deeper than %d levels", maxMacroDepth)
+	}
+
+	var expanded []string
+	for _, statement := range statements {
+		name, args, where, ok, err := m.parseStatementMacroCall(statement)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			condition, err := m.expandConditions(statement, depth)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, condition)
+			continue
+		}
+
+		body, err := m.instantiate(name, args)
+		if err != nil {
+			return nil, err
+		}
+		if where != "" {
+			for i, s := range body {
+				body[i] = addCondition(s, where)
+			}
+		}
+		body, err = m.expandStatements(body, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, body...)
+	}
+	return expanded, nil
+}
+
+// ExpandConditions replaces the references to condition macros in the conditions with their bodies.
+func (m Macros) ExpandConditions(conditions []string) ([]string, error) {
+	if len(m) == 0 {
+		return conditions, nil
+	}
+	expanded := make([]string, len(conditions))
+	for i, condition := range conditions {
+		var err error
+		if expanded[i], err = m.expandConditions(condition, 0); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
+func (m Macros) expandConditions(text string, depth int) (string, error) {
+	if depth > maxMacroDepth {
+		return "", fmt.Errorf("macros nested deeper than %d levels", maxMacroDepth)
+	}
+
+	var b strings.Builder
+	replaced := false
+	i := 0
+	for i < len(text) {
+		if text[i] == '"' {
+			end := skipString(text, i)
+			b.WriteString(text[i:end])
+			i = end
+			continue
+		}
+		if !isIdentStart(text[i]) || (i > 0 && isIdentPart(text[i-1])) {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(text) && isIdentPart(text[end]) {
+			end++
+		}
+		name := text[i:end]
+		macro, ok := m[name]
+		if !ok || macro.Condition == "" || end >= len(text) || text[end] != '(' {
+			b.WriteString(name)
+			i = end
+			continue
+		}
+
+		args, next, err := parseMacroArgs(text, end)
+		if err != nil {
+			return "", fmt.Errorf("macro %q: %w", name, err)
+		}
+		body, err := m.instantiate(name, args)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("(" + body[0] + ")")
+		replaced = true
+		i = next
+	}
+
+	if !replaced {
+		return text, nil
+	}
+	// the conditions may reference other macros
+	return m.expandConditions(b.String(), depth+1)
+}
+
+// parseStatementMacroCall checks whether the statement is a call to a statement macro.
+func (m Macros) parseStatementMacroCall(statement string) (name string, args []string, where string, ok bool, err error) {
+	statement = strings.TrimSpace(statement)
+	end := 0
+	for end < len(statement) && isIdentPart(statement[end]) {
+		end++
+	}
+	name = statement[:end]
+	macro, found := m[name]
+	if !found || len(macro.Statements) == 0 || end >= len(statement) || statement[end] != '(' {
+		return "", nil, "", false, nil
+	}
+
+	args, next, err := parseMacroArgs(statement, end)
+	if err != nil {
+		return "", nil, "", false, fmt.Errorf("macro %q: %w", name, err)
+	}
+	rest := strings.TrimSpace(statement[next:])
+	if rest != "" {
+		condition, found := strings.CutPrefix(rest, "where")
+		if !found || condition == "" || !isSpace(condition[0]) {
+			return "", nil, "", false, fmt.Errorf("macro %q: unexpected %q after the call", name, rest)
+		}
+		where = strings.TrimSpace(condition)
+	}
+	return name, args, where, true, nil
+}
+
+// instantiate returns the body of the macro with the parameters replaced by the arguments.
+func (m Macros) instantiate(name string, args []string) ([]string, error) {
+	macro := m[name]
+	if len(args) != len(macro.Params) {
+		return nil, fmt.Errorf("macro %q expects %d arguments, got %d", name, len(macro.Params), len(args))
+	}
+	values := make(map[string]string, len(args))
+	params := make(map[string]bool, len(args))
+	for i, p := range macro.Params {
+		values[p] = args[i]
+		params[p] = true
+	}
+
+	bodies := macro.Statements
+	if macro.Condition != "" {
+		bodies = []string{macro.Condition}
+	}
+	instantiated := make([]string, len(bodies))
+	for i, body := range bodies {
+		s, err := substituteParams(body, params, values)
+		if err != nil {
+			return nil, fmt.Errorf("macro %q: %w", name, err)
+		}
+		instantiated[i] = s
+	}
+	return instantiated, nil
+}
+
+// substituteParams replaces the `$name` references outside of string literals with
+// the given values. It only checks the references when values is nil.
+func substituteParams(body string, params map[string]bool, values map[string]string) (string, error) {
+	var b strings.Builder
+	i := 0
+	for i < len(body) {
+		switch body[i] {
+		case '"':
+			end := skipString(body, i)
+			b.WriteString(body[i:end])
+			i = end
+		case '$':
+			end := i + 1
+			for end < len(body) && isIdentPart(body[end]) {
+				end++
+			}
+			param := body[i+1 : end]
+			if !params[param] {
+				return "", fmt.Errorf("unknown parameter %q", "$"+param)
+			}
+			b.WriteString(values[param])
+			i = end
+		default:
+			b.WriteByte(body[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// parseMacroArgs splits the arguments of the call whose opening parenthesis is at
+// the given position, and returns the position following the closing parenthesis.
+func parseMacroArgs(text string, open int) ([]string, int, error) {
+	var args []string
+	depth := 0
+	start := open + 1
+	for i := open + 1; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			i = skipString(text, i) - 1
+		case '(', '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case ')':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if arg := strings.TrimSpace(text[start:i]); arg != "" || len(args) > 0 {
+				if arg == "" {
+					return nil, 0, errors.New("empty argument")
+				}
+				args = append(args, arg)
+			}
+			return args, i + 1, nil
+		case ',':
+			if depth == 0 {
+				arg := strings.TrimSpace(text[start:i])
+				if arg == "" {
+					return nil, 0, errors.New("empty argument")
+				}
+				args = append(args, arg)
+				start = i + 1
+			}
+		}
+	}
+	return nil, 0, errors.New("missing closing parenthesis")
+}
+
+// addCondition adds the condition to the where clause of the statement.
+func addCondition(statement string, condition string) string {
+	if idx := whereIndex(statement); idx >= 0 {
+		return fmt.Sprintf("%s where (%s) and (%s)", strings.TrimSpace(statement[:idx]), strings.TrimSpace(statement[idx+len("where"):]), condition)
+	}
+	return fmt.Sprintf("%s where %s", strings.TrimSpace(statement), condition)
+}
+
+// whereIndex returns the position of the where keyword of the statement, or -1.
+func whereIndex(statement string) int {
+	for i := 0; i < len(statement); i++ {
+		if statement[i] == '"' {
+			i = skipString(statement, i) - 1
+			continue
+		}
+		if strings.HasPrefix(statement[i:], "where") &&
+			(i == 0 || isSpace(statement[i-1])) &&
+			(i+len("where") == len(statement) || isSpace(statement[i+len("where")])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// skipString returns the position following the string literal starting at the given position.
+func skipString(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(text)
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
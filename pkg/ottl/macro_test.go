@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMacros() Macros {
+	return Macros{
+		"redact": {
+			Params: []string{"target"},
+			Statements: []string{
+				`replace_pattern($target, "[0-9]+", "?")`,
+				`set(attributes["redacted"], true) where $target != nil`,
+			},
+		},
+		"is_health_check": {
+			Params:    []string{"path"},
+			Condition: `$path == "/health" or $path == "/ready"`,
+		},
+		"is_noisy": {
+			Params:    []string{"path"},
+			Condition: `is_health_check($path) or $path == "/metrics"`,
+		},
+		"drop_noise": {
+			Statements: []string{`set(attributes["noise"], true) where is_noisy(name)`},
+		},
+		"cleanup": {
+			Statements: []string{`redact(attributes["query"])`, `drop_noise()`},
+		},
+	}
+}
+
+func Test_Macros_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		macros  Macros
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			macros: testMacros(),
+		},
+		{
+			name:    "invalid name",
+			macros:  Macros{"Redact": {Statements: []string{`set(name, "x")`}}},
+			wantErr: `macro "Redact": name must be lowercase letters, digits and underscores, starting with a letter`,
+		},
+		{
+			name:    "no body",
+			macros:  Macros{"empty": {}},
+			wantErr: `macro "empty": exactly one of statements or condition must be set`,
+		},
+		{
+			name:    "both bodies",
+			macros:  Macros{"both": {Statements: []string{`set(name, "x")`}, Condition: `name == "x"`}},
+			wantErr: `macro "both": exactly one of statements or condition must be set`,
+		},
+		{
+			name:    "duplicate parameter",
+			macros:  Macros{"dup": {Params: []string{"a", "a"}, Condition: `$a == 1`}},
+			wantErr: `macro "dup": duplicate parameter "a"`,
+		},
+		{
+			name:    "unknown parameter",
+			macros:  Macros{"unknown": {Params: []string{"a"}, Condition: `$a == $b`}},
+			wantErr: `macro "unknown": unknown parameter "$b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.macros.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Macros_ExpandStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		expected   []string
+	}{
+		{
+			name:       "no macro",
+			statements: []string{`set(name, "redact(name)")`},
+			expected:   []string{`set(name, "redact(name)")`},
+		},
+		{
+			name:       "statement macro",
+			statements: []string{`redact(attributes["db.statement"])`},
+			expected: []string{
+				`replace_pattern(attributes["db.statement"], "[0-9]+", "?")`,
+				`set(attributes["redacted"], true) where attributes["db.statement"] != nil`,
+			},
+		},
+		{
+			name:       "statement macro with condition",
+			statements: []string{`redact(attributes["q"]) where attributes["db.system"] == "mysql"`},
+			expected: []string{
+				`replace_pattern(attributes["q"], "[0-9]+", "?") where attributes["db.system"] == "mysql"`,
+				`set(attributes["redacted"], true) where (attributes["q"] != nil) and (attributes["db.system"] == "mysql")`,
+			},
+		},
+		{
+			name:       "condition macro",
+			statements: []string{`set(attributes["health"], true) where is_health_check(attributes["http.target"])`},
+			expected:   []string{`set(attributes["health"], true) where (attributes["http.target"] == "/health" or attributes["http.target"] == "/ready")`},
+		},
+		{
+			name:       "nested macros",
+			statements: []string{`cleanup()`},
+			expected: []string{
+				`replace_pattern(attributes["query"], "[0-9]+", "?")`,
+				`set(attributes["redacted"], true) where attributes["query"] != nil`,
+				`set(attributes["noise"], true) where ((name == "/health" or name == "/ready") or name == "/metrics")`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := testMacros().ExpandStatements(tt.statements)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded)
+
+			for _, statement := range expanded {
+				_, err = parseStatement(statement)
+				assert.NoError(t, err, statement)
+			}
+		})
+	}
+}
+
+func Test_Macros_ExpandStatements_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		macros    Macros
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "wrong number of arguments",
+			macros:    testMacros(),
+			statement: `redact(name, attributes["x"])`,
+			wantErr:   `macro "redact" expects 1 arguments, got 2`,
+		},
+		{
+			name:      "missing parenthesis",
+			macros:    testMacros(),
+			statement: `redact(attributes["x"]`,
+			wantErr:   `macro "redact": missing closing parenthesis`,
+		},
+		{
+			name:      "trailing text",
+			macros:    testMacros(),
+			statement: `redact(name) and more`,
+			wantErr:   `macro "redact": unexpected "and more" after the call`,
+		},
+		{
+			name:      "recursive",
+			macros:    Macros{"loop": {Statements: []string{`loop()`}}},
+			statement: `loop()`,
+			wantErr:   `macros nested deeper than 10 levels`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.macros.ExpandStatements([]string{tt.statement})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Macros_ExpandConditions(t *testing.T) {
+	expanded, err := testMacros().ExpandConditions([]string{
+		`is_health_check(attributes["http.target"]) and name != "is_noisy(name)"`,
+		`name == "x"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`(attributes["http.target"] == "/health" or attributes["http.target"] == "/ready") and name != "is_noisy(name)"`,
+		`name == "x"`,
+	}, expanded)
+
+	_, err = testMacros().ExpandConditions([]string{`is_health_check()`})
+	assert.EqualError(t, err, `macro "is_health_check" expects 1 arguments, got 0`)
+
+	conditions := []string{`name == "x"`}
+	expanded, err = Macros{}.ExpandConditions(conditions)
+	require.NoError(t, err)
+	assert.Equal(t, conditions, expanded)
+}
+
+func Test_Macros_ValidateNames(t *testing.T) {
+	macros := Macros{
+		"set":    {Statements: []string{`set(name, "x")`}},
+		"redact": {Statements: []string{`set(name, "x")`}},
+	}
+	assert.NoError(t, macros.ValidateNames([]string{"delete_key", "IsMatch"}))
+	assert.EqualError(t, macros.ValidateNames([]string{"delete_key", "set", "set"}), `macro "set": name is already used by a function`)
+}
+
+func Test_FunctionNames(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, FunctionNames(
+		map[string]Factory[any]{"b": nil, "a": nil},
+		map[string]Factory[any]{"c": nil, "a": nil},
+	))
+}
+
+func Test_LoadMacros(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared.yaml")
+	require.NoError(t, os.WriteFile(shared, []byte(`
+is_health_check:
+  params: [path]
+  condition: $path == "/health"
+`), 0600))
+
+	macros, err := LoadMacros(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, macros)
+
+	macros, err = LoadMacros(Macros{"drop_noise": {Statements: []string{`set(attributes["noise"], true) where is_health_check(name)`}}}, []string{shared})
+	require.NoError(t, err)
+	assert.Equal(t, Macros{
+		"drop_noise":      {Statements: []string{`set(attributes["noise"], true) where is_health_check(name)`}},
+		"is_health_check": {Params: []string{"path"}, Condition: `$path == "/health"`},
+	}, macros)
+
+	_, err = LoadMacros(Macros{"is_health_check": {Condition: "true"}}, []string{shared})
+	assert.EqualError(t, err, `macro "is_health_check" of `+shared+` is already defined`)
+
+	_, err = LoadMacros(nil, []string{filepath.Join(dir, "missing.yaml")})
+	assert.ErrorContains(t, err, "failed to read the macros")
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("is_health_check:\n  expression: true\n"), 0600))
+	_, err = LoadMacros(nil, []string{invalid})
+	assert.ErrorContains(t, err, "failed to parse the macros")
+}
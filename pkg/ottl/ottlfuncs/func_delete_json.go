@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type DeleteJSONArguments[K any] struct {
+	Target ottl.GetSetter[K]
+	Path   string
+}
+
+func NewDeleteJSONFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("delete_json", &DeleteJSONArguments[K]{}, createDeleteJSONFunction[K])
+}
+
+func createDeleteJSONFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DeleteJSONArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DeleteJSONFactory args must be of type *DeleteJSONArguments[K]")
+	}
+
+	keys, err := splitJSONPath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteJSON(args.Target, keys), nil
+}
+
+func deleteJSON[K any](target ottl.GetSetter[K], keys []string) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		return nil, updateJSON(ctx, tCtx, target, func(object pcommon.Map) (bool, error) {
+			for _, key := range keys[:len(keys)-1] {
+				child, ok := object.Get(key)
+				if !ok || child.Type() != pcommon.ValueTypeMap {
+					return false, nil
+				}
+				object = child.Map()
+			}
+			return object.Remove(keys[len(keys)-1]), nil
+		})
+	}
+}
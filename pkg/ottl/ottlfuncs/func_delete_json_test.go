@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_deleteJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "delete top level key",
+			body:     `{"user":"alice","password":"secret","status":200}`,
+			path:     "password",
+			expected: `{"user":"alice","status":200}`,
+		},
+		{
+			name:     "delete nested key",
+			body:     `{"request":{"headers":{"authorization":"Bearer x","host":"example.com"}}}`,
+			path:     "request.headers.authorization",
+			expected: `{"request":{"headers":{"host":"example.com"}}}`,
+		},
+		{
+			name:     "missing key leaves the body untouched",
+			body:     `{"took": 1.0, "user": "alice"}`,
+			path:     "request.headers",
+			expected: `{"took": 1.0, "user": "alice"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := splitJSONPath(tt.path)
+			require.NoError(t, err)
+			body := pcommon.NewValueStr(tt.body)
+
+			exprFunc := deleteJSON[pcommon.Value](jsonTarget, keys)
+			_, err = exprFunc(context.Background(), body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, body.Str())
+		})
+	}
+}
+
+func Test_deleteJSON_map(t *testing.T) {
+	body := pcommon.NewValueMap()
+	user := body.Map().PutEmptyMap("user")
+	user.PutStr("name", "alice")
+	user.PutStr("password", "secret")
+
+	exprFunc := deleteJSON[pcommon.Value](jsonTarget, []string{"user", "password"})
+	_, err := exprFunc(context.Background(), body)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"user": map[string]any{"name": "alice"}}, body.Map().AsRaw())
+}
+
+func Test_deleteJSON_invalid(t *testing.T) {
+	exprFunc := deleteJSON[pcommon.Value](jsonTarget, []string{"user"})
+	_, err := exprFunc(context.Background(), pcommon.NewValueStr("not json"))
+	assert.ErrorContains(t, err, "expected a JSON object")
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+//go:embed patterns/grok-patterns
+var grokPatternLibrary string
+
+// grokReferenceRegex matches %{SYNTAX}, %{SYNTAX:SEMANTIC} and %{SYNTAX:SEMANTIC:TYPE}.
+var grokReferenceRegex = regexp.MustCompile(`%\{(\w+)(?::([\w@.\[\]-]+))?(?::(\w+))?\}`)
+
+var grokDefaultPatterns = func() map[string]string {
+	patterns, err := parseGrokPatterns(strings.Split(grokPatternLibrary, "\n"))
+	if err != nil {
+		panic(err)
+	}
+	return patterns
+}()
+
+type GrokArguments[K any] struct {
+	Target             ottl.StringGetter[K]
+	Pattern            string
+	PatternDefinitions ottl.Optional[[]string]
+	PatternPaths       ottl.Optional[[]string]
+}
+
+func NewGrokFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Grok", &GrokArguments[K]{}, createGrokFunction[K])
+}
+
+func createGrokFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*GrokArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("GrokFactory args must be of type *GrokArguments[K]")
+	}
+
+	return grok(args.Target, args.Pattern, args.PatternDefinitions, args.PatternPaths)
+}
+
+// grokCapture describes a capture group of the compiled pattern.
+type grokCapture struct {
+	name      string
+	valueType string
+}
+
+func grok[K any](target ottl.StringGetter[K], pattern string, definitions ottl.Optional[[]string], paths ottl.Optional[[]string]) (ottl.ExprFunc[K], error) {
+	patterns := grokDefaultPatterns
+	if !definitions.IsEmpty() || !paths.IsEmpty() {
+		patterns = make(map[string]string, len(grokDefaultPatterns))
+		for name, p := range grokDefaultPatterns {
+			patterns[name] = p
+		}
+	}
+	if !paths.IsEmpty() {
+		custom, err := loadGrokPatternFiles(paths.Get())
+		if err != nil {
+			return nil, fmt.Errorf("the pattern files supplied to Grok are invalid: %w", err)
+		}
+		for name, p := range custom {
+			patterns[name] = p
+		}
+	}
+	if !definitions.IsEmpty() {
+		custom, err := parseGrokPatterns(definitions.Get())
+		if err != nil {
+			return nil, fmt.Errorf("the pattern definitions supplied to Grok are invalid: %w", err)
+		}
+		for name, p := range custom {
+			patterns[name] = p
+		}
+	}
+
+	var captures []grokCapture
+	expanded, err := expandGrokPattern(pattern, patterns, &captures, nil)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to Grok is not a valid pattern: %w", err)
+	}
+	r, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to Grok is not a valid pattern: %w", err)
+	}
+
+	// Map the regex capture groups to the Grok captures. Named groups written
+	// directly in the pattern are kept as is.
+	groups := make([]grokCapture, len(r.SubexpNames()))
+	namedCaptureGroups := 0
+	for i, name := range r.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		if index, ok := grokCaptureIndex(name); ok {
+			groups[i] = captures[index]
+		} else {
+			groups[i] = grokCapture{name: name}
+		}
+		namedCaptureGroups++
+	}
+
+	if namedCaptureGroups == 0 {
+		return nil, fmt.Errorf("at least 1 named capture must be supplied in the given Grok pattern")
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		matches := r.FindStringSubmatchIndex(val)
+		if matches == nil {
+			return result, nil
+		}
+
+		for i, group := range groups {
+			// Skip unnamed groups and groups that did not participate in the match
+			if group.name == "" || matches[2*i] < 0 {
+				continue
+			}
+			value := val[matches[2*i]:matches[2*i+1]]
+			switch group.valueType {
+			case "int":
+				if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+					result.PutInt(group.name, intVal)
+					continue
+				}
+			case "float":
+				if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+					result.PutDouble(group.name, floatVal)
+					continue
+				}
+			}
+			result.PutStr(group.name, value)
+		}
+		return result, nil
+	}, nil
+}
+
+const grokCapturePrefix = "grok__"
+
+func grokCaptureIndex(name string) (int, bool) {
+	suffix, ok := strings.CutPrefix(name, grokCapturePrefix)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(suffix)
+	return index, err == nil
+}
+
+// expandGrokPattern recursively replaces the pattern references with their
+// definitions. Semantic references become capture groups named after their index
+// in captures, since Grok semantics are not valid regex group names.
+func expandGrokPattern(pattern string, patterns map[string]string, captures *[]grokCapture, parents []string) (string, error) {
+	var expandErr error
+	expanded := grokReferenceRegex.ReplaceAllStringFunc(pattern, func(reference string) string {
+		if expandErr != nil {
+			return ""
+		}
+		parts := grokReferenceRegex.FindStringSubmatch(reference)
+		syntax, semantic, valueType := parts[1], parts[2], parts[3]
+
+		definition, ok := patterns[syntax]
+		if !ok {
+			expandErr = fmt.Errorf("unknown pattern %q", syntax)
+			return ""
+		}
+		for _, parent := range parents {
+			if parent == syntax {
+				expandErr = fmt.Errorf("pattern %q references itself", syntax)
+				return ""
+			}
+		}
+		switch valueType {
+		case "", "int", "float":
+		default:
+			expandErr = fmt.Errorf("unsupported type %q for %q, must be one of \"int\" or \"float\"", valueType, semantic)
+			return ""
+		}
+
+		var group string
+		if semantic == "" {
+			group = "(?:"
+		} else {
+			group = fmt.Sprintf("(?P<%s%d>", grokCapturePrefix, len(*captures))
+			*captures = append(*captures, grokCapture{name: semantic, valueType: valueType})
+		}
+		inner, err := expandGrokPattern(definition, patterns, captures, append(parents, syntax))
+		if err != nil {
+			expandErr = err
+			return ""
+		}
+		return group + inner + ")"
+	})
+	return expanded, expandErr
+}
+
+// parseGrokPatterns parses pattern definitions of the form "NAME REGEX". Empty
+// lines and lines starting with # are ignored.
+func parseGrokPatterns(lines []string) (map[string]string, error) {
+	patterns := make(map[string]string, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, definition, ok := strings.Cut(line, " ")
+		if !ok || !isGrokPatternName(name) {
+			return nil, fmt.Errorf("invalid pattern definition %q, must be of the form \"NAME REGEX\"", line)
+		}
+		patterns[name] = strings.TrimSpace(definition)
+	}
+	return patterns, nil
+}
+
+// loadGrokPatternFiles parses the pattern definitions of the given files. The files
+// of a directory are loaded in lexical order, without descending into subdirectories.
+func loadGrokPatternFiles(paths []string) (map[string]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var dirFiles []string
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				dirFiles = append(dirFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+
+	patterns := make(map[string]string)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		filePatterns, err := parseGrokPatterns(strings.Split(string(content), "\n"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for name, p := range filePatterns {
+			patterns[name] = p
+		}
+	}
+	return patterns, nil
+}
+
+func isGrokPatternName(name string) bool {
+	for _, r := range name {
+		if r != '_' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_grok(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		pattern     string
+		definitions ottl.Optional[[]string]
+		want        func(pcommon.Map)
+	}{
+		{
+			name:    "standard patterns",
+			value:   `55.3.244.1 GET /index.html 15824 0.043`,
+			pattern: `%{IP:client} %{WORD:method} %{URIPATHPARAM:request} %{NUMBER:bytes} %{NUMBER:duration}`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("client", "55.3.244.1")
+				expectedMap.PutStr("method", "GET")
+				expectedMap.PutStr("request", "/index.html")
+				expectedMap.PutStr("bytes", "15824")
+				expectedMap.PutStr("duration", "0.043")
+			},
+		},
+		{
+			name:    "typed captures",
+			value:   `55.3.244.1 GET /index.html 15824 0.043`,
+			pattern: `%{IP:client} %{WORD} %{NOTSPACE} %{NUMBER:bytes:int} %{NUMBER:duration:float}`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("client", "55.3.244.1")
+				expectedMap.PutInt("bytes", 15824)
+				expectedMap.PutDouble("duration", 0.043)
+			},
+		},
+		{
+			name:    "nested captures",
+			value:   `Oct 11 22:14:15 mymachine su[1234]: 'su root' failed for lonvick on /dev/pts/8`,
+			pattern: `%{SYSLOGBASE} %{GREEDYDATA:message}`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("timestamp", "Oct 11 22:14:15")
+				expectedMap.PutStr("logsource", "mymachine")
+				expectedMap.PutStr("program", "su")
+				expectedMap.PutStr("pid", "1234")
+				expectedMap.PutStr("message", "'su root' failed for lonvick on /dev/pts/8")
+			},
+		},
+		{
+			name:    "combined apache log",
+			value:   `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			pattern: `^%{COMBINEDAPACHELOG}$`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("clientip", "127.0.0.1")
+				expectedMap.PutStr("ident", "-")
+				expectedMap.PutStr("auth", "frank")
+				expectedMap.PutStr("timestamp", "10/Oct/2000:13:55:36 -0700")
+				expectedMap.PutStr("verb", "GET")
+				expectedMap.PutStr("request", "/apache_pb.gif")
+				expectedMap.PutStr("httpversion", "1.0")
+				expectedMap.PutStr("response", "200")
+				expectedMap.PutStr("bytes", "2326")
+				expectedMap.PutStr("referrer", `"http://www.example.com/start.html"`)
+				expectedMap.PutStr("agent", `"Mozilla/4.08"`)
+			},
+		},
+		{
+			name:    "custom pattern definitions",
+			value:   `[2023-10-11T22:14:15Z] ERROR payment-svc: card declined`,
+			pattern: `\[%{TIMESTAMP_ISO8601:timestamp}\] %{LOGLEVEL:level} %{SERVICE:service}: %{GREEDYDATA:message}`,
+			definitions: ottl.NewTestingOptional[[]string]([]string{
+				"# services are named after their domain",
+				"SERVICE %{SERVICENAME}-svc",
+				"SERVICENAME [a-z]+",
+			}),
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("timestamp", "2023-10-11T22:14:15Z")
+				expectedMap.PutStr("level", "ERROR")
+				expectedMap.PutStr("service", "payment-svc")
+				expectedMap.PutStr("message", "card declined")
+			},
+		},
+		{
+			name:    "regex named capture groups",
+			value:   `user=jdoe took 15ms`,
+			pattern: `user=(?P<user>\w+) took %{INT:duration_ms:int}ms`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("user", "jdoe")
+				expectedMap.PutInt("duration_ms", 15)
+			},
+		},
+		{
+			name:    "optional capture not matched",
+			value:   `Oct 11 22:14:15 mymachine cron: job started`,
+			pattern: `%{SYSLOGBASE} %{GREEDYDATA:message}`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("timestamp", "Oct 11 22:14:15")
+				expectedMap.PutStr("logsource", "mymachine")
+				expectedMap.PutStr("program", "cron")
+				expectedMap.PutStr("message", "job started")
+			},
+		},
+		{
+			name:    "no match",
+			value:   `not an ip`,
+			pattern: `^%{IP:client}$`,
+			want:    func(expectedMap pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			exprFunc, err := grok[any](target, tt.pattern, tt.definitions, ottl.Optional[[]string]{})
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_grok_validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		definitions ottl.Optional[[]string]
+	}{
+		{
+			name:    "bad regex",
+			pattern: "%{WORD:word}(",
+		},
+		{
+			name:    "no named capture",
+			pattern: "%{WORD} (.*)",
+		},
+		{
+			name:    "unknown pattern",
+			pattern: "%{NOT_A_PATTERN:value}",
+		},
+		{
+			name:    "unsupported type",
+			pattern: "%{NUMBER:value:bool}",
+		},
+		{
+			name:        "recursive pattern",
+			pattern:     "%{LOOP:value}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"LOOP a%{LOOP}"}),
+		},
+		{
+			name:        "invalid pattern definition",
+			pattern:     "%{WORD:value}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"NO_REGEX"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return "foobar", nil
+				},
+			}
+			exprFunc, err := grok[any](target, tt.pattern, tt.definitions, ottl.Optional[[]string]{})
+			assert.Error(t, err)
+			assert.Nil(t, exprFunc)
+		})
+	}
+}
+
+func Test_grok_pattern_paths(t *testing.T) {
+	dir := t.TempDir()
+	patternsDir := filepath.Join(dir, "patterns")
+	require.NoError(t, os.Mkdir(patternsDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(patternsDir, "a"), []byte("# services\nSERVICE %{WORD}-svc\nLEVEL [A-Z]+\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(patternsDir, "b"), []byte("LEVEL (?:INFO|WARN|ERROR)\n"), 0600))
+	file := filepath.Join(dir, "extra")
+	require.NoError(t, os.WriteFile(file, []byte("REQUEST_ID [0-9a-f]{8}\n"), 0600))
+
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return "auth-svc WARN 0badcafe 12", nil
+		},
+	}
+	exprFunc, err := grok[any](target, "%{SERVICE:service} %{LEVEL:level} %{REQUEST_ID:request_id} %{COUNT:count:int}",
+		ottl.NewTestingOptional[[]string]([]string{"COUNT %{INT}"}),
+		ottl.NewTestingOptional[[]string]([]string{patternsDir, file}))
+	require.NoError(t, err)
+
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	expected := pcommon.NewMap()
+	expected.PutStr("service", "auth-svc")
+	expected.PutStr("level", "WARN")
+	expected.PutStr("request_id", "0badcafe")
+	expected.PutInt("count", 12)
+	assert.Equal(t, expected.AsRaw(), result.(pcommon.Map).AsRaw())
+
+	// Missing and invalid pattern files are rejected on startup.
+	_, err = grok[any](target, "%{SERVICE:service}", ottl.Optional[[]string]{}, ottl.NewTestingOptional[[]string]([]string{filepath.Join(dir, "missing")}))
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(file, []byte("NO_REGEX\n"), 0600))
+	_, err = grok[any](target, "%{SERVICE:service}", ottl.Optional[[]string]{}, ottl.NewTestingOptional[[]string]([]string{file}))
+	assert.Error(t, err)
+}
+
+func Test_grok_bad_input(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return 123, nil
+		},
+	}
+	exprFunc, err := grok[any](target, "%{GREEDYDATA:line}", ottl.Optional[[]string]{}, ottl.Optional[[]string]{})
+	assert.NoError(t, err)
+
+	result, err := exprFunc(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_grokDefaultPatterns(t *testing.T) {
+	for name := range grokDefaultPatterns {
+		var captures []grokCapture
+		expanded, err := expandGrokPattern("%{"+name+"}", grokDefaultPatterns, &captures, nil)
+		require.NoError(t, err, name)
+		_, err = regexp.Compile(expanded)
+		assert.NoError(t, err, name)
+	}
+}
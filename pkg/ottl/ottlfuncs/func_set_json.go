@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SetJSONArguments[K any] struct {
+	Target ottl.GetSetter[K]
+	Path   string
+	Value  ottl.Getter[K]
+}
+
+func NewSetJSONFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("set_json", &SetJSONArguments[K]{}, createSetJSONFunction[K])
+}
+
+func createSetJSONFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SetJSONArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SetJSONFactory args must be of type *SetJSONArguments[K]")
+	}
+
+	keys, err := splitJSONPath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return setJSON(args.Target, keys, args.Value), nil
+}
+
+func setJSON[K any](target ottl.GetSetter[K], keys []string, value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, updateJSON(ctx, tCtx, target, func(object pcommon.Map) (bool, error) {
+			for i, key := range keys[:len(keys)-1] {
+				child, ok := object.Get(key)
+				if !ok {
+					object = object.PutEmptyMap(key)
+					continue
+				}
+				if child.Type() != pcommon.ValueTypeMap {
+					return false, fmt.Errorf("%q is not an object", strings.Join(keys[:i+1], "."))
+				}
+				object = child.Map()
+			}
+			return true, setJSONValue(object.PutEmpty(keys[len(keys)-1]), val)
+		})
+	}
+}
+
+// updateJSON applies the update to the JSON object held by the target, either as a map,
+// which is updated in place, or as a string, which is parsed and serialized again when
+// the update reports a change.
+func updateJSON[K any](ctx context.Context, tCtx K, target ottl.GetSetter[K], update func(pcommon.Map) (bool, error)) error {
+	val, err := target.Get(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	switch v := val.(type) {
+	case pcommon.Map:
+		_, err = update(v)
+		return err
+	case string:
+		object, err := unmarshalJSONObject(v)
+		if err != nil {
+			return err
+		}
+		changed, err := update(object)
+		if err != nil || !changed {
+			return err
+		}
+		serialized, err := marshalJSONObject(object)
+		if err != nil {
+			return err
+		}
+		return target.Set(ctx, tCtx, serialized)
+	default:
+		return fmt.Errorf("expected a JSON object but got %T", val)
+	}
+}
+
+// splitJSONPath splits a path made of keys separated by dots.
+func splitJSONPath(path string) ([]string, error) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+	}
+	return keys, nil
+}
+
+func setJSONValue(value pcommon.Value, val any) error {
+	switch v := val.(type) {
+	case pcommon.Map:
+		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
+	case pcommon.Value:
+		v.CopyTo(value)
+	default:
+		return value.FromRaw(v)
+	}
+	return nil
+}
+
+// unmarshalJSONObject parses a JSON object, keeping the order of its keys.
+func unmarshalJSONObject(s string) (pcommon.Map, error) {
+	iter := jsoniter.ConfigDefault.BorrowIterator([]byte(s))
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		return pcommon.Map{}, errors.New("expected a JSON object")
+	}
+	object := pcommon.NewMap()
+	readJSONObject(iter, object)
+	if iter.Error != nil {
+		return pcommon.Map{}, iter.Error
+	}
+	return object, nil
+}
+
+func readJSONObject(iter *jsoniter.Iterator, object pcommon.Map) {
+	iter.ReadMapCB(func(iter *jsoniter.Iterator, key string) bool {
+		readJSONValue(iter, object.PutEmpty(key))
+		return iter.Error == nil
+	})
+}
+
+func readJSONValue(iter *jsoniter.Iterator, value pcommon.Value) {
+	switch iter.WhatIsNext() {
+	case jsoniter.StringValue:
+		value.SetStr(iter.ReadString())
+	case jsoniter.NumberValue:
+		n := iter.ReadNumber()
+		if i, err := n.Int64(); err == nil {
+			value.SetInt(i)
+		} else if f, err := n.Float64(); err == nil {
+			value.SetDouble(f)
+		} else {
+			iter.ReportError("readJSONValue", err.Error())
+		}
+	case jsoniter.BoolValue:
+		value.SetBool(iter.ReadBool())
+	case jsoniter.NilValue:
+		iter.ReadNil()
+	case jsoniter.ArrayValue:
+		slice := value.SetEmptySlice()
+		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			readJSONValue(iter, slice.AppendEmpty())
+			return iter.Error == nil
+		})
+	case jsoniter.ObjectValue:
+		readJSONObject(iter, value.SetEmptyMap())
+	default:
+		iter.ReportError("readJSONValue", "invalid JSON value")
+	}
+}
+
+// marshalJSONObject serializes the object as compact JSON, in the order of its keys.
+func marshalJSONObject(object pcommon.Map) (string, error) {
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+
+	writeJSONObject(stream, object)
+	if stream.Error != nil {
+		return "", stream.Error
+	}
+	return string(stream.Buffer()), nil
+}
+
+func writeJSONObject(stream *jsoniter.Stream, object pcommon.Map) {
+	stream.WriteObjectStart()
+	first := true
+	object.Range(func(k string, v pcommon.Value) bool {
+		if !first {
+			stream.WriteMore()
+		}
+		first = false
+		stream.WriteObjectField(k)
+		writeJSONValue(stream, v)
+		return true
+	})
+	stream.WriteObjectEnd()
+}
+
+func writeJSONValue(stream *jsoniter.Stream, value pcommon.Value) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		stream.WriteString(value.Str())
+	case pcommon.ValueTypeInt:
+		stream.WriteInt64(value.Int())
+	case pcommon.ValueTypeDouble:
+		stream.WriteFloat64(value.Double())
+	case pcommon.ValueTypeBool:
+		stream.WriteBool(value.Bool())
+	case pcommon.ValueTypeMap:
+		writeJSONObject(stream, value.Map())
+	case pcommon.ValueTypeSlice:
+		stream.WriteArrayStart()
+		for i := 0; i < value.Slice().Len(); i++ {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			writeJSONValue(stream, value.Slice().At(i))
+		}
+		stream.WriteArrayEnd()
+	case pcommon.ValueTypeBytes:
+		stream.WriteString(value.AsString())
+	default:
+		stream.WriteNil()
+	}
+}
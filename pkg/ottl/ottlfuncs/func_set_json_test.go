@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// jsonTarget reads and writes the value of the transform context, like a log body.
+var jsonTarget = &ottl.StandardGetSetter[pcommon.Value]{
+	Getter: func(ctx context.Context, tCtx pcommon.Value) (interface{}, error) {
+		switch tCtx.Type() {
+		case pcommon.ValueTypeStr:
+			return tCtx.Str(), nil
+		case pcommon.ValueTypeMap:
+			return tCtx.Map(), nil
+		case pcommon.ValueTypeInt:
+			return tCtx.Int(), nil
+		default:
+			return nil, nil
+		}
+	},
+	Setter: func(ctx context.Context, tCtx pcommon.Value, val interface{}) error {
+		tCtx.SetStr(val.(string))
+		return nil
+	},
+}
+
+func literalGetter(val any) ottl.Getter[pcommon.Value] {
+	return &ottl.StandardGetSetter[pcommon.Value]{
+		Getter: func(context.Context, pcommon.Value) (interface{}, error) {
+			return val, nil
+		},
+	}
+}
+
+func Test_setJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		path     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "set top level key",
+			body:     `{"user":"alice","status":200}`,
+			path:     "status",
+			value:    int64(500),
+			expected: `{"user":"alice","status":500}`,
+		},
+		{
+			name:     "set nested key",
+			body:     `{"user":{"name":"alice","password":"secret"},"took":1.5}`,
+			path:     "user.password",
+			value:    "***",
+			expected: `{"user":{"name":"alice","password":"***"},"took":1.5}`,
+		},
+		{
+			name:     "create intermediate objects",
+			body:     `{"user":"alice"}`,
+			path:     "request.headers.host",
+			value:    "example.com",
+			expected: `{"user":"alice","request":{"headers":{"host":"example.com"}}}`,
+		},
+		{
+			name:     "set object",
+			body:     `{"tags":[1,"a",null,true]}`,
+			path:     "meta",
+			value:    map[string]any{"env": "prod"},
+			expected: `{"tags":[1,"a",null,true],"meta":{"env":"prod"}}`,
+		},
+		{
+			name:     "keep escaped strings",
+			body:     `{"html":"<b>\"x\"</b>"}`,
+			path:     "ok",
+			value:    true,
+			expected: `{"html":"<b>\"x\"</b>","ok":true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := splitJSONPath(tt.path)
+			require.NoError(t, err)
+			body := pcommon.NewValueStr(tt.body)
+
+			exprFunc := setJSON[pcommon.Value](jsonTarget, keys, literalGetter(tt.value))
+			_, err = exprFunc(context.Background(), body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, body.Str())
+		})
+	}
+}
+
+func Test_setJSON_map(t *testing.T) {
+	body := pcommon.NewValueMap()
+	body.Map().PutStr("user", "alice")
+
+	exprFunc := setJSON[pcommon.Value](jsonTarget, []string{"request", "id"}, literalGetter(int64(42)))
+	_, err := exprFunc(context.Background(), body)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"user": "alice", "request": map[string]any{"id": int64(42)}}, body.Map().AsRaw())
+}
+
+func Test_setJSON_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     pcommon.Value
+		path     []string
+		expected string
+	}{
+		{
+			name:     "not an object",
+			body:     pcommon.NewValueStr(`{"user":"alice"}`),
+			path:     []string{"user", "name"},
+			expected: `"user" is not an object`,
+		},
+		{
+			name:     "not JSON",
+			body:     pcommon.NewValueStr(`user=alice`),
+			path:     []string{"user"},
+			expected: "expected a JSON object",
+		},
+		{
+			name:     "JSON array",
+			body:     pcommon.NewValueStr(`["alice"]`),
+			path:     []string{"user"},
+			expected: "expected a JSON object",
+		},
+		{
+			name:     "not a string",
+			body:     pcommon.NewValueInt(1),
+			path:     []string{"user"},
+			expected: "expected a JSON object but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := setJSON[pcommon.Value](jsonTarget, tt.path, literalGetter("bob"))
+			_, err := exprFunc(context.Background(), tt.body)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+
+	_, err := splitJSONPath("user..name")
+	assert.EqualError(t, err, `invalid JSON path "user..name"`)
+}
+
+func Test_SetJSON_factory(t *testing.T) {
+	factory := NewSetJSONFactory[pcommon.Value]()
+	_, err := factory.CreateFunction(ottl.FunctionContext{}, &SetJSONArguments[pcommon.Value]{Target: jsonTarget, Path: ""})
+	assert.EqualError(t, err, `invalid JSON path ""`)
+
+	_, err = factory.CreateFunction(ottl.FunctionContext{}, &DeleteJSONArguments[pcommon.Value]{})
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ func StandardFuncs[K any]() map[string]ottl.Factory[K] {
 	f := []ottl.Factory[K]{
 		// Editors
 		NewDeleteKeyFactory[K](),
+		NewDeleteJSONFactory[K](),
 		NewDeleteMatchingKeysFactory[K](),
 		NewKeepKeysFactory[K](),
 		NewLimitFactory[K](),
@@ -20,6 +21,7 @@ func StandardFuncs[K any]() map[string]ottl.Factory[K] {
 		NewReplaceMatchFactory[K](),
 		NewReplacePatternFactory[K](),
 		NewSetFactory[K](),
+		NewSetJSONFactory[K](),
 		NewTruncateAllFactory[K](),
 	}
 	f = append(f, converters[K]()...)
@@ -40,6 +42,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewDurationFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewFnvFactory[K](),
+		NewGrokFactory[K](),
 		NewHoursFactory[K](),
 		NewIntFactory[K](),
 		NewIsMapFactory[K](),
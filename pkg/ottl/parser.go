@@ -141,8 +141,64 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	}, nil
 }
 
+// ValueExpression holds an expression, such as a path, a converter invocation or a literal, which is evaluated to a
+// value instead of being executed as a statement, for example to read the key telemetry is routed by.
+type ValueExpression[K any] struct {
+	getter   Getter[K]
+	origText string
+}
+
+// Eval evaluates the expression for the given context.
+func (e *ValueExpression[K]) Eval(ctx context.Context, tCtx K) (any, error) {
+	return e.getter.Get(ctx, tCtx)
+}
+
+// EvalString evaluates the expression to a string, converting the value as a StringLikeGetter does.
+// nil is returned without an error if the value is nil, for example because the attribute it reads is missing.
+func (e *ValueExpression[K]) EvalString(ctx context.Context, tCtx K) (*string, error) {
+	return StandardStringLikeGetter[K]{Getter: e.getter.Get}.Get(ctx, tCtx)
+}
+
+// EvalFloat evaluates the expression to a float64, converting the value as a FloatLikeGetter does.
+// nil is returned without an error if the value is nil.
+func (e *ValueExpression[K]) EvalFloat(ctx context.Context, tCtx K) (*float64, error) {
+	return StandardFloatLikeGetter[K]{Getter: e.getter.Get}.Get(ctx, tCtx)
+}
+
+// ParseValueExpression parses a string expression into an ottl.ValueExpression ready for evaluation. Only the
+// converters of the parser can be invoked by the expression.
+func (p *Parser[K]) ParseValueExpression(expression string) (*ValueExpression[K], error) {
+	parsed, err := parseValueExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	getter, err := p.newGetter(*parsed)
+	if err != nil {
+		return nil, err
+	}
+	return &ValueExpression[K]{
+		getter:   getter,
+		origText: expression,
+	}, nil
+}
+
 var parser = newParser[parsedStatement]()
 
+var valueExpressionParser = newParser[value]()
+
+func parseValueExpression(raw string) (*value, error) {
+	parsed, err := valueExpressionParser.ParseString("", raw)
+	if err != nil {
+		return nil, fmt.Errorf("expression has invalid syntax: %w", err)
+	}
+	err = parsed.checkForCustomError()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
 func parseStatement(raw string) (*parsedStatement, error) {
 	parsed, err := parser.ParseString("", raw)
 
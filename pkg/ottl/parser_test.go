@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/multierr"
 
@@ -1964,3 +1965,40 @@ func Test_Statements_Eval_Error(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseValueExpression(t *testing.T) {
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath,
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+
+	expr, err := p.ParseValueExpression(`name`)
+	require.NoError(t, err)
+	value, err := expr.Eval(context.Background(), "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", value)
+	str, err := expr.EvalString(context.Background(), int64(42))
+	require.NoError(t, err)
+	assert.Equal(t, "42", *str)
+	str, err = expr.EvalString(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, str)
+
+	expr, err = p.ParseValueExpression(`1 + 2`)
+	require.NoError(t, err)
+	float, err := expr.EvalFloat(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, *float)
+
+	// The editors can't be invoked by an expression.
+	_, err = p.ParseValueExpression(`set(name, "test")`)
+	assert.ErrorContains(t, err, "converter names must start with an uppercase letter")
+
+	_, err = p.ParseValueExpression(`name.`)
+	assert.ErrorContains(t, err, "expression has invalid syntax")
+
+	_, err = p.ParseValueExpression(`Unknown(name)`)
+	assert.Error(t, err)
+}
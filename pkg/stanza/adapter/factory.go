@@ -5,9 +5,11 @@ package adapter // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
 	rcvr "go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
@@ -16,6 +18,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
+var operatorMetricsGate = featuregate.GlobalRegistry().MustRegister(
+	"stanza.operatorMetrics",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the operators of stanza based receivers emit record metrics."),
+)
+
 // LogReceiverType is the interface used by stanza-based log receivers
 type LogReceiverType interface {
 	Type() component.Type
@@ -40,6 +48,10 @@ func createLogsReceiver(logReceiverType LogReceiverType) rcvr.CreateLogsFunc {
 		cfg component.Config,
 		nextConsumer consumer.Logs,
 	) (rcvr.Logs, error) {
+		if err := registerViews(); err != nil {
+			return nil, fmt.Errorf("failed to register metric views: %w", err)
+		}
+
 		inputCfg := logReceiverType.InputConfig(cfg)
 		baseCfg := logReceiverType.BaseConfig(cfg)
 
@@ -53,10 +65,14 @@ func createLogsReceiver(logReceiverType LogReceiverType) rcvr.CreateLogsFunc {
 			emitterOpts = append(emitterOpts, withFlushInterval(baseCfg.flushInterval))
 		}
 		emitter := NewLogEmitter(params.Logger.Sugar(), emitterOpts...)
-		pipe, err := pipeline.Config{
+		pipeCfg := pipeline.Config{
 			Operators:     operators,
 			DefaultOutput: emitter,
-		}.Build(params.Logger.Sugar())
+		}
+		if operatorMetricsGate.IsEnabled() {
+			pipeCfg.ReceiverID = params.ID.String()
+		}
+		pipe, err := pipeCfg.Build(params.Logger.Sugar())
 		if err != nil {
 			return nil, err
 		}
@@ -82,6 +98,9 @@ func createLogsReceiver(logReceiverType LogReceiverType) rcvr.CreateLogsFunc {
 			converter: converter,
 			obsrecv:   obsrecv,
 			storageID: baseCfg.StorageID,
+
+			lag:               newLagTracker(params.ID.String()),
+			lagReportInterval: defaultLagReportInterval,
 		}, nil
 	}
 }
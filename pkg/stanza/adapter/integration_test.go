@@ -53,6 +53,9 @@ func createNoopReceiver(nextConsumer consumer.Logs) (*receiver, error) {
 		logger:    zap.NewNop(),
 		converter: NewConverter(zap.NewNop()),
 		obsrecv:   obsrecv,
+
+		lag:               newLagTracker(receiverID.String()),
+		lagReportInterval: defaultLagReportInterval,
 	}, nil
 }
 
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adapter // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+const defaultLagReportInterval = 10 * time.Second
+
+var (
+	tagReceiver, _ = tag.NewKey("receiver")
+
+	mIngestionLag     = stats.Float64("stanza_receiver_ingestion_lag", "Time between now and the timestamp of the newest log record consumed", stats.UnitSeconds)
+	mCheckpointAge    = stats.Float64("stanza_receiver_checkpoint_age", "Time since the receiver last persisted its read position, such as file offsets or a journald cursor", stats.UnitSeconds)
+	mCheckpointWrites = stats.Int64("stanza_receiver_checkpoint_writes", "Number of times the receiver persisted its read position", stats.UnitDimensionless)
+
+	// lastValue is shared by the views so that registering them again is not
+	// rejected as registering a different view.
+	lastValue = view.LastValue()
+
+	registerViewsOnce sync.Once
+	errRegisterViews  error
+)
+
+// registerViews registers the metric views the first time a receiver is
+// created, and returns the registration error to every receiver.
+func registerViews() error {
+	registerViewsOnce.Do(func() {
+		errRegisterViews = view.Register(MetricViews()...)
+	})
+	return errRegisterViews
+}
+
+// MetricViews returns the metrics views related to the lag of stanza based
+// receivers, which are registered when the first receiver is created.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagReceiver}
+	return []*view.View{
+		{
+			Name:        mIngestionLag.Name(),
+			Measure:     mIngestionLag,
+			Description: mIngestionLag.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: lastValue,
+		},
+		{
+			Name:        mCheckpointAge.Name(),
+			Measure:     mCheckpointAge,
+			Description: mCheckpointAge.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: lastValue,
+		},
+		{
+			Name:        mCheckpointWrites.Name(),
+			Measure:     mCheckpointWrites,
+			Description: mCheckpointWrites.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// lagTracker keeps track of the newest log record consumed and of the last
+// checkpoint persisted by a receiver. Both are unix nanoseconds, zero until observed.
+type lagTracker struct {
+	ctx             context.Context
+	newestTimestamp atomic.Int64
+	lastCheckpoint  atomic.Int64
+}
+
+func newLagTracker(receiverID string) *lagTracker {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tagReceiver, receiverID))
+	return &lagTracker{ctx: ctx}
+}
+
+// observeEntries records the newest timestamp of the given entries.
+// Entries without a timestamp are ignored.
+func (t *lagTracker) observeEntries(entries []*entry.Entry) {
+	var newest int64
+	for _, e := range entries {
+		if e.Timestamp.IsZero() {
+			continue
+		}
+		if ts := e.Timestamp.UnixNano(); ts > newest {
+			newest = ts
+		}
+	}
+	for {
+		current := t.newestTimestamp.Load()
+		if newest <= current || t.newestTimestamp.CompareAndSwap(current, newest) {
+			return
+		}
+	}
+}
+
+func (t *lagTracker) observeCheckpoint(now time.Time) {
+	t.lastCheckpoint.Store(now.UnixNano())
+	stats.Record(t.ctx, mCheckpointWrites.M(1))
+}
+
+// report records the ingestion lag and checkpoint age as of now.
+func (t *lagTracker) report(now time.Time) {
+	if newest := t.newestTimestamp.Load(); newest != 0 {
+		stats.Record(t.ctx, mIngestionLag.M(secondsSince(now, newest)))
+	}
+	if last := t.lastCheckpoint.Load(); last != 0 {
+		stats.Record(t.ctx, mCheckpointAge.M(secondsSince(now, last)))
+	}
+}
+
+func secondsSince(now time.Time, unixNano int64) float64 {
+	d := now.Sub(time.Unix(0, unixNano))
+	if d < 0 {
+		return 0
+	}
+	return d.Seconds()
+}
+
+// lagLoop periodically reports the lag, so that it keeps growing while nothing is consumed.
+func (r *receiver) lagLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.lagReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.lag.report(now)
+		}
+	}
+}
+
+// checkpointClient is a storage client recording when checkpoints are written.
+type checkpointClient struct {
+	storage.Client
+	lag *lagTracker
+}
+
+func (c *checkpointClient) Set(ctx context.Context, key string, value []byte) error {
+	if err := c.Client.Set(ctx, key, value); err != nil {
+		return err
+	}
+	c.lag.observeCheckpoint(time.Now())
+	return nil
+}
+
+func (c *checkpointClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	if err := c.Client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.Type == storage.Set {
+			c.lag.observeCheckpoint(time.Now())
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"stanza_receiver_ingestion_lag",
+		"stanza_receiver_checkpoint_age",
+		"stanza_receiver_checkpoint_writes",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+// receiverValue returns the value of a view for the given receiver, and whether it was found.
+func receiverValue(t *testing.T, viewName, receiverID string) (float64, bool) {
+	rows, err := view.RetrieveData(viewName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key != tagReceiver || tag.Value != receiverID {
+				continue
+			}
+			switch data := row.Data.(type) {
+			case *view.LastValueData:
+				return data.Value, true
+			case *view.SumData:
+				return data.Value, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestLagTracker(t *testing.T) {
+	// Views may already have been registered by a receiver of another test.
+	require.NoError(t, view.Register(MetricViews()...))
+
+	receiverID := component.NewIDWithName("test", "lag_tracker").String()
+	lag := newLagTracker(receiverID)
+	now := time.Now()
+
+	lag.report(now)
+	_, found := receiverValue(t, "stanza_receiver_ingestion_lag", receiverID)
+	assert.False(t, found, "no lag is reported before any entry is consumed")
+
+	older, newer, untimed := entry.New(), entry.New(), entry.New()
+	older.Timestamp = now.Add(-time.Minute)
+	newer.Timestamp = now.Add(-10 * time.Second)
+	lag.observeEntries([]*entry.Entry{newer, older, untimed})
+	lag.observeEntries([]*entry.Entry{older})
+
+	lag.report(now)
+	value, found := receiverValue(t, "stanza_receiver_ingestion_lag", receiverID)
+	assert.True(t, found)
+	assert.Equal(t, float64(10), value)
+
+	lag.report(now.Add(5 * time.Second))
+	value, _ = receiverValue(t, "stanza_receiver_ingestion_lag", receiverID)
+	assert.Equal(t, float64(15), value, "lag keeps growing while nothing is consumed")
+
+	_, found = receiverValue(t, "stanza_receiver_checkpoint_age", receiverID)
+	assert.False(t, found, "no checkpoint age is reported before any checkpoint")
+}
+
+func TestCheckpointClient(t *testing.T) {
+	// Views may already have been registered by a receiver of another test.
+	require.NoError(t, view.Register(MetricViews()...))
+
+	ctx := context.Background()
+	receiverID := component.NewIDWithName("test", "checkpoint_client").String()
+	lag := newLagTracker(receiverID)
+	client := &checkpointClient{
+		Client: storagetest.NewInMemoryClient(component.KindReceiver, component.NewID("test"), ""),
+		lag:    lag,
+	}
+
+	_, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.NoError(t, client.Batch(ctx, storage.GetOperation("key")))
+	_, found := receiverValue(t, "stanza_receiver_checkpoint_writes", receiverID)
+	assert.False(t, found, "reads are not checkpoints")
+
+	require.NoError(t, client.Set(ctx, "key", []byte("cursor")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("key", []byte("cursor")), storage.SetOperation("other", []byte("offset"))))
+	writes, _ := receiverValue(t, "stanza_receiver_checkpoint_writes", receiverID)
+	assert.Equal(t, float64(2), writes)
+
+	lastCheckpoint := time.Unix(0, lag.lastCheckpoint.Load())
+	lag.report(lastCheckpoint.Add(30 * time.Second))
+	age, found := receiverValue(t, "stanza_receiver_checkpoint_age", receiverID)
+	assert.True(t, found)
+	assert.Equal(t, float64(30), age)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -33,6 +34,9 @@ type receiver struct {
 
 	storageID     *component.ID
 	storageClient storage.Client
+
+	lag               *lagTracker
+	lagReportInterval time.Duration
 }
 
 // Ensure this receiver adheres to required interface
@@ -48,7 +52,12 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("storage client: %w", err)
 	}
 
-	if err := r.pipe.Start(r.storageClient); err != nil {
+	// Checkpoints are only tracked when they are actually persisted.
+	var persister storage.Client = r.storageClient
+	if r.storageID != nil {
+		persister = &checkpointClient{Client: r.storageClient, lag: r.lag}
+	}
+	if err := r.pipe.Start(persister); err != nil {
 		return fmt.Errorf("start stanza: %w", err)
 	}
 
@@ -67,6 +76,9 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 	r.wg.Add(1)
 	go r.consumerLoop(rctx)
 
+	r.wg.Add(1)
+	go r.lagLoop(rctx)
+
 	// Those 2 loops are started in separate goroutines because batching in
 	// the emitter loop can cause a flush, caused by either reaching the max
 	// flush size or by the configurable ticker which would in turn cause
@@ -95,6 +107,7 @@ func (r *receiver) emitterLoop(ctx context.Context) {
 				continue
 			}
 
+			r.lag.observeEntries(e)
 			if err := r.converter.Batch(e); err != nil {
 				r.logger.Error("Could not add entry to batch", zap.Error(err))
 			}
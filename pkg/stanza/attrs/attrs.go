@@ -8,4 +8,6 @@ const (
 	LogFilePath         = "log.file.path"
 	LogFileNameResolved = "log.file.name_resolved"
 	LogFilePathResolved = "log.file.path_resolved"
+	LogFileStream       = "log.file.stream"
+	LogFileTruncated    = "log.file.truncated"
 )
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decode // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/decode"
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// Auto is the encoding name that enables detecting the encoding of each file.
+const Auto = "auto"
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// Detector guesses the encoding of data from a sample of its first bytes.
+type Detector struct {
+	fallbacks []string
+	encodings []encoding.Encoding
+}
+
+// NewDetector creates a Detector trying the given fallback encodings, in order,
+// when the sample has no byte order mark and is not valid UTF-8.
+func NewDetector(fallbacks []string) (*Detector, error) {
+	d := &Detector{fallbacks: fallbacks}
+	for _, name := range fallbacks {
+		if name == Auto {
+			return nil, errors.New("'auto' cannot be used as a fallback encoding")
+		}
+		enc, err := LookupEncoding(name)
+		if err != nil {
+			return nil, err
+		}
+		d.encodings = append(d.encodings, enc)
+	}
+	return d, nil
+}
+
+// Detect returns the name of the encoding of the sample, which can be passed to LookupEncoding,
+// along with the length of its byte order mark, if any. UTF-8 is returned when no fallback matches.
+func (d *Detector) Detect(sample []byte) (string, int) {
+	switch {
+	case bytes.HasPrefix(sample, bomUTF8):
+		return "utf-8", len(bomUTF8)
+	case bytes.HasPrefix(sample, bomUTF16LE):
+		return "utf-16le", len(bomUTF16LE)
+	case bytes.HasPrefix(sample, bomUTF16BE):
+		return "utf-16be", len(bomUTF16BE)
+	}
+
+	if utf8.Valid(trimPartialRune(sample)) {
+		return "utf-8", 0
+	}
+	for i, enc := range d.encodings {
+		if decodesCleanly(enc, sample) {
+			return d.fallbacks[i], 0
+		}
+	}
+	return "utf-8", 0
+}
+
+// trimPartialRune removes a multi-byte rune cut at the end of the sample.
+func trimPartialRune(sample []byte) []byte {
+	for i := len(sample) - 1; i >= 0 && i >= len(sample)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(sample[i]) {
+			if !utf8.FullRune(sample[i:]) {
+				return sample[:i]
+			}
+			break
+		}
+	}
+	return sample
+}
+
+// decodesCleanly reports whether the sample decodes without invalid sequences.
+// A sequence cut at the end of the sample is not considered invalid.
+func decodesCleanly(enc encoding.Encoding, sample []byte) bool {
+	dst := make([]byte, 4*len(sample)+utf8.UTFMax)
+	nDst, _, err := enc.NewDecoder().Transform(dst, sample, false)
+	if err != nil && !errors.Is(err, transform.ErrShortSrc) {
+		return false
+	}
+	return !bytes.ContainsRune(dst[:nDst], utf8.RuneError)
+}
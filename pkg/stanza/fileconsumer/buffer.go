@@ -0,0 +1,480 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	defaultBufferMaxInMemory = 1000
+	defaultBufferMaxOnDisk   = 256 * 1024 * 1024
+
+	spillMetaKey   = "spill"
+	spillKeyPrefix = "spill."
+)
+
+// BufferConfig configures a buffer between the file readers and the downstream
+// consumer, so that files keep being read while the consumer applies backpressure.
+// Up to MaxInMemory tokens are kept in memory, the others being spilled to the
+// storage extension. The tokens kept in memory are persisted with the file offsets.
+type BufferConfig struct {
+	Enabled     bool            `mapstructure:"enabled"`
+	MaxInMemory int             `mapstructure:"max_in_memory"`
+	MaxOnDisk   helper.ByteSize `mapstructure:"max_on_disk"`
+}
+
+func (c BufferConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxInMemory <= 0 {
+		return fmt.Errorf("`buffer.max_in_memory` must be positive")
+	}
+	if c.MaxOnDisk <= 0 {
+		return fmt.Errorf("`buffer.max_on_disk` must be positive")
+	}
+	return nil
+}
+
+func init() {
+	// Register the types of the attribute values that are not registered by default,
+	// e.g. those set by the header metadata operators.
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+}
+
+type bufferedToken struct {
+	Token []byte
+	Attrs map[string]any
+}
+
+// spillMeta locates the spilled chunks in storage. Each chunk holds a batch of
+// tokens, and chunks are stored under consecutive sequence numbers, from Head
+// (oldest) up to but excluding Tail.
+type spillMeta struct {
+	Head  uint64 `json:"head"`
+	Tail  uint64 `json:"tail"`
+	Bytes int64  `json:"bytes"`
+}
+
+// spillBuffer decouples the readers from the downstream consumer. Tokens are kept
+// in memory, and only written to storage when more than maxInMemory tokens are not
+// emitted yet, or when flush is called before the file offsets are checkpointed, so
+// that no token is lost on crash once the offsets moved past it. A consumer keeping
+// up never causes any storage access. Readers only block when the disk limit is reached.
+//
+// The tokens are emitted in order: the head first, then the chunks in storage, then
+// the tokens in memory.
+type spillBuffer struct {
+	*zap.SugaredLogger
+	emit        emit.Callback
+	maxInMemory int
+	maxOnDisk   int64
+
+	// queued is signaled when a token is buffered, and drained when one is emitted.
+	queued  chan struct{}
+	drained chan struct{}
+
+	mu        sync.Mutex
+	persister operator.Persister
+	meta      spillMeta
+	memory    []bufferedToken
+
+	// head holds the tokens being emitted. They are either the remaining tokens of
+	// the chunk Head when headPersisted, or tokens taken from memory, which precede
+	// the chunks in storage.
+	head          []bufferedToken
+	headPersisted bool
+	headEmitted   int
+	headSize      int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newSpillBuffer(logger *zap.SugaredLogger, cfg BufferConfig, callback emit.Callback) *spillBuffer {
+	return &spillBuffer{
+		SugaredLogger: logger,
+		emit:          callback,
+		maxInMemory:   cfg.MaxInMemory,
+		maxOnDisk:     int64(cfg.MaxOnDisk),
+		queued:        make(chan struct{}, 1),
+		drained:       make(chan struct{}, 1),
+		cancel:        func() {},
+	}
+}
+
+func (b *spillBuffer) start(persister operator.Persister) error {
+	if persister == nil {
+		return fmt.Errorf("`buffer` requires a storage extension")
+	}
+	encoded, err := persister.Get(context.Background(), spillMetaKey)
+	if err != nil {
+		return fmt.Errorf("read spill buffer from database: %w", err)
+	}
+	// Sequence numbers start at 1 so that tokens taken from memory can always be
+	// persisted before the chunk Head.
+	meta := spillMeta{Head: 1, Tail: 1}
+	if encoded != nil {
+		if err = json.Unmarshal(encoded, &meta); err != nil {
+			return fmt.Errorf("decode spill buffer: %w", err)
+		}
+	}
+	if meta.Head != meta.Tail {
+		b.Infow("Resuming buffered tokens", "chunks", meta.Tail-meta.Head, "bytes", meta.Bytes)
+	}
+
+	b.mu.Lock()
+	b.persister = persister
+	b.meta = meta
+	b.mu.Unlock()
+	recordBufferDiskBytes(meta.Bytes)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.drain(ctx)
+	}()
+	return nil
+}
+
+// stop waits for the drain loop to exit, and persists the tokens not emitted yet so
+// that they are emitted after a restart. The readers must be stopped beforehand.
+func (b *spillBuffer) stop() {
+	b.cancel()
+	b.wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.persister == nil {
+		return
+	}
+	if b.headPersisted && b.headEmitted > 0 {
+		// Do not emit again the tokens of the chunk already emitted.
+		size, err := b.putChunk(b.meta.Head, b.head)
+		if err != nil {
+			b.Errorw("Failed to persist buffered tokens", zap.Error(err))
+		} else {
+			b.meta.Bytes += size - b.headSize
+			if err = b.saveMeta(); err != nil {
+				b.Errorw("Failed to save spill buffer", zap.Error(err))
+			}
+		}
+	}
+	if err := b.persistMemory(); err != nil {
+		b.Errorw("Failed to persist buffered tokens", zap.Error(err))
+	}
+	recordBufferDiskBytes(-b.meta.Bytes)
+	b.persister = nil
+	b.memory = nil
+	b.head = nil
+	b.headPersisted = false
+}
+
+// flush persists the tokens kept in memory. It is called before the file offsets
+// are checkpointed.
+func (b *spillBuffer) flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.persister == nil {
+		return nil
+	}
+	return b.persistMemory()
+}
+
+// persistMemory writes the tokens not persisted yet to storage: the head taken from
+// memory before the chunks in storage, and the tokens in memory after them.
+func (b *spillBuffer) persistMemory() error {
+	if len(b.memory) > 0 {
+		if _, err := b.appendChunk(b.memory, false); err != nil {
+			return err
+		}
+		b.memory = nil
+	}
+	if !b.headPersisted && len(b.head) > 0 {
+		if err := b.persistHead(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bufferEmit is the emit.Callback used by the readers when buffering is enabled.
+// It returns once the token is buffered.
+func (b *spillBuffer) bufferEmit(ctx context.Context, token []byte, attrs map[string]any) error {
+	// Both the token and the attributes are reused by the readers.
+	t := bufferedToken{Token: append([]byte(nil), token...), Attrs: make(map[string]any, len(attrs))}
+	for k, v := range attrs {
+		t.Attrs[k] = v
+	}
+
+	for {
+		b.mu.Lock()
+		if b.persister == nil {
+			b.mu.Unlock()
+			return fmt.Errorf("`buffer` is not started")
+		}
+		if b.inMemory() < b.maxInMemory {
+			b.memory = append(b.memory, t)
+			b.mu.Unlock()
+			select {
+			case b.queued <- struct{}{}:
+			default:
+			}
+			return nil
+		}
+		ok, err := b.spill()
+		b.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+
+		// The disk limit is reached, wait for the consumer to catch up.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.drained:
+		}
+	}
+}
+
+// inMemory returns the number of tokens not emitted yet that are only kept in memory.
+func (b *spillBuffer) inMemory() int {
+	n := len(b.memory)
+	if !b.headPersisted {
+		n += len(b.head)
+	}
+	return n
+}
+
+// spill writes the tokens kept in memory to storage, the head being spilled only
+// when there are no other tokens in memory. It returns false if the disk limit is
+// reached or there is nothing to spill.
+func (b *spillBuffer) spill() (bool, error) {
+	if len(b.memory) > 0 {
+		ok, err := b.appendChunk(b.memory, true)
+		if ok {
+			b.memory = nil
+		}
+		return ok, err
+	}
+	if b.headPersisted || len(b.head) == 0 || !b.fits(0) {
+		return false, nil
+	}
+	return true, b.persistHead()
+}
+
+// fits returns whether a chunk of the given size can be spilled. A chunk is always
+// accepted when nothing is spilled, whatever its size.
+func (b *spillBuffer) fits(size int64) bool {
+	return b.meta.Bytes == 0 || b.meta.Bytes+size <= b.maxOnDisk
+}
+
+// appendChunk writes the tokens as the chunk Tail. If checkLimit is set, it returns
+// false instead when the disk limit would be exceeded.
+func (b *spillBuffer) appendChunk(tokens []bufferedToken, checkLimit bool) (bool, error) {
+	data, err := encodeChunk(tokens)
+	if err != nil {
+		return false, err
+	}
+	if checkLimit && !b.fits(int64(len(data))) {
+		return false, nil
+	}
+	if err = b.persister.Set(context.Background(), spillKey(b.meta.Tail), data); err != nil {
+		return false, fmt.Errorf("persist tokens: %w", err)
+	}
+	b.meta.Tail++
+	b.meta.Bytes += int64(len(data))
+	if err = b.saveMeta(); err != nil {
+		b.meta.Tail--
+		b.meta.Bytes -= int64(len(data))
+		return false, err
+	}
+	recordBufferDiskBytes(int64(len(data)))
+	stats.Record(context.Background(), mBufferSpilled.M(int64(len(tokens))))
+	return true, nil
+}
+
+// persistHead writes the head taken from memory as the chunk preceding Head.
+func (b *spillBuffer) persistHead() error {
+	seq := b.meta.Head - 1
+	size, err := b.putChunk(seq, b.head)
+	if err != nil {
+		return err
+	}
+	b.meta.Head = seq
+	b.meta.Bytes += size
+	if err = b.saveMeta(); err != nil {
+		b.meta.Head++
+		b.meta.Bytes -= size
+		return err
+	}
+	recordBufferDiskBytes(size)
+	stats.Record(context.Background(), mBufferSpilled.M(int64(len(b.head))))
+	b.headPersisted = true
+	b.headEmitted = 0
+	b.headSize = size
+	return nil
+}
+
+func (b *spillBuffer) putChunk(seq uint64, tokens []bufferedToken) (int64, error) {
+	data, err := encodeChunk(tokens)
+	if err != nil {
+		return 0, err
+	}
+	if err = b.persister.Set(context.Background(), spillKey(seq), data); err != nil {
+		return 0, fmt.Errorf("persist tokens: %w", err)
+	}
+	return int64(len(data)), nil
+}
+
+// drain emits the buffered tokens in order until ctx is done.
+func (b *spillBuffer) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		if b.forwardNext(ctx) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.queued:
+		}
+	}
+}
+
+// forwardNext emits the oldest buffered token, if any. A chunk is only removed from
+// storage once all its tokens are emitted, and the tokens of the head are only
+// removed once emitted, so that they are persisted on stop if their emission is
+// interrupted.
+func (b *spillBuffer) forwardNext(ctx context.Context) bool {
+	b.mu.Lock()
+	if len(b.head) == 0 && !b.nextHead() {
+		b.mu.Unlock()
+		return false
+	}
+	t := b.head[0]
+	b.mu.Unlock()
+
+	if err := b.emit(ctx, t.Token, t.Attrs); err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		b.Errorw("Failed to emit buffered token", zap.Error(err))
+	}
+
+	b.mu.Lock()
+	b.head = b.head[1:]
+	b.headEmitted++
+	if len(b.head) == 0 && b.headPersisted {
+		b.removeHead()
+	}
+	b.mu.Unlock()
+
+	select {
+	case b.drained <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// nextHead loads the next tokens to emit, from the chunk Head if any or else from
+// memory. It returns false if there is no token to emit.
+func (b *spillBuffer) nextHead() bool {
+	b.headPersisted = false
+	b.headEmitted = 0
+	for b.meta.Head != b.meta.Tail {
+		data, err := b.persister.Get(context.Background(), spillKey(b.meta.Head))
+		var tokens []bufferedToken
+		switch {
+		case err != nil:
+			b.Errorw("Failed to read buffered tokens", zap.Error(err))
+		case data != nil:
+			if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&tokens); err != nil {
+				b.Errorw("Failed to decode buffered tokens", zap.Error(err))
+				tokens = nil
+			}
+		}
+		b.headPersisted = true
+		b.headSize = int64(len(data))
+		if len(tokens) > 0 {
+			b.head = tokens
+			return true
+		}
+		b.removeHead()
+	}
+	if len(b.memory) == 0 {
+		return false
+	}
+	b.head, b.memory = b.memory, nil
+	return true
+}
+
+// removeHead removes the chunk Head from storage.
+func (b *spillBuffer) removeHead() {
+	if err := b.persister.Delete(context.Background(), spillKey(b.meta.Head)); err != nil {
+		b.Errorw("Failed to delete buffered tokens", zap.Error(err))
+	}
+	previousBytes := b.meta.Bytes
+	b.meta.Head++
+	b.meta.Bytes -= b.headSize
+	if b.meta.Head == b.meta.Tail || b.meta.Bytes < 0 {
+		b.meta.Bytes = 0
+	}
+	if err := b.saveMeta(); err != nil {
+		b.Errorw("Failed to save spill buffer", zap.Error(err))
+	}
+	recordBufferDiskBytes(b.meta.Bytes - previousBytes)
+	b.head = nil
+	b.headPersisted = false
+	b.headEmitted = 0
+	b.headSize = 0
+}
+
+func (b *spillBuffer) saveMeta() error {
+	encoded, err := json.Marshal(b.meta)
+	if err != nil {
+		return fmt.Errorf("encode spill buffer: %w", err)
+	}
+	if err = b.persister.Set(context.Background(), spillMetaKey, encoded); err != nil {
+		return fmt.Errorf("persist spill buffer: %w", err)
+	}
+	return nil
+}
+
+func encodeChunk(tokens []bufferedToken) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(tokens); err != nil {
+		return nil, fmt.Errorf("encode tokens: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func spillKey(seq uint64) string {
+	return spillKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+func recordBufferDiskBytes(delta int64) {
+	if delta != 0 {
+		stats.Record(context.Background(), mBufferDiskBytes.M(delta))
+	}
+}
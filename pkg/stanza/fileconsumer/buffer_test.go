@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+// blockingEmit simulates a consumer applying backpressure: tokens are only
+// accepted once they are read from received.
+func blockingEmit(received chan string) emit.Callback {
+	return func(ctx context.Context, token []byte, _ map[string]any) error {
+		select {
+		case received <- string(token):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func newTestSpillBuffer(maxInMemory int, maxOnDisk helper.ByteSize, callback emit.Callback) *spillBuffer {
+	cfg := BufferConfig{Enabled: true, MaxInMemory: maxInMemory, MaxOnDisk: maxOnDisk}
+	return newSpillBuffer(zap.NewNop().Sugar(), cfg, callback)
+}
+
+func emitTokens(t *testing.T, b *spillBuffer, from, to int) {
+	for i := from; i < to; i++ {
+		require.NoError(t, b.bufferEmit(context.Background(), []byte(fmt.Sprintf("token%d", i)), map[string]any{"log.file.name": "a.log"}))
+	}
+}
+
+func expectTokens(t *testing.T, received chan string, from, to int) {
+	for i := from; i < to; i++ {
+		select {
+		case token := <-received:
+			require.Equal(t, fmt.Sprintf("token%d", i), token)
+		case <-time.After(3 * time.Second):
+			require.FailNow(t, "timed out waiting for token", "token%d", i)
+		}
+	}
+}
+
+func TestSpillBufferKeepsOrder(t *testing.T) {
+	received := make(chan string)
+	b := newTestSpillBuffer(2, 1024*1024, blockingEmit(received))
+	persister := testutil.NewUnscopedMockPersister()
+	require.NoError(t, b.start(persister))
+	defer b.stop()
+
+	// None of these calls block although the consumer does not read anything.
+	emitTokens(t, b, 0, 10)
+	b.mu.Lock()
+	require.LessOrEqual(t, b.inMemory(), 2)
+	require.Positive(t, b.meta.Tail-b.meta.Head, "Must spill the tokens beyond max_in_memory")
+	b.mu.Unlock()
+
+	expectTokens(t, received, 0, 10)
+
+	emitTokens(t, b, 10, 12)
+	expectTokens(t, received, 10, 12)
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.meta.Head == b.meta.Tail && b.meta.Bytes == 0 && len(b.memory) == 0 && len(b.head) == 0
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestSpillBufferKeepsUpInMemory(t *testing.T) {
+	received := make(chan string, 10)
+	b := newTestSpillBuffer(10, 1024*1024, blockingEmit(received))
+	persister := testutil.NewUnscopedMockPersister()
+	require.NoError(t, b.start(persister))
+	defer b.stop()
+
+	emitTokens(t, b, 0, 10)
+	expectTokens(t, received, 0, 10)
+
+	// Nothing is written to storage while the consumer keeps up.
+	meta, err := persister.Get(context.Background(), spillMetaKey)
+	require.NoError(t, err)
+	require.Nil(t, meta)
+}
+
+func TestSpillBufferMaxOnDisk(t *testing.T) {
+	received := make(chan string)
+	b := newTestSpillBuffer(1, 1, blockingEmit(received))
+	require.NoError(t, b.start(testutil.NewUnscopedMockPersister()))
+	defer b.stop()
+
+	// The first token is spilled although it is larger than the disk limit, and
+	// kept until the consumer takes it. The second one is kept in memory.
+	emitTokens(t, b, 0, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, b.bufferEmit(ctx, []byte("token2"), nil), context.DeadlineExceeded)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		emitTokens(t, b, 2, 4)
+	}()
+	expectTokens(t, received, 0, 4)
+	<-done
+}
+
+func TestSpillBufferRestart(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+
+	received := make(chan string)
+	b := newTestSpillBuffer(3, 1024*1024, blockingEmit(received))
+	require.NoError(t, b.start(persister))
+	emitTokens(t, b, 0, 10)
+	expectTokens(t, received, 0, 2)
+	b.stop()
+
+	b = newTestSpillBuffer(3, 1024*1024, blockingEmit(received))
+	require.NoError(t, b.start(persister))
+	defer b.stop()
+	expectTokens(t, received, 2, 10)
+}
+
+func TestSpillBufferCrash(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+
+	// The consumer never takes the tokens, and the buffer is not stopped before
+	// another one resumes from the same storage.
+	crashed := newTestSpillBuffer(100, 1024*1024, blockingEmit(make(chan string)))
+	require.NoError(t, crashed.start(persister))
+	defer crashed.stop()
+	emitTokens(t, crashed, 0, 5)
+	// The tokens kept in memory are persisted before the offsets are checkpointed.
+	require.NoError(t, crashed.flush())
+
+	received := make(chan string)
+	b := newTestSpillBuffer(100, 1024*1024, blockingEmit(received))
+	require.NoError(t, b.start(persister))
+	defer b.stop()
+	expectTokens(t, received, 0, 5)
+}
+
+func TestSpillBufferPreservesTypes(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+	attrs := map[string]any{
+		"log.file.name": "a.log",
+		"line":          42,
+		"ratio":         1.5,
+		"header":        map[string]any{"version": int64(2), "tags": []any{"a", true}},
+	}
+
+	b := newTestSpillBuffer(1, 1024*1024, blockingEmit(make(chan string)))
+	require.NoError(t, b.start(persister))
+	require.NoError(t, b.bufferEmit(context.Background(), []byte("token0"), attrs))
+	b.stop()
+
+	// The token is read back from storage after the restart.
+	received := make(chan map[string]any, 1)
+	b = newTestSpillBuffer(1, 1024*1024, func(_ context.Context, _ []byte, attrs map[string]any) error {
+		received <- attrs
+		return nil
+	})
+	require.NoError(t, b.start(persister))
+	defer b.stop()
+	select {
+	case got := <-received:
+		require.Equal(t, attrs, got)
+	case <-time.After(3 * time.Second):
+		require.FailNow(t, "timed out waiting for token")
+	}
+}
+
+func TestBufferRequiresPersister(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Include = []string{"*.log"}
+	cfg.Buffer.Enabled = true
+	m, err := cfg.Build(testutil.Logger(t), nopEmitFunc)
+	require.NoError(t, err)
+	require.Error(t, m.Start(nil))
+}
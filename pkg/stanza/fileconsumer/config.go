@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/featuregate"
 	"go.uber.org/zap"
 	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/decode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
@@ -57,11 +58,16 @@ func NewConfig() *Config {
 		PollInterval:            defaultPollInterval,
 		Encoding:                defaultEncoding,
 		StartAt:                 "end",
+		OnTruncate:              onTruncateBeginning,
 		FingerprintSize:         fingerprint.DefaultSize,
 		MaxLogSize:              defaultMaxLogSize,
 		MaxConcurrentFiles:      defaultMaxConcurrentFiles,
 		MaxBatches:              0,
 		FlushPeriod:             defaultFlushPeriod,
+		Buffer: BufferConfig{
+			MaxInMemory: defaultBufferMaxInMemory,
+			MaxOnDisk:   defaultBufferMaxOnDisk,
+		},
 	}
 }
 
@@ -74,6 +80,7 @@ type Config struct {
 	IncludeFilePathResolved bool            `mapstructure:"include_file_path_resolved,omitempty"`
 	PollInterval            time.Duration   `mapstructure:"poll_interval,omitempty"`
 	StartAt                 string          `mapstructure:"start_at,omitempty"`
+	OnTruncate              string          `mapstructure:"on_truncate,omitempty"`
 	FingerprintSize         helper.ByteSize `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int             `mapstructure:"max_concurrent_files,omitempty"`
@@ -82,8 +89,14 @@ type Config struct {
 	SplitConfig             split.Config    `mapstructure:"multiline,omitempty"`
 	TrimConfig              trim.Config     `mapstructure:",squash,omitempty"`
 	Encoding                string          `mapstructure:"encoding,omitempty"`
+	EncodingFallbacks       []string        `mapstructure:"encoding_fallbacks,omitempty"`
 	FlushPeriod             time.Duration   `mapstructure:"force_flush_period,omitempty"`
 	Header                  *HeaderConfig   `mapstructure:"header,omitempty"`
+	Buffer                  BufferConfig    `mapstructure:"buffer,omitempty"`
+
+	// Streams is not configurable directly. It is populated by receivers that
+	// expose multiple named sets of matching criteria under a single consumer.
+	Streams []StreamConfig `mapstructure:"-"`
 }
 
 type HeaderConfig struct {
@@ -97,7 +110,7 @@ func (c Config) Build(logger *zap.SugaredLogger, emit emit.Callback) (*Manager,
 		return nil, err
 	}
 
-	enc, err := decode.LookupEncoding(c.Encoding)
+	enc, err := c.lookupEncoding()
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +125,14 @@ func (c Config) Build(logger *zap.SugaredLogger, emit emit.Callback) (*Manager,
 		trimFunc = c.TrimConfig.Func()
 	}
 
-	return c.buildManager(logger, emit, splitFunc, trimFunc)
+	m, err := c.buildManager(logger, emit, splitFunc, trimFunc)
+	if err != nil {
+		return nil, err
+	}
+	m.readerFactory.NewSplitFunc = func(enc encoding.Encoding) (bufio.SplitFunc, error) {
+		return c.SplitConfig.Func(enc, false, int(c.MaxLogSize))
+	}
+	return m, nil
 }
 
 // BuildWithSplitFunc will build a file input operator with customized splitFunc function
@@ -120,7 +140,23 @@ func (c Config) BuildWithSplitFunc(logger *zap.SugaredLogger, emit emit.Callback
 	if err := c.validate(); err != nil {
 		return nil, err
 	}
-	return c.buildManager(logger, emit, splitFunc, c.TrimConfig.Func())
+	m, err := c.buildManager(logger, emit, splitFunc, c.TrimConfig.Func())
+	if err != nil {
+		return nil, err
+	}
+	m.readerFactory.NewSplitFunc = func(encoding.Encoding) (bufio.SplitFunc, error) {
+		return splitFunc, nil
+	}
+	return m, nil
+}
+
+// lookupEncoding returns the configured encoding. When the encoding is detected
+// for each file, UTF-8 is returned as a placeholder.
+func (c Config) lookupEncoding() (encoding.Encoding, error) {
+	if c.Encoding == decode.Auto {
+		return unicode.UTF8, nil
+	}
+	return decode.LookupEncoding(c.Encoding)
 }
 
 func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, splitFunc bufio.SplitFunc, trimFunc trim.Func) (*Manager, error) {
@@ -137,11 +173,22 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, spli
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
-	enc, err := decode.LookupEncoding(c.Encoding)
+	enc, err := c.lookupEncoding()
 	if err != nil {
 		return nil, fmt.Errorf("failed to find encoding: %w", err)
 	}
 
+	if err = registerViews(); err != nil {
+		return nil, fmt.Errorf("failed to register metric views: %w", err)
+	}
+
+	var detector *decode.Detector
+	if c.Encoding == decode.Auto {
+		if detector, err = decode.NewDetector(c.EncodingFallbacks); err != nil {
+			return nil, fmt.Errorf("invalid `encoding_fallbacks`: %w", err)
+		}
+	}
+
 	var hCfg *header.Config
 	if c.Header != nil {
 		hCfg, err = header.NewConfig(c.Header.Pattern, c.Header.MetadataOperators, enc)
@@ -150,11 +197,17 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, spli
 		}
 	}
 
-	fileMatcher, err := matcher.New(c.Criteria)
+	fileMatchers, err := c.buildStreamMatchers()
 	if err != nil {
 		return nil, err
 	}
 
+	var buffer *spillBuffer
+	if c.Buffer.Enabled {
+		buffer = newSpillBuffer(logger.With("component", "fileconsumer"), c.Buffer, emit)
+		emit = buffer.bufferEmit
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -176,8 +229,13 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, spli
 			SplitFunc:     splitFunc,
 			TrimFunc:      trimFunc,
 			HeaderConfig:  hCfg,
+
+			EncodingDetector: detector,
 		},
-		fileMatcher:       fileMatcher,
+		fileMatchers:      fileMatchers,
+		buffer:            buffer,
+		telemetry:         newTelemetry(),
+		onTruncate:        c.OnTruncate,
 		pollInterval:      c.PollInterval,
 		maxBatchFiles:     c.MaxConcurrentFiles / 2,
 		maxBatches:        c.MaxBatches,
@@ -195,7 +253,7 @@ func (c Config) validate() error {
 		return fmt.Errorf("`header` requires feature gate `%s`", AllowHeaderMetadataParsing.ID())
 	}
 
-	if _, err := matcher.New(c.Criteria); err != nil {
+	if _, err := c.buildStreamMatchers(); err != nil {
 		return err
 	}
 
@@ -219,15 +277,36 @@ func (c Config) validate() error {
 		return fmt.Errorf("`header` cannot be specified with `start_at: end`")
 	}
 
+	switch c.OnTruncate {
+	case onTruncateBeginning, onTruncateEnd, onTruncateEvent:
+	default:
+		return fmt.Errorf("invalid on_truncate policy '%s'", c.OnTruncate)
+	}
+
 	if c.MaxBatches < 0 {
 		return errors.New("`max_batches` must not be negative")
 	}
 
-	enc, err := decode.LookupEncoding(c.Encoding)
+	if err := c.Buffer.validate(); err != nil {
+		return err
+	}
+
+	enc, err := c.lookupEncoding()
 	if err != nil {
 		return err
 	}
 
+	if c.Encoding == decode.Auto {
+		if _, err := decode.NewDetector(c.EncodingFallbacks); err != nil {
+			return fmt.Errorf("invalid `encoding_fallbacks`: %w", err)
+		}
+		if c.Header != nil {
+			return fmt.Errorf("`header` cannot be specified with `encoding: auto`")
+		}
+	} else if len(c.EncodingFallbacks) > 0 {
+		return fmt.Errorf("`encoding_fallbacks` requires `encoding: auto`")
+	}
+
 	if c.Header != nil {
 		if _, err := header.NewConfig(c.Header.Pattern, c.Header.MetadataOperators, enc); err != nil {
 			return fmt.Errorf("invalid config for `header`: %w", err)
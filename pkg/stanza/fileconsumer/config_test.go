@@ -28,6 +28,7 @@ func TestNewConfig(t *testing.T) {
 	assert.False(t, cfg.IncludeFileNameResolved)
 	assert.False(t, cfg.IncludeFilePathResolved)
 	assert.Equal(t, "end", cfg.StartAt)
+	assert.Equal(t, "beginning", cfg.OnTruncate)
 	assert.Equal(t, 200*time.Millisecond, cfg.PollInterval)
 	assert.Equal(t, fingerprint.DefaultSize, int(cfg.FingerprintSize))
 	assert.Equal(t, defaultEncoding, cfg.Encoding)
@@ -332,6 +333,14 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "on_truncate_event",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.OnTruncate = "event"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "max_concurrent_large",
 				Expect: func() *mockOperatorConfig {
@@ -380,6 +389,25 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "encoding_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Encoding = "auto"
+					cfg.EncodingFallbacks = []string{"shift_jis", "iso-8859-1"}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "buffer",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Buffer.Enabled = true
+					cfg.Buffer.MaxInMemory = 500
+					cfg.Buffer.MaxOnDisk = 64 * 1024 * 1024
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "encoding_upper",
 				Expect: func() *mockOperatorConfig {
@@ -500,6 +528,34 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"EncodingAuto",
+			func(cfg *Config) {
+				cfg.Encoding = "auto"
+				cfg.EncodingFallbacks = []string{"shift_jis", "iso-8859-1"}
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.NotNil(t, m.readerFactory.EncodingDetector)
+			},
+		},
+		{
+			"InvalidEncodingFallback",
+			func(cfg *Config) {
+				cfg.Encoding = "auto"
+				cfg.EncodingFallbacks = []string{"UTF-3233"}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"EncodingFallbacksWithoutAuto",
+			func(cfg *Config) {
+				cfg.EncodingFallbacks = []string{"shift_jis"}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"LineStartAndEnd",
 			func(cfg *Config) {
@@ -540,6 +596,52 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"InvalidOnTruncate",
+			func(cfg *Config) {
+				cfg.OnTruncate = "middle"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidOnTruncate",
+			func(cfg *Config) {
+				cfg.OnTruncate = "end"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "end", m.onTruncate)
+			},
+		},
+		{
+			"Buffer",
+			func(cfg *Config) {
+				cfg.Buffer.Enabled = true
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.NotNil(t, m.buffer)
+			},
+		},
+		{
+			"InvalidBufferMaxInMemory",
+			func(cfg *Config) {
+				cfg.Buffer.Enabled = true
+				cfg.Buffer.MaxInMemory = 0
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidBufferMaxOnDisk",
+			func(cfg *Config) {
+				cfg.Buffer.Enabled = true
+				cfg.Buffer.MaxOnDisk = 0
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidMaxBatches",
 			func(cfg *Config) {
@@ -760,6 +862,25 @@ func TestBuildWithHeader(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"HeaderConfigWithEncodingAuto",
+			func(cfg *Config) {
+				regexCfg := regex.NewConfig()
+				regexCfg.Regex = "^(?P<field>.*)"
+				cfg.Header = &HeaderConfig{
+					Pattern: "^#",
+					MetadataOperators: []operator.Config{
+						{
+							Builder: regexCfg,
+						},
+					},
+				}
+				cfg.StartAt = "beginning"
+				cfg.Encoding = "auto"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"ValidHeaderConfig",
 			func(cfg *Config) {
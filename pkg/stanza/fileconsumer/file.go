@@ -12,10 +12,10 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/checkpoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
@@ -25,7 +25,11 @@ type Manager struct {
 	cancel context.CancelFunc
 
 	readerFactory reader.Factory
-	fileMatcher   *matcher.Matcher
+	fileMatchers  []streamMatcher
+	fileStreams   map[string]string
+	buffer        *spillBuffer
+	telemetry     *telemetry
+	onTruncate    string
 
 	pollInterval  time.Duration
 	persister     operator.Persister
@@ -53,7 +57,13 @@ func (m *Manager) Start(persister operator.Persister) error {
 		}
 	}
 
-	if _, err := m.fileMatcher.MatchFiles(); err != nil {
+	if m.buffer != nil {
+		if err := m.buffer.start(persister); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err := m.matchFiles(); err != nil {
 		m.Warnf("finding files: %v", err)
 	}
 
@@ -76,7 +86,11 @@ func (m *Manager) closePreviousFiles() {
 func (m *Manager) Stop() error {
 	m.cancel()
 	m.wg.Wait()
+	if m.buffer != nil {
+		m.buffer.stop()
+	}
 	m.closePreviousFiles()
+	m.telemetry.reset()
 	if m.persister != nil {
 		if err := checkpoint.Save(context.Background(), m.persister, m.knownFiles); err != nil {
 			m.Errorw("save offsets", zap.Error(err))
@@ -113,7 +127,8 @@ func (m *Manager) poll(ctx context.Context) {
 	batchesProcessed := 0
 
 	// Get the list of paths on disk
-	matches, err := m.fileMatcher.MatchFiles()
+	matches, streams, err := m.matchFiles()
+	m.fileStreams = streams
 	if err != nil {
 		m.Debugf("finding files: %v", err)
 	}
@@ -136,6 +151,13 @@ func (m *Manager) poll(ctx context.Context) {
 
 	// Any new files that appear should be consumed entirely
 	m.readerFactory.FromBeginning = true
+	if m.buffer != nil {
+		// The offsets must not move past buffered tokens that would be lost on crash.
+		if err := m.buffer.flush(); err != nil {
+			m.Errorw("flush buffer", zap.Error(err))
+			return
+		}
+	}
 	if m.persister != nil {
 		if err := checkpoint.Save(context.Background(), m.persister, m.knownFiles); err != nil {
 			m.Errorw("save offsets", zap.Error(err))
@@ -159,12 +181,13 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 		wg.Add(1)
 		go func(r *reader.Reader) {
 			defer wg.Done()
-			r.ReadToEnd(ctx)
+			m.readToEnd(ctx, r)
 		}(r)
 	}
 	wg.Wait()
 
 	m.previousPollFiles = readers
+	m.telemetry.recordTailed(readers)
 }
 
 func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.File) {
@@ -219,6 +242,11 @@ func (m *Manager) makeReaders(paths []string) []*reader.Reader {
 			m.Errorw("Failed to create reader", zap.Error(err))
 			continue
 		}
+		if stream := m.fileStreams[path]; stream != "" {
+			r.FileAttributes[attrs.LogFileStream] = stream
+		} else {
+			delete(r.FileAttributes, attrs.LogFileStream)
+		}
 
 		readers = append(readers, r)
 	}
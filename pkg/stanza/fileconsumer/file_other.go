@@ -43,7 +43,7 @@ OUTER:
 		lostWG.Add(1)
 		go func(r *reader.Reader) {
 			defer lostWG.Done()
-			r.ReadToEnd(ctx)
+			m.readToEnd(ctx, r)
 		}(lostReader)
 	}
 	lostWG.Wait()
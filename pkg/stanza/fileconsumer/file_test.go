@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/featuregate"
+	"golang.org/x/text/encoding/japanese"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
@@ -1357,6 +1358,119 @@ func TestEncodings(t *testing.T) {
 	}
 }
 
+func TestEncodingAuto(t *testing.T) {
+	t.Parallel()
+	shiftJIS, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte("日本語\n"))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		contents  []byte
+		fallbacks []string
+		expected  [][]byte
+	}{
+		{
+			"UTF8BOM",
+			[]byte{0xef, 0xbb, 0xbf, 'f', 'o', 'o', '\n'},
+			nil,
+			[][]byte{[]byte("foo")},
+		},
+		{
+			"UTF16LEBOM",
+			[]byte{0xff, 0xfe, 'f', 0, 'o', 0, 'o', 0, '\n', 0},
+			nil,
+			[][]byte{[]byte("foo")},
+		},
+		{
+			"UTF16BEBOM",
+			[]byte{0xfe, 0xff, 0, 'f', 0, 'o', 0, 'o', 0, '\n'},
+			nil,
+			[][]byte{[]byte("foo")},
+		},
+		{
+			"UTF8WithoutBOM",
+			[]byte("折\n"),
+			[]string{"shift_jis", "iso-8859-1"},
+			[][]byte{[]byte("折")},
+		},
+		{
+			"ShiftJISFallback",
+			shiftJIS,
+			[]string{"shift_jis", "iso-8859-1"},
+			[][]byte{[]byte("日本語")},
+		},
+		{
+			"Latin1Fallback",
+			[]byte("caf\xe9\n"),
+			[]string{"shift_jis", "iso-8859-1"},
+			[][]byte{[]byte("café")},
+		},
+		{
+			"NoFallbackMatches",
+			[]byte("caf\xe9\n"),
+			[]string{"shift_jis"},
+			[][]byte{[]byte("caf\ufffd")},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Encoding = "auto"
+			cfg.EncodingFallbacks = tc.fallbacks
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			_, err := temp.Write(tc.contents)
+			require.NoError(t, err)
+
+			require.NoError(t, operator.Start(testutil.NewUnscopedMockPersister()))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, tc.expected...)
+		})
+	}
+}
+
+// TestEncodingAutoPersisted tests that the detected encoding is only kept
+// once the fingerprint is complete.
+func TestEncodingAutoPersisted(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FingerprintSize = helper.ByteSize(16)
+	cfg.Encoding = "auto"
+	cfg.EncodingFallbacks = []string{"iso-8859-1"}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "caf\xe9\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("café"))
+	require.Len(t, operator.previousPollFiles, 1)
+	require.Equal(t, "", operator.previousPollFiles[0].Encoding)
+
+	writeString(t, temp, "cr\xe8me br\xfbl\xe9e\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("crème brûlée"))
+
+	// The fingerprint was completed while reading, so the next reader keeps the encoding.
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+	require.Len(t, operator.previousPollFiles, 1)
+	require.Equal(t, "iso-8859-1", operator.previousPollFiles[0].Encoding)
+}
+
 func TestDeleteAfterRead(t *testing.T) {
 	t.Parallel()
 
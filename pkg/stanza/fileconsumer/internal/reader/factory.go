@@ -29,6 +29,11 @@ type Factory struct {
 	HeaderConfig  *header.Config
 	SplitFunc     bufio.SplitFunc
 	TrimFunc      trim.Func
+
+	// EncodingDetector, when set, detects the encoding of each file, overriding Encoding.
+	// NewSplitFunc then creates the split function matching the detected encoding.
+	EncodingDetector *decode.Detector
+	NewSplitFunc     func(encoding.Encoding) (bufio.SplitFunc, error)
 }
 
 func (f *Factory) NewFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
@@ -44,17 +49,24 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 }
 
 func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader, err error) {
+	enc, splitFunc := f.Encoding, f.SplitFunc
+	if f.EncodingDetector != nil {
+		if enc, splitFunc, err = f.detectEncoding(m); err != nil {
+			return nil, err
+		}
+	}
+
 	r = &Reader{
 		Config:        f.Config,
 		Metadata:      m,
 		file:          file,
 		fileName:      file.Name(),
 		logger:        f.SugaredLogger.With("path", file.Name()),
-		decoder:       decode.New(f.Encoding),
-		lineSplitFunc: f.SplitFunc,
+		decoder:       decode.New(enc),
+		lineSplitFunc: splitFunc,
 	}
 
-	flushFunc := m.FlushState.Func(f.SplitFunc, f.Config.FlushTimeout)
+	flushFunc := m.FlushState.Func(splitFunc, f.Config.FlushTimeout)
 	r.lineSplitFunc = trim.WithFunc(trim.ToLength(flushFunc, f.Config.MaxLogSize), f.TrimFunc)
 
 	if !f.FromBeginning {
@@ -114,3 +126,29 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 
 	return r, nil
 }
+
+// detectEncoding detects the encoding of a file from its fingerprint and skips its byte order mark.
+// The encoding is kept in the metadata once the fingerprint is complete, so that it is not detected again.
+func (f *Factory) detectEncoding(m *Metadata) (encoding.Encoding, bufio.SplitFunc, error) {
+	name := m.Encoding
+	if name == "" {
+		var bomLen int
+		name, bomLen = f.EncodingDetector.Detect(m.Fingerprint.FirstBytes)
+		if m.Offset < int64(bomLen) {
+			m.Offset = int64(bomLen)
+		}
+		if len(m.Fingerprint.FirstBytes) == f.Config.FingerprintSize {
+			m.Encoding = name
+		}
+	}
+
+	enc, err := decode.LookupEncoding(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	splitFunc, err := f.NewSplitFunc(enc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, splitFunc, nil
+}
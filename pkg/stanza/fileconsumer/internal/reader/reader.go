@@ -5,6 +5,7 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -39,6 +40,11 @@ type Metadata struct {
 	FileAttributes  map[string]any
 	HeaderFinalized bool
 	FlushState      *flush.State
+	// Encoding is the detected encoding of the file, empty when not detected yet.
+	Encoding string
+	// Tail holds the bytes preceding Offset, up to the fingerprint size, which
+	// no longer match when the file was truncated and then written past Offset.
+	Tail []byte
 }
 
 // Reader manages a single file
@@ -127,7 +133,35 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 	if eof && r.DeleteAtEOF {
 		r.delete()
+		return
+	}
+	if tail, err := r.readTail(); err == nil {
+		r.Tail = tail
+	}
+}
+
+// readTail returns the bytes preceding the offset, up to the fingerprint size.
+func (r *Reader) readTail() ([]byte, error) {
+	n := int64(r.FingerprintSize)
+	if n > r.Offset {
+		n = r.Offset
+	}
+	tail := make([]byte, n)
+	if _, err := r.file.ReadAt(tail, r.Offset-n); err != nil {
+		return nil, err
 	}
+	return tail, nil
+}
+
+// TailChanged reports whether the bytes preceding the offset differ from the
+// ones read last, as when the file was truncated and written past the offset
+// again between two polls.
+func (r *Reader) TailChanged() bool {
+	if r.file == nil || len(r.Tail) == 0 {
+		return false
+	}
+	tail, err := r.readTail()
+	return err == nil && !bytes.Equal(tail, r.Tail)
 }
 
 func (r *Reader) finalizeHeader() {
@@ -212,3 +246,11 @@ func (r *Reader) Validate() bool {
 	}
 	return false
 }
+
+// Stat returns the file info of the file being read.
+func (r *Reader) Stat() (os.FileInfo, error) {
+	if r.file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return r.file.Stat()
+}
@@ -6,7 +6,9 @@ package matcher // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher/internal/filter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher/internal/finder"
@@ -26,6 +28,7 @@ type Criteria struct {
 	Include          []string         `mapstructure:"include,omitempty"`
 	Exclude          []string         `mapstructure:"exclude,omitempty"`
 	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
+	ExcludeOlderThan time.Duration    `mapstructure:"exclude_older_than,omitempty"`
 }
 
 type OrderingCriteria struct {
@@ -56,10 +59,15 @@ func New(c Criteria) (*Matcher, error) {
 		return nil, fmt.Errorf("exclude: %w", err)
 	}
 
+	if c.ExcludeOlderThan < 0 {
+		return nil, fmt.Errorf("'exclude_older_than' must not be negative")
+	}
+
 	if len(c.OrderingCriteria.SortBy) == 0 {
 		return &Matcher{
-			include: c.Include,
-			exclude: c.Exclude,
+			include:          c.Include,
+			exclude:          c.Exclude,
+			excludeOlderThan: c.ExcludeOlderThan,
 		}, nil
 	}
 
@@ -107,44 +115,78 @@ func New(c Criteria) (*Matcher, error) {
 	}
 
 	return &Matcher{
-		include:    c.Include,
-		exclude:    c.Exclude,
-		regex:      regex,
-		topN:       c.OrderingCriteria.TopN,
-		filterOpts: filterOpts,
+		include:          c.Include,
+		exclude:          c.Exclude,
+		excludeOlderThan: c.ExcludeOlderThan,
+		regex:            regex,
+		topN:             c.OrderingCriteria.TopN,
+		filterOpts:       filterOpts,
 	}, nil
 }
 
 type Matcher struct {
-	include    []string
-	exclude    []string
-	regex      *regexp.Regexp
-	topN       int
-	filterOpts []filter.Option
+	include          []string
+	exclude          []string
+	excludeOlderThan time.Duration
+	regex            *regexp.Regexp
+	topN             int
+	filterOpts       []filter.Option
+}
+
+// Stats describes the outcome of matching files.
+type Stats struct {
+	// ExcludedOlderThan is the number of files excluded because they were
+	// last modified longer ago than `exclude_older_than`.
+	ExcludedOlderThan int
 }
 
 // MatchFiles gets a list of paths given an array of glob patterns to include and exclude
 func (m Matcher) MatchFiles() ([]string, error) {
+	files, _, err := m.MatchFilesWithStats()
+	return files, err
+}
+
+// MatchFilesWithStats is like MatchFiles, but also reports statistics about the files that were matched.
+func (m Matcher) MatchFilesWithStats() ([]string, Stats, error) {
 	var errs error
+	var stats Stats
 	files, err := finder.FindFiles(m.include, m.exclude)
 	if err != nil {
 		errs = errors.Join(errs, err)
 	}
+	if m.excludeOlderThan > 0 {
+		files, stats.ExcludedOlderThan = m.filterOlderThan(files)
+	}
 	if len(files) == 0 {
-		return files, errors.Join(fmt.Errorf("no files match the configured criteria"), errs)
+		return files, stats, errors.Join(fmt.Errorf("no files match the configured criteria"), errs)
 	}
 	if len(m.filterOpts) == 0 {
-		return files, errs
+		return files, stats, errs
 	}
 
 	result, err := filter.Filter(files, m.regex, m.filterOpts...)
 	if len(result) == 0 {
-		return result, errors.Join(err, errs)
+		return result, stats, errors.Join(err, errs)
 	}
 
 	if len(result) <= m.topN {
-		return result, errors.Join(err, errs)
+		return result, stats, errors.Join(err, errs)
 	}
 
-	return result[:m.topN], errors.Join(err, errs)
+	return result[:m.topN], stats, errors.Join(err, errs)
+}
+
+// filterOlderThan removes files that have not been modified within `exclude_older_than`.
+// Files that cannot be inspected are kept so that errors surface when they are opened.
+func (m Matcher) filterOlderThan(files []string) ([]string, int) {
+	cutoff := time.Now().Add(-m.excludeOlderThan)
+	kept := files[:0]
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err == nil && info.ModTime().Before(cutoff) {
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept, len(files) - len(kept)
 }
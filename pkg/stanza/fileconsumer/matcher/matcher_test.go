@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -58,6 +59,21 @@ func TestNew(t *testing.T) {
 				Exclude: []string{"a.log", "b.log"},
 			},
 		},
+		{
+			name: "ExcludeOlderThan",
+			criteria: Criteria{
+				Include:          []string{"*.log"},
+				ExcludeOlderThan: time.Hour,
+			},
+		},
+		{
+			name: "ExcludeOlderThanNegative",
+			criteria: Criteria{
+				Include:          []string{"*.log"},
+				ExcludeOlderThan: -time.Hour,
+			},
+			expectedErr: "'exclude_older_than' must not be negative",
+		},
 		{
 			name: "ExcludeInvalidGlob",
 			criteria: Criteria{
@@ -714,3 +730,31 @@ func TestMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestMatcherExcludeOlderThan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"old.log", "new.log"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0600))
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "old.log"), old, old))
+
+	matcher, err := New(Criteria{
+		Include:          []string{filepath.Join(dir, "*.log")},
+		ExcludeOlderThan: time.Hour,
+	})
+	require.NoError(t, err)
+
+	files, stats, err := matcher.MatchFilesWithStats()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "new.log")}, files)
+	assert.Equal(t, 1, stats.ExcludedOlderThan)
+
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "new.log"), old, old))
+	files, stats, err = matcher.MatchFilesWithStats()
+	assert.EqualError(t, err, "no files match the configured criteria")
+	assert.Empty(t, files)
+	assert.Equal(t, 2, stats.ExcludedOlderThan)
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+)
+
+var (
+	tagStream = tag.MustNewKey("stream")
+
+	// Gauges are recorded as deltas and aggregated with a sum so that the values
+	// reported by several consumers add up instead of overwriting each other.
+	mFilesMatched      = stats.Int64("fileconsumer_files_matched", "Number of files matched by the include and exclude criteria", stats.UnitDimensionless)
+	mFilesTailed       = stats.Int64("fileconsumer_files_tailed", "Number of files actively being read", stats.UnitDimensionless)
+	mFilesExcludedOld  = stats.Int64("fileconsumer_files_excluded_older_than", "Number of files skipped because of `exclude_older_than`", stats.UnitDimensionless)
+	mBytesRead         = stats.Int64("fileconsumer_bytes_read", "Number of bytes read from files", stats.UnitBytes)
+	mHarvestLagSeconds = stats.Float64("fileconsumer_harvest_lag", "Time between the last modification of a file and the start of reading its new data", stats.UnitSeconds)
+	mBufferSpilled     = stats.Int64("fileconsumer_buffer_spilled", "Number of tokens written to storage by the buffer", stats.UnitDimensionless)
+	mBufferDiskBytes   = stats.Int64("fileconsumer_buffer_disk_usage", "Size of the tokens currently persisted by the buffer", stats.UnitBytes)
+
+	// harvestLagDistribution is shared by the views so that registering them
+	// again is not rejected as registering a different view.
+	harvestLagDistribution = view.Distribution(0, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600)
+
+	registerViewsOnce sync.Once
+	errRegisterViews  error
+)
+
+// registerViews registers the metric views the first time a consumer is built,
+// and returns the registration error to every consumer.
+func registerViews() error {
+	registerViewsOnce.Do(func() {
+		errRegisterViews = view.Register(MetricViews()...)
+	})
+	return errRegisterViews
+}
+
+// MetricViews returns the metric views of the file consumer, which are
+// registered when the first consumer is built.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagStream}
+	sumView := func(m stats.Measure) *view.View {
+		return &view.View{
+			Name:        m.Name(),
+			Measure:     m,
+			Description: m.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		}
+	}
+	return []*view.View{
+		sumView(mFilesMatched),
+		sumView(mFilesTailed),
+		sumView(mFilesExcludedOld),
+		sumView(mBytesRead),
+		sumView(mBufferSpilled),
+		sumView(mBufferDiskBytes),
+		{
+			Name:        mHarvestLagSeconds.Name(),
+			Measure:     mHarvestLagSeconds,
+			Description: mHarvestLagSeconds.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: harvestLagDistribution,
+		},
+	}
+}
+
+// telemetry keeps track of the gauges last reported for every stream so that
+// only the change in value is recorded.
+type telemetry struct {
+	matched  map[string]int64
+	tailed   map[string]int64
+	excluded map[string]int64
+}
+
+func newTelemetry() *telemetry {
+	return &telemetry{
+		matched:  make(map[string]int64),
+		tailed:   make(map[string]int64),
+		excluded: make(map[string]int64),
+	}
+}
+
+func recordForStream(stream string, ms ...stats.Measurement) {
+	_ = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(tagStream, stream)}, ms...)
+}
+
+// updateGauge records the difference between the current and the previously
+// reported values of every stream. Streams absent from current are reset to 0.
+func updateGauge(m *stats.Int64Measure, previous map[string]int64, current map[string]int64) {
+	for stream, value := range current {
+		if delta := value - previous[stream]; delta != 0 {
+			recordForStream(stream, m.M(delta))
+		}
+		previous[stream] = value
+	}
+	for stream, value := range previous {
+		if _, ok := current[stream]; !ok {
+			if value != 0 {
+				recordForStream(stream, m.M(-value))
+			}
+			delete(previous, stream)
+		}
+	}
+}
+
+func (t *telemetry) recordMatched(streams map[string]string, excluded map[string]int) {
+	matched := make(map[string]int64)
+	for _, stream := range streams {
+		matched[stream]++
+	}
+	updateGauge(mFilesMatched, t.matched, matched)
+
+	excludedCounts := make(map[string]int64, len(excluded))
+	for stream, count := range excluded {
+		excludedCounts[stream] = int64(count)
+	}
+	updateGauge(mFilesExcludedOld, t.excluded, excludedCounts)
+}
+
+func (t *telemetry) recordTailed(readers []*reader.Reader) {
+	tailed := make(map[string]int64)
+	for _, r := range readers {
+		if r.Metadata != nil {
+			tailed[readerStream(r)]++
+		}
+	}
+	updateGauge(mFilesTailed, t.tailed, tailed)
+}
+
+// reset zeroes all gauges, so that a stopped consumer no longer contributes to them.
+func (t *telemetry) reset() {
+	updateGauge(mFilesMatched, t.matched, nil)
+	updateGauge(mFilesTailed, t.tailed, nil)
+	updateGauge(mFilesExcludedOld, t.excluded, nil)
+}
+
+func readerStream(r *reader.Reader) string {
+	stream, _ := r.FileAttributes[attrs.LogFileStream].(string)
+	return stream
+}
+
+// readToEnd reads a file to its end while recording how many bytes were read
+// and how long its new data waited to be read. Truncated files are handled first.
+func (m *Manager) readToEnd(ctx context.Context, r *reader.Reader) {
+	stream := readerStream(r)
+	info, err := r.Stat()
+	if err == nil && truncated(r, info) {
+		m.handleTruncation(ctx, r, info)
+	}
+	startOffset := r.Offset
+	if err == nil && info.Size() > startOffset {
+		lag := time.Since(info.ModTime())
+		if lag < 0 {
+			lag = 0
+		}
+		recordForStream(stream, mHarvestLagSeconds.M(lag.Seconds()))
+	}
+
+	r.ReadToEnd(ctx)
+
+	var read int64
+	switch {
+	case r.Metadata != nil:
+		read = r.Offset - startOffset
+	case err == nil:
+		// The reader was closed because the file was deleted after reaching its end.
+		read = info.Size() - startOffset
+	}
+	if read > 0 {
+		recordForStream(stream, mBytesRead.M(read))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"fileconsumer_files_matched",
+		"fileconsumer_files_tailed",
+		"fileconsumer_files_excluded_older_than",
+		"fileconsumer_bytes_read",
+		"fileconsumer_buffer_spilled",
+		"fileconsumer_buffer_disk_usage",
+		"fileconsumer_harvest_lag",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+// streamSum returns the value of a sum view for the given stream.
+func streamSum(t *testing.T, viewName, stream string) float64 {
+	rows, err := view.RetrieveData(viewName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == tagStream && tag.Value == stream {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	tempDir := t.TempDir()
+	cfg := NewConfig()
+	cfg.StartAt = "beginning"
+	cfg.Streams = []StreamConfig{{
+		Name: "metrics-test",
+		Criteria: matcher.Criteria{
+			Include:          []string{filepath.Join(tempDir, "*.log")},
+			ExcludeOlderThan: time.Hour,
+		},
+	}}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	writeString(t, openTempWithPattern(t, tempDir, "*.log"), "testlog1\n")
+	writeString(t, openTempWithPattern(t, tempDir, "*.log"), "testlog2\n")
+	old := openTempWithPattern(t, tempDir, "*.log")
+	writeString(t, old, "too old\n")
+	oldTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(old.Name(), oldTime, oldTime))
+
+	require.NoError(t, operator.Start(testutil.NewUnscopedMockPersister()))
+	waitForTokens(t, emitCalls, []byte("testlog1"), []byte("testlog2"))
+
+	assert.Equal(t, float64(2), streamSum(t, "fileconsumer_files_matched", "metrics-test"))
+	assert.Equal(t, float64(2), streamSum(t, "fileconsumer_files_tailed", "metrics-test"))
+	assert.Equal(t, float64(1), streamSum(t, "fileconsumer_files_excluded_older_than", "metrics-test"))
+	assert.Equal(t, float64(18), streamSum(t, "fileconsumer_bytes_read", "metrics-test"))
+
+	rows, err := view.RetrieveData("fileconsumer_harvest_lag")
+	require.NoError(t, err)
+	var lagCount int64
+	for _, row := range rows {
+		if len(row.Tags) == 1 && row.Tags[0].Value == "metrics-test" {
+			lagCount = row.Data.(*view.DistributionData).Count
+		}
+	}
+	assert.Equal(t, int64(2), lagCount)
+
+	require.NoError(t, operator.Stop())
+	assert.Equal(t, float64(0), streamSum(t, "fileconsumer_files_matched", "metrics-test"))
+	assert.Equal(t, float64(0), streamSum(t, "fileconsumer_files_tailed", "metrics-test"))
+	assert.Equal(t, float64(0), streamSum(t, "fileconsumer_files_excluded_older_than", "metrics-test"))
+	assert.Equal(t, float64(18), streamSum(t, "fileconsumer_bytes_read", "metrics-test"))
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
+)
+
+// StreamConfig is a named set of file matching criteria. Multiple streams share
+// a single poll loop and checkpoint store. Entries read from a file matched by a
+// stream are tagged with the stream name in the `log.file.stream` attribute.
+type StreamConfig struct {
+	Name             string `mapstructure:"name"`
+	matcher.Criteria `mapstructure:",squash"`
+}
+
+// streamMatcher pairs a file matcher with the name of the stream it belongs to.
+// The unnamed stream corresponds to the top level matching criteria.
+type streamMatcher struct {
+	name    string
+	matcher *matcher.Matcher
+}
+
+func (c Config) buildStreamMatchers() ([]streamMatcher, error) {
+	matchers := make([]streamMatcher, 0, len(c.Streams)+1)
+	seen := make(map[string]struct{}, len(c.Streams))
+	for _, s := range c.Streams {
+		if s.Name == "" {
+			return nil, errors.New("stream 'name' must be specified")
+		}
+		if _, ok := seen[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate stream name '%s'", s.Name)
+		}
+		seen[s.Name] = struct{}{}
+
+		m, err := matcher.New(s.Criteria)
+		if err != nil {
+			return nil, fmt.Errorf("stream '%s': %w", s.Name, err)
+		}
+		matchers = append(matchers, streamMatcher{name: s.Name, matcher: m})
+	}
+
+	// The top level criteria act as a catch-all for files not claimed by any stream.
+	if len(c.Streams) == 0 || len(c.Criteria.Include) > 0 {
+		m, err := matcher.New(c.Criteria)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, streamMatcher{matcher: m})
+	}
+	return matchers, nil
+}
+
+// matchFiles returns the union of the files matched by all streams, along with
+// the name of the stream that claimed each file. A file matched by more than
+// one stream belongs to the first stream, in configuration order, that matched it.
+func (m *Manager) matchFiles() ([]string, map[string]string, error) {
+	var errs error
+	var matches []string
+	streams := make(map[string]string)
+	excluded := make(map[string]int)
+	for _, sm := range m.fileMatchers {
+		paths, stats, err := sm.matcher.MatchFilesWithStats()
+		excluded[sm.name] += stats.ExcludedOlderThan
+		if err != nil {
+			if sm.name == "" {
+				errs = errors.Join(errs, err)
+			} else {
+				errs = errors.Join(errs, fmt.Errorf("stream '%s': %w", sm.name, err))
+			}
+		}
+		for _, path := range paths {
+			if _, ok := streams[path]; ok {
+				continue
+			}
+			streams[path] = sm.name
+			matches = append(matches, path)
+		}
+	}
+	m.telemetry.recordMatched(streams, excluded)
+	return matches, streams, errs
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestStreamsValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		streams     []StreamConfig
+		include     []string
+		expectedErr string
+	}{
+		{
+			name:    "streams without top level include",
+			streams: []StreamConfig{{Name: "a", Criteria: matcher.Criteria{Include: []string{"a.log"}}}},
+		},
+		{
+			name:    "streams with top level include",
+			include: []string{"b.log"},
+			streams: []StreamConfig{{Name: "a", Criteria: matcher.Criteria{Include: []string{"a.log"}}}},
+		},
+		{
+			name:        "no include at all",
+			expectedErr: "'include' must be specified",
+		},
+		{
+			name:        "missing stream name",
+			streams:     []StreamConfig{{Criteria: matcher.Criteria{Include: []string{"a.log"}}}},
+			expectedErr: "stream 'name' must be specified",
+		},
+		{
+			name: "duplicate stream name",
+			streams: []StreamConfig{
+				{Name: "a", Criteria: matcher.Criteria{Include: []string{"a.log"}}},
+				{Name: "a", Criteria: matcher.Criteria{Include: []string{"b.log"}}},
+			},
+			expectedErr: "duplicate stream name 'a'",
+		},
+		{
+			name:        "stream without include",
+			streams:     []StreamConfig{{Name: "a"}},
+			expectedErr: "stream 'a': 'include' must be specified",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.Include = tc.include
+			cfg.Streams = tc.streams
+			err := cfg.validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestStreamsTagFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "app1"), 0700))
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "app2"), 0700))
+
+	cfg := NewConfig()
+	cfg.StartAt = "beginning"
+	cfg.Streams = []StreamConfig{
+		{Name: "app1", Criteria: matcher.Criteria{Include: []string{filepath.Join(tempDir, "app1", "*")}}},
+		{Name: "app2", Criteria: matcher.Criteria{Include: []string{filepath.Join(tempDir, "app2", "*")}}},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	writeString(t, openTemp(t, filepath.Join(tempDir, "app1")), "from app1\n")
+	writeString(t, openTemp(t, filepath.Join(tempDir, "app2")), "from app2\n")
+
+	require.NoError(t, operator.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	received := map[string]any{}
+	for i := 0; i < 2; i++ {
+		call := waitForEmit(t, emitCalls)
+		received[string(call.token)] = call.attrs[attrs.LogFileStream]
+	}
+	require.Equal(t, map[string]any{"from app1": "app1", "from app2": "app2"}, received)
+}
+
+func TestStreamsFirstMatchWins(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig()
+	cfg.StartAt = "beginning"
+	cfg.Include = []string{filepath.Join(tempDir, "*")}
+	cfg.Streams = []StreamConfig{
+		{Name: "first", Criteria: matcher.Criteria{Include: []string{filepath.Join(tempDir, "*.log")}}},
+		{Name: "second", Criteria: matcher.Criteria{Include: []string{filepath.Join(tempDir, "*")}}},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	writeString(t, openTempWithPattern(t, tempDir, "*.log"), "testlog\n")
+
+	require.NoError(t, operator.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	call := waitForEmit(t, emitCalls)
+	require.Equal(t, "first", call.attrs[attrs.LogFileStream])
+}
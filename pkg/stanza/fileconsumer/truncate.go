@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+)
+
+const (
+	// onTruncateBeginning re-reads a truncated file from its start.
+	onTruncateBeginning = "beginning"
+	// onTruncateEnd skips to the end of a truncated file, only reading data written afterwards.
+	onTruncateEnd = "end"
+	// onTruncateEvent emits a diagnostic entry, then re-reads a truncated file from its start.
+	onTruncateEvent = "event"
+)
+
+// truncated reports whether the file of a reader was truncated in place, e.g. by
+// copytruncate rotation, while its new content still matches the previous
+// fingerprint. Either the file is now smaller than the offset, or it was written
+// past the offset again and the data preceding the offset changed.
+func truncated(r *reader.Reader, info os.FileInfo) bool {
+	return info.Size() < r.Offset || r.TailChanged()
+}
+
+// handleTruncation applies the truncation policy to a reader whose file was truncated.
+func (m *Manager) handleTruncation(ctx context.Context, r *reader.Reader, info os.FileInfo) {
+	size := info.Size()
+	r.Tail = nil
+	m.Infow("File truncated", "file", info.Name(), "offset", r.Offset, "size", size)
+
+	switch m.onTruncate {
+	case onTruncateEnd:
+		r.Offset = size
+		return
+	case onTruncateEvent:
+		attributes := make(map[string]any, len(r.FileAttributes)+1)
+		for k, v := range r.FileAttributes {
+			attributes[k] = v
+		}
+		attributes[attrs.LogFileTruncated] = true
+		token := []byte(fmt.Sprintf("file truncated from %d to %d bytes", r.Offset, size))
+		if err := m.readerFactory.Config.Emit(ctx, token, attributes); err != nil {
+			m.Errorw("Failed to emit truncation event", zap.Error(err))
+		}
+	}
+	r.Offset = 0
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+// The header line is longer than the fingerprint, so that the truncated
+// file keeps matching the fingerprint of the original one.
+const truncateTestHeader = "this line is longer than the fingerprint"
+
+func TestTruncateSameFingerprint(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		onTruncate string
+		expected   [][]byte
+	}{
+		{
+			name:       "beginning",
+			onTruncate: "beginning",
+			expected:   [][]byte{[]byte(truncateTestHeader), []byte("testlog3")},
+		},
+		{
+			name:       "end",
+			onTruncate: "end",
+			expected:   [][]byte{[]byte("testlog4")},
+		},
+		{
+			name:       "event",
+			onTruncate: "event",
+			expected: [][]byte{
+				[]byte("file truncated from 68 to 50 bytes"),
+				[]byte(truncateTestHeader),
+				[]byte("testlog3"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.FingerprintSize = 16
+			cfg.OnTruncate = tc.onTruncate
+			operator, emitCalls := buildTestManager(t, cfg)
+			operator.persister = testutil.NewUnscopedMockPersister()
+
+			temp := openTemp(t, tempDir)
+			writeString(t, temp, truncateTestHeader+"\ntestlog1\ntestlog2\ntestlog3\n")
+
+			operator.poll(context.Background())
+			waitForNTokens(t, emitCalls, 4)
+
+			require.NoError(t, temp.Truncate(0))
+			_, err := temp.Seek(0, 0)
+			require.NoError(t, err)
+			writeString(t, temp, truncateTestHeader+"\ntestlog3\n")
+			if tc.onTruncate == "end" {
+				operator.poll(context.Background())
+				writeString(t, temp, "testlog4\n")
+			}
+
+			operator.poll(context.Background())
+			waitForTokens(t, emitCalls, tc.expected...)
+		})
+	}
+}
+
+func TestTruncateGrownPastOffset(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FingerprintSize = 16
+	cfg.OnTruncate = "event"
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, truncateTestHeader+"\ntestlog1\n")
+	operator.poll(context.Background())
+	waitForNTokens(t, emitCalls, 2)
+
+	// The file is truncated, then grows past the previous offset before the next poll.
+	require.NoError(t, temp.Truncate(0))
+	_, err := temp.Seek(0, 0)
+	require.NoError(t, err)
+	writeString(t, temp, truncateTestHeader+"\ntestlog2\ntestlog3\n")
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls,
+		[]byte("file truncated from 50 to 59 bytes"),
+		[]byte(truncateTestHeader),
+		[]byte("testlog2"),
+		[]byte("testlog3"),
+	)
+}
+
+func TestTruncateEventAttributes(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FingerprintSize = 16
+	cfg.OnTruncate = "event"
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, truncateTestHeader+"\ntestlog1\n")
+	operator.poll(context.Background())
+	waitForNTokens(t, emitCalls, 2)
+
+	require.NoError(t, temp.Truncate(0))
+	_, err := temp.Seek(0, 0)
+	require.NoError(t, err)
+	writeString(t, temp, truncateTestHeader[:20]+"\n")
+
+	operator.poll(context.Background())
+	call := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("file truncated from 50 to 21 bytes"), call.token)
+	require.Equal(t, true, call.attrs[attrs.LogFileTruncated])
+	require.NotNil(t, call.attrs[attrs.LogFileName])
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// The PROXY protocol is specified in
+// https://www.haproxy.org/download/2.8/doc/proxy-protocol.txt
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+const (
+	// proxyV1MaxLength is the maximum length of a v1 header, including the CRLF.
+	proxyV1MaxLength = 107
+	// proxyV2HeaderLength is the length of a v2 header, without the addresses.
+	proxyV2HeaderLength = 16
+)
+
+var errMissingProxyHeader = errors.New("missing PROXY protocol header")
+
+// ProxyHeader holds the addresses of the original connection sent by a proxy
+// using the PROXY protocol. The addresses are nil when the proxy did not send
+// them, e.g. for health checks of the proxy itself.
+type ProxyHeader struct {
+	Source      net.Addr
+	Destination net.Addr
+}
+
+// ReadProxyHeader reads a v1 or v2 PROXY protocol header from r. The data
+// following the header is left in r.
+func ReadProxyHeader(r *bufio.Reader) (*ProxyHeader, error) {
+	prefix, err := r.Peek(len(proxyV1Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("read PROXY protocol header: %w", err)
+	}
+	if bytes.Equal(prefix, proxyV1Prefix) {
+		return readProxyHeaderV1(r)
+	}
+
+	header, err := r.Peek(proxyV2HeaderLength)
+	if err != nil {
+		return nil, fmt.Errorf("read PROXY protocol header: %w", err)
+	}
+	if !bytes.HasPrefix(header, proxyV2Signature) {
+		return nil, errMissingProxyHeader
+	}
+	data, err := r.Peek(proxyV2HeaderLength + int(binary.BigEndian.Uint16(header[14:16])))
+	if err != nil {
+		return nil, fmt.Errorf("read PROXY protocol header: %w", err)
+	}
+	proxyHeader, n, err := ParseProxyHeaderV2(data)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.Discard(n)
+	return proxyHeader, err
+}
+
+func readProxyHeaderV1(r *bufio.Reader) (*ProxyHeader, error) {
+	var line []byte
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) == proxyV1MaxLength {
+			return nil, errors.New("PROXY protocol v1 header is too long")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("read PROXY protocol header: %w", err)
+		}
+		line = append(line, b)
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return &ProxyHeader{}, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 header %q", strings.TrimSpace(string(line)))
+	}
+
+	source, err := parseProxyAddrV1(fields[2], fields[4])
+	if err != nil {
+		return nil, err
+	}
+	destination, err := parseProxyAddrV1(fields[3], fields[5])
+	if err != nil {
+		return nil, err
+	}
+	return &ProxyHeader{Source: source, Destination: destination}, nil
+}
+
+func parseProxyAddrV1(ip, port string) (*net.TCPAddr, error) {
+	addr := &net.TCPAddr{IP: net.ParseIP(ip)}
+	if addr.IP == nil {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 address %q", ip)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 port %q", port)
+	}
+	addr.Port = int(p)
+	return addr, nil
+}
+
+// ParseProxyHeaderV2 parses the v2 PROXY protocol header at the start of data,
+// and returns it along with its length in bytes.
+func ParseProxyHeaderV2(data []byte) (*ProxyHeader, int, error) {
+	if len(data) < proxyV2HeaderLength || !bytes.HasPrefix(data, proxyV2Signature) {
+		return nil, 0, errMissingProxyHeader
+	}
+	if version := data[12] >> 4; version != 2 {
+		return nil, 0, fmt.Errorf("unsupported PROXY protocol version %d", version)
+	}
+	n := proxyV2HeaderLength + int(binary.BigEndian.Uint16(data[14:16]))
+	if len(data) < n {
+		return nil, 0, errors.New("truncated PROXY protocol v2 header")
+	}
+
+	// LOCAL connections are established by the proxy itself
+	if command := data[12] & 0x0F; command == 0x00 {
+		return &ProxyHeader{}, n, nil
+	}
+
+	addrs := data[proxyV2HeaderLength:n]
+	var ipLength int
+	switch family := data[13] >> 4; family {
+	case 0x1: // AF_INET
+		ipLength = net.IPv4len
+	case 0x2: // AF_INET6
+		ipLength = net.IPv6len
+	default: // AF_UNSPEC and AF_UNIX have no IP addresses
+		return &ProxyHeader{}, n, nil
+	}
+	if len(addrs) < 2*ipLength+4 {
+		return nil, 0, errors.New("truncated PROXY protocol v2 addresses")
+	}
+
+	srcIP := net.IP(append([]byte(nil), addrs[:ipLength]...))
+	dstIP := net.IP(append([]byte(nil), addrs[ipLength:2*ipLength]...))
+	srcPort := int(binary.BigEndian.Uint16(addrs[2*ipLength:]))
+	dstPort := int(binary.BigEndian.Uint16(addrs[2*ipLength+2:]))
+	if data[13]&0x0F == 0x2 { // SOCK_DGRAM
+		return &ProxyHeader{
+			Source:      &net.UDPAddr{IP: srcIP, Port: srcPort},
+			Destination: &net.UDPAddr{IP: dstIP, Port: dstPort},
+		}, n, nil
+	}
+	return &ProxyHeader{
+		Source:      &net.TCPAddr{IP: srcIP, Port: srcPort},
+		Destination: &net.TCPAddr{IP: dstIP, Port: dstPort},
+	}, n, nil
+}
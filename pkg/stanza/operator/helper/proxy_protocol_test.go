@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func proxyV2Header(command, family byte, addrs []byte) []byte {
+	header := append([]byte(nil), proxyV2Signature...)
+	header = append(header, 0x20|command, family, byte(len(addrs)>>8), byte(len(addrs)))
+	return append(header, addrs...)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	ipv4Addrs := []byte{
+		192, 168, 0, 1, // source IP
+		10, 0, 0, 1, // destination IP
+		0x30, 0x39, // source port 12345
+		0x02, 0x02, // destination port 514
+	}
+	ipv6Addrs := append(append(append([]byte(nil), net.ParseIP("2001:db8::1")...), net.ParseIP("2001:db8::2")...), 0x30, 0x39, 0x02, 0x02)
+
+	cases := []struct {
+		name     string
+		input    string
+		expected *ProxyHeader
+		err      string
+	}{
+		{
+			name:  "V1TCP4",
+			input: "PROXY TCP4 192.168.0.1 10.0.0.1 12345 514\r\n",
+			expected: &ProxyHeader{
+				Source:      &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 12345},
+				Destination: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 514},
+			},
+		},
+		{
+			name:  "V1TCP6",
+			input: "PROXY TCP6 2001:db8::1 2001:db8::2 12345 514\r\n",
+			expected: &ProxyHeader{
+				Source:      &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 12345},
+				Destination: &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 514},
+			},
+		},
+		{
+			name:     "V1Unknown",
+			input:    "PROXY UNKNOWN\r\n",
+			expected: &ProxyHeader{},
+		},
+		{
+			name:  "V1InvalidAddress",
+			input: "PROXY TCP4 localhost 10.0.0.1 12345 514\r\n",
+			err:   `invalid PROXY protocol v1 address "localhost"`,
+		},
+		{
+			name:  "V1TooLong",
+			input: "PROXY TCP4 " + strings.Repeat("1", 200),
+			err:   "PROXY protocol v1 header is too long",
+		},
+		{
+			name:  "V2TCP4",
+			input: string(proxyV2Header(0x1, 0x11, ipv4Addrs)),
+			expected: &ProxyHeader{
+				Source:      &net.TCPAddr{IP: net.IP{192, 168, 0, 1}, Port: 12345},
+				Destination: &net.TCPAddr{IP: net.IP{10, 0, 0, 1}, Port: 514},
+			},
+		},
+		{
+			name:  "V2UDP6",
+			input: string(proxyV2Header(0x1, 0x22, ipv6Addrs)),
+			expected: &ProxyHeader{
+				Source:      &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 12345},
+				Destination: &net.UDPAddr{IP: net.ParseIP("2001:db8::2"), Port: 514},
+			},
+		},
+		{
+			name:     "V2Local",
+			input:    string(proxyV2Header(0x0, 0x00, nil)),
+			expected: &ProxyHeader{},
+		},
+		{
+			name:  "V2Truncated",
+			input: string(proxyV2Header(0x1, 0x11, ipv4Addrs[:6])),
+			err:   "truncated PROXY protocol v2 addresses",
+		},
+		{
+			name:  "Missing",
+			input: "<13>message without header\n",
+			err:   "missing PROXY protocol header",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tc.input + "message"))
+			header, err := ReadProxyHeader(r)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, header)
+
+			rest, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "message", string(rest))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// NewListenConfig returns a net.ListenConfig that enables SO_REUSEPORT on the sockets
+// it opens when reusePort is set. This lets several sockets bind the same address, the
+// kernel spreading incoming packets and connections across them.
+func NewListenConfig(reusePort bool) (net.ListenConfig, error) {
+	if !reusePort {
+		return net.ListenConfig{}, nil
+	}
+	if !ReusePortSupported {
+		return net.ListenConfig{}, fmt.Errorf("'reuse_port' is not supported on this platform")
+	}
+	return net.ListenConfig{
+		Control: func(_, _ string, c syscall.RawConn) error {
+			var sockErr error
+			if err := c.Control(func(fd uintptr) {
+				sockErr = setReusePort(fd)
+			}); err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}, nil
+}
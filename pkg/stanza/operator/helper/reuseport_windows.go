@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"errors"
+)
+
+// ReusePortSupported reports whether SO_REUSEPORT can be enabled on this platform.
+const ReusePortSupported = false
+
+func setReusePort(uintptr) error {
+	return errors.New("SO_REUSEPORT is not supported on windows")
+}
@@ -14,26 +14,46 @@ import (
 
 // SeverityParser is a helper that parses severity onto an entry.
 type SeverityParser struct {
-	ParseFrom     entry.Field
-	Mapping       severityMap
-	overwriteText bool
+	ParseFrom         entry.Field
+	fallbackParseFrom []entry.Field
+	Mapping           severityMap
+	overwriteText     bool
 }
 
-// Parse will parse severity from a field and attach it to the entry
+// Parse will parse severity from a field and attach it to the entry. The
+// fallback fields are tried in order when parse_from is missing or its value
+// does not map to a severity.
 func (p *SeverityParser) Parse(ent *entry.Entry) error {
-	value, ok := ent.Get(p.ParseFrom)
-	if !ok {
+	var severity entry.Severity
+	var sevText string
+	found := false
+	for _, field := range append([]entry.Field{p.ParseFrom}, p.fallbackParseFrom...) {
+		value, ok := ent.Get(field)
+		if !ok {
+			continue
+		}
+
+		fieldSeverity, fieldSevText, err := p.Mapping.find(value)
+		if err != nil {
+			return errors.Wrap(err, "parse")
+		}
+
+		// Unknown values are only kept if no other field maps to a severity
+		if !found || fieldSeverity != entry.Default {
+			severity, sevText, found = fieldSeverity, fieldSevText, true
+		}
+		if severity != entry.Default {
+			break
+		}
+	}
+
+	if !found {
 		return errors.NewError(
 			"log entry does not have the expected parse_from field",
 			"ensure that all entries forwarded to this parser contain the parse_from field",
 			"parse_from", p.ParseFrom.String(),
 		)
 	}
-
-	severity, sevText, err := p.Mapping.find(value)
-	if err != nil {
-		return errors.Wrap(err, "parse")
-	}
 	if p.overwriteText && severity != entry.Default {
 		sevText = severity.String()
 	}
@@ -5,10 +5,12 @@ package helper // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 )
@@ -111,51 +113,110 @@ func NewSeverityConfig() SeverityConfig {
 
 // SeverityConfig allows users to specify how to parse a severity from a field.
 type SeverityConfig struct {
-	ParseFrom     *entry.Field           `mapstructure:"parse_from,omitempty"`
-	Preset        string                 `mapstructure:"preset,omitempty"`
-	Mapping       map[string]interface{} `mapstructure:"mapping,omitempty"`
-	OverwriteText bool                   `mapstructure:"overwrite_text,omitempty"`
+	ParseFrom         *entry.Field           `mapstructure:"parse_from,omitempty"`
+	FallbackParseFrom []entry.Field          `mapstructure:"fallback_parse_from,omitempty"`
+	Preset            string                 `mapstructure:"preset,omitempty"`
+	MappingFile       string                 `mapstructure:"mapping_file,omitempty"`
+	Mapping           map[string]interface{} `mapstructure:"mapping,omitempty"`
+	OverwriteText     bool                   `mapstructure:"overwrite_text,omitempty"`
 }
 
 // Build builds a SeverityParser from a SeverityConfig
 func (c *SeverityConfig) Build(_ *zap.SugaredLogger) (SeverityParser, error) {
 	operatorMapping := getBuiltinMapping(c.Preset)
 
-	for severity, unknown := range c.Mapping {
-		sev, err := validateSeverity(severity)
+	if c.MappingFile != "" {
+		fileMapping, err := readMappingFile(c.MappingFile)
 		if err != nil {
 			return SeverityParser{}, err
 		}
+		if err := operatorMapping.addMapping(fileMapping); err != nil {
+			return SeverityParser{}, fmt.Errorf("mapping_file %s: %w", c.MappingFile, err)
+		}
+	}
+
+	if err := operatorMapping.addMapping(c.Mapping); err != nil {
+		return SeverityParser{}, err
+	}
+
+	if c.ParseFrom == nil {
+		return SeverityParser{}, fmt.Errorf("missing required field 'parse_from'")
+	}
+
+	p := SeverityParser{
+		ParseFrom:         *c.ParseFrom,
+		fallbackParseFrom: c.FallbackParseFrom,
+		Mapping:           operatorMapping,
+		overwriteText:     c.OverwriteText,
+	}
+
+	return p, nil
+}
+
+// addMapping adds the values of a mapping, as configured by users, to m.
+func (m severityMap) addMapping(mapping map[string]interface{}) error {
+	for severity, unknown := range mapping {
+		sev, err := validateSeverity(severity)
+		if err != nil {
+			return err
+		}
 
 		switch u := unknown.(type) {
 		case []interface{}: // check before interface{}
 			for _, value := range u {
 				v, err := parseableValues(value)
 				if err != nil {
-					return SeverityParser{}, err
+					return err
 				}
-				operatorMapping.add(sev, v...)
+				m.add(sev, v...)
 			}
 		case interface{}:
 			v, err := parseableValues(u)
 			if err != nil {
-				return SeverityParser{}, err
+				return err
 			}
-			operatorMapping.add(sev, v...)
+			m.add(sev, v...)
 		}
 	}
+	return nil
+}
 
-	if c.ParseFrom == nil {
-		return SeverityParser{}, fmt.Errorf("missing required field 'parse_from'")
+// readMappingFile reads a YAML file containing a mapping, in the same format as
+// the mapping field.
+func readMappingFile(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mapping_file: %w", err)
 	}
 
-	p := SeverityParser{
-		ParseFrom:     *c.ParseFrom,
-		Mapping:       operatorMapping,
-		overwriteText: c.OverwriteText,
+	var mapping map[string]interface{}
+	if err := yaml.Unmarshal(content, &mapping); err != nil {
+		return nil, fmt.Errorf("parse mapping_file %s: %w", path, err)
+	}
+	for k, v := range mapping {
+		mapping[k] = stringKeys(v)
 	}
+	return mapping, nil
+}
 
-	return p, nil
+// stringKeys converts the maps decoded from YAML, such as ranges, to maps with
+// string keys as decoded from the configuration.
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = stringKeys(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = stringKeys(val)
+		}
+		return v
+	default:
+		return value
+	}
 }
 
 func validateSeverity(severity interface{}) (entry.Severity, error) {
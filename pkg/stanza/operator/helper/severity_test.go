@@ -576,6 +576,113 @@ func TestBuildCustomMapping(t *testing.T) {
 	}
 }
 
+func TestSeverityParserFallback(t *testing.T) {
+	level := entry.NewAttributeField("level")
+	status := entry.NewBodyField("status")
+	cfg := &SeverityConfig{
+		ParseFrom:         &level,
+		FallbackParseFrom: []entry.Field{status},
+		Mapping:           map[string]interface{}{"error": "5xx"},
+	}
+	severityParser, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		attributes   map[string]interface{}
+		body         map[string]interface{}
+		expected     entry.Severity
+		expectedText string
+		parseErr     bool
+	}{
+		{
+			name:         "parse_from",
+			attributes:   map[string]interface{}{"level": "warn"},
+			body:         map[string]interface{}{"status": 500},
+			expected:     entry.Warn,
+			expectedText: "warn",
+		},
+		{
+			name:         "missing_parse_from",
+			body:         map[string]interface{}{"status": 500},
+			expected:     entry.Error,
+			expectedText: "500",
+		},
+		{
+			name:         "unknown_parse_from",
+			attributes:   map[string]interface{}{"level": "unknown"},
+			body:         map[string]interface{}{"status": 500},
+			expected:     entry.Error,
+			expectedText: "500",
+		},
+		{
+			name:         "all_unknown",
+			attributes:   map[string]interface{}{"level": "unknown"},
+			body:         map[string]interface{}{"status": 200},
+			expected:     entry.Default,
+			expectedText: "unknown",
+		},
+		{
+			name:     "all_missing",
+			parseErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ent := entry.New()
+			ent.Attributes = tc.attributes
+			ent.Body = tc.body
+			err := severityParser.Parse(ent)
+			if tc.parseErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ent.Severity)
+			require.Equal(t, tc.expectedText, ent.SeverityText)
+		})
+	}
+}
+
+func TestBuildMappingFile(t *testing.T) {
+	parseFrom := entry.NewBodyField()
+	cfg := &SeverityConfig{
+		ParseFrom:   &parseFrom,
+		MappingFile: filepath.Join("testdata", "severity_mapping.yaml"),
+		// The mapping is applied after the mapping file
+		Mapping: map[string]interface{}{"fatal": "crash"},
+	}
+
+	severityParser, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	expected := map[string]entry.Severity{
+		"oops":  entry.Error,
+		"404":   entry.Error,
+		"503":   entry.Fatal,
+		"crash": entry.Fatal,
+		"hey!":  entry.Warn,
+		"ysk":   entry.Warn,
+		"1050":  entry.Info,
+		"12":    entry.Info,
+		"info":  entry.Info,
+	}
+	for k, v := range expected {
+		sev, _, err := severityParser.Mapping.find(k)
+		require.NoError(t, err)
+		require.Equal(t, v, sev, k)
+	}
+
+	cfg.MappingFile = filepath.Join("testdata", "missing.yaml")
+	_, err = cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "read mapping_file")
+
+	cfg.MappingFile = filepath.Join("testdata", "severity_mapping_invalid.yaml")
+	_, err = cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "mapping_file")
+}
+
 func TestUnmarshalSeverityConfig(t *testing.T) {
 	operatortest.ConfigUnmarshalTests{
 		DefaultConfig: newHelpersConfig(),
@@ -609,6 +716,29 @@ func TestUnmarshalSeverityConfig(t *testing.T) {
 					return c
 				}(),
 			},
+			{
+				Name: "fallback_parse_from",
+				Expect: func() *helpersConfig {
+					c := newHelpersConfig()
+					c.Severity = NewSeverityConfig()
+					from := entry.NewBodyField("from")
+					c.Severity.ParseFrom = &from
+					c.Severity.FallbackParseFrom = []entry.Field{
+						entry.NewAttributeField("level"),
+						entry.NewBodyField("status"),
+					}
+					return c
+				}(),
+			},
+			{
+				Name: "mapping_file",
+				Expect: func() *helpersConfig {
+					c := newHelpersConfig()
+					c.Severity = NewSeverityConfig()
+					c.Severity.MappingFile = "/etc/otel/severity.yaml"
+					return c
+				}(),
+			},
 		},
 	}.Run(t)
 }
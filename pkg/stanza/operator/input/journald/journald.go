@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"path"
 	"regexp"
 	"sort"
 	"strconv"
@@ -78,16 +79,23 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	excludeUnits, err := c.buildExcludeUnits()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Input{
 		InputOperator: inputOperator,
 		newCmd: func(ctx context.Context, cursor []byte) cmd {
+			cmdArgs := args
 			if cursor != nil {
-				args = append(args, "--after-cursor", string(cursor))
+				cmdArgs = append(cmdArgs[:len(cmdArgs):len(cmdArgs)], "--after-cursor", string(cursor))
 			}
-			return exec.CommandContext(ctx, "journalctl", args...) // #nosec - ...
+			return exec.CommandContext(ctx, "journalctl", cmdArgs...) // #nosec - ...
 			// journalctl is an executable that is required for this operator to function
 		},
-		json: jsoniter.ConfigFastest,
+		excludeUnits: excludeUnits,
+		json:         jsoniter.ConfigFastest,
 	}, nil
 }
 
@@ -111,8 +119,11 @@ func (c Config) buildArgs() ([]string, error) {
 		return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
 	}
 
+	// Exclusions are not supported by journalctl, and are applied by the operator
 	for _, unit := range c.Units {
-		args = append(args, "--unit", unit)
+		if !strings.HasPrefix(unit, "!") {
+			args = append(args, "--unit", unit)
+		}
 	}
 
 	for _, identifier := range c.Identifiers {
@@ -149,6 +160,42 @@ func (c Config) buildArgs() ([]string, error) {
 	return args, nil
 }
 
+// unitSuffixes are the unit types known to systemd.
+var unitSuffixes = []string{
+	".service", ".socket", ".device", ".mount", ".automount", ".swap",
+	".target", ".path", ".timer", ".slice", ".scope",
+}
+
+// buildExcludeUnits returns the glob patterns of the units prefixed with "!".
+// Like journalctl does for included units, ".service" is appended to names
+// without unit type.
+func (c Config) buildExcludeUnits() ([]string, error) {
+	var patterns []string
+	for _, unit := range c.Units {
+		pattern, ok := strings.CutPrefix(unit, "!")
+		if !ok {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid unit pattern '%s': %w", unit, err)
+		}
+		if !hasUnitSuffix(pattern) && !strings.ContainsAny(pattern, "*?[") {
+			pattern += ".service"
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func hasUnitSuffix(unit string) bool {
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(unit, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 func buildMatchConfig(mc MatchConfig) ([]string, error) {
 	re := regexp.MustCompile("^[_A-Z]+$")
 
@@ -192,7 +239,8 @@ func (c Config) buildMatchesConfig() ([]string, error) {
 type Input struct {
 	helper.InputOperator
 
-	newCmd func(ctx context.Context, cursor []byte) cmd
+	newCmd       func(ctx context.Context, cursor []byte) cmd
+	excludeUnits []string
 
 	persister operator.Persister
 	json      jsoniter.API
@@ -313,10 +361,14 @@ func (operator *Input) Start(persister operator.Persister) error {
 				operator.Warnw("Failed to parse journal entry", zap.Error(err))
 				continue
 			}
+			// The cursor is saved once the entry is written, so that it is read
+			// again if the collector stops in between
+			if !operator.excluded(entry) {
+				operator.Write(ctx, entry)
+			}
 			if err := operator.persister.Set(ctx, lastReadCursorKey, []byte(cursor)); err != nil {
 				operator.Warnw("Failed to set offset", zap.Error(err))
 			}
-			operator.Write(ctx, entry)
 		}
 	}()
 
@@ -375,6 +427,27 @@ func (operator *Input) parseJournalEntry(line []byte) (*entry.Entry, string, err
 	return entry, cursorString, nil
 }
 
+// excluded returns whether the unit of the entry matches an excluded unit pattern.
+func (operator *Input) excluded(e *entry.Entry) bool {
+	if len(operator.excludeUnits) == 0 {
+		return false
+	}
+	body, ok := e.Body.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	unit, ok := body["_SYSTEMD_UNIT"].(string)
+	if !ok {
+		return false
+	}
+	for _, pattern := range operator.excludeUnits {
+		if matched, _ := path.Match(pattern, unit); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // Stop will stop generating logs.
 func (operator *Input) Stop() error {
 	operator.cancel()
@@ -145,6 +145,13 @@ func TestBuildConfig(t *testing.T) {
 			},
 			Expected: []string{"--utc", "--output=json", "--follow", "--unit", "dbus.service", "--unit", "user@1000.service", "--priority", "info"},
 		},
+		{
+			Name: "unit globs and exclusions",
+			Config: func(cfg *Config) {
+				cfg.Units = []string{"docker*", "!*.scope", "ssh"}
+			},
+			Expected: []string{"--utc", "--output=json", "--follow", "--unit", "docker*", "--unit", "ssh", "--priority", "info"},
+		},
 		{
 			Name: "matches",
 			Config: func(cfg *Config) {
@@ -223,6 +230,73 @@ func TestBuildConfig(t *testing.T) {
 	}
 }
 
+func TestBuildExcludeUnits(t *testing.T) {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.Units = []string{"docker*", "!*.scope", "!ssh", "!user@1000.service", "!getty@*"}
+	patterns, err := cfg.buildExcludeUnits()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.scope", "ssh.service", "user@1000.service", "getty@*"}, patterns)
+
+	cfg.Units = []string{"![docker"}
+	_, err = cfg.buildExcludeUnits()
+	require.ErrorContains(t, err, "invalid unit pattern '![docker'")
+}
+
+func TestInputJournaldExcludeUnits(t *testing.T) {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.OutputIDs = []string{"output"}
+	cfg.Units = []string{"!user@*"}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.NewMockOperator("output")
+	received := make(chan *entry.Entry)
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		received <- args.Get(1).(*entry.Entry)
+	}).Return(nil)
+	require.NoError(t, op.SetOutputs([]operator.Operator{mockOutput}))
+
+	op.(*Input).newCmd = func(ctx context.Context, cursor []byte) cmd {
+		return &fakeJournaldCmd{}
+	}
+
+	persister := testutil.NewUnscopedMockPersister()
+	err = op.Start(persister)
+	assert.EqualError(t, err, "journalctl command exited")
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	select {
+	case <-received:
+		require.FailNow(t, "Received entry of an excluded unit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The cursor of excluded entries is saved as well
+	require.Eventually(t, func() bool {
+		cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+		return err == nil && string(cursor) == "s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewCmdCursor(t *testing.T) {
+	cfg := NewConfigWithID("my_journald_input")
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	newCmd := op.(*Input).newCmd
+
+	journal := newCmd(context.Background(), nil).(*exec.Cmd)
+	assert.Equal(t, []string{"journalctl", "--utc", "--output=json", "--follow", "--priority", "info"}, journal.Args)
+
+	// The cursor of a previous start does not leak into the next one
+	journal = newCmd(context.Background(), []byte("cursor1")).(*exec.Cmd)
+	assert.Equal(t, []string{"journalctl", "--utc", "--output=json", "--follow", "--priority", "info", "--after-cursor", "cursor1"}, journal.Args)
+	journal = newCmd(context.Background(), []byte("cursor2")).(*exec.Cmd)
+	assert.Equal(t, []string{"journalctl", "--utc", "--output=json", "--follow", "--priority", "info", "--after-cursor", "cursor2"}, journal.Args)
+}
+
 func TestInputJournaldError(t *testing.T) {
 	cfg := NewConfigWithID("my_journald_input")
 	cfg.OutputIDs = []string{"output"}
@@ -43,6 +43,27 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "reuse_port",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.ReusePort = true
+					cfg.Listeners = 4
+					return cfg
+				}(),
+			},
+			{
+				Name:      "proxy_protocol",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.ProxyProtocol = true
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
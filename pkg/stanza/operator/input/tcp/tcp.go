@@ -73,6 +73,9 @@ type BaseConfig struct {
 	TLS              *configtls.TLSServerSetting `mapstructure:"tls,omitempty"`
 	AddAttributes    bool                        `mapstructure:"add_attributes,omitempty"`
 	OneLogPerPacket  bool                        `mapstructure:"one_log_per_packet,omitempty"`
+	ReusePort        bool                        `mapstructure:"reuse_port,omitempty"`
+	Listeners        int                         `mapstructure:"listeners,omitempty"`
+	ProxyProtocol    bool                        `mapstructure:"proxy_protocol,omitempty"`
 	Encoding         string                      `mapstructure:"encoding,omitempty"`
 	SplitConfig      split.Config                `mapstructure:"multiline,omitempty"`
 	TrimConfig       trim.Config                 `mapstructure:",squash"`
@@ -110,6 +113,18 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("failed to resolve listen_address: %w", err)
 	}
 
+	if c.ReusePort && !helper.ReusePortSupported {
+		return nil, fmt.Errorf("'reuse_port' is not supported on this platform")
+	}
+
+	if c.Listeners <= 0 {
+		c.Listeners = 1
+	}
+
+	if c.Listeners > 1 && !c.ReusePort {
+		return nil, fmt.Errorf("'listeners' greater than 1 requires 'reuse_port'")
+	}
+
 	enc, err := decode.LookupEncoding(c.Encoding)
 	if err != nil {
 		return nil, err
@@ -136,6 +151,9 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		address:         c.ListenAddress,
 		MaxLogSize:      int(c.MaxLogSize),
 		addAttributes:   c.AddAttributes,
+		reusePort:       c.ReusePort,
+		listenerCount:   c.Listeners,
+		proxyProtocol:   c.ProxyProtocol,
 		OneLogPerPacket: c.OneLogPerPacket,
 		encoding:        enc,
 		splitFunc:       splitFunc,
@@ -161,13 +179,18 @@ type Input struct {
 	address         string
 	MaxLogSize      int
 	addAttributes   bool
+	reusePort       bool
+	listenerCount   int
+	proxyProtocol   bool
 	OneLogPerPacket bool
 
-	listener net.Listener
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
-	tls      *tls.Config
-	backoff  backoff.Backoff
+	// listeners holds the sockets bound to the address, each accepting connections
+	// in its own goroutine. There are several only when reuse_port is set.
+	listeners []net.Listener
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	tls       *tls.Config
+	backoff   backoff.Backoff
 
 	encoding  encoding.Encoding
 	splitFunc bufio.SplitFunc
@@ -176,47 +199,65 @@ type Input struct {
 
 // Start will start listening for log entries over tcp.
 func (t *Input) Start(_ operator.Persister) error {
-	if err := t.configureListener(); err != nil {
+	if err := t.configureListeners(); err != nil {
 		return fmt.Errorf("failed to listen on interface: %w", err)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.cancel = cancel
-	t.goListen(ctx)
+	for _, listener := range t.listeners {
+		t.goListen(ctx, listener)
+	}
 	return nil
 }
 
-func (t *Input) configureListener() error {
-	if t.tls == nil {
-		listener, err := net.Listen("tcp", t.address)
+func (t *Input) configureListeners() error {
+	listenConfig, err := helper.NewListenConfig(t.reusePort)
+	if err != nil {
+		return err
+	}
+
+	if t.tls != nil {
+		t.tls.Time = time.Now
+		t.tls.Rand = rand.Reader
+	}
+
+	address := t.address
+	for i := 0; i < t.listenerCount; i++ {
+		listener, err := listenConfig.Listen(context.Background(), "tcp", address)
 		if err != nil {
+			t.closeListeners()
 			return fmt.Errorf("failed to configure tcp listener: %w", err)
 		}
-		t.listener = listener
-		return nil
+		t.listeners = append(t.listeners, t.wrapListener(listener))
+		// Bind the other sockets to the same port, in case a random one was requested.
+		address = listener.Addr().String()
 	}
+	return nil
+}
 
-	t.tls.Time = time.Now
-	t.tls.Rand = rand.Reader
+func (t *Input) wrapListener(listener net.Listener) net.Listener {
 
-	listener, err := tls.Listen("tcp", t.address, t.tls)
-	if err != nil {
-		return fmt.Errorf("failed to configure tls listener: %w", err)
+	// The PROXY protocol header is sent before the TLS handshake
+	if t.proxyProtocol {
+		listener = proxyListener{Listener: listener}
 	}
 
-	t.listener = listener
-	return nil
+	if t.tls == nil {
+		return listener
+	}
+	return tls.NewListener(listener, t.tls)
 }
 
-// goListenn will listen for tcp connections.
-func (t *Input) goListen(ctx context.Context) {
+// goListen will listen for tcp connections.
+func (t *Input) goListen(ctx context.Context, listener net.Listener) {
 	t.wg.Add(1)
 
 	go func() {
 		defer t.wg.Done()
 
 		for {
-			conn, err := t.listener.Accept()
+			conn, err := listener.Accept()
 			if err != nil {
 				select {
 				case <-ctx.Done():
@@ -316,6 +357,20 @@ func (t *Input) handleMessage(ctx context.Context, conn net.Conn, dec *decode.De
 			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
 			entry.AddAttribute("net.host.name", t.resolver.GetHostFromIP(ip))
 		}
+
+		// The address of the socket peer, when it is not the client, e.g. a load balancer
+		if t.proxyProtocol {
+			if addr, ok := socketPeerAddr(conn).(*net.TCPAddr); ok {
+				entry.AddAttribute("net.sock.peer.addr", addr.IP.String())
+				entry.AddAttribute("net.sock.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+			}
+		}
+
+		// The server name requested by the client, which can be used to route
+		// the entries of the virtual hosts sharing the listener
+		if tlsConn, ok := conn.(*tls.Conn); ok && tlsConn.ConnectionState().ServerName != "" {
+			entry.AddAttribute("tls.client.server_name", tlsConn.ConnectionState().ServerName)
+		}
 	}
 
 	t.Write(ctx, entry)
@@ -339,12 +394,7 @@ func (t *Input) Stop() error {
 		return nil
 	}
 	t.cancel()
-
-	if t.listener != nil {
-		if err := t.listener.Close(); err != nil {
-			t.Errorf("failed to close TCP connection: %s", err)
-		}
-	}
+	t.closeListeners()
 
 	t.wg.Wait()
 	if t.resolver != nil {
@@ -352,3 +402,24 @@ func (t *Input) Stop() error {
 	}
 	return nil
 }
+
+func (t *Input) closeListeners() {
+	for _, listener := range t.listeners {
+		if err := listener.Close(); err != nil {
+			t.Errorf("failed to close TCP connection: %s", err)
+		}
+	}
+	t.listeners = nil
+}
+
+// socketPeerAddr returns the address of the peer of the underlying socket, which is
+// not the one of the client when the connection starts with a PROXY protocol header.
+func socketPeerAddr(conn net.Conn) net.Addr {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	if pc, ok := conn.(*proxyConn); ok {
+		return pc.Conn.RemoteAddr()
+	}
+	return conn.RemoteAddr()
+}
@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -96,7 +98,7 @@ func tcpInputTest(input []byte, expected []string) func(t *testing.T) {
 			require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
 		}()
 
-		conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+		conn, err := net.Dial("tcp", tcpInput.listeners[0].Addr().String())
 		require.NoError(t, err)
 		defer conn.Close()
 
@@ -145,7 +147,7 @@ func tcpInputAttributesTest(input []byte, expected []string) func(t *testing.T)
 			require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
 		}()
 
-		conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+		conn, err := net.Dial("tcp", tcpInput.listeners[0].Addr().String())
 		require.NoError(t, err)
 		defer conn.Close()
 
@@ -231,7 +233,7 @@ func tlsInputTest(input []byte, expected []string) func(t *testing.T) {
 			require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
 		}()
 
-		conn, err := tls.Dial("tcp", tcpInput.listener.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		conn, err := tls.Dial("tcp", tcpInput.listeners[0].Addr().String(), &tls.Config{InsecureSkipVerify: true})
 		require.NoError(t, err)
 		defer conn.Close()
 
@@ -335,6 +337,16 @@ func TestBuild(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"listeners-without-reuse-port",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress: "10.0.0.1:9000",
+					Listeners:     2,
+				},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -343,6 +355,7 @@ func TestBuild(t *testing.T) {
 			cfg.ListenAddress = tc.inputBody.ListenAddress
 			cfg.MaxLogSize = tc.inputBody.MaxLogSize
 			cfg.TLS = tc.inputBody.TLS
+			cfg.Listeners = tc.inputBody.Listeners
 			_, err := cfg.Build(testutil.Logger(t))
 			if tc.expectErr {
 				require.Error(t, err)
@@ -368,6 +381,77 @@ func TestTLSTCPInput(t *testing.T) {
 	t.Run("CarriageReturn", tlsInputTest([]byte("message\r\n"), []string{"message"}))
 }
 
+func TestProxyProtocol(t *testing.T) {
+	for _, useTLS := range []bool{false, true} {
+		t.Run("TLS="+strconv.FormatBool(useTLS), func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.ListenAddress = ":0"
+			cfg.AddAttributes = true
+			cfg.ProxyProtocol = true
+			if useTLS {
+				dir := t.TempDir()
+				cfg.TLS = &configtls.TLSServerSetting{
+					TLSSetting: configtls.TLSSetting{
+						CertFile: filepath.Join(dir, "test.crt"),
+						KeyFile:  filepath.Join(dir, "test.key"),
+					},
+				}
+				require.NoError(t, os.WriteFile(cfg.TLS.CertFile, []byte(testTLSCertificate+"\n"), 0600))
+				require.NoError(t, os.WriteFile(cfg.TLS.KeyFile, []byte(testTLSPrivateKey+"\n"), 0600))
+			}
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			mockOutput := testutil.Operator{}
+			tcpInput := op.(*Input)
+			tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+			entryChan := make(chan *entry.Entry, 1)
+			mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				entryChan <- args.Get(1).(*entry.Entry)
+			}).Return(nil)
+
+			require.NoError(t, tcpInput.Start(testutil.NewUnscopedMockPersister()))
+			defer func() {
+				require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+			}()
+
+			var conn net.Conn
+			conn, err = net.Dial("tcp", tcpInput.listeners[0].Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			clientAddr := conn.LocalAddr().(*net.TCPAddr)
+			_, err = conn.Write([]byte("PROXY TCP4 192.168.0.1 10.0.0.1 12345 514\r\n"))
+			require.NoError(t, err)
+			if useTLS {
+				conn = tls.Client(conn, &tls.Config{InsecureSkipVerify: true, ServerName: "logs.example.com"}) // #nosec G402
+			}
+			_, err = conn.Write([]byte("message\n"))
+			require.NoError(t, err)
+
+			select {
+			case e := <-entryChan:
+				require.Equal(t, "message", e.Body)
+				require.Equal(t, "192.168.0.1", e.Attributes["net.peer.ip"])
+				require.Equal(t, "12345", e.Attributes["net.peer.port"])
+				require.Equal(t, "10.0.0.1", e.Attributes["net.host.ip"])
+				require.Equal(t, "514", e.Attributes["net.host.port"])
+				require.Equal(t, clientAddr.IP.String(), e.Attributes["net.sock.peer.addr"])
+				require.Equal(t, strconv.Itoa(clientAddr.Port), e.Attributes["net.sock.peer.port"])
+				if useTLS {
+					require.Equal(t, "logs.example.com", e.Attributes["tls.client.server_name"])
+				} else {
+					require.NotContains(t, e.Attributes, "tls.client.server_name")
+				}
+			case <-time.After(time.Second):
+				require.FailNow(t, "Timed out waiting for message to be written")
+			}
+		})
+	}
+}
+
 func TestFailToBind(t *testing.T) {
 	ip := "localhost"
 	port := 0
@@ -411,6 +495,83 @@ func TestFailToBind(t *testing.T) {
 	require.Error(t, err, "expected second tcp operator to fail to start")
 }
 
+func TestReusePort(t *testing.T) {
+	if !helper.ReusePortSupported {
+		t.Skip("reuse_port is not supported on this platform")
+	}
+
+	var startTCP = func(address string) *Input {
+		cfg := NewConfigWithID("test_id")
+		cfg.ListenAddress = address
+		cfg.ReusePort = true
+		op, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		tcpInput := op.(*Input)
+		tcpInput.InputOperator.OutputOperators = []operator.Operator{&testutil.Operator{}}
+		require.NoError(t, tcpInput.Start(testutil.NewUnscopedMockPersister()))
+		return tcpInput
+	}
+
+	first := startTCP("127.0.0.1:0")
+	defer func() {
+		require.NoError(t, first.Stop(), "expected to stop tcp input operator without error")
+	}()
+	second := startTCP(first.listeners[0].Addr().String())
+	defer func() {
+		require.NoError(t, second.Stop(), "expected to stop tcp input operator without error")
+	}()
+	require.Equal(t, first.listeners[0].Addr().String(), second.listeners[0].Addr().String())
+}
+
+func TestListeners(t *testing.T) {
+	if !helper.ReusePortSupported {
+		t.Skip("reuse_port is not supported on this platform")
+	}
+
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.ReusePort = true
+	cfg.Listeners = 3
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.Operator{}
+	tcpInput := op.(*Input)
+	tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	entryChan := make(chan *entry.Entry, 10)
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entryChan <- args.Get(1).(*entry.Entry)
+	}).Return(nil)
+
+	require.NoError(t, tcpInput.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+	}()
+
+	require.Len(t, tcpInput.listeners, 3)
+	address := tcpInput.listeners[0].Addr().String()
+	for _, listener := range tcpInput.listeners {
+		require.Equal(t, address, listener.Addr().String())
+	}
+
+	for i := 0; i < 10; i++ {
+		conn, err := net.Dial("tcp", address)
+		require.NoError(t, err)
+		_, err = conn.Write([]byte("message\n"))
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case e := <-entryChan:
+			require.Equal(t, "message", e.Body)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for message to be written")
+		}
+	}
+}
+
 func BenchmarkTCPInput(b *testing.B) {
 	cfg := NewConfigWithID("test_id")
 	cfg.ListenAddress = ":0"
@@ -427,7 +588,7 @@ func BenchmarkTCPInput(b *testing.B) {
 
 	done := make(chan struct{})
 	go func() {
-		conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+		conn, err := net.Dial("tcp", tcpInput.listeners[0].Addr().String())
 		require.NoError(b, err)
 		defer func() {
 			err := tcpInput.Stop()
@@ -51,6 +51,31 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "reuse_port",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.ReusePort = true
+					cfg.AsyncConfig = &AsyncConfig{
+						Readers:        4,
+						Processors:     4,
+						MaxQueueLength: 100,
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name:      "proxy_protocol",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.ProxyProtocol = true
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
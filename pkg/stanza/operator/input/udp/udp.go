@@ -73,6 +73,8 @@ type BaseConfig struct {
 	ListenAddress   string       `mapstructure:"listen_address,omitempty"`
 	OneLogPerPacket bool         `mapstructure:"one_log_per_packet,omitempty"`
 	AddAttributes   bool         `mapstructure:"add_attributes,omitempty"`
+	ReusePort       bool         `mapstructure:"reuse_port,omitempty"`
+	ProxyProtocol   bool         `mapstructure:"proxy_protocol,omitempty"`
 	Encoding        string       `mapstructure:"encoding,omitempty"`
 	SplitConfig     split.Config `mapstructure:"multiline,omitempty"`
 	TrimConfig      trim.Config  `mapstructure:",squash"`
@@ -107,6 +109,10 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	}
 	splitFunc = trim.WithFunc(splitFunc, c.TrimConfig.Func())
 
+	if c.ReusePort && !helper.ReusePortSupported {
+		return nil, fmt.Errorf("'reuse_port' is not supported on this platform")
+	}
+
 	var resolver *helper.IPResolver
 	if c.AddAttributes {
 		resolver = helper.NewIPResolver()
@@ -127,8 +133,9 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	udpInput := &Input{
 		InputOperator:   inputOperator,
 		address:         address,
-		buffer:          make([]byte, MaxUDPSize),
 		addAttributes:   c.AddAttributes,
+		reusePort:       c.ReusePort,
+		proxyProtocol:   c.ProxyProtocol,
 		encoding:        enc,
 		splitFunc:       splitFunc,
 		resolver:        resolver,
@@ -144,16 +151,19 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 // Input is an operator that listens to a socket for log entries.
 type Input struct {
-	buffer []byte
 	helper.InputOperator
 	address         *net.UDPAddr
 	addAttributes   bool
+	reusePort       bool
+	proxyProtocol   bool
 	OneLogPerPacket bool
 	AsyncConfig     *AsyncConfig
 
-	connection net.PacketConn
-	cancel     context.CancelFunc
-	wg         sync.WaitGroup
+	// connections holds one socket per async reader when reuse_port is set,
+	// a single socket shared by all readers otherwise.
+	connections []net.PacketConn
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
 
 	encoding  encoding.Encoding
 	splitFunc bufio.SplitFunc
@@ -173,11 +183,26 @@ func (u *Input) Start(_ operator.Persister) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	u.cancel = cancel
 
-	conn, err := net.ListenUDP("udp", u.address)
+	listenConfig, err := helper.NewListenConfig(u.reusePort)
 	if err != nil {
-		return fmt.Errorf("failed to open connection: %w", err)
+		return err
+	}
+
+	listeners := 1
+	if u.reusePort && u.AsyncConfig != nil {
+		listeners = u.AsyncConfig.Readers
+	}
+	address := u.address.String()
+	for i := 0; i < listeners; i++ {
+		conn, err := listenConfig.ListenPacket(ctx, "udp", address)
+		if err != nil {
+			u.closeConnections()
+			return fmt.Errorf("failed to open connection: %w", err)
+		}
+		u.connections = append(u.connections, conn)
+		// Bind the other sockets to the same port, in case a random one was requested.
+		address = conn.LocalAddr().String()
 	}
-	u.connection = conn
 
 	u.goHandleMessages(ctx)
 	return nil
@@ -187,13 +212,13 @@ func (u *Input) Start(_ operator.Persister) error {
 func (u *Input) goHandleMessages(ctx context.Context) {
 	if u.AsyncConfig == nil {
 		u.wg.Add(1)
-		go u.readAndProcessMessages(ctx)
+		go u.readAndProcessMessages(ctx, u.connections[0])
 		return
 	}
 
 	for i := 0; i < u.AsyncConfig.Readers; i++ {
 		u.wg.Add(1)
-		go u.readMessagesAsync(ctx)
+		go u.readMessagesAsync(ctx, u.connections[i%len(u.connections)])
 	}
 
 	for i := 0; i < u.AsyncConfig.Processors; i++ {
@@ -202,13 +227,14 @@ func (u *Input) goHandleMessages(ctx context.Context) {
 	}
 }
 
-func (u *Input) readAndProcessMessages(ctx context.Context) {
+func (u *Input) readAndProcessMessages(ctx context.Context, conn net.PacketConn) {
 	defer u.wg.Done()
 
 	dec := decode.New(u.encoding)
+	readBuf := make([]byte, MaxUDPSize)
 	buf := make([]byte, 0, MaxUDPSize)
 	for {
-		message, remoteAddr, err := u.readMessage()
+		message, remoteAddr, err := readMessage(conn, readBuf)
 		if err != nil {
 			select {
 			case <-ctx.Done():
@@ -224,9 +250,25 @@ func (u *Input) readAndProcessMessages(ctx context.Context) {
 }
 
 func (u *Input) processMessage(ctx context.Context, message []byte, remoteAddr net.Addr, dec *decode.Decoder, buf []byte) {
+	// The address of the socket peer, when it is not the client, e.g. a load balancer
+	var sockAddr net.Addr
+	if u.proxyProtocol {
+		// Only v2 headers can be sent over UDP, each datagram starting with one
+		header, n, err := helper.ParseProxyHeaderV2(message)
+		if err != nil {
+			u.Errorw("Failed to parse PROXY protocol header", zap.Error(err), zap.Stringer("remote_addr", remoteAddr))
+			return
+		}
+		message = message[n:]
+		sockAddr = remoteAddr
+		if header.Source != nil {
+			remoteAddr = header.Source
+		}
+	}
+
 	if u.OneLogPerPacket {
 		log := truncateMaxLog(message)
-		u.handleMessage(ctx, remoteAddr, dec, log)
+		u.handleMessage(ctx, remoteAddr, sockAddr, dec, log)
 		return
 	}
 
@@ -236,18 +278,19 @@ func (u *Input) processMessage(ctx context.Context, message []byte, remoteAddr n
 	scanner.Split(u.splitFunc)
 
 	for scanner.Scan() {
-		u.handleMessage(ctx, remoteAddr, dec, scanner.Bytes())
+		u.handleMessage(ctx, remoteAddr, sockAddr, dec, scanner.Bytes())
 	}
 	if err := scanner.Err(); err != nil {
 		u.Errorw("Scanner error", zap.Error(err))
 	}
 }
 
-func (u *Input) readMessagesAsync(ctx context.Context) {
+func (u *Input) readMessagesAsync(ctx context.Context, conn net.PacketConn) {
 	defer u.wg.Done()
 
+	readBuf := make([]byte, MaxUDPSize)
 	for {
-		message, remoteAddr, err := u.readMessage()
+		message, remoteAddr, err := readMessage(conn, readBuf)
 		if err != nil {
 			select {
 			case <-ctx.Done():
@@ -258,8 +301,9 @@ func (u *Input) readMessagesAsync(ctx context.Context) {
 			break
 		}
 
+		// The read buffer is reused for the next message.
 		messageAndAddr := messageAndAddress{
-			Message:    message,
+			Message:    append([]byte(nil), message...),
 			RemoteAddr: remoteAddr,
 		}
 
@@ -297,7 +341,7 @@ func truncateMaxLog(data []byte) (token []byte) {
 	return data
 }
 
-func (u *Input) handleMessage(ctx context.Context, remoteAddr net.Addr, dec *decode.Decoder, log []byte) {
+func (u *Input) handleMessage(ctx context.Context, remoteAddr, sockAddr net.Addr, dec *decode.Decoder, log []byte) {
 	decoded, err := dec.Decode(log)
 	if err != nil {
 		u.Errorw("Failed to decode data", zap.Error(err))
@@ -312,7 +356,7 @@ func (u *Input) handleMessage(ctx context.Context, remoteAddr net.Addr, dec *dec
 
 	if u.addAttributes {
 		entry.AddAttribute("net.transport", "IP.UDP")
-		if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
+		if addr, ok := u.connections[0].LocalAddr().(*net.UDPAddr); ok {
 			ip := addr.IP.String()
 			entry.AddAttribute("net.host.ip", addr.IP.String())
 			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
@@ -325,23 +369,28 @@ func (u *Input) handleMessage(ctx context.Context, remoteAddr net.Addr, dec *dec
 			entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
 			entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIP(ip))
 		}
+
+		if addr, ok := sockAddr.(*net.UDPAddr); ok {
+			entry.AddAttribute("net.sock.peer.addr", addr.IP.String())
+			entry.AddAttribute("net.sock.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+		}
 	}
 
 	u.Write(ctx, entry)
 }
 
-// readMessage will read log messages from the connection.
-func (u *Input) readMessage() ([]byte, net.Addr, error) {
-	n, addr, err := u.connection.ReadFrom(u.buffer)
+// readMessage will read log messages from the connection into buf.
+func readMessage(conn net.PacketConn, buf []byte) ([]byte, net.Addr, error) {
+	n, addr, err := conn.ReadFrom(buf)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Remove trailing characters and NULs
-	for ; (n > 0) && (u.buffer[n-1] < 32); n-- { // nolint
+	for ; (n > 0) && (buf[n-1] < 32); n-- { // nolint
 	}
 
-	return u.buffer[:n], addr, nil
+	return buf[:n], addr, nil
 }
 
 // Stop will stop listening for udp messages.
@@ -355,11 +404,7 @@ func (u *Input) Stop() error {
 			return
 		}
 		u.cancel()
-		if u.connection != nil {
-			if err := u.connection.Close(); err != nil {
-				u.Errorf("failed to close UDP connection: %s", err)
-			}
-		}
+		u.closeConnections()
 		u.wg.Wait()
 		if u.resolver != nil {
 			u.resolver.Stop()
@@ -367,3 +412,12 @@ func (u *Input) Stop() error {
 	})
 	return nil
 }
+
+func (u *Input) closeConnections() {
+	for _, conn := range u.connections {
+		if err := conn.Close(); err != nil {
+			u.Errorf("failed to close UDP connection: %s", err)
+		}
+	}
+	u.connections = nil
+}
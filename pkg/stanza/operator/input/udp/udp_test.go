@@ -15,6 +15,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -40,7 +41,7 @@ func udpInputTest(input []byte, expected []string, cfg *Config) func(t *testing.
 			require.NoError(t, udpInput.Stop(), "expected to stop udp input operator without error")
 		}()
 
-		conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+		conn, err := net.Dial("udp", udpInput.connections[0].LocalAddr().String())
 		require.NoError(t, err)
 		defer conn.Close()
 
@@ -91,7 +92,7 @@ func udpInputAttributesTest(input []byte, expected []string) func(t *testing.T)
 			require.NoError(t, udpInput.Stop(), "expected to stop udp input operator without error")
 		}()
 
-		conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+		conn, err := net.Dial("udp", udpInput.connections[0].LocalAddr().String())
 		require.NoError(t, err)
 		defer conn.Close()
 
@@ -105,7 +106,7 @@ func udpInputAttributesTest(input []byte, expected []string) func(t *testing.T)
 					"net.transport": "IP.UDP",
 				}
 				// LocalAddr for udpInput.connection is a server address
-				if addr, ok := udpInput.connection.LocalAddr().(*net.UDPAddr); ok {
+				if addr, ok := udpInput.connections[0].LocalAddr().(*net.UDPAddr); ok {
 					ip := addr.IP.String()
 					expectedAttributes["net.host.ip"] = addr.IP.String()
 					expectedAttributes["net.host.port"] = strconv.FormatInt(int64(addr.Port), 10)
@@ -151,6 +152,33 @@ func TestInput(t *testing.T) {
 	t.Run("SimpleAsync", udpInputTest([]byte("message1"), []string{"message1"}, cfg))
 }
 
+func TestInputReusePort(t *testing.T) {
+	if !helper.ReusePortSupported {
+		t.Skip("reuse_port is not supported on this platform")
+	}
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.ReusePort = true
+	cfg.AsyncConfig = &AsyncConfig{
+		Readers:        3,
+		Processors:     2,
+		MaxQueueLength: 100,
+	}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	udpInput := op.(*Input)
+	udpInput.InputOperator.OutputOperators = []operator.Operator{&testutil.Operator{}}
+	require.NoError(t, udpInput.Start(testutil.NewUnscopedMockPersister()))
+	require.Len(t, udpInput.connections, 3)
+	for _, conn := range udpInput.connections {
+		require.Equal(t, udpInput.connections[0].LocalAddr().String(), conn.LocalAddr().String())
+	}
+	require.NoError(t, udpInput.Stop())
+
+	t.Run("Simple", udpInputTest([]byte("message1"), []string{"message1"}, cfg))
+}
+
 func TestInputAttributes(t *testing.T) {
 	t.Run("Simple", udpInputAttributesTest([]byte("message1"), []string{"message1"}))
 	t.Run("TrailingNewlines", udpInputAttributesTest([]byte("message1\n"), []string{"message1"}))
@@ -158,6 +186,62 @@ func TestInputAttributes(t *testing.T) {
 	t.Run("NewlineInMessage", udpInputAttributesTest([]byte("message1\nmessage2\n"), []string{"message1\nmessage2"}))
 }
 
+func TestInputProxyProtocol(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.AddAttributes = true
+	cfg.ProxyProtocol = true
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	udpInput := op.(*Input)
+	mockOutput := testutil.Operator{}
+	udpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	entryChan := make(chan *entry.Entry, 1)
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entryChan <- args.Get(1).(*entry.Entry)
+	}).Return(nil)
+
+	require.NoError(t, udpInput.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, udpInput.Stop(), "expected to stop udp input operator without error")
+	}()
+
+	conn, err := net.Dial("udp", udpInput.connections[0].LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Datagrams without header are dropped
+	_, err = conn.Write([]byte("message0"))
+	require.NoError(t, err)
+
+	header := []byte("\r\n\r\n\x00\r\nQUIT\n")
+	header = append(header,
+		0x21,    // v2, PROXY command
+		0x12,    // AF_INET, SOCK_DGRAM
+		0x0, 12, // addresses length
+		192, 168, 0, 1, // source IP
+		10, 0, 0, 1, // destination IP
+		0x30, 0x39, // source port 12345
+		0x02, 0x02, // destination port 514
+	)
+	_, err = conn.Write(append(header, "message1"...))
+	require.NoError(t, err)
+
+	select {
+	case e := <-entryChan:
+		require.Equal(t, "message1", e.Body)
+		require.Equal(t, "192.168.0.1", e.Attributes["net.peer.ip"])
+		require.Equal(t, "12345", e.Attributes["net.peer.port"])
+		clientAddr := conn.LocalAddr().(*net.UDPAddr)
+		require.Equal(t, clientAddr.IP.String(), e.Attributes["net.sock.peer.addr"])
+		require.Equal(t, strconv.Itoa(clientAddr.Port), e.Attributes["net.sock.peer.port"])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for message to be written")
+	}
+}
+
 func TestFailToBind(t *testing.T) {
 	ip := "localhost"
 	port := 0
@@ -223,7 +307,7 @@ func BenchmarkUDPInput(b *testing.B) {
 
 	done := make(chan struct{})
 	go func() {
-		conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+		conn, err := net.Dial("udp", udpInput.connections[0].LocalAddr().String())
 		require.NoError(b, err)
 		defer func() {
 			require.NoError(b, udpInput.Stop())
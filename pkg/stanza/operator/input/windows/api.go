@@ -24,6 +24,11 @@ var (
 	updateBookmarkProc        SyscallProc = api.NewProc("EvtUpdateBookmark")
 	openPublisherMetadataProc SyscallProc = api.NewProc("EvtOpenPublisherMetadata")
 	formatMessageProc         SyscallProc = api.NewProc("EvtFormatMessage")
+	openSessionProc           SyscallProc = api.NewProc("EvtOpenSession")
+
+	kernel = windows.NewLazySystemDLL("kernel32.dll")
+
+	localeNameToLCIDProc SyscallProc = kernel.NewProc("LocaleNameToLCID")
 )
 
 // SyscallProc is a syscall procedure.
@@ -53,6 +58,20 @@ const (
 	ErrorInvalidOperation syscall.Errno = 4317
 )
 
+const (
+	// EvtRPCLogin is a login class to open a session on a remote computer using RPC.
+	EvtRPCLogin uint32 = 1
+)
+
+// EvtRPCLoginInfo holds the credentials used to open a session on a remote computer (https://docs.microsoft.com/en-us/windows/win32/api/winevt/ns-winevt-evt_rpc_login)
+type EvtRPCLoginInfo struct {
+	Server   *uint16
+	User     *uint16
+	Domain   *uint16
+	Password *uint16
+	Flags    uint32
+}
+
 const (
 	// EvtFormatMessageXML is flag that formats a message as an XML string that contains all event details and message strings.
 	EvtFormatMessageXML uint32 = 9
@@ -147,3 +166,23 @@ func evtFormatMessage(publisherMetadata uintptr, event uintptr, messageID uint32
 
 	return bufferUsed, nil
 }
+
+// evtOpenSession is the direct syscall implementation of EvtOpenSession (https://docs.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtopensession)
+func evtOpenSession(loginClass uint32, login *EvtRPCLoginInfo, timeout uint32, flags uint32) (uintptr, error) {
+	handle, _, err := openSessionProc.Call(uintptr(loginClass), uintptr(unsafe.Pointer(login)), uintptr(timeout), uintptr(flags))
+	if err != ErrorSuccess {
+		return 0, err
+	}
+
+	return handle, nil
+}
+
+// localeNameToLCID is the direct syscall implementation of LocaleNameToLCID (https://docs.microsoft.com/en-us/windows/win32/api/winnls/nf-winnls-localenametolcid)
+func localeNameToLCID(name *uint16, flags uint32) (uint32, error) {
+	lcid, _, err := localeNameToLCIDProc.Call(uintptr(unsafe.Pointer(name)), uintptr(flags))
+	if lcid == 0 {
+		return 0, err
+	}
+
+	return uint32(lcid), nil
+}
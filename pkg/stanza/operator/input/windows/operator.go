@@ -10,8 +10,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"syscall"
 	"time"
 
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -43,11 +45,22 @@ func NewConfigWithID(operatorID string) *Config {
 type Config struct {
 	helper.InputConfig `mapstructure:",squash"`
 	Channel            string        `mapstructure:"channel"`
+	Query              string        `mapstructure:"query,omitempty"`
 	MaxReads           int           `mapstructure:"max_reads,omitempty"`
 	StartAt            string        `mapstructure:"start_at,omitempty"`
 	PollInterval       time.Duration `mapstructure:"poll_interval,omitempty"`
 	Raw                bool          `mapstructure:"raw,omitempty"`
 	ExcludeProviders   []string      `mapstructure:"exclude_providers,omitempty"`
+	Locale             string        `mapstructure:"locale,omitempty"`
+	Remote             RemoteConfig  `mapstructure:"remote,omitempty"`
+}
+
+// RemoteConfig is the configuration of the remote computer events are read from.
+type RemoteConfig struct {
+	Server   string              `mapstructure:"server"`
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	Domain   string              `mapstructure:"domain"`
 }
 
 // Build will build a windows event log operator.
@@ -57,8 +70,34 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
-	if c.Channel == "" {
-		return nil, fmt.Errorf("missing required `channel` field")
+	if c.Channel == "" && c.Query == "" {
+		return nil, fmt.Errorf("either `channel` or `query` must be set")
+	}
+
+	if c.Channel != "" && c.Query != "" {
+		return nil, fmt.Errorf("only one of `channel` or `query` can be set")
+	}
+
+	if c.Remote.Server == "" && (c.Remote.Username != "" || c.Remote.Password != "" || c.Remote.Domain != "") {
+		return nil, fmt.Errorf("the `remote.server` field is required with remote credentials")
+	}
+
+	var locale uint32
+	if c.Locale != "" {
+		name, err := syscall.UTF16PtrFromString(c.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the `locale` field to utf16: %w", err)
+		}
+		if locale, err = localeNameToLCID(name, 0); err != nil {
+			return nil, fmt.Errorf("invalid `locale` field %q: %w", c.Locale, err)
+		}
+	}
+
+	// Bookmarks are stored by channel, or by query for structured queries,
+	// so that a bookmark is not reused when the query changes.
+	bookmarkKey := c.Channel
+	if bookmarkKey == "" {
+		bookmarkKey = c.Query
 	}
 
 	if c.MaxReads < 1 {
@@ -73,6 +112,10 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		InputOperator:    inputOperator,
 		buffer:           NewBuffer(),
 		channel:          c.Channel,
+		query:            c.Query,
+		bookmarkKey:      bookmarkKey,
+		remote:           c.Remote,
+		locale:           locale,
 		maxReads:         c.MaxReads,
 		startAt:          c.StartAt,
 		pollInterval:     c.PollInterval,
@@ -85,9 +128,14 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 type Input struct {
 	helper.InputOperator
 	bookmark         Bookmark
+	session          Session
 	subscription     Subscription
 	buffer           Buffer
 	channel          string
+	query            string
+	bookmarkKey      string
+	remote           RemoteConfig
+	locale           uint32
 	maxReads         int
 	startAt          string
 	raw              bool
@@ -106,31 +154,51 @@ func (e *Input) Start(persister operator.Persister) error {
 
 	e.persister = persister
 
+	e.session = NewSession()
+	if e.remote.Server != "" {
+		if err := e.session.Open(e.remote); err != nil {
+			return fmt.Errorf("failed to open session: %w", err)
+		}
+	}
+
 	e.bookmark = NewBookmark()
 	offsetXML, err := e.getBookmarkOffset(ctx)
 	if err != nil {
 		e.Errorf("Failed to open bookmark, continuing without previous bookmark: %s", err)
-		e.persister.Delete(ctx, e.channel)
+		e.persister.Delete(ctx, e.bookmarkKey)
 	}
 
 	if offsetXML != "" {
 		if err := e.bookmark.Open(offsetXML); err != nil {
+			e.abortStart()
 			return fmt.Errorf("failed to open bookmark: %w", err)
 		}
 	}
 
 	e.subscription = NewSubscription()
-	if err := e.subscription.Open(e.channel, e.startAt, e.bookmark); err != nil {
+	if err := e.subscription.Open(e.session.handle, e.channel, e.query, e.startAt, e.bookmark); err != nil {
+		e.abortStart()
 		return fmt.Errorf("failed to open subscription: %w", err)
 	}
 
-	e.publisherCache = newPublisherCache()
+	e.publisherCache = newPublisherCache(e.session.handle, e.locale)
 
 	e.wg.Add(1)
 	go e.readOnInterval(ctx)
 	return nil
 }
 
+// abortStart releases the handles opened by a Start that failed part way.
+func (e *Input) abortStart() {
+	e.cancel()
+	if err := e.bookmark.Close(); err != nil {
+		e.Errorf("Failed to close bookmark: %s", err)
+	}
+	if err := e.session.Close(); err != nil {
+		e.Errorf("Failed to close session: %s", err)
+	}
+}
+
 // Stop will stop reading events from a subscription.
 func (e *Input) Stop() error {
 	e.cancel()
@@ -148,6 +216,10 @@ func (e *Input) Stop() error {
 		return fmt.Errorf("failed to close publishers: %w", err)
 	}
 
+	if err := e.session.Close(); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+
 	return nil
 }
 
@@ -287,7 +359,7 @@ func (e *Input) sendEventRaw(ctx context.Context, eventRaw EventRaw) {
 
 // getBookmarkXML will get the bookmark xml from the offsets database.
 func (e *Input) getBookmarkOffset(ctx context.Context) (string, error) {
-	bytes, err := e.persister.Get(ctx, e.channel)
+	bytes, err := e.persister.Get(ctx, e.bookmarkKey)
 	return string(bytes), err
 }
 
@@ -304,7 +376,7 @@ func (e *Input) updateBookmarkOffset(ctx context.Context, event Event) {
 		return
 	}
 
-	if err := e.persister.Set(ctx, e.channel, []byte(bookmarkXML)); err != nil {
+	if err := e.persister.Set(ctx, e.bookmarkKey, []byte(bookmarkXML)); err != nil {
 		e.Errorf("failed to set offsets: %s", err)
 		return
 	}
@@ -16,8 +16,11 @@ type Publisher struct {
 	handle uintptr
 }
 
-// Open will open the publisher handle using the supplied provider.
-func (p *Publisher) Open(provider string) error {
+// Open will open the publisher handle using the supplied provider. The message
+// strings are rendered in the language of locale, or in the language of the
+// calling thread if locale is 0. A zero sessionHandle opens the publisher of
+// the local computer.
+func (p *Publisher) Open(sessionHandle uintptr, provider string, locale uint32) error {
 	if p.handle != 0 {
 		return fmt.Errorf("publisher handle is already open")
 	}
@@ -27,7 +30,7 @@ func (p *Publisher) Open(provider string) error {
 		return fmt.Errorf("failed to convert the provider name %q to utf16: %w", provider, err)
 	}
 
-	handle, err := evtOpenPublisherMetadata(0, utf16, nil, 0, 0)
+	handle, err := evtOpenPublisherMetadata(sessionHandle, utf16, nil, locale, 0)
 	if err != nil {
 		return fmt.Errorf("failed to open the metadata for the %q provider: %w", provider, err)
 	}
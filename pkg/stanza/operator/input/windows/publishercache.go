@@ -11,12 +11,16 @@ import (
 )
 
 type publisherCache struct {
-	cache map[string]Publisher
+	cache         map[string]Publisher
+	sessionHandle uintptr
+	locale        uint32
 }
 
-func newPublisherCache() publisherCache {
+func newPublisherCache(sessionHandle uintptr, locale uint32) publisherCache {
 	return publisherCache{
-		cache: make(map[string]Publisher),
+		cache:         make(map[string]Publisher),
+		sessionHandle: sessionHandle,
+		locale:        locale,
 	}
 }
 
@@ -27,7 +31,7 @@ func (c *publisherCache) get(provider string) (publisher Publisher, openPublishe
 	}
 
 	publisher = NewPublisher()
-	err := publisher.Open(provider)
+	err := publisher.Open(c.sessionHandle, provider, c.locale)
 
 	// Always store the publisher even if there was an error opening it.
 	c.cache[provider] = publisher
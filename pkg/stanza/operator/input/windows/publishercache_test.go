@@ -13,7 +13,7 @@ import (
 )
 
 func TestGetValidPublisher(t *testing.T) {
-	publisherCache := newPublisherCache()
+	publisherCache := newPublisherCache(0, 0)
 	defer publisherCache.evictAll()
 
 	// Provider "Application" exists in all Windows versions.
@@ -28,7 +28,7 @@ func TestGetValidPublisher(t *testing.T) {
 }
 
 func TestGetInvalidPublisher(t *testing.T) {
-	publisherCache := newPublisherCache()
+	publisherCache := newPublisherCache(0, 0)
 	defer publisherCache.evictAll()
 
 	// Provider "InvalidProvider" does not exist in any Windows version.
@@ -43,7 +43,7 @@ func TestGetInvalidPublisher(t *testing.T) {
 }
 
 func TestValidAndInvalidPublishers(t *testing.T) {
-	publisherCache := newPublisherCache()
+	publisherCache := newPublisherCache(0, 0)
 	defer publisherCache.evictAll()
 
 	// Provider "Application" exists in all Windows versions.
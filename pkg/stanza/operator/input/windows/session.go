@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Session is a session to the event log service of a remote computer.
+type Session struct {
+	handle uintptr
+}
+
+// Open will open a session to the remote computer configured in remote.
+func (s *Session) Open(remote RemoteConfig) error {
+	if s.handle != 0 {
+		return fmt.Errorf("session handle is already open")
+	}
+
+	login := EvtRPCLoginInfo{}
+	for _, field := range []struct {
+		name  string
+		value string
+		ptr   **uint16
+	}{
+		{"server", remote.Server, &login.Server},
+		{"username", remote.Username, &login.User},
+		{"domain", remote.Domain, &login.Domain},
+		{"password", string(remote.Password), &login.Password},
+	} {
+		if field.value == "" {
+			continue
+		}
+		ptr, err := syscall.UTF16PtrFromString(field.value)
+		if err != nil {
+			return fmt.Errorf("failed to convert the %s to utf16: %w", field.name, err)
+		}
+		*field.ptr = ptr
+	}
+
+	handle, err := evtOpenSession(EvtRPCLogin, &login, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open a session to %s: %w", remote.Server, err)
+	}
+
+	s.handle = handle
+	return nil
+}
+
+// Close will close the session.
+func (s *Session) Close() error {
+	if s.handle == 0 {
+		return nil
+	}
+
+	if err := evtClose(s.handle); err != nil {
+		return fmt.Errorf("failed to close session handle: %w", err)
+	}
+
+	s.handle = 0
+	return nil
+}
+
+// NewSession will create a new session with an empty handle. An empty handle
+// refers to the local computer.
+func NewSession() Session {
+	return Session{
+		handle: 0,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionOpenPreexisting(t *testing.T) {
+	session := Session{handle: 5}
+	err := session.Open(RemoteConfig{Server: "remote-host"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "session handle is already open")
+	require.Equal(t, uintptr(5), session.handle)
+}
+
+func TestSessionOpenInvalidUTF8(t *testing.T) {
+	session := NewSession()
+	err := session.Open(RemoteConfig{Server: "remote-host", Password: "\u0000"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to convert the password to utf16: invalid argument")
+	require.Equal(t, uintptr(0), session.handle)
+}
+
+func TestSessionOpenSyscallFailure(t *testing.T) {
+	session := NewSession()
+	defer mockWithDeferredRestore(&openSessionProc, SimpleMockProc(0, 0, ErrorNotSupported))()
+	err := session.Open(RemoteConfig{Server: "remote-host"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open a session to remote-host: The request is not supported.")
+	require.Equal(t, uintptr(0), session.handle)
+}
+
+func TestSessionOpenSuccess(t *testing.T) {
+	session := NewSession()
+	defer mockWithDeferredRestore(&openSessionProc, SimpleMockProc(5, 0, ErrorSuccess))()
+	err := session.Open(RemoteConfig{Server: "remote-host", Username: "user", Password: "password", Domain: "domain"})
+	require.NoError(t, err)
+	require.Equal(t, uintptr(5), session.handle)
+}
+
+func TestSessionCloseWhenAlreadyClosed(t *testing.T) {
+	session := NewSession()
+	err := session.Close()
+	require.NoError(t, err)
+}
+
+func TestSessionCloseSyscallFailure(t *testing.T) {
+	session := Session{handle: 5}
+	defer mockWithDeferredRestore(&closeProc, SimpleMockProc(0, 0, ErrorNotSupported))()
+	err := session.Close()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to close session handle")
+	require.Equal(t, uintptr(5), session.handle)
+}
+
+func TestSessionCloseSuccess(t *testing.T) {
+	session := Session{handle: 5}
+	defer mockWithDeferredRestore(&closeProc, SimpleMockProc(1, 0, ErrorSuccess))()
+	err := session.Close()
+	require.NoError(t, err)
+	require.Equal(t, uintptr(0), session.handle)
+}
@@ -19,8 +19,10 @@ type Subscription struct {
 	handle uintptr
 }
 
-// Open will open the subscription handle.
-func (s *Subscription) Open(channel string, startAt string, bookmark Bookmark) error {
+// Open will open the subscription handle. The events are selected by channel, or
+// by the structured XML query if channel is empty. A zero sessionHandle subscribes
+// to the events of the local computer.
+func (s *Subscription) Open(sessionHandle uintptr, channel string, query string, startAt string, bookmark Bookmark) error {
 	if s.handle != 0 {
 		return fmt.Errorf("subscription handle is already open")
 	}
@@ -31,14 +33,25 @@ func (s *Subscription) Open(channel string, startAt string, bookmark Bookmark) e
 	}
 	defer windows.CloseHandle(signalEvent)
 
-	channelPtr, err := syscall.UTF16PtrFromString(channel)
-	if err != nil {
-		return fmt.Errorf("failed to convert channel to utf16: %w", err)
+	var channelPtr, queryPtr *uint16
+	if channel != "" {
+		channelPtr, err = syscall.UTF16PtrFromString(channel)
+		if err != nil {
+			return fmt.Errorf("failed to convert channel to utf16: %w", err)
+		}
+	} else {
+		queryPtr, err = syscall.UTF16PtrFromString(query)
+		if err != nil {
+			return fmt.Errorf("failed to convert query to utf16: %w", err)
+		}
 	}
 
 	flags := s.createFlags(startAt, bookmark)
-	subscriptionHandle, err := evtSubscribe(0, signalEvent, channelPtr, nil, bookmark.handle, 0, 0, flags)
+	subscriptionHandle, err := evtSubscribe(sessionHandle, signalEvent, channelPtr, queryPtr, bookmark.handle, 0, 0, flags)
 	if err != nil {
+		if channel == "" {
+			return fmt.Errorf("failed to subscribe to query: %w", err)
+		}
 		return fmt.Errorf("failed to subscribe to %s channel: %w", channel, err)
 	}
 
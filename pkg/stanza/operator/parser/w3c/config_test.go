@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "fields",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.Fields = "date time cs-method cs-uri-stem sc-status"
+					return p
+				}(),
+			},
+			{
+				Name: "file_attribute",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.FileAttribute = "log.file.name"
+					return p
+				}(),
+			},
+			{
+				Name: "file_expiration",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.FileExpiration = 10 * time.Minute
+					return p
+				}(),
+			},
+			{
+				Name: "parse_to_attributes",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.ParseTo = entry.RootableField{Field: entry.NewAttributeField()}
+					return p
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/w3c"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "w3c_parser"
+
+	fieldsDirective = "#Fields:"
+
+	// fieldsKey is the key under which the fields of the files are persisted.
+	fieldsKey = "fields"
+
+	defaultFileExpiration = time.Hour
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new W3C parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new W3C parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:   helper.NewParserConfig(operatorID, operatorType),
+		FileAttribute:  attrs.LogFilePath,
+		FileExpiration: defaultFileExpiration,
+	}
+}
+
+// Config is the configuration of a W3C parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Fields         string        `mapstructure:"fields"`
+	FileAttribute  string        `mapstructure:"file_attribute"`
+	FileExpiration time.Duration `mapstructure:"file_expiration"`
+}
+
+// Build will build a W3C parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	if c.FileExpiration <= 0 {
+		return nil, errors.New("`file_expiration` must be positive")
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+		fields:         strings.Fields(c.Fields),
+		fileAttribute:  c.FileAttribute,
+		fileExpiration: c.FileExpiration,
+		fieldsByFile:   make(map[string]*fileFields),
+		now:            time.Now,
+	}, nil
+}
+
+// fileFields are the fields set by the last #Fields directive of a file.
+type fileFields struct {
+	fields   []string
+	lastSeen time.Time
+}
+
+// Parser is an operator that parses W3C extended log format lines in an entry.
+type Parser struct {
+	helper.ParserOperator
+	fields         []string
+	fileAttribute  string
+	fileExpiration time.Duration
+
+	mu           sync.Mutex
+	persister    operator.Persister
+	fieldsByFile map[string]*fileFields
+	lastSweep    time.Time
+	now          func() time.Time
+}
+
+// Start restores the fields of the files persisted along with the file offsets,
+// so that the lines of the files resumed after a restart are parsed with them.
+func (p *Parser) Start(persister operator.Persister) error {
+	if persister == nil {
+		return nil
+	}
+	data, err := persister.Get(context.Background(), fieldsKey)
+	if err != nil {
+		return fmt.Errorf("read fields from database: %w", err)
+	}
+	saved := map[string][]string{}
+	if data != nil {
+		if err = json.Unmarshal(data, &saved); err != nil {
+			return fmt.Errorf("decode fields: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.persister = persister
+	now := p.now()
+	p.lastSweep = now
+	for file, fields := range saved {
+		p.fieldsByFile[file] = &fileFields{fields: fields, lastSeen: now}
+	}
+	return nil
+}
+
+// Process will parse an entry for W3C extended log format. Directive lines are
+// consumed: #Fields directives set the field names of the following lines of the
+// same file, and the other directives are dropped.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	if value, ok := e.Get(p.ParseFrom); ok {
+		if line, err := valueAsString(value); err == nil && strings.HasPrefix(line, "#") {
+			if fields, ok := strings.CutPrefix(line, fieldsDirective); ok {
+				p.setFields(p.fileKey(e), strings.Fields(fields))
+			}
+			return nil
+		}
+	}
+
+	fields := p.fieldsOf(p.fileKey(e))
+	return p.ParserOperator.ProcessWith(ctx, e, func(value interface{}) (interface{}, error) {
+		return parse(fields, value)
+	})
+}
+
+// fileKey returns the key under which the fields of the file e was read from are
+// stored. The file name is used when the file path is not included in the entries,
+// in which case files with the same name in different directories share their fields.
+func (p *Parser) fileKey(e *entry.Entry) string {
+	if p.fileAttribute == "" {
+		return ""
+	}
+	if file, ok := e.Attributes[p.fileAttribute].(string); ok {
+		return file
+	}
+	if p.fileAttribute == attrs.LogFilePath {
+		if file, ok := e.Attributes[attrs.LogFileName].(string); ok {
+			return file
+		}
+	}
+	return ""
+}
+
+func (p *Parser) setFields(file string, fields []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fieldsByFile[file] = &fileFields{fields: fields, lastSeen: p.now()}
+	p.save()
+}
+
+func (p *Parser) fieldsOf(file string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	if now.Sub(p.lastSweep) >= p.fileExpiration {
+		p.sweep(now)
+	}
+	if f, ok := p.fieldsByFile[file]; ok {
+		f.lastSeen = now
+		return f.fields
+	}
+	return p.fields
+}
+
+// sweep forgets the fields of the files from which no line was read for the
+// file expiration, as their reading is over.
+func (p *Parser) sweep(now time.Time) {
+	p.lastSweep = now
+	expired := false
+	for file, f := range p.fieldsByFile {
+		if now.Sub(f.lastSeen) >= p.fileExpiration {
+			delete(p.fieldsByFile, file)
+			expired = true
+		}
+	}
+	if expired {
+		p.save()
+	}
+}
+
+// save persists the fields of the files, if a persister is available.
+func (p *Parser) save() {
+	if p.persister == nil {
+		return
+	}
+	saved := make(map[string][]string, len(p.fieldsByFile))
+	for file, f := range p.fieldsByFile {
+		saved[file] = f.fields
+	}
+	data, err := json.Marshal(saved)
+	if err == nil {
+		err = p.persister.Set(context.Background(), fieldsKey, data)
+	}
+	if err != nil {
+		p.Errorw("Failed to persist fields", zap.Error(err))
+	}
+}
+
+// parse maps the values of a line to fields. Values that are not set, written -,
+// are omitted.
+func parse(fields []string, value interface{}) (interface{}, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no fields defined: missing #Fields directive")
+	}
+
+	line, err := valueAsString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := splitValues(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(fields) {
+		return nil, fmt.Errorf("wrong number of values: expected %d, found %d", len(fields), len(values))
+	}
+
+	parsed := make(map[string]interface{}, len(fields))
+	for i, v := range values {
+		if v == "-" {
+			continue
+		}
+		parsed[fields[i]] = v
+	}
+	return parsed, nil
+}
+
+// splitValues splits a line on whitespace. Values may be quoted, in which case
+// they can contain spaces, and quotes are escaped by doubling them.
+func splitValues(line string) ([]string, error) {
+	var values []string
+	for i := 0; i < len(line); {
+		if isSpace(line[i]) {
+			i++
+			continue
+		}
+
+		if line[i] != '"' {
+			end := strings.IndexFunc(line[i:], func(r rune) bool { return r < utf8.RuneSelf && isSpace(byte(r)) })
+			if end < 0 {
+				end = len(line) - i
+			}
+			values = append(values, line[i:i+end])
+			i += end
+			continue
+		}
+
+		var b strings.Builder
+		closed := false
+		for i++; i < len(line); i++ {
+			if line[i] != '"' {
+				b.WriteByte(line[i])
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+				continue
+			}
+			closed = true
+			i++
+			break
+		}
+		if !closed {
+			return nil, errors.New("unterminated quoted value")
+		}
+		values = append(values, b.String())
+	}
+	return values, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r'
+}
+
+// valueAsString interprets the given value as a string.
+func valueAsString(value interface{}) (string, error) {
+	switch t := value.(type) {
+	case string:
+		return t, nil
+	case []byte:
+		return string(t), nil
+	default:
+		return "", fmt.Errorf("type '%T' cannot be parsed as W3C extended log format", value)
+	}
+}
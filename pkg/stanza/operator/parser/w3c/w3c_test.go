@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	config := NewConfigWithID("test")
+	config.OutputIDs = []string{"fake"}
+	config.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+	configure(config)
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op.(*Parser), fake
+}
+
+func newTestEntry(file string, line interface{}) *entry.Entry {
+	e := entry.New()
+	e.Body = line
+	if file != "" {
+		e.Attributes = map[string]interface{}{"log.file.name": file}
+	}
+	return e
+}
+
+func TestW3CImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParserIISLog(t *testing.T) {
+	parser, fake := newTestParser(t, func(*Config) {})
+
+	lines := []string{
+		"#Software: Microsoft Internet Information Services 10.0",
+		"#Version: 1.0",
+		"#Date: 2023-10-11 22:14:15",
+		"#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs(User-Agent) sc-status time-taken",
+		"2023-10-11 22:14:15 10.0.0.1 GET /index.html - 443 Mozilla/5.0+(Windows+NT+10.0) 200 15\r",
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry("u_ex231011.log", line)))
+	}
+
+	// Directives are consumed, unset values are omitted
+	fake.ExpectBody(t, map[string]interface{}{
+		"date":           "2023-10-11",
+		"time":           "22:14:15",
+		"s-ip":           "10.0.0.1",
+		"cs-method":      "GET",
+		"cs-uri-stem":    "/index.html",
+		"s-port":         "443",
+		"cs(User-Agent)": "Mozilla/5.0+(Windows+NT+10.0)",
+		"sc-status":      "200",
+		"time-taken":     "15",
+	})
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserFieldsRedefinition(t *testing.T) {
+	parser, fake := newTestParser(t, func(*Config) {})
+
+	lines := []string{
+		"#Fields: date time cs-method",
+		"2023-10-11 22:14:15 GET",
+		"#Fields: date time cs-method sc-status",
+		"2023-10-11 22:14:16 POST 201",
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry("u_ex231011.log", line)))
+	}
+
+	fake.ExpectBody(t, map[string]interface{}{"date": "2023-10-11", "time": "22:14:15", "cs-method": "GET"})
+	fake.ExpectBody(t, map[string]interface{}{"date": "2023-10-11", "time": "22:14:16", "cs-method": "POST", "sc-status": "201"})
+}
+
+func TestParserFieldsPerFile(t *testing.T) {
+	parser, fake := newTestParser(t, func(c *Config) {
+		c.Fields = "date time"
+	})
+
+	entries := []*entry.Entry{
+		newTestEntry("a.log", "#Fields: cs-method sc-status"),
+		newTestEntry("b.log", "#Fields: sc-status cs-method"),
+		newTestEntry("a.log", "GET 200"),
+		newTestEntry("b.log", "404 PUT"),
+		// Files without directive use the configured fields
+		newTestEntry("c.log", "2023-10-11 22:14:15"),
+	}
+	for _, e := range entries {
+		require.NoError(t, parser.Process(context.Background(), e))
+	}
+
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "GET", "sc-status": "200"})
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "PUT", "sc-status": "404"})
+	fake.ExpectBody(t, map[string]interface{}{"date": "2023-10-11", "time": "22:14:15"})
+}
+
+func TestParserFieldsPerFilePath(t *testing.T) {
+	parser, fake := newTestParser(t, func(*Config) {})
+
+	newPathEntry := func(path, line string) *entry.Entry {
+		e := newTestEntry(filepath.Base(path), line)
+		e.Attributes["log.file.path"] = path
+		return e
+	}
+	entries := []*entry.Entry{
+		newPathEntry("/logs/W3SVC1/u_ex231011.log", "#Fields: cs-method sc-status"),
+		newPathEntry("/logs/W3SVC2/u_ex231011.log", "#Fields: sc-status cs-method"),
+		newPathEntry("/logs/W3SVC1/u_ex231011.log", "GET 200"),
+		newPathEntry("/logs/W3SVC2/u_ex231011.log", "404 PUT"),
+	}
+	for _, e := range entries {
+		require.NoError(t, parser.Process(context.Background(), e))
+	}
+
+	// Files with the same name in different directories have their own fields
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "GET", "sc-status": "200"})
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "PUT", "sc-status": "404"})
+}
+
+func TestParserFieldsPersisted(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+	parser, _ := newTestParser(t, func(*Config) {})
+	require.NoError(t, parser.Start(persister))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("a.log", "#Fields: cs-method sc-status")))
+	require.NoError(t, parser.Stop())
+
+	// The file is resumed after a restart, past its #Fields directive.
+	parser, fake := newTestParser(t, func(*Config) {})
+	require.NoError(t, parser.Start(persister))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("a.log", "GET 200")))
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "GET", "sc-status": "200"})
+}
+
+func TestParserFileExpiration(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+	parser, fake := newTestParser(t, func(c *Config) {
+		c.Fields = "date time"
+		c.FileExpiration = time.Minute
+	})
+	now := time.Now()
+	parser.now = func() time.Time { return now }
+	require.NoError(t, parser.Start(persister))
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("a.log", "#Fields: cs-method sc-status")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("b.log", "#Fields: sc-status cs-method")))
+	now = now.Add(40 * time.Second)
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("a.log", "GET 200")))
+	fake.ExpectBody(t, map[string]interface{}{"cs-method": "GET", "sc-status": "200"})
+
+	// b.log is no longer read and is forgotten, a.log is kept.
+	now = now.Add(40 * time.Second)
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("c.log", "2023-10-11 22:14:15")))
+	fake.ExpectBody(t, map[string]interface{}{"date": "2023-10-11", "time": "22:14:15"})
+	require.Len(t, parser.fieldsByFile, 1)
+	require.Contains(t, parser.fieldsByFile, "a.log")
+
+	data, err := persister.Get(context.Background(), fieldsKey)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a.log": ["cs-method", "sc-status"]}`, string(data))
+}
+
+func TestParserInvalidFileExpiration(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.FileExpiration = 0
+	_, err := config.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "`file_expiration` must be positive")
+}
+
+func TestParserQuotedValues(t *testing.T) {
+	parser, fake := newTestParser(t, func(c *Config) {
+		c.Fields = "x-message x-user\tx-empty"
+	})
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("", `"hello  world" "say ""hi""" ""`)))
+	fake.ExpectBody(t, map[string]interface{}{"x-message": "hello  world", "x-user": `say "hi"`, "x-empty": ""})
+}
+
+func TestParserFailure(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields string
+		input  interface{}
+		errMsg string
+	}{
+		{"no_fields", "", "GET 200", "no fields defined: missing #Fields directive"},
+		{"too_few_values", "cs-method sc-status", "GET", "wrong number of values: expected 2, found 1"},
+		{"too_many_values", "cs-method", "GET 200", "wrong number of values: expected 1, found 2"},
+		{"unterminated_quote", "cs-method", `"GET`, "unterminated quoted value"},
+		{"invalid_type", "cs-method", 42, "type 'int' cannot be parsed as W3C extended log format"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, _ := newTestParser(t, func(c *Config) {
+				c.Fields = tc.fields
+			})
+			err := parser.Process(context.Background(), newTestEntry("", tc.input))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
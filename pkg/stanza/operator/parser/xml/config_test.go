@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_to_body",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+					return cfg
+				}(),
+			},
+			{
+				Name: "on_error_drop",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OnError = "drop"
+					return cfg
+				}(),
+			},
+			{
+				Name: "attribute_prefix",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.AttributePrefix = "attr_"
+					return cfg
+				}(),
+			},
+			{
+				Name: "text_key",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.TextKey = "value"
+					return cfg
+				}(),
+			},
+			{
+				Name: "force_array",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ForceArray = []string{"Data", "Binary"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "flatten",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Flatten = true
+					return cfg
+				}(),
+			},
+			{
+				Name: "fields",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Fields = map[string]string{
+						"event_id": "/Event/System/EventID",
+						"provider": "/Event/System/Provider/@Name",
+						"user":     "/Event/EventData/Data[@Name='TargetUserName']/text()",
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
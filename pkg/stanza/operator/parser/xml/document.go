@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// node is an XML element. Namespaces are ignored, elements and attributes are
+// identified by their local name.
+type node struct {
+	name     string
+	attrs    []attr
+	children []*node
+	text     string
+}
+
+type attr struct {
+	name  string
+	value string
+}
+
+// parseDocument parses an XML document into a tree of elements. The text of an
+// element is the concatenation of its character data, with surrounding whitespace
+// trimmed.
+func parseDocument(value string) (*node, error) {
+	decoder := xml.NewDecoder(strings.NewReader(value))
+	decoder.Strict = true
+
+	var root *node
+	var stack []*node
+	var texts []*strings.Builder
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, errors.New("parse XML: expected a single root element")
+			}
+			n := &node{name: t.Name.Local}
+			for _, a := range t.Attr {
+				// Namespace declarations are not attributes of the element
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				n.attrs = append(n.attrs, attr{name: a.Name.Local, value: a.Value})
+			}
+			if len(stack) == 0 {
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+			texts = append(texts, &strings.Builder{})
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.text = strings.TrimSpace(texts[len(texts)-1].String())
+			stack = stack[:len(stack)-1]
+			texts = texts[:len(texts)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				texts[len(texts)-1].Write(t)
+			} else if strings.TrimSpace(string(t)) != "" {
+				return nil, errors.New("parse XML: unexpected text outside of the root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("parse XML: no root element found")
+	}
+	return root, nil
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// path is a compiled subset of XPath. It supports absolute location paths made of
+// child (/) and descendant (//) steps. Steps select elements by name or with *,
+// and can be followed by a single predicate: a 1-based position ([2]) or an
+// attribute value ([@Name='value']). The last step can select an attribute
+// (@Name) or the text of the element (text()).
+type path struct {
+	steps []step
+}
+
+type stepKind int
+
+const (
+	elementStep stepKind = iota
+	attributeStep
+	textStep
+)
+
+type step struct {
+	kind       stepKind
+	descendant bool
+	name       string
+
+	// predicates, position is 0 when not set
+	position   int
+	attrName   string
+	attrValue  string
+	attrFilter bool
+}
+
+// match is an element, or the value of an attribute or text node.
+type match struct {
+	node  *node
+	value string
+}
+
+func compilePath(expr string) (*path, error) {
+	if !strings.HasPrefix(expr, "/") {
+		return nil, fmt.Errorf("path '%s' must be absolute", expr)
+	}
+
+	var steps []step
+	rest := expr
+	for rest != "" {
+		var s step
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			s.descendant = true
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "/"):
+			rest = rest[1:]
+		default:
+			return nil, fmt.Errorf("unexpected '%s' in path '%s'", rest, expr)
+		}
+
+		end := stepEnd(rest)
+		raw := rest[:end]
+		rest = rest[end:]
+		if err := s.parse(raw); err != nil {
+			return nil, fmt.Errorf("invalid step '%s' in path '%s': %w", raw, expr, err)
+		}
+		if len(steps) > 0 && steps[len(steps)-1].kind != elementStep {
+			return nil, fmt.Errorf("only the last step of path '%s' can select an attribute or text", expr)
+		}
+		steps = append(steps, s)
+	}
+	return &path{steps: steps}, nil
+}
+
+// stepEnd returns the index of the next step separator, ignoring slashes in predicates.
+func stepEnd(s string) int {
+	inPredicate := false
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case inPredicate && (c == '\'' || c == '"'):
+			quote = c
+		case c == '[':
+			inPredicate = true
+		case c == ']':
+			inPredicate = false
+		case c == '/' && !inPredicate:
+			return i
+		}
+	}
+	return len(s)
+}
+
+func (s *step) parse(raw string) error {
+	switch {
+	case raw == "":
+		return errors.New("empty step")
+	case raw == "text()":
+		s.kind = textStep
+		return nil
+	case strings.HasPrefix(raw, "@"):
+		s.kind = attributeStep
+		s.name = raw[1:]
+		if !isName(s.name) {
+			return errors.New("invalid attribute name")
+		}
+		return nil
+	}
+
+	s.kind = elementStep
+	name, predicate, hasPredicate := strings.Cut(raw, "[")
+	s.name = name
+	if name != "*" && !isName(name) {
+		return errors.New("invalid element name")
+	}
+	if !hasPredicate {
+		return nil
+	}
+	if !strings.HasSuffix(predicate, "]") {
+		return errors.New("unterminated predicate")
+	}
+	predicate = predicate[:len(predicate)-1]
+
+	if position, err := strconv.Atoi(predicate); err == nil {
+		if position < 1 {
+			return errors.New("positions start at 1")
+		}
+		s.position = position
+		return nil
+	}
+
+	attrName, attrValue, ok := strings.Cut(predicate, "=")
+	if !ok || !strings.HasPrefix(attrName, "@") || !isName(attrName[1:]) {
+		return errors.New("predicate must be a position or of the form [@name='value']")
+	}
+	unquoted, err := unquote(attrValue)
+	if err != nil {
+		return err
+	}
+	s.attrName = attrName[1:]
+	s.attrValue = unquoted
+	s.attrFilter = true
+	return nil
+}
+
+func unquote(s string) (string, error) {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], nil
+	}
+	return "", errors.New("predicate value must be quoted")
+}
+
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	return !strings.ContainsAny(s, "/[]@='\" \t")
+}
+
+// evaluate returns the matches of the path in document order.
+func (p *path) evaluate(root *node) []match {
+	// The document node is the parent of the root element
+	current := []*node{{children: []*node{root}}}
+	for _, s := range p.steps {
+		if s.kind != elementStep {
+			return s.values(current)
+		}
+		var selected []*node
+		seen := make(map[*node]bool)
+		for _, n := range current {
+			for _, c := range s.selectElements(n) {
+				// Nested context elements can select the same descendants
+				if !seen[c] {
+					seen[c] = true
+					selected = append(selected, c)
+				}
+			}
+		}
+		current = selected
+	}
+
+	matches := make([]match, 0, len(current))
+	for _, n := range current {
+		matches = append(matches, match{node: n})
+	}
+	return matches
+}
+
+func (s step) selectElements(n *node) []*node {
+	var candidates []*node
+	if s.descendant {
+		candidates = descendants(n, nil)
+	} else {
+		candidates = n.children
+	}
+
+	var selected []*node
+	position := 0
+	for _, c := range candidates {
+		if s.name != "*" && c.name != s.name {
+			continue
+		}
+		if s.attrFilter {
+			value, ok := c.attr(s.attrName)
+			if !ok || value != s.attrValue {
+				continue
+			}
+		}
+		position++
+		if s.position != 0 && position != s.position {
+			continue
+		}
+		selected = append(selected, c)
+	}
+	return selected
+}
+
+func (s step) values(current []*node) []match {
+	var matches []match
+	for _, n := range current {
+		candidates := []*node{n}
+		if s.descendant {
+			candidates = descendants(n, candidates)
+		}
+		for _, c := range candidates {
+			switch s.kind {
+			case attributeStep:
+				if value, ok := c.attr(s.name); ok {
+					matches = append(matches, match{value: value})
+				}
+			case textStep:
+				if c.text != "" {
+					matches = append(matches, match{value: c.text})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+func descendants(n *node, result []*node) []*node {
+	for _, c := range n.children {
+		result = append(result, c)
+		result = descendants(c, result)
+	}
+	return result
+}
+
+func (n *node) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.name == name {
+			return a.value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathEvaluate(t *testing.T) {
+	root, err := parseDocument(`<a>
+  <b id="1"><c>one</c></b>
+  <b id="2"><c>two</c><b id="3"><c>three</c></b></b>
+  <d>text</d>
+</a>`)
+	require.NoError(t, err)
+
+	cases := []struct {
+		path   string
+		expect []string
+	}{
+		{"/a/d", []string{"d"}},
+		{"/a/d/text()", []string{"text"}},
+		{"/a/b/@id", []string{"1", "2"}},
+		{"/a/b[2]/@id", []string{"2"}},
+		{"/a/b[@id='1']/c/text()", []string{"one"}},
+		{"/a/b[@id=\"2\"]/c/text()", []string{"two"}},
+		{"/a/*/@id", []string{"1", "2"}},
+		{"//b/@id", []string{"1", "2", "3"}},
+		{"//b//c/text()", []string{"one", "two", "three"}},
+		{"/a//@id", []string{"1", "2", "3"}},
+		{"/a/b[3]", nil},
+		{"/x", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := compilePath(tc.path)
+			require.NoError(t, err)
+
+			var actual []string
+			for _, m := range p.evaluate(root) {
+				if m.node != nil {
+					actual = append(actual, m.node.name)
+				} else {
+					actual = append(actual, m.value)
+				}
+			}
+			require.Equal(t, tc.expect, actual)
+		})
+	}
+}
+
+func TestCompilePathFailure(t *testing.T) {
+	cases := []struct {
+		path   string
+		errMsg string
+	}{
+		{"a/b", "must be absolute"},
+		{"/", "empty step"},
+		{"/a/", "empty step"},
+		{"/a/@id/b", "only the last step"},
+		{"/a/text()/b", "only the last step"},
+		{"/a[0]", "positions start at 1"},
+		{"/a[@id='1'", "unterminated predicate"},
+		{"/a[id='1']", "predicate must be a position"},
+		{"/a[@id=1]", "predicate value must be quoted"},
+		{"/@", "invalid attribute name"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			_, err := compilePath(tc.path)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "xml_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, operatorType),
+		AttributePrefix: "@",
+		TextKey:         "#text",
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	AttributePrefix string            `mapstructure:"attribute_prefix"`
+	TextKey         string            `mapstructure:"text_key"`
+	ForceArray      []string          `mapstructure:"force_array"`
+	Flatten         bool              `mapstructure:"flatten"`
+	Fields          map[string]string `mapstructure:"fields"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TextKey == "" {
+		return nil, errors.New("text_key cannot be empty")
+	}
+
+	forceArray := make(map[string]bool, len(c.ForceArray))
+	for _, name := range c.ForceArray {
+		forceArray[name] = true
+	}
+
+	fields := make([]field, 0, len(c.Fields))
+	for name, p := range c.Fields {
+		compiled, err := compilePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path for field '%s': %w", name, err)
+		}
+		fields = append(fields, field{name: name, path: compiled})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		attributePrefix: c.AttributePrefix,
+		textKey:         c.TextKey,
+		forceArray:      forceArray,
+		flatten:         c.Flatten,
+		fields:          fields,
+	}, nil
+}
+
+type field struct {
+	name string
+	path *path
+}
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+	attributePrefix string
+	textKey         string
+	forceArray      map[string]bool
+	flatten         bool
+	fields          []field
+}
+
+// Process will parse an entry for XML.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as XML.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	var root *node
+	var err error
+	switch m := value.(type) {
+	case string:
+		root, err = parseDocument(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed map[string]interface{}
+	if len(p.fields) == 0 {
+		parsed = map[string]interface{}{root.name: p.convert(root)}
+	} else {
+		parsed = p.extract(root)
+	}
+
+	if p.flatten {
+		flat := make(map[string]interface{}, len(parsed))
+		flattenInto(flat, "", parsed)
+		parsed = flat
+	}
+	return parsed, nil
+}
+
+// extract evaluates the configured paths against the document. Fields without
+// matches are omitted, fields with several matches are parsed as arrays.
+func (p *Parser) extract(root *node) map[string]interface{} {
+	parsed := make(map[string]interface{}, len(p.fields))
+	for _, f := range p.fields {
+		var values []interface{}
+		for _, match := range f.path.evaluate(root) {
+			if match.node != nil {
+				values = append(values, p.convert(match.node))
+			} else {
+				values = append(values, match.value)
+			}
+		}
+		switch len(values) {
+		case 0:
+		case 1:
+			parsed[f.name] = values[0]
+		default:
+			parsed[f.name] = values
+		}
+	}
+	return parsed
+}
+
+// convert maps an element to a value. Elements with neither attributes nor child
+// elements are mapped to their text. Other elements are mapped to a map holding
+// their attributes, child elements and text. Repeated child elements, and those
+// listed in force_array, are grouped in arrays.
+func (p *Parser) convert(n *node) interface{} {
+	if len(n.attrs) == 0 && len(n.children) == 0 {
+		return n.text
+	}
+
+	m := make(map[string]interface{}, len(n.attrs)+len(n.children)+1)
+	for _, attr := range n.attrs {
+		m[p.attributePrefix+attr.name] = attr.value
+	}
+	for _, child := range n.children {
+		value := p.convert(child)
+		// convert never returns arrays, so an array is a group of repeated elements
+		switch existing := m[child.name].(type) {
+		case nil:
+			if p.forceArray[child.name] {
+				m[child.name] = []interface{}{value}
+			} else {
+				m[child.name] = value
+			}
+		case []interface{}:
+			m[child.name] = append(existing, value)
+		default:
+			m[child.name] = []interface{}{existing, value}
+		}
+	}
+	if n.text != "" {
+		m[p.textKey] = n.text
+	}
+	return m
+}
+
+func flattenInto(dst map[string]interface{}, prefix string, src map[string]interface{}) {
+	for k, v := range src {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			flattenInto(dst, key, m)
+			continue
+		}
+		dst[key] = v
+	}
+}
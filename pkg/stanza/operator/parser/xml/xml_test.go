@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const windowsEvent = `<Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event">
+  <System>
+    <Provider Name="Microsoft-Windows-Security-Auditing" Guid="{54849625-5478-4994-a5ba-3e3b0328c30d}"/>
+    <EventID>4624</EventID>
+    <Level>0</Level>
+    <TimeCreated SystemTime="2023-10-11T22:14:15.123Z"/>
+  </System>
+  <EventData>
+    <Data Name="TargetUserName">jdoe</Data>
+    <Data Name="LogonType">2</Data>
+  </EventData>
+</Event>`
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestConfigBuildEmptyTextKey(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.TextKey = ""
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "text_key cannot be empty")
+}
+
+func TestConfigBuildInvalidField(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.Fields = map[string]string{"user": "Event/EventData"}
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid path for field 'user'")
+}
+
+func TestParserFailure(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  interface{}
+		errMsg string
+	}{
+		{"malformed", "<a><b></a>", "parse XML"},
+		{"no_root", "just text", "parse XML: unexpected text outside of the root element"},
+		{"empty", "", "parse XML: no root element found"},
+		{"several_roots", "<a/><b/>", "parse XML: expected a single root element"},
+		{"bytes", []byte("<a/>"), "type []uint8 cannot be parsed as XML"},
+		{"invalid_type", []int{}, "type []int cannot be parsed as XML"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t)
+			_, err := parser.parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestXMLImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expect    map[string]interface{}
+	}{
+		{
+			"simple",
+			func(p *Config) {},
+			`<message>hello</message>`,
+			map[string]interface{}{
+				"message": "hello",
+			},
+		},
+		{
+			"declaration_and_comments",
+			func(p *Config) {},
+			`<?xml version="1.0" encoding="UTF-8"?><!-- comment --><message><![CDATA[a < b]]></message>`,
+			map[string]interface{}{
+				"message": "a < b",
+			},
+		},
+		{
+			"attributes_and_text",
+			func(p *Config) {},
+			`<log level="info" source="app">started</log>`,
+			map[string]interface{}{
+				"log": map[string]interface{}{
+					"@level":  "info",
+					"@source": "app",
+					"#text":   "started",
+				},
+			},
+		},
+		{
+			"repeated_elements",
+			func(p *Config) {},
+			`<hosts><host>a</host><host>b</host><host>c</host><port>80</port></hosts>`,
+			map[string]interface{}{
+				"hosts": map[string]interface{}{
+					"host": []interface{}{"a", "b", "c"},
+					"port": "80",
+				},
+			},
+		},
+		{
+			"windows_event",
+			func(p *Config) {},
+			windowsEvent,
+			map[string]interface{}{
+				"Event": map[string]interface{}{
+					"System": map[string]interface{}{
+						"Provider": map[string]interface{}{
+							"@Name": "Microsoft-Windows-Security-Auditing",
+							"@Guid": "{54849625-5478-4994-a5ba-3e3b0328c30d}",
+						},
+						"EventID": "4624",
+						"Level":   "0",
+						"TimeCreated": map[string]interface{}{
+							"@SystemTime": "2023-10-11T22:14:15.123Z",
+						},
+					},
+					"EventData": map[string]interface{}{
+						"Data": []interface{}{
+							map[string]interface{}{"@Name": "TargetUserName", "#text": "jdoe"},
+							map[string]interface{}{"@Name": "LogonType", "#text": "2"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"attribute_prefix_and_text_key",
+			func(p *Config) {
+				p.AttributePrefix = ""
+				p.TextKey = "value"
+			},
+			`<log level="info">started</log>`,
+			map[string]interface{}{
+				"log": map[string]interface{}{
+					"level": "info",
+					"value": "started",
+				},
+			},
+		},
+		{
+			"force_array",
+			func(p *Config) {
+				p.ForceArray = []string{"host"}
+			},
+			`<hosts><host>a</host><port>80</port></hosts>`,
+			map[string]interface{}{
+				"hosts": map[string]interface{}{
+					"host": []interface{}{"a"},
+					"port": "80",
+				},
+			},
+		},
+		{
+			"flatten",
+			func(p *Config) {
+				p.Flatten = true
+			},
+			`<event><user id="42"><name>jdoe</name></user><tag>a</tag><tag>b</tag></event>`,
+			map[string]interface{}{
+				"event.user.@id":  "42",
+				"event.user.name": "jdoe",
+				"event.tag":       []interface{}{"a", "b"},
+			},
+		},
+		{
+			"fields",
+			func(p *Config) {
+				p.Fields = map[string]string{
+					"event_id":   "/Event/System/EventID",
+					"provider":   "/Event/System/Provider/@Name",
+					"user":       "/Event/EventData/Data[@Name='TargetUserName']/text()",
+					"data_names": "//Data/@Name",
+					"system":     "/Event/System/TimeCreated",
+					"missing":    "/Event/UserData",
+				}
+			},
+			windowsEvent,
+			map[string]interface{}{
+				"event_id":   "4624",
+				"provider":   "Microsoft-Windows-Security-Auditing",
+				"user":       "jdoe",
+				"data_names": []interface{}{"TargetUserName", "LogonType"},
+				"system": map[string]interface{}{
+					"@SystemTime": "2023-10-11T22:14:15.123Z",
+				},
+			},
+		},
+		{
+			"fields_flatten",
+			func(p *Config) {
+				p.Flatten = true
+				p.Fields = map[string]string{
+					"system": "/Event/System/Provider",
+				}
+			},
+			windowsEvent,
+			map[string]interface{}{
+				"system.@Name": "Microsoft-Windows-Security-Auditing",
+				"system.@Guid": "{54849625-5478-4994-a5ba-3e3b0328c30d}",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			input := &entry.Entry{Body: tc.input, ObservedTimestamp: ots}
+			expect := &entry.Entry{Body: tc.input, Attributes: tc.expect, ObservedTimestamp: ots}
+
+			err = op.Process(context.Background(), input)
+			require.NoError(t, err)
+			fake.ExpectEntry(t, expect)
+		})
+	}
+}
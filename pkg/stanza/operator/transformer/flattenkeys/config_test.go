@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package flattenkeys
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+// Test unmarshalling of values into config struct
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "body_to_attributes",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.RootableField{Field: entry.NewBodyField()}
+					cfg.To = entry.RootableField{Field: entry.NewAttributeField()}
+					return cfg
+				}(),
+			},
+			{
+				Name: "nested_field",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.RootableField{Field: entry.NewAttributeField("nested")}
+					return cfg
+				}(),
+			},
+			{
+				Name: "separator",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Separator = "_"
+					return cfg
+				}(),
+			},
+			{
+				Name: "limits",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxDepth = 2
+					cfg.MaxKeys = 100
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package flattenkeys // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/flattenkeys"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "flatten_keys"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new flatten_keys operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new flatten_keys operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Field:             entry.RootableField{Field: entry.NewBodyField()},
+		Separator:         ".",
+	}
+}
+
+// Config is the configuration of a flatten_keys operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Field                    entry.RootableField `mapstructure:"field"`
+	To                       entry.RootableField `mapstructure:"to"`
+	Separator                string              `mapstructure:"separator"`
+	MaxDepth                 int                 `mapstructure:"max_depth"`
+	MaxKeys                  int                 `mapstructure:"max_keys"`
+}
+
+// Build will build a flatten_keys operator from the supplied configuration
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Separator == "" {
+		return nil, fmt.Errorf("separator must not be empty")
+	}
+	if c.MaxDepth < 0 {
+		return nil, fmt.Errorf("max_depth must not be negative")
+	}
+	if c.MaxKeys < 0 {
+		return nil, fmt.Errorf("max_keys must not be negative")
+	}
+
+	to := c.To.Field
+	if to.FieldInterface == nil {
+		to = c.Field.Field
+	}
+
+	return &Transformer{
+		TransformerOperator: transformerOperator,
+		Field:               c.Field.Field,
+		To:                  to,
+		Separator:           c.Separator,
+		MaxDepth:            c.MaxDepth,
+		MaxKeys:             c.MaxKeys,
+	}, nil
+}
+
+// Transformer is an operator that flattens a nested map into a map of joined keys
+type Transformer struct {
+	helper.TransformerOperator
+	Field     entry.Field
+	To        entry.Field
+	Separator string
+	MaxDepth  int
+	MaxKeys   int
+}
+
+// Process will process an entry with a flatten_keys transformation.
+func (p *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.Transform)
+}
+
+// Transform will apply the flatten_keys operation to an entry
+func (p *Transformer) Transform(e *entry.Entry) error {
+	val, ok := p.Field.Get(e)
+	if !ok {
+		return fmt.Errorf("apply flatten_keys: field %s does not exist on entry", p.Field)
+	}
+
+	valMap, ok := val.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("apply flatten_keys: field %s is not a map", p.Field)
+	}
+
+	flattened := make(map[string]interface{}, len(valMap))
+	depths := make(map[string]int, len(valMap))
+	if err := p.flatten(flattened, depths, "", valMap, 1); err != nil {
+		return fmt.Errorf("apply flatten_keys: %w", err)
+	}
+
+	// The entry is only modified once the whole map was flattened within the limits
+	p.Field.Delete(e)
+	return p.To.Set(e, flattened)
+}
+
+// flatten adds the values of m to dst, with their keys joined to prefix. When several
+// values get the same key, such as `a.b` next to `a: {b: }`, the one nested in the
+// fewest maps is kept, and the first one in key order among those. depths records the
+// number of maps each value of dst is nested in.
+func (p *Transformer) flatten(dst map[string]interface{}, depths map[string]int, prefix string, m map[string]interface{}, depth int) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := m[key]
+		k := key
+		if prefix != "" {
+			k = prefix + p.Separator + key
+		}
+
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 && (p.MaxDepth == 0 || depth <= p.MaxDepth) {
+			if err := p.flatten(dst, depths, k, nested, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if existing, ok := depths[k]; ok {
+			if existing > depth {
+				dst[k] = v
+				depths[k] = depth
+			}
+			continue
+		}
+		if p.MaxKeys > 0 && len(dst) >= p.MaxKeys {
+			return fmt.Errorf("field %s has more than %d keys once flattened", p.Field, p.MaxKeys)
+		}
+		dst[k] = v
+		depths[k] = depth
+	}
+	return nil
+}
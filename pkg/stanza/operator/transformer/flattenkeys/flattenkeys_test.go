@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package flattenkeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuildErrors(t *testing.T) {
+	cases := map[string]func(*Config){
+		"empty_separator": func(cfg *Config) { cfg.Separator = "" },
+		"negative_depth":  func(cfg *Config) { cfg.MaxDepth = -1 },
+		"negative_keys":   func(cfg *Config) { cfg.MaxKeys = -1 },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewConfig()
+			mutate(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBuildAndProcess(t *testing.T) {
+	now := time.Now()
+	newTestEntry := func() *entry.Entry {
+		e := entry.New()
+		e.ObservedTimestamp = now
+		e.Timestamp = time.Unix(1586632809, 0)
+		e.Body = map[string]interface{}{
+			"key": "val",
+			"http": map[string]interface{}{
+				"method": "GET",
+				"request": map[string]interface{}{
+					"size":    int64(42),
+					"headers": []interface{}{"a", "b"},
+				},
+			},
+			"empty": map[string]interface{}{},
+		}
+		return e
+	}
+
+	cases := []struct {
+		name      string
+		op        func() *Config
+		input     func() *entry.Entry
+		output    func() *entry.Entry
+		expectErr bool
+	}{
+		{
+			name:  "body_in_place",
+			op:    NewConfig,
+			input: newTestEntry,
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"key":                  "val",
+					"http.method":          "GET",
+					"http.request.size":    int64(42),
+					"http.request.headers": []interface{}{"a", "b"},
+					"empty":                map[string]interface{}{},
+				}
+				return e
+			},
+		},
+		{
+			name: "body_to_attributes",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.To = entry.RootableField{Field: entry.NewAttributeField()}
+				return cfg
+			},
+			input: func() *entry.Entry {
+				e := newTestEntry()
+				e.Attributes = map[string]interface{}{"existing": "attr"}
+				return e
+			},
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = nil
+				e.Attributes = map[string]interface{}{
+					"existing":             "attr",
+					"key":                  "val",
+					"http.method":          "GET",
+					"http.request.size":    int64(42),
+					"http.request.headers": []interface{}{"a", "b"},
+					"empty":                map[string]interface{}{},
+				}
+				return e
+			},
+		},
+		{
+			name: "nested_field",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.RootableField{Field: entry.NewBodyField("http")}
+				return cfg
+			},
+			input: newTestEntry,
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"key": "val",
+					"http": map[string]interface{}{
+						"method":          "GET",
+						"request.size":    int64(42),
+						"request.headers": []interface{}{"a", "b"},
+					},
+					"empty": map[string]interface{}{},
+				}
+				return e
+			},
+		},
+		{
+			name: "separator",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.RootableField{Field: entry.NewBodyField("http")}
+				cfg.Separator = "_"
+				return cfg
+			},
+			input: newTestEntry,
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"key": "val",
+					"http": map[string]interface{}{
+						"method":          "GET",
+						"request_size":    int64(42),
+						"request_headers": []interface{}{"a", "b"},
+					},
+					"empty": map[string]interface{}{},
+				}
+				return e
+			},
+		},
+		{
+			name: "max_depth",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.MaxDepth = 1
+				return cfg
+			},
+			input: newTestEntry,
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"key":         "val",
+					"http.method": "GET",
+					"http.request": map[string]interface{}{
+						"size":    int64(42),
+						"headers": []interface{}{"a", "b"},
+					},
+					"empty": map[string]interface{}{},
+				}
+				return e
+			},
+		},
+		{
+			name: "colliding_keys",
+			op:   NewConfig,
+			input: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"a.b.c": "literal",
+					"a": map[string]interface{}{
+						"b": map[string]interface{}{"c": "nested"},
+						"b.c": "partially nested",
+						"x":   "1",
+					},
+					"d": map[string]interface{}{
+						"e.f": "first",
+					},
+					"d.e": map[string]interface{}{
+						"f": "second",
+					},
+				}
+				return e
+			},
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = map[string]interface{}{
+					"a.b.c": "literal",
+					"a.x":   "1",
+					"d.e.f": "first",
+				}
+				return e
+			},
+		},
+		{
+			name: "max_keys",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.MaxKeys = 4
+				return cfg
+			},
+			input:     newTestEntry,
+			output:    newTestEntry,
+			expectErr: true,
+		},
+		{
+			name: "missing_field",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.RootableField{Field: entry.NewBodyField("missing")}
+				return cfg
+			},
+			input:     newTestEntry,
+			output:    newTestEntry,
+			expectErr: true,
+		},
+		{
+			name: "not_a_map",
+			op:   NewConfig,
+			input: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = "a string"
+				return e
+			},
+			output: func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = "a string"
+				return e
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.op()
+			cfg.OutputIDs = []string{"fake"}
+			cfg.OnError = "send"
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			err = op.Process(context.Background(), tc.input())
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			// Entries are sent unchanged on error
+			fake.ExpectEntry(t, tc.output())
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unflattenkeys
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+// Test unmarshalling of values into config struct
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "attributes_to_body",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.RootableField{Field: entry.NewAttributeField()}
+					cfg.To = entry.RootableField{Field: entry.NewBodyField()}
+					return cfg
+				}(),
+			},
+			{
+				Name: "separator",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Separator = "_"
+					return cfg
+				}(),
+			},
+			{
+				Name: "max_depth",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxDepth = 2
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
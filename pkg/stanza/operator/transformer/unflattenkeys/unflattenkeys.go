@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unflattenkeys // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/unflattenkeys"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "unflatten_keys"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new unflatten_keys operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new unflatten_keys operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Field:             entry.RootableField{Field: entry.NewBodyField()},
+		Separator:         ".",
+	}
+}
+
+// Config is the configuration of an unflatten_keys operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Field                    entry.RootableField `mapstructure:"field"`
+	To                       entry.RootableField `mapstructure:"to"`
+	Separator                string              `mapstructure:"separator"`
+	MaxDepth                 int                 `mapstructure:"max_depth"`
+}
+
+// Build will build an unflatten_keys operator from the supplied configuration
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Separator == "" {
+		return nil, fmt.Errorf("separator must not be empty")
+	}
+	if c.MaxDepth < 0 {
+		return nil, fmt.Errorf("max_depth must not be negative")
+	}
+
+	to := c.To.Field
+	if to.FieldInterface == nil {
+		to = c.Field.Field
+	}
+
+	return &Transformer{
+		TransformerOperator: transformerOperator,
+		Field:               c.Field.Field,
+		To:                  to,
+		Separator:           c.Separator,
+		MaxDepth:            c.MaxDepth,
+	}, nil
+}
+
+// Transformer is an operator that splits the keys of a map into nested maps
+type Transformer struct {
+	helper.TransformerOperator
+	Field     entry.Field
+	To        entry.Field
+	Separator string
+	MaxDepth  int
+}
+
+// Process will process an entry with an unflatten_keys transformation.
+func (p *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.Transform)
+}
+
+// Transform will apply the unflatten_keys operation to an entry
+func (p *Transformer) Transform(e *entry.Entry) error {
+	val, ok := p.Field.Get(e)
+	if !ok {
+		return fmt.Errorf("apply unflatten_keys: field %s does not exist on entry", p.Field)
+	}
+
+	valMap, ok := val.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("apply unflatten_keys: field %s is not a map", p.Field)
+	}
+
+	// Keys are sorted so that conflicts are reported consistently
+	keys := make([]string, 0, len(valMap))
+	for k := range valMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	unflattened := make(map[string]interface{}, len(valMap))
+	for _, k := range keys {
+		if err := p.insert(unflattened, k, valMap[k]); err != nil {
+			return fmt.Errorf("apply unflatten_keys: %w", err)
+		}
+	}
+
+	// The entry is only modified once all the keys could be unflattened
+	p.Field.Delete(e)
+	return p.To.Set(e, unflattened)
+}
+
+// insert sets value in dst at the path described by key.
+func (p *Transformer) insert(dst map[string]interface{}, key string, value interface{}) error {
+	path := p.split(key)
+	current := dst
+	for i, segment := range path[:len(path)-1] {
+		existing, ok := current[segment]
+		if !ok {
+			nested := map[string]interface{}{}
+			current[segment] = nested
+			current = nested
+			continue
+		}
+		nested, ok := existing.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("key %q conflicts with the value of key %q", key, strings.Join(path[:i+1], p.Separator))
+		}
+		current = nested
+	}
+
+	last := path[len(path)-1]
+	existing, ok := current[last]
+	if !ok {
+		current[last] = copyValue(value)
+		return nil
+	}
+
+	// Maps are merged, so that both a.b and a: {c: 1} end up in a
+	existingMap, existingIsMap := existing.(map[string]interface{})
+	valueMap, valueIsMap := value.(map[string]interface{})
+	if !existingIsMap || !valueIsMap {
+		return fmt.Errorf("key %q conflicts with another key", key)
+	}
+	for k, v := range valueMap {
+		if _, ok := existingMap[k]; ok {
+			return fmt.Errorf("key %q conflicts with another key", key+p.Separator+k)
+		}
+		existingMap[k] = copyValue(v)
+	}
+	return nil
+}
+
+// copyValue deep copies maps, so that the field is left untouched when a conflict
+// is found after some of its nested maps were merged into.
+func copyValue(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = copyValue(v)
+	}
+	return c
+}
+
+// split returns the path of key. Keys with empty segments, such as a..b or .a, are
+// not split.
+func (p *Transformer) split(key string) []string {
+	n := -1
+	if p.MaxDepth > 0 {
+		n = p.MaxDepth + 1
+	}
+	path := strings.SplitN(key, p.Separator, n)
+	for _, segment := range path {
+		if segment == "" {
+			return []string{key}
+		}
+	}
+	return path
+}
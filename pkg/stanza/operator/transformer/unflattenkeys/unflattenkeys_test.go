@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unflattenkeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuildErrors(t *testing.T) {
+	cases := map[string]func(*Config){
+		"empty_separator": func(cfg *Config) { cfg.Separator = "" },
+		"negative_depth":  func(cfg *Config) { cfg.MaxDepth = -1 },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewConfig()
+			mutate(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBuildAndProcess(t *testing.T) {
+	now := time.Now()
+	newTestEntry := func(body interface{}) func() *entry.Entry {
+		return func() *entry.Entry {
+			e := entry.New()
+			e.ObservedTimestamp = now
+			e.Timestamp = time.Unix(1586632809, 0)
+			e.Body = body
+			return e
+		}
+	}
+	flat := func() map[string]interface{} {
+		return map[string]interface{}{
+			"key":                  "val",
+			"http.method":          "GET",
+			"http.request.size":    int64(42),
+			"http.request.headers": []interface{}{"a", "b"},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		op        func() *Config
+		input     func() *entry.Entry
+		output    func() *entry.Entry
+		expectErr bool
+	}{
+		{
+			name:  "body_in_place",
+			op:    NewConfig,
+			input: newTestEntry(flat()),
+			output: newTestEntry(map[string]interface{}{
+				"key": "val",
+				"http": map[string]interface{}{
+					"method": "GET",
+					"request": map[string]interface{}{
+						"size":    int64(42),
+						"headers": []interface{}{"a", "b"},
+					},
+				},
+			}),
+		},
+		{
+			name: "attributes_to_body",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.RootableField{Field: entry.NewAttributeField()}
+				cfg.To = entry.RootableField{Field: entry.NewBodyField("attributes")}
+				return cfg
+			},
+			input: func() *entry.Entry {
+				e := newTestEntry(map[string]interface{}{"message": "hello"})()
+				e.Attributes = map[string]interface{}{"log.file.name": "app.log"}
+				return e
+			},
+			output: newTestEntry(map[string]interface{}{
+				"message": "hello",
+				"attributes": map[string]interface{}{
+					"log": map[string]interface{}{
+						"file": map[string]interface{}{
+							"name": "app.log",
+						},
+					},
+				},
+			}),
+		},
+		{
+			name: "separator",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.Separator = "_"
+				return cfg
+			},
+			input: newTestEntry(map[string]interface{}{"http_method": "GET", "http.path": "/"}),
+			output: newTestEntry(map[string]interface{}{
+				"http":      map[string]interface{}{"method": "GET"},
+				"http.path": "/",
+			}),
+		},
+		{
+			name: "max_depth",
+			op: func() *Config {
+				cfg := NewConfig()
+				cfg.MaxDepth = 1
+				return cfg
+			},
+			input: newTestEntry(flat()),
+			output: newTestEntry(map[string]interface{}{
+				"key": "val",
+				"http": map[string]interface{}{
+					"method":          "GET",
+					"request.size":    int64(42),
+					"request.headers": []interface{}{"a", "b"},
+				},
+			}),
+		},
+		{
+			name: "merge_maps",
+			op:   NewConfig,
+			input: newTestEntry(map[string]interface{}{
+				"http":        map[string]interface{}{"method": "GET"},
+				"http.status": int64(200),
+			}),
+			output: newTestEntry(map[string]interface{}{
+				"http": map[string]interface{}{"method": "GET", "status": int64(200)},
+			}),
+		},
+		{
+			name:   "empty_segments",
+			op:     NewConfig,
+			input:  newTestEntry(map[string]interface{}{".hidden": "a", "trailing.": "b", "a..b": "c"}),
+			output: newTestEntry(map[string]interface{}{".hidden": "a", "trailing.": "b", "a..b": "c"}),
+		},
+		{
+			name:      "conflict",
+			op:        NewConfig,
+			input:     newTestEntry(map[string]interface{}{"http": "GET", "http.status": int64(200)}),
+			output:    newTestEntry(map[string]interface{}{"http": "GET", "http.status": int64(200)}),
+			expectErr: true,
+		},
+		{
+			name: "conflict_in_nested_map",
+			op:   NewConfig,
+			input: newTestEntry(map[string]interface{}{
+				"http":        map[string]interface{}{"status": int64(200)},
+				"http.status": int64(500),
+			}),
+			output: newTestEntry(map[string]interface{}{
+				"http":        map[string]interface{}{"status": int64(200)},
+				"http.status": int64(500),
+			}),
+			expectErr: true,
+		},
+		{
+			name:      "not_a_map",
+			op:        NewConfig,
+			input:     newTestEntry("a string"),
+			output:    newTestEntry("a string"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.op()
+			cfg.OutputIDs = []string{"fake"}
+			cfg.OnError = "send"
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			err = op.Process(context.Background(), tc.input())
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			// Entries are sent unchanged on error
+			fake.ExpectEntry(t, tc.output())
+		})
+	}
+}
@@ -18,6 +18,10 @@ import (
 type Config struct {
 	DefaultOutput operator.Operator
 	Operators     []operator.Config
+
+	// ReceiverID is the ID of the receiver running the pipeline. When set, the
+	// operators record metrics tagged with it, see MetricViews.
+	ReceiverID string
 }
 
 // Build will build a pipeline from the config.
@@ -69,6 +73,18 @@ func (c Config) Build(logger *zap.SugaredLogger) (*DirectedPipeline, error) {
 		}
 	}
 
+	if c.ReceiverID != "" {
+		if err := registerViews(); err != nil {
+			return nil, fmt.Errorf("failed to register metric views: %w", err)
+		}
+		for i, op := range ops {
+			// The default output is not configured by users
+			if op != c.DefaultOutput {
+				ops[i] = newInstrumentedOperator(op, c.ReceiverID)
+			}
+		}
+	}
+
 	return NewDirectedPipeline(ops)
 }
 
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+var (
+	tagReceiver     = tag.MustNewKey("receiver")
+	tagOperatorID   = tag.MustNewKey("operator_id")
+	tagOperatorType = tag.MustNewKey("operator_type")
+
+	mRecordsIn      = stats.Int64("stanza_operator_records_in", "Number of records processed by an operator", stats.UnitDimensionless)
+	mRecordsOut     = stats.Int64("stanza_operator_records_out", "Number of records written by an operator to its outputs", stats.UnitDimensionless)
+	mRecordsDropped = stats.Int64("stanza_operator_records_dropped", "Number of records an operator neither wrote to an output nor failed to process, e.g. filtered, not routed, or combined with other records", stats.UnitDimensionless)
+	mErrors         = stats.Int64("stanza_operator_errors", "Number of records an operator failed to process", stats.UnitDimensionless)
+	mLatency        = stats.Float64("stanza_operator_processing_latency", "Time an operator spent processing a record, excluding the time spent by its outputs", stats.UnitMilliseconds)
+
+	// latencyDistribution is shared by the views so that registering them
+	// again is not rejected as registering a different view.
+	latencyDistribution = view.Distribution(0.01, 0.05, 0.1, 0.5, 1, 5, 10, 50, 100, 500, 1000)
+
+	registerViewsOnce sync.Once
+	errRegisterViews  error
+)
+
+// registerViews registers the metric views the first time an instrumented
+// pipeline is built, and returns the registration error to every pipeline.
+func registerViews() error {
+	registerViewsOnce.Do(func() {
+		errRegisterViews = view.Register(MetricViews()...)
+	})
+	return errRegisterViews
+}
+
+// MetricViews returns the metric views of the operators of the pipelines,
+// which are registered when the first pipeline with a ReceiverID is built.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagReceiver, tagOperatorID, tagOperatorType}
+	sumView := func(m *stats.Int64Measure) *view.View {
+		return &view.View{
+			Name:        m.Name(),
+			Measure:     m,
+			Description: m.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		}
+	}
+	return []*view.View{
+		sumView(mRecordsIn),
+		sumView(mRecordsOut),
+		sumView(mRecordsDropped),
+		sumView(mErrors),
+		{
+			Name:        mLatency.Name(),
+			Measure:     mLatency,
+			Description: mLatency.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: latencyDistribution,
+		},
+	}
+}
+
+// callStatsKey is the context key of the callStats of the record being processed.
+type callStatsKey struct{}
+
+// callStats tracks what happens to a record while an operator processes it.
+type callStats struct {
+	writes     atomic.Int64
+	downstream atomic.Int64 // nanoseconds spent by the outputs
+}
+
+// instrumentedOperator records the metrics of an operator. Its outputs are
+// wrapped in outputs counting the records the operator writes to them.
+type instrumentedOperator struct {
+	operator.Operator
+	telemetryCtx context.Context
+}
+
+func newInstrumentedOperator(op operator.Operator, receiverID string) *instrumentedOperator {
+	ctx, _ := tag.New(context.Background(),
+		tag.Upsert(tagReceiver, receiverID),
+		tag.Upsert(tagOperatorID, op.ID()),
+		tag.Upsert(tagOperatorType, op.Type()),
+	)
+	return &instrumentedOperator{Operator: op, telemetryCtx: ctx}
+}
+
+func (o *instrumentedOperator) Process(ctx context.Context, e *entry.Entry) error {
+	cs := &callStats{}
+	start := time.Now()
+	err := o.Operator.Process(context.WithValue(ctx, callStatsKey{}, cs), e)
+	own := time.Since(start) - time.Duration(cs.downstream.Load())
+
+	ms := []stats.Measurement{
+		mRecordsIn.M(1),
+		mLatency.M(float64(own) / float64(time.Millisecond)),
+	}
+	switch {
+	case err != nil:
+		ms = append(ms, mErrors.M(1))
+	case cs.writes.Load() == 0 && o.CanOutput():
+		ms = append(ms, mRecordsDropped.M(1))
+	}
+	stats.Record(o.telemetryCtx, ms...)
+	return err
+}
+
+func (o *instrumentedOperator) SetOutputs(operators []operator.Operator) error {
+	outputs := make([]operator.Operator, len(operators))
+	for i, op := range operators {
+		outputs[i] = &instrumentedOutput{Operator: op, from: o}
+	}
+	return o.Operator.SetOutputs(outputs)
+}
+
+// instrumentedOutput is an output of an instrumentedOperator.
+type instrumentedOutput struct {
+	operator.Operator
+	from *instrumentedOperator
+}
+
+func (o *instrumentedOutput) Process(ctx context.Context, e *entry.Entry) error {
+	stats.Record(o.from.telemetryCtx, mRecordsOut.M(1))
+	cs, ok := ctx.Value(callStatsKey{}).(*callStats)
+	if !ok {
+		// Written outside of a call to Process, e.g. by an input operator
+		return o.Operator.Process(ctx, e)
+	}
+	cs.writes.Add(1)
+	start := time.Now()
+	err := o.Operator.Process(ctx, e)
+	cs.downstream.Add(int64(time.Since(start)))
+	return err
+}
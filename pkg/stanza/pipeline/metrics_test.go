@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/filter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"stanza_operator_records_in",
+		"stanza_operator_records_out",
+		"stanza_operator_records_dropped",
+		"stanza_operator_errors",
+		"stanza_operator_processing_latency",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+// operatorSum returns the value of a sum view for the given operator.
+func operatorSum(t *testing.T, viewName, operatorID string) float64 {
+	rows, err := view.RetrieveData(viewName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == tagOperatorID && tag.Value == operatorID {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
+
+func TestOperatorMetrics(t *testing.T) {
+	require.NoError(t, registerViews())
+
+	noopCfg := noop.NewConfigWithID("noop")
+	noopCfg.OutputIDs = []string{"filter"}
+	filterCfg := filter.NewConfigWithID("filter")
+	filterCfg.Expression = `body == "drop"`
+
+	output := testutil.NewFakeOutput(t)
+	pipe, err := Config{
+		Operators:     []operator.Config{{Builder: noopCfg}, {Builder: filterCfg}},
+		DefaultOutput: output,
+		ReceiverID:    "filelog",
+	}.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	first := pipe.Operators()[0]
+	require.Equal(t, "noop", first.ID())
+	for _, body := range []string{"keep", "drop", "keep"} {
+		e := entry.New()
+		e.Body = body
+		require.NoError(t, first.Process(context.Background(), e))
+	}
+	output.ExpectBody(t, "keep")
+	output.ExpectBody(t, "keep")
+
+	assert.Equal(t, float64(3), operatorSum(t, "stanza_operator_records_in", "noop"))
+	assert.Equal(t, float64(3), operatorSum(t, "stanza_operator_records_out", "noop"))
+	assert.Equal(t, float64(0), operatorSum(t, "stanza_operator_records_dropped", "noop"))
+	assert.Equal(t, float64(3), operatorSum(t, "stanza_operator_records_in", "filter"))
+	assert.Equal(t, float64(2), operatorSum(t, "stanza_operator_records_out", "filter"))
+	assert.Equal(t, float64(1), operatorSum(t, "stanza_operator_records_dropped", "filter"))
+	assert.Equal(t, float64(0), operatorSum(t, "stanza_operator_errors", "filter"))
+
+	// The default output is not instrumented
+	assert.Equal(t, float64(0), operatorSum(t, "stanza_operator_records_in", "fake"))
+}
@@ -5,6 +5,7 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 const (
@@ -136,6 +139,54 @@ func parseAttributeNames(attrsToSelect pcommon.Value) []string {
 	return out
 }
 
+// filterAttributeLabels removes the labels promoted from the resource and log
+// attributes which are rejected by filter. The default labels are kept.
+func filterAttributeLabels(labels model.LabelSet, resAttrs pcommon.Map, logAttrs pcommon.Map, filter func(name, value string) bool) {
+	for name, value := range labels {
+		if name == model.LabelName(levelLabel) {
+			continue
+		}
+		if _, ok := getAttribute(string(name), resAttrs); !ok {
+			if _, ok = getAttribute(string(name), logAttrs); !ok {
+				// job, instance and exporter
+				continue
+			}
+		}
+		if !filter(string(name), string(value)) {
+			delete(labels, name)
+		}
+	}
+}
+
+// attributesToStructuredMetadata converts the resource and log attributes into
+// the structured metadata of a Loki entry, sorted by name. The names are
+// normalized like the labels, and the maps and slices are flattened like in
+// logfmt lines. The log attributes take precedence over the resource attributes
+// of the same name.
+func attributesToStructuredMetadata(resAttrs pcommon.Map, logAttrs pcommon.Map) push.LabelsAdapter {
+	metadata := map[string]string{}
+	for _, attrs := range []pcommon.Map{resAttrs, logAttrs} {
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			keyvals := valueToKeyvals(k, v)
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				name := prometheustranslator.NormalizeLabel(fmt.Sprint(keyvals[i]))
+				metadata[name] = fmt.Sprint(keyvals[i+1])
+			}
+			return true
+		})
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	out := make(push.LabelsAdapter, 0, len(metadata))
+	for name, value := range metadata {
+		out = append(out, push.LabelAdapter{Name: name, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
 func removeAttributes(attrs pcommon.Map, labels model.LabelSet) {
 	attrs.RemoveIf(func(s string, v pcommon.Value) bool {
 		if s == hintAttributes || s == hintResources || s == hintTenant || s == hintFormat {
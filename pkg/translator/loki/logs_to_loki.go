@@ -48,6 +48,26 @@ const (
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[string]PushRequest {
+	return LogsToLokiRequestsWithOptions(ld, Options{DefaultLabelsEnabled: defaultLabelsEnabled})
+}
+
+// Options configures the conversion of logs into Loki requests.
+type Options struct {
+	// DefaultLabelsEnabled allows to disable the default labels: exporter, job,
+	// instance and level.
+	DefaultLabelsEnabled map[string]bool
+	// StructuredMetadata moves the resource and log attributes not promoted to
+	// labels out of the log lines, into the structured metadata of the entries.
+	StructuredMetadata bool
+	// LabelFilter, when set, is called with the name and value of the attributes
+	// hinted to be promoted to labels. The attributes it rejects are kept as
+	// attributes, in the log line or the structured metadata.
+	LabelFilter func(name, value string) bool
+}
+
+// LogsToLokiRequestsWithOptions is LogsToLokiRequests with the options of the
+// conversion.
+func LogsToLokiRequestsWithOptions(ld plog.Logs, opts Options) map[string]PushRequest {
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -70,7 +90,7 @@ func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[
 					groups[tenant] = group
 				}
 
-				entry, err := LogToLokiEntry(log, resource, scope, defaultLabelsEnabled)
+				entry, err := LogToLokiEntryWithOptions(log, resource, scope, opts)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -122,6 +142,13 @@ type PushEntry struct {
 
 // LogToLokiEntry converts LogRecord into Loki log entry enriched with normalized labels
 func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, defaultLabelsEnabled map[string]bool) (*PushEntry, error) {
+	return LogToLokiEntryWithOptions(lr, rl, scope, Options{DefaultLabelsEnabled: defaultLabelsEnabled})
+}
+
+// LogToLokiEntryWithOptions is LogToLokiEntry with the options of the conversion.
+func LogToLokiEntryWithOptions(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, opts Options) (*PushEntry, error) {
+	defaultLabelsEnabled := opts.DefaultLabelsEnabled
+
 	// we may remove attributes, so change only our version
 	log := plog.NewLogRecord()
 	lr.CopyTo(log)
@@ -138,14 +165,25 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 	format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
 
 	mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes(), defaultLabelsEnabled)
+	if opts.LabelFilter != nil {
+		filterAttributeLabels(mergedLabels, resource.Attributes(), log.Attributes(), opts.LabelFilter)
+	}
 	// remove the attributes that were promoted to labels
 	removeAttributes(log.Attributes(), mergedLabels)
 	removeAttributes(resource.Attributes(), mergedLabels)
 
+	var structuredMetadata push.LabelsAdapter
+	if opts.StructuredMetadata {
+		structuredMetadata = attributesToStructuredMetadata(resource.Attributes(), log.Attributes())
+		resource.Attributes().Clear()
+		log.Attributes().Clear()
+	}
+
 	entry, err := convertLogToLokiEntry(log, resource, format, scope)
 	if err != nil {
 		return nil, err
 	}
+	entry.StructuredMetadata = structuredMetadata
 
 	labels := model.LabelSet{}
 	for label := range mergedLabels {
@@ -692,6 +692,108 @@ func TestLogToLokiEntry(t *testing.T) {
 	}
 }
 
+func TestLogToLokiEntryWithOptions(t *testing.T) {
+	newLog := func() (plog.LogRecord, pcommon.Resource) {
+		lr := plog.NewLogRecord()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1677592916000000000)))
+		lr.SetSeverityNumber(plog.SeverityNumberWarn)
+		lr.Body().SetStr("request failed")
+		lr.Attributes().PutStr(hintAttributes, "http.route, user.id")
+		lr.Attributes().PutStr("http.route", "/cart")
+		lr.Attributes().PutStr("user.id", "42")
+		lr.Attributes().PutInt("http.status", 500)
+		lr.Attributes().PutEmptyMap("peer").PutStr("name", "db")
+
+		res := pcommon.NewResource()
+		res.Attributes().PutStr("service.name", "checkout")
+		res.Attributes().PutStr("host.name", "guarana")
+		return lr, res
+	}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected *PushEntry
+	}{
+		{
+			name: "structured metadata",
+			opts: Options{StructuredMetadata: true},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `{"body":"request failed"}`,
+					StructuredMetadata: push.LabelsAdapter{
+						{Name: "host_name", Value: "guarana"},
+						{Name: "http_status", Value: "500"},
+						{Name: "peer_name", Value: "db"},
+						{Name: "service_name", Value: "checkout"},
+					},
+				},
+				Labels: model.LabelSet{
+					"exporter":   "OTLP",
+					"job":        "checkout",
+					"level":      "WARN",
+					"http_route": "/cart",
+					"user_id":    "42",
+				},
+			},
+		},
+		{
+			name: "label filter with structured metadata",
+			opts: Options{
+				StructuredMetadata: true,
+				LabelFilter:        func(name, _ string) bool { return name != "user.id" },
+			},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `{"body":"request failed"}`,
+					StructuredMetadata: push.LabelsAdapter{
+						{Name: "host_name", Value: "guarana"},
+						{Name: "http_status", Value: "500"},
+						{Name: "peer_name", Value: "db"},
+						{Name: "service_name", Value: "checkout"},
+						{Name: "user_id", Value: "42"},
+					},
+				},
+				Labels: model.LabelSet{
+					"exporter":   "OTLP",
+					"job":        "checkout",
+					"level":      "WARN",
+					"http_route": "/cart",
+				},
+			},
+		},
+		{
+			name: "label filter without structured metadata",
+			opts: Options{
+				DefaultLabelsEnabled: map[string]bool{"job": false},
+				LabelFilter:          func(_, value string) bool { return value != "/cart" },
+			},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `{"body":"request failed","attributes":{"http.route":"/cart","http.status":500,"peer":{"name":"db"}},"resources":{"host.name":"guarana","service.name":"checkout"}}`,
+				},
+				Labels: model.LabelSet{
+					"exporter": "OTLP",
+					"level":    "WARN",
+					"user_id":  "42",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, res := newLog()
+			entry, err := LogToLokiEntryWithOptions(lr, res, pcommon.NewInstrumentationScope(), tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, entry)
+		})
+	}
+}
+
 func TestGetTenantFromTenantHint(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -54,6 +54,34 @@ func TestPushRequestToLogs(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "Should add structured metadata to attributes",
+			pushRequest: &push.PushRequest{
+				Streams: []push.Stream{
+					{
+						Labels: "{label1=\"value1\"}",
+						Entries: []push.Entry{
+							{
+								Timestamp:          time.Unix(0, 1676888496000000000),
+								Line:               "logline 1",
+								StructuredMetadata: push.LabelsAdapter{{Name: "trace_id", Value: "0102"}},
+							},
+						},
+					},
+				},
+			},
+			keepTimestamp: true,
+			expected: generateLogs([]Log{
+				{
+					Timestamp: 1676888496000000000,
+					Body:      pcommon.NewValueStr("logline 1"),
+					Attributes: map[string]interface{}{
+						"label1":   "value1",
+						"trace_id": "0102",
+					},
+				},
+			}),
+		},
 		{
 			name: "Should ignore label with name starting from __",
 			pushRequest: &push.PushRequest{
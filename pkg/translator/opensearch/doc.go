@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opensearch provides translation helpers to convert OTLP traces and logs
+// to documents following the OpenSearch Simple Schema for Observability (SS4O).
+// See: https://github.com/opensearch-project/opensearch-catalog/tree/main/docs/schema/observability
+package opensearch // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/opensearch"
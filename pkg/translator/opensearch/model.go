@@ -1,19 +1,21 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package opensearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opensearchexporter"
+package opensearch // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/opensearch"
 
 import (
 	"time"
 )
 
-type dataStream struct {
+// DataStream identifies the data stream a document is indexed into.
+type DataStream struct {
 	Dataset   string `json:"dataset,omitempty"`
 	Namespace string `json:"namespace,omitempty"`
 	Type      string `json:"type,omitempty"`
 }
 
-type ssoSpanEvent struct {
+// SpanEvent is the SS4O representation of a span event.
+type SpanEvent struct {
 	Attributes             map[string]any `json:"attributes"`
 	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
 	Name                   string         `json:"name"`
@@ -21,7 +23,8 @@ type ssoSpanEvent struct {
 	Timestamp              *time.Time     `json:"@timestamp,omitempty"`
 }
 
-type ssoSpanLinks struct {
+// SpanLink is the SS4O representation of a span link.
+type SpanLink struct {
 	Attributes             map[string]any `json:"attributes,omitempty"`
 	SpanID                 string         `json:"spanId,omitempty"`
 	TraceID                string         `json:"traceId,omitempty"`
@@ -29,13 +32,14 @@ type ssoSpanLinks struct {
 	DroppedAttributesCount uint32         `json:"droppedAttributesCount,omitempty"`
 }
 
-type ssoSpan struct {
+// Span is the SS4O document of a span.
+type Span struct {
 	Attributes             map[string]any `json:"attributes,omitempty"`
 	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
 	DroppedEventsCount     uint32         `json:"droppedEventsCount"`
 	DroppedLinksCount      uint32         `json:"droppedLinksCount"`
 	EndTime                time.Time      `json:"endTime"`
-	Events                 []ssoSpanEvent `json:"events,omitempty"`
+	Events                 []SpanEvent    `json:"events,omitempty"`
 	InstrumentationScope   struct {
 		Attributes             map[string]any `json:"attributes,omitempty"`
 		DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
@@ -44,7 +48,7 @@ type ssoSpan struct {
 		Version                string         `json:"version"`
 	} `json:"instrumentationScope,omitempty"`
 	Kind         string            `json:"kind"`
-	Links        []ssoSpanLinks    `json:"links,omitempty"`
+	Links        []SpanLink        `json:"links,omitempty"`
 	Name         string            `json:"name"`
 	ParentSpanID string            `json:"parentSpanId"`
 	Resource     map[string]string `json:"resource,omitempty"`
@@ -59,7 +63,8 @@ type ssoSpan struct {
 	TraceState string    `json:"traceState"`
 }
 
-type ssoRecord struct {
+// LogRecord is the SS4O document of a log record.
+type LogRecord struct {
 	Attributes           map[string]any `json:"attributes,omitempty"`
 	Body                 string         `json:"body"`
 	InstrumentationScope struct {
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/opensearch"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	dataStreamTypeSpan   = "span"
+	dataStreamTypeRecord = "record"
+
+	dataStreamAttribute = "data_stream"
+)
+
+// now is the observed time of the records without timestamp.
+var now = time.Now
+
+// LogRecordToSS4O converts record to its SS4O document. When dataStream has a
+// dataset or a namespace, it is added to the attributes of the document.
+func LogRecordToSS4O(
+	resource pcommon.Resource,
+	scope pcommon.InstrumentationScope,
+	schemaURL string,
+	record plog.LogRecord,
+	dataStream DataStream,
+) LogRecord {
+	sso := LogRecord{}
+	sso.Attributes = record.Attributes().AsRaw()
+	sso.Body = record.Body().AsString()
+
+	observed := now()
+	ts := record.Timestamp().AsTime()
+	sso.ObservedTimestamp = &observed
+	sso.Timestamp = &ts
+
+	sso.Resource = attributesToMapString(resource.Attributes())
+	sso.SchemaURL = schemaURL
+	sso.SpanID = record.SpanID().String()
+	sso.TraceID = record.TraceID().String()
+
+	addDataStream(sso.Attributes, dataStream, dataStreamTypeRecord)
+
+	sso.InstrumentationScope.Name = scope.Name()
+	sso.InstrumentationScope.Version = scope.Version()
+	sso.InstrumentationScope.SchemaURL = schemaURL
+	sso.InstrumentationScope.Attributes = scope.Attributes().AsRaw()
+
+	sso.Severity.Text = record.SeverityText()
+	sso.Severity.Number = int64(record.SeverityNumber())
+
+	return sso
+}
+
+// SpanToSS4O converts span to its SS4O document. When dataStream has a dataset
+// or a namespace, it is added to the attributes of the document.
+func SpanToSS4O(
+	resource pcommon.Resource,
+	scope pcommon.InstrumentationScope,
+	schemaURL string,
+	span ptrace.Span,
+	dataStream DataStream,
+) Span {
+	sso := Span{}
+	sso.Attributes = span.Attributes().AsRaw()
+	sso.DroppedAttributesCount = span.DroppedAttributesCount()
+	sso.DroppedEventsCount = span.DroppedEventsCount()
+	sso.DroppedLinksCount = span.DroppedLinksCount()
+	sso.EndTime = span.EndTimestamp().AsTime()
+	sso.Kind = span.Kind().String()
+	sso.Name = span.Name()
+	sso.ParentSpanID = span.ParentSpanID().String()
+	sso.Resource = attributesToMapString(resource.Attributes())
+	sso.SpanID = span.SpanID().String()
+	sso.StartTime = span.StartTimestamp().AsTime()
+	sso.Status.Code = span.Status().Code().String()
+	sso.Status.Message = span.Status().Message()
+	sso.TraceID = span.TraceID().String()
+	sso.TraceState = span.TraceState().AsRaw()
+
+	if span.Events().Len() > 0 {
+		sso.Events = make([]SpanEvent, span.Events().Len())
+		for i := 0; i < span.Events().Len(); i++ {
+			e := span.Events().At(i)
+			ssoEvent := &sso.Events[i]
+			ssoEvent.Attributes = e.Attributes().AsRaw()
+			ssoEvent.DroppedAttributesCount = e.DroppedAttributesCount()
+			ssoEvent.Name = e.Name()
+			ts := e.Timestamp().AsTime()
+			if ts.Unix() != 0 {
+				ssoEvent.Timestamp = &ts
+			} else {
+				observed := now()
+				ssoEvent.ObservedTimestamp = &observed
+			}
+		}
+	}
+
+	addDataStream(sso.Attributes, dataStream, dataStreamTypeSpan)
+
+	sso.InstrumentationScope.Name = scope.Name()
+	sso.InstrumentationScope.DroppedAttributesCount = scope.DroppedAttributesCount()
+	sso.InstrumentationScope.Version = scope.Version()
+	sso.InstrumentationScope.SchemaURL = schemaURL
+	sso.InstrumentationScope.Attributes = scope.Attributes().AsRaw()
+
+	if span.Links().Len() > 0 {
+		sso.Links = make([]SpanLink, span.Links().Len())
+		for i := 0; i < span.Links().Len(); i++ {
+			link := span.Links().At(i)
+			ssoLink := &sso.Links[i]
+			ssoLink.Attributes = link.Attributes().AsRaw()
+			ssoLink.DroppedAttributesCount = link.DroppedAttributesCount()
+			ssoLink.TraceID = link.TraceID().String()
+			ssoLink.TraceState = link.TraceState().AsRaw()
+			ssoLink.SpanID = link.SpanID().String()
+		}
+	}
+	return sso
+}
+
+// addDataStream adds the data stream to attributes, unless it has neither
+// dataset nor namespace.
+func addDataStream(attributes map[string]any, dataStream DataStream, dataStreamType string) {
+	if dataStream.Dataset == "" && dataStream.Namespace == "" {
+		return
+	}
+	dataStream.Type = dataStreamType
+	attributes[dataStreamAttribute] = dataStream
+}
+
+// attributesToMapString returns the string representation of attributes.
+func attributesToMapString(attributes pcommon.Map) map[string]string {
+	m := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
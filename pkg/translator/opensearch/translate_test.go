@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newResourceAndScope() (pcommon.Resource, pcommon.InstrumentationScope) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutInt("process.pid", 42)
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("io.opentelemetry.http")
+	scope.SetVersion("1.0.0")
+	return resource, scope
+}
+
+func TestLogRecordToSS4O(t *testing.T) {
+	observed := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return observed }
+	defer func() { now = time.Now }()
+
+	resource, scope := newResourceAndScope()
+	record := plog.NewLogRecord()
+	record.Body().SetStr("order placed")
+	record.SetTimestamp(pcommon.NewTimestampFromTime(observed.Add(-time.Second)))
+	record.SetSeverityText("INFO")
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	record.Attributes().PutStr("order.id", "1234")
+
+	doc := LogRecordToSS4O(resource, scope, "https://opentelemetry.io/schemas/1.6.1", record, DataStream{Dataset: "checkout"})
+
+	assert.Equal(t, "order placed", doc.Body)
+	assert.Equal(t, observed, *doc.ObservedTimestamp)
+	assert.Equal(t, observed.Add(-time.Second), *doc.Timestamp)
+	assert.Equal(t, map[string]string{"service.name": "checkout", "process.pid": "42"}, doc.Resource)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", doc.TraceID)
+	assert.Equal(t, "INFO", doc.Severity.Text)
+	assert.Equal(t, int64(9), doc.Severity.Number)
+	assert.Equal(t, "io.opentelemetry.http", doc.InstrumentationScope.Name)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.6.1", doc.InstrumentationScope.SchemaURL)
+	assert.Equal(t, "1234", doc.Attributes["order.id"])
+	assert.Equal(t, DataStream{Dataset: "checkout", Type: "record"}, doc.Attributes["data_stream"])
+
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func TestSpanToSS4O(t *testing.T) {
+	observed := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return observed }
+	defer func() { now = time.Now }()
+
+	resource, scope := newResourceAndScope()
+	span := ptrace.NewSpan()
+	span.SetName("POST /orders")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.TraceID{1})
+	span.SetSpanID(pcommon.SpanID{2})
+	span.SetParentSpanID(pcommon.SpanID{3})
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(observed.Add(-time.Second)))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(observed))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("timeout")
+	timed := span.Events().AppendEmpty()
+	timed.SetName("retry")
+	timed.SetTimestamp(pcommon.NewTimestampFromTime(observed))
+	span.Events().AppendEmpty().SetName("untimed")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID{4})
+	link.SetSpanID(pcommon.SpanID{5})
+
+	doc := SpanToSS4O(resource, scope, "", span, DataStream{})
+
+	assert.Equal(t, "POST /orders", doc.Name)
+	assert.Equal(t, "Server", doc.Kind)
+	assert.Equal(t, "0200000000000000", doc.SpanID)
+	assert.Equal(t, "0300000000000000", doc.ParentSpanID)
+	assert.Equal(t, observed.Add(-time.Second), doc.StartTime)
+	assert.Equal(t, observed, doc.EndTime)
+	assert.Equal(t, "Error", doc.Status.Code)
+	assert.Equal(t, "timeout", doc.Status.Message)
+	require.Len(t, doc.Events, 2)
+	assert.Equal(t, observed, *doc.Events[0].Timestamp)
+	assert.Nil(t, doc.Events[0].ObservedTimestamp)
+	assert.Nil(t, doc.Events[1].Timestamp)
+	assert.Equal(t, observed, *doc.Events[1].ObservedTimestamp)
+	require.Len(t, doc.Links, 1)
+	assert.Equal(t, "0500000000000000", doc.Links[0].SpanID)
+	assert.Equal(t, "1.0.0", doc.InstrumentationScope.Version)
+	// The data stream is not set
+	assert.NotContains(t, doc.Attributes, "data_stream")
+}
+
+func TestSpanToSS4ODataStream(t *testing.T) {
+	resource, scope := newResourceAndScope()
+	doc := SpanToSS4O(resource, scope, "", ptrace.NewSpan(), DataStream{Namespace: "prod"})
+	assert.Equal(t, DataStream{Namespace: "prod", Type: "span"}, doc.Attributes["data_stream"])
+}
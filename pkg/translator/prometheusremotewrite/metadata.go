@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// OtelMetricsToMetadata returns the metadata of the metric families FromMetrics
+// converts md to, with the same settings. Families are returned once, in the order
+// they are first seen in md.
+func OtelMetricsToMetadata(md pmetric.Metrics, settings Settings) []*prompb.MetricMetadata {
+	var metadata []*prompb.MetricMetadata
+	seen := make(map[string]bool)
+
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		scopeMetricsSlice := resourceMetricsSlice.At(i).ScopeMetrics()
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				name := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+				if seen[name] {
+					continue
+				}
+				seen[name] = true
+				metadata = append(metadata, &prompb.MetricMetadata{
+					Type:             otelMetricTypeToPromMetricType(metric),
+					MetricFamilyName: name,
+					Help:             metric.Description(),
+					Unit:             metric.Unit(),
+				})
+			}
+		}
+	}
+
+	if !settings.DisableTargetInfo && resourceMetricsSlice.Len() > 0 {
+		name := targetMetricName
+		if len(settings.Namespace) > 0 {
+			name = settings.Namespace + "_" + name
+		}
+		if !seen[name] {
+			metadata = append(metadata, &prompb.MetricMetadata{
+				Type:             prompb.MetricMetadata_INFO,
+				MetricFamilyName: name,
+				Help:             "Target metadata",
+			})
+		}
+	}
+	return metadata
+}
+
+func otelMetricTypeToPromMetricType(metric pmetric.Metric) prompb.MetricMetadata_MetricType {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeSum:
+		if metric.Sum().IsMonotonic() {
+			return prompb.MetricMetadata_COUNTER
+		}
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+		return prompb.MetricMetadata_HISTOGRAM
+	case pmetric.MetricTypeSummary:
+		return prompb.MetricMetadata_SUMMARY
+	}
+	return prompb.MetricMetadata_UNKNOWN
+}
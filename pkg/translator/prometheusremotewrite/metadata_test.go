@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestOtelMetricsToMetadata(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetDescription("Memory in use")
+	gauge.SetUnit("By")
+	gauge.SetEmptyGauge()
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("requests")
+	counter.SetEmptySum().SetIsMonotonic(true)
+	counter.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	upDownCounter := metrics.AppendEmpty()
+	upDownCounter.SetName("queue.size")
+	upDownCounter.SetEmptySum().SetIsMonotonic(false)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetUnit("ms")
+	histogram.SetEmptyHistogram()
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("size")
+	expHistogram.SetEmptyExponentialHistogram()
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("duration")
+	summary.SetEmptySummary()
+
+	// Families are only returned once
+	counter.CopyTo(metrics.AppendEmpty())
+
+	tests := []struct {
+		name     string
+		settings Settings
+		want     []*prompb.MetricMetadata
+	}{
+		{
+			name: "default",
+			want: []*prompb.MetricMetadata{
+				{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "memory_usage", Help: "Memory in use", Unit: "By"},
+				{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests"},
+				{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_size"},
+				{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency", Unit: "ms"},
+				{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "size"},
+				{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "duration"},
+				{Type: prompb.MetricMetadata_INFO, MetricFamilyName: "target_info", Help: "Target metadata"},
+			},
+		},
+		{
+			name:     "namespace_and_suffixes",
+			settings: Settings{Namespace: "test", AddMetricSuffixes: true, DisableTargetInfo: true},
+			want: []*prompb.MetricMetadata{
+				{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_memory_usage_bytes", Help: "Memory in use", Unit: "By"},
+				{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "test_requests_total"},
+				{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_queue_size"},
+				{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "test_latency_milliseconds", Unit: "ms"},
+				{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "test_size"},
+				{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "test_duration"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OtelMetricsToMetadata(md, tt.settings))
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package winservice provides a handler running a collector as a Windows
+// service, which reports the failures of the collector and its components to the
+// Windows Event Log, stops the service with an error so that its recovery
+// actions apply, and accepts the session change events.
+package winservice // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winservice"
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package winservice // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winservice"
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// Event IDs of the entries written to the Windows Event Log. The IDs 1 to 3 are
+// the ones of the otelcol service handler, so that existing event filters keep
+// matching the collector logs.
+const (
+	eventIDInfo    = 1
+	eventIDWarning = 2
+	eventIDError   = 3
+
+	// Errors logged by the components, by kind of component.
+	eventIDReceiverError  = 10
+	eventIDProcessorError = 11
+	eventIDExporterError  = 12
+	eventIDExtensionError = 13
+	eventIDConnectorError = 14
+
+	// Events of the service.
+	eventIDStartFailed       = 100
+	eventIDStopFailed        = 101
+	eventIDCollectorFailed   = 102
+	eventIDUnexpectedControl = 103
+	eventIDSessionChange     = 104
+	eventIDRecoveryActions   = 105
+)
+
+// componentKindKey is the field holding the kind of component in the logs of
+// the components.
+const componentKindKey = "kind"
+
+// componentErrorEventIDs are the event IDs of the errors logged by the components.
+var componentErrorEventIDs = map[string]uint32{
+	"receiver":  eventIDReceiverError,
+	"processor": eventIDProcessorError,
+	"exporter":  eventIDExporterError,
+	"extension": eventIDExtensionError,
+	"connector": eventIDConnectorError,
+}
+
+var _ zapcore.Core = (*windowsEventLogCore)(nil)
+
+type windowsEventLogCore struct {
+	core    zapcore.Core
+	elog    *eventlog.Log
+	encoder zapcore.Encoder
+	// errorEventID is the event ID of the errors, which depends on the component logging.
+	errorEventID uint32
+}
+
+func (w windowsEventLogCore) Enabled(level zapcore.Level) bool {
+	return w.core.Enabled(level)
+}
+
+func (w windowsEventLogCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := w.encoder.Clone()
+	errorEventID := w.errorEventID
+	for _, field := range fields {
+		field.AddTo(enc)
+		if field.Key == componentKindKey && field.Type == zapcore.StringType {
+			if id, ok := componentErrorEventIDs[field.String]; ok {
+				errorEventID = id
+			}
+		}
+	}
+	return windowsEventLogCore{
+		core:         w.core,
+		elog:         w.elog,
+		encoder:      enc,
+		errorEventID: errorEventID,
+	}
+}
+
+func (w windowsEventLogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if w.Enabled(ent.Level) {
+		return ce.AddCore(ent, w)
+	}
+	return ce
+}
+
+func (w windowsEventLogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := w.encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		_ = w.elog.Warning(eventIDWarning, fmt.Sprintf("failed encoding log entry %v\r\n", err))
+		return err
+	}
+	msg := buf.String()
+	buf.Free()
+
+	switch ent.Level {
+	case zapcore.FatalLevel, zapcore.PanicLevel, zapcore.DPanicLevel, zapcore.ErrorLevel:
+		// golang.org/x/sys/windows/svc/eventlog does not support Critical level event logs
+		return w.elog.Error(w.errorEventID, msg)
+	case zapcore.WarnLevel:
+		return w.elog.Warning(eventIDWarning, msg)
+	}
+	// We would not be here if debug were disabled so log as info to not drop.
+	return w.elog.Info(eventIDInfo, msg)
+}
+
+func (w windowsEventLogCore) Sync() error {
+	return w.core.Sync()
+}
+
+func withWindowsCore(elog *eventlog.Log) func(zapcore.Core) zapcore.Core {
+	return func(core zapcore.Core) zapcore.Core {
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.LineEnding = "\r\n"
+		return windowsEventLogCore{core, elog, zapcore.NewConsoleEncoder(encoderConfig), eventIDError}
+	}
+}
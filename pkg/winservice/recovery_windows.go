@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package winservice // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winservice"
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// defaultRecoveryActions restart the service after increasing delays.
+var defaultRecoveryActions = []mgr.RecoveryAction{
+	{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+	{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	{Type: mgr.ServiceRestart, Delay: time.Minute},
+}
+
+// defaultRecoveryResetPeriod is the time without failure, in seconds, after which
+// the failure count of the service is reset.
+const defaultRecoveryResetPeriod = uint32(24 * time.Hour / time.Second)
+
+// configureRecoveryActions configures the default recovery actions of the service,
+// unless recovery actions were already configured, for example with `sc.exe failure`.
+// The actions also apply when the service stops with an error, which the service
+// does when the collector fails.
+func configureRecoveryActions(serviceName string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = m.Disconnect()
+	}()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	actions, err := s.RecoveryActions()
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		if err = s.SetRecoveryActions(defaultRecoveryActions, defaultRecoveryResetPeriod); err != nil {
+			return err
+		}
+	}
+	return s.SetRecoveryActionsOnNonCrashFailures(true)
+}
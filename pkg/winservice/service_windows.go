@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package winservice // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winservice"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/provider/envprovider"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpsprovider"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// Windows error codes returned when the service fails.
+const (
+	errorInvalidServiceControl = 1052 // ERROR_INVALID_SERVICE_CONTROL
+	errorExceptionInService    = 1064 // ERROR_EXCEPTION_IN_SERVICE
+	errorInvalidServiceName    = 1213 // ERROR_INVALID_SERVICENAME
+	errorEventLogCantStart     = 1501 // ERROR_EVENTLOG_CANT_START
+)
+
+// exitCodeCollectorFailed is the service specific exit code reported when the
+// collector stops on its own, so that the recovery actions of the service apply.
+const exitCodeCollectorFailed = 1
+
+// Option configures the service handler returned by NewSvcHandler.
+type Option func(*windowsService)
+
+// WithSessionChangeHandler calls handler with the type of the session change
+// events received by the service, e.g. windows.WTS_SESSION_LOCK, so that the
+// distribution can react to them. The events are logged in any case.
+func WithSessionChangeHandler(handler func(eventType uint32)) Option {
+	return func(s *windowsService) {
+		s.sessionChangeHandler = handler
+	}
+}
+
+// windowsService runs the collector as a Windows service. Unlike the handler of
+// otelcol, it reports the failures of the collector once running to the Service
+// Control Manager, and accepts the session change events.
+type windowsService struct {
+	settings             otelcol.CollectorSettings
+	col                  *otelcol.Collector
+	flags                *flag.FlagSet
+	configs              *configFlagValue
+	sessionChangeHandler func(eventType uint32)
+}
+
+// NewSvcHandler returns the handler running the collector as a Windows service,
+// to be used in place of otelcol.NewSvcHandler by the main of a distribution.
+func NewSvcHandler(set otelcol.CollectorSettings, opts ...Option) svc.Handler {
+	s := &windowsService{settings: set, configs: new(configFlagValue)}
+	s.flags = newFlags(s.configs)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Execute implements https://godoc.org/golang.org/x/sys/windows/svc#Handler
+func (s *windowsService) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (ssec bool, errno uint32) {
+	// The first argument supplied to service.Execute is the service name. If this is
+	// not provided for some reason, raise a relevant error to the system event log
+	if len(args) == 0 {
+		return false, errorInvalidServiceName
+	}
+	serviceName := args[0]
+
+	elog, err := eventlog.Open(serviceName)
+	if err != nil {
+		return false, errorEventLogCantStart
+	}
+	defer elog.Close()
+
+	if value, present := os.LookupEnv("NO_WINDOWS_SERVICE_RECOVERY"); !present || value == "0" {
+		if err = configureRecoveryActions(serviceName); err != nil {
+			_ = elog.Warning(eventIDRecoveryActions, fmt.Sprintf("failed to configure the recovery actions of the service: %v", err))
+		}
+	}
+
+	changes <- svc.Status{State: svc.StartPending}
+	colErrorChannel, err := s.start(elog)
+	if err != nil {
+		_ = elog.Error(eventIDStartFailed, fmt.Sprintf("failed to start service: %v", err))
+		return false, errorExceptionInService
+	}
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown | svc.AcceptSessionChange}
+
+	for {
+		select {
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+
+			case svc.SessionChange:
+				_ = elog.Info(eventIDSessionChange, fmt.Sprintf("session change event: %s", sessionChangeEventName(req.EventType)))
+				if s.sessionChangeHandler != nil {
+					s.sessionChangeHandler(req.EventType)
+				}
+
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				s.col.Shutdown()
+				if err = <-colErrorChannel; err != nil {
+					_ = elog.Error(eventIDStopFailed, fmt.Sprintf("errors occurred while shutting down the service: %v", err))
+				}
+				changes <- svc.Status{State: svc.Stopped}
+				return false, 0
+
+			default:
+				_ = elog.Error(eventIDUnexpectedControl, fmt.Sprintf("unexpected service control request #%d", req.Cmd))
+				return false, errorInvalidServiceControl
+			}
+
+		case err = <-colErrorChannel:
+			// The collector stopped without being requested to, typically after a
+			// component reported a fatal error.
+			if err == nil {
+				err = errors.New("collector stopped unexpectedly")
+			}
+			_ = elog.Error(eventIDCollectorFailed, fmt.Sprintf("collector failed: %v", err))
+			changes <- svc.Status{State: svc.Stopped}
+			return true, exitCodeCollectorFailed
+		}
+	}
+}
+
+// start starts the collector, returning once it is running. The returned channel
+// receives the result of the collector run.
+func (s *windowsService) start(elog *eventlog.Log) (<-chan error, error) {
+	// Append to new slice instead of the already existing s.settings.LoggingOptions slice to not change that.
+	s.settings.LoggingOptions = append(
+		[]zap.Option{zap.WrapCore(withWindowsCore(elog))},
+		s.settings.LoggingOptions...,
+	)
+	// Parse all the flags manually.
+	if err := s.flags.Parse(os.Args[1:]); err != nil {
+		return nil, err
+	}
+	if s.settings.ConfigProvider == nil {
+		uris := s.configs.uris()
+		if len(uris) == 0 {
+			return nil, errors.New("at least one config flag must be provided")
+		}
+		var err error
+		if s.settings.ConfigProvider, err = otelcol.NewConfigProvider(newConfigProviderSettings(uris)); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	s.col, err = otelcol.NewCollector(s.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// col.Run blocks until the collector is shut down, so needs to be started
+	// asynchronously, but it will exit early if an error occurs on startup
+	colErrorChannel := make(chan error, 1)
+	go func() {
+		colErrorChannel <- s.col.Run(context.Background())
+	}()
+
+	// wait until the collector server is in the Running state, or an error was returned
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for s.col.GetState() != otelcol.StateRunning {
+		select {
+		case err = <-colErrorChannel:
+			if err == nil {
+				err = errors.New("collector stopped while starting")
+			}
+			return nil, err
+		case <-ticker.C:
+		}
+	}
+	return colErrorChannel, nil
+}
+
+// sessionChangeEventName returns the name of the WTS_* session change event.
+func sessionChangeEventName(eventType uint32) string {
+	switch eventType {
+	case windows.WTS_CONSOLE_CONNECT:
+		return "console connect"
+	case windows.WTS_CONSOLE_DISCONNECT:
+		return "console disconnect"
+	case windows.WTS_REMOTE_CONNECT:
+		return "remote connect"
+	case windows.WTS_REMOTE_DISCONNECT:
+		return "remote disconnect"
+	case windows.WTS_SESSION_LOGON:
+		return "logon"
+	case windows.WTS_SESSION_LOGOFF:
+		return "logoff"
+	case windows.WTS_SESSION_LOCK:
+		return "lock"
+	case windows.WTS_SESSION_UNLOCK:
+		return "unlock"
+	case windows.WTS_SESSION_REMOTE_CONTROL:
+		return "remote control"
+	case windows.WTS_SESSION_CREATE:
+		return "create"
+	case windows.WTS_SESSION_TERMINATE:
+		return "terminate"
+	}
+	return fmt.Sprintf("#%d", eventType)
+}
+
+// configFlagValue collects the --config and --set flags, as otelcol does.
+type configFlagValue struct {
+	values []string
+	sets   []string
+}
+
+func (s *configFlagValue) Set(val string) error {
+	s.values = append(s.values, val)
+	return nil
+}
+
+func (s *configFlagValue) String() string {
+	return "[" + strings.Join(s.values, ", ") + "]"
+}
+
+func (s *configFlagValue) uris() []string {
+	return append(s.values, s.sets...)
+}
+
+// newFlags returns the flags of the collector command, which the service is
+// started with.
+func newFlags(cfgs *configFlagValue) *flag.FlagSet {
+	flagSet := new(flag.FlagSet)
+	flagSet.Var(cfgs, "config", "Locations to the config file(s).")
+	flagSet.Func("set", "Set arbitrary component config property.", func(s string) error {
+		idx := strings.Index(s, "=")
+		if idx == -1 {
+			return errors.New("missing equal sign")
+		}
+		cfgs.sets = append(cfgs.sets, "yaml:"+strings.TrimSpace(strings.ReplaceAll(s[:idx], ".", "::"))+": "+strings.TrimSpace(s[idx+1:]))
+		return nil
+	})
+	flagSet.Var(featuregate.NewFlag(featuregate.GlobalRegistry()), "feature-gates",
+		"Comma-delimited list of feature gate identifiers.")
+	return flagSet
+}
+
+func newConfigProviderSettings(uris []string) otelcol.ConfigProviderSettings {
+	providers := map[string]confmap.Provider{}
+	for _, provider := range []confmap.Provider{fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New(), httpsprovider.New()} {
+		providers[provider.Scheme()] = provider
+	}
+	return otelcol.ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:       uris,
+			Providers:  providers,
+			Converters: []confmap.Converter{expandconverter.New()},
+		},
+	}
+}
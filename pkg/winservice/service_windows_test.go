@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package winservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows"
+)
+
+func TestWindowsEventLogCoreEventIDs(t *testing.T) {
+	core := withWindowsCore(nil)(zapcore.NewNopCore()).(windowsEventLogCore)
+	assert.Equal(t, uint32(eventIDError), core.errorEventID)
+
+	exporterCore := core.With([]zapcore.Field{zap.String("kind", "exporter"), zap.String("name", "otlp")}).(windowsEventLogCore)
+	assert.Equal(t, uint32(eventIDExporterError), exporterCore.errorEventID)
+
+	// The fields of the components loggers are kept by the derived cores.
+	dataTypeCore := exporterCore.With([]zapcore.Field{zap.String("data_type", "traces")}).(windowsEventLogCore)
+	assert.Equal(t, uint32(eventIDExporterError), dataTypeCore.errorEventID)
+
+	otherCore := core.With([]zapcore.Field{zap.String("kind", "pipeline")}).(windowsEventLogCore)
+	assert.Equal(t, uint32(eventIDError), otherCore.errorEventID)
+}
+
+func TestSessionChangeEventName(t *testing.T) {
+	assert.Equal(t, "lock", sessionChangeEventName(windows.WTS_SESSION_LOCK))
+	assert.Equal(t, "unlock", sessionChangeEventName(windows.WTS_SESSION_UNLOCK))
+	assert.Equal(t, "#42", sessionChangeEventName(42))
+}
+
+func TestFlags(t *testing.T) {
+	cfgs := new(configFlagValue)
+	flagSet := newFlags(cfgs)
+	require.NoError(t, flagSet.Parse([]string{"--config=file:config.yaml", "--set=processors.batch.timeout=2s"}))
+	assert.Equal(t, []string{"file:config.yaml", "yaml:processors::batch::timeout: 2s"}, cfgs.uris())
+
+	assert.EqualError(t, newFlags(new(configFlagValue)).Parse([]string{"--set=processors"}),
+		`invalid value "processors" for flag -set: missing equal sign`)
+}
+
+func TestWithSessionChangeHandler(t *testing.T) {
+	var events []uint32
+	s := NewSvcHandler(otelcol.CollectorSettings{}, WithSessionChangeHandler(func(eventType uint32) {
+		events = append(events, eventType)
+	})).(*windowsService)
+	s.sessionChangeHandler(windows.WTS_SESSION_LOCK)
+	assert.Equal(t, []uint32{windows.WTS_SESSION_LOCK}, events)
+}
@@ -9,21 +9,20 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 type spanAttributesProcessor struct {
 	logger   *zap.Logger
-	attrProc *attraction.AttrProc
+	attrProc attrProcessor
 	skipExpr expr.BoolExpr[ottlspan.TransformContext]
 }
 
 // newTracesProcessor returns a processor that modifies attributes of a span.
 // To construct the attributes processors, the use of the factory methods are required
 // in order to validate the inputs.
-func newSpanAttributesProcessor(logger *zap.Logger, attrProc *attraction.AttrProc, skipExpr expr.BoolExpr[ottlspan.TransformContext]) *spanAttributesProcessor {
+func newSpanAttributesProcessor(logger *zap.Logger, attrProc attrProcessor, skipExpr expr.BoolExpr[ottlspan.TransformContext]) *spanAttributesProcessor {
 	return &spanAttributesProcessor{
 		logger:   logger,
 		attrProc: attrProc,
@@ -5,6 +5,7 @@ package attributesprocessor // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 
@@ -26,14 +27,35 @@ type Config struct {
 	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
 	// This is a required field.
 	attraction.Settings `mapstructure:",squash"`
+
+	// Rename renames attribute keys in bulk, before the actions are applied.
+	Rename RenameConfig `mapstructure:"rename"`
+}
+
+// RenameConfig configures the bulk renaming of attribute keys, for instance to migrate
+// to a new version of the semantic conventions.
+type RenameConfig struct {
+	// File is the path of a YAML file mapping the old keys to their new key.
+	// The file is reloaded when it changes.
+	File string `mapstructure:"file"`
+
+	// Presets are built-in mappings applied before the ones of the file.
+	// The supported preset is `semconv_http`, migrating the HTTP and network
+	// attributes to the stable semantic conventions.
+	Presets []string `mapstructure:"presets"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	if len(cfg.Actions) == 0 {
+	if len(cfg.Actions) == 0 && cfg.Rename.File == "" && len(cfg.Rename.Presets) == 0 {
 		return errors.New("missing required field \"actions\"")
 	}
+	for _, preset := range cfg.Rename.Presets {
+		if _, ok := renamePresets[preset]; !ok {
+			return fmt.Errorf("unknown rename preset %q, supported presets are %v", preset, presetNames())
+		}
+	}
 	return nil
 }
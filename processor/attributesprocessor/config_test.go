@@ -197,6 +197,20 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "rename"),
+			expected: &Config{
+				Settings: attraction.Settings{
+					Actions: []attraction.ActionKeyValue{
+						{Key: "http.response.status_code", Action: attraction.CONVERT, ConvertedType: "int"},
+					},
+				},
+				Rename: RenameConfig{
+					File:    "./testdata/rename.yaml",
+					Presets: []string{"semconv_http"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,3 +230,14 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRename(t *testing.T) {
+	cfg := &Config{Rename: RenameConfig{Presets: []string{"semconv_http"}}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg = &Config{Rename: RenameConfig{Presets: []string{"semconv_db"}}}
+	assert.EqualError(t, cfg.Validate(), `unknown rename preset "semconv_db", supported presets are [semconv_http]`)
+
+	cfg = &Config{}
+	assert.EqualError(t, cfg.Validate(), `missing required field "actions"`)
+}
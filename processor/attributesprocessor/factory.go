@@ -11,7 +11,6 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterlog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
@@ -43,7 +42,7 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
-	attrProc, err := attraction.NewAttrProc(&oCfg.Settings)
+	attrProc, err := newAttrProcessor(oCfg, set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +66,7 @@ func createLogsProcessor(
 	nextConsumer consumer.Logs,
 ) (processor.Logs, error) {
 	oCfg := cfg.(*Config)
-	attrProc, err := attraction.NewAttrProc(&oCfg.Settings)
+	attrProc, err := newAttrProcessor(oCfg, set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +93,7 @@ func createMetricsProcessor(
 ) (processor.Metrics, error) {
 
 	oCfg := cfg.(*Config)
-	attrProc, err := attraction.NewAttrProc(&oCfg.Settings)
+	attrProc, err := newAttrProcessor(oCfg, set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+)
+
+// renameReloadInterval is the minimum interval between two checks of the mapping file.
+const renameReloadInterval = 30 * time.Second
+
+// renamePresets holds the built-in mappings, migrating attributes to the stable semantic conventions.
+var renamePresets = map[string]map[string]string{
+	"semconv_http": {
+		"http.method":                          "http.request.method",
+		"http.status_code":                     "http.response.status_code",
+		"http.scheme":                          "url.scheme",
+		"http.url":                             "url.full",
+		"http.user_agent":                      "user_agent.original",
+		"http.client_ip":                       "client.address",
+		"http.request_content_length":          "http.request.body.size",
+		"http.response_content_length":         "http.response.body.size",
+		"http.resend_count":                    "http.request.resend_count",
+		"net.protocol.name":                    "network.protocol.name",
+		"net.protocol.version":                 "network.protocol.version",
+		"net.sock.peer.addr":                   "network.peer.address",
+		"net.sock.peer.port":                   "network.peer.port",
+		"net.sock.host.addr":                   "network.local.address",
+		"net.sock.host.port":                   "network.local.port",
+		"net.peer.name":                        "server.address",
+		"net.peer.port":                        "server.port",
+		"net.host.name":                        "server.address",
+		"net.host.port":                        "server.port",
+		"messaging.message.payload_size_bytes": "messaging.message.body.size",
+	},
+}
+
+// presetNames returns the sorted names of the presets, for error messages.
+func presetNames() []string {
+	names := make([]string, 0, len(renamePresets))
+	for name := range renamePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renameTable renames attribute keys following the configured presets and mapping file,
+// reloading the file when it changes.
+type renameTable struct {
+	file    string
+	presets []string
+	logger  *zap.Logger
+	now     func() time.Time
+
+	mu        sync.RWMutex
+	renames   map[string]string
+	modTime   time.Time
+	nextCheck time.Time
+}
+
+func newRenameTable(cfg RenameConfig, logger *zap.Logger) (*renameTable, error) {
+	t := &renameTable{
+		file:    cfg.File,
+		presets: cfg.Presets,
+		logger:  logger,
+		now:     time.Now,
+	}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// load builds the renames from the presets, overridden by the mapping file.
+func (t *renameTable) load() error {
+	renames := map[string]string{}
+	for _, preset := range t.presets {
+		for from, to := range renamePresets[preset] {
+			renames[from] = to
+		}
+	}
+
+	var modTime time.Time
+	if t.file != "" {
+		info, err := os.Stat(t.file)
+		if err != nil {
+			return err
+		}
+		modTime = info.ModTime()
+		data, err := os.ReadFile(t.file)
+		if err != nil {
+			return err
+		}
+		var mapping map[string]string
+		if err = yaml.Unmarshal(data, &mapping); err != nil {
+			return fmt.Errorf("failed to parse rename mapping file %q: %w", t.file, err)
+		}
+		for from, to := range mapping {
+			if from == "" || to == "" {
+				return fmt.Errorf("invalid rename of %q to %q in %q", from, to, t.file)
+			}
+			renames[from] = to
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.renames = renames
+	t.modTime = modTime
+	t.nextCheck = t.now().Add(renameReloadInterval)
+	return nil
+}
+
+// reloadIfChanged reloads the mapping file when it was modified since it was last loaded.
+// Failures are logged and the previous renames are kept.
+func (t *renameTable) reloadIfChanged() {
+	if t.file == "" {
+		return
+	}
+	t.mu.Lock()
+	now := t.now()
+	if now.Before(t.nextCheck) {
+		t.mu.Unlock()
+		return
+	}
+	t.nextCheck = now.Add(renameReloadInterval)
+	modTime := t.modTime
+	t.mu.Unlock()
+
+	info, err := os.Stat(t.file)
+	if err != nil {
+		t.logger.Warn("failed to check rename mapping file", zap.String("file", t.file), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(modTime) {
+		return
+	}
+	if err = t.load(); err != nil {
+		t.logger.Warn("failed to reload rename mapping file", zap.String("file", t.file), zap.Error(err))
+		return
+	}
+	t.logger.Info("reloaded rename mapping file", zap.String("file", t.file))
+}
+
+// rename moves the attributes found in the table to their new key. Attributes already
+// present under their new key are kept and the old ones are removed. Attributes renamed
+// to the same key are moved in the order of their old keys, the first one being kept.
+func (t *renameTable) rename(attrs pcommon.Map) {
+	t.mu.RLock()
+	renames := t.renames
+	t.mu.RUnlock()
+
+	if len(renames) == 0 {
+		return
+	}
+	var renamed []string
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		if _, ok := renames[k]; ok {
+			renamed = append(renamed, k)
+		}
+		return true
+	})
+	sort.Strings(renamed)
+	for _, from := range renamed {
+		to := renames[from]
+		if _, exists := attrs.Get(to); !exists {
+			value := attrs.PutEmpty(to)
+			old, _ := attrs.Get(from)
+			old.CopyTo(value)
+		}
+		attrs.Remove(from)
+	}
+}
+
+// attrProcessor applies the changes of the processor to attributes.
+type attrProcessor interface {
+	Process(ctx context.Context, logger *zap.Logger, attrs pcommon.Map)
+}
+
+// renamingAttrProc renames the attributes before applying the actions to them.
+type renamingAttrProc struct {
+	renames  *renameTable
+	attrProc *attraction.AttrProc
+}
+
+func (r *renamingAttrProc) Process(ctx context.Context, logger *zap.Logger, attrs pcommon.Map) {
+	r.renames.reloadIfChanged()
+	r.renames.rename(attrs)
+	r.attrProc.Process(ctx, logger, attrs)
+}
+
+// newAttrProcessor creates the processor applying the renames and the actions of the configuration.
+func newAttrProcessor(cfg *Config, logger *zap.Logger) (attrProcessor, error) {
+	attrProc, err := attraction.NewAttrProc(&cfg.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Rename.File == "" && len(cfg.Rename.Presets) == 0 {
+		return attrProc, nil
+	}
+	renames, err := newRenameTable(cfg.Rename, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &renamingAttrProc{renames: renames, attrProc: attrProc}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributesprocessor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+func TestRenameTable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rename.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("old.key: new.key\nhttp.method: method\n"), 0600))
+
+	table, err := newRenameTable(RenameConfig{File: file, Presets: []string{"semconv_http"}}, zap.NewNop())
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	require.NoError(t, attrs.FromRaw(map[string]any{
+		"old.key":                              "value",
+		"http.method":                          "GET",
+		"http.status_code":                     200,
+		"http.url":                             "https://example.com",
+		"url.full":                             "https://example.com/kept",
+		"net.host.name":                        "host",
+		"net.peer.name":                        "peer",
+		"net.peer.port":                        8080,
+		"messaging.message.payload_size_bytes": 42,
+		"other":                                true,
+	}))
+	table.rename(attrs)
+	assert.Equal(t, map[string]any{
+		"new.key":                     "value",
+		"method":                      "GET",
+		"http.response.status_code":   int64(200),
+		"url.full":                    "https://example.com/kept",
+		"server.address":              "host",
+		"server.port":                 int64(8080),
+		"messaging.message.body.size": int64(42),
+		"other":                       true,
+	}, attrs.AsRaw())
+}
+
+func TestRenameTableReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rename.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("a: b\n"), 0600))
+
+	table, err := newRenameTable(RenameConfig{File: file}, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Now()
+	table.now = func() time.Time { return now }
+
+	assertRenamed := func(expected string) {
+		t.Helper()
+		attrs := pcommon.NewMap()
+		attrs.PutStr("a", "value")
+		table.reloadIfChanged()
+		table.rename(attrs)
+		_, ok := attrs.Get(expected)
+		assert.True(t, ok, "expected %q in %v", expected, attrs.AsRaw())
+	}
+	assertRenamed("b")
+
+	require.NoError(t, os.WriteFile(file, []byte("a: c\n"), 0600))
+	require.NoError(t, os.Chtimes(file, now, now.Add(time.Minute)))
+	assertRenamed("b")
+
+	now = now.Add(renameReloadInterval)
+	assertRenamed("c")
+
+	// invalid files don't replace the previous renames
+	require.NoError(t, os.WriteFile(file, []byte("a: [d]\n"), 0600))
+	require.NoError(t, os.Chtimes(file, now, now.Add(2*time.Minute)))
+	now = now.Add(renameReloadInterval)
+	assertRenamed("c")
+}
+
+func TestRenameTableErrors(t *testing.T) {
+	_, err := newRenameTable(RenameConfig{File: filepath.Join(t.TempDir(), "missing.yaml")}, zap.NewNop())
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "rename.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("a: \"\"\n"), 0600))
+	_, err = newRenameTable(RenameConfig{File: file}, zap.NewNop())
+	assert.EqualError(t, err, `invalid rename of "a" to "" in "`+file+`"`)
+}
@@ -31,6 +31,15 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// Storage is the ID of a storage extension used to persist the state of the
+	// processor across restarts. If not set, the state is kept in memory only.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// StorageInterval is the interval at which the state is persisted to the storage
+	// extension, in addition to the shutdown of the processor. Set to 0 to only persist
+	// the state on shutdown.
+	StorageInterval time.Duration `mapstructure:"storage_interval"`
 }
 
 type MatchMetrics struct {
@@ -52,5 +61,8 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
+	if config.StorageInterval < 0 {
+		return fmt.Errorf("storage_interval must not be negative")
+	}
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewIDWithName("file_storage", "cumulativetodelta")
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -49,14 +50,24 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
-				InitialValue: tracking.InitialValueAuto,
+				MaxStaleness:    10 * time.Second,
+				InitialValue:    tracking.InitialValueAuto,
+				StorageInterval: defaultStorageInterval,
 			},
 		},
 		{
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				MaxStaleness:    time.Hour,
+				InitialValue:    tracking.InitialValueAuto,
+				Storage:         &storageID,
+				StorageInterval: 30 * time.Second,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "regexp"),
 			expected: &Config{
@@ -78,8 +89,9 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
-				InitialValue: tracking.InitialValueAuto,
+				MaxStaleness:    10 * time.Second,
+				InitialValue:    tracking.InitialValueAuto,
+				StorageInterval: defaultStorageInterval,
 			},
 		},
 		{
@@ -90,22 +102,29 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "missing_name"),
 			errorMessage: "metrics must be supplied if match_type is set",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_storage_interval"),
+			errorMessage: "storage_interval must not be negative",
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "auto"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueAuto,
+				InitialValue:    tracking.InitialValueAuto,
+				StorageInterval: defaultStorageInterval,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "keep"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueKeep,
+				InitialValue:    tracking.InitialValueKeep,
+				StorageInterval: defaultStorageInterval,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "drop"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueDrop,
+				InitialValue:    tracking.InitialValueDrop,
+				StorageInterval: defaultStorageInterval,
 			},
 		},
 	}
@@ -6,7 +6,10 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor"
@@ -17,8 +20,18 @@ import (
 
 var processorCapabilities = consumer.Capabilities{MutatesData: true}
 
+// defaultStorageInterval is the default interval at which the state is persisted.
+const defaultStorageInterval = time.Minute
+
+var once sync.Once
+
 // NewFactory returns a new factory for the Metrics Generation processor.
 func NewFactory() processor.Factory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(metricViews()...)
+	})
+
 	return processor.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
@@ -26,7 +39,9 @@ func NewFactory() processor.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		StorageInterval: defaultStorageInterval,
+	}
 }
 
 func createMetricsProcessor(
@@ -40,7 +55,7 @@ func createMetricsProcessor(
 		return nil, fmt.Errorf("configuration parsing error")
 	}
 
-	metricsProcessor := newCumulativeToDeltaProcessor(processorConfig, set.Logger)
+	metricsProcessor := newCumulativeToDeltaProcessor(processorConfig, set.ID, set.Logger)
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
@@ -49,5 +64,6 @@ func createMetricsProcessor(
 		nextConsumer,
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
 		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
@@ -71,12 +71,17 @@ type DeltaValue struct {
 	HistogramValue *HistogramPoint
 }
 
-func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, initalValue InitialValue) *MetricTracker {
+// StaleRemovedFunc is called after every sweep of stale states with the number of
+// states that were removed and the number of states that remain.
+type StaleRemovedFunc func(removed, remaining int)
+
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, initalValue InitialValue, onStaleRemoved StaleRemovedFunc) *MetricTracker {
 	t := &MetricTracker{
-		logger:       logger,
-		maxStaleness: maxStaleness,
-		initialValue: initalValue,
-		startTime:    pcommon.NewTimestampFromTime(time.Now()),
+		logger:         logger,
+		maxStaleness:   maxStaleness,
+		initialValue:   initalValue,
+		startTime:      pcommon.NewTimestampFromTime(time.Now()),
+		onStaleRemoved: onStaleRemoved,
 	}
 	if maxStaleness > 0 {
 		go t.sweeper(ctx, t.removeStale)
@@ -85,11 +90,52 @@ func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time
 }
 
 type MetricTracker struct {
-	logger       *zap.Logger
-	maxStaleness time.Duration
-	states       sync.Map
-	initialValue InitialValue
-	startTime    pcommon.Timestamp
+	logger         *zap.Logger
+	maxStaleness   time.Duration
+	states         sync.Map
+	initialValue   InitialValue
+	startTime      pcommon.Timestamp
+	onStaleRemoved StaleRemovedFunc
+}
+
+// Snapshot returns a copy of the last point of every tracked metric identity,
+// keyed by the hashable identity.
+func (t *MetricTracker) Snapshot() map[string]ValuePoint {
+	snapshot := make(map[string]ValuePoint)
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		point := s.PrevPoint
+		if point.HistogramValue != nil {
+			val := point.HistogramValue.Clone()
+			point.HistogramValue = &val
+		}
+		s.Unlock()
+		snapshot[key.(string)] = point
+		return true
+	})
+	return snapshot
+}
+
+// Restore tracks the points of a snapshot, as if they had been converted before, and
+// returns the number of points restored. Metric identities that are already tracked
+// are left untouched, and points older than maxStaleness are dropped as they would
+// have been removed by the sweeper.
+func (t *MetricTracker) Restore(snapshot map[string]ValuePoint) int {
+	var staleBefore pcommon.Timestamp
+	if t.maxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-t.maxStaleness))
+	}
+	restored := 0
+	for key, point := range snapshot {
+		if point.ObservedTimestamp < staleBefore {
+			continue
+		}
+		if _, loaded := t.states.LoadOrStore(key, &State{PrevPoint: point}); !loaded {
+			restored++
+		}
+	}
+	return restored
 }
 
 func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
@@ -203,6 +249,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 }
 
 func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
+	removed, remaining := 0, 0
 	t.states.Range(func(key, value interface{}) bool {
 		s := value.(*State)
 
@@ -225,9 +272,15 @@ func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
 		if lastObserved < staleBefore {
 			t.logger.Debug("removing stale state key", zap.String("key", key.(string)))
 			t.states.Delete(key)
+			removed++
+		} else {
+			remaining++
 		}
 		return true
 	})
+	if t.onStaleRemoved != nil {
+		t.onStaleRemoved(removed, remaining)
+	}
 }
 
 func (t *MetricTracker) sweeper(ctx context.Context, remove func(pcommon.Timestamp)) {
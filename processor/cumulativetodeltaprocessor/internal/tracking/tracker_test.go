@@ -194,7 +194,7 @@ func TestMetricTracker_Convert(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.initValue.String(), func(t *testing.T) {
-			m := NewMetricTracker(context.Background(), zap.NewNop(), 0, tt.initValue)
+			m := NewMetricTracker(context.Background(), zap.NewNop(), 0, tt.initValue, nil)
 
 			miSum := miSum
 			miSum.StartTimestamp = tt.metricStartTime
@@ -231,7 +231,7 @@ func TestMetricTracker_Convert(t *testing.T) {
 	}
 
 	t.Run("Invalid metric identity", func(t *testing.T) {
-		m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto)
+		m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto, nil)
 		invalidID := miIntSum
 		invalidID.MetricType = pmetric.MetricTypeGauge
 		_, valid := m.Convert(MetricPoint{
@@ -288,14 +288,20 @@ func Test_metricTracker_removeStale(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var removed, remaining int
 			tr := &MetricTracker{
 				logger:       zap.NewNop(),
 				maxStaleness: tt.fields.MaxStaleness,
+				onStaleRemoved: func(r, rem int) {
+					removed, remaining = r, rem
+				},
 			}
 			for k, v := range tt.fields.States {
 				tr.states.Store(k, v)
 			}
 			tr.removeStale(currentTime)
+			assert.Equal(t, len(tt.fields.States)-len(tt.wantOut), removed)
+			assert.Equal(t, len(tt.wantOut), remaining)
 
 			gotOut := make(map[string]*State)
 			tr.states.Range(func(key, value interface{}) bool {
@@ -342,3 +348,45 @@ func Test_metricTracker_sweeper(t *testing.T) {
 		t.Errorf("Sweeper did not terminate.")
 	}
 }
+
+func Test_metricTracker_snapshotRestore(t *testing.T) {
+	tr := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueKeep, nil)
+	sumID := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "requests",
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+	histogramID := sumID
+	histogramID.MetricType = pmetric.MetricTypeHistogram
+	histogramID.MetricName = "latency"
+
+	_, valid := tr.Convert(MetricPoint{Identity: sumID, Value: ValuePoint{ObservedTimestamp: 10, IntValue: 100}})
+	require.True(t, valid)
+	_, valid = tr.Convert(MetricPoint{Identity: histogramID, Value: ValuePoint{
+		ObservedTimestamp: 10,
+		HistogramValue:    &HistogramPoint{Count: 10, Sum: 5, Buckets: []uint64{4, 6}},
+	}})
+	require.True(t, valid)
+
+	snapshot := tr.Snapshot()
+	require.Len(t, snapshot, 2)
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueDrop, nil)
+	assert.Equal(t, 2, restored.Restore(snapshot))
+
+	out, valid := restored.Convert(MetricPoint{Identity: sumID, Value: ValuePoint{ObservedTimestamp: 20, IntValue: 130}})
+	require.True(t, valid)
+	assert.Equal(t, int64(30), out.IntValue)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+
+	out, valid = restored.Convert(MetricPoint{Identity: histogramID, Value: ValuePoint{
+		ObservedTimestamp: 20,
+		HistogramValue:    &HistogramPoint{Count: 15, Sum: 8, Buckets: []uint64{6, 9}},
+	}})
+	require.True(t, valid)
+	assert.Equal(t, &HistogramPoint{Count: 5, Sum: 3, Buckets: []uint64{2, 3}}, out.HistogramValue)
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/metadata"
+)
+
+var (
+	mStaleSeriesRemoved = stats.Int64("stale_series_removed", "Number of series removed from the state because they were not seen for max_staleness", stats.UnitDimensionless)
+	mTrackedSeries      = stats.Int64("tracked_series", "Number of series tracked in the state after the last removal of stale series", stats.UnitDimensionless)
+	mSeriesRestored     = stats.Int64("series_restored", "Number of series restored from the storage extension on start", stats.UnitDimensionless)
+)
+
+// metricViews returns the metrics views of the processor.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mStaleSeriesRemoved.Name()),
+			Measure:     mStaleSeriesRemoved,
+			Description: mStaleSeriesRemoved.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mTrackedSeries.Name()),
+			Measure:     mTrackedSeries,
+			Description: mTrackedSeries.Description(),
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mSeriesRestored.Name()),
+			Measure:     mSeriesRestored,
+			Description: mSeriesRestored.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
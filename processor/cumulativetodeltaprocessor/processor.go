@@ -4,30 +4,52 @@
 package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"fmt"
 	"math"
+	"sync"
+	"time"
 
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
+// stateKey is the key under which the tracked series are persisted in the storage.
+const stateKey = "states"
+
 type cumulativeToDeltaProcessor struct {
+	id              component.ID
 	includeFS       filterset.FilterSet
 	excludeFS       filterset.FilterSet
 	logger          *zap.Logger
 	deltaCalculator *tracking.MetricTracker
 	cancelFunc      context.CancelFunc
+	storageID       *component.ID
+	storageClient   storage.Client
+	storageInterval time.Duration
+	stopPersisting  context.CancelFunc
+	persistWG       sync.WaitGroup
 }
 
-func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
+func newCumulativeToDeltaProcessor(config *Config, id component.ID, logger *zap.Logger) *cumulativeToDeltaProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
+		id:              id,
 		logger:          logger,
-		deltaCalculator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue),
+		deltaCalculator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue, recordStaleRemoved),
 		cancelFunc:      cancel,
+		storageID:       config.Storage,
+		storageClient:   storage.NewNopClient(),
+		storageInterval: config.StorageInterval,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -106,11 +128,112 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+func recordStaleRemoved(removed, remaining int) {
+	stats.Record(context.Background(), mStaleSeriesRemoved.M(int64(removed)), mTrackedSeries.M(int64(remaining)))
+}
+
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+	extension, ok := host.GetExtensions()[*ctdp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", ctdp.storageID)
+	}
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", ctdp.storageID)
+	}
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, ctdp.id, "")
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	ctdp.storageClient = client
+	if err = ctdp.restoreState(ctx); err != nil {
+		return err
+	}
+	if ctdp.storageInterval > 0 {
+		ctdp.startPersisting()
+	}
+	return nil
+}
+
+// restoreState tracks the series persisted last, so that their next points are
+// converted instead of being handled as initial values. The persisted series are
+// deleted once restored, so that a collector stopping before persisting its state
+// again does not restore the same, outdated, points on its next start.
+func (ctdp *cumulativeToDeltaProcessor) restoreState(ctx context.Context) error {
+	data, err := ctdp.storageClient.Get(ctx, stateKey)
+	if err != nil {
+		return fmt.Errorf("failed to read state from storage: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	var snapshot map[string]tracking.ValuePoint
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot); err != nil {
+		// a corrupted state must not prevent the collector from starting
+		ctdp.logger.Warn("failed to decode state from storage, starting without it", zap.Error(err))
+	} else {
+		restored := ctdp.deltaCalculator.Restore(snapshot)
+		stats.Record(ctx, mSeriesRestored.M(int64(restored)))
+		ctdp.logger.Debug("restored state from storage", zap.Int("series", restored), zap.Int("stale", len(snapshot)-restored))
+	}
+	if err = ctdp.storageClient.Delete(ctx, stateKey); err != nil {
+		return fmt.Errorf("failed to delete state from storage: %w", err)
+	}
 	return nil
 }
 
+// startPersisting persists the tracked series every storage interval, so that they
+// are not lost if the collector is not shut down gracefully.
+func (ctdp *cumulativeToDeltaProcessor) startPersisting() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctdp.stopPersisting = cancel
+	ctdp.persistWG.Add(1)
+	go ctdp.persistPeriodically(ctx)
+}
+
+func (ctdp *cumulativeToDeltaProcessor) persistPeriodically(ctx context.Context) {
+	defer ctdp.persistWG.Done()
+	ticker := time.NewTicker(ctdp.storageInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctdp.persistState(ctx); err != nil {
+				ctdp.logger.Warn("failed to persist state", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// persistState writes the tracked series to the storage.
+func (ctdp *cumulativeToDeltaProcessor) persistState(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ctdp.deltaCalculator.Snapshot()); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := ctdp.storageClient.Set(ctx, stateKey, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write state to storage: %w", err)
+	}
+	return nil
+}
+
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	if ctdp.stopPersisting != nil {
+		ctdp.stopPersisting()
+		ctdp.persistWG.Wait()
+	}
+	if ctdp.storageID == nil {
+		return nil
+	}
+	return multierr.Append(ctdp.persistState(ctx), ctdp.storageClient.Close(ctx))
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
@@ -4,7 +4,9 @@
 package cumulativetodeltaprocessor
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"math"
 	"testing"
 	"time"
@@ -19,7 +21,10 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
 var (
@@ -534,6 +539,112 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorRestoresState(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+	metrics := func(value float64) pmetric.Metrics {
+		return generateTestSumMetrics(testSumMetric{
+			metricNames:  []string{"metric_1"},
+			metricValues: [][]float64{{value}},
+			isCumulative: []bool{true},
+			isMonotonic:  []bool{true},
+		})
+	}
+
+	run := func(value float64) *consumertest.MetricsSink {
+		next := new(consumertest.MetricsSink)
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+		mgp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+		require.NoError(t, err)
+		require.NoError(t, mgp.Start(context.Background(), host))
+		require.NoError(t, mgp.ConsumeMetrics(context.Background(), metrics(value)))
+		require.NoError(t, mgp.Shutdown(context.Background()))
+		return next
+	}
+
+	// the first point of the series is stored, but not sent
+	next := run(100)
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Equal(t, 0, next.AllMetrics()[0].DataPointCount())
+
+	// after a restart, the series is known and the delta is sent
+	next = run(130)
+	require.Len(t, next.AllMetrics(), 1)
+	dps := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 30.0, dps.At(0).DoubleValue())
+}
+
+func TestCumulativeToDeltaProcessorStateLifecycle(t *testing.T) {
+	ctx := context.Background()
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxStaleness = time.Hour
+	cfg.StorageInterval = 10 * time.Millisecond
+	ctdp := newCumulativeToDeltaProcessor(cfg, component.NewID(metadata.Type), zap.NewNop())
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(metadata.Type), "")
+	ctdp.storageClient = client
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(map[string]tracking.ValuePoint{
+		"fresh": {ObservedTimestamp: pcommon.NewTimestampFromTime(time.Now()), IntValue: 10},
+		"stale": {ObservedTimestamp: pcommon.NewTimestampFromTime(time.Now().Add(-2 * time.Hour)), IntValue: 10},
+	}))
+	require.NoError(t, client.Set(ctx, stateKey, buf.Bytes()))
+
+	// stale points are not restored, and the persisted state is deleted once restored
+	require.NoError(t, ctdp.restoreState(ctx))
+	assert.Len(t, ctdp.deltaCalculator.Snapshot(), 1)
+	data, err := client.Get(ctx, stateKey)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	// the state is persisted again without waiting for the shutdown
+	ctdp.startPersisting()
+	assert.Eventually(t, func() bool {
+		data, err = client.Get(ctx, stateKey)
+		return err == nil && data != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, ctdp.shutdown(ctx))
+
+	var snapshot map[string]tracking.ValuePoint
+	require.NoError(t, gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot))
+	assert.Contains(t, snapshot, "fresh")
+	assert.NotContains(t, snapshot, "stale")
+}
+
+func TestCumulativeToDeltaProcessorStorageErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		storageID component.ID
+		host      component.Host
+		wantErr   string
+	}{
+		{
+			name:      "missing extension",
+			storageID: storagetest.NewStorageID("test"),
+			host:      storagetest.NewStorageHost(),
+			wantErr:   "storage extension 'test_storage/test' not found",
+		},
+		{
+			name:      "non-storage extension",
+			storageID: storagetest.NewNonStorageID("test"),
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+			wantErr:   "non-storage extension 'non_storage/test' found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Storage = &tt.storageID
+			mgp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+			assert.EqualError(t, mgp.Start(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the delta to cumulative processor.
+type Config struct {
+	// MaxStale is the time after which a stream that received no data point is
+	// forgotten. Set to 0 to never forget streams.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// State configures the export of the state on shutdown and its import on start.
+	State StateConfig `mapstructure:"state"`
+}
+
+// StateConfig configures where the state of the processor is exported on shutdown
+// and imported from on start, so that another instance of the collector can carry
+// on with the cumulative values.
+type StateConfig struct {
+	// Identity is the identity of the state, stable across the instances that take
+	// over from each other, such as the ordinal of a replica.
+	Identity string `mapstructure:"identity"`
+	// Directory is the directory where the state is written, as a file named after
+	// the identity.
+	Directory string `mapstructure:"directory"`
+	// Storage is the ID of the storage extension where the state is written, under
+	// a key made of the identity.
+	Storage *component.ID `mapstructure:"storage"`
+}
+
+func (cfg *StateConfig) enabled() bool {
+	return cfg.Identity != ""
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxStale < 0 {
+		return errors.New("max_stale must not be negative")
+	}
+	state := cfg.State
+	if !state.enabled() {
+		if state.Directory != "" || state.Storage != nil {
+			return errors.New("state.identity must be set to export and import the state")
+		}
+		return nil
+	}
+	if strings.ContainsAny(state.Identity, `/\`) || state.Identity == "." || state.Identity == ".." {
+		return errors.New("state.identity must not be a path")
+	}
+	if (state.Directory == "") == (state.Storage == nil) {
+		return errors.New("exactly one of state.directory and state.storage must be set")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "directory"),
+			expected: &Config{
+				MaxStale: 10 * time.Minute,
+				State: StateConfig{
+					Identity:  "collector-0",
+					Directory: "/var/lib/otelcol/deltatocumulative",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				State: StateConfig{
+					Identity: "collector-1",
+					Storage:  &storageID,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "negative max_stale",
+			config: Config{MaxStale: -time.Second},
+			err:    "max_stale must not be negative",
+		},
+		{
+			name:   "directory without identity",
+			config: Config{State: StateConfig{Directory: "/tmp"}},
+			err:    "state.identity must be set to export and import the state",
+		},
+		{
+			name:   "identity without store",
+			config: Config{State: StateConfig{Identity: "collector-0"}},
+			err:    "exactly one of state.directory and state.storage must be set",
+		},
+		{
+			name:   "directory and storage",
+			config: Config{State: StateConfig{Identity: "collector-0", Directory: "/tmp", Storage: &storageID}},
+			err:    "exactly one of state.directory and state.storage must be set",
+		},
+		{
+			name:   "identity is a path",
+			config: Config{State: StateConfig{Identity: "../collector-0", Directory: "/tmp"}},
+			err:    "state.identity must not be a path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.config.Validate(), tt.err)
+		})
+	}
+}
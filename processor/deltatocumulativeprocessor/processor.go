@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+type deltaToCumulative struct {
+	id       component.ID
+	logger   *zap.Logger
+	maxStale time.Duration
+	state    StateConfig
+	now      func() time.Time
+
+	mu      sync.Mutex
+	streams map[string]*stream
+
+	store  stateStore
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDeltaToCumulative(cfg *Config, id component.ID, logger *zap.Logger) *deltaToCumulative {
+	return &deltaToCumulative{
+		id:       id,
+		logger:   logger,
+		maxStale: cfg.MaxStale,
+		state:    cfg.State,
+		now:      time.Now,
+		streams:  make(map[string]*stream),
+	}
+}
+
+func (p *deltaToCumulative) start(ctx context.Context, host component.Host) error {
+	if p.state.enabled() {
+		store, err := newStateStore(ctx, host, p.id, p.state)
+		if err != nil {
+			return err
+		}
+		p.store = store
+		p.importState(ctx)
+	}
+	if p.maxStale > 0 {
+		var sweepCtx context.Context
+		sweepCtx, p.cancel = context.WithCancel(context.Background())
+		p.done = make(chan struct{})
+		go p.sweep(sweepCtx)
+	}
+	return nil
+}
+
+func (p *deltaToCumulative) shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+		<-p.done
+	}
+	if p.store == nil {
+		return nil
+	}
+	return multierr.Append(p.exportState(ctx), p.store.close(ctx))
+}
+
+// importState tracks the streams of the state exported by the last instance with
+// the same identity.
+func (p *deltaToCumulative) importState(ctx context.Context) {
+	s, err := p.store.load(ctx)
+	if err != nil {
+		// the cumulative values start over, as they would without state
+		p.logger.Warn("failed to import state", zap.String("identity", p.state.Identity), zap.Error(err))
+		return
+	}
+	if s == nil {
+		p.logger.Info("no state to import", zap.String("identity", p.state.Identity))
+		return
+	}
+	if s.Identity != p.state.Identity {
+		p.logger.Warn("ignoring the state of another identity", zap.String("identity", p.state.Identity), zap.String("state_identity", s.Identity))
+		return
+	}
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, st := range s.Streams {
+		if _, ok := p.streams[key]; ok {
+			continue
+		}
+		st.lastSeen = now
+		p.streams[key] = st
+	}
+	p.logger.Info("imported state", zap.String("identity", p.state.Identity), zap.Int("streams", len(s.Streams)))
+}
+
+// exportState writes the streams, so that the next instance with the same identity
+// carries on with their cumulative values.
+func (p *deltaToCumulative) exportState(ctx context.Context) error {
+	p.mu.Lock()
+	s := &snapshot{Identity: p.state.Identity, Streams: p.streams}
+	err := p.store.save(ctx, s)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	p.logger.Info("exported state", zap.String("identity", p.state.Identity), zap.Int("streams", len(s.Streams)))
+	return nil
+}
+
+func (p *deltaToCumulative) sweep(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.maxStale)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.removeStale()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// removeStale forgets the streams that received no data point for max_stale.
+func (p *deltaToCumulative) removeStale() {
+	staleBefore := p.now().Add(-p.maxStale)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, s := range p.streams {
+		if s.lastSeen.Before(staleBefore) {
+			delete(p.streams, key)
+		}
+	}
+}
+
+func (p *deltaToCumulative) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					sum := m.Sum()
+					if sum.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return !p.convertNumber(rm.Resource(), sm.Scope(), m, dp, now)
+					})
+					sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return sum.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					histogram := m.Histogram()
+					if histogram.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					histogram.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						return !p.convertHistogram(rm.Resource(), sm.Scope(), m, dp, now)
+					})
+					histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return histogram.DataPoints().Len() == 0
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+					fallthrough
+				default:
+					return false
+				}
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// convertNumber replaces the delta value of the data point with the cumulative one.
+// It returns false if the data point must be dropped.
+func (p *deltaToCumulative) convertNumber(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, dp pmetric.NumberDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() || dp.ValueType() == pmetric.NumberDataPointValueTypeEmpty {
+		return false
+	}
+	extra := dp.ValueType().String()
+	if m.Sum().IsMonotonic() {
+		extra += "/monotonic"
+	}
+	key := streamKey(res, scope, m, dp.Attributes(), extra)
+	s, ok := p.streams[key]
+	if !ok {
+		s = newNumberStream(dp)
+		p.streams[key] = s
+	} else if dp.Timestamp() <= s.Last {
+		p.logger.Debug("dropping out of order data point", zap.String("metric", m.Name()))
+		return false
+	}
+	s.lastSeen = now
+	s.addNumber(dp)
+	return true
+}
+
+// convertHistogram replaces the delta values of the data point with the cumulative
+// ones. It returns false if the data point must be dropped.
+func (p *deltaToCumulative) convertHistogram(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, dp pmetric.HistogramDataPoint, now time.Time) bool {
+	if dp.Flags().NoRecordedValue() {
+		return false
+	}
+	key := streamKey(res, scope, m, dp.Attributes(), "")
+	s, ok := p.streams[key]
+	switch {
+	case !ok:
+		s = newHistogramStream(dp)
+		p.streams[key] = s
+	case dp.Timestamp() <= s.Last:
+		p.logger.Debug("dropping out of order data point", zap.String("metric", m.Name()))
+		return false
+	case !s.sameBounds(dp):
+		// the buckets can't be added up, the cumulative values start over
+		prev := s
+		s = newHistogramStream(dp)
+		if dp.StartTimestamp() == 0 {
+			s.Start = prev.Last
+		}
+		p.streams[key] = s
+	}
+	s.lastSeen = now
+	s.addHistogram(dp)
+	return true
+}
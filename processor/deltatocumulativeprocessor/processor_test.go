@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newSum(temporality pmetric.AggregationTemporality, start, ts pcommon.Timestamp, value int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(value)
+	dp.Attributes().PutStr("route", "/cart")
+	return md
+}
+
+func newHistogram(start, ts pcommon.Timestamp, bounds []float64, buckets []uint64, sum, min, max float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	histogram := m.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := histogram.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(buckets)
+	var count uint64
+	for _, c := range buckets {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(min)
+	dp.SetMax(max)
+	return md
+}
+
+func sumPoints(md pmetric.Metrics) pmetric.NumberDataPointSlice {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+}
+
+func histogramPoints(md pmetric.Metrics) pmetric.HistogramDataPointSlice {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+}
+
+func TestConvertSum(t *testing.T) {
+	p := newDeltaToCumulative(&Config{}, component.NewID("deltatocumulative"), zap.NewNop())
+
+	for i, want := range []int64{5, 8, 15} {
+		ts := pcommon.Timestamp(100 * (i + 1))
+		md, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, ts-100, ts, []int64{5, 3, 7}[i]))
+		require.NoError(t, err)
+		require.Equal(t, pmetric.AggregationTemporalityCumulative, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().AggregationTemporality())
+		dp := sumPoints(md).At(0)
+		assert.Equal(t, want, dp.IntValue())
+		// the first point has no start timestamp, its timestamp is used instead
+		assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+		assert.Equal(t, ts, dp.Timestamp())
+	}
+
+	// out of order points are dropped, dropping the empty metric
+	md, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, 100, 200, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// cumulative sums are left as is
+	md, err = p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityCumulative, 0, 400, 1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), sumPoints(md).At(0).IntValue())
+}
+
+func TestConvertHistogram(t *testing.T) {
+	p := newDeltaToCumulative(&Config{}, component.NewID("deltatocumulative"), zap.NewNop())
+	bounds := []float64{10, 100}
+
+	md, err := p.processMetrics(context.Background(), newHistogram(0, 100, bounds, []uint64{1, 2, 0}, 60, 5, 40))
+	require.NoError(t, err)
+	dp := histogramPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+
+	md, err = p.processMetrics(context.Background(), newHistogram(100, 200, bounds, []uint64{0, 1, 1}, 250, 20, 200))
+	require.NoError(t, err)
+	dp = histogramPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 310.0, dp.Sum())
+	assert.Equal(t, 5.0, dp.Min())
+	assert.Equal(t, 200.0, dp.Max())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+
+	// a change of the boundaries starts over
+	md, err = p.processMetrics(context.Background(), newHistogram(0, 300, []float64{50}, []uint64{2, 1}, 90, 10, 70))
+	require.NoError(t, err)
+	dp = histogramPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(200), dp.StartTimestamp())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, []uint64{2, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestRemoveStale(t *testing.T) {
+	p := newDeltaToCumulative(&Config{MaxStale: time.Minute}, component.NewID("deltatocumulative"), zap.NewNop())
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, 0, 100, 5))
+	require.NoError(t, err)
+	p.removeStale()
+	assert.Len(t, p.streams, 1)
+
+	now = now.Add(2 * time.Minute)
+	p.removeStale()
+	assert.Empty(t, p.streams)
+
+	md, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, 100, 200, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sumPoints(md).At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(100), sumPoints(md).At(0).StartTimestamp())
+}
+
+// runInstance starts an instance of the processor, sends it a delta data point and
+// shuts it down, like a replica replaced during a rolling upgrade.
+func runInstance(t *testing.T, cfg *Config, host component.Host, ts pcommon.Timestamp, value int64) int64 {
+	next := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, ts-100, ts, value)))
+	require.NoError(t, mp.Shutdown(context.Background()))
+	require.Len(t, next.AllMetrics(), 1)
+	dp := sumPoints(next.AllMetrics()[0]).At(0)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	return dp.IntValue()
+}
+
+func TestStateDirectory(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{MaxStale: time.Minute, State: StateConfig{Identity: "collector-0", Directory: dir}}
+	host := componenttest.NewNopHost()
+
+	assert.Equal(t, int64(5), runInstance(t, cfg, host, 200, 5))
+	assert.FileExists(t, filepath.Join(dir, "collector-0.state"))
+	assert.Equal(t, int64(8), runInstance(t, cfg, host, 300, 3))
+
+	// another identity doesn't import the state
+	other := &Config{State: StateConfig{Identity: "collector-1", Directory: dir}}
+	md := newSum(pmetric.AggregationTemporalityDelta, 300, 400, 2)
+	p := newDeltaToCumulative(other, component.NewID("deltatocumulative"), zap.NewNop())
+	require.NoError(t, p.start(context.Background(), host))
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), sumPoints(md).At(0).IntValue())
+	require.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestStateStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("state")
+	cfg := &Config{State: StateConfig{Identity: "collector-0", Storage: &storageID}}
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("state", t.TempDir())
+
+	assert.Equal(t, int64(5), runInstance(t, cfg, host, 200, 5))
+	assert.Equal(t, int64(8), runInstance(t, cfg, host, 300, 3))
+}
+
+func TestStateErrors(t *testing.T) {
+	storageID := storagetest.NewStorageID("state")
+	nonStorageID := storagetest.NewNonStorageID("state")
+	tests := []struct {
+		name    string
+		state   StateConfig
+		host    component.Host
+		wantErr string
+	}{
+		{
+			name:    "missing extension",
+			state:   StateConfig{Identity: "collector-0", Storage: &storageID},
+			host:    storagetest.NewStorageHost(),
+			wantErr: "storage extension 'test_storage/state' not found",
+		},
+		{
+			name:    "non-storage extension",
+			state:   StateConfig{Identity: "collector-0", Storage: &nonStorageID},
+			host:    storagetest.NewStorageHost().WithNonStorageExtension("state"),
+			wantErr: "non-storage extension 'non_storage/state' found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newDeltaToCumulative(&Config{State: tt.state}, component.NewID("deltatocumulative"), zap.NewNop())
+			assert.EqualError(t, p.start(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
+
+func TestCorruptedStateIsIgnored(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "collector-0.state"), []byte("not a state"), 0600))
+	cfg := &Config{State: StateConfig{Identity: "collector-0", Directory: dir}}
+
+	assert.Equal(t, int64(5), runInstance(t, cfg, componenttest.NewNopHost(), 200, 5))
+	assert.Equal(t, int64(8), runInstance(t, cfg, componenttest.NewNopHost(), 300, 3))
+}
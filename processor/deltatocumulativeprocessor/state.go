@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// snapshot is the state exported by an instance of the processor.
+type snapshot struct {
+	Identity string
+	Streams  map[string]*stream
+}
+
+func encodeSnapshot(s *snapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeSnapshot(data []byte) (*snapshot, error) {
+	s := &snapshot{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	return s, nil
+}
+
+// stateStore is where the state is exported to and imported from. load returns
+// nil if no state was exported yet.
+type stateStore interface {
+	load(ctx context.Context) (*snapshot, error)
+	save(ctx context.Context, s *snapshot) error
+	close(ctx context.Context) error
+}
+
+func newStateStore(ctx context.Context, host component.Host, id component.ID, cfg StateConfig) (stateStore, error) {
+	if cfg.Directory != "" {
+		return &fileStore{path: filepath.Join(cfg.Directory, cfg.Identity+".state")}, nil
+	}
+	extension, ok := host.GetExtensions()[*cfg.Storage]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", cfg.Storage)
+	}
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", cfg.Storage)
+	}
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, id, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage client: %w", err)
+	}
+	return &storageStore{client: client, key: "state/" + cfg.Identity}, nil
+}
+
+// fileStore keeps the state in a file, typically on a volume shared by the
+// instances that take over from each other.
+type fileStore struct {
+	path string
+}
+
+func (f *fileStore) load(context.Context) (*snapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	return decodeSnapshot(data)
+}
+
+func (f *fileStore) save(_ context.Context, s *snapshot) error {
+	data, err := encodeSnapshot(s)
+	if err != nil {
+		return err
+	}
+	// write and rename, so that a concurrent load never reads a partial state
+	tmp := f.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err = os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+func (f *fileStore) close(context.Context) error {
+	return nil
+}
+
+// storageStore keeps the state in a storage extension.
+type storageStore struct {
+	client storage.Client
+	key    string
+}
+
+func (s *storageStore) load(ctx context.Context) (*snapshot, error) {
+	data, err := s.client.Get(ctx, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	return decodeSnapshot(data)
+}
+
+func (s *storageStore) save(ctx context.Context, snap *snapshot) error {
+	data, err := encodeSnapshot(snap)
+	if err != nil {
+		return err
+	}
+	if err = s.client.Set(ctx, s.key, data); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+func (s *storageStore) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
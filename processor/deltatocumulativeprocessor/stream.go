@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const keySeparator = "\x1e"
+
+// stream is the cumulative value of the data points of a stream. Its fields are
+// exported so that it can be part of the exported state.
+type stream struct {
+	// Start is the start timestamp of the cumulative value.
+	Start pcommon.Timestamp
+	// Last is the timestamp of the last data point of the stream.
+	Last pcommon.Timestamp
+
+	IntValue    int64
+	DoubleValue float64
+
+	Count   uint64
+	Sum     float64
+	HasMin  bool
+	Min     float64
+	HasMax  bool
+	Max     float64
+	Bounds  []float64
+	Buckets []uint64
+
+	// lastSeen is when the processor last received a data point of the stream.
+	lastSeen time.Time
+}
+
+// streamKey returns the identity of the stream of a data point. It is stable
+// across instances of the collector, as it is part of the exported state.
+func streamKey(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, attrs pcommon.Map, extra string) string {
+	resHash := pdatautil.MapHash(res.Attributes())
+	attrsHash := pdatautil.MapHash(attrs)
+	return strings.Join([]string{
+		string(resHash[:]),
+		scope.Name(),
+		scope.Version(),
+		m.Type().String(),
+		m.Name(),
+		m.Unit(),
+		extra,
+		string(attrsHash[:]),
+	}, keySeparator)
+}
+
+// newNumberStream starts a stream with the first data point received.
+func newNumberStream(dp pmetric.NumberDataPoint) *stream {
+	s := &stream{Start: dp.StartTimestamp()}
+	if s.Start == 0 {
+		s.Start = dp.Timestamp()
+	}
+	return s
+}
+
+// addNumber adds a delta data point to the stream and replaces its value with the
+// cumulative one.
+func (s *stream) addNumber(dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		s.IntValue += dp.IntValue()
+		dp.SetIntValue(s.IntValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		s.DoubleValue += dp.DoubleValue()
+		dp.SetDoubleValue(s.DoubleValue)
+	case pmetric.NumberDataPointValueTypeEmpty:
+	}
+	s.Last = dp.Timestamp()
+	dp.SetStartTimestamp(s.Start)
+}
+
+// newHistogramStream starts a stream with the first data point received, or after
+// a change of the bucket boundaries.
+func newHistogramStream(dp pmetric.HistogramDataPoint) *stream {
+	s := &stream{
+		Start:   dp.StartTimestamp(),
+		Bounds:  dp.ExplicitBounds().AsRaw(),
+		Buckets: make([]uint64, dp.BucketCounts().Len()),
+	}
+	if s.Start == 0 {
+		s.Start = dp.Timestamp()
+	}
+	return s
+}
+
+// sameBounds reports whether the data point has the bucket boundaries of the stream.
+func (s *stream) sameBounds(dp pmetric.HistogramDataPoint) bool {
+	if dp.ExplicitBounds().Len() != len(s.Bounds) || dp.BucketCounts().Len() != len(s.Buckets) {
+		return false
+	}
+	for i, bound := range s.Bounds {
+		if dp.ExplicitBounds().At(i) != bound {
+			return false
+		}
+	}
+	return true
+}
+
+// addHistogram adds a delta data point to the stream and replaces its values with
+// the cumulative ones.
+func (s *stream) addHistogram(dp pmetric.HistogramDataPoint) {
+	s.Count += dp.Count()
+	dp.SetCount(s.Count)
+	if dp.HasSum() {
+		s.Sum += dp.Sum()
+		dp.SetSum(s.Sum)
+	}
+	if dp.HasMin() {
+		if !s.HasMin || dp.Min() < s.Min {
+			s.HasMin, s.Min = true, dp.Min()
+		}
+		dp.SetMin(s.Min)
+	}
+	if dp.HasMax() {
+		if !s.HasMax || dp.Max() > s.Max {
+			s.HasMax, s.Max = true, dp.Max()
+		}
+		dp.SetMax(s.Max)
+	}
+	for i := range s.Buckets {
+		s.Buckets[i] += dp.BucketCounts().At(i)
+	}
+	dp.BucketCounts().FromRaw(s.Buckets)
+	s.Last = dp.Timestamp()
+	dp.SetStartTimestamp(s.Start)
+}
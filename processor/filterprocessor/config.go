@@ -6,6 +6,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
@@ -36,6 +37,48 @@ type Config struct {
 	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 
 	Traces TraceFilters `mapstructure:"traces"`
+
+	// Macros are named conditions that can be referenced from the OTTL conditions.
+	Macros ottl.Macros `mapstructure:"macros"`
+
+	// MacroFiles are YAML files defining macros, shared with other components.
+	MacroFiles []string `mapstructure:"macro_files"`
+
+	// DryRun reports what the OTTL conditions would drop without dropping it.
+	DryRun DryRunConfig `mapstructure:"dry_run"`
+}
+
+// DryRunConfig configures the dry-run mode, used to validate conditions against real traffic
+// before enabling them.
+type DryRunConfig struct {
+	// Enabled keeps all telemetry: the items matched by each OTTL condition are only counted
+	// by the processor_filter_dry_run_matched metric.
+	Enabled bool `mapstructure:"enabled"`
+
+	// AnnotationKey is the attribute set to the name of the first condition matching a span, span event,
+	// datapoint or log record. Matching items are not annotated if empty.
+	AnnotationKey string `mapstructure:"annotation_key"`
+
+	// RuleNames names the conditions, mapping the names to the conditions as configured. The
+	// conditions without name are named after their context and position, e.g. `log_record/0`.
+	RuleNames map[string]string `mapstructure:"rule_names"`
+}
+
+// ruleNames returns the names of the conditions, indexed by condition.
+func (d DryRunConfig) ruleNames() map[string]string {
+	names := make(map[string]string, len(d.RuleNames))
+	for name, condition := range d.RuleNames {
+		names[condition] = name
+	}
+	return names
+}
+
+// ruleName returns the name of the condition at the given position of a context.
+func (d DryRunConfig) ruleName(names map[string]string, ottlContext string, index int, condition string) string {
+	if name, ok := names[condition]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s/%d", ottlContext, index)
 }
 
 // MetricFilters filters by Metric properties.
@@ -276,30 +319,60 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for logs at the same time")
 	}
 
+	if cfg.DryRun.Enabled && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil || cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil || cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
+		return fmt.Errorf("dry_run is only supported with ottl conditions")
+	}
+	if !cfg.DryRun.Enabled && cfg.DryRun.AnnotationKey != "" {
+		return fmt.Errorf("dry_run::annotation_key requires dry_run::enabled")
+	}
+	if err := cfg.validateRuleNames(); err != nil {
+		return err
+	}
+
+	macros, err := cfg.loadMacros()
+	if err != nil {
+		return err
+	}
+
 	var errors error
 
 	if cfg.Traces.SpanConditions != nil {
-		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := macros.ExpandConditions(cfg.Traces.SpanConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanEventConditions != nil {
-		_, err := filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := macros.ExpandConditions(cfg.Traces.SpanEventConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForSpanEvent(conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.MetricConditions != nil {
-		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := macros.ExpandConditions(cfg.Metrics.MetricConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForMetric(conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.DataPointConditions != nil {
-		_, err := filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := macros.ExpandConditions(cfg.Metrics.DataPointConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.LogConditions != nil {
-		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := macros.ExpandConditions(cfg.Logs.LogConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
@@ -313,3 +386,57 @@ func (cfg *Config) Validate() error {
 
 	return errors
 }
+
+// validateRuleNames checks that the names of the dry run rules name distinct conditions which are configured.
+func (cfg *Config) validateRuleNames() error {
+	if len(cfg.DryRun.RuleNames) == 0 {
+		return nil
+	}
+	if !cfg.DryRun.Enabled {
+		return fmt.Errorf("dry_run::rule_names requires dry_run::enabled")
+	}
+	configured := map[string]bool{}
+	for _, conditions := range [][]string{
+		cfg.Traces.SpanConditions,
+		cfg.Traces.SpanEventConditions,
+		cfg.Metrics.MetricConditions,
+		cfg.Metrics.DataPointConditions,
+		cfg.Logs.LogConditions,
+	} {
+		for _, condition := range conditions {
+			configured[condition] = true
+		}
+	}
+	names := make([]string, 0, len(cfg.DryRun.RuleNames))
+	for name := range cfg.DryRun.RuleNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	named := map[string]string{}
+	for _, name := range names {
+		condition := cfg.DryRun.RuleNames[name]
+		if !configured[condition] {
+			return fmt.Errorf("dry_run::rule_names: %q names an unknown condition: %s", name, condition)
+		}
+		if other, ok := named[condition]; ok {
+			return fmt.Errorf("dry_run::rule_names: %q and %q name the same condition", other, name)
+		}
+		named[condition] = name
+	}
+	return nil
+}
+
+// loadMacros returns the macros of the configuration and of the macro files.
+func (cfg *Config) loadMacros() (ottl.Macros, error) {
+	macros, err := ottl.LoadMacros(cfg.Macros, cfg.MacroFiles)
+	if err != nil {
+		return nil, err
+	}
+	var functions []string
+	functions = append(functions, ottl.FunctionNames(filterottl.StandardSpanFuncs())...)
+	functions = append(functions, ottl.FunctionNames(filterottl.StandardSpanEventFuncs())...)
+	functions = append(functions, ottl.FunctionNames(filterottl.StandardMetricFuncs())...)
+	functions = append(functions, ottl.FunctionNames(filterottl.StandardDataPointFuncs())...)
+	functions = append(functions, ottl.FunctionNames(filterottl.StandardLogFuncs())...)
+	return macros, macros.ValidateNames(functions)
+}
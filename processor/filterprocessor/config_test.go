@@ -892,6 +892,73 @@ func TestLoadingConfigOTTL(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "logs_mix_config"),
 			errorMessage: "cannot use ottl conditions and include/exclude for logs at the same time",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macros"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Macros: ottl.Macros{
+					"is_health_check": {
+						Params:    []string{"path"},
+						Condition: `$path == "/health" or $path == "/ready"`,
+					},
+				},
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`is_health_check(attributes["http.target"])`,
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macro_files"),
+			expected: &Config{
+				ErrorMode:  ottl.PropagateError,
+				MacroFiles: []string{"testdata/macros.yaml"},
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`is_health_check(attributes["http.target"])`,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_macro"),
+			errorMessage: `macro "is_health_check" of testdata/macros.yaml is already defined`,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "dry_run"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				DryRun: DryRunConfig{
+					Enabled:       true,
+					AnnotationKey: "filter.dry_run",
+					RuleNames: map[string]string{
+						"below_warn": "severity_number < SEVERITY_NUMBER_WARN",
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						"severity_number < SEVERITY_NUMBER_WARN",
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "dry_run_unknown_rule"),
+			errorMessage: `dry_run::rule_names: "below_error" names an unknown condition: severity_number < SEVERITY_NUMBER_ERROR`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "dry_run_annotation_only"),
+			errorMessage: "dry_run::annotation_key requires dry_run::enabled",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "dry_run_include"),
+			errorMessage: "dry_run is only supported with ottl conditions",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_macro_arguments"),
+			errorMessage: `macro "is_health_check" expects 1 arguments, got 0`,
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_span"),
 		},
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+)
+
+// newSkipExprForConditions expands the macros of the conditions and builds the expression
+// deciding which items are dropped. In dry-run mode, the expression never drops anything.
+func newSkipExprForConditions[K any](
+	set processor.CreateSettings,
+	cfg *Config,
+	ottlContext string,
+	conditions []string,
+	newExpr func([]string) (expr.BoolExpr[K], error),
+	attributes func(K) pcommon.Map,
+) (expr.BoolExpr[K], error) {
+	macros, err := cfg.loadMacros()
+	if err != nil {
+		return nil, err
+	}
+	expanded, err := macros.ExpandConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.DryRun.Enabled {
+		return newExpr(expanded)
+	}
+
+	dryRun := &dryRunExpr[K]{
+		annotationKey: cfg.DryRun.AnnotationKey,
+		attributes:    attributes,
+	}
+	names := cfg.DryRun.ruleNames()
+	for i, condition := range expanded {
+		conditionExpr, err := newExpr([]string{condition})
+		if err != nil {
+			return nil, err
+		}
+		name := cfg.DryRun.ruleName(names, ottlContext, i, conditions[i])
+		tags, err := tag.New(context.Background(),
+			tag.Upsert(processorTagKey, set.ID.String()),
+			tag.Upsert(contextTagKey, ottlContext),
+			tag.Upsert(ruleTagKey, name),
+		)
+		if err != nil {
+			return nil, err
+		}
+		dryRun.rules = append(dryRun.rules, dryRunRule[K]{
+			name: name,
+			expr: conditionExpr,
+			tags: tags,
+		})
+	}
+	return dryRun, nil
+}
+
+type dryRunRule[K any] struct {
+	// name identifies the condition in the metrics and the annotations.
+	name string
+	expr expr.BoolExpr[K]
+	// tags is the context the matches of the condition are recorded with.
+	tags context.Context
+}
+
+// dryRunExpr evaluates each condition separately and records the items it matches,
+// without ever reporting them as to be dropped.
+type dryRunExpr[K any] struct {
+	rules         []dryRunRule[K]
+	annotationKey string
+	// attributes returns the attributes annotated with the first matching condition,
+	// nil for contexts whose items have no attributes.
+	attributes func(K) pcommon.Map
+}
+
+func (d *dryRunExpr[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
+	annotated := d.annotationKey == "" || d.attributes == nil
+	for _, rule := range d.rules {
+		matched, err := rule.expr.Eval(ctx, tCtx)
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			continue
+		}
+		stats.Record(rule.tags, statDryRunMatched.M(1))
+		if !annotated {
+			d.attributes(tCtx).PutStr(d.annotationKey, rule.name)
+			annotated = true
+		}
+	}
+	return false, nil
+}
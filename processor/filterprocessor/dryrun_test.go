@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
+)
+
+// dryRunMatches returns the number of items matched by each rule of the given processor.
+func dryRunMatches(t *testing.T, id component.ID) map[string]int64 {
+	rows, err := view.RetrieveData(processorhelper.BuildCustomMetricName(metadata.Type, statDryRunMatched.Name()))
+	require.NoError(t, err)
+	matches := map[string]int64{}
+	for _, row := range rows {
+		tags := map[tag.Key]string{}
+		for _, t := range row.Tags {
+			tags[t.Key] = t.Value
+		}
+		if tags[processorTagKey] == id.String() {
+			matches[tags[contextTagKey]+": "+tags[ruleTagKey]] = int64(row.Data.(*view.SumData).Value)
+		}
+	}
+	return matches
+}
+
+func TestFilterLogProcessorDryRun(t *testing.T) {
+	require.NoError(t, view.Register(metricViews()...))
+	defer view.Unregister(metricViews()...)
+
+	cfg := &Config{
+		ErrorMode: ottl.PropagateError,
+		Macros: ottl.Macros{
+			"is_debug": {Condition: `severity_number < SEVERITY_NUMBER_INFO`},
+		},
+		Logs: LogFilters{
+			LogConditions: []string{
+				`is_debug()`,
+				`attributes["http.target"] == "/health"`,
+			},
+		},
+		DryRun: DryRunConfig{
+			Enabled:       true,
+			AnnotationKey: "filter.dry_run",
+			RuleNames:     map[string]string{"debug": `is_debug()`},
+		},
+	}
+	set := processortest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(metadata.Type, "dry_run")
+	processor, err := newFilterLogsProcessor(set, cfg)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	debugHealth := lrs.AppendEmpty()
+	debugHealth.SetSeverityNumber(plog.SeverityNumberDebug)
+	debugHealth.Attributes().PutStr("http.target", "/health")
+	health := lrs.AppendEmpty()
+	health.SetSeverityNumber(plog.SeverityNumberInfo)
+	health.Attributes().PutStr("http.target", "/health")
+	kept := lrs.AppendEmpty()
+	kept.SetSeverityNumber(plog.SeverityNumberError)
+
+	got, err := processor.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, got.LogRecordCount())
+	assert.Equal(t, map[string]any{"http.target": "/health", "filter.dry_run": "debug"}, debugHealth.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"http.target": "/health", "filter.dry_run": "log_record/1"}, health.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{}, kept.Attributes().AsRaw())
+
+	assert.Equal(t, map[string]int64{
+		"log_record: debug":        1,
+		"log_record: log_record/1": 2,
+	}, dryRunMatches(t, set.ID))
+}
+
+func TestFilterMetricProcessorDryRun(t *testing.T) {
+	require.NoError(t, view.Register(metricViews()...))
+	defer view.Unregister(metricViews()...)
+
+	cfg := &Config{
+		ErrorMode: ottl.PropagateError,
+		Metrics: MetricFilters{
+			MetricConditions:    []string{`name == "operationA"`},
+			DataPointConditions: []string{`attributes["attr1"] == "test1"`},
+		},
+		DryRun: DryRunConfig{
+			Enabled:       true,
+			AnnotationKey: "filter.dry_run",
+		},
+	}
+	set := processortest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(metadata.Type, "dry_run_metrics")
+	processor, err := newFilterMetricProcessor(set, cfg)
+	require.NoError(t, err)
+
+	got, err := processor.processMetrics(context.Background(), constructMetrics())
+	require.NoError(t, err)
+	expected := constructMetrics()
+	assert.Equal(t, expected.DataPointCount(), got.DataPointCount())
+
+	// data points are annotated, metrics have no attributes
+	dp := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	annotation, ok := dp.Attributes().Get("filter.dry_run")
+	require.True(t, ok)
+	assert.Equal(t, "datapoint/0", annotation.Str())
+
+	matches := dryRunMatches(t, set.ID)
+	assert.Equal(t, int64(1), matches["metric: metric/0"])
+	assert.Positive(t, matches["datapoint: datapoint/0"])
+}
@@ -6,6 +6,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor"
@@ -19,6 +20,7 @@ var processorCapabilities = consumer.Capabilities{MutatesData: true}
 
 // NewFactory returns a new factory for the Filter processor.
 func NewFactory() processor.Factory {
+	_ = view.Register(metricViews()...)
 	return processor.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
@@ -40,7 +42,7 @@ func createMetricsProcessor(
 	cfg component.Config,
 	nextConsumer consumer.Metrics,
 ) (processor.Metrics, error) {
-	fp, err := newFilterMetricProcessor(set.TelemetrySettings, cfg.(*Config))
+	fp, err := newFilterMetricProcessor(set, cfg.(*Config))
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +61,7 @@ func createLogsProcessor(
 	cfg component.Config,
 	nextConsumer consumer.Logs,
 ) (processor.Logs, error) {
-	fp, err := newFilterLogsProcessor(set.TelemetrySettings, cfg.(*Config))
+	fp, err := newFilterLogsProcessor(set, cfg.(*Config))
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +80,7 @@ func createTracesProcessor(
 	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
-	fp, err := newFilterSpansProcessor(set.TelemetrySettings, cfg.(*Config))
+	fp, err := newFilterSpansProcessor(set, cfg.(*Config))
 	if err != nil {
 		return nil, err
 	}
@@ -7,8 +7,9 @@ import (
 	"context"
 	"fmt"
 
-	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -25,12 +26,18 @@ type filterLogProcessor struct {
 	logger   *zap.Logger
 }
 
-func newFilterLogsProcessor(set component.TelemetrySettings, cfg *Config) (*filterLogProcessor, error) {
+func newFilterLogsProcessor(set processor.CreateSettings, cfg *Config) (*filterLogProcessor, error) {
 	flp := &filterLogProcessor{
 		logger: set.Logger,
 	}
 	if cfg.Logs.LogConditions != nil {
-		skipExpr, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set)
+		skipExpr, err := newSkipExprForConditions(set, cfg, "log_record", cfg.Logs.LogConditions,
+			func(conditions []string) (expr.BoolExpr[ottllog.TransformContext], error) {
+				return filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			},
+			func(tCtx ottllog.TransformContext) pcommon.Map {
+				return tCtx.GetLogRecord().Attributes()
+			})
 		if err != nil {
 			return nil, err
 		}
@@ -6,9 +6,9 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 
-	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -31,21 +31,29 @@ type filterMetricProcessor struct {
 	logger            *zap.Logger
 }
 
-func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*filterMetricProcessor, error) {
+func newFilterMetricProcessor(set processor.CreateSettings, cfg *Config) (*filterMetricProcessor, error) {
 	var err error
 	fsp := &filterMetricProcessor{
 		logger: set.Logger,
 	}
 	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
 		if cfg.Metrics.MetricConditions != nil {
-			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set)
+			fsp.skipMetricExpr, err = newSkipExprForConditions(set, cfg, "metric", cfg.Metrics.MetricConditions,
+				func(conditions []string) (expr.BoolExpr[ottlmetric.TransformContext], error) {
+					return filterottl.NewBoolExprForMetric(conditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+				},
+				nil)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Metrics.DataPointConditions != nil {
-			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set)
+			fsp.skipDataPointExpr, err = newSkipExprForConditions(set, cfg, "datapoint", cfg.Metrics.DataPointConditions,
+				func(conditions []string) (expr.BoolExpr[ottldatapoint.TransformContext], error) {
+					return filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+				},
+				dataPointAttributes)
 			if err != nil {
 				return nil, err
 			}
@@ -293,3 +301,18 @@ func (fmp *filterMetricProcessor) handleSummaryDataPoints(ctx context.Context, d
 	})
 	return errors
 }
+
+// dataPointAttributes returns the attributes of the data point of the transform context.
+func dataPointAttributes(tCtx ottldatapoint.TransformContext) pcommon.Map {
+	switch dp := tCtx.GetDataPoint().(type) {
+	case pmetric.NumberDataPoint:
+		return dp.Attributes()
+	case pmetric.HistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.ExponentialHistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.SummaryDataPoint:
+		return dp.Attributes()
+	}
+	return pcommon.NewMap()
+}
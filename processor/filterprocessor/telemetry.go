@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
+)
+
+var (
+	processorTagKey = tag.MustNewKey("filter")
+	contextTagKey   = tag.MustNewKey("context")
+	ruleTagKey      = tag.MustNewKey("rule")
+
+	statDryRunMatched = stats.Int64("dry_run_matched", "Number of items that would have been dropped by each condition in dry-run mode", stats.UnitDimensionless)
+)
+
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        processorhelper.BuildCustomMetricName(metadata.Type, statDryRunMatched.Name()),
+			Measure:     statDryRunMatched,
+			Description: statDryRunMatched.Description(),
+			TagKeys:     []tag.Key{processorTagKey, contextTagKey, ruleTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
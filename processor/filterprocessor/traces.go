@@ -6,8 +6,9 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 
-	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -25,20 +26,32 @@ type filterSpanProcessor struct {
 	logger            *zap.Logger
 }
 
-func newFilterSpansProcessor(set component.TelemetrySettings, cfg *Config) (*filterSpanProcessor, error) {
+func newFilterSpansProcessor(set processor.CreateSettings, cfg *Config) (*filterSpanProcessor, error) {
 	var err error
 	fsp := &filterSpanProcessor{
 		logger: set.Logger,
 	}
 	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
 		if cfg.Traces.SpanConditions != nil {
-			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set)
+			fsp.skipSpanExpr, err = newSkipExprForConditions(set, cfg, "span", cfg.Traces.SpanConditions,
+				func(conditions []string) (expr.BoolExpr[ottlspan.TransformContext], error) {
+					return filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+				},
+				func(tCtx ottlspan.TransformContext) pcommon.Map {
+					return tCtx.GetSpan().Attributes()
+				})
 			if err != nil {
 				return nil, err
 			}
 		}
 		if cfg.Traces.SpanEventConditions != nil {
-			fsp.skipSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set)
+			fsp.skipSpanEventExpr, err = newSkipExprForConditions(set, cfg, "spanevent", cfg.Traces.SpanEventConditions,
+				func(conditions []string) (expr.BoolExpr[ottlspanevent.TransformContext], error) {
+					return filterottl.NewBoolExprForSpanEvent(conditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+				},
+				func(tCtx ottlspanevent.TransformContext) pcommon.Map {
+					return tCtx.GetSpanEvent().Attributes()
+				})
 			if err != nil {
 				return nil, err
 			}
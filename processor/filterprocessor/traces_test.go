@@ -10,7 +10,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -264,7 +263,7 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			processor, err := newFilterSpansProcessor(componenttest.NewNopTelemetrySettings(), &Config{Traces: tt.conditions, ErrorMode: tt.errorMode})
+			processor, err := newFilterSpansProcessor(processortest.NewNopCreateSettings(), &Config{Traces: tt.conditions, ErrorMode: tt.errorMode})
 			assert.NoError(t, err)
 
 			got, err := processor.processTraces(context.Background(), constructTraces())
@@ -281,6 +280,34 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 	}
 }
 
+func TestFilterTraceProcessorWithOTTLMacros(t *testing.T) {
+	cfg := &Config{
+		ErrorMode: ottl.IgnoreError,
+		Macros: ottl.Macros{
+			"is_operation": {
+				Params:    []string{"operation"},
+				Condition: `name == $operation`,
+			},
+		},
+		Traces: TraceFilters{
+			SpanConditions: []string{`is_operation("operationA")`},
+		},
+	}
+	processor, err := newFilterSpansProcessor(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	got, err := processor.processTraces(context.Background(), constructTraces())
+	require.NoError(t, err)
+
+	exTd := constructTraces()
+	for i := 0; i < exTd.ResourceSpans().At(0).ScopeSpans().Len(); i++ {
+		exTd.ResourceSpans().At(0).ScopeSpans().At(i).Spans().RemoveIf(func(span ptrace.Span) bool {
+			return span.Name() == "operationA"
+		})
+	}
+	assert.Equal(t, exTd, got)
+}
+
 func constructTraces() ptrace.Traces {
 	td := ptrace.NewTraces()
 	rs0 := td.ResourceSpans().AppendEmpty()
@@ -4,7 +4,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -28,9 +31,31 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
+	// StoreOnDisk tells the processor to spill the spans of traces exceeding MaxTracesInMemory
+	// to the storage extension set in Storage, keeping only their trace IDs in memory.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension used to hold spilled traces.
+	// Required when StoreOnDisk is enabled.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// MaxTracesInMemory is the number of traces kept in memory before new traces are spilled
+	// to the storage extension. Only used when StoreOnDisk is enabled; zero spills every trace.
+	// Default: 0.
+	MaxTracesInMemory int `mapstructure:"max_traces_in_memory"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxTracesInMemory < 0 {
+		return errors.New("max_traces_in_memory must not be negative")
+	}
+	if cfg.StoreOnDisk && cfg.Storage == nil {
+		return errors.New("storage must be set when store_on_disk is enabled")
+	}
+	return nil
 }
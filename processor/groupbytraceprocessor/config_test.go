@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	storageID := component.NewID("file_storage")
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				NumTraces:    1000,
+				NumWorkers:   defaultNumWorkers,
+				WaitDuration: 10 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "spill"),
+			expected: &Config{
+				NumTraces:         100000,
+				NumWorkers:        defaultNumWorkers,
+				WaitDuration:      time.Minute,
+				StoreOnDisk:       true,
+				Storage:           &storageID,
+				MaxTracesInMemory: 1000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	storageID := component.NewID("file_storage")
+
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "store on disk",
+			config: Config{StoreOnDisk: true, Storage: &storageID},
+		},
+		{
+			name:   "store on disk without storage",
+			config: Config{StoreOnDisk: true},
+			err:    "storage must be set when store_on_disk is enabled",
+		},
+		{
+			name:   "negative max_traces_in_memory",
+			config: Config{StoreOnDisk: true, Storage: &storageID, MaxTracesInMemory: -1},
+			err:    "max_traces_in_memory must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -52,12 +52,6 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			},
 			errDiscardOrphansNotSupported,
 		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), tt.config, next)
 
@@ -21,6 +21,9 @@ var (
 	mReleasedTraces     = stats.Int64("processor_groupbytrace_traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("processor_groupbytrace_incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("processor_groupbytrace_event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
+	mReleaseLatency     = stats.Int64("processor_groupbytrace_release_latency", "How long traces are held between receiving their first spans and being released", stats.UnitMilliseconds)
+	mTracesSpilled      = stats.Int64("processor_groupbytrace_traces_spilled", "Traces spilled to the storage extension", stats.UnitDimensionless)
+	mNumTracesSpilled   = stats.Int64("processor_groupbytrace_num_traces_spilled", "Number of traces currently in the storage extension", stats.UnitDimensionless)
 )
 
 // metricViews return the metrics views according to given telemetry level.
@@ -78,5 +81,23 @@ func metricViews() []*view.View {
 			},
 			Aggregation: view.Distribution(0, 5, 10, 20, 50, 100, 200, 500, 1000),
 		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mReleaseLatency.Name()),
+			Measure:     mReleaseLatency,
+			Description: mReleaseLatency.Description(),
+			Aggregation: view.Distribution(0, 100, 500, 1000, 5000, 10000, 30000, 60000, 300000, 600000),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mTracesSpilled.Name()),
+			Measure:     mTracesSpilled,
+			Description: mTracesSpilled.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mNumTracesSpilled.Name()),
+			Measure:     mNumTracesSpilled,
+			Description: mNumTracesSpilled.Description(),
+			Aggregation: view.LastValue(),
+		},
 	}
 }
@@ -6,6 +6,7 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -42,6 +43,9 @@ type groupByTraceProcessor struct {
 
 	// the trace storage
 	st storage
+
+	// the time at which the first spans of each in-flight trace were received
+	receivedAt sync.Map
 }
 
 var _ processor.Traces = (*groupByTraceProcessor)(nil)
@@ -83,7 +87,13 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
+	if st, ok := sp.st.(*spillStorage); ok {
+		if err := st.bind(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
@@ -125,6 +135,7 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			payload: evicted,
 		})
 
+		sp.receivedAt.Delete(evicted)
 		stats.Record(context.Background(), mTracesEvicted.M(1))
 
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
@@ -135,6 +146,8 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	if err := sp.addSpans(traceID, trace.td); err != nil {
 		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
 	}
+	// keep the time at which the first spans of the trace were received
+	sp.receivedAt.LoadOrStore(traceID, time.Now())
 
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
@@ -185,6 +198,9 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.Stringer("traceID", traceID))
+	if receivedAt, ok := sp.receivedAt.LoadAndDelete(traceID); ok {
+		stats.Record(context.Background(), mReleaseLatency.M(time.Since(receivedAt.(time.Time)).Milliseconds()))
+	}
 
 	// atomically fire the two events, so that a concurrent shutdown won't leave
 	// an orphaned trace in the storage
@@ -102,6 +102,13 @@ func (st *memoryStorage) periodicMetrics() {
 	})
 }
 
+func (st *memoryStorage) contains(traceID pcommon.TraceID) bool {
+	st.RLock()
+	defer st.RUnlock()
+	_, ok := st.content[traceID]
+	return ok
+}
+
 func (st *memoryStorage) count() int {
 	st.RLock()
 	defer st.RUnlock()
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+// spillStorage keeps up to maxInMemory traces in memory, spilling the spans of any further trace
+// to a storage extension until the trace is released. Spans arriving for a spilled trace are
+// spilled too, so that a trace is never split between memory and the extension. Every batch of
+// spans is stored under its own key, so that appending to a spilled trace doesn't have to read
+// and rewrite the spans spilled before.
+type spillStorage struct {
+	sync.Mutex
+	memory      *memoryStorage
+	maxInMemory int
+
+	id        component.ID
+	storageID component.ID
+	client    storageext.Client
+
+	// spilled holds the number of batches of the traces currently in the storage extension
+	spilled map[pcommon.TraceID]int
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+}
+
+var _ storage = (*spillStorage)(nil)
+
+func newSpillStorage(id component.ID, storageID component.ID, maxInMemory int) *spillStorage {
+	return &spillStorage{
+		memory:      newMemoryStorage(),
+		maxInMemory: maxInMemory,
+		id:          id,
+		storageID:   storageID,
+		client:      storageext.NewNopClient(),
+		spilled:     make(map[pcommon.TraceID]int),
+		marshaler:   &ptrace.ProtoMarshaler{},
+		unmarshaler: &ptrace.ProtoUnmarshaler{},
+	}
+}
+
+// bind obtains the storage client from the extension registered in the host.
+func (st *spillStorage) bind(ctx context.Context, host component.Host) error {
+	extension, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExtension, ok := extension.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, st.id, "")
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	st.client = client
+	return nil
+}
+
+func (st *spillStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if batches, ok := st.spilled[traceID]; ok {
+		if err := st.write(spillKey(traceID, batches), td); err != nil {
+			return err
+		}
+		st.spilled[traceID] = batches + 1
+		return nil
+	}
+
+	if st.memory.contains(traceID) || st.memory.count() < st.maxInMemory {
+		return st.memory.createOrAppend(traceID, td)
+	}
+
+	if err := st.write(spillKey(traceID, 0), td); err != nil {
+		return err
+	}
+	st.spilled[traceID] = 1
+
+	stats.Record(context.Background(),
+		mTracesSpilled.M(1),
+		mNumTracesSpilled.M(int64(len(st.spilled))),
+	)
+	return nil
+}
+
+func (st *spillStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	batches, ok := st.spilled[traceID]
+	if !ok {
+		return st.memory.get(traceID)
+	}
+
+	td, err := st.read(traceID, batches)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSpansOf(td), nil
+}
+
+func (st *spillStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	batches, ok := st.spilled[traceID]
+	if !ok {
+		return st.memory.delete(traceID)
+	}
+
+	td, err := st.read(traceID, batches)
+	if err != nil {
+		return nil, err
+	}
+	if err = st.client.Batch(context.Background(), deleteOperations(traceID, batches)...); err != nil {
+		return nil, fmt.Errorf("failed to delete spilled trace: %w", err)
+	}
+	delete(st.spilled, traceID)
+
+	stats.Record(context.Background(), mNumTracesSpilled.M(int64(len(st.spilled))))
+	return resourceSpansOf(td), nil
+}
+
+func (st *spillStorage) start() error {
+	stats.Record(context.Background(), mTracesSpilled.M(0), mNumTracesSpilled.M(0))
+	return st.memory.start()
+}
+
+// shutdown removes the traces that are still spilled, as they would never be released
+// by the next instance of the processor, and closes the storage client.
+func (st *spillStorage) shutdown() error {
+	st.Lock()
+	defer st.Unlock()
+
+	errs := st.memory.shutdown()
+
+	var ops []storageext.Operation
+	for traceID, batches := range st.spilled {
+		ops = append(ops, deleteOperations(traceID, batches)...)
+	}
+	if len(ops) > 0 {
+		errs = multierr.Append(errs, st.client.Batch(context.Background(), ops...))
+	}
+	st.spilled = make(map[pcommon.TraceID]int)
+
+	return multierr.Append(errs, st.client.Close(context.Background()))
+}
+
+// read merges the batches of spans spilled for a trace.
+func (st *spillStorage) read(traceID pcommon.TraceID, batches int) (ptrace.Traces, error) {
+	ops := make([]storageext.Operation, batches)
+	for i := range ops {
+		ops[i] = storageext.GetOperation(spillKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return ptrace.Traces{}, fmt.Errorf("failed to read spilled trace: %w", err)
+	}
+	td := ptrace.NewTraces()
+	for _, op := range ops {
+		if op.Value == nil {
+			return ptrace.Traces{}, fmt.Errorf("spilled trace %q not found at the storage extension", traceID)
+		}
+		batch, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return ptrace.Traces{}, fmt.Errorf("failed to unmarshal spilled trace: %w", err)
+		}
+		batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td, nil
+}
+
+func (st *spillStorage) write(key string, td ptrace.Traces) error {
+	buf, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	if err = st.client.Set(context.Background(), key, buf); err != nil {
+		return fmt.Errorf("failed to spill trace: %w", err)
+	}
+	return nil
+}
+
+func deleteOperations(traceID pcommon.TraceID, batches int) []storageext.Operation {
+	ops := make([]storageext.Operation, batches)
+	for i := range ops {
+		ops[i] = storageext.DeleteOperation(spillKey(traceID, i))
+	}
+	return ops
+}
+
+// spillKey is the key of a batch of spans spilled for a trace.
+func spillKey(traceID pcommon.TraceID, batch int) string {
+	return "trace/" + traceID.String() + "/" + strconv.Itoa(batch)
+}
+
+func resourceSpansOf(td ptrace.Traces) []ptrace.ResourceSpans {
+	rss := make([]ptrace.ResourceSpans, td.ResourceSpans().Len())
+	for i := range rss {
+		rss[i] = td.ResourceSpans().At(i)
+	}
+	return rss
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newTestSpillStorage(t *testing.T, maxInMemory int) *spillStorage {
+	storageID := storagetest.NewStorageID("spill")
+	st := newSpillStorage(component.NewID(metadata.Type), storageID, maxInMemory)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("spill", t.TempDir())
+	require.NoError(t, st.bind(context.Background(), host))
+	require.NoError(t, st.start())
+	return st
+}
+
+func TestSpillStorageSpillsBeyondMaxInMemory(t *testing.T) {
+	// prepare
+	st := newTestSpillStorage(t, 1)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	first := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	// test
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+
+	// verify
+	assert.Equal(t, 1, st.memory.count())
+	// every batch of the spilled trace is stored under its own key
+	assert.Equal(t, 2, st.spilled[second])
+	assert.NotContains(t, st.spilled, first)
+
+	for _, traceID := range []pcommon.TraceID{first, second} {
+		rss, err := st.get(traceID)
+		require.NoError(t, err)
+		require.Len(t, rss, 2)
+		for _, rs := range rss {
+			assert.Equal(t, traceID, rs.ScopeSpans().At(0).Spans().At(0).TraceID())
+		}
+	}
+}
+
+func TestSpillStorageDeleteTrace(t *testing.T) {
+	// prepare
+	st := newTestSpillStorage(t, 0)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.Contains(t, st.spilled, traceID)
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Empty(t, st.spilled)
+
+	buf, err := st.client.Get(context.Background(), spillKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestSpillStorageShutdownRemovesSpilledTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	storageID := storagetest.NewStorageID("spill")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("spill", dir)
+	st := newSpillStorage(component.NewID(metadata.Type), storageID, 0)
+	require.NoError(t, st.bind(context.Background(), host))
+	require.NoError(t, st.start())
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	require.NoError(t, st.shutdown())
+
+	// verify
+	client := storagetest.NewFileBackedClient(component.KindProcessor, component.NewID(metadata.Type), "", dir)
+	defer func() {
+		assert.NoError(t, client.Close(context.Background()))
+	}()
+	buf, err := client.Get(context.Background(), spillKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+}
+
+func TestSpillStorageBindErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		storageID component.ID
+		host      component.Host
+		err       string
+	}{
+		{
+			name:      "missing extension",
+			storageID: storagetest.NewStorageID("missing"),
+			host:      storagetest.NewStorageHost(),
+			err:       "storage extension 'test_storage/missing' not found",
+		},
+		{
+			name:      "non-storage extension",
+			storageID: storagetest.NewNonStorageID("other"),
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("other"),
+			err:       "non-storage extension 'non_storage/other' found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSpillStorage(component.NewID(metadata.Type), tt.storageID, 0)
+			assert.EqualError(t, st.bind(context.Background(), tt.host), tt.err)
+		})
+	}
+}
+
+func TestSpilledTraceIsReleased(t *testing.T) {
+	// prepare
+	traces := simpleTraces()
+	storageID := storagetest.NewStorageID("spill")
+	config := Config{
+		WaitDuration:      time.Nanosecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+		StoreOnDisk:       true,
+		Storage:           &storageID,
+		MaxTracesInMemory: 0,
+	}
+
+	wg := &sync.WaitGroup{}
+	next := &mockProcessor{
+		onTraces: func(ctx context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wg.Done()
+			return nil
+		},
+	}
+
+	st := newSpillStorage(component.NewID(metadata.Type), storageID, config.MaxTracesInMemory)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("spill", t.TempDir())
+
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	wg.Add(1)
+	assert.NoError(t, p.ConsumeTraces(ctx, traces))
+
+	// verify
+	wg.Wait()
+}
+
+func TestProcessorStartFailsWithoutStorageExtension(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	config := Config{
+		WaitDuration: time.Second,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+		Storage:      &storageID,
+	}
+	st := newSpillStorage(component.NewID(metadata.Type), storageID, 0)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+
+	assert.EqualError(t, p.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'test_storage/missing' not found")
+}
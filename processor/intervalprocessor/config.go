@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+// Config defines the configuration for the interval processor.
+type Config struct {
+	// Interval is the interval at which the metrics not matched by a policy are
+	// exported. Set to 0 to let them through as they are received.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Policies set the interval of the metrics matching their names. A metric gets
+	// the interval of the first policy matching it.
+	Policies []Policy `mapstructure:"policies"`
+}
+
+// Policy sets the export interval of the metrics matching its names or patterns.
+type Policy struct {
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics are the names or the patterns of the metrics the policy applies to.
+	Metrics []string `mapstructure:"metrics"`
+
+	// Interval is the interval at which the metrics are exported.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	for i, policy := range cfg.Policies {
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("policies[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validate() error {
+	if len(p.Metrics) == 0 {
+		return errors.New("metrics must be set")
+	}
+	if p.MatchType == "" {
+		return errors.New("match_type must be set")
+	}
+	if p.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "policies"),
+			expected: &Config{
+				Policies: []Policy{
+					{
+						Config:   filterset.Config{MatchType: filterset.Regexp},
+						Metrics:  []string{`^slo\.`},
+						Interval: 10 * time.Second,
+					},
+					{
+						Config:   filterset.Config{MatchType: filterset.Strict},
+						Metrics:  []string{"system.cpu.time", "system.memory.usage"},
+						Interval: 5 * time.Minute,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	strict := filterset.Config{MatchType: filterset.Strict}
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "negative interval",
+			config: Config{Interval: -time.Second},
+			err:    "interval must not be negative",
+		},
+		{
+			name:   "policy without metrics",
+			config: Config{Policies: []Policy{{Config: strict, Interval: time.Second}}},
+			err:    "policies[0]: metrics must be set",
+		},
+		{
+			name:   "policy without match_type",
+			config: Config{Policies: []Policy{{Metrics: []string{"a"}, Interval: time.Second}}},
+			err:    "policies[0]: match_type must be set",
+		},
+		{
+			name:   "policy without interval",
+			config: Config{Interval: time.Minute, Policies: []Policy{{Config: strict, Metrics: []string{"a"}}}},
+			err:    "policies[0]: interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.config.Validate(), tt.err)
+		})
+	}
+}
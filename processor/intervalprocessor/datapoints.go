@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// forEachDataPoint calls fn with the attributes and the timestamp of every data
+// point of a metric, and a function copying the metric with only this data point.
+func forEachDataPoint(m pmetric.Metric, fn func(attrs pcommon.Map, ts pcommon.Timestamp, copyTo func(pmetric.Metric))) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dp.Attributes(), dp.Timestamp(), func(dst pmetric.Metric) {
+				copyMetadata(m, dst)
+				dp.CopyTo(dst.Gauge().DataPoints().AppendEmpty())
+			})
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dp.Attributes(), dp.Timestamp(), func(dst pmetric.Metric) {
+				copyMetadata(m, dst)
+				dp.CopyTo(dst.Sum().DataPoints().AppendEmpty())
+			})
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dp.Attributes(), dp.Timestamp(), func(dst pmetric.Metric) {
+				copyMetadata(m, dst)
+				dp.CopyTo(dst.Histogram().DataPoints().AppendEmpty())
+			})
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dp.Attributes(), dp.Timestamp(), func(dst pmetric.Metric) {
+				copyMetadata(m, dst)
+				dp.CopyTo(dst.ExponentialHistogram().DataPoints().AppendEmpty())
+			})
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dp.Attributes(), dp.Timestamp(), func(dst pmetric.Metric) {
+				copyMetadata(m, dst)
+				dp.CopyTo(dst.Summary().DataPoints().AppendEmpty())
+			})
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+// copyMetadata copies the name, the description, the unit and the type of a metric,
+// without its data points.
+func copyMetadata(src, dst pmetric.Metric) {
+	dst.SetName(src.Name())
+	dst.SetDescription(src.Description())
+	dst.SetUnit(src.Unit())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dst.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dst.SetEmptySum()
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+	case pmetric.MetricTypeHistogram:
+		dst.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dst.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dst.SetEmptySummary()
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+// appendDataPoint appends the data points of a metric to another of the same type.
+func appendDataPoint(src, dst pmetric.Metric) {
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		src.Gauge().DataPoints().MoveAndAppendTo(dst.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		src.Sum().DataPoints().MoveAndAppendTo(dst.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		src.Histogram().DataPoints().MoveAndAppendTo(dst.Histogram().DataPoints())
+	case pmetric.MetricTypeExponentialHistogram:
+		src.ExponentialHistogram().DataPoints().MoveAndAppendTo(dst.ExponentialHistogram().DataPoints())
+	case pmetric.MetricTypeSummary:
+		src.Summary().DataPoints().MoveAndAppendTo(dst.Summary().DataPoints())
+	case pmetric.MetricTypeEmpty:
+	}
+}
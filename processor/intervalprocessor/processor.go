@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const keySeparator = "\x1e"
+
+// stream holds the latest data point of a stream, in a metric holding only it.
+type stream struct {
+	resourceKey string
+	scopeKey    string
+	metricKey   string
+
+	resource  pcommon.Resource
+	scope     pcommon.InstrumentationScope
+	metric    pmetric.Metric
+	timestamp pcommon.Timestamp
+}
+
+// window holds the latest data points of the streams exported at the same interval.
+type window struct {
+	interval time.Duration
+	streams  map[string]*stream
+}
+
+type policy struct {
+	metrics filterset.FilterSet
+	window  *window
+}
+
+type intervalProcessor struct {
+	logger   *zap.Logger
+	next     consumer.Metrics
+	policies []policy
+	// defaultWindow is the window of the metrics not matched by a policy, nil if
+	// they are let through.
+	defaultWindow *window
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newIntervalProcessor(cfg *Config, logger *zap.Logger, next consumer.Metrics) (*intervalProcessor, error) {
+	p := &intervalProcessor{
+		logger: logger,
+		next:   next,
+	}
+	for i := range cfg.Policies {
+		fs, err := filterset.CreateFilterSet(cfg.Policies[i].Metrics, &cfg.Policies[i].Config)
+		if err != nil {
+			return nil, err
+		}
+		p.policies = append(p.policies, policy{metrics: fs, window: newWindow(cfg.Policies[i].Interval)})
+	}
+	if cfg.Interval > 0 {
+		p.defaultWindow = newWindow(cfg.Interval)
+	}
+	return p, nil
+}
+
+func newWindow(interval time.Duration) *window {
+	return &window{interval: interval, streams: make(map[string]*stream)}
+}
+
+// windows returns all the windows of the processor.
+func (p *intervalProcessor) windows() []*window {
+	windows := make([]*window, 0, len(p.policies)+1)
+	for _, pol := range p.policies {
+		windows = append(windows, pol.window)
+	}
+	if p.defaultWindow != nil {
+		windows = append(windows, p.defaultWindow)
+	}
+	return windows
+}
+
+func (p *intervalProcessor) start(context.Context, component.Host) error {
+	var ctx context.Context
+	ctx, p.cancel = context.WithCancel(context.Background())
+	for _, w := range p.windows() {
+		p.wg.Add(1)
+		go p.exportLoop(ctx, w)
+	}
+	return nil
+}
+
+func (p *intervalProcessor) shutdown(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	p.wg.Wait()
+	// the latest data points are exported, so that they are not lost
+	var errs error
+	for _, w := range p.windows() {
+		errs = multierr.Append(errs, p.export(ctx, w))
+	}
+	return errs
+}
+
+func (p *intervalProcessor) exportLoop(ctx context.Context, w *window) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.export(ctx, w); err != nil {
+				p.logger.Error("failed to export metrics", zap.Duration("interval", w.interval), zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// export sends the latest data points of the streams of a window, and empties it.
+func (p *intervalProcessor) export(ctx context.Context, w *window) error {
+	p.mu.Lock()
+	streams := w.streams
+	w.streams = make(map[string]*stream)
+	p.mu.Unlock()
+	if len(streams) == 0 {
+		return nil
+	}
+	return p.next.ConsumeMetrics(ctx, buildMetrics(streams))
+}
+
+// buildMetrics groups the data points of the streams by resource, scope and metric.
+func buildMetrics(streams map[string]*stream) pmetric.Metrics {
+	keys := make([]string, 0, len(streams))
+	for key := range streams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	md := pmetric.NewMetrics()
+	resources := make(map[string]pmetric.ResourceMetrics)
+	scopes := make(map[string]pmetric.ScopeMetrics)
+	metrics := make(map[string]pmetric.Metric)
+	for _, key := range keys {
+		s := streams[key]
+		m, ok := metrics[s.metricKey]
+		if !ok {
+			sm, ok := scopes[s.scopeKey]
+			if !ok {
+				rm, ok := resources[s.resourceKey]
+				if !ok {
+					rm = md.ResourceMetrics().AppendEmpty()
+					s.resource.CopyTo(rm.Resource())
+					resources[s.resourceKey] = rm
+				}
+				sm = rm.ScopeMetrics().AppendEmpty()
+				s.scope.CopyTo(sm.Scope())
+				scopes[s.scopeKey] = sm
+			}
+			s.metric.CopyTo(sm.Metrics().AppendEmpty())
+			metrics[s.metricKey] = sm.Metrics().At(sm.Metrics().Len() - 1)
+			continue
+		}
+		appendDataPoint(s.metric, m)
+	}
+	return md
+}
+
+// windowFor returns the window of a metric, nil if it is let through.
+func (p *intervalProcessor) windowFor(name string) *window {
+	for _, pol := range p.policies {
+		if pol.metrics.Matches(name) {
+			return pol.window
+		}
+	}
+	return p.defaultWindow
+}
+
+// processMetrics moves the data points of the cumulative metrics and of the gauges
+// to their window, keeping the latest data point of every stream, and lets the
+// delta metrics and the metrics without window through.
+func (p *intervalProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resHash := pdatautil.MapHash(rm.Resource().Attributes())
+		resourceKey := string(resHash[:])
+		// the resource and the scope are copied once for all their streams, as the
+		// batch is let through
+		var res *pcommon.Resource
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scopeKey := strings.Join([]string{resourceKey, sm.Scope().Name(), sm.Scope().Version()}, keySeparator)
+			var scope *pcommon.InstrumentationScope
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				w := p.windowFor(m.Name())
+				if w == nil || !cumulativeOrGauge(m) {
+					return false
+				}
+				metricKey := strings.Join([]string{scopeKey, m.Name(), m.Unit(), m.Type().String()}, keySeparator)
+				if m.Type() == pmetric.MetricTypeSum && m.Sum().IsMonotonic() {
+					metricKey += keySeparator + "monotonic"
+				}
+				forEachDataPoint(m, func(attrs pcommon.Map, ts pcommon.Timestamp, copyTo func(pmetric.Metric)) {
+					attrsHash := pdatautil.MapHash(attrs)
+					key := metricKey + keySeparator + string(attrsHash[:])
+					if s, ok := w.streams[key]; ok && s.timestamp >= ts {
+						// a data point older than the one kept
+						return
+					}
+					if res == nil {
+						copied := pcommon.NewResource()
+						rm.Resource().CopyTo(copied)
+						res = &copied
+					}
+					if scope == nil {
+						copied := pcommon.NewInstrumentationScope()
+						sm.Scope().CopyTo(copied)
+						scope = &copied
+					}
+					s := &stream{
+						resourceKey: resourceKey,
+						scopeKey:    scopeKey,
+						metricKey:   metricKey,
+						resource:    *res,
+						scope:       *scope,
+						metric:      pmetric.NewMetric(),
+						timestamp:   ts,
+					}
+					copyTo(s.metric)
+					w.streams[key] = s
+				})
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// cumulativeOrGauge reports whether the latest data point of the streams of a
+// metric holds all their values.
+func cumulativeOrGauge(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary:
+		return true
+	case pmetric.MetricTypeSum:
+		return m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeEmpty:
+	}
+	return false
+}
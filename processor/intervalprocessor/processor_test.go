@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+// newSum creates a sum with a data point per value of the host attribute.
+func newSum(name string, temporality pmetric.AggregationTemporality, ts pcommon.Timestamp, values map[string]int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for host, value := range values {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", host)
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(value)
+	}
+	return md
+}
+
+func sumValues(t *testing.T, md pmetric.Metrics) map[string]int64 {
+	values := map[string]int64{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				dps := ms.At(k).Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					host, ok := dps.At(l).Attributes().Get("host")
+					require.True(t, ok)
+					values[ms.At(k).Name()+"/"+host.Str()] = dps.At(l).IntValue()
+				}
+			}
+		}
+	}
+	return values
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (*intervalProcessor, *consumertest.MetricsSink) {
+	sink := new(consumertest.MetricsSink)
+	p, err := newIntervalProcessor(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	return p, sink
+}
+
+func TestKeepLatestDataPoint(t *testing.T) {
+	p, sink := newTestProcessor(t, &Config{Interval: time.Hour})
+
+	for _, md := range []pmetric.Metrics{
+		newSum("requests", pmetric.AggregationTemporalityCumulative, 100, map[string]int64{"a": 1, "b": 10}),
+		newSum("requests", pmetric.AggregationTemporalityCumulative, 200, map[string]int64{"a": 2}),
+		// older than the data point kept
+		newSum("requests", pmetric.AggregationTemporalityCumulative, 150, map[string]int64{"a": 100}),
+	} {
+		out, err := p.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		assert.Equal(t, 0, out.ResourceMetrics().Len())
+	}
+
+	// delta metrics are let through
+	out, err := p.processMetrics(context.Background(), newSum("bytes", pmetric.AggregationTemporalityDelta, 200, map[string]int64{"a": 5}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"bytes/a": 5}, sumValues(t, out))
+
+	require.NoError(t, p.export(context.Background(), p.defaultWindow))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.Equal(t, map[string]int64{"requests/a": 2, "requests/b": 10}, sumValues(t, md))
+
+	// the window is emptied by the export
+	sink.Reset()
+	require.NoError(t, p.export(context.Background(), p.defaultWindow))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestPolicies(t *testing.T) {
+	p, sink := newTestProcessor(t, &Config{
+		Policies: []Policy{
+			{Config: filterset.Config{MatchType: filterset.Regexp}, Metrics: []string{`^slo\.`}, Interval: 10 * time.Second},
+			{Config: filterset.Config{MatchType: filterset.Strict}, Metrics: []string{"system.cpu.time"}, Interval: 5 * time.Minute},
+		},
+	})
+	require.Nil(t, p.defaultWindow)
+
+	for _, name := range []string{"slo.availability", "system.cpu.time", "requests"} {
+		out, err := p.processMetrics(context.Background(), newSum(name, pmetric.AggregationTemporalityCumulative, 100, map[string]int64{"a": 1}))
+		require.NoError(t, err)
+		if name == "requests" {
+			// not matched by a policy, let through
+			assert.Equal(t, 1, out.DataPointCount())
+		} else {
+			assert.Equal(t, 0, out.DataPointCount())
+		}
+	}
+
+	require.NoError(t, p.export(context.Background(), p.policies[0].window))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, map[string]int64{"slo.availability/a": 1}, sumValues(t, sink.AllMetrics()[0]))
+
+	sink.Reset()
+	require.NoError(t, p.export(context.Background(), p.policies[1].window))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, map[string]int64{"system.cpu.time/a": 1}, sumValues(t, sink.AllMetrics()[0]))
+}
+
+func TestExportLoop(t *testing.T) {
+	p, sink := newTestProcessor(t, &Config{
+		Interval: time.Hour,
+		Policies: []Policy{
+			{Config: filterset.Config{MatchType: filterset.Strict}, Metrics: []string{"slo.availability"}, Interval: time.Millisecond},
+		},
+	})
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := p.processMetrics(context.Background(), newSum("slo.availability", pmetric.AggregationTemporalityCumulative, 100, map[string]int64{"a": 1}))
+	require.NoError(t, err)
+	_, err = p.processMetrics(context.Background(), newSum("requests", pmetric.AggregationTemporalityCumulative, 100, map[string]int64{"a": 2}))
+	require.NoError(t, err)
+
+	// only the window of the policy is exported before the shutdown
+	assert.Eventually(t, func() bool {
+		return sink.DataPointCount() == 1
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, map[string]int64{"slo.availability/a": 1}, sumValues(t, sink.AllMetrics()[0]))
+
+	require.NoError(t, p.shutdown(context.Background()))
+	assert.Equal(t, 2, sink.DataPointCount())
+}
+
+func TestAllMetricTypes(t *testing.T) {
+	p, sink := newTestProcessor(t, &Config{Interval: time.Hour})
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	histogram.Histogram().DataPoints().AppendEmpty().SetCount(3)
+	exponential := ms.AppendEmpty()
+	exponential.SetName("exponential")
+	exponential.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	exponential.ExponentialHistogram().DataPoints().AppendEmpty().SetCount(4)
+	summary := ms.AppendEmpty()
+	summary.SetName("summary")
+	summary.SetEmptySummary().DataPoints().AppendEmpty().SetCount(5)
+	deltaHistogram := ms.AppendEmpty()
+	deltaHistogram.SetName("delta_histogram")
+	deltaHistogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	deltaHistogram.Histogram().DataPoints().AppendEmpty().SetCount(6)
+
+	out, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.DataPointCount())
+	assert.Equal(t, "delta_histogram", out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	require.NoError(t, p.export(context.Background(), p.defaultWindow))
+	require.Len(t, sink.AllMetrics(), 1)
+	got := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	types := map[string]pmetric.MetricType{}
+	for i := 0; i < got.Len(); i++ {
+		types[got.At(i).Name()] = got.At(i).Type()
+	}
+	assert.Equal(t, map[string]pmetric.MetricType{
+		"gauge":       pmetric.MetricTypeGauge,
+		"histogram":   pmetric.MetricTypeHistogram,
+		"exponential": pmetric.MetricTypeExponentialHistogram,
+		"summary":     pmetric.MetricTypeSummary,
+	}, types)
+}
@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
@@ -26,6 +27,7 @@ type fakeClient struct {
 	NodeInformer       cache.SharedInformer
 	Namespaces         map[string]*kube.Namespace
 	Nodes              map[string]*kube.Node
+	Objects            map[schema.GroupVersionResource]map[string]*kube.Object
 	StopCh             chan struct{}
 }
 
@@ -35,7 +37,7 @@ func selectors() (labels.Selector, fields.Selector) {
 }
 
 // newFakeClient instantiates a new FakeClient object and satisfies the ClientProvider type
-func newFakeClient(_ *zap.Logger, _ k8sconfig.APIConfig, rules kube.ExtractionRules, filters kube.Filters, associations []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet) (kube.Client, error) {
+func newFakeClient(_ *zap.Logger, _ k8sconfig.APIConfig, rules kube.ExtractionRules, filters kube.Filters, associations []kube.Association, _ kube.Excludes, _ kube.Limits, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderObject) (kube.Client, error) {
 	cs := fake.NewSimpleClientset()
 
 	ls, fs := selectors()
@@ -69,6 +71,23 @@ func (f *fakeClient) GetNode(nodeName string) (*kube.Node, bool) {
 	return node, ok
 }
 
+func (f *fakeClient) GetObject(resource schema.GroupVersionResource, namespace, name string) (*kube.Object, bool) {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+	object, ok := f.Objects[resource][name]
+	return object, ok
+}
+
+func (f *fakeClient) GetPodObject(resource schema.GroupVersionResource, pod *kube.Pod) (*kube.Object, bool) {
+	for _, object := range f.Objects[resource] {
+		if object.Selector != nil && object.Namespace == pod.Namespace && object.Selector.Matches(labels.Set(pod.Labels)) {
+			return object, true
+		}
+	}
+	return nil, false
+}
+
 // Start is a noop for FakeClient.
 func (f *fakeClient) Start() {
 	if f.Informer != nil {
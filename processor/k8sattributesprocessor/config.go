@@ -38,6 +38,13 @@ type Config struct {
 	// Exclude section allows to define names of pod that should be
 	// ignored while tagging.
 	Exclude ExcludeConfig `mapstructure:"exclude"`
+
+	// PipelineHints section allows pods to control how their telemetry is
+	// handled through standardized annotations.
+	PipelineHints PipelineHintsConfig `mapstructure:"pipeline_hints"`
+
+	// Limits section allows bounding the memory used to cache pod metadata.
+	Limits LimitsConfig `mapstructure:"limits"`
 }
 
 func (cfg *Config) Validate() error {
@@ -95,6 +102,28 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Filter.Namespace != "" && len(cfg.Filter.Namespaces) > 0 {
+		return fmt.Errorf("only one of filter.namespace and filter.namespaces can be set")
+	}
+
+	if cfg.Limits.MaxPods < 0 {
+		return fmt.Errorf("limits.max_pods must not be negative")
+	}
+
+	// the informers cache all the pods they watch, whatever the limit of the pods cached by the processor
+	if cfg.Limits.MaxPods > 0 && !cfg.Filter.selectsPods() {
+		return fmt.Errorf("limits.max_pods requires filtering the watched pods with filter.node, filter.node_from_env_var, filter.namespace, filter.namespaces, filter.labels or filter.fields")
+	}
+
+	if cfg.PipelineHints.Enabled {
+		if cfg.Passthrough {
+			return fmt.Errorf("pipeline_hints cannot be enabled in passthrough mode")
+		}
+		if cfg.PipelineHints.AnnotationPrefix == "" {
+			return fmt.Errorf("pipeline_hints.annotation_prefix must not be empty")
+		}
+	}
+
 	for _, f := range cfg.Filter.Labels {
 		switch f.Op {
 		case "", filterOPEquals, filterOPNotEquals, filterOPExists, filterOPDoesNotExist:
@@ -155,6 +184,112 @@ type ExtractConfig struct {
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// Objects allows extracting metadata from objects of other resources, like
+	// services, jobs, cronjobs or custom resources. Objects are associated with
+	// telemetry through a resource attribute holding their name.
+	// It is a list of ObjectExtractConfig type. See ObjectExtractConfig
+	// documentation for more details.
+	Objects []ObjectExtractConfig `mapstructure:"objects"`
+}
+
+// ObjectExtractConfig allows specifying the rules to extract resource attributes from
+// the objects of a resource.
+//
+// For example, to add the team owning the job of a pod, set in an annotation of the job:
+//
+//	extract:
+//	  metadata:
+//	    - k8s.job.name
+//	  objects:
+//	    - group: batch
+//	      version: v1
+//	      resource: jobs
+//	      name_attribute: k8s.job.name
+//	      annotations:
+//	        - tag_name: team
+//	          key: example.com/team
+type ObjectExtractConfig struct {
+	// Group is the API group of the resource, empty for the core group.
+	Group string `mapstructure:"group"`
+	// Version is the API version of the resource, like v1.
+	Version string `mapstructure:"version"`
+	// Resource is the plural name of the resource, like services or jobs.
+	Resource string `mapstructure:"resource"`
+
+	// ClusterScoped must be set for resources that don't belong to a namespace.
+	// Objects of namespaced resources are looked up in the namespace set in the
+	// k8s.namespace.name resource attribute.
+	ClusterScoped bool `mapstructure:"cluster_scoped"`
+
+	// NameAttribute is the resource attribute holding the name of the object
+	// associated with the telemetry, like k8s.job.name. Attributes extracted
+	// from pods can be used, as objects are looked up after pods.
+	NameAttribute string `mapstructure:"name_attribute"`
+
+	// PodSelector associates the objects with the pods matched by their selector,
+	// like services, instead of looking them up by the name attribute. The name of
+	// the object matching the pod of the telemetry is set in the name attribute.
+	PodSelector bool `mapstructure:"pod_selector"`
+
+	// AttributePrefix is the prefix of the default tag names of the labels
+	// and annotations, like k8s.job for k8s.job.labels.<label key>.
+	// Default: the name attribute without its .name suffix.
+	AttributePrefix string `mapstructure:"attribute_prefix"`
+
+	// Annotations allows extracting data from the object annotations.
+	// The from field is not supported.
+	Annotations []FieldExtractConfig `mapstructure:"annotations"`
+
+	// Labels allows extracting data from the object labels.
+	// The from field is not supported.
+	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// Fields allows extracting any field of the object with a JSONPath.
+	Fields []ObjectFieldExtractConfig `mapstructure:"fields"`
+}
+
+// ObjectFieldExtractConfig allows extracting a resource attribute from a field of an object.
+type ObjectFieldExtractConfig struct {
+	// TagName represents the name of the resource attribute that will be added to logs, metrics or spans.
+	TagName string `mapstructure:"tag_name"`
+
+	// JSONPath is the JSONPath template of the field, in the kubectl syntax,
+	// like {.spec.type}. Missing fields are skipped.
+	JSONPath string `mapstructure:"json_path"`
+}
+
+func (o *ObjectExtractConfig) Validate() error {
+	if o.Version == "" || o.Resource == "" {
+		return fmt.Errorf("objects must have a version and a resource")
+	}
+	resource := o.Resource
+	if o.Group != "" {
+		resource += "." + o.Group
+	}
+	if o.NameAttribute == "" {
+		return fmt.Errorf("name_attribute must be set for the %s objects", resource)
+	}
+	if o.PodSelector && o.ClusterScoped {
+		return fmt.Errorf("pod_selector is not supported for the cluster scoped %s objects", resource)
+	}
+	for _, f := range append(o.Labels, o.Annotations...) {
+		if f.From != "" {
+			return fmt.Errorf("from is not supported for the labels and annotations of the %s objects", resource)
+		}
+		if f.Key != "" && f.KeyRegex != "" {
+			return fmt.Errorf("Out of Key or KeyRegex only one option is expected to be configured at a time, currently Key:%s and KeyRegex:%s", f.Key, f.KeyRegex)
+		}
+	}
+	for _, f := range o.Fields {
+		if f.TagName == "" {
+			return fmt.Errorf("tag_name must be set for the fields of the %s objects", resource)
+		}
+		if _, err := kube.ParseJSONPath(f.JSONPath); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a resource attribute from pod (or namespace)
@@ -246,6 +381,12 @@ type FilterConfig struct {
 	// Namespace filters all pods by the provided namespace. All other pods are ignored.
 	Namespace string `mapstructure:"namespace"`
 
+	// Namespaces filters all pods by the provided namespaces. All other pods are ignored.
+	// One informer is started for each namespace, so that collectors can be sharded by
+	// namespace and only list, watch and cache the pods of their own namespaces.
+	// Only one of Namespace and Namespaces can be set.
+	Namespaces []string `mapstructure:"namespaces"`
+
 	// Fields allows to filter pods by generic k8s fields.
 	// Only the following operations are supported:
 	//    - equals
@@ -290,6 +431,43 @@ type PodAssociationConfig struct {
 	Sources []PodAssociationSourceConfig `mapstructure:"sources"`
 }
 
+// PipelineHintsConfig allows pods to set pipeline hints through annotations.
+// The following annotations are read, relative to AnnotationPrefix:
+//   - opt-out: "true" drops all telemetry of the pod.
+//   - sample-rate: fraction of traces to keep, between 0 and 1.
+//   - redact: one of "attributes", "body" or "all".
+type PipelineHintsConfig struct {
+	// Enabled turns on reading pipeline hints from pod annotations. Hints are
+	// recorded as k8s.pod.hints.* resource attributes.
+	Enabled bool `mapstructure:"enabled"`
+
+	// AnnotationPrefix is the prefix of the hint annotations.
+	// Default: telemetry.
+	AnnotationPrefix string `mapstructure:"annotation_prefix"`
+
+	// Apply makes the processor act on the hints, dropping, sampling and
+	// redacting telemetry. When false, hints are only recorded as attributes
+	// so that later components can act on them.
+	Apply bool `mapstructure:"apply"`
+}
+
+// selectsPods returns whether the filter restricts the pods watched by the informers.
+func (f FilterConfig) selectsPods() bool {
+	return f.Node != "" || f.NodeFromEnvVar != "" || f.Namespace != "" || len(f.Namespaces) > 0 ||
+		len(f.Labels) > 0 || len(f.Fields) > 0
+}
+
+// LimitsConfig bounds the memory used to cache pod metadata.
+type LimitsConfig struct {
+	// MaxPods is the maximum number of pods to cache. When it is reached, the
+	// pods that were not looked up for the longest time are evicted, and the
+	// otelsvc/k8s/pod_evicted metric is incremented. Evicted pods are cached
+	// again on their next update or resync. The default 0 means no limit.
+	// As the informers keep the pods they watch, the watched pods must be
+	// restricted by the filter for the limit to bound the memory used.
+	MaxPods int `mapstructure:"max_pods"`
+}
+
 // ExcludeConfig represent a list of Pods to exclude
 type ExcludeConfig struct {
 	Pods []ExcludePodConfig `mapstructure:"pods"`
@@ -32,6 +32,7 @@ func TestLoadConfig(t *testing.T) {
 				Extract: ExtractConfig{
 					Metadata: enabledAttributes(),
 				},
+				PipelineHints: PipelineHintsConfig{AnnotationPrefix: kube.DefaultHintsPrefix},
 			},
 		},
 		{
@@ -103,6 +104,7 @@ func TestLoadConfig(t *testing.T) {
 						{Name: "jaeger-collector"},
 					},
 				},
+				PipelineHints: PipelineHintsConfig{AnnotationPrefix: kube.DefaultHintsPrefix},
 			},
 		},
 		{
@@ -125,6 +127,74 @@ func TestLoadConfig(t *testing.T) {
 						{Name: "jaeger-collector"},
 					},
 				},
+				PipelineHints: PipelineHintsConfig{AnnotationPrefix: kube.DefaultHintsPrefix},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "pipeline_hints"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Exclude:   ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+				},
+				PipelineHints: PipelineHintsConfig{
+					Enabled:          true,
+					AnnotationPrefix: "example.com/telemetry.",
+					Apply:            true,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "objects"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Exclude:   ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}},
+				Extract: ExtractConfig{
+					Metadata: []string{"k8s.namespace.name", "k8s.job.name"},
+					Objects: []ObjectExtractConfig{
+						{
+							Group:         "batch",
+							Version:       "v1",
+							Resource:      "jobs",
+							NameAttribute: "k8s.job.name",
+							Annotations:   []FieldExtractConfig{{TagName: "team", Key: "example.com/team"}},
+							Labels:        []FieldExtractConfig{{TagName: "$$1", KeyRegex: "app.kubernetes.io/(.*)"}},
+						},
+						{
+							Group:           "example.com",
+							Version:         "v1alpha1",
+							Resource:        "tenants",
+							ClusterScoped:   true,
+							NameAttribute:   "tenant.id",
+							AttributePrefix: "example.tenant",
+							Fields:          []ObjectFieldExtractConfig{{TagName: "tenant.tier", JSONPath: "{.spec.tier}"}},
+						},
+						{
+							Version:       "v1",
+							Resource:      "services",
+							NameAttribute: "k8s.service.name",
+							PodSelector:   true,
+						},
+					},
+				},
+				PipelineHints: PipelineHintsConfig{AnnotationPrefix: kube.DefaultHintsPrefix},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "sharded"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Exclude:   ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+				},
+				Filter: FilterConfig{
+					Namespaces: []string{"payments", "checkout"},
+					Labels:     []FieldFilterConfig{{Key: "telemetry", Value: "enabled"}},
+				},
+				Limits:        LimitsConfig{MaxPods: 2000},
+				PipelineHints: PipelineHintsConfig{AnnotationPrefix: kube.DefaultHintsPrefix},
 			},
 		},
 		{
@@ -172,6 +242,33 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_filter_field_op"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_pipeline_hints_passthrough"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_pipeline_hints_prefix"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_objects_name_attribute"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_filter_namespaces"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_limits_max_pods"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_limits_unfiltered"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_objects_from"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_objects_json_path"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_objects_pod_selector"),
+		},
 	}
 
 	for _, tt := range tests {
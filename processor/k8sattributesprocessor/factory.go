@@ -38,6 +38,9 @@ func createDefaultConfig() component.Config {
 		Extract: ExtractConfig{
 			Metadata: enabledAttributes(),
 		},
+		PipelineHints: PipelineHintsConfig{
+			AnnotationPrefix: kube.DefaultHintsPrefix,
+		},
 	}
 }
 
@@ -174,10 +177,12 @@ func createProcessorOpts(cfg component.Config) []option {
 	opts = append(opts, withExtractMetadata(oCfg.Extract.Metadata...))
 	opts = append(opts, withExtractLabels(oCfg.Extract.Labels...))
 	opts = append(opts, withExtractAnnotations(oCfg.Extract.Annotations...))
+	opts = append(opts, withExtractObjects(oCfg.Extract.Objects...))
 
 	// filters
 	opts = append(opts, withFilterNode(oCfg.Filter.Node, oCfg.Filter.NodeFromEnvVar))
 	opts = append(opts, withFilterNamespace(oCfg.Filter.Namespace))
+	opts = append(opts, withFilterNamespaces(oCfg.Filter.Namespaces...))
 	opts = append(opts, withFilterLabels(oCfg.Filter.Labels...))
 	opts = append(opts, withFilterFields(oCfg.Filter.Fields...))
 	opts = append(opts, withAPIConfig(oCfg.APIConfig))
@@ -186,5 +191,9 @@ func createProcessorOpts(cfg component.Config) []option {
 
 	opts = append(opts, withExcludes(oCfg.Exclude))
 
+	opts = append(opts, withPipelineHints(oCfg.PipelineHints))
+
+	opts = append(opts, withLimits(oCfg.Limits))
+
 	return opts
 }
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+
+import (
+	"encoding/binary"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+)
+
+// applyTracesHints applies the pod pipeline hints to the resource spans. It
+// returns true if the resource spans should be dropped.
+func applyTracesHints(hints *kube.PodHints, rs ptrace.ResourceSpans) bool {
+	if hints == nil {
+		return false
+	}
+	if hints.OptOut {
+		return true
+	}
+	redactAttrs := hints.Redact == kube.RedactAttributes || hints.Redact == kube.RedactAll
+	sampled := false
+	rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+		ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+			if hints.SampleRate != nil && !keepTraceID(span.TraceID(), *hints.SampleRate) {
+				sampled = true
+				return true
+			}
+			if redactAttrs {
+				span.Attributes().Clear()
+				for i := 0; i < span.Events().Len(); i++ {
+					span.Events().At(i).Attributes().Clear()
+				}
+				for i := 0; i < span.Links().Len(); i++ {
+					span.Links().At(i).Attributes().Clear()
+				}
+			}
+			return false
+		})
+		return sampled && ss.Spans().Len() == 0
+	})
+	return sampled && rs.ScopeSpans().Len() == 0
+}
+
+// applyLogsHints applies the pod pipeline hints to the resource logs. It
+// returns true if the resource logs should be dropped. Sampling only applies
+// to log records that carry a trace ID.
+func applyLogsHints(hints *kube.PodHints, rl plog.ResourceLogs) bool {
+	if hints == nil {
+		return false
+	}
+	if hints.OptOut {
+		return true
+	}
+	redactAttrs := hints.Redact == kube.RedactAttributes || hints.Redact == kube.RedactAll
+	redactBody := hints.Redact == kube.RedactBody || hints.Redact == kube.RedactAll
+	sampled := false
+	rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+		sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+			if hints.SampleRate != nil && !lr.TraceID().IsEmpty() && !keepTraceID(lr.TraceID(), *hints.SampleRate) {
+				sampled = true
+				return true
+			}
+			if redactAttrs {
+				lr.Attributes().Clear()
+			}
+			if redactBody {
+				pcommon.NewValueEmpty().CopyTo(lr.Body())
+			}
+			return false
+		})
+		return sampled && sl.LogRecords().Len() == 0
+	})
+	return sampled && rl.ScopeLogs().Len() == 0
+}
+
+// keepTraceID makes a sampling decision from the random part of the trace ID,
+// so that all spans and logs of a trace get the same decision.
+func keepTraceID(id pcommon.TraceID, rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	}
+	return binary.BigEndian.Uint64(id[8:])>>1 < uint64(rate*(1<<63))
+}
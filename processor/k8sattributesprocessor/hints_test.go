@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sattributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+)
+
+var (
+	keptTraceID    = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 0, 0, 0, 0, 0, 0, 0, 1})
+	sampledTraceID = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+)
+
+func newHintsProcessor(t *testing.T, apply bool, pods map[string]*kube.PodHints) *kubernetesprocessor {
+	kc, err := newFakeClient(zap.NewNop(), k8sconfig.APIConfig{}, kube.ExtractionRules{}, kube.Filters{}, nil, kube.Excludes{}, kube.Limits{}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	for uid, hints := range pods {
+		kc.(*fakeClient).Pods[newPodIdentifier("resource_attribute", "k8s.pod.uid", uid)] = &kube.Pod{
+			Name:       uid,
+			Attributes: map[string]string{"k8s.pod.name": uid},
+			Hints:      hints,
+		}
+	}
+	return &kubernetesprocessor{
+		logger:     zap.NewNop(),
+		kc:         kc,
+		applyHints: apply,
+		podAssociations: []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "resource_attribute",
+						Name: "k8s.pod.uid",
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateHintsTraces(uids ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, uid := range uids {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("k8s.pod.uid", uid)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, id := range []pcommon.TraceID{keptTraceID, sampledTraceID} {
+			span := spans.AppendEmpty()
+			span.SetTraceID(id)
+			span.Attributes().PutStr("user.email", "jdoe@example.com")
+			span.Events().AppendEmpty().Attributes().PutStr("user.email", "jdoe@example.com")
+		}
+	}
+	return td
+}
+
+func generateHintsLogs(uids ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, uid := range uids {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("k8s.pod.uid", uid)
+		lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for _, id := range []pcommon.TraceID{keptTraceID, sampledTraceID, pcommon.NewTraceIDEmpty()} {
+			lr := lrs.AppendEmpty()
+			lr.SetTraceID(id)
+			lr.Body().SetStr("login failed for jdoe@example.com")
+			lr.Attributes().PutStr("user.email", "jdoe@example.com")
+		}
+	}
+	return ld
+}
+
+func TestPipelineHintsOptOut(t *testing.T) {
+	kp := newHintsProcessor(t, true, map[string]*kube.PodHints{
+		"opted-out": {OptOut: true},
+		"regular":   nil,
+	})
+
+	td, err := kp.processTraces(context.Background(), generateHintsTraces("opted-out", "regular"))
+	require.NoError(t, err)
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	assertResourceHasStringAttribute(t, td.ResourceSpans().At(0).Resource(), "k8s.pod.name", "regular")
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("k8s.pod.uid", "opted-out")
+	_, err = kp.processMetrics(context.Background(), md)
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+
+	_, err = kp.processLogs(context.Background(), generateHintsLogs("opted-out"))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestPipelineHintsSampleRate(t *testing.T) {
+	half := 0.5
+	none := 0.0
+	kp := newHintsProcessor(t, true, map[string]*kube.PodHints{
+		"half": {SampleRate: &half},
+		"none": {SampleRate: &none},
+	})
+
+	td, err := kp.processTraces(context.Background(), generateHintsTraces("half", "none"))
+	require.NoError(t, err)
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, keptTraceID, spans.At(0).TraceID())
+
+	ld, err := kp.processLogs(context.Background(), generateHintsLogs("half", "none"))
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.ResourceLogs().Len())
+	// log records without a trace ID are never sampled out
+	assert.Equal(t, 2, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().Len())
+	lrs := ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, lrs.Len())
+	assert.True(t, lrs.At(0).TraceID().IsEmpty())
+}
+
+func TestPipelineHintsRedact(t *testing.T) {
+	kp := newHintsProcessor(t, true, map[string]*kube.PodHints{
+		"attributes": {Redact: kube.RedactAttributes},
+		"body":       {Redact: kube.RedactBody},
+		"all":        {Redact: kube.RedactAll},
+	})
+
+	td, err := kp.processTraces(context.Background(), generateHintsTraces("attributes"))
+	require.NoError(t, err)
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, 0, span.Attributes().Len())
+	assert.Equal(t, 0, span.Events().At(0).Attributes().Len())
+
+	ld, err := kp.processLogs(context.Background(), generateHintsLogs("attributes", "body", "all"))
+	require.NoError(t, err)
+	require.Equal(t, 3, ld.ResourceLogs().Len())
+
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, 0, lr.Attributes().Len())
+	assert.Equal(t, "login failed for jdoe@example.com", lr.Body().Str())
+
+	lr = ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, 1, lr.Attributes().Len())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+
+	lr = ld.ResourceLogs().At(2).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, 0, lr.Attributes().Len())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+}
+
+func TestPipelineHintsNotApplied(t *testing.T) {
+	kp := newHintsProcessor(t, false, map[string]*kube.PodHints{
+		"opted-out": {OptOut: true, Redact: kube.RedactAll},
+	})
+
+	td, err := kp.processTraces(context.Background(), generateHintsTraces("opted-out"))
+	require.NoError(t, err)
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, 1, spans.At(0).Attributes().Len())
+}
+
+func TestKeepTraceID(t *testing.T) {
+	assert.True(t, keepTraceID(sampledTraceID, 1))
+	assert.False(t, keepTraceID(keptTraceID, 0))
+	assert.True(t, keepTraceID(keptTraceID, 0.5))
+	assert.False(t, keepTraceID(sampledTraceID, 0.5))
+}
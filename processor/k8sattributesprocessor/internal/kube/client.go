@@ -4,6 +4,7 @@
 package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
 
 import (
+	"container/list"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,8 +17,10 @@ import (
 	apps_v1 "k8s.io/api/apps/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -36,18 +39,19 @@ var enableRFC3339Timestamp = featuregate.GlobalRegistry().MustRegister(
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m                  sync.RWMutex
-	deleteMut          sync.Mutex
-	logger             *zap.Logger
-	kc                 kubernetes.Interface
-	informer           cache.SharedInformer
-	namespaceInformer  cache.SharedInformer
-	nodeInformer       cache.SharedInformer
-	replicasetInformer cache.SharedInformer
-	replicasetRegex    *regexp.Regexp
-	cronJobRegex       *regexp.Regexp
-	deleteQueue        []deleteRequest
-	stopCh             chan struct{}
+	m                   sync.RWMutex
+	deleteMut           sync.Mutex
+	logger              *zap.Logger
+	kc                  kubernetes.Interface
+	informers           []cache.SharedInformer
+	namespaceInformer   cache.SharedInformer
+	nodeInformer        cache.SharedInformer
+	replicasetInformers []cache.SharedInformer
+	objectInformers     []objectInformer
+	replicasetRegex     *regexp.Regexp
+	cronJobRegex        *regexp.Regexp
+	deleteQueue         []deleteRequest
+	stopCh              chan struct{}
 
 	// A map containing Pod related data, used to associate them with resources.
 	// Key can be either an IP address or Pod UID
@@ -56,6 +60,13 @@ type WatchClient struct {
 	Filters      Filters
 	Associations []Association
 	Exclude      Excludes
+	Limits       Limits
+
+	// The cached pods from the most to the least recently looked up, and their
+	// entries by pod UID, only tracked when the number of cached pods is limited.
+	podsLRU     *list.List
+	trackedPods map[string]*list.Element
+	lruMut      sync.Mutex
 
 	// A map containing Namespace related data, used to associate them with resources.
 	// Key is namespace name
@@ -68,6 +79,10 @@ type WatchClient struct {
 	// A map containing ReplicaSets related data, used to associate them with resources.
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
+
+	// A map containing the objects of the resources in the extraction rules, used to associate them with resources.
+	// Keys are the resource, then the namespace and name of the object
+	Objects map[schema.GroupVersionResource]map[string]*Object
 }
 
 // Extract replicaset name from the pod name. Pod name is created using
@@ -79,13 +94,14 @@ var rRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]+$`)
 var cronJobRegex = regexp.MustCompile(`^(.*)-[0-9]+$`)
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace, newReplicaSetInformer InformerProviderReplicaSet) (Client, error) {
+func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, limits Limits, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace, newReplicaSetInformer InformerProviderReplicaSet, newObjectInformer InformerProviderObject) (Client, error) {
 	c := &WatchClient{
 		logger:          logger,
 		Rules:           rules,
 		Filters:         filters,
 		Associations:    associations,
 		Exclude:         exclude,
+		Limits:          limits,
 		replicasetRegex: rRegex,
 		cronJobRegex:    cronJobRegex,
 		stopCh:          make(chan struct{}),
@@ -96,6 +112,9 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	c.Namespaces = map[string]*Namespace{}
 	c.Nodes = map[string]*Node{}
 	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Objects = map[schema.GroupVersionResource]map[string]*Object{}
+	c.podsLRU = list.New()
+	c.trackedPods = map[string]*list.Element{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -131,19 +150,26 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		}
 	}
 
-	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
-	err = c.informer.SetTransform(
-		func(object interface{}) (interface{}, error) {
-			originalPod, success := object.(*api_v1.Pod)
-			if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
-				return object, nil
-			}
+	for _, namespace := range c.Filters.namespaces() {
+		informer := newInformer(c.kc, namespace, labelSelector, fieldSelector)
+		err = informer.SetTransform(
+			func(object interface{}) (interface{}, error) {
+				originalPod, success := object.(*api_v1.Pod)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
 
-			return removeUnnecessaryPodData(originalPod, c.Rules), nil
-		},
-	)
-	if err != nil {
-		return nil, err
+				// the metadata of ignored pods is never used, only what identifies them is kept
+				if c.shouldIgnorePod(originalPod) {
+					return removeIgnoredPodData(originalPod), nil
+				}
+				return removeUnnecessaryPodData(originalPod, c.Rules), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.informers = append(c.informers, informer)
 	}
 
 	c.namespaceInformer = newNamespaceInformer(c.kc)
@@ -152,19 +178,22 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		if newReplicaSetInformer == nil {
 			newReplicaSetInformer = newReplicaSetSharedInformer
 		}
-		c.replicasetInformer = newReplicaSetInformer(c.kc, c.Filters.Namespace)
-		err = c.replicasetInformer.SetTransform(
-			func(object interface{}) (interface{}, error) {
-				originalReplicaset, success := object.(*apps_v1.ReplicaSet)
-				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
-					return object, nil
-				}
+		for _, namespace := range c.Filters.namespaces() {
+			informer := newReplicaSetInformer(c.kc, namespace)
+			err = informer.SetTransform(
+				func(object interface{}) (interface{}, error) {
+					originalReplicaset, success := object.(*apps_v1.ReplicaSet)
+					if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+						return object, nil
+					}
 
-				return removeUnnecessaryReplicaSetData(originalReplicaset), nil
-			},
-		)
-		if err != nil {
-			return nil, err
+					return removeUnnecessaryReplicaSetData(originalReplicaset), nil
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			c.replicasetInformers = append(c.replicasetInformers, informer)
 		}
 	}
 
@@ -172,22 +201,59 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		c.nodeInformer = newNodeSharedInformer(c.kc, c.Filters.Node)
 	}
 
+	if len(rules.Objects) > 0 {
+		dc, err := k8sconfig.MakeDynamicClient(apiCfg)
+		if err != nil {
+			return nil, err
+		}
+		if newObjectInformer == nil {
+			newObjectInformer = newObjectSharedInformer
+		}
+		for i := range c.Rules.Objects {
+			objectRules := &c.Rules.Objects[i]
+			c.Objects[objectRules.Resource] = map[string]*Object{}
+			namespaces := c.Filters.namespaces()
+			if objectRules.ClusterScoped {
+				namespaces = []string{""}
+			}
+			for _, namespace := range namespaces {
+				informer := newObjectInformer(dc, objectRules.Resource, namespace)
+				err = informer.SetTransform(
+					func(object interface{}) (interface{}, error) {
+						originalObject, success := object.(*unstructured.Unstructured)
+						if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+							return object, nil
+						}
+
+						return removeUnnecessaryObjectData(originalObject, objectRules), nil
+					},
+				)
+				if err != nil {
+					return nil, err
+				}
+				c.objectInformers = append(c.objectInformers, objectInformer{rules: objectRules, informer: informer})
+			}
+		}
+	}
+
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
 func (c *WatchClient) Start() {
-	_, err := c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    c.handlePodAdd,
-		UpdateFunc: c.handlePodUpdate,
-		DeleteFunc: c.handlePodDelete,
-	})
-	if err != nil {
-		c.logger.Error("error adding event handler to pod informer", zap.Error(err))
+	for _, informer := range c.informers {
+		_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handlePodAdd,
+			UpdateFunc: c.handlePodUpdate,
+			DeleteFunc: c.handlePodDelete,
+		})
+		if err != nil {
+			c.logger.Error("error adding event handler to pod informer", zap.Error(err))
+		}
+		go informer.Run(c.stopCh)
 	}
-	go c.informer.Run(c.stopCh)
 
-	_, err = c.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	_, err := c.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleNamespaceAdd,
 		UpdateFunc: c.handleNamespaceUpdate,
 		DeleteFunc: c.handleNamespaceDelete,
@@ -198,15 +264,17 @@ func (c *WatchClient) Start() {
 	go c.namespaceInformer.Run(c.stopCh)
 
 	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
-		_, err = c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.handleReplicaSetAdd,
-			UpdateFunc: c.handleReplicaSetUpdate,
-			DeleteFunc: c.handleReplicaSetDelete,
-		})
-		if err != nil {
-			c.logger.Error("error adding event handler to replicaset informer", zap.Error(err))
+		for _, informer := range c.replicasetInformers {
+			_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.handleReplicaSetAdd,
+				UpdateFunc: c.handleReplicaSetUpdate,
+				DeleteFunc: c.handleReplicaSetDelete,
+			})
+			if err != nil {
+				c.logger.Error("error adding event handler to replicaset informer", zap.Error(err))
+			}
+			go informer.Run(c.stopCh)
 		}
-		go c.replicasetInformer.Run(c.stopCh)
 	}
 
 	if c.nodeInformer != nil {
@@ -220,6 +288,14 @@ func (c *WatchClient) Start() {
 		}
 		go c.nodeInformer.Run(c.stopCh)
 	}
+
+	for _, oi := range c.objectInformers {
+		_, err = oi.informer.AddEventHandler(c.objectEventHandler(oi.rules))
+		if err != nil {
+			c.logger.Error("error adding event handler to object informer", zap.String("resource", oi.rules.Resource.String()), zap.Error(err))
+		}
+		go oi.informer.Run(c.stopCh)
+	}
 }
 
 // Stop signals the the k8s watcher/informer to stop watching for new events.
@@ -353,6 +429,7 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 					// and the underlying state (ip<>pod mapping) has not changed.
 					if p.Name == d.podName {
 						delete(c.Pods, d.id)
+						c.untrackPodIdentifier(p.PodUID, d.id)
 					}
 				}
 			}
@@ -370,6 +447,10 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 func (c *WatchClient) GetPod(identifier PodIdentifier) (*Pod, bool) {
 	c.m.RLock()
 	pod, ok := c.Pods[identifier]
+	if ok && !pod.Ignore {
+		// marked with the lock held so that evicted pods are never marked
+		c.markPodUsed(pod)
+	}
 	c.m.RUnlock()
 	if ok {
 		if pod.Ignore {
@@ -598,11 +679,11 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 		}
 	}
 
-	if len(rules.Labels) > 0 {
+	if len(rules.Labels) > 0 || rules.SelectsPods() {
 		transformedPod.Labels = pod.Labels
 	}
 
-	if len(rules.Annotations) > 0 {
+	if len(rules.Annotations) > 0 || rules.HintsPrefix != "" {
 		transformedPod.Annotations = pod.Annotations
 	}
 
@@ -613,6 +694,29 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 	return &transformedPod
 }
 
+// removeIgnoredPodData removes all data from an ignored pod except what identifies it,
+// and the annotation it is ignored by.
+func removeIgnoredPodData(pod *api_v1.Pod) *api_v1.Pod {
+	transformedPod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      pod.GetName(),
+			Namespace: pod.GetNamespace(),
+			UID:       pod.GetUID(),
+		},
+		Status: api_v1.PodStatus{
+			PodIP:     pod.Status.PodIP,
+			StartTime: pod.Status.StartTime,
+		},
+		Spec: api_v1.PodSpec{
+			HostNetwork: pod.Spec.HostNetwork,
+		},
+	}
+	if v, ok := pod.Annotations[ignoreAnnotation]; ok {
+		transformedPod.Annotations = map[string]string{ignoreAnnotation: v}
+	}
+	return transformedPod
+}
+
 func (c *WatchClient) extractPodContainersAttributes(pod *api_v1.Pod) PodContainers {
 	containers := PodContainers{
 		ByID:   map[string]*Container{},
@@ -707,9 +811,16 @@ func (c *WatchClient) podFromAPI(pod *api_v1.Pod) *Pod {
 		newPod.Ignore = true
 	} else {
 		newPod.Attributes = c.extractPodAttributes(pod)
+		if c.Rules.HintsPrefix != "" {
+			newPod.Hints = c.extractPodHints(pod)
+			newPod.Hints.addAttributes(newPod.Attributes)
+		}
 		if needContainerAttributes(c.Rules) {
 			newPod.Containers = c.extractPodContainersAttributes(pod)
 		}
+		if c.Rules.SelectsPods() {
+			newPod.Labels = pod.Labels
+		}
 	}
 
 	return newPod
@@ -797,7 +908,8 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 	c.m.Lock()
 	defer c.m.Unlock()
 
-	for _, id := range c.getIdentifiersFromAssoc(newPod) {
+	ids := c.getIdentifiersFromAssoc(newPod)
+	for _, id := range ids {
 		// compare initial scheduled timestamp for existing pod and new pod with same identifier
 		// and only replace old pod if scheduled time of new pod is newer or equal.
 		// This should fix the case where scheduler has assigned the same attributes (like IP address)
@@ -809,6 +921,7 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 		}
 		c.Pods[id] = newPod
 	}
+	c.trackPod(newPod, ids)
 }
 
 func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
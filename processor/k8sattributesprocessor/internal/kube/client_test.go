@@ -144,12 +144,12 @@ func nodeAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj interfa
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, Limits{}, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, Limits{}, newFakeAPIClientset, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -162,10 +162,12 @@ func TestBadFilters(t *testing.T) {
 		Filters{Fields: []FieldFilter{{Op: selection.Exists}}},
 		[]Association{},
 		Excludes{},
+		Limits{},
 		newFakeAPIClientset,
 		NewFakeInformer,
 		NewFakeNamespaceInformer,
 		NewFakeReplicaSetInformer,
+		nil,
 	)
 	assert.Error(t, err)
 	assert.Nil(t, c)
@@ -173,7 +175,7 @@ func TestBadFilters(t *testing.T) {
 
 func TestClientStartStop(t *testing.T) {
 	c, _ := newTestClient(t)
-	ctr := c.informer.GetController()
+	ctr := c.informers[0].GetController()
 	require.IsType(t, &FakeController{}, ctr)
 	fctr := ctr.(*FakeController)
 	require.NotNil(t, fctr)
@@ -202,7 +204,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, nil)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, Limits{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, nil, nil)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, "error creating k8s client", err.Error())
@@ -1491,7 +1493,7 @@ func TestFilters(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			c, _ := newTestClientWithRulesAndFilters(t, tc.filters)
-			inf := c.informer.(*FakeInformer)
+			inf := c.informers[0].(*FakeInformer)
 			assert.Equal(t, tc.filters.Namespace, inf.namespace)
 			assert.Equal(t, tc.labels, inf.labelSelector.String())
 			assert.Equal(t, tc.fields, inf.fieldSelector.String())
@@ -1587,6 +1589,74 @@ func TestPodIgnorePatterns(t *testing.T) {
 	}
 }
 
+func TestPodHints(t *testing.T) {
+	rate := 0.25
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		hints       *PodHints
+		attributes  map[string]string
+	}{
+		{
+			name: "no hints",
+			annotations: map[string]string{
+				"other": "value",
+			},
+		},
+		{
+			name: "all hints",
+			annotations: map[string]string{
+				"telemetry.opt-out":     "True",
+				"telemetry.sample-rate": "0.25",
+				"telemetry.redact":      "body",
+			},
+			hints: &PodHints{OptOut: true, SampleRate: &rate, Redact: RedactBody},
+			attributes: map[string]string{
+				"k8s.pod.hints.opt_out":     "true",
+				"k8s.pod.hints.sample_rate": "0.25",
+				"k8s.pod.hints.redact":      "body",
+			},
+		},
+		{
+			name: "invalid values are ignored",
+			annotations: map[string]string{
+				"telemetry.opt-out":     "maybe",
+				"telemetry.sample-rate": "2",
+				"telemetry.redact":      "everything",
+				"telemetry.unknown":     "true",
+			},
+		},
+		{
+			name: "explicit defaults",
+			annotations: map[string]string{
+				"telemetry.opt-out": "false",
+				"telemetry.redact":  "none",
+			},
+			hints:      &PodHints{},
+			attributes: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newTestClient(t)
+			c.Rules = ExtractionRules{HintsPrefix: DefaultHintsPrefix}
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:        "auth-service-abc12-xyz3",
+					Annotations: tc.annotations,
+				},
+			}
+			got := c.podFromAPI(removeUnnecessaryPodData(pod, c.Rules))
+			assert.Equal(t, tc.hints, got.Hints)
+			if tc.attributes == nil {
+				tc.attributes = map[string]string{}
+			}
+			assert.Equal(t, tc.attributes, got.Attributes)
+		})
+	}
+}
+
 func Test_extractPodContainersAttributes(t *testing.T) {
 	pod := api_v1.Pod{
 		Spec: api_v1.PodSpec{
@@ -1959,7 +2029,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, f Filters) (*WatchClient, *o
 			},
 		},
 	}
-	c, err := New(logger, k8sconfig.APIConfig{}, ExtractionRules{}, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, ExtractionRules{}, f, associations, exclude, Limits{}, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, nil)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
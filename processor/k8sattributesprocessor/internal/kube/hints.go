@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	api_v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultHintsPrefix is the annotation prefix pipeline hints are read from by default.
+	DefaultHintsPrefix = "telemetry."
+
+	hintOptOut     = "opt-out"
+	hintSampleRate = "sample-rate"
+	hintRedact     = "redact"
+
+	tagHintOptOut     = "k8s.pod.hints.opt_out"
+	tagHintSampleRate = "k8s.pod.hints.sample_rate"
+	tagHintRedact     = "k8s.pod.hints.redact"
+
+	// RedactAttributes removes span, span event and log record attributes.
+	RedactAttributes = "attributes"
+	// RedactBody removes log record bodies.
+	RedactBody = "body"
+	// RedactAll removes both attributes and log record bodies.
+	RedactAll = "all"
+)
+
+// PodHints are the pipeline hints a pod sets through annotations to control
+// how its telemetry is handled.
+type PodHints struct {
+	// OptOut is set when the pod asked for its telemetry to be dropped.
+	OptOut bool
+	// SampleRate is the fraction of traces to keep, in the [0, 1] range.
+	// It is nil when the pod did not set a sample rate.
+	SampleRate *float64
+	// Redact is the redaction level requested by the pod, empty if none.
+	Redact string
+}
+
+// extractPodHints reads the pipeline hints from the pod annotations. Invalid
+// hint values are logged and ignored. It returns nil if the pod sets no hints.
+func (c *WatchClient) extractPodHints(pod *api_v1.Pod) *PodHints {
+	var hints PodHints
+	found := false
+	for key, value := range pod.Annotations {
+		name, ok := strings.CutPrefix(key, c.Rules.HintsPrefix)
+		if !ok {
+			continue
+		}
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch name {
+		case hintOptOut:
+			optOut, err := strconv.ParseBool(value)
+			if err != nil {
+				c.logger.Debug("ignoring invalid pod hint", zap.String("pod", pod.Name), zap.String("annotation", key), zap.Error(err))
+				continue
+			}
+			hints.OptOut = optOut
+		case hintSampleRate:
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				c.logger.Debug("ignoring invalid pod hint, sample rate must be between 0 and 1", zap.String("pod", pod.Name), zap.String("annotation", key))
+				continue
+			}
+			hints.SampleRate = &rate
+		case hintRedact:
+			switch value {
+			case RedactAttributes, RedactBody, RedactAll:
+				hints.Redact = value
+			case "none":
+			default:
+				c.logger.Debug("ignoring invalid pod hint, unknown redaction level", zap.String("pod", pod.Name), zap.String("annotation", key))
+				continue
+			}
+		default:
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return &hints
+}
+
+// addAttributes records the hints as pod attributes.
+func (h *PodHints) addAttributes(tags map[string]string) {
+	if h == nil {
+		return
+	}
+	if h.OptOut {
+		tags[tagHintOptOut] = "true"
+	}
+	if h.SampleRate != nil {
+		tags[tagHintSampleRate] = strconv.FormatFloat(*h.SampleRate, 'f', -1, 64)
+	}
+	if h.Redact != "" {
+		tags[tagHintRedact] = h.Redact
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 
@@ -91,12 +92,14 @@ type Client interface {
 	GetPod(PodIdentifier) (*Pod, bool)
 	GetNamespace(string) (*Namespace, bool)
 	GetNode(string) (*Node, bool)
+	GetObject(schema.GroupVersionResource, string, string) (*Object, bool)
+	GetPodObject(schema.GroupVersionResource, *Pod) (*Object, bool)
 	Start()
 	Stop()
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet) (Client, error)
+type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, Limits, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet, InformerProviderObject) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
@@ -114,9 +117,16 @@ type Pod struct {
 	NodeName    string
 	HostNetwork bool
 
+	// Labels holds the labels of the pod, only kept to match the selectors of objects.
+	Labels map[string]string
+
 	// Containers specifies all containers in this pod.
 	Containers PodContainers
 
+	// Hints holds the pipeline hints set through pod annotations, nil if
+	// the pod has none or hints are not enabled.
+	Hints *PodHints
+
 	DeletedAt time.Time
 }
 
@@ -174,8 +184,27 @@ type deleteRequest struct {
 type Filters struct {
 	Node      string
 	Namespace string
-	Fields    []FieldFilter
-	Labels    []FieldFilter
+	// Namespaces shards the watched pods by namespace, one informer
+	// being started for each of them. Namespace is ignored when set.
+	Namespaces []string
+	Fields     []FieldFilter
+	Labels     []FieldFilter
+}
+
+// namespaces returns the namespaces to start pod informers for, where the
+// empty namespace stands for all namespaces.
+func (f Filters) namespaces() []string {
+	if len(f.Namespaces) > 0 {
+		return f.Namespaces
+	}
+	return []string{f.Namespace}
+}
+
+// Limits is used to bound the memory used by the client.
+type Limits struct {
+	// MaxPods is the maximum number of pods to cache, 0 for no limit.
+	// The least recently looked up pods are evicted first.
+	MaxPods int
 }
 
 // FieldFilter represents exactly one filter by field rule.
@@ -217,8 +246,15 @@ type ExtractionRules struct {
 	ContainerImageTag  bool
 	ClusterUID         bool
 
+	// HintsPrefix is the annotation prefix pipeline hints are read from.
+	// Pod hints are not read when it is empty.
+	HintsPrefix string
+
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
+
+	// Objects lists the rules to extract metadata from objects of other resources.
+	Objects []ObjectExtractionRules
 }
 
 // IncludesOwnerMetadata determines whether the ExtractionRules include metadata about Pod Owners
@@ -244,6 +280,16 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 	return false
 }
 
+// SelectsPods determines whether objects are associated with pods through their selector.
+func (rules *ExtractionRules) SelectsPods() bool {
+	for _, objectRules := range rules.Objects {
+		if objectRules.PodSelector {
+			return true
+		}
+	}
+	return false
+}
+
 // FieldExtractionRule is used to specify which fields to extract from pod fields
 // and inject into spans as attributes.
 type FieldExtractionRule struct {
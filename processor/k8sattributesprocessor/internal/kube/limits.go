@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/observability"
+)
+
+// trackedPod is an entry of the list of cached pods, ordered from the most to
+// the least recently used.
+type trackedPod struct {
+	uid string
+	// ids are the identifiers the pod is cached under.
+	ids []PodIdentifier
+}
+
+// markPodUsed records that the pod was looked up, moving it to the front of the
+// list of cached pods so that it is evicted after the pods that have not been
+// looked up recently. It must be called with the pods lock held.
+func (c *WatchClient) markPodUsed(pod *Pod) {
+	if c.Limits.MaxPods <= 0 || pod.PodUID == "" {
+		return
+	}
+	c.lruMut.Lock()
+	if e, ok := c.trackedPods[pod.PodUID]; ok {
+		c.podsLRU.MoveToFront(e)
+	}
+	c.lruMut.Unlock()
+}
+
+// trackPod records the identifiers the pod is cached under and evicts the least
+// recently used pod when there are more pods cached than allowed. It must be
+// called with the pods lock held.
+func (c *WatchClient) trackPod(pod *Pod, ids []PodIdentifier) {
+	if c.Limits.MaxPods <= 0 || pod.PodUID == "" {
+		return
+	}
+	c.lruMut.Lock()
+	defer c.lruMut.Unlock()
+	e, ok := c.trackedPods[pod.PodUID]
+	if !ok {
+		// new pods count as used, so that they are not evicted before older pods
+		e = c.podsLRU.PushFront(&trackedPod{uid: pod.PodUID})
+		c.trackedPods[pod.PodUID] = e
+	}
+	tracked := e.Value.(*trackedPod)
+	for _, id := range ids {
+		if !containsPodIdentifier(tracked.ids, id) {
+			tracked.ids = append(tracked.ids, id)
+		}
+	}
+	if !ok && c.podsLRU.Len() > c.Limits.MaxPods {
+		c.evictLeastRecentlyUsedPod()
+	}
+}
+
+// untrackPodIdentifier forgets an identifier of a pod removed from the cache.
+// It must be called with the pods lock held.
+func (c *WatchClient) untrackPodIdentifier(uid string, id PodIdentifier) {
+	if c.Limits.MaxPods <= 0 || uid == "" {
+		return
+	}
+	c.lruMut.Lock()
+	defer c.lruMut.Unlock()
+	e, ok := c.trackedPods[uid]
+	if !ok {
+		return
+	}
+	tracked := e.Value.(*trackedPod)
+	for i := range tracked.ids {
+		if tracked.ids[i] == id {
+			tracked.ids = append(tracked.ids[:i], tracked.ids[i+1:]...)
+			break
+		}
+	}
+	if len(tracked.ids) == 0 {
+		c.podsLRU.Remove(e)
+		delete(c.trackedPods, uid)
+	}
+}
+
+// evictLeastRecentlyUsedPod removes the pod looked up or added the longest time ago
+// from the cache. It must be called with the pods and the LRU locks held.
+func (c *WatchClient) evictLeastRecentlyUsedPod() {
+	e := c.podsLRU.Back()
+	if e == nil {
+		return
+	}
+	evicted := c.podsLRU.Remove(e).(*trackedPod)
+	delete(c.trackedPods, evicted.uid)
+	for _, id := range evicted.ids {
+		if p, ok := c.Pods[id]; ok && p.PodUID == evicted.uid {
+			delete(c.Pods, id)
+		}
+	}
+	observability.RecordPodEvicted()
+	c.logger.Debug("evicted pod from the cache", zap.String("uid", evicted.uid), zap.Int("max_pods", c.Limits.MaxPods))
+}
+
+func containsPodIdentifier(ids []PodIdentifier, id PodIdentifier) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
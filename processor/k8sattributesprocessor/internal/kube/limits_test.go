@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func newTestLimitedClient(t *testing.T, limits Limits) *WatchClient {
+	associations := []Association{{Sources: []AssociationSource{{From: ConnectionSource}}}}
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, associations, Excludes{}, limits, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, nil)
+	require.NoError(t, err)
+	return c.(*WatchClient)
+}
+
+func newLimitedPod(name, ip string) *api_v1.Pod {
+	pod := &api_v1.Pod{}
+	pod.Name = name
+	pod.UID = types.UID(name + "-uid")
+	pod.Status.PodIP = ip
+	return pod
+}
+
+func TestMaxPodsEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTestLimitedClient(t, Limits{MaxPods: 2})
+
+	c.handlePodAdd(newLimitedPod("podA", "1.1.1.1"))
+	c.handlePodAdd(newLimitedPod("podB", "2.2.2.2"))
+	c.handlePodAdd(newLimitedPod("podC", "3.3.3.3"))
+	assert.Len(t, c.trackedPods, 2)
+	// podA was never looked up and was added first
+	_, ok := c.GetPod(newPodIdentifier(ConnectionSource, "", "1.1.1.1"))
+	assert.False(t, ok)
+
+	_, ok = c.GetPod(newPodIdentifier(ConnectionSource, "", "3.3.3.3"))
+	require.True(t, ok)
+
+	// updates of cached pods don't evict anything
+	c.handlePodUpdate(nil, newLimitedPod("podB", "2.2.2.2"))
+	assert.Len(t, c.trackedPods, 2)
+
+	// podB was added after podA, but podC was looked up since
+	c.handlePodAdd(newLimitedPod("podD", "4.4.4.4"))
+	assert.Len(t, c.trackedPods, 2)
+	for _, ip := range []string{"3.3.3.3", "4.4.4.4"} {
+		_, ok = c.GetPod(newPodIdentifier(ConnectionSource, "", ip))
+		assert.True(t, ok, ip)
+	}
+	_, ok = c.GetPod(newPodIdentifier(ConnectionSource, "", "2.2.2.2"))
+	assert.False(t, ok)
+	for id, pod := range c.Pods {
+		assert.Contains(t, []string{"podC", "podD"}, pod.Name, id)
+	}
+}
+
+func TestMaxPodsUntrackDeletedPods(t *testing.T) {
+	c := newTestLimitedClient(t, Limits{MaxPods: 2})
+
+	pod := newLimitedPod("podA", "1.1.1.1")
+	c.handlePodAdd(pod)
+	_, ok := c.GetPod(newPodIdentifier(ConnectionSource, "", "1.1.1.1"))
+	require.True(t, ok)
+	require.Len(t, c.trackedPods, 1)
+
+	c.handlePodDelete(pod)
+	go c.deleteLoop(time.Millisecond, 0)
+	assert.Eventually(t, func() bool {
+		c.m.RLock()
+		defer c.m.RUnlock()
+		return len(c.Pods) == 0 && len(c.trackedPods) == 0
+	}, 5*time.Second, time.Millisecond)
+	c.lruMut.Lock()
+	assert.Zero(t, c.podsLRU.Len())
+	c.lruMut.Unlock()
+	c.Stop()
+}
+
+func TestNoPodsLimit(t *testing.T) {
+	c := newTestLimitedClient(t, Limits{})
+	c.handlePodAdd(newLimitedPod("podA", "1.1.1.1"))
+	c.handlePodAdd(newLimitedPod("podB", "2.2.2.2"))
+	_, ok := c.GetPod(newPodIdentifier(ConnectionSource, "", "1.1.1.1"))
+	assert.True(t, ok)
+	assert.Empty(t, c.trackedPods)
+	assert.Zero(t, c.podsLRU.Len())
+}
+
+func TestNamespaceShards(t *testing.T) {
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{DeploymentName: true}, Filters{Namespace: "ignored", Namespaces: []string{"ns1", "ns2"}}, []Association{}, Excludes{}, Limits{}, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, nil)
+	require.NoError(t, err)
+	wc := c.(*WatchClient)
+
+	require.Len(t, wc.informers, 2)
+	assert.Equal(t, "ns1", wc.informers[0].(*FakeInformer).namespace)
+	assert.Equal(t, "ns2", wc.informers[1].(*FakeInformer).namespace)
+	assert.Len(t, wc.replicasetInformers, 2)
+}
+
+func TestRemoveIgnoredPodData(t *testing.T) {
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{Pods: []ExcludePods{{Name: regexp.MustCompile(`jaeger-agent`)}}}, Limits{}, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, nil)
+	require.NoError(t, err)
+	wc := c.(*WatchClient)
+
+	annotated := newLimitedPod("podA", "1.1.1.1")
+	annotated.Labels = map[string]string{"app": "a"}
+	annotated.Annotations = map[string]string{ignoreAnnotation: "true", "other": "value"}
+	annotated.Spec.Containers = []api_v1.Container{{Name: "app", Image: "app:1.0"}}
+	excluded := newLimitedPod("jaeger-agent", "2.2.2.2")
+	excluded.Labels = map[string]string{"app": "jaeger"}
+
+	for _, pod := range []*api_v1.Pod{annotated, excluded} {
+		require.True(t, wc.shouldIgnorePod(pod))
+		transformed := removeIgnoredPodData(pod)
+		// ignored pods are still identified and still ignored
+		assert.True(t, wc.shouldIgnorePod(transformed))
+		assert.Equal(t, pod.Name, transformed.Name)
+		assert.Equal(t, pod.UID, transformed.UID)
+		assert.Equal(t, pod.Status.PodIP, transformed.Status.PodIP)
+		assert.Empty(t, transformed.Labels)
+		assert.Empty(t, transformed.Spec.Containers)
+	}
+	assert.Equal(t, map[string]string{ignoreAnnotation: "true"}, removeIgnoredPodData(annotated).Annotations)
+}
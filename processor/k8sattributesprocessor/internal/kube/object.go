@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// InformerProviderObject defines a function type that returns a new SharedInformer. It is used to
+// allow passing custom shared informers to the watch client for fetching objects of any resource.
+type InformerProviderObject func(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer
+
+// objectInformer is an informer for the objects of a resource in the extraction rules.
+type objectInformer struct {
+	rules    *ObjectExtractionRules
+	informer cache.SharedInformer
+}
+
+// Object represents a kubernetes object of a resource configured in the extraction rules,
+// like a service, a job or a custom resource.
+type Object struct {
+	Name       string
+	Namespace  string
+	UID        string
+	Attributes map[string]string
+	// Selector selects the pods associated with the object, nil unless the
+	// objects are associated with pods through their selector.
+	Selector labels.Selector
+}
+
+// ObjectExtractionRules is used to specify the information that needs to be extracted
+// from the objects of a resource, and how they are associated with telemetry.
+type ObjectExtractionRules struct {
+	// Resource is the group, version and resource of the objects to watch.
+	Resource schema.GroupVersionResource
+	// ClusterScoped is set for resources that don't belong to a namespace.
+	ClusterScoped bool
+	// NameAttribute is the resource attribute holding the name of the object
+	// associated with the telemetry.
+	NameAttribute string
+	// Prefix is the prefix of the default tag names of labels and annotations.
+	Prefix string
+	// PodSelector associates the objects with the pods matched by their selector,
+	// like services, the name of the object being set in NameAttribute.
+	PodSelector bool
+
+	Labels      []FieldExtractionRule
+	Annotations []FieldExtractionRule
+	Fields      []JSONPathExtractionRule
+}
+
+// JSONPathExtractionRule is used to extract the value at a JSONPath of an object.
+type JSONPathExtractionRule struct {
+	// Name is used as the resource attribute name.
+	Name string
+	// Path is the JSONPath template, parsed for every object as parsed
+	// templates are not safe for concurrent use.
+	Path string
+}
+
+// ParseJSONPath parses a JSONPath template, as accepted by kubectl.
+func ParseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New("").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid json_path %q: %w", path, err)
+	}
+	return jp, nil
+}
+
+func newObjectSharedInformer(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer {
+	informer := cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.Resource(resource).Namespace(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.Resource(resource).Namespace(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&unstructured.Unstructured{},
+		watchSyncPeriod,
+	)
+	return informer
+}
+
+// objectKey returns the key of an object in the objects maps.
+func objectKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// GetPodObject takes a resource and a pod and returns the object of the resource whose selector
+// matches the pod, in the namespace of the pod. The first one by name is returned when several do.
+func (c *WatchClient) GetPodObject(resource schema.GroupVersionResource, pod *Pod) (*Object, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	var found *Object
+	podLabels := labels.Set(pod.Labels)
+	for _, object := range c.Objects[resource] {
+		if object.Selector == nil || object.Namespace != pod.Namespace || !object.Selector.Matches(podLabels) {
+			continue
+		}
+		if found == nil || object.Name < found.Name {
+			found = object
+		}
+	}
+	return found, found != nil
+}
+
+// GetObject takes a resource, a namespace and a name and returns the object they identify.
+// The namespace is ignored for cluster scoped resources.
+func (c *WatchClient) GetObject(resource schema.GroupVersionResource, namespace, name string) (*Object, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	objects, ok := c.Objects[resource]
+	if !ok {
+		return nil, false
+	}
+	object, ok := objects[objectKey(namespace, name)]
+	return object, ok
+}
+
+func (c *WatchClient) objectEventHandler(rules *ObjectExtractionRules) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.handleObjectAddOrUpdate(rules, obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.handleObjectAddOrUpdate(rules, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.handleObjectDelete(rules, obj)
+		},
+	}
+}
+
+func (c *WatchClient) handleObjectAddOrUpdate(rules *ObjectExtractionRules, obj interface{}) {
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		c.logger.Error("object received was not of type unstructured.Unstructured", zap.Any("received", obj))
+		return
+	}
+	newObject := &Object{
+		Name:       object.GetName(),
+		Namespace:  object.GetNamespace(),
+		UID:        string(object.GetUID()),
+		Attributes: c.extractObjectAttributes(rules, object),
+	}
+	if rules.PodSelector {
+		selector, err := objectSelector(object)
+		if err != nil {
+			c.logger.Debug("failed to parse object selector", zap.String("object", object.GetName()), zap.Error(err))
+		}
+		newObject.Selector = selector
+	}
+
+	c.m.Lock()
+	if newObject.Name != "" {
+		c.Objects[rules.Resource][objectKey(newObject.Namespace, newObject.Name)] = newObject
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) handleObjectDelete(rules *ObjectExtractionRules, obj interface{}) {
+	object, ok := ignoreDeletedFinalStateUnknown(obj).(*unstructured.Unstructured)
+	if !ok {
+		c.logger.Error("object received was not of type unstructured.Unstructured", zap.Any("received", obj))
+		return
+	}
+	c.m.Lock()
+	delete(c.Objects[rules.Resource], objectKey(object.GetNamespace(), object.GetName()))
+	c.m.Unlock()
+}
+
+func (c *WatchClient) extractObjectAttributes(rules *ObjectExtractionRules, object *unstructured.Unstructured) map[string]string {
+	tags := map[string]string{}
+
+	for _, r := range rules.Labels {
+		r.extractFromMetadata(object.GetLabels(), tags, rules.Prefix+".labels.%s")
+	}
+
+	for _, r := range rules.Annotations {
+		r.extractFromMetadata(object.GetAnnotations(), tags, rules.Prefix+".annotations.%s")
+	}
+
+	for _, r := range rules.Fields {
+		jp, err := ParseJSONPath(r.Path)
+		if err != nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err = jp.Execute(&buf, object.Object); err != nil {
+			c.logger.Debug("failed to extract object field", zap.String("object", object.GetName()), zap.String("attribute", r.Name), zap.Error(err))
+			continue
+		}
+		if buf.Len() > 0 {
+			tags[r.Name] = buf.String()
+		}
+	}
+
+	return tags
+}
+
+// objectSelector returns the selector of the pods of an object, read from its spec.selector field.
+// The field is either a map of labels, like in services, or a label selector, like in deployments.
+// Objects without selector select no pods, and nil is returned.
+func objectSelector(object *unstructured.Unstructured) (labels.Selector, error) {
+	field, found, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil || !found || len(field) == 0 {
+		return nil, err
+	}
+	_, hasMatchLabels := field["matchLabels"]
+	_, hasMatchExpressions := field["matchExpressions"]
+	if hasMatchLabels || hasMatchExpressions {
+		var labelSelector metav1.LabelSelector
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(field, &labelSelector); err != nil {
+			return nil, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+		if err != nil || selector.Empty() {
+			return nil, err
+		}
+		return selector, nil
+	}
+	set := labels.Set{}
+	for key, value := range field {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value of the %q selector label", key)
+		}
+		set[key] = str
+	}
+	return labels.SelectorFromSet(set), nil
+}
+
+// removeUnnecessaryObjectData removes all data from the object except its metadata,
+// and its selector for the objects associated with pods through it, unless fields
+// have to be extracted from it.
+func removeUnnecessaryObjectData(object *unstructured.Unstructured, rules *ObjectExtractionRules) *unstructured.Unstructured {
+	if len(rules.Fields) > 0 {
+		return object
+	}
+	transformedObject := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	transformedObject.SetAPIVersion(object.GetAPIVersion())
+	transformedObject.SetKind(object.GetKind())
+	transformedObject.SetName(object.GetName())
+	transformedObject.SetNamespace(object.GetNamespace())
+	transformedObject.SetUID(object.GetUID())
+	transformedObject.SetResourceVersion(object.GetResourceVersion())
+	if len(rules.Labels) > 0 {
+		transformedObject.SetLabels(object.GetLabels())
+	}
+	if len(rules.Annotations) > 0 {
+		transformedObject.SetAnnotations(object.GetAnnotations())
+	}
+	if rules.PodSelector {
+		if selector, found, _ := unstructured.NestedFieldNoCopy(object.Object, "spec", "selector"); found {
+			_ = unstructured.SetNestedField(transformedObject.Object, selector, "spec", "selector")
+		}
+	}
+	return transformedObject
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+var (
+	jobs        = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	services    = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	deployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+func newTestObjectClient(t *testing.T, rules ...ObjectExtractionRules) *WatchClient {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "127.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone}, ExtractionRules{Objects: rules}, Filters{}, []Association{}, Excludes{}, Limits{}, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, NewFakeObjectInformer)
+	require.NoError(t, err)
+	return c.(*WatchClient)
+}
+
+func newJob(namespace, name string) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"completions": int64(3),
+		},
+	}}
+	job.SetAPIVersion("batch/v1")
+	job.SetKind("Job")
+	job.SetNamespace(namespace)
+	job.SetName(name)
+	job.SetUID("11111111-2222-3333-4444-555555555555")
+	job.SetLabels(map[string]string{
+		"app.kubernetes.io/name":    "backup",
+		"app.kubernetes.io/version": "1.2",
+		"tier":                      "batch",
+	})
+	job.SetAnnotations(map[string]string{"example.com/team": "storage"})
+	return job
+}
+
+func TestObjectAddUpdateDelete(t *testing.T) {
+	c := newTestObjectClient(t, ObjectExtractionRules{
+		Resource:      jobs,
+		NameAttribute: "k8s.job.name",
+		Prefix:        "k8s.job",
+		Labels: []FieldExtractionRule{
+			{Name: "k8s.job.labels.tier", Key: "tier"},
+			{Name: "$1", KeyRegex: regexp.MustCompile("^(?:app.kubernetes.io/(.*))$"), HasKeyRegexReference: true},
+		},
+		Annotations: []FieldExtractionRule{
+			{Name: "team", Key: "example.com/team"},
+		},
+		Fields: []JSONPathExtractionRule{
+			{Name: "k8s.job.completions", Path: "{.spec.completions}"},
+			{Name: "k8s.job.parallelism", Path: "{.spec.parallelism}"},
+		},
+	})
+	require.Len(t, c.objectInformers, 1)
+	handler := c.objectEventHandler(&c.Rules.Objects[0])
+
+	handler.OnAdd(newJob("ns1", "backup-28231"), false)
+	_, ok := c.GetObject(jobs, "ns2", "backup-28231")
+	assert.False(t, ok)
+	got, ok := c.GetObject(jobs, "ns1", "backup-28231")
+	require.True(t, ok)
+	assert.Equal(t, "11111111-2222-3333-4444-555555555555", got.UID)
+	assert.Equal(t, map[string]string{
+		"k8s.job.labels.tier": "batch",
+		"name":                "backup",
+		"version":             "1.2",
+		"team":                "storage",
+		"k8s.job.completions": "3",
+	}, got.Attributes)
+
+	updated := newJob("ns1", "backup-28231")
+	updated.SetAnnotations(map[string]string{"example.com/team": "platform"})
+	handler.OnUpdate(nil, updated)
+	got, ok = c.GetObject(jobs, "ns1", "backup-28231")
+	require.True(t, ok)
+	assert.Equal(t, "platform", got.Attributes["team"])
+
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Obj: updated})
+	_, ok = c.GetObject(jobs, "ns1", "backup-28231")
+	assert.False(t, ok)
+
+	_, ok = c.GetObject(schema.GroupVersionResource{Version: "v1", Resource: "services"}, "ns1", "backup-28231")
+	assert.False(t, ok)
+}
+
+func TestRemoveUnnecessaryObjectData(t *testing.T) {
+	job := newJob("ns1", "backup-28231")
+
+	transformed := removeUnnecessaryObjectData(job, &ObjectExtractionRules{Resource: jobs})
+	assert.Equal(t, "backup-28231", transformed.GetName())
+	assert.Equal(t, "ns1", transformed.GetNamespace())
+	assert.Empty(t, transformed.GetLabels())
+	assert.Empty(t, transformed.GetAnnotations())
+	assert.NotContains(t, transformed.Object, "spec")
+
+	transformed = removeUnnecessaryObjectData(job, &ObjectExtractionRules{Resource: jobs, Labels: []FieldExtractionRule{{Key: "tier"}}})
+	assert.Equal(t, job.GetLabels(), transformed.GetLabels())
+	assert.Empty(t, transformed.GetAnnotations())
+
+	// fields can be anywhere in the object
+	transformed = removeUnnecessaryObjectData(job, &ObjectExtractionRules{Resource: jobs, Fields: []JSONPathExtractionRule{{Path: "{.spec.completions}"}}})
+	assert.Equal(t, job, transformed)
+}
+
+func newSelectingObject(apiVersion, kind, namespace, name string, selector map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"selector": selector,
+		},
+	}}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	return object
+}
+
+func TestObjectPodSelector(t *testing.T) {
+	c := newTestObjectClient(t,
+		ObjectExtractionRules{Resource: services, NameAttribute: "k8s.service.name", PodSelector: true},
+		ObjectExtractionRules{Resource: deployments, NameAttribute: "k8s.deployment.name", PodSelector: true},
+	)
+	servicesHandler := c.objectEventHandler(&c.Rules.Objects[0])
+	deploymentsHandler := c.objectEventHandler(&c.Rules.Objects[1])
+
+	servicesHandler.OnAdd(newSelectingObject("v1", "Service", "ns1", "web", map[string]interface{}{"app": "web"}), false)
+	servicesHandler.OnAdd(newSelectingObject("v1", "Service", "ns1", "all", map[string]interface{}{"tier": "frontend"}), false)
+	servicesHandler.OnAdd(newSelectingObject("v1", "Service", "ns2", "other", map[string]interface{}{"app": "web"}), false)
+	// services without selector match no pods
+	servicesHandler.OnAdd(newSelectingObject("v1", "Service", "ns1", "external", map[string]interface{}{}), false)
+	deploymentsHandler.OnAdd(newSelectingObject("apps/v1", "Deployment", "ns1", "web", map[string]interface{}{
+		"matchLabels": map[string]interface{}{"app": "web"},
+		"matchExpressions": []interface{}{
+			map[string]interface{}{"key": "track", "operator": "NotIn", "values": []interface{}{"canary"}},
+		},
+	}), false)
+
+	pod := &Pod{Namespace: "ns1", Labels: map[string]string{"app": "web", "tier": "frontend"}}
+	got, ok := c.GetPodObject(services, pod)
+	require.True(t, ok)
+	// the first service by name is returned
+	assert.Equal(t, "all", got.Name)
+	got, ok = c.GetPodObject(deployments, pod)
+	require.True(t, ok)
+	assert.Equal(t, "web", got.Name)
+
+	pod = &Pod{Namespace: "ns1", Labels: map[string]string{"app": "web", "track": "canary"}}
+	got, ok = c.GetPodObject(services, pod)
+	require.True(t, ok)
+	assert.Equal(t, "web", got.Name)
+	_, ok = c.GetPodObject(deployments, pod)
+	assert.False(t, ok)
+
+	_, ok = c.GetPodObject(services, &Pod{Namespace: "ns3", Labels: map[string]string{"app": "web"}})
+	assert.False(t, ok)
+	_, ok = c.GetPodObject(services, &Pod{Namespace: "ns1"})
+	assert.False(t, ok)
+}
+
+func TestRemoveUnnecessaryObjectDataKeepsSelector(t *testing.T) {
+	service := newSelectingObject("v1", "Service", "ns1", "web", map[string]interface{}{"app": "web"})
+	service.Object["spec"].(map[string]interface{})["clusterIP"] = "10.0.0.1"
+
+	transformed := removeUnnecessaryObjectData(service, &ObjectExtractionRules{Resource: services, PodSelector: true})
+	assert.Equal(t, map[string]interface{}{"selector": map[string]interface{}{"app": "web"}}, transformed.Object["spec"])
+}
@@ -20,6 +20,7 @@ func init() {
 		viewPodsDeleted,
 		viewIPLookupMiss,
 		viewPodTableSize,
+		viewPodsEvicted,
 		viewNamespacesAdded,
 		viewNamespacesUpdated,
 		viewNamespacesDeleted,
@@ -34,6 +35,7 @@ var (
 	mPodsAdded          = stats.Int64("otelsvc/k8s/pod_added", "Number of pod add events received", "1")
 	mPodsDeleted        = stats.Int64("otelsvc/k8s/pod_deleted", "Number of pod delete events received", "1")
 	mPodTableSize       = stats.Int64("otelsvc/k8s/pod_table_size", "Size of table containing pod info", "1")
+	mPodsEvicted        = stats.Int64("otelsvc/k8s/pod_evicted", "Number of pods evicted from the cache to stay under max_pods", "1")
 	mIPLookupMiss       = stats.Int64("otelsvc/k8s/ip_lookup_miss", "Number of times pod by IP lookup failed.", "1")
 	mNamespacesUpdated  = stats.Int64("otelsvc/k8s/namespace_updated", "Number of namespace update events received", "1")
 	mNamespacesAdded    = stats.Int64("otelsvc/k8s/namespace_added", "Number of namespace add events received", "1")
@@ -81,6 +83,13 @@ var viewPodTableSize = &view.View{
 	Aggregation: view.LastValue(),
 }
 
+var viewPodsEvicted = &view.View{
+	Name:        mPodsEvicted.Name(),
+	Description: mPodsEvicted.Description(),
+	Measure:     mPodsEvicted,
+	Aggregation: view.Sum(),
+}
+
 var viewNamespacesUpdated = &view.View{
 	Name:        mNamespacesUpdated.Name(),
 	Description: mNamespacesUpdated.Description(),
@@ -148,6 +157,11 @@ func RecordPodTableSize(podTableSize int64) {
 	stats.Record(context.Background(), mPodTableSize.M(podTableSize))
 }
 
+// RecordPodEvicted increments the metric that records pods evicted from the cache.
+func RecordPodEvicted() {
+	stats.Record(context.Background(), mPodsEvicted.M(int64(1)))
+}
+
 // RecordNamespaceUpdated increments the metric that records namespace update events received.
 func RecordNamespaceUpdated() {
 	stats.Record(context.Background(), mNamespacesUpdated.M(int64(1)))
@@ -76,6 +76,10 @@ func TestMetrics(t *testing.T) {
 			"otelsvc/k8s/ip_lookup_miss",
 			RecordIPLookupMiss,
 		},
+		{
+			"otelsvc/k8s/pod_evicted",
+			RecordPodEvicted,
+		},
 		{
 			"otelsvc/k8s/namespace_added",
 			RecordNamespaceAdded,
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -201,6 +203,61 @@ func withExtractAnnotations(annotations ...FieldExtractConfig) option {
 	}
 }
 
+// withExtractObjects allows specifying options to control extraction of metadata from objects of other resources.
+func withExtractObjects(objects ...ObjectExtractConfig) option {
+	return func(p *kubernetesprocessor) error {
+		var rules []kube.ObjectExtractionRules
+		for _, o := range objects {
+			prefix := o.AttributePrefix
+			if prefix == "" {
+				prefix = strings.TrimSuffix(o.NameAttribute, ".name")
+			}
+			objectRules := kube.ObjectExtractionRules{
+				Resource:      schema.GroupVersionResource{Group: o.Group, Version: o.Version, Resource: o.Resource},
+				ClusterScoped: o.ClusterScoped,
+				NameAttribute: o.NameAttribute,
+				Prefix:        prefix,
+				PodSelector:   o.PodSelector,
+			}
+
+			var err error
+			objectRules.Labels, err = extractObjectFieldRules(prefix, "labels", o.Labels...)
+			if err != nil {
+				return err
+			}
+			objectRules.Annotations, err = extractObjectFieldRules(prefix, "annotations", o.Annotations...)
+			if err != nil {
+				return err
+			}
+			for _, f := range o.Fields {
+				if _, err = kube.ParseJSONPath(f.JSONPath); err != nil {
+					return err
+				}
+				objectRules.Fields = append(objectRules.Fields, kube.JSONPathExtractionRule{Name: f.TagName, Path: f.JSONPath})
+			}
+			rules = append(rules, objectRules)
+		}
+		p.rules.Objects = rules
+		return nil
+	}
+}
+
+// extractObjectFieldRules builds the label or annotation rules of objects, whose default
+// tag names start with the prefix of the object instead of the from field.
+func extractObjectFieldRules(prefix string, fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
+	rules, err := extractFieldRules(fieldType, fields...)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range fields {
+		if f.TagName == "" && f.Key != "" {
+			rules[i].Name = fmt.Sprintf("%v.%v.%v", prefix, fieldType, f.Key)
+		}
+		rules[i].From = ""
+	}
+	return rules, nil
+}
+
 func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
 	var rules []kube.FieldExtractionRule
 	for _, a := range fields {
@@ -265,6 +322,14 @@ func withFilterNamespace(ns string) option {
 	}
 }
 
+// withFilterNamespaces allows specifying options to shard pods by namespaces.
+func withFilterNamespaces(namespaces ...string) option {
+	return func(p *kubernetesprocessor) error {
+		p.filters.Namespaces = namespaces
+		return nil
+	}
+}
+
 // withFilterLabels allows specifying options to control filtering pods by pod labels.
 func withFilterLabels(filters ...FieldFilterConfig) option {
 	return func(p *kubernetesprocessor) error {
@@ -361,3 +426,23 @@ func withExcludes(podExclude ExcludeConfig) option {
 		return nil
 	}
 }
+
+// withLimits allows bounding the number of cached pods.
+func withLimits(cfg LimitsConfig) option {
+	return func(p *kubernetesprocessor) error {
+		p.limits = kube.Limits{MaxPods: cfg.MaxPods}
+		return nil
+	}
+}
+
+// withPipelineHints allows pods to control their telemetry through annotations.
+func withPipelineHints(cfg PipelineHintsConfig) option {
+	return func(p *kubernetesprocessor) error {
+		if !cfg.Enabled {
+			return nil
+		}
+		p.rules.HintsPrefix = cfg.AnnotationPrefix
+		p.applyHints = cfg.Apply
+		return nil
+	}
+}
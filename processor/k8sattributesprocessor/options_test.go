@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -354,6 +355,45 @@ func TestWithExtractLabels(t *testing.T) {
 	}
 }
 
+func TestWithExtractObjects(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, withExtractObjects(
+		ObjectExtractConfig{
+			Group:         "batch",
+			Version:       "v1",
+			Resource:      "cronjobs",
+			NameAttribute: "k8s.cronjob.name",
+			Labels:        []FieldExtractConfig{{Key: "app"}},
+			Annotations:   []FieldExtractConfig{{TagName: "team", Key: "example.com/team"}},
+		},
+		ObjectExtractConfig{
+			Version:         "v1",
+			Resource:        "services",
+			NameAttribute:   "service.name",
+			AttributePrefix: "k8s.service",
+			Fields:          []ObjectFieldExtractConfig{{TagName: "k8s.service.type", JSONPath: "{.spec.type}"}},
+		},
+	)(p))
+	require.Len(t, p.rules.Objects, 2)
+
+	cronjobs := p.rules.Objects[0]
+	assert.Equal(t, schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, cronjobs.Resource)
+	assert.Equal(t, "k8s.cronjob", cronjobs.Prefix)
+	assert.Equal(t, []kube.FieldExtractionRule{{Name: "k8s.cronjob.labels.app", Key: "app"}}, cronjobs.Labels)
+	assert.Equal(t, []kube.FieldExtractionRule{{Name: "team", Key: "example.com/team"}}, cronjobs.Annotations)
+
+	services := p.rules.Objects[1]
+	assert.Equal(t, "k8s.service", services.Prefix)
+	assert.Equal(t, []kube.JSONPathExtractionRule{{Name: "k8s.service.type", Path: "{.spec.type}"}}, services.Fields)
+
+	assert.EqualError(t, withExtractObjects(ObjectExtractConfig{
+		Version:       "v1",
+		Resource:      "services",
+		NameAttribute: "service.name",
+		Fields:        []ObjectFieldExtractConfig{{TagName: "k8s.service.type", JSONPath: "{.spec.type"}},
+	})(p), `invalid json_path "{.spec.type": unclosed action`)
+}
+
 func TestWithExtractMetadata(t *testing.T) {
 	p := &kubernetesprocessor{}
 	assert.NoError(t, withExtractMetadata(enabledAttributes()...)(p))
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
 	conventions "go.opentelemetry.io/collector/semconv/v1.8.0"
 	"go.uber.org/zap"
 
@@ -33,6 +34,8 @@ type kubernetesprocessor struct {
 	filters         kube.Filters
 	podAssociations []kube.Association
 	podIgnore       kube.Excludes
+	limits          kube.Limits
+	applyHints      bool
 }
 
 func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kube.ClientProvider) error {
@@ -40,7 +43,7 @@ func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kub
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, kp.limits, nil, nil, nil, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -75,8 +78,17 @@ func (kp *kubernetesprocessor) Shutdown(context.Context) error {
 // processTraces process traces and add k8s metadata using resource IP or incoming IP as pod origin.
 func (kp *kubernetesprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	rss := td.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
-		kp.processResource(ctx, rss.At(i).Resource())
+	dropped := false
+	rss.RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		hints := kp.processResource(ctx, rs.Resource())
+		if applyTracesHints(hints, rs) {
+			dropped = true
+			return true
+		}
+		return false
+	})
+	if dropped && rss.Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
 	}
 
 	return td, nil
@@ -85,8 +97,17 @@ func (kp *kubernetesprocessor) processTraces(ctx context.Context, td ptrace.Trac
 // processMetrics process metrics and add k8s metadata using resource IP, hostname or incoming IP as pod origin.
 func (kp *kubernetesprocessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	rm := md.ResourceMetrics()
-	for i := 0; i < rm.Len(); i++ {
-		kp.processResource(ctx, rm.At(i).Resource())
+	dropped := false
+	rm.RemoveIf(func(rms pmetric.ResourceMetrics) bool {
+		hints := kp.processResource(ctx, rms.Resource())
+		if hints != nil && hints.OptOut {
+			dropped = true
+			return true
+		}
+		return false
+	})
+	if dropped && rm.Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
 	}
 
 	return md, nil
@@ -95,15 +116,27 @@ func (kp *kubernetesprocessor) processMetrics(ctx context.Context, md pmetric.Me
 // processLogs process logs and add k8s metadata using resource IP, hostname or incoming IP as pod origin.
 func (kp *kubernetesprocessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
 	rl := ld.ResourceLogs()
-	for i := 0; i < rl.Len(); i++ {
-		kp.processResource(ctx, rl.At(i).Resource())
+	dropped := false
+	rl.RemoveIf(func(rls plog.ResourceLogs) bool {
+		hints := kp.processResource(ctx, rls.Resource())
+		if applyLogsHints(hints, rls) {
+			dropped = true
+			return true
+		}
+		return false
+	})
+	if dropped && rl.Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
 	}
 
 	return ld, nil
 }
 
-// processResource adds Pod metadata tags to resource based on pod association configuration
-func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pcommon.Resource) {
+// processResource adds Pod metadata tags to resource based on pod association configuration.
+// It returns the pipeline hints of the pod when they should be applied, nil otherwise.
+func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pcommon.Resource) *kube.PodHints {
+	var hints *kube.PodHints
+	var pod *kube.Pod
 	podIdentifierValue := extractPodID(ctx, resource.Attributes(), kp.podAssociations)
 	kp.logger.Debug("evaluating pod identifier", zap.Any("value", podIdentifierValue))
 
@@ -116,11 +149,12 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 		}
 	}
 	if kp.passthroughMode {
-		return
+		return nil
 	}
 
 	if podIdentifierValue.IsNotEmpty() {
-		if pod, ok := kp.kc.GetPod(podIdentifierValue); ok {
+		var ok bool
+		if pod, ok = kp.kc.GetPod(podIdentifierValue); ok {
 			kp.logger.Debug("getting the pod", zap.Any("pod", pod))
 
 			for key, val := range pod.Attributes {
@@ -129,6 +163,9 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 				}
 			}
 			kp.addContainerAttributes(resource.Attributes(), pod)
+			if kp.applyHints {
+				hints = pod.Hints
+			}
 		}
 	}
 
@@ -151,6 +188,22 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			}
 		}
 	}
+
+	for _, objectRules := range kp.rules.Objects {
+		var attrsToAdd map[string]string
+		if objectRules.PodSelector {
+			attrsToAdd = kp.getAttributesForPodObject(resource.Attributes(), pod, objectRules)
+		} else {
+			attrsToAdd = kp.getAttributesForObject(resource.Attributes(), objectRules)
+		}
+		for key, val := range attrsToAdd {
+			if _, found := resource.Attributes().Get(key); !found {
+				resource.Attributes().PutStr(key, val)
+			}
+		}
+	}
+
+	return hints
 }
 
 // addContainerAttributes looks if pod has any container identifiers and adds additional container attributes
@@ -233,6 +286,42 @@ func (kp *kubernetesprocessor) getAttributesForPodsNode(nodeName string) map[str
 	return node.Attributes
 }
 
+// getAttributesForPodObject returns the attributes of the object whose selector matches the pod,
+// setting its name in the name attribute.
+func (kp *kubernetesprocessor) getAttributesForPodObject(attrs pcommon.Map, pod *kube.Pod, objectRules kube.ObjectExtractionRules) map[string]string {
+	if pod == nil {
+		return nil
+	}
+	object, ok := kp.kc.GetPodObject(objectRules.Resource, pod)
+	if !ok {
+		return nil
+	}
+	if _, found := attrs.Get(objectRules.NameAttribute); !found {
+		attrs.PutStr(objectRules.NameAttribute, object.Name)
+	}
+	return object.Attributes
+}
+
+// getAttributesForObject returns the attributes of the object associated with the resource attributes.
+func (kp *kubernetesprocessor) getAttributesForObject(attrs pcommon.Map, objectRules kube.ObjectExtractionRules) map[string]string {
+	name := stringAttributeFromMap(attrs, objectRules.NameAttribute)
+	if name == "" {
+		return nil
+	}
+	namespace := ""
+	if !objectRules.ClusterScoped {
+		namespace = stringAttributeFromMap(attrs, conventions.AttributeK8SNamespaceName)
+		if namespace == "" {
+			return nil
+		}
+	}
+	object, ok := kp.kc.GetObject(objectRules.Resource, namespace, name)
+	if !ok {
+		return nil
+	}
+	return object.Attributes
+}
+
 // intFromAttribute extracts int value from an attribute stored as string or int
 func intFromAttribute(val pcommon.Value) (int, error) {
 	switch val.Type() {
@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	conventions "go.opentelemetry.io/collector/semconv/v1.8.0"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
@@ -215,7 +217,7 @@ func TestNewProcessor(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.Limits, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderObject) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -727,6 +729,133 @@ func TestProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestProcessorAddObjectAttributes(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Extract.Objects = []ObjectExtractConfig{
+		{Group: "batch", Version: "v1", Resource: "jobs", NameAttribute: "k8s.job.name"},
+		{Group: "example.com", Version: "v1alpha1", Resource: "tenants", ClusterScoped: true, NameAttribute: "tenant.id"},
+	}
+	m := newMultiTest(t, cfg, nil)
+
+	jobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	tenants := schema.GroupVersionResource{Group: "example.com", Version: "v1alpha1", Resource: "tenants"}
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection("1.1.1.1"),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{Attributes: map[string]string{
+			"k8s.namespace.name": "ns1",
+			"k8s.job.name":       "backup-28231",
+			"tenant.id":          "acme",
+		}}
+		kp.kc.(*fakeClient).Objects = map[schema.GroupVersionResource]map[string]*kube.Object{
+			jobs: {
+				"ns1/backup-28231": {Name: "backup-28231", Namespace: "ns1", Attributes: map[string]string{"team": "storage"}},
+				// same name in another namespace
+				"ns2/backup-28231": {Name: "backup-28231", Namespace: "ns2", Attributes: map[string]string{"team": "other"}},
+			},
+			tenants: {
+				"acme": {Name: "acme", Attributes: map[string]string{"tenant.tier": "gold"}},
+			},
+		}
+	})
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP("1.1.1.1"),
+		},
+	})
+	m.testConsume(
+		ctx,
+		generateTraces(),
+		generateMetrics(),
+		generateLogs(),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(1)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.job.name", "backup-28231")
+		assertResourceHasStringAttribute(t, res, "team", "storage")
+		assertResourceHasStringAttribute(t, res, "tenant.tier", "gold")
+	})
+}
+
+func TestProcessorAddPodSelectorObjectAttributes(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Extract.Objects = []ObjectExtractConfig{
+		{Version: "v1", Resource: "services", NameAttribute: "k8s.service.name", PodSelector: true},
+	}
+	m := newMultiTest(t, cfg, nil)
+
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection("1.1.1.1"),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{
+			Namespace:  "ns1",
+			Labels:     map[string]string{"app": "web"},
+			Attributes: map[string]string{"k8s.namespace.name": "ns1"},
+		}
+		kp.kc.(*fakeClient).Objects = map[schema.GroupVersionResource]map[string]*kube.Object{
+			services: {
+				"ns1/web": {
+					Name:       "web",
+					Namespace:  "ns1",
+					Attributes: map[string]string{"team": "storefront"},
+					Selector:   labels.SelectorFromSet(labels.Set{"app": "web"}),
+				},
+				"ns1/db": {
+					Name:       "db",
+					Namespace:  "ns1",
+					Attributes: map[string]string{"team": "storage"},
+					Selector:   labels.SelectorFromSet(labels.Set{"app": "db"}),
+				},
+			},
+		}
+	})
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP("1.1.1.1"),
+		},
+	})
+	m.testConsume(
+		ctx,
+		generateTraces(),
+		generateMetrics(),
+		generateLogs(),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(1)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.service.name", "web")
+		assertResourceHasStringAttribute(t, res, "team", "storefront")
+	})
+}
+
 func TestProcessorAddContainerAttributes(t *testing.T) {
 	tests := []struct {
 		name         string
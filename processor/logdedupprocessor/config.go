@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the log deduplication processor.
+type Config struct {
+	// Interval is the interval at which the deduplicated logs are exported.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// LogCountAttribute is the attribute holding the number of occurrences of
+	// the deduplicated logs.
+	LogCountAttribute string `mapstructure:"log_count_attribute"`
+
+	// ExcludeAttributes are the log record attributes ignored when comparing
+	// the logs, such as request IDs. They are removed from the deduplicated logs.
+	ExcludeAttributes []string `mapstructure:"exclude_attributes"`
+
+	// MaxLogs is the maximum number of distinct logs held between the
+	// exports, 0 meaning no limit. When it is reached, the logs held are
+	// exported without waiting for the next interval.
+	MaxLogs int `mapstructure:"max_logs"`
+
+	// Templates collapses the logs whose messages only differ by variable
+	// tokens, such as IDs or timestamps.
+	Templates TemplatesConfig `mapstructure:"templates"`
+}
+
+// TemplatesConfig configures the mining of the templates of the log messages,
+// following the Drain algorithm.
+type TemplatesConfig struct {
+	// Enabled turns the template mining on. Otherwise, only the logs with
+	// identical bodies are deduplicated.
+	Enabled bool `mapstructure:"enabled"`
+
+	// TemplateAttribute is the attribute holding the template of the
+	// deduplicated logs.
+	TemplateAttribute string `mapstructure:"template_attribute"`
+
+	// SimilarityThreshold is the ratio of the tokens of a message that must be
+	// equal to the ones of a template for the message to match it, between 0
+	// and 1.
+	SimilarityThreshold float64 `mapstructure:"similarity_threshold"`
+
+	// Depth is the depth of the parse tree, from 3. The messages are matched
+	// with the templates sharing their first depth - 2 tokens.
+	Depth int `mapstructure:"depth"`
+
+	// MaxChildren is the maximum number of children of a node of the parse
+	// tree. The tokens over it are treated as variable.
+	MaxChildren int `mapstructure:"max_children"`
+
+	// MaxTemplates is the maximum number of templates kept, the least
+	// recently matched ones being evicted.
+	MaxTemplates int `mapstructure:"max_templates"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.LogCountAttribute == "" {
+		return errors.New("log_count_attribute must be set")
+	}
+	if cfg.MaxLogs < 0 {
+		return errors.New("max_logs must not be negative")
+	}
+	if cfg.Templates.Enabled {
+		return cfg.Templates.validate()
+	}
+	return nil
+}
+
+func (cfg *TemplatesConfig) validate() error {
+	if cfg.TemplateAttribute == "" {
+		return errors.New("templates: template_attribute must be set")
+	}
+	if cfg.SimilarityThreshold <= 0 || cfg.SimilarityThreshold > 1 {
+		return errors.New("templates: similarity_threshold must be greater than 0 and at most 1")
+	}
+	if cfg.Depth < 3 {
+		return errors.New("templates: depth must be at least 3")
+	}
+	if cfg.MaxChildren < 2 {
+		return errors.New("templates: max_children must be at least 2")
+	}
+	if cfg.MaxTemplates <= 0 {
+		return errors.New("templates: max_templates must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "templates"),
+			expected: &Config{
+				Interval:          time.Minute,
+				LogCountAttribute: "occurrences",
+				ExcludeAttributes: []string{"request.id"},
+				MaxLogs:           500,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					TemplateAttribute:   defaultTemplateAttribute,
+					SimilarityThreshold: 0.6,
+					Depth:               5,
+					MaxChildren:         50,
+					MaxTemplates:        500,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Config)
+		expected string
+	}{
+		{
+			name:     "no interval",
+			modify:   func(cfg *Config) { cfg.Interval = 0 },
+			expected: "interval must be positive",
+		},
+		{
+			name:     "no log count attribute",
+			modify:   func(cfg *Config) { cfg.LogCountAttribute = "" },
+			expected: "log_count_attribute must be set",
+		},
+		{
+			name:     "negative max logs",
+			modify:   func(cfg *Config) { cfg.MaxLogs = -1 },
+			expected: "max_logs must not be negative",
+		},
+		{
+			name:     "no template attribute",
+			modify:   func(cfg *Config) { cfg.Templates.TemplateAttribute = "" },
+			expected: "templates: template_attribute must be set",
+		},
+		{
+			name:     "similarity threshold out of range",
+			modify:   func(cfg *Config) { cfg.Templates.SimilarityThreshold = 1.5 },
+			expected: "templates: similarity_threshold must be greater than 0 and at most 1",
+		},
+		{
+			name:     "depth too small",
+			modify:   func(cfg *Config) { cfg.Templates.Depth = 2 },
+			expected: "templates: depth must be at least 3",
+		},
+		{
+			name:     "max children too small",
+			modify:   func(cfg *Config) { cfg.Templates.MaxChildren = 1 },
+			expected: "templates: max_children must be at least 2",
+		},
+		{
+			name:     "no max templates",
+			modify:   func(cfg *Config) { cfg.Templates.MaxTemplates = 0 },
+			expected: "templates: max_templates must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Templates.Enabled = true
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// wildcard stands for the variable tokens of the templates.
+const wildcard = "<*>"
+
+// template is a cluster of the messages sharing the same constant tokens.
+type template struct {
+	id     uint64
+	tokens []string
+	// leaf is the node of the parse tree holding the template, and elem its
+	// element in the least recently used list, so that it can be evicted.
+	leaf *node
+	elem *list.Element
+}
+
+func (t *template) String() string {
+	return strings.Join(t.tokens, " ")
+}
+
+// node is a node of the parse tree. The children of the root are keyed by
+// the number of tokens of the messages, the next ones by their first tokens.
+type node struct {
+	children  map[string]*node
+	templates []*template
+}
+
+// templateMiner finds the templates of the log messages with the Drain
+// algorithm: the messages are routed through a fixed depth tree by their
+// length and first tokens, and matched with the most similar template of the
+// leaf, which is generalized by replacing the differing tokens by wildcards.
+// See https://jiemingzhu.github.io/pub/pjhe_icws2017.pdf.
+type templateMiner struct {
+	similarityThreshold float64
+	prefixTokens        int
+	maxChildren         int
+	maxTemplates        int
+
+	root *node
+	// lru holds the templates, the most recently matched first.
+	lru    *list.List
+	nextID uint64
+}
+
+func newTemplateMiner(cfg TemplatesConfig) *templateMiner {
+	return &templateMiner{
+		similarityThreshold: cfg.SimilarityThreshold,
+		prefixTokens:        cfg.Depth - 2,
+		maxChildren:         cfg.MaxChildren,
+		maxTemplates:        cfg.MaxTemplates,
+		root:                newNode(),
+		lru:                 list.New(),
+	}
+}
+
+func newNode() *node {
+	return &node{children: map[string]*node{}}
+}
+
+// match returns the template of the message, creating it when no template is
+// similar enough.
+func (m *templateMiner) match(message string) *template {
+	tokens := strings.Fields(message)
+	leaf := m.leaf(tokens)
+
+	var best *template
+	bestSimilarity, bestWildcards := -1.0, -1
+	for _, t := range leaf.templates {
+		s, wildcards := similarity(t.tokens, tokens)
+		if s > bestSimilarity || (s == bestSimilarity && wildcards > bestWildcards) {
+			best, bestSimilarity, bestWildcards = t, s, wildcards
+		}
+	}
+	if best != nil && bestSimilarity >= m.similarityThreshold {
+		for i, token := range tokens {
+			if best.tokens[i] != token {
+				best.tokens[i] = wildcard
+			}
+		}
+		m.lru.MoveToFront(best.elem)
+		return best
+	}
+
+	if m.lru.Len() >= m.maxTemplates {
+		m.evict()
+	}
+	m.nextID++
+	t := &template{id: m.nextID, tokens: make([]string, len(tokens)), leaf: leaf}
+	for i, token := range tokens {
+		if isVariable(token) {
+			token = wildcard
+		}
+		t.tokens[i] = token
+	}
+	t.elem = m.lru.PushFront(t)
+	leaf.templates = append(leaf.templates, t)
+	return t
+}
+
+// leaf returns the leaf of the parse tree the messages with the tokens are
+// routed to, creating the missing nodes.
+func (m *templateMiner) leaf(tokens []string) *node {
+	n := child(m.root, strconv.Itoa(len(tokens)))
+	for i := 0; i < len(tokens) && i < m.prefixTokens; i++ {
+		token := tokens[i]
+		if isVariable(token) {
+			token = wildcard
+		}
+		if _, ok := n.children[token]; !ok && len(n.children) >= m.maxChildren {
+			// The tokens are too diverse at this position to be constant.
+			token = wildcard
+		}
+		n = child(n, token)
+	}
+	return n
+}
+
+func child(n *node, key string) *node {
+	c, ok := n.children[key]
+	if !ok {
+		c = newNode()
+		n.children[key] = c
+	}
+	return c
+}
+
+// evict removes the least recently matched template.
+func (m *templateMiner) evict() {
+	t := m.lru.Remove(m.lru.Back()).(*template)
+	templates := t.leaf.templates
+	for i := range templates {
+		if templates[i] == t {
+			t.leaf.templates = append(templates[:i], templates[i+1:]...)
+			break
+		}
+	}
+}
+
+// similarity returns the ratio of the tokens of the message equal to the
+// constant tokens of the template, which have the same length, and the number
+// of wildcards of the template.
+func similarity(template, tokens []string) (float64, int) {
+	if len(tokens) == 0 {
+		return 1, 0
+	}
+	var equal, wildcards int
+	for i, token := range template {
+		switch token {
+		case wildcard:
+			wildcards++
+		case tokens[i]:
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(tokens)), wildcards
+}
+
+// isVariable reports whether the token holds digits, such as IDs, numbers,
+// addresses or timestamps, which are most likely variable.
+func isVariable(token string) bool {
+	return strings.IndexFunc(token, unicode.IsDigit) >= 0
+}
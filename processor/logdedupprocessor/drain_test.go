@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTemplateMiner() *templateMiner {
+	return newTemplateMiner(TemplatesConfig{
+		SimilarityThreshold: defaultSimilarityThreshold,
+		Depth:               defaultDepth,
+		MaxChildren:         defaultMaxChildren,
+		MaxTemplates:        defaultMaxTemplates,
+	})
+}
+
+func TestTemplateMinerMatch(t *testing.T) {
+	m := newTestTemplateMiner()
+
+	connected := m.match("connected to 10.0.0.1 in 35ms")
+	assert.Equal(t, "connected to <*> in <*>", connected.String(), "Must treat the tokens holding digits as variable")
+	assert.Same(t, connected, m.match("connected to 10.0.0.2 in 12ms"))
+
+	login := m.match("user logged in as alice from web")
+	assert.Equal(t, "user logged in as alice from web", login.String())
+	assert.Same(t, login, m.match("user logged in as bob from web"))
+	assert.Equal(t, "user logged in as <*> from web", login.String(), "Must generalize the template with the differing tokens")
+
+	assert.NotSame(t, login, m.match("user logged in as alice from web app"), "Must not match messages of another length")
+	assert.NotSame(t, login, m.match("user signed in as alice from web"), "Must not match messages with other first tokens")
+	assert.NotSame(t, login, m.match("user logged the whole database to disk"), "Must not match dissimilar messages")
+
+	assert.Equal(t, "", m.match("  ").String())
+}
+
+func TestTemplateMinerMaxChildren(t *testing.T) {
+	m := newTemplateMiner(TemplatesConfig{
+		SimilarityThreshold: defaultSimilarityThreshold,
+		Depth:               defaultDepth,
+		MaxChildren:         2,
+		MaxTemplates:        defaultMaxTemplates,
+	})
+
+	m.match("alpha service started")
+	m.match("beta service started")
+	gamma := m.match("gamma service started")
+	assert.Same(t, gamma, m.match("delta service started"), "Must route the tokens over max children to the wildcard")
+	assert.Equal(t, "<*> service started", gamma.String())
+}
+
+func TestTemplateMinerEviction(t *testing.T) {
+	m := newTemplateMiner(TemplatesConfig{
+		SimilarityThreshold: defaultSimilarityThreshold,
+		Depth:               defaultDepth,
+		MaxChildren:         defaultMaxChildren,
+		MaxTemplates:        2,
+	})
+
+	first := m.match("cache warmed up")
+	second := m.match("queue is full")
+	assert.Same(t, first, m.match("cache warmed up"))
+	m.match("disk almost full now")
+
+	assert.Equal(t, 2, m.lru.Len())
+	assert.Same(t, first, m.match("cache warmed up"), "Must keep the recently matched templates")
+	assert.NotSame(t, second, m.match("queue is full"), "Must evict the least recently matched templates")
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+const (
+	defaultInterval            = 10 * time.Second
+	defaultLogCountAttribute   = "log_count"
+	defaultMaxLogs             = 10000
+	defaultTemplateAttribute   = "log.template"
+	defaultSimilarityThreshold = 0.5
+	defaultDepth               = 4
+	defaultMaxChildren         = 100
+	defaultMaxTemplates        = 1000
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory creates a factory for the log deduplication processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Interval:          defaultInterval,
+		LogCountAttribute: defaultLogCountAttribute,
+		MaxLogs:           defaultMaxLogs,
+		Templates: TemplatesConfig{
+			TemplateAttribute:   defaultTemplateAttribute,
+			SimilarityThreshold: defaultSimilarityThreshold,
+			Depth:               defaultDepth,
+			MaxChildren:         defaultMaxChildren,
+			MaxTemplates:        defaultMaxTemplates,
+		},
+	}
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	p := newLogDedupProcessor(cfg.(*Config), set.Logger, next)
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		p.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(p.start),
+		processorhelper.WithShutdown(p.shutdown))
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const keySeparator = "\x1e"
+
+// occurrences holds the first occurrence of a log and counts the others.
+type occurrences struct {
+	resourceKey string
+	scopeKey    string
+
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+	record   plog.LogRecord
+	// template is the template of the body, nil when the templates are not
+	// mined or the body is not a string.
+	template *template
+
+	count        int64
+	lastObserved pcommon.Timestamp
+}
+
+type logDedupProcessor struct {
+	logger            *zap.Logger
+	next              consumer.Logs
+	interval          time.Duration
+	logCountAttribute string
+	templateAttribute string
+	maxLogs           int
+	excluded          map[string]struct{}
+	// miner is nil when the templates are not mined.
+	miner *templateMiner
+	// now is set as a reference to help with testing.
+	now func() time.Time
+
+	mu sync.Mutex
+	// logs are the occurrences of the logs by key, exported in the order
+	// they were first received.
+	logs  map[string]*occurrences
+	order []string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newLogDedupProcessor(cfg *Config, logger *zap.Logger, next consumer.Logs) *logDedupProcessor {
+	p := &logDedupProcessor{
+		logger:            logger,
+		next:              next,
+		interval:          cfg.Interval,
+		logCountAttribute: cfg.LogCountAttribute,
+		maxLogs:           cfg.MaxLogs,
+		excluded:          map[string]struct{}{cfg.LogCountAttribute: {}},
+		now:               time.Now,
+		logs:              map[string]*occurrences{},
+	}
+	for _, key := range cfg.ExcludeAttributes {
+		p.excluded[key] = struct{}{}
+	}
+	if cfg.Templates.Enabled {
+		p.miner = newTemplateMiner(cfg.Templates)
+		p.templateAttribute = cfg.Templates.TemplateAttribute
+		p.excluded[p.templateAttribute] = struct{}{}
+	}
+	return p
+}
+
+func (p *logDedupProcessor) start(context.Context, component.Host) error {
+	var ctx context.Context
+	ctx, p.cancel = context.WithCancel(context.Background())
+	p.wg.Add(1)
+	go p.exportLoop(ctx)
+	return nil
+}
+
+func (p *logDedupProcessor) shutdown(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	p.wg.Wait()
+	// the pending logs are exported, so that they are not lost
+	return p.export(ctx)
+}
+
+func (p *logDedupProcessor) exportLoop(ctx context.Context) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.export(ctx); err != nil {
+				p.logger.Error("failed to export logs", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// export sends the deduplicated logs received since the last export. The
+// logs are held until the next export when they can't be sent.
+func (p *logDedupProcessor) export(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exportLocked(ctx)
+}
+
+// exportLocked is export, p.mu being held. The logs are sent under the lock,
+// so that the logs received meanwhile wait for the held ones to be sent.
+func (p *logDedupProcessor) exportLocked(ctx context.Context) error {
+	logs, order := p.logs, p.order
+	if len(order) == 0 {
+		return nil
+	}
+	// the templates are rendered under the lock, as they are generalized by
+	// the logs being received
+	templates := make(map[string]string, len(logs))
+	for key, o := range logs {
+		if o.template != nil {
+			templates[key] = o.template.String()
+		}
+	}
+	if err := p.next.ConsumeLogs(ctx, p.buildLogs(logs, order, templates)); err != nil {
+		return err
+	}
+	p.logs, p.order = map[string]*occurrences{}, nil
+	return nil
+}
+
+// buildLogs groups copies of the first occurrences of the logs by resource
+// and scope, annotated with their number of occurrences and their templates.
+// The occurrences are left untouched, to be sent again if the logs can't be.
+func (p *logDedupProcessor) buildLogs(logs map[string]*occurrences, order []string, templates map[string]string) plog.Logs {
+	ld := plog.NewLogs()
+	resources := make(map[string]plog.ResourceLogs)
+	scopes := make(map[string]plog.ScopeLogs)
+	for _, key := range order {
+		o := logs[key]
+		sl, ok := scopes[o.scopeKey]
+		if !ok {
+			rl, ok := resources[o.resourceKey]
+			if !ok {
+				rl = ld.ResourceLogs().AppendEmpty()
+				o.resource.CopyTo(rl.Resource())
+				resources[o.resourceKey] = rl
+			}
+			sl = rl.ScopeLogs().AppendEmpty()
+			o.scope.CopyTo(sl.Scope())
+			scopes[o.scopeKey] = sl
+		}
+		lr := sl.LogRecords().AppendEmpty()
+		o.record.CopyTo(lr)
+		lr.SetObservedTimestamp(o.lastObserved)
+		lr.Attributes().PutInt(p.logCountAttribute, o.count)
+		if tmpl, ok := templates[key]; ok {
+			lr.Attributes().PutStr(p.templateAttribute, tmpl)
+		}
+	}
+	return ld
+}
+
+// processLogs moves the logs to the occurrences of their key, which are
+// exported at the next interval. When max_logs distinct logs are held, they
+// are exported first, and the logs are refused if they can't be, those of
+// the data already held being counted again when the data is sent again.
+func (p *logDedupProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	now := pcommon.NewTimestampFromTime(p.now())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resHash := pdatautil.MapHash(rl.Resource().Attributes())
+		resourceKey := string(resHash[:])
+		// the resource and the scope are copied once for all their logs, only
+		// the first of the copies sharing a key being exported
+		var res *pcommon.Resource
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			scopeKey := strings.Join([]string{resourceKey, sl.Scope().Name(), sl.Scope().Version()}, keySeparator)
+			var scope *pcommon.InstrumentationScope
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				lr.Attributes().RemoveIf(func(key string, _ pcommon.Value) bool {
+					_, ok := p.excluded[key]
+					return ok
+				})
+
+				var tmpl *template
+				var bodyKey string
+				if p.miner != nil && lr.Body().Type() == pcommon.ValueTypeStr {
+					tmpl = p.miner.match(lr.Body().Str())
+					// the templates are compared by ID, as they are
+					// generalized over time
+					bodyKey = "t" + strconv.FormatUint(tmpl.id, 10)
+				} else {
+					bodyHash := pdatautil.ValueHash(lr.Body())
+					bodyKey = "b" + string(bodyHash[:])
+				}
+				attrsHash := pdatautil.MapHash(lr.Attributes())
+				key := strings.Join([]string{
+					scopeKey,
+					lr.SeverityNumber().String(),
+					lr.SeverityText(),
+					bodyKey,
+					string(attrsHash[:]),
+				}, keySeparator)
+
+				observed := lr.ObservedTimestamp()
+				if observed == 0 {
+					observed = now
+				}
+				if o, ok := p.logs[key]; ok {
+					o.count++
+					if observed > o.lastObserved {
+						o.lastObserved = observed
+					}
+					continue
+				}
+
+				if p.maxLogs > 0 && len(p.logs) >= p.maxLogs {
+					if err := p.exportLocked(ctx); err != nil {
+						return ld, err
+					}
+				}
+				if res == nil {
+					copied := pcommon.NewResource()
+					rl.Resource().CopyTo(copied)
+					res = &copied
+				}
+				if scope == nil {
+					copied := pcommon.NewInstrumentationScope()
+					sl.Scope().CopyTo(copied)
+					scope = &copied
+				}
+				o := &occurrences{
+					resourceKey:  resourceKey,
+					scopeKey:     scopeKey,
+					resource:     *res,
+					scope:        *scope,
+					record:       plog.NewLogRecord(),
+					template:     tmpl,
+					count:        1,
+					lastObserved: observed,
+				}
+				lr.MoveTo(o.record)
+				p.logs[key] = o
+				p.order = append(p.order, key)
+			}
+		}
+	}
+	return ld, processorhelper.ErrSkipProcessingData
+}
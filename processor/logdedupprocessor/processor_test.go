@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// newLogs creates logs of the service with a log record per body.
+func newLogs(service string, observed time.Time, bodies ...string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("app")
+	for i, body := range bodies {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr(body)
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed.Add(time.Duration(i) * time.Second)))
+		lr.Attributes().PutInt("request.id", int64(i))
+	}
+	return ld
+}
+
+type exportedLog struct {
+	service  string
+	body     string
+	attrs    map[string]any
+	observed time.Time
+}
+
+func exportedLogs(ld plog.Logs) []exportedLog {
+	var logs []exportedLog
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		service, _ := rl.Resource().Attributes().Get("service.name")
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			lrs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				logs = append(logs, exportedLog{
+					service:  service.Str(),
+					body:     lrs.At(k).Body().AsString(),
+					attrs:    lrs.At(k).Attributes().AsRaw(),
+					observed: lrs.At(k).ObservedTimestamp().AsTime(),
+				})
+			}
+		}
+	}
+	return logs
+}
+
+func TestProcessLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExcludeAttributes = []string{"request.id"}
+	sink := &consumertest.LogsSink{}
+	p := newLogDedupProcessor(cfg, zap.NewNop(), sink)
+
+	observed := time.Unix(1700000000, 0).UTC()
+	_, err := p.processLogs(context.Background(), newLogs("checkout", observed, "payment failed", "payment failed", "retrying"))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "Must hold the logs until the next interval")
+	_, err = p.processLogs(context.Background(), newLogs("cart", observed, "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	_, err = p.processLogs(context.Background(), newLogs("checkout", observed.Add(time.Minute), "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, []exportedLog{
+		{service: "checkout", body: "payment failed", attrs: map[string]any{"log_count": int64(3)}, observed: observed.Add(time.Minute)},
+		{service: "checkout", body: "retrying", attrs: map[string]any{"log_count": int64(1)}, observed: observed.Add(2 * time.Second)},
+		{service: "cart", body: "payment failed", attrs: map[string]any{"log_count": int64(1)}, observed: observed},
+	}, exportedLogs(sink.AllLogs()[0]))
+
+	require.NoError(t, p.export(context.Background()))
+	assert.Len(t, sink.AllLogs(), 1, "Must not export empty logs")
+}
+
+func TestProcessLogsTemplates(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExcludeAttributes = []string{"request.id"}
+	cfg.Templates.Enabled = true
+	sink := &consumertest.LogsSink{}
+	p := newLogDedupProcessor(cfg, zap.NewNop(), sink)
+
+	observed := time.Unix(1700000000, 0).UTC()
+	_, err := p.processLogs(context.Background(), newLogs("checkout", observed,
+		"connected to 10.0.0.1 in 35ms",
+		"user logged in as alice from web",
+		"connected to 10.0.0.2 in 12ms",
+		"user logged in as bob from web",
+	))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	ld := newLogs("checkout", observed, "")
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetInt(42)
+	_, err = p.processLogs(context.Background(), ld)
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, []exportedLog{
+		{
+			service:  "checkout",
+			body:     "connected to 10.0.0.1 in 35ms",
+			attrs:    map[string]any{"log_count": int64(2), "log.template": "connected to <*> in <*>"},
+			observed: observed.Add(2 * time.Second),
+		},
+		{
+			service:  "checkout",
+			body:     "user logged in as alice from web",
+			attrs:    map[string]any{"log_count": int64(2), "log.template": "user logged in as <*> from web"},
+			observed: observed.Add(3 * time.Second),
+		},
+		{
+			service:  "checkout",
+			body:     "42",
+			attrs:    map[string]any{"log_count": int64(1)},
+			observed: observed,
+		},
+	}, exportedLogs(sink.AllLogs()[0]))
+}
+
+func TestProcessLogsMaxLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExcludeAttributes = []string{"request.id"}
+	cfg.MaxLogs = 2
+	sink := &consumertest.LogsSink{}
+	p := newLogDedupProcessor(cfg, zap.NewNop(), sink)
+
+	observed := time.Unix(1700000000, 0).UTC()
+	_, err := p.processLogs(context.Background(), newLogs("checkout", observed, "payment failed", "retrying", "payment failed", "gave up"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	require.Len(t, sink.AllLogs(), 1, "Must export the logs held once max_logs is reached")
+	assert.Equal(t, []exportedLog{
+		{service: "checkout", body: "payment failed", attrs: map[string]any{"log_count": int64(2)}, observed: observed.Add(2 * time.Second)},
+		{service: "checkout", body: "retrying", attrs: map[string]any{"log_count": int64(1)}, observed: observed.Add(time.Second)},
+	}, exportedLogs(sink.AllLogs()[0]))
+
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 1, sink.AllLogs()[1].LogRecordCount())
+}
+
+func TestExportError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExcludeAttributes = []string{"request.id"}
+	cfg.MaxLogs = 1
+	sink := &consumertest.LogsSink{}
+	var next consumer.Logs = consumertest.NewErr(errors.New("backend unavailable"))
+	logs, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return next.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	p := newLogDedupProcessor(cfg, zap.NewNop(), logs)
+
+	observed := time.Unix(1700000000, 0).UTC()
+	_, err = p.processLogs(context.Background(), newLogs("checkout", observed, "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	require.EqualError(t, p.export(context.Background()), "backend unavailable")
+	_, err = p.processLogs(context.Background(), newLogs("checkout", observed, "retrying"))
+	require.EqualError(t, err, "backend unavailable", "Must refuse the logs while the logs held can't be exported")
+	_, err = p.processLogs(context.Background(), newLogs("checkout", observed.Add(time.Minute), "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	next = sink
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, []exportedLog{
+		{service: "checkout", body: "payment failed", attrs: map[string]any{"log_count": int64(2)}, observed: observed.Add(time.Minute)},
+	}, exportedLogs(sink.AllLogs()[0]), "Must hold the logs that couldn't be exported")
+}
+
+func TestShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	sink := &consumertest.LogsSink{}
+	p := newLogDedupProcessor(cfg, zap.NewNop(), sink)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := p.processLogs(context.Background(), newLogs("checkout", time.Now(), "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	require.NoError(t, p.shutdown(context.Background()))
+	assert.Equal(t, 1, sink.LogRecordCount(), "Must export the pending logs on shutdown")
+}
+
+func TestExportLoop(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	cfg.ExcludeAttributes = []string{"request.id"}
+	sink := &consumertest.LogsSink{}
+	p := newLogDedupProcessor(cfg, zap.NewNop(), sink)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	_, err := p.processLogs(context.Background(), newLogs("checkout", time.Now(), "payment failed", "payment failed"))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorybudgetprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/memorybudgetprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+)
+
+// Config defines the configuration of a memory budget. The budget is named
+// after the processor ID, and is shared by all the pipelines the processor is
+// part of. Its limits are expected to be lower than the limits of the
+// memory_limiter processor enforcing the limit of the whole process.
+type Config struct {
+	memorylimiterprocessor.Config `mapstructure:",squash"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errors.New("check_interval must be greater than zero")
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errors.New("limit_mib or limit_percentage must be greater than zero")
+	}
+	if cfg.MemoryLimitMiB != 0 && cfg.MemorySpikeLimitMiB >= cfg.MemoryLimitMiB {
+		return errors.New("spike_limit_mib must be smaller than limit_mib")
+	}
+	if cfg.MemoryLimitMiB == 0 && (cfg.MemoryLimitPercentage > 100 || cfg.MemorySpikePercentage == 0 || cfg.MemorySpikePercentage >= cfg.MemoryLimitPercentage) {
+		return errors.New("spike_limit_percentage must be greater than zero and smaller than limit_percentage, which must not exceed 100")
+	}
+	return nil
+}
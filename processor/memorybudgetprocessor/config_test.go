@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorybudgetprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/memorybudgetprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "logs"),
+			expected: &Config{
+				Config: memorylimiterprocessor.Config{
+					CheckInterval:       2 * time.Second,
+					MemoryLimitMiB:      2500,
+					MemorySpikeLimitMiB: 500,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "percentage"),
+			expected: &Config{
+				Config: memorylimiterprocessor.Config{
+					CheckInterval:         time.Second,
+					MemoryLimitPercentage: 50,
+					MemorySpikePercentage: 10,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "default",
+			cfg:      createDefaultConfig().(*Config),
+			expected: "limit_mib or limit_percentage must be greater than zero",
+		},
+		{
+			name:     "no check interval",
+			cfg:      &Config{Config: memorylimiterprocessor.Config{MemoryLimitMiB: 100}},
+			expected: "check_interval must be greater than zero",
+		},
+		{
+			name:     "spike limit too large",
+			cfg:      &Config{Config: memorylimiterprocessor.Config{CheckInterval: time.Second, MemoryLimitMiB: 100, MemorySpikeLimitMiB: 100}},
+			expected: "spike_limit_mib must be smaller than limit_mib",
+		},
+		{
+			name:     "spike limit percentage too large",
+			cfg:      &Config{Config: memorylimiterprocessor.Config{CheckInterval: time.Second, MemoryLimitPercentage: 50, MemorySpikePercentage: 50}},
+			expected: "spike_limit_percentage must be greater than zero and smaller than limit_percentage, which must not exceed 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.expected)
+		})
+	}
+}
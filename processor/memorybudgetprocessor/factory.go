@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package memorybudgetprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/memorybudgetprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/memorybudgetprocessor/internal/metadata"
+)
+
+const defaultCheckInterval = time.Second
+
+// factory creates the processors of the budgets with the factory of the
+// memory_limiter processor, which shares a memory limiter between the
+// pipelines a processor config is part of.
+type factory struct {
+	limiter processor.Factory
+}
+
+// NewFactory returns a new factory for the memory budget processor.
+func NewFactory() processor.Factory {
+	f := &factory{
+		limiter: memorylimiterprocessor.NewFactory(),
+	}
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(f.createTracesProcessor, metadata.TracesStability),
+		processor.WithMetrics(f.createMetricsProcessor, metadata.MetricsStability),
+		processor.WithLogs(f.createLogsProcessor, metadata.LogsStability))
+}
+
+// createDefaultConfig creates the default configuration of the processor,
+// which is expected to fail validation since limit_mib must be set.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Config: memorylimiterprocessor.Config{
+			CheckInterval: defaultCheckInterval,
+		},
+	}
+}
+
+func (f *factory) createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	return f.limiter.CreateTracesProcessor(ctx, set, &cfg.(*Config).Config, nextConsumer)
+}
+
+func (f *factory) createMetricsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	return f.limiter.CreateMetricsProcessor(ctx, set, &cfg.(*Config).Config, nextConsumer)
+}
+
+func (f *factory) createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	return f.limiter.CreateLogsProcessor(ctx, set, &cfg.(*Config).Config, nextConsumer)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+// Aggregation is how the values of the gauges aggregated together are combined.
+type Aggregation string
+
+const (
+	AggregationSum Aggregation = "sum"
+	AggregationMin Aggregation = "min"
+	AggregationMax Aggregation = "max"
+)
+
+// Config defines the configuration for the metrics aggregation processor.
+type Config struct {
+	// Interval is the length of the tumbling window over which the data points
+	// are aggregated.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxSeries is the maximum number of series produced by a window for every
+	// metric. The data points of the series beyond it are aggregated into an
+	// overflow series of the metric.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// Rules select the metrics to aggregate and the attributes to aggregate away.
+	// A metric is aggregated by the first rule matching its name.
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// Rule selects metrics and the attributes of their data points to aggregate away.
+type Rule struct {
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics are the names or the patterns of the metrics to aggregate.
+	Metrics []string `mapstructure:"metrics"`
+
+	// KeepAttributes are the attributes kept, all others are aggregated away.
+	KeepAttributes []string `mapstructure:"keep_attributes"`
+
+	// DropAttributes are the attributes aggregated away, all others are kept.
+	DropAttributes []string `mapstructure:"drop_attributes"`
+
+	// Aggregation is how the values of the gauges are combined. The values of the
+	// sums are always added up, and the buckets of the histograms merged.
+	Aggregation Aggregation `mapstructure:"aggregation"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.MaxSeries <= 0 {
+		return errors.New("max_series must be positive")
+	}
+	if len(cfg.Rules) == 0 {
+		return errors.New("at least one rule must be set")
+	}
+	for i, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if len(r.Metrics) == 0 {
+		return errors.New("metrics must be set")
+	}
+	if r.MatchType == "" {
+		return errors.New("match_type must be set")
+	}
+	if (len(r.KeepAttributes) == 0) == (len(r.DropAttributes) == 0) {
+		return errors.New("exactly one of keep_attributes and drop_attributes must be set")
+	}
+	switch r.Aggregation {
+	case "", AggregationSum, AggregationMin, AggregationMax:
+	default:
+		return fmt.Errorf("unsupported aggregation %q, must be %q, %q or %q", r.Aggregation, AggregationSum, AggregationMin, AggregationMax)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsaggregationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig()
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "custom").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t, &Config{
+		Interval:  30 * time.Second,
+		MaxSeries: 500,
+		Rules: []Rule{
+			{
+				Config:         filterset.Config{MatchType: filterset.Strict},
+				Metrics:        []string{"http.server.duration", "http.server.request.size"},
+				KeepAttributes: []string{"http.route", "http.request.method"},
+			},
+			{
+				Config:         filterset.Config{MatchType: filterset.Regexp},
+				Metrics:        []string{`^rpc\..*`},
+				DropAttributes: []string{"client.address"},
+				Aggregation:    AggregationMax,
+			},
+		},
+	}, cfg)
+}
+
+func TestValidate(t *testing.T) {
+	strict := filterset.Config{MatchType: filterset.Strict}
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: Config{Interval: time.Minute, MaxSeries: 10, Rules: []Rule{{Config: strict, Metrics: []string{"a"}, DropAttributes: []string{"b"}}}},
+		},
+		{
+			name:   "no interval",
+			config: Config{MaxSeries: 10, Rules: []Rule{{Config: strict, Metrics: []string{"a"}, DropAttributes: []string{"b"}}}},
+			err:    "interval must be positive",
+		},
+		{
+			name:   "no max_series",
+			config: Config{Interval: time.Minute, Rules: []Rule{{Config: strict, Metrics: []string{"a"}, DropAttributes: []string{"b"}}}},
+			err:    "max_series must be positive",
+		},
+		{
+			name:   "no rules",
+			config: Config{Interval: time.Minute, MaxSeries: 10},
+			err:    "at least one rule must be set",
+		},
+		{
+			name:   "no metrics",
+			config: Config{Interval: time.Minute, MaxSeries: 10, Rules: []Rule{{Config: strict, DropAttributes: []string{"b"}}}},
+			err:    "rules[0]: metrics must be set",
+		},
+		{
+			name:   "no match_type",
+			config: Config{Interval: time.Minute, MaxSeries: 10, Rules: []Rule{{Metrics: []string{"a"}, DropAttributes: []string{"b"}}}},
+			err:    "rules[0]: match_type must be set",
+		},
+		{
+			name:   "keep and drop",
+			config: Config{Interval: time.Minute, MaxSeries: 10, Rules: []Rule{{Config: strict, Metrics: []string{"a"}, KeepAttributes: []string{"b"}, DropAttributes: []string{"c"}}}},
+			err:    "rules[0]: exactly one of keep_attributes and drop_attributes must be set",
+		},
+		{
+			name:   "unsupported aggregation",
+			config: Config{Interval: time.Minute, MaxSeries: 10, Rules: []Rule{{Config: strict, Metrics: []string{"a"}, DropAttributes: []string{"b"}, Aggregation: "avg"}}},
+			err:    `rules[0]: unsupported aggregation "avg", must be "sum", "min" or "max"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const (
+	keySeparator = "\x1e"
+	// overflowAttribute is the attribute of the series aggregating the data points
+	// beyond max_series, as set by the SDKs when they overflow their cardinality limit.
+	overflowAttribute = "otel.metric.overflow"
+)
+
+type aggregationRule struct {
+	metrics     filterset.FilterSet
+	keep        map[string]struct{}
+	drop        map[string]struct{}
+	aggregation Aggregation
+}
+
+// keepAttribute reports whether an attribute is kept by the rule.
+func (r *aggregationRule) keepAttribute(k string) bool {
+	if r.keep != nil {
+		_, ok := r.keep[k]
+		return ok
+	}
+	_, ok := r.drop[k]
+	return !ok
+}
+
+type aggregator struct {
+	logger    *zap.Logger
+	next      consumer.Metrics
+	interval  time.Duration
+	maxSeries int
+	rules     []aggregationRule
+	now       func() time.Time
+
+	mu     sync.Mutex
+	series map[string]*series
+	// metricSeries is the number of series of every metric of the window,
+	// excluding their overflow series.
+	metricSeries map[string]int
+	windowStart  time.Time
+	overflowed   int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newAggregator(cfg *Config, logger *zap.Logger, next consumer.Metrics) (*aggregator, error) {
+	a := &aggregator{
+		logger:       logger,
+		next:         next,
+		interval:     cfg.Interval,
+		maxSeries:    cfg.MaxSeries,
+		now:          time.Now,
+		series:       make(map[string]*series),
+		metricSeries: make(map[string]int),
+	}
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		fs, err := filterset.CreateFilterSet(rule.Metrics, &rule.Config)
+		if err != nil {
+			return nil, err
+		}
+		r := aggregationRule{metrics: fs, aggregation: rule.Aggregation}
+		if r.aggregation == "" {
+			r.aggregation = AggregationSum
+		}
+		if len(rule.KeepAttributes) > 0 {
+			r.keep = toSet(rule.KeepAttributes)
+		} else {
+			r.drop = toSet(rule.DropAttributes)
+		}
+		a.rules = append(a.rules, r)
+	}
+	return a, nil
+}
+
+func toSet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func (a *aggregator) start(context.Context, component.Host) error {
+	a.windowStart = a.now()
+	var ctx context.Context
+	ctx, a.cancel = context.WithCancel(context.Background())
+	a.done = make(chan struct{})
+	go a.flushLoop(ctx)
+	return nil
+}
+
+func (a *aggregator) shutdown(ctx context.Context) error {
+	if a.cancel == nil {
+		return nil
+	}
+	a.cancel()
+	<-a.done
+	// the last window is flushed, short as it may be
+	return a.flush(ctx)
+}
+
+func (a *aggregator) flushLoop(ctx context.Context) {
+	defer close(a.done)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.flush(ctx); err != nil {
+				a.logger.Error("failed to send aggregated metrics", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flush sends the series of the window that ends, and starts a new one.
+func (a *aggregator) flush(ctx context.Context) error {
+	end := a.now()
+	a.mu.Lock()
+	flushed, start, overflowed := a.series, a.windowStart, a.overflowed
+	a.series, a.metricSeries, a.windowStart, a.overflowed = make(map[string]*series), make(map[string]int), end, 0
+	a.mu.Unlock()
+
+	if overflowed > 0 {
+		a.logger.Warn("data points aggregated into overflow series, max_series was reached for some metrics",
+			zap.Int("data_points", overflowed), zap.Int("max_series", a.maxSeries))
+	}
+	if len(flushed) == 0 {
+		return nil
+	}
+	return a.next.ConsumeMetrics(ctx, buildMetrics(flushed, pcommon.NewTimestampFromTime(start), pcommon.NewTimestampFromTime(end)))
+}
+
+// buildMetrics groups the series by resource, scope and metric.
+func buildMetrics(flushed map[string]*series, start, end pcommon.Timestamp) pmetric.Metrics {
+	keys := make([]string, 0, len(flushed))
+	for key := range flushed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	md := pmetric.NewMetrics()
+	resources := make(map[string]pmetric.ResourceMetrics)
+	scopes := make(map[string]pmetric.ScopeMetrics)
+	metrics := make(map[string]pmetric.Metric)
+	for _, key := range keys {
+		s := flushed[key]
+		m, ok := metrics[s.metricKey]
+		if !ok {
+			sm, ok := scopes[s.scopeKey]
+			if !ok {
+				rm, ok := resources[s.resourceKey]
+				if !ok {
+					rm = md.ResourceMetrics().AppendEmpty()
+					s.resource.CopyTo(rm.Resource())
+					resources[s.resourceKey] = rm
+				}
+				sm = rm.ScopeMetrics().AppendEmpty()
+				s.scope.CopyTo(sm.Scope())
+				scopes[s.scopeKey] = sm
+			}
+			m = sm.Metrics().AppendEmpty()
+			s.metric.CopyTo(m)
+			metrics[s.metricKey] = m
+		}
+		s.appendTo(m, start, end)
+	}
+	return md
+}
+
+// processMetrics moves the data points of the metrics matched by a rule to the
+// series of the window, and lets the other data points through.
+func (a *aggregator) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resHash := pdatautil.MapHash(rm.Resource().Attributes())
+		resourceKey := string(resHash[:])
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scopeKey := strings.Join([]string{resourceKey, sm.Scope().Name(), sm.Scope().Version()}, keySeparator)
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				rule := a.matchRule(m.Name())
+				if rule == nil {
+					return false
+				}
+				metricKey := strings.Join([]string{scopeKey, m.Name(), m.Unit(), m.Type().String()}, keySeparator)
+				if m.Type() == pmetric.MetricTypeSum && m.Sum().IsMonotonic() {
+					metricKey += keySeparator + "monotonic"
+				}
+				add := func(attrs pcommon.Map) *series {
+					return a.seriesFor(metricKey, resourceKey, scopeKey, rm.Resource(), sm.Scope(), m, attrs, rule)
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return addGauge(add, dp)
+					})
+					return m.Gauge().DataPoints().Len() == 0
+				case pmetric.MetricTypeSum:
+					sum := m.Sum()
+					if sum.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return addNumber(add, dp)
+					})
+					return sum.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					histogram := m.Histogram()
+					if histogram.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					histogram.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						if dp.Flags().NoRecordedValue() {
+							return true
+						}
+						// the data points with other bucket boundaries are let through
+						return add(dp.Attributes()).addHistogram(dp)
+					})
+					return histogram.DataPoints().Len() == 0
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+					fallthrough
+				default:
+					return false
+				}
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// addNumber aggregates a data point of a gauge or a sum, dropping it from the batch.
+func addNumber(add func(pcommon.Map) *series, dp pmetric.NumberDataPoint) bool {
+	if dp.Flags().NoRecordedValue() || dp.ValueType() == pmetric.NumberDataPointValueTypeEmpty {
+		return true
+	}
+	add(dp.Attributes()).addNumber(dp)
+	return true
+}
+
+// addGauge keeps a data point of a gauge as the last value of its source series,
+// dropping it from the batch.
+func addGauge(add func(pcommon.Map) *series, dp pmetric.NumberDataPoint) bool {
+	if dp.Flags().NoRecordedValue() || dp.ValueType() == pmetric.NumberDataPointValueTypeEmpty {
+		return true
+	}
+	source := pdatautil.MapHash(dp.Attributes())
+	add(dp.Attributes()).addGauge(string(source[:]), dp)
+	return true
+}
+
+func (a *aggregator) matchRule(name string) *aggregationRule {
+	for i := range a.rules {
+		if a.rules[i].metrics.Matches(name) {
+			return &a.rules[i]
+		}
+	}
+	return nil
+}
+
+// seriesFor returns the series of the window a data point is aggregated into,
+// creating it if needed. Beyond max_series series for the metric, it returns the
+// overflow series of the metric.
+func (a *aggregator) seriesFor(metricKey, resourceKey, scopeKey string, res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, dpAttrs pcommon.Map, rule *aggregationRule) *series {
+	attrs := pcommon.NewMap()
+	dpAttrs.Range(func(k string, v pcommon.Value) bool {
+		if rule.keepAttribute(k) {
+			v.CopyTo(attrs.PutEmpty(k))
+		}
+		return true
+	})
+	attrsHash := pdatautil.MapHash(attrs)
+	key := metricKey + keySeparator + string(attrsHash[:])
+	if s, ok := a.series[key]; ok {
+		return s
+	}
+	if a.metricSeries[metricKey] >= a.maxSeries {
+		a.overflowed++
+		key = metricKey + keySeparator + overflowAttribute
+		if s, ok := a.series[key]; ok {
+			return s
+		}
+		attrs = pcommon.NewMap()
+		attrs.PutBool(overflowAttribute, true)
+	} else {
+		a.metricSeries[metricKey]++
+	}
+	s := newSeries(resourceKey, scopeKey, metricKey, res, scope, m, attrs, rule.aggregation)
+	a.series[key] = s
+	return s
+}
@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsaggregationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+func newTestAggregator(t *testing.T, maxSeries int, rules ...Rule) (*aggregator, *consumertest.MetricsSink) {
+	sink := new(consumertest.MetricsSink)
+	a, err := newAggregator(&Config{Interval: time.Hour, MaxSeries: maxSeries, Rules: rules}, zap.NewNop(), sink)
+	require.NoError(t, err)
+	return a, sink
+}
+
+func strictRule(metric string) Rule {
+	return Rule{Config: filterset.Config{MatchType: filterset.Strict}, Metrics: []string{metric}}
+}
+
+// newRequests creates a delta sum with one data point per user of a route.
+func newRequests(route string, users map[string]int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("http")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetUnit("{request}")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for user, value := range users {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("http.route", route)
+		dp.Attributes().PutStr("user.id", user)
+		dp.SetIntValue(value)
+	}
+	return md
+}
+
+func flushed(t *testing.T, a *aggregator, sink *consumertest.MetricsSink) pmetric.Metric {
+	sink.Reset()
+	require.NoError(t, a.flush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+}
+
+func TestAggregateSum(t *testing.T) {
+	rule := strictRule("requests")
+	rule.DropAttributes = []string{"user.id"}
+	a, sink := newTestAggregator(t, 100, rule)
+	start := time.Unix(100, 0)
+	a.now = func() time.Time { return start }
+	require.NoError(t, a.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.shutdown(context.Background())) }()
+
+	for _, md := range []pmetric.Metrics{
+		newRequests("/cart", map[string]int64{"alice": 1, "bob": 2}),
+		newRequests("/cart", map[string]int64{"carol": 4}),
+		newRequests("/pay", map[string]int64{"alice": 8}),
+	} {
+		out, err := a.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		assert.Equal(t, 0, out.ResourceMetrics().Len())
+	}
+
+	a.now = func() time.Time { return start.Add(time.Minute) }
+	m := flushed(t, a, sink)
+	assert.Equal(t, "requests", m.Name())
+	assert.Equal(t, "{request}", m.Unit())
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	got := map[string]int64{}
+	for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+		dp := m.Sum().DataPoints().At(i)
+		assert.Equal(t, 1, dp.Attributes().Len())
+		route, _ := dp.Attributes().Get("http.route")
+		got[route.Str()] = dp.IntValue()
+		assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(start.Add(time.Minute)), dp.Timestamp())
+	}
+	assert.Equal(t, map[string]int64{"/cart": 7, "/pay": 8}, got)
+
+	// the next window starts empty
+	sink.Reset()
+	require.NoError(t, a.flush(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestPassThrough(t *testing.T) {
+	rule := strictRule("other")
+	rule.KeepAttributes = []string{"http.route"}
+	a, _ := newTestAggregator(t, 100, rule)
+
+	// metrics not matched by a rule
+	out, err := a.processMetrics(context.Background(), newRequests("/cart", map[string]int64{"alice": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.DataPointCount())
+
+	// cumulative sums
+	a.rules[0].metrics, err = filterset.CreateFilterSet([]string{"requests"}, &filterset.Config{MatchType: filterset.Strict})
+	require.NoError(t, err)
+	md := newRequests("/cart", map[string]int64{"alice": 1})
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	out, err = a.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.DataPointCount())
+	assert.Empty(t, a.series)
+}
+
+func TestAggregateGauge(t *testing.T) {
+	for _, tt := range []struct {
+		aggregation Aggregation
+		want        float64
+	}{
+		{aggregation: "", want: 15.5},
+		{aggregation: AggregationMin, want: 1.5},
+		{aggregation: AggregationMax, want: 8},
+	} {
+		t.Run(string(tt.aggregation), func(t *testing.T) {
+			rule := strictRule("queue.size")
+			rule.KeepAttributes = []string{"queue"}
+			rule.Aggregation = tt.aggregation
+			a, sink := newTestAggregator(t, 100, rule)
+
+			// only the last value of every worker is aggregated
+			for _, batch := range [][]struct {
+				worker    int64
+				value     float64
+				timestamp pcommon.Timestamp
+			}{
+				{{worker: 0, value: 4, timestamp: 10}, {worker: 1, value: 1.5, timestamp: 10}, {worker: 2, value: 8, timestamp: 10}},
+				{{worker: 0, value: 6, timestamp: 20}, {worker: 1, value: 100, timestamp: 5}},
+			} {
+				md := pmetric.NewMetrics()
+				m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+				m.SetName("queue.size")
+				dps := m.SetEmptyGauge().DataPoints()
+				for _, p := range batch {
+					dp := dps.AppendEmpty()
+					dp.Attributes().PutStr("queue", "orders")
+					dp.Attributes().PutInt("worker", p.worker)
+					dp.SetTimestamp(p.timestamp)
+					if p.worker == 2 {
+						dp.SetIntValue(int64(p.value))
+					} else {
+						dp.SetDoubleValue(p.value)
+					}
+				}
+				_, err := a.processMetrics(context.Background(), md)
+				require.NoError(t, err)
+			}
+
+			out := flushed(t, a, sink)
+			require.Equal(t, 1, out.Gauge().DataPoints().Len())
+			dp := out.Gauge().DataPoints().At(0)
+			assert.Equal(t, tt.want, dp.DoubleValue())
+			assert.Equal(t, map[string]any{"queue": "orders"}, dp.Attributes().AsRaw())
+			assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+		})
+	}
+}
+
+func TestAggregateHistogram(t *testing.T) {
+	rule := strictRule("latency")
+	rule.DropAttributes = []string{"user.id"}
+	a, sink := newTestAggregator(t, 100, rule)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	histogram := m.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for i, tc := range []struct {
+		bounds   []float64
+		buckets  []uint64
+		sum      float64
+		min, max float64
+	}{
+		{bounds: []float64{10, 100}, buckets: []uint64{1, 2, 0}, sum: 60, min: 5, max: 40},
+		{bounds: []float64{10, 100}, buckets: []uint64{0, 1, 1}, sum: 250, min: 20, max: 200},
+		{bounds: []float64{50}, buckets: []uint64{1, 0}, sum: 10, min: 10, max: 10},
+	} {
+		dp := histogram.DataPoints().AppendEmpty()
+		dp.Attributes().PutInt("user.id", int64(i))
+		dp.ExplicitBounds().FromRaw(tc.bounds)
+		dp.BucketCounts().FromRaw(tc.buckets)
+		var count uint64
+		for _, c := range tc.buckets {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(tc.sum)
+		dp.SetMin(tc.min)
+		dp.SetMax(tc.max)
+	}
+
+	out, err := a.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	// the data point with other boundaries is let through
+	require.Equal(t, 1, out.DataPointCount())
+	passed := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{50}, passed.ExplicitBounds().AsRaw())
+
+	got := flushed(t, a, sink).Histogram().DataPoints()
+	require.Equal(t, 1, got.Len())
+	dp := got.At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 310.0, dp.Sum())
+	assert.Equal(t, 5.0, dp.Min())
+	assert.Equal(t, 200.0, dp.Max())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, 0, dp.Attributes().Len())
+}
+
+func TestMaxSeriesOverflow(t *testing.T) {
+	rule := strictRule("requests")
+	rule.KeepAttributes = []string{"user.id"}
+	rule.Metrics = append(rule.Metrics, "errors")
+	a, sink := newTestAggregator(t, 2, rule)
+
+	for _, user := range []string{"alice", "bob", "carol", "dave", "alice"} {
+		_, err := a.processMetrics(context.Background(), newRequests("/cart", map[string]int64{user: 1}))
+		require.NoError(t, err)
+	}
+	// the series of other metrics are not limited by the ones of requests
+	for _, user := range []string{"erin", "frank"} {
+		md := newRequests("/cart", map[string]int64{user: 1})
+		md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("errors")
+		_, err := a.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+	}
+
+	sink.Reset()
+	require.NoError(t, a.flush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	got := map[string]map[string]int64{}
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		got[m.Name()] = map[string]int64{}
+		dps := m.Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			if user, ok := dp.Attributes().Get("user.id"); ok {
+				got[m.Name()][user.Str()] = dp.IntValue()
+				continue
+			}
+			overflow, ok := dp.Attributes().Get(overflowAttribute)
+			require.True(t, ok)
+			assert.True(t, overflow.Bool())
+			got[m.Name()]["overflow"] = dp.IntValue()
+		}
+	}
+	assert.Equal(t, map[string]map[string]int64{
+		"requests": {"alice": 2, "bob": 1, "overflow": 2},
+		"errors":   {"erin": 1, "frank": 1},
+	}, got)
+}
+
+func TestFlushOnShutdown(t *testing.T) {
+	rule := strictRule("requests")
+	rule.DropAttributes = []string{"user.id"}
+	a, sink := newTestAggregator(t, 100, rule)
+	require.NoError(t, a.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := a.processMetrics(context.Background(), newRequests("/cart", map[string]int64{"alice": 1}))
+	require.NoError(t, err)
+	require.NoError(t, a.shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.AllMetrics()[0].DataPointCount())
+}
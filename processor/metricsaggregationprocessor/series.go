@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// series is the aggregation of the data points of a window that have the same
+// resource, scope, metric and kept attributes.
+type series struct {
+	// resourceKey, scopeKey and metricKey group the series on flush.
+	resourceKey string
+	scopeKey    string
+	metricKey   string
+
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+	metric   pmetric.Metric
+	attrs    pcommon.Map
+
+	aggregation Aggregation
+	// number holds the aggregated value of a gauge or a sum.
+	number pmetric.NumberDataPoint
+	// gauges holds the last data point of a gauge of every source series, keyed by
+	// the hash of their attributes, the values of a series not being aggregated
+	// with each other.
+	gauges map[string]pmetric.NumberDataPoint
+	// histogram holds the merged buckets of a histogram.
+	histogram pmetric.HistogramDataPoint
+	empty     bool
+}
+
+// newSeries creates an empty series for a metric, copying the metadata of the
+// metric and of its resource and scope.
+func newSeries(resourceKey, scopeKey, metricKey string, res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, attrs pcommon.Map, aggregation Aggregation) *series {
+	s := &series{
+		resourceKey: resourceKey,
+		scopeKey:    scopeKey,
+		metricKey:   metricKey,
+		resource:    pcommon.NewResource(),
+		scope:       pcommon.NewInstrumentationScope(),
+		metric:      pmetric.NewMetric(),
+		attrs:       attrs,
+		aggregation: aggregation,
+		number:      pmetric.NewNumberDataPoint(),
+		histogram:   pmetric.NewHistogramDataPoint(),
+		empty:       true,
+	}
+	res.CopyTo(s.resource)
+	scope.CopyTo(s.scope)
+	s.metric.SetName(m.Name())
+	s.metric.SetDescription(m.Description())
+	s.metric.SetUnit(m.Unit())
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		s.metric.SetEmptyGauge()
+		s.gauges = make(map[string]pmetric.NumberDataPoint)
+	case pmetric.MetricTypeSum:
+		sum := s.metric.SetEmptySum()
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	case pmetric.MetricTypeHistogram:
+		s.metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+	}
+	return s
+}
+
+// addNumber aggregates the value of a data point of a gauge or a sum.
+func (s *series) addNumber(dp pmetric.NumberDataPoint) {
+	if s.empty {
+		s.empty = false
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			s.number.SetIntValue(dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			s.number.SetDoubleValue(dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeEmpty:
+		}
+		return
+	}
+	if s.number.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		s.number.SetIntValue(aggregateInt(s.aggregation, s.number.IntValue(), dp.IntValue()))
+		return
+	}
+	s.number.SetDoubleValue(aggregateDouble(s.aggregation, numberValue(s.number), numberValue(dp)))
+}
+
+// addGauge keeps the data point of a gauge if it is the last one of its source series.
+func (s *series) addGauge(source string, dp pmetric.NumberDataPoint) {
+	last, ok := s.gauges[source]
+	if !ok {
+		last = pmetric.NewNumberDataPoint()
+		s.gauges[source] = last
+	} else if dp.Timestamp() < last.Timestamp() {
+		return
+	}
+	last.SetTimestamp(dp.Timestamp())
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		last.SetIntValue(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		last.SetDoubleValue(dp.DoubleValue())
+	case pmetric.NumberDataPointValueTypeEmpty:
+	}
+	s.empty = false
+}
+
+// aggregateGauges aggregates the last values of the source series of a gauge.
+func (s *series) aggregateGauges() {
+	sources := make([]string, 0, len(s.gauges))
+	for source := range s.gauges {
+		sources = append(sources, source)
+	}
+	// sorted for the floating point sums to be reproducible
+	sort.Strings(sources)
+	s.empty = true
+	for _, source := range sources {
+		s.addNumber(s.gauges[source])
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func aggregateInt(aggregation Aggregation, a, b int64) int64 {
+	switch aggregation {
+	case AggregationMin:
+		if b < a {
+			return b
+		}
+		return a
+	case AggregationMax:
+		if b > a {
+			return b
+		}
+		return a
+	case AggregationSum:
+	}
+	return a + b
+}
+
+func aggregateDouble(aggregation Aggregation, a, b float64) float64 {
+	switch aggregation {
+	case AggregationMin:
+		if b < a {
+			return b
+		}
+		return a
+	case AggregationMax:
+		if b > a {
+			return b
+		}
+		return a
+	case AggregationSum:
+	}
+	return a + b
+}
+
+// addHistogram merges the buckets of a data point of a histogram. It returns false
+// if the bucket boundaries of the data point differ from the ones of the series.
+func (s *series) addHistogram(dp pmetric.HistogramDataPoint) bool {
+	if s.empty {
+		s.empty = false
+		dp.ExplicitBounds().CopyTo(s.histogram.ExplicitBounds())
+		dp.BucketCounts().CopyTo(s.histogram.BucketCounts())
+		s.histogram.SetCount(dp.Count())
+		if dp.HasSum() {
+			s.histogram.SetSum(dp.Sum())
+		}
+		if dp.HasMin() {
+			s.histogram.SetMin(dp.Min())
+		}
+		if dp.HasMax() {
+			s.histogram.SetMax(dp.Max())
+		}
+		return true
+	}
+	if !sameBounds(dp.ExplicitBounds(), s.histogram.ExplicitBounds()) || dp.BucketCounts().Len() != s.histogram.BucketCounts().Len() {
+		return false
+	}
+	s.histogram.SetCount(s.histogram.Count() + dp.Count())
+	if dp.HasSum() {
+		s.histogram.SetSum(s.histogram.Sum() + dp.Sum())
+	}
+	if dp.HasMin() && (!s.histogram.HasMin() || dp.Min() < s.histogram.Min()) {
+		s.histogram.SetMin(dp.Min())
+	}
+	if dp.HasMax() && (!s.histogram.HasMax() || dp.Max() > s.histogram.Max()) {
+		s.histogram.SetMax(dp.Max())
+	}
+	buckets := s.histogram.BucketCounts()
+	for i := 0; i < buckets.Len(); i++ {
+		buckets.SetAt(i, buckets.At(i)+dp.BucketCounts().At(i))
+	}
+	return true
+}
+
+func sameBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendTo appends the aggregated data point of the series to its metric.
+func (s *series) appendTo(m pmetric.Metric, start, end pcommon.Timestamp) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		s.aggregateGauges()
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		s.number.CopyTo(dp)
+		s.attrs.CopyTo(dp.Attributes())
+		dp.SetTimestamp(end)
+	case pmetric.MetricTypeSum:
+		dp := m.Sum().DataPoints().AppendEmpty()
+		s.number.CopyTo(dp)
+		s.attrs.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(end)
+	case pmetric.MetricTypeHistogram:
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		s.histogram.CopyTo(dp)
+		s.attrs.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(end)
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+	}
+}
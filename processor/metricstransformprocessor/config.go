@@ -36,6 +36,9 @@ const (
 
 	// submatchCaseFieldName is the mapstructure field name for submatchCase field
 	submatchCaseFieldName = "submatch_case"
+
+	// valueMappingsFieldName is the mapstructure field name for ValueMappings field
+	valueMappingsFieldName = "value_mappings"
 )
 
 // Config defines configuration for Resource processor.
@@ -128,6 +131,14 @@ type Operation struct {
 
 	// LabelValue identifies the exact label value to operate on
 	LabelValue string `mapstructure:"label_value"`
+
+	// ValueMappings is an ordered list of rules mapping label values. The first rule
+	// matching a label value sets its new value.
+	ValueMappings []ValueMapping `mapstructure:"value_mappings"`
+
+	// DefaultValue is the new label value when no rule of ValueMappings matches.
+	// The label value is kept when empty.
+	DefaultValue string `mapstructure:"default_value"`
 }
 
 // ValueAction renames label values.
@@ -139,6 +150,16 @@ type ValueAction struct {
 	NewValue string `mapstructure:"new_value"`
 }
 
+// ValueMapping maps the label values matching a regexp.
+type ValueMapping struct {
+	// Pattern is the regexp matched against the label value.
+	Pattern string `mapstructure:"pattern"`
+
+	// NewValue specifies the new label value, which may refer to the submatches of
+	// the pattern, e.g. $1.
+	NewValue string `mapstructure:"new_value"`
+}
+
 // ConfigAction is the enum to capture the type of action to perform on a metric.
 type ConfigAction string
 
@@ -202,9 +223,16 @@ const (
 	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
 	// otherwise the operation will be ignored.
 	aggregateLabelValues operationAction = "aggregate_label_values"
+
+	// mapLabelValues maps the label values by the first rule of Operation.ValueMappings
+	// matching them, or to Operation.DefaultValue, and aggregates the points by the
+	// method indicated by Operation.AggregationType.
+	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
+	// otherwise the operation will be ignored.
+	mapLabelValues operationAction = "map_label_values"
 )
 
-var operationActions = []operationAction{addLabel, updateLabel, deleteLabelValue, toggleScalarDataType, scaleValue, aggregateLabels, aggregateLabelValues}
+var operationActions = []operationAction{addLabel, updateLabel, deleteLabelValue, toggleScalarDataType, scaleValue, aggregateLabels, aggregateLabelValues, mapLabelValues}
 
 func (oa operationAction) isValid() bool {
 	for _, operationAction := range operationActions {
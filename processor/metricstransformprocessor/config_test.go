@@ -106,6 +106,16 @@ func TestLoadConfig(t *testing.T) {
 								AggregatedValues: []string{"value1", "value2"},
 								NewValue:         "new_value",
 							},
+							{
+								Action: "map_label_values",
+								Label:  "queue",
+								ValueMappings: []ValueMapping{
+									{Pattern: "^(critical|high)-.*$", NewValue: "$1"},
+									{Pattern: "^batch-", NewValue: "batch"},
+								},
+								DefaultValue:    "other",
+								AggregationType: "sum",
+							},
 						},
 					},
 					{
@@ -113,6 +113,11 @@ func validateConfiguration(config *Config) error {
 			if op.Action == scaleValue && op.Scale == 0 {
 				return fmt.Errorf("operation %v: missing required field %q while %q is %v", i+1, scaleFieldName, actionFieldName, scaleValue)
 			}
+			if op.Action == mapLabelValues {
+				if err := validateMapLabelValues(op); err != nil {
+					return fmt.Errorf("operation %v: %w", i+1, err)
+				}
+			}
 
 			if op.AggregationType != "" && !op.AggregationType.isValid() {
 				return fmt.Errorf("operation %v: %q must be in %q", i+1, aggregationTypeFieldName, aggregationTypes)
@@ -122,6 +127,24 @@ func validateConfiguration(config *Config) error {
 	return nil
 }
 
+func validateMapLabelValues(op Operation) error {
+	if op.Label == "" {
+		return fmt.Errorf("missing required field %q while %q is %v", labelFieldName, actionFieldName, mapLabelValues)
+	}
+	if len(op.ValueMappings) == 0 {
+		return fmt.Errorf("missing required field %q while %q is %v", valueMappingsFieldName, actionFieldName, mapLabelValues)
+	}
+	if op.AggregationType == "" {
+		return fmt.Errorf("missing required field %q while %q is %v", aggregationTypeFieldName, actionFieldName, mapLabelValues)
+	}
+	for j, mapping := range op.ValueMappings {
+		if _, err := regexp.Compile(mapping.Pattern); err != nil {
+			return fmt.Errorf("value mapping %v: %w", j+1, err)
+		}
+	}
+	return nil
+}
+
 // buildHelperConfig constructs the maps that will be useful for the operations
 func buildHelperConfig(config *Config, version string) ([]internalTransform, error) {
 	helperDataTransforms := make([]internalTransform, len(config.Transforms))
@@ -158,6 +181,12 @@ func buildHelperConfig(config *Config, version string) ([]internalTransform, err
 				mtpOp.labelSetMap = sliceToSet(op.LabelSet)
 			} else if op.Action == aggregateLabelValues {
 				mtpOp.aggregatedValuesSet = sliceToSet(op.AggregatedValues)
+			} else if op.Action == mapLabelValues {
+				op.DefaultValue = strings.ReplaceAll(op.DefaultValue, "{{version}}", version)
+				mtpOp.configOperation.DefaultValue = op.DefaultValue
+				if mtpOp.valueMappings, err = createValueMappings(op.ValueMappings, version); err != nil {
+					return nil, err
+				}
 			}
 			helperT.Operations[j] = mtpOp
 		}
@@ -195,6 +224,22 @@ func createLabelValueMapping(valueActions []ValueAction, version string) map[str
 	return mapping
 }
 
+// createValueMappings compiles the patterns of the valueMappings
+func createValueMappings(valueMappings []ValueMapping, version string) ([]valueMapping, error) {
+	mappings := make([]valueMapping, len(valueMappings))
+	for i, mapping := range valueMappings {
+		pattern, err := regexp.Compile(mapping.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		mappings[i] = valueMapping{
+			pattern:  pattern,
+			newValue: strings.ReplaceAll(mapping.NewValue, "{{version}}", version),
+		}
+	}
+	return mappings, nil
+}
+
 // sliceToSet converts slice of strings to set of strings
 // Returns the set of strings
 func sliceToSet(slice []string) map[string]bool {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -78,6 +79,16 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("operation %v: missing required field %q while %q is %v", 1, scaleFieldName, actionFieldName, scaleValue),
 		},
+		{
+			configName:   "config_invalid_valuemappings.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operation %v: missing required field %q while %q is %v", 1, valueMappingsFieldName, actionFieldName, mapLabelValues),
+		},
+		{
+			configName:   "config_invalid_valuemappings_pattern.yaml",
+			succeed:      false,
+			errorMessage: "operation 1: value mapping 2: error parsing regexp: missing closing ): `^(batch`",
+		},
 		{
 			configName:   "config_invalid_regexp.yaml",
 			succeed:      false,
@@ -229,6 +240,15 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 					NewValue:         "new-value",
 					AggregationType:  sum,
 				},
+				{
+					Action: mapLabelValues,
+					Label:  "label",
+					ValueMappings: []ValueMapping{
+						{Pattern: "^(.*)-canary$", NewValue: "$1 {{version}}"},
+					},
+					DefaultValue:    "other {{version}}",
+					AggregationType: sum,
+				},
 			},
 		},
 	}
@@ -284,6 +304,20 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 						"value2": true,
 					},
 				},
+				{
+					configOperation: Operation{
+						Action: mapLabelValues,
+						Label:  "label",
+						ValueMappings: []ValueMapping{
+							{Pattern: "^(.*)-canary$", NewValue: "$1 {{version}}"},
+						},
+						DefaultValue:    "other v0.0.1",
+						AggregationType: sum,
+					},
+					valueMappings: []valueMapping{
+						{pattern: regexp.MustCompile("^(.*)-canary$"), newValue: "$1 v0.0.1"},
+					},
+				},
 			},
 		},
 	}
@@ -302,6 +336,7 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 			assert.Equal(t, expOp.valueActionsMapping, mtpOp.valueActionsMapping)
 			assert.Equal(t, expOp.labelSetMap, mtpOp.labelSetMap)
 			assert.Equal(t, expOp.aggregatedValuesSet, mtpOp.aggregatedValuesSet)
+			assert.Equal(t, expOp.valueMappings, mtpOp.valueMappings)
 		}
 	}
 }
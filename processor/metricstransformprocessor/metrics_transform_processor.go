@@ -33,6 +33,12 @@ type internalOperation struct {
 	valueActionsMapping map[string]string
 	labelSetMap         map[string]bool
 	aggregatedValuesSet map[string]bool
+	valueMappings       []valueMapping
+}
+
+type valueMapping struct {
+	pattern  *regexp.Regexp
+	newValue string
 }
 
 type internalFilter interface {
@@ -515,6 +515,82 @@ var (
 					build(),
 			},
 		},
+		{
+			name: "metric_label_values_mapping_sum_int_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          mapLabelValues,
+								Label:           "label2",
+								DefaultValue:    "other",
+								AggregationType: sum,
+							},
+							valueMappings: []valueMapping{
+								{pattern: regexp.MustCompile("^(critical|high)-.*$"), newValue: "$1"},
+								{pattern: regexp.MustCompile("^critical"), newValue: "unreachable"},
+								{pattern: regexp.MustCompile("^batch-"), newValue: "batch"},
+							},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1", "label1", "label2").
+					addIntDatapoint(0, 2, 3, "label1-value1", "critical-payments").
+					addIntDatapoint(0, 2, 1, "label1-value1", "critical-orders").
+					addIntDatapoint(0, 2, 2, "label1-value1", "batch-reports").
+					addIntDatapoint(0, 2, 5, "label1-value1", "batch-exports").
+					addIntDatapoint(0, 2, 4, "label1-value1", "emails").
+					addIntDatapoint(0, 2, 6, "label1-value1", "high-search").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1", "label1", "label2").
+					addIntDatapoint(0, 2, 4, "label1-value1", "critical").
+					addIntDatapoint(0, 2, 7, "label1-value1", "batch").
+					addIntDatapoint(0, 2, 4, "label1-value1", "other").
+					addIntDatapoint(0, 2, 6, "label1-value1", "high").
+					build(),
+			},
+		},
+		{
+			name: "metric_label_values_mapping_without_default_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          mapLabelValues,
+								Label:           "label2",
+								AggregationType: max,
+							},
+							valueMappings: []valueMapping{
+								{pattern: regexp.MustCompile("^batch-"), newValue: "batch"},
+							},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1", "label1", "label2").
+					addIntDatapoint(0, 2, 2, "label1-value1", "batch-reports").
+					addIntDatapoint(0, 2, 5, "label1-value1", "batch-exports").
+					addIntDatapoint(0, 2, 4, "label1-value1", "emails").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1", "label1", "label2").
+					addIntDatapoint(0, 2, 5, "label1-value1", "batch").
+					addIntDatapoint(0, 2, 4, "label1-value1", "emails").
+					build(),
+			},
+		},
 		// this test case also tests the correctness of the SumOfSquaredDeviation merging
 		{
 			name: "metric_label_values_aggregation_sum_distribution_update",
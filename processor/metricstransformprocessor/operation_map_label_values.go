@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// mapLabelValuesOp maps the label values by the first matching rule of value_mappings,
+// or to default_value, and aggregates the points that end up with the same labels
+func mapLabelValuesOp(metric pmetric.Metric, mtpOp internalOperation) {
+	rangeDataPointAttributes(metric, func(attrs pcommon.Map) bool {
+		val, ok := attrs.Get(mtpOp.configOperation.Label)
+		if !ok {
+			return true
+		}
+
+		if newValue, ok := mapLabelValue(val.AsString(), mtpOp); ok {
+			val.SetStr(newValue)
+		}
+		return true
+	})
+
+	newMetric := pmetric.NewMetric()
+	copyMetricDetails(metric, newMetric)
+	ag := groupDataPoints(metric, aggGroups{})
+	mergeDataPoints(newMetric, mtpOp.configOperation.AggregationType, ag)
+	newMetric.MoveTo(metric)
+}
+
+func mapLabelValue(value string, mtpOp internalOperation) (string, bool) {
+	for _, mapping := range mtpOp.valueMappings {
+		if submatches := mapping.pattern.FindStringSubmatchIndex(value); submatches != nil {
+			return string(mapping.pattern.ExpandString(nil, mapping.newValue, value, submatches)), true
+		}
+	}
+	if mtpOp.configOperation.DefaultValue != "" {
+		return mtpOp.configOperation.DefaultValue, true
+	}
+	return "", false
+}
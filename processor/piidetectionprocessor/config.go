@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Action is what the processor does with the PII found by a detector.
+type Action string
+
+const (
+	// ActionMask replaces the PII with asterisks.
+	ActionMask Action = "mask"
+	// ActionHash replaces the PII with its salted SHA-256 hash, keeping it
+	// correlatable without exposing it.
+	ActionHash Action = "hash"
+	// ActionDrop drops the span or the log record holding the PII.
+	ActionDrop Action = "drop"
+	// ActionAnnotate leaves the PII in place and records where it was found
+	// in the attributes of the span or the log record.
+	ActionAnnotate Action = "annotate"
+)
+
+// Config defines the configuration for the PII detection processor.
+type Config struct {
+	// Detectors are applied in order to the string values of the span, span
+	// event and log record attributes and of the log bodies.
+	Detectors []DetectorConfig `mapstructure:"detectors"`
+
+	// MinConfidence is the confidence from which the matches of the detectors
+	// are considered PII, between 0 and 1.
+	MinConfidence float64 `mapstructure:"min_confidence"`
+
+	// IgnoredKeys are the attribute keys whose values are never scanned.
+	IgnoredKeys []string `mapstructure:"ignored_keys"`
+
+	// HashSalt is prepended to the PII before hashing it, so that hashes of
+	// guessable values can't be reversed with precomputed tables.
+	HashSalt configopaque.String `mapstructure:"hash_salt"`
+}
+
+// DetectorConfig configures a builtin detector, or a custom one when Pattern is set.
+type DetectorConfig struct {
+	// Name is the name of a builtin detector, one of email, phone,
+	// national_id, jwt and private_key, or the name of a custom detector.
+	Name string `mapstructure:"name"`
+
+	// Pattern is the regular expression of a custom detector.
+	Pattern string `mapstructure:"pattern"`
+
+	// Confidence is the confidence of the matches of a custom detector,
+	// 1 when unset.
+	Confidence float64 `mapstructure:"confidence"`
+
+	// MinConfidence overrides the processor MinConfidence for this detector.
+	MinConfidence float64 `mapstructure:"min_confidence"`
+
+	// Action is what is done with the PII found by the detector: mask, hash,
+	// drop or annotate.
+	Action Action `mapstructure:"action"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MinConfidence < 0 || cfg.MinConfidence > 1 {
+		return errors.New("min_confidence must be between 0 and 1")
+	}
+	if len(cfg.Detectors) == 0 {
+		return errors.New("detectors must not be empty")
+	}
+	names := make(map[string]struct{}, len(cfg.Detectors))
+	for i, d := range cfg.Detectors {
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("detectors[%d]: %w", i, err)
+		}
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("detectors[%d]: duplicate detector %q", i, d.Name)
+		}
+		names[d.Name] = struct{}{}
+	}
+	return nil
+}
+
+func (d *DetectorConfig) validate() error {
+	if d.Name == "" {
+		return errors.New("name must be set")
+	}
+	if d.Pattern == "" {
+		if _, ok := newBuiltinDetector(d.Name); !ok {
+			return fmt.Errorf("unknown detector %q, pattern must be set for custom detectors", d.Name)
+		}
+		if d.Confidence != 0 {
+			return errors.New("confidence can only be set for custom detectors")
+		}
+	} else if _, err := regexp.Compile(d.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if d.Confidence < 0 || d.Confidence > 1 {
+		return errors.New("confidence must be between 0 and 1")
+	}
+	if d.MinConfidence < 0 || d.MinConfidence > 1 {
+		return errors.New("min_confidence must be between 0 and 1")
+	}
+	switch d.Action {
+	case ActionMask, ActionHash, ActionDrop, ActionAnnotate:
+	default:
+		return fmt.Errorf("invalid action %q, must be one of mask, hash, drop and annotate", d.Action)
+	}
+	return nil
+}
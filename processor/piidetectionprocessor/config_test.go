@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				MinConfidence: 0.7,
+				HashSalt:      "s4lt",
+				IgnoredKeys:   []string{"user.id"},
+				Detectors: []DetectorConfig{
+					{Name: "private_key", Action: ActionDrop},
+					{Name: "email", Action: ActionHash},
+					{Name: "phone", Action: ActionAnnotate, MinConfidence: 0.9},
+					{Name: "employee_id", Pattern: `EMP-\d{6}`, Confidence: 0.8, Action: ActionMask},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		detectors []DetectorConfig
+		minConf   float64
+		expected  string
+	}{
+		{
+			name:      "min confidence out of range",
+			detectors: []DetectorConfig{{Name: "email", Action: ActionMask}},
+			minConf:   1.5,
+			expected:  "min_confidence must be between 0 and 1",
+		},
+		{
+			name:     "no detectors",
+			minConf:  0.5,
+			expected: "detectors must not be empty",
+		},
+		{
+			name:      "unknown detector",
+			detectors: []DetectorConfig{{Name: "passport", Action: ActionMask}},
+			expected:  `detectors[0]: unknown detector "passport", pattern must be set for custom detectors`,
+		},
+		{
+			name:      "confidence of builtin detector",
+			detectors: []DetectorConfig{{Name: "email", Confidence: 0.5, Action: ActionMask}},
+			expected:  "detectors[0]: confidence can only be set for custom detectors",
+		},
+		{
+			name:      "invalid pattern",
+			detectors: []DetectorConfig{{Name: "custom", Pattern: "(", Action: ActionMask}},
+			expected:  "detectors[0]: invalid pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:      "invalid action",
+			detectors: []DetectorConfig{{Name: "email", Action: "redact"}},
+			expected:  `detectors[0]: invalid action "redact", must be one of mask, hash, drop and annotate`,
+		},
+		{
+			name:      "missing name",
+			detectors: []DetectorConfig{{Pattern: "x", Action: ActionMask}},
+			expected:  "detectors[0]: name must be set",
+		},
+		{
+			name: "duplicate detector",
+			detectors: []DetectorConfig{
+				{Name: "email", Action: ActionMask},
+				{Name: "email", Action: ActionHash},
+			},
+			expected: `detectors[1]: duplicate detector "email"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Detectors: tt.detectors, MinConfidence: tt.minConf}
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Names of the builtin detectors.
+const (
+	detectorEmail      = "email"
+	detectorPhone      = "phone"
+	detectorNationalID = "national_id"
+	detectorJWT        = "jwt"
+	detectorPrivateKey = "private_key"
+)
+
+// match is a part of a value suspected to be PII.
+type match struct {
+	start, end int
+	confidence float64
+}
+
+// detector finds the parts of a value suspected to be PII. The key is the
+// attribute key the value is stored under, empty for log bodies, and may raise
+// the confidence of the matches.
+type detector interface {
+	find(key, value string) []match
+}
+
+// builtinDetectors holds the detectors available by name, in the order they
+// are applied by default: the most specific ones first.
+var builtinDetectors = []struct {
+	name string
+	new  func() detector
+}{
+	{name: detectorPrivateKey, new: newPrivateKeyDetector},
+	{name: detectorJWT, new: newJWTDetector},
+	{name: detectorEmail, new: newEmailDetector},
+	{name: detectorNationalID, new: newNationalIDDetector},
+	{name: detectorPhone, new: newPhoneDetector},
+}
+
+func newBuiltinDetector(name string) (detector, bool) {
+	for _, d := range builtinDetectors {
+		if d.name == name {
+			return d.new(), true
+		}
+	}
+	return nil, false
+}
+
+// regexpDetector reports the matches of a pattern, scored by a function that
+// returns a confidence of 0 for the matches that turn out not to be PII.
+type regexpDetector struct {
+	pattern *regexp.Regexp
+	score   func(value string) float64
+	// keyHints are the substrings of the attribute keys that raise the
+	// confidence of the matches by keyBoost.
+	keyHints []string
+	keyBoost float64
+	// delimited requires the matches not to be part of a longer word or number.
+	delimited bool
+}
+
+func (d *regexpDetector) find(key, value string) []match {
+	var matches []match
+	boost := 0.0
+	if hasKeyHint(key, d.keyHints) {
+		boost = d.keyBoost
+	}
+	for _, loc := range d.pattern.FindAllStringIndex(value, -1) {
+		if d.delimited && !isDelimited(value, loc[0], loc[1]) {
+			continue
+		}
+		confidence := d.score(value[loc[0]:loc[1]])
+		if confidence <= 0 {
+			continue
+		}
+		if confidence += boost; confidence > 1 {
+			confidence = 1
+		}
+		matches = append(matches, match{start: loc[0], end: loc[1], confidence: confidence})
+	}
+	return matches
+}
+
+func hasKeyHint(key string, hints []string) bool {
+	key = strings.ToLower(key)
+	for _, hint := range hints {
+		if strings.Contains(key, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDelimited reports whether the match is not part of a longer word or
+// number, since RE2 doesn't support the lookarounds needed to check it.
+func isDelimited(value string, start, end int) bool {
+	isWord := func(r byte) bool {
+		return r < unicode.MaxASCII && (unicode.IsLetter(rune(r)) || unicode.IsDigit(rune(r)))
+	}
+	return (start == 0 || !isWord(value[start-1])) && (end == len(value) || !isWord(value[end]))
+}
+
+func newEmailDetector() detector {
+	return &regexpDetector{
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+		score: func(string) float64 {
+			return 0.8
+		},
+		keyHints:  []string{"email", "mail"},
+		keyBoost:  0.2,
+		delimited: true,
+	}
+}
+
+// notPhone matches the dates and the IPv4 addresses, which are formatted
+// like phone numbers.
+var notPhone = regexp.MustCompile(`^(?:\d{4}-\d{2}-\d{2}|\d{1,3}(?:\.\d{1,3}){3})`)
+
+// newPhoneDetector detects the phone numbers made of 10 to 15 digits. Since
+// many numbers have as many digits, plain numbers score low, unless they are
+// formatted the way phone numbers usually are.
+func newPhoneDetector() detector {
+	return &regexpDetector{
+		pattern: regexp.MustCompile(`\+?\(?\d[\d ().-]{8,}\d`),
+		score: func(value string) float64 {
+			if notPhone.MatchString(value) {
+				return 0
+			}
+			digits := 0
+			for _, r := range value {
+				if unicode.IsDigit(r) {
+					digits++
+				}
+			}
+			switch {
+			case digits < 10 || digits > 15:
+				return 0
+			case strings.HasPrefix(value, "+"):
+				return 0.7
+			case strings.ContainsAny(value, " ().-"):
+				return 0.6
+			default:
+				return 0.3
+			}
+		},
+		keyHints:  []string{"phone", "mobile", "tel", "fax"},
+		keyBoost:  0.3,
+		delimited: true,
+	}
+}
+
+// newNationalIDDetector detects the US social security numbers, whose area,
+// group and serial numbers are checked against the ranges never assigned.
+func newNationalIDDetector() detector {
+	return &regexpDetector{
+		pattern: regexp.MustCompile(`\d{3}-\d{2}-\d{4}|\d{9}`),
+		score: func(value string) float64 {
+			digits := strings.ReplaceAll(value, "-", "")
+			area, group, serial := digits[:3], digits[3:5], digits[5:]
+			if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+				return 0
+			}
+			if len(digits) == len(value) {
+				return 0.3
+			}
+			return 0.8
+		},
+		keyHints:  []string{"ssn", "national_id", "social_security", "nationalid"},
+		keyBoost:  0.4,
+		delimited: true,
+	}
+}
+
+// newJWTDetector detects the JSON web tokens, which are trusted once their
+// header is decoded and holds a signing algorithm.
+func newJWTDetector() detector {
+	return &regexpDetector{
+		pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		score: func(value string) float64 {
+			header, err := base64.RawURLEncoding.DecodeString(value[:strings.IndexByte(value, '.')])
+			if err != nil {
+				return 0.6
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(header, &fields); err != nil {
+				return 0.6
+			}
+			if _, ok := fields["alg"]; !ok {
+				return 0.6
+			}
+			return 0.95
+		},
+		keyHints:  []string{"token", "authorization", "jwt"},
+		keyBoost:  0.05,
+		delimited: true,
+	}
+}
+
+// newPrivateKeyDetector detects the PEM encoded private keys, including the
+// ones truncated before their footer.
+func newPrivateKeyDetector() detector {
+	return &regexpDetector{
+		pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----(?:[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----|[\s\S]*)`),
+		score: func(value string) float64 {
+			if strings.HasSuffix(value, "PRIVATE KEY-----") {
+				return 1
+			}
+			return 0.9
+		},
+		delimited: true,
+	}
+}
+
+// newPatternDetector creates a detector for a custom pattern, whose matches
+// all have the same confidence.
+func newPatternDetector(pattern *regexp.Regexp, confidence float64) detector {
+	return &regexpDetector{
+		pattern: pattern,
+		score: func(string) float64 {
+			return confidence
+		},
+	}
+}
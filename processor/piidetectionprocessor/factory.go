@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package piidetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor"
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor/internal/metadata"
+)
+
+const defaultMinConfidence = 0.5
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+var onceMetrics sync.Once
+
+// NewFactory creates a factory for the PII detection processor.
+func NewFactory() processor.Factory {
+	onceMetrics.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(metricViews()...)
+	})
+
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
+		processor.WithLogs(createLogsProcessor, metadata.LogsStability),
+	)
+}
+
+// createDefaultConfig masks the PII found by all the builtin detectors.
+func createDefaultConfig() component.Config {
+	detectors := make([]DetectorConfig, 0, len(builtinDetectors))
+	for _, d := range builtinDetectors {
+		detectors = append(detectors, DetectorConfig{Name: d.name, Action: ActionMask})
+	}
+	return &Config{
+		Detectors:     detectors,
+		MinConfidence: defaultMinConfidence,
+	}
+}
+
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Traces,
+) (processor.Traces, error) {
+	p, err := newPIIDetection(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTracesProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		p.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	p, err := newPIIDetection(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		p.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor/internal/metadata"
+)
+
+var (
+	processorTagKey = tag.MustNewKey("processor")
+	detectorTagKey  = tag.MustNewKey("detector")
+	actionTagKey    = tag.MustNewKey("action")
+
+	mDetected       = stats.Int64("detected", "Number of values suspected to be PII found by each detector", stats.UnitDimensionless)
+	mRecordsDropped = stats.Int64("records_dropped", "Number of spans and log records dropped because they held PII", stats.UnitDimensionless)
+)
+
+// metricViews returns the metrics views of the processor.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mDetected.Name()),
+			Measure:     mDetected,
+			Description: mDetected.Description(),
+			TagKeys:     []tag.Key{processorTagKey, detectorTagKey, actionTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mRecordsDropped.Name()),
+			Measure:     mRecordsDropped,
+			Description: mRecordsDropped.Description(),
+			TagKeys:     []tag.Key{processorTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// detectorsKey and keysKey are the attributes the annotate action adds to
+	// the spans and the log records, holding the names of the detectors that
+	// found PII and the keys it was found under.
+	detectorsKey = "piidetection.detectors"
+	keysKey      = "piidetection.keys"
+
+	// bodyKey stands for the log body in the keysKey attribute.
+	bodyKey = "body"
+
+	attrValuesSeparator = ","
+	maskedValue         = "****"
+)
+
+type configuredDetector struct {
+	name          string
+	detector      detector
+	minConfidence float64
+	action        Action
+	// tags is the context the detections are recorded with.
+	tags context.Context
+}
+
+type piiDetection struct {
+	detectors []configuredDetector
+	ignored   map[string]struct{}
+	hashSalt  string
+	// tags is the context the dropped records are recorded with.
+	tags context.Context
+}
+
+func newPIIDetection(set processor.CreateSettings, cfg *Config) (*piiDetection, error) {
+	tags, err := tag.New(context.Background(), tag.Upsert(processorTagKey, set.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	p := &piiDetection{
+		ignored:  map[string]struct{}{detectorsKey: {}, keysKey: {}},
+		hashSalt: string(cfg.HashSalt),
+		tags:     tags,
+	}
+	for _, key := range cfg.IgnoredKeys {
+		p.ignored[key] = struct{}{}
+	}
+	for _, dc := range cfg.Detectors {
+		d := configuredDetector{
+			name:          dc.Name,
+			minConfidence: cfg.MinConfidence,
+			action:        dc.Action,
+		}
+		if dc.MinConfidence > 0 {
+			d.minConfidence = dc.MinConfidence
+		}
+		if dc.Pattern != "" {
+			pattern, err := regexp.Compile(dc.Pattern)
+			if err != nil {
+				return nil, err
+			}
+			confidence := dc.Confidence
+			if confidence == 0 {
+				confidence = 1
+			}
+			d.detector = newPatternDetector(pattern, confidence)
+		} else {
+			d.detector, _ = newBuiltinDetector(dc.Name)
+		}
+		d.tags, err = tag.New(tags,
+			tag.Upsert(detectorTagKey, dc.Name),
+			tag.Upsert(actionTagKey, string(dc.Action)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.detectors = append(p.detectors, d)
+	}
+	return p, nil
+}
+
+func (p *piiDetection) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		var rf findings
+		p.scanAttributes(rs.Resource().Attributes(), &rf)
+		if p.handle(rs.Resource().Attributes(), &rf, int64(resourceSpanCount(rs))) {
+			return true
+		}
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				var f findings
+				p.scanAttributes(span.Attributes(), &f)
+				for i := 0; i < span.Events().Len() && !f.drop; i++ {
+					p.scanAttributes(span.Events().At(i).Attributes(), &f)
+				}
+				return p.handle(span.Attributes(), &f, 1)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (p *piiDetection) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		var rf findings
+		p.scanAttributes(rl.Resource().Attributes(), &rf)
+		if p.handle(rl.Resource().Attributes(), &rf, int64(resourceLogCount(rl))) {
+			return true
+		}
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				var f findings
+				p.scanAttributes(lr.Attributes(), &f)
+				if !f.drop {
+					p.scanValue("", lr.Body(), &f)
+				}
+				return p.handle(lr.Attributes(), &f, 1)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// findings are what the detectors found in a span or a log record.
+type findings struct {
+	drop bool
+	// detectors and keys are the names of the detectors with the annotate
+	// action that found PII, and the keys it was found under.
+	detectors map[string]struct{}
+	keys      map[string]struct{}
+}
+
+func (f *findings) annotate(detector, key string) {
+	if f.detectors == nil {
+		f.detectors = map[string]struct{}{}
+		f.keys = map[string]struct{}{}
+	}
+	if key == "" {
+		key = bodyKey
+	}
+	f.detectors[detector] = struct{}{}
+	f.keys[key] = struct{}{}
+}
+
+// handle annotates the record or the resource with the findings, and reports
+// whether it must be dropped, along with the given number of records.
+func (p *piiDetection) handle(attributes pcommon.Map, f *findings, records int64) bool {
+	if f.drop {
+		stats.Record(p.tags, mRecordsDropped.M(records))
+		return true
+	}
+	if len(f.detectors) > 0 {
+		addMetaAttr(attributes, detectorsKey, f.detectors)
+		addMetaAttr(attributes, keysKey, f.keys)
+	}
+	return false
+}
+
+func resourceSpanCount(rs ptrace.ResourceSpans) int {
+	count := 0
+	for i := 0; i < rs.ScopeSpans().Len(); i++ {
+		count += rs.ScopeSpans().At(i).Spans().Len()
+	}
+	return count
+}
+
+func resourceLogCount(rl plog.ResourceLogs) int {
+	count := 0
+	for i := 0; i < rl.ScopeLogs().Len(); i++ {
+		count += rl.ScopeLogs().At(i).LogRecords().Len()
+	}
+	return count
+}
+
+// addMetaAttr merges the values with the ones already held by the attribute,
+// which can be set by another instance of the processor.
+func addMetaAttr(attributes pcommon.Map, key string, values map[string]struct{}) {
+	if existing, ok := attributes.Get(key); ok && existing.Str() != "" {
+		for _, v := range strings.Split(existing.Str(), attrValuesSeparator) {
+			values[v] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(values))
+	for v := range values {
+		sorted = append(sorted, v)
+	}
+	sort.Strings(sorted)
+	attributes.PutStr(key, strings.Join(sorted, attrValuesSeparator))
+}
+
+func (p *piiDetection) scanAttributes(attributes pcommon.Map, f *findings) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := p.ignored[k]; ok {
+			return true
+		}
+		p.scanValue(k, v, f)
+		return !f.drop
+	})
+}
+
+// scanValue scans the strings of the value, including the strings nested in
+// maps and slices, which are scanned with their own keys.
+func (p *piiDetection) scanValue(key string, value pcommon.Value, f *findings) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		if scanned, changed := p.scanString(key, value.Str(), f); changed {
+			value.SetStr(scanned)
+		}
+	case pcommon.ValueTypeMap:
+		value.Map().Range(func(k string, v pcommon.Value) bool {
+			p.scanValue(k, v, f)
+			return !f.drop
+		})
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < value.Slice().Len() && !f.drop; i++ {
+			p.scanValue(key, value.Slice().At(i), f)
+		}
+	}
+}
+
+// scanString applies the detectors in order to the string, and returns it
+// with the PII masked or hashed, and whether it was changed.
+func (p *piiDetection) scanString(key, value string, f *findings) (string, bool) {
+	var changed bool
+	for _, d := range p.detectors {
+		var matches []match
+		for _, m := range d.detector.find(key, value) {
+			if m.confidence >= d.minConfidence {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		stats.Record(d.tags, mDetected.M(int64(len(matches))))
+
+		switch d.action {
+		case ActionDrop:
+			f.drop = true
+			return value, changed
+		case ActionAnnotate:
+			f.annotate(d.name, key)
+		case ActionMask, ActionHash:
+			value = p.replace(value, matches, d.action)
+			changed = true
+		}
+	}
+	return value, changed
+}
+
+// replace masks or hashes the matches, which must be sorted and not overlap.
+func (p *piiDetection) replace(value string, matches []match, action Action) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(value[last:m.start])
+		if action == ActionHash {
+			b.WriteString(p.hash(value[m.start:m.end]))
+		} else {
+			b.WriteString(maskedValue)
+		}
+		last = m.end
+	}
+	b.WriteString(value[last:])
+	return b.String()
+}
+
+// hash returns the hexadecimal SHA-256 hash of the salted value
+func (p *piiDetection) hash(value string) string {
+	sum := sha256.Sum256([]byte(p.hashSalt + value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package piidetectionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/piidetectionprocessor/internal/metadata"
+)
+
+func newTestPIIDetection(t *testing.T, id component.ID, cfg *Config) *piiDetection {
+	require.NoError(t, cfg.Validate())
+	set := processortest.NewNopCreateSettings()
+	set.ID = id
+	p, err := newPIIDetection(set, cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestPIIDetection(t, component.NewID(metadata.Type), &Config{
+		MinConfidence: 0.5,
+		HashSalt:      "s4lt",
+		IgnoredKeys:   []string{"user.id"},
+		Detectors: []DetectorConfig{
+			{Name: detectorJWT, Action: ActionAnnotate},
+			{Name: detectorEmail, Action: ActionHash},
+			{Name: detectorPhone, Action: ActionMask, MinConfidence: 0.65},
+			{Name: "employee_id", Pattern: `EMP-\d{6}`, Action: ActionMask},
+		},
+	})
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, lr.Attributes().FromRaw(map[string]any{
+		"user.id":       "jane@example.com",
+		"contact":       "jane@example.com, +1 415 555 0132 or 415 555 0133",
+		"employees":     []any{"EMP-123456", "EMP-654321"},
+		"authorization": "Bearer " + testJWT,
+		"attempt":       int64(2),
+	}))
+	require.NoError(t, lr.Body().SetEmptyMap().FromRaw(map[string]any{
+		"message": "login of EMP-123456",
+		"session": map[string]any{"token": testJWT},
+	}))
+
+	out, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	lr = out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{
+		"user.id":                "jane@example.com",
+		"contact":                p.hash("jane@example.com") + ", **** or 415 555 0133",
+		"employees":              []any{"****", "****"},
+		"authorization":          "Bearer " + testJWT,
+		"attempt":                int64(2),
+		"piidetection.detectors": "jwt",
+		"piidetection.keys":      "authorization,token",
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"message": "login of ****",
+		"session": map[string]any{"token": testJWT},
+	}, lr.Body().AsRaw())
+}
+
+func TestProcessLogsDrop(t *testing.T) {
+	id := component.NewIDWithName(metadata.Type, "drop")
+	require.NoError(t, view.Register(metricViews()...))
+	defer view.Unregister(metricViews()...)
+
+	p := newTestPIIDetection(t, id, &Config{
+		MinConfidence: 0.5,
+		Detectors: []DetectorConfig{
+			{Name: detectorPrivateKey, Action: ActionDrop},
+			{Name: detectorEmail, Action: ActionAnnotate},
+		},
+	})
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("loaded " + testPrivateKey)
+	records.AppendEmpty().Body().SetStr("sent to ops@example.com")
+	dropped := ld.ResourceLogs().AppendEmpty()
+	dropped.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("key", testPrivateKey)
+	dropped = ld.ResourceLogs().AppendEmpty()
+	dropped.Resource().Attributes().PutStr("deployment.key", testPrivateKey)
+	dropped.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("started")
+
+	out, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.LogRecordCount(), "Must drop the records holding a private key")
+	require.Equal(t, 1, out.ResourceLogs().Len(), "Must remove the resources left empty")
+	lr := out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "sent to ops@example.com", lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		"piidetection.detectors": "email",
+		"piidetection.keys":      "body",
+	}, lr.Attributes().AsRaw())
+
+	ld = plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(testPrivateKey)
+	_, err = p.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "Must skip batches left empty")
+
+	assert.Equal(t, map[string]int64{"email/annotate": 1, "private_key/drop": 4}, detections(t, id))
+}
+
+func TestProcessTraces(t *testing.T) {
+	p := newTestPIIDetection(t, component.NewID(metadata.Type), &Config{
+		MinConfidence: 0.5,
+		Detectors: []DetectorConfig{
+			{Name: detectorNationalID, Action: ActionDrop},
+			{Name: detectorEmail, Action: ActionMask},
+			{Name: detectorPhone, Action: ActionAnnotate},
+		},
+	})
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("owner.email", "ops@example.com")
+	rs.Resource().Attributes().PutStr("owner.phone", "4155550135")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	span := spans.AppendEmpty()
+	span.SetName("checkout")
+	span.Attributes().PutStr("user.email", "jane@example.com")
+	span.Events().AppendEmpty().Attributes().PutStr("customer.phone", "4155550134")
+	span = spans.AppendEmpty()
+	span.SetName("verify")
+	span.Events().AppendEmpty().Attributes().PutStr("message", "checking 123-45-6789")
+
+	out, err := p.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.SpanCount(), "Must drop the spans whose events hold a national ID")
+
+	span = out.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "checkout", span.Name())
+	assert.Equal(t, map[string]any{
+		"user.email":             "****",
+		"piidetection.detectors": "phone",
+		"piidetection.keys":      "customer.phone",
+	}, span.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"customer.phone": "4155550134"}, span.Events().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"owner.email":            "****",
+		"owner.phone":            "4155550135",
+		"piidetection.detectors": "phone",
+		"piidetection.keys":      "owner.phone",
+	}, out.ResourceSpans().At(0).Resource().Attributes().AsRaw(), "Must scan the resource attributes")
+
+	// PII found in the resource attributes drops all its spans
+	td = ptrace.NewTraces()
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("owner.ssn", "123-45-6789")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	_, err = p.processTraces(context.Background(), td)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestAddMetaAttr(t *testing.T) {
+	p := newTestPIIDetection(t, component.NewID(metadata.Type), &Config{
+		MinConfidence: 0.5,
+		Detectors:     []DetectorConfig{{Name: detectorEmail, Action: ActionAnnotate}},
+	})
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("to", "ops@example.com")
+	lr.Attributes().PutStr(detectorsKey, "phone")
+	lr.Attributes().PutStr(keysKey, "customer.phone")
+
+	_, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"to":                     "ops@example.com",
+		"piidetection.detectors": "email,phone",
+		"piidetection.keys":      "customer.phone,to",
+	}, lr.Attributes().AsRaw(), "Must merge the annotations of another processor")
+}
+
+// detections returns the number of detections of each detector and action of the given processor.
+func detections(t *testing.T, id component.ID) map[string]int64 {
+	rows, err := view.RetrieveData(processorhelper.BuildCustomMetricName(metadata.Type, mDetected.Name()))
+	require.NoError(t, err)
+	counts := map[string]int64{}
+	for _, row := range rows {
+		tags := map[tag.Key]string{}
+		for _, t := range row.Tags {
+			tags[t.Key] = t.Value
+		}
+		if tags[processorTagKey] == id.String() {
+			counts[tags[detectorTagKey]+"/"+tags[actionTagKey]] = int64(row.Data.(*view.SumData).Value)
+		}
+	}
+	return counts
+}
@@ -23,6 +23,20 @@ var validAttributeSource = map[AttributeSource]bool{
 	recordAttributeSource:  true,
 }
 
+// SamplerMode selects how the sampling decision is derived from the trace ID.
+type SamplerMode string
+
+const (
+	// HashSeed hashes the trace ID with the configured seed.
+	HashSeed SamplerMode = "hash_seed"
+	// Consistent compares the randomness carried by the trace ID (its r-value) with
+	// the threshold matching the sampling percentage, so that every sampler using
+	// the same percentage reaches the same decision for spans and logs of a trace.
+	Consistent SamplerMode = "consistent"
+
+	defaultMode = HashSeed
+)
+
 // Config has the configuration guiding the sampler processor.
 type Config struct {
 
@@ -35,6 +49,10 @@ type Config struct {
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
 
+	// Mode selects how the sampling decision is derived from the trace ID. The allowed values are
+	// `hash_seed` and `consistent`. Default is `hash_seed`.
+	Mode SamplerMode `mapstructure:"mode"`
+
 	// AttributeSource (logs only) defines where to look for the attribute in from_attribute. The allowed values are
 	// `traceID` or `record`. Default is `traceID`.
 	AttributeSource `mapstructure:"attribute_source"`
@@ -55,6 +73,15 @@ func (cfg *Config) Validate() error {
 	if cfg.SamplingPercentage < 0 {
 		return fmt.Errorf("negative sampling rate: %.2f", cfg.SamplingPercentage)
 	}
+	switch cfg.Mode {
+	case "", HashSeed:
+	case Consistent:
+		if cfg.HashSeed != 0 {
+			return fmt.Errorf("hash_seed is not supported in %v mode", Consistent)
+		}
+	default:
+		return fmt.Errorf("invalid mode: %v. Expected: %v or %v", cfg.Mode, HashSeed, Consistent)
+	}
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
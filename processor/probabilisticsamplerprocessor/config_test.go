@@ -27,6 +27,7 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               HashSeed,
 				AttributeSource:    "traceID",
 			},
 		},
@@ -35,11 +36,20 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               HashSeed,
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "consistent"),
+			expected: &Config{
+				SamplingPercentage: 25,
+				Mode:               Consistent,
+				AttributeSource:    "traceID",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -72,3 +82,36 @@ func TestLoadInvalidConfig(t *testing.T) {
 	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
 	require.ErrorContains(t, err, "negative sampling rate: -15.30")
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "consistent",
+			cfg:  &Config{SamplingPercentage: 10, Mode: Consistent},
+		},
+		{
+			name: "consistent with hash seed",
+			cfg:  &Config{SamplingPercentage: 10, Mode: Consistent, HashSeed: 22},
+			err:  "hash_seed is not supported in consistent mode",
+		},
+		{
+			name: "invalid mode",
+			cfg:  &Config{SamplingPercentage: 10, Mode: "equalizing"},
+			err:  "invalid mode: equalizing. Expected: hash_seed or consistent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == "" {
+				assert.NoError(t, tt.cfg.Validate())
+				return
+			}
+			assert.EqualError(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// numRandomValues is the number of distinct r-values: W3C trace context level 2
+	// requires the 56 least significant bits of the trace ID to be random.
+	numRandomValues = uint64(1) << 56
+	randomnessMask  = numRandomValues - 1
+
+	// numHexDigits is the number of hexadecimal digits of the 56 bits r-values and thresholds.
+	numHexDigits = 14
+
+	// otelTraceStateKey is the key of the OpenTelemetry entry of the W3C tracestate, holding the
+	// sampling threshold (th) and the explicit randomness value (rv) of the trace.
+	otelTraceStateKey = "ot"
+	thresholdField    = "th"
+	randomnessField   = "rv"
+)
+
+// traceIDRandomness returns the r-value carried by the trace ID.
+func traceIDRandomness(id pcommon.TraceID) uint64 {
+	return binary.BigEndian.Uint64(id[8:]) & randomnessMask
+}
+
+// samplingThreshold returns the rejection threshold matching the sampling percentage:
+// items are sampled when their r-value is greater than or equal to the threshold.
+func samplingThreshold(percentage float64) uint64 {
+	switch {
+	case percentage >= 100:
+		return 0
+	case percentage <= 0:
+		return numRandomValues
+	}
+	return uint64((1 - percentage/100) * float64(numRandomValues))
+}
+
+// consistentSample returns whether the trace is sampled at the given threshold.
+func consistentSample(id pcommon.TraceID, threshold uint64) bool {
+	return traceIDRandomness(id) >= threshold
+}
+
+// otelTraceState holds the fields of the OpenTelemetry entry of the W3C tracestate.
+type otelTraceState struct {
+	threshold    uint64
+	hasThreshold bool
+	randomness   uint64
+	hasRandom    bool
+	// others are the fields of the entry not used by the sampler, kept as they are.
+	others []string
+	// members are the other entries of the tracestate, kept as they are.
+	members []string
+}
+
+// parseTraceState returns the OpenTelemetry entry of the tracestate, and the other entries.
+func parseTraceState(raw string) (otelTraceState, error) {
+	var ts otelTraceState
+	for _, member := range strings.Split(raw, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		value, ok := strings.CutPrefix(member, otelTraceStateKey+"=")
+		if !ok {
+			ts.members = append(ts.members, member)
+			continue
+		}
+		for _, field := range strings.Split(value, ";") {
+			key, fieldValue, _ := strings.Cut(field, ":")
+			var err error
+			switch key {
+			case thresholdField:
+				ts.threshold, err = parseThreshold(fieldValue)
+				ts.hasThreshold = true
+			case randomnessField:
+				ts.randomness, err = parseRandomness(fieldValue)
+				ts.hasRandom = true
+			default:
+				ts.others = append(ts.others, field)
+			}
+			if err != nil {
+				return otelTraceState{}, err
+			}
+		}
+	}
+	return ts, nil
+}
+
+// parseThreshold parses a threshold, whose trailing zeros are omitted.
+func parseThreshold(value string) (uint64, error) {
+	if value == "" || len(value) > numHexDigits {
+		return 0, fmt.Errorf("invalid tracestate threshold %q", value)
+	}
+	threshold, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tracestate threshold %q: %w", value, err)
+	}
+	return threshold << (4 * (numHexDigits - len(value))), nil
+}
+
+func parseRandomness(value string) (uint64, error) {
+	if len(value) != numHexDigits {
+		return 0, fmt.Errorf("invalid tracestate randomness value %q", value)
+	}
+	randomness, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tracestate randomness value %q: %w", value, err)
+	}
+	return randomness, nil
+}
+
+// String returns the tracestate, the OpenTelemetry entry being first as it was updated.
+func (ts otelTraceState) String() string {
+	var fields []string
+	if ts.hasThreshold {
+		threshold := strings.TrimRight(fmt.Sprintf("%014x", ts.threshold), "0")
+		if threshold == "" {
+			threshold = "0"
+		}
+		fields = append(fields, thresholdField+":"+threshold)
+	}
+	if ts.hasRandom {
+		fields = append(fields, fmt.Sprintf("%s:%014x", randomnessField, ts.randomness))
+	}
+	fields = append(fields, ts.others...)
+	members := append([]string{otelTraceStateKey + "=" + strings.Join(fields, ";")}, ts.members...)
+	return strings.Join(members, ",")
+}
+
+// consistentSampleTraceState returns whether the trace is sampled at the given threshold, using the
+// r-value of the tracestate if any, otherwise the one carried by the trace ID. When it is, the threshold
+// of the tracestate is set to the highest of the threshold it holds and the given threshold, so that
+// the next samplers and the backends know the effective sampling probability.
+func consistentSampleTraceState(id pcommon.TraceID, ts *otelTraceState, threshold uint64) bool {
+	randomness := traceIDRandomness(id)
+	if ts.hasRandom {
+		randomness = ts.randomness
+	}
+	if randomness < threshold {
+		return false
+	}
+	// A threshold higher than the r-value was not applied consistently, and is replaced.
+	if !ts.hasThreshold || ts.threshold < threshold || ts.threshold > randomness {
+		ts.threshold = threshold
+		ts.hasThreshold = true
+	}
+	return true
+}
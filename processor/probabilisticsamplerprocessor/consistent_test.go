@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestSamplingThreshold(t *testing.T) {
+	assert.Equal(t, uint64(0), samplingThreshold(100))
+	assert.Equal(t, uint64(0), samplingThreshold(150))
+	assert.Equal(t, numRandomValues, samplingThreshold(0))
+	assert.Equal(t, numRandomValues/2, samplingThreshold(50))
+	assert.Equal(t, numRandomValues/4*3, samplingThreshold(25))
+}
+
+func TestTraceIDRandomness(t *testing.T) {
+	id := pcommon.TraceID([16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 1, 2, 3, 4, 5, 6, 7})
+	assert.Equal(t, uint64(0x01020304050607), traceIDRandomness(id))
+	assert.True(t, consistentSample(id, 0x01020304050607))
+	assert.False(t, consistentSample(id, 0x01020304050608))
+}
+
+func TestParseTraceState(t *testing.T) {
+	ts, err := parseTraceState("vendor=value, ot=th:c;rv:0102030405060a;xx:yy")
+	require.NoError(t, err)
+	assert.Equal(t, numRandomValues/4*3, ts.threshold)
+	assert.Equal(t, uint64(0x0102030405060a), ts.randomness)
+	assert.Equal(t, "ot=th:c;rv:0102030405060a;xx:yy,vendor=value", ts.String())
+
+	ts, err = parseTraceState("")
+	require.NoError(t, err)
+	assert.False(t, ts.hasThreshold)
+	assert.False(t, ts.hasRandom)
+
+	for _, invalid := range []string{"ot=th:", "ot=th:123456789abcdef", "ot=th:zz", "ot=rv:0102", "ot=rv:0102030405060z"} {
+		_, err = parseTraceState(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestConsistentSampleTraceState(t *testing.T) {
+	tests := []struct {
+		name       string
+		traceState string
+		traceID    pcommon.TraceID
+		sampled    bool
+		expected   string
+	}{
+		{
+			name:     "trace ID randomness",
+			traceID:  pcommon.TraceID{9: 0x80},
+			sampled:  true,
+			expected: "ot=th:8",
+		},
+		{
+			name:       "explicit randomness",
+			traceState: "ot=rv:7fffffffffffff",
+			traceID:    pcommon.TraceID{9: 0x80},
+			sampled:    false,
+		},
+		{
+			name:       "lower upstream threshold",
+			traceState: "ot=th:4;rv:c0000000000000,vendor=value",
+			sampled:    true,
+			expected:   "ot=th:8;rv:c0000000000000,vendor=value",
+		},
+		{
+			name:       "higher upstream threshold",
+			traceState: "ot=th:c;rv:c0000000000000",
+			sampled:    true,
+			expected:   "ot=th:c;rv:c0000000000000",
+		},
+		{
+			name:       "inconsistent upstream threshold",
+			traceState: "ot=th:f;rv:c0000000000000",
+			sampled:    true,
+			expected:   "ot=th:8;rv:c0000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(tt.traceID)
+			span.TraceState().FromRaw(tt.traceState)
+
+			sink := new(consumertest.TracesSink)
+			tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(),
+				&Config{SamplingPercentage: 50, Mode: Consistent}, sink)
+			require.NoError(t, err)
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), traces))
+
+			if !tt.sampled {
+				assert.Zero(t, sink.SpanCount())
+				return
+			}
+			require.Equal(t, 1, sink.SpanCount())
+			assert.Equal(t, tt.expected, sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw())
+		})
+	}
+}
+
+func TestConsistentTracesAndLogs(t *testing.T) {
+	const numTraces = 1000
+	cfg := &Config{
+		SamplingPercentage: 30,
+		Mode:               Consistent,
+		AttributeSource:    traceIDAttributeSource,
+	}
+
+	r := rand.New(rand.NewSource(42))
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < numTraces; i++ {
+		var id pcommon.TraceID
+		_, _ = r.Read(id[:])
+		spans.AppendEmpty().SetTraceID(id)
+		records.AppendEmpty().SetTraceID(id)
+	}
+
+	tracesSink := new(consumertest.TracesSink)
+	tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), traces))
+
+	logsSink := new(consumertest.LogsSink)
+	lsp, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), logsSink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, lsp.ConsumeLogs(context.Background(), logs))
+
+	sampledTraces := map[pcommon.TraceID]bool{}
+	for _, td := range tracesSink.AllTraces() {
+		sampled := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < sampled.Len(); i++ {
+			sampledTraces[sampled.At(i).TraceID()] = true
+		}
+	}
+	sampledLogs := map[pcommon.TraceID]bool{}
+	for _, ld := range logsSink.AllLogs() {
+		sampled := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < sampled.Len(); i++ {
+			sampledLogs[sampled.At(i).TraceID()] = true
+		}
+	}
+
+	assert.InDelta(t, numTraces*0.3, len(sampledTraces), numTraces*0.05)
+	assert.Equal(t, sampledTraces, sampledLogs)
+}
@@ -37,6 +37,7 @@ func NewFactory() processor.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		AttributeSource: defaultAttributeSource,
+		Mode:            defaultMode,
 	}
 }
 
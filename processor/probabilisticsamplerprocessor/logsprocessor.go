@@ -18,8 +18,10 @@ import (
 )
 
 type logSamplerProcessor struct {
+	samplingPercentage float64
 	scaledSamplingRate uint32
 	hashSeed           uint32
+	consistent         bool
 	traceIDEnabled     bool
 	samplingSource     string
 	samplingPriority   string
@@ -31,8 +33,10 @@ type logSamplerProcessor struct {
 func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextConsumer consumer.Logs, cfg *Config) (processor.Logs, error) {
 
 	lsp := &logSamplerProcessor{
+		samplingPercentage: float64(cfg.SamplingPercentage),
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		consistent:         cfg.Mode == Consistent,
 		traceIDEnabled:     cfg.AttributeSource == traceIDAttributeSource,
 		samplingPriority:   cfg.SamplingPriority,
 		samplingSource:     cfg.FromAttribute,
@@ -56,9 +60,16 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 				tagPolicyValue := "always_sampling"
 				// pick the sampling source.
 				var lidBytes []byte
+				// logs carrying a trace context follow the decision of the trace in consistent mode.
+				byRandomness := false
 				if lsp.traceIDEnabled && !l.TraceID().IsEmpty() {
 					value := l.TraceID()
-					tagPolicyValue = "trace_id_hash"
+					if lsp.consistent {
+						tagPolicyValue = "trace_id_randomness"
+						byRandomness = true
+					} else {
+						tagPolicyValue = "trace_id_hash"
+					}
 					lidBytes = value[:]
 				}
 				if lidBytes == nil && lsp.samplingSource != "" {
@@ -67,19 +78,27 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 						lidBytes = getBytesFromValue(value)
 					}
 				}
+				percentage := lsp.samplingPercentage
 				priority := lsp.scaledSamplingRate
 				if lsp.samplingPriority != "" {
 					if localPriority, ok := l.Attributes().Get(lsp.samplingPriority); ok {
 						switch localPriority.Type() {
 						case pcommon.ValueTypeDouble:
-							priority = uint32(localPriority.Double() * percentageScaleFactor)
+							percentage = localPriority.Double()
+							priority = uint32(percentage * percentageScaleFactor)
 						case pcommon.ValueTypeInt:
-							priority = uint32(float64(localPriority.Int()) * percentageScaleFactor)
+							percentage = float64(localPriority.Int())
+							priority = uint32(percentage * percentageScaleFactor)
 						}
 					}
 				}
 
-				sampled := computeHash(lidBytes, lsp.hashSeed)&bitMaskHashBuckets < priority
+				var sampled bool
+				if byRandomness {
+					sampled = consistentSample(l.TraceID(), samplingThreshold(percentage))
+				} else {
+					sampled = computeHash(lidBytes, lsp.hashSeed)&bitMaskHashBuckets < priority
+				}
 				var err error = stats.RecordWithTags(
 					ctx,
 					[]tag.Mutator{tag.Upsert(tagPolicyKey, tagPolicyValue), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
@@ -89,6 +89,16 @@ func TestLogsSampling(t *testing.T) {
 			},
 			received: 45,
 		},
+		{
+			name: "consistent",
+			cfg: &Config{
+				SamplingPercentage: 70,
+				Mode:               Consistent,
+				AttributeSource:    traceIDAttributeSource,
+			},
+			// the r-values of the generated trace IDs are below 40% of the range.
+			received: 23,
+		},
 		{
 			name: "sampling_source no sampling",
 			cfg: &Config{
@@ -44,6 +44,8 @@ const (
 type traceSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
+	consistent         bool
+	threshold          uint64
 	logger             *zap.Logger
 }
 
@@ -54,6 +56,8 @@ func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *
 		// Adjust sampling percentage on private so recalculations are avoided.
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		consistent:         cfg.Mode == Consistent,
+		threshold:          samplingThreshold(float64(cfg.SamplingPercentage)),
 		logger:             set.Logger,
 	}
 
@@ -66,6 +70,21 @@ func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *
 		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
 }
 
+// consistentSampleSpan returns whether the span is sampled, honoring the threshold and the r-value of
+// its W3C tracestate, whose threshold is updated when sampled. The tracestate is ignored when invalid.
+func (tsp *traceSamplerProcessor) consistentSampleSpan(s ptrace.Span) bool {
+	ts, err := parseTraceState(s.TraceState().AsRaw())
+	if err != nil {
+		tsp.logger.Debug("Ignoring the invalid tracestate of the span", zap.Error(err))
+		return consistentSample(s.TraceID(), tsp.threshold)
+	}
+	if !consistentSampleTraceState(s.TraceID(), &ts, tsp.threshold) {
+		return false
+	}
+	s.TraceState().FromRaw(ts.String())
+	return true
+}
+
 func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		rs.ScopeSpans().RemoveIf(func(ils ptrace.ScopeSpans) bool {
@@ -93,12 +112,19 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
 				// Hashing here prevents bias due to such systems.
 				tidBytes := s.TraceID()
-				sampled := sp == mustSampleSpan ||
-					computeHash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+				policy := "trace_id_hash"
+				var sampled bool
+				if tsp.consistent {
+					policy = "trace_id_randomness"
+					sampled = sp == mustSampleSpan || tsp.consistentSampleSpan(s)
+				} else {
+					sampled = sp == mustSampleSpan ||
+						computeHash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+				}
 
 				_ = stats.RecordWithTags(
 					ctx,
-					[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+					[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
 					statCountTracesSampled.M(int64(1)),
 				)
 				return !sampled
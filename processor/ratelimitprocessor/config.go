@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Action is what the processor does with the data exceeding the limits of its key.
+type Action string
+
+const (
+	// ActionDrop drops the data exceeding the limits.
+	ActionDrop Action = "drop"
+	// ActionDelay holds the data until the limits allow it, up to MaxDelay,
+	// and refuses it with a retryable error when it would take longer.
+	ActionDelay Action = "delay"
+	// ActionError refuses the whole batch with a retryable error, so that the
+	// receivers ask their clients to send it again later.
+	ActionError Action = "error"
+)
+
+// Config defines the configuration for the rate limit processor.
+type Config struct {
+	// ResourceAttributes are the resource attributes whose values make the
+	// key the limits are enforced for, such as service.name.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// MetadataKeys are the client metadata keys, such as the headers of the
+	// requests when the receiver includes them, whose values make the key the
+	// limits are enforced for.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// Limits are the limits enforced for every key.
+	Limits `mapstructure:",squash"`
+
+	// Overrides replace the limits of the given keys, their values joined by
+	// commas, metadata keys first, when several values make the key.
+	Overrides map[string]Limits `mapstructure:"overrides"`
+
+	// Action is what is done with the data exceeding the limits of its key:
+	// drop, delay or error.
+	Action Action `mapstructure:"action"`
+
+	// MaxDelay is the longest the data is held by the delay action.
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+}
+
+// Limits are the rates enforced for a key, 0 leaving the rate unlimited.
+type Limits struct {
+	// RecordsPerSecond is the number of spans, data points or log records
+	// accepted per second.
+	RecordsPerSecond float64 `mapstructure:"records_per_second"`
+
+	// RecordsBurst is the number of records accepted at once, which defaults
+	// to RecordsPerSecond. Larger batches are never admitted.
+	RecordsBurst int `mapstructure:"records_burst"`
+
+	// BytesPerSecond is the size of the data, in bytes of its protobuf
+	// encoding, accepted per second.
+	BytesPerSecond float64 `mapstructure:"bytes_per_second"`
+
+	// BytesBurst is the size accepted at once, which defaults to
+	// BytesPerSecond. Larger batches are never admitted.
+	BytesBurst int `mapstructure:"bytes_burst"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if err := cfg.Limits.validate(); err != nil {
+		return err
+	}
+	if cfg.RecordsPerSecond == 0 && cfg.BytesPerSecond == 0 {
+		return errors.New("records_per_second or bytes_per_second must be set")
+	}
+	for key, limits := range cfg.Overrides {
+		if err := limits.validate(); err != nil {
+			return fmt.Errorf("overrides[%s]: %w", key, err)
+		}
+	}
+	switch cfg.Action {
+	case ActionDrop, ActionError:
+	case ActionDelay:
+		if cfg.MaxDelay <= 0 {
+			return errors.New("max_delay must be greater than zero with the delay action")
+		}
+	default:
+		return fmt.Errorf("invalid action %q, must be one of drop, delay and error", cfg.Action)
+	}
+	return nil
+}
+
+func (l *Limits) validate() error {
+	if l.RecordsPerSecond < 0 || l.BytesPerSecond < 0 {
+		return errors.New("records_per_second and bytes_per_second must not be negative")
+	}
+	if l.RecordsBurst < 0 || l.BytesBurst < 0 {
+		return errors.New("records_burst and bytes_burst must not be negative")
+	}
+	return nil
+}
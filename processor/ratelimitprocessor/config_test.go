@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Limits:   Limits{RecordsPerSecond: 1000},
+				Action:   ActionError,
+				MaxDelay: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tenants"),
+			expected: &Config{
+				MetadataKeys:       []string{"x-tenant"},
+				ResourceAttributes: []string{"service.name"},
+				Limits: Limits{
+					RecordsPerSecond: 500,
+					RecordsBurst:     2000,
+					BytesPerSecond:   1048576,
+				},
+				Overrides: map[string]Limits{
+					"acme,checkout": {RecordsPerSecond: 5000, BytesPerSecond: 10485760},
+				},
+				Action:   ActionDelay,
+				MaxDelay: 500 * time.Millisecond,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "no limit",
+			cfg:      &Config{Action: ActionDrop},
+			expected: "records_per_second or bytes_per_second must be set",
+		},
+		{
+			name:     "negative rate",
+			cfg:      &Config{Limits: Limits{RecordsPerSecond: -1}, Action: ActionDrop},
+			expected: "records_per_second and bytes_per_second must not be negative",
+		},
+		{
+			name:     "negative burst",
+			cfg:      &Config{Limits: Limits{RecordsPerSecond: 1, BytesBurst: -1}, Action: ActionDrop},
+			expected: "records_burst and bytes_burst must not be negative",
+		},
+		{
+			name: "invalid override",
+			cfg: &Config{
+				Limits:    Limits{RecordsPerSecond: 1},
+				Overrides: map[string]Limits{"acme": {BytesPerSecond: -1}},
+				Action:    ActionDrop,
+			},
+			expected: "overrides[acme]: records_per_second and bytes_per_second must not be negative",
+		},
+		{
+			name:     "invalid action",
+			cfg:      &Config{Limits: Limits{RecordsPerSecond: 1}, Action: "block"},
+			expected: `invalid action "block", must be one of drop, delay and error`,
+		},
+		{
+			name:     "delay without max delay",
+			cfg:      &Config{Limits: Limits{RecordsPerSecond: 1}, Action: ActionDelay},
+			expected: "max_delay must be greater than zero with the delay action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.expected)
+		})
+	}
+}
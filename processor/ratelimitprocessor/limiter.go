@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// idleTimeout is how long the buckets of the keys no data was received
+	// for are kept, so that the keys of terminated tenants don't pile up.
+	idleTimeout = 5 * time.Minute
+	// sweepInterval is how often the idle buckets are looked for.
+	sweepInterval = time.Minute
+)
+
+// bucket holds the token buckets of a key.
+type bucket struct {
+	records  *rate.Limiter
+	bytes    *rate.Limiter
+	lastSeen time.Time
+}
+
+// reservation is the admission of a batch by the bucket of its key.
+type reservation struct {
+	records *rate.Reservation
+	bytes   *rate.Reservation
+	// ok is false when the batch is larger than the bursts of the bucket,
+	// and can never be admitted.
+	ok bool
+	// delay is how long to wait before the batch is within the limits.
+	delay time.Duration
+}
+
+// cancel gives the tokens of the reservation back to its bucket, as far as
+// they weren't used by later reservations.
+func (r *reservation) cancel(now time.Time) {
+	r.records.CancelAt(now)
+	r.bytes.CancelAt(now)
+}
+
+// buckets are the token buckets of the keys, created as data is received.
+type buckets struct {
+	limits    Limits
+	overrides map[string]Limits
+
+	mu        sync.Mutex
+	byKey     map[string]*bucket
+	lastSweep time.Time
+}
+
+func newBuckets(cfg *Config) *buckets {
+	return &buckets{
+		limits:    cfg.Limits,
+		overrides: cfg.Overrides,
+		byKey:     map[string]*bucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+// reserve takes the tokens for the records and the bytes of a batch from the
+// bucket of the key.
+func (b *buckets) reserve(key string, records, bytes int, now time.Time) *reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, ok := b.byKey[key]
+	if !ok {
+		limits, ok := b.overrides[key]
+		if !ok {
+			limits = b.limits
+		}
+		bk = &bucket{
+			records: newLimiter(limits.RecordsPerSecond, limits.RecordsBurst),
+			bytes:   newLimiter(limits.BytesPerSecond, limits.BytesBurst),
+		}
+		b.byKey[key] = bk
+	}
+	bk.lastSeen = now
+
+	r := &reservation{
+		records: bk.records.ReserveN(now, records),
+		bytes:   bk.bytes.ReserveN(now, bytes),
+	}
+	r.ok = r.records.OK() && r.bytes.OK()
+	if r.ok {
+		r.delay = r.records.DelayFrom(now)
+		if d := r.bytes.DelayFrom(now); d > r.delay {
+			r.delay = d
+		}
+	}
+	return r
+}
+
+// sweep removes the buckets of the keys no data was received for since the
+// idle timeout, at most once per sweep interval.
+func (b *buckets) sweep(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.lastSweep) < sweepInterval {
+		return
+	}
+	b.lastSweep = now
+	for key, bk := range b.byKey {
+		if now.Sub(bk.lastSeen) >= idleTimeout {
+			delete(b.byKey, key)
+		}
+	}
+}
+
+// newLimiter returns a token bucket refilled at the rate per second, or one
+// that is never exhausted when the rate is 0. The burst defaults to the rate.
+func newLimiter(perSecond float64, burst int) *rate.Limiter {
+	if perSecond == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst == 0 {
+		burst = int(math.Max(1, math.Ceil(perSecond)))
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
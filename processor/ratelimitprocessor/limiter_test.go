@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketsReserve(t *testing.T) {
+	b := newBuckets(&Config{
+		Limits:    Limits{RecordsPerSecond: 10, BytesPerSecond: 1000, BytesBurst: 100},
+		Overrides: map[string]Limits{"acme": {RecordsPerSecond: 100}},
+	})
+	now := time.Now()
+
+	r := b.reserve("initech", 10, 100, now)
+	assert.True(t, r.ok)
+	assert.Zero(t, r.delay)
+
+	r = b.reserve("initech", 1, 0, now)
+	assert.True(t, r.ok)
+	assert.Equal(t, 100*time.Millisecond, r.delay)
+	r.cancel(now)
+
+	r = b.reserve("initech", 1, 101, now)
+	assert.False(t, r.ok, "Must never admit batches larger than the burst")
+
+	r = b.reserve("acme", 100, 1<<20, now)
+	assert.True(t, r.ok, "Must apply the overrides of the key, which leave the size unlimited")
+	assert.Zero(t, r.delay)
+}
+
+func TestBucketsSweep(t *testing.T) {
+	b := newBuckets(&Config{Limits: Limits{RecordsPerSecond: 10}})
+	now := time.Now()
+	b.reserve("acme", 1, 0, now)
+	b.reserve("initech", 1, 0, now.Add(4*time.Minute))
+
+	b.sweep(now.Add(30 * time.Second))
+	assert.Len(t, b.byKey, 2, "Must sweep at most once per interval")
+
+	b.sweep(now.Add(5 * time.Minute))
+	assert.Len(t, b.byKey, 1, "Must remove the buckets of idle keys")
+	assert.Contains(t, b.byKey, "initech")
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+var (
+	processorTagKey = tag.MustNewKey("processor")
+	actionTagKey    = tag.MustNewKey("action")
+
+	mRecordsLimited = stats.Int64("records_limited", "Number of records over the limits of their key", stats.UnitDimensionless)
+	mBytesLimited   = stats.Int64("bytes_limited", "Size of the data over the limits of its key", stats.UnitBytes)
+)
+
+// metricViews returns the metrics views of the processor.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mRecordsLimited.Name()),
+			Measure:     mRecordsLimited,
+			Description: mRecordsLimited.Description(),
+			TagKeys:     []tag.Key{processorTagKey, actionTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mBytesLimited.Name()),
+			Measure:     mBytesLimited,
+			Description: mBytesLimited.Description(),
+			TagKeys:     []tag.Key{processorTagKey, actionTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// keyValuesSeparator joins the values making the key of a batch.
+const keyValuesSeparator = ","
+
+type rateLimiter struct {
+	logger             *zap.Logger
+	resourceAttributes []string
+	metadataKeys       []string
+	action             Action
+	maxDelay           time.Duration
+	buckets            *buckets
+	// tags is the context the limited data is recorded with.
+	tags context.Context
+	// now is set as a reference to help with testing.
+	now func() time.Time
+}
+
+func newRateLimiter(set processor.CreateSettings, cfg *Config) (*rateLimiter, error) {
+	tags, err := tag.New(context.Background(),
+		tag.Upsert(processorTagKey, set.ID.String()),
+		tag.Upsert(actionTagKey, string(cfg.Action)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimiter{
+		logger:             set.Logger,
+		resourceAttributes: cfg.ResourceAttributes,
+		metadataKeys:       cfg.MetadataKeys,
+		action:             cfg.Action,
+		maxDelay:           cfg.MaxDelay,
+		buckets:            newBuckets(cfg),
+		tags:               tags,
+		now:                time.Now,
+	}, nil
+}
+
+// batch is the part of the data sharing a key.
+type batch struct {
+	key     string
+	records int
+	bytes   int
+}
+
+// resourceData is a resource of a signal with its data, such as
+// ptrace.ResourceSpans.
+type resourceData[R any] interface {
+	Resource() pcommon.Resource
+	MoveTo(dest R)
+}
+
+// resourceSlice are the resources of the data of a signal, such as
+// ptrace.ResourceSpansSlice.
+type resourceSlice[S any, R resourceData[R]] interface {
+	Len() int
+	At(i int) R
+	AppendEmpty() R
+	MoveAndAppendTo(dest S)
+}
+
+// signal holds the functions handling the data of a signal. The type
+// parameters are expected to be ptrace.Traces, ptrace.ResourceSpansSlice and
+// ptrace.ResourceSpans, or their equivalents for the metrics and the logs.
+type signal[D any, S resourceSlice[S, R], R resourceData[R]] struct {
+	// newData returns empty data.
+	newData func() D
+	// resources returns the resources of the data.
+	resources func(data D) S
+	// records returns the number of records of the data.
+	records func(data D) int
+	// size returns the size of the protobuf encoding of the data.
+	size func(data D) int
+}
+
+var tracesSignal = signal[ptrace.Traces, ptrace.ResourceSpansSlice, ptrace.ResourceSpans]{
+	newData:   ptrace.NewTraces,
+	resources: ptrace.Traces.ResourceSpans,
+	records:   ptrace.Traces.SpanCount,
+	size:      (&ptrace.ProtoMarshaler{}).TracesSize,
+}
+
+var metricsSignal = signal[pmetric.Metrics, pmetric.ResourceMetricsSlice, pmetric.ResourceMetrics]{
+	newData:   pmetric.NewMetrics,
+	resources: pmetric.Metrics.ResourceMetrics,
+	records:   pmetric.Metrics.DataPointCount,
+	size:      (&pmetric.ProtoMarshaler{}).MetricsSize,
+}
+
+var logsSignal = signal[plog.Logs, plog.ResourceLogsSlice, plog.ResourceLogs]{
+	newData:   plog.NewLogs,
+	resources: plog.Logs.ResourceLogs,
+	records:   plog.Logs.LogRecordCount,
+	size:      (&plog.ProtoMarshaler{}).LogsSize,
+}
+
+func (rl *rateLimiter) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	return process(ctx, rl, tracesSignal, td)
+}
+
+func (rl *rateLimiter) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	return process(ctx, rl, metricsSignal, md)
+}
+
+func (rl *rateLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	return process(ctx, rl, logsSignal, ld)
+}
+
+// process groups the resources of the data by key, and returns the data of
+// the keys admitted by the rate limiter.
+func process[D any, S resourceSlice[S, R], R resourceData[R]](ctx context.Context, rl *rateLimiter, s signal[D, S, R], data D) (D, error) {
+	resources := s.resources(data)
+	if resources.Len() == 0 {
+		return data, nil
+	}
+	keys, distinct := rl.resourceKeys(ctx, resources.Len(), func(i int) pcommon.Resource {
+		return resources.At(i).Resource()
+	})
+	groups := make(map[string]D, len(distinct))
+	if len(distinct) == 1 {
+		groups[distinct[0]] = data
+	} else {
+		for _, key := range distinct {
+			groups[key] = s.newData()
+		}
+		for i, key := range keys {
+			resources.At(i).MoveTo(s.resources(groups[key]).AppendEmpty())
+		}
+	}
+
+	batches := make([]batch, len(distinct))
+	for i, key := range distinct {
+		batches[i] = batch{key: key, records: s.records(groups[key]), bytes: s.size(groups[key])}
+	}
+	admitted, err := rl.admit(ctx, batches)
+	if err != nil {
+		return data, err
+	}
+
+	if len(distinct) == 1 {
+		if len(admitted) == 0 {
+			return data, processorhelper.ErrSkipProcessingData
+		}
+		return data, nil
+	}
+	result := s.newData()
+	for _, key := range admitted {
+		s.resources(groups[key]).MoveAndAppendTo(s.resources(result))
+	}
+	if s.resources(result).Len() == 0 {
+		return result, processorhelper.ErrSkipProcessingData
+	}
+	return result, nil
+}
+
+// resourceKeys returns the key of every resource, and the distinct keys in
+// the order they are first found.
+func (rl *rateLimiter) resourceKeys(ctx context.Context, n int, resourceAt func(int) pcommon.Resource) ([]string, []string) {
+	keys := make([]string, n)
+	var distinct []string
+	seen := map[string]struct{}{}
+	for i := 0; i < n; i++ {
+		keys[i] = rl.key(ctx, resourceAt(i))
+		if _, ok := seen[keys[i]]; !ok {
+			seen[keys[i]] = struct{}{}
+			distinct = append(distinct, keys[i])
+		}
+	}
+	return keys, distinct
+}
+
+// key returns the values of the metadata keys and of the resource attributes
+// joined by commas, values missing being empty.
+func (rl *rateLimiter) key(ctx context.Context, resource pcommon.Resource) string {
+	if len(rl.metadataKeys) == 0 && len(rl.resourceAttributes) == 0 {
+		return ""
+	}
+	values := make([]string, 0, len(rl.metadataKeys)+len(rl.resourceAttributes))
+	info := client.FromContext(ctx)
+	for _, key := range rl.metadataKeys {
+		var value string
+		if vs := info.Metadata.Get(key); len(vs) > 0 {
+			value = vs[0]
+		}
+		values = append(values, value)
+	}
+	for _, key := range rl.resourceAttributes {
+		var value string
+		if v, ok := resource.Attributes().Get(key); ok {
+			value = v.AsString()
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, keyValuesSeparator)
+}
+
+// admit reserves the tokens of the batches from the buckets of their keys,
+// and returns the keys of the batches to pass on, depending on the action.
+func (rl *rateLimiter) admit(ctx context.Context, batches []batch) ([]string, error) {
+	now := rl.now()
+	rl.buckets.sweep(now)
+
+	reservations := make([]*reservation, len(batches))
+	var limited, oversized []int
+	var maxDelay time.Duration
+	for i, b := range batches {
+		r := rl.buckets.reserve(b.key, b.records, b.bytes, now)
+		reservations[i] = r
+		switch {
+		case !r.ok:
+			limited = append(limited, i)
+			oversized = append(oversized, i)
+		case r.delay > 0 && (rl.action != ActionDelay || r.delay > rl.maxDelay):
+			limited = append(limited, i)
+		case r.delay > maxDelay:
+			maxDelay = r.delay
+		}
+	}
+
+	if len(limited) > 0 && rl.action != ActionDrop {
+		// The whole data is refused, the tokens of the batches within their
+		// limits are given back as the data will be sent again.
+		for _, r := range reservations {
+			r.cancel(now)
+		}
+		for _, i := range limited {
+			rl.recordLimited(batches[i])
+		}
+		if len(oversized) > 0 {
+			// Retrying would never succeed, the batch must be split upstream.
+			return nil, consumererror.NewPermanent(fmt.Errorf("batch larger than the burst of key %q", batches[oversized[0]].key))
+		}
+		return nil, fmt.Errorf("rate limit exceeded for key %q", batches[limited[0]].key)
+	}
+
+	admitted := make([]string, 0, len(batches)-len(limited))
+	for i, b := range batches {
+		if len(limited) > 0 && limited[0] == i {
+			limited = limited[1:]
+			reservations[i].cancel(now)
+			rl.recordLimited(b)
+			continue
+		}
+		admitted = append(admitted, b.key)
+	}
+
+	if maxDelay > 0 {
+		timer := time.NewTimer(maxDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			for _, r := range reservations {
+				r.cancel(rl.now())
+			}
+			return nil, ctx.Err()
+		}
+	}
+	return admitted, nil
+}
+
+func (rl *rateLimiter) recordLimited(b batch) {
+	rl.logger.Debug("Rate limit exceeded",
+		zap.String("key", b.key),
+		zap.Int("records", b.records),
+		zap.Int("bytes", b.bytes),
+		zap.String("action", string(rl.action)))
+	stats.Record(rl.tags, mRecordsLimited.M(int64(b.records)), mBytesLimited.M(int64(b.bytes)))
+}
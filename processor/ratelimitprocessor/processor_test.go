@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+func newTestRateLimiter(t *testing.T, id component.ID, cfg *Config) (*rateLimiter, *time.Time) {
+	require.NoError(t, cfg.Validate())
+	set := processortest.NewNopCreateSettings()
+	set.ID = id
+	rl, err := newRateLimiter(set, cfg)
+	require.NoError(t, err)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+	return rl, &now
+}
+
+func appendLogs(ld plog.Logs, service string, n int) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < n; i++ {
+		records.AppendEmpty().Body().SetStr(service)
+	}
+}
+
+func TestProcessLogsDrop(t *testing.T) {
+	id := component.NewIDWithName(metadata.Type, "drop")
+	require.NoError(t, view.Register(metricViews()...))
+	defer view.Unregister(metricViews()...)
+
+	rl, now := newTestRateLimiter(t, id, &Config{
+		ResourceAttributes: []string{"service.name"},
+		Limits:             Limits{RecordsPerSecond: 2},
+		Overrides:          map[string]Limits{"checkout": {RecordsPerSecond: 10}},
+		Action:             ActionDrop,
+	})
+
+	ld := plog.NewLogs()
+	appendLogs(ld, "cart", 1)
+	appendLogs(ld, "search", 3)
+	appendLogs(ld, "checkout", 3)
+	appendLogs(ld, "cart", 1)
+	out, err := rl.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 3, out.ResourceLogs().Len(), "Must drop the resources of the keys over their limits")
+	for i, service := range []string{"cart", "cart", "checkout"} {
+		assert.Equal(t, service, out.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+
+	ld = plog.NewLogs()
+	appendLogs(ld, "cart", 1)
+	_, err = rl.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "Must skip batches left empty")
+
+	*now = now.Add(time.Second)
+	ld = plog.NewLogs()
+	appendLogs(ld, "cart", 2)
+	out, err = rl.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 2, out.LogRecordCount(), "Must admit batches once the tokens of the key are refilled")
+
+	assert.Equal(t, int64(3+1), limitedRecords(t, id))
+}
+
+func TestProcessTracesError(t *testing.T) {
+	rl, now := newTestRateLimiter(t, component.NewIDWithName(metadata.Type, "error"), &Config{
+		MetadataKeys: []string{"x-tenant"},
+		Limits:       Limits{RecordsPerSecond: 1, RecordsBurst: 3},
+		Action:       ActionError,
+	})
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+		})
+	}
+	traces := func(n int) ptrace.Traces {
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for i := 0; i < n; i++ {
+			spans.AppendEmpty()
+		}
+		return td
+	}
+
+	_, err := rl.processTraces(tenantCtx("acme"), traces(2))
+	require.NoError(t, err)
+
+	_, err = rl.processTraces(tenantCtx("acme"), traces(2))
+	require.EqualError(t, err, `rate limit exceeded for key "acme"`)
+	assert.False(t, consumererror.IsPermanent(err), "Must ask the clients to retry")
+
+	_, err = rl.processTraces(tenantCtx("initech"), traces(2))
+	assert.NoError(t, err, "Must enforce the limits per key")
+
+	*now = now.Add(time.Second)
+	_, err = rl.processTraces(tenantCtx("acme"), traces(2))
+	assert.NoError(t, err, "Must give back the tokens of the refused batches")
+}
+
+func TestProcessMetricsDelay(t *testing.T) {
+	rl, _ := newTestRateLimiter(t, component.NewIDWithName(metadata.Type, "delay"), &Config{
+		Limits:   Limits{RecordsPerSecond: 20, RecordsBurst: 1},
+		Action:   ActionDelay,
+		MaxDelay: 200 * time.Millisecond,
+	})
+	rl.now = time.Now
+	metrics := func(n int) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+		for i := 0; i < n; i++ {
+			dps.AppendEmpty().SetIntValue(int64(i))
+		}
+		return md
+	}
+
+	_, err := rl.processMetrics(context.Background(), metrics(1))
+	require.NoError(t, err)
+
+	start := time.Now()
+	out, err := rl.processMetrics(context.Background(), metrics(1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.DataPointCount())
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "Must hold the data until the rate allows it")
+
+	_, err = rl.processMetrics(context.Background(), metrics(2))
+	assert.EqualError(t, err, `Permanent error: batch larger than the burst of key ""`)
+	assert.True(t, consumererror.IsPermanent(err), "Must refuse batches larger than the burst for good")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = rl.processMetrics(ctx, metrics(1))
+	assert.ErrorIs(t, err, context.Canceled, "Must stop waiting when the context is done")
+}
+
+func TestProcessBytes(t *testing.T) {
+	rl, _ := newTestRateLimiter(t, component.NewID(metadata.Type), &Config{
+		Limits: Limits{BytesPerSecond: 100},
+		Action: ActionDrop,
+	})
+
+	ld := plog.NewLogs()
+	appendLogs(ld, "cart", 1)
+	_, err := rl.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	ld = plog.NewLogs()
+	appendLogs(ld, "cart", 10)
+	_, err = rl.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "Must limit the size of the data")
+}
+
+// limitedRecords returns the number of records limited by the given processor.
+func limitedRecords(t *testing.T, id component.ID) int64 {
+	rows, err := view.RetrieveData(processorhelper.BuildCustomMetricName(metadata.Type, mRecordsLimited.Name()))
+	require.NoError(t, err)
+	var limited int64
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == processorTagKey && tg.Value == id.String() {
+				limited += int64(row.Data.(*view.SumData).Value)
+			}
+		}
+	}
+	return limited
+}
@@ -3,6 +3,8 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import "go.opentelemetry.io/collector/config/configopaque"
+
 type Config struct {
 
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
@@ -20,10 +22,28 @@ type Config struct {
 	// without being changed or removed.
 	IgnoredKeys []string `mapstructure:"ignored_keys"`
 
+	// HashedKeys is a list of attribute keys whose values are replaced by
+	// their SHA-256 hash instead of being removed. Hashing keeps values
+	// correlatable without exposing them.
+	HashedKeys []string `mapstructure:"hashed_keys"`
+
+	// HashSalt is prepended to the values before hashing them, so that
+	// hashes of guessable values can't be reversed with precomputed tables.
+	HashSalt configopaque.String `mapstructure:"hash_salt"`
+
 	// BlockedValues is a list of regular expressions for blocking values of
-	// allowed span attributes. Values that match are masked
+	// allowed span attributes and of log bodies. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// Resource lists the keys allowed in resource attributes in addition to
+	// AllowedKeys. They are inherited by the scope and record levels.
+	Resource LevelConfig `mapstructure:"resource"`
+
+	// Scope lists the keys allowed in scope attributes in addition to
+	// AllowedKeys and the resource level keys. They are inherited by the
+	// record level.
+	Scope LevelConfig `mapstructure:"scope"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -31,3 +51,10 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// LevelConfig holds the keys allowed at one level of the telemetry.
+type LevelConfig struct {
+	// AllowedKeys is a list of attribute keys allowed at this level and at
+	// the levels below it.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+}
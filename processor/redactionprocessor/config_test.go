@@ -29,6 +29,10 @@ func TestLoadConfig(t *testing.T) {
 				AllowedKeys:   []string{"description", "group", "id", "name"},
 				IgnoredKeys:   []string{"safe_attribute"},
 				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
+				HashedKeys:    []string{"user.email"},
+				HashSalt:      "pepper",
+				Resource:      LevelConfig{AllowedKeys: []string{"service.name"}},
+				Scope:         LevelConfig{AllowedKeys: []string{"library.version"}},
 				Summary:       debug,
 			},
 		},
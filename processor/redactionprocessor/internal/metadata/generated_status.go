@@ -9,4 +9,5 @@ import (
 const (
 	Type            = "redaction"
 	TracesStability = component.StabilityLevelBeta
+	LogsStability   = component.StabilityLevelDevelopment
 )
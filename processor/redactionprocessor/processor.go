@@ -5,12 +5,15 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -18,12 +21,20 @@ import (
 const attrValuesSeparator = ","
 
 type redaction struct {
-	// Attribute keys allowed in a span
+	// Attribute keys allowed in a span or log record
 	allowList map[string]string
+	// Attribute keys allowed in a resource
+	resourceAllowList map[string]string
+	// Attribute keys allowed in a scope
+	scopeAllowList map[string]string
+	// Attribute keys whose values are hashed
+	hashList map[string]string
 	// Attribute keys ignored in a span
 	ignoreList map[string]string
 	// Attribute values blocked in a span
 	blockRegexList map[string]*regexp.Regexp
+	// Whether the attributes of the scopes are redacted
+	redactScope bool
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -32,8 +43,11 @@ type redaction struct {
 
 // newRedaction creates a new instance of the redaction processor
 func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
-	allowList := makeAllowList(config)
+	resourceAllowList := makeAllowList(config, config.Resource.AllowedKeys)
+	scopeAllowList := makeAllowList(config, config.Resource.AllowedKeys, config.Scope.AllowedKeys)
+	allowList := scopeAllowList
 	ignoreList := makeIgnoreList(config)
+	hashList := makeHashList(config)
 	blockRegexList, err := makeBlockRegexList(ctx, config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
@@ -41,11 +55,15 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 	}
 
 	return &redaction{
-		allowList:      allowList,
-		ignoreList:     ignoreList,
-		blockRegexList: blockRegexList,
-		config:         config,
-		logger:         logger,
+		allowList:         allowList,
+		resourceAllowList: resourceAllowList,
+		scopeAllowList:    scopeAllowList,
+		hashList:          hashList,
+		ignoreList:        ignoreList,
+		blockRegexList:    blockRegexList,
+		redactScope:       redactScopeAttributesFeatureGate.IsEnabled(),
+		config:            config,
+		logger:            logger,
 	}, nil
 }
 
@@ -59,31 +77,69 @@ func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptr
 	return batch, nil
 }
 
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
 // processResourceSpan processes the RS and all of its spans and then returns the last
 // view metric context. The context can be used for tests
 func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceSpans) {
 	rsAttrs := rs.Resource().Attributes()
 
 	// Attributes can be part of a resource span
-	s.processAttrs(ctx, rsAttrs)
+	s.processAttrs(ctx, rsAttrs, s.resourceAllowList)
 
 	for j := 0; j < rs.ScopeSpans().Len(); j++ {
 		ils := rs.ScopeSpans().At(j)
+		s.processScope(ctx, ils.Scope())
 		for k := 0; k < ils.Spans().Len(); k++ {
 			span := ils.Spans().At(k)
 			spanAttrs := span.Attributes()
 
 			// Attributes can also be part of span
-			s.processAttrs(ctx, spanAttrs)
+			s.processAttrs(ctx, spanAttrs, s.allowList)
+		}
+	}
+}
+
+// processResourceLog processes the resource, scopes and log records of a RL,
+// including the log record bodies
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	s.processAttrs(ctx, rl.Resource().Attributes(), s.resourceAllowList)
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		sl := rl.ScopeLogs().At(j)
+		s.processScope(ctx, sl.Scope())
+		for k := 0; k < sl.LogRecords().Len(); k++ {
+			lr := sl.LogRecords().At(k)
+			s.processAttrs(ctx, lr.Attributes(), s.allowList)
+			if s.maskValue(lr.Body()) {
+				s.addBodyMetaAttrs(lr.Attributes())
+			}
 		}
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
-func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
+// processScope redacts the attributes of a scope when the feature gate is enabled
+func (s *redaction) processScope(ctx context.Context, scope pcommon.InstrumentationScope) {
+	if s.redactScope {
+		s.processAttrs(ctx, scope.Attributes(), s.scopeAllowList)
+	}
+}
+
+// processAttrs redacts the attributes of a resource, a scope, a span or a
+// log record, keeping the keys of the given allow list
+func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map, allowList map[string]string) {
 	// TODO: Use the context for recording metrics
 	var toDelete []string
 	var toBlock []string
+	var toHash []string
 	var ignoring []string
 
 	// Identify attributes to redact and mask in the following sequence
@@ -102,9 +158,17 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 			return true
 		}
 
+		// Hash the values of the hashed keys instead of redacting them
+		if _, hashed := s.hashList[k]; hashed {
+			toHash = append(toHash, k)
+			value.SetStr(s.hash(value.AsString()))
+			// Skip to the next attribute
+			return true
+		}
+
 		// Make a list of attribute keys to redact
 		if !s.config.AllowAllKeys {
-			if _, allowed := s.allowList[k]; !allowed {
+			if _, allowed := allowList[k]; !allowed {
 				toDelete = append(toDelete, k)
 				// Skip to the next attribute
 				return true
@@ -112,20 +176,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		var matched bool
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				if !matched {
-					matched = true
-					toBlock = append(toBlock, k)
-				}
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-				strVal = maskedValue
-			}
+		if maskedValue, matched := s.maskString(value.Str()); matched {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -137,9 +190,60 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// Add diagnostic information to the span
 	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
 	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
+	s.addMetaAttrs(toHash, attributes, hashedKeys, hashedKeyCount)
 	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
+// maskString masks the parts of the string matching a blocked value, and
+// reports whether any did
+func (s *redaction) maskString(strVal string) (string, bool) {
+	var matched bool
+	for _, compiledRE := range s.blockRegexList {
+		if compiledRE.MatchString(strVal) {
+			matched = true
+			strVal = compiledRE.ReplaceAllString(strVal, "****")
+		}
+	}
+	return strVal, matched
+}
+
+// maskValue masks the blocked values found in a log body, including in the
+// strings nested in maps and slices, and reports whether any was masked
+func (s *redaction) maskValue(value pcommon.Value) bool {
+	var masked bool
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		if maskedValue, matched := s.maskString(value.Str()); matched {
+			value.SetStr(maskedValue)
+			masked = true
+		}
+	case pcommon.ValueTypeMap:
+		value.Map().Range(func(_ string, v pcommon.Value) bool {
+			masked = s.maskValue(v) || masked
+			return true
+		})
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < value.Slice().Len(); i++ {
+			masked = s.maskValue(value.Slice().At(i)) || masked
+		}
+	}
+	return masked
+}
+
+// addBodyMetaAttrs records in the log record attributes that values of its
+// body were masked
+func (s *redaction) addBodyMetaAttrs(attributes pcommon.Map) {
+	if s.config.Summary == info || s.config.Summary == debug {
+		attributes.PutBool(maskedBody, true)
+	}
+}
+
+// hash returns the hexadecimal SHA-256 hash of the salted value
+func (s *redaction) hash(value string) string {
+	sum := sha256.Sum256([]byte(string(s.config.HashSalt) + value))
+	return hex.EncodeToString(sum[:])
+}
+
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -170,11 +274,15 @@ const (
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
 	maskedValueCount = "redaction.masked.count"
+	hashedKeys       = "redaction.hashed.keys"
+	hashedKeyCount   = "redaction.hashed.count"
+	maskedBody       = "redaction.masked.body"
 	ignoredKeyCount  = "redaction.ignored.count"
 )
 
-// makeAllowList sets up a lookup table of allowed span attribute keys
-func makeAllowList(c *Config) map[string]string {
+// makeAllowList sets up a lookup table of allowed attribute keys, made of the
+// allowed keys of the configuration and the ones inherited from the levels above
+func makeAllowList(c *Config, inherited ...[]string) map[string]string {
 	// redactionKeys are additional span attributes created by the processor to
 	// summarize the changes it made to a span. If the processor removes
 	// 2 attributes from a span (e.g. `birth_date`, `mothers_maiden_name`),
@@ -185,7 +293,7 @@ func makeAllowList(c *Config) map[string]string {
 	// span attributes (e.g. `notes`, `description`), then it will those
 	// attribute keys in `redaction.masked.keys` and set the
 	// `redaction.masked.count` to 2
-	redactionKeys := []string{redactedKeys, redactedKeyCount, maskedValues, maskedValueCount, ignoredKeyCount}
+	redactionKeys := []string{redactedKeys, redactedKeyCount, maskedValues, maskedValueCount, hashedKeys, hashedKeyCount, maskedBody, ignoredKeyCount}
 	// allowList consists of the keys explicitly allowed by the configuration
 	// as well as of the new span attributes that the processor creates to
 	// summarize its changes
@@ -193,6 +301,11 @@ func makeAllowList(c *Config) map[string]string {
 	for _, key := range c.AllowedKeys {
 		allowList[key] = key
 	}
+	for _, keys := range inherited {
+		for _, key := range keys {
+			allowList[key] = key
+		}
+	}
 	for _, key := range redactionKeys {
 		allowList[key] = key
 	}
@@ -207,6 +320,14 @@ func makeIgnoreList(c *Config) map[string]string {
 	return ignoreList
 }
 
+func makeHashList(c *Config) map[string]string {
+	hashList := make(map[string]string, len(c.HashedKeys))
+	for _, key := range c.HashedKeys {
+		hashList[key] = key
+	}
+	return hashList
+}
+
 // makeBlockRegexList precompiles all the blocked regex patterns
 func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
 	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
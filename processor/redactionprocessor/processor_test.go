@@ -5,13 +5,17 @@ package redactionprocessor
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
@@ -350,7 +354,7 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 		maskedValues:     "mystery",
 		maskedValueCount: 1,
 	}))
-	processor.processAttrs(context.TODO(), attrs)
+	processor.processAttrs(context.TODO(), attrs, processor.allowList)
 
 	assert.Equal(t, 7, attrs.Len())
 	val, found := attrs.Get(redactedKeys)
@@ -367,6 +371,133 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.Int())
 }
 
+// TestHashedKeys validates that the processor replaces the values of the
+// hashed keys by their salted hash instead of deleting them
+func TestHashedKeys(t *testing.T) {
+	config := &Config{
+		AllowedKeys: []string{"id"},
+		HashedKeys:  []string{"user.email", "user.id"},
+		HashSalt:    "pepper",
+		Summary:     "debug",
+	}
+	allowed := map[string]pcommon.Value{
+		"id": pcommon.NewValueInt(5),
+	}
+	hashed := map[string]pcommon.Value{
+		"user.email": pcommon.NewValueStr("alice@example.com"),
+		"user.id":    pcommon.NewValueInt(42),
+	}
+
+	outTraces := runTest(t, allowed, nil, hashed, nil, config)
+
+	attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	email, ok := attr.Get("user.email")
+	require.True(t, ok)
+	sum := sha256.Sum256([]byte("pepperalice@example.com"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), email.Str())
+	userID, ok := attr.Get("user.id")
+	require.True(t, ok)
+	sum = sha256.Sum256([]byte("pepper42"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), userID.Str())
+
+	keys, ok := attr.Get(hashedKeys)
+	require.True(t, ok)
+	assert.Equal(t, "user.email,user.id", keys.Str())
+	count, ok := attr.Get(hashedKeyCount)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), count.Int())
+}
+
+// TestAllowListInheritance validates that the keys allowed at the resource
+// and scope levels are also allowed at the levels below them
+func TestAllowListInheritance(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(redactScopeAttributesFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(redactScopeAttributesFeatureGate.ID(), false))
+	}()
+
+	config := &Config{
+		AllowedKeys: []string{"id"},
+		Resource:    LevelConfig{AllowedKeys: []string{"service.name"}},
+		Scope:       LevelConfig{AllowedKeys: []string{"library.version"}},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	attrs := map[string]any{"id": 1, "service.name": "svc", "library.version": "1.0", "secret": "x"}
+	inBatch := ptrace.NewTraces()
+	rs := inBatch.ResourceSpans().AppendEmpty()
+	require.NoError(t, rs.Resource().Attributes().FromRaw(attrs))
+	ss := rs.ScopeSpans().AppendEmpty()
+	require.NoError(t, ss.Scope().Attributes().FromRaw(attrs))
+	require.NoError(t, ss.Spans().AppendEmpty().Attributes().FromRaw(attrs))
+
+	outBatch, err := processor.processTraces(context.Background(), inBatch)
+	require.NoError(t, err)
+
+	rs = outBatch.ResourceSpans().At(0)
+	assert.Equal(t, map[string]any{"id": int64(1), "service.name": "svc"}, rs.Resource().Attributes().AsRaw())
+	ss = rs.ScopeSpans().At(0)
+	assert.Equal(t, map[string]any{"id": int64(1), "service.name": "svc", "library.version": "1.0"}, ss.Scope().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"id": int64(1), "service.name": "svc", "library.version": "1.0"}, ss.Spans().At(0).Attributes().AsRaw())
+}
+
+// TestScopeAttributesNotRedacted validates that the scope attributes are left
+// unchanged unless the feature gate is enabled
+func TestScopeAttributesNotRedacted(t *testing.T) {
+	config := &Config{AllowedKeys: []string{"id"}}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	attrs := map[string]any{"id": 1, "secret": "x"}
+	inBatch := ptrace.NewTraces()
+	ss := inBatch.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	require.NoError(t, ss.Scope().Attributes().FromRaw(attrs))
+	require.NoError(t, ss.Spans().AppendEmpty().Attributes().FromRaw(attrs))
+
+	outBatch, err := processor.processTraces(context.Background(), inBatch)
+	require.NoError(t, err)
+
+	ss = outBatch.ResourceSpans().At(0).ScopeSpans().At(0)
+	assert.Equal(t, map[string]any{"id": int64(1), "secret": "x"}, ss.Scope().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"id": int64(1)}, ss.Spans().At(0).Attributes().AsRaw())
+}
+
+// TestRedactLogs validates that the processor redacts log record attributes
+// and masks blocked values in log bodies
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       info,
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	inLogs := plog.NewLogs()
+	lrs := inLogs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	strBody := lrs.AppendEmpty()
+	strBody.Body().SetStr("payment with card 4111111111111111 accepted")
+	require.NoError(t, strBody.Attributes().FromRaw(map[string]any{"id": 1, "card": "4111111111111111"}))
+	mapBody := lrs.AppendEmpty()
+	require.NoError(t, mapBody.Body().SetEmptyMap().FromRaw(map[string]any{
+		"message": "card 4111111111111111",
+		"cards":   []any{"4111111111111111", int64(3)},
+	}))
+
+	outLogs, err := processor.processLogs(context.Background(), inLogs)
+	require.NoError(t, err)
+
+	lrs = outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "payment with card **** accepted", lrs.At(0).Body().Str())
+	assert.Equal(t, map[string]any{"id": int64(1), redactedKeyCount: int64(1), maskedBody: true}, lrs.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"message": "card ****",
+		"cards":   []any{"****", int64(3)},
+	}, lrs.At(1).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{maskedBody: true}, lrs.At(1).Attributes().AsRaw())
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
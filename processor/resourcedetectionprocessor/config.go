@@ -4,6 +4,8 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
@@ -15,6 +17,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/custom"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
@@ -32,6 +35,10 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// RefreshInterval is the interval at which the detectors are run again to
+	// update the resource of the following telemetry. The resource is only
+	// detected at startup when unset.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 	// DetectorConfig is a list of settings specific to all detectors
 	DetectorConfig DetectorConfig `mapstructure:",squash"`
 	// HTTP client settings for the detector
@@ -69,6 +76,9 @@ type DetectorConfig struct {
 	// ConsulConfig contains user-specified configurations for the Consul detector
 	ConsulConfig consul.Config `mapstructure:"consul"`
 
+	// CustomConfig contains user-specified configurations for the custom detector
+	CustomConfig custom.Config `mapstructure:"custom"`
+
 	// DockerConfig contains user-specified configurations for the docker detector
 	DockerConfig docker.Config `mapstructure:"docker"`
 
@@ -98,6 +108,7 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		AzureConfig:            azure.CreateDefaultConfig(),
 		AksConfig:              aks.CreateDefaultConfig(),
 		ConsulConfig:           consul.CreateDefaultConfig(),
+		CustomConfig:           custom.CreateDefaultConfig(),
 		DockerConfig:           docker.CreateDefaultConfig(),
 		GcpConfig:              gcp.CreateDefaultConfig(),
 		HerokuConfig:           heroku.CreateDefaultConfig(),
@@ -125,6 +136,8 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.AksConfig
 	case consul.TypeStr:
 		return d.ConsulConfig
+	case custom.TypeStr:
+		return d.CustomConfig
 	case docker.TypeStr:
 		return d.DockerConfig
 	case gcp.TypeStr:
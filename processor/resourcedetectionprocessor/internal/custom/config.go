@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package custom // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/custom"
+
+import (
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Config defines where the custom detector gets the resource information from,
+// and how it maps it to resource attributes.
+type Config struct {
+	// Endpoint is the URL of a JSON object describing the host, for instance
+	// an entry of a CMDB. Exactly one of Endpoint and Command must be set.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are added to the request sent to the endpoint.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+
+	// Command is a program and its arguments, writing a JSON object
+	// describing the host to its standard output.
+	Command []string `mapstructure:"command"`
+
+	// Attributes maps the resource attributes to the field of the JSON object
+	// they are set from. Nested fields are separated by dots.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{}
+}
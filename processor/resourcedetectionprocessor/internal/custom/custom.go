@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package custom provides a detector that loads resource information from
+// the JSON object returned by an HTTP endpoint or written by a command, such
+// as the entries of a CMDB describing private clouds and bare-metal hosts.
+package custom // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/custom"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// TypeStr is type of detector.
+const TypeStr = "custom"
+
+// maxResponseSize is the maximum size of the JSON object read from the endpoint or the command.
+const maxResponseSize = 1 << 20
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a custom resource detector
+type Detector struct {
+	cfg    Config
+	logger *zap.Logger
+}
+
+// NewDetector creates a new custom detector
+func NewDetector(set processor.CreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if (cfg.Endpoint == "") == (len(cfg.Command) == 0) {
+		return nil, errors.New("exactly one of endpoint and command must be set")
+	}
+	if len(cfg.Attributes) == 0 {
+		return nil, errors.New("attributes must not be empty")
+	}
+	return &Detector{cfg: cfg, logger: set.Logger}, nil
+}
+
+// Detect returns a resource holding the configured attributes found in the JSON object
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+
+	var data []byte
+	if d.cfg.Endpoint != "" {
+		data, err = d.fetch(ctx)
+	} else {
+		data, err = d.execute(ctx)
+	}
+	if err != nil {
+		return res, "", err
+	}
+
+	var object map[string]any
+	if err = json.Unmarshal(data, &object); err != nil {
+		return res, "", fmt.Errorf("failed to parse the custom resource information: %w", err)
+	}
+
+	for attribute, field := range d.cfg.Attributes {
+		value, ok := lookupField(object, field)
+		if !ok {
+			d.logger.Debug("field not found in the custom resource information", zap.String("field", field))
+			continue
+		}
+		if err = putValue(res.Attributes(), attribute, value); err != nil {
+			return pcommon.NewResource(), "", fmt.Errorf("failed to set %q from field %q: %w", attribute, field, err)
+		}
+	}
+	return res, "", nil
+}
+
+func (d *Detector) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.cfg.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range d.cfg.Headers {
+		req.Header.Set(k, string(v))
+	}
+	resp, err := d.httpClient(ctx).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the custom resource information: %s", resp.Status)
+	}
+	return readLimited(resp.Body)
+}
+
+// httpClient returns the client configured in the processor settings, which sets the timeout,
+// TLS and proxy of the requests.
+func (d *Detector) httpClient(ctx context.Context) *http.Client {
+	client, err := internal.ClientFromContext(ctx)
+	if err != nil {
+		d.logger.Debug("Error retrieving client from context thus creating default", zap.Error(err))
+		return http.DefaultClient
+	}
+	return client
+}
+
+// readLimited reads the custom resource information, failing if it's larger than maxResponseSize.
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("the custom resource information is larger than %d bytes", maxResponseSize)
+	}
+	return data, nil
+}
+
+func (d *Detector) execute(ctx context.Context) ([]byte, error) {
+	var stderr bytes.Buffer
+	// #nosec G204 -- the command is set by the collector configuration
+	cmd := exec.CommandContext(ctx, d.cfg.Command[0], d.cfg.Command[1:]...)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to execute %q: %w", d.cfg.Command[0], err)
+	}
+	out, readErr := readLimited(stdout)
+	if readErr != nil {
+		// stop the command instead of waiting for it to write its whole output
+		_ = cmd.Process.Kill()
+	}
+	if err = cmd.Wait(); err != nil && readErr == nil {
+		return nil, fmt.Errorf("failed to execute %q: %w: %s", d.cfg.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, readErr
+}
+
+// lookupField returns the value of the field, whose nested keys are separated by dots.
+func lookupField(object map[string]any, field string) (any, bool) {
+	keys := strings.Split(field, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := object[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		object = child
+	}
+	value, ok := object[keys[len(keys)-1]]
+	return value, ok && value != nil
+}
+
+func putValue(attrs pcommon.Map, key string, value any) error {
+	switch v := value.(type) {
+	case string:
+		attrs.PutStr(key, v)
+	case float64:
+		if v == float64(int64(v)) {
+			attrs.PutInt(key, int64(v))
+		} else {
+			attrs.PutDouble(key, v)
+		}
+	case bool:
+		attrs.PutBool(key, v)
+	default:
+		return attrs.PutEmpty(key).FromRaw(v)
+	}
+	return nil
+}
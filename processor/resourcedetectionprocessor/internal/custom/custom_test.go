@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package custom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const hostJSON = `{
+	"name": "db-01",
+	"rack": {"id": "r12", "row": 3},
+	"cpu": {"load": 0.5},
+	"virtual": false,
+	"tags": ["db", "prod"],
+	"owner": null
+}`
+
+func TestNewDetector(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{
+			name: "endpoint",
+			cfg:  Config{Endpoint: "http://cmdb", Attributes: map[string]string{"host.name": "name"}},
+		},
+		{
+			name: "command",
+			cfg:  Config{Command: []string{"cat", "host.json"}, Attributes: map[string]string{"host.name": "name"}},
+		},
+		{
+			name: "no source",
+			cfg:  Config{Attributes: map[string]string{"host.name": "name"}},
+			err:  "exactly one of endpoint and command must be set",
+		},
+		{
+			name: "both sources",
+			cfg:  Config{Endpoint: "http://cmdb", Command: []string{"cat"}, Attributes: map[string]string{"host.name": "name"}},
+			err:  "exactly one of endpoint and command must be set",
+		},
+		{
+			name: "no attributes",
+			cfg:  Config{Endpoint: "http://cmdb"},
+			err:  "attributes must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDetector(processortest.NewNopCreateSettings(), tt.cfg)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, d)
+		})
+	}
+}
+
+func TestDetectEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(hostJSON))
+	}))
+	defer server.Close()
+
+	d, err := NewDetector(processortest.NewNopCreateSettings(), Config{
+		Endpoint: server.URL,
+		Headers:  map[string]configopaque.String{"Authorization": "Bearer token"},
+		Attributes: map[string]string{
+			"host.name":    "name",
+			"host.rack":    "rack.id",
+			"host.row":     "rack.row",
+			"host.load":    "cpu.load",
+			"host.virtual": "virtual",
+			"host.tags":    "tags",
+			"host.owner":   "owner",
+			"host.missing": "rack.missing.id",
+		},
+	})
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "", schemaURL)
+	assert.Equal(t, map[string]any{
+		"host.name":    "db-01",
+		"host.rack":    "r12",
+		"host.row":     int64(3),
+		"host.load":    0.5,
+		"host.virtual": false,
+		"host.tags":    []any{"db", "prod"},
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectEndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d, err := NewDetector(processortest.NewNopCreateSettings(), Config{
+		Endpoint:   server.URL,
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed to fetch the custom resource information: 404 Not Found")
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectEndpointClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("large") != "" {
+			_, _ = w.Write([]byte(`{"name": "` + strings.Repeat("x", maxResponseSize) + `"}`))
+			return
+		}
+		time.Sleep(time.Second)
+		_, _ = w.Write([]byte(hostJSON))
+	}))
+	defer server.Close()
+
+	// the client of the processor settings is used
+	ctx := internal.ContextWithClient(context.Background(), &http.Client{Timeout: 10 * time.Millisecond})
+	d, err := NewDetector(processortest.NewNopCreateSettings(), Config{
+		Endpoint:   server.URL,
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+	_, _, err = d.Detect(ctx)
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+
+	d, err = NewDetector(processortest.NewNopCreateSettings(), Config{
+		Endpoint:   server.URL + "?large=true",
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+	_, _, err = d.Detect(context.Background())
+	assert.EqualError(t, err, "the custom resource information is larger than 1048576 bytes")
+}
+
+func TestDetectCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test relies on sh")
+	}
+
+	d, err := NewDetector(processortest.NewNopCreateSettings(), Config{
+		Command:    []string{"sh", "-c", "echo '" + hostJSON + "'"},
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host.name": "db-01"}, res.Attributes().AsRaw())
+
+	d, err = NewDetector(processortest.NewNopCreateSettings(), Config{
+		Command:    []string{"sh", "-c", "echo 'not json'"},
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+	_, _, err = d.Detect(context.Background())
+	assert.ErrorContains(t, err, "failed to parse the custom resource information")
+
+	d, err = NewDetector(processortest.NewNopCreateSettings(), Config{
+		Command:    []string{"sh", "-c", "echo 'no cmdb' >&2; exit 1"},
+		Attributes: map[string]string{"host.name": "name"},
+	})
+	require.NoError(t, err)
+	_, _, err = d.Detect(context.Background())
+	assert.EqualError(t, err, `failed to execute "sh": exit status 1: no cmdb`)
+}
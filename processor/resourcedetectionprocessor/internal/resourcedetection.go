@@ -14,6 +14,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -87,7 +88,14 @@ type ResourceProvider struct {
 	detectors        []Detector
 	detectedResource *resourceResult
 	once             sync.Once
+	mu               sync.RWMutex
 	attributesToKeep map[string]struct{}
+
+	// refreshMu guards the refresh loop, shared by the processors of all the pipelines.
+	refreshMu    sync.Mutex
+	refreshUsers int
+	cancel       context.CancelFunc
+	done         chan struct{}
 }
 
 type resourceResult struct {
@@ -110,17 +118,99 @@ func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resour
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
 		defer cancel()
-		p.detectResource(ctx)
+		result, _ := p.detectResource(ctx)
+		p.mu.Lock()
+		p.detectedResource = result
+		p.mu.Unlock()
 	})
 
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// Refresh runs the detectors again and returns the newly detected resource, which is also
+// returned by the following calls to Get. When a detector fails, the previously detected
+// resource is kept and the error is returned.
+func (p *ResourceProvider) Refresh(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, client.Timeout)
+	defer cancel()
+	result, err := p.detectResource(ctx)
+	if err != nil {
+		return pcommon.Resource{}, "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.detectedResource = result
+	return result.resource, result.schemaURL, result.err
+}
+
+// Current returns the resource detected last, without running the detectors.
+func (p *ResourceProvider) Current() (resource pcommon.Resource, schemaURL string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.detectedResource == nil {
+		return pcommon.NewResource(), ""
+	}
+	return p.detectedResource.resource, p.detectedResource.schemaURL
+}
+
+// StartRefreshing runs the detectors again at every interval, the first caller starting
+// the refresh loop and the following ones sharing it. Each call must be matched by a call
+// to StopRefreshing.
+func (p *ResourceProvider) StartRefreshing(client *http.Client, interval time.Duration) {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+	p.refreshUsers++
+	if p.refreshUsers > 1 {
+		return
+	}
+	ctx, cancel := context.WithCancel(ContextWithClient(context.Background(), client))
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.refreshLoop(ctx, client, interval)
+}
+
+// StopRefreshing stops the refresh loop once all the callers of StartRefreshing stopped it.
+func (p *ResourceProvider) StopRefreshing() {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+	if p.refreshUsers == 0 {
+		return
+	}
+	p.refreshUsers--
+	if p.refreshUsers > 0 {
+		return
+	}
+	p.cancel()
+	<-p.done
+}
+
+// refreshLoop refreshes the resource at every interval, so that the following telemetry
+// gets the changes of the resource, like the labels of a node.
+func (p *ResourceProvider) refreshLoop(ctx context.Context, client *http.Client, interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, _, err := p.Refresh(ctx, client); err != nil {
+				p.logger.Warn("failed to refresh the resource, keeping the previous one", zap.Error(err))
+			}
+		}
+	}
+}
 
+// detectResource runs the detectors and merges the resources they return. The errors of
+// the detectors are logged, and returned with the resource detected by the others.
+func (p *ResourceProvider) detectResource(ctx context.Context) (*resourceResult, error) {
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
+	var errs error
 
 	p.logger.Info("began detecting resource information")
 
@@ -128,6 +218,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		r, schemaURL, err := detector.Detect(ctx)
 		if err != nil {
 			p.logger.Warn("failed to detect resource", zap.Error(err))
+			errs = multierr.Append(errs, err)
 		} else {
 			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
 			MergeResource(res, r, false)
@@ -141,8 +232,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	return &resourceResult{resource: res, schemaURL: mergedSchemaURL}, errs
 }
 
 func MergeSchemaURL(currentSchemaURL string, newSchemaURL string) string {
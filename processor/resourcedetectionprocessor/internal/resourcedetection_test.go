@@ -144,6 +144,70 @@ func TestDetectResource_Error(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDetectResource_Refresh(t *testing.T) {
+	md := &MockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("lifecycle", "normal")
+	md.On("Detect").Return(res1, nil).Once()
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("lifecycle", "spot")
+	md.On("Detect").Return(res2, nil).Once()
+	md.On("Detect").Return(pcommon.NewResource(), errors.New("metadata unavailable")).Once()
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	got, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"lifecycle": "normal"}, got.Attributes().AsRaw())
+
+	got, _, err = p.Refresh(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"lifecycle": "spot"}, got.Attributes().AsRaw())
+
+	_, _, err = p.Refresh(context.Background(), http.DefaultClient)
+	assert.EqualError(t, err, "metadata unavailable")
+
+	// failed refreshes keep the previous resource
+	got, _, err = p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"lifecycle": "spot"}, got.Attributes().AsRaw())
+	md.AssertExpectations(t)
+}
+
+func TestResourceProvider_StartRefreshing(t *testing.T) {
+	md := &MockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("lifecycle", "normal")
+	md.On("Detect").Return(res1, nil).Once()
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("lifecycle", "spot")
+	md.On("Detect").Return(res2, nil)
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	got, _ := p.Current()
+	assert.Equal(t, map[string]any{"lifecycle": "normal"}, got.Attributes().AsRaw())
+
+	// the processors of the pipelines share a single refresh loop
+	p.StartRefreshing(http.DefaultClient, 10*time.Millisecond)
+	done := p.done
+	p.StartRefreshing(http.DefaultClient, 10*time.Millisecond)
+	assert.Equal(t, done, p.done)
+	assert.Eventually(t, func() bool {
+		got, _ = p.Current()
+		return got.Attributes().AsRaw()["lifecycle"] == "spot"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.StopRefreshing()
+	select {
+	case <-done:
+		t.Fatal("the refresh loop was stopped while still in use")
+	default:
+	}
+	p.StopRefreshing()
+	<-done
+}
+
 func TestMergeResource(t *testing.T) {
 	for _, tt := range []struct {
 		name       string
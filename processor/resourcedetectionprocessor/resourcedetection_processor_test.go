@@ -328,3 +328,44 @@ func BenchmarkConsumeLogsAll(b *testing.B) {
 	cfg := &Config{Override: true, Detectors: []string{env.TypeStr, gcp.TypeStr}}
 	benchmarkConsumeLogs(b, cfg)
 }
+
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[component.ID]*internal.ResourceProvider{}}
+
+	md := &MockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("k8s.node.label", "old")
+	md.On("Detect").Return(res1, nil).Once()
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("k8s.node.label", "new")
+	md.On("Detect").Return(res2, nil)
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(processor.CreateSettings, internal.DetectorConfig) (internal.Detector, error) {
+			return md, nil
+		}})
+
+	cfg := &Config{
+		Override:           true,
+		Detectors:          []string{"mock"},
+		RefreshInterval:    10 * time.Millisecond,
+		HTTPClientSettings: confighttp.HTTPClientSettings{Timeout: time.Second},
+	}
+	sink := new(consumertest.LogsSink)
+	rlp, err := factory.createLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rlp.Start(context.Background(), componenttest.NewNopHost()))
+
+	label := func() any {
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		require.NoError(t, rlp.ConsumeLogs(context.Background(), ld))
+		logs := sink.AllLogs()
+		return logs[len(logs)-1].ResourceLogs().At(0).Resource().Attributes().AsRaw()["k8s.node.label"]
+	}
+	assert.Equal(t, "old", label())
+	assert.Eventually(t, func() bool {
+		return label() == "new"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, rlp.Shutdown(context.Background()))
+}
@@ -7,7 +7,9 @@ package schemaprocessor // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
@@ -19,6 +21,8 @@ import (
 
 var processorCapabilities = consumer.Capabilities{MutatesData: true}
 
+var onceMetrics sync.Once
+
 // factory will store any of the precompiled schemas in future
 type factory struct{}
 
@@ -31,6 +35,11 @@ func newDefaultConfiguration() component.Config {
 }
 
 func NewFactory() processor.Factory {
+	onceMetrics.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(metricViews()...)
+	})
+
 	f := &factory{}
 	return processor.NewFactory(
 		metadata.Type,
@@ -59,6 +68,7 @@ func (f factory) createLogsProcessor(
 		transformer.processLogs,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
 
@@ -80,6 +90,7 @@ func (f factory) createMetricsProcessor(
 		transformer.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
 
@@ -101,5 +112,6 @@ func (f factory) createTracesProcessor(
 		transformer.processTraces,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
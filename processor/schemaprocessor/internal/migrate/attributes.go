@@ -20,6 +20,18 @@ type AttributeChangeSet struct {
 	rollback ast.AttributeMap
 }
 
+// AttributeConflictError is reported when an attribute can not be
+// migrated since the key it should have been renamed to, or kept as,
+// is already used by another migrated attribute.
+// The attribute is dropped from the resulting map.
+type AttributeConflictError struct {
+	Key string
+}
+
+func (e *AttributeConflictError) Error() string {
+	return fmt.Sprintf("value %q already exists", e.Key)
+}
+
 // AttributeChangeSetSlice allows for `AttributeChangeSet`
 // to be chained together as they are defined within the schema
 // and be applied sequentially to ensure deterministic behavior.
@@ -49,31 +61,34 @@ func (a *AttributeChangeSet) Rollback(attrs pcommon.Map) error {
 
 func (a *AttributeChangeSet) do(ss StateSelector, attrs pcommon.Map) (errs error) {
 	var (
-		updated = make(map[string]struct{})
-		results = pcommon.NewMap()
+		mappings map[string]string
+		updated  = make(map[string]struct{})
+		results  = pcommon.NewMap()
 	)
-	attrs.Range(func(k string, v pcommon.Value) bool {
-		var (
-			key     string
-			matched bool
-		)
-		switch ss {
-		case StateSelectorApply:
-			key, matched = a.updates[k]
-		case StateSelectorRollback:
-			key, matched = a.rollback[k]
+	switch ss {
+	case StateSelectorApply:
+		mappings = a.updates
+	case StateSelectorRollback:
+		mappings = a.rollback
+	}
+	// The renamed keys are collected beforehand so that conflicts
+	// are detected regardless of the order of the attributes.
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		if key, matched := mappings[k]; matched {
+			updated[key] = struct{}{}
 		}
-		if matched {
-			k, updated[key] = key, struct{}{}
-		} else {
+		return true
+	})
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if key, matched := mappings[k]; matched {
+			k = key
+		} else if _, overridden := updated[k]; overridden {
 			// TODO: Since the spec hasn't decided the behavior on what
 			//       should happen on a name conflict, this will assume
 			//       the rewrite has priority and will set it to the original
 			//       entry's value, not the existing value.
-			if _, overridden := updated[k]; overridden {
-				errs = multierr.Append(errs, fmt.Errorf("value %q already exists", k))
-				return true
-			}
+			errs = multierr.Append(errs, &AttributeConflictError{Key: k})
+			return true
 		}
 		v.CopyTo(results.PutEmpty(k))
 		return true
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/schema/v1.0/ast"
+	"go.uber.org/multierr"
+)
+
+// MultiConditionalAttributeSet is a `ConditionalAttributeSet` that is checked
+// against several fields at once, such as the span and event name of a span event.
+// The changes are only applied when every field satisfies its conditions,
+// a field without any conditions matches all values.
+type MultiConditionalAttributeSet struct {
+	on    map[string]map[string]struct{}
+	attrs *AttributeChangeSet
+}
+
+type MultiConditionalAttributeSetSlice []*MultiConditionalAttributeSet
+
+func NewMultiConditionalAttributeSet(mappings ast.AttributeMap, matches map[string][]string) *MultiConditionalAttributeSet {
+	on := make(map[string]map[string]struct{}, len(matches))
+	for field, values := range matches {
+		set := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			set[v] = struct{}{}
+		}
+		on[field] = set
+	}
+	return &MultiConditionalAttributeSet{
+		on:    on,
+		attrs: NewAttributeChangeSet(mappings),
+	}
+}
+
+func (ca *MultiConditionalAttributeSet) Apply(attrs pcommon.Map, values map[string]string) (errs error) {
+	if ca.check(values) {
+		errs = ca.attrs.Apply(attrs)
+	}
+	return errs
+}
+
+func (ca *MultiConditionalAttributeSet) Rollback(attrs pcommon.Map, values map[string]string) (errs error) {
+	if ca.check(values) {
+		errs = ca.attrs.Rollback(attrs)
+	}
+	return errs
+}
+
+func (ca *MultiConditionalAttributeSet) check(values map[string]string) bool {
+	for field, set := range ca.on {
+		if len(set) == 0 {
+			continue
+		}
+		if _, ok := set[values[field]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func NewMultiConditionalAttributeSetSlice(conditions ...*MultiConditionalAttributeSet) *MultiConditionalAttributeSetSlice {
+	values := new(MultiConditionalAttributeSetSlice)
+	for _, c := range conditions {
+		(*values) = append((*values), c)
+	}
+	return values
+}
+
+func (slice *MultiConditionalAttributeSetSlice) Apply(attrs pcommon.Map, values map[string]string) error {
+	return slice.do(StateSelectorApply, attrs, values)
+}
+
+func (slice *MultiConditionalAttributeSetSlice) Rollback(attrs pcommon.Map, values map[string]string) error {
+	return slice.do(StateSelectorRollback, attrs, values)
+}
+
+func (slice *MultiConditionalAttributeSetSlice) do(ss StateSelector, attrs pcommon.Map, values map[string]string) (errs error) {
+	for i := 0; i < len((*slice)); i++ {
+		switch ss {
+		case StateSelectorApply:
+			errs = multierr.Append(errs, (*slice)[i].Apply(attrs, values))
+		case StateSelectorRollback:
+			errs = multierr.Append(errs, (*slice)[len((*slice))-i-1].Rollback(attrs, values))
+		}
+	}
+	return errs
+}
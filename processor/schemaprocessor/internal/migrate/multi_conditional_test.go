@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMultiConditionalAttributeSetApply(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		cond   *MultiConditionalAttributeSet
+		check  map[string]string
+		attr   pcommon.Map
+		expect pcommon.Map
+	}{
+		{
+			name: "No conditions set, applies to all",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": nil, "event.name": nil},
+			),
+			check: map[string]string{"span.name": "database operation", "event.name": "exception"},
+			attr: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("application.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "All conditions matched",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": {"database operation"}, "event.name": {"exception"}},
+			),
+			check: map[string]string{"span.name": "database operation", "event.name": "exception"},
+			attr: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("application.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "Only one condition matched",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": {"database operation"}, "event.name": {"exception"}},
+			),
+			check: map[string]string{"span.name": "database operation", "event.name": "retry"},
+			attr: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "Unconditioned field ignored",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": nil, "event.name": {"exception"}},
+			),
+			check: map[string]string{"span.name": "database operation", "event.name": "exception"},
+			attr: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("application.version", "v0.0.0")
+			}),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, tc.cond.Apply(tc.attr, tc.check))
+			assert.Equal(t, tc.expect.AsRaw(), tc.attr.AsRaw(), "Must match the expected value")
+		})
+	}
+}
+
+func TestMultiConditionalAttributeSetSliceRollback(t *testing.T) {
+	t.Parallel()
+
+	slice := NewMultiConditionalAttributeSetSlice(
+		NewMultiConditionalAttributeSet(
+			map[string]string{"service.version": "application.version"},
+			map[string][]string{"event.name": {"exception"}},
+		),
+		NewMultiConditionalAttributeSet(
+			map[string]string{"application.version": "app.version"},
+			map[string][]string{"event.name": {"exception"}},
+		),
+	)
+
+	attrs := testHelperBuildMap(func(m pcommon.Map) {
+		m.PutStr("service.version", "v0.0.0")
+	})
+	check := map[string]string{"event.name": "exception"}
+
+	assert.NoError(t, slice.Apply(attrs, check))
+	assert.Equal(t, map[string]any{"app.version": "v0.0.0"}, attrs.AsRaw())
+
+	assert.NoError(t, slice.Rollback(attrs, check))
+	assert.Equal(t, map[string]any{"service.version": "v0.0.0"}, attrs.AsRaw())
+}
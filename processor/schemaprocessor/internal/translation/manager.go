@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+var errNoProvider = errors.New("no schema provider has been set")
+
+// ErrTranslationPending is returned while the schema file needed
+// by a translation is being retrieved.
+var ErrTranslationPending = errors.New("schema file is being retrieved")
+
+const (
+	// retryInterval is how long a schema file that could not be
+	// retrieved is left alone before it is requested again.
+	retryInterval = time.Minute
+
+	// maxCacheEntries is the number of schema files cached, the least
+	// recently used one being evicted to cache another one.
+	maxCacheEntries = 64
+)
+
+// Manager resolves the translation for the schema URL published with a signal,
+// retrieving and caching the schema files needed to reach the targets.
+type Manager interface {
+	// RequestTranslation returns the translation to the target of the schema URL's family,
+	// or a translation that leaves the signal unchanged when the family has no target.
+	// It never waits for the schema file to be retrieved, and returns ErrTranslationPending
+	// until it is.
+	RequestTranslation(ctx context.Context, schemaURL string) (Translation, error)
+
+	// SetProviders sets the providers used to retrieve schema files,
+	// they are tried in order until one succeeds.
+	SetProviders(providers ...Provider)
+
+	// Shutdown cancels the retrievals in progress and waits for them to return.
+	Shutdown()
+}
+
+type cacheEntry struct {
+	fileURL   string
+	elem      *list.Element
+	ready     chan struct{}
+	tr        *translator
+	err       error
+	retrieved time.Time
+}
+
+type manager struct {
+	log *zap.Logger
+
+	// targets maps a schema family to its target schema URL
+	targets map[string]string
+
+	rw         sync.RWMutex
+	providers  []Provider
+	cache      map[string]*cacheEntry
+	lru        *list.List
+	maxEntries int
+
+	// ctx is cancelled on shutdown to stop the retrievals in progress
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetching sync.WaitGroup
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager translating signals to the given targets.
+func NewManager(targets []string, log *zap.Logger) (Manager, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &manager{
+		log:        log,
+		targets:    make(map[string]string, len(targets)),
+		cache:      make(map[string]*cacheEntry),
+		lru:        list.New(),
+		maxEntries: maxCacheEntries,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	for _, target := range targets {
+		family, _, err := GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = target
+	}
+	return m, nil
+}
+
+func (m *manager) SetProviders(providers ...Provider) {
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	m.providers = providers
+}
+
+func (m *manager) Shutdown() {
+	m.cancel()
+	m.fetching.Wait()
+}
+
+func (m *manager) RequestTranslation(_ context.Context, schemaURL string) (Translation, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	targetSchemaURL, ok := m.targets[family]
+	if !ok {
+		return nopTranslation{}, nil
+	}
+	_, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// A schema file defines every version up to its own,
+	// so the newer of both versions is needed in either direction.
+	fileURL := targetSchemaURL
+	if version.GreaterThan(target) {
+		fileURL = schemaURL
+	}
+	tr, err := m.lookup(targetSchemaURL, fileURL)
+	if err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// lookup returns the cached translator built from the schema file, starting
+// its retrieval in the background when it isn't cached or the last attempt
+// failed longer than retryInterval ago.
+func (m *manager) lookup(targetSchemaURL, fileURL string) (*translator, error) {
+	m.rw.Lock()
+	entry, ok := m.cache[fileURL]
+	if ok && !entry.expired() {
+		m.lru.MoveToFront(entry.elem)
+	} else {
+		if len(m.providers) == 0 {
+			m.rw.Unlock()
+			return nil, errNoProvider
+		}
+		if ok {
+			m.evict(entry)
+		}
+		entry = &cacheEntry{fileURL: fileURL, ready: make(chan struct{})}
+		entry.elem = m.lru.PushFront(entry)
+		m.cache[fileURL] = entry
+		for m.lru.Len() > m.maxEntries {
+			m.evict(m.lru.Back().Value.(*cacheEntry))
+		}
+		m.fetching.Add(1)
+		go m.fetch(entry, m.providers, targetSchemaURL)
+	}
+	m.rw.Unlock()
+
+	select {
+	case <-entry.ready:
+		return entry.tr, entry.err
+	default:
+		return nil, ErrTranslationPending
+	}
+}
+
+// fetch retrieves the schema file of a cache entry, and marks it as ready.
+func (m *manager) fetch(entry *cacheEntry, providers []Provider, targetSchemaURL string) {
+	defer m.fetching.Done()
+	entry.tr, entry.err = m.retrieve(m.ctx, providers, targetSchemaURL, entry.fileURL)
+	entry.retrieved = time.Now()
+	close(entry.ready)
+}
+
+// evict removes an entry from the cache, a retrieval in progress
+// for it still completing without being cached.
+func (m *manager) evict(entry *cacheEntry) {
+	m.lru.Remove(entry.elem)
+	delete(m.cache, entry.fileURL)
+}
+
+func (m *manager) retrieve(ctx context.Context, providers []Provider, targetSchemaURL, fileURL string) (*translator, error) {
+	if len(providers) == 0 {
+		return nil, errNoProvider
+	}
+	var errs error
+	for _, p := range providers {
+		content, err := p.Lookup(ctx, fileURL)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		tr, err := newTranslatorFromReader(targetSchemaURL, content)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		m.log.Debug("Retrieved schema file", zap.String("schema-url", fileURL))
+		return tr, nil
+	}
+	m.log.Warn("Unable to retrieve schema file", zap.String("schema-url", fileURL), zap.Error(errs))
+	return nil, fmt.Errorf("failed to retrieve %q: %w", fileURL, errs)
+}
+
+// expired reports if a failed retrieval should be attempted again,
+// entries still being retrieved or retrieved successfully never expire.
+func (e *cacheEntry) expired() bool {
+	select {
+	case <-e.ready:
+		return e.err != nil && time.Since(e.retrieved) > retryInterval
+	default:
+		return false
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+// newTestSchemaServer serves the schema files within testdata
+// and counts the requests that were made.
+func newTestSchemaServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	requests := new(atomic.Int64)
+	files := http.FileServer(http.Dir("testdata"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func newTestManager(t *testing.T, targets ...string) Manager {
+	m, err := NewManager(targets, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	t.Cleanup(m.Shutdown)
+	return m
+}
+
+// awaitTranslation requests a translation until its schema file is retrieved.
+func awaitTranslation(t *testing.T, m Manager, schemaURL string) (tr Translation, err error) {
+	require.Eventually(t, func() bool {
+		tr, err = m.RequestTranslation(context.Background(), schemaURL)
+		return !errors.Is(err, ErrTranslationPending)
+	}, 5*time.Second, 10*time.Millisecond, "Must retrieve the schema file")
+	return tr, err
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestSchemaServer(t)
+	m := newTestManager(t, srv.URL+"/schemas/1.1.0")
+	m.SetProviders(NewHTTPProvider(srv.Client()))
+
+	for _, tc := range []struct {
+		name      string
+		schemaURL string
+		expect    map[string]any
+	}{
+		{
+			name:      "upgrade",
+			schemaURL: srv.URL + "/schemas/1.0.0",
+			expect:    map[string]any{"service.name": "checkout", "net.peer.ip": "10.0.0.1"},
+		},
+		{
+			name:      "downgrade",
+			schemaURL: srv.URL + "/schemas/1.2.0",
+			expect:    map[string]any{"service.name": "checkout", "net.peer.ip": "10.0.0.1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr, err := awaitTranslation(t, m, tc.schemaURL)
+			require.NoError(t, err, "Must not error when requesting translation")
+
+			rl := plog.NewResourceLogs()
+			rl.SetSchemaUrl(tc.schemaURL)
+			if tc.name == "upgrade" {
+				rl.Resource().Attributes().PutStr("service_name", "checkout")
+				rl.Resource().Attributes().PutStr("net.peer.ip", "10.0.0.1")
+			} else {
+				rl.Resource().Attributes().PutStr("service.name", "checkout")
+				rl.Resource().Attributes().PutStr("net.sock.peer.addr", "10.0.0.1")
+			}
+
+			assert.NoError(t, tr.ApplyAllResourceChanges(rl, tc.schemaURL))
+			assert.Equal(t, tc.expect, rl.Resource().Attributes().AsRaw())
+			assert.Equal(t, srv.URL+"/schemas/1.1.0", rl.SchemaUrl())
+		})
+	}
+
+	// the same schema files are served from the cache
+	_, err := m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.0.0")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load(), "Must only retrieve the target and the newer schema file once")
+}
+
+func TestManagerNoTarget(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, "https://opentelemetry.io/schemas/1.9.0")
+
+	tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, nopTranslation{}, tr, "Must not translate families without a target")
+
+	_, err = m.RequestTranslation(context.Background(), "invalid schema url")
+	assert.Error(t, err, "Must error with an invalid schema URL")
+}
+
+func TestManagerRetrievalFailures(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestSchemaServer(t)
+	m := newTestManager(t, srv.URL+"/schemas/1.1.0")
+
+	_, err := m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.0.0")
+	assert.ErrorIs(t, err, errNoProvider, "Must error without providers")
+
+	m.SetProviders(NewHTTPProvider(srv.Client()))
+	_, err = m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.3.0")
+	assert.ErrorIs(t, err, ErrTranslationPending, "Must not wait for the schema file to be retrieved")
+	_, err = awaitTranslation(t, m, srv.URL+"/schemas/1.3.0")
+	assert.Error(t, err, "Must error when the schema file does not exist")
+	for i := 0; i < 2; i++ {
+		_, err = m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.3.0")
+		assert.Error(t, err, "Must error when the schema file does not exist")
+		assert.NotErrorIs(t, err, ErrTranslationPending)
+	}
+	assert.EqualValues(t, 1, requests.Load(), "Must not retrieve a failed schema file before the retry interval")
+
+	_, err = awaitTranslation(t, m, srv.URL+"/schemas/1.0.0")
+	assert.NoError(t, err, "Must retrieve the schema file once a provider is set")
+}
+
+func TestManagerCacheBounded(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestSchemaServer(t)
+	m := newTestManager(t, srv.URL+"/schemas/1.1.0")
+	m.(*manager).maxEntries = 1
+	m.SetProviders(NewHTTPProvider(srv.Client()))
+
+	for _, schemaURL := range []string{"/schemas/1.0.0", "/schemas/1.2.0", "/schemas/1.0.0"} {
+		_, err := awaitTranslation(t, m, srv.URL+schemaURL)
+		require.NoError(t, err)
+	}
+	assert.Len(t, m.(*manager).cache, 1, "Must not cache more schema files than the maximum")
+	assert.EqualValues(t, 3, requests.Load(), "Must retrieve an evicted schema file again")
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestSchemaServer(t)
+	m := newTestManager(t, srv.URL+"/schemas/1.2.0")
+	m.SetProviders(NewHTTPProvider(srv.Client()))
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		_, err := m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.0.0")
+		if errors.Is(err, ErrTranslationPending) {
+			return nil
+		}
+		return err
+	})
+	_, err := awaitTranslation(t, m, srv.URL+"/schemas/1.0.0")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, requests.Load(), "Must share the retrieval of the schema file")
+}
@@ -12,17 +12,23 @@ import (
 // RevisionV1 represents all changes that are to be
 // applied to a signal at a given version.
 type RevisionV1 struct {
-	ver              *Version
-	all              *migrate.AttributeChangeSetSlice
-	resource         *migrate.AttributeChangeSetSlice
-	spans            *migrate.ConditionalAttributeSetSlice
-	eventNames       *migrate.SignalNameChangeSlice
-	eventAttrsOnSpan *migrate.ConditionalAttributeSetSlice
-	eventAttrsOnName *migrate.ConditionalAttributeSetSlice
-	metricsAttrs     *migrate.ConditionalAttributeSetSlice
-	metricNames      *migrate.SignalNameChangeSlice
+	ver          *Version
+	all          *migrate.AttributeChangeSetSlice
+	resource     *migrate.AttributeChangeSetSlice
+	spans        *migrate.ConditionalAttributeSetSlice
+	eventNames   *migrate.SignalNameChangeSlice
+	eventAttrs   *migrate.MultiConditionalAttributeSetSlice
+	logs         *migrate.AttributeChangeSetSlice
+	metricsAttrs *migrate.ConditionalAttributeSetSlice
+	metricNames  *migrate.SignalNameChangeSlice
 }
 
+// The fields a span event attribute change is conditioned on.
+const (
+	conditionSpanName  = "span.name"
+	conditionEventName = "event.name"
+)
+
 // NewRevision processes the VersionDef and assigns the version to this revision
 // to allow sorting within a slice.
 // Since VersionDef uses custom types for various definitions, it isn't possible
@@ -30,15 +36,15 @@ type RevisionV1 struct {
 // Generics would be handy here.
 func NewRevision(ver *Version, def ast.VersionDef) *RevisionV1 {
 	return &RevisionV1{
-		ver:              ver,
-		all:              newAttributeChangeSetSliceFromChanges(def.All),
-		resource:         newAttributeChangeSetSliceFromChanges(def.Resources),
-		spans:            newSpanConditionalAttributeSlice(def.Spans),
-		eventNames:       newSpanEventSignalSlice(def.SpanEvents),
-		eventAttrsOnSpan: newSpanEventConditionalSpans(def.SpanEvents),
-		eventAttrsOnName: newSpanEventConditionalNames(def.SpanEvents),
-		metricsAttrs:     newMetricConditionalSlice(def.Metrics),
-		metricNames:      newMetricNameSignalSlice(def.Metrics),
+		ver:          ver,
+		all:          newAttributeChangeSetSliceFromChanges(def.All),
+		resource:     newAttributeChangeSetSliceFromChanges(def.Resources),
+		spans:        newSpanConditionalAttributeSlice(def.Spans),
+		eventNames:   newSpanEventSignalSlice(def.SpanEvents),
+		eventAttrs:   newSpanEventConditionalAttributes(def.SpanEvents),
+		logs:         newLogsAttributeChangeSetSlice(def.Logs),
+		metricsAttrs: newMetricConditionalSlice(def.Metrics),
+		metricNames:  newMetricNameSignalSlice(def.Metrics),
 	}
 }
 
@@ -75,24 +81,34 @@ func newSpanEventSignalSlice(events ast.SpanEvents) *migrate.SignalNameChangeSli
 	return migrate.NewSignalNameChangeSlice(values...)
 }
 
-func newSpanEventConditionalSpans(events ast.SpanEvents) *migrate.ConditionalAttributeSetSlice {
-	values := make([]*migrate.ConditionalAttributeSet, 0, 10)
+func newSpanEventConditionalAttributes(events ast.SpanEvents) *migrate.MultiConditionalAttributeSetSlice {
+	values := make([]*migrate.MultiConditionalAttributeSet, 0, 10)
 	for _, ch := range events.Changes {
 		if rename := ch.RenameAttributes; rename != nil {
-			values = append(values, migrate.NewConditionalAttributeSet(rename.AttributeMap, rename.ApplyToSpans...))
+			on := map[string][]string{
+				conditionSpanName:  make([]string, 0, len(rename.ApplyToSpans)),
+				conditionEventName: make([]string, 0, len(rename.ApplyToEvents)),
+			}
+			for _, name := range rename.ApplyToSpans {
+				on[conditionSpanName] = append(on[conditionSpanName], string(name))
+			}
+			for _, name := range rename.ApplyToEvents {
+				on[conditionEventName] = append(on[conditionEventName], string(name))
+			}
+			values = append(values, migrate.NewMultiConditionalAttributeSet(rename.AttributeMap, on))
 		}
 	}
-	return migrate.NewConditionalAttributeSetSlice(values...)
+	return migrate.NewMultiConditionalAttributeSetSlice(values...)
 }
 
-func newSpanEventConditionalNames(events ast.SpanEvents) *migrate.ConditionalAttributeSetSlice {
-	values := make([]*migrate.ConditionalAttributeSet, 0, 10)
-	for _, ch := range events.Changes {
-		if rename := ch.RenameAttributes; rename != nil {
-			values = append(values, migrate.NewConditionalAttributeSet(rename.AttributeMap, rename.ApplyToEvents...))
+func newLogsAttributeChangeSetSlice(logs ast.Logs) *migrate.AttributeChangeSetSlice {
+	values := make([]*migrate.AttributeChangeSet, 0, 10)
+	for _, ch := range logs.Changes {
+		if renamed := ch.RenameAttributes; renamed != nil {
+			values = append(values, migrate.NewAttributeChangeSet(renamed.AttributeMap))
 		}
 	}
-	return migrate.NewConditionalAttributeSetSlice(values...)
+	return migrate.NewAttributeChangeSetSlice(values...)
 }
 
 func newMetricConditionalSlice(metrics ast.Metrics) *migrate.ConditionalAttributeSetSlice {
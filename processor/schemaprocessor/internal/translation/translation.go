@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// Translation converts signals published with a schema URL of a family
+// to the target version of that family, upgrading older versions and
+// downgrading newer ones as described by the schema file.
+//
+// Attributes that can not be mapped to the target version are reported
+// as `migrate.AttributeConflictError` within the returned error.
+type Translation interface {
+	// SupportedVersion checks if the provided version is defined
+	// as part of the schema file used by the translation.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges translates the resource attributes
+	// and updates its schema URL to the target.
+	ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) error
+
+	// ApplyScopeSpanChanges translates all the spans and span events within the scope.
+	ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error
+
+	// ApplyScopeLogChanges translates all the log records within the scope.
+	ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error
+
+	// ApplyScopeMetricChanges translates all the metrics within the scope.
+	ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error
+}
+
+// nopTranslation is used for signals whose schema family has no target,
+// leaving them unchanged.
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+func (nopTranslation) SupportedVersion(_ *Version) bool {
+	return false
+}
+
+func (nopTranslation) ApplyAllResourceChanges(_ alias.Resource, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ string) error {
+	return nil
+}
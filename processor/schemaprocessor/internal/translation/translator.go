@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	encoder "go.opentelemetry.io/otel/schema/v1.0"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported schema version")
+
+// translator applies the revisions of a schema file to reach the target version.
+// Revisions are applied in ascending order to upgrade a signal
+// and rolled back in descending order to downgrade it.
+type translator struct {
+	targetSchemaURL string
+	target          *Version
+
+	indexes   map[Version]int
+	revisions []*RevisionV1
+}
+
+var _ Translation = (*translator)(nil)
+
+// newTranslatorFromReader parses the schema file content, which must define
+// the target version of the translation.
+func newTranslatorFromReader(targetSchemaURL string, content io.Reader) (*translator, error) {
+	_, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	def, err := encoder.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &translator{
+		targetSchemaURL: targetSchemaURL,
+		target:          target,
+		indexes:         make(map[Version]int, len(def.Versions)),
+		revisions:       make([]*RevisionV1, 0, len(def.Versions)),
+	}
+	for v, rev := range def.Versions {
+		ver, err := NewVersion(string(v))
+		if err != nil {
+			return nil, err
+		}
+		t.revisions = append(t.revisions, NewRevision(ver, rev))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].ver.LessThan(t.revisions[j].ver)
+	})
+	for i, rev := range t.revisions {
+		t.indexes[*rev.ver] = i
+	}
+
+	if !t.SupportedVersion(target) {
+		return nil, fmt.Errorf("target %q is not defined by the schema file: %w", targetSchemaURL, ErrUnsupportedVersion)
+	}
+	return t, nil
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	_, ok := t.indexes[*v]
+	return ok
+}
+
+// revisionsFrom returns the revisions to translate a signal published
+// with the schema URL to the target, and whether they are rolled back.
+func (t *translator) revisionsFrom(schemaURL string) ([]*RevisionV1, bool, error) {
+	_, ver, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, false, err
+	}
+	from, ok := t.indexes[*ver]
+	if !ok {
+		return nil, false, fmt.Errorf("%q: %w", schemaURL, ErrUnsupportedVersion)
+	}
+	to := t.indexes[*t.target]
+
+	switch {
+	case from < to:
+		return t.revisions[from+1 : to+1], false, nil
+	case from > to:
+		revs := make([]*RevisionV1, 0, from-to)
+		for i := from; i > to; i-- {
+			revs = append(revs, t.revisions[i])
+		}
+		return revs, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func (t *translator) ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) (errs error) {
+	revs, rollback, err := t.revisionsFrom(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	attrs := in.Resource().Attributes()
+	for _, rev := range revs {
+		if rollback {
+			errs = multierr.Append(errs, rev.resource.Rollback(attrs))
+			errs = multierr.Append(errs, rev.all.Rollback(attrs))
+			continue
+		}
+		errs = multierr.Append(errs, rev.all.Apply(attrs))
+		errs = multierr.Append(errs, rev.resource.Apply(attrs))
+	}
+	in.SetSchemaUrl(t.targetSchemaURL)
+	return errs
+}
+
+func (t *translator) ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) (errs error) {
+	revs, rollback, err := t.revisionsFrom(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.Spans().Len(); i++ {
+			span := in.Spans().At(i)
+			if rollback {
+				errs = multierr.Append(errs, rev.rollbackSpan(span))
+				continue
+			}
+			errs = multierr.Append(errs, rev.applySpan(span))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func (t *translator) ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) (errs error) {
+	revs, rollback, err := t.revisionsFrom(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.LogRecords().Len(); i++ {
+			attrs := in.LogRecords().At(i).Attributes()
+			if rollback {
+				errs = multierr.Append(errs, rev.logs.Rollback(attrs))
+				errs = multierr.Append(errs, rev.all.Rollback(attrs))
+				continue
+			}
+			errs = multierr.Append(errs, rev.all.Apply(attrs))
+			errs = multierr.Append(errs, rev.logs.Apply(attrs))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func (t *translator) ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) (errs error) {
+	revs, rollback, err := t.revisionsFrom(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.Metrics().Len(); i++ {
+			metric := in.Metrics().At(i)
+			if rollback {
+				errs = multierr.Append(errs, rev.rollbackMetric(metric))
+				continue
+			}
+			errs = multierr.Append(errs, rev.applyMetric(metric))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+// Attribute changes conditioned on a name are checked against the name
+// the signal has in the older version, so names are updated last when
+// applying a revision and first when rolling it back.
+
+func (rev *RevisionV1) applySpan(span ptrace.Span) (errs error) {
+	errs = multierr.Append(errs, rev.all.Apply(span.Attributes()))
+	errs = multierr.Append(errs, rev.spans.Apply(span.Attributes(), span.Name()))
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		errs = multierr.Append(errs, rev.all.Apply(event.Attributes()))
+		errs = multierr.Append(errs, rev.eventAttrs.Apply(event.Attributes(), map[string]string{
+			conditionSpanName:  span.Name(),
+			conditionEventName: event.Name(),
+		}))
+		rev.eventNames.Apply(event)
+	}
+	return errs
+}
+
+func (rev *RevisionV1) rollbackSpan(span ptrace.Span) (errs error) {
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		rev.eventNames.Rollback(event)
+		errs = multierr.Append(errs, rev.eventAttrs.Rollback(event.Attributes(), map[string]string{
+			conditionSpanName:  span.Name(),
+			conditionEventName: event.Name(),
+		}))
+		errs = multierr.Append(errs, rev.all.Rollback(event.Attributes()))
+	}
+	errs = multierr.Append(errs, rev.spans.Rollback(span.Attributes(), span.Name()))
+	errs = multierr.Append(errs, rev.all.Rollback(span.Attributes()))
+	return errs
+}
+
+func (rev *RevisionV1) applyMetric(metric pmetric.Metric) (errs error) {
+	forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+		errs = multierr.Append(errs, rev.all.Apply(attrs))
+		errs = multierr.Append(errs, rev.metricsAttrs.Apply(attrs, metric.Name()))
+	})
+	rev.metricNames.Apply(metric)
+	return errs
+}
+
+func (rev *RevisionV1) rollbackMetric(metric pmetric.Metric) (errs error) {
+	rev.metricNames.Rollback(metric)
+	forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+		errs = multierr.Append(errs, rev.metricsAttrs.Rollback(attrs, metric.Name()))
+		errs = multierr.Append(errs, rev.all.Rollback(attrs))
+	})
+	return errs
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
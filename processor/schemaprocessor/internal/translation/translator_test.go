@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+)
+
+func newTestTranslator(t *testing.T, targetSchemaURL, file string) *translator {
+	f, err := os.Open(filepath.Join("testdata", "schemas", file))
+	require.NoError(t, err, "Must be able to open the schema file")
+	t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+	tr, err := newTranslatorFromReader(targetSchemaURL, f)
+	require.NoError(t, err, "Must not error when creating translator")
+	return tr
+}
+
+func TestNewTranslatorFromReader(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslator(t, "https://example.com/schemas/1.1.0", "1.2.0")
+	for _, v := range []*Version{{1, 0, 0}, {1, 1, 0}, {1, 2, 0}} {
+		assert.True(t, tr.SupportedVersion(v), "Must support version %s", v)
+	}
+	assert.False(t, tr.SupportedVersion(&Version{1, 3, 0}), "Must not support undefined version")
+
+	f, err := os.Open(filepath.Join("testdata", "schemas", "1.1.0"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = newTranslatorFromReader("https://example.com/schemas/1.2.0", f)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion, "Must error when the target is not defined")
+}
+
+func TestTranslatorResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		target string
+		file   string
+		in     string
+		attrs  map[string]any
+		expect map[string]any
+	}{
+		{
+			name:   "upgrade",
+			target: "https://example.com/schemas/1.2.0",
+			file:   "1.2.0",
+			in:     "https://example.com/schemas/1.0.0",
+			attrs:  map[string]any{"service_name": "checkout", "net.peer.ip": "10.0.0.1"},
+			expect: map[string]any{"service.name": "checkout", "net.sock.peer.addr": "10.0.0.1"},
+		},
+		{
+			name:   "downgrade",
+			target: "https://example.com/schemas/1.0.0",
+			file:   "1.2.0",
+			in:     "https://example.com/schemas/1.2.0",
+			attrs:  map[string]any{"service.name": "checkout", "net.sock.peer.addr": "10.0.0.1"},
+			expect: map[string]any{"service_name": "checkout", "net.peer.ip": "10.0.0.1"},
+		},
+		{
+			name:   "same version",
+			target: "https://example.com/schemas/1.1.0",
+			file:   "1.1.0",
+			in:     "https://example.com/schemas/1.1.0",
+			attrs:  map[string]any{"service_name": "checkout"},
+			expect: map[string]any{"service_name": "checkout"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := newTestTranslator(t, tc.target, tc.file)
+
+			rs := ptrace.NewResourceSpans()
+			rs.SetSchemaUrl(tc.in)
+			require.NoError(t, rs.Resource().Attributes().FromRaw(tc.attrs))
+
+			assert.NoError(t, tr.ApplyAllResourceChanges(rs, tc.in))
+			assert.Equal(t, tc.expect, rs.Resource().Attributes().AsRaw())
+			assert.Equal(t, tc.target, rs.SchemaUrl(), "Must update the schema URL to the target")
+		})
+	}
+}
+
+func TestTranslatorSpanChanges(t *testing.T) {
+	t.Parallel()
+
+	newSpans := func(schemaURL string, span map[string]any, eventName string, event map[string]any) ptrace.ScopeSpans {
+		ss := ptrace.NewScopeSpans()
+		ss.SetSchemaUrl(schemaURL)
+		s := ss.Spans().AppendEmpty()
+		s.SetName("checkout")
+		require.NoError(t, s.Attributes().FromRaw(span))
+		e := s.Events().AppendEmpty()
+		e.SetName(eventName)
+		require.NoError(t, e.Attributes().FromRaw(event))
+		return ss
+	}
+
+	older := newSpans("https://example.com/schemas/1.1.0",
+		map[string]any{"http.method": "GET", "net.peer.ip": "10.0.0.1"},
+		"retry", map[string]any{"retry.count": int64(2)},
+	)
+	newer := newSpans("https://example.com/schemas/1.2.0",
+		map[string]any{"http.request.method": "GET", "net.sock.peer.addr": "10.0.0.1"},
+		"retry", map[string]any{"retry.attempt": int64(2)},
+	)
+
+	upgraded := ptrace.NewScopeSpans()
+	older.CopyTo(upgraded)
+	tr := newTestTranslator(t, "https://example.com/schemas/1.2.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeSpanChanges(upgraded, "https://example.com/schemas/1.1.0"))
+	assertSpansEqual(t, newer, upgraded, "Must upgrade the spans")
+
+	downgraded := ptrace.NewScopeSpans()
+	newer.CopyTo(downgraded)
+	tr = newTestTranslator(t, "https://example.com/schemas/1.1.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeSpanChanges(downgraded, "https://example.com/schemas/1.2.0"))
+	assertSpansEqual(t, older, downgraded, "Must downgrade the spans")
+
+	// the event attribute change only applies to the retry event
+	// of the checkout span, the event rename applies to all of them
+	ss := ptrace.NewScopeSpans()
+	s := ss.Spans().AppendEmpty()
+	s.SetName("payment")
+	e := s.Events().AppendEmpty()
+	e.SetName("retry")
+	e.Attributes().PutInt("retry.count", 1)
+	e = s.Events().AppendEmpty()
+	e.SetName("exception.raised")
+
+	tr = newTestTranslator(t, "https://example.com/schemas/1.2.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeSpanChanges(ss, "https://example.com/schemas/1.1.0"))
+	assert.Equal(t, map[string]any{"retry.count": int64(1)}, s.Events().At(0).Attributes().AsRaw())
+	assert.Equal(t, "exception", s.Events().At(1).Name())
+	assert.Empty(t, ss.SchemaUrl(), "Must not set the scope schema URL when it was unset")
+}
+
+func assertSpansEqual(t *testing.T, expect, actual ptrace.ScopeSpans, msg string) {
+	assert.Equal(t, expect.SchemaUrl(), actual.SchemaUrl(), msg)
+	require.Equal(t, expect.Spans().Len(), actual.Spans().Len(), msg)
+	for i := 0; i < expect.Spans().Len(); i++ {
+		es, as := expect.Spans().At(i), actual.Spans().At(i)
+		assert.Equal(t, es.Attributes().AsRaw(), as.Attributes().AsRaw(), msg)
+		require.Equal(t, es.Events().Len(), as.Events().Len(), msg)
+		for j := 0; j < es.Events().Len(); j++ {
+			assert.Equal(t, es.Events().At(j).Name(), as.Events().At(j).Name(), msg)
+			assert.Equal(t, es.Events().At(j).Attributes().AsRaw(), as.Events().At(j).Attributes().AsRaw(), msg)
+		}
+	}
+}
+
+func TestTranslatorMetricChanges(t *testing.T) {
+	t.Parallel()
+
+	sm := pmetric.NewScopeMetrics()
+	sm.SetSchemaUrl("https://example.com/schemas/1.1.0")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("http.server.duration")
+	m.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("http.method", "GET")
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("process.runtime.uptime")
+	m.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("http.method", "GET")
+
+	tr := newTestTranslator(t, "https://example.com/schemas/1.2.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeMetricChanges(sm, "https://example.com/schemas/1.1.0"))
+
+	assert.Equal(t, "https://example.com/schemas/1.2.0", sm.SchemaUrl())
+	assert.Equal(t, "http.server.duration", sm.Metrics().At(0).Name())
+	assert.Equal(t, map[string]any{"http.request.method": "GET"}, sm.Metrics().At(0).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "process.uptime", sm.Metrics().At(1).Name())
+	assert.Equal(t, map[string]any{"http.method": "GET"}, sm.Metrics().At(1).Sum().DataPoints().At(0).Attributes().AsRaw())
+
+	tr = newTestTranslator(t, "https://example.com/schemas/1.1.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeMetricChanges(sm, "https://example.com/schemas/1.2.0"))
+
+	assert.Equal(t, "https://example.com/schemas/1.1.0", sm.SchemaUrl())
+	assert.Equal(t, map[string]any{"http.method": "GET"}, sm.Metrics().At(0).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "process.runtime.uptime", sm.Metrics().At(1).Name())
+}
+
+func TestTranslatorLogChanges(t *testing.T) {
+	t.Parallel()
+
+	sl := plog.NewScopeLogs()
+	require.NoError(t, sl.LogRecords().AppendEmpty().Attributes().FromRaw(map[string]any{
+		"log.severity": "warn",
+		"net.peer.ip":  "10.0.0.1",
+	}))
+
+	tr := newTestTranslator(t, "https://example.com/schemas/1.2.0", "1.2.0")
+	assert.NoError(t, tr.ApplyScopeLogChanges(sl, "https://example.com/schemas/1.0.0"))
+	assert.Equal(t, map[string]any{
+		"severity":           "warn",
+		"net.sock.peer.addr": "10.0.0.1",
+	}, sl.LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestTranslatorReportsUnmappedAttributes(t *testing.T) {
+	t.Parallel()
+
+	sl := plog.NewScopeLogs()
+	require.NoError(t, sl.LogRecords().AppendEmpty().Attributes().FromRaw(map[string]any{
+		"log.severity": "warn",
+		"severity":     "error",
+	}))
+
+	tr := newTestTranslator(t, "https://example.com/schemas/1.2.0", "1.2.0")
+	err := tr.ApplyScopeLogChanges(sl, "https://example.com/schemas/1.1.0")
+	require.Error(t, err)
+
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 1)
+	var conflict *migrate.AttributeConflictError
+	assert.True(t, errors.As(errs[0], &conflict), "Must report the attribute conflict")
+	assert.Len(t, sl.LogRecords().At(0).Attributes().AsRaw(), 1)
+}
+
+func TestTranslatorUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslator(t, "https://example.com/schemas/1.1.0", "1.1.0")
+	rl := plog.NewResourceLogs()
+	rl.SetSchemaUrl("https://example.com/schemas/1.0.1")
+
+	err := tr.ApplyAllResourceChanges(rl, rl.SchemaUrl())
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	assert.Equal(t, "https://example.com/schemas/1.0.1", rl.SchemaUrl(), "Must not update the schema URL")
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/metadata"
+)
+
+var (
+	mUnmappedAttributes  = stats.Int64("unmapped_attributes", "Number of attributes passed on untranslated because the schema of their signal was not available", stats.UnitDimensionless)
+	mAttributeConflicts  = stats.Int64("attribute_conflicts", "Number of attributes dropped because a translated attribute was renamed to their name", stats.UnitDimensionless)
+	mTranslationFailures = stats.Int64("translation_failures", "Number of resources and scopes passed on untranslated because their schema could not be resolved", stats.UnitDimensionless)
+)
+
+// metricViews returns the metrics views of the processor.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mUnmappedAttributes.Name()),
+			Measure:     mUnmappedAttributes,
+			Description: mUnmappedAttributes.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mAttributeConflicts.Name()),
+			Measure:     mAttributeConflicts,
+			Description: mAttributeConflicts.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(string(metadata.Type), mTranslationFailures.Name()),
+			Measure:     mTranslationFailures,
+			Description: mTranslationFailures.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -7,17 +7,24 @@ import (
 	"context"
 	"errors"
 
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
+	cfg     *Config
+	set     component.TelemetrySettings
 	log     *zap.Logger
+	manager translation.Manager
 }
 
 func newTransformer(
@@ -29,29 +36,202 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	m, err := translation.NewManager(cfg.Targets, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return &transformer{
+		cfg:     cfg,
+		set:     set.TelemetrySettings,
 		log:     set.Logger,
-		targets: cfg.Targets,
+		manager: m,
 	}, nil
 }
 
-func (t transformer) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.translate(ctx, resourceSchemaURL, func(tr translation.Translation) error {
+				return tr.ApplyAllResourceChanges(rLog, resourceSchemaURL)
+			}, rLog.Resource().Attributes().Len)
+		}
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			log := rLog.ScopeLogs().At(sl)
+			schemaURL := log.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			if schemaURL == "" {
+				continue
+			}
+			t.translate(ctx, schemaURL, func(tr translation.Translation) error {
+				return tr.ApplyScopeLogChanges(log, schemaURL)
+			}, func() int { return countLogAttributes(log) })
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.translate(ctx, resourceSchemaURL, func(tr translation.Translation) error {
+				return tr.ApplyAllResourceChanges(rMetric, resourceSchemaURL)
+			}, rMetric.Resource().Attributes().Len)
+		}
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			metric := rMetric.ScopeMetrics().At(sm)
+			schemaURL := metric.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			if schemaURL == "" {
+				continue
+			}
+			t.translate(ctx, schemaURL, func(tr translation.Translation) error {
+				return tr.ApplyScopeMetricChanges(metric, schemaURL)
+			}, func() int { return countMetricAttributes(metric) })
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rt := 0; rt < td.ResourceSpans().Len(); rt++ {
+		rTrace := td.ResourceSpans().At(rt)
+		resourceSchemaURL := rTrace.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.translate(ctx, resourceSchemaURL, func(tr translation.Translation) error {
+				return tr.ApplyAllResourceChanges(rTrace, resourceSchemaURL)
+			}, rTrace.Resource().Attributes().Len)
+		}
+		for ss := 0; ss < rTrace.ScopeSpans().Len(); ss++ {
+			span := rTrace.ScopeSpans().At(ss)
+			schemaURL := span.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			if schemaURL == "" {
+				continue
+			}
+			t.translate(ctx, schemaURL, func(tr translation.Translation) error {
+				return tr.ApplyScopeSpanChanges(span, schemaURL)
+			}, func() int { return countSpanAttributes(span) })
+		}
+	}
 	return td, nil
 }
 
-// start will load the remote file definition if it isn't already cached
-// and resolve the schema translation file
-func (t *transformer) start(_ context.Context, _ component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+// translate resolves the translation for the schema URL and applies it.
+// Signals that can not be translated are passed on unchanged, their attributes
+// being counted as unmapped, and attributes dropped because a translated
+// attribute was renamed to their name are counted as conflicts.
+func (t transformer) translate(ctx context.Context, schemaURL string, apply func(tr translation.Translation) error, attributes func() int) {
+	tr, err := t.manager.RequestTranslation(ctx, schemaURL)
+	if err != nil {
+		stats.Record(ctx, mUnmappedAttributes.M(int64(attributes())))
+		if errors.Is(err, translation.ErrTranslationPending) {
+			t.log.Debug("Passing on signal untranslated while its schema is retrieved", zap.String("schema-url", schemaURL))
+			return
+		}
+		stats.Record(ctx, mTranslationFailures.M(1))
+		t.log.Debug("Unable to translate signal", zap.String("schema-url", schemaURL), zap.Error(err))
+		return
+	}
+
+	var conflicts int64
+	for _, err := range multierr.Errors(apply(tr)) {
+		var conflict *migrate.AttributeConflictError
+		if errors.As(err, &conflict) {
+			conflicts++
+			t.log.Debug("Dropped conflicting attribute", zap.String("schema-url", schemaURL), zap.String("attribute", conflict.Key))
+			continue
+		}
+		// the signal is left untranslated when its version is not supported
+		stats.Record(ctx, mTranslationFailures.M(1), mUnmappedAttributes.M(int64(attributes())))
+		t.log.Debug("Unable to translate signal", zap.String("schema-url", schemaURL), zap.Error(err))
+	}
+	if conflicts > 0 {
+		stats.Record(ctx, mAttributeConflicts.M(conflicts))
+	}
+}
+
+// start creates the client used to retrieve the schema files
+// and prefetches the translations configured to be cached.
+func (t *transformer) start(ctx context.Context, host component.Host) error {
+	client, err := t.cfg.HTTPClientSettings.ToClient(host, t.set)
+	if err != nil {
+		return err
+	}
+	t.manager.SetProviders(translation.NewHTTPProvider(client))
+
+	for _, schemaURL := range t.cfg.Prefetch {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		if _, err := t.manager.RequestTranslation(ctx, schemaURL); err != nil && !errors.Is(err, translation.ErrTranslationPending) {
+			t.log.Warn("Unable to prefetch schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
+
+// shutdown stops the retrievals of schema files in progress.
+func (t *transformer) shutdown(context.Context) error {
+	t.manager.Shutdown()
+	return nil
+}
+
+func countLogAttributes(sl plog.ScopeLogs) int {
+	count := 0
+	for i := 0; i < sl.LogRecords().Len(); i++ {
+		count += sl.LogRecords().At(i).Attributes().Len()
+	}
+	return count
+}
+
+func countSpanAttributes(ss ptrace.ScopeSpans) int {
+	count := 0
+	for i := 0; i < ss.Spans().Len(); i++ {
+		span := ss.Spans().At(i)
+		count += span.Attributes().Len()
+		for j := 0; j < span.Events().Len(); j++ {
+			count += span.Events().At(j).Attributes().Len()
+		}
+	}
+	return count
+}
+
+func countMetricAttributes(sm pmetric.ScopeMetrics) int {
+	count := 0
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		m := sm.Metrics().At(i)
+		//exhaustive:enforce
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			for j := 0; j < m.Gauge().DataPoints().Len(); j++ {
+				count += m.Gauge().DataPoints().At(j).Attributes().Len()
+			}
+		case pmetric.MetricTypeSum:
+			for j := 0; j < m.Sum().DataPoints().Len(); j++ {
+				count += m.Sum().DataPoints().At(j).Attributes().Len()
+			}
+		case pmetric.MetricTypeHistogram:
+			for j := 0; j < m.Histogram().DataPoints().Len(); j++ {
+				count += m.Histogram().DataPoints().At(j).Attributes().Len()
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			for j := 0; j < m.ExponentialHistogram().DataPoints().Len(); j++ {
+				count += m.ExponentialHistogram().DataPoints().At(j).Attributes().Len()
+			}
+		case pmetric.MetricTypeSummary:
+			for j := 0; j < m.Summary().DataPoints().Len(); j++ {
+				count += m.Summary().DataPoints().At(j).Attributes().Len()
+			}
+		case pmetric.MetricTypeEmpty:
+		}
+	}
+	return count
+}
@@ -6,7 +6,12 @@ package schemaprocessor
 import (
 	"context"
 	_ "embed"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 func newTestTransformer(t *testing.T) *transformer {
@@ -86,3 +93,121 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func newTestSchemaServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.FileServer(http.Dir(filepath.Join("internal", "translation", "testdata"))))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestTranslatingTransformer(t *testing.T, target string) *transformer {
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{target}
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), nil), "Must not error when starting transformer")
+	t.Cleanup(func() {
+		assert.NoError(t, trans.shutdown(context.Background()))
+	})
+	return trans
+}
+
+// awaitSchema waits for the schema file needed to translate the schema URL to be retrieved.
+func awaitSchema(t *testing.T, trans *transformer, schemaURL string) {
+	require.Eventually(t, func() bool {
+		_, err := trans.manager.RequestTranslation(context.Background(), schemaURL)
+		return !errors.Is(err, translation.ErrTranslationPending)
+	}, 5*time.Second, 10*time.Millisecond, "Must retrieve the schema file")
+}
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestSchemaServer(t)
+
+	t.Run("upgrade", func(t *testing.T) {
+		trans := newTestTranslatingTransformer(t, srv.URL+"/schemas/1.2.0")
+		awaitSchema(t, trans, srv.URL+"/schemas/1.0.0")
+
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(srv.URL + "/schemas/1.0.0")
+		rs.Resource().Attributes().PutStr("service_name", "checkout")
+		s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		s.SetName("checkout")
+		s.Attributes().PutStr("http.method", "GET")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, srv.URL+"/schemas/1.2.0", rs.SchemaUrl())
+		assert.Equal(t, map[string]any{"service.name": "checkout"}, rs.Resource().Attributes().AsRaw())
+		assert.Equal(t, map[string]any{"http.request.method": "GET"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("downgrade", func(t *testing.T) {
+		trans := newTestTranslatingTransformer(t, srv.URL+"/schemas/1.1.0")
+		awaitSchema(t, trans, srv.URL+"/schemas/1.2.0")
+
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(srv.URL + "/schemas/1.2.0")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.SetSchemaUrl(srv.URL + "/schemas/1.2.0")
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("process.uptime")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("net.sock.peer.addr", "10.0.0.1")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		sm = out.ResourceMetrics().At(0).ScopeMetrics().At(0)
+		assert.Equal(t, srv.URL+"/schemas/1.1.0", out.ResourceMetrics().At(0).SchemaUrl())
+		assert.Equal(t, srv.URL+"/schemas/1.1.0", sm.SchemaUrl())
+		assert.Equal(t, "process.runtime.uptime", sm.Metrics().At(0).Name())
+		assert.Equal(t, map[string]any{"net.peer.ip": "10.0.0.1"}, sm.Metrics().At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("unmapped attributes", func(t *testing.T) {
+		trans := newTestTranslatingTransformer(t, srv.URL+"/schemas/1.2.0")
+		awaitSchema(t, trans, srv.URL+"/schemas/1.1.0")
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(srv.URL + "/schemas/1.1.0")
+		require.NoError(t, rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().FromRaw(map[string]any{
+			"log.severity": "warn",
+			"severity":     "error",
+		}))
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when attributes can not be mapped")
+		assert.Len(t, out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw(), 1)
+		assert.Equal(t, srv.URL+"/schemas/1.2.0", out.ResourceLogs().At(0).SchemaUrl())
+	})
+
+	t.Run("unavailable schema", func(t *testing.T) {
+		trans := newTestTranslatingTransformer(t, srv.URL+"/schemas/1.2.0")
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(srv.URL + "/schemas/1.3.0")
+		rl.Resource().Attributes().PutStr("service.name", "checkout")
+
+		expect := plog.NewLogs()
+		in.CopyTo(expect)
+
+		// the signal is passed on while the schema is retrieved, and once it failed to be
+		for i := 0; i < 2; i++ {
+			out, err := trans.processLogs(context.Background(), in)
+			require.NoError(t, err, "Must not error when the schema can not be retrieved")
+			assert.Equal(t, expect, out, "Must pass on the signal unchanged")
+			awaitSchema(t, trans, srv.URL+"/schemas/1.3.0")
+		}
+	})
+}
@@ -34,6 +34,9 @@ type Config struct {
 	StoreExpirationLoop time.Duration `mapstructure:"store_expiration_loop"`
 	// VirtualNodePeerAttributes the list of attributes need to match, the higher the front, the higher the priority.
 	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
+	// MetricsExpiration is the time after which the series of an edge that was not seen again are
+	// removed from the cache, and no longer emitted. The cache is checked every CacheLoop.
+	MetricsExpiration time.Duration `mapstructure:"metrics_expiration"`
 
 	// MetricsFlushInterval is the interval at which metrics are flushed to the exporter.
 	// If set to 0, metrics are flushed on every received batch of traces.
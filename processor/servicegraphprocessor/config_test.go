@@ -43,6 +43,7 @@ func TestLoadConfig(t *testing.T) {
 			CacheLoop:                 2 * time.Minute,
 			StoreExpirationLoop:       10 * time.Second,
 			VirtualNodePeerAttributes: []string{"db.name", "rpc.service"},
+			MetricsExpiration:         30 * time.Minute,
 		},
 		cfg.Processors[component.NewID(metadata.Type)],
 	)
@@ -62,6 +63,7 @@ func TestLoadConfig(t *testing.T) {
 			},
 			CacheLoop:           time.Minute,
 			StoreExpirationLoop: 2 * time.Second,
+			MetricsExpiration:   15 * time.Minute,
 		},
 		cfg.Connectors[component.NewID(metadata.Type)],
 	)
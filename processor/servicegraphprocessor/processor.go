@@ -30,6 +30,8 @@ const (
 	metricKeySeparator = string(byte(0))
 	clientKind         = "client"
 	serverKind         = "server"
+
+	defaultMetricsExpiration = 15 * time.Minute
 )
 
 var (
@@ -41,7 +43,7 @@ var (
 	}
 
 	defaultPeerAttributes = []string{
-		semconv.AttributeDBName, semconv.AttributeNetSockPeerAddr, semconv.AttributeNetPeerName, semconv.AttributeRPCService, semconv.AttributeNetSockPeerName, semconv.AttributeNetPeerName, semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget,
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeNetSockPeerAddr, semconv.AttributeNetPeerName, semconv.AttributeRPCService, semconv.AttributeNetSockPeerName, semconv.AttributeNetPeerName, semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget,
 	}
 )
 
@@ -98,6 +100,10 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 		pConfig.StoreExpirationLoop = 2 * time.Second
 	}
 
+	if pConfig.MetricsExpiration <= 0 {
+		pConfig.MetricsExpiration = defaultMetricsExpiration
+	}
+
 	if pConfig.VirtualNodePeerAttributes == nil {
 		pConfig.VirtualNodePeerAttributes = defaultPeerAttributes
 	}
@@ -224,11 +230,6 @@ func (p *serviceGraphProcessor) ConsumeTraces(ctx context.Context, td ptrace.Tra
 }
 
 func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err error) {
-	var (
-		isNew             bool
-		totalDroppedSpans int
-	)
-
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rSpans := rss.At(i)
@@ -257,7 +258,7 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					err = p.upsertEdge(ctx, key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
@@ -283,40 +284,70 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 					fallthrough
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
-					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
-						e.TraceID = traceID
-						e.ConnectionType = connectionType
-						e.ServerService = serviceName
-						e.ServerLatencySec = spanDuration(span)
-						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
-						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
-					})
+					for _, key := range serverEdgeKeys(span) {
+						err = p.upsertEdge(ctx, key, func(e *store.Edge) {
+							e.TraceID = traceID
+							e.ConnectionType = connectionType
+							e.ServerService = serviceName
+							e.ServerLatencySec = spanDuration(span)
+							e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+							p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+						})
+						if err != nil {
+							break
+						}
+					}
 				default:
 					// this span is not part of an edge
 					continue
 				}
 
-				if errors.Is(err, store.ErrTooManyItems) {
-					totalDroppedSpans++
-					stats.Record(ctx, statDroppedSpans.M(1))
-					continue
-				}
-
-				// UpsertEdge will only return ErrTooManyItems
 				if err != nil {
 					return err
 				}
-
-				if isNew {
-					stats.Record(ctx, statTotalEdges.M(1))
-				}
 			}
 		}
 	}
 	return nil
 }
 
+// serverEdgeKeys returns the keys of the edges the given server or consumer span is part of.
+// The client side of a request is the parent span, except for the consumers of messaging systems
+// (e.g. Kafka or Pulsar) without parent, which link to the spans of the producers instead, as a
+// consumer span may process messages sent by many producers. Each link is then an edge.
+func serverEdgeKeys(span ptrace.Span) []store.Key {
+	links := span.Links()
+	if span.Kind() != ptrace.SpanKindConsumer || !span.ParentSpanID().IsEmpty() || links.Len() == 0 {
+		return []store.Key{store.NewKey(span.TraceID(), span.ParentSpanID())}
+	}
+
+	keys := make([]store.Key, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		keys = append(keys, store.NewKey(links.At(i).TraceID(), links.At(i).SpanID()))
+	}
+	return keys
+}
+
+// upsertEdge updates the edge with the given key in the store, recording the dropped spans
+// when the store is full and the new edges.
+func (p *serviceGraphProcessor) upsertEdge(ctx context.Context, key store.Key, update store.Callback) error {
+	isNew, err := p.store.UpsertEdge(key, update)
+	if errors.Is(err, store.ErrTooManyItems) {
+		stats.Record(ctx, statDroppedSpans.M(1))
+		return nil
+	}
+
+	// UpsertEdge will only return ErrTooManyItems
+	if err != nil {
+		return err
+	}
+
+	if isNew {
+		stats.Record(ctx, statTotalEdges.M(1))
+	}
+	return nil
+}
+
 func (p *serviceGraphProcessor) upsertDimensions(kind string, m map[string]string, resourceAttr pcommon.Map, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := findAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -631,12 +662,12 @@ func (p *serviceGraphProcessor) cacheLoop(d time.Duration) {
 
 }
 
-// cleanCache removes series that have not been updated in 15 minutes
+// cleanCache removes series that have not been updated since the metrics expiration
 func (p *serviceGraphProcessor) cleanCache() {
 	var staleSeries []string
 	p.metricMutex.RLock()
 	for key, series := range p.keyToMetric {
-		if series.lastUpdated+15*time.Minute.Milliseconds() < time.Now().UnixMilli() {
+		if series.lastUpdated+p.config.MetricsExpiration.Milliseconds() < time.Now().UnixMilli() {
 			staleSeries = append(staleSeries, key)
 		}
 	}
@@ -28,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -161,6 +163,54 @@ func TestProcessorConsume(t *testing.T) {
 				assert.Equal(t, "127.10.10.1", v.Str())
 			},
 		},
+		{
+			name: "incomplete traces with virtual server span from peer service",
+			cfg: &Config{
+				MetricsExporter: "mock",
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+			},
+			gates: []*featuregate.Gate{virtualNodeFeatureGate},
+			sampleTraces: func() ptrace.Traces {
+				td := incompleteClientTraces()
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr(semconv.AttributePeerService, "payments")
+				return td
+			}(),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				v, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get("server")
+				assert.True(t, ok)
+				assert.Equal(t, "payments", v.Str())
+			},
+		},
+		{
+			name: "messaging traces with consumer span links",
+			cfg: &Config{
+				MetricsExporter: "mock",
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+			},
+			sampleTraces: buildMessagingTrace(t),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				require.Equal(t, 6, m.Len())
+				servers := map[string]int64{}
+				for i := 0; i < m.Len(); i++ {
+					if m.At(i).Name() != "traces_service_graph_request_total" {
+						continue
+					}
+					dp := m.At(i).Sum().DataPoints().At(0)
+					verifyAttr(t, dp.Attributes(), "server", "consumer-service")
+					verifyAttr(t, dp.Attributes(), "connection_type", string(store.MessagingSystem))
+					client, _ := dp.Attributes().Get("client")
+					servers[client.Str()] += dp.IntValue()
+				}
+				assert.Equal(t, map[string]int64{"producer-a": 1, "producer-b": 1}, servers)
+			},
+		},
 		{
 			name: "incomplete traces with virtual client span",
 			cfg: &Config{
@@ -434,6 +484,54 @@ func buildSampleTrace(t *testing.T, attrValue string) ptrace.Traces {
 	return traces
 }
 
+// buildMessagingTrace builds the spans of two producers, in separate traces, and of a consumer
+// processing the messages of both producers in a batch, linking to their spans.
+func buildMessagingTrace(t *testing.T) ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	var consumerTraceID pcommon.TraceID
+	_, err := rand.Read(consumerTraceID[:])
+	assert.NoError(t, err)
+
+	consumerResourceSpans := traces.ResourceSpans().AppendEmpty()
+	consumerResourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "consumer-service")
+	consumerSpan := consumerResourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	consumerSpan.SetName("consumer span")
+	consumerSpan.SetSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+	consumerSpan.SetTraceID(consumerTraceID)
+	consumerSpan.SetKind(ptrace.SpanKindConsumer)
+	consumerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	consumerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+
+	for _, service := range []string{"producer-a", "producer-b"} {
+		var traceID pcommon.TraceID
+		_, err = rand.Read(traceID[:])
+		assert.NoError(t, err)
+		var spanID pcommon.SpanID
+		_, err = rand.Read(spanID[:])
+		assert.NoError(t, err)
+
+		resourceSpans := traces.ResourceSpans().AppendEmpty()
+		resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, service)
+		producerSpan := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		producerSpan.SetName("producer span")
+		producerSpan.SetSpanID(spanID)
+		producerSpan.SetTraceID(traceID)
+		producerSpan.SetKind(ptrace.SpanKindProducer)
+		producerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+		producerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+
+		link := consumerSpan.Links().AppendEmpty()
+		link.SetTraceID(traceID)
+		link.SetSpanID(spanID)
+	}
+
+	return traces
+}
+
 func incompleteClientTraces() ptrace.Traces {
 	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
 	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
@@ -586,6 +684,24 @@ func TestUpdateDurationMetrics(t *testing.T) {
 	}
 }
 
+func TestServerEdgeKeys(t *testing.T) {
+	traces := buildMessagingTrace(t)
+	consumerSpan := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	links := consumerSpan.Links()
+
+	// Without parent, the consumer span is paired with the linked producer spans.
+	assert.Equal(t, []store.Key{
+		store.NewKey(links.At(0).TraceID(), links.At(0).SpanID()),
+		store.NewKey(links.At(1).TraceID(), links.At(1).SpanID()),
+	}, serverEdgeKeys(consumerSpan))
+
+	// With a parent, the consumer span is paired with its parent and the links are ignored.
+	consumerSpan.SetParentSpanID([8]byte{2, 2, 2, 2, 2, 2, 2, 2})
+	assert.Equal(t, []store.Key{
+		store.NewKey(consumerSpan.TraceID(), consumerSpan.ParentSpanID()),
+	}, serverEdgeKeys(consumerSpan))
+}
+
 func TestStaleSeriesCleanup(t *testing.T) {
 	// Prepare
 	cfg := &Config{
@@ -629,3 +745,31 @@ func TestStaleSeriesCleanup(t *testing.T) {
 	// Shutdown the processor
 	assert.NoError(t, p.Shutdown(context.Background()))
 }
+
+func TestMetricsExpiration(t *testing.T) {
+	p := newProcessor(zaptest.NewLogger(t), &Config{
+		MetricsExporter:   "mock",
+		Store:             StoreConfig{MaxItems: 10},
+		MetricsExpiration: time.Hour,
+	})
+	p.store = store.NewStore(time.Second, 10, p.onComplete, p.onExpire)
+	require.NoError(t, p.aggregateMetrics(context.Background(), buildSampleTrace(t, "val")))
+	require.Len(t, p.keyToMetric, 1)
+
+	// The series was updated before the default expiration, but within the configured one.
+	for key, metric := range p.keyToMetric {
+		metric.lastUpdated = time.Now().Add(-30 * time.Minute).UnixMilli()
+		p.keyToMetric[key] = metric
+	}
+	p.cleanCache()
+	assert.Len(t, p.keyToMetric, 1)
+	assert.Len(t, p.reqTotal, 1)
+
+	for key, metric := range p.keyToMetric {
+		metric.lastUpdated = time.Now().Add(-2 * time.Hour).UnixMilli()
+		p.keyToMetric[key] = metric
+	}
+	p.cleanCache()
+	assert.Len(t, p.keyToMetric, 0)
+	assert.Len(t, p.reqTotal, 0)
+}
@@ -4,8 +4,12 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -216,6 +220,49 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// TenantsCfg holds the configurable settings to evaluate the sampling policies
+// separately for each tenant, identified by the value of an attribute.
+type TenantsCfg struct {
+	// AttributeKey is the resource or span attribute holding the tenant name.
+	// Per-tenant sampling is disabled when empty.
+	AttributeKey string `mapstructure:"attribute_key"`
+	// SpansPerSecond is the default maximum number of spans sampled each second
+	// for a single tenant. Zero means that tenants are not rate limited.
+	SpansPerSecond int64 `mapstructure:"spans_per_second"`
+	// Overrides sets the spans per second budget of specific tenants.
+	Overrides map[string]int64 `mapstructure:"overrides"`
+	// MaxTenants is the maximum number of tenants tracked at the same time. Traces
+	// from tenants beyond this limit share the budget of an overflow tenant.
+	MaxTenants int `mapstructure:"max_tenants"`
+}
+
+// DecisionCacheBackend is the type of store used to share sampling decisions.
+type DecisionCacheBackend string
+
+const (
+	// DecisionCacheRedis shares the sampling decisions through Redis.
+	DecisionCacheRedis DecisionCacheBackend = "redis"
+	// DecisionCacheMemcached shares the sampling decisions through memcached.
+	DecisionCacheMemcached DecisionCacheBackend = "memcached"
+)
+
+// DecisionCacheCfg holds the configurable settings of the external cache used to
+// share sampling decisions between collector replicas.
+type DecisionCacheCfg struct {
+	// Backend is the type of the external cache. The cache is disabled when empty.
+	Backend DecisionCacheBackend `mapstructure:"backend"`
+	// Endpoint is the address of the cache server, in the host:port form.
+	Endpoint string `mapstructure:"endpoint"`
+	// Password is used to authenticate with Redis.
+	Password configopaque.String `mapstructure:"password"`
+	// KeyPrefix is prepended to the trace ID to build the cache keys.
+	KeyPrefix string `mapstructure:"key_prefix"`
+	// TTL is how long a decision is kept in the cache.
+	TTL time.Duration `mapstructure:"ttl"`
+	// Timeout bounds each request to the cache server.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -230,4 +277,59 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// Tenants configures the per-tenant evaluation of the policies and sampling budgets.
+	Tenants TenantsCfg `mapstructure:"tenants"`
+	// DecisionCache configures an external cache sharing the sampling decisions
+	// between collector replicas.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if err := cfg.DecisionCache.validate(); err != nil {
+		return err
+	}
+
+	tenants := cfg.Tenants
+	if tenants.AttributeKey == "" {
+		if tenants.SpansPerSecond != 0 || len(tenants.Overrides) > 0 {
+			return errors.New("tenants: attribute_key must be set")
+		}
+		return nil
+	}
+	if tenants.SpansPerSecond < 0 {
+		return errors.New("tenants: spans_per_second must not be negative")
+	}
+	for name, sps := range tenants.Overrides {
+		if sps < 0 {
+			return fmt.Errorf("tenants: spans_per_second of tenant %q must not be negative", name)
+		}
+	}
+	if tenants.MaxTenants <= 0 {
+		return errors.New("tenants: max_tenants must be positive")
+	}
+	return nil
+}
+
+func (cfg *DecisionCacheCfg) validate() error {
+	switch cfg.Backend {
+	case "":
+		return nil
+	case DecisionCacheRedis, DecisionCacheMemcached:
+	default:
+		return fmt.Errorf("decision_cache: unknown backend %q", cfg.Backend)
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("decision_cache: endpoint must be set")
+	}
+	if cfg.Password != "" && cfg.Backend != DecisionCacheRedis {
+		return errors.New("decision_cache: password is only supported by the redis backend")
+	}
+	if cfg.TTL < time.Second {
+		return errors.New("decision_cache: ttl must be at least 1s")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("decision_cache: timeout must be positive")
+	}
+	return nil
 }
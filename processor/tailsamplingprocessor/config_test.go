@@ -36,6 +36,19 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			Tenants: TenantsCfg{
+				AttributeKey:   "tenant.id",
+				SpansPerSecond: 100,
+				Overrides:      map[string]int64{"acme": 500},
+				MaxTenants:     50,
+			},
+			DecisionCache: DecisionCacheCfg{
+				Backend:   DecisionCacheRedis,
+				Endpoint:  "localhost:6379",
+				KeyPrefix: "tail_sampling:",
+				TTL:       10 * time.Minute,
+				Timeout:   100 * time.Millisecond,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -186,3 +199,78 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		tenants       TenantsCfg
+		decisionCache DecisionCacheCfg
+		err           string
+	}{
+		{
+			name:    "disabled",
+			tenants: TenantsCfg{MaxTenants: 1000},
+		},
+		{
+			name:    "budget without attribute key",
+			tenants: TenantsCfg{SpansPerSecond: 10, MaxTenants: 1000},
+			err:     "tenants: attribute_key must be set",
+		},
+		{
+			name:    "negative spans per second",
+			tenants: TenantsCfg{AttributeKey: "tenant", SpansPerSecond: -1, MaxTenants: 1000},
+			err:     "tenants: spans_per_second must not be negative",
+		},
+		{
+			name:    "negative override",
+			tenants: TenantsCfg{AttributeKey: "tenant", Overrides: map[string]int64{"acme": -1}, MaxTenants: 1000},
+			err:     `tenants: spans_per_second of tenant "acme" must not be negative`,
+		},
+		{
+			name:    "no max tenants",
+			tenants: TenantsCfg{AttributeKey: "tenant"},
+			err:     "tenants: max_tenants must be positive",
+		},
+		{
+			name:          "unknown decision cache backend",
+			tenants:       TenantsCfg{MaxTenants: 1000},
+			decisionCache: DecisionCacheCfg{Backend: "etcd"},
+			err:           `decision_cache: unknown backend "etcd"`,
+		},
+		{
+			name:          "decision cache without endpoint",
+			tenants:       TenantsCfg{MaxTenants: 1000},
+			decisionCache: DecisionCacheCfg{Backend: DecisionCacheRedis, TTL: time.Minute, Timeout: time.Second},
+			err:           "decision_cache: endpoint must be set",
+		},
+		{
+			name:          "memcached password",
+			tenants:       TenantsCfg{MaxTenants: 1000},
+			decisionCache: DecisionCacheCfg{Backend: DecisionCacheMemcached, Endpoint: "localhost:11211", Password: "secret", TTL: time.Minute, Timeout: time.Second},
+			err:           "decision_cache: password is only supported by the redis backend",
+		},
+		{
+			name:          "decision cache ttl too short",
+			tenants:       TenantsCfg{MaxTenants: 1000},
+			decisionCache: DecisionCacheCfg{Backend: DecisionCacheMemcached, Endpoint: "localhost:11211", TTL: time.Millisecond, Timeout: time.Second},
+			err:           "decision_cache: ttl must be at least 1s",
+		},
+		{
+			name:          "decision cache without timeout",
+			tenants:       TenantsCfg{MaxTenants: 1000},
+			decisionCache: DecisionCacheCfg{Backend: DecisionCacheRedis, Endpoint: "localhost:6379", TTL: time.Minute},
+			err:           "decision_cache: timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Tenants: tt.tenants, DecisionCache: tt.decisionCache}
+			if tt.err == "" {
+				assert.NoError(t, cfg.Validate())
+				return
+			}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
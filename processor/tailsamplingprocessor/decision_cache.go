@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-redis/redis/v7"
+	"github.com/grobie/gomemcache/memcache"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	cachedSampled    = "1"
+	cachedNotSampled = "0"
+
+	// memcachedConcurrency is the number of decisions added to memcached concurrently.
+	memcachedConcurrency = 16
+)
+
+// decisionCache shares the final sampling decisions between collector replicas,
+// so that all of them keep or drop the spans of a trace consistently. The decisions
+// of all the traces of a tick are requested together, to avoid a round trip per trace.
+type decisionCache interface {
+	// getAll returns the decisions stored for the traces, the traces without
+	// decision being absent from the returned map.
+	getAll(ids []pcommon.TraceID) (map[pcommon.TraceID]sampling.Decision, error)
+	// setAllIfAbsent stores the decisions of the traces for which another replica
+	// didn't already store one, and returns the decisions that must be applied.
+	setAllIfAbsent(decisions map[pcommon.TraceID]sampling.Decision) (map[pcommon.TraceID]sampling.Decision, error)
+	// close releases the connections to the cache server.
+	close() error
+}
+
+func newDecisionCache(cfg DecisionCacheCfg) (decisionCache, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case DecisionCacheRedis:
+		return newRedisDecisionCache(cfg), nil
+	case DecisionCacheMemcached:
+		return newMemcachedDecisionCache(cfg)
+	default:
+		return nil, fmt.Errorf("unknown decision cache backend %q", cfg.Backend)
+	}
+}
+
+// mergeDecisions returns the decisions, replaced by the decisions stored by other replicas for the
+// existing traces. The decisions of the existing traces which expired in the meantime are kept.
+func mergeDecisions(
+	decisions map[pcommon.TraceID]sampling.Decision,
+	existing []pcommon.TraceID,
+	getAll func([]pcommon.TraceID) (map[pcommon.TraceID]sampling.Decision, error),
+) (map[pcommon.TraceID]sampling.Decision, error) {
+	if len(existing) == 0 {
+		return decisions, nil
+	}
+	stored, err := getAll(existing)
+	merged := make(map[pcommon.TraceID]sampling.Decision, len(decisions))
+	for id, decision := range decisions {
+		merged[id] = decision
+	}
+	for id, decision := range stored {
+		merged[id] = decision
+	}
+	return merged, err
+}
+
+func cacheKey(prefix string, id pcommon.TraceID) string {
+	return prefix + id.String()
+}
+
+func encodeDecision(decision sampling.Decision) string {
+	if decision == sampling.Sampled {
+		return cachedSampled
+	}
+	return cachedNotSampled
+}
+
+func decodeDecision(value string) (sampling.Decision, error) {
+	switch value {
+	case cachedSampled:
+		return sampling.Sampled, nil
+	case cachedNotSampled:
+		return sampling.NotSampled, nil
+	default:
+		return sampling.Unspecified, fmt.Errorf("invalid cached decision %q", value)
+	}
+}
+
+type redisDecisionCache struct {
+	client *redis.Client
+	cfg    DecisionCacheCfg
+}
+
+var _ decisionCache = (*redisDecisionCache)(nil)
+
+func newRedisDecisionCache(cfg DecisionCacheCfg) *redisDecisionCache {
+	return &redisDecisionCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:         cfg.Endpoint,
+			Password:     string(cfg.Password),
+			DialTimeout:  cfg.Timeout,
+			ReadTimeout:  cfg.Timeout,
+			WriteTimeout: cfg.Timeout,
+		}),
+		cfg: cfg,
+	}
+}
+
+func (c *redisDecisionCache) getAll(ids []pcommon.TraceID) (map[pcommon.TraceID]sampling.Decision, error) {
+	decisions := make(map[pcommon.TraceID]sampling.Decision, len(ids))
+	if len(ids) == 0 {
+		return decisions, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = cacheKey(c.cfg.KeyPrefix, id)
+	}
+	values, err := c.client.MGet(keys...).Result()
+	if err != nil {
+		return decisions, err
+	}
+	var errs error
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			// no decision stored
+			continue
+		}
+		decision, err := decodeDecision(str)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		decisions[ids[i]] = decision
+	}
+	return decisions, errs
+}
+
+func (c *redisDecisionCache) setAllIfAbsent(decisions map[pcommon.TraceID]sampling.Decision) (map[pcommon.TraceID]sampling.Decision, error) {
+	pipe := c.client.Pipeline()
+	cmds := make(map[pcommon.TraceID]*redis.BoolCmd, len(decisions))
+	for id, decision := range decisions {
+		cmds[id] = pipe.SetNX(cacheKey(c.cfg.KeyPrefix, id), encodeDecision(decision), c.cfg.TTL)
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return decisions, err
+	}
+	var existing []pcommon.TraceID
+	for id, cmd := range cmds {
+		if !cmd.Val() {
+			existing = append(existing, id)
+		}
+	}
+	return mergeDecisions(decisions, existing, c.getAll)
+}
+
+func (c *redisDecisionCache) close() error {
+	return c.client.Close()
+}
+
+type memcachedDecisionCache struct {
+	client *memcache.Client
+	cfg    DecisionCacheCfg
+}
+
+var _ decisionCache = (*memcachedDecisionCache)(nil)
+
+func newMemcachedDecisionCache(cfg DecisionCacheCfg) (*memcachedDecisionCache, error) {
+	client, err := memcache.New(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = cfg.Timeout
+	return &memcachedDecisionCache{
+		client: client,
+		cfg:    cfg,
+	}, nil
+}
+
+func (c *memcachedDecisionCache) getAll(ids []pcommon.TraceID) (map[pcommon.TraceID]sampling.Decision, error) {
+	decisions := make(map[pcommon.TraceID]sampling.Decision, len(ids))
+	if len(ids) == 0 {
+		return decisions, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = cacheKey(c.cfg.KeyPrefix, id)
+	}
+	items, err := c.client.GetMulti(keys)
+	if err != nil {
+		return decisions, err
+	}
+	var errs error
+	for i, key := range keys {
+		item, ok := items[key]
+		if !ok {
+			// no decision stored
+			continue
+		}
+		decision, err := decodeDecision(string(item.Value))
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		decisions[ids[i]] = decision
+	}
+	return decisions, errs
+}
+
+// setAllIfAbsent adds the decisions concurrently, the memcached protocol having no batch version of add.
+func (c *memcachedDecisionCache) setAllIfAbsent(decisions map[pcommon.TraceID]sampling.Decision) (map[pcommon.TraceID]sampling.Decision, error) {
+	var (
+		mu       sync.Mutex
+		errs     error
+		existing []pcommon.TraceID
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, memcachedConcurrency)
+	for id, decision := range decisions {
+		id, decision := id, decision
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := c.client.Add(&memcache.Item{
+				Key:        cacheKey(c.cfg.KeyPrefix, id),
+				Value:      []byte(encodeDecision(decision)),
+				Expiration: int32(c.cfg.TTL.Seconds()),
+			})
+			if err == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, memcache.ErrNotStored) {
+				existing = append(existing, id)
+			} else {
+				errs = errors.Join(errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if errs != nil {
+		return decisions, errs
+	}
+	return mergeDecisions(decisions, existing, c.getAll)
+}
+
+func (c *memcachedDecisionCache) close() error {
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+type fakeDecisionCache struct {
+	decisions map[pcommon.TraceID]sampling.Decision
+	err       error
+	closed    bool
+	// requests is the number of requests to the cache.
+	requests int
+}
+
+var _ decisionCache = (*fakeDecisionCache)(nil)
+
+func (c *fakeDecisionCache) getAll(ids []pcommon.TraceID) (map[pcommon.TraceID]sampling.Decision, error) {
+	c.requests++
+	decisions := map[pcommon.TraceID]sampling.Decision{}
+	if c.err != nil {
+		return decisions, c.err
+	}
+	for _, id := range ids {
+		if d, ok := c.decisions[id]; ok {
+			decisions[id] = d
+		}
+	}
+	return decisions, nil
+}
+
+func (c *fakeDecisionCache) setAllIfAbsent(decisions map[pcommon.TraceID]sampling.Decision) (map[pcommon.TraceID]sampling.Decision, error) {
+	c.requests++
+	if c.err != nil {
+		return decisions, c.err
+	}
+	applied := map[pcommon.TraceID]sampling.Decision{}
+	for id, decision := range decisions {
+		if d, ok := c.decisions[id]; ok {
+			applied[id] = d
+			continue
+		}
+		c.decisions[id] = decision
+		applied[id] = decision
+	}
+	return applied, nil
+}
+
+func (c *fakeDecisionCache) close() error {
+	c.closed = true
+	return nil
+}
+
+func TestSharedDecisionCache(t *testing.T) {
+	const maxSize = 100
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	cache := &fakeDecisionCache{decisions: map[pcommon.TraceID]sampling.Decision{}}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		decisionCache:   cache,
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  &atomic.Uint64{},
+		mutatorsBuf:     make([]tag.Mutator, 1),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ids, batches := generateIdsAndBatches(3)
+	// another replica already decided to sample the first trace
+	cache.decisions[ids[0]] = sampling.Sampled
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	assert.Equal(t, 2, mpe.EvaluationCount, "cached decisions must not be evaluated again")
+	assert.Equal(t, 2, cache.requests, "the decisions of a tick must be requested together")
+	require.Len(t, msp.AllTraces(), 1)
+	assert.Equal(t, ids[0], msp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+	assert.Equal(t, map[pcommon.TraceID]sampling.Decision{
+		ids[0]: sampling.Sampled,
+		ids[1]: sampling.NotSampled,
+		ids[2]: sampling.NotSampled,
+	}, cache.decisions)
+
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.True(t, cache.closed)
+}
+
+func TestSharedDecisionCacheErrors(t *testing.T) {
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	cache := &fakeDecisionCache{
+		decisions: map[pcommon.TraceID]sampling.Decision{},
+		err:       errors.New("connection refused"),
+	}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:           context.Background(),
+		logger:        zap.NewNop(),
+		policies:      []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		decisionCache: cache,
+		mutatorsBuf:   make([]tag.Mutator, 1),
+	}
+
+	metrics := &policyMetrics{}
+	decisions, policies := tsp.sharedDecisions([]pcommon.TraceID{uInt64ToTraceID(1)}, []*sampling.TraceData{tenantTraceData(t, "", false)}, metrics)
+	assert.Equal(t, []sampling.Decision{sampling.Sampled}, decisions, "the local decision must be used when the cache is unavailable")
+	assert.NotNil(t, policies[0])
+	assert.EqualValues(t, 1, metrics.decisionCacheErrorCount)
+	assert.Equal(t, 1, cache.requests, "the decisions must not be shared after a failure")
+	assert.Empty(t, cache.decisions)
+}
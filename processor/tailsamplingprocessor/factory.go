@@ -38,6 +38,14 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		DecisionWait: 30 * time.Second,
 		NumTraces:    50000,
+		Tenants: TenantsCfg{
+			MaxTenants: 1000,
+		},
+		DecisionCache: DecisionCacheCfg{
+			KeyPrefix: "tail_sampling:",
+			TTL:       5 * time.Minute,
+			Timeout:   100 * time.Millisecond,
+		},
 	}
 }
 
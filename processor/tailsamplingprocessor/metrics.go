@@ -18,6 +18,7 @@ var (
 	tagPolicyKey, _    = tag.NewKey("policy")
 	tagSampledKey, _   = tag.NewKey("sampled")
 	tagSourceFormat, _ = tag.NewKey("source_format")
+	tagTenantKey, _    = tag.NewKey("tenant")
 
 	statDecisionLatencyMicroSec  = stats.Int64("sampling_decision_latency", "Latency (in microseconds) of a given sampling policy", "µs")
 	statOverallDecisionLatencyUs = stats.Int64("sampling_decision_timer_latency", "Latency (in microseconds) of each run of the sampling decision timer", "µs")
@@ -29,10 +30,15 @@ var (
 
 	statCountTracesSampled       = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not per sampling policy", stats.UnitDimensionless)
 	statCountGlobalTracesSampled = stats.Int64("global_count_traces_sampled", "Global count of traces that were sampled or not by at least one policy", stats.UnitDimensionless)
+	statCountTenantTracesSampled = stats.Int64("count_tenant_traces_sampled", "Count of traces that were sampled or not per tenant", stats.UnitDimensionless)
+	statTenantBudgetExceeded     = stats.Int64("tenant_budget_exceeded", "Count of traces not sampled because the tenant exhausted its sampling budget", stats.UnitDimensionless)
 
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount   = stats.Int64("sampling_decision_cache_hit", "Count of sampling decisions taken from the shared decision cache", stats.UnitDimensionless)
+	statDecisionCacheErrorCount = stats.Int64("sampling_decision_cache_error", "Count of failed requests to the shared decision cache", stats.UnitDimensionless)
 )
 
 // samplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -97,6 +103,21 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countTenantTracesSampledView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statCountTenantTracesSampled.Name()),
+		Measure:     statCountTenantTracesSampled,
+		Description: statCountTenantTracesSampled.Description(),
+		TagKeys:     []tag.Key{tagTenantKey, tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+	countTenantBudgetExceededView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statTenantBudgetExceeded.Name()),
+		Measure:     statTenantBudgetExceeded,
+		Description: statTenantBudgetExceeded.Description(),
+		TagKeys:     []tag.Key{tagTenantKey},
+		Aggregation: view.Sum(),
+	}
+
 	countTraceDroppedTooEarlyView := &view.View{
 		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statDroppedTooEarlyCount.Name()),
 		Measure:     statDroppedTooEarlyCount,
@@ -115,6 +136,18 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Description: statTracesOnMemoryGauge.Description(),
 		Aggregation: view.LastValue(),
 	}
+	countDecisionCacheHitView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		Aggregation: view.Sum(),
+	}
+	countDecisionCacheErrorView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statDecisionCacheErrorCount.Name()),
+		Measure:     statDecisionCacheErrorCount,
+		Description: statDecisionCacheErrorCount.Description(),
+		Aggregation: view.Sum(),
+	}
 
 	return []*view.View{
 		decisionLatencyView,
@@ -127,9 +160,14 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 
 		countTracesSampledView,
 		countGlobalTracesSampledView,
+		countTenantTracesSampledView,
+		countTenantBudgetExceededView,
 
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+
+		countDecisionCacheHitView,
+		countDecisionCacheErrorView,
 	}
 }
@@ -49,6 +49,8 @@ type tailSamplingSpanProcessor struct {
 	nextConsumer    consumer.Traces
 	maxNumTraces    uint64
 	policies        []*policy
+	tenants         *tenantSampler
+	decisionCache   decisionCache
 	logger          *zap.Logger
 	idToTrace       sync.Map
 	policyTicker    timeutils.TTicker
@@ -81,30 +83,19 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		return nil, component.ErrNilNextConsumer
 	}
 
-	policyNames := map[string]bool{}
-	policies := make([]*policy, len(cfg.PolicyCfgs))
-	for i := range cfg.PolicyCfgs {
-		policyCfg := &cfg.PolicyCfgs[i]
+	policies, err := newPolicies(ctx, settings, cfg.PolicyCfgs)
+	if err != nil {
+		return nil, err
+	}
 
-		if policyNames[policyCfg.Name] {
-			return nil, fmt.Errorf("duplicate policy name %q", policyCfg.Name)
-		}
-		policyNames[policyCfg.Name] = true
+	var tenants *tenantSampler
+	if cfg.Tenants.AttributeKey != "" {
+		tenants = newTenantSampler(ctx, settings, cfg.Tenants, cfg.PolicyCfgs)
+	}
 
-		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
-		if err != nil {
-			return nil, err
-		}
-		eval, err := getPolicyEvaluator(settings, policyCfg)
-		if err != nil {
-			return nil, err
-		}
-		p := &policy{
-			name:      policyCfg.Name,
-			evaluator: eval,
-			ctx:       policyCtx,
-		}
-		policies[i] = p
+	cache, err := newDecisionCache(cfg.DecisionCache)
+	if err != nil {
+		return nil, err
 	}
 
 	// this will start a goroutine in the background, so we run it only if everything went
@@ -122,6 +113,8 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		logger:          settings.Logger,
 		decisionBatcher: inBatcher,
 		policies:        policies,
+		tenants:         tenants,
+		decisionCache:   cache,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  &atomic.Uint64{},
 
@@ -136,6 +129,37 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 	return tsp, nil
 }
 
+// newPolicies creates the policies described by the given configs, carrying the
+// policy metric tags on top of the given context.
+func newPolicies(ctx context.Context, settings component.TelemetrySettings, cfgs []PolicyCfg) ([]*policy, error) {
+	policyNames := map[string]bool{}
+	policies := make([]*policy, len(cfgs))
+	for i := range cfgs {
+		policyCfg := &cfgs[i]
+
+		if policyNames[policyCfg.Name] {
+			return nil, fmt.Errorf("duplicate policy name %q", policyCfg.Name)
+		}
+		policyNames[policyCfg.Name] = true
+
+		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
+		if err != nil {
+			return nil, err
+		}
+		eval, err := getPolicyEvaluator(settings, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		p := &policy{
+			name:      policyCfg.Name,
+			evaluator: eval,
+			ctx:       policyCtx,
+		}
+		policies[i] = p
+	}
+	return policies, nil
+}
+
 func getPolicyEvaluator(settings component.TelemetrySettings, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case Composite:
@@ -191,6 +215,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 
 type policyMetrics struct {
 	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
+	decisionCacheHitCount, decisionCacheErrorCount                                int64
 }
 
 func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
@@ -200,6 +225,8 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
 	batchLen := len(batch)
 	tsp.logger.Debug("Sampling Policy Evaluation ticked")
+	ids := make([]pcommon.TraceID, 0, batchLen)
+	traces := make([]*sampling.TraceData, 0, batchLen)
 	for _, id := range batch {
 		d, ok := tsp.idToTrace.Load(id)
 		if !ok {
@@ -208,18 +235,25 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
+		ids = append(ids, id)
+		traces = append(traces, trace)
+	}
 
-		decision, policy := tsp.makeDecision(id, trace, &metrics)
-
+	decisions, policies := tsp.sharedDecisions(ids, traces, &metrics)
+	for i, trace := range traces {
 		// Sampled or not, remove the batches
 		trace.Lock()
 		allSpans := trace.ReceivedBatches
-		trace.FinalDecision = decision
+		trace.FinalDecision = decisions[i]
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
-		if decision == sampling.Sampled {
-			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
+		if decisions[i] == sampling.Sampled {
+			ctx := tsp.ctx
+			if policies[i] != nil {
+				ctx = policies[i].ctx
+			}
+			_ = tsp.nextConsumer.ConsumeTraces(ctx, allSpans)
 		}
 	}
 
@@ -227,7 +261,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		statOverallDecisionLatencyUs.M(int64(time.Since(startTime)/time.Microsecond)),
 		statDroppedTooEarlyCount.M(metrics.idNotFoundOnMapCount),
 		statPolicyEvaluationErrorCount.M(metrics.evaluateErrorCount),
-		statTracesOnMemoryGauge.M(int64(tsp.numTracesOnMap.Load())))
+		statTracesOnMemoryGauge.M(int64(tsp.numTracesOnMap.Load())),
+		statDecisionCacheHitCount.M(metrics.decisionCacheHitCount),
+		statDecisionCacheErrorCount.M(metrics.decisionCacheErrorCount))
 
 	tsp.logger.Debug("Sampling policy evaluation completed",
 		zap.Int("batch.len", batchLen),
@@ -238,9 +274,69 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	)
 }
 
+// sharedDecisions returns the decisions of the traces, and the policies which sampled them. When a
+// decision cache is configured, the decisions already taken by other replicas are fetched, and the
+// new decisions are shared, for all the traces at once. Cache failures fall back to the local decisions.
+func (tsp *tailSamplingSpanProcessor) sharedDecisions(ids []pcommon.TraceID, traces []*sampling.TraceData, metrics *policyMetrics) ([]sampling.Decision, []*policy) {
+	decisions := make([]sampling.Decision, len(ids))
+	policies := make([]*policy, len(ids))
+	if tsp.decisionCache == nil || len(ids) == 0 {
+		for i, id := range ids {
+			decisions[i], policies[i] = tsp.makeDecision(id, traces[i], metrics)
+		}
+		return decisions, policies
+	}
+
+	cached, err := tsp.decisionCache.getAll(ids)
+	if err != nil {
+		metrics.decisionCacheErrorCount++
+		tsp.logger.Debug("Failed to get the cached sampling decisions", zap.Error(err))
+	}
+	local := make(map[pcommon.TraceID]sampling.Decision)
+	for i, id := range ids {
+		if decision, ok := cached[id]; ok {
+			metrics.decisionCacheHitCount++
+			decisions[i] = decision
+			continue
+		}
+		decisions[i], policies[i] = tsp.makeDecision(id, traces[i], metrics)
+		local[id] = decisions[i]
+	}
+	if err != nil || len(local) == 0 {
+		// don't wait for the cache timeout a second time
+		return decisions, policies
+	}
+
+	shared, err := tsp.decisionCache.setAllIfAbsent(local)
+	if err != nil {
+		metrics.decisionCacheErrorCount++
+		tsp.logger.Debug("Failed to share the sampling decisions", zap.Error(err))
+		return decisions, policies
+	}
+	for i, id := range ids {
+		if decision, ok := shared[id]; ok && decision != decisions[i] {
+			metrics.decisionCacheHitCount++
+			decisions[i] = decision
+			policies[i] = nil
+		}
+	}
+	return decisions, policies
+}
+
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
 	finalDecision := sampling.NotSampled
 	var matchingPolicy *policy
+
+	policies := tsp.policies
+	var t *tenant
+	if tsp.tenants != nil {
+		var err error
+		if t, err = tsp.tenants.tenantFor(trace); err != nil {
+			tsp.logger.Warn("Failed to create the tenant policies, using the shared ones", zap.Error(err))
+		} else {
+			policies = t.policies
+		}
+	}
 	samplingDecision := map[sampling.Decision]bool{
 		sampling.Error:            false,
 		sampling.Sampled:          false,
@@ -250,7 +346,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	}
 
 	// Check all policies before making a final decision
-	for i, p := range tsp.policies {
+	for i, p := range policies {
 		policyEvaluateStartTime := time.Now()
 		decision, err := p.evaluator.Evaluate(p.ctx, id, trace)
 		stats.Record(
@@ -293,7 +389,11 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	}
 
 	mutators := tsp.mutatorsBuf
-	for i, p := range tsp.policies {
+	if t != nil {
+		finalDecision = t.applyBudget(id, trace, finalDecision, mutators)
+	}
+
+	for i, p := range policies {
 		switch trace.Decisions[i] {
 		case sampling.Sampled:
 			// any single policy that decides to sample will cause the decision to be sampled
@@ -459,6 +559,9 @@ func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) err
 func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.decisionCache != nil {
+		return tsp.decisionCache.close()
+	}
 	return nil
 }
 
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// unknownTenant is used for traces without the tenant attribute.
+	unknownTenant = "unknown"
+	// overflowTenant is shared by all tenants seen after max_tenants was reached.
+	overflowTenant = "overflow"
+)
+
+// tenant holds the policies and the sampling budget of a single tenant.
+type tenant struct {
+	name string
+	// ctx carries the tenant metric tag.
+	ctx      context.Context
+	policies []*policy
+	// budget limits the spans sampled each second for the tenant, nil when unlimited.
+	budget sampling.PolicyEvaluator
+}
+
+// tenantSampler keeps the state of the known tenants. It is only used from the
+// sampling decision ticker and is therefore not synchronized.
+type tenantSampler struct {
+	ctx        context.Context
+	settings   component.TelemetrySettings
+	cfg        TenantsCfg
+	policyCfgs []PolicyCfg
+	tenants    map[string]*tenant
+	overflow   *tenant
+}
+
+func newTenantSampler(ctx context.Context, settings component.TelemetrySettings, cfg TenantsCfg, policyCfgs []PolicyCfg) *tenantSampler {
+	return &tenantSampler{
+		ctx:        ctx,
+		settings:   settings,
+		cfg:        cfg,
+		policyCfgs: policyCfgs,
+		tenants:    make(map[string]*tenant),
+	}
+}
+
+// tenantFor returns the tenant owning the given trace, creating its state on first use.
+func (ts *tenantSampler) tenantFor(trace *sampling.TraceData) (*tenant, error) {
+	name := ts.tenantName(trace)
+	if t, ok := ts.tenants[name]; ok {
+		return t, nil
+	}
+
+	if len(ts.tenants) >= ts.cfg.MaxTenants {
+		if ts.overflow == nil {
+			t, err := ts.newTenant(overflowTenant, ts.cfg.SpansPerSecond)
+			if err != nil {
+				return nil, err
+			}
+			ts.overflow = t
+		}
+		return ts.overflow, nil
+	}
+
+	sps, ok := ts.cfg.Overrides[name]
+	if !ok {
+		sps = ts.cfg.SpansPerSecond
+	}
+	t, err := ts.newTenant(name, sps)
+	if err != nil {
+		return nil, err
+	}
+	ts.tenants[name] = t
+	return t, nil
+}
+
+func (ts *tenantSampler) newTenant(name string, spansPerSecond int64) (*tenant, error) {
+	ctx, err := tag.New(ts.ctx, tag.Upsert(tagTenantKey, name))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := ts.settings
+	settings.Logger = settings.Logger.With(zap.String("tenant", name))
+	policies, err := newPolicies(ctx, settings, ts.policyCfgs)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tenant{
+		name:     name,
+		ctx:      ctx,
+		policies: policies,
+	}
+	if spansPerSecond > 0 {
+		t.budget = sampling.NewRateLimiting(settings, spansPerSecond)
+	}
+	return t, nil
+}
+
+// tenantName looks up the tenant attribute on the resources first and on the spans
+// of the trace afterwards.
+func (ts *tenantSampler) tenantName(trace *sampling.TraceData) string {
+	trace.Lock()
+	defer trace.Unlock()
+
+	rss := trace.ReceivedBatches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		if v, ok := rss.At(i).Resource().Attributes().Get(ts.cfg.AttributeKey); ok {
+			return v.AsString()
+		}
+	}
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if v, ok := spans.At(k).Attributes().Get(ts.cfg.AttributeKey); ok {
+					return v.AsString()
+				}
+			}
+		}
+	}
+	return unknownTenant
+}
+
+// applyBudget downgrades a sampled decision when the tenant has exhausted its budget
+// and records the resulting per-tenant decision.
+func (t *tenant) applyBudget(id pcommon.TraceID, trace *sampling.TraceData, decision sampling.Decision, mutators []tag.Mutator) sampling.Decision {
+	if decision == sampling.Sampled && t.budget != nil {
+		if d, _ := t.budget.Evaluate(t.ctx, id, trace); d != sampling.Sampled {
+			decision = sampling.NotSampled
+			stats.Record(t.ctx, statTenantBudgetExceeded.M(int64(1)))
+		}
+	}
+
+	if decision == sampling.Sampled {
+		mutators[0] = tagUpsertSampled
+	} else {
+		mutators[0] = tagUpsertNotSampled
+	}
+	_ = stats.RecordWithTags(t.ctx, mutators, statCountTenantTracesSampled.M(int64(1)))
+	return decision
+}
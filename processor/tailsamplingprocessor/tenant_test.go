@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestTenantSamplingBudgets(t *testing.T) {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}},
+		},
+		Tenants: TenantsCfg{
+			AttributeKey: "tenant.id",
+			// a budget of a single span per second never lets a trace through
+			SpansPerSecond: 1,
+			Overrides:      map[string]int64{"gold": 1000},
+			MaxTenants:     2,
+		},
+	}
+	sp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	tests := []struct {
+		name     string
+		trace    *sampling.TraceData
+		decision sampling.Decision
+	}{
+		{
+			name:     "resource attribute with override",
+			trace:    tenantTraceData(t, "gold", true),
+			decision: sampling.Sampled,
+		},
+		{
+			name:     "span attribute with default budget",
+			trace:    tenantTraceData(t, "free", false),
+			decision: sampling.NotSampled,
+		},
+		{
+			name:     "overflow",
+			trace:    tenantTraceData(t, "", false),
+			decision: sampling.NotSampled,
+		},
+		{
+			name:     "known tenant after overflow",
+			trace:    tenantTraceData(t, "gold", false),
+			decision: sampling.Sampled,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, _ := tsp.makeDecision(uInt64ToTraceID(uint64(i)), tt.trace, &policyMetrics{})
+			assert.Equal(t, tt.decision, decision)
+		})
+	}
+
+	require.Len(t, tsp.tenants.tenants, 2)
+	gold, free := tsp.tenants.tenants["gold"], tsp.tenants.tenants["free"]
+	require.NotNil(t, gold)
+	require.NotNil(t, free)
+	assert.NotSame(t, gold.policies[0], free.policies[0])
+	require.NotNil(t, tsp.tenants.overflow)
+	assert.Equal(t, overflowTenant, tsp.tenants.overflow.name)
+}
+
+func TestTenantsDisabled(t *testing.T) {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}},
+		},
+	}
+	sp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assert.Nil(t, tsp.tenants)
+
+	decision, _ := tsp.makeDecision(uInt64ToTraceID(1), tenantTraceData(t, "free", false), &policyMetrics{})
+	assert.Equal(t, sampling.Sampled, decision)
+}
+
+func tenantTraceData(t *testing.T, tenant string, onResource bool) *sampling.TraceData {
+	t.Helper()
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetSpanID(pcommon.SpanID([8]byte{1}))
+	switch {
+	case tenant == "":
+	case onResource:
+		rs.Resource().Attributes().PutStr("tenant.id", tenant)
+	default:
+		span.Attributes().PutStr("tenant.id", tenant)
+	}
+
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	return &sampling.TraceData{
+		Decisions:       []sampling.Decision{sampling.Pending},
+		ArrivalTime:     time.Now(),
+		SpanCount:       spanCount,
+		ReceivedBatches: traces,
+	}
+}
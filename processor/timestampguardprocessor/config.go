@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timestampguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/timestampguardprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// actionClamp corrects the wrong timestamps.
+	actionClamp = "clamp"
+	// actionTag flags the items with wrong timestamps with an attribute.
+	actionTag = "tag"
+	// actionDrop drops the items with wrong timestamps.
+	actionDrop = "drop"
+)
+
+// Config defines the configuration of the timestamp guard processor.
+type Config struct {
+	// MaxFuture is how far after the time of receipt a timestamp may be.
+	MaxFuture time.Duration `mapstructure:"max_future"`
+	// MaxPast is how far before the time of receipt a timestamp may be.
+	MaxPast time.Duration `mapstructure:"max_past"`
+	// TimezoneOffsets enables the detection of the timestamps shifted by a
+	// timezone offset, such as local times sent as UTC.
+	TimezoneOffsets bool `mapstructure:"timezone_offsets"`
+	// TimezoneTolerance is how far from a timezone offset the shift of a
+	// timestamp may be, to account for the delay of the delivery.
+	TimezoneTolerance time.Duration `mapstructure:"timezone_tolerance"`
+	// Action is what is done with the items with wrong timestamps: clamp, tag
+	// or drop.
+	Action string `mapstructure:"action"`
+	// Attribute is the attribute set to the reason why the timestamp is wrong
+	// when the action is tag.
+	Attribute string `mapstructure:"attribute"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxFuture < 0 {
+		return errors.New("max_future must not be negative")
+	}
+	if cfg.MaxPast <= 0 {
+		return errors.New("max_past must be positive")
+	}
+	if cfg.TimezoneOffsets && (cfg.TimezoneTolerance < 0 || cfg.TimezoneTolerance >= timezoneGranularity/2) {
+		return fmt.Errorf("timezone_tolerance must be between 0 and %v", timezoneGranularity/2)
+	}
+	switch cfg.Action {
+	case actionClamp, actionDrop:
+	case actionTag:
+		if cfg.Attribute == "" {
+			return errors.New("attribute must be set with the tag action")
+		}
+	default:
+		return fmt.Errorf("unsupported action %q, must be %q, %q or %q", cfg.Action, actionClamp, actionTag, actionDrop)
+	}
+	return nil
+}
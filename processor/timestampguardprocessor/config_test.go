@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timestampguardprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/timestampguardprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				MaxFuture:         time.Minute,
+				MaxPast:           168 * time.Hour,
+				TimezoneOffsets:   false,
+				TimezoneTolerance: defaultTimezoneTolerance,
+				Action:            actionTag,
+				Attribute:         "clock.skewed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "clamp",
+			config: Config{MaxFuture: time.Minute, MaxPast: time.Hour, Action: actionClamp},
+		},
+		{
+			name:   "negative max_future",
+			config: Config{MaxFuture: -time.Minute, MaxPast: time.Hour, Action: actionClamp},
+			err:    "max_future must not be negative",
+		},
+		{
+			name:   "zero max_past",
+			config: Config{Action: actionClamp},
+			err:    "max_past must be positive",
+		},
+		{
+			name:   "timezone tolerance too large",
+			config: Config{MaxPast: time.Hour, TimezoneOffsets: true, TimezoneTolerance: 10 * time.Minute, Action: actionClamp},
+			err:    "timezone_tolerance must be between 0 and 7m30s",
+		},
+		{
+			name:   "tag without attribute",
+			config: Config{MaxPast: time.Hour, Action: actionTag},
+			err:    "attribute must be set with the tag action",
+		},
+		{
+			name:   "unsupported action",
+			config: Config{MaxPast: time.Hour, Action: "fix"},
+			err:    `unsupported action "fix", must be "clamp", "tag" or "drop"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package timestampguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/timestampguardprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/timestampguardprocessor/internal/metadata"
+)
+
+const (
+	defaultMaxFuture         = 5 * time.Minute
+	defaultMaxPast           = 7 * 24 * time.Hour
+	defaultTimezoneTolerance = time.Minute
+	defaultAttribute         = "timestamp.anomaly"
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory creates a factory for the timestamp guard processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
+		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability),
+		processor.WithLogs(createLogsProcessor, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxFuture:         defaultMaxFuture,
+		MaxPast:           defaultMaxPast,
+		TimezoneOffsets:   true,
+		TimezoneTolerance: defaultTimezoneTolerance,
+		Action:            actionClamp,
+		Attribute:         defaultAttribute,
+	}
+}
+
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Traces,
+) (processor.Traces, error) {
+	guard := newTimestampGuard(cfg.(*Config), set.Logger)
+	return processorhelper.NewTracesProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		guard.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (processor.Metrics, error) {
+	guard := newTimestampGuard(cfg.(*Config), set.Logger)
+	return processorhelper.NewMetricsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		guard.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	guard := newTimestampGuard(cfg.(*Config), set.Logger)
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		guard.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timestampguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/timestampguardprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// reason tells why a timestamp is wrong.
+type reason string
+
+const (
+	reasonZero           reason = "zero"
+	reasonFuture         reason = "future"
+	reasonPast           reason = "past"
+	reasonTimezoneOffset reason = "timezone_offset"
+)
+
+const (
+	// timezoneGranularity is the granularity of the timezone offsets, some of
+	// them being 30 or 45 minutes off the hour.
+	timezoneGranularity = 15 * time.Minute
+	maxTimezoneOffset   = 14 * time.Hour
+)
+
+type timestampGuard struct {
+	config *Config
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func newTimestampGuard(config *Config, logger *zap.Logger) *timestampGuard {
+	return &timestampGuard{
+		config: config,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// check returns why ts is wrong, if it is, and what has to be added to it to
+// correct it. The correction of a zero timestamp is left to the caller.
+func (g *timestampGuard) check(ts pcommon.Timestamp, now time.Time) (reason, time.Duration) {
+	if ts == 0 {
+		return reasonZero, 0
+	}
+
+	offset := ts.AsTime().Sub(now)
+	// The whole hour offsets, which most timezones have, are detected even
+	// within the bounds, a local time being a few hours off only.
+	if g.config.TimezoneOffsets {
+		if correction, ok := g.timezoneCorrection(offset, time.Hour); ok {
+			return reasonTimezoneOffset, correction
+		}
+	}
+
+	var r reason
+	switch {
+	case offset > g.config.MaxFuture:
+		r = reasonFuture
+	case -offset > g.config.MaxPast:
+		r = reasonPast
+	default:
+		return "", 0
+	}
+
+	if g.config.TimezoneOffsets {
+		if correction, ok := g.timezoneCorrection(offset, timezoneGranularity); ok {
+			return reasonTimezoneOffset, correction
+		}
+	}
+	return r, -offset
+}
+
+// timezoneCorrection returns the correction of offset if it is a timezone
+// offset, that is a non zero multiple of granularity up to 14 hours, within the
+// timezone tolerance.
+func (g *timestampGuard) timezoneCorrection(offset time.Duration, granularity time.Duration) (time.Duration, bool) {
+	tzOffset := offset.Round(granularity)
+	if tzOffset != 0 && abs(tzOffset) <= maxTimezoneOffset && abs(offset-tzOffset) <= g.config.TimezoneTolerance {
+		return -tzOffset, true
+	}
+	return 0, false
+}
+
+// apply applies the action to an item whose timestamp is wrong, returning
+// whether the item is kept and whether its timestamps have to be corrected.
+func (g *timestampGuard) apply(r reason, attributes pcommon.Map) (keep bool, correct bool) {
+	switch g.config.Action {
+	case actionDrop:
+		return false, false
+	case actionTag:
+		attributes.PutStr(g.config.Attribute, string(r))
+		return true, false
+	default:
+		return true, true
+	}
+}
+
+// batchStats counts the items of a batch whose timestamp is wrong.
+type batchStats struct {
+	wrong   int
+	dropped int
+}
+
+func (g *timestampGuard) logStats(stats batchStats) {
+	if stats.wrong > 0 {
+		g.logger.Debug("Found wrong timestamps",
+			zap.Int("items", stats.wrong),
+			zap.Int("dropped", stats.dropped),
+			zap.String("action", g.config.Action))
+	}
+}
+
+func (g *timestampGuard) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	now := g.now()
+	var stats batchStats
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		resourceDropped := stats.dropped
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			scopeDropped := stats.dropped
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !g.guardSpan(span, now, &stats)
+			})
+			return stats.dropped > scopeDropped && ss.Spans().Len() == 0
+		})
+		return stats.dropped > resourceDropped && rs.ScopeSpans().Len() == 0
+	})
+	g.logStats(stats)
+
+	if stats.dropped > 0 && td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// guardSpan checks the end timestamp of the span, or its start timestamp if it
+// has none, and returns whether the span is kept. The span is moved as a
+// whole, so that its duration is kept.
+func (g *timestampGuard) guardSpan(span ptrace.Span, now time.Time, stats *batchStats) bool {
+	ts := span.EndTimestamp()
+	if ts == 0 {
+		ts = span.StartTimestamp()
+	}
+	r, correction := g.check(ts, now)
+	if r == "" {
+		return true
+	}
+	stats.wrong++
+	keep, correct := g.apply(r, span.Attributes())
+	if !keep {
+		stats.dropped++
+		return false
+	}
+	if !correct {
+		return true
+	}
+
+	if r == reasonZero {
+		// Neither the start nor the end of the span is known.
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(now))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(now))
+		return true
+	}
+	span.SetStartTimestamp(shift(span.StartTimestamp(), correction))
+	span.SetEndTimestamp(shift(span.EndTimestamp(), correction))
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		events.At(i).SetTimestamp(shift(events.At(i).Timestamp(), correction))
+	}
+	return true
+}
+
+func (g *timestampGuard) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	now := g.now()
+	var stats batchStats
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		resourceDropped := stats.dropped
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			scopeDropped := stats.dropped
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !g.guardLogRecord(lr, now, &stats)
+			})
+			return stats.dropped > scopeDropped && sl.LogRecords().Len() == 0
+		})
+		return stats.dropped > resourceDropped && rl.ScopeLogs().Len() == 0
+	})
+	g.logStats(stats)
+
+	if stats.dropped > 0 && ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// guardLogRecord checks the timestamp of the log record, and returns whether
+// the record is kept. The timestamp of a log record being optional, records
+// without one are left as is.
+func (g *timestampGuard) guardLogRecord(lr plog.LogRecord, now time.Time, stats *batchStats) bool {
+	if lr.Timestamp() == 0 {
+		return true
+	}
+	r, correction := g.check(lr.Timestamp(), now)
+	if r == "" {
+		return true
+	}
+	stats.wrong++
+	keep, correct := g.apply(r, lr.Attributes())
+	if !keep {
+		stats.dropped++
+		return false
+	}
+	if correct {
+		lr.SetTimestamp(shift(lr.Timestamp(), correction))
+	}
+	return true
+}
+
+func (g *timestampGuard) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	now := g.now()
+	var stats batchStats
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resourceDropped := stats.dropped
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scopeDropped := stats.dropped
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return !g.guardMetric(m, now, &stats)
+			})
+			return stats.dropped > scopeDropped && sm.Metrics().Len() == 0
+		})
+		return stats.dropped > resourceDropped && rm.ScopeMetrics().Len() == 0
+	})
+	g.logStats(stats)
+
+	if stats.dropped > 0 && md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// guardMetric checks the data points of the metric, and returns whether the
+// metric is kept, which it is not once all its data points were dropped.
+func (g *timestampGuard) guardMetric(m pmetric.Metric, now time.Time, stats *batchStats) bool {
+	dropped := stats.dropped
+	var remaining int
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !g.guardDataPoint(dp, now, stats) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !g.guardDataPoint(dp, now, stats) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return !g.guardDataPoint(dp, now, stats) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return !g.guardDataPoint(dp, now, stats) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return !g.guardDataPoint(dp, now, stats) })
+		remaining = dps.Len()
+	default:
+		return true
+	}
+	return stats.dropped == dropped || remaining > 0
+}
+
+// dataPoint is implemented by the data points of all the metric types.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// guardDataPoint checks the timestamp of the data point, and returns whether
+// the data point is kept. Its start timestamp is left as is, since moving it
+// would make the cumulative series look reset.
+func (g *timestampGuard) guardDataPoint(dp dataPoint, now time.Time, stats *batchStats) bool {
+	r, correction := g.check(dp.Timestamp(), now)
+	if r == "" {
+		return true
+	}
+	stats.wrong++
+	keep, correct := g.apply(r, dp.Attributes())
+	if !keep {
+		stats.dropped++
+		return false
+	}
+	if !correct {
+		return true
+	}
+
+	if r == reasonZero {
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+		return true
+	}
+	dp.SetTimestamp(shift(dp.Timestamp(), correction))
+	return true
+}
+
+// shift adds d to ts, leaving unset timestamps unset.
+func shift(ts pcommon.Timestamp, d time.Duration) pcommon.Timestamp {
+	if ts == 0 {
+		return 0
+	}
+	return pcommon.NewTimestampFromTime(ts.AsTime().Add(d))
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timestampguardprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+var receiveTime = time.Date(2023, 10, 30, 12, 0, 0, 0, time.UTC)
+
+func newTestGuard(config *Config) *timestampGuard {
+	g := newTimestampGuard(config, zap.NewNop())
+	g.now = func() time.Time { return receiveTime }
+	return g
+}
+
+func timestamp(offset time.Duration) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(receiveTime.Add(offset))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		ts         pcommon.Timestamp
+		reason     reason
+		correction time.Duration
+	}{
+		{
+			name:   "valid",
+			config: Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour},
+			ts:     timestamp(-time.Hour),
+		},
+		{
+			name:   "slightly in the future",
+			config: Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour},
+			ts:     timestamp(time.Minute),
+		},
+		{
+			name:   "zero",
+			config: Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour},
+			ts:     0,
+			reason: reasonZero,
+		},
+		{
+			name:       "future",
+			config:     Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour},
+			ts:         timestamp(10 * time.Minute),
+			reason:     reasonFuture,
+			correction: -10 * time.Minute,
+		},
+		{
+			name:       "past",
+			config:     Config{MaxPast: 24 * time.Hour},
+			ts:         timestamp(-48 * time.Hour),
+			reason:     reasonPast,
+			correction: 48 * time.Hour,
+		},
+		{
+			name:       "timezone offset in the future",
+			config:     Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:         timestamp(2*time.Hour + 20*time.Second),
+			reason:     reasonTimezoneOffset,
+			correction: -2 * time.Hour,
+		},
+		{
+			name:       "half hour timezone offset in the past",
+			config:     Config{MaxPast: time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:         timestamp(-5*time.Hour - 30*time.Minute - 30*time.Second),
+			reason:     reasonTimezoneOffset,
+			correction: 5*time.Hour + 30*time.Minute,
+		},
+		{
+			name:       "not a timezone offset",
+			config:     Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:         timestamp(2*time.Hour + 5*time.Minute),
+			reason:     reasonFuture,
+			correction: -2*time.Hour - 5*time.Minute,
+		},
+		{
+			name:       "beyond the timezone offsets",
+			config:     Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:         timestamp(24 * time.Hour),
+			reason:     reasonFuture,
+			correction: -24 * time.Hour,
+		},
+		{
+			name:       "whole hour timezone offset within max_past",
+			config:     Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:         timestamp(-3*time.Hour + 10*time.Second),
+			reason:     reasonTimezoneOffset,
+			correction: 3 * time.Hour,
+		},
+		{
+			name:   "past within max_past",
+			config: Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:     timestamp(-3*time.Hour - 10*time.Minute),
+		},
+		{
+			name:   "half hour past within max_past",
+			config: Config{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour, TimezoneOffsets: true, TimezoneTolerance: time.Minute},
+			ts:     timestamp(-30 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, correction := newTestGuard(&tt.config).check(tt.ts, receiveTime)
+			assert.Equal(t, tt.reason, r)
+			assert.Equal(t, tt.correction, correction)
+		})
+	}
+}
+
+func testConfig(action string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Action = action
+	return cfg
+}
+
+func TestProcessTraces(t *testing.T) {
+	newTraces := func() ptrace.Traces {
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		valid := spans.AppendEmpty()
+		valid.SetName("valid")
+		valid.SetStartTimestamp(timestamp(-time.Second))
+		valid.SetEndTimestamp(timestamp(0))
+		shifted := spans.AppendEmpty()
+		shifted.SetName("shifted")
+		shifted.SetStartTimestamp(timestamp(3*time.Hour - time.Second))
+		shifted.SetEndTimestamp(timestamp(3 * time.Hour))
+		shifted.Events().AppendEmpty().SetTimestamp(timestamp(3*time.Hour - time.Millisecond))
+		zero := spans.AppendEmpty()
+		zero.SetName("zero")
+		return td
+	}
+
+	t.Run("clamp", func(t *testing.T) {
+		td, err := newTestGuard(testConfig(actionClamp)).processTraces(context.Background(), newTraces())
+		require.NoError(t, err)
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		require.Equal(t, 3, spans.Len())
+		assert.Equal(t, timestamp(0), spans.At(0).EndTimestamp())
+		// The span is moved back by the timezone offset, keeping its duration.
+		assert.Equal(t, timestamp(-time.Second), spans.At(1).StartTimestamp())
+		assert.Equal(t, timestamp(0), spans.At(1).EndTimestamp())
+		assert.Equal(t, timestamp(-time.Millisecond), spans.At(1).Events().At(0).Timestamp())
+		assert.Equal(t, timestamp(0), spans.At(2).StartTimestamp())
+		assert.Equal(t, timestamp(0), spans.At(2).EndTimestamp())
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		td, err := newTestGuard(testConfig(actionTag)).processTraces(context.Background(), newTraces())
+		require.NoError(t, err)
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		require.Equal(t, 3, spans.Len())
+		_, ok := spans.At(0).Attributes().Get(defaultAttribute)
+		assert.False(t, ok)
+		assert.Equal(t, map[string]any{defaultAttribute: "timezone_offset"}, spans.At(1).Attributes().AsRaw())
+		assert.Equal(t, timestamp(3*time.Hour), spans.At(1).EndTimestamp())
+		assert.Equal(t, map[string]any{defaultAttribute: "zero"}, spans.At(2).Attributes().AsRaw())
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		td, err := newTestGuard(testConfig(actionDrop)).processTraces(context.Background(), newTraces())
+		require.NoError(t, err)
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		require.Equal(t, 1, spans.Len())
+		assert.Equal(t, "valid", spans.At(0).Name())
+	})
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	// The timestamp of a log record is optional.
+	records.AppendEmpty().Body().SetStr("no timestamp")
+	future := records.AppendEmpty()
+	future.Body().SetStr("future")
+	future.SetTimestamp(timestamp(365 * 24 * time.Hour))
+
+	ld, err := newTestGuard(testConfig(actionClamp)).processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	records = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, pcommon.Timestamp(0), records.At(0).Timestamp())
+	assert.Equal(t, timestamp(0), records.At(1).Timestamp())
+
+	// Only the scopes and resources left empty by the drops are removed.
+	dropped := plog.NewLogs()
+	droppedRecords := dropped.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	droppedRecords.AppendEmpty().SetTimestamp(timestamp(time.Hour))
+	droppedRecords.AppendEmpty().SetTimestamp(timestamp(0))
+	dropped.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	dropped.ResourceLogs().AppendEmpty()
+	dropped, err = newTestGuard(testConfig(actionDrop)).processLogs(context.Background(), dropped)
+	require.NoError(t, err)
+	require.Equal(t, 3, dropped.ResourceLogs().Len())
+	assert.Equal(t, 1, dropped.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().Len())
+	assert.Equal(t, 1, dropped.ResourceLogs().At(1).ScopeLogs().Len())
+
+	// Dropping all the records skips the batch.
+	records.At(0).SetTimestamp(timestamp(time.Hour))
+	records.At(1).SetTimestamp(timestamp(time.Hour))
+	_, err = newTestGuard(testConfig(actionDrop)).processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestProcessMetrics(t *testing.T) {
+	newMetrics := func() pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		gauge := metrics.AppendEmpty()
+		gauge.SetName("gauge")
+		gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(timestamp(0))
+		sum := metrics.AppendEmpty()
+		sum.SetName("sum")
+		dp := sum.SetEmptySum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(timestamp(-time.Minute - 10*time.Hour))
+		dp.SetTimestamp(timestamp(-10 * time.Hour))
+		histogram := metrics.AppendEmpty()
+		histogram.SetName("histogram")
+		histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+		return md
+	}
+
+	cfg := testConfig(actionClamp)
+	cfg.MaxPast = time.Hour
+	md, err := newTestGuard(cfg).processMetrics(context.Background(), newMetrics())
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+	assert.Equal(t, timestamp(0), metrics.At(0).Gauge().DataPoints().At(0).Timestamp())
+	// The start timestamp is left as is, so that cumulative series are not reset.
+	assert.Equal(t, timestamp(-time.Minute-10*time.Hour), metrics.At(1).Sum().DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, timestamp(0), metrics.At(1).Sum().DataPoints().At(0).Timestamp())
+	assert.Equal(t, timestamp(0), metrics.At(2).Histogram().DataPoints().At(0).Timestamp())
+
+	// The metrics left without data points are removed.
+	cfg = testConfig(actionDrop)
+	cfg.MaxPast = time.Hour
+	md, err = newTestGuard(cfg).processMetrics(context.Background(), newMetrics())
+	require.NoError(t, err)
+	metrics = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "gauge", metrics.At(0).Name())
+}
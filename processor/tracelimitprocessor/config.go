@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracelimitprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration of the trace limit processor.
+type Config struct {
+	// MaxSpansPerTrace is the maximum number of spans forwarded for a trace, 0
+	// meaning no limit.
+	MaxSpansPerTrace int `mapstructure:"max_spans_per_trace"`
+	// MaxEventsPerSpan is the maximum number of events kept in a span, 0 meaning
+	// no limit.
+	MaxEventsPerSpan int `mapstructure:"max_events_per_span"`
+	// MaxAttributesPerSpan is the maximum number of attributes kept in a span, 0
+	// meaning no limit.
+	MaxAttributesPerSpan int `mapstructure:"max_attributes_per_span"`
+	// NumTraces is the number of traces whose forwarded spans are counted across
+	// batches.
+	NumTraces int `mapstructure:"num_traces"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxSpansPerTrace < 0 || cfg.MaxEventsPerSpan < 0 || cfg.MaxAttributesPerSpan < 0 {
+		return errors.New("limits must not be negative")
+	}
+	if cfg.MaxSpansPerTrace > 0 && cfg.NumTraces <= 0 {
+		return errors.New("num_traces must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package tracelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracelimitprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracelimitprocessor/internal/metadata"
+)
+
+const (
+	defaultMaxSpansPerTrace     = 10000
+	defaultMaxEventsPerSpan     = 128
+	defaultMaxAttributesPerSpan = 128
+	defaultNumTraces            = 100000
+)
+
+// NewFactory creates a factory for the trace limit processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxSpansPerTrace:     defaultMaxSpansPerTrace,
+		MaxEventsPerSpan:     defaultMaxEventsPerSpan,
+		MaxAttributesPerSpan: defaultMaxAttributesPerSpan,
+		NumTraces:            defaultNumTraces,
+	}
+}
+
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	next consumer.Traces,
+) (processor.Traces, error) {
+	limiter := newTraceLimiter(cfg.(*Config), set.Logger)
+
+	return processorhelper.NewTracesProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		limiter.processTraces,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
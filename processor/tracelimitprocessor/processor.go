@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracelimitprocessor"
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// truncatedAttribute flags the spans forwarded for a trace whose other spans
+	// were dropped.
+	truncatedAttribute = "tracelimit.truncated"
+
+	exceptionEventName = "exception"
+)
+
+type traceLimiter struct {
+	config *Config
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	states *traceStates
+}
+
+func newTraceLimiter(config *Config, logger *zap.Logger) *traceLimiter {
+	l := &traceLimiter{
+		config: config,
+		logger: logger,
+	}
+	if config.MaxSpansPerTrace > 0 {
+		l.states = newTraceStates(config.NumTraces)
+	}
+	return l
+}
+
+// spanRef locates a span in a batch.
+type spanRef struct {
+	resource, scope, index int
+}
+
+type traceSpan struct {
+	spanRef
+	span ptrace.Span
+}
+
+func (l *traceLimiter) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	traces := make(map[pcommon.TraceID][]traceSpan)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				l.limitEvents(span)
+				l.limitAttributes(span)
+				if l.states != nil {
+					traces[span.TraceID()] = append(traces[span.TraceID()], traceSpan{spanRef: spanRef{i, j, k}, span: span})
+				}
+			}
+		}
+	}
+	if l.states == nil {
+		return td, nil
+	}
+
+	dropped := l.limitSpans(traces)
+	if len(dropped) == 0 {
+		return td, nil
+	}
+	l.logger.Debug("Dropped the spans of traces exceeding max_spans_per_trace", zap.Int("spans", len(dropped)))
+
+	i := 0
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		j := 0
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			k := 0
+			ss.Spans().RemoveIf(func(ptrace.Span) bool {
+				_, drop := dropped[spanRef{i, j, k}]
+				k++
+				return drop
+			})
+			j++
+			return ss.Spans().Len() == 0
+		})
+		i++
+		return rs.ScopeSpans().Len() == 0
+	})
+	return td, nil
+}
+
+// limitSpans returns the spans of the batch exceeding the span budget of their
+// traces. The spans kept are the roots first, then the spans with an error
+// status, then the slowest spans. As the spans of a trace are spread across
+// batches, part of the budget of a trace is reserved for the spans that have
+// not arrived yet but would be kept first: one span for the root until it is
+// forwarded, and a tenth of the budget for the spans with an error status.
+func (l *traceLimiter) limitSpans(traces map[pcommon.TraceID][]traceSpan) map[spanRef]struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dropped := make(map[spanRef]struct{})
+	for traceID, spans := range traces {
+		state := l.states.get(traceID)
+		sort.SliceStable(spans, func(i, j int) bool {
+			return retainedBefore(spans[i].span, spans[j].span)
+		})
+
+		var forwarded []ptrace.Span
+		for _, s := range spans {
+			if !l.forward(state, s.span) {
+				dropped[s.spanRef] = struct{}{}
+				state.truncated = true
+				continue
+			}
+			forwarded = append(forwarded, s.span)
+		}
+		if state.truncated {
+			for _, span := range forwarded {
+				span.Attributes().PutBool(truncatedAttribute, true)
+			}
+		}
+	}
+	return dropped
+}
+
+// forward counts span in the state of its trace if it fits in the budget left
+// for its priority, and returns whether it does.
+func (l *traceLimiter) forward(state *traceState, span ptrace.Span) bool {
+	budget := l.config.MaxSpansPerTrace - state.forwarded
+	isRoot := span.ParentSpanID().IsEmpty()
+	isError := span.Status().Code() == ptrace.StatusCodeError
+	if !isRoot && !state.rootForwarded {
+		budget--
+	}
+	if !isRoot && !isError {
+		if reserved := l.config.MaxSpansPerTrace/10 - state.errors; reserved > 0 {
+			budget -= reserved
+		}
+	}
+	if budget <= 0 {
+		return false
+	}
+
+	state.forwarded++
+	if isRoot {
+		state.rootForwarded = true
+	}
+	if isError {
+		state.errors++
+	}
+	return true
+}
+
+// retainedBefore reports whether span a is kept in priority over span b.
+func retainedBefore(a, b ptrace.Span) bool {
+	if aRoot, bRoot := a.ParentSpanID().IsEmpty(), b.ParentSpanID().IsEmpty(); aRoot != bRoot {
+		return aRoot
+	}
+	if aErr, bErr := a.Status().Code() == ptrace.StatusCodeError, b.Status().Code() == ptrace.StatusCodeError; aErr != bErr {
+		return aErr
+	}
+	if aDuration, bDuration := duration(a), duration(b); aDuration != bDuration {
+		return aDuration > bDuration
+	}
+	aID, bID := a.SpanID(), b.SpanID()
+	return bytes.Compare(aID[:], bID[:]) < 0
+}
+
+func duration(span ptrace.Span) uint64 {
+	if span.EndTimestamp() < span.StartTimestamp() {
+		return 0
+	}
+	return uint64(span.EndTimestamp() - span.StartTimestamp())
+}
+
+// limitEvents keeps the exception events first, then the earliest events.
+func (l *traceLimiter) limitEvents(span ptrace.Span) {
+	events := span.Events()
+	limit := l.config.MaxEventsPerSpan
+	if limit == 0 || events.Len() <= limit {
+		return
+	}
+
+	keep := make([]bool, events.Len())
+	kept := 0
+	for i := 0; i < events.Len() && kept < limit; i++ {
+		if events.At(i).Name() == exceptionEventName {
+			keep[i] = true
+			kept++
+		}
+	}
+	for i := 0; i < events.Len() && kept < limit; i++ {
+		if !keep[i] {
+			keep[i] = true
+			kept++
+		}
+	}
+
+	dropped := events.Len() - limit
+	i := 0
+	events.RemoveIf(func(ptrace.SpanEvent) bool {
+		drop := !keep[i]
+		i++
+		return drop
+	})
+	span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(dropped))
+}
+
+// limitAttributes keeps the first attributes of the span.
+func (l *traceLimiter) limitAttributes(span ptrace.Span) {
+	attrs := span.Attributes()
+	limit := l.config.MaxAttributesPerSpan
+	if limit == 0 || attrs.Len() <= limit {
+		return
+	}
+
+	dropped := attrs.Len() - limit
+	i := 0
+	attrs.RemoveIf(func(string, pcommon.Value) bool {
+		i++
+		return i > limit
+	})
+	span.SetDroppedAttributesCount(span.DroppedAttributesCount() + uint32(dropped))
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracelimitprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func appendSpan(spans ptrace.SpanSlice, traceID pcommon.TraceID, spanID pcommon.SpanID, parentID pcommon.SpanID, duration pcommon.Timestamp) ptrace.Span {
+	span := spans.AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	span.SetParentSpanID(parentID)
+	span.SetName(string(rune('a' + spanID[0])))
+	span.SetStartTimestamp(1)
+	span.SetEndTimestamp(1 + duration)
+	return span
+}
+
+// spanNames returns the names of the spans of td, and whether they are flagged
+// as truncated.
+func spanNames(td ptrace.Traces) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				_, truncated := spans.At(k).Attributes().Get(truncatedAttribute)
+				names[spans.At(k).Name()] = truncated
+			}
+		}
+	}
+	return names
+}
+
+func TestLimitSpans(t *testing.T) {
+	limiter := newTraceLimiter(&Config{MaxSpansPerTrace: 3, NumTraces: 10}, zap.NewNop())
+
+	td := ptrace.NewTraces()
+	large, small := pcommon.TraceID{1}, pcommon.TraceID{2}
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, large, pcommon.SpanID{1}, pcommon.SpanID{}, 1)
+	appendSpan(spans, large, pcommon.SpanID{2}, pcommon.SpanID{1}, 20)
+	appendSpan(spans, large, pcommon.SpanID{3}, pcommon.SpanID{1}, 5).Status().SetCode(ptrace.StatusCodeError)
+	appendSpan(spans, small, pcommon.SpanID{4}, pcommon.SpanID{}, 1)
+	// The resources left without spans are removed
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, large, pcommon.SpanID{5}, pcommon.SpanID{1}, 10)
+	appendSpan(spans, large, pcommon.SpanID{6}, pcommon.SpanID{1}, 10)
+
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 1, td.ResourceSpans().Len())
+	assert.Equal(t, map[string]bool{
+		"b": true,  // root
+		"d": true,  // error
+		"c": true,  // slowest
+		"e": false, // other trace
+	}, spanNames(td))
+}
+
+func TestLimitSpansAcrossBatches(t *testing.T) {
+	limiter := newTraceLimiter(&Config{MaxSpansPerTrace: 3, NumTraces: 10}, zap.NewNop())
+	traceID := pcommon.TraceID{1}
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, traceID, pcommon.SpanID{1}, pcommon.SpanID{9}, 1)
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"b": false}, spanNames(td))
+
+	// A span is reserved for the root. Spans with the same priority are kept by
+	// span ID.
+	td = ptrace.NewTraces()
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, traceID, pcommon.SpanID{3}, pcommon.SpanID{9}, 1)
+	appendSpan(spans, traceID, pcommon.SpanID{2}, pcommon.SpanID{9}, 1)
+	td, err = limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"c": true}, spanNames(td))
+
+	// The root arriving last is kept
+	td = ptrace.NewTraces()
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, traceID, pcommon.SpanID{5}, pcommon.SpanID{9}, 1)
+	appendSpan(spans, traceID, pcommon.SpanID{4}, pcommon.SpanID{}, 1)
+	td, err = limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"e": true}, spanNames(td))
+
+	// The budget of the trace is exhausted
+	td = ptrace.NewTraces()
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, traceID, pcommon.SpanID{6}, pcommon.SpanID{}, 1)
+	td, err = limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 0, td.SpanCount())
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestLimitSpansReservesErrors(t *testing.T) {
+	limiter := newTraceLimiter(&Config{MaxSpansPerTrace: 20, NumTraces: 10}, zap.NewNop())
+	traceID := pcommon.TraceID{1}
+
+	// The root and 2 spans with an error status are reserved a span each
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 1; i <= 20; i++ {
+		appendSpan(spans, traceID, pcommon.SpanID{byte(i)}, pcommon.SpanID{99}, 1)
+	}
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 17, td.SpanCount())
+
+	td = ptrace.NewTraces()
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	appendSpan(spans, traceID, pcommon.SpanID{21}, pcommon.SpanID{99}, 1)
+	appendSpan(spans, traceID, pcommon.SpanID{22}, pcommon.SpanID{99}, 1).Status().SetCode(ptrace.StatusCodeError)
+	appendSpan(spans, traceID, pcommon.SpanID{23}, pcommon.SpanID{99}, 1).Status().SetCode(ptrace.StatusCodeError)
+	appendSpan(spans, traceID, pcommon.SpanID{24}, pcommon.SpanID{}, 1)
+	td, err = limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		string(rune('a' + 22)): true,
+		string(rune('a' + 23)): true,
+		string(rune('a' + 24)): true,
+	}, spanNames(td))
+}
+
+func TestLimitEvents(t *testing.T) {
+	limiter := newTraceLimiter(&Config{MaxEventsPerSpan: 2}, zap.NewNop())
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetDroppedEventsCount(1)
+	for _, name := range []string{"first", "second", "third", exceptionEventName} {
+		span.Events().AppendEmpty().SetName(name)
+	}
+
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	span = td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	require.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, "first", span.Events().At(0).Name())
+	assert.Equal(t, exceptionEventName, span.Events().At(1).Name())
+	assert.Equal(t, uint32(3), span.DroppedEventsCount())
+}
+
+func TestLimitAttributes(t *testing.T) {
+	limiter := newTraceLimiter(&Config{MaxAttributesPerSpan: 2}, zap.NewNop())
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("a", "1")
+	span.Attributes().PutStr("b", "2")
+	span.Attributes().PutStr("c", "3")
+
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	span = td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, map[string]any{"a": "1", "b": "2"}, span.Attributes().AsRaw())
+	assert.Equal(t, uint32(1), span.DroppedAttributesCount())
+}
+
+func TestNoLimits(t *testing.T) {
+	limiter := newTraceLimiter(&Config{}, zap.NewNop())
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 10; i++ {
+		span := appendSpan(spans, pcommon.TraceID{1}, pcommon.SpanID{byte(i)}, pcommon.SpanID{}, 1)
+		span.Events().AppendEmpty()
+		span.Attributes().PutInt("i", int64(i))
+	}
+	expected := ptrace.NewTraces()
+	td.CopyTo(expected)
+
+	td, err := limiter.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
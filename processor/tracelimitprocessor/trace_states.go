@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracelimitprocessor"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// traceState is what is known of the spans already forwarded for a trace.
+type traceState struct {
+	// forwarded is the number of spans forwarded.
+	forwarded int
+	// errors is the number of spans with an error status forwarded.
+	errors int
+	// rootForwarded is whether the root span was forwarded.
+	rootForwarded bool
+	// truncated is whether spans of the trace were dropped.
+	truncated bool
+}
+
+// traceStates keeps the state of the most recent traces in a bounded buffer,
+// the oldest trace being evicted when the buffer is full.
+type traceStates struct {
+	index  int
+	ids    []pcommon.TraceID
+	states map[pcommon.TraceID]*traceState
+}
+
+func newTraceStates(size int) *traceStates {
+	return &traceStates{
+		index:  -1, // the first trace to be tracked will be placed at position '0'
+		ids:    make([]pcommon.TraceID, size),
+		states: make(map[pcommon.TraceID]*traceState),
+	}
+}
+
+// get returns the state of traceID, tracking it if it is not yet.
+func (s *traceStates) get(traceID pcommon.TraceID) *traceState {
+	if state, found := s.states[traceID]; found {
+		return state
+	}
+	s.index = (s.index + 1) % len(s.ids)
+	if evicted := s.ids[s.index]; !evicted.IsEmpty() {
+		delete(s.states, evicted)
+	}
+	s.ids[s.index] = traceID
+	state := &traceState{}
+	s.states[traceID] = state
+	return state
+}
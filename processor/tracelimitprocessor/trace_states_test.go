@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracelimitprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTraceStates(t *testing.T) {
+	states := newTraceStates(2)
+	states.get(pcommon.TraceID{1}).forwarded = 3
+	states.get(pcommon.TraceID{1}).forwarded += 2
+	states.get(pcommon.TraceID{2}).forwarded = 1
+	assert.Equal(t, 5, states.get(pcommon.TraceID{1}).forwarded)
+	assert.Equal(t, 1, states.get(pcommon.TraceID{2}).forwarded)
+
+	// The oldest trace is evicted
+	states.get(pcommon.TraceID{3}).forwarded = 4
+	assert.Len(t, states.states, 2)
+	assert.Equal(t, 1, states.get(pcommon.TraceID{2}).forwarded)
+	assert.Equal(t, 4, states.get(pcommon.TraceID{3}).forwarded)
+	assert.Equal(t, 0, states.get(pcommon.TraceID{1}).forwarded)
+}
@@ -4,6 +4,8 @@
 package transformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -24,6 +26,15 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// Macros are named groups of statements and conditions that can be referenced from the statements.
+	Macros ottl.Macros `mapstructure:"macros"`
+
+	// MacroFiles are YAML files defining macros, shared with other components.
+	MacroFiles []string `mapstructure:"macro_files"`
+
+	// GeoIP configures the databases used by the GeoIP function.
+	GeoIP common.GeoIPConfig `mapstructure:"geoip"`
+
 	TraceStatements  []common.ContextStatements `mapstructure:"trace_statements"`
 	MetricStatements []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
@@ -34,12 +45,29 @@ var _ component.Config = (*Config)(nil)
 func (c *Config) Validate() error {
 	var errors error
 
-	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+	macros, err := c.loadMacros()
+	if err != nil {
+		return err
+	}
+	traceStatements, err := expandMacros(macros, c.TraceStatements)
+	if err != nil {
+		return err
+	}
+	metricStatements, err := expandMacros(macros, c.MetricStatements)
+	if err != nil {
+		return err
+	}
+	logStatements, err := expandMacros(macros, c.LogStatements)
+	if err != nil {
+		return err
+	}
+
+	if len(traceStatements) > 0 {
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()), common.WithTraceGeoIP(c.GeoIP))
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.TraceStatements {
+		for _, cs := range traceStatements {
 			_, err = pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
@@ -47,12 +75,12 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()))
+	if len(metricStatements) > 0 {
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()), common.WithMetricGeoIP(c.GeoIP))
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.MetricStatements {
+		for _, cs := range metricStatements {
 			_, err := pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
@@ -60,12 +88,12 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()))
+	if len(logStatements) > 0 {
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()), common.WithLogGeoIP(c.GeoIP))
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.LogStatements {
+		for _, cs := range logStatements {
 			_, err = pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
@@ -75,3 +103,38 @@ func (c *Config) Validate() error {
 
 	return errors
 }
+
+// loadMacros returns the macros of the configuration and of the macro files.
+func (c *Config) loadMacros() (ottl.Macros, error) {
+	macros, err := ottl.LoadMacros(c.Macros, c.MacroFiles)
+	if err != nil {
+		return nil, err
+	}
+	return macros, macros.ValidateNames(functionNames())
+}
+
+// functionNames returns the names of the functions of all the contexts.
+func functionNames() []string {
+	var names []string
+	names = append(names, ottl.FunctionNames(common.ResourceFunctions())...)
+	names = append(names, ottl.FunctionNames(common.ScopeFunctions())...)
+	names = append(names, ottl.FunctionNames(traces.SpanFunctions())...)
+	names = append(names, ottl.FunctionNames(traces.SpanEventFunctions())...)
+	names = append(names, ottl.FunctionNames(metrics.MetricFunctions())...)
+	names = append(names, ottl.FunctionNames(metrics.DataPointFunctions())...)
+	names = append(names, ottl.FunctionNames(logs.LogFunctions())...)
+	return names
+}
+
+// expandMacros returns the statements with the references to the macros replaced by their bodies.
+func expandMacros(macros ottl.Macros, contextStatements []common.ContextStatements) ([]common.ContextStatements, error) {
+	expanded := make([]common.ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		statements, err := macros.ExpandStatements(cs.Statements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand the %s statements: %w", cs.Context, err)
+		}
+		expanded[i] = common.ContextStatements{Context: cs.Context, Statements: statements}
+	}
+	return expanded, nil
+}
@@ -92,6 +92,70 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macros"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Macros: ottl.Macros{
+					"redact": {
+						Params:     []string{"target"},
+						Statements: []string{`replace_pattern($target, "[0-9]+", "?")`},
+					},
+					"is_health_check": {
+						Params:    []string{"path"},
+						Condition: `$path == "/health" or $path == "/ready"`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Context: "span",
+						Statements: []string{
+							`redact(attributes["db.statement"]) where not is_health_check(attributes["http.target"])`,
+						},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements:    []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "unknown_macro_param"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macro_files"),
+			expected: &Config{
+				ErrorMode:  ottl.PropagateError,
+				MacroFiles: []string{"testdata/macros.yaml"},
+				TraceStatements: []common.ContextStatements{
+					{
+						Context:    "span",
+						Statements: []string{`redact(attributes["db.statement"])`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements:    []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macro_function_name"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "geoip"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				GeoIP: common.GeoIPConfig{
+					Databases: []string{"/usr/share/GeoIP/GeoLite2-City.mmdb", "/usr/share/GeoIP/GeoLite2-ASN.mmdb"},
+				},
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context:    "log",
+						Statements: []string{`set(attributes["client.address"], attributes["net.sock.peer.addr"])`},
+					},
+				},
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 		},
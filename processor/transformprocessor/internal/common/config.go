@@ -35,3 +35,11 @@ type ContextStatements struct {
 	Context    ContextID `mapstructure:"context"`
 	Statements []string  `mapstructure:"statements"`
 }
+
+// GeoIPConfig configures the GeoIP function.
+type GeoIPConfig struct {
+	// Databases are the paths of the MaxMind databases, in the MMDB format, the IP addresses are looked up in.
+	// The fields found in all of them are returned, so that the City and ASN databases can be combined.
+	// The databases are reloaded when the files change.
+	Databases []string `mapstructure:"databases"`
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type GeoIPArguments[K any] struct {
+	IP ottl.StringGetter[K]
+}
+
+func NewGeoIPFactory[K any](cfg *GeoIPConfig) ottl.Factory[K] {
+	return ottl.NewFactory("GeoIP", &GeoIPArguments[K]{}, createGeoIPFunction[K](cfg))
+}
+
+func createGeoIPFunction[K any](cfg *GeoIPConfig) ottl.CreateFunctionFunc[K] {
+	return func(fCtx ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*GeoIPArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("GeoIPFactory args must be of type *GeoIPArguments[K]")
+		}
+
+		if len(cfg.Databases) == 0 {
+			return nil, errors.New("GeoIP: no database configured, see the geoip setting of the processor")
+		}
+		databases := make([]*geoIPDatabase, len(cfg.Databases))
+		for i, path := range cfg.Databases {
+			db, err := getGeoIPDatabase(path, fCtx.Set.Logger)
+			if err != nil {
+				return nil, fmt.Errorf("GeoIP: %w", err)
+			}
+			databases[i] = db
+		}
+		return geoIP(databases, args.IP), nil
+	}
+}
+
+func geoIP[K any](databases []*geoIPDatabase, target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		attrs := pcommon.NewMap()
+		ip := net.ParseIP(val)
+		if ip == nil {
+			// like the addresses missing from the databases, so that
+			// telemetry from clients without address is still processed.
+			return attrs, nil
+		}
+		for _, db := range databases {
+			if err := db.lookup(ip, attrs); err != nil {
+				return nil, err
+			}
+		}
+		return attrs, nil
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// writeGeoIPDatabase writes a database holding the records of the given networks.
+func writeGeoIPDatabase(t *testing.T, path string, databaseType string, records map[string]mmdbtype.Map) {
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: databaseType, RecordSize: 24})
+	require.NoError(t, err)
+	for cidr, record := range records {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(network, record))
+	}
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+}
+
+func cityRecord(country string, city string) mmdbtype.Map {
+	return mmdbtype.Map{
+		"continent": mmdbtype.Map{"code": mmdbtype.String("EU")},
+		"country": mmdbtype.Map{
+			"iso_code": mmdbtype.String(country),
+			"names":    mmdbtype.Map{"en": mmdbtype.String("Country " + country)},
+		},
+		"city":     mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String(city)}},
+		"location": mmdbtype.Map{"latitude": mmdbtype.Float64(48.85), "longitude": mmdbtype.Float64(2.35)},
+	}
+}
+
+func Test_GeoIP(t *testing.T) {
+	dir := t.TempDir()
+	cityPath := filepath.Join(dir, "city.mmdb")
+	asnPath := filepath.Join(dir, "asn.mmdb")
+	writeGeoIPDatabase(t, cityPath, "GeoIP2-City", map[string]mmdbtype.Map{
+		"81.2.69.0/24": cityRecord("FR", "Paris"),
+	})
+	writeGeoIPDatabase(t, asnPath, "GeoLite2-ASN", map[string]mmdbtype.Map{
+		"81.2.0.0/16": {
+			"autonomous_system_number":       mmdbtype.Uint32(20712),
+			"autonomous_system_organization": mmdbtype.String("Andrews & Arnold Ltd"),
+		},
+	})
+
+	factory := NewGeoIPFactory[any](&GeoIPConfig{Databases: []string{cityPath, asnPath}})
+	newGeoIP := func(ip string) ottl.ExprFunc[any] {
+		exprFunc, err := factory.CreateFunction(
+			ottl.FunctionContext{Set: componenttest.NewNopTelemetrySettings()},
+			&GeoIPArguments[any]{
+				IP: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return ip, nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+		return exprFunc
+	}
+
+	result, err := newGeoIP("81.2.69.160")(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"geo.continent_code":   "EU",
+		"geo.country_iso_code": "FR",
+		"geo.country_name":     "Country FR",
+		"geo.locality.name":    "Paris",
+		"geo.location.lat":     48.85,
+		"geo.location.lon":     2.35,
+		"as.number":            int64(20712),
+		"as.organization.name": "Andrews & Arnold Ltd",
+	}, result.(pcommon.Map).AsRaw())
+
+	result, err = newGeoIP("81.2.70.1")(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"as.number":            int64(20712),
+		"as.organization.name": "Andrews & Arnold Ltd",
+	}, result.(pcommon.Map).AsRaw())
+
+	result, err = newGeoIP("8.8.8.8")(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.(pcommon.Map).Len())
+
+	for _, invalid := range []string{"not an ip", ""} {
+		result, err = newGeoIP(invalid)(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.(pcommon.Map).Len())
+	}
+}
+
+func Test_GeoIP_errors(t *testing.T) {
+	set := ottl.FunctionContext{Set: componenttest.NewNopTelemetrySettings()}
+
+	_, err := NewGeoIPFactory[any](&GeoIPConfig{}).CreateFunction(set, &GeoIPArguments[any]{})
+	assert.EqualError(t, err, "GeoIP: no database configured, see the geoip setting of the processor")
+
+	_, err = NewGeoIPFactory[any](&GeoIPConfig{Databases: []string{filepath.Join(t.TempDir(), "missing.mmdb")}}).CreateFunction(set, &GeoIPArguments[any]{})
+	assert.Error(t, err)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.mmdb")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a database"), 0600))
+	_, err = NewGeoIPFactory[any](&GeoIPConfig{Databases: []string{invalid}}).CreateFunction(set, &GeoIPArguments[any]{})
+	assert.ErrorContains(t, err, "failed to open GeoIP database")
+}
+
+func Test_geoIPDatabase_reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+	writeGeoIPDatabase(t, path, "GeoIP2-City", map[string]mmdbtype.Map{
+		"81.2.69.0/24": cityRecord("FR", "Paris"),
+	})
+
+	db, err := getGeoIPDatabase(path, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	db.now = func() time.Time { return now }
+
+	assertCity := func(expected string) {
+		t.Helper()
+		attrs := pcommon.NewMap()
+		require.NoError(t, db.lookup(net.ParseIP("81.2.69.160"), attrs))
+		city, ok := attrs.Get("geo.locality.name")
+		require.True(t, ok)
+		assert.Equal(t, expected, city.Str())
+	}
+	assertCity("Paris")
+
+	writeGeoIPDatabase(t, path, "GeoIP2-City", map[string]mmdbtype.Map{
+		"81.2.69.0/24": cityRecord("FR", "Lyon"),
+	})
+	require.NoError(t, os.Chtimes(path, now, now.Add(time.Minute)))
+	assertCity("Paris")
+
+	now = now.Add(lookupReloadInterval)
+	assertCity("Lyon")
+
+	// broken files don't replace the previous database
+	require.NoError(t, os.WriteFile(path, []byte("broken"), 0600))
+	require.NoError(t, os.Chtimes(path, now, now.Add(2*time.Minute)))
+	now = now.Add(lookupReloadInterval)
+	assertCity("Lyon")
+
+	same, err := getGeoIPDatabase(path, nil)
+	require.NoError(t, err)
+	assert.Same(t, db, same)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type LookupArguments[K any] struct {
+	Path string
+	Key  ottl.StringLikeGetter[K]
+}
+
+func NewLookupCSVFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("LookupCSV", &LookupArguments[K]{}, createLookupFunction[K]("LookupCSV", parseLookupCSV))
+}
+
+func NewLookupMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("LookupMap", &LookupArguments[K]{}, createLookupFunction[K]("LookupMap", parseLookupMap))
+}
+
+// LookupFunctions returns the functions looking up reference data in files.
+func LookupFunctions[K any]() map[string]ottl.Factory[K] {
+	return ottl.CreateFactoryMap(
+		NewLookupCSVFactory[K](),
+		NewLookupMapFactory[K](),
+	)
+}
+
+func createLookupFunction[K any](name string, parse lookupParser) ottl.CreateFunctionFunc[K] {
+	return func(fCtx ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*LookupArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("%sFactory args must be of type *LookupArguments[K]", name)
+		}
+
+		table, err := getLookupTable(name, args.Path, parse, fCtx.Set.Logger)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return lookup(table, args.Key), nil
+	}
+}
+
+func lookup[K any](table *lookupTable, key ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		k, err := key.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if k == nil {
+			return nil, nil
+		}
+		v, ok := table.get(*k)
+		if !ok {
+			return nil, nil
+		}
+		switch v.Type() {
+		case pcommon.ValueTypeMap:
+			return v.Map(), nil
+		case pcommon.ValueTypeSlice:
+			return v.Slice(), nil
+		default:
+			return v.AsRaw(), nil
+		}
+	}
+}
@@ -11,9 +11,24 @@ import (
 )
 
 func ResourceFunctions() map[string]ottl.Factory[ottlresource.TransformContext] {
-	return ottlfuncs.StandardFuncs[ottlresource.TransformContext]()
+	return WithLookupFunctions(ottlfuncs.StandardFuncs[ottlresource.TransformContext]())
 }
 
 func ScopeFunctions() map[string]ottl.Factory[ottlscope.TransformContext] {
-	return ottlfuncs.StandardFuncs[ottlscope.TransformContext]()
+	return WithLookupFunctions(ottlfuncs.StandardFuncs[ottlscope.TransformContext]())
+}
+
+// WithLookupFunctions adds the lookup functions to the given functions.
+func WithLookupFunctions[K any](functions map[string]ottl.Factory[K]) map[string]ottl.Factory[K] {
+	for k, v := range LookupFunctions[K]() {
+		functions[k] = v
+	}
+	return functions
+}
+
+// WithGeoIPFunction adds the GeoIP function, using the databases of the given configuration, to the given functions.
+func WithGeoIPFunction[K any](functions map[string]ottl.Factory[K], cfg *GeoIPConfig) map[string]ottl.Factory[K] {
+	f := NewGeoIPFactory[K](cfg)
+	functions[f.Name()] = f
+	return functions
 }
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// geoIPRecord holds the fields of the GeoIP2 and GeoLite2 City, Country and ASN databases
+// returned by the GeoIP function.
+type geoIPRecord struct {
+	Continent struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"continent"`
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Postal struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"postal"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+	AutonomousSystemNumber       uint   `maxminddb:"autonomous_system_number"`
+	AutonomousSystemOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+// putAttributes adds the non-empty fields of the record to the attributes.
+func (r *geoIPRecord) putAttributes(attrs pcommon.Map) {
+	putStr := func(key string, value string) {
+		if value != "" {
+			attrs.PutStr(key, value)
+		}
+	}
+	putStr("geo.continent_code", r.Continent.Code)
+	putStr("geo.country_iso_code", r.Country.ISOCode)
+	putStr("geo.country_name", r.Country.Names["en"])
+	if len(r.Subdivisions) > 0 {
+		putStr("geo.region_iso_code", r.Subdivisions[0].ISOCode)
+		putStr("geo.region_name", r.Subdivisions[0].Names["en"])
+	}
+	putStr("geo.locality.name", r.City.Names["en"])
+	putStr("geo.postal_code", r.Postal.Code)
+	if r.Location.Latitude != nil && r.Location.Longitude != nil {
+		attrs.PutDouble("geo.location.lat", *r.Location.Latitude)
+		attrs.PutDouble("geo.location.lon", *r.Location.Longitude)
+	}
+	if r.AutonomousSystemNumber != 0 {
+		attrs.PutInt("as.number", int64(r.AutonomousSystemNumber))
+	}
+	putStr("as.organization.name", r.AutonomousSystemOrganization)
+}
+
+// geoIPDatabase holds a MaxMind database, reloading it when the file changes.
+type geoIPDatabase struct {
+	path   string
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	reader    *maxminddb.Reader
+	modTime   time.Time
+	nextCheck time.Time
+}
+
+// geoIPDatabases shares the databases between all the statements using the same file.
+var geoIPDatabases sync.Map
+
+func getGeoIPDatabase(path string, logger *zap.Logger) (*geoIPDatabase, error) {
+	if db, ok := geoIPDatabases.Load(path); ok {
+		return db.(*geoIPDatabase), nil
+	}
+
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	db := &geoIPDatabase{
+		path:   path,
+		logger: logger,
+		now:    time.Now,
+	}
+	if err := db.load(); err != nil {
+		return nil, err
+	}
+	actual, _ := geoIPDatabases.LoadOrStore(path, db)
+	return actual.(*geoIPDatabase), nil
+}
+
+func (db *geoIPDatabase) load() error {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return err
+	}
+	// the database is read in memory, so that it can be replaced while lookups are in progress
+	data, err := os.ReadFile(db.path)
+	if err != nil {
+		return err
+	}
+	reader, err := maxminddb.FromBytes(data)
+	if err != nil {
+		return fmt.Errorf("failed to open GeoIP database %q: %w", db.path, err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.reader = reader
+	db.modTime = info.ModTime()
+	db.nextCheck = db.now().Add(lookupReloadInterval)
+	return nil
+}
+
+// reloadIfChanged reloads the database when the file was modified since it was last loaded.
+// Failures are logged and the previous database is kept.
+func (db *geoIPDatabase) reloadIfChanged() {
+	db.mu.Lock()
+	now := db.now()
+	if now.Before(db.nextCheck) {
+		db.mu.Unlock()
+		return
+	}
+	db.nextCheck = now.Add(lookupReloadInterval)
+	modTime := db.modTime
+	db.mu.Unlock()
+
+	info, err := os.Stat(db.path)
+	if err != nil {
+		db.logger.Warn("Failed to check the GeoIP database, keeping the previous one", zap.String("path", db.path), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(modTime) {
+		return
+	}
+	if err = db.load(); err != nil {
+		db.logger.Warn("Failed to reload the GeoIP database, keeping the previous one", zap.String("path", db.path), zap.Error(err))
+	}
+}
+
+// lookup adds the data of the network containing the IP to the attributes.
+func (db *geoIPDatabase) lookup(ip net.IP, attrs pcommon.Map) error {
+	db.reloadIfChanged()
+
+	db.mu.RLock()
+	reader := db.reader
+	db.mu.RUnlock()
+
+	var record geoIPRecord
+	_, found, err := reader.LookupNetwork(ip, &record)
+	if err != nil {
+		return fmt.Errorf("failed to look up %s in GeoIP database %q: %w", ip, db.path, err)
+	}
+	if found {
+		record.putAttributes(attrs)
+	}
+	return nil
+}
@@ -55,7 +55,7 @@ type LogParserCollectionOption func(*LogParserCollection) error
 
 func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext]) LogParserCollectionOption {
 	return func(lp *LogParserCollection) error {
-		logParser, err := ottllog.NewParser(functions, lp.settings)
+		logParser, err := ottllog.NewParser(WithGeoIPFunction(functions, &lp.geoIP), lp.settings)
 		if err != nil {
 			return err
 		}
@@ -71,22 +71,30 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	}
 }
 
+func WithLogGeoIP(geoIP GeoIPConfig) LogParserCollectionOption {
+	return func(lp *LogParserCollection) error {
+		lp.geoIP = geoIP
+		return nil
+	}
+}
+
 func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+	lpc := &LogParserCollection{
+		parserCollection: parserCollection{
+			settings: settings,
+		},
+	}
+	// the GeoIP configuration is only read when the statements are parsed, after the options are applied
+	rp, err := ottlresource.NewParser(WithGeoIPFunction(ResourceFunctions(), &lpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(WithGeoIPFunction(ScopeFunctions(), &lpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	lpc := &LogParserCollection{
-		parserCollection: parserCollection{
-			settings:       settings,
-			resourceParser: rp,
-			scopeParser:    sp,
-		},
-	}
+	lpc.resourceParser = rp
+	lpc.scopeParser = sp
 
 	for _, op := range options {
 		err := op(lpc)
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// lookupReloadInterval is how often the lookup files are checked for changes.
+var lookupReloadInterval = 30 * time.Second
+
+// lookupParser turns the content of a lookup file into a map from the lookup keys to their values.
+type lookupParser func(data []byte) (pcommon.Map, error)
+
+// lookupTable holds the content of a lookup file, reloading it when the file changes.
+type lookupTable struct {
+	path   string
+	parse  lookupParser
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	entries   pcommon.Map
+	modTime   time.Time
+	nextCheck time.Time
+}
+
+type lookupTableKey struct {
+	kind string
+	path string
+}
+
+// lookupTables shares the tables between all the statements looking up the same file.
+var lookupTables sync.Map
+
+func getLookupTable(kind string, path string, parse lookupParser, logger *zap.Logger) (*lookupTable, error) {
+	key := lookupTableKey{kind: kind, path: path}
+	if t, ok := lookupTables.Load(key); ok {
+		return t.(*lookupTable), nil
+	}
+
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	t := &lookupTable{
+		path:   path,
+		parse:  parse,
+		logger: logger,
+		now:    time.Now,
+	}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	actual, _ := lookupTables.LoadOrStore(key, t)
+	return actual.(*lookupTable), nil
+}
+
+func (t *lookupTable) load() error {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return err
+	}
+	entries, err := t.parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse lookup file %q: %w", t.path, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = entries
+	t.modTime = info.ModTime()
+	t.nextCheck = t.now().Add(lookupReloadInterval)
+	return nil
+}
+
+// reloadIfChanged reloads the file when it was modified since it was last loaded.
+// Failures are logged and the previous content is kept.
+func (t *lookupTable) reloadIfChanged() {
+	t.mu.Lock()
+	now := t.now()
+	if now.Before(t.nextCheck) {
+		t.mu.Unlock()
+		return
+	}
+	t.nextCheck = now.Add(lookupReloadInterval)
+	modTime := t.modTime
+	t.mu.Unlock()
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		t.logger.Warn("Failed to check the lookup file, keeping the previous content", zap.String("path", t.path), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(modTime) {
+		return
+	}
+	if err = t.load(); err != nil {
+		t.logger.Warn("Failed to reload the lookup file, keeping the previous content", zap.String("path", t.path), zap.Error(err))
+	}
+}
+
+// get returns the value stored under the key.
+func (t *lookupTable) get(key string) (pcommon.Value, bool) {
+	t.reloadIfChanged()
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.entries.Get(key)
+}
+
+// parseLookupCSV parses a CSV file with a header row. Each row is stored under the
+// value of its first column, as a map from the column names to the row values.
+func parseLookupCSV(data []byte) (pcommon.Map, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return pcommon.Map{}, errors.New("missing header row")
+	}
+	if err != nil {
+		return pcommon.Map{}, err
+	}
+
+	entries := pcommon.NewMap()
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return pcommon.Map{}, err
+		}
+		row := entries.PutEmptyMap(record[0])
+		row.EnsureCapacity(len(header))
+		for i, column := range header {
+			row.PutStr(column, record[i])
+		}
+	}
+}
+
+// parseLookupMap parses a YAML or JSON file holding a single object.
+func parseLookupMap(data []byte) (pcommon.Map, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return pcommon.Map{}, err
+	}
+	entries := pcommon.NewMap()
+	if err := entries.FromRaw(raw); err != nil {
+		return pcommon.Map{}, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+func Test_parseLookupCSV(t *testing.T) {
+	entries, err := parseLookupCSV([]byte("ip,datacenter,rack\n10.0.0.1,dc-1,r1\n10.0.0.2,dc-2,r7\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"10.0.0.1": map[string]any{"ip": "10.0.0.1", "datacenter": "dc-1", "rack": "r1"},
+		"10.0.0.2": map[string]any{"ip": "10.0.0.2", "datacenter": "dc-2", "rack": "r7"},
+	}, entries.AsRaw())
+
+	_, err = parseLookupCSV(nil)
+	assert.EqualError(t, err, "missing header row")
+
+	_, err = parseLookupCSV([]byte("ip,datacenter\n10.0.0.1\n"))
+	assert.Error(t, err)
+}
+
+func Test_parseLookupMap(t *testing.T) {
+	entries, err := parseLookupMap([]byte(`{"payments": "alice", "search": {"owner": "bob", "oncall": true}, "retries": 3}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"payments": "alice",
+		"search":   map[string]any{"owner": "bob", "oncall": true},
+		"retries":  int64(3),
+	}, entries.AsRaw())
+
+	_, err = parseLookupMap([]byte("- not\n- a map\n"))
+	assert.Error(t, err)
+}
+
+func Test_lookupTable_reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("payments: alice\n"), 0600))
+
+	table, err := getLookupTable("test", path, parseLookupMap, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Now()
+	table.now = func() time.Time { return now }
+
+	assertLookup := func(key string, expected string) {
+		t.Helper()
+		v, ok := table.get(key)
+		require.True(t, ok)
+		assert.Equal(t, pcommon.ValueTypeStr, v.Type())
+		assert.Equal(t, expected, v.Str())
+	}
+	assertLookup("payments", "alice")
+
+	require.NoError(t, os.WriteFile(path, []byte("payments: bob\n"), 0600))
+	require.NoError(t, os.Chtimes(path, now, now.Add(time.Minute)))
+	assertLookup("payments", "alice")
+
+	now = now.Add(lookupReloadInterval)
+	assertLookup("payments", "bob")
+
+	// broken files don't replace the previous content
+	require.NoError(t, os.WriteFile(path, []byte("- broken\n"), 0600))
+	require.NoError(t, os.Chtimes(path, now, now.Add(2*time.Minute)))
+	now = now.Add(lookupReloadInterval)
+	assertLookup("payments", "bob")
+
+	same, err := getLookupTable("test", path, parseLookupMap, zap.NewNop())
+	require.NoError(t, err)
+	assert.Same(t, table, same)
+
+	_, err = getLookupTable("test", filepath.Join(t.TempDir(), "missing.yaml"), parseLookupMap, zap.NewNop())
+	assert.Error(t, err)
+}
@@ -145,7 +145,7 @@ type MetricParserCollectionOption func(*MetricParserCollection) error
 
 func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformContext]) MetricParserCollectionOption {
 	return func(mp *MetricParserCollection) error {
-		metricParser, err := ottlmetric.NewParser(functions, mp.settings)
+		metricParser, err := ottlmetric.NewParser(WithGeoIPFunction(functions, &mp.geoIP), mp.settings)
 		if err != nil {
 			return err
 		}
@@ -156,7 +156,7 @@ func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformCont
 
 func WithDataPointParser(functions map[string]ottl.Factory[ottldatapoint.TransformContext]) MetricParserCollectionOption {
 	return func(mp *MetricParserCollection) error {
-		dataPointParser, err := ottldatapoint.NewParser(functions, mp.settings)
+		dataPointParser, err := ottldatapoint.NewParser(WithGeoIPFunction(functions, &mp.geoIP), mp.settings)
 		if err != nil {
 			return err
 		}
@@ -172,22 +172,30 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	}
 }
 
+func WithMetricGeoIP(geoIP GeoIPConfig) MetricParserCollectionOption {
+	return func(mp *MetricParserCollection) error {
+		mp.geoIP = geoIP
+		return nil
+	}
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+	mpc := &MetricParserCollection{
+		parserCollection: parserCollection{
+			settings: settings,
+		},
+	}
+	// the GeoIP configuration is only read when the statements are parsed, after the options are applied
+	rp, err := ottlresource.NewParser(WithGeoIPFunction(ResourceFunctions(), &mpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(WithGeoIPFunction(ScopeFunctions(), &mpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	mpc := &MetricParserCollection{
-		parserCollection: parserCollection{
-			settings:       settings,
-			resourceParser: rp,
-			scopeParser:    sp,
-		},
-	}
+	mpc.resourceParser = rp
+	mpc.scopeParser = sp
 
 	for _, op := range options {
 		err := op(mpc)
@@ -134,6 +134,7 @@ type parserCollection struct {
 	resourceParser ottl.Parser[ottlresource.TransformContext]
 	scopeParser    ottl.Parser[ottlscope.TransformContext]
 	errorMode      ottl.ErrorMode
+	geoIP          GeoIPConfig
 }
 
 type baseContext interface {
@@ -91,7 +91,7 @@ type TraceParserCollectionOption func(*TraceParserCollection) error
 
 func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]) TraceParserCollectionOption {
 	return func(tp *TraceParserCollection) error {
-		spanParser, err := ottlspan.NewParser(functions, tp.settings)
+		spanParser, err := ottlspan.NewParser(WithGeoIPFunction(functions, &tp.geoIP), tp.settings)
 		if err != nil {
 			return err
 		}
@@ -102,7 +102,7 @@ func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]
 
 func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext]) TraceParserCollectionOption {
 	return func(tp *TraceParserCollection) error {
-		spanEventParser, err := ottlspanevent.NewParser(functions, tp.settings)
+		spanEventParser, err := ottlspanevent.NewParser(WithGeoIPFunction(functions, &tp.geoIP), tp.settings)
 		if err != nil {
 			return err
 		}
@@ -118,22 +118,30 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	}
 }
 
+func WithTraceGeoIP(geoIP GeoIPConfig) TraceParserCollectionOption {
+	return func(tp *TraceParserCollection) error {
+		tp.geoIP = geoIP
+		return nil
+	}
+}
+
 func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+	tpc := &TraceParserCollection{
+		parserCollection: parserCollection{
+			settings: settings,
+		},
+	}
+	// the GeoIP configuration is only read when the statements are parsed, after the options are applied
+	rp, err := ottlresource.NewParser(WithGeoIPFunction(ResourceFunctions(), &tpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(WithGeoIPFunction(ScopeFunctions(), &tpc.geoIP), settings)
 	if err != nil {
 		return nil, err
 	}
-	tpc := &TraceParserCollection{
-		parserCollection: parserCollection{
-			settings:       settings,
-			resourceParser: rp,
-			scopeParser:    sp,
-		},
-	}
+	tpc.resourceParser = rp
+	tpc.scopeParser = sp
 
 	for _, op := range options {
 		err := op(tpc)
@@ -11,10 +11,11 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 func Test_LogFunctions(t *testing.T) {
-	expected := ottlfuncs.StandardFuncs[ottllog.TransformContext]()
+	expected := common.WithLookupFunctions(ottlfuncs.StandardFuncs[ottllog.TransformContext]())
 	actual := LogFunctions()
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
@@ -5,10 +5,17 @@ package logs
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -49,7 +56,7 @@ func Test_ProcessLogs_ResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -63,6 +70,64 @@ func Test_ProcessLogs_ResourceContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessLogs_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "hosts.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("host,datacenter,team\nlocalhost,dc-1,core\n"), 0600))
+	mapPath := filepath.Join(dir, "teams.yaml")
+	require.NoError(t, os.WriteFile(mapPath, []byte("core: alice\n"), 0600))
+
+	statements := []string{
+		fmt.Sprintf(`set(attributes["datacenter"], LookupCSV(%q, attributes["host.name"])["datacenter"])`, csvPath),
+		fmt.Sprintf(`set(attributes["owner"], LookupMap(%q, LookupCSV(%q, attributes["host.name"])["team"]))`, mapPath, csvPath),
+		fmt.Sprintf(`set(attributes["missing"], LookupMap(%q, "unknown"))`, mapPath),
+	}
+	processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: statements}}, ottl.PropagateError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	td := constructLogs()
+	_, err = processor.ProcessLogs(context.Background(), td)
+	require.NoError(t, err)
+
+	exTd := constructLogs()
+	exTd.ResourceLogs().At(0).Resource().Attributes().PutStr("datacenter", "dc-1")
+	exTd.ResourceLogs().At(0).Resource().Attributes().PutStr("owner", "alice")
+	assert.Equal(t, exTd, td)
+}
+
+func Test_ProcessLogs_GeoIP(t *testing.T) {
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "GeoLite2-Country", RecordSize: 24})
+	require.NoError(t, err)
+	_, network, err := net.ParseCIDR("81.2.69.0/24")
+	require.NoError(t, err)
+	require.NoError(t, tree.Insert(network, mmdbtype.Map{"country": mmdbtype.Map{"iso_code": mmdbtype.String("GB")}}))
+	path := filepath.Join(t.TempDir(), "country.mmdb")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	statements := []string{
+		`set(attributes["client.address"], "81.2.69.160") where body == "operationA"`,
+		`merge_maps(attributes, GeoIP(attributes["client.address"]), "upsert") where attributes["client.address"] != nil`,
+	}
+	processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: statements}}, ottl.PropagateError, common.GeoIPConfig{Databases: []string{path}}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	td := constructLogs()
+	_, err = processor.ProcessLogs(context.Background(), td)
+	require.NoError(t, err)
+
+	exTd := constructLogs()
+	exTd.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("client.address", "81.2.69.160")
+	exTd.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("geo.country_iso_code", "GB")
+	assert.Equal(t, exTd, td)
+
+	_, err = NewProcessor([]common.ContextStatements{{Context: "log", Statements: statements}}, ottl.PropagateError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "GeoIP: no database configured")
+}
+
 func Test_ProcessLogs_ScopeContext(t *testing.T) {
 	tests := []struct {
 		statement string
@@ -84,7 +149,7 @@ func Test_ProcessLogs_ScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -334,7 +399,7 @@ func Test_ProcessLogs_LogContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -451,7 +516,7 @@ func Test_ProcessLogs_MixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatments, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.contextStatments, ottl.IgnoreError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -484,7 +549,7 @@ func Test_ProcessTraces_Error(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(string(tt.context), func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, common.GeoIPConfig{}, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
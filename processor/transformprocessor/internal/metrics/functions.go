@@ -8,10 +8,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext] {
-	functions := ottlfuncs.StandardFuncs[ottldatapoint.TransformContext]()
+	functions := common.WithLookupFunctions(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext]())
 
 	datapointFunctions := ottl.CreateFactoryMap[ottldatapoint.TransformContext](
 		newConvertSumToGaugeFactory(),
@@ -28,7 +29,7 @@ func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext
 }
 
 func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
-	functions := ottlfuncs.StandardFuncs[ottlmetric.TransformContext]()
+	functions := common.WithLookupFunctions(ottlfuncs.StandardFuncs[ottlmetric.TransformContext]())
 
 	metricFunctions := ottl.CreateFactoryMap(
 		newExtractSumMetricFactory(),
@@ -12,10 +12,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 func Test_DataPointFunctions(t *testing.T) {
-	expected := ottlfuncs.StandardFuncs[ottldatapoint.TransformContext]()
+	expected := common.WithLookupFunctions(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext]())
 	expected["convert_sum_to_gauge"] = newConvertSumToGaugeFactory()
 	expected["convert_gauge_to_sum"] = newConvertGaugeToSumFactory()
 	expected["convert_summary_sum_val_to_sum"] = newConvertSummarySumValToSumFactory()
@@ -30,7 +31,7 @@ func Test_DataPointFunctions(t *testing.T) {
 }
 
 func Test_MetricFunctions(t *testing.T) {
-	expected := ottlfuncs.StandardFuncs[ottlmetric.TransformContext]()
+	expected := common.WithLookupFunctions(ottlfuncs.StandardFuncs[ottlmetric.TransformContext]())
 	expected["extract_sum_metric"] = newExtractSumMetricFactory()
 	expected["extract_count_metric"] = newExtractCountMetricFactory()
 	actual := MetricFunctions()
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/unitnormalizationprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// Conversions are applied to the metrics whose unit matches their From unit.
+	// The first matching conversion is used.
+	Conversions []Conversion `mapstructure:"conversions"`
+}
+
+// Conversion converts metrics from one unit to another unit of the same dimension.
+type Conversion struct {
+	// From is the unit of the metrics to convert. Any spelling of the same unit
+	// matches, for instance "ms" also matches metrics declared in "milliseconds".
+	From string `mapstructure:"from"`
+
+	// To is the unit the metrics are converted to.
+	To string `mapstructure:"to"`
+
+	// Metrics restricts the conversion to the metrics with these names. All the
+	// metrics in the From unit are converted when empty.
+	Metrics []string `mapstructure:"metrics"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if len(cfg.Conversions) == 0 {
+		return errors.New("at least one conversion must be configured")
+	}
+	for i, c := range cfg.Conversions {
+		if c.From == "" || c.To == "" {
+			return fmt.Errorf("conversions[%d]: both from and to must be set", i)
+		}
+		if _, err := conversionFactor(c.From, c.To); err != nil {
+			return fmt.Errorf("conversions[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/unitnormalizationprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Conversions: []Conversion{
+					{From: "By", To: "MiBy"},
+					{From: "ms", To: "s", Metrics: []string{"http.server.duration", "rpc.server.duration"}},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "aliases"),
+			expected: &Config{
+				Conversions: []Conversion{
+					{From: "milliseconds", To: "ms"},
+					{From: "bytes/s", To: "MBy/s"},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "empty"),
+			errorMessage: "at least one conversion must be configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_to"),
+			errorMessage: "conversions[0]: both from and to must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_unit"),
+			errorMessage: `conversions[0]: unknown unit "parsecs"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "dimension_mismatch"),
+			errorMessage: `conversions[0]: cannot convert "By" (data) to "s" (time)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
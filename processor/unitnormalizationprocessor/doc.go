@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package unitnormalizationprocessor implements a processor which converts
+// metrics to a common unit, rewriting their values and unit consistently.
+package unitnormalizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/unitnormalizationprocessor"
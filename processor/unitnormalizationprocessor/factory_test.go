@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestType(t *testing.T) {
+	factory := NewFactory()
+	pType := factory.Type()
+	assert.Equal(t, pType, component.Type("unitnormalization"))
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, cfg, &Config{})
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := &Config{Conversions: []Conversion{{From: "By", To: "MiBy"}}}
+
+	tp, tErr := factory.CreateTracesProcessor(
+		context.Background(),
+		processortest.NewNopCreateSettings(),
+		cfg,
+		consumertest.NewNop())
+	// Not implemented error
+	assert.Error(t, tErr)
+	assert.Nil(t, tp)
+
+	mp, mErr := factory.CreateMetricsProcessor(
+		context.Background(),
+		processortest.NewNopCreateSettings(),
+		cfg,
+		consumertest.NewNop())
+	require.NoError(t, mErr)
+	assert.NotNil(t, mp)
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/unitnormalizationprocessor"
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type conversion struct {
+	from    unit
+	to      string
+	metrics map[string]bool
+}
+
+type unitNormalizationProcessor struct {
+	conversions []conversion
+	logger      *zap.Logger
+}
+
+func newUnitNormalizationProcessor(config *Config, logger *zap.Logger) (*unitNormalizationProcessor, error) {
+	conversions := make([]conversion, 0, len(config.Conversions))
+	for _, c := range config.Conversions {
+		from, ok := parseUnit(c.From)
+		if !ok {
+			return nil, fmt.Errorf("unknown unit %q", c.From)
+		}
+		var metrics map[string]bool
+		if len(c.Metrics) > 0 {
+			metrics = make(map[string]bool, len(c.Metrics))
+			for _, name := range c.Metrics {
+				metrics[name] = true
+			}
+		}
+		conversions = append(conversions, conversion{from: from, to: c.To, metrics: metrics})
+	}
+	return &unitNormalizationProcessor{
+		conversions: conversions,
+		logger:      logger,
+	}, nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *unitNormalizationProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				p.processMetric(metrics.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *unitNormalizationProcessor) processMetric(metric pmetric.Metric) {
+	u, ok := parseUnit(metric.Unit())
+	if !ok {
+		return
+	}
+	for _, c := range p.conversions {
+		if c.from != u || (c.metrics != nil && !c.metrics[metric.Name()]) {
+			continue
+		}
+		// Both units have the same dimension, the configuration was validated
+		factor, _ := conversionFactor(metric.Unit(), c.to)
+		if !convertMetric(metric, factor) {
+			p.logger.Debug("Metric cannot be converted, keeping its unit",
+				zap.String("metric", metric.Name()),
+				zap.String("unit", metric.Unit()),
+				zap.String("target_unit", c.to))
+			return
+		}
+		metric.SetUnit(c.to)
+		return
+	}
+}
+
+// convertMetric multiplies the values of the metric by factor. It returns false
+// if the metric was left untouched because it cannot be converted exactly.
+func convertMetric(metric pmetric.Metric, factor float64) bool {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		convertNumberDataPoints(metric.Gauge().DataPoints(), factor)
+	case pmetric.MetricTypeSum:
+		convertNumberDataPoints(metric.Sum().DataPoints(), factor)
+	case pmetric.MetricTypeHistogram:
+		convertHistogramDataPoints(metric.Histogram().DataPoints(), factor)
+	case pmetric.MetricTypeExponentialHistogram:
+		return convertExponentialHistogramDataPoints(metric.ExponentialHistogram().DataPoints(), factor)
+	case pmetric.MetricTypeSummary:
+		convertSummaryDataPoints(metric.Summary().DataPoints(), factor)
+	default:
+		return false
+	}
+	return true
+}
+
+// convertNumberDataPoints converts integer values to doubles, unless the factor is
+// a whole number.
+func convertNumberDataPoints(dps pmetric.NumberDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			if isWhole(factor) {
+				dp.SetIntValue(dp.IntValue() * int64(factor))
+			} else {
+				dp.SetDoubleValue(float64(dp.IntValue()) * factor)
+			}
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(dp.DoubleValue() * factor)
+		}
+		convertExemplars(dp.Exemplars(), factor)
+	}
+}
+
+func convertHistogramDataPoints(dps pmetric.HistogramDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		bounds := dp.ExplicitBounds().AsRaw()
+		for j := range bounds {
+			bounds[j] *= factor
+		}
+		dp.ExplicitBounds().FromRaw(bounds)
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		convertExemplars(dp.Exemplars(), factor)
+	}
+}
+
+// convertExponentialHistogramDataPoints shifts the buckets of the data points. This
+// is only possible when the factor is a power of two, and the shift a whole number
+// of buckets at the data point scale. Data points with a zero bucket are not
+// converted either, since pdata does not expose the zero threshold to scale along.
+func convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, factor float64) bool {
+	frac, exp := math.Frexp(factor)
+	if frac != 0.5 {
+		return false
+	}
+	exponent := int64(exp - 1)
+
+	shifts := make([]int64, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		if dps.At(i).ZeroCount() > 0 {
+			return false
+		}
+		scale := int64(dps.At(i).Scale())
+		if scale >= 0 {
+			shifts[i] = exponent << scale
+			continue
+		}
+		if exponent%(1<<-scale) != 0 {
+			return false
+		}
+		shifts[i] = exponent / (1 << -scale)
+	}
+
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.Positive().SetOffset(dp.Positive().Offset() + int32(shifts[i]))
+		dp.Negative().SetOffset(dp.Negative().Offset() + int32(shifts[i]))
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		convertExemplars(dp.Exemplars(), factor)
+	}
+	return true
+}
+
+func convertSummaryDataPoints(dps pmetric.SummaryDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetSum(dp.Sum() * factor)
+		quantiles := dp.QuantileValues()
+		for j := 0; j < quantiles.Len(); j++ {
+			quantiles.At(j).SetValue(quantiles.At(j).Value() * factor)
+		}
+	}
+}
+
+func convertExemplars(exemplars pmetric.ExemplarSlice, factor float64) {
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			if isWhole(factor) {
+				e.SetIntValue(e.IntValue() * int64(factor))
+			} else {
+				e.SetDoubleValue(float64(e.IntValue()) * factor)
+			}
+		case pmetric.ExemplarValueTypeDouble:
+			e.SetDoubleValue(e.DoubleValue() * factor)
+		}
+	}
+}
+
+func isWhole(f float64) bool {
+	return f == math.Trunc(f)
+}
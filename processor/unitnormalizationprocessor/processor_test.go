@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor(t *testing.T, conversions ...Conversion) *unitNormalizationProcessor {
+	cfg := &Config{Conversions: conversions}
+	require.NoError(t, cfg.Validate())
+	p, err := newUnitNormalizationProcessor(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return p
+}
+
+func newMetrics() (pmetric.Metrics, pmetric.MetricSlice) {
+	md := pmetric.NewMetrics()
+	return md, md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+}
+
+func TestConvertGauge(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "By", To: "MiBy"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("memory.usage")
+	m.SetUnit("By")
+	dps := m.SetEmptyGauge().DataPoints()
+	dps.AppendEmpty().SetIntValue(3 << 20)
+	dps.AppendEmpty().SetDoubleValue(1 << 19)
+	e := dps.At(0).Exemplars().AppendEmpty()
+	e.SetIntValue(1 << 20)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MiBy", m.Unit())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dps.At(0).ValueType())
+	assert.Equal(t, 3.0, dps.At(0).DoubleValue())
+	assert.Equal(t, 0.5, dps.At(1).DoubleValue())
+	assert.Equal(t, 1.0, e.DoubleValue())
+}
+
+func TestConvertSumToSmallerUnit(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "s", To: "ms"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("seconds")
+	dps := m.SetEmptySum().DataPoints()
+	dps.AppendEmpty().SetIntValue(3)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// Integers are kept when the factor is a whole number
+	assert.Equal(t, "ms", m.Unit())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dps.At(0).ValueType())
+	assert.Equal(t, int64(3000), dps.At(0).IntValue())
+}
+
+func TestConvertHistogram(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "ms", To: "s"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("ms")
+	dp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.ExplicitBounds().FromRaw([]float64{100, 500, 1000})
+	dp.BucketCounts().FromRaw([]uint64{1, 2, 3, 4})
+	dp.SetCount(10)
+	dp.SetSum(2500)
+	dp.SetMin(50)
+	dp.SetMax(1500)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s", m.Unit())
+	assert.Equal(t, []float64{0.1, 0.5, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2, 3, 4}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(10), dp.Count())
+	assert.Equal(t, 2.5, dp.Sum())
+	assert.Equal(t, 0.05, dp.Min())
+	assert.Equal(t, 1.5, dp.Max())
+}
+
+func TestConvertExponentialHistogram(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "KiBy", To: "By"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("KiBy")
+	dp := m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(1)
+	dp.Positive().SetOffset(2)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp.SetSum(4)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// 2^10 at scale 1 shifts the buckets by 20
+	assert.Equal(t, "By", m.Unit())
+	assert.Equal(t, int32(22), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 4096.0, dp.Sum())
+}
+
+func TestConvertExponentialHistogramZeroBucket(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "KiBy", To: "By"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("KiBy")
+	dp := m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(1)
+	dp.SetZeroCount(3)
+	dp.Positive().SetOffset(2)
+	dp.SetSum(4)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// The zero threshold cannot be scaled along with the buckets
+	assert.Equal(t, "KiBy", m.Unit())
+	assert.Equal(t, int32(2), dp.Positive().Offset())
+	assert.Equal(t, 4.0, dp.Sum())
+}
+
+func TestConvertExponentialHistogramNotExact(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "ms", To: "s"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("ms")
+	dp := m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.Positive().SetOffset(2)
+	dp.SetSum(4)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// Metrics that cannot be converted keep their unit and values
+	assert.Equal(t, "ms", m.Unit())
+	assert.Equal(t, int32(2), dp.Positive().Offset())
+	assert.Equal(t, 4.0, dp.Sum())
+}
+
+func TestConvertSummary(t *testing.T) {
+	p := newTestProcessor(t, Conversion{From: "us", To: "ms"})
+	md, metrics := newMetrics()
+	m := metrics.AppendEmpty()
+	m.SetUnit("us")
+	dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+	dp.SetCount(2)
+	dp.SetSum(3000)
+	q := dp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(2000)
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ms", m.Unit())
+	assert.Equal(t, 3.0, dp.Sum())
+	assert.Equal(t, 0.99, q.Quantile())
+	assert.Equal(t, 2.0, q.Value())
+}
+
+func TestConversionSelection(t *testing.T) {
+	p := newTestProcessor(t,
+		Conversion{From: "ms", To: "s", Metrics: []string{"http.server.duration"}},
+		Conversion{From: "ms", To: "us"},
+		Conversion{From: "By/s", To: "KiBy/s"},
+	)
+	md, metrics := newMetrics()
+	for _, metric := range []struct{ name, unit string }{
+		{"http.server.duration", "ms"},
+		{"db.query.duration", "milliseconds"},
+		{"network.io.rate", "By/s"},
+		{"network.packets", "{packets}"},
+		{"already.normalized", "s"},
+	} {
+		m := metrics.AppendEmpty()
+		m.SetName(metric.name)
+		m.SetUnit(metric.unit)
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(2048)
+	}
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	expected := []struct {
+		unit  string
+		value float64
+	}{
+		{"s", 2.048},
+		{"us", 2048000},
+		{"KiBy/s", 2},
+		{"{packets}", 2048},
+		{"s", 2048},
+	}
+	for i, e := range expected {
+		m := metrics.At(i)
+		assert.Equal(t, e.unit, m.Unit(), m.Name())
+		assert.Equal(t, e.value, m.Gauge().DataPoints().At(0).DoubleValue(), m.Name())
+	}
+}
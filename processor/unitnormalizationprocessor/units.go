@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/unitnormalizationprocessor"
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type dimension string
+
+const (
+	dimensionData dimension = "data"
+	dimensionTime dimension = "time"
+)
+
+// unit is a unit of measure, expressed as a multiple of the base unit of its dimension.
+type unit struct {
+	dimension dimension
+	scale     float64
+}
+
+// knownUnits are keyed by their UCUM symbol, as recommended by the OpenTelemetry
+// semantic conventions.
+var knownUnits = map[string]unit{
+	"bit":   {dimensionData, 1.0 / 8},
+	"Kibit": {dimensionData, 1 << 10 / 8},
+	"Mibit": {dimensionData, 1 << 20 / 8},
+	"Gibit": {dimensionData, 1 << 30 / 8},
+	"kbit":  {dimensionData, 1e3 / 8},
+	"Mbit":  {dimensionData, 1e6 / 8},
+	"Gbit":  {dimensionData, 1e9 / 8},
+	"By":    {dimensionData, 1},
+	"KiBy":  {dimensionData, 1 << 10},
+	"MiBy":  {dimensionData, 1 << 20},
+	"GiBy":  {dimensionData, 1 << 30},
+	"TiBy":  {dimensionData, 1 << 40},
+	"kBy":   {dimensionData, 1e3},
+	"MBy":   {dimensionData, 1e6},
+	"GBy":   {dimensionData, 1e9},
+	"TBy":   {dimensionData, 1e12},
+
+	"ns":  {dimensionTime, 1e-9},
+	"us":  {dimensionTime, 1e-6},
+	"ms":  {dimensionTime, 1e-3},
+	"s":   {dimensionTime, 1},
+	"min": {dimensionTime, 60},
+	"h":   {dimensionTime, 3600},
+	"d":   {dimensionTime, 86400},
+}
+
+// unitAliases maps the spellings commonly found in instrumentation that does not
+// follow UCUM to the matching symbol.
+var unitAliases = map[string]string{
+	"bits":         "bit",
+	"B":            "By",
+	"byte":         "By",
+	"bytes":        "By",
+	"KiB":          "KiBy",
+	"MiB":          "MiBy",
+	"GiB":          "GiBy",
+	"TiB":          "TiBy",
+	"KB":           "kBy",
+	"kB":           "kBy",
+	"MB":           "MBy",
+	"GB":           "GBy",
+	"TB":           "TBy",
+	"nanoseconds":  "ns",
+	"μs":           "us",
+	"microseconds": "us",
+	"milliseconds": "ms",
+	"sec":          "s",
+	"seconds":      "s",
+	"minutes":      "min",
+	"hours":        "h",
+	"days":         "d",
+}
+
+// parseUnit resolves a unit symbol or alias. Rates such as By/s are resolved as
+// the ratio of two known units.
+func parseUnit(symbol string) (unit, bool) {
+	if numerator, denominator, ok := strings.Cut(symbol, "/"); ok {
+		n, nOK := parseSimpleUnit(numerator)
+		d, dOK := parseSimpleUnit(denominator)
+		if !nOK || !dOK {
+			return unit{}, false
+		}
+		return unit{dimension: n.dimension + "/" + d.dimension, scale: n.scale / d.scale}, true
+	}
+	return parseSimpleUnit(symbol)
+}
+
+func parseSimpleUnit(symbol string) (unit, bool) {
+	if alias, ok := unitAliases[symbol]; ok {
+		symbol = alias
+	}
+	u, ok := knownUnits[symbol]
+	return u, ok
+}
+
+// conversionFactor returns the factor values in unit from are multiplied by to be
+// expressed in unit to.
+func conversionFactor(from, to string) (float64, error) {
+	fromUnit, ok := parseUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	toUnit, ok := parseUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return 0, fmt.Errorf("cannot convert %q (%s) to %q (%s)", from, fromUnit.dimension, to, toUnit.dimension)
+	}
+	return roundFactor(fromUnit.scale / toUnit.scale), nil
+}
+
+// roundFactor removes the floating point error of the division of two scales, so
+// that for instance converting ms to us multiplies values by exactly 1000.
+func roundFactor(factor float64) float64 {
+	const epsilon = 1e-9
+	if whole := math.Round(factor); whole != 0 && math.Abs(factor-whole) < epsilon*whole {
+		return whole
+	}
+	if inverse := math.Round(1 / factor); inverse != 0 && math.Abs(1/factor-inverse) < epsilon*inverse {
+		return 1 / inverse
+	}
+	return factor
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitnormalizationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversionFactor(t *testing.T) {
+	tests := []struct {
+		from, to string
+		factor   float64
+	}{
+		{"By", "KiBy", 1.0 / 1024},
+		{"MiBy", "By", 1 << 20},
+		{"bytes", "kBy", 1e-3},
+		{"Gbit", "MBy", 125},
+		{"ms", "s", 1e-3},
+		{"h", "min", 60},
+		{"μs", "ns", 1e3},
+		{"By/s", "MiBy/min", 60.0 / (1 << 20)},
+		{"milliseconds", "ms", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			factor, err := conversionFactor(tt.from, tt.to)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.factor, factor, tt.factor*1e-12)
+		})
+	}
+}
+
+func TestConversionFactorErrors(t *testing.T) {
+	tests := []struct {
+		from, to string
+		err      string
+	}{
+		{"By", "furlong", `unknown unit "furlong"`},
+		{"{requests}", "By", `unknown unit "{requests}"`},
+		{"By/s", "By", `cannot convert "By/s" (data/time) to "By" (data)`},
+		{"By/s", "By/parsec", `unknown unit "By/parsec"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			_, err := conversionFactor(tt.from, tt.to)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
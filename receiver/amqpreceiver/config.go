@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+)
+
+// Config defines the configuration of the AMQP receiver.
+type Config struct {
+	// Endpoint is the URL of the broker, e.g. amqp://localhost:5672/vhost. The
+	// connection uses TLS with the amqps scheme.
+	Endpoint string `mapstructure:"endpoint"`
+	// TLSSetting configures the TLS connection of the amqps endpoints.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+	// Auth overrides the credentials of the endpoint.
+	Auth AuthConfig `mapstructure:"auth"`
+	// ConnectionTimeout is the timeout of the connection to the broker (default 10s).
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+	// ReconnectInterval is the time waited before reconnecting to the broker after
+	// the connection was lost (default 5s).
+	ReconnectInterval time.Duration `mapstructure:"reconnect_interval"`
+
+	// Queue is the queue the messages are consumed from.
+	Queue QueueConfig `mapstructure:"queue"`
+	// Exchange is the exchange the queue is bound to, if any.
+	Exchange ExchangeConfig `mapstructure:"exchange"`
+
+	// Encoding of the messages, otlp_proto or otlp_json (default otlp_proto).
+	Encoding string `mapstructure:"encoding"`
+	// PrefetchCount is the number of unacknowledged messages delivered to the
+	// receiver (default 100).
+	PrefetchCount int `mapstructure:"prefetch_count"`
+	// ConsumerTag identifies the consumer on the broker, generated when empty.
+	ConsumerTag string `mapstructure:"consumer_tag"`
+	// RequeueOnError requeues the messages the pipeline failed to consume instead
+	// of rejecting them (default false).
+	RequeueOnError bool `mapstructure:"requeue_on_error"`
+}
+
+// AuthConfig defines the credentials of the connection to the broker.
+type AuthConfig struct {
+	Plain *PlainAuthConfig `mapstructure:"plain"`
+}
+
+// PlainAuthConfig defines the credentials of the PLAIN SASL mechanism.
+type PlainAuthConfig struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+}
+
+// QueueConfig defines the queue the messages are consumed from.
+type QueueConfig struct {
+	// Name of the queue.
+	Name string `mapstructure:"name"`
+	// Declare declares the queue, creating it if missing (default false).
+	Declare    bool `mapstructure:"declare"`
+	Durable    bool `mapstructure:"durable"`
+	AutoDelete bool `mapstructure:"auto_delete"`
+	Exclusive  bool `mapstructure:"exclusive"`
+	// Arguments of the declaration, e.g. x-queue-type.
+	Arguments map[string]any `mapstructure:"arguments"`
+}
+
+// ExchangeConfig defines the exchange the queue is bound to.
+type ExchangeConfig struct {
+	// Name of the exchange. The queue is not bound when empty.
+	Name string `mapstructure:"name"`
+	// Declare declares the exchange, creating it if missing (default false).
+	Declare bool `mapstructure:"declare"`
+	// Type of the exchange, among direct, fanout, topic and headers (default direct).
+	Type    string `mapstructure:"type"`
+	Durable bool   `mapstructure:"durable"`
+	// RoutingKeys are the keys the queue is bound with (default the empty key).
+	RoutingKeys []string `mapstructure:"routing_keys"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if _, err := amqp.ParseURI(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if cfg.Queue.Name == "" {
+		return errors.New("queue name must be specified")
+	}
+	switch cfg.Exchange.Type {
+	case amqp.ExchangeDirect, amqp.ExchangeFanout, amqp.ExchangeTopic, amqp.ExchangeHeaders:
+	default:
+		return fmt.Errorf("unsupported exchange type %q", cfg.Exchange.Type)
+	}
+	switch cfg.Encoding {
+	case encodingOTLPProto, encodingOTLPJSON:
+	default:
+		return fmt.Errorf("unsupported encoding %q", cfg.Encoding)
+	}
+	if cfg.PrefetchCount < 0 {
+		return errors.New("prefetch_count must not be negative")
+	}
+	if cfg.ConnectionTimeout <= 0 || cfg.ReconnectInterval <= 0 {
+		return errors.New("connection_timeout and reconnect_interval must be positive")
+	}
+	return nil
+}
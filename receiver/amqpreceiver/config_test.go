@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Endpoint:          "amqp://localhost:5672/",
+				ConnectionTimeout: defaultConnectionTimeout,
+				ReconnectInterval: defaultReconnectInterval,
+				Queue:             QueueConfig{Name: "otlp_spans"},
+				Exchange:          ExchangeConfig{Type: "direct"},
+				Encoding:          encodingOTLPProto,
+				PrefetchCount:     defaultPrefetchCount,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: &Config{
+				Endpoint: "amqps://broker:5671/otel",
+				TLSSetting: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{CAFile: "ca.pem"},
+				},
+				Auth: AuthConfig{
+					Plain: &PlainAuthConfig{Username: "otel", Password: "secret"},
+				},
+				ConnectionTimeout: 30 * time.Second,
+				ReconnectInterval: time.Second,
+				Queue: QueueConfig{
+					Name:      "otlp_logs",
+					Declare:   true,
+					Durable:   true,
+					Arguments: map[string]any{"x-queue-type": "quorum"},
+				},
+				Exchange: ExchangeConfig{
+					Name:        "telemetry",
+					Declare:     true,
+					Type:        "topic",
+					Durable:     true,
+					RoutingKeys: []string{"logs.#"},
+				},
+				Encoding:       encodingOTLPJSON,
+				PrefetchCount:  500,
+				ConsumerTag:    "collector",
+				RequeueOnError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be specified",
+		},
+		{
+			name:   "invalid endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "http://localhost" },
+			err:    "invalid endpoint: AMQP scheme must be either 'amqp://' or 'amqps://'",
+		},
+		{
+			name:   "missing queue",
+			modify: func(cfg *Config) { cfg.Queue.Name = "" },
+			err:    "queue name must be specified",
+		},
+		{
+			name:   "exchange type",
+			modify: func(cfg *Config) { cfg.Exchange.Type = "x-delayed-message" },
+			err:    `unsupported exchange type "x-delayed-message"`,
+		},
+		{
+			name:   "encoding",
+			modify: func(cfg *Config) { cfg.Encoding = "json" },
+			err:    `unsupported encoding "json"`,
+		},
+		{
+			name:   "prefetch count",
+			modify: func(cfg *Config) { cfg.PrefetchCount = -1 },
+			err:    "prefetch_count must not be negative",
+		},
+		{
+			name:   "reconnect interval",
+			modify: func(cfg *Config) { cfg.ReconnectInterval = 0 },
+			err:    "connection_timeout and reconnect_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "amqp://localhost:5672/"
+			cfg.Queue.Name = "otlp_spans"
+			require.NoError(t, cfg.Validate())
+
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
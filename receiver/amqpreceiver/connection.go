@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver"
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// connection is the connection to the broker, abstracted for testing.
+type connection interface {
+	channel() (channel, error)
+	Close() error
+}
+
+// channel is the channel the queue is consumed on, abstracted for testing.
+type channel interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+}
+
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) channel() (channel, error) {
+	return c.Channel()
+}
+
+// dial connects to the broker of cfg.
+func dial(cfg *Config) (connection, error) {
+	uri, err := amqp.ParseURI(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	config := amqp.Config{
+		Dial: amqp.DefaultDial(cfg.ConnectionTimeout),
+	}
+	if cfg.Auth.Plain != nil {
+		config.SASL = []amqp.Authentication{&amqp.PlainAuth{
+			Username: cfg.Auth.Plain.Username,
+			Password: string(cfg.Auth.Plain.Password),
+		}}
+	}
+	if uri.Scheme == "amqps" {
+		if config.TLSClientConfig, err = cfg.TLSSetting.LoadTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := amqp.DialConfig(cfg.Endpoint, config)
+	if err != nil {
+		return nil, err
+	}
+	return amqpConnection{Connection: conn}, nil
+}
+
+// declare sets the prefetch count of ch, and declares and binds the queue and
+// exchange of cfg.
+func declare(ch channel, cfg *Config) error {
+	if cfg.PrefetchCount > 0 {
+		if err := ch.Qos(cfg.PrefetchCount, 0, false); err != nil {
+			return err
+		}
+	}
+
+	queue := cfg.Queue
+	if queue.Declare {
+		if _, err := ch.QueueDeclare(queue.Name, queue.Durable, queue.AutoDelete, queue.Exclusive, false, queue.Arguments); err != nil {
+			return err
+		}
+	}
+
+	exchange := cfg.Exchange
+	if exchange.Name == "" {
+		return nil
+	}
+	if exchange.Declare {
+		if err := ch.ExchangeDeclare(exchange.Name, exchange.Type, exchange.Durable, false, false, false, nil); err != nil {
+			return err
+		}
+	}
+	routingKeys := exchange.RoutingKeys
+	if len(routingKeys) == 0 {
+		routingKeys = []string{""}
+	}
+	for _, key := range routingKeys {
+		if err := ch.QueueBind(queue.Name, key, exchange.Name, false, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
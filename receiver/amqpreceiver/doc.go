@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package amqpreceiver receives telemetry from the queues of an AMQP 0.9.1 broker
+// such as RabbitMQ.
+package amqpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver"
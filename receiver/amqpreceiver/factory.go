@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver"
+
+import (
+	"context"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver/internal/metadata"
+)
+
+const (
+	defaultConnectionTimeout = 10 * time.Second
+	defaultReconnectInterval = 5 * time.Second
+	defaultPrefetchCount     = 100
+)
+
+// NewFactory creates a factory for the AMQP receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ConnectionTimeout: defaultConnectionTimeout,
+		ReconnectInterval: defaultReconnectInterval,
+		Exchange: ExchangeConfig{
+			Type: amqp.ExchangeDirect,
+		},
+		Encoding:      encodingOTLPProto,
+		PrefetchCount: defaultPrefetchCount,
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), set, nextConsumer)
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/amqpreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const transport = "amqp"
+
+// amqpReceiver consumes the messages of a queue, passing their body to consume.
+type amqpReceiver struct {
+	config   *Config
+	settings receiver.CreateSettings
+	obsrecv  *receiverhelper.ObsReport
+	consume  func(ctx context.Context, body []byte) error
+	dial     func(cfg *Config) (connection, error)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	conn connection
+}
+
+func newAMQPReceiver(config *Config, set receiver.CreateSettings) (*amqpReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		LongLivedCtx:           false,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &amqpReceiver{
+		config:   config,
+		settings: set,
+		obsrecv:  obsrecv,
+		dial:     dial,
+	}, nil
+}
+
+func newTracesReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Traces) (*amqpReceiver, error) {
+	r, err := newAMQPReceiver(config, set)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler ptrace.Unmarshaler = &ptrace.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &ptrace.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, body []byte) error {
+		traces, err := unmarshaler.UnmarshalTraces(body)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = nextConsumer.ConsumeTraces(ctx, traces)
+		r.obsrecv.EndTracesOp(ctx, config.Encoding, traces.SpanCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Metrics) (*amqpReceiver, error) {
+	r, err := newAMQPReceiver(config, set)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler pmetric.Unmarshaler = &pmetric.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &pmetric.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, body []byte) error {
+		metrics, err := unmarshaler.UnmarshalMetrics(body)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = nextConsumer.ConsumeMetrics(ctx, metrics)
+		r.obsrecv.EndMetricsOp(ctx, config.Encoding, metrics.DataPointCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newLogsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Logs) (*amqpReceiver, error) {
+	r, err := newAMQPReceiver(config, set)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler plog.Unmarshaler = &plog.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &plog.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, body []byte) error {
+		logs, err := unmarshaler.UnmarshalLogs(body)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = nextConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, config.Encoding, logs.LogRecordCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+// Start connects to the broker and starts consuming the queue.
+func (r *amqpReceiver) Start(context.Context, component.Host) error {
+	deliveries, err := r.subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.consumeLoop(ctx, deliveries)
+	}()
+	return nil
+}
+
+// Shutdown stops consuming the queue. The messages not acknowledged yet are
+// delivered again by the broker.
+func (r *amqpReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return r.closeConnection()
+}
+
+// subscribe connects to the broker, declares the queue and starts consuming it.
+func (r *amqpReceiver) subscribe() (<-chan amqp.Delivery, error) {
+	conn, err := r.dial(r.config)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := conn.channel()
+	if err == nil {
+		err = declare(ch, r.config)
+	}
+	var deliveries <-chan amqp.Delivery
+	if err == nil {
+		deliveries, err = ch.Consume(r.config.Queue.Name, r.config.ConsumerTag, false, false, false, false, nil)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.conn = conn
+	r.mu.Unlock()
+	return deliveries, nil
+}
+
+func (r *amqpReceiver) closeConnection() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	if err != nil && err != amqp.ErrClosed {
+		return err
+	}
+	return nil
+}
+
+func (r *amqpReceiver) consumeLoop(ctx context.Context, deliveries <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-deliveries:
+			if !ok {
+				// The connection or the channel was closed
+				if deliveries = r.resubscribe(ctx); deliveries == nil {
+					return
+				}
+				continue
+			}
+			r.handleDelivery(ctx, delivery)
+		}
+	}
+}
+
+// resubscribe reconnects to the broker until it succeeds, or returns nil when
+// ctx is done.
+func (r *amqpReceiver) resubscribe(ctx context.Context) <-chan amqp.Delivery {
+	_ = r.closeConnection()
+	r.settings.Logger.Warn("Lost the connection to the broker, reconnecting", zap.Duration("reconnect_interval", r.config.ReconnectInterval))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.config.ReconnectInterval):
+		}
+		deliveries, err := r.subscribe()
+		if err == nil {
+			r.settings.Logger.Info("Reconnected to the broker")
+			return deliveries
+		}
+		r.settings.Logger.Warn("Failed to reconnect to the broker", zap.Error(err))
+	}
+}
+
+// handleDelivery acknowledges the messages consumed successfully, and rejects
+// the others. Only the messages failing with a retryable error are requeued,
+// when requeue_on_error is enabled.
+func (r *amqpReceiver) handleDelivery(ctx context.Context, delivery amqp.Delivery) {
+	if err := r.consume(ctx, delivery.Body); err != nil {
+		r.settings.Logger.Error("Failed to consume message", zap.Error(err))
+		requeue := r.config.RequeueOnError && !consumererror.IsPermanent(err)
+		if err = delivery.Nack(false, requeue); err != nil {
+			r.settings.Logger.Error("Failed to reject message", zap.Error(err))
+		}
+		return
+	}
+	if err := delivery.Ack(false); err != nil {
+		r.settings.Logger.Error("Failed to acknowledge message", zap.Error(err))
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package amqpreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// fakeBroker records the operations of the receiver on its connections.
+type fakeBroker struct {
+	mu          sync.Mutex
+	dials       int
+	dialErr     error
+	calls       []string
+	deliveries  chan amqp.Delivery
+	closedConns int
+}
+
+func (b *fakeBroker) dial(*Config) (connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dials++
+	if b.dialErr != nil {
+		return nil, b.dialErr
+	}
+	b.deliveries = make(chan amqp.Delivery)
+	return &fakeConnection{broker: b, deliveries: b.deliveries}, nil
+}
+
+func (b *fakeBroker) record(call string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, call)
+}
+
+func (b *fakeBroker) current() chan amqp.Delivery {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.deliveries
+}
+
+type fakeConnection struct {
+	broker     *fakeBroker
+	deliveries chan amqp.Delivery
+}
+
+func (c *fakeConnection) channel() (channel, error) {
+	return &fakeChannel{broker: c.broker, deliveries: c.deliveries}, nil
+}
+
+func (c *fakeConnection) Close() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.closedConns++
+	return nil
+}
+
+type fakeChannel struct {
+	broker     *fakeBroker
+	deliveries chan amqp.Delivery
+}
+
+func (c *fakeChannel) Qos(_, _ int, _ bool) error {
+	c.broker.record("qos")
+	return nil
+}
+
+func (c *fakeChannel) QueueDeclare(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	c.broker.record("queue.declare " + name)
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeChannel) ExchangeDeclare(name, kind string, _, _, _, _ bool, _ amqp.Table) error {
+	c.broker.record("exchange.declare " + name + " " + kind)
+	return nil
+}
+
+func (c *fakeChannel) QueueBind(name, key, exchange string, _ bool, _ amqp.Table) error {
+	c.broker.record("queue.bind " + name + " " + key + " " + exchange)
+	return nil
+}
+
+func (c *fakeChannel) Consume(queue, _ string, _, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	c.broker.record("consume " + queue)
+	return c.deliveries, nil
+}
+
+// acknowledger records the acknowledgements of the deliveries.
+type acknowledger struct {
+	mu      sync.Mutex
+	acks    int
+	nacks   int
+	requeue bool
+}
+
+func (a *acknowledger) Ack(uint64, bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.acks++
+	return nil
+}
+
+func (a *acknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nacks++
+	a.requeue = requeue
+	return nil
+}
+
+func (a *acknowledger) Reject(_ uint64, requeue bool) error {
+	return a.Nack(0, false, requeue)
+}
+
+func (a *acknowledger) counts() (int, int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.acks, a.nacks, a.requeue
+}
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "amqp://localhost:5672/"
+	cfg.Queue.Name = "otlp"
+	cfg.ReconnectInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func TestTracesReceiver(t *testing.T) {
+	cfg := testConfig()
+	cfg.Queue.Declare = true
+	cfg.Exchange = ExchangeConfig{Name: "telemetry", Declare: true, Type: "topic", RoutingKeys: []string{"a", "b"}}
+	sink := &consumertest.TracesSink{}
+	r, err := newTracesReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	broker := &fakeBroker{}
+	r.dial = broker.dial
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []string{
+		"qos",
+		"queue.declare otlp",
+		"exchange.declare telemetry topic",
+		"queue.bind otlp a telemetry",
+		"queue.bind otlp b telemetry",
+		"consume otlp",
+	}, broker.calls)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	body, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	ack := &acknowledger{}
+	broker.current() <- amqp.Delivery{Acknowledger: ack, Body: body}
+
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 1 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { acks, _, _ := ack.counts(); return acks == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 1, broker.closedConns)
+}
+
+func TestMetricsReceiverJSON(t *testing.T) {
+	cfg := testConfig()
+	cfg.Encoding = encodingOTLPJSON
+	sink := &consumertest.MetricsSink{}
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	broker := &fakeBroker{}
+	r.dial = broker.dial
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	body, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	broker.current() <- amqp.Delivery{Acknowledger: &acknowledger{}, Body: body}
+
+	assert.Eventually(t, func() bool { return sink.DataPointCount() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestLogsReceiverErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           []byte
+		consumerErr    error
+		requeueOnError bool
+		requeue        bool
+	}{
+		{
+			name: "invalid message",
+			body: []byte("invalid"),
+		},
+		{
+			name:           "invalid message never requeued",
+			body:           []byte("invalid"),
+			requeueOnError: true,
+		},
+		{
+			name:        "consumer error",
+			consumerErr: errors.New("failed"),
+		},
+		{
+			name:           "consumer error requeued",
+			consumerErr:    errors.New("failed"),
+			requeueOnError: true,
+			requeue:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.RequeueOnError = tt.requeueOnError
+			r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewErr(tt.consumerErr))
+			require.NoError(t, err)
+			broker := &fakeBroker{}
+			r.dial = broker.dial
+			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+			body := tt.body
+			if body == nil {
+				ld := plog.NewLogs()
+				ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+				body, err = (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+				require.NoError(t, err)
+			}
+			ack := &acknowledger{}
+			broker.current() <- amqp.Delivery{Acknowledger: ack, Body: body}
+
+			assert.Eventually(t, func() bool { _, nacks, _ := ack.counts(); return nacks == 1 }, time.Second, time.Millisecond)
+			acks, _, requeue := ack.counts()
+			assert.Equal(t, 0, acks)
+			assert.Equal(t, tt.requeue, requeue)
+			require.NoError(t, r.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r, err := newLogsReceiver(testConfig(), receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	broker := &fakeBroker{}
+	r.dial = broker.dial
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The connection is lost
+	close(broker.current())
+	assert.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return broker.dials == 2
+	}, time.Second, time.Millisecond)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	body, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	broker.current() <- amqp.Delivery{Acknowledger: &acknowledger{}, Body: body}
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 2, broker.closedConns)
+}
+
+func TestStartError(t *testing.T) {
+	r, err := newTracesReceiver(testConfig(), receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	broker := &fakeBroker{dialErr: errors.New("connection refused")}
+	r.dial = broker.dial
+
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), "connection refused")
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
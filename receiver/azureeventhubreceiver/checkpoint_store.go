@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/checkpoints"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+)
+
+const defaultStorageEndpointSuffix = "core.windows.net"
+
+// partitionProcessor balances the partitions of the Event Hub between the
+// collector replicas sharing a checkpoint store.
+type partitionProcessor interface {
+	Run(ctx context.Context) error
+	// NextPartitionClient returns the client of the next partition leased by
+	// the processor, or nil once ctx is done.
+	NextPartitionClient(ctx context.Context) partitionClient
+	Close(ctx context.Context) error
+}
+
+// partitionClient receives the events of a leased partition.
+type partitionClient interface {
+	PartitionID() string
+	ReceiveEvents(ctx context.Context, count int, options *azeventhubs.ReceiveEventsOptions) ([]*azeventhubs.ReceivedEventData, error)
+	UpdateCheckpoint(ctx context.Context, latestEvent *azeventhubs.ReceivedEventData, options *azeventhubs.UpdateCheckpointOptions) error
+	Close(ctx context.Context) error
+}
+
+type partitionProcessorImpl struct {
+	processor      *azeventhubs.Processor
+	consumerClient *azeventhubs.ConsumerClient
+}
+
+func (p *partitionProcessorImpl) Run(ctx context.Context) error {
+	return p.processor.Run(ctx)
+}
+
+func (p *partitionProcessorImpl) NextPartitionClient(ctx context.Context) partitionClient {
+	client := p.processor.NextPartitionClient(ctx)
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+func (p *partitionProcessorImpl) Close(ctx context.Context) error {
+	return p.consumerClient.Close(ctx)
+}
+
+// newPartitionProcessor creates a processor whose ownerships and checkpoints are
+// stored in Azure Blob Storage, in the checkpoint layout of the Event Hubs
+// processors of the current Azure SDKs.
+func newPartitionProcessor(ctx context.Context, config *Config) (partitionProcessor, error) {
+	containerClient, err := container.NewClientFromConnectionString(config.CheckpointStore.Connection, config.CheckpointStore.Container, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = containerClient.Create(ctx, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return nil, err
+	}
+	store, err := checkpoints.NewBlobStore(containerClient, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	consumerGroup := config.ConsumerGroup
+	if consumerGroup == "" {
+		consumerGroup = azeventhubs.DefaultConsumerGroup
+	}
+	consumerClient, err := azeventhubs.NewConsumerClientFromConnectionString(config.Connection, "", consumerGroup, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without checkpoint, a partition is read from its latest offset like when no
+	// checkpoint store is configured.
+	latest := true
+	processor, err := azeventhubs.NewProcessor(consumerClient, store, &azeventhubs.ProcessorOptions{
+		StartPositions: azeventhubs.StartPositions{
+			Default: azeventhubs.StartPosition{Latest: &latest},
+		},
+	})
+	if err != nil {
+		_ = consumerClient.Close(ctx)
+		return nil, err
+	}
+	return &partitionProcessorImpl{processor: processor, consumerClient: consumerClient}, nil
+}
+
+// toEvent converts an event received from a leased partition to the event
+// type of the unmarshalers.
+func toEvent(received *azeventhubs.ReceivedEventData) *eventhub.Event {
+	event := eventhub.NewEvent(received.Body)
+	event.Properties = received.Properties
+	event.SystemProperties = &eventhub.SystemProperties{
+		SequenceNumber: &received.SequenceNumber,
+		EnqueuedTime:   received.EnqueuedTime,
+		Offset:         &received.Offset,
+		PartitionKey:   received.PartitionKey,
+	}
+	return event
+}
+
+type storageAccount struct {
+	name                  string
+	key                   string
+	endpointSuffix        string
+	blobEndpoint          string
+	sharedAccessSignature string
+}
+
+// parseStorageConnectionString parses the connection string of a storage account,
+// e.g. DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key;EndpointSuffix=core.windows.net,
+// or BlobEndpoint=https://name.blob.core.windows.net;SharedAccessSignature=sv=...&sig=....
+func parseStorageConnectionString(connection string) (storageAccount, error) {
+	account := storageAccount{endpointSuffix: defaultStorageEndpointSuffix}
+	for _, pair := range strings.Split(connection, ";") {
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return storageAccount{}, errors.New("failed parsing connection string due to unmatched key value separated by '='")
+		}
+		switch key {
+		case "AccountName":
+			account.name = value
+		case "AccountKey":
+			account.key = value
+		case "EndpointSuffix":
+			account.endpointSuffix = value
+		case "BlobEndpoint":
+			account.blobEndpoint = value
+		case "SharedAccessSignature":
+			account.sharedAccessSignature = value
+		}
+	}
+	if account.name == "" && account.blobEndpoint == "" {
+		return storageAccount{}, errors.New("connection string must contain AccountName or BlobEndpoint")
+	}
+	if (account.name == "" || account.key == "") && account.sharedAccessSignature == "" {
+		return storageAccount{}, errors.New("connection string must contain AccountName and AccountKey, or SharedAccessSignature")
+	}
+	return account, nil
+}
@@ -24,6 +24,16 @@ var (
 	errMissingConnection = errors.New("missing connection")
 )
 
+// CheckpointStoreConfig defines the Azure Blob Storage container the partition
+// leases and checkpoints are shared in, so that the collector replicas consuming
+// the same consumer group balance the partitions between them.
+type CheckpointStoreConfig struct {
+	// Connection is the connection string of the storage account.
+	Connection string `mapstructure:"connection"`
+	// Container is the name of the blob container, created if missing.
+	Container string `mapstructure:"container"`
+}
+
 type Config struct {
 	Connection    string        `mapstructure:"connection"`
 	Partition     string        `mapstructure:"partition"`
@@ -31,6 +41,8 @@ type Config struct {
 	StorageID     *component.ID `mapstructure:"storage"`
 	Format        string        `mapstructure:"format"`
 	ConsumerGroup string        `mapstructure:"group"`
+
+	CheckpointStore *CheckpointStoreConfig `mapstructure:"checkpoint_store"`
 }
 
 func isValidFormat(format string) bool {
@@ -53,5 +65,25 @@ func (config *Config) Validate() error {
 	if !isValidFormat(config.Format) {
 		return fmt.Errorf("invalid format; must be one of %#v", validFormats)
 	}
+	if config.CheckpointStore != nil {
+		return config.CheckpointStore.validate(config)
+	}
+	return nil
+}
+
+func (cs *CheckpointStoreConfig) validate(config *Config) error {
+	if _, err := parseStorageConnectionString(cs.Connection); err != nil {
+		return fmt.Errorf("invalid checkpoint_store connection: %w", err)
+	}
+	if cs.Container == "" {
+		return errors.New("missing checkpoint_store container")
+	}
+	// The partitions and their offsets are owned by the checkpoint store
+	if config.Partition != "" || config.Offset != "" {
+		return errors.New("partition and offset cannot be set with checkpoint_store")
+	}
+	if config.StorageID != nil {
+		return errors.New("storage cannot be set with checkpoint_store")
+	}
 	return nil
 }
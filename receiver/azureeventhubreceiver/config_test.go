@@ -26,7 +26,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	r0 := cfg.Receivers[component.NewID(metadata.Type)]
 	assert.Equal(t, "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName", r0.(*Config).Connection)
@@ -39,6 +39,13 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, "1234-5566", r1.(*Config).Offset)
 	assert.Equal(t, "foo", r1.(*Config).Partition)
 	assert.Equal(t, rawLogFormat, logFormat(r1.(*Config).Format))
+
+	r2 := cfg.Receivers[component.NewIDWithName(metadata.Type, "checkpoint_store")]
+	assert.Equal(t, "collector", r2.(*Config).ConsumerGroup)
+	assert.Equal(t, &CheckpointStoreConfig{
+		Connection: "DefaultEndpointsProtocol=https;AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=;EndpointSuffix=core.windows.net",
+		Container:  "checkpoints",
+	}, r2.(*Config).CheckpointStore)
 }
 
 func TestMissingConnection(t *testing.T) {
@@ -71,3 +78,73 @@ func TestInvalidFormat(t *testing.T) {
 	err := component.ValidateConfig(cfg)
 	assert.ErrorContains(t, err, "invalid format; must be one of")
 }
+
+func TestInvalidCheckpointStore(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "invalid connection",
+			modify: func(cfg *Config) { cfg.CheckpointStore.Connection = "foo" },
+			err:    "invalid checkpoint_store connection: failed parsing connection string due to unmatched key value separated by '='",
+		},
+		{
+			name:   "missing account key",
+			modify: func(cfg *Config) { cfg.CheckpointStore.Connection = "AccountName=account" },
+			err:    "invalid checkpoint_store connection: connection string must contain AccountName and AccountKey, or SharedAccessSignature",
+		},
+		{
+			name:   "missing container",
+			modify: func(cfg *Config) { cfg.CheckpointStore.Container = "" },
+			err:    "missing checkpoint_store container",
+		},
+		{
+			name:   "partition",
+			modify: func(cfg *Config) { cfg.Partition = "foo" },
+			err:    "partition and offset cannot be set with checkpoint_store",
+		},
+		{
+			name:   "storage",
+			modify: func(cfg *Config) { cfg.StorageID = &storageID },
+			err:    "storage cannot be set with checkpoint_store",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+			cfg.CheckpointStore = &CheckpointStoreConfig{
+				Connection: "AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=",
+				Container:  "checkpoints",
+			}
+			require.NoError(t, component.ValidateConfig(cfg))
+
+			tt.modify(cfg)
+			assert.EqualError(t, component.ValidateConfig(cfg), tt.err)
+		})
+	}
+}
+
+func TestParseStorageConnectionString(t *testing.T) {
+	account, err := parseStorageConnectionString("DefaultEndpointsProtocol=https;AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=;EndpointSuffix=core.chinacloudapi.cn;")
+	require.NoError(t, err)
+	assert.Equal(t, storageAccount{name: "account", key: "c3VwZXJTZWNyZXQ=", endpointSuffix: "core.chinacloudapi.cn"}, account)
+
+	account, err = parseStorageConnectionString("AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=")
+	require.NoError(t, err)
+	assert.Equal(t, defaultStorageEndpointSuffix, account.endpointSuffix)
+
+	account, err = parseStorageConnectionString("BlobEndpoint=https://account.blob.core.windows.net/;SharedAccessSignature=sv=2022-11-02&ss=b&sig=c2lnbmF0dXJl")
+	require.NoError(t, err)
+	assert.Equal(t, "https://account.blob.core.windows.net/", account.blobEndpoint)
+	assert.Equal(t, "sv=2022-11-02&ss=b&sig=c2lnbmF0dXJl", account.sharedAccessSignature)
+
+	_, err = parseStorageConnectionString("BlobEndpoint=https://account.blob.core.windows.net/")
+	assert.EqualError(t, err, "connection string must contain AccountName and AccountKey, or SharedAccessSignature")
+
+	_, err = parseStorageConnectionString("SharedAccessSignature=sv=2022-11-02&sig=c2lnbmF0dXJl")
+	assert.EqualError(t, err, "connection string must contain AccountName or BlobEndpoint")
+}
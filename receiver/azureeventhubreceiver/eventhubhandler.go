@@ -5,15 +5,32 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 )
 
+const (
+	// receiveBatchSize is the maximum number of events received at once from
+	// a partition leased from the checkpoint store.
+	receiveBatchSize = 100
+	// receiveTimeout is how long to wait for receiveBatchSize events before
+	// consuming the events already received.
+	receiveTimeout = 10 * time.Second
+	// consumeRetryInterval is how long to wait before consuming again an event
+	// whose consumption failed.
+	consumeRetryInterval = time.Second
+)
+
 type eventHandler interface {
 	run(ctx context.Context, host component.Host) error
 	close(ctx context.Context) error
@@ -49,10 +66,14 @@ type listerHandleWrapper interface {
 }
 
 type eventhubHandler struct {
-	hub          hubWrapper
-	dataConsumer dataConsumer
-	config       *Config
-	settings     receiver.CreateSettings
+	hub       hubWrapper
+	processor partitionProcessor
+	// cancelProcessor stops the processor and the receivers of its partitions.
+	cancelProcessor context.CancelFunc
+	partitions      sync.WaitGroup
+	dataConsumer    dataConsumer
+	config          *Config
+	settings        receiver.CreateSettings
 }
 
 // Implement eventHandler Interface
@@ -60,6 +81,10 @@ var _ eventHandler = (*eventhubHandler)(nil)
 
 func (h *eventhubHandler) run(ctx context.Context, host component.Host) error {
 
+	if h.config.CheckpointStore != nil {
+		return h.runProcessor(ctx)
+	}
+
 	storageClient, err := adapter.GetStorageClient(ctx, host, h.config.StorageID, h.settings.ID)
 	if err != nil {
 		h.settings.Logger.Debug("Error connecting to Storage", zap.Error(err))
@@ -129,6 +154,101 @@ func (h *eventhubHandler) run(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// runProcessor receives the partitions leased from the checkpoint store.
+func (h *eventhubHandler) runProcessor(ctx context.Context) error {
+
+	if h.processor == nil { // set manually for testing.
+		processor, err := newPartitionProcessor(ctx, h.config)
+		if err != nil {
+			h.settings.Logger.Debug("Error connecting to Event Hub", zap.Error(err))
+			return err
+		}
+		h.processor = processor
+	}
+
+	// The processor runs until the receiver is shut down, not until ctx is done.
+	processorCtx, cancel := context.WithCancel(context.Background())
+	h.cancelProcessor = cancel
+	h.partitions.Add(1)
+	go func() {
+		defer h.partitions.Done()
+		if err := h.processor.Run(processorCtx); err != nil && processorCtx.Err() == nil {
+			h.settings.Logger.Error("Error reported by the event processor", zap.Error(err))
+		}
+	}()
+	h.partitions.Add(1)
+	go func() {
+		defer h.partitions.Done()
+		for {
+			client := h.processor.NextPartitionClient(processorCtx)
+			if client == nil {
+				return
+			}
+			h.partitions.Add(1)
+			go func() {
+				defer h.partitions.Done()
+				h.receivePartition(processorCtx, client)
+			}()
+		}
+	}()
+	return nil
+}
+
+// receivePartition consumes the events of a leased partition until the
+// partition is lost or ctx is done. The checkpoint of a batch of events is
+// only updated once all of them are consumed.
+func (h *eventhubHandler) receivePartition(ctx context.Context, client partitionClient) {
+	defer func() {
+		_ = client.Close(context.Background())
+	}()
+
+	for {
+		receiveCtx, cancel := context.WithTimeout(ctx, receiveTimeout)
+		events, err := client.ReceiveEvents(receiveCtx, receiveBatchSize, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			var ehErr *azeventhubs.Error
+			if errors.As(err, &ehErr) && ehErr.Code == azeventhubs.ErrorCodeOwnershipLost {
+				h.settings.Logger.Debug("Partition leased by another collector", zap.String("partition", client.PartitionID()))
+			} else {
+				h.settings.Logger.Error("Error receiving from partition", zap.String("partition", client.PartitionID()), zap.Error(err))
+			}
+			return
+		}
+		if len(events) == 0 {
+			continue
+		}
+
+		for _, event := range events {
+			if !h.consumeWithRetry(ctx, toEvent(event)) {
+				return
+			}
+		}
+		if err = client.UpdateCheckpoint(ctx, events[len(events)-1], nil); err != nil {
+			h.settings.Logger.Error("Error updating the checkpoint", zap.String("partition", client.PartitionID()), zap.Error(err))
+		}
+	}
+}
+
+// consumeWithRetry consumes event until it succeeds or fails permanently. It
+// returns false if ctx is done before.
+func (h *eventhubHandler) consumeWithRetry(ctx context.Context, event *eventhub.Event) bool {
+	for {
+		err := h.newMessageHandler(ctx, event)
+		if err == nil || consumererror.IsPermanent(err) {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(consumeRetryInterval):
+		}
+	}
+}
+
 func (h *eventhubHandler) setUpOnePartition(ctx context.Context, partitionID string, applyOffset bool) error {
 
 	receiverOptions := []eventhub.ReceiveOption{}
@@ -170,6 +290,16 @@ func (h *eventhubHandler) newMessageHandler(ctx context.Context, event *eventhub
 
 func (h *eventhubHandler) close(ctx context.Context) error {
 
+	if h.processor != nil {
+		h.cancelProcessor()
+		h.partitions.Wait()
+		err := h.processor.Close(ctx)
+		if err != nil {
+			return err
+		}
+		h.processor = nil
+	}
+
 	if h.hub != nil {
 		err := h.hub.Close(ctx)
 		if err != nil {
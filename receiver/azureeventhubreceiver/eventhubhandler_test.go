@@ -5,15 +5,19 @@ package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/receivertest"
@@ -44,6 +48,88 @@ func (m mockHubWrapper) Close(_ context.Context) error {
 	return nil
 }
 
+type mockPartitionProcessor struct {
+	clients chan partitionClient
+	closed  bool
+}
+
+func (m *mockPartitionProcessor) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (m *mockPartitionProcessor) NextPartitionClient(ctx context.Context) partitionClient {
+	select {
+	case client := <-m.clients:
+		return client
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (m *mockPartitionProcessor) Close(_ context.Context) error {
+	m.closed = true
+	return nil
+}
+
+type mockPartitionClient struct {
+	mu          sync.Mutex
+	batches     [][]*azeventhubs.ReceivedEventData
+	checkpoints []int64
+	closed      bool
+	// consumed returns the number of events consumed when a checkpoint is updated.
+	consumed             func() int
+	consumedAtCheckpoint []int
+}
+
+func (m *mockPartitionClient) PartitionID() string {
+	return "0"
+}
+
+func (m *mockPartitionClient) ReceiveEvents(ctx context.Context, _ int, _ *azeventhubs.ReceiveEventsOptions) ([]*azeventhubs.ReceivedEventData, error) {
+	m.mu.Lock()
+	if len(m.batches) > 0 {
+		batch := m.batches[0]
+		m.batches = m.batches[1:]
+		m.mu.Unlock()
+		return batch, nil
+	}
+	m.mu.Unlock()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (m *mockPartitionClient) UpdateCheckpoint(_ context.Context, latestEvent *azeventhubs.ReceivedEventData, _ *azeventhubs.UpdateCheckpointOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints = append(m.checkpoints, latestEvent.SequenceNumber)
+	m.consumedAtCheckpoint = append(m.consumedAtCheckpoint, m.consumed())
+	return nil
+}
+
+func (m *mockPartitionClient) Close(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *mockPartitionClient) getCheckpoints() ([]int64, []int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpoints, m.consumedAtCheckpoint
+}
+
+type funcDataConsumer func(ctx context.Context, event *eventhub.Event) error
+
+func (f funcDataConsumer) consume(ctx context.Context, event *eventhub.Event) error {
+	return f(ctx, event)
+}
+
+func (f funcDataConsumer) setNextLogsConsumer(_ consumer.Logs) {}
+
+func (f funcDataConsumer) setNextMetricsConsumer(_ consumer.Metrics) {}
+
 type mockListenerHandleWrapper struct {
 	ctx context.Context
 }
@@ -102,6 +188,130 @@ func TestEventhubHandler_Start(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestEventhubHandler_StartWithCheckpointStore(t *testing.T) {
+
+	config := createDefaultConfig()
+	config.(*Config).Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=superSecret1234=;EntityPath=hubName"
+	config.(*Config).CheckpointStore = &CheckpointStoreConfig{
+		Connection: "AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=",
+		Container:  "checkpoints",
+	}
+
+	// The first event is consumed after a retry, the second one fails permanently.
+	var mu sync.Mutex
+	var consumed []string
+	dataConsumer := funcDataConsumer(func(_ context.Context, event *eventhub.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		consumed = append(consumed, string(event.Data))
+		switch {
+		case len(consumed) == 1:
+			return errors.New("memory limit exceeded")
+		case string(event.Data) == "invalid":
+			return consumererror.NewPermanent(errors.New("failed to unmarshal logs"))
+		}
+		return nil
+	})
+	getConsumed := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), consumed...)
+	}
+
+	now := time.Now()
+	client := &mockPartitionClient{
+		batches: [][]*azeventhubs.ReceivedEventData{{
+			{EventData: azeventhubs.EventData{Body: []byte("hello")}, EnqueuedTime: &now, SequenceNumber: 1},
+			{EventData: azeventhubs.EventData{Body: []byte("invalid")}, EnqueuedTime: &now, SequenceNumber: 2},
+		}},
+		consumed: func() int { return len(getConsumed()) },
+	}
+	processor := &mockPartitionProcessor{clients: make(chan partitionClient, 1)}
+	processor.clients <- client
+	ehHandler := &eventhubHandler{
+		settings:     receivertest.NewNopCreateSettings(),
+		dataConsumer: dataConsumer,
+		config:       config.(*Config),
+		processor:    processor,
+	}
+
+	require.NoError(t, ehHandler.run(context.Background(), componenttest.NewNopHost()))
+	assert.Nil(t, ehHandler.hub)
+
+	assert.Eventually(t, func() bool {
+		checkpoints, _ := client.getCheckpoints()
+		return len(checkpoints) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	checkpoints, consumedAtCheckpoint := client.getCheckpoints()
+	assert.Equal(t, []int64{2}, checkpoints)
+	assert.Equal(t, []int{3}, consumedAtCheckpoint)
+	assert.Equal(t, []string{"hello", "hello", "invalid"}, getConsumed())
+
+	require.NoError(t, ehHandler.close(context.Background()))
+	assert.True(t, processor.closed)
+	assert.True(t, client.closed)
+	assert.Nil(t, ehHandler.processor)
+}
+
+func TestEventhubHandler_CheckpointStoreNotUpdatedOnShutdown(t *testing.T) {
+
+	config := createDefaultConfig()
+	config.(*Config).CheckpointStore = &CheckpointStoreConfig{
+		Connection: "AccountName=account;AccountKey=c3VwZXJTZWNyZXQ=",
+		Container:  "checkpoints",
+	}
+
+	client := &mockPartitionClient{
+		batches: [][]*azeventhubs.ReceivedEventData{{
+			{EventData: azeventhubs.EventData{Body: []byte("hello")}, SequenceNumber: 1},
+		}},
+		consumed: func() int { return 0 },
+	}
+	processor := &mockPartitionProcessor{clients: make(chan partitionClient, 1)}
+	processor.clients <- client
+	attempted := make(chan struct{}, 1)
+	ehHandler := &eventhubHandler{
+		settings: receivertest.NewNopCreateSettings(),
+		dataConsumer: funcDataConsumer(func(context.Context, *eventhub.Event) error {
+			select {
+			case attempted <- struct{}{}:
+			default:
+			}
+			return errors.New("exporter unavailable")
+		}),
+		config:    config.(*Config),
+		processor: processor,
+	}
+
+	require.NoError(t, ehHandler.run(context.Background(), componenttest.NewNopHost()))
+	<-attempted
+	require.NoError(t, ehHandler.close(context.Background()))
+
+	checkpoints, _ := client.getCheckpoints()
+	assert.Empty(t, checkpoints)
+}
+
+func TestToEvent(t *testing.T) {
+	now := time.Now()
+	partitionKey := "key"
+	event := toEvent(&azeventhubs.ReceivedEventData{
+		EventData: azeventhubs.EventData{
+			Body:       []byte("hello"),
+			Properties: map[string]any{"foo": "bar"},
+		},
+		EnqueuedTime:   &now,
+		PartitionKey:   &partitionKey,
+		Offset:         12,
+		SequenceNumber: 3,
+	})
+	assert.Equal(t, []byte("hello"), event.Data)
+	assert.Equal(t, map[string]any{"foo": "bar"}, event.Properties)
+	assert.Equal(t, &now, event.SystemProperties.EnqueuedTime)
+	assert.Equal(t, int64(12), *event.SystemProperties.Offset)
+	assert.Equal(t, int64(3), *event.SystemProperties.SequenceNumber)
+	assert.Equal(t, "key", *event.SystemProperties.PartitionKey)
+}
+
 func TestEventhubHandler_newMessageHandler(t *testing.T) {
 
 	config := createDefaultConfig()
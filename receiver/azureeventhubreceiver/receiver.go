@@ -11,6 +11,7 @@ import (
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
@@ -87,14 +88,14 @@ func (receiver *eventhubReceiver) consumeLogs(ctx context.Context, event *eventh
 	}
 
 	if receiver.logsUnmarshaler == nil {
-		return errors.New("unable to unmarshal logs with configured format")
+		return consumererror.NewPermanent(errors.New("unable to unmarshal logs with configured format"))
 	}
 
 	logsContext := receiver.obsrecv.StartLogsOp(ctx)
 
 	logs, err := receiver.logsUnmarshaler.UnmarshalLogs(event)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal logs: %w", err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal logs: %w", err))
 	}
 
 	receiver.logger.Debug("Log Records", zap.Any("logs", logs))
@@ -111,14 +112,14 @@ func (receiver *eventhubReceiver) consumeMetrics(ctx context.Context, event *eve
 	}
 
 	if receiver.metricsUnmarshaler == nil {
-		return errors.New("unable to unmarshal metrics with configured format")
+		return consumererror.NewPermanent(errors.New("unable to unmarshal metrics with configured format"))
 	}
 
 	metricsContext := receiver.obsrecv.StartMetricsOp(ctx)
 
 	metrics, err := receiver.metricsUnmarshaler.UnmarshalMetrics(event)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal metrics: %w", err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal metrics: %w", err))
 	}
 
 	receiver.logger.Debug("Metric Records", zap.Any("metrics", metrics))
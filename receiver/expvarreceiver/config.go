@@ -18,6 +18,8 @@ type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	// ResourceAttributes are static attributes set on the resource of the scraped metrics.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -48,8 +49,10 @@ func TestLoadConfig(t *testing.T) {
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: "http://localhost:8000/custom/path",
 					Timeout:  time.Second * 5,
+					Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("bearertokenauth")},
 				},
 				MetricsBuilderConfig: metricCfg,
+				ResourceAttributes:   map[string]string{"service.name": "my-service"},
 			},
 		},
 		{
@@ -83,7 +86,7 @@ func TestLoadConfig(t *testing.T) {
 				return
 			}
 			assert.NoError(t, component.ValidateConfig(cfg))
-			if diff := cmp.Diff(tt.expected, cfg, cmpopts.IgnoreUnexported(metadata.MetricConfig{})); diff != "" {
+			if diff := cmp.Diff(tt.expected, cfg, cmpopts.IgnoreUnexported(metadata.MetricConfig{}), cmpopts.EquateComparable(component.ID{})); diff != "" {
 				t.Errorf("Config mismatch (-expected +actual):\n%s", diff)
 			}
 		})
@@ -104,7 +104,11 @@ func (e *expVarScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	// The most recent pause is at PauseNs[(NumGC+255)%256].
 	e.mb.RecordProcessRuntimeMemstatsLastPauseDataPoint(now, int64(memStats.PauseNs[(memStats.NumGC+255)%256]))
 
-	return e.mb.Emit(), nil
+	res := pcommon.NewResource()
+	for k, v := range e.cfg.ResourceAttributes {
+		res.Attributes().PutStr(k, v)
+	}
+	return e.mb.Emit(metadata.WithResource(res)), nil
 }
 
 func decodeResponseBody(body io.ReadCloser) (*expVar, error) {
@@ -12,7 +12,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -184,3 +187,63 @@ func TestEmptyResponseBodyError(t *testing.T) {
 	require.EqualError(t, err, "could not decode response body to JSON: EOF")
 	require.NoError(t, pmetrictest.CompareMetrics(expectedMetrics, actualMetrics))
 }
+
+// extensionsHost is a host with extensions.
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAuthAndResourceAttributes(t *testing.T) {
+	ms := newMockServer(t, filepath.Join("testdata", "response", "expvar_response.json"))
+	defer ms.Close()
+	authServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ms.Config.Handler.ServeHTTP(rw, req)
+	}))
+	defer authServer.Close()
+
+	authID := component.NewID("bearertokenauth")
+	authenticator := auth.NewClient(auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			return base.RoundTrip(req)
+		}), nil
+	}))
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{authID: authenticator},
+	}
+
+	cfg := newDefaultConfig().(*Config)
+	cfg.Endpoint = authServer.URL + defaultPath
+	cfg.ResourceAttributes = map[string]string{"service.name": "my-service"}
+
+	scraper := newExpVarScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	_, err := scraper.scrape(context.Background())
+	require.EqualError(t, err, "expected 200 but received 401 status code")
+
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: authID}
+	scraper = newExpVarScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), host))
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, actualMetrics.ResourceMetrics().Len())
+	serviceName, ok := actualMetrics.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	require.Equal(t, "my-service", serviceName.Str())
+}
@@ -4,6 +4,8 @@
 package filelogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver"
 
@@ -44,16 +46,34 @@ func createDefaultConfig() *FileLogConfig {
 
 // BaseConfig gets the base config from config, for now
 func (f ReceiverType) BaseConfig(cfg component.Config) adapter.BaseConfig {
-	return cfg.(*FileLogConfig).BaseConfig
+	fileLogConfig := cfg.(*FileLogConfig)
+	if len(fileLogConfig.Streams) == 0 {
+		return fileLogConfig.BaseConfig
+	}
+	baseConfig := fileLogConfig.BaseConfig
+	baseConfig.Operators = append(fileLogConfig.streamOperators(), fileLogConfig.Operators...)
+	return baseConfig
 }
 
 // FileLogConfig defines configuration for the filelog receiver
 type FileLogConfig struct {
 	InputConfig        file.Config `mapstructure:",squash"`
 	adapter.BaseConfig `mapstructure:",squash"`
+	Streams            []StreamConfig `mapstructure:"streams"`
+}
+
+// Validate checks that spilled logs can be persisted when buffering is enabled.
+func (cfg *FileLogConfig) Validate() error {
+	if cfg.InputConfig.Buffer.Enabled && cfg.StorageID == nil {
+		return errors.New("`buffer` requires a `storage` extension")
+	}
+	return nil
 }
 
 // InputConfig unmarshals the input operator
 func (f ReceiverType) InputConfig(cfg component.Config) operator.Config {
-	return operator.NewConfig(&cfg.(*FileLogConfig).InputConfig)
+	fileLogConfig := cfg.(*FileLogConfig)
+	inputConfig := fileLogConfig.InputConfig
+	inputConfig.Streams = fileLogConfig.fileconsumerStreams()
+	return operator.NewConfig(&inputConfig)
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -171,6 +172,54 @@ func (l *recallLogger) close() error {
 	return l.logFile.Close()
 }
 
+func TestStorageBuffer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	logsDir := t.TempDir()
+	storageDir := t.TempDir()
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := rotationTestConfig(logsDir)
+	cfg.Operators = nil
+	cfg.StorageID = &ext.ID
+	cfg.InputConfig.Buffer.Enabled = true
+	cfg.InputConfig.Buffer.MaxInMemory = 1
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	logger := newRecallLogger(t, logsDir)
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(ctx, receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err, "failed to create receiver")
+	require.NoError(t, rcvr.Start(ctx, host))
+
+	for i := 0; i < 20; i++ {
+		logger.log(fmt.Sprintf("This is a simple log line with the number %3d", i))
+	}
+
+	require.Eventually(t,
+		expectLogs(sink, logger.recall()),
+		5*time.Second,
+		10*time.Millisecond,
+		"expected 20 but got %d logs",
+		sink.LogRecordCount(),
+	)
+
+	require.NoError(t, rcvr.Shutdown(ctx))
+	for _, e := range host.GetExtensions() {
+		require.NoError(t, e.Shutdown(ctx))
+	}
+	require.NoError(t, logger.close())
+}
+
+func TestBufferRequiresStorage(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.InputConfig.Buffer.Enabled = true
+	require.Error(t, component.ValidateConfig(cfg))
+}
+
 func expectLogs(sink *consumertest.LogsSink, expected []string) func() bool {
 	return func() bool {
 
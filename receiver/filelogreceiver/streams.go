@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filelogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/add"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/router"
+)
+
+const (
+	streamsRouterID = "streams_router"
+	streamsJoinID   = "streams_join"
+)
+
+// StreamConfig defines a named set of files read by the receiver, along with the
+// operators and resource attributes that apply only to entries read from those files.
+// All streams share a single poll loop and checkpoint store.
+type StreamConfig struct {
+	Name             string `mapstructure:"name"`
+	matcher.Criteria `mapstructure:",squash"`
+	Resource         map[string]string `mapstructure:"resource"`
+	Operators        []operator.Config `mapstructure:"operators"`
+}
+
+func (c *FileLogConfig) fileconsumerStreams() []fileconsumer.StreamConfig {
+	if len(c.Streams) == 0 {
+		return nil
+	}
+	streams := make([]fileconsumer.StreamConfig, 0, len(c.Streams))
+	for _, s := range c.Streams {
+		streams = append(streams, fileconsumer.StreamConfig{Name: s.Name, Criteria: s.Criteria})
+	}
+	return streams
+}
+
+// streamOperators builds the operator graph that fans entries out to the
+// operators of each stream and joins them back before the top level operators.
+//
+//	file_input -> streams_router -> <stream operators> -> streams_join -> <operators>
+func (c *FileLogConfig) streamOperators() []operator.Config {
+	routes := make([]*router.RouteConfig, 0, len(c.Streams))
+	var ops []operator.Config
+	for _, s := range c.Streams {
+		chain := make([]operator.Config, 0, len(s.Resource)+len(s.Operators))
+		keys := make([]string, 0, len(s.Resource))
+		for k := range s.Resource {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			addCfg := add.NewConfigWithID(fmt.Sprintf("%s/resource/%s", s.Name, k))
+			addCfg.Field = entry.NewResourceField(k)
+			addCfg.Value = s.Resource[k]
+			chain = append(chain, operator.NewConfig(addCfg))
+		}
+		chain = append(chain, scopeOperatorIDs(s.Name, s.Operators)...)
+
+		route := &router.RouteConfig{
+			Expression: fmt.Sprintf("attributes[%q] == %q", attrs.LogFileStream, s.Name),
+			OutputIDs:  []string{streamsJoinID},
+		}
+		if len(chain) > 0 {
+			route.OutputIDs = []string{chain[0].ID()}
+			chain[len(chain)-1] = operator.NewConfig(&outputOverride{Builder: chain[len(chain)-1].Builder, outputIDs: []string{streamsJoinID}})
+		}
+		routes = append(routes, route)
+		ops = append(ops, chain...)
+	}
+
+	routerCfg := router.NewConfigWithID(streamsRouterID)
+	routerCfg.Routes = routes
+	routerCfg.Default = []string{streamsJoinID}
+
+	result := make([]operator.Config, 0, len(ops)+2)
+	result = append(result, operator.NewConfig(routerCfg))
+	result = append(result, ops...)
+	result = append(result, operator.NewConfig(noop.NewConfigWithID(streamsJoinID)))
+	return result
+}
+
+// scopeOperatorIDs prefixes default operator IDs with the stream name so that
+// streams may use the same operator types without colliding with each other.
+// Operators with an explicitly configured ID are left untouched.
+func scopeOperatorIDs(stream string, ops []operator.Config) []operator.Config {
+	counts := make(map[string]int)
+	for _, op := range ops {
+		if op.ID() != op.Type() {
+			continue
+		}
+		id := fmt.Sprintf("%s/%s", stream, op.Type())
+		if n := counts[op.Type()]; n > 0 {
+			id = fmt.Sprintf("%s%d", id, n)
+		}
+		counts[op.Type()]++
+		op.SetID(id)
+	}
+	return ops
+}
+
+// outputOverride sets the outputs of the operator it builds, unless the
+// operator has already been configured with explicit outputs.
+type outputOverride struct {
+	operator.Builder
+	outputIDs []string
+}
+
+func (o *outputOverride) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	op, err := o.Builder.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	if op.CanOutput() && len(op.GetOutputIDs()) == 0 {
+		op.SetOutputIDs(o.outputIDs)
+	}
+	return op, nil
+}
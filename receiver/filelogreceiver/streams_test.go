@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filelogreceiver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func loadStreamsConfig(t *testing.T) *FileLogConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "streams.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewID("filelog").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+	return cfg.(*FileLogConfig)
+}
+
+func TestLoadStreamsConfig(t *testing.T) {
+	cfg := loadStreamsConfig(t)
+
+	require.Len(t, cfg.Streams, 2)
+	assert.Equal(t, "app1", cfg.Streams[0].Name)
+	assert.Equal(t, []string{"testdata/streams/app1/*.log"}, cfg.Streams[0].Include)
+	assert.Equal(t, map[string]string{"service.name": "app1"}, cfg.Streams[0].Resource)
+	require.Len(t, cfg.Streams[0].Operators, 1)
+	assert.Equal(t, "regex_parser", cfg.Streams[0].Operators[0].Type())
+
+	assert.Equal(t, "app2", cfg.Streams[1].Name)
+	require.Len(t, cfg.Streams[1].Operators, 1)
+	assert.Equal(t, "json_parser", cfg.Streams[1].Operators[0].Type())
+}
+
+func TestStreamOperators(t *testing.T) {
+	cfg := loadStreamsConfig(t)
+
+	rt := ReceiverType{}
+	var ids []string
+	for _, op := range rt.BaseConfig(cfg).Operators {
+		ids = append(ids, op.ID())
+	}
+	assert.Equal(t, []string{
+		streamsRouterID,
+		"app1/resource/service.name",
+		"app1/regex_parser",
+		"app2/resource/service.name",
+		"app2/json_parser",
+		streamsJoinID,
+		"move",
+	}, ids)
+
+	inputCfg := rt.InputConfig(cfg)
+	require.NoError(t, componenttest.CheckConfigStruct(inputCfg.Builder))
+	assert.Empty(t, cfg.InputConfig.Streams, "input config of the receiver must not be modified")
+}
+
+func TestReadStreams(t *testing.T) {
+	t.Parallel()
+
+	cfg := loadStreamsConfig(t)
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}()
+
+	require.Eventually(t, expectNLogs(sink, 2), 2*time.Second, 5*time.Millisecond)
+
+	byService := map[string]plog.LogRecord{}
+	for _, logs := range sink.AllLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			rl := logs.ResourceLogs().At(i)
+			serviceName, ok := rl.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			byService[serviceName.Str()] = rl.ScopeLogs().At(0).LogRecords().At(0)
+		}
+	}
+	require.Len(t, byService, 2)
+
+	app1 := byService["app1"].Attributes().AsRaw()
+	assert.Equal(t, "app1 started", app1["msg"])
+	assert.Equal(t, "INFO", app1["sev"])
+	assert.Equal(t, "app1", app1["stream"])
+
+	app2 := byService["app2"].Attributes().AsRaw()
+	assert.Equal(t, "app2 started", app2["msg"])
+	assert.Equal(t, "warn", app2["level"])
+	assert.Equal(t, "app2", app2["stream"])
+}
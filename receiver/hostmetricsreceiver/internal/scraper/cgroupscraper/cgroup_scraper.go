@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/common"
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	cpuMetricsLen    = 2
+	memoryMetricsLen = 2
+	ioMetricsLen     = 2
+	pidsMetricsLen   = 1
+
+	// cgroupMountPoint is where the cgroup v2 hierarchy is mounted, relative
+	// to the root path.
+	cgroupMountPoint = "/sys/fs/cgroup"
+)
+
+// containerIDPattern matches the cgroup directories of the containers, named
+// after their IDs by the container runtimes, such as docker-<id>.scope,
+// cri-containerd-<id>.scope, crio-<id>.scope or <id> with the cgroupfs driver.
+var containerIDPattern = regexp.MustCompile(`^(?:[a-z-]+-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+// scraper for Cgroup Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+	// root is the directory the cgroup v2 hierarchy is mounted on.
+	root string
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+}
+
+// newCgroupScraper creates a Cgroup Scraper
+func newCgroupScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	scraper := &scraper{
+		settings: settings,
+		config:   cfg,
+		root:     filepath.Join(cfg.RootPath, cgroupMountPoint),
+		bootTime: host.BootTimeWithContext,
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(ctx context.Context, _ component.Host) error {
+	// the cgroup.controllers file only exists at the root of a cgroup v2 hierarchy,
+	// the cgroup v1 hierarchies being mounted in subdirectories
+	if _, err := os.Stat(filepath.Join(s.root, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("no cgroup v2 hierarchy mounted on %q: %w", s.root, err)
+	}
+
+	ctx = context.WithValue(ctx, common.EnvKey, s.config.EnvMap)
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	err := filepath.WalkDir(s.root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if dir == s.root {
+				return err
+			}
+			// the cgroups can be removed while they are walked
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs.Add(err)
+			}
+			return nil
+		}
+		if !d.IsDir() || dir == s.root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, dir)
+		if err != nil {
+			return err
+		}
+		path := "/" + filepath.ToSlash(rel)
+		if s.config.MaxDepth > 0 && strings.Count(path, "/") > s.config.MaxDepth {
+			return fs.SkipDir
+		}
+
+		containerID := containerIDFromPath(path)
+		if (s.config.ContainersOnly && containerID == "") ||
+			(s.includeFS != nil && !s.includeFS.Matches(path)) ||
+			(s.excludeFS != nil && s.excludeFS.Matches(path)) {
+			return nil
+		}
+		s.scrapeCgroup(dir, path, containerID, &errs)
+		return nil
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// scrapeCgroup records the metrics of a cgroup, the metrics of the controllers
+// not enabled for it being skipped.
+func (s *scraper) scrapeCgroup(dir, path, containerID string, errs *scrapererror.ScrapeErrors) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	if err := s.scrapeAndAppendCPUMetrics(now, dir); err != nil {
+		errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu stats for cgroup %q: %w", path, err))
+	}
+
+	if err := s.scrapeAndAppendMemoryMetrics(now, dir); err != nil {
+		errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory stats for cgroup %q: %w", path, err))
+	}
+
+	if err := s.scrapeAndAppendIOMetrics(now, dir); err != nil {
+		errs.AddPartial(ioMetricsLen, fmt.Errorf("error reading io stats for cgroup %q: %w", path, err))
+	}
+
+	if err := s.scrapeAndAppendPidsMetric(now, dir); err != nil {
+		errs.AddPartial(pidsMetricsLen, fmt.Errorf("error reading pids for cgroup %q: %w", path, err))
+	}
+
+	rb := s.mb.NewResourceBuilder()
+	rb.SetCgroupPath(path)
+	if containerID != "" {
+		rb.SetContainerID(containerID)
+	}
+	s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+func (s *scraper) scrapeAndAppendCPUMetrics(now pcommon.Timestamp, dir string) error {
+	stats, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+	if err != nil || stats == nil {
+		return err
+	}
+
+	if usec, ok := stats["user_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, float64(usec)/1e6, metadata.AttributeStateUser)
+	}
+	if usec, ok := stats["system_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, float64(usec)/1e6, metadata.AttributeStateSystem)
+	}
+	// only reported when the cpu controller is enabled
+	if usec, ok := stats["throttled_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUThrottledTimeDataPoint(now, float64(usec)/1e6)
+	}
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendMemoryMetrics(now pcommon.Timestamp, dir string) error {
+	usage, ok, err := readSingleValue(filepath.Join(dir, "memory.current"))
+	if err != nil || !ok {
+		return err
+	}
+	s.mb.RecordSystemCgroupMemoryUsageDataPoint(now, usage)
+
+	limit, ok, err := readSingleValue(filepath.Join(dir, "memory.max"))
+	if err != nil || !ok {
+		return err
+	}
+	s.mb.RecordSystemCgroupMemoryLimitDataPoint(now, limit)
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendIOMetrics(now pcommon.Timestamp, dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	// every line holds the nested keys of a device, such as
+	// 8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		device := fields[0]
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("invalid io.stat field %q", field)
+			}
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			switch key {
+			case "rbytes":
+				s.mb.RecordSystemCgroupIoBytesDataPoint(now, v, device, metadata.AttributeDirectionRead)
+			case "wbytes":
+				s.mb.RecordSystemCgroupIoBytesDataPoint(now, v, device, metadata.AttributeDirectionWrite)
+			case "rios":
+				s.mb.RecordSystemCgroupIoOperationsDataPoint(now, v, device, metadata.AttributeDirectionRead)
+			case "wios":
+				s.mb.RecordSystemCgroupIoOperationsDataPoint(now, v, device, metadata.AttributeDirectionWrite)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *scraper) scrapeAndAppendPidsMetric(now pcommon.Timestamp, dir string) error {
+	pids, ok, err := readSingleValue(filepath.Join(dir, "pids.current"))
+	if err != nil || !ok {
+		return err
+	}
+	s.mb.RecordSystemCgroupPidsDataPoint(now, pids)
+	return nil
+}
+
+// containerIDFromPath returns the ID of the container of the cgroup, found in
+// the deepest directory of its path named after a container, or an empty string.
+func containerIDFromPath(path string) string {
+	dirs := strings.Split(path, "/")
+	for i := len(dirs) - 1; i >= 0; i-- {
+		// the cgroups of the conmon processes monitoring the CRI-O and Podman
+		// containers are named after the containers as well
+		if strings.Contains(dirs[i], "conmon") {
+			continue
+		}
+		if m := containerIDPattern.FindStringSubmatch(dirs[i]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// readFlatKeyed reads a file of "<key> <value>" lines, such as cpu.stat, and
+// returns nil when the file does not exist.
+func readFlatKeyed(file string) (map[string]int64, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	values := make(map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[fields[0]] = v
+	}
+	return values, scanner.Err()
+}
+
+// readSingleValue reads a file holding a single value, such as memory.current,
+// and reports whether the value is set: it is not when the file does not exist
+// or when the value is "max", meaning unlimited.
+func readSingleValue(file string) (int64, bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, false, nil
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return v, true, nil
+}
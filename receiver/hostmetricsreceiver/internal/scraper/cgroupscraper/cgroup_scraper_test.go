@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	testContainerID   = "3f4e1a7c9b2d8e6f0a1b2c3d4e5f60718293a4b5c6d7e8f90123456789abcdef"
+	testContainerPath = "/system.slice/docker-" + testContainerID + ".scope"
+)
+
+func newTestScraper(t *testing.T, cfg *Config) *scraper {
+	cfg.MetricsBuilderConfig = metadata.DefaultMetricsBuilderConfig()
+	cfg.Metrics.SystemCgroupIoOperations.Enabled = true
+	cfg.Metrics.SystemCgroupPids.Enabled = true
+	cfg.SetRootPath("testdata")
+
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// scrapedCgroups returns the metrics of the scraped cgroups by path.
+func scrapedCgroups(t *testing.T, md pmetric.Metrics) map[string]pmetric.ResourceMetrics {
+	cgroups := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, ok := rm.Resource().Attributes().Get("cgroup.path")
+		require.True(t, ok)
+		cgroups[path.Str()] = rm
+	}
+	return cgroups
+}
+
+// dataPoints returns the values of the data points of the metrics by name and attributes.
+func dataPoints(rm pmetric.ResourceMetrics) map[string]float64 {
+	values := map[string]float64{}
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		dps := m.Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			key := m.Name()
+			dps.At(j).Attributes().Range(func(k string, v pcommon.Value) bool {
+				key += "/" + v.AsString()
+				return true
+			})
+			if dps.At(j).ValueType() == pmetric.NumberDataPointValueTypeDouble {
+				values[key] = dps.At(j).DoubleValue()
+			} else {
+				values[key] = float64(dps.At(j).IntValue())
+			}
+		}
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, &Config{})
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	cgroups := scrapedCgroups(t, md)
+	require.Len(t, cgroups, 3, "Must scrape all the cgroups but the root one")
+
+	container := cgroups[testContainerPath]
+	assert.Equal(t, map[string]any{
+		"cgroup.path":  testContainerPath,
+		"container.id": testContainerID,
+	}, container.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]float64{
+		"system.cgroup.cpu.time/user":             2,
+		"system.cgroup.cpu.time/system":           1.5,
+		"system.cgroup.cpu.throttled_time":        0.25,
+		"system.cgroup.memory.usage":              104857600,
+		"system.cgroup.memory.limit":              268435456,
+		"system.cgroup.io.bytes/8:0/read":         1459200,
+		"system.cgroup.io.bytes/8:0/write":        314773504,
+		"system.cgroup.io.bytes/253:1/read":       4096,
+		"system.cgroup.io.bytes/253:1/write":      0,
+		"system.cgroup.io.operations/8:0/read":    192,
+		"system.cgroup.io.operations/8:0/write":   353,
+		"system.cgroup.io.operations/253:1/read":  1,
+		"system.cgroup.io.operations/253:1/write": 0,
+		"system.cgroup.pids":                      12,
+	}, dataPoints(container))
+	assert.Equal(t, pcommon.Timestamp(100*1e9), container.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp())
+
+	slice := cgroups["/system.slice"]
+	assert.Equal(t, map[string]any{"cgroup.path": "/system.slice"}, slice.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]float64{
+		"system.cgroup.cpu.time/user":      5,
+		"system.cgroup.cpu.time/system":    2.5,
+		"system.cgroup.cpu.throttled_time": 0,
+		"system.cgroup.memory.usage":       524288000,
+	}, dataPoints(slice), "Must not report the limit of cgroups with unlimited memory")
+
+	assert.Equal(t, map[string]float64{
+		"system.cgroup.cpu.time/user":   1,
+		"system.cgroup.cpu.time/system": 0.2,
+	}, dataPoints(cgroups["/user.slice"]), "Must skip the metrics of the controllers not enabled")
+}
+
+func TestScrapeFilters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *Config
+		expected []string
+	}{
+		{
+			name:     "ContainersOnly",
+			config:   &Config{ContainersOnly: true},
+			expected: []string{testContainerPath},
+		},
+		{
+			name:     "MaxDepth",
+			config:   &Config{MaxDepth: 1},
+			expected: []string{"/system.slice", "/user.slice"},
+		},
+		{
+			name: "Include",
+			config: &Config{Include: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Paths:  []string{`^/system\.slice/`},
+			}},
+			expected: []string{testContainerPath},
+		},
+		{
+			name: "Exclude",
+			config: &Config{Exclude: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Paths:  []string{"/system.slice"},
+			}},
+			expected: []string{testContainerPath, "/user.slice"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestScraper(t, tc.config)
+
+			md, err := s.scrape(context.Background())
+			require.NoError(t, err)
+
+			var paths []string
+			for path := range scrapedCgroups(t, md) {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tc.expected, paths)
+		})
+	}
+}
+
+func TestScrapeInvalidStats(t *testing.T) {
+	root := t.TempDir()
+	mountPoint := filepath.Join(root, cgroupMountPoint)
+	require.NoError(t, os.MkdirAll(filepath.Join(mountPoint, "broken.slice"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(mountPoint, "cgroup.controllers"), []byte("cpu memory\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(mountPoint, "broken.slice", "cpu.stat"), []byte("user_usec 10\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(mountPoint, "broken.slice", "memory.current"), []byte("lots\n"), 0o600))
+
+	cfg := &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()}
+	cfg.SetRootPath(root)
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `error reading memory stats for cgroup "/broken.slice"`)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, 1, md.MetricCount(), "Must report the metrics read")
+}
+
+func TestStartWithoutCgroupV2(t *testing.T) {
+	cfg := &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()}
+	cfg.SetRootPath(t.TempDir())
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = s.start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "no cgroup v2 hierarchy mounted on")
+}
+
+func TestContainerIDFromPath(t *testing.T) {
+	testCases := map[string]string{
+		"/system.slice/docker-" + testContainerID + ".scope":                                       testContainerID,
+		"/kubepods.slice/kubepods-pod1234_5678.slice/cri-containerd-" + testContainerID + ".scope": testContainerID,
+		"/kubepods.slice/kubepods-burstable.slice/crio-" + testContainerID + ".scope/container":    testContainerID,
+		"/machine.slice/libpod-conmon-" + testContainerID + ".scope":                               "",
+		"/kubepods/burstable/pod0d2c1b6e-58b4-4b40-9f2f-2a9e6b8e0f11/" + testContainerID:           testContainerID,
+		"/system.slice/containerd.service":                                                         "",
+		"/user.slice/user-1000.slice":                                                              "",
+	}
+	for path, expected := range testCases {
+		assert.Equal(t, expected, containerIDFromPath(path), path)
+	}
+}
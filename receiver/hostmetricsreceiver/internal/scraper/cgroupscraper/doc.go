@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package cgroupscraper scrapes the CPU, memory and IO usage of the cgroups of
+// the cgroup v2 hierarchy, so that the usage of the containers is known on the
+// nodes without cAdvisor.
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
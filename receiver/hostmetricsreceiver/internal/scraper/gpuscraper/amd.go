@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rocmSMIArgs are the arguments of rocm-smi printing the readings of all the
+// GPUs as json.
+var rocmSMIArgs = []string{
+	"--showproductname",
+	"--showuniqueid",
+	"--showuse",
+	"--showmeminfo", "vram",
+	"--showtemp",
+	"--showpower",
+	"--json",
+}
+
+// rocmSMI reads the AMD GPUs with rocm-smi.
+type rocmSMI struct {
+	path string
+	run  runFunc
+}
+
+func (r *rocmSMI) vendor() string {
+	return "amd"
+}
+
+func (r *rocmSMI) devices(ctx context.Context) ([]device, error) {
+	out, err := r.run(ctx, r.path, rocmSMIArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the readings are keyed by card, such as card0, and named after their units
+	var cards map[string]map[string]string
+	if err = json.Unmarshal(out, &cards); err != nil {
+		return nil, fmt.Errorf("invalid rocm-smi output: %w", err)
+	}
+
+	indexes := make([]int, 0, len(cards))
+	for key := range cards {
+		// other keys, such as system, hold the readings of the host
+		index, ok := strings.CutPrefix(key, "card")
+		if !ok {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	devices := make([]device, 0, len(indexes))
+	for _, i := range indexes {
+		card := cards["card"+strconv.Itoa(i)]
+		d := device{
+			index: strconv.Itoa(i),
+			uuid:  card["Unique ID"],
+			model: firstOf(card, "Card series", "Card model"),
+		}
+		if utilization := parseFloat(card["GPU use (%)"]); utilization != nil {
+			*utilization /= 100
+			d.utilization = utilization
+		}
+		total := parseInt(card["VRAM Total Memory (B)"])
+		d.memoryUsed = parseInt(card["VRAM Total Used Memory (B)"])
+		if total != nil && d.memoryUsed != nil {
+			free := *total - *d.memoryUsed
+			d.memoryFree = &free
+		}
+		d.temperature = parseFloat(firstOf(card, "Temperature (Sensor edge) (C)", "Temperature (Sensor junction) (C)"))
+		d.power = parseFloat(firstOf(card, "Average Graphics Package Power (W)", "Current Socket Graphics Package Power (W)"))
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// firstOf returns the first reading found under the keys, which depend on the
+// GPU and the version of rocm-smi.
+func firstOf(card map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := card[key]; ok {
+			return v
+		}
+	}
+	return ""
+}
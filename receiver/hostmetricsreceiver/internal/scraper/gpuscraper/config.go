@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper/internal/metadata"
+)
+
+// Config relating to GPU Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+	internal.ScraperConfig
+
+	// NvidiaSMIPath is the path of the nvidia-smi tool reading the NVIDIA GPUs through NVML,
+	// looked up in the PATH when it is a name. The default value is nvidia-smi.
+	NvidiaSMIPath string `mapstructure:"nvidia_smi_path"`
+
+	// ROCmSMIPath is the path of the rocm-smi tool reading the AMD GPUs,
+	// looked up in the PATH when it is a name. The default value is rocm-smi.
+	ROCmSMIPath string `mapstructure:"rocm_smi_path"`
+
+	// Timeout is the maximum amount of time the tools are given to read the GPUs.
+	// The default value is 10 seconds (10s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
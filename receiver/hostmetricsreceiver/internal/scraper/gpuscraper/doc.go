@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package gpuscraper scrapes the utilization, memory, temperature and power of
+// the NVIDIA and AMD GPUs with the nvidia-smi and rocm-smi tools of their drivers.
+package gpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
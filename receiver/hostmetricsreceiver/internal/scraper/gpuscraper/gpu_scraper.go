@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper/internal/metadata"
+)
+
+const metricsLen = 5
+
+// device holds the readings of a GPU, the ones not supported by the GPU being nil.
+type device struct {
+	index string
+	uuid  string
+	model string
+
+	utilization *float64
+	memoryUsed  *int64
+	memoryFree  *int64
+	temperature *float64
+	power       *float64
+
+	processes []gpuProcess
+}
+
+// gpuProcess is a process running on a GPU.
+type gpuProcess struct {
+	pid        int64
+	name       string
+	memoryUsed int64
+}
+
+// vendorTool reads the GPUs of a vendor.
+type vendorTool interface {
+	vendor() string
+	// devices returns the GPUs, along with an error when only the processes
+	// running on them can't be read, or nil and an error when they can't be.
+	devices(ctx context.Context) ([]device, error)
+}
+
+// runFunc runs a command and returns its standard output.
+type runFunc func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// scraper for GPU Metrics
+type scraper struct {
+	settings receiver.CreateSettings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+	tools    []vendorTool
+
+	// for mocking
+	lookPath func(file string) (string, error)
+	run      runFunc
+}
+
+// newGPUScraper creates a GPU Scraper
+func newGPUScraper(settings receiver.CreateSettings, cfg *Config) *scraper {
+	return &scraper{
+		settings: settings,
+		config:   cfg,
+		lookPath: exec.LookPath,
+		run:      runCommand,
+	}
+}
+
+// start looks for the tools of the vendors, and fails when none is found.
+func (s *scraper) start(context.Context, component.Host) error {
+	if path, err := s.lookPath(s.config.NvidiaSMIPath); err == nil {
+		s.tools = append(s.tools, &nvidiaSMI{path: path, run: s.run})
+	}
+	if path, err := s.lookPath(s.config.ROCmSMIPath); err == nil {
+		s.tools = append(s.tools, &rocmSMI{path: path, run: s.run})
+	}
+	if len(s.tools) == 0 {
+		return fmt.Errorf("no GPU tool found, looked for %q and %q", s.config.NvidiaSMIPath, s.config.ROCmSMIPath)
+	}
+	for _, tool := range s.tools {
+		s.settings.Logger.Info("Reading GPUs", zap.String("vendor", tool.vendor()))
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	for _, tool := range s.tools {
+		devices, err := s.readDevices(ctx, tool)
+		if err != nil && devices == nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("error reading %s GPUs: %w", tool.vendor(), err))
+			continue
+		}
+		if err != nil {
+			// the readings of the GPUs are reported without the processes
+			errs.AddPartial(1, fmt.Errorf("error reading the processes of the %s GPUs: %w", tool.vendor(), err))
+		}
+
+		now := pcommon.NewTimestampFromTime(time.Now())
+		for _, d := range devices {
+			s.recordDeviceMetrics(now, d)
+
+			rb := s.mb.NewResourceBuilder()
+			rb.SetGpuVendor(tool.vendor())
+			rb.SetGpuIndex(d.index)
+			rb.SetGpuUUID(d.uuid)
+			rb.SetGpuModel(d.model)
+			s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) readDevices(ctx context.Context, tool vendorTool) ([]device, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	return tool.devices(ctx)
+}
+
+func (s *scraper) recordDeviceMetrics(now pcommon.Timestamp, d device) {
+	if d.utilization != nil {
+		s.mb.RecordSystemGpuUtilizationDataPoint(now, *d.utilization)
+	}
+	if d.memoryUsed != nil {
+		s.mb.RecordSystemGpuMemoryUsageDataPoint(now, *d.memoryUsed, metadata.AttributeStateUsed)
+	}
+	if d.memoryFree != nil {
+		s.mb.RecordSystemGpuMemoryUsageDataPoint(now, *d.memoryFree, metadata.AttributeStateFree)
+	}
+	if d.temperature != nil {
+		s.mb.RecordSystemGpuTemperatureDataPoint(now, *d.temperature)
+	}
+	if d.power != nil {
+		s.mb.RecordSystemGpuPowerDataPoint(now, *d.power)
+	}
+	for _, p := range d.processes {
+		s.mb.RecordSystemGpuProcessMemoryUsageDataPoint(now, p.memoryUsed, p.pid, p.name)
+	}
+}
+
+// parseFloat parses a reading, returning nil when the GPU doesn't support it,
+// in which case the tools report values such as [N/A] or [Not Supported].
+func parseFloat(value string) *float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// parseInt parses a reading like parseFloat.
+func parseInt(value string) *int64 {
+	v, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuscraper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// fakeTools returns the functions looking up and running the tools found, which
+// print the outputs of the testdata files.
+func fakeTools(t *testing.T, found ...string) (func(string) (string, error), runFunc) {
+	lookPath := func(file string) (string, error) {
+		for _, f := range found {
+			if f == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}
+	run := func(_ context.Context, name string, args ...string) ([]byte, error) {
+		var file string
+		switch {
+		case name == "/usr/bin/nvidia-smi" && strings.HasPrefix(args[0], "--query-gpu="):
+			file = "nvidia_smi_gpus.csv"
+		case name == "/usr/bin/nvidia-smi" && strings.HasPrefix(args[0], "--query-compute-apps="):
+			file = "nvidia_smi_processes.csv"
+		case name == "/usr/bin/rocm-smi":
+			file = "rocm_smi.json"
+		default:
+			return nil, errors.New("exit status 9")
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		return data, nil
+	}
+	return lookPath, run
+}
+
+func newTestScraper(t *testing.T, found ...string) *scraper {
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	s := newGPUScraper(receivertest.NewNopCreateSettings(), cfg)
+	s.lookPath, s.run = fakeTools(t, found...)
+	return s
+}
+
+// scrapedGPUs returns the data points of the scraped GPUs by vendor and index.
+func scrapedGPUs(md pmetric.Metrics) map[string]map[string]any {
+	gpus := map[string]map[string]any{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		attrs := rm.Resource().Attributes()
+		vendor, _ := attrs.Get("gpu.vendor")
+		index, _ := attrs.Get("gpu.index")
+		values := map[string]any{}
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			values[k] = v.AsString()
+			return true
+		})
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			m := ms.At(j)
+			var dps pmetric.NumberDataPointSlice
+			if m.Type() == pmetric.MetricTypeGauge {
+				dps = m.Gauge().DataPoints()
+			} else {
+				dps = m.Sum().DataPoints()
+			}
+			for k := 0; k < dps.Len(); k++ {
+				key := m.Name()
+				dps.At(k).Attributes().Range(func(_ string, v pcommon.Value) bool {
+					key += "/" + v.AsString()
+					return true
+				})
+				if dps.At(k).ValueType() == pmetric.NumberDataPointValueTypeDouble {
+					values[key] = dps.At(k).DoubleValue()
+				} else {
+					values[key] = dps.At(k).IntValue()
+				}
+			}
+		}
+		gpus[vendor.Str()+"/"+index.Str()] = values
+	}
+	return gpus
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, "nvidia-smi", "rocm-smi")
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[string]any{
+		"nvidia/0": {
+			"gpu.vendor":                   "nvidia",
+			"gpu.index":                    "0",
+			"gpu.uuid":                     "GPU-5a3e8f2c-1d4b-4c7a-9e6f-0b2d8c4a1e93",
+			"gpu.model":                    "NVIDIA A100-SXM4-40GB",
+			"system.gpu.utilization":       0.87,
+			"system.gpu.memory.usage/used": int64(30517 * mebibyte),
+			"system.gpu.memory.usage/free": int64(10443 * mebibyte),
+			"system.gpu.temperature":       64.0,
+			"system.gpu.power":             312.45,
+			"system.gpu.process.memory.usage/4242/python3":      int64(28672 * mebibyte),
+			"system.gpu.process.memory.usage/4315/tritonserver": int64(1840 * mebibyte),
+		},
+		"nvidia/1": {
+			"gpu.vendor":                   "nvidia",
+			"gpu.index":                    "1",
+			"gpu.uuid":                     "GPU-b71c2d9e-6a0f-4e3b-8d15-7c9a2e4f6b01",
+			"gpu.model":                    "NVIDIA A100-SXM4-40GB",
+			"system.gpu.utilization":       0.0,
+			"system.gpu.memory.usage/used": int64(4 * mebibyte),
+			"system.gpu.memory.usage/free": int64(40956 * mebibyte),
+			"system.gpu.temperature":       31.0,
+		},
+		"amd/0": {
+			"gpu.vendor":                   "amd",
+			"gpu.index":                    "0",
+			"gpu.uuid":                     "0x9cd1ac1a2c08c2e4",
+			"gpu.model":                    "Instinct MI210",
+			"system.gpu.utilization":       0.35,
+			"system.gpu.memory.usage/used": int64(17179869184),
+			"system.gpu.memory.usage/free": int64(68702699520 - 17179869184),
+			"system.gpu.temperature":       45.0,
+			"system.gpu.power":             92.0,
+		},
+		"amd/1": {
+			"gpu.vendor":                   "amd",
+			"gpu.index":                    "1",
+			"gpu.uuid":                     "0x4a2d9e0b7c31f586",
+			"gpu.model":                    "Instinct MI210",
+			"system.gpu.utilization":       0.0,
+			"system.gpu.memory.usage/used": int64(10960896),
+			"system.gpu.memory.usage/free": int64(68702699520 - 10960896),
+			"system.gpu.temperature":       39.0,
+			"system.gpu.power":             41.0,
+		},
+	}, scrapedGPUs(md))
+}
+
+func TestStartWithoutTools(t *testing.T) {
+	s := newTestScraper(t)
+	err := s.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `no GPU tool found, looked for "nvidia-smi" and "rocm-smi"`)
+}
+
+func TestScrapeToolError(t *testing.T) {
+	s := newTestScraper(t, "nvidia-smi", "rocm-smi")
+	run := s.run
+	s.run = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		if name == "/usr/bin/nvidia-smi" {
+			return nil, errors.New("exit status 9")
+		}
+		return run(ctx, name, args...)
+	}
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.EqualError(t, err, "error reading nvidia GPUs: exit status 9")
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Len(t, scrapedGPUs(md), 2, "Must report the GPUs of the other vendors")
+}
+
+func TestScrapeProcessesError(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes string
+		expected  string
+	}{
+		{
+			name:     "query failure",
+			expected: "error reading the processes of the nvidia GPUs: exit status 9",
+		},
+		{
+			name:      "invalid pid",
+			processes: "GPU-5a3e8f2c-1d4b-4c7a-9e6f-0b2d8c4a1e93, N/A, /usr/bin/python3, 28672\nGPU-5a3e8f2c-1d4b-4c7a-9e6f-0b2d8c4a1e93, 4315, /opt/triton/bin/tritonserver, 1840\n",
+			expected:  `error reading the processes of the nvidia GPUs: invalid pid "N/A": strconv.ParseInt: parsing "N/A": invalid syntax`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScraper(t, "nvidia-smi")
+			run := s.run
+			s.run = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+				if strings.HasPrefix(args[0], "--query-compute-apps=") {
+					if tt.processes == "" {
+						return nil, errors.New("exit status 9")
+					}
+					return []byte(tt.processes), nil
+				}
+				return run(ctx, name, args...)
+			}
+			require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+			md, err := s.scrape(context.Background())
+			assert.EqualError(t, err, tt.expected)
+			assert.True(t, scrapererror.IsPartialScrapeError(err))
+			gpus := scrapedGPUs(md)
+			require.Len(t, gpus, 2, "Must report the GPUs without their processes")
+			assert.Equal(t, 0.87, gpus["nvidia/0"]["system.gpu.utilization"])
+			assert.NotContains(t, gpus["nvidia/0"], "system.gpu.process.memory.usage/4242/python3")
+		})
+	}
+}
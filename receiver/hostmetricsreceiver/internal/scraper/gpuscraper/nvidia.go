@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+const mebibyte = 1024 * 1024
+
+var (
+	// nvidiaGPUQuery and nvidiaProcessQuery are the fields queried from nvidia-smi,
+	// in the order of the columns of its output.
+	nvidiaGPUQuery     = []string{"index", "uuid", "name", "utilization.gpu", "memory.used", "memory.free", "temperature.gpu", "power.draw"}
+	nvidiaProcessQuery = []string{"gpu_uuid", "pid", "process_name", "used_memory"}
+)
+
+// nvidiaSMI reads the NVIDIA GPUs with nvidia-smi, which reads them through NVML.
+type nvidiaSMI struct {
+	path string
+	run  runFunc
+}
+
+func (n *nvidiaSMI) vendor() string {
+	return "nvidia"
+}
+
+func (n *nvidiaSMI) devices(ctx context.Context) ([]device, error) {
+	gpus, err := n.query(ctx, "--query-gpu", nvidiaGPUQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]device, 0, len(gpus))
+	byUUID := make(map[string]int, len(gpus))
+	for _, gpu := range gpus {
+		d := device{
+			index: gpu[0],
+			uuid:  gpu[1],
+			model: gpu[2],
+		}
+		if utilization := parseFloat(gpu[3]); utilization != nil {
+			*utilization /= 100
+			d.utilization = utilization
+		}
+		d.memoryUsed = parseMiB(gpu[4])
+		d.memoryFree = parseMiB(gpu[5])
+		d.temperature = parseFloat(gpu[6])
+		d.power = parseFloat(gpu[7])
+		byUUID[d.uuid] = len(devices)
+		devices = append(devices, d)
+	}
+
+	processes, err := n.query(ctx, "--query-compute-apps", nvidiaProcessQuery)
+	if err != nil {
+		return devices, err
+	}
+	var errs error
+	for _, process := range processes {
+		i, ok := byUUID[process[0]]
+		if !ok {
+			continue
+		}
+		pid, err := strconv.ParseInt(process[1], 10, 64)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid pid %q: %w", process[1], err))
+			continue
+		}
+		memoryUsed := parseMiB(process[3])
+		if memoryUsed == nil {
+			continue
+		}
+		devices[i].processes = append(devices[i].processes, gpuProcess{
+			pid:        pid,
+			name:       filepath.Base(process[2]),
+			memoryUsed: *memoryUsed,
+		})
+	}
+	return devices, errs
+}
+
+// query runs nvidia-smi with the query flag and returns the rows of its csv output.
+func (n *nvidiaSMI) query(ctx context.Context, flag string, fields []string) ([][]string, error) {
+	out, err := n.run(ctx, n.path, flag+"="+strings.Join(fields, ","), "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	r := csv.NewReader(bytes.NewReader(out))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = len(fields)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid nvidia-smi output: %w", err)
+	}
+	return rows, nil
+}
+
+// parseMiB parses an amount of memory in MiB and returns it in bytes.
+func parseMiB(value string) *int64 {
+	v := parseInt(value)
+	if v != nil {
+		*v *= mebibyte
+	}
+	return v
+}
@@ -4,6 +4,8 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -30,11 +32,19 @@ type MessageMarking struct {
 	// Note: this can block the entire partition in case a message processing returns
 	// a permanent error.
 	OnError bool `mapstructure:"on_error"`
+
+	// MaxInFlight is the maximum number of messages of a partition sent to the
+	// pipeline concurrently when After is true. The offset of a message is only
+	// marked once it and all the messages before it were processed (default 1).
+	MaxInFlight int `mapstructure:"max_in_flight"`
 }
 
 type HeaderExtraction struct {
 	ExtractHeaders bool     `mapstructure:"extract_headers"`
 	Headers        []string `mapstructure:"headers"`
+	// Target is where the extracted headers are attached, either to the resource
+	// attributes or to the attributes of the records (default "resource").
+	Target string `mapstructure:"target"`
 }
 
 // Config defines configuration for Kafka receiver.
@@ -43,7 +53,8 @@ type Config struct {
 	Brokers []string `mapstructure:"brokers"`
 	// Kafka protocol version
 	ProtocolVersion string `mapstructure:"protocol_version"`
-	// The name of the kafka topic to consume from (default "otlp_spans")
+	// The name of the kafka topic to consume from (default "otlp_spans"), or a
+	// regular expression matching the topics to consume from when it starts with "^".
 	Topic string `mapstructure:"topic"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
@@ -51,6 +62,15 @@ type Config struct {
 	GroupID string `mapstructure:"group_id"`
 	// The consumer client ID that receiver will use (default "otel-collector")
 	ClientID string `mapstructure:"client_id"`
+	// The strategy assigning the partitions to the members of the consumer group,
+	// one of "range", "roundrobin" or "sticky" (default "range").
+	GroupRebalanceStrategy string `mapstructure:"group_rebalance_strategy"`
+	// The timeout after which a member of the consumer group is removed from it
+	// when the brokers received no heartbeat (default 10s).
+	SessionTimeout time.Duration `mapstructure:"session_timeout"`
+	// The interval between the heartbeats sent to the consumer group coordinator,
+	// lower than the session timeout (default 3s).
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
 	// The initial offset to use if no offset was previously committed.
 	// Must be `latest` or `earliest` (default "latest").
 	InitialOffset string `mapstructure:"initial_offset"`
@@ -74,11 +94,56 @@ type Config struct {
 const (
 	offsetLatest   string = "latest"
 	offsetEarliest string = "earliest"
+
+	rebalanceStrategyRange      string = "range"
+	rebalanceStrategyRoundRobin string = "roundrobin"
+	rebalanceStrategySticky     string = "sticky"
+	// rebalanceStrategyCooperativeSticky is rejected, since the kafka client only
+	// implements the eager rebalance protocol.
+	rebalanceStrategyCooperativeSticky string = "cooperative-sticky"
+
+	headerTargetResource string = "resource"
+	headerTargetRecord   string = "record"
 )
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if isTopicPattern(cfg.Topic) {
+		if _, err := regexp.Compile(cfg.Topic); err != nil {
+			return fmt.Errorf("topic pattern %q is not a valid regular expression: %w", cfg.Topic, err)
+		}
+	}
+	switch cfg.GroupRebalanceStrategy {
+	case rebalanceStrategyRange, rebalanceStrategyRoundRobin, rebalanceStrategySticky:
+	case rebalanceStrategyCooperativeSticky:
+		return fmt.Errorf("group_rebalance_strategy %q is not supported, the kafka client only implements the eager rebalance protocol",
+			rebalanceStrategyCooperativeSticky)
+	default:
+		return fmt.Errorf("group_rebalance_strategy must be one of %q, %q or %q, got %q",
+			rebalanceStrategyRange, rebalanceStrategyRoundRobin, rebalanceStrategySticky, cfg.GroupRebalanceStrategy)
+	}
+	// Zero uses the default of the kafka client
+	if cfg.HeartbeatInterval < 0 || cfg.SessionTimeout < 0 {
+		return fmt.Errorf("session_timeout and heartbeat_interval must not be negative")
+	}
+	if cfg.HeartbeatInterval > 0 && cfg.SessionTimeout > 0 && cfg.HeartbeatInterval >= cfg.SessionTimeout {
+		return fmt.Errorf("heartbeat_interval %v must be lower than session_timeout %v", cfg.HeartbeatInterval, cfg.SessionTimeout)
+	}
+	if cfg.MessageMarking.MaxInFlight < 1 {
+		return fmt.Errorf("message_marking.max_in_flight must be at least 1, got %d", cfg.MessageMarking.MaxInFlight)
+	}
+	if cfg.MessageMarking.MaxInFlight > 1 && !cfg.MessageMarking.After {
+		return fmt.Errorf("message_marking.max_in_flight requires message_marking.after to be enabled")
+	}
+	if cfg.HeaderExtraction.ExtractHeaders {
+		switch cfg.HeaderExtraction.Target {
+		case headerTargetResource, headerTargetRecord:
+		default:
+			return fmt.Errorf("header_extraction.target must be %q or %q, got %q",
+				headerTargetResource, headerTargetRecord, cfg.HeaderExtraction.Target)
+		}
+	}
 	return nil
 }
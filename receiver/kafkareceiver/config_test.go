@@ -33,12 +33,15 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, ""),
 			expected: &Config{
-				Topic:         "spans",
-				Encoding:      "otlp_proto",
-				Brokers:       []string{"foo:123", "bar:456"},
-				ClientID:      "otel-collector",
-				GroupID:       "otel-collector",
-				InitialOffset: "latest",
+				Topic:                  "spans",
+				Encoding:               "otlp_proto",
+				Brokers:                []string{"foo:123", "bar:456"},
+				ClientID:               "otel-collector",
+				GroupID:                "otel-collector",
+				GroupRebalanceStrategy: "range",
+				SessionTimeout:         10 * time.Second,
+				HeartbeatInterval:      3 * time.Second,
+				InitialOffset:          "latest",
 				Authentication: kafka.Authentication{
 					TLS: &configtls.TLSClientSetting{
 						TLSSetting: configtls.TLSSetting{
@@ -59,18 +62,27 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				MessageMarking: MessageMarking{
+					MaxInFlight: 1,
+				},
+				HeaderExtraction: HeaderExtraction{
+					Target: "resource",
+				},
 			},
 		},
 		{
 
 			id: component.NewIDWithName(metadata.Type, "logs"),
 			expected: &Config{
-				Topic:         "logs",
-				Encoding:      "direct",
-				Brokers:       []string{"coffee:123", "foobar:456"},
-				ClientID:      "otel-collector",
-				GroupID:       "otel-collector",
-				InitialOffset: "earliest",
+				Topic:                  "logs",
+				Encoding:               "direct",
+				Brokers:                []string{"coffee:123", "foobar:456"},
+				ClientID:               "otel-collector",
+				GroupID:                "otel-collector",
+				GroupRebalanceStrategy: "sticky",
+				SessionTimeout:         30 * time.Second,
+				HeartbeatInterval:      5 * time.Second,
+				InitialOffset:          "earliest",
 				Authentication: kafka.Authentication{
 					TLS: &configtls.TLSClientSetting{
 						TLSSetting: configtls.TLSSetting{
@@ -91,6 +103,46 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				MessageMarking: MessageMarking{
+					After:       true,
+					MaxInFlight: 16,
+				},
+				HeaderExtraction: HeaderExtraction{
+					Target: "resource",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "headers"),
+			expected: &Config{
+				Topic:                  "otlp_spans",
+				Encoding:               "otlp_proto",
+				Brokers:                []string{"localhost:9092"},
+				ClientID:               "otel-collector",
+				GroupID:                "otel-collector",
+				GroupRebalanceStrategy: "range",
+				SessionTimeout:         10 * time.Second,
+				HeartbeatInterval:      3 * time.Second,
+				InitialOffset:          "latest",
+				Metadata: kafkaexporter.Metadata{
+					Full: true,
+					Retry: kafkaexporter.MetadataRetry{
+						Max:     3,
+						Backoff: time.Millisecond * 250,
+					},
+				},
+				AutoCommit: AutoCommit{
+					Enable:   true,
+					Interval: 1 * time.Second,
+				},
+				MessageMarking: MessageMarking{
+					MaxInFlight: 1,
+				},
+				HeaderExtraction: HeaderExtraction{
+					ExtractHeaders: true,
+					Headers:        []string{"tenant"},
+					Target:         "record",
+				},
 			},
 		},
 	}
@@ -109,3 +161,90 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHeaderExtractionTarget(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.HeaderExtraction.Target = "scope"
+	// The target is only used when extracting headers
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.HeaderExtraction.Target = ""
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.HeaderExtraction.ExtractHeaders = true
+	assert.EqualError(t, component.ValidateConfig(cfg), `header_extraction.target must be "resource" or "record", got ""`)
+	cfg.HeaderExtraction.Target = "scope"
+	assert.EqualError(t, component.ValidateConfig(cfg), `header_extraction.target must be "resource" or "record", got "scope"`)
+}
+
+func TestValidateMessageMarking(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.MessageMarking.MaxInFlight = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "message_marking.max_in_flight must be at least 1, got 0")
+
+	cfg.MessageMarking.MaxInFlight = 4
+	assert.EqualError(t, component.ValidateConfig(cfg), "message_marking.max_in_flight requires message_marking.after to be enabled")
+
+	cfg.MessageMarking.After = true
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateTopicsAndGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "topic pattern",
+			modify: func(cfg *Config) { cfg.Topic = "^otlp_spans_.*" },
+		},
+		{
+			name:   "invalid topic pattern",
+			modify: func(cfg *Config) { cfg.Topic = "^otlp_spans_(" },
+			err:    "topic pattern \"^otlp_spans_(\" is not a valid regular expression: error parsing regexp: missing closing ): `^otlp_spans_(`",
+		},
+		{
+			name:   "cooperative sticky",
+			modify: func(cfg *Config) { cfg.GroupRebalanceStrategy = "cooperative-sticky" },
+			err:    "group_rebalance_strategy \"cooperative-sticky\" is not supported, the kafka client only implements the eager rebalance protocol",
+		},
+		{
+			name:   "unknown strategy",
+			modify: func(cfg *Config) { cfg.GroupRebalanceStrategy = "random" },
+			err:    "group_rebalance_strategy must be one of \"range\", \"roundrobin\" or \"sticky\", got \"random\"",
+		},
+		{
+			name:   "heartbeat after session timeout",
+			modify: func(cfg *Config) { cfg.HeartbeatInterval = cfg.SessionTimeout },
+			err:    "heartbeat_interval 10s must be lower than session_timeout 10s",
+		},
+		{
+			name: "client default timeouts",
+			modify: func(cfg *Config) {
+				cfg.SessionTimeout = 0
+				cfg.HeartbeatInterval = 0
+			},
+		},
+		{
+			name:   "client default session timeout",
+			modify: func(cfg *Config) { cfg.SessionTimeout = 0 },
+		},
+		{
+			name:   "negative session timeout",
+			modify: func(cfg *Config) { cfg.SessionTimeout = -time.Second },
+			err:    "session_timeout and heartbeat_interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := component.ValidateConfig(cfg)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
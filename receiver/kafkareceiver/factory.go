@@ -35,6 +35,11 @@ const (
 	defaultAutoCommitEnable = true
 	// default from sarama.NewConfig()
 	defaultAutoCommitInterval = 1 * time.Second
+
+	// default from sarama.NewConfig()
+	defaultSessionTimeout = 10 * time.Second
+	// default from sarama.NewConfig()
+	defaultHeartbeatInterval = 3 * time.Second
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -90,12 +95,15 @@ func NewFactory(options ...FactoryOption) receiver.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Topic:         defaultTopic,
-		Encoding:      defaultEncoding,
-		Brokers:       []string{defaultBroker},
-		ClientID:      defaultClientID,
-		GroupID:       defaultGroupID,
-		InitialOffset: defaultInitialOffset,
+		Topic:                  defaultTopic,
+		Encoding:               defaultEncoding,
+		Brokers:                []string{defaultBroker},
+		ClientID:               defaultClientID,
+		GroupID:                defaultGroupID,
+		GroupRebalanceStrategy: rebalanceStrategyRange,
+		SessionTimeout:         defaultSessionTimeout,
+		HeartbeatInterval:      defaultHeartbeatInterval,
+		InitialOffset:          defaultInitialOffset,
 		Metadata: kafkaexporter.Metadata{
 			Full: defaultMetadataFull,
 			Retry: kafkaexporter.MetadataRetry{
@@ -108,11 +116,13 @@ func createDefaultConfig() component.Config {
 			Interval: defaultAutoCommitInterval,
 		},
 		MessageMarking: MessageMarking{
-			After:   false,
-			OnError: false,
+			After:       false,
+			OnError:     false,
+			MaxInFlight: 1,
 		},
 		HeaderExtraction: HeaderExtraction{
 			ExtractHeaders: false,
+			Target:         headerTargetResource,
 		},
 	}
 }
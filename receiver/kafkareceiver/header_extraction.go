@@ -26,6 +26,9 @@ type HeaderExtractor interface {
 type headerExtractor struct {
 	logger  *zap.Logger
 	headers []string
+	// target is where the headers are attached, either headerTargetResource or
+	// headerTargetRecord
+	target string
 }
 
 func (he *headerExtractor) extractHeadersTraces(traces ptrace.Traces, message *sarama.ConsumerMessage) {
@@ -37,7 +40,16 @@ func (he *headerExtractor) extractHeadersTraces(traces ptrace.Traces, message *s
 		}
 		for i := 0; i < traces.ResourceSpans().Len(); i++ {
 			rs := traces.ResourceSpans().At(i)
-			rs.Resource().Attributes().PutStr(getAttribute(header), value)
+			if he.target != headerTargetRecord {
+				rs.Resource().Attributes().PutStr(getAttribute(header), value)
+				continue
+			}
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				spans := rs.ScopeSpans().At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					spans.At(k).Attributes().PutStr(getAttribute(header), value)
+				}
+			}
 		}
 	}
 }
@@ -51,7 +63,16 @@ func (he *headerExtractor) extractHeadersLogs(logs plog.Logs, message *sarama.Co
 		}
 		for i := 0; i < logs.ResourceLogs().Len(); i++ {
 			rl := logs.ResourceLogs().At(i)
-			rl.Resource().Attributes().PutStr(getAttribute(header), value)
+			if he.target != headerTargetRecord {
+				rl.Resource().Attributes().PutStr(getAttribute(header), value)
+				continue
+			}
+			for j := 0; j < rl.ScopeLogs().Len(); j++ {
+				records := rl.ScopeLogs().At(j).LogRecords()
+				for k := 0; k < records.Len(); k++ {
+					records.At(k).Attributes().PutStr(getAttribute(header), value)
+				}
+			}
 		}
 	}
 }
@@ -65,11 +86,49 @@ func (he *headerExtractor) extractHeadersMetrics(metrics pmetric.Metrics, messag
 		}
 		for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
 			rm := metrics.ResourceMetrics().At(i)
-			rm.Resource().Attributes().PutStr(getAttribute(header), value)
+			if he.target != headerTargetRecord {
+				rm.Resource().Attributes().PutStr(getAttribute(header), value)
+				continue
+			}
+			for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+				ms := rm.ScopeMetrics().At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					putDataPointsAttribute(ms.At(k), getAttribute(header), value)
+				}
+			}
 		}
 	}
 }
 
+// putDataPointsAttribute sets the attribute key to value on all the data points
+// of metric.
+func putDataPointsAttribute(metric pmetric.Metric, key, value string) {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			metric.Gauge().DataPoints().At(i).Attributes().PutStr(key, value)
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			metric.Sum().DataPoints().At(i).Attributes().PutStr(key, value)
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			metric.Histogram().DataPoints().At(i).Attributes().PutStr(key, value)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			metric.ExponentialHistogram().DataPoints().At(i).Attributes().PutStr(key, value)
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			metric.Summary().DataPoints().At(i).Attributes().PutStr(key, value)
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
 func getHeaderValue(headers []*sarama.RecordHeader, header string) (string, bool) {
 	for _, kafkaHeader := range headers {
 		headerKey := string(kafkaHeader.Key)
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -203,6 +204,42 @@ func TestHeaderExtractionMetrics(t *testing.T) {
 
 }
 
+func TestHeaderExtractionRecordTarget(t *testing.T) {
+	he := &headerExtractor{
+		logger:  zaptest.NewLogger(t),
+		headers: []string{"tenant", "missing"},
+		target:  headerTargetRecord,
+	}
+	message := &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	he.extractHeadersTraces(td, message)
+	assert.Equal(t, 0, td.ResourceSpans().At(0).Resource().Attributes().Len())
+	assert.Equal(t, map[string]any{"kafka.header.tenant": "acme"},
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	he.extractHeadersLogs(ld, message)
+	assert.Equal(t, 0, ld.ResourceLogs().At(0).Resource().Attributes().Len())
+	assert.Equal(t, map[string]any{"kafka.header.tenant": "acme"},
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+
+	md := testdata.GenerateMetricsOneMetric()
+	he.extractHeadersMetrics(md, message)
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("kafka.header.tenant")
+	assert.False(t, ok)
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		v, ok := dps.At(i).Attributes().Get("kafka.header.tenant")
+		require.True(t, ok)
+		assert.Equal(t, "acme", v.Str())
+	}
+}
+
 func validateHeader(t *testing.T, rs pcommon.Resource, headerKey string, headerValue string) {
 	val, ok := rs.Attributes().Get(headerKey)
 	assert.Equal(t, ok, true)
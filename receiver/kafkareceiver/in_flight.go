@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// consumeInFlight sends up to marking.MaxInFlight messages of claim to the
+// pipeline concurrently with handle. The offset of a message is only marked once
+// the pipeline accepted it and all the messages before it, so that no message
+// is committed before being exported, or persisted by a persistent queue.
+// Without marking.OnError, the first failure stops the marking of the partition
+// and is returned once the messages in flight are handled.
+func consumeInFlight(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, marking MessageMarking, autocommitEnabled bool, handle func(*sarama.ConsumerMessage) error) error {
+	tracker := newOffsetTracker(session, marking.OnError, autocommitEnabled)
+	slots := make(chan struct{}, marking.MaxInFlight)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-tracker.failed:
+				return tracker.err
+			case <-session.Context().Done():
+				return nil
+			}
+			tracker.add(message)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tracker.done(message, handle(message))
+				<-slots
+			}()
+
+		case <-tracker.failed:
+			return tracker.err
+
+		// Should return when `session.Context()` is done, see the sequential consumption.
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// offsetTracker marks the offset of the last message of a partition handled
+// after all the messages before it.
+type offsetTracker struct {
+	session           sarama.ConsumerGroupSession
+	markOnError       bool
+	autocommitEnabled bool
+
+	mu sync.Mutex
+	// pending are the messages in flight, by offset
+	pending []*pendingMessage
+	// failed is closed with the first error when the failed messages are not marked
+	failed chan struct{}
+	err    error
+}
+
+type pendingMessage struct {
+	message *sarama.ConsumerMessage
+	handled bool
+}
+
+func newOffsetTracker(session sarama.ConsumerGroupSession, markOnError, autocommitEnabled bool) *offsetTracker {
+	return &offsetTracker{
+		session:           session,
+		markOnError:       markOnError,
+		autocommitEnabled: autocommitEnabled,
+		failed:            make(chan struct{}),
+	}
+}
+
+// add tracks message, received after the messages already tracked.
+func (t *offsetTracker) add(message *sarama.ConsumerMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, &pendingMessage{message: message})
+}
+
+// done records that message was handled with err, and marks the offset of the
+// messages handled with all the messages before them.
+func (t *offsetTracker) done(message *sarama.ConsumerMessage, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil && !t.markOnError {
+		// The message and the ones after it are never marked
+		if t.err == nil {
+			t.err = err
+			close(t.failed)
+		}
+		return
+	}
+	for _, pending := range t.pending {
+		if pending.message == message {
+			pending.handled = true
+			break
+		}
+	}
+
+	var last *sarama.ConsumerMessage
+	for len(t.pending) > 0 && t.pending[0].handled {
+		last = t.pending[0].message
+		t.pending = t.pending[1:]
+	}
+	if last == nil {
+		return
+	}
+	t.session.MarkMessage(last, "")
+	if !t.autocommitEnabled {
+		t.session.Commit()
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// markingConsumerGroupSession records the marked offsets.
+type markingConsumerGroupSession struct {
+	testConsumerGroupSession
+
+	mu      sync.Mutex
+	marked  []int64
+	commits int
+}
+
+func (s *markingConsumerGroupSession) MarkMessage(message *sarama.ConsumerMessage, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, message.Offset)
+}
+
+func (s *markingConsumerGroupSession) Commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commits++
+}
+
+func (s *markingConsumerGroupSession) markedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.marked...)
+}
+
+func TestOffsetTracker(t *testing.T) {
+	session := &markingConsumerGroupSession{}
+	tracker := newOffsetTracker(session, false, false)
+	messages := make([]*sarama.ConsumerMessage, 4)
+	for i := range messages {
+		messages[i] = &sarama.ConsumerMessage{Offset: int64(i)}
+		tracker.add(messages[i])
+	}
+
+	// Nothing is marked before the first message is handled
+	tracker.done(messages[1], nil)
+	tracker.done(messages[2], nil)
+	assert.Empty(t, session.markedOffsets())
+
+	tracker.done(messages[0], nil)
+	assert.Equal(t, []int64{2}, session.markedOffsets())
+	assert.Equal(t, 1, session.commits)
+
+	tracker.done(messages[3], nil)
+	assert.Equal(t, []int64{2, 3}, session.markedOffsets())
+}
+
+func TestOffsetTracker_error(t *testing.T) {
+	session := &markingConsumerGroupSession{}
+	tracker := newOffsetTracker(session, false, true)
+	messages := make([]*sarama.ConsumerMessage, 3)
+	for i := range messages {
+		messages[i] = &sarama.ConsumerMessage{Offset: int64(i)}
+		tracker.add(messages[i])
+	}
+
+	tracker.done(messages[1], errors.New("failed to consume"))
+	tracker.done(messages[2], nil)
+	tracker.done(messages[0], nil)
+	assert.Equal(t, []int64{0}, session.markedOffsets())
+	assert.Zero(t, session.commits)
+	assert.EqualError(t, tracker.err, "failed to consume")
+	select {
+	case <-tracker.failed:
+	default:
+		assert.Fail(t, "the tracker should have failed")
+	}
+}
+
+func TestOffsetTracker_markOnError(t *testing.T) {
+	session := &markingConsumerGroupSession{}
+	tracker := newOffsetTracker(session, true, true)
+	messages := []*sarama.ConsumerMessage{{Offset: 0}, {Offset: 1}}
+	tracker.add(messages[0])
+	tracker.add(messages[1])
+
+	tracker.done(messages[0], errors.New("failed to consume"))
+	tracker.done(messages[1], nil)
+	assert.Equal(t, []int64{0, 1}, session.markedOffsets())
+	assert.NoError(t, tracker.err)
+}
+
+func TestConsumeInFlight(t *testing.T) {
+	session := &markingConsumerGroupSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+	claim := testConsumerGroupClaim{messageChan: make(chan *sarama.ConsumerMessage)}
+
+	// The first message is handled once the second one is in flight
+	release := make(chan struct{})
+	var inFlight sync.WaitGroup
+	inFlight.Add(2)
+	handle := func(message *sarama.ConsumerMessage) error {
+		inFlight.Done()
+		if message.Offset == 0 {
+			<-release
+		}
+		return nil
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- consumeInFlight(session, claim, MessageMarking{After: true, MaxInFlight: 2}, true, handle)
+	}()
+	claim.messageChan <- &sarama.ConsumerMessage{Offset: 0}
+	claim.messageChan <- &sarama.ConsumerMessage{Offset: 1}
+	inFlight.Wait()
+	assert.Empty(t, session.markedOffsets())
+
+	close(release)
+	close(claim.messageChan)
+	require.NoError(t, <-done)
+	assert.Equal(t, []int64{1}, session.markedOffsets())
+}
+
+func TestConsumeInFlight_error(t *testing.T) {
+	session := &markingConsumerGroupSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+	claim := testConsumerGroupClaim{messageChan: make(chan *sarama.ConsumerMessage)}
+	consumerError := errors.New("failed to consume")
+
+	done := make(chan error)
+	go func() {
+		done <- consumeInFlight(session, claim, MessageMarking{After: true, MaxInFlight: 4}, true, func(*sarama.ConsumerMessage) error {
+			return consumerError
+		})
+	}()
+	claim.messageChan <- &sarama.ConsumerMessage{Offset: 0}
+	assert.Equal(t, consumerError, <-done)
+	assert.Empty(t, session.markedOffsets())
+}
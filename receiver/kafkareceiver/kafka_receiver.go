@@ -32,7 +32,7 @@ var errInvalidInitialOffset = fmt.Errorf("invalid initial offset")
 type kafkaTracesConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Traces
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
 
@@ -42,13 +42,14 @@ type kafkaTracesConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	headersTarget     string
 }
 
 // kafkaMetricsConsumer uses sarama to consume and handle messages from kafka.
 type kafkaMetricsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Metrics
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
 
@@ -58,13 +59,14 @@ type kafkaMetricsConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	headersTarget     string
 }
 
 // kafkaLogsConsumer uses sarama to consume and handle messages from kafka.
 type kafkaLogsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Logs
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
 
@@ -74,6 +76,7 @@ type kafkaLogsConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	headersTarget     string
 }
 
 var _ receiver.Traces = (*kafkaTracesConsumer)(nil)
@@ -108,13 +111,13 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if err := kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, subscription, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaTracesConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
 		settings:          set,
@@ -122,6 +125,7 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		headersTarget:     config.HeaderExtraction.Target,
 	}, nil
 }
 
@@ -150,6 +154,7 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 		consumerGroup.headerExtractor = &headerExtractor{
 			logger:  c.settings.Logger,
 			headers: c.headers,
+			target:  c.headersTarget,
 		}
 	}
 	go func() {
@@ -157,7 +162,7 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 			host.ReportFatalError(err)
 		}
 	}()
-	<-consumerGroup.ready
+	c.subscription.waitReady(consumerGroup.ready)
 	return nil
 }
 
@@ -166,7 +171,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.subscription.consume(ctx, c.consumerGroup, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -210,13 +215,13 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if err := kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, subscription, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaMetricsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
 		settings:          set,
@@ -224,6 +229,7 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		headersTarget:     config.HeaderExtraction.Target,
 	}, nil
 }
 
@@ -252,6 +258,7 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 		metricsConsumerGroup.headerExtractor = &headerExtractor{
 			logger:  c.settings.Logger,
 			headers: c.headers,
+			target:  c.headersTarget,
 		}
 	}
 	go func() {
@@ -259,7 +266,7 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 			host.ReportFatalError(err)
 		}
 	}()
-	<-metricsConsumerGroup.ready
+	c.subscription.waitReady(metricsConsumerGroup.ready)
 	return nil
 }
 
@@ -268,7 +275,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.subscription.consume(ctx, c.consumerGroup, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -312,13 +319,13 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 	if err = kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, subscription, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaLogsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
 		settings:          set,
@@ -326,6 +333,7 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		headersTarget:     config.HeaderExtraction.Target,
 	}, nil
 }
 
@@ -382,6 +390,7 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 		logsConsumerGroup.headerExtractor = &headerExtractor{
 			logger:  c.settings.Logger,
 			headers: c.headers,
+			target:  c.headersTarget,
 		}
 	}
 	go func() {
@@ -389,7 +398,7 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 			host.ReportFatalError(err)
 		}
 	}()
-	<-logsConsumerGroup.ready
+	c.subscription.waitReady(logsConsumerGroup.ready)
 	return nil
 }
 
@@ -398,7 +407,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := c.subscription.consume(ctx, c.consumerGroup, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -486,41 +495,21 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	if c.messageMarking.After && c.messageMarking.MaxInFlight > 1 {
+		return consumeInFlight(session, claim, c.messageMarking, c.autocommitEnabled, func(message *sarama.ConsumerMessage) error {
+			return c.handleMessage(session, claim, message)
+		})
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
 			if !ok {
 				return nil
 			}
-			c.logger.Debug("Kafka message claimed",
-				zap.String("value", string(message.Value)),
-				zap.Time("timestamp", message.Timestamp),
-				zap.String("topic", message.Topic))
 			if !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
-
-			ctx := c.obsrecv.StartTracesOp(session.Context())
-			statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
-			_ = stats.RecordWithTags(ctx, statsTags,
-				statMessageCount.M(1),
-				statMessageOffset.M(message.Offset),
-				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
-
-			traces, err := c.unmarshaler.Unmarshal(message.Value)
-			if err != nil {
-				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
-				}
-				return err
-			}
-
-			c.headerExtractor.extractHeadersTraces(traces, message)
-			spanCount := traces.SpanCount()
-			err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
-			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
-			if err != nil {
+			if err := c.handleMessage(session, claim, message); err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
@@ -542,6 +531,33 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 	}
 }
 
+// handleMessage sends the traces of message to the next consumer.
+func (c *tracesConsumerGroupHandler) handleMessage(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) error {
+	c.logger.Debug("Kafka message claimed",
+		zap.String("value", string(message.Value)),
+		zap.Time("timestamp", message.Timestamp),
+		zap.String("topic", message.Topic))
+
+	ctx := c.obsrecv.StartTracesOp(session.Context())
+	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
+	_ = stats.RecordWithTags(ctx, statsTags,
+		statMessageCount.M(1),
+		statMessageOffset.M(message.Offset),
+		statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
+
+	traces, err := c.unmarshaler.Unmarshal(message.Value)
+	if err != nil {
+		c.logger.Error("failed to unmarshal message", zap.Error(err))
+		return err
+	}
+
+	c.headerExtractor.extractHeadersTraces(traces, message)
+	spanCount := traces.SpanCount()
+	err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+	c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
+	return err
+}
+
 func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
 	c.readyCloser.Do(func() {
 		close(c.ready)
@@ -562,41 +578,21 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	if c.messageMarking.After && c.messageMarking.MaxInFlight > 1 {
+		return consumeInFlight(session, claim, c.messageMarking, c.autocommitEnabled, func(message *sarama.ConsumerMessage) error {
+			return c.handleMessage(session, claim, message)
+		})
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
 			if !ok {
 				return nil
 			}
-			c.logger.Debug("Kafka message claimed",
-				zap.String("value", string(message.Value)),
-				zap.Time("timestamp", message.Timestamp),
-				zap.String("topic", message.Topic))
 			if !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
-
-			ctx := c.obsrecv.StartMetricsOp(session.Context())
-			statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
-			_ = stats.RecordWithTags(ctx, statsTags,
-				statMessageCount.M(1),
-				statMessageOffset.M(message.Offset),
-				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
-
-			metrics, err := c.unmarshaler.Unmarshal(message.Value)
-			if err != nil {
-				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
-				}
-				return err
-			}
-			c.headerExtractor.extractHeadersMetrics(metrics, message)
-
-			dataPointCount := metrics.DataPointCount()
-			err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
-			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
-			if err != nil {
+			if err := c.handleMessage(session, claim, message); err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
@@ -618,6 +614,33 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 	}
 }
 
+// handleMessage sends the metrics of message to the next consumer.
+func (c *metricsConsumerGroupHandler) handleMessage(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) error {
+	c.logger.Debug("Kafka message claimed",
+		zap.String("value", string(message.Value)),
+		zap.Time("timestamp", message.Timestamp),
+		zap.String("topic", message.Topic))
+
+	ctx := c.obsrecv.StartMetricsOp(session.Context())
+	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
+	_ = stats.RecordWithTags(ctx, statsTags,
+		statMessageCount.M(1),
+		statMessageOffset.M(message.Offset),
+		statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
+
+	metrics, err := c.unmarshaler.Unmarshal(message.Value)
+	if err != nil {
+		c.logger.Error("failed to unmarshal message", zap.Error(err))
+		return err
+	}
+	c.headerExtractor.extractHeadersMetrics(metrics, message)
+
+	dataPointCount := metrics.DataPointCount()
+	err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+	c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
+	return err
+}
+
 func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
 	c.readyCloser.Do(func() {
 		close(c.ready)
@@ -642,41 +665,21 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	if c.messageMarking.After && c.messageMarking.MaxInFlight > 1 {
+		return consumeInFlight(session, claim, c.messageMarking, c.autocommitEnabled, func(message *sarama.ConsumerMessage) error {
+			return c.handleMessage(session, claim, message)
+		})
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
 			if !ok {
 				return nil
 			}
-			c.logger.Debug("Kafka message claimed",
-				zap.String("value", string(message.Value)),
-				zap.Time("timestamp", message.Timestamp),
-				zap.String("topic", message.Topic))
 			if !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
-
-			ctx := c.obsrecv.StartLogsOp(session.Context())
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
-				statMessageCount.M(1),
-				statMessageOffset.M(message.Offset),
-				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
-
-			logs, err := c.unmarshaler.Unmarshal(message.Value)
-			if err != nil {
-				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
-				}
-				return err
-			}
-			c.headerExtractor.extractHeadersLogs(logs, message)
-			err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
-			// TODO
-			c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
-			if err != nil {
+			if err := c.handleMessage(session, claim, message); err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
@@ -698,6 +701,33 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 	}
 }
 
+// handleMessage sends the logs of message to the next consumer.
+func (c *logsConsumerGroupHandler) handleMessage(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) error {
+	c.logger.Debug("Kafka message claimed",
+		zap.String("value", string(message.Value)),
+		zap.Time("timestamp", message.Timestamp),
+		zap.String("topic", message.Topic))
+
+	ctx := c.obsrecv.StartLogsOp(session.Context())
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
+		statMessageCount.M(1),
+		statMessageOffset.M(message.Offset),
+		statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
+
+	logs, err := c.unmarshaler.Unmarshal(message.Value)
+	if err != nil {
+		c.logger.Error("failed to unmarshal message", zap.Error(err))
+		return err
+	}
+	c.headerExtractor.extractHeadersLogs(logs, message)
+	err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+	// TODO
+	c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
+	return err
+}
+
 func toSaramaInitialOffset(initialOffset string) (int64, error) {
 	switch initialOffset {
 	case offsetEarliest:
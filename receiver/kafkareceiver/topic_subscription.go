@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+// topicPatternPrefix starts the topics which are regular expressions matching
+// the topics to consume from.
+const topicPatternPrefix = "^"
+
+// topicsRefreshInterval is how often the topics matching a pattern are listed.
+var topicsRefreshInterval = time.Minute
+
+// isTopicPattern returns whether topic is a regular expression.
+func isTopicPattern(topic string) bool {
+	return strings.HasPrefix(topic, topicPatternPrefix)
+}
+
+// topicSubscription is the topic a consumer group consumes from, or the topics
+// matching a regular expression.
+type topicSubscription struct {
+	topic   string
+	pattern *regexp.Regexp
+	// listTopics lists the topics of the cluster
+	listTopics func() ([]string, error)
+	logger     *zap.Logger
+}
+
+// newConsumerGroup creates the consumer group of config, subscribed to its
+// topics.
+func newConsumerGroup(config Config, c *sarama.Config, logger *zap.Logger) (sarama.ConsumerGroup, topicSubscription, error) {
+	c.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{toSaramaBalanceStrategy(config.GroupRebalanceStrategy)}
+	if config.SessionTimeout > 0 {
+		c.Consumer.Group.Session.Timeout = config.SessionTimeout
+	}
+	if config.HeartbeatInterval > 0 {
+		c.Consumer.Group.Heartbeat.Interval = config.HeartbeatInterval
+	}
+
+	if !isTopicPattern(config.Topic) {
+		group, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+		return group, topicSubscription{topic: config.Topic}, err
+	}
+
+	pattern, err := regexp.Compile(config.Topic)
+	if err != nil {
+		return nil, topicSubscription{}, err
+	}
+	client, err := sarama.NewClient(config.Brokers, c)
+	if err != nil {
+		return nil, topicSubscription{}, err
+	}
+	group, err := sarama.NewConsumerGroupFromClient(config.GroupID, client)
+	if err != nil {
+		_ = client.Close()
+		return nil, topicSubscription{}, err
+	}
+	return &clientConsumerGroup{ConsumerGroup: group, client: client}, topicSubscription{
+		topic:   config.Topic,
+		pattern: pattern,
+		listTopics: func() ([]string, error) {
+			// The topics created since the last refresh are only known afterwards
+			if err := client.RefreshMetadata(); err != nil {
+				return nil, err
+			}
+			return client.Topics()
+		},
+		logger: logger,
+	}, nil
+}
+
+// consume runs a consumer group session on the subscribed topics, until ctx is
+// done or the topics matching the pattern change.
+func (s topicSubscription) consume(ctx context.Context, group sarama.ConsumerGroup, handler sarama.ConsumerGroupHandler) error {
+	if s.pattern == nil {
+		return group.Consume(ctx, []string{s.topic}, handler)
+	}
+
+	topics, err := s.matchingTopics()
+	if err != nil {
+		return err
+	}
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(topicsRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sessionCtx.Done():
+				return
+			case <-ticker.C:
+				current, err := s.matchingTopics()
+				if err != nil {
+					s.logger.Warn("Failed to list the topics matching the pattern", zap.String("pattern", s.topic), zap.Error(err))
+					continue
+				}
+				if !equalTopics(topics, current) {
+					s.logger.Info("Topics matching the pattern changed", zap.Strings("topics", current))
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	if len(topics) == 0 {
+		// A session requires topics, wait for the first matching topic
+		<-sessionCtx.Done()
+		return nil
+	}
+	return group.Consume(sessionCtx, topics, handler)
+}
+
+// waitReady waits until the first consumer group session is set up, except
+// for a pattern, since its sessions only start once a topic matches it.
+func (s topicSubscription) waitReady(ready <-chan bool) {
+	if s.pattern != nil {
+		return
+	}
+	<-ready
+}
+
+// matchingTopics returns the sorted topics matching the pattern.
+func (s topicSubscription) matchingTopics() ([]string, error) {
+	all, err := s.listTopics()
+	if err != nil {
+		return nil, err
+	}
+	var topics []string
+	for _, topic := range all {
+		if s.pattern.MatchString(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// clientConsumerGroup is a consumer group closing the client it was created from.
+type clientConsumerGroup struct {
+	sarama.ConsumerGroup
+	client sarama.Client
+}
+
+func (g *clientConsumerGroup) Close() error {
+	return errors.Join(g.ConsumerGroup.Close(), g.client.Close())
+}
+
+func toSaramaBalanceStrategy(strategy string) sarama.BalanceStrategy {
+	switch strategy {
+	case rebalanceStrategyRoundRobin:
+		return sarama.NewBalanceStrategyRoundRobin()
+	case rebalanceStrategySticky:
+		return sarama.NewBalanceStrategySticky()
+	default:
+		return sarama.NewBalanceStrategyRange()
+	}
+}
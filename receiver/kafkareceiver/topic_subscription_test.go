@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// sessionConsumerGroup runs sessions lasting until their context is done,
+// recording their topics.
+type sessionConsumerGroup struct {
+	testConsumerGroup
+
+	mu       sync.Mutex
+	sessions [][]string
+}
+
+func (g *sessionConsumerGroup) Consume(ctx context.Context, topics []string, _ sarama.ConsumerGroupHandler) error {
+	g.mu.Lock()
+	g.sessions = append(g.sessions, topics)
+	g.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func (g *sessionConsumerGroup) sessionTopics() [][]string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([][]string(nil), g.sessions...)
+}
+
+func TestTopicSubscription_pattern(t *testing.T) {
+	refreshInterval := topicsRefreshInterval
+	topicsRefreshInterval = 10 * time.Millisecond
+	defer func() { topicsRefreshInterval = refreshInterval }()
+
+	var mu sync.Mutex
+	clusterTopics := []string{"otlp_spans_b", "otlp_metrics", "otlp_spans_a"}
+	subscription := topicSubscription{
+		topic:   "^otlp_spans_.*",
+		pattern: regexp.MustCompile("^otlp_spans_.*"),
+		listTopics: func() ([]string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), clusterTopics...), nil
+		},
+		logger: zap.NewNop(),
+	}
+	group := &sessionConsumerGroup{}
+
+	done := make(chan error)
+	go func() {
+		done <- subscription.consume(context.Background(), group, nil)
+	}()
+	assert.Eventually(t, func() bool { return len(group.sessionTopics()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"otlp_spans_a", "otlp_spans_b"}, group.sessionTopics()[0])
+
+	// A new matching topic ends the session
+	mu.Lock()
+	clusterTopics = append(clusterTopics, "otlp_spans_c")
+	mu.Unlock()
+	require.NoError(t, <-done)
+}
+
+func TestTopicSubscription_noMatchingTopic(t *testing.T) {
+	subscription := topicSubscription{
+		topic:      "^otlp_spans_.*",
+		pattern:    regexp.MustCompile("^otlp_spans_.*"),
+		listTopics: func() ([]string, error) { return []string{"otlp_metrics"}, nil },
+		logger:     zap.NewNop(),
+	}
+	group := &sessionConsumerGroup{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- subscription.consume(ctx, group, nil)
+	}()
+	cancel()
+	require.NoError(t, <-done)
+	assert.Empty(t, group.sessionTopics())
+}
+
+func TestTopicSubscription_waitReady(t *testing.T) {
+	ready := make(chan bool)
+	// A pattern may match no topic yet, its session is not waited for
+	topicSubscription{topic: "^otlp_spans_.*", pattern: regexp.MustCompile("^otlp_spans_.*")}.waitReady(ready)
+
+	done := make(chan struct{})
+	go func() {
+		topicSubscription{topic: "otlp_spans"}.waitReady(ready)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("must wait for the session of a topic")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(ready)
+	<-done
+}
+
+func TestTopicSubscription_topic(t *testing.T) {
+	group := &sessionConsumerGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, topicSubscription{topic: "otlp_spans"}.consume(ctx, group, nil))
+	assert.Equal(t, [][]string{{"otlp_spans"}}, group.sessionTopics())
+}
+
+func TestToSaramaBalanceStrategy(t *testing.T) {
+	assert.Equal(t, sarama.RangeBalanceStrategyName, toSaramaBalanceStrategy("range").Name())
+	assert.Equal(t, sarama.RoundRobinBalanceStrategyName, toSaramaBalanceStrategy("roundrobin").Name())
+	assert.Equal(t, sarama.StickyBalanceStrategyName, toSaramaBalanceStrategy("sticky").Name())
+}
@@ -110,10 +110,12 @@ func (l LabelSet) String() string {
 	return b.String()
 }
 
-// Entry represents a log entry. It includes a log message and the time it occurred at.
+// Entry represents a log entry. It includes a log message and the time it occurred at,
+// and optionally its structured metadata. It must have the layout of push.Entry.
 type Entry struct {
-	Timestamp time.Time
-	Line      string
+	Timestamp          time.Time
+	Line               string
+	StructuredMetadata push.LabelsAdapter
 }
 
 func (e *Entry) UnmarshalJSON(data []byte) error {
@@ -122,6 +124,22 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 		parseError error
 	)
 	_, err := jsonparser.ArrayEach(data, func(value []byte, t jsonparser.ValueType, _ int, _ error) {
+		if i == 2 && t == jsonparser.Object { // structured metadata
+			var metadata LabelSet
+			if err := metadata.UnmarshalJSON(value); err != nil {
+				parseError = err
+				return
+			}
+			e.StructuredMetadata = make(push.LabelsAdapter, 0, len(metadata))
+			for k, v := range metadata {
+				e.StructuredMetadata = append(e.StructuredMetadata, push.LabelAdapter{Name: k, Value: v})
+			}
+			sort.Slice(e.StructuredMetadata, func(i, j int) bool {
+				return e.StructuredMetadata[i].Name < e.StructuredMetadata[j].Name
+			})
+			i++
+			return
+		}
 		// assert that both items in array are of type string
 		if t != jsonparser.String {
 			parseError = jsonparser.MalformedStringError
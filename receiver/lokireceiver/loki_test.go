@@ -292,6 +292,24 @@ func TestSendingPushRequestToHTTPEndpoint(t *testing.T) {
 			}),
 			err: nil,
 		},
+		{
+			name:            "Sending structured metadata with contentType=application/json to http endpoint",
+			contentEncoding: "",
+			contentType:     jsonContentType,
+			body:            []byte(`{"streams": [{"stream": {"foo": "bar"},"values": [[ "1676888496000000000", "logline 1", {"trace_id": "0102", "pod": "checkout-1"} ]]}]}`),
+			expected: generateLogs([]Log{
+				{
+					Timestamp: 1676888496000000000,
+					Attributes: map[string]interface{}{
+						"foo":      "bar",
+						"pod":      "checkout-1",
+						"trace_id": "0102",
+					},
+					Body: pcommon.NewValueStr("logline 1"),
+				},
+			}),
+			err: nil,
+		},
 	}
 
 	// Start http server
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	encodingJSON      = "json"
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+)
+
+// Config defines the configuration of the MQTT receiver.
+type Config struct {
+	// Endpoint is the URL of the broker, e.g. tcp://localhost:1883. The connection
+	// uses TLS with the ssl, tls and mqtts schemes, and WebSocket with the ws and wss
+	// schemes.
+	Endpoint string `mapstructure:"endpoint"`
+	// TLSSetting configures the TLS connection to the broker.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls"`
+	Username   string                      `mapstructure:"username"`
+	Password   configopaque.String         `mapstructure:"password"`
+	// ClientID identifies the session of the receiver on the broker, and must be
+	// unique among the clients of the broker (default otelcol_<receiver ID>_<signal>_<host name>).
+	ClientID string `mapstructure:"client_id"`
+	// ConnectionTimeout is the timeout of the connection to the broker (default 10s).
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+
+	// Topics are the topic filters the receiver subscribes to, which may contain
+	// the + and # wildcards.
+	Topics []string `mapstructure:"topics"`
+	// QoS is the quality of service of the subscriptions, 0 or 1 (default 1).
+	QoS int `mapstructure:"qos"`
+	// CleanSession discards the session of the receiver on the broker when it
+	// disconnects. Otherwise the broker keeps the subscriptions and queues the
+	// messages published with QoS 1 until the receiver reconnects (default false).
+	CleanSession bool `mapstructure:"clean_session"`
+	// SessionDirectory is the directory persisting the messages in flight, so that
+	// they are acknowledged once consumed after a restart (in memory when empty).
+	SessionDirectory string `mapstructure:"session_directory"`
+
+	// TopicAttributes is a template of the topics of the messages, whose {name}
+	// segments set the name resource attribute to the segment of the topic, e.g.
+	// devices/{site}/{device.id}/#. The other segments are matched as topic filters.
+	TopicAttributes string `mapstructure:"topic_attributes"`
+	// Encoding of the messages, json, otlp_proto or otlp_json (default json).
+	Encoding string `mapstructure:"encoding"`
+	// ConsumeRetryTimeout is how long the consumption of a message failing with a
+	// retryable error is retried before the message is dropped (default 5m).
+	ConsumeRetryTimeout time.Duration `mapstructure:"consume_retry_timeout"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if len(cfg.Topics) == 0 {
+		return errors.New("at least one topic must be specified")
+	}
+	for _, topic := range cfg.Topics {
+		if err := validateTopicFilter(topic); err != nil {
+			return err
+		}
+	}
+	if cfg.QoS != 0 && cfg.QoS != 1 {
+		return fmt.Errorf("unsupported qos %d", cfg.QoS)
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		return errors.New("connection_timeout must be positive")
+	}
+	if cfg.ConsumeRetryTimeout <= 0 {
+		return errors.New("consume_retry_timeout must be positive")
+	}
+	if _, err := newTopicTemplate(cfg.TopicAttributes); err != nil {
+		return err
+	}
+	switch cfg.Encoding {
+	case encodingJSON, encodingOTLPProto, encodingOTLPJSON:
+	default:
+		return fmt.Errorf("unsupported encoding %q", cfg.Encoding)
+	}
+	return nil
+}
+
+// validateTopicFilter checks the wildcards of filter occupy whole levels, and # is
+// the last level.
+func validateTopicFilter(filter string) error {
+	if filter == "" {
+		return errors.New("topics must not be empty")
+	}
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		if strings.ContainsAny(level, "+#") && len(level) > 1 {
+			return fmt.Errorf("topic %q: wildcards must occupy a whole level", filter)
+		}
+		if level == "#" && i != len(levels)-1 {
+			return fmt.Errorf("topic %q: # must be the last level", filter)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Endpoint:          defaultEndpoint,
+				ConnectionTimeout: defaultConnectionTimeout,
+				Topics:            []string{"devices/+/telemetry"},
+				QoS:               defaultQoS,
+				Encoding:          encodingJSON,
+
+				ConsumeRetryTimeout: defaultConsumeRetryTimeout,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				Endpoint: "ssl://broker:8883",
+				TLSSetting: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{CAFile: "ca.pem"},
+				},
+				Username:          "otel",
+				Password:          "secret",
+				ClientID:          "otelcol_gateway",
+				ConnectionTimeout: 30 * time.Second,
+				Topics:            []string{"$share/otelcol/devices/+/+/telemetry", "sensors/#"},
+				QoS:               0,
+				CleanSession:      true,
+				SessionDirectory:  "/var/lib/otelcol/mqtt",
+				TopicAttributes:   "devices/{site}/{device.id}/#",
+				Encoding:          encodingOTLPJSON,
+
+				ConsumeRetryTimeout: time.Minute,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_topics"),
+			errorMessage: "at least one topic must be specified",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_topic"),
+			errorMessage: `topic "devices/sensor#": wildcards must occupy a whole level`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_qos"),
+			errorMessage: "unsupported qos 2",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_topic_attributes"),
+			errorMessage: `topic_attributes "devices/site-{site}/#": attributes must occupy a whole level`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_encoding"),
+			errorMessage: `unsupported encoding "text"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
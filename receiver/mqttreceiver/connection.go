@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+)
+
+// disconnectQuiesce is the time in milliseconds waited for the messages being
+// handled when disconnecting.
+const disconnectQuiesce = 250
+
+// connection is the subset of the MQTT client used by the receiver.
+type connection interface {
+	// Disconnect closes the connection, keeping the session on the broker unless
+	// it is a clean session.
+	Disconnect()
+}
+
+type mqttConnection struct {
+	client mqtt.Client
+}
+
+// connect connects to the broker, subscribing handler to the topics of cfg each
+// time the connection is established.
+func connect(cfg *Config, clientID string, handler mqtt.MessageHandler, logger *zap.Logger) (connection, error) {
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.Endpoint).
+		SetClientID(clientID).
+		SetUsername(cfg.Username).
+		SetPassword(string(cfg.Password)).
+		SetCleanSession(cfg.CleanSession).
+		SetConnectTimeout(cfg.ConnectionTimeout).
+		SetAutoReconnect(true).
+		// The messages are acknowledged once consumed
+		SetAutoAckDisabled(true).
+		SetResumeSubs(true)
+	if cfg.TLSSetting != nil {
+		tlsConfig, err := cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+	if cfg.SessionDirectory != "" {
+		opts.SetStore(mqtt.NewFileStore(cfg.SessionDirectory))
+	}
+
+	filters := make(map[string]byte, len(cfg.Topics))
+	for _, topic := range cfg.Topics {
+		filters[topic] = byte(cfg.QoS)
+	}
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		token := client.SubscribeMultiple(filters, handler)
+		if token.Wait(); token.Error() != nil {
+			logger.Error("Failed to subscribe to the topics", zap.Strings("topics", cfg.Topics), zap.Error(token.Error()))
+		}
+	})
+	opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		logger.Warn("Lost the connection to the broker, reconnecting", zap.Error(err))
+	})
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(cfg.ConnectionTimeout) {
+		client.Disconnect(0)
+		return nil, errors.New("timed out connecting to the broker")
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+	return &mqttConnection{client: client}, nil
+}
+
+func (c *mqttConnection) Disconnect() {
+	c.client.Disconnect(disconnectQuiesce)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const topicAttribute = "mqtt.topic"
+
+// unmarshalJSONLogs returns a log record whose body holds the JSON payload.
+func unmarshalJSONLogs(topic string, payload []byte, now time.Time) (plog.Logs, error) {
+	var body any
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return plog.Logs{}, err
+	}
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	if err := record.Body().FromRaw(body); err != nil {
+		return plog.Logs{}, err
+	}
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	record.Attributes().PutStr(topicAttribute, topic)
+	return logs, nil
+}
+
+// unmarshalJSONMetrics returns a gauge for each number of the JSON object payload,
+// named after the keys leading to the number joined by dots.
+func unmarshalJSONMetrics(payload []byte, now time.Time) (pmetric.Metrics, error) {
+	var object map[string]any
+	if err := json.Unmarshal(payload, &object); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	appendJSONGauges(ms, "", object, pcommon.NewTimestampFromTime(now))
+	if ms.Len() == 0 {
+		return pmetric.Metrics{}, errors.New("the payload holds no number")
+	}
+	return metrics, nil
+}
+
+func appendJSONGauges(ms pmetric.MetricSlice, prefix string, object map[string]any, ts pcommon.Timestamp) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch value := object[key].(type) {
+		case float64:
+			m := ms.AppendEmpty()
+			m.SetName(name)
+			dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			dp.SetDoubleValue(value)
+		case map[string]any:
+			appendJSONGauges(ms, name, value, ts)
+		}
+	}
+}
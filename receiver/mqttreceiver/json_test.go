@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestUnmarshalJSONLogs(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	logs, err := unmarshalJSONLogs("devices/paris/sensor-1/events", []byte(`{"event":"door_open","count":2}`), now)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"event": "door_open", "count": float64(2)}, record.Body().AsRaw())
+	assert.Equal(t, map[string]any{"mqtt.topic": "devices/paris/sensor-1/events"}, record.Attributes().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), record.ObservedTimestamp())
+
+	_, err = unmarshalJSONLogs("devices", []byte("door_open"), now)
+	assert.Error(t, err)
+}
+
+func TestUnmarshalJSONMetrics(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	metrics, err := unmarshalJSONMetrics([]byte(`{"temperature":21.5,"battery":{"level":87,"charging":false},"firmware":"1.2.0","tags":[1,2]}`), now)
+	require.NoError(t, err)
+
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "battery.level", ms.At(0).Name())
+	assert.Equal(t, 87.0, ms.At(0).Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, "temperature", ms.At(1).Name())
+	assert.Equal(t, 21.5, ms.At(1).Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), ms.At(1).Gauge().DataPoints().At(0).Timestamp())
+
+	_, err = unmarshalJSONMetrics([]byte(`{"firmware":"1.2.0"}`), now)
+	assert.EqualError(t, err, "the payload holds no number")
+
+	_, err = unmarshalJSONMetrics([]byte(`[21.5]`), now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "mqtt"
+
+	// consumeRetryInterval is how long to wait before consuming again a message
+	// the pipeline failed to consume.
+	consumeRetryInterval = time.Second
+)
+
+// hostname returns the host name of the collector, replaced in tests.
+var hostname = os.Hostname
+
+// mqttReceiver receives the messages of the topics, passing them to consume.
+type mqttReceiver struct {
+	config   *Config
+	settings receiver.CreateSettings
+	obsrecv  *receiverhelper.ObsReport
+	clientID string
+	template *topicTemplate
+	consume  func(ctx context.Context, topic string, payload []byte) error
+	connect  func(cfg *Config, clientID string, handler mqtt.MessageHandler, logger *zap.Logger) (connection, error)
+
+	cancel context.CancelFunc
+	conn   connection
+}
+
+func newMQTTReceiver(config *Config, set receiver.CreateSettings, signal string) (*mqttReceiver, error) {
+	template, err := newTopicTemplate(config.TopicAttributes)
+	if err != nil {
+		return nil, err
+	}
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		LongLivedCtx:           false,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &mqttReceiver{
+		config:   config,
+		settings: set,
+		obsrecv:  obsrecv,
+		clientID: config.ClientID,
+		template: template,
+		connect:  connect,
+	}
+	if r.clientID == "" {
+		r.clientID = defaultClientID(set.ID, signal)
+	}
+	return r, nil
+}
+
+// defaultClientID returns a client ID unique to the receiver of signal on the
+// host, so that the replicas of the collector do not take over the sessions of
+// each other.
+func defaultClientID(id component.ID, signal string) string {
+	host, err := hostname()
+	if err != nil || host == "" {
+		suffix := make([]byte, 8)
+		_, _ = rand.Read(suffix)
+		host = hex.EncodeToString(suffix)
+	}
+	return fmt.Sprintf("otelcol_%s_%s_%s", id, signal, host)
+}
+
+func newMetricsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Metrics) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(config, set, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	unmarshal := func(_ string, payload []byte) (pmetric.Metrics, error) {
+		return unmarshalJSONMetrics(payload, time.Now())
+	}
+	switch config.Encoding {
+	case encodingOTLPProto:
+		unmarshal = func(_ string, payload []byte) (pmetric.Metrics, error) {
+			return (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(payload)
+		}
+	case encodingOTLPJSON:
+		unmarshal = func(_ string, payload []byte) (pmetric.Metrics, error) {
+			return (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(payload)
+		}
+	}
+	r.consume = func(ctx context.Context, topic string, payload []byte) error {
+		metrics, err := unmarshal(topic, payload)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if r.template != nil {
+			for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+				r.template.apply(topic, metrics.ResourceMetrics().At(i).Resource().Attributes())
+			}
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = nextConsumer.ConsumeMetrics(ctx, metrics)
+		r.obsrecv.EndMetricsOp(ctx, config.Encoding, metrics.DataPointCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newLogsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Logs) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(config, set, "logs")
+	if err != nil {
+		return nil, err
+	}
+	unmarshal := func(topic string, payload []byte) (plog.Logs, error) {
+		return unmarshalJSONLogs(topic, payload, time.Now())
+	}
+	switch config.Encoding {
+	case encodingOTLPProto:
+		unmarshal = func(_ string, payload []byte) (plog.Logs, error) {
+			return (&plog.ProtoUnmarshaler{}).UnmarshalLogs(payload)
+		}
+	case encodingOTLPJSON:
+		unmarshal = func(_ string, payload []byte) (plog.Logs, error) {
+			return (&plog.JSONUnmarshaler{}).UnmarshalLogs(payload)
+		}
+	}
+	r.consume = func(ctx context.Context, topic string, payload []byte) error {
+		logs, err := unmarshal(topic, payload)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if r.template != nil {
+			for i := 0; i < logs.ResourceLogs().Len(); i++ {
+				r.template.apply(topic, logs.ResourceLogs().At(i).Resource().Attributes())
+			}
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = nextConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, config.Encoding, logs.LogRecordCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+// Start connects to the broker and subscribes to the topics.
+func (r *mqttReceiver) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := r.connect(r.config, r.clientID, func(_ mqtt.Client, msg mqtt.Message) {
+		r.handleMessage(ctx, msg)
+	}, r.settings.Logger)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to connect to %s: %w", r.config.Endpoint, err)
+	}
+	r.cancel = cancel
+	r.conn = conn
+	return nil
+}
+
+// Shutdown disconnects from the broker. Unless the session is clean, the broker
+// keeps queuing the messages until the receiver reconnects.
+func (r *mqttReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	r.cancel()
+	r.conn.Disconnect()
+	return nil
+}
+
+// handleMessage acknowledges the messages once consumed by the pipeline, so
+// that the messages received with QoS 1 are delivered again when the receiver
+// stops before consuming them. The consumption of a message is retried until it
+// succeeds, the receiver stops or the consume retry timeout elapses, which holds
+// back the next messages. The messages failing permanently, e.g. which cannot be
+// unmarshaled, or for longer than the timeout are dropped.
+func (r *mqttReceiver) handleMessage(ctx context.Context, msg mqtt.Message) {
+	deadline := time.Now().Add(r.config.ConsumeRetryTimeout)
+	for {
+		err := r.consume(ctx, msg.Topic(), msg.Payload())
+		if err == nil {
+			msg.Ack()
+			return
+		}
+		if consumererror.IsPermanent(err) {
+			r.settings.Logger.Error("Dropping message", zap.String("topic", msg.Topic()), zap.Error(err))
+			msg.Ack()
+			return
+		}
+		if time.Now().Add(consumeRetryInterval).After(deadline) {
+			r.settings.Logger.Error("Dropping message after retrying its consumption", zap.String("topic", msg.Topic()),
+				zap.Duration("consume_retry_timeout", r.config.ConsumeRetryTimeout), zap.Error(err))
+			msg.Ack()
+			return
+		}
+		r.settings.Logger.Warn("Failed to consume message, retrying", zap.String("topic", msg.Topic()), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consumeRetryInterval):
+		}
+	}
+}
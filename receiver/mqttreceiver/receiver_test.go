@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+// fakeConnection records the handler of the receiver.
+type fakeConnection struct {
+	clientID     string
+	handler      mqtt.MessageHandler
+	disconnected bool
+}
+
+func (c *fakeConnection) Disconnect() {
+	c.disconnected = true
+}
+
+// fakeMessage records whether it was acknowledged.
+type fakeMessage struct {
+	topic   string
+	payload []byte
+	acked   bool
+}
+
+func (m *fakeMessage) Duplicate() bool   { return false }
+func (m *fakeMessage) Qos() byte         { return 1 }
+func (m *fakeMessage) Retained() bool    { return false }
+func (m *fakeMessage) Topic() string     { return m.topic }
+func (m *fakeMessage) MessageID() uint16 { return 1 }
+func (m *fakeMessage) Payload() []byte   { return m.payload }
+func (m *fakeMessage) Ack()              { m.acked = true }
+
+func startReceiver(t *testing.T, r *mqttReceiver) *fakeConnection {
+	conn := &fakeConnection{}
+	r.connect = func(_ *Config, clientID string, handler mqtt.MessageHandler, _ *zap.Logger) (connection, error) {
+		conn.clientID, conn.handler = clientID, handler
+		return conn, nil
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+		assert.True(t, conn.disconnected)
+	})
+	return conn
+}
+
+func TestMetricsReceiverJSON(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Topics = []string{"devices/+/+/telemetry"}
+	cfg.TopicAttributes = "devices/{site}/{device.id}/#"
+	sink := new(consumertest.MetricsSink)
+	set := receivertest.NewNopCreateSettings()
+	set.ID = component.NewID("mqtt")
+	r, err := newMetricsReceiver(cfg, set, sink)
+	require.NoError(t, err)
+	conn := startReceiver(t, r)
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	assert.Equal(t, "otelcol_mqtt_metrics_"+host, conn.clientID)
+
+	valid := &fakeMessage{topic: "devices/paris/sensor-1/telemetry", payload: []byte(`{"temperature":21.5}`)}
+	conn.handler(nil, valid)
+	invalid := &fakeMessage{topic: "devices/paris/sensor-1/telemetry", payload: []byte("21.5")}
+	conn.handler(nil, invalid)
+	assert.True(t, valid.acked)
+	assert.True(t, invalid.acked)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"site": "paris", "device.id": "sensor-1"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, "temperature", rm.ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestLogsReceiverOTLP(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "otelcol_gateway"
+	cfg.Topics = []string{"otlp/logs/#"}
+	cfg.TopicAttributes = "otlp/logs/{service.namespace}"
+	cfg.Encoding = encodingOTLPProto
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	conn := startReceiver(t, r)
+	assert.Equal(t, "otelcol_gateway", conn.clientID)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	conn.handler(nil, &fakeMessage{topic: "otlp/logs/shop", payload: payload})
+
+	require.Len(t, sink.AllLogs(), 1)
+	rl.Resource().Attributes().PutStr("service.namespace", "shop")
+	assert.Equal(t, ld, sink.AllLogs()[0])
+}
+
+func TestHandleMessageConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewErr(errors.New("failed")))
+	require.NoError(t, err)
+
+	// The message is not acknowledged when the receiver stops before consuming it
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	msg := &fakeMessage{topic: "devices", payload: []byte(`{"temperature":21.5}`)}
+	r.handleMessage(ctx, msg)
+	assert.False(t, msg.acked)
+}
+
+func TestHandleMessageRetry(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink := new(consumertest.MetricsSink)
+	failures := 1
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), consumerFunc(func(ctx context.Context, md pmetric.Metrics) error {
+		if failures > 0 {
+			failures--
+			return errors.New("memory limit exceeded")
+		}
+		return sink.ConsumeMetrics(ctx, md)
+	}))
+	require.NoError(t, err)
+
+	msg := &fakeMessage{topic: "devices", payload: []byte(`{"temperature":21.5}`)}
+	r.handleMessage(context.Background(), msg)
+	assert.True(t, msg.acked)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestHandleMessageRetryTimeout(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ConsumeRetryTimeout = consumeRetryInterval / 2
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewErr(errors.New("failed")))
+	require.NoError(t, err)
+
+	// The message is dropped once retrying its consumption would exceed the timeout
+	msg := &fakeMessage{topic: "devices", payload: []byte(`{"temperature":21.5}`)}
+	r.handleMessage(context.Background(), msg)
+	assert.True(t, msg.acked)
+}
+
+func TestDefaultClientID(t *testing.T) {
+	defer func() { hostname = os.Hostname }()
+
+	hostname = func() (string, error) { return "node-1", nil }
+	assert.Equal(t, "otelcol_mqtt/devices_logs_node-1", defaultClientID(component.NewIDWithName("mqtt", "devices"), "logs"))
+
+	hostname = func() (string, error) { return "", errors.New("no hostname") }
+	first := defaultClientID(component.NewID("mqtt"), "logs")
+	assert.Regexp(t, "^otelcol_mqtt_logs_[0-9a-f]{16}$", first)
+	assert.NotEqual(t, first, defaultClientID(component.NewID("mqtt"), "logs"))
+}
+
+type consumerFunc func(ctx context.Context, md pmetric.Metrics) error
+
+func (f consumerFunc) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (f consumerFunc) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return f(ctx, md)
+}
+
+func TestStartError(t *testing.T) {
+	r, err := newLogsReceiver(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	r.connect = func(*Config, string, mqtt.MessageHandler, *zap.Logger) (connection, error) {
+		return nil, errors.New("connection refused")
+	}
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to connect to tcp://localhost:1883: connection refused")
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestConsumeOTLPJSONMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Encoding = encodingOTLPJSON
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	payload, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	require.NoError(t, r.consume(context.Background(), "otlp/metrics", payload))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, md, sink.AllMetrics()[0])
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// topicTemplate maps the segments of the topics to resource attributes.
+type topicTemplate struct {
+	// levels are the levels of the template. The attribute levels are held by
+	// attributes, and are empty in levels.
+	levels     []string
+	attributes map[int]string
+}
+
+// newTopicTemplate parses template, returning nil when empty.
+func newTopicTemplate(template string) (*topicTemplate, error) {
+	if template == "" {
+		return nil, nil
+	}
+	if err := validateTopicFilter(template); err != nil {
+		return nil, fmt.Errorf("topic_attributes: %w", err)
+	}
+	t := &topicTemplate{
+		levels:     strings.Split(template, "/"),
+		attributes: map[int]string{},
+	}
+	for i, level := range t.levels {
+		if !strings.HasPrefix(level, "{") || !strings.HasSuffix(level, "}") {
+			if strings.ContainsAny(level, "{}") {
+				return nil, fmt.Errorf("topic_attributes %q: attributes must occupy a whole level", template)
+			}
+			continue
+		}
+		name := level[1 : len(level)-1]
+		if name == "" || strings.ContainsAny(name, "{}") {
+			return nil, fmt.Errorf("topic_attributes %q: invalid attribute level %q", template, level)
+		}
+		t.attributes[i] = name
+		t.levels[i] = ""
+	}
+	return t, nil
+}
+
+// apply puts the attributes of topic into attrs, returning false when topic does
+// not match the template.
+func (t *topicTemplate) apply(topic string, attrs pcommon.Map) bool {
+	levels := strings.Split(topic, "/")
+	values := make(map[string]string, len(t.attributes))
+	for i, level := range t.levels {
+		if level == "#" {
+			break
+		}
+		if i >= len(levels) {
+			return false
+		}
+		if name, ok := t.attributes[i]; ok {
+			values[name] = levels[i]
+		} else if level != "+" && level != levels[i] {
+			return false
+		}
+		if i == len(t.levels)-1 && len(levels) != len(t.levels) {
+			return false
+		}
+	}
+	for name, value := range values {
+		attrs.PutStr(name, value)
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTopicTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		topic    string
+		expected map[string]any
+	}{
+		{
+			name:     "attributes",
+			template: "devices/{site}/{device.id}/telemetry",
+			topic:    "devices/paris/sensor-1/telemetry",
+			expected: map[string]any{"site": "paris", "device.id": "sensor-1"},
+		},
+		{
+			name:     "wildcards",
+			template: "+/{site}/#",
+			topic:    "devices/paris/sensor-1/telemetry",
+			expected: map[string]any{"site": "paris"},
+		},
+		{
+			name:     "multi-level wildcard matching the parent level",
+			template: "devices/{site}/#",
+			topic:    "devices/paris",
+			expected: map[string]any{"site": "paris"},
+		},
+		{
+			name:     "different level",
+			template: "devices/{site}/telemetry",
+			topic:    "devices/paris/status",
+			expected: map[string]any{},
+		},
+		{
+			name:     "shorter topic",
+			template: "devices/{site}/{device.id}",
+			topic:    "devices/paris",
+			expected: map[string]any{},
+		},
+		{
+			name:     "longer topic",
+			template: "devices/{site}",
+			topic:    "devices/paris/sensor-1",
+			expected: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := newTopicTemplate(tt.template)
+			require.NoError(t, err)
+			attrs := pcommon.NewMap()
+			assert.Equal(t, len(tt.expected) > 0, template.apply(tt.topic, attrs))
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestNewTopicTemplate(t *testing.T) {
+	template, err := newTopicTemplate("")
+	assert.NoError(t, err)
+	assert.Nil(t, template)
+
+	_, err = newTopicTemplate("devices/{}/#")
+	assert.EqualError(t, err, `topic_attributes "devices/{}/#": invalid attribute level "{}"`)
+
+	_, err = newTopicTemplate("devices/#/{site}")
+	assert.EqualError(t, err, `topic_attributes: topic "devices/#/{site}": # must be the last level`)
+}
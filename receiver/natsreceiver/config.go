@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+
+	deliverPolicyAll  = "all"
+	deliverPolicyNew  = "new"
+	deliverPolicyLast = "last"
+)
+
+// Config defines the configuration of the NATS receiver.
+type Config struct {
+	// URL of the NATS server, or comma separated URLs of the servers of a cluster
+	// (default nats://localhost:4222).
+	URL string `mapstructure:"url"`
+	// TLSSetting configures the TLS connection to the servers.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls"`
+	// Auth defines the credentials of the connection to the servers.
+	Auth natsconn.AuthConfig `mapstructure:"auth"`
+
+	// Subject the messages are received from, which may contain wildcards
+	// (default otlp.traces for traces, otlp.metrics for metrics and otlp.logs for
+	// logs).
+	Subject string `mapstructure:"subject"`
+	// QueueGroup load balances the messages between the receivers of the group.
+	QueueGroup string `mapstructure:"queue_group"`
+	// Encoding of the messages, otlp_proto or otlp_json (default otlp_proto).
+	Encoding string `mapstructure:"encoding"`
+	// JetStream receives the messages from a JetStream durable consumer.
+	JetStream JetStreamConfig `mapstructure:"jetstream"`
+}
+
+// JetStreamConfig defines the durable consumer the messages are received from.
+type JetStreamConfig struct {
+	// Enabled receives the messages from a JetStream durable consumer, which
+	// acknowledges them once consumed (default false).
+	Enabled bool `mapstructure:"enabled"`
+	// Stream is the name of the stream storing the messages, looked up from the
+	// subject when empty.
+	Stream string `mapstructure:"stream"`
+	// Durable is the name of the durable consumer, created when missing (default
+	// otelcol_traces for traces, otelcol_metrics for metrics and otelcol_logs for
+	// logs).
+	Durable string `mapstructure:"durable"`
+	// DeliverPolicy is where a new consumer starts in the stream, among all, new
+	// and last (default all).
+	DeliverPolicy string `mapstructure:"deliver_policy"`
+	// AckWait is the time after which the messages not acknowledged are delivered
+	// again (default 30s).
+	AckWait time.Duration `mapstructure:"ack_wait"`
+	// MaxAckPending is the maximum number of messages delivered and not yet
+	// acknowledged (default 1000).
+	MaxAckPending int `mapstructure:"max_ack_pending"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.URL == "" {
+		return errors.New("url must be specified")
+	}
+	if strings.ContainsAny(cfg.Subject, " \t\r\n") {
+		return fmt.Errorf("subject %q must not contain whitespaces", cfg.Subject)
+	}
+	switch cfg.Encoding {
+	case encodingOTLPProto, encodingOTLPJSON:
+	default:
+		return fmt.Errorf("unsupported encoding %q", cfg.Encoding)
+	}
+	if !cfg.JetStream.Enabled {
+		return nil
+	}
+	if strings.ContainsAny(cfg.JetStream.Durable, ".*> \t\r\n") {
+		return fmt.Errorf("jetstream durable %q must not contain dots, wildcards or whitespaces", cfg.JetStream.Durable)
+	}
+	switch cfg.JetStream.DeliverPolicy {
+	case deliverPolicyAll, deliverPolicyNew, deliverPolicyLast:
+	default:
+		return fmt.Errorf("unsupported jetstream deliver_policy %q", cfg.JetStream.DeliverPolicy)
+	}
+	if cfg.JetStream.AckWait <= 0 || cfg.JetStream.MaxAckPending <= 0 {
+		return errors.New("jetstream ack_wait and max_ack_pending must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				URL: "tls://nats-1:4222,tls://nats-2:4222",
+				TLSSetting: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{CAFile: "ca.pem"},
+				},
+				Auth:       natsconn.AuthConfig{CredentialsFile: "user.creds"},
+				Subject:    "otlp.traces.>",
+				QueueGroup: "otelcol",
+				Encoding:   encodingOTLPJSON,
+				JetStream: JetStreamConfig{
+					Enabled:       true,
+					Stream:        "OTLP",
+					Durable:       "otelcol_gateway",
+					DeliverPolicy: deliverPolicyNew,
+					AckWait:       time.Minute,
+					MaxAckPending: 100,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_encoding"),
+			errorMessage: `unsupported encoding "jaeger_proto"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_durable"),
+			errorMessage: `jetstream durable "otelcol.traces" must not contain dots, wildcards or whitespaces`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_deliver_policy"),
+			errorMessage: `unsupported jetstream deliver_policy "by_start_time"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "several_auth"),
+			errorMessage: "only one of auth token, username, credentials_file and nkey_seed_file may be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
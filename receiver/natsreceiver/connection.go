@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsconn"
+)
+
+// connection is the subset of the NATS connection used by the receiver.
+type connection interface {
+	// Subscribe subscribes handler to subject, in the queue group when not empty.
+	Subscribe(subject, queue string, handler nats.MsgHandler) error
+	// SubscribeJetStream subscribes handler to the consumer of subject described by opts.
+	SubscribeJetStream(subject, queue string, handler nats.MsgHandler, opts ...nats.SubOpt) error
+	// Drain stops the subscriptions once their pending messages were handled, and
+	// closes the connection.
+	Drain(ctx context.Context) error
+	// Close closes the connection, without unsubscribing from the consumers so
+	// that the durable consumers created by the receiver are kept.
+	Close()
+}
+
+type natsConnection struct {
+	conn   *nats.Conn
+	closed chan struct{}
+}
+
+func connect(cfg *Config, name string) (connection, error) {
+	opts, err := natsconn.ConnectOptions(cfg.TLSSetting, cfg.Auth, name)
+	if err != nil {
+		return nil, err
+	}
+	c := &natsConnection{closed: make(chan struct{})}
+	opts = append(opts, nats.ClosedHandler(func(*nats.Conn) { close(c.closed) }))
+	if c.conn, err = nats.Connect(cfg.URL, opts...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *natsConnection) Subscribe(subject, queue string, handler nats.MsgHandler) error {
+	_, err := c.conn.QueueSubscribe(subject, queue, handler)
+	return err
+}
+
+func (c *natsConnection) SubscribeJetStream(subject, queue string, handler nats.MsgHandler, opts ...nats.SubOpt) error {
+	js, err := c.conn.JetStream()
+	if err != nil {
+		return err
+	}
+	if queue == "" {
+		_, err = js.Subscribe(subject, handler, opts...)
+	} else {
+		_, err = js.QueueSubscribe(subject, queue, handler, opts...)
+	}
+	return err
+}
+
+func (c *natsConnection) Drain(ctx context.Context) error {
+	if err := c.conn.Drain(); err != nil {
+		return err
+	}
+	select {
+	case <-c.closed:
+		return nil
+	case <-ctx.Done():
+		c.conn.Close()
+		return ctx.Err()
+	}
+}
+
+func (c *natsConnection) Close() {
+	c.conn.Close()
+}
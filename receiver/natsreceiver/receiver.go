@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const transport = "nats"
+
+// acknowledger acknowledges the messages of JetStream consumers.
+type acknowledger interface {
+	Ack(opts ...nats.AckOpt) error
+	Nak(opts ...nats.AckOpt) error
+	Term(opts ...nats.AckOpt) error
+}
+
+// natsReceiver receives the messages of a subject, passing their data to consume.
+type natsReceiver struct {
+	config   *Config
+	settings receiver.CreateSettings
+	obsrecv  *receiverhelper.ObsReport
+	subject  string
+	durable  string
+	consume  func(ctx context.Context, data []byte) error
+	connect  func(cfg *Config, name string) (connection, error)
+
+	cancel context.CancelFunc
+	conn   connection
+}
+
+func newNATSReceiver(config *Config, set receiver.CreateSettings, signal string) (*natsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		LongLivedCtx:           false,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &natsReceiver{
+		config:   config,
+		settings: set,
+		obsrecv:  obsrecv,
+		subject:  config.Subject,
+		durable:  config.JetStream.Durable,
+		connect:  connect,
+	}
+	if r.subject == "" {
+		r.subject = "otlp." + signal
+	}
+	if r.durable == "" {
+		r.durable = "otelcol_" + signal
+	}
+	return r, nil
+}
+
+func newTracesReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Traces) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, set, "traces")
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler ptrace.Unmarshaler = &ptrace.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &ptrace.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, data []byte) error {
+		traces, err := unmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = nextConsumer.ConsumeTraces(ctx, traces)
+		r.obsrecv.EndTracesOp(ctx, config.Encoding, traces.SpanCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Metrics) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, set, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler pmetric.Unmarshaler = &pmetric.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &pmetric.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, data []byte) error {
+		metrics, err := unmarshaler.UnmarshalMetrics(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = nextConsumer.ConsumeMetrics(ctx, metrics)
+		r.obsrecv.EndMetricsOp(ctx, config.Encoding, metrics.DataPointCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newLogsReceiver(config *Config, set receiver.CreateSettings, nextConsumer consumer.Logs) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, set, "logs")
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler plog.Unmarshaler = &plog.ProtoUnmarshaler{}
+	if config.Encoding == encodingOTLPJSON {
+		unmarshaler = &plog.JSONUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, data []byte) error {
+		logs, err := unmarshaler.UnmarshalLogs(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = nextConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, config.Encoding, logs.LogRecordCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+// Start connects to the servers and subscribes to the subject.
+func (r *natsReceiver) Start(context.Context, component.Host) error {
+	conn, err := r.connect(r.config, "otelcol/"+r.settings.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", r.config.URL, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if r.config.JetStream.Enabled {
+		err = conn.SubscribeJetStream(r.subject, r.config.QueueGroup, func(msg *nats.Msg) {
+			r.handleJetStreamMessage(ctx, msg.Data, msg)
+		}, r.jetStreamOptions()...)
+	} else {
+		err = conn.Subscribe(r.subject, r.config.QueueGroup, func(msg *nats.Msg) {
+			if err := r.consume(ctx, msg.Data); err != nil {
+				r.settings.Logger.Error("Failed to consume message", zap.String("subject", msg.Subject), zap.Error(err))
+			}
+		})
+	}
+	if err != nil {
+		cancel()
+		conn.Close()
+		return fmt.Errorf("failed to subscribe to %s: %w", r.subject, err)
+	}
+	r.cancel = cancel
+	r.conn = conn
+	return nil
+}
+
+// Shutdown stops receiving the messages. The messages of JetStream consumers not
+// acknowledged yet are delivered again once their ack wait expired.
+func (r *natsReceiver) Shutdown(ctx context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	r.cancel()
+	if r.config.JetStream.Enabled {
+		// Draining the subscription would delete the durable consumer
+		r.conn.Close()
+		return nil
+	}
+	return r.conn.Drain(ctx)
+}
+
+func (r *natsReceiver) jetStreamOptions() []nats.SubOpt {
+	opts := []nats.SubOpt{
+		nats.Durable(r.durable),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.AckWait(r.config.JetStream.AckWait),
+		nats.MaxAckPending(r.config.JetStream.MaxAckPending),
+	}
+	switch r.config.JetStream.DeliverPolicy {
+	case deliverPolicyNew:
+		opts = append(opts, nats.DeliverNew())
+	case deliverPolicyLast:
+		opts = append(opts, nats.DeliverLast())
+	default:
+		opts = append(opts, nats.DeliverAll())
+	}
+	if r.config.JetStream.Stream != "" {
+		opts = append(opts, nats.BindStream(r.config.JetStream.Stream))
+	}
+	return opts
+}
+
+// handleJetStreamMessage acknowledges the messages consumed successfully. The
+// messages failing with a retryable error are delivered again, while the others
+// are terminated.
+func (r *natsReceiver) handleJetStreamMessage(ctx context.Context, data []byte, msg acknowledger) {
+	if err := r.consume(ctx, data); err != nil {
+		r.settings.Logger.Error("Failed to consume message", zap.Error(err))
+		if consumererror.IsPermanent(err) {
+			err = msg.Term()
+		} else {
+			err = msg.Nak()
+		}
+		if err != nil {
+			r.settings.Logger.Error("Failed to reject message", zap.Error(err))
+		}
+		return
+	}
+	if err := msg.Ack(); err != nil {
+		r.settings.Logger.Error("Failed to acknowledge message", zap.Error(err))
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// fakeConnection records the subscriptions of the receiver.
+type fakeConnection struct {
+	subscribeErr error
+	subject      string
+	queue        string
+	jetStream    bool
+	opts         []nats.SubOpt
+	handler      nats.MsgHandler
+	drained      bool
+	closed       bool
+}
+
+func (c *fakeConnection) Subscribe(subject, queue string, handler nats.MsgHandler) error {
+	c.subject, c.queue, c.handler = subject, queue, handler
+	return c.subscribeErr
+}
+
+func (c *fakeConnection) SubscribeJetStream(subject, queue string, handler nats.MsgHandler, opts ...nats.SubOpt) error {
+	c.subject, c.queue, c.handler, c.opts = subject, queue, handler, opts
+	c.jetStream = true
+	return c.subscribeErr
+}
+
+func (c *fakeConnection) Drain(context.Context) error {
+	c.drained = true
+	return nil
+}
+
+func (c *fakeConnection) Close() {
+	c.closed = true
+}
+
+// fakeAcknowledger records how a message was acknowledged.
+type fakeAcknowledger struct {
+	acked, naked, termed bool
+}
+
+func (a *fakeAcknowledger) Ack(...nats.AckOpt) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nak(...nats.AckOpt) error {
+	a.naked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Term(...nats.AckOpt) error {
+	a.termed = true
+	return nil
+}
+
+func startReceiver(t *testing.T, r *natsReceiver) *fakeConnection {
+	conn := &fakeConnection{}
+	r.connect = func(*Config, string) (connection, error) {
+		return conn, nil
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+	return conn
+}
+
+func TestTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.QueueGroup = "otelcol"
+	sink := new(consumertest.TracesSink)
+	r, err := newTracesReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	conn := startReceiver(t, r)
+	assert.Equal(t, "otlp.traces", conn.subject)
+	assert.Equal(t, "otelcol", conn.queue)
+	assert.False(t, conn.jetStream)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	conn.handler(&nats.Msg{Subject: "otlp.traces", Data: data})
+	conn.handler(&nats.Msg{Subject: "otlp.traces", Data: []byte("invalid")})
+
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestMetricsReceiverJSON(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subject = "otlp.metrics.>"
+	cfg.Encoding = encodingOTLPJSON
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	conn := startReceiver(t, r)
+	assert.Equal(t, "otlp.metrics.>", conn.subject)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	conn.handler(&nats.Msg{Subject: "otlp.metrics.node-1", Data: data})
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, md, sink.AllMetrics()[0])
+}
+
+func TestLogsReceiverJetStream(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JetStream.Enabled = true
+	cfg.JetStream.Stream = "OTLP"
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	assert.Equal(t, "otelcol_logs", r.durable)
+
+	conn := &fakeConnection{}
+	r.connect = func(*Config, string) (connection, error) {
+		return conn, nil
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, "otlp.logs", conn.subject)
+	assert.True(t, conn.jetStream)
+	assert.Len(t, conn.opts, 7)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.True(t, conn.closed)
+	assert.False(t, conn.drained)
+}
+
+func TestHandleJetStreamMessage(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		err      error
+		expected fakeAcknowledger
+	}{
+		{
+			name:     "consumed",
+			data:     data,
+			expected: fakeAcknowledger{acked: true},
+		},
+		{
+			name:     "retryable error",
+			data:     data,
+			err:      errors.New("retryable"),
+			expected: fakeAcknowledger{naked: true},
+		},
+		{
+			name:     "permanent error",
+			data:     data,
+			err:      consumererror.NewPermanent(errors.New("permanent")),
+			expected: fakeAcknowledger{termed: true},
+		},
+		{
+			name:     "invalid message",
+			data:     []byte("invalid"),
+			expected: fakeAcknowledger{termed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.JetStream.Enabled = true
+			next := consumertest.NewNop()
+			if tt.err != nil {
+				next = consumertest.NewErr(tt.err)
+			}
+			r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), next)
+			require.NoError(t, err)
+
+			ack := &fakeAcknowledger{}
+			r.handleJetStreamMessage(context.Background(), tt.data, ack)
+			assert.Equal(t, tt.expected, *ack)
+		})
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	r, err := newTracesReceiver(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	r.connect = func(*Config, string) (connection, error) {
+		return nil, nats.ErrNoServers
+	}
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to connect to nats://localhost:4222: nats: no servers available for connection")
+
+	conn := &fakeConnection{subscribeErr: nats.ErrBadSubject}
+	r.connect = func(*Config, string) (connection, error) {
+		return conn, nil
+	}
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to subscribe to otlp.traces: nats: invalid subject")
+	assert.True(t, conn.closed)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netstatreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver/internal/metadata"
+)
+
+// Config defines the configuration of the netstat receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+
+	// RootPath is the path procfs is mounted under, e.g. /hostfs when the
+	// collector runs in a container (default "/").
+	RootPath string `mapstructure:"root_path"`
+	// Protocols are the socket tables read, among tcp, tcp6, udp and udp6
+	// (default all).
+	Protocols []string `mapstructure:"protocols"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Protocols) == 0 {
+		return errors.New("protocols must not be empty")
+	}
+	for _, protocol := range cfg.Protocols {
+		if _, ok := metadata.MapAttributeProtocol[protocol]; !ok {
+			return fmt.Errorf("protocol %q is not one of tcp, tcp6, udp or udp6", protocol)
+		}
+	}
+	return nil
+}
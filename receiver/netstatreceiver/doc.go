@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package netstatreceiver counts the TCP and UDP sockets of the host, read from
+// procfs, by state, port and process.
+package netstatreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver"
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netstatreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// socketStates are the names of the states of the sockets in /proc/net, as
+// defined by include/net/tcp_states.h.
+var socketStates = map[uint64]string{
+	0x01: "ESTABLISHED",
+	0x02: "SYN_SENT",
+	0x03: "SYN_RECV",
+	0x04: "FIN_WAIT1",
+	0x05: "FIN_WAIT2",
+	0x06: "TIME_WAIT",
+	0x07: "CLOSE",
+	0x08: "CLOSE_WAIT",
+	0x09: "LAST_ACK",
+	0x0A: "LISTEN",
+	0x0B: "CLOSING",
+	0x0C: "NEW_SYN_RECV",
+}
+
+const stateListen = "LISTEN"
+
+// socket is an entry of a socket table of /proc/net.
+type socket struct {
+	localPort  int64
+	remotePort int64
+	state      string
+	inode      uint64
+}
+
+// readSockets reads the socket table of protocol, e.g. /proc/net/tcp.
+func readSockets(rootPath, protocol string) ([]socket, error) {
+	f, err := os.Open(filepath.Join(rootPath, "proc", "net", protocol))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseSockets(f)
+}
+
+// parseSockets parses a socket table of /proc/net, whose lines look like:
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+//	 0: 0100007F:0277 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 ...
+func parseSockets(r io.Reader) ([]socket, error) {
+	var sockets []socket
+	scanner := bufio.NewScanner(r)
+	// Skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localPort, err := parsePort(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		remotePort, err := parsePort(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		st, err := strconv.ParseUint(fields[3], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket state %q: %w", fields[3], err)
+		}
+		state, ok := socketStates[st]
+		if !ok {
+			state = "UNKNOWN"
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket inode %q: %w", fields[9], err)
+		}
+		sockets = append(sockets, socket{
+			localPort:  localPort,
+			remotePort: remotePort,
+			state:      state,
+			inode:      inode,
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// parsePort returns the port of an address of /proc/net, e.g. 0100007F:0277.
+func parsePort(address string) (int64, error) {
+	i := strings.LastIndexByte(address, ':')
+	if i < 0 {
+		return 0, fmt.Errorf("invalid socket address %q", address)
+	}
+	port, err := strconv.ParseInt(address[i+1:], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid socket address %q: %w", address, err)
+	}
+	return port, nil
+}
+
+// process identifies the process owning sockets.
+type process struct {
+	pid  int64
+	name string
+}
+
+// readSocketProcesses maps the inodes of the sockets to the processes whose file
+// descriptors refer to them. The processes which cannot be read, e.g. without
+// permission, are skipped.
+func readSocketProcesses(rootPath string) (map[uint64]process, error) {
+	procPath := filepath.Join(rootPath, "proc")
+	entries, err := os.ReadDir(procPath)
+	if err != nil {
+		return nil, err
+	}
+	processes := make(map[uint64]process)
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		fdPath := filepath.Join(procPath, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdPath)
+		if err != nil {
+			continue
+		}
+		var p *process
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdPath, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if p == nil {
+				comm, err := os.ReadFile(filepath.Join(procPath, entry.Name(), "comm"))
+				if err != nil {
+					break
+				}
+				p = &process{pid: pid, name: strings.TrimSpace(string(comm))}
+			}
+			processes[inode] = *p
+		}
+	}
+	return processes, nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netstatreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver"
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netstatreceiver/internal/metadata"
+)
+
+var errUnsupportedOS = errors.New("the netstat receiver is only supported on linux")
+
+type netstatScraper struct {
+	logger   *zap.Logger
+	cfg      *Config
+	settings receiver.CreateSettings
+	mb       *metadata.MetricsBuilder
+}
+
+func newNetstatScraper(settings receiver.CreateSettings, cfg *Config) *netstatScraper {
+	return &netstatScraper{
+		logger:   settings.Logger,
+		cfg:      cfg,
+		settings: settings,
+	}
+}
+
+func (s *netstatScraper) start(context.Context, component.Host) error {
+	if runtime.GOOS != "linux" {
+		return errUnsupportedOS
+	}
+	s.mb = metadata.NewMetricsBuilder(s.cfg.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+type connectionKey struct {
+	protocol metadata.AttributeProtocol
+	state    string
+}
+
+type portKey struct {
+	connectionKey
+	direction metadata.AttributeDirection
+	port      int64
+}
+
+type processKey struct {
+	connectionKey
+	process
+}
+
+func (s *netstatScraper) scrape(context.Context) (pmetric.Metrics, error) {
+	metrics := s.cfg.MetricsBuilderConfig.Metrics
+	var processes map[uint64]process
+	if metrics.NetstatProcessConnections.Enabled {
+		var err error
+		if processes, err = readSocketProcesses(s.cfg.RootPath); err != nil {
+			return pmetric.NewMetrics(), err
+		}
+	}
+
+	connections := make(map[connectionKey]int64)
+	ports := make(map[portKey]int64)
+	processConnections := make(map[processKey]int64)
+	var errs scrapererror.ScrapeErrors
+	for _, protocol := range s.cfg.Protocols {
+		sockets, err := readSockets(s.cfg.RootPath, protocol)
+		if err != nil {
+			errs.AddPartial(1, err)
+			continue
+		}
+
+		// The ports the inbound sockets are accepted on
+		listening := make(map[int64]bool)
+		for _, socket := range sockets {
+			if socket.state == stateListen || socket.remotePort == 0 {
+				listening[socket.localPort] = true
+			}
+		}
+
+		for _, socket := range sockets {
+			key := connectionKey{protocol: metadata.MapAttributeProtocol[protocol], state: socket.state}
+			connections[key]++
+			if metrics.NetstatPortConnections.Enabled {
+				if listening[socket.localPort] {
+					ports[portKey{connectionKey: key, direction: metadata.AttributeDirectionInbound, port: socket.localPort}]++
+				} else {
+					ports[portKey{connectionKey: key, direction: metadata.AttributeDirectionOutbound, port: socket.remotePort}]++
+				}
+			}
+			if p, ok := processes[socket.inode]; ok {
+				processConnections[processKey{connectionKey: key, process: p}]++
+			}
+		}
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for key, count := range connections {
+		s.mb.RecordNetstatConnectionsDataPoint(now, count, key.protocol, key.state)
+	}
+	for key, count := range ports {
+		s.mb.RecordNetstatPortConnectionsDataPoint(now, count, key.protocol, key.state, key.direction, key.port)
+	}
+	for key, count := range processConnections {
+		s.mb.RecordNetstatProcessConnectionsDataPoint(now, count, key.protocol, key.state, key.name, key.pid)
+	}
+	return s.mb.Emit(), errs.Combine()
+}
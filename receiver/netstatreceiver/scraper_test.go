@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package netstatreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+)
+
+func TestScraper(t *testing.T) {
+	tests := []struct {
+		name           string
+		portMetrics    bool
+		processMetrics bool
+		expectedFile   string
+	}{
+		{
+			name:         "default",
+			expectedFile: "expected.yaml",
+		},
+		{
+			name:           "all_metrics",
+			portMetrics:    true,
+			processMetrics: true,
+			expectedFile:   "expected_all_metrics.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.RootPath = "testdata"
+			cfg.Metrics.NetstatPortConnections.Enabled = tt.portMetrics
+			cfg.Metrics.NetstatProcessConnections.Enabled = tt.processMetrics
+
+			scraper := newNetstatScraper(receivertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+			actualMetrics, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "scraper", tt.expectedFile))
+			require.NoError(t, err)
+
+			require.NoError(t, pmetrictest.CompareMetrics(expectedMetrics, actualMetrics,
+				pmetrictest.IgnoreStartTimestamp(),
+				pmetrictest.IgnoreMetricDataPointsOrder(),
+				pmetrictest.IgnoreTimestamp(),
+				pmetrictest.IgnoreMetricsOrder()))
+		})
+	}
+}
+
+func TestScraperMissingTable(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "proc", "net"), 0o755))
+	table, err := os.ReadFile(filepath.Join("testdata", "proc", "net", "tcp"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "proc", "net", "tcp"), table, 0o600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.RootPath = rootPath
+	cfg.Protocols = []string{"tcp", "udp"}
+
+	scraper := newNetstatScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	// The tables of the other protocols are still scraped
+	metrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, 3, metrics.DataPointCount())
+}
+
+func TestParseSockets(t *testing.T) {
+	sockets, err := parseSockets(strings.NewReader(
+		"  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+			"   0: 0100007F:1F90 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 1002 1 0000000000000000 20 4 30 10 -1\n" +
+			"   1: 0100007F:D433 0100007F:1538 0F 00000000:00000000 03:00001770 00000000     0        0 0 3 0000000000000000\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []socket{
+		{localPort: 8080, remotePort: 54321, state: "ESTABLISHED", inode: 1002},
+		{localPort: 54323, remotePort: 5432, state: "UNKNOWN", inode: 0},
+	}, sockets)
+
+	_, err = parseSockets(strings.NewReader(
+		"  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+			"   0: 0100007F 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 1002\n"))
+	assert.EqualError(t, err, `invalid socket address "0100007F"`)
+}
+
+func TestReadSocketProcesses(t *testing.T) {
+	processes, err := readSocketProcesses("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]process{
+		1001: {pid: 42, name: "server"},
+		1002: {pid: 42, name: "server"},
+		1003: {pid: 42, name: "server"},
+		2001: {pid: 77, name: "client"},
+		2002: {pid: 77, name: "client"},
+	}, processes)
+}
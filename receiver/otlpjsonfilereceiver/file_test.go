@@ -125,10 +125,15 @@ func testdataConfigYamlAsMap() *Config {
 			PollInterval:            200 * time.Millisecond,
 			Encoding:                "utf-8",
 			StartAt:                 "end",
+			OnTruncate:              "beginning",
 			FingerprintSize:         1000,
 			MaxLogSize:              1024 * 1024,
 			MaxConcurrentFiles:      1024,
 			FlushPeriod:             500 * time.Millisecond,
+			Buffer: fileconsumer.BufferConfig{
+				MaxInMemory: 1000,
+				MaxOnDisk:   256 * 1024 * 1024,
+			},
 			Criteria: matcher.Criteria{
 				Include: []string{"/var/log/*.log"},
 				Exclude: []string{"/var/log/example.log"},
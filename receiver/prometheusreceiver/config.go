@@ -57,6 +57,11 @@ type Config struct {
 	// EnableProtobufNegotiation allows the collector to set the scraper option for
 	// protobuf negotiation when conferring with a prometheus client.
 	EnableProtobufNegotiation bool `mapstructure:"enable_protobuf_negotiation"`
+
+	// HTTPClientOptions are added to the options of the HTTP clients scraping the
+	// targets. It cannot be configured, it allows the receivers wrapping this
+	// receiver to customize the scrapes, e.g. to dial the targets themselves.
+	HTTPClientOptions []commonconfig.HTTPClientOption `mapstructure:"-"`
 }
 
 type targetAllocator struct {
@@ -270,9 +270,9 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 		PassMetadataInContext:     true,
 		EnableProtobufNegotiation: r.cfg.EnableProtobufNegotiation,
 		ExtraMetrics:              r.cfg.ReportExtraScrapeMetrics,
-		HTTPClientOptions: []commonconfig.HTTPClientOption{
+		HTTPClientOptions: append([]commonconfig.HTTPClientOption{
 			commonconfig.WithUserAgent(r.settings.BuildInfo.Command + "/" + r.settings.BuildInfo.Version),
-		},
+		}, r.cfg.HTTPClientOptions...),
 	}, logger, store)
 
 	go func() {
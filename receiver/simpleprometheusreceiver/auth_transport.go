@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package simpleprometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/simpleprometheusreceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+
+	configutil "github.com/prometheus/common/config"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+)
+
+// authTransport authenticates the scrapes of the prometheus receiver with the
+// HTTP client of the receiver configuration. The prometheus receiver creates
+// its HTTP clients itself, so its scrape client dials in-memory connections
+// served in process by a handler sending the requests through the
+// authenticated client. No port is opened.
+type authTransport struct {
+	listener *pipeListener
+	server   *http.Server
+}
+
+func startAuthTransport(cfg *Config, host component.Host, set component.TelemetrySettings) (*authTransport, error) {
+	client, err := cfg.HTTPClientSettings.ToClient(host, set)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "http"
+	tlsConfig, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
+	handler := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = scheme
+			req.URL.Host = cfg.Endpoint
+			req.Host = cfg.Endpoint
+		},
+		Transport: client.Transport,
+	}
+	t := &authTransport{
+		listener: newPipeListener(),
+		server:   &http.Server{Handler: handler, ReadHeaderTimeout: cfg.CollectionInterval},
+	}
+	go func() {
+		if err := t.server.Serve(t.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			set.Logger.Error("Failed to serve the authenticated scrapes", zap.Error(err))
+		}
+	}()
+	return t, nil
+}
+
+// scrapeThroughTransport makes the scrape client of pConfig dial the
+// authenticated transport instead of the endpoint.
+func (t *authTransport) scrapeThroughTransport(pConfig *prometheusreceiver.Config) {
+	for _, scrapeConfig := range pConfig.PrometheusConfig.ScrapeConfigs {
+		// The TLS connection to the endpoint is established by the authenticated client
+		scrapeConfig.Scheme = "http"
+		scrapeConfig.HTTPClientConfig.TLSConfig = configutil.TLSConfig{}
+	}
+	pConfig.HTTPClientOptions = append(pConfig.HTTPClientOptions, configutil.WithDialContextFunc(t.listener.dial))
+}
+
+func (t *authTransport) shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return t.server.Shutdown(ctx)
+}
+
+// pipeListener is a net.Listener accepting the in-memory connections it dials.
+type pipeListener struct {
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// dial returns the client end of a connection accepted by the listener,
+// whatever the address.
+func (l *pipeListener) dial(ctx context.Context, _, _ string) (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
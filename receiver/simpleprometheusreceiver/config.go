@@ -23,6 +23,9 @@ type Config struct {
 	Params url.Values `mapstructure:"params,omitempty"`
 	// Labels static labels
 	Labels map[string]string `mapstructure:"labels,omitempty"`
+	// ResourceAttributes are static attributes set on the resource of the scraped
+	// metrics.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes,omitempty"`
 	// Whether or not to use pod service account to authenticate.
 	UseServiceAccount bool `mapstructure:"use_service_account"`
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -50,6 +51,7 @@ func TestLoadConfig(t *testing.T) {
 				CollectionInterval: 30 * time.Second,
 				MetricsPath:        "/v2/metrics",
 				Params:             url.Values{"columns": []string{"name", "messages"}, "key": []string{"foo", "bar"}},
+				ResourceAttributes: map[string]string{"deployment.environment": "production"},
 				UseServiceAccount:  true,
 			},
 		},
@@ -76,6 +78,20 @@ func TestLoadConfig(t *testing.T) {
 				MetricsPath:        "/metrics",
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "auth"),
+			expected: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "localhost:1234",
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+					Auth: &configauth.Authentication{AuthenticatorID: component.NewID("bearertokenauth")},
+				},
+				CollectionInterval: 10 * time.Second,
+				MetricsPath:        "/metrics",
+			},
+		},
 	}
 
 	for _, tt := range tests {
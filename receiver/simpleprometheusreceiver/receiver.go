@@ -15,7 +15,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
 	"k8s.io/client-go/rest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
@@ -27,6 +29,7 @@ type prometheusReceiverWrapper struct {
 	config            *Config
 	consumer          consumer.Metrics
 	prometheusRecever receiver.Metrics
+	authTransport     *authTransport
 }
 
 // newPrometheusReceiverWrapper returns a prometheusReceiverWrapper
@@ -43,7 +46,20 @@ func (prw *prometheusReceiverWrapper) Start(ctx context.Context, host component.
 		return fmt.Errorf("failed to create prometheus receiver config: %w", err)
 	}
 
-	pr, err := pFactory.CreateMetricsReceiver(ctx, prw.params, pConfig, prw.consumer)
+	if prw.config.Auth != nil {
+		prw.authTransport, err = startAuthTransport(prw.config, host, prw.params.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create authenticated transport: %w", err)
+		}
+		prw.authTransport.scrapeThroughTransport(pConfig)
+	}
+
+	nextConsumer := prw.consumer
+	if len(prw.config.ResourceAttributes) > 0 {
+		nextConsumer = &resourceAttributesConsumer{next: nextConsumer, attributes: prw.config.ResourceAttributes}
+	}
+
+	pr, err := pFactory.CreateMetricsReceiver(ctx, prw.params, pConfig, nextConsumer)
 	if err != nil {
 		return fmt.Errorf("failed to create prometheus receiver: %w", err)
 	}
@@ -138,8 +154,33 @@ func getPrometheusConfig(cfg *Config) (*prometheusreceiver.Config, error) {
 
 // Shutdown stops the underlying Prometheus receiver.
 func (prw *prometheusReceiverWrapper) Shutdown(ctx context.Context) error {
-	if prw.prometheusRecever == nil {
-		return nil
+	var err error
+	if prw.prometheusRecever != nil {
+		err = prw.prometheusRecever.Shutdown(ctx)
+	}
+	if prw.authTransport != nil {
+		err = multierr.Append(err, prw.authTransport.shutdown(ctx))
+	}
+	return err
+}
+
+// resourceAttributesConsumer sets the static resource attributes of the
+// configuration on the scraped metrics.
+type resourceAttributesConsumer struct {
+	next       consumer.Metrics
+	attributes map[string]string
+}
+
+func (c *resourceAttributesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *resourceAttributesConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		attrs := md.ResourceMetrics().At(i).Resource().Attributes()
+		for k, v := range c.attributes {
+			attrs.PutStr(k, v)
+		}
 	}
-	return prw.prometheusRecever.Shutdown(ctx)
+	return c.next.ConsumeMetrics(ctx, md)
 }
@@ -5,7 +5,10 @@ package simpleprometheusreceiver
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,10 +18,13 @@ import (
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
@@ -400,3 +406,70 @@ func TestGetPrometheusConfigWrapper(t *testing.T) {
 		})
 	}
 }
+
+// extensionsHost is a host with extensions.
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestReceiverWithAuthAndResourceAttributes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("# TYPE up_total counter\nup_total 1\n"))
+	}))
+	defer server.Close()
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+
+	authID := component.NewID("bearertokenauth")
+	authenticator := auth.NewClient(auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			return base.RoundTrip(req)
+		}), nil
+	}))
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{authID: authenticator},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: authID}
+	cfg.CollectionInterval = 100 * time.Millisecond
+	cfg.ResourceAttributes = map[string]string{"deployment.environment": "test"}
+
+	sink := new(consumertest.MetricsSink)
+	r, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), host))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 10*time.Second, 10*time.Millisecond)
+	attrs := sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes()
+	instance, _ := attrs.Get("service.instance.id")
+	assert.Equal(t, endpoint, instance.Str())
+	environment, _ := attrs.Get("deployment.environment")
+	assert.Equal(t, "test", environment.Str())
+
+	// The up metric reports whether the authenticated scrapes succeeded
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == "up" {
+			assert.Equal(t, 1.0, metrics.At(i).Gauge().DataPoints().At(0).DoubleValue())
+		}
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	metricTypeGauge     = "gauge"
+	metricTypeSum       = "sum"
+	metricTypeHistogram = "histogram"
+)
+
+// Config defines the configuration of the synthetic receiver.
+type Config struct {
+	// Interval is how often a batch of telemetry is generated. The number of
+	// items per batch is derived from the configured rate.
+	Interval time.Duration `mapstructure:"interval"`
+
+	Traces  TracesConfig  `mapstructure:"traces"`
+	Metrics MetricsConfig `mapstructure:"metrics"`
+	Logs    LogsConfig    `mapstructure:"logs"`
+}
+
+// LoadConfig holds the settings shared by every signal.
+type LoadConfig struct {
+	// Rate is the number of items (traces, data points or log records) generated per second.
+	Rate float64 `mapstructure:"rate"`
+	// Resources is the number of distinct resources the load is spread across.
+	Resources int `mapstructure:"resources"`
+	// AttributeCardinality is the number of distinct values of the `synthetic.key` attribute.
+	AttributeCardinality int `mapstructure:"attribute_cardinality"`
+	// ErrorRatio is the fraction of items, between 0 and 1, that are marked as errors.
+	ErrorRatio float64 `mapstructure:"error_ratio"`
+}
+
+// TracesConfig defines the shape of the generated traces.
+type TracesConfig struct {
+	LoadConfig `mapstructure:",squash"`
+	// Depth is the number of levels of the span tree, including the root span.
+	Depth int `mapstructure:"depth"`
+	// Breadth is the number of children of every non-leaf span.
+	Breadth int `mapstructure:"breadth"`
+	// SpanDuration is the duration of the root span. Child spans split the duration of their parent.
+	SpanDuration time.Duration `mapstructure:"span_duration"`
+}
+
+// MetricsConfig defines the shape of the generated metrics.
+type MetricsConfig struct {
+	LoadConfig `mapstructure:",squash"`
+	// Type is the type of the generated metrics: gauge, sum or histogram.
+	Type string `mapstructure:"type"`
+	// MetricCount is the number of distinct metric names.
+	MetricCount int `mapstructure:"metric_count"`
+}
+
+// LogsConfig defines the shape of the generated log records.
+type LogsConfig struct {
+	LoadConfig `mapstructure:",squash"`
+	// BodySize is the size, in bytes, of the generated log bodies.
+	BodySize int `mapstructure:"body_size"`
+}
+
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Interval <= 0 {
+		errs = multierr.Append(errs, errors.New("'interval' must be positive"))
+	}
+	errs = multierr.Append(errs, cfg.Traces.validate("traces"))
+	if cfg.Traces.Depth < 1 {
+		errs = multierr.Append(errs, errors.New("traces: 'depth' must be at least 1"))
+	}
+	if cfg.Traces.Breadth < 1 {
+		errs = multierr.Append(errs, errors.New("traces: 'breadth' must be at least 1"))
+	}
+	if cfg.Traces.SpanDuration <= 0 {
+		errs = multierr.Append(errs, errors.New("traces: 'span_duration' must be positive"))
+	}
+	errs = multierr.Append(errs, cfg.Metrics.validate("metrics"))
+	switch cfg.Metrics.Type {
+	case metricTypeGauge, metricTypeSum, metricTypeHistogram:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("metrics: unsupported 'type' %q", cfg.Metrics.Type))
+	}
+	if cfg.Metrics.MetricCount < 1 {
+		errs = multierr.Append(errs, errors.New("metrics: 'metric_count' must be at least 1"))
+	}
+	errs = multierr.Append(errs, cfg.Logs.validate("logs"))
+	if cfg.Logs.BodySize < 0 {
+		errs = multierr.Append(errs, errors.New("logs: 'body_size' must not be negative"))
+	}
+	return errs
+}
+
+func (cfg LoadConfig) validate(signal string) error {
+	var errs error
+	if cfg.Rate < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("%s: 'rate' must not be negative", signal))
+	}
+	if cfg.Resources < 1 {
+		errs = multierr.Append(errs, fmt.Errorf("%s: 'resources' must be at least 1", signal))
+	}
+	if cfg.AttributeCardinality < 1 {
+		errs = multierr.Append(errs, fmt.Errorf("%s: 'attribute_cardinality' must be at least 1", signal))
+	}
+	if cfg.ErrorRatio < 0 || cfg.ErrorRatio > 1 {
+		errs = multierr.Append(errs, fmt.Errorf("%s: 'error_ratio' must be between 0 and 1", signal))
+	}
+	return errs
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Interval: 500 * time.Millisecond,
+				Traces: TracesConfig{
+					LoadConfig: LoadConfig{
+						Rate:                 100,
+						Resources:            5,
+						AttributeCardinality: 20,
+						ErrorRatio:           0.1,
+					},
+					Depth:        4,
+					Breadth:      3,
+					SpanDuration: time.Second,
+				},
+				Metrics: MetricsConfig{
+					LoadConfig: LoadConfig{
+						Rate:                 1000,
+						Resources:            1,
+						AttributeCardinality: 1,
+					},
+					Type:        metricTypeHistogram,
+					MetricCount: 50,
+				},
+				Logs: LogsConfig{
+					LoadConfig: LoadConfig{
+						Rate:                 500,
+						Resources:            1,
+						AttributeCardinality: 1,
+						ErrorRatio:           0.01,
+					},
+					BodySize: 256,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:        "zero interval",
+			modify:      func(cfg *Config) { cfg.Interval = 0 },
+			expectedErr: "'interval' must be positive",
+		},
+		{
+			name:        "negative rate",
+			modify:      func(cfg *Config) { cfg.Logs.Rate = -1 },
+			expectedErr: "logs: 'rate' must not be negative",
+		},
+		{
+			name:        "error ratio above 1",
+			modify:      func(cfg *Config) { cfg.Traces.ErrorRatio = 1.5 },
+			expectedErr: "traces: 'error_ratio' must be between 0 and 1",
+		},
+		{
+			name:        "zero cardinality",
+			modify:      func(cfg *Config) { cfg.Metrics.AttributeCardinality = 0 },
+			expectedErr: "metrics: 'attribute_cardinality' must be at least 1",
+		},
+		{
+			name:        "zero depth",
+			modify:      func(cfg *Config) { cfg.Traces.Depth = 0 },
+			expectedErr: "traces: 'depth' must be at least 1",
+		},
+		{
+			name:        "unknown metric type",
+			modify:      func(cfg *Config) { cfg.Metrics.Type = "summary" },
+			expectedErr: `metrics: unsupported 'type' "summary"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedErr)
+		})
+	}
+}
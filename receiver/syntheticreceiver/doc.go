@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package syntheticreceiver generates synthetic traces, metrics and logs load
+// from within the collector.
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver/internal/metadata"
+)
+
+const (
+	defaultInterval     = time.Second
+	defaultRate         = 10
+	defaultDepth        = 3
+	defaultBreadth      = 2
+	defaultSpanDuration = 100 * time.Millisecond
+	defaultMetricCount  = 10
+	defaultBodySize     = 64
+)
+
+// NewFactory creates a factory for the synthetic receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func defaultLoadConfig() LoadConfig {
+	return LoadConfig{
+		Rate:                 defaultRate,
+		Resources:            1,
+		AttributeCardinality: 1,
+	}
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Interval: defaultInterval,
+		Traces: TracesConfig{
+			LoadConfig:   defaultLoadConfig(),
+			Depth:        defaultDepth,
+			Breadth:      defaultBreadth,
+			SpanDuration: defaultSpanDuration,
+		},
+		Metrics: MetricsConfig{
+			LoadConfig:  defaultLoadConfig(),
+			Type:        metricTypeGauge,
+			MetricCount: defaultMetricCount,
+		},
+		Logs: LogsConfig{
+			LoadConfig: defaultLoadConfig(),
+			BodySize:   defaultBodySize,
+		},
+	}
+}
+
+func createTracesReceiver(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	rCfg := cfg.(*Config)
+	gen := newTracesGenerator(rCfg.Traces)
+	return newSyntheticReceiver(set, rCfg.Interval, rCfg.Traces.Rate, func(ctx context.Context, n int) (int, error) {
+		td := gen.generate(n)
+		return td.SpanCount(), next.ConsumeTraces(ctx, td)
+	}), nil
+}
+
+func createMetricsReceiver(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	rCfg := cfg.(*Config)
+	gen := newMetricsGenerator(rCfg.Metrics)
+	return newSyntheticReceiver(set, rCfg.Interval, rCfg.Metrics.Rate, func(ctx context.Context, n int) (int, error) {
+		md := gen.generate(n)
+		return md.DataPointCount(), next.ConsumeMetrics(ctx, md)
+	}), nil
+}
+
+func createLogsReceiver(_ context.Context, set receiver.CreateSettings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	rCfg := cfg.(*Config)
+	gen := newLogsGenerator(rCfg.Logs)
+	return newSyntheticReceiver(set, rCfg.Interval, rCfg.Logs.Rate, func(ctx context.Context, n int) (int, error) {
+		ld := gen.generate(n)
+		return ld.LogRecordCount(), next.ConsumeLogs(ctx, ld)
+	}), nil
+}
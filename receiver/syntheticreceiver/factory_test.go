@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateReceivers(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	cfg.Traces.Rate = 1000
+	cfg.Metrics.Rate = 1000
+	cfg.Logs.Rate = 1000
+
+	tracesSink := new(consumertest.TracesSink)
+	traces, err := factory.CreateTracesReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, tracesSink)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	metrics, err := factory.CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, metricsSink)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	logs, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, logsSink)
+	require.NoError(t, err)
+
+	host := componenttest.NewNopHost()
+	require.NoError(t, traces.Start(context.Background(), host))
+	require.NoError(t, metrics.Start(context.Background(), host))
+	require.NoError(t, logs.Start(context.Background(), host))
+
+	assert.Eventually(t, func() bool {
+		return tracesSink.SpanCount() > 0 && metricsSink.DataPointCount() > 0 && logsSink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, traces.Shutdown(context.Background()))
+	require.NoError(t, metrics.Shutdown(context.Background()))
+	require.NoError(t, logs.Shutdown(context.Background()))
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
+)
+
+const (
+	scopeName    = "otelcol/syntheticreceiver"
+	attributeKey = "synthetic.key"
+)
+
+// generator holds the state shared by the generators of every signal.
+// It is not safe for concurrent use.
+type generator struct {
+	cfg     LoadConfig
+	rand    *rand.Rand
+	counter int
+}
+
+func newGenerator(cfg LoadConfig) generator {
+	return generator{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 -- synthetic data does not need a secure source
+	}
+}
+
+// fillResource sets the attributes of the i-th synthetic resource.
+func (g *generator) fillResource(res pcommon.Resource, i int) {
+	res.Attributes().PutStr(conventions.AttributeServiceName, fmt.Sprintf("synthetic-service-%d", i))
+	res.Attributes().PutStr(conventions.AttributeServiceInstanceID, fmt.Sprintf("synthetic-instance-%d", i))
+}
+
+// nextAttributes sets the attributes of the next item, cycling through the
+// configured number of distinct attribute values.
+func (g *generator) nextAttributes(attrs pcommon.Map) {
+	attrs.PutStr(attributeKey, fmt.Sprintf("value-%d", g.counter%g.cfg.AttributeCardinality))
+	g.counter++
+}
+
+func (g *generator) isError() bool {
+	return g.cfg.ErrorRatio > 0 && g.rand.Float64() < g.cfg.ErrorRatio
+}
+
+// split distributes n items over the configured number of resources.
+func (g *generator) split(n int) []int {
+	counts := make([]int, g.cfg.Resources)
+	for i := 0; i < n; i++ {
+		counts[i%len(counts)]++
+	}
+	return counts
+}
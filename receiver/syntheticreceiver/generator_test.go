@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestBatcher(t *testing.T) {
+	b := batcher{perTick: 2.5}
+	var total int
+	for i := 0; i < 4; i++ {
+		total += b.next()
+	}
+	assert.Equal(t, 10, total)
+
+	b = batcher{perTick: 0.25}
+	assert.Equal(t, []int{0, 0, 0, 1}, []int{b.next(), b.next(), b.next(), b.next()})
+}
+
+func TestTracesShape(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Traces
+	cfg.Resources = 2
+	cfg.Depth = 3
+	cfg.Breadth = 2
+	cfg.AttributeCardinality = 3
+	cfg.ErrorRatio = 1
+
+	td := newTracesGenerator(cfg).generate(4)
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	// 1 root + 2 children + 4 grandchildren per trace
+	assert.Equal(t, 4*7, td.SpanCount())
+
+	values := map[string]struct{}{}
+	var roots, errors int
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		spans := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			span := spans.At(j)
+			if span.ParentSpanID().IsEmpty() {
+				roots++
+			}
+			if span.Status().Code() == ptrace.StatusCodeError {
+				errors++
+			}
+			v, ok := span.Attributes().Get(attributeKey)
+			require.True(t, ok)
+			values[v.Str()] = struct{}{}
+		}
+	}
+	assert.Equal(t, 4, roots)
+	// root, first child and first grandchild of every trace
+	assert.Equal(t, 4*3, errors)
+	assert.Len(t, values, 3)
+}
+
+func TestMetricsTypes(t *testing.T) {
+	for _, tc := range []struct {
+		metricType string
+		expected   pmetric.MetricType
+	}{
+		{metricType: metricTypeGauge, expected: pmetric.MetricTypeGauge},
+		{metricType: metricTypeSum, expected: pmetric.MetricTypeSum},
+		{metricType: metricTypeHistogram, expected: pmetric.MetricTypeHistogram},
+	} {
+		t.Run(tc.metricType, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config).Metrics
+			cfg.Type = tc.metricType
+			cfg.MetricCount = 3
+
+			md := newMetricsGenerator(cfg).generate(10)
+			assert.Equal(t, 10, md.DataPointCount())
+			metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 3, metrics.Len())
+			for i := 0; i < metrics.Len(); i++ {
+				assert.Equal(t, tc.expected, metrics.At(i).Type())
+			}
+		})
+	}
+}
+
+func TestSumsAreCumulative(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Metrics
+	cfg.Type = metricTypeSum
+	cfg.MetricCount = 1
+
+	gen := newMetricsGenerator(cfg)
+	first := gen.generate(1).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	second := gen.generate(1).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.GreaterOrEqual(t, second.DoubleValue(), first.DoubleValue())
+	assert.Equal(t, first.StartTimestamp(), second.StartTimestamp())
+}
+
+func TestLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Logs
+	cfg.BodySize = 16
+	cfg.ErrorRatio = 1
+
+	ld := newLogsGenerator(cfg).generate(5)
+	require.Equal(t, 5, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Len(t, lr.Body().Str(), 16)
+	assert.Equal(t, "ERROR", lr.SeverityText())
+}
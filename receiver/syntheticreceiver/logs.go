@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logsGenerator struct {
+	generator
+	body string
+}
+
+func newLogsGenerator(cfg LogsConfig) *logsGenerator {
+	return &logsGenerator{
+		generator: newGenerator(cfg.LoadConfig),
+		body:      strings.Repeat("x", cfg.BodySize),
+	}
+}
+
+// generate creates n log records.
+func (g *logsGenerator) generate(n int) plog.Logs {
+	ld := plog.NewLogs()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i, count := range g.split(n) {
+		if count == 0 {
+			continue
+		}
+		rl := ld.ResourceLogs().AppendEmpty()
+		g.fillResource(rl.Resource(), i)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+		for j := 0; j < count; j++ {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.SetTimestamp(now)
+			lr.SetObservedTimestamp(now)
+			lr.Body().SetStr(g.body)
+			g.nextAttributes(lr.Attributes())
+			if g.isError() {
+				lr.SetSeverityNumber(plog.SeverityNumberError)
+				lr.SetSeverityText("ERROR")
+			} else {
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				lr.SetSeverityText("INFO")
+			}
+		}
+	}
+	return ld
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var histogramBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000}
+
+type metricsGenerator struct {
+	generator
+	cfg   MetricsConfig
+	start pcommon.Timestamp
+	// sums tracks the cumulative value of every generated sum series.
+	sums map[string]float64
+}
+
+func newMetricsGenerator(cfg MetricsConfig) *metricsGenerator {
+	return &metricsGenerator{
+		generator: newGenerator(cfg.LoadConfig),
+		cfg:       cfg,
+		start:     pcommon.NewTimestampFromTime(time.Now()),
+		sums:      make(map[string]float64),
+	}
+}
+
+// generate creates n data points, spread over the configured number of metric names.
+func (g *metricsGenerator) generate(n int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i, count := range g.split(n) {
+		if count == 0 {
+			continue
+		}
+		rm := md.ResourceMetrics().AppendEmpty()
+		g.fillResource(rm.Resource(), i)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+
+		metrics := make(map[int]pmetric.Metric, g.cfg.MetricCount)
+		for j := 0; j < count; j++ {
+			idx := j % g.cfg.MetricCount
+			m, ok := metrics[idx]
+			if !ok {
+				m = g.newMetric(sm.Metrics(), idx)
+				metrics[idx] = m
+			}
+			g.appendDataPoint(m, i, now)
+		}
+	}
+	return md
+}
+
+func (g *metricsGenerator) newMetric(metrics pmetric.MetricSlice, idx int) pmetric.Metric {
+	m := metrics.AppendEmpty()
+	m.SetName(fmt.Sprintf("synthetic.metric.%d", idx))
+	m.SetDescription("Synthetic metric generated by the synthetic receiver")
+	switch g.cfg.Type {
+	case metricTypeSum:
+		m.SetUnit("1")
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case metricTypeHistogram:
+		m.SetUnit("ms")
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	default:
+		m.SetUnit("1")
+		m.SetEmptyGauge()
+	}
+	return m
+}
+
+func (g *metricsGenerator) appendDataPoint(m pmetric.Metric, resource int, now pcommon.Timestamp) {
+	value := g.rand.Float64() * 1000
+	if g.isError() {
+		value = 0
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		dp := m.Sum().DataPoints().AppendEmpty()
+		g.nextAttributes(dp.Attributes())
+		key := fmt.Sprintf("%d/%s/%s", resource, m.Name(), dp.Attributes().AsRaw()[attributeKey])
+		g.sums[key] += value
+		dp.SetStartTimestamp(g.start)
+		dp.SetTimestamp(now)
+		dp.SetDoubleValue(g.sums[key])
+	case pmetric.MetricTypeHistogram:
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		g.nextAttributes(dp.Attributes())
+		dp.SetStartTimestamp(now)
+		dp.SetTimestamp(now)
+		dp.SetCount(1)
+		dp.SetSum(value)
+		dp.SetMin(value)
+		dp.SetMax(value)
+		dp.ExplicitBounds().FromRaw(histogramBounds)
+		buckets := make([]uint64, len(histogramBounds)+1)
+		bucket := len(histogramBounds)
+		for i, bound := range histogramBounds {
+			if value <= bound {
+				bucket = i
+				break
+			}
+		}
+		buckets[bucket] = 1
+		dp.BucketCounts().FromRaw(buckets)
+	default:
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		g.nextAttributes(dp.Attributes())
+		dp.SetTimestamp(now)
+		dp.SetDoubleValue(value)
+	}
+}
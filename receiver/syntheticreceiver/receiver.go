@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// emitFunc generates and forwards n items, returning the number of
+// telemetry elements that were sent downstream.
+type emitFunc func(ctx context.Context, n int) (int, error)
+
+type syntheticReceiver struct {
+	logger   *zap.Logger
+	interval time.Duration
+	rate     float64
+	emit     emitFunc
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newSyntheticReceiver(set receiver.CreateSettings, interval time.Duration, rate float64, emit emitFunc) *syntheticReceiver {
+	return &syntheticReceiver{
+		logger:   set.Logger,
+		interval: interval,
+		rate:     rate,
+		emit:     emit,
+	}
+}
+
+func (r *syntheticReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		b := batcher{perTick: r.rate * r.interval.Seconds()}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			n := b.next()
+			if n == 0 {
+				continue
+			}
+			if _, err := r.emit(ctx, n); err != nil {
+				r.logger.Debug("Failed to send synthetic telemetry", zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *syntheticReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// batcher converts a fractional number of items per tick into whole batches,
+// carrying the remainder over so that the configured rate is honored on average.
+type batcher struct {
+	perTick   float64
+	remainder float64
+}
+
+func (b *batcher) next() int {
+	total := b.perTick + b.remainder
+	n := int(total)
+	b.remainder = total - float64(n)
+	return n
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syntheticreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syntheticreceiver"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type tracesGenerator struct {
+	generator
+	cfg TracesConfig
+}
+
+func newTracesGenerator(cfg TracesConfig) *tracesGenerator {
+	return &tracesGenerator{generator: newGenerator(cfg.LoadConfig), cfg: cfg}
+}
+
+// generate creates n traces. Every trace is a tree of spans with the
+// configured depth and breadth.
+func (g *tracesGenerator) generate(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	now := time.Now()
+	for i, count := range g.split(n) {
+		if count == 0 {
+			continue
+		}
+		rs := td.ResourceSpans().AppendEmpty()
+		g.fillResource(rs.Resource(), i)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(scopeName)
+		for j := 0; j < count; j++ {
+			g.generateTrace(ss.Spans(), now)
+		}
+	}
+	return td
+}
+
+func (g *tracesGenerator) generateTrace(spans ptrace.SpanSlice, now time.Time) {
+	var traceID pcommon.TraceID
+	g.rand.Read(traceID[:])
+	start := now.Add(-g.cfg.SpanDuration)
+	g.generateSpan(spans, traceID, pcommon.NewSpanIDEmpty(), 1, start, g.cfg.SpanDuration, g.isError())
+}
+
+func (g *tracesGenerator) generateSpan(spans ptrace.SpanSlice, traceID pcommon.TraceID, parentID pcommon.SpanID, level int, start time.Time, duration time.Duration, isError bool) {
+	var spanID pcommon.SpanID
+	g.rand.Read(spanID[:])
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	span.SetParentSpanID(parentID)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(duration)))
+	g.nextAttributes(span.Attributes())
+	if level == 1 {
+		span.SetName("synthetic-root")
+		span.SetKind(ptrace.SpanKindServer)
+	} else {
+		span.SetName(fmt.Sprintf("synthetic-span-%d", level))
+		span.SetKind(ptrace.SpanKindInternal)
+	}
+	if isError {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("synthetic error")
+	}
+
+	if level >= g.cfg.Depth {
+		return
+	}
+	childDuration := duration / time.Duration(g.cfg.Breadth)
+	for i := 0; i < g.cfg.Breadth; i++ {
+		// Errors propagate along the first child so that erroring traces have an erroring leaf.
+		g.generateSpan(spans, traceID, spanID, level+1, start.Add(time.Duration(i)*childDuration), childDuration, isError && i == 0)
+	}
+}
@@ -48,6 +48,34 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, createTestConfig(), cfg)
 }
 
+func TestLoadConfigRemoteQuery(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "remote").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	expected := createTestConfig()
+	expected.InputConfig.Channel = ""
+	expected.InputConfig.Query = `<QueryList>
+  <Query Id="0">
+    <Select Path="Security">*[System[(Level=1 or Level=2)]]</Select>
+  </Query>
+</QueryList>
+`
+	expected.InputConfig.Locale = "en-US"
+	expected.InputConfig.Remote = windows.RemoteConfig{
+		Server:   "remote-host",
+		Username: "user",
+		Password: "secret",
+		Domain:   "corp",
+	}
+	assert.Equal(t, expected, cfg)
+}
+
 func TestCreateWithInvalidInputConfig(t *testing.T) {
 	t.Parallel()
 
@@ -69,6 +97,48 @@ func TestCreateWithInvalidInputConfig(t *testing.T) {
 	require.Error(t, err, "receiver creation should fail if given invalid input config")
 }
 
+func TestCreateWithInvalidRemoteQueryConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]func(c *windows.Config){
+		"no channel or query": func(c *windows.Config) {},
+		"both channel and query": func(c *windows.Config) {
+			c.Channel = "application"
+			c.Query = `<QueryList><Query Id="0"><Select Path="Application">*</Select></Query></QueryList>`
+		},
+		"remote credentials without server": func(c *windows.Config) {
+			c.Channel = "application"
+			c.Remote.Username = "user"
+		},
+		"invalid locale": func(c *windows.Config) {
+			c.Channel = "application"
+			c.Locale = "not-a-locale"
+		},
+	}
+
+	for name, configure := range tests {
+		configure := configure
+		t.Run(name, func(t *testing.T) {
+			cfg := &WindowsLogConfig{
+				BaseConfig: adapter.BaseConfig{},
+				InputConfig: func() windows.Config {
+					c := windows.NewConfig()
+					configure(c)
+					return *c
+				}(),
+			}
+
+			_, err := NewFactory().CreateLogsReceiver(
+				context.Background(),
+				receivertest.NewNopCreateSettings(),
+				cfg,
+				new(consumertest.LogsSink),
+			)
+			require.Error(t, err, "receiver creation should fail if given invalid input config")
+		})
+	}
+}
+
 func TestReadWindowsEventLogger(t *testing.T) {
 	logMessage := "Test log"
 